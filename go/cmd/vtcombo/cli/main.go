@@ -81,6 +81,9 @@ In particular, it contains:
 	plannerName           string
 	vschemaPersistenceDir string
 
+	fakeTime               string
+	deterministicSequences bool
+
 	tpb               vttestpb.VTTestTopology
 	ts                *topo.Server
 	resilientServer   *srvtopo.ResilientServer
@@ -118,6 +121,11 @@ func init() {
 		"this is neither a perfect nor a production solution for vschema persistence. Consider using the --external-topo-server flag if "+
 		"you require a more complete solution. This flag is ignored if --external-topo-server is set.")
 
+	Main.Flags().StringVar(&fakeTime, "fake-time", fakeTime, "If set, freezes the clock at the given RFC 3339 time (e.g. '2024-01-01T00:00:00Z'): NOW() and similar functions evaluate to it, "+
+		"both in vtgate and in MySQL. This is meant to make application tests reproducible, and must not be used in production.")
+	Main.Flags().BoolVar(&deterministicSequences, "deterministic-sequences", deterministicSequences, "If set, sequence values are allocated one at a time, ignoring the cache of the sequence tables, "+
+		"so that generated values do not depend on restarts. This is meant to make application tests reproducible, and must not be used in production.")
+
 	utils.SetFlagVar(Main.Flags(), vttest.TextTopoData(&tpb), "proto-topo", "vttest proto definition of the topology, encoded in compact text format. See vttest.proto for more information.")
 	utils.SetFlagVar(Main.Flags(), vttest.JSONTopoData(&tpb), "json-topo", "vttest proto definition of the topology, encoded in json format. See vttest.proto for more information.")
 
@@ -181,10 +189,19 @@ func run(cmd *cobra.Command, args []string) (err error) {
 		cmd.Flags().Set("log_dir", "$VTDATAROOT/tmp")
 	}
 
+	var frozenTime time.Time
+	if fakeTime != "" {
+		frozenTime, err = time.Parse(time.RFC3339Nano, fakeTime)
+		if err != nil {
+			return fmt.Errorf("invalid --fake-time %q: %w", fakeTime, err)
+		}
+	}
 	env, err = vtenv.New(vtenv.Options{
-		MySQLServerVersion: servenv.MySQLServerVersion(),
-		TruncateUILen:      servenv.TruncateUILen,
-		TruncateErrLen:     servenv.TruncateErrLen,
+		MySQLServerVersion:     servenv.MySQLServerVersion(),
+		TruncateUILen:          servenv.TruncateUILen,
+		TruncateErrLen:         servenv.TruncateErrLen,
+		FakeTime:               frozenTime,
+		DeterministicSequences: deterministicSequences,
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize env: %v", err)
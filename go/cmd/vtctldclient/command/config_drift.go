@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/vt/configsnapshot"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// ConfigDrift compares the configuration snapshots of the tablets in a
+// keyspace or shard against a golden configuration.
+var ConfigDrift = &cobra.Command{
+	Use:   "ConfigDrift [--golden <file>] [--ignore-flags <flag1,flag2,...>] [--format text|json] <keyspace|keyspace/shard>",
+	Short: "Detects configuration drift between the tablets of a keyspace or shard.",
+	Long: `Detects configuration drift between the tablets of a keyspace or shard.
+
+The configuration snapshot of each tablet (its flags, the MySQL variables relevant
+to Vitess and the schema of the sidecar database) is fetched from the tablet's
+` + configsnapshot.HTTPPath + ` endpoint and compared against a golden configuration.
+
+The golden configuration is read from the JSON file passed with --golden, which can
+be the output of that endpoint on a known good tablet. Without --golden, the
+snapshot of the primary of the first shard is used.
+
+The command fails if any tablet drifts from the golden configuration.`,
+	Example: `ConfigDrift commerce
+ConfigDrift --golden golden.json --ignore-flags queryserver-config-pool-size customer/-80`,
+	DisableFlagsInUseLine: true,
+	Args:                  cobra.ExactArgs(1),
+	RunE:                  commandConfigDrift,
+}
+
+var configDriftOptions = struct {
+	Golden      string
+	IgnoreFlags []string
+	Format      string
+	Timeout     time.Duration
+}{}
+
+// tabletConfigDrift is the drift of a single tablet from the golden configuration.
+type tabletConfigDrift struct {
+	Tablet string                 `json:"tablet"`
+	Error  string                 `json:"error,omitempty"`
+	Drifts []configsnapshot.Drift `json:"drifts,omitempty"`
+}
+
+func commandConfigDrift(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(configDriftOptions.Format)
+	switch format {
+	case "text", "json":
+	default:
+		return fmt.Errorf("invalid output format, got %s", configDriftOptions.Format)
+	}
+
+	keyspace, shard := cmd.Flags().Arg(0), ""
+	if strings.ContainsAny(keyspace, "/:") {
+		var err error
+		keyspace, shard, err = topoproto.ParseKeyspaceShard(keyspace)
+		if err != nil {
+			return err
+		}
+	}
+
+	var golden *configsnapshot.Snapshot
+	if configDriftOptions.Golden != "" {
+		data, err := os.ReadFile(configDriftOptions.Golden)
+		if err != nil {
+			return err
+		}
+		golden = &configsnapshot.Snapshot{}
+		if err := json.Unmarshal(data, golden); err != nil {
+			return fmt.Errorf("invalid golden configuration in %s: %w", configDriftOptions.Golden, err)
+		}
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTablets(commandCtx, &vtctldatapb.GetTabletsRequest{
+		Keyspace: keyspace,
+		Shard:    shard,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Tablets) == 0 {
+		return fmt.Errorf("no tablets found in %s", cmd.Flags().Arg(0))
+	}
+
+	tablets := resp.Tablets
+	sort.Slice(tablets, func(i, j int) bool {
+		if tablets[i].Shard != tablets[j].Shard {
+			return tablets[i].Shard < tablets[j].Shard
+		}
+		return topoproto.TabletAliasString(tablets[i].Alias) < topoproto.TabletAliasString(tablets[j].Alias)
+	})
+
+	httpClient := &http.Client{Timeout: configDriftOptions.Timeout}
+	snapshots := make([]*configsnapshot.Snapshot, len(tablets))
+	errs := make([]error, len(tablets))
+	for i, tablet := range tablets {
+		snapshots[i], errs[i] = configsnapshot.Fetch(commandCtx, httpClient, tablet)
+	}
+
+	if golden == nil {
+		for i, tablet := range tablets {
+			if tablet.Type == topodatapb.TabletType_PRIMARY {
+				if errs[i] != nil {
+					return fmt.Errorf("cannot use the primary as the golden configuration: %w", errs[i])
+				}
+				golden = snapshots[i]
+				break
+			}
+		}
+		if golden == nil {
+			return fmt.Errorf("no primary tablet found in %s to use as the golden configuration, use --golden instead", cmd.Flags().Arg(0))
+		}
+	}
+
+	ignoredFlags := slices.Concat(configsnapshot.DefaultIgnoredFlags, configDriftOptions.IgnoreFlags)
+	results := make([]tabletConfigDrift, 0, len(tablets))
+	drifted := 0
+	for i, tablet := range tablets {
+		result := tabletConfigDrift{Tablet: topoproto.TabletAliasString(tablet.Alias)}
+		if errs[i] != nil {
+			result.Error = errs[i].Error()
+		} else {
+			result.Drifts = configsnapshot.Diff(golden, snapshots[i], ignoredFlags)
+		}
+		if result.Error != "" || len(result.Drifts) > 0 {
+			drifted++
+		}
+		results = append(results, result)
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+	case "text":
+		for _, result := range results {
+			switch {
+			case result.Error != "":
+				fmt.Printf("%s: ERROR: %s\n", result.Tablet, result.Error)
+			case len(result.Drifts) == 0:
+				fmt.Printf("%s: OK\n", result.Tablet)
+			default:
+				fmt.Printf("%s: DRIFT\n", result.Tablet)
+				for _, drift := range result.Drifts {
+					fmt.Printf("  %s: golden=%q actual=%q\n", drift.Key, drift.Golden, drift.Actual)
+				}
+			}
+		}
+	}
+
+	if drifted > 0 {
+		return fmt.Errorf("%d of %d tablets drifted from the golden configuration", drifted, len(tablets))
+	}
+	return nil
+}
+
+func init() {
+	ConfigDrift.Flags().StringVar(&configDriftOptions.Golden, "golden", "", "Path to a JSON configuration snapshot to use as the golden configuration. Defaults to the snapshot of the primary of the first shard.")
+	ConfigDrift.Flags().StringSliceVar(&configDriftOptions.IgnoreFlags, "ignore-flags", nil, "Flags to ignore in addition to the ones which are expected to differ between tablets, like ports and paths.")
+	ConfigDrift.Flags().StringVar(&configDriftOptions.Format, "format", "text", "Output format to use; valid choices are (text, json).")
+	ConfigDrift.Flags().DurationVar(&configDriftOptions.Timeout, "fetch-timeout", 10*time.Second, "Timeout for fetching the configuration snapshot of each tablet.")
+	Root.AddCommand(ConfigDrift)
+}
@@ -26,6 +26,7 @@ import (
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topotools"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -86,6 +87,26 @@ func commandApplyKeyspaceRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	keyspacesResp, err := client.GetKeyspaces(commandCtx, &vtctldatapb.GetKeyspacesRequest{})
+	if err != nil {
+		return err
+	}
+	keyspaces := make([]string, 0, len(keyspacesResp.Keyspaces))
+	for _, ks := range keyspacesResp.Keyspaces {
+		keyspaces = append(keyspaces, ks.Name)
+	}
+	if err := topotools.ValidateKeyspaceRoutingRules(krr, keyspaces); err != nil {
+		return fmt.Errorf("invalid keyspace routing rules: %w", err)
+	}
+
+	current, err := client.GetKeyspaceRoutingRules(commandCtx, &vtctldatapb.GetKeyspaceRoutingRulesRequest{})
+	if err != nil {
+		return err
+	}
+	if err := printRoutingRulesDiff("KeyspaceRoutingRules", topotools.DiffKeyspaceRoutingRules(current.KeyspaceRoutingRules, krr)); err != nil {
+		return err
+	}
+
 	if opts.DryRun {
 		// Round-trip so that when we display the result it's readable.
 		data, err := cli.MarshalJSON(krr)
@@ -109,9 +130,10 @@ func commandApplyKeyspaceRoutingRules(cmd *cobra.Command, args []string) error {
 	}
 
 	resp, err := client.ApplyKeyspaceRoutingRules(commandCtx, &vtctldatapb.ApplyKeyspaceRoutingRulesRequest{
-		KeyspaceRoutingRules: krr,
-		SkipRebuild:          opts.SkipRebuild,
-		RebuildCells:         opts.Cells,
+		KeyspaceRoutingRules:         krr,
+		SkipRebuild:                  opts.SkipRebuild,
+		RebuildCells:                 opts.Cells,
+		ExpectedKeyspaceRoutingRules: current.KeyspaceRoutingRules,
 	})
 	if err != nil {
 		return err
@@ -50,12 +50,20 @@ as well as a snapshot time.`,
 	}
 	// DeleteKeyspace makes a DeleteKeyspace gRPC call to a vtctld.
 	DeleteKeyspace = &cobra.Command{
-		Use:   "DeleteKeyspace [--recursive|-r] [--force|-f] <keyspace>",
+		Use:   "DeleteKeyspace [--recursive|-r] [--force|-f] [--skip-dependency-checks] [--traffic-window <duration>] [--soft-delete-window <duration>] <keyspace>",
 		Short: "Deletes the specified keyspace from the topology.",
 		Long: `Deletes the specified keyspace from the topology.
 
 In recursive mode, it also recursively deletes all shards in the keyspace.
-Otherwise, the keyspace must be empty (have no shards), or returns an error.`,
+Otherwise, the keyspace must be empty (have no shards), or returns an error.
+
+Unless --skip-dependency-checks is set, the keyspace is not deleted while routing
+rules or vreplication workflows still reference it, or, with --traffic-window, while
+its tablets served queries within that window.
+
+With --soft-delete-window, the keyspace is only tombstoned: it is no longer served,
+and can be restored with RestoreKeyspace within that window. Once it has passed,
+vtctld purges the keyspace every --keyspace-purge-interval, as does DeleteKeyspace.`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandDeleteKeyspace,
@@ -95,6 +103,14 @@ Otherwise, the keyspace must be empty (have no shards), or returns an error.`,
 		Args:                  cobra.ExactArgs(2),
 		RunE:                  commandRemoveKeyspaceCell,
 	}
+	// RestoreKeyspace makes a RestoreKeyspace gRPC call to a vtctld.
+	RestoreKeyspace = &cobra.Command{
+		Use:                   "RestoreKeyspace <keyspace>",
+		Short:                 "Restores a soft-deleted keyspace within its recovery window, and rebuilds its serving graph.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandRestoreKeyspace,
+	}
 	// SetKeyspaceDurabilityPolicy makes a SetKeyspaceDurabilityPolicy gRPC call to a vtcltd.
 	SetKeyspaceDurabilityPolicy = &cobra.Command{
 		Use:   "SetKeyspaceDurabilityPolicy [--durability-policy=policy_name] <keyspace name>",
@@ -206,23 +222,40 @@ func commandCreateKeyspace(cmd *cobra.Command, args []string) error {
 }
 
 var deleteKeyspaceOptions = struct {
-	Recursive bool
-	Force     bool
+	Recursive            bool
+	Force                bool
+	SkipDependencyChecks bool
+	TrafficWindow        time.Duration
+	SoftDeleteWindow     time.Duration
 }{}
 
 func commandDeleteKeyspace(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
 	ks := cmd.Flags().Arg(0)
-	_, err := client.DeleteKeyspace(commandCtx, &vtctldatapb.DeleteKeyspaceRequest{
-		Keyspace:  ks,
-		Recursive: deleteKeyspaceOptions.Recursive,
-		Force:     deleteKeyspaceOptions.Force,
-	})
+	req := &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:             ks,
+		Recursive:            deleteKeyspaceOptions.Recursive,
+		Force:                deleteKeyspaceOptions.Force,
+		SkipDependencyChecks: deleteKeyspaceOptions.SkipDependencyChecks,
+	}
+	if deleteKeyspaceOptions.TrafficWindow > 0 {
+		req.TrafficWindow = protoutil.DurationToProto(deleteKeyspaceOptions.TrafficWindow)
+	}
+	if deleteKeyspaceOptions.SoftDeleteWindow > 0 {
+		req.SoftDeleteWindow = protoutil.DurationToProto(deleteKeyspaceOptions.SoftDeleteWindow)
+	}
+
+	resp, err := client.DeleteKeyspace(commandCtx, req)
 	if err != nil {
 		return fmt.Errorf("DeleteKeyspace(%v) error: %w; please check the topo", ks, err)
 	}
 
+	if resp.Tombstone != nil {
+		fmt.Printf("Successfully soft-deleted keyspace %v; it can be restored until %v.\n", ks, protoutil.TimeFromProto(resp.Tombstone.PurgeAfter).UTC())
+		return nil
+	}
+
 	fmt.Printf("Successfully deleted keyspace %v.\n", ks)
 
 	return nil
@@ -313,6 +346,26 @@ func commandRemoveKeyspaceCell(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandRestoreKeyspace(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	ks := cmd.Flags().Arg(0)
+	resp, err := client.RestoreKeyspace(commandCtx, &vtctldatapb.RestoreKeyspaceRequest{
+		Keyspace: ks,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.Keyspace)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var setKeyspaceDurabilityPolicyOptions = struct {
 	DurabilityPolicy string
 }{}
@@ -370,6 +423,9 @@ func init() {
 
 	DeleteKeyspace.Flags().BoolVarP(&deleteKeyspaceOptions.Recursive, "recursive", "r", false, "Recursively delete all shards in the keyspace, and all tablets in those shards.")
 	DeleteKeyspace.Flags().BoolVarP(&deleteKeyspaceOptions.Force, "force", "f", false, "Delete the keyspace even if it cannot be locked; this should only be used for cleanup operations.")
+	DeleteKeyspace.Flags().BoolVar(&deleteKeyspaceOptions.SkipDependencyChecks, "skip-dependency-checks", false, "Delete the keyspace even if routing rules or vreplication workflows still reference it, or it served queries within the traffic window.")
+	DeleteKeyspace.Flags().DurationVar(&deleteKeyspaceOptions.TrafficWindow, "traffic-window", 0, "If set, do not delete the keyspace if its tablets served queries within this window.")
+	DeleteKeyspace.Flags().DurationVar(&deleteKeyspaceOptions.SoftDeleteWindow, "soft-delete-window", 0, "If set, only tombstone the keyspace, which can then be restored with RestoreKeyspace within this window.")
 	Root.AddCommand(DeleteKeyspace)
 
 	Root.AddCommand(FindAllShardsInKeyspace)
@@ -380,6 +436,8 @@ func init() {
 	RemoveKeyspaceCell.Flags().BoolVarP(&removeKeyspaceCellOptions.Recursive, "recursive", "r", false, "Also delete all tablets in that cell beloning to the specified keyspace.")
 	Root.AddCommand(RemoveKeyspaceCell)
 
+	Root.AddCommand(RestoreKeyspace)
+
 	SetKeyspaceDurabilityPolicy.Flags().StringVar(&setKeyspaceDurabilityPolicyOptions.DurabilityPolicy, "durability-policy", policy.DurabilityNone, "Type of durability to enforce for this keyspace. Default is none. Other values include 'semi_sync' and others as dictated by registered plugins.")
 	Root.AddCommand(SetKeyspaceDurabilityPolicy)
 
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topotools"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	// ApplyRateLimitRules makes an ApplyRateLimitRules gRPC call to a vtctld.
+	ApplyRateLimitRules = &cobra.Command{
+		Use:   "ApplyRateLimitRules {--rules RULES | --rules-file RULES_FILE} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run]",
+		Short: "Applies the provided rate limit rules.",
+		Long: `Applies the provided rate limit rules, which limit the rate of the queries executed by vtgate.
+
+Each rule limits the queries on a keyspace, or on a table of a keyspace, optionally only for a user. A rule is a token
+bucket which is refilled with queries_per_second tokens every second, up to burst tokens. Queries over the limit are
+either rejected, or queued until they are allowed for at most max_queue_wait_ms, depending on the policy of the rule.
+The rules replace the current ones, and no rules remove them.`,
+		Example:               `ApplyRateLimitRules --rules '{"rules": [{"keyspace": "commerce", "table": "orders", "user": "reporting", "queries_per_second": 50, "policy": "QUEUE", "max_queue_wait_ms": 1000}]}'`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandApplyRateLimitRules,
+	}
+	// GetRateLimitRules makes a GetRateLimitRules gRPC call to a vtctld.
+	GetRateLimitRules = &cobra.Command{
+		Use:                   "GetRateLimitRules",
+		Short:                 "Displays the rate limit rules as a JSON document.",
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandGetRateLimitRules,
+	}
+)
+
+var applyRateLimitRulesOptions = struct {
+	Rules         string
+	RulesFilePath string
+	Cells         []string
+	SkipRebuild   bool
+	DryRun        bool
+}{}
+
+func commandApplyRateLimitRules(cmd *cobra.Command, args []string) error {
+	if applyRateLimitRulesOptions.Rules != "" && applyRateLimitRulesOptions.RulesFilePath != "" {
+		return fmt.Errorf("cannot pass both --rules (=%s) and --rules-file (=%s)", applyRateLimitRulesOptions.Rules, applyRateLimitRulesOptions.RulesFilePath)
+	}
+
+	if applyRateLimitRulesOptions.Rules == "" && applyRateLimitRulesOptions.RulesFilePath == "" {
+		return errors.New("must pass exactly one of --rules or --rules-file")
+	}
+
+	cli.FinishedParsing(cmd)
+
+	var rulesBytes []byte
+	if applyRateLimitRulesOptions.RulesFilePath != "" {
+		data, err := os.ReadFile(applyRateLimitRulesOptions.RulesFilePath)
+		if err != nil {
+			return err
+		}
+
+		rulesBytes = data
+	} else {
+		rulesBytes = []byte(applyRateLimitRulesOptions.Rules)
+	}
+
+	rlr := &vschemapb.RateLimitRules{}
+	if err := json2.UnmarshalPB(rulesBytes, rlr); err != nil {
+		return err
+	}
+	if err := topotools.ValidateRateLimitRules(rlr); err != nil {
+		return fmt.Errorf("invalid rate limit rules: %w", err)
+	}
+	// Round-trip so when we display the result it's readable.
+	data, err := cli.MarshalJSON(rlr)
+	if err != nil {
+		return err
+	}
+
+	if applyRateLimitRulesOptions.DryRun {
+		fmt.Printf("[DRY RUN] Would have saved new RateLimitRules object:\n%s\n", data)
+
+		if applyRateLimitRulesOptions.SkipRebuild {
+			fmt.Println("[DRY RUN] Would not have rebuilt VSchema graph, would have required operator to run RebuildVSchemaGraph for changes to take effect.")
+		} else {
+			fmt.Print("[DRY RUN] Would have rebuilt the VSchema graph")
+			if len(applyRateLimitRulesOptions.Cells) == 0 {
+				fmt.Print(" in all cells\n")
+			} else {
+				fmt.Printf(" in the following cells: %s.\n", strings.Join(applyRateLimitRulesOptions.Cells, ", "))
+			}
+		}
+
+		return nil
+	}
+
+	_, err = client.ApplyRateLimitRules(commandCtx, &vtctldatapb.ApplyRateLimitRulesRequest{
+		RateLimitRules: rlr,
+		SkipRebuild:    applyRateLimitRulesOptions.SkipRebuild,
+		RebuildCells:   applyRateLimitRulesOptions.Cells,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("New RateLimitRules object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", data)
+
+	if applyRateLimitRulesOptions.SkipRebuild {
+		fmt.Println("Skipping rebuild of VSchema graph as requested, you will need to run RebuildVSchemaGraph for the changes to take effect.")
+	}
+
+	return nil
+}
+
+func commandGetRateLimitRules(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetRateLimitRules(commandCtx, &vtctldatapb.GetRateLimitRulesRequest{})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp.RateLimitRules)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func init() {
+	ApplyRateLimitRules.Flags().StringVarP(&applyRateLimitRulesOptions.Rules, "rules", "r", "", "Rate limit rules, specified as a string")
+	ApplyRateLimitRules.Flags().StringVarP(&applyRateLimitRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing rate limit rules specified as JSON")
+	ApplyRateLimitRules.Flags().StringSliceVarP(&applyRateLimitRulesOptions.Cells, "cells", "c", nil, "Limit the VSchema graph rebuilding to the specified cells. Ignored if --skip-rebuild is specified.")
+	ApplyRateLimitRules.Flags().BoolVar(&applyRateLimitRulesOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvVSchema objects.")
+	ApplyRateLimitRules.Flags().BoolVarP(&applyRateLimitRulesOptions.DryRun, "dry-run", "d", false, "Validate the specified rate limit rules and note actions that would be taken, but do not actually apply the rules to the topo.")
+	Root.AddCommand(ApplyRateLimitRules)
+
+	Root.AddCommand(GetRateLimitRules)
+}
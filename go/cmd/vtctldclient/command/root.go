@@ -46,6 +46,7 @@ import (
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/migrate"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/mount"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/movetables"
+	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/primaryvindex"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/reshard"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/vdiff"
 	_ "vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/workflow"
@@ -17,6 +17,7 @@ limitations under the License.
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -26,6 +27,7 @@ import (
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topotools"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -92,6 +94,24 @@ func commandApplyRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	vschemas, err := getKeyspaceVSchemas(commandCtx)
+	if err != nil {
+		return err
+	}
+	if err := topotools.ValidateRoutingRules(rr, vschemas); err != nil {
+		return fmt.Errorf("invalid routing rules: %w", err)
+	}
+
+	// Keep the rules that the diff is computed against so that vtctld can refuse
+	// to apply the new rules if they were changed concurrently.
+	current, err := client.GetRoutingRules(commandCtx, &vtctldatapb.GetRoutingRulesRequest{})
+	if err != nil {
+		return err
+	}
+	if err := printRoutingRulesDiff("RoutingRules", topotools.DiffRoutingRules(current.RoutingRules, rr)); err != nil {
+		return err
+	}
+
 	if applyRoutingRulesOptions.DryRun {
 		fmt.Printf("[DRY RUN] Would have saved new RoutingRules object:\n%s\n", data)
 
@@ -110,9 +130,10 @@ func commandApplyRoutingRules(cmd *cobra.Command, args []string) error {
 	}
 
 	_, err = client.ApplyRoutingRules(commandCtx, &vtctldatapb.ApplyRoutingRulesRequest{
-		RoutingRules: rr,
-		SkipRebuild:  applyRoutingRulesOptions.SkipRebuild,
-		RebuildCells: applyRoutingRulesOptions.Cells,
+		RoutingRules:         rr,
+		SkipRebuild:          applyRoutingRulesOptions.SkipRebuild,
+		RebuildCells:         applyRoutingRulesOptions.Cells,
+		ExpectedRoutingRules: current.RoutingRules,
 	})
 	if err != nil {
 		return err
@@ -145,6 +166,38 @@ func commandGetRoutingRules(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// getKeyspaceVSchemas returns the vschema of every keyspace, keyed by keyspace name.
+func getKeyspaceVSchemas(ctx context.Context) (map[string]*vschemapb.Keyspace, error) {
+	resp, err := client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	vschemas := make(map[string]*vschemapb.Keyspace, len(resp.Keyspaces))
+	for _, ks := range resp.Keyspaces {
+		vsResp, err := client.GetVSchema(ctx, &vtctldatapb.GetVSchemaRequest{Keyspace: ks.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the vschema of keyspace %s: %w", ks.Name, err)
+		}
+		vschemas[ks.Name] = vsResp.VSchema
+	}
+	return vschemas, nil
+}
+
+// printRoutingRulesDiff prints the changes that applying new rules of the given kind makes
+// to the current ones.
+func printRoutingRulesDiff(kind string, changes []*topotools.RoutingRuleChange) error {
+	if len(changes) == 0 {
+		fmt.Printf("No changes to the current %s object.\n", kind)
+		return nil
+	}
+	data, err := cli.MarshalJSON(changes)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Changes to the current %s object:\n%s\n", kind, data)
+	return nil
+}
+
 func init() {
 	ApplyRoutingRules.Flags().StringVarP(&applyRoutingRulesOptions.Rules, "rules", "r", "", "Routing rules, specified as a string.")
 	ApplyRoutingRules.Flags().StringVarP(&applyRoutingRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing routing rules specified as JSON.")
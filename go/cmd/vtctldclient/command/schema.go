@@ -19,20 +19,27 @@ package command
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/logutil"
 	"vitess.io/vitess/go/vt/schema"
+	"vitess.io/vitess/go/vt/schemadiff"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/utils"
 	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
 
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -41,7 +48,7 @@ import (
 var (
 	// ApplySchema makes an ApplySchema gRPC call to a vtctld.
 	ApplySchema = &cobra.Command{
-		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] {--sql-file <file> | --sql <sql>} <keyspace>",
+		Use:   "ApplySchema [--ddl-strategy <strategy>] [--uuid <uuid> ...] [--migration-context <context>] [--wait-replicas-timeout <duration>] [--caller-id <caller_id>] [--plan] [--canary-shard <shard> [--canary-auto-proceed | --canary-approved] [--canary-timeout <duration>]] {--sql-file <file> | --sql <sql> | --schema-dir <dir> [--allow-drops]} <keyspace>",
 		Short: "Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.",
 		Long: `Applies the schema change to the specified keyspace on every primary, running in parallel on all shards. The changes are then propagated to replicas via replication.
 
@@ -49,6 +56,12 @@ If --allow-long-unavailability is set, schema changes affecting a large number o
 --ddl-strategy is used to instruct migrations via vreplication, mysql or direct with optional parameters.
 --migration-context allows the user to specify a custom migration context for online DDL migrations.
 If --skip-preflight, SQL goes directly to shards without going through sanity checks.
+If --plan is set, nothing is executed. Instead, the semantic changes that the SQL would make to the schema of each shard are printed, in the order in which they can be safely applied, along with the estimated size of affected tables.
+If --schema-dir is set, the schema change is declarative: the directory holds .sql files with the CREATE TABLE and CREATE VIEW statements of the desired schema. The diff between the live schema and the desired schema is computed by schemadiff, and the resulting statements are submitted as Online DDL migrations. The diff must be the same on all shards. Tables and views missing from the directory are only dropped if --allow-drops is set.
+If --canary-shard is set, the schema change is first applied on that shard only, waiting for its Online DDL migrations there to complete, and the outcome of the canary is printed. The schema change is then applied on the remaining shards if --canary-auto-proceed is set. Otherwise, it is applied on the remaining shards by running ApplySchema again with the same SQL, --canary-approved, and the UUIDs returned by the canary:
+
+	ApplySchema --ddl-strategy vitess --sql "ALTER TABLE my_table ADD COLUMN c INT" --canary-shard -80 commerce
+	ApplySchema --ddl-strategy vitess --sql "ALTER TABLE my_table ADD COLUMN c INT" --canary-shard -80 --canary-approved --uuid $uuid commerce
 
 The --uuid and --sql flags are repeatable, so they can be passed multiple times to build a list of values.
 For --uuid, this is used like "--uuid $first_uuid --uuid $second_uuid".
@@ -133,6 +146,13 @@ type ApplySchemaOptions struct {
 	SkipPreflight           bool
 	CallerID                string
 	BatchSize               int64
+	Plan                    bool
+	SchemaDir               string
+	AllowDrops              bool
+	CanaryShard             string
+	CanaryAutoProceed       bool
+	CanaryApproved          bool
+	CanaryTimeout           time.Duration
 }
 
 // CallerIDProto returns a *vtrpcpb.CallerID constructed from this options
@@ -147,6 +167,16 @@ func (o *ApplySchemaOptions) CallerIDProto() *vtrpcpb.CallerID {
 var applySchemaOptions ApplySchemaOptions
 
 func commandApplySchema(cmd *cobra.Command, args []string) error {
+	if applySchemaOptions.SchemaDir != "" {
+		if applySchemaOptions.SQLFile != "" || len(applySchemaOptions.SQL) != 0 {
+			return errors.New("--schema-dir cannot be combined with --sql or --sql-file.")
+		}
+		return commandApplySchemaDeclarative(cmd)
+	}
+	if applySchemaOptions.AllowDrops {
+		return errors.New("--allow-drops is only applicable with --schema-dir.")
+	}
+
 	var allSQL string
 	if applySchemaOptions.SQLFile != "" {
 		if len(applySchemaOptions.SQL) != 0 {
@@ -170,28 +200,223 @@ func commandApplySchema(cmd *cobra.Command, args []string) error {
 
 	cli.FinishedParsing(cmd)
 
-	cid := applySchemaOptions.CallerIDProto()
-
 	ks := cmd.Flags().Arg(0)
 
+	if applySchemaOptions.Plan {
+		return planApplySchema(ks, parts)
+	}
+
+	return submitApplySchema(ks, applySchemaOptions.DDLStrategy, parts)
+}
+
+func submitApplySchema(keyspace string, ddlStrategy string, sql []string) error {
 	resp, err := client.ApplySchema(commandCtx, &vtctldatapb.ApplySchemaRequest{
-		Keyspace:            ks,
-		DdlStrategy:         applySchemaOptions.DDLStrategy,
-		Sql:                 parts,
+		Keyspace:            keyspace,
+		DdlStrategy:         ddlStrategy,
+		Sql:                 sql,
 		UuidList:            applySchemaOptions.UUIDList,
 		MigrationContext:    applySchemaOptions.MigrationContext,
 		WaitReplicasTimeout: protoutil.DurationToProto(applySchemaOptions.WaitReplicasTimeout),
-		CallerId:            cid,
+		CallerId:            applySchemaOptions.CallerIDProto(),
 		BatchSize:           applySchemaOptions.BatchSize,
+		CanaryShard:         applySchemaOptions.CanaryShard,
+		CanaryAutoProceed:   applySchemaOptions.CanaryAutoProceed,
+		CanaryApproved:      applySchemaOptions.CanaryApproved,
+		CanaryTimeout:       protoutil.DurationToProto(applySchemaOptions.CanaryTimeout),
 	})
 	if err != nil {
 		return err
 	}
 
+	if resp.Canary != nil {
+		data, err := cli.MarshalJSON(resp)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
 	fmt.Println(strings.Join(resp.UuidList, "\n"))
 	return nil
 }
 
+// commandApplySchemaDeclarative applies the desired schema found in --schema-dir: it diffs the desired
+// schema against the live schema of each shard, and submits the resulting statements as Online DDL
+// migrations.
+func commandApplySchemaDeclarative(cmd *cobra.Command) error {
+	ddlStrategy := applySchemaOptions.DDLStrategy
+	if !cmd.Flags().Changed("ddl-strategy") {
+		ddlStrategy = string(schema.DDLStrategyVitess)
+	}
+	setting, err := schema.ParseDDLStrategy(ddlStrategy)
+	if err != nil {
+		return err
+	}
+	if setting.Strategy.IsDirect() {
+		return fmt.Errorf("--schema-dir requires an Online DDL strategy, got '%s'.", ddlStrategy)
+	}
+	if setting.IsDeclarative() {
+		// The submitted statements are the already computed diff, not CREATE statements.
+		return errors.New("--schema-dir cannot be used with a --declarative ddl strategy.")
+	}
+
+	desired, err := readSchemaDir(applySchemaOptions.SchemaDir)
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	ks := cmd.Flags().Arg(0)
+	schemadiffEnv := schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset())
+	shardChanges, err := planShards(ks, func(sd *tabletmanagerdatapb.SchemaDefinition) ([]*schematools.PlannedSchemaChange, error) {
+		return schematools.PlanDeclarativeSchemaChange(commandCtx, schemadiffEnv, sd, desired)
+	})
+	if err != nil {
+		return err
+	}
+
+	if applySchemaOptions.Plan {
+		for _, shardName := range slices.Sorted(maps.Keys(shardChanges)) {
+			printSchemaChangePlan(ks, shardName, shardChanges[shardName])
+		}
+		return nil
+	}
+
+	var (
+		sql        []string
+		firstShard string
+	)
+	for i, shardName := range slices.Sorted(maps.Keys(shardChanges)) {
+		var shardSQL []string
+		for _, change := range shardChanges[shardName] {
+			if change.Action == "drop" && !applySchemaOptions.AllowDrops {
+				return fmt.Errorf("shard %s/%s: desired schema drops %s, which requires --allow-drops", ks, shardName, change.Entity)
+			}
+			shardSQL = append(shardSQL, change.Statement)
+		}
+		if i == 0 {
+			sql, firstShard = shardSQL, shardName
+			continue
+		}
+		if !slices.Equal(sql, shardSQL) {
+			return fmt.Errorf("schema of shard %s/%s differs from schema of shard %s/%s; use ValidateSchemaKeyspace to find the differences", ks, shardName, ks, firstShard)
+		}
+	}
+	if len(sql) == 0 {
+		fmt.Println("Schema is up to date, no changes to apply")
+		return nil
+	}
+
+	return submitApplySchema(ks, ddlStrategy, sql)
+}
+
+// readSchemaDir reads the statements of all .sql files in the given directory, in lexical file order.
+func readSchemaDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var statements []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		parts, err := env.Parser().SplitStatementToPieces(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		statements = append(statements, parts...)
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no statements found in .sql files of %s", dir)
+	}
+	return statements, nil
+}
+
+// planApplySchema prints, per shard, the schema changes that the given SQL would make, without executing anything.
+func planApplySchema(keyspace string, sql []string) error {
+	schemadiffEnv := schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset())
+	shardChanges, err := planShards(keyspace, func(sd *tabletmanagerdatapb.SchemaDefinition) ([]*schematools.PlannedSchemaChange, error) {
+		return schematools.PlanSchemaChange(commandCtx, schemadiffEnv, sd, sql)
+	})
+	if err != nil {
+		return err
+	}
+	for _, shardName := range slices.Sorted(maps.Keys(shardChanges)) {
+		printSchemaChangePlan(keyspace, shardName, shardChanges[shardName])
+	}
+	return nil
+}
+
+// planShards reads the schema of the primary of each shard in the keyspace, and computes the schema
+// changes of each shard with the given plan function.
+func planShards(keyspace string, plan func(sd *tabletmanagerdatapb.SchemaDefinition) ([]*schematools.PlannedSchemaChange, error)) (map[string][]*schematools.PlannedSchemaChange, error) {
+	resp, err := client.FindAllShardsInKeyspace(commandCtx, &vtctldatapb.FindAllShardsInKeyspaceRequest{
+		Keyspace: keyspace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shardChanges := make(map[string][]*schematools.PlannedSchemaChange, len(resp.Shards))
+	for shardName, shard := range resp.Shards {
+		if shard.Shard.PrimaryAlias == nil {
+			return nil, fmt.Errorf("shard %s/%s has no primary", keyspace, shardName)
+		}
+		schemaResp, err := client.GetSchema(commandCtx, &vtctldatapb.GetSchemaRequest{
+			TabletAlias:  shard.Shard.PrimaryAlias,
+			IncludeViews: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes, err := plan(schemaResp.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("shard %s/%s: %w", keyspace, shardName, err)
+		}
+		shardChanges[shardName] = changes
+	}
+	return shardChanges, nil
+}
+
+func printSchemaChangePlan(keyspace string, shard string, changes []*schematools.PlannedSchemaChange) {
+	if len(changes) == 0 {
+		fmt.Printf("Shard %s/%s: no changes\n\n", keyspace, shard)
+		return
+	}
+	fmt.Printf("Shard %s/%s:\n", keyspace, shard)
+	actionCounts := map[string]int{}
+	for i, change := range changes {
+		actionCounts[change.Action]++
+		var details []string
+		if change.RowCount > 0 || change.DataLength > 0 {
+			details = append(details, fmt.Sprintf("~%d rows", change.RowCount), humanize.IBytes(change.DataLength))
+		}
+		if change.InstantDDLCapable {
+			details = append(details, "instant")
+		}
+		fmt.Printf("  %d. %s %s", i+1, change.Action, change.Entity)
+		if len(details) > 0 {
+			fmt.Printf(" (%s)", strings.Join(details, ", "))
+		}
+		fmt.Println()
+		for line := range strings.SplitSeq(change.Statement, "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
+	var summary []string
+	for _, action := range slices.Sorted(maps.Keys(actionCounts)) {
+		summary = append(summary, fmt.Sprintf("%d to %s", actionCounts[action], action))
+	}
+	fmt.Printf("Plan: %s\n\n", strings.Join(summary, ", "))
+}
+
 var copySchemaShardOptions = struct {
 	tables              []string
 	excludeTables       []string
@@ -447,6 +672,14 @@ func init() {
 	ApplySchema.Flags().StringArrayVar(&applySchemaOptions.SQL, "sql", nil, "Semicolon-delimited, repeatable SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().StringVar(&applySchemaOptions.SQLFile, "sql-file", "", "Path to a file containing semicolon-delimited SQL commands to apply. Exactly one of --sql|--sql-file is required.")
 	ApplySchema.Flags().Int64Var(&applySchemaOptions.BatchSize, "batch-size", 0, "How many queries to batch together. Only applicable when all queries are CREATE TABLE|VIEW")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.Plan, "plan", false, "Print the schema changes that the SQL would make on each shard, computed by schemadiff, without executing anything.")
+	ApplySchema.Flags().StringVar(&applySchemaOptions.SchemaDir, "schema-dir", "", "Path to a directory of .sql files with the CREATE TABLE|VIEW statements of the desired schema. The diff against the live schema is applied as Online DDL migrations.")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.AllowDrops, "allow-drops", false, "With --schema-dir, allow dropping tables and views that are missing from the desired schema.")
+	ApplySchema.Flags().StringVar(&applySchemaOptions.CanaryShard, "canary-shard", "", "Apply the schema change on this shard first, and wait for its Online DDL migrations there to complete before applying it on the remaining shards.")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.CanaryAutoProceed, "canary-auto-proceed", false, "With --canary-shard, apply the schema change on the remaining shards as soon as it succeeded on the canary shard.")
+	ApplySchema.Flags().BoolVar(&applySchemaOptions.CanaryApproved, "canary-approved", false, "With --canary-shard, apply the schema change on the remaining shards, after checking that the migrations given by --uuid completed on the canary shard.")
+	ApplySchema.Flags().DurationVar(&applySchemaOptions.CanaryTimeout, "canary-timeout", 0, "With --canary-shard, how long to wait for the Online DDL migrations on the canary shard to complete. Defaults to 1h on the server.")
+	ApplySchema.MarkFlagsMutuallyExclusive("canary-auto-proceed", "canary-approved")
 	Root.AddCommand(ApplySchema)
 
 	CopySchemaShard.Flags().StringSliceVar(&copySchemaShardOptions.tables, "tables", nil, "Specifies a comma-separated list of tables to copy. Each is either an exact match, or a regular expression of the form /regexp/")
@@ -17,6 +17,7 @@ limitations under the License.
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -26,6 +27,7 @@ import (
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/json2"
+	"vitess.io/vitess/go/vt/topotools"
 
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -96,6 +98,22 @@ func commandApplyShardRoutingRules(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	shards, err := getKeyspaceShards(commandCtx)
+	if err != nil {
+		return err
+	}
+	if err := topotools.ValidateShardRoutingRules(srr, shards); err != nil {
+		return fmt.Errorf("invalid shard routing rules: %w", err)
+	}
+
+	current, err := client.GetShardRoutingRules(commandCtx, &vtctldatapb.GetShardRoutingRulesRequest{})
+	if err != nil {
+		return err
+	}
+	if err := printRoutingRulesDiff("ShardRoutingRules", topotools.DiffShardRoutingRules(current.ShardRoutingRules, srr)); err != nil {
+		return err
+	}
+
 	if applyShardRoutingRulesOptions.DryRun {
 		fmt.Printf("[DRY RUN] Would have saved new ShardRoutingRules object:\n%s\n", data)
 
@@ -114,9 +132,10 @@ func commandApplyShardRoutingRules(cmd *cobra.Command, args []string) error {
 	}
 
 	_, err = client.ApplyShardRoutingRules(commandCtx, &vtctldatapb.ApplyShardRoutingRulesRequest{
-		ShardRoutingRules: srr,
-		SkipRebuild:       applyShardRoutingRulesOptions.SkipRebuild,
-		RebuildCells:      applyShardRoutingRulesOptions.Cells,
+		ShardRoutingRules:         srr,
+		SkipRebuild:               applyShardRoutingRulesOptions.SkipRebuild,
+		RebuildCells:              applyShardRoutingRulesOptions.Cells,
+		ExpectedShardRoutingRules: current.ShardRoutingRules,
 	})
 	if err != nil {
 		return err
@@ -149,6 +168,26 @@ func commandGetShardRoutingRules(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// getKeyspaceShards returns the names of the shards of every keyspace, keyed by keyspace name.
+func getKeyspaceShards(ctx context.Context) (map[string][]string, error) {
+	resp, err := client.GetKeyspaces(ctx, &vtctldatapb.GetKeyspacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[string][]string, len(resp.Keyspaces))
+	for _, ks := range resp.Keyspaces {
+		shardsResp, err := client.FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{Keyspace: ks.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the shards of keyspace %s: %w", ks.Name, err)
+		}
+		shards[ks.Name] = make([]string, 0, len(shardsResp.Shards))
+		for _, shard := range shardsResp.Shards {
+			shards[ks.Name] = append(shards[ks.Name], shard.Name)
+		}
+	}
+	return shards, nil
+}
+
 func init() {
 	ApplyShardRoutingRules.Flags().StringVarP(&applyShardRoutingRulesOptions.Rules, "rules", "r", "", "Shard routing rules, specified as a string")
 	ApplyShardRoutingRules.Flags().StringVarP(&applyShardRoutingRulesOptions.RulesFilePath, "rules-file", "f", "", "Path to a file containing shard routing rules specified as JSON")
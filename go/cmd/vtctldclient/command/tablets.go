@@ -17,16 +17,20 @@ limitations under the License.
 package command
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/shardhealth"
 
 	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -34,6 +38,25 @@ import (
 )
 
 var (
+	// AddTabletTags makes an AddTabletTags gRPC call to a vtctld.
+	AddTabletTags = &cobra.Command{
+		Use:   "AddTabletTags [--keyspace <keyspace> [--shard <shard>]] [--cell <cell> ...] [--tablet-type <tablet-type>] [--concurrency <concurrency>] <tablet-tag> [ <tablet-tag> ... ]",
+		Short: "Adds or updates tags on all the tablets matching the filters.",
+		Long: `Adds or updates tags on all the tablets matching the --keyspace, --shard, --cell
+and --tablet-type filters.
+
+Tags must be specified as key=value pairs. Values are Go templates rendered for
+each tablet, which may refer to its {{.Alias}}, {{.Cell}}, {{.Uid}}, {{.Keyspace}},
+{{.Shard}}, {{.Type}} and {{.Hostname}}.
+
+The result is reported for each tablet, and the command fails if the tags of
+any tablet could not be changed.`,
+		Example: `AddTabletTags --keyspace commerce --tablet-type replica pool=reporting
+AddTabletTags --cell zone1 rack={{.Cell}}-{{.Hostname}}`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandAddTabletTags,
+	}
 	// ChangeTabletTags makes a ChangeTabletTags gRPC call to a vtctld.
 	ChangeTabletTags = &cobra.Command{
 		Use:   "ChangeTabletTags <alias> <tablet-tag> [ <tablet-tag> ... ]",
@@ -86,12 +109,40 @@ Note: hook names may not contain slash (/) characters.
 	}
 	// GetFullStatus makes a FullStatus gRPC call to a vttablet.
 	GetFullStatus = &cobra.Command{
-		Use:                   "GetFullStatus <alias>",
-		Short:                 "Outputs a JSON structure that contains full status of MySQL including the replication information, semi-sync information, GTID information among others.",
+		Use:   "GetFullStatus [<alias> | [--keyspace <keyspace> [--shard <shard>]] [--cell <cell> ...] [--max-replication-lag <duration>] [--min-score <score>] [--format text|json]]",
+		Short: "Outputs a JSON structure that contains full status of MySQL including the replication information, semi-sync information, GTID information among others.",
+		Long: `Outputs a JSON structure that contains full status of MySQL including the replication information, semi-sync information, GTID information among others.
+
+When no tablet alias is passed, the full status of all the tablets matching the
+--keyspace, --shard and --cell filters is fetched concurrently instead, and a
+health score out of 100 is computed for each shard, along with the anomalies which
+lowered it: a missing or read-only primary, stopped replication, errant GTIDs,
+replication lag above --max-replication-lag, semi-sync misconfigurations, tablets
+which cannot be reached, and settings or MySQL versions which differ between the
+tablets of the shard.
+
+The command fails if the score of any shard is below --min-score, so that it can
+be used as a gate before a deployment.`,
+		Example: `GetFullStatus zone1-0000000100
+GetFullStatus --keyspace commerce --min-score 100
+GetFullStatus --cell zone1 --max-replication-lag 10s --format json`,
 		DisableFlagsInUseLine: true,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		RunE:                  commandGetFullStatus,
 	}
+	// GetMysqlErrorLogEvents makes a GetMysqlErrorLogEvents gRPC call to a vtctld.
+	GetMysqlErrorLogEvents = &cobra.Command{
+		Use:   "GetMysqlErrorLogEvents [--category <category>] [--limit <limit>] <alias>",
+		Short: "Outputs a JSON structure that contains the recent notable events of the MySQL error log of the tablet.",
+		Long: `Outputs a JSON structure that contains the recent notable events of the MySQL error log of the tablet,
+such as crash recoveries, deadlock spikes, disk errors and semi-sync timeouts, along with the number of
+events of every category. The tablet must be started with --mysql-error-log-harvest.`,
+		Example: `GetMysqlErrorLogEvents zone1-0000000100
+GetMysqlErrorLogEvents --category disk_error --limit 10 zone1-0000000100`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetMysqlErrorLogEvents,
+	}
 	// GetTablet makes a GetTablet gRPC call to a vtctld.
 	GetTablet = &cobra.Command{
 		Use:                   "GetTablet <alias>",
@@ -100,6 +151,23 @@ Note: hook names may not contain slash (/) characters.
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandGetTablet,
 	}
+	// GetTabletQueryPlans makes a GetTabletQueryPlans gRPC call to a vtctld.
+	GetTabletQueryPlans = &cobra.Command{
+		Use:   "GetTabletQueryPlans [--filter <substring>] [--limit <limit>] [--evict <query> ...] [--evict-all] [--pin <query> ...] [--unpin <query> ...] <alias>",
+		Short: "Outputs a JSON structure that contains the query plans cached by the tablet.",
+		Long: `Outputs a JSON structure that contains the query plans cached by the tablet, the most hit first,
+along with their hit counts, execution stats and the names of the query rules they match.
+
+The plans of the queries passed with --evict are evicted from the cache and unpinned, and the plans
+of the queries passed with --pin are pinned so that they are never evicted, until they are passed with
+--unpin. The plans are listed after evicting, unpinning and pinning them, in that order.`,
+		Example: `GetTabletQueryPlans --filter "from customer" zone1-0000000100
+GetTabletQueryPlans --evict "select * from customer where id = :id" zone1-0000000100
+GetTabletQueryPlans --pin "select * from customer where id = :id" --limit 10 zone1-0000000100`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		RunE:                  commandGetTabletQueryPlans,
+	}
 	// GetTablets makes a GetTablets gRPC call to a vtctld.
 	GetTablets = &cobra.Command{
 		Use:   "GetTablets [--strict] [{--cell $c1 [--cell $c2 ...] [--tablet-type $t1] [--keyspace $ks [--shard $shard]], --tablet-alias $alias}]",
@@ -159,6 +227,20 @@ Valid output formats are "awk" and "json".`, strings.Join(topoproto.MakeUniqueSt
 		Args:                  cobra.ExactArgs(1),
 		RunE:                  commandRefreshStateByShard,
 	}
+	// RemoveTabletTags makes a RemoveTabletTags gRPC call to a vtctld.
+	RemoveTabletTags = &cobra.Command{
+		Use:   "RemoveTabletTags [--keyspace <keyspace> [--shard <shard>]] [--cell <cell> ...] [--tablet-type <tablet-type>] [--concurrency <concurrency>] <tag-key> [ <tag-key> ... ]",
+		Short: "Removes tags from all the tablets matching the filters.",
+		Long: `Removes the tags with the given keys from all the tablets matching the --keyspace,
+--shard, --cell and --tablet-type filters.
+
+The result is reported for each tablet, and the command fails if the tags of
+any tablet could not be changed.`,
+		Example:               `RemoveTabletTags --keyspace commerce --tablet-type replica pool`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE:                  commandRemoveTabletTags,
+	}
 	// RunHealthCheck makes a RunHealthCheck gRPC call to a vtctld.
 	RunHealthCheck = &cobra.Command{
 		Use:                   "RunHealthCheck <tablet_alias>",
@@ -215,6 +297,85 @@ Note that, in the SleepTablet implementation, the value should be positively-sig
 	}
 )
 
+// tabletTagsOptions are the options of AddTabletTags and RemoveTabletTags.
+type tabletTagsOptions struct {
+	Keyspace    string
+	Shard       string
+	Cells       []string
+	TabletType  topodatapb.TabletType
+	Concurrency int32
+}
+
+func (o *tabletTagsOptions) validate() error {
+	if o.Keyspace == "" && o.Shard != "" {
+		return fmt.Errorf("--shard (= %s) cannot be passed without also passing --keyspace", o.Shard)
+	}
+	if o.Concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", o.Concurrency)
+	}
+	return nil
+}
+
+func (o *tabletTagsOptions) addFlags(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVarP(&o.Keyspace, "keyspace", "k", "", fmt.Sprintf("Keyspace of the tablets to %s.", verb))
+	cmd.Flags().StringVarP(&o.Shard, "shard", "s", "", fmt.Sprintf("Shard of the tablets to %s.", verb))
+	cmd.Flags().StringSliceVarP(&o.Cells, "cell", "c", nil, fmt.Sprintf("List of cells of the tablets to %s.", verb))
+	cmd.Flags().Var((*topoproto.TabletTypeFlag)(&o.TabletType), "tablet-type", fmt.Sprintf("Tablet type of the tablets to %s (e.g. primary or replica).", verb))
+	cmd.Flags().Int32Var(&o.Concurrency, "concurrency", 10, fmt.Sprintf("Maximum number of tablets to %s concurrently.", verb))
+}
+
+// printTabletTagsResults prints the results of AddTabletTags or
+// RemoveTabletTags, and returns an error if the tags of any tablet could not
+// be changed.
+func printTabletTagsResults(results []*vtctldatapb.TabletTagsResult) error {
+	data, err := cli.MarshalJSON(results)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to change the tags of %d out of %d tablets", failed, len(results))
+	}
+	return nil
+}
+
+var addTabletTagsOptions tabletTagsOptions
+
+func commandAddTabletTags(cmd *cobra.Command, args []string) error {
+	if err := addTabletTagsOptions.validate(); err != nil {
+		return err
+	}
+
+	tags, err := cli.TabletTagsFromPosArgs(cmd.Flags().Args())
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.AddTabletTags(commandCtx, &vtctldatapb.AddTabletTagsRequest{
+		Keyspace:    addTabletTagsOptions.Keyspace,
+		Shard:       addTabletTagsOptions.Shard,
+		Cells:       addTabletTagsOptions.Cells,
+		TabletType:  addTabletTagsOptions.TabletType,
+		Tags:        tags,
+		Concurrency: addTabletTagsOptions.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printTabletTagsResults(resp.Results)
+}
+
 var changeTabletTagsOptions = struct {
 	Replace bool
 }{}
@@ -347,7 +508,26 @@ func commandExecuteHook(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var getFullStatusOptions = struct {
+	Keyspace          string
+	Shard             string
+	Cells             []string
+	Concurrency       int
+	MaxReplicationLag time.Duration
+	MinScore          int
+	Format            string
+}{}
+
 func commandGetFullStatus(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().NArg() == 0 {
+		return commandGetFleetFullStatus(cmd)
+	}
+	for _, name := range []string{"keyspace", "shard", "cell", "concurrency", "max-replication-lag", "min-score", "format"} {
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--%s cannot be passed with a tablet alias", name)
+		}
+	}
+
 	aliasStr := cmd.Flags().Arg(0)
 	alias, err := topoproto.ParseTabletAlias(aliasStr)
 	if err != nil {
@@ -371,6 +551,131 @@ func commandGetFullStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// commandGetFleetFullStatus fetches the full status of all the tablets
+// matching the filters and reports the health of each of their shards.
+func commandGetFleetFullStatus(cmd *cobra.Command) error {
+	format := strings.ToLower(getFullStatusOptions.Format)
+	switch format {
+	case "text", "json":
+	default:
+		return fmt.Errorf("invalid output format, got %s", getFullStatusOptions.Format)
+	}
+	if getFullStatusOptions.Keyspace == "" && getFullStatusOptions.Shard != "" {
+		return fmt.Errorf("--shard (= %s) cannot be passed without also passing --keyspace", getFullStatusOptions.Shard)
+	}
+	if getFullStatusOptions.Concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", getFullStatusOptions.Concurrency)
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTablets(commandCtx, &vtctldatapb.GetTabletsRequest{
+		Cells:    getFullStatusOptions.Cells,
+		Keyspace: getFullStatusOptions.Keyspace,
+		Shard:    getFullStatusOptions.Shard,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Tablets) == 0 {
+		return errors.New("no tablets found")
+	}
+
+	// A tablet which cannot be reached is an anomaly of its shard rather
+	// than an error of the command.
+	statuses := make([]shardhealth.TabletStatus, len(resp.Tablets))
+	eg := errgroup.Group{}
+	eg.SetLimit(getFullStatusOptions.Concurrency)
+	for i, tablet := range resp.Tablets {
+		eg.Go(func() error {
+			statuses[i].Tablet = tablet
+			fsResp, err := client.GetFullStatus(commandCtx, &vtctldatapb.GetFullStatusRequest{TabletAlias: tablet.Alias})
+			if err != nil {
+				statuses[i].Err = err
+				return nil
+			}
+			statuses[i].Status = fsResp.Status
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	results := shardhealth.Evaluate(statuses, shardhealth.Options{
+		MaxReplicationLag: getFullStatusOptions.MaxReplicationLag,
+	})
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+	case "text":
+		for _, result := range results {
+			fmt.Printf("%s: score %d/%d\n", result.Name(), result.Score, shardhealth.MaxScore)
+			for _, tablet := range result.Tablets {
+				if tablet.Error != "" {
+					fmt.Printf("  %s %s ERROR: %s\n", tablet.Tablet, tablet.Type, tablet.Error)
+					continue
+				}
+				fmt.Printf("  %s %s version=%s lag=%ds semi_sync=%t position=%s\n", tablet.Tablet, tablet.Type,
+					tablet.Version, tablet.ReplicationLagSeconds, tablet.SemiSync, tablet.Position)
+			}
+			for _, anomaly := range result.Anomalies {
+				if anomaly.Tablet == "" {
+					fmt.Printf("  %s: %s\n", anomaly.Severity, anomaly.Message)
+				} else {
+					fmt.Printf("  %s: %s: %s\n", anomaly.Severity, anomaly.Tablet, anomaly.Message)
+				}
+			}
+		}
+	}
+
+	var unhealthy []string
+	for _, result := range results {
+		if result.Score < getFullStatusOptions.MinScore {
+			unhealthy = append(unhealthy, result.Name())
+		}
+	}
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("%d of %d shards have a health score below %d: %s", len(unhealthy), len(results),
+			getFullStatusOptions.MinScore, strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
+
+var getMysqlErrorLogEventsOptions = struct {
+	Category string
+	Limit    uint32
+}{}
+
+func commandGetMysqlErrorLogEvents(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetMysqlErrorLogEvents(commandCtx, &vtctldatapb.GetMysqlErrorLogEventsRequest{
+		TabletAlias: alias,
+		Category:    getMysqlErrorLogEventsOptions.Category,
+		Limit:       getMysqlErrorLogEventsOptions.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 func commandGetTablet(cmd *cobra.Command, args []string) error {
 	aliasStr := cmd.Flags().Arg(0)
 	alias, err := topoproto.ParseTabletAlias(aliasStr)
@@ -395,6 +700,45 @@ func commandGetTablet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var getTabletQueryPlansOptions = struct {
+	Filter   string
+	Limit    uint32
+	Evict    []string
+	EvictAll bool
+	Pin      []string
+	Unpin    []string
+}{}
+
+func commandGetTabletQueryPlans(cmd *cobra.Command, args []string) error {
+	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
+	if err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.GetTabletQueryPlans(commandCtx, &vtctldatapb.GetTabletQueryPlansRequest{
+		TabletAlias: alias,
+		Filter:      getTabletQueryPlansOptions.Filter,
+		Limit:       getTabletQueryPlansOptions.Limit,
+		Evict:       getTabletQueryPlansOptions.Evict,
+		EvictAll:    getTabletQueryPlansOptions.EvictAll,
+		Pin:         getTabletQueryPlansOptions.Pin,
+		Unpin:       getTabletQueryPlansOptions.Unpin,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSON(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
 var getTabletsOptions = struct {
 	Cells      []string
 	TabletType topodatapb.TabletType
@@ -559,6 +903,30 @@ func commandRefreshStateByShard(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var removeTabletTagsOptions tabletTagsOptions
+
+func commandRemoveTabletTags(cmd *cobra.Command, args []string) error {
+	if err := removeTabletTagsOptions.validate(); err != nil {
+		return err
+	}
+
+	cli.FinishedParsing(cmd)
+
+	resp, err := client.RemoveTabletTags(commandCtx, &vtctldatapb.RemoveTabletTagsRequest{
+		Keyspace:    removeTabletTagsOptions.Keyspace,
+		Shard:       removeTabletTagsOptions.Shard,
+		Cells:       removeTabletTagsOptions.Cells,
+		TabletType:  removeTabletTagsOptions.TabletType,
+		Tags:        cmd.Flags().Args(),
+		Concurrency: removeTabletTagsOptions.Concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printTabletTagsResults(resp.Results)
+}
+
 func commandRunHealthCheck(cmd *cobra.Command, args []string) error {
 	alias, err := topoproto.ParseTabletAlias(cmd.Flags().Arg(0))
 	if err != nil {
@@ -642,6 +1010,9 @@ func commandStopReplication(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	addTabletTagsOptions.addFlags(AddTabletTags, "tag")
+	Root.AddCommand(AddTabletTags)
+
 	ChangeTabletTags.Flags().BoolVarP(&changeTabletTagsOptions.Replace, "replace", "r", false, "Replace all tablet tags with the tags provided. By default tags are merged/updated.")
 	Root.AddCommand(ChangeTabletTags)
 
@@ -652,9 +1023,29 @@ func init() {
 	Root.AddCommand(DeleteTablets)
 
 	Root.AddCommand(ExecuteHook)
+	GetFullStatus.Flags().StringVarP(&getFullStatusOptions.Keyspace, "keyspace", "k", "", "Keyspace of the tablets to aggregate the full status of.")
+	GetFullStatus.Flags().StringVarP(&getFullStatusOptions.Shard, "shard", "s", "", "Shard of the tablets to aggregate the full status of.")
+	GetFullStatus.Flags().StringSliceVarP(&getFullStatusOptions.Cells, "cell", "c", nil, "List of cells of the tablets to aggregate the full status of.")
+	GetFullStatus.Flags().IntVar(&getFullStatusOptions.Concurrency, "concurrency", 10, "Maximum number of tablets to fetch the full status of concurrently.")
+	GetFullStatus.Flags().DurationVar(&getFullStatusOptions.MaxReplicationLag, "max-replication-lag", 30*time.Second, "Replication lag above which a replica is reported as lagging, on top of its configured SQL delay. Zero disables the check.")
+	GetFullStatus.Flags().IntVar(&getFullStatusOptions.MinScore, "min-score", 0, "Fail if the health score of any shard is below this value.")
+	GetFullStatus.Flags().StringVar(&getFullStatusOptions.Format, "format", "text", "Output format to use for the aggregated status; valid choices are (text, json).")
 	Root.AddCommand(GetFullStatus)
+
+	GetMysqlErrorLogEvents.Flags().StringVar(&getMysqlErrorLogEventsOptions.Category, "category", "", "Only output the events of this category (crash_recovery, deadlock_spike, disk_error or semi_sync_timeout).")
+	GetMysqlErrorLogEvents.Flags().Uint32Var(&getMysqlErrorLogEventsOptions.Limit, "limit", 0, "Maximum number of events to output, the most recent first. 0 outputs all the events kept by the tablet.")
+	Root.AddCommand(GetMysqlErrorLogEvents)
+
 	Root.AddCommand(GetTablet)
 
+	GetTabletQueryPlans.Flags().StringVar(&getTabletQueryPlansOptions.Filter, "filter", "", "Only output the plans whose query contains this substring.")
+	GetTabletQueryPlans.Flags().Uint32Var(&getTabletQueryPlansOptions.Limit, "limit", 0, "Maximum number of plans to output, the most hit first. 0 outputs all the plans.")
+	GetTabletQueryPlans.Flags().StringArrayVar(&getTabletQueryPlansOptions.Evict, "evict", nil, "Query whose plans are evicted from the cache and unpinned. May be repeated.")
+	GetTabletQueryPlans.Flags().BoolVar(&getTabletQueryPlansOptions.EvictAll, "evict-all", false, "Evict all the plans which are not pinned from the cache.")
+	GetTabletQueryPlans.Flags().StringArrayVar(&getTabletQueryPlansOptions.Pin, "pin", nil, "Query whose cached plans are pinned, so that they are never evicted. May be repeated.")
+	GetTabletQueryPlans.Flags().StringArrayVar(&getTabletQueryPlansOptions.Unpin, "unpin", nil, "Query whose plans are unpinned. May be repeated.")
+	Root.AddCommand(GetTabletQueryPlans)
+
 	GetTablets.Flags().StringSliceVarP(&getTabletsOptions.TabletAliasStrings, "tablet-alias", "t", nil, "List of tablet aliases to filter by.")
 	GetTablets.Flags().StringSliceVarP(&getTabletsOptions.Cells, "cell", "c", nil, "List of cells to filter tablets by.")
 	GetTablets.Flags().Var((*topoproto.TabletTypeFlag)(&getTabletsOptions.TabletType), "tablet-type", "Tablet type to filter by (e.g. primary or replica).")
@@ -671,6 +1062,9 @@ func init() {
 	RefreshStateByShard.Flags().StringSliceVarP(&refreshStateByShardOptions.Cells, "cells", "c", nil, "If specified, only call RefreshState on tablets in the specified cells. If empty, all cells are considered.")
 	Root.AddCommand(RefreshStateByShard)
 
+	removeTabletTagsOptions.addFlags(RemoveTabletTags, "untag")
+	Root.AddCommand(RemoveTabletTags)
+
 	Root.AddCommand(RunHealthCheck)
 	Root.AddCommand(SetWritable)
 	Root.AddCommand(SleepTablet)
@@ -18,10 +18,12 @@ package command
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
@@ -49,6 +51,19 @@ var (
 		RunE:                  commandSetVtorcEmergencyReparent,
 	}
 
+	// WatchClusterEvents makes a WatchClusterEvents gRPC call to a vtctld.
+	WatchClusterEvents = &cobra.Command{
+		Use:   "WatchClusterEvents [--resume-token <token>]",
+		Short: "Streams the changes made to the cluster, one JSON encoded event per line.",
+		Long: `Streams the changes made to the cluster, one JSON encoded event per line.
+
+The events are tablets added and removed, primary changes, shards created and deleted, vschema changes and workflow state changes.
+The stream can be resumed after the last event received by passing its token with --resume-token.`,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.NoArgs,
+		RunE:                  commandWatchClusterEvents,
+	}
+
 	// WriteTopologyPath writes the contents of a local file to a path
 	// in the topology server.
 	WriteTopologyPath = &cobra.Command{
@@ -138,6 +153,37 @@ func commandSetVtorcEmergencyReparent(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var watchClusterEventsOptions = struct {
+	ResumeToken string
+}{}
+
+func commandWatchClusterEvents(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	stream, err := client.WatchClusterEvents(commandCtx, &vtctldatapb.WatchClusterEventsRequest{
+		ResumeToken: watchClusterEventsOptions.ResumeToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch err {
+		case nil:
+			data, err := cli.MarshalJSON(resp.Event, protojson.MarshalOptions{})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", data)
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
 var writeTopologyPathOptions = struct {
 	// The cell to use for the copy. Defaults to the global cell.
 	cell string
@@ -177,6 +223,9 @@ func init() {
 	SetVtorcEmergencyReparent.Flags().BoolVarP(&setVtorcEmergencyReparentOptions.Disable, "disable", "d", false, "Disable the use of EmergencyReparentShard in recoveries.")
 	SetVtorcEmergencyReparent.Flags().BoolVarP(&setVtorcEmergencyReparentOptions.Enable, "enable", "e", false, "Enable the use of EmergencyReparentShard in recoveries.")
 
+	WatchClusterEvents.Flags().StringVar(&watchClusterEventsOptions.ResumeToken, "resume-token", "", "Token of the last event received, to stream the events which followed it.")
+	Root.AddCommand(WatchClusterEvents)
+
 	WriteTopologyPath.Flags().StringVar(&writeTopologyPathOptions.cell, "cell", topo.GlobalCell, "Topology server cell to copy the file to.")
 	Root.AddCommand(WriteTopologyPath)
 }
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const (
+	LagTrendIncreasing = "increasing"
+	LagTrendDecreasing = "decreasing"
+	LagTrendSteady     = "steady"
+	LagTrendUnknown    = "unknown"
+
+	// lagTrendThreshold is how much the replication lag of a stream has to change
+	// between two samples for it to be considered as increasing or decreasing.
+	lagTrendThreshold = time.Second
+)
+
+// WorkflowProgress is the copy throughput and ETA of a workflow, as computed from two
+// samples of its state.
+type WorkflowProgress struct {
+	Workflow       string  `json:"workflow"`
+	RowsPerSecond  float64 `json:"rows_per_second"`
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	RowsRemaining  int64   `json:"rows_remaining"`
+	// ETA is the estimated time left until the copy phase completes. It is empty
+	// when it cannot be estimated, e.g. when no rows were copied between the samples.
+	ETA     string            `json:"eta,omitempty"`
+	Streams []*StreamProgress `json:"streams"`
+}
+
+// StreamProgress is the copy throughput and replication lag trend of a single stream.
+type StreamProgress struct {
+	Shard                 string  `json:"shard"`
+	ID                    int64   `json:"id"`
+	State                 string  `json:"state"`
+	RowsPerSecond         float64 `json:"rows_per_second"`
+	ReplicationLagSeconds int64   `json:"replication_lag_seconds"`
+	ReplicationLagTrend   string  `json:"replication_lag_trend"`
+}
+
+// WorkflowSample is the state of a workflow at a given point in time. Status is nil
+// when the workflow's copy status could not be read.
+type WorkflowSample struct {
+	Time     time.Time
+	Workflow *vtctldatapb.Workflow
+	Status   *vtctldatapb.WorkflowStatusResponse
+}
+
+// AddProgressSampleIntervalFlag adds the flag used to request the progress of the
+// workflow(s) shown by the given command.
+func AddProgressSampleIntervalFlag(cmd *cobra.Command, interval *time.Duration) {
+	cmd.Flags().DurationVar(interval, "progress-sample-interval", 0, "If set, sample the workflow twice this far apart and include the copy throughput (rows/s, bytes/s), estimated time to copy completion, and replication lag trend of each stream in the output.")
+}
+
+// ShowWorkflowsWithProgress gets the workflows matching the given request and, if the
+// sample interval is not zero, computes their progress from two samples taken that far
+// apart. The result is written to stdout as JSON.
+func ShowWorkflowsWithProgress(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest, interval time.Duration) error {
+	first, err := sampleWorkflows(ctx, req, interval > 0)
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		data, err := cli.MarshalJSONPretty(first.resp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+	}
+	second, err := sampleWorkflows(ctx, req, true)
+	if err != nil {
+		return err
+	}
+
+	progress := make([]*WorkflowProgress, 0, len(second.samples))
+	for _, wf := range second.resp.Workflows {
+		before, ok := first.samples[wf.Name]
+		if !ok {
+			// The workflow was created in between the two samples.
+			continue
+		}
+		progress = append(progress, ComputeWorkflowProgress(before, second.samples[wf.Name]))
+	}
+
+	workflowsData, err := cli.MarshalJSONPretty(second.resp)
+	if err != nil {
+		return err
+	}
+	data, err := cli.MarshalJSON(struct {
+		Workflows json.RawMessage     `json:"workflows"`
+		Progress  []*WorkflowProgress `json:"progress"`
+	}{
+		Workflows: workflowsData,
+		Progress:  progress,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+type workflowsSample struct {
+	resp    *vtctldatapb.GetWorkflowsResponse
+	samples map[string]*WorkflowSample
+}
+
+func sampleWorkflows(ctx context.Context, req *vtctldatapb.GetWorkflowsRequest, withStatus bool) (*workflowsSample, error) {
+	resp, err := GetClient().GetWorkflows(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	sample := &workflowsSample{
+		resp:    resp,
+		samples: make(map[string]*WorkflowSample, len(resp.Workflows)),
+	}
+	if !withStatus {
+		return sample, nil
+	}
+	for _, wf := range resp.Workflows {
+		ws := &WorkflowSample{Time: now, Workflow: wf}
+		// The copy status is only available for some workflow types, and is only
+		// needed for the bytes/s and ETA, so we do without it when it cannot be read.
+		status, err := GetClient().WorkflowStatus(ctx, &vtctldatapb.WorkflowStatusRequest{
+			Keyspace: wf.GetTarget().GetKeyspace(),
+			Workflow: wf.Name,
+			Shards:   req.Shards,
+		})
+		if err == nil {
+			ws.Status = status
+		}
+		sample.samples[wf.Name] = ws
+	}
+	return sample, nil
+}
+
+// ComputeWorkflowProgress computes the progress of a workflow between two samples of it.
+func ComputeWorkflowProgress(before, after *WorkflowSample) *WorkflowProgress {
+	progress := &WorkflowProgress{
+		Workflow: after.Workflow.Name,
+		Streams:  []*StreamProgress{},
+	}
+	elapsed := after.Time.Sub(before.Time).Seconds()
+	if elapsed <= 0 {
+		return progress
+	}
+
+	type streamKey struct {
+		shard string
+		id    int64
+	}
+	beforeStreams := make(map[streamKey]*vtctldatapb.Workflow_Stream)
+	for _, ss := range before.Workflow.ShardStreams {
+		for _, stream := range ss.Streams {
+			beforeStreams[streamKey{stream.Shard, stream.Id}] = stream
+		}
+	}
+	var rowsCopied int64
+	for _, ss := range after.Workflow.ShardStreams {
+		for _, stream := range ss.Streams {
+			sp := &StreamProgress{
+				Shard:               stream.Shard,
+				ID:                  stream.Id,
+				State:               stream.State,
+				ReplicationLagTrend: LagTrendUnknown,
+			}
+			afterLag, afterLagOK := streamLag(stream, after.Time)
+			if afterLagOK {
+				sp.ReplicationLagSeconds = int64(afterLag.Seconds())
+			}
+			if prev, ok := beforeStreams[streamKey{stream.Shard, stream.Id}]; ok {
+				if delta := stream.RowsCopied - prev.RowsCopied; delta > 0 {
+					rowsCopied += delta
+					sp.RowsPerSecond = roundRate(float64(delta) / elapsed)
+				}
+				if beforeLag, ok := streamLag(prev, before.Time); ok && afterLagOK {
+					sp.ReplicationLagTrend = lagTrend(beforeLag, afterLag)
+				}
+			}
+			progress.Streams = append(progress.Streams, sp)
+		}
+	}
+	sort.Slice(progress.Streams, func(i, j int) bool {
+		if progress.Streams[i].Shard != progress.Streams[j].Shard {
+			return progress.Streams[i].Shard < progress.Streams[j].Shard
+		}
+		return progress.Streams[i].ID < progress.Streams[j].ID
+	})
+	progress.RowsPerSecond = roundRate(float64(rowsCopied) / elapsed)
+
+	if before.Status == nil || after.Status == nil {
+		return progress
+	}
+	var bytesCopied int64
+	for table, state := range after.Status.TableCopyState {
+		if state.Phase != vtctldatapb.TableCopyPhase_COMPLETE && state.RowsTotal > state.RowsCopied {
+			progress.RowsRemaining += state.RowsTotal - state.RowsCopied
+		}
+		if prev, ok := before.Status.TableCopyState[table]; ok && state.BytesCopied > prev.BytesCopied {
+			bytesCopied += state.BytesCopied - prev.BytesCopied
+		}
+	}
+	progress.BytesPerSecond = roundRate(float64(bytesCopied) / elapsed)
+	switch {
+	case len(after.Status.TableCopyState) == 0:
+		// The workflow is not copying any tables.
+	case progress.RowsRemaining == 0:
+		progress.ETA = "0s"
+	case progress.RowsPerSecond > 0:
+		eta := time.Duration(float64(progress.RowsRemaining) / progress.RowsPerSecond * float64(time.Second))
+		progress.ETA = eta.Round(time.Second).String()
+	}
+	return progress
+}
+
+// streamLag returns the replication lag of the given stream at the given time.
+func streamLag(stream *vtctldatapb.Workflow_Stream, at time.Time) (time.Duration, bool) {
+	if stream.TransactionTimestamp.GetSeconds() == 0 {
+		return 0, false
+	}
+	lag := at.Sub(protoutil.TimeFromProto(stream.TransactionTimestamp))
+	if lag < 0 {
+		lag = 0
+	}
+	return lag, true
+}
+
+func lagTrend(before, after time.Duration) string {
+	switch {
+	case after-before > lagTrendThreshold:
+		return LagTrendIncreasing
+	case before-after > lagTrendThreshold:
+		return LagTrendDecreasing
+	default:
+		return LagTrendSteady
+	}
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*100) / 100
+}
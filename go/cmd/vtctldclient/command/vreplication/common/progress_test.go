@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vttimepb "vitess.io/vitess/go/vt/proto/vttime"
+)
+
+func TestComputeWorkflowProgress(t *testing.T) {
+	now := time.Now()
+	newWorkflow := func(rowsLow, rowsHigh int64, tsLow, tsHigh *vttimepb.Time) *vtctldatapb.Workflow {
+		return &vtctldatapb.Workflow{
+			Name: "wf1",
+			ShardStreams: map[string]*vtctldatapb.Workflow_ShardStream{
+				"-80/zone1-100": {
+					Streams: []*vtctldatapb.Workflow_Stream{{
+						Id:                   1,
+						Shard:                "-80",
+						State:                "Copying",
+						RowsCopied:           rowsLow,
+						TransactionTimestamp: tsLow,
+					}},
+				},
+				"80-/zone1-200": {
+					Streams: []*vtctldatapb.Workflow_Stream{{
+						Id:                   1,
+						Shard:                "80-",
+						State:                "Running",
+						RowsCopied:           rowsHigh,
+						TransactionTimestamp: tsHigh,
+					}},
+				},
+			},
+		}
+	}
+	newStatus := func(rowsCopied, bytesCopied int64) *vtctldatapb.WorkflowStatusResponse {
+		return &vtctldatapb.WorkflowStatusResponse{
+			TableCopyState: map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState{
+				"t1": {RowsCopied: rowsCopied, RowsTotal: 10000, BytesCopied: bytesCopied, Phase: vtctldatapb.TableCopyPhase_IN_PROGRESS},
+				"t2": {RowsCopied: 500, RowsTotal: 500, BytesCopied: 4096, Phase: vtctldatapb.TableCopyPhase_COMPLETE},
+			},
+		}
+	}
+	later := now.Add(10 * time.Second)
+	before := &WorkflowSample{
+		Time: now,
+		// The second stream has no transaction timestamp in the first sample.
+		Workflow: newWorkflow(1000, 500, protoutil.TimeToProto(now.Add(-2*time.Second)), nil),
+		Status:   newStatus(1000, 100000),
+	}
+	after := &WorkflowSample{
+		Time:     later,
+		Workflow: newWorkflow(3000, 500, protoutil.TimeToProto(later.Add(-30*time.Second)), protoutil.TimeToProto(later.Add(-time.Second))),
+		Status:   newStatus(3000, 300000),
+	}
+
+	progress := ComputeWorkflowProgress(before, after)
+	assert.Equal(t, &WorkflowProgress{
+		Workflow:       "wf1",
+		RowsPerSecond:  200,
+		BytesPerSecond: 20000,
+		RowsRemaining:  7000,
+		ETA:            "35s",
+		Streams: []*StreamProgress{
+			{Shard: "-80", ID: 1, State: "Copying", RowsPerSecond: 200, ReplicationLagSeconds: 30, ReplicationLagTrend: LagTrendIncreasing},
+			{Shard: "80-", ID: 1, State: "Running", ReplicationLagSeconds: 1, ReplicationLagTrend: LagTrendUnknown},
+		},
+	}, progress)
+
+	// Without the copy status we cannot compute the bytes/s or the ETA.
+	after.Status = nil
+	progress = ComputeWorkflowProgress(before, after)
+	assert.EqualValues(t, 200, progress.RowsPerSecond)
+	assert.Zero(t, progress.BytesPerSecond)
+	assert.Empty(t, progress.ETA)
+
+	assert.Equal(t, LagTrendDecreasing, lagTrend(10*time.Second, 2*time.Second))
+	assert.Equal(t, LagTrendSteady, lagTrend(2*time.Second, 2500*time.Millisecond))
+}
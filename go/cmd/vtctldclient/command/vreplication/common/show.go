@@ -18,6 +18,7 @@ package common
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -27,8 +28,9 @@ import (
 )
 
 var ShowOptions = struct {
-	IncludeLogs bool
-	Shards      []string
+	IncludeLogs            bool
+	Shards                 []string
+	ProgressSampleInterval time.Duration
 }{}
 
 func GetShowCommand(opts *SubCommandsOpts) *cobra.Command {
@@ -42,6 +44,7 @@ func GetShowCommand(opts *SubCommandsOpts) *cobra.Command {
 		RunE:                  commandShow,
 	}
 	cmd.Flags().BoolVar(&ShowOptions.IncludeLogs, "include-logs", true, "Include recent logs for the workflow.")
+	AddProgressSampleIntervalFlag(cmd, &ShowOptions.ProgressSampleInterval)
 	return cmd
 }
 
@@ -54,17 +57,5 @@ func commandShow(cmd *cobra.Command, args []string) error {
 		IncludeLogs: ShowOptions.IncludeLogs,
 		Shards:      ShowOptions.Shards,
 	}
-	resp, err := GetClient().GetWorkflows(GetCommandCtx(), req)
-	if err != nil {
-		return err
-	}
-
-	data, err := cli.MarshalJSONPretty(resp)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s\n", data)
-
-	return nil
+	return ShowWorkflowsWithProgress(GetCommandCtx(), req, ShowOptions.ProgressSampleInterval)
 }
@@ -103,6 +103,9 @@ func commandSwitchTraffic(cmd *cobra.Command, args []string) error {
 		EnableReverseReplication:  SwitchTrafficOptions.EnableReverseReplication,
 		InitializeTargetSequences: SwitchTrafficOptions.InitializeTargetSequences,
 		Direction:                 int32(SwitchTrafficOptions.Direction),
+		RampPercentages:           SwitchTrafficOptions.RampPercentages,
+		RampStepDuration:          protoutil.DurationToProto(SwitchTrafficOptions.RampStepDuration),
+		RampMaxErrorRate:          SwitchTrafficOptions.RampMaxErrorRate,
 	}
 	resp, err := GetClient().WorkflowSwitchTraffic(GetCommandCtx(), req)
 	if err != nil {
@@ -23,11 +23,14 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/movetables"
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqlescape"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -84,6 +87,8 @@ var (
 		IgnoreNulls                  bool
 		ContinueAfterCopyWithOwner   bool
 		ParamsFile                   string
+		MaxReplicationLag            time.Duration
+		MaxRowsPerSecond             int64
 	}{}
 
 	externalizeOptions = struct {
@@ -99,6 +104,17 @@ var (
 		Keyspace string
 	}{}
 
+	cancelOptions = struct {
+		Keyspace        string
+		DeleteData      bool
+		DeleteBatchSize int64
+	}{}
+
+	updateThrottleOptions = struct {
+		MaxReplicationLag time.Duration
+		MaxRowsPerSecond  int64
+	}{}
+
 	parseAndValidateCreate = func(cmd *cobra.Command, args []string) error {
 		if createOptions.ParamsFile != "" {
 			if createOptions.TableOwner != "" {
@@ -281,10 +297,10 @@ var (
 		return nil
 	}
 
-	// cancel makes a WorkflowDelete call to a vtctld.
+	// cancel makes a LookupVindexCancel call to a vtctld.
 	cancel = &cobra.Command{
 		Use:                   "cancel",
-		Short:                 "Cancel the VReplication workflow that backfills the Lookup Vindex.",
+		Short:                 "Cancel the VReplication workflow that backfills the Lookup Vindex, leaving the Vindex and the lookup table in place unless --delete-data is specified. The Vindex must not have been externalized.",
 		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer cancel`,
 		SilenceUsage:          true,
 		DisableFlagsInUseLine: true,
@@ -342,6 +358,18 @@ var (
 		RunE:                  commandInternalize,
 	}
 
+	// progress makes a LookupVindexProgress call to a vtctld.
+	progress = &cobra.Command{
+		Use:                   "progress",
+		Short:                 "Show the progress of the backfill of the Lookup Vindex on each shard of the lookup table.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer progress`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Progress"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandProgress,
+	}
+
 	// show makes a GetWorkflows call to a vtctld.
 	show = &cobra.Command{
 		Use:                   "show",
@@ -353,22 +381,51 @@ var (
 		Args:                  cobra.NoArgs,
 		RunE:                  commandShow,
 	}
+
+	// updateThrottle makes a LookupVindexUpdateThrottle call to a vtctld.
+	updateThrottle = &cobra.Command{
+		Use:                   "update-throttle",
+		Short:                 "Change the limits of the copy phase of the backfill of the Lookup Vindex while it runs. The VReplication streams are restarted with the new limits.",
+		Example:               `vtctldclient --server localhost:15999 LookupVindex --name corder_lookup_vdx --table-keyspace customer update-throttle --max-rows-per-second 500`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"UpdateThrottle"},
+		Args:                  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("max-replication-lag") && !cmd.Flags().Changed("max-rows-per-second") {
+				return errors.New("at least one of --max-replication-lag or --max-rows-per-second must be specified")
+			}
+			return nil
+		},
+		RunE: commandUpdateThrottle,
+	}
 )
 
 func commandCancel(cmd *cobra.Command, args []string) error {
+	if cancelOptions.Keyspace == "" {
+		cancelOptions.Keyspace = baseOptions.TableKeyspace
+	}
 	cli.FinishedParsing(cmd)
 
-	req := &vtctldatapb.WorkflowDeleteRequest{
-		Keyspace: baseOptions.TableKeyspace,
-		Workflow: baseOptions.Name,
-	}
-	_, err := common.GetClient().WorkflowDelete(common.GetCommandCtx(), req)
+	resp, err := common.GetClient().LookupVindexCancel(common.GetCommandCtx(), &vtctldatapb.LookupVindexCancelRequest{
+		Keyspace: cancelOptions.Keyspace,
+		// The name of the workflow and lookup vindex.
+		Name: baseOptions.Name,
+		// Where the lookup table and VReplication workflow were created.
+		TableKeyspace:   baseOptions.TableKeyspace,
+		DeleteData:      cancelOptions.DeleteData,
+		DeleteBatchSize: cancelOptions.DeleteBatchSize,
+	})
 	if err != nil {
 		return err
 	}
 
 	output := fmt.Sprintf("LookupVindex %s left in place and the %s VReplication wokflow has been deleted",
 		baseOptions.Name, baseOptions.Name)
+	if cancelOptions.DeleteData {
+		output = fmt.Sprintf("LookupVindex %s and the %s VReplication workflow have been deleted, along with the %d rows backfilled in the lookup table",
+			baseOptions.Name, baseOptions.Name, resp.RowsDeleted)
+	}
 	fmt.Println(output)
 
 	return nil
@@ -409,6 +466,8 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		Cells:                      createOptions.Cells,
 		TabletTypes:                createOptions.TabletTypes,
 		TabletSelectionPreference:  tsp,
+		MaxReplicationLag:          protoutil.DurationToProto(createOptions.MaxReplicationLag),
+		MaxRowsPerSecond:           createOptions.MaxRowsPerSecond,
 	})
 	if err != nil {
 		return err
@@ -474,6 +533,27 @@ func commandInternalize(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandProgress(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().LookupVindexProgress(common.GetCommandCtx(), &vtctldatapb.LookupVindexProgressRequest{
+		Name:          baseOptions.Name,
+		TableKeyspace: baseOptions.TableKeyspace,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func commandShow(cmd *cobra.Command, args []string) error {
 	cli.FinishedParsing(cmd)
 
@@ -496,6 +576,34 @@ func commandShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func commandUpdateThrottle(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.LookupVindexUpdateThrottleRequest{
+		Name:          baseOptions.Name,
+		TableKeyspace: baseOptions.TableKeyspace,
+	}
+	if cmd.Flags().Changed("max-replication-lag") {
+		req.MaxReplicationLag = protoutil.DurationToProto(updateThrottleOptions.MaxReplicationLag)
+	}
+	if cmd.Flags().Changed("max-rows-per-second") {
+		req.MaxRowsPerSecond = &updateThrottleOptions.MaxRowsPerSecond
+	}
+	resp, err := common.GetClient().LookupVindexUpdateThrottle(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
 func registerCommands(root *cobra.Command) {
 	base.PersistentFlags().StringVar(&baseOptions.Name, "name", "", "The name of the Lookup Vindex to create. This will also be the name of the VReplication workflow created to backfill the Lookup Vindex. This will be used only for the workflow name if params-file is used.")
 	base.MarkPersistentFlagRequired("name")
@@ -519,12 +627,18 @@ func registerCommands(root *cobra.Command) {
 	create.Flags().StringSliceVar(&createOptions.Cells, "cells", nil, "Cells to look in for source tablets to replicate from.")
 	create.Flags().Var((*topoprotopb.TabletTypeListFlag)(&createOptions.TabletTypes), "tablet-types", "Source tablet types to replicate from.")
 	create.Flags().BoolVar(&createOptions.TabletTypesInPreferenceOrder, "tablet-types-in-preference-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
+	create.Flags().DurationVar(&createOptions.MaxReplicationLag, "max-replication-lag", 0, "Throttle the copy phase of the backfill while the replication lag on the target shards is above this value, if it is lower than the threshold of their tablet throttler. Zero means that only the tablet throttler is used.")
+	create.Flags().Int64Var(&createOptions.MaxRowsPerSecond, "max-rows-per-second", 0, "The maximum number of rows that each VReplication stream copies per second during the copy phase of the backfill. Zero means no limit.")
 	base.AddCommand(create)
 
 	// This will show the output of GetWorkflows client call
 	// for the VReplication workflow used.
 	base.AddCommand(show)
 
+	// This will show the backfill progress of each shard of
+	// the lookup table.
+	base.AddCommand(progress)
+
 	// This will also stop the VReplication workflow if the
 	// vindex has an owner as the lookup vindex will then be
 	// managed by VTGate.
@@ -539,9 +653,16 @@ func registerCommands(root *cobra.Command) {
 	base.AddCommand(complete)
 
 	// The cancel command deletes the VReplication workflow used
-	// to backfill the lookup vindex. It ends up making a
-	// WorkflowDelete VtctldServer call.
+	// to backfill the lookup vindex, along with the vindex and
+	// the backfilled lookup table rows unless asked to keep them.
+	cancel.Flags().StringVar(&cancelOptions.Keyspace, "keyspace", "", "The keyspace containing the Lookup Vindex. If no value is specified then the table-keyspace will be used.")
+	cancel.Flags().BoolVar(&cancelOptions.DeleteData, "delete-data", false, "Also delete the Lookup Vindex and the rows backfilled in the lookup table, instead of only deleting the VReplication workflow.")
+	cancel.Flags().Int64Var(&cancelOptions.DeleteBatchSize, "delete-batch-size", movetables.DefaultDeleteBatchSize, "Delete the rows backfilled in the lookup table in batches of this size.")
 	base.AddCommand(cancel)
+
+	updateThrottle.Flags().DurationVar(&updateThrottleOptions.MaxReplicationLag, "max-replication-lag", 0, "Throttle the copy phase of the backfill while the replication lag on the target shards is above this value. Zero removes the limit.")
+	updateThrottle.Flags().Int64Var(&updateThrottleOptions.MaxRowsPerSecond, "max-rows-per-second", 0, "The maximum number of rows that each VReplication stream copies per second during the copy phase of the backfill. Zero removes the limit.")
+	base.AddCommand(updateThrottle)
 }
 
 func init() {
@@ -68,6 +68,13 @@ should be copied as-is from the source keyspace. Here's an example value for tab
     "create_ddl": "create table sales_by_sku (sku varbinary(128) not null primary key, orders bigint, revenue bigint)"
   }
 ]
+The same tables can be materialized in multiple target keyspaces, for example to keep a copy of
+a lookup table in every keyspace, by passing a comma-separated list of keyspaces to
+--target-keyspace. The workflow is then created in each of them with the same name and the
+show, start, stop, cancel and update commands act on all of them when given the same list.
+Target tables which aggregate their source table with GROUP BY, like sales_by_sku above, can be
+fully re-materialized when the schema of their source table changes by using --on-ddl=REMATERIALIZE:
+the target table is then truncated and copied again from the source.
 `,
 		SilenceUsage:          true,
 		DisableFlagsInUseLine: true,
@@ -99,13 +106,18 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		Config: configOverrides,
 	}
 
+	keyspaces, err := targetKeyspaces()
+	if err != nil {
+		return err
+	}
+
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:                  common.BaseOptions.Workflow,
 		MaterializationIntent:     vtctldatapb.MaterializationIntent_CUSTOM,
-		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
 		SourceKeyspace:            createOptions.SourceKeyspace,
 		TableSettings:             createOptions.TableSettings.val,
 		StopAfterCopy:             common.CreateOptions.StopAfterCopy,
+		OnDdl:                     strings.ToUpper(common.CreateOptions.OnDDL),
 		Cell:                      strings.Join(common.CreateOptions.Cells, ","),
 		TabletTypes:               topoproto.MakeStringTypeCSV(common.CreateOptions.TabletTypes),
 		TabletSelectionPreference: tsp,
@@ -122,13 +134,22 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	req := &vtctldatapb.MaterializeCreateRequest{
-		Settings: ms,
-	}
-
-	_, err = common.GetClient().MaterializeCreate(common.GetCommandCtx(), req)
-	if err != nil {
-		return err
+	// The workflow is created in each target keyspace in turn. If that fails in
+	// one of them, it is cancelled in the ones it was already created in.
+	var created []string
+	for _, keyspace := range keyspaces {
+		settings := ms.CloneVT()
+		settings.TargetKeyspace = keyspace
+		req := &vtctldatapb.MaterializeCreateRequest{
+			Settings: settings,
+		}
+		if _, err := common.GetClient().MaterializeCreate(common.GetCommandCtx(), req); err != nil {
+			if len(created) > 0 {
+				err = cancelCreated(created, fmt.Errorf("failed to create workflow %s in target keyspace %s: %w", common.BaseOptions.Workflow, keyspace, err))
+			}
+			return err
+		}
+		created = append(created, keyspace)
 	}
 
 	if format == "json" {
@@ -142,8 +163,12 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 		jsonText, _ := cli.MarshalJSONPretty(resp)
 		fmt.Println(string(jsonText))
 	} else {
-		fmt.Printf("Materialization workflow %s successfully created in the %s keyspace. Use show to view the status.\n",
-			common.BaseOptions.Workflow, common.BaseOptions.TargetKeyspace)
+		target := fmt.Sprintf("the %s keyspace", keyspaces[0])
+		if len(keyspaces) > 1 {
+			target = fmt.Sprintf("the %s keyspaces", strings.Join(keyspaces, ", "))
+		}
+		fmt.Printf("Materialization workflow %s successfully created in %s. Use show to view the status.\n",
+			common.BaseOptions.Workflow, target)
 	}
 
 	return nil
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package materialize
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// A Materialize workflow can fan out the same tables to multiple target
+// keyspaces by passing a comma-separated list of keyspaces to --target-keyspace.
+// The workflow then has one set of streams in each target keyspace, all using
+// the same workflow name, and every Materialize command acts on all of them.
+
+// parseTargetKeyspaces returns the target keyspaces of the workflow, in the
+// order they were specified and without duplicates.
+func parseTargetKeyspaces(value string) ([]string, error) {
+	var keyspaces []string
+	for _, keyspace := range strings.Split(value, ",") {
+		keyspace = strings.TrimSpace(keyspace)
+		if keyspace == "" {
+			return nil, fmt.Errorf("invalid target-keyspace value %q: empty keyspace name", value)
+		}
+		if !slices.Contains(keyspaces, keyspace) {
+			keyspaces = append(keyspaces, keyspace)
+		}
+	}
+	return keyspaces, nil
+}
+
+func targetKeyspaces() ([]string, error) {
+	return parseTargetKeyspaces(common.BaseOptions.TargetKeyspace)
+}
+
+// forEachTargetKeyspace calls fn once for each target keyspace, with
+// common.BaseOptions.TargetKeyspace set to that keyspace. The value of
+// the flag is restored afterwards.
+func forEachTargetKeyspace(fn func() error) error {
+	keyspaces, err := targetKeyspaces()
+	if err != nil {
+		return err
+	}
+	value := common.BaseOptions.TargetKeyspace
+	defer func() {
+		common.BaseOptions.TargetKeyspace = value
+	}()
+	for _, keyspace := range keyspaces {
+		common.BaseOptions.TargetKeyspace = keyspace
+		if err := fn(); err != nil {
+			if len(keyspaces) > 1 {
+				return fmt.Errorf("failed in target keyspace %s: %w", keyspace, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// fanOut makes the command act on the workflow in every target keyspace,
+// by running it once per keyspace.
+func fanOut(cmd *cobra.Command) *cobra.Command {
+	preRun, runE := cmd.PreRun, cmd.RunE
+	cmd.PreRun = nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return forEachTargetKeyspace(func() error {
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+			return runE(cmd, args)
+		})
+	}
+	return cmd
+}
+
+// fanOutShow makes the show command report the workflow streams of every
+// target keyspace as a single list of workflows.
+func fanOutShow(cmd *cobra.Command) *cobra.Command {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		keyspaces, err := targetKeyspaces()
+		if err != nil {
+			return err
+		}
+		if len(keyspaces) == 1 {
+			return runE(cmd, args)
+		}
+		cli.FinishedParsing(cmd)
+
+		resp := &vtctldatapb.GetWorkflowsResponse{}
+		for _, keyspace := range keyspaces {
+			ksResp, err := common.GetClient().GetWorkflows(common.GetCommandCtx(), &vtctldatapb.GetWorkflowsRequest{
+				Keyspace:    keyspace,
+				Workflow:    common.BaseOptions.Workflow,
+				IncludeLogs: common.ShowOptions.IncludeLogs,
+				Shards:      common.ShowOptions.Shards,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get workflow %s in target keyspace %s: %w", common.BaseOptions.Workflow, keyspace, err)
+			}
+			resp.Workflows = append(resp.Workflows, ksResp.Workflows...)
+		}
+		data, err := cli.MarshalJSONPretty(resp)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+	return cmd
+}
+
+// cancelCreated deletes the workflow from the target keyspaces in which it was
+// already created, when its creation failed in another one, so that a failed
+// create does not leave a partial fan-out behind.
+func cancelCreated(created []string, createErr error) error {
+	errs := []error{createErr}
+	for _, keyspace := range created {
+		_, err := common.GetClient().WorkflowDelete(common.GetCommandCtx(), &vtctldatapb.WorkflowDeleteRequest{
+			Keyspace: keyspace,
+			Workflow: common.BaseOptions.Workflow,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to cancel workflow %s in target keyspace %s: %w", common.BaseOptions.Workflow, keyspace, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package materialize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+)
+
+func TestParseTargetKeyspaces(t *testing.T) {
+	keyspaces, err := parseTargetKeyspaces("customer")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customer"}, keyspaces)
+
+	keyspaces, err = parseTargetKeyspaces("customer, commerce,customer")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customer", "commerce"}, keyspaces)
+
+	_, err = parseTargetKeyspaces("customer,,commerce")
+	require.ErrorContains(t, err, "empty keyspace name")
+}
+
+func TestForEachTargetKeyspace(t *testing.T) {
+	defer func(value string) {
+		common.BaseOptions.TargetKeyspace = value
+	}(common.BaseOptions.TargetKeyspace)
+	common.BaseOptions.TargetKeyspace = "ks1,ks2"
+
+	var seen []string
+	err := forEachTargetKeyspace(func() error {
+		seen = append(seen, common.BaseOptions.TargetKeyspace)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ks1", "ks2"}, seen)
+	assert.Equal(t, "ks1,ks2", common.BaseOptions.TargetKeyspace)
+
+	err = forEachTargetKeyspace(func() error {
+		if common.BaseOptions.TargetKeyspace == "ks2" {
+			return assert.AnError
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "failed in target keyspace ks2")
+}
@@ -37,7 +37,7 @@ var (
 
 	// base is the base command for all actions related to Materialize.
 	base = &cobra.Command{
-		Use:                   "Materialize --workflow <workflow> --target-keyspace <keyspace> [command] [command-flags]",
+		Use:                   "Materialize --workflow <workflow> --target-keyspace <keyspace>[,<keyspace>...] [command] [command-flags]",
 		Short:                 "Perform commands related to materializing query results from the source keyspace into tables in the target keyspace.",
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"materialize"},
@@ -64,11 +64,14 @@ func commandUpdate(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	_, err := common.GetClient().WorkflowAddTables(common.GetCommandCtx(), &vtctldatapb.WorkflowAddTablesRequest{
-		Workflow:              common.BaseOptions.Workflow,
-		Keyspace:              common.BaseOptions.TargetKeyspace,
-		TableSettings:         tableSettings,
-		MaterializationIntent: vtctldatapb.MaterializationIntent_REFERENCE,
+	err := forEachTargetKeyspace(func() error {
+		_, err := common.GetClient().WorkflowAddTables(common.GetCommandCtx(), &vtctldatapb.WorkflowAddTablesRequest{
+			Workflow:              common.BaseOptions.Workflow,
+			Keyspace:              common.BaseOptions.TargetKeyspace,
+			TableSettings:         tableSettings,
+			MaterializationIntent: vtctldatapb.MaterializationIntent_REFERENCE,
+		})
+		return err
 	})
 	if err != nil {
 		return err
@@ -99,15 +102,15 @@ func registerCommands(root *cobra.Command) {
 	update.MarkFlagRequired("add-reference-tables")
 	base.AddCommand(update)
 
-	// Generic workflow commands.
 	opts := &common.SubCommandsOpts{
 		SubCommand: "Materialize",
 		Workflow:   "product_sales",
 	}
-	base.AddCommand(common.GetCancelCommand(opts))
-	base.AddCommand(common.GetShowCommand(opts))
-	base.AddCommand(common.GetStartCommand(opts))
-	base.AddCommand(common.GetStopCommand(opts))
+	// Generic workflow commands, which act on the workflow in every target keyspace.
+	base.AddCommand(fanOut(common.GetCancelCommand(opts)))
+	base.AddCommand(fanOutShow(common.GetShowCommand(opts)))
+	base.AddCommand(fanOut(common.GetStartCommand(opts)))
+	base.AddCommand(fanOut(common.GetStopCommand(opts)))
 }
 
 func init() {
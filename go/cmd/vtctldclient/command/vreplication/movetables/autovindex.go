@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package movetables
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// consolidationsFetchTimeout is how long we wait for a source tablet's query
+// consolidator stats.
+const consolidationsFetchTimeout = 10 * time.Second
+
+// draftAutoVindexes checks whether all of the tables being moved have a primary vindex
+// in the sharded target keyspace. When some do not, it proposes vindexes for them,
+// writes a draft vschema for review, and returns true to signal that the workflow
+// should not be created yet.
+func draftAutoVindexes(ctx context.Context) (bool, error) {
+	client := common.GetClient()
+	vsResp, err := client.GetVSchema(ctx, &vtctldatapb.GetVSchemaRequest{Keyspace: common.BaseOptions.TargetKeyspace})
+	if err != nil {
+		return false, err
+	}
+	if !vsResp.VSchema.GetSharded() {
+		return false, nil
+	}
+
+	tabletsResp, err := client.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Keyspace:   createOptions.SourceKeyspace,
+		TabletType: topodatapb.TabletType_PRIMARY,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(tabletsResp.Tablets) == 0 {
+		return false, fmt.Errorf("no primary tablets found in source keyspace %s", createOptions.SourceKeyspace)
+	}
+	primaries := tabletsResp.Tablets
+	sort.Slice(primaries, func(i, j int) bool {
+		return topoproto.TabletAliasString(primaries[i].Alias) < topoproto.TabletAliasString(primaries[j].Alias)
+	})
+
+	schemaReq := &vtctldatapb.GetSchemaRequest{
+		TabletAlias:     primaries[0].Alias,
+		ExcludeTables:   createOptions.ExcludeTables,
+		TableSchemaOnly: true,
+	}
+	if !createOptions.AllTables {
+		schemaReq.Tables = createOptions.IncludeTables
+	}
+	schemaResp, err := client.GetSchema(ctx, schemaReq)
+	if err != nil {
+		return false, err
+	}
+
+	// The query consolidator stats are only used to rank the candidate columns, so
+	// we carry on without them for any tablet that we cannot get them from.
+	queryCounts := make(map[string]int64)
+	for _, tablet := range primaries {
+		counts, err := fetchConsolidations(ctx, tablet)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: could not get the query consolidator stats of tablet %s: %v\n",
+				topoproto.TabletAliasString(tablet.Alias), err)
+			continue
+		}
+		for query, count := range counts {
+			queryCounts[query] += count
+		}
+	}
+
+	env, err := vtenv.New(vtenv.Options{
+		MySQLServerVersion: servenv.MySQLServerVersion(),
+		TruncateUILen:      servenv.TruncateUILen,
+		TruncateErrLen:     servenv.TruncateErrLen,
+	})
+	if err != nil {
+		return false, err
+	}
+	draft, suggestions, unresolved, err := workflow.SuggestVindexes(env, vsResp.VSchema,
+		schemaResp.GetSchema().GetTableDefinitions(), queryCounts)
+	if err != nil {
+		return false, err
+	}
+	if len(suggestions) == 0 && len(unresolved) == 0 {
+		// Every table already has a primary vindex.
+		return false, nil
+	}
+
+	data, err := cli.MarshalJSON(draft)
+	if err != nil {
+		return false, err
+	}
+	if createOptions.AutoVindexOutput == "" {
+		fmt.Printf("%s\n", data)
+	} else if err := os.WriteFile(createOptions.AutoVindexOutput, data, 0o644); err != nil {
+		return false, err
+	}
+
+	for _, s := range suggestions {
+		fmt.Fprintf(os.Stderr, "Suggested %s vindex on %s.%s (%s)\n", s.VindexType, s.Table, s.Column, s.Reason)
+	}
+	for _, table := range unresolved {
+		fmt.Fprintf(os.Stderr, "WARNING: no vindex could be suggested for table %s as it has no usable primary or unique key, please add one to the draft vschema manually\n", table)
+	}
+	fmt.Fprintf(os.Stderr, "The MoveTables workflow was not created. Review the draft vschema for keyspace %s, apply it using ApplyVSchema, and then run this command again.\n",
+		common.BaseOptions.TargetKeyspace)
+	return true, nil
+}
+
+// fetchConsolidations returns the query consolidator stats of the given tablet, as
+// exposed on its /debug/consolidations page.
+func fetchConsolidations(ctx context.Context, tablet *topodatapb.Tablet) (map[string]int64, error) {
+	port, ok := tablet.PortMap["vt"]
+	if !ok {
+		return nil, fmt.Errorf("tablet has no vt port")
+	}
+	ctx, cancel := context.WithTimeout(ctx, consolidationsFetchTimeout)
+	defer cancel()
+	url := fmt.Sprintf("http://%s/debug/consolidations", netutil.JoinHostPort(tablet.Hostname, port))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return workflow.ParseConsolidations(string(body)), nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"vitess.io/vitess/go/cmd/vtctldclient/cli"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
@@ -32,6 +33,7 @@ import (
 var (
 	createOptions = struct {
 		SourceKeyspace      string
+		SourceKeyspaces     []string
 		SourceShards        []string
 		ExternalClusterName string
 		AllTables           bool
@@ -43,18 +45,42 @@ var (
 		WorkflowOptions     vtctldatapb.WorkflowOptions
 		// This maps to a WorkflowOptions.ShardedAutoIncrementHandling ENUM value.
 		ShardedAutoIncrementHandlingStr string
+		AutoVindex                      bool
+		AutoVindexOutput                string
 	}{}
 
 	// create makes a MoveTablesCreate gRPC call to a vtctld.
 	create = &cobra.Command{
-		Use:                   "create",
-		Short:                 "Create and optionally run a MoveTables VReplication workflow.",
-		Example:               `vtctldclient --server localhost:15999 movetables --workflow commerce2customer --target-keyspace customer create --source-keyspace commerce --cells zone1 --cells zone2 --tablet-types replica`,
+		Use:   "create",
+		Short: "Create and optionally run a MoveTables VReplication workflow.",
+		Example: `vtctldclient --server localhost:15999 movetables --workflow commerce2customer --target-keyspace customer create --source-keyspace commerce --cells zone1 --cells zone2 --tablet-types replica
+
+# Rename the orders table to orders_v2 in the target keyspace while moving it
+vtctldclient --server localhost:15999 movetables --workflow commerce2customer --target-keyspace customer create --source-keyspace commerce --tables 'orders:orders_v2,customer'
+
+# Merge the tables of the commerce1 and commerce2 keyspaces into the customer keyspace
+vtctldclient --server localhost:15999 movetables --workflow merge --target-keyspace customer create --source-keyspaces commerce1,commerce2 --all-tables`,
 		SilenceUsage:          true,
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"Create"},
 		Args:                  cobra.NoArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Exactly one of the source keyspace or the source keyspaces flags is required.
+			if (createOptions.SourceKeyspace == "") == (len(createOptions.SourceKeyspaces) == 0) {
+				return errors.New("exactly one of source-keyspace or source-keyspaces is required")
+			}
+			if len(createOptions.SourceKeyspaces) > 0 {
+				switch {
+				case len(createOptions.SourceKeyspaces) < 2:
+					return errors.New("source-keyspaces requires at least two keyspaces, use source-keyspace to move tables from a single keyspace")
+				case len(createOptions.SourceShards) > 0:
+					return errors.New("cannot specify both --source-keyspaces and --source-shards")
+				case createOptions.WorkflowOptions.GetTenantId() != "":
+					return errors.New("cannot specify both --source-keyspaces and --tenant-id")
+				case createOptions.AutoVindex:
+					return errors.New("--auto-vindex is not supported when merging tables from multiple keyspaces")
+				}
+			}
 			// Either specific tables or the all tables flags are required.
 			if !cmd.Flags().Lookup("tables").Changed && !cmd.Flags().Lookup("all-tables").Changed {
 				return errors.New("tables or all-tables are required to specify which tables to move")
@@ -82,6 +108,21 @@ var (
 				return err
 			}
 
+			_, renamedTables, err := workflow.ParseTableMappings(createOptions.IncludeTables)
+			if err != nil {
+				return err
+			}
+			if len(renamedTables) > 0 && createOptions.AutoVindex {
+				return errors.New("--auto-vindex is not supported when renaming tables")
+			}
+
+			if createOptions.AutoVindex && createOptions.ExternalClusterName != "" {
+				return errors.New("--auto-vindex is not supported when moving tables from an external cluster")
+			}
+			if cmd.Flags().Lookup("auto-vindex-output").Changed && !createOptions.AutoVindex {
+				return errors.New("--auto-vindex-output requires --auto-vindex")
+			}
+
 			tenantId := createOptions.WorkflowOptions.GetTenantId()
 			if len(createOptions.WorkflowOptions.GetShards()) > 0 && tenantId == "" {
 				return errors.New("--shards specified, but not --tenant-id: you can only specify target shards for multi-tenant migrations")
@@ -101,7 +142,7 @@ var (
 			}
 			createOptions.WorkflowOptions.ShardedAutoIncrementHandling = vtctldatapb.ShardedAutoIncrementHandling(val)
 			if val == int32(vtctldatapb.ShardedAutoIncrementHandling_REPLACE) && createOptions.WorkflowOptions.GlobalKeyspace == "" {
-				fmt.Println("WARNING: no global-keyspace value provided so all sequence table references not fully qualified must be created manually before switching traffic")
+				fmt.Println("WARNING: no global-keyspace value provided so the sequence tables will be created in the source keyspace if it is unsharded, otherwise all sequence table references not fully qualified must be created manually before switching traffic")
 			}
 
 			return nil
@@ -124,10 +165,21 @@ func commandCreate(cmd *cobra.Command, args []string) error {
 	}
 	createOptions.WorkflowOptions.Config = configOverrides
 
+	if createOptions.AutoVindex {
+		drafted, err := draftAutoVindexes(common.GetCommandCtx())
+		if err != nil {
+			return err
+		}
+		if drafted {
+			return nil
+		}
+	}
+
 	req := &vtctldatapb.MoveTablesCreateRequest{
 		Workflow:                  common.BaseOptions.Workflow,
 		TargetKeyspace:            common.BaseOptions.TargetKeyspace,
 		SourceKeyspace:            createOptions.SourceKeyspace,
+		SourceKeyspaces:           createOptions.SourceKeyspaces,
 		SourceShards:              createOptions.SourceShards,
 		SourceTimeZone:            createOptions.SourceTimeZone,
 		Cells:                     common.CreateOptions.Cells,
@@ -24,6 +24,7 @@ import (
 
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 )
@@ -50,20 +51,22 @@ func registerCommands(root *cobra.Command) {
 
 	common.AddCommonCreateFlags(create)
 	create.PersistentFlags().StringVar(&createOptions.SourceKeyspace, "source-keyspace", "", "Keyspace where the tables are being moved from.")
-	create.MarkPersistentFlagRequired("source-keyspace")
+	create.Flags().StringSliceVar(&createOptions.SourceKeyspaces, "source-keyspaces", nil, "Keyspaces whose tables are merged into the target keyspace. A workflow named <workflow>_<source keyspace> is created for each of them, and the workflow name can be used to show, switch the traffic of, VDiff, complete, or cancel all of them at once.")
 	create.Flags().StringSliceVar(&createOptions.SourceShards, "source-shards", nil, "Source shards to copy data from when performing a partial MoveTables (experimental).")
 	create.Flags().StringVar(&createOptions.SourceTimeZone, "source-time-zone", "", "Specifying this causes any DATETIME fields to be converted from the given time zone into UTC.")
 	create.Flags().BoolVar(&createOptions.AllTables, "all-tables", false, "Copy all tables from the source.")
-	create.Flags().StringSliceVar(&createOptions.IncludeTables, "tables", nil, "Source tables to copy.")
+	create.Flags().StringSliceVar(&createOptions.IncludeTables, "tables", nil, "Source tables to copy. A table can be renamed in the target keyspace by specifying it as source_table:target_table.")
 	create.Flags().StringSliceVar(&createOptions.ExcludeTables, "exclude-tables", nil, "Source tables to exclude from copying.")
 	create.Flags().BoolVar(&createOptions.NoRoutingRules, "no-routing-rules", false, "(Advanced) Do not create routing rules while creating the workflow. See the reference documentation for limitations if you use this flag.")
 	create.Flags().BoolVar(&createOptions.AtomicCopy, "atomic-copy", false, "(EXPERIMENTAL) A single copy phase is run for all tables from the source. Use this, for example, if your source keyspace has tables which use foreign key constraints.")
 	create.Flags().StringVar(&createOptions.WorkflowOptions.TenantId, "tenant-id", "", "(EXPERIMENTAL: Multi-tenant migrations only) The tenant ID to use for the MoveTables workflow into a multi-tenant keyspace.")
 	create.Flags().StringSliceVar(&createOptions.WorkflowOptions.Shards, "shards", nil, "(EXPERIMENTAL: Multi-tenant migrations only) Specify that vreplication streams should only be created on this subset of target shards. Warning: you should first ensure that all rows on the source route to the specified subset of target shards using your VIndex of choice or you could lose data during the migration.")
-	create.Flags().StringVar(&createOptions.WorkflowOptions.GlobalKeyspace, "global-keyspace", "", "If specified, then attempt to create any global resources here such as sequence tables needed to replace auto_increment table clauses that are removed due to --sharded-auto-increment-handling=REPLACE. The value must be an unsharded keyspace that already exists.")
+	create.Flags().StringVar(&createOptions.WorkflowOptions.GlobalKeyspace, "global-keyspace", "", "If specified, then attempt to create any global resources here such as sequence tables needed to replace auto_increment table clauses that are removed due to --sharded-auto-increment-handling=REPLACE. The value must be an unsharded keyspace that already exists. If not specified and the source keyspace is unsharded, then the source keyspace is used.")
 	create.Flags().StringVar(&createOptions.ShardedAutoIncrementHandlingStr, "sharded-auto-increment-handling", vtctldatapb.ShardedAutoIncrementHandling_REMOVE.String(),
-		fmt.Sprintf("If moving the table(s) to a sharded keyspace, remove any MySQL auto_increment clauses when copying the schema to the target as sharded keyspaces should rely on either user/application generated values or Vitess sequences to ensure uniqueness. If REPLACE is specified then they are automatically replaced by Vitess sequence definitions. (options are: %s)",
+		fmt.Sprintf("If moving the table(s) to a sharded keyspace, remove any MySQL auto_increment clauses when copying the schema to the target as sharded keyspaces should rely on either user/application generated values or Vitess sequences to ensure uniqueness. If REPLACE is specified then they are automatically replaced by Vitess sequence definitions, whose backing tables are created and initialized when switching writes. (options are: %s)",
 			shardedAutoIncHandlingStrOptions))
+	create.Flags().BoolVar(&createOptions.AutoVindex, "auto-vindex", false, "If moving the table(s) to a sharded keyspace and some of them have no primary vindex in the target keyspace's vschema, propose vindexes based on the source tables' primary and unique keys and query consolidator stats, and write a draft vschema for review instead of creating the workflow.")
+	create.Flags().StringVar(&createOptions.AutoVindexOutput, "auto-vindex-output", "", "The file to write the draft vschema generated by --auto-vindex to. By default it is written to stdout.")
 	base.AddCommand(create)
 
 	opts := &common.SubCommandsOpts{
@@ -94,6 +97,9 @@ func registerCommands(root *cobra.Command) {
 	switchTrafficCommand := common.GetSwitchTrafficCommand(opts)
 	common.AddCommonSwitchTrafficFlags(switchTrafficCommand, true)
 	common.AddShardSubsetFlag(switchTrafficCommand, &common.SwitchTrafficOptions.Shards)
+	switchTrafficCommand.Flags().Float32SliceVar(&common.SwitchTrafficOptions.RampPercentages, "ramp-percentages", nil, "Percentages of the reads to serve from the target keyspace, in increasing order, before switching the reads (e.g. 1,10,50,100). The ramp is rolled back if the error rate on the target tablets exceeds --ramp-max-error-rate.")
+	switchTrafficCommand.Flags().DurationVar(&common.SwitchTrafficOptions.RampStepDuration, "ramp-step-duration", workflow.DefaultRampStepDuration, "How long to serve each percentage of --ramp-percentages before checking the error rate on the target tablets.")
+	switchTrafficCommand.Flags().Float64Var(&common.SwitchTrafficOptions.RampMaxErrorRate, "ramp-max-error-rate", 0.01, "Fraction of the queries on the target tablets that may fail during a step of --ramp-percentages before the ramp is rolled back.")
 	base.AddCommand(switchTrafficCommand)
 
 	reverseTrafficCommand := common.GetReverseTrafficCommand(opts)
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package primaryvindex
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	topoprotopb "vitess.io/vitess/go/vt/topo/topoproto"
+)
+
+var (
+	tabletTypesDefault = []topodatapb.TabletType{
+		topodatapb.TabletType_REPLICA,
+		topodatapb.TabletType_PRIMARY,
+	}
+
+	baseOptions = struct {
+		// This is where the table lives, and where the shadow table and
+		// the VReplication workflow are created.
+		Keyspace string
+		Workflow string
+	}{}
+
+	// base is the base command for all actions related to changing the
+	// Primary Vindex of a table.
+	base = &cobra.Command{
+		Use:                   "PrimaryVindex --workflow <workflow> --keyspace <keyspace> [command] [command-flags]",
+		Short:                 "Change the Primary Vindex of a table in place, by copying it into a shadow table with the new Primary Vindex using a VReplication workflow and then routing its traffic to the shadow table.",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"primaryvindex"},
+		Args:                  cobra.NoArgs,
+	}
+
+	createOptions = struct {
+		Table                        string
+		ShadowTable                  string
+		Vindex                       string
+		VindexType                   string
+		VindexParams                 map[string]string
+		Columns                      []string
+		Cells                        []string
+		TabletTypes                  []topodatapb.TabletType
+		TabletTypesInPreferenceOrder bool
+	}{}
+
+	completeOptions = struct {
+		Timeout time.Duration
+	}{}
+
+	cancelOptions = struct {
+		KeepData bool
+	}{}
+
+	// cancel makes a PrimaryVindexCancel call to a vtctld.
+	cancel = &cobra.Command{
+		Use:                   "cancel",
+		Short:                 "Cancel the VReplication workflow that fills the shadow table, and drop the shadow table unless --keep-data is specified. The traffic of the table must not have been switched.",
+		Example:               `vtctldclient --server localhost:15999 PrimaryVindex --workflow corder_by_sku --keyspace customer cancel`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Cancel"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandCancel,
+	}
+
+	// complete makes a PrimaryVindexComplete call to a vtctld.
+	complete = &cobra.Command{
+		Use:                   "complete",
+		Short:                 "Stop the writes to the table, wait for the shadow table to catch up, route the traffic of the table to the shadow table and delete the VReplication workflow. Use VDiff to verify the shadow table first.",
+		Example:               `vtctldclient --server localhost:15999 PrimaryVindex --workflow corder_by_sku --keyspace customer complete`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Complete"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandComplete,
+	}
+
+	// create makes a PrimaryVindexCreate call to a vtctld.
+	create = &cobra.Command{
+		Use:                   "create",
+		Short:                 "Create a shadow table of the table with the new Primary Vindex in the same keyspace, and fill it with a VReplication workflow which copies the table and then replicates its writes.",
+		Example:               `vtctldclient --server localhost:15999 PrimaryVindex --workflow corder_by_sku --keyspace customer create --table corder --vindex sku_hash --vindex-type unicode_loose_xxhash --columns sku`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Create"},
+		Args:                  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("tablet-types") {
+				createOptions.TabletTypes = tabletTypesDefault
+			}
+			for i, cell := range createOptions.Cells {
+				createOptions.Cells[i] = strings.TrimSpace(cell)
+			}
+			return nil
+		},
+		RunE: commandCreate,
+	}
+
+	// show makes a GetWorkflows call to a vtctld.
+	show = &cobra.Command{
+		Use:                   "show",
+		Short:                 "Show the status of the VReplication workflow that fills the shadow table.",
+		Example:               `vtctldclient --server localhost:15999 PrimaryVindex --workflow corder_by_sku --keyspace customer show`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Show"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandShow,
+	}
+)
+
+func commandCancel(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	_, err := common.GetClient().PrimaryVindexCancel(common.GetCommandCtx(), &vtctldatapb.PrimaryVindexCancelRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+		KeepData: cancelOptions.KeepData,
+	})
+	if err != nil {
+		return err
+	}
+
+	output := fmt.Sprintf("The %s VReplication workflow has been deleted and the shadow table has been dropped", baseOptions.Workflow)
+	if cancelOptions.KeepData {
+		output = fmt.Sprintf("The %s VReplication workflow has been deleted and the shadow table left in place", baseOptions.Workflow)
+	}
+	fmt.Println(output)
+
+	return nil
+}
+
+func commandComplete(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().PrimaryVindexComplete(common.GetCommandCtx(), &vtctldatapb.PrimaryVindexCompleteRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+		Timeout:  protoutil.DurationToProto(completeOptions.Timeout),
+	})
+	if err != nil {
+		return err
+	}
+
+	output := fmt.Sprintf("The traffic of the %s table is now routed to the %s table and the %s VReplication workflow has been deleted",
+		resp.Table, resp.ShadowTable, baseOptions.Workflow)
+	fmt.Println(output)
+
+	return nil
+}
+
+func commandCreate(cmd *cobra.Command, args []string) error {
+	tsp := tabletmanagerdatapb.TabletSelectionPreference_ANY
+	if createOptions.TabletTypesInPreferenceOrder {
+		tsp = tabletmanagerdatapb.TabletSelectionPreference_INORDER
+	}
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.PrimaryVindexCreateRequest{
+		Keyspace:    baseOptions.Keyspace,
+		Workflow:    baseOptions.Workflow,
+		Table:       createOptions.Table,
+		ShadowTable: createOptions.ShadowTable,
+		ColumnVindex: &vschemapb.ColumnVindex{
+			Name:    createOptions.Vindex,
+			Columns: createOptions.Columns,
+		},
+		Cells:                     createOptions.Cells,
+		TabletTypes:               createOptions.TabletTypes,
+		TabletSelectionPreference: tsp,
+	}
+	if createOptions.VindexType != "" {
+		req.Vindex = &vschemapb.Vindex{
+			Type:   createOptions.VindexType,
+			Params: createOptions.VindexParams,
+		}
+	}
+	resp, err := common.GetClient().PrimaryVindexCreate(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	output := fmt.Sprintf("The %s shadow table has been created in the %s keyspace and the %s VReplication workflow scheduled to fill it, use show to view progress and VDiff to verify it",
+		resp.ShadowTable, baseOptions.Keyspace, baseOptions.Workflow)
+	fmt.Println(output)
+
+	return nil
+}
+
+func commandShow(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.GetWorkflowsRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+	}
+	resp, err := common.GetClient().GetWorkflows(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func registerCommands(root *cobra.Command) {
+	base.PersistentFlags().StringVar(&baseOptions.Workflow, "workflow", "", "The name of the VReplication workflow which fills the shadow table.")
+	base.MarkPersistentFlagRequired("workflow")
+	base.PersistentFlags().StringVar(&baseOptions.Keyspace, "keyspace", "", "The keyspace of the table. This is also where the shadow table and the VReplication workflow are created.")
+	base.MarkPersistentFlagRequired("keyspace")
+	root.AddCommand(base)
+
+	create.Flags().StringVar(&createOptions.Table, "table", "", "The table whose Primary Vindex is changed.")
+	create.MarkFlagRequired("table")
+	create.Flags().StringVar(&createOptions.ShadowTable, "shadow-table", "", "The name of the shadow table. If not specified, then the name of the table with a _shadow suffix is used.")
+	create.Flags().StringVar(&createOptions.Vindex, "vindex", "", "The name of the new Primary Vindex.")
+	create.MarkFlagRequired("vindex")
+	create.Flags().StringSliceVar(&createOptions.Columns, "columns", nil, "The columns of the table used by the new Primary Vindex.")
+	create.MarkFlagRequired("columns")
+	create.Flags().StringVar(&createOptions.VindexType, "vindex-type", "", "The type of the new Primary Vindex, if it does not exist in the keyspace yet.")
+	create.Flags().StringToStringVar(&createOptions.VindexParams, "vindex-params", nil, "The parameters of the new Primary Vindex, if it does not exist in the keyspace yet.")
+	// VReplication specific flags.
+	create.Flags().StringSliceVar(&createOptions.Cells, "cells", nil, "Cells to look in for source tablets to replicate from.")
+	create.Flags().Var((*topoprotopb.TabletTypeListFlag)(&createOptions.TabletTypes), "tablet-types", "Source tablet types to replicate from.")
+	create.Flags().BoolVar(&createOptions.TabletTypesInPreferenceOrder, "tablet-types-in-preference-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
+	base.AddCommand(create)
+
+	// This will show the output of GetWorkflows client call
+	// for the VReplication workflow used.
+	base.AddCommand(show)
+
+	complete.Flags().DurationVar(&completeOptions.Timeout, "timeout", 30*time.Second, "How long to wait for the shadow table to catch up with the writes to the table once they have been stopped.")
+	base.AddCommand(complete)
+
+	cancel.Flags().BoolVar(&cancelOptions.KeepData, "keep-data", false, "Keep the shadow table, only deleting the VReplication workflow.")
+	base.AddCommand(cancel)
+}
+
+func init() {
+	common.RegisterCommandHandler("PrimaryVindex", registerCommands)
+}
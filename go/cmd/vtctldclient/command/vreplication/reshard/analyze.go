@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reshard
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	reshardAnalyzeOptions = struct {
+		maxShardSizeGB float64
+		maxShardQPS    float64
+		expectedGrowth float64
+		fetchTimeout   time.Duration
+	}{}
+
+	// reshardAnalyze recommends the target shards of a Reshard workflow.
+	reshardAnalyze = &cobra.Command{
+		Use:   "analyze",
+		Short: "Recommend the target shards of a Reshard workflow based on the current size and load of the shards.",
+		Long: `Analyze inspects the data size of the primary of every shard of the target keyspace, and its
+QPS as reported by the tablet in /debug/vars, projects them with the expected growth, and recommends
+splitting the shards which would then exceed --max-shard-size-gb or --max-shard-qps. Each such shard
+is split in the smallest power of two number of equal key ranges which brings it under both limits.
+
+The recommendation assumes that the keyspace IDs are evenly distributed within each shard. The output
+includes the Reshard create command which implements it.`,
+		Example:               `vtctldclient --server localhost:15999 reshard --workflow cust2cust --target-keyspace customer analyze --max-shard-size-gb 200 --expected-growth 50`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Analyze"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandReshardAnalyze,
+	}
+)
+
+// shardLoad is the current size and load of a shard, and the target shards
+// it should be split into.
+type shardLoad struct {
+	Shard        string   `json:"shard"`
+	DataBytes    uint64   `json:"data_bytes"`
+	Rows         uint64   `json:"rows"`
+	QPS          float64  `json:"qps"`
+	TargetShards []string `json:"target_shards,omitempty"`
+	// Error is set when the size or the load of the shard could not be read.
+	Error string `json:"error,omitempty"`
+
+	keyRange *topodatapb.KeyRange
+}
+
+// reshardRecommendation is the output of Reshard analyze.
+type reshardRecommendation struct {
+	Keyspace     string       `json:"keyspace"`
+	Shards       []*shardLoad `json:"shards"`
+	SourceShards []string     `json:"source_shards,omitempty"`
+	TargetShards []string     `json:"target_shards,omitempty"`
+	Command      string       `json:"command,omitempty"`
+}
+
+func commandReshardAnalyze(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if reshardAnalyzeOptions.maxShardSizeGB <= 0 || reshardAnalyzeOptions.maxShardQPS <= 0 {
+		return errors.New("--max-shard-size-gb and --max-shard-qps must be greater than zero")
+	}
+	if reshardAnalyzeOptions.expectedGrowth < 0 {
+		return errors.New("--expected-growth cannot be negative")
+	}
+	cli.FinishedParsing(cmd)
+
+	ctx := common.GetCommandCtx()
+	keyspace := common.BaseOptions.TargetKeyspace
+	resp, err := common.GetClient().FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{
+		Keyspace: keyspace,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Shards) == 0 {
+		return fmt.Errorf("no shards found in keyspace %s", keyspace)
+	}
+
+	httpClient := &http.Client{Timeout: reshardAnalyzeOptions.fetchTimeout}
+	shards := make([]*shardLoad, 0, len(resp.Shards))
+	for name, shard := range resp.Shards {
+		load := &shardLoad{Shard: name, keyRange: shard.Shard.KeyRange}
+		if err := getShardLoad(ctx, httpClient, shard.Shard, load); err != nil {
+			load.Error = err.Error()
+		}
+		shards = append(shards, load)
+	}
+	sort.Slice(shards, func(i, j int) bool {
+		return key.KeyRangeLess(shards[i].keyRange, shards[j].keyRange)
+	})
+
+	rec, err := recommendTargetShards(keyspace, shards, reshardAnalyzeOptions.maxShardSizeGB*1e9, reshardAnalyzeOptions.maxShardQPS, reshardAnalyzeOptions.expectedGrowth)
+	if err != nil {
+		return err
+	}
+	if len(rec.SourceShards) > 0 {
+		rec.Command = fmt.Sprintf("vtctldclient Reshard --workflow %s --target-keyspace %s create --source-shards '%s' --target-shards '%s'",
+			common.BaseOptions.Workflow, keyspace, strings.Join(rec.SourceShards, ","), strings.Join(rec.TargetShards, ","))
+	}
+
+	if format == "json" {
+		data, err := cli.MarshalJSONPretty(rec)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
+	fmt.Printf("Shards of keyspace %s (growth of %.0f%% expected):\n", keyspace, reshardAnalyzeOptions.expectedGrowth)
+	for _, shard := range shards {
+		if shard.Error != "" {
+			fmt.Printf("  %s: ERROR: %s\n", shard.Shard, shard.Error)
+			continue
+		}
+		fmt.Printf("  %s: %.2f GB, %d rows, %.1f QPS", shard.Shard, float64(shard.DataBytes)/1e9, shard.Rows, shard.QPS)
+		if len(shard.TargetShards) > 0 {
+			fmt.Printf(" -> split into %s", strings.Join(shard.TargetShards, ","))
+		}
+		fmt.Println()
+	}
+	if rec.Command == "" {
+		fmt.Println("\nNo resharding is needed.")
+		return nil
+	}
+	fmt.Printf("\nRecommended command:\n  %s\n", rec.Command)
+	return nil
+}
+
+// getShardLoad sets the data size and the QPS of the primary of the shard.
+func getShardLoad(ctx context.Context, httpClient *http.Client, shard *topodatapb.Shard, load *shardLoad) error {
+	if err := getShardSize(ctx, shard, load); err != nil {
+		return err
+	}
+	tablet, err := common.GetClient().GetTablet(ctx, &vtctldatapb.GetTabletRequest{TabletAlias: shard.PrimaryAlias})
+	if err != nil {
+		return err
+	}
+	load.QPS, err = fetchTabletQPS(ctx, httpClient, tablet.Tablet)
+	if err != nil {
+		return fmt.Errorf("cannot get the QPS of tablet %s: %w", topoproto.TabletAliasString(shard.PrimaryAlias), err)
+	}
+	return nil
+}
+
+// getShardSize sets the data size and the row count of the primary of the
+// shard.
+func getShardSize(ctx context.Context, shard *topodatapb.Shard, load *shardLoad) error {
+	if shard.PrimaryAlias == nil {
+		return errors.New("shard has no primary")
+	}
+	schema, err := common.GetClient().GetSchema(ctx, &vtctldatapb.GetSchemaRequest{
+		TabletAlias:    shard.PrimaryAlias,
+		TableSizesOnly: true,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get the table sizes: %w", err)
+	}
+	for _, td := range schema.Schema.GetTableDefinitions() {
+		load.DataBytes += td.DataLength
+		load.Rows += td.RowCount
+	}
+	return nil
+}
+
+// fetchTabletQPS returns the average QPS of the tablet over the period its
+// QPS rates cover.
+func fetchTabletQPS(ctx context.Context, httpClient *http.Client, tablet *topodatapb.Tablet) (float64, error) {
+	vars := struct {
+		QPS map[string][]float64 `json:"QPS"`
+	}{}
+	if err := fetchTabletVars(ctx, httpClient, tablet, &vars); err != nil {
+		return 0, err
+	}
+	return averageRate(vars.QPS["All"]), nil
+}
+
+// fetchTabletVars unmarshals the /debug/vars of the tablet into vars.
+func fetchTabletVars(ctx context.Context, httpClient *http.Client, tablet *topodatapb.Tablet, vars any) error {
+	url := "http://" + netutil.JoinHostPort(tablet.Hostname, tablet.PortMap["vt"]) + "/debug/vars"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, vars)
+}
+
+func averageRate(rates []float64) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, rate := range rates {
+		sum += rate
+	}
+	return sum / float64(len(rates))
+}
+
+// recommendTargetShards computes the target shards of every shard which would
+// exceed maxBytes or maxQPS once grown by expectedGrowth percent. The shards
+// must be sorted by key range.
+func recommendTargetShards(keyspace string, shards []*shardLoad, maxBytes, maxQPS, expectedGrowth float64) (*reshardRecommendation, error) {
+	rec := &reshardRecommendation{Keyspace: keyspace, Shards: shards}
+	growth := 1 + expectedGrowth/100
+	for _, shard := range shards {
+		if shard.Error != "" {
+			continue
+		}
+		parts := math.Max(float64(shard.DataBytes)*growth/maxBytes, shard.QPS*growth/maxQPS)
+		count := 1
+		for float64(count) < parts {
+			count *= 2
+		}
+		if count == 1 {
+			continue
+		}
+		targets, err := splitKeyRange(shard.keyRange, count)
+		if err != nil {
+			return nil, fmt.Errorf("cannot split shard %s: %w", shard.Shard, err)
+		}
+		shard.TargetShards = targets
+		rec.SourceShards = append(rec.SourceShards, shard.Shard)
+		rec.TargetShards = append(rec.TargetShards, targets...)
+	}
+	return rec, nil
+}
+
+// splitKeyRange splits the key range in count equal key ranges, count being a
+// power of two. Only the first 8 bytes of the key range bounds are used, which
+// is what the keyspace IDs of the hash based vindexes are made of.
+func splitKeyRange(kr *topodatapb.KeyRange, count int) ([]string, error) {
+	if count <= 0 || count&(count-1) != 0 {
+		return nil, fmt.Errorf("the shard count must be a power of two: %d", count)
+	}
+	var start, end uint64
+	if kr != nil {
+		if len(kr.Start) > 8 || len(kr.End) > 8 {
+			return nil, fmt.Errorf("key ranges with bounds longer than 8 bytes are not supported: %s", key.KeyRangeString(kr))
+		}
+		start = uint64FromKey(kr.Start)
+		end = uint64FromKey(kr.End)
+	}
+	// An end of 0 means 2^64, so the width wraps around accordingly.
+	width := end - start
+	step := width / uint64(count)
+	if width == 0 {
+		step = math.MaxUint64/uint64(count) + 1
+	}
+	if step == 0 || width%uint64(count) != 0 {
+		return nil, fmt.Errorf("key range %s cannot be split in %d equal parts", key.KeyRangeString(kr), count)
+	}
+
+	bounds := make([]uint64, count+1)
+	for i := range bounds {
+		bounds[i] = start + uint64(i)*step
+	}
+	// All the bounds are formatted with the same number of bytes, which is
+	// the smallest one that represents them all.
+	size := 1
+	for _, bound := range bounds {
+		for size < 8 && bound<<(8*size) != 0 {
+			size++
+		}
+	}
+	format := func(bound uint64) string {
+		// Only the start of the first shard and the end of the last one
+		// can be 0, and are then left unbounded.
+		if bound == 0 {
+			return ""
+		}
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], bound)
+		return fmt.Sprintf("%x", buf[:size])
+	}
+	shards := make([]string, count)
+	for i := range shards {
+		shards[i] = format(bounds[i]) + "-" + format(bounds[i+1])
+	}
+	return shards, nil
+}
+
+func uint64FromKey(k []byte) uint64 {
+	var buf [8]byte
+	copy(buf[:], k)
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+func registerAnalyzeCommand(root *cobra.Command) {
+	reshardAnalyze.Flags().Float64Var(&reshardAnalyzeOptions.maxShardSizeGB, "max-shard-size-gb", 256, "The maximum data size of a shard, in GB, once grown by the expected growth.")
+	reshardAnalyze.Flags().Float64Var(&reshardAnalyzeOptions.maxShardQPS, "max-shard-qps", 5000, "The maximum QPS of the primary of a shard, once grown by the expected growth.")
+	reshardAnalyze.Flags().Float64Var(&reshardAnalyzeOptions.expectedGrowth, "expected-growth", 100, "The growth of the data size and of the QPS to plan for, in percent.")
+	reshardAnalyze.Flags().DurationVar(&reshardAnalyzeOptions.fetchTimeout, "fetch-timeout", 10*time.Second, "Timeout for fetching the QPS of each primary tablet.")
+	root.AddCommand(reshardAnalyze)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reshard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/key"
+)
+
+func TestSplitKeyRange(t *testing.T) {
+	tests := []struct {
+		shard string
+		count int
+		want  []string
+	}{
+		{shard: "-", count: 2, want: []string{"-80", "80-"}},
+		{shard: "-", count: 4, want: []string{"-40", "40-80", "80-c0", "c0-"}},
+		{shard: "-80", count: 2, want: []string{"-40", "40-80"}},
+		{shard: "80-", count: 4, want: []string{"80-a0", "a0-c0", "c0-e0", "e0-"}},
+		{shard: "40-80", count: 2, want: []string{"40-60", "60-80"}},
+		{shard: "40-41", count: 2, want: []string{"4000-4080", "4080-4100"}},
+		{shard: "-", count: 512, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shard, func(t *testing.T) {
+			kr, err := key.ParseShardingSpec(tt.shard)
+			require.NoError(t, err)
+			got, err := splitKeyRange(kr[0], tt.count)
+			require.NoError(t, err)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+			require.Len(t, got, tt.count)
+			// The target shards cover the same key range as the source one.
+			targets, err := key.ParseShardingSpec(got[0])
+			require.NoError(t, err)
+			for _, shard := range got[1:] {
+				kr, err := key.ParseShardingSpec(shard)
+				require.NoError(t, err)
+				targets = append(targets, kr...)
+			}
+			for i := 1; i < len(targets); i++ {
+				assert.Equal(t, targets[i-1].End, targets[i].Start)
+			}
+		})
+	}
+
+	_, err := splitKeyRange(nil, 3)
+	assert.ErrorContains(t, err, "power of two")
+}
+
+func TestRecommendTargetShards(t *testing.T) {
+	newShard := func(shard string, bytes uint64, qps float64) *shardLoad {
+		kr, err := key.ParseShardingSpec(shard)
+		require.NoError(t, err)
+		return &shardLoad{Shard: shard, DataBytes: bytes, QPS: qps, keyRange: kr[0]}
+	}
+	shards := []*shardLoad{
+		// Fits once doubled.
+		newShard("-40", 40e9, 100),
+		// Too large once doubled: 120 GB, so split in 2.
+		newShard("40-80", 60e9, 100),
+		// Too busy once doubled: 900 QPS, so split in 4.
+		newShard("80-c0", 1e9, 450),
+		{Shard: "c0-", Error: "shard has no primary"},
+	}
+
+	rec, err := recommendTargetShards("ks", shards, 100e9, 250, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"40-80", "80-c0"}, rec.SourceShards)
+	assert.Equal(t, []string{"40-60", "60-80", "80-90", "90-a0", "a0-b0", "b0-c0"}, rec.TargetShards)
+	assert.Empty(t, shards[0].TargetShards)
+	assert.Equal(t, []string{"40-60", "60-80"}, shards[1].TargetShards)
+
+	rec, err = recommendTargetShards("ks", []*shardLoad{newShard("-", 1e9, 10)}, 100e9, 250, 100)
+	require.NoError(t, err)
+	assert.Empty(t, rec.SourceShards)
+}
@@ -0,0 +1,428 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reshard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	reshardPlanOptions = struct {
+		targetShards       []string
+		copyRowsPerSecond  float64
+		diskOverheadFactor float64
+		fetchTimeout       time.Duration
+	}{}
+
+	// reshardPlan estimates a Reshard workflow before it is created.
+	reshardPlan = &cobra.Command{
+		Use:   "plan",
+		Short: "Estimate the data movement, copy duration and disk space of a Reshard workflow into the target shards, and compute the new ring of the consistent_hash vindexes of the target keyspace.",
+		Long: `Plan estimates, before the workflow is created, what resharding the target keyspace into --target-shards
+involves. The source shards are the serving shards of the keyspace which overlap the target shards.
+
+For every target shard, it estimates the data size and the rows copied from each source shard, from the
+table statistics of the primaries of the source shards and assuming that the keyspace IDs are evenly
+distributed within each shard. The copy duration of a target shard is that of its slowest stream, the
+streams from the source shards running in parallel, at --copy-rows-per-second rows per second per stream,
+or by default at the rate measured from the copy phase of the VReplication streams of the tablets of the
+keyspace. The disk space required on a target shard is its data size multiplied by --disk-overhead-factor,
+which accounts for the indexes, the binary logs and the fragmentation.
+
+Plan also computes, for every consistent_hash vindex of the target keyspace, the nodes of its ring once
+the keyspace is resharded, and the fraction of the ids which then move from a node to another. The nodes
+of the ring are kept with their key range, which must fit in a single target shard, and the target shards
+which contain no node are added to the ring as new nodes, so that only the ids of the new nodes move.
+The Reshard workflow copies the rows by their current keyspace id and does not move these ids: the new
+ring is applied by a MoveTables workflow into a keyspace whose VSchema has the planned nodes param.`,
+		Example:               `vtctldclient --server localhost:15999 reshard --workflow cust2cust --target-keyspace customer plan --target-shards '-40,40-80,80-c0,c0-e0,e0-'`,
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Plan"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandReshardPlan,
+	}
+)
+
+// targetShardEstimate is the data a target shard of a Reshard workflow
+// copies from the source shards.
+type targetShardEstimate struct {
+	Shard        string   `json:"shard"`
+	DataBytes    uint64   `json:"data_bytes"`
+	Rows         uint64   `json:"rows"`
+	SourceShards []string `json:"source_shards"`
+	// CopySeconds is the duration of the copy phase of the shard, unset if
+	// the copy rate is unknown.
+	CopySeconds       float64 `json:"copy_seconds,omitempty"`
+	RequiredDiskBytes uint64  `json:"required_disk_bytes"`
+
+	// maxStreamRows is the number of rows of the largest stream.
+	maxStreamRows float64
+}
+
+// reshardEstimate is the output of Reshard plan.
+type reshardEstimate struct {
+	Keyspace     string                 `json:"keyspace"`
+	SourceShards []*shardLoad           `json:"source_shards"`
+	TargetShards []*targetShardEstimate `json:"target_shards"`
+	DataBytes    uint64                 `json:"data_bytes"`
+	Rows         uint64                 `json:"rows"`
+	// CopyRowsPerSecond is the rate at which each stream copies rows, 0 if
+	// unknown.
+	CopyRowsPerSecond float64 `json:"copy_rows_per_second,omitempty"`
+	// CopySeconds is the duration of the copy phase of the workflow, that of
+	// its slowest target shard.
+	CopySeconds            float64                        `json:"copy_seconds,omitempty"`
+	ConsistentHashVindexes []*vindexes.ConsistentHashPlan `json:"consistent_hash_vindexes,omitempty"`
+}
+
+func commandReshardPlan(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	targets, err := parseTargetKeyRanges(reshardPlanOptions.targetShards)
+	if err != nil {
+		return err
+	}
+	if reshardPlanOptions.copyRowsPerSecond < 0 {
+		return errors.New("--copy-rows-per-second cannot be negative")
+	}
+	if reshardPlanOptions.diskOverheadFactor < 1 {
+		return errors.New("--disk-overhead-factor must be at least 1")
+	}
+	cli.FinishedParsing(cmd)
+
+	ctx := common.GetCommandCtx()
+	keyspace := common.BaseOptions.TargetKeyspace
+	shardsResp, err := common.GetClient().FindAllShardsInKeyspace(ctx, &vtctldatapb.FindAllShardsInKeyspaceRequest{
+		Keyspace: keyspace,
+	})
+	if err != nil {
+		return err
+	}
+	sources := sourceShards(shardsResp.Shards, targets)
+	if len(sources) == 0 {
+		return fmt.Errorf("no serving shard of keyspace %s overlaps the target shards", keyspace)
+	}
+	for _, source := range sources {
+		if err := getShardSize(ctx, shardsResp.Shards[source.Shard].Shard, source); err != nil {
+			return fmt.Errorf("cannot get the size of shard %s: %w", source.Shard, err)
+		}
+	}
+
+	copyRowsPerSecond := reshardPlanOptions.copyRowsPerSecond
+	if copyRowsPerSecond == 0 {
+		copyRowsPerSecond, err = measureCopyRowsPerSecond(ctx, keyspace)
+		if err != nil {
+			return err
+		}
+	}
+	estimate, err := estimateReshard(keyspace, sources, targets, copyRowsPerSecond, reshardPlanOptions.diskOverheadFactor)
+	if err != nil {
+		return err
+	}
+
+	vschemaResp, err := common.GetClient().GetVSchema(ctx, &vtctldatapb.GetVSchemaRequest{
+		Keyspace: keyspace,
+	})
+	if err != nil {
+		return err
+	}
+	estimate.ConsistentHashVindexes, err = planConsistentHashVindexes(vschemaResp.VSchema, targets)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		data, err := cli.MarshalJSONPretty(estimate)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", data)
+		return nil
+	}
+
+	fmt.Printf("Source shards of keyspace %s:\n", keyspace)
+	for _, source := range estimate.SourceShards {
+		fmt.Printf("  %s: %.2f GB, %d rows\n", source.Shard, float64(source.DataBytes)/1e9, source.Rows)
+	}
+	fmt.Println("Target shards:")
+	for _, target := range estimate.TargetShards {
+		fmt.Printf("  %s: %.2f GB, %d rows from %s, %.2f GB of disk required", target.Shard, float64(target.DataBytes)/1e9, target.Rows,
+			strings.Join(target.SourceShards, ","), float64(target.RequiredDiskBytes)/1e9)
+		if target.CopySeconds > 0 {
+			fmt.Printf(", copied in %v", secondsDuration(target.CopySeconds))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Total: %.2f GB, %d rows copied", float64(estimate.DataBytes)/1e9, estimate.Rows)
+	if estimate.CopyRowsPerSecond > 0 {
+		fmt.Printf(" in %v at %.1f rows/s per stream\n", secondsDuration(estimate.CopySeconds), estimate.CopyRowsPerSecond)
+	} else {
+		fmt.Println("; the copy duration is unknown as no VReplication copy was measured on the tablets of the keyspace, use --copy-rows-per-second")
+	}
+
+	for _, plan := range estimate.ConsistentHashVindexes {
+		fmt.Printf("Vindex %s: %.2f%% of the ids move\n", plan.Vindex, plan.MovedFraction*100)
+		for _, move := range plan.Moves {
+			fmt.Printf("  %s (%s) -> %s (%s): %.2f%%\n", move.FromNode, move.FromKeyRange, move.ToNode, move.ToKeyRange, move.Fraction*100)
+		}
+		fmt.Printf("  New nodes: %s\n", plan.Nodes)
+	}
+	return nil
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds)) * time.Second
+}
+
+// sourceShards returns the serving shards which overlap the target key
+// ranges, other than the target shards themselves, sorted by key range.
+func sourceShards(shards map[string]*vtctldatapb.Shard, targets []*topodatapb.KeyRange) []*shardLoad {
+	var sources []*shardLoad
+	for name, shard := range shards {
+		if !shard.Shard.IsPrimaryServing {
+			continue
+		}
+		overlaps := false
+		for _, target := range targets {
+			if key.KeyRangeEqual(shard.Shard.KeyRange, target) {
+				overlaps = false
+				break
+			}
+			overlaps = overlaps || key.KeyRangeIntersect(shard.Shard.KeyRange, target)
+		}
+		if overlaps {
+			sources = append(sources, &shardLoad{Shard: name, keyRange: shard.Shard.KeyRange})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return key.KeyRangeLess(sources[i].keyRange, sources[j].keyRange)
+	})
+	return sources
+}
+
+// estimateReshard estimates the data copied by every target shard from the
+// source shards, assuming that the keyspace IDs are evenly distributed within
+// each source shard, and how long it takes at copyRowsPerSecond rows per
+// second per stream. Every source shard must be covered by the targets.
+func estimateReshard(keyspace string, sources []*shardLoad, targets []*topodatapb.KeyRange, copyRowsPerSecond, diskOverheadFactor float64) (*reshardEstimate, error) {
+	estimate := &reshardEstimate{
+		Keyspace:          keyspace,
+		SourceShards:      sources,
+		CopyRowsPerSecond: copyRowsPerSecond,
+	}
+	covered := make([]float64, len(sources))
+	for _, target := range targets {
+		shard := key.KeyRangeString(target)
+		te := &targetShardEstimate{Shard: shard}
+		var bytes, rows, targetCovered float64
+		for i, source := range sources {
+			fraction := overlapFraction(source.keyRange, target)
+			if fraction == 0 {
+				continue
+			}
+			covered[i] += fraction
+			targetCovered += fraction * keyRangeWidth(source.keyRange)
+			bytes += fraction * float64(source.DataBytes)
+			streamRows := fraction * float64(source.Rows)
+			rows += streamRows
+			te.maxStreamRows = max(te.maxStreamRows, streamRows)
+			te.SourceShards = append(te.SourceShards, source.Shard)
+		}
+		if math.Abs(targetCovered-keyRangeWidth(target)) > keyRangeWidth(target)*1e-9 {
+			return nil, fmt.Errorf("target shard %s is not covered by the source shards", shard)
+		}
+		te.DataBytes = uint64(math.Round(bytes))
+		te.Rows = uint64(math.Round(rows))
+		te.RequiredDiskBytes = uint64(math.Round(bytes * diskOverheadFactor))
+		if copyRowsPerSecond > 0 {
+			te.CopySeconds = te.maxStreamRows / copyRowsPerSecond
+		}
+		estimate.TargetShards = append(estimate.TargetShards, te)
+		estimate.DataBytes += te.DataBytes
+		estimate.Rows += te.Rows
+		estimate.CopySeconds = max(estimate.CopySeconds, te.CopySeconds)
+	}
+	for i, source := range sources {
+		if math.Abs(covered[i]-1) > 1e-9 {
+			return nil, fmt.Errorf("source shard %s is not covered by the target shards", source.Shard)
+		}
+	}
+	return estimate, nil
+}
+
+// keyRangeWidth returns the width of the key range over the first 8 bytes of
+// its bounds, which is what the keyspace IDs of the hash based vindexes are
+// made of.
+func keyRangeWidth(kr *topodatapb.KeyRange) float64 {
+	start, end := keyRangeBounds(kr)
+	return end - start
+}
+
+// keyRangeBounds returns the bounds of the key range over the first 8 bytes
+// of its bounds, an unbounded end being 2^64.
+func keyRangeBounds(kr *topodatapb.KeyRange) (start, end float64) {
+	start, end = 0, math.Exp2(64)
+	if len(kr.GetStart()) > 0 {
+		start = float64(uint64FromKey(kr.Start))
+	}
+	if len(kr.GetEnd()) > 0 {
+		end = float64(uint64FromKey(kr.End))
+	}
+	return start, end
+}
+
+// overlapFraction returns the fraction of the source key range which is in
+// the target one.
+func overlapFraction(source, target *topodatapb.KeyRange) float64 {
+	sourceStart, sourceEnd := keyRangeBounds(source)
+	targetStart, targetEnd := keyRangeBounds(target)
+	overlap := min(sourceEnd, targetEnd) - max(sourceStart, targetStart)
+	if overlap <= 0 {
+		return 0
+	}
+	return overlap / (sourceEnd - sourceStart)
+}
+
+// measureCopyRowsPerSecond returns the rate at which the VReplication streams
+// of the tablets of the keyspace copied rows in their copy phase, per stream,
+// or 0 if none of them copied rows.
+func measureCopyRowsPerSecond(ctx context.Context, keyspace string) (float64, error) {
+	resp, err := common.GetClient().GetTablets(ctx, &vtctldatapb.GetTabletsRequest{Keyspace: keyspace})
+	if err != nil {
+		return 0, err
+	}
+	httpClient := &http.Client{Timeout: reshardPlanOptions.fetchTimeout}
+	var rows int64
+	var copyTime time.Duration
+	for _, tablet := range resp.Tablets {
+		// The tablets which cannot be reached are ignored, the rate is
+		// measured on the others.
+		tabletRows, tabletCopyTime, err := fetchTabletCopyStats(ctx, httpClient, tablet)
+		if err != nil {
+			continue
+		}
+		rows += tabletRows
+		copyTime += tabletCopyTime
+	}
+	if copyTime <= 0 {
+		return 0, nil
+	}
+	return float64(rows) / copyTime.Seconds(), nil
+}
+
+// fetchTabletCopyStats returns the rows copied by the VReplication streams of
+// the tablet in their copy phase, and the time they spent in it.
+func fetchTabletCopyStats(ctx context.Context, httpClient *http.Client, tablet *topodatapb.Tablet) (rows int64, copyTime time.Duration, err error) {
+	vars := struct {
+		CopyRowCount map[string]int64 `json:"VReplicationCopyRowCount"`
+		PhaseTimings map[string]int64 `json:"VReplicationPhaseTimings"`
+	}{}
+	if err := fetchTabletVars(ctx, httpClient, tablet, &vars); err != nil {
+		return 0, 0, err
+	}
+	rows, copyTime = copyStats(vars.CopyRowCount, vars.PhaseTimings)
+	return rows, copyTime, nil
+}
+
+// copyStats sums the rows copied by the streams which spent time in their
+// copy phase, and that time. Both maps are keyed by stream, the phase timings
+// with the phase appended.
+func copyStats(copyRowCounts, phaseTimings map[string]int64) (rows int64, copyTime time.Duration) {
+	for name, nanos := range phaseTimings {
+		stream, ok := strings.CutSuffix(name, ".copy")
+		if !ok || nanos <= 0 {
+			continue
+		}
+		rows += copyRowCounts[stream]
+		copyTime += time.Duration(nanos)
+	}
+	return rows, copyTime
+}
+
+// parseTargetKeyRanges returns the key ranges of the target shards, sorted.
+func parseTargetKeyRanges(shards []string) ([]*topodatapb.KeyRange, error) {
+	if len(shards) == 0 {
+		return nil, errors.New("--target-shards must be specified")
+	}
+	keyRanges := make([]*topodatapb.KeyRange, 0, len(shards))
+	for _, shard := range shards {
+		_, kr, err := topo.ValidateShardName(shard)
+		if err != nil {
+			return nil, err
+		}
+		keyRanges = append(keyRanges, kr)
+	}
+	sort.Slice(keyRanges, func(i, j int) bool {
+		return key.KeyRangeLess(keyRanges[i], keyRanges[j])
+	})
+	return keyRanges, nil
+}
+
+// planConsistentHashVindexes plans the reshard of every consistent_hash
+// vindex of the keyspace, sorted by name.
+func planConsistentHashVindexes(ks *vschemapb.Keyspace, targets []*topodatapb.KeyRange) ([]*vindexes.ConsistentHashPlan, error) {
+	var plans []*vindexes.ConsistentHashPlan
+	for name, vindex := range ks.GetVindexes() {
+		if vindex.Type != "consistent_hash" {
+			continue
+		}
+		v, err := vindexes.CreateVindex(vindex.Type, name, vindex.Params)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot create vindex %s", name)
+		}
+		plan, err := vindexes.PlanConsistentHashReshard(v.(*vindexes.ConsistentHash), targets)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "cannot plan vindex %s", name)
+		}
+		plans = append(plans, plan)
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].Vindex < plans[j].Vindex
+	})
+	return plans, nil
+}
+
+func registerPlanCommand(root *cobra.Command) {
+	reshardPlan.Flags().StringSliceVar(&reshardPlanOptions.targetShards, "target-shards", nil, "Target shards of the Reshard workflow.")
+	reshardPlan.Flags().Float64Var(&reshardPlanOptions.copyRowsPerSecond, "copy-rows-per-second", 0, "The rate at which each stream of the workflow copies rows. If 0, it is measured from the copy phase of the VReplication streams of the tablets of the keyspace.")
+	reshardPlan.Flags().Float64Var(&reshardPlanOptions.diskOverheadFactor, "disk-overhead-factor", 2, "The disk space required on a target shard, as a multiple of the data size it copies, to account for the indexes, the binary logs and the fragmentation.")
+	reshardPlan.Flags().DurationVar(&reshardPlanOptions.fetchTimeout, "fetch-timeout", 10*time.Second, "Timeout for fetching the VReplication stats of each tablet.")
+	root.AddCommand(reshardPlan)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reshard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/key"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestParseTargetKeyRanges(t *testing.T) {
+	targets, err := parseTargetKeyRanges([]string{"80-", "-40", "40-80"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-40", "40-80", "80-"}, []string{key.KeyRangeString(targets[0]), key.KeyRangeString(targets[1]), key.KeyRangeString(targets[2])})
+
+	_, err = parseTargetKeyRanges(nil)
+	assert.EqualError(t, err, "--target-shards must be specified")
+}
+
+func TestPlanConsistentHashVindexes(t *testing.T) {
+	ks := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"ring2":  {Type: "consistent_hash", Params: map[string]string{"nodes": "-40,80-c0"}},
+			"ring1":  {Type: "consistent_hash", Params: map[string]string{"nodes": "a:-40,b:80-c0"}},
+			"xxhash": {Type: "xxhash"},
+		},
+	}
+	targets, err := parseTargetKeyRanges([]string{"80-", "-40", "40-80"})
+	require.NoError(t, err)
+
+	plans, err := planConsistentHashVindexes(ks, targets)
+	require.NoError(t, err)
+	require.Len(t, plans, 2)
+	assert.Equal(t, "ring1", plans[0].Vindex)
+	assert.Equal(t, "a:-40,b:80-c0,40-80", plans[0].Nodes)
+	assert.Equal(t, "ring2", plans[1].Vindex)
+	assert.Equal(t, "-40,80-c0,40-80", plans[1].Nodes)
+	for _, plan := range plans {
+		assert.InDelta(t, 1.0/3, plan.MovedFraction, 0.05)
+	}
+
+	ks.Vindexes["ring1"].Params["nodes"] = "a:-80,b:80-"
+	_, err = planConsistentHashVindexes(ks, targets)
+	assert.EqualError(t, err, "cannot plan vindex ring1: the key range -80 of node a spans several target shards, and cannot change")
+
+	ks.Vindexes["ring1"].Params["nodes"] = ""
+	_, err = planConsistentHashVindexes(ks, targets)
+	assert.EqualError(t, err, "cannot create vindex ring1: consistent_hash vindex requires the nodes param")
+}
+
+func TestEstimateReshard(t *testing.T) {
+	newShard := func(shard string, serving bool) *vtctldatapb.Shard {
+		kr, err := key.ParseShardingSpec(shard)
+		require.NoError(t, err)
+		return &vtctldatapb.Shard{Name: shard, Shard: &topodatapb.Shard{KeyRange: kr[0], IsPrimaryServing: serving}}
+	}
+	shards := map[string]*vtctldatapb.Shard{
+		"-80":   newShard("-80", true),
+		"80-":   newShard("80-", true),
+		"-40":   newShard("-40", false),
+		"40-80": newShard("40-80", false),
+	}
+	targets, err := parseTargetKeyRanges([]string{"-40", "40-80", "80-"})
+	require.NoError(t, err)
+
+	// The target shards which already serve are not resharded.
+	sources := sourceShards(shards, targets)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "-80", sources[0].Shard)
+	sources[0].DataBytes, sources[0].Rows = 10e9, 1000
+
+	estimate, err := estimateReshard("ks", sources, targets[:2], 10, 2)
+	require.NoError(t, err)
+	require.Len(t, estimate.TargetShards, 2)
+	for i, shard := range []string{"-40", "40-80"} {
+		target := estimate.TargetShards[i]
+		assert.Equal(t, shard, target.Shard)
+		assert.EqualValues(t, 5e9, target.DataBytes)
+		assert.EqualValues(t, 500, target.Rows)
+		assert.EqualValues(t, 10e9, target.RequiredDiskBytes)
+		assert.Equal(t, []string{"-80"}, target.SourceShards)
+		assert.InDelta(t, 50, target.CopySeconds, 1e-9)
+	}
+	assert.EqualValues(t, 10e9, estimate.DataBytes)
+	assert.EqualValues(t, 1000, estimate.Rows)
+	assert.InDelta(t, 50, estimate.CopySeconds, 1e-9)
+
+	// Merging shards: the target shard copies from both source shards in
+	// parallel, as long as its largest stream.
+	sources = sourceShards(shards, []*topodatapb.KeyRange{nil})
+	require.Len(t, sources, 2)
+	sources[0].Rows, sources[1].Rows = 1000, 3000
+	estimate, err = estimateReshard("ks", sources, []*topodatapb.KeyRange{nil}, 10, 2)
+	require.NoError(t, err)
+	require.Len(t, estimate.TargetShards, 1)
+	assert.Equal(t, []string{"-80", "80-"}, estimate.TargetShards[0].SourceShards)
+	assert.EqualValues(t, 4000, estimate.Rows)
+	assert.InDelta(t, 300, estimate.CopySeconds, 1e-9)
+
+	// Without a copy rate, the duration is unknown.
+	estimate, err = estimateReshard("ks", sources, []*topodatapb.KeyRange{nil}, 0, 2)
+	require.NoError(t, err)
+	assert.Zero(t, estimate.CopySeconds)
+
+	// The target shards must cover the source shards exactly.
+	_, err = estimateReshard("ks", sources, targets[:2], 10, 2)
+	assert.EqualError(t, err, "source shard 80- is not covered by the target shards")
+	_, err = estimateReshard("ks", sources[:1], targets, 10, 2)
+	assert.EqualError(t, err, "target shard 80- is not covered by the source shards")
+}
+
+func TestCopyStats(t *testing.T) {
+	rows, copyTime := copyStats(map[string]int64{
+		"commerce.0.wf1.1": 1000,
+		"commerce.0.wf2.2": 500,
+		"commerce.0.wf3.3": 200,
+	}, map[string]int64{
+		"commerce.0.wf1.1.copy":        int64(10 * time.Second),
+		"commerce.0.wf1.1.catchup":     int64(time.Second),
+		"commerce.0.wf2.2.copy":        int64(5 * time.Second),
+		"commerce.0.wf3.3.fastforward": int64(time.Second),
+	})
+	assert.EqualValues(t, 1500, rows)
+	assert.Equal(t, 15*time.Second, copyTime)
+}
@@ -36,6 +36,8 @@ func registerReshardCommands(root *cobra.Command) {
 	root.AddCommand(reshard)
 
 	registerCreateCommand(reshard)
+	registerAnalyzeCommand(reshard)
+	registerPlanCommand(reshard)
 	opts := &common.SubCommandsOpts{
 		SubCommand: "Reshard",
 		Workflow:   "cust2cust",
@@ -23,6 +23,7 @@ import (
 	"io"
 	"math"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -76,6 +77,12 @@ var (
 		Arg string
 	}{}
 
+	repairOptions = struct {
+		UUID         uuid.UUID
+		TargetShards []string
+		Apply        bool
+	}{}
+
 	resumeOptions = struct {
 		UUID         uuid.UUID
 		TargetShards []string
@@ -179,6 +186,32 @@ vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --targe
 		RunE: commandDelete,
 	}
 
+	// repair makes a VDiffRepair gRPC call to a vtctld.
+	repair = &cobra.Command{
+		Use:   "repair",
+		Short: "Repair the rows that a completed VDiff found to differ on the target shards.",
+		Long: `Repair the rows that a completed VDiff found to differ on the target shards.
+The VDiff report is only used to find the primary keys of the rows that differed. The current source and target
+rows are read again and the statements that make the target rows match the source rows are reported, and executed
+when --apply is specified. Only the rows that are sampled in the report can be repaired, so the VDiff should be
+created with a --max-report-sample-rows value that covers all the differences.`,
+		Example: `vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace customer repair a037a9e2-5628-11ee-8c99-0242ac120002
+vtctldclient --server localhost:15999 vdiff --workflow commerce2customer --target-keyspace customer repair --apply a037a9e2-5628-11ee-8c99-0242ac120002`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Repair"},
+		Args:                  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			uuid, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid UUID provided: %v", err)
+			}
+			repairOptions.UUID = uuid
+
+			return common.ValidateShards(repairOptions.TargetShards)
+		},
+		RunE: commandRepair,
+	}
+
 	// resume makes a VDiffResume gRPC call to a vtctld.
 	resume = &cobra.Command{
 		Use:                   "resume",
@@ -371,6 +404,71 @@ func commandDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// repairStatement is a statement that repairs a row of a target shard.
+type repairStatement struct {
+	Shard, Table, Operation, Statement, Status string
+}
+
+func commandRepair(cmd *cobra.Command, args []string) error {
+	format, err := common.GetOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	cli.FinishedParsing(cmd)
+
+	resp, err := common.GetClient().VDiffRepair(common.GetCommandCtx(), &vtctldatapb.VDiffRepairRequest{
+		Workflow:       common.BaseOptions.Workflow,
+		TargetKeyspace: common.BaseOptions.TargetKeyspace,
+		Uuid:           repairOptions.UUID.String(),
+		TargetShards:   repairOptions.TargetShards,
+		Apply:          repairOptions.Apply,
+	})
+	if err != nil {
+		return err
+	}
+
+	return displayRepairResponse(cmd.OutOrStdout(), format, repairOptions.UUID.String(), resp)
+}
+
+func displayRepairResponse(out io.Writer, format, uuid string, resp *vtctldatapb.VDiffRepairResponse) error {
+	var stmts []*repairStatement
+	for shard, tabletResp := range resp.TabletResponses {
+		if tabletResp == nil || tabletResp.Output == nil {
+			continue
+		}
+		for _, row := range sqltypes.Proto3ToResult(tabletResp.Output).Named().Rows {
+			stmts = append(stmts, &repairStatement{
+				Shard:     shard,
+				Table:     row.AsString("table_name", ""),
+				Operation: row.AsString("operation", ""),
+				Statement: row.AsString("statement", ""),
+				Status:    row.AsString("status", ""),
+			})
+		}
+	}
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return stmts[i].Shard < stmts[j].Shard
+	})
+	if format == "json" {
+		jsonText, err := cli.MarshalJSONPretty(stmts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(jsonText))
+		return nil
+	}
+	if len(stmts) == 0 {
+		fmt.Fprintf(out, "VDiff %s found no rows to repair on the target shards\n", uuid)
+		return nil
+	}
+	rows := [][]string{getStructFieldNames(repairStatement{})}
+	for _, stmt := range stmts {
+		rows = append(rows, []string{stmt.Shard, stmt.Table, stmt.Operation, stmt.Statement, stmt.Status})
+	}
+	fmt.Fprintln(out, gotabulate.Create(rows).Render("grid"))
+	return nil
+}
+
 func commandResume(cmd *cobra.Command, args []string) error {
 	format, err := common.GetOutputFormat(cmd)
 	if err != nil {
@@ -444,6 +542,7 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.SamplePercent}}	SamplePercent:    {{$table.SamplePercent}}% (EstimatedMismatchedRows: {{$table.EstimatedMismatchedRows}}){{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
@@ -683,6 +782,10 @@ func registerCommands(root *cobra.Command) {
 
 	base.AddCommand(delete)
 
+	repair.Flags().StringSliceVar(&repairOptions.TargetShards, "target-shards", nil, "The target shards to repair the rows on; default is all shards.")
+	repair.Flags().BoolVar(&repairOptions.Apply, "apply", false, "Execute the statements that repair the rows on the target shards, rather than only reporting them.")
+	base.AddCommand(repair)
+
 	resume.Flags().StringSliceVar(&resumeOptions.TargetShards, "target-shards", nil, "The target shards to resume the vdiff on; default is all shards.")
 	base.AddCommand(resume)
 
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/yaml2"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	exportOptions = struct {
+		OutputFile string
+	}{}
+
+	importOptions = struct {
+		File      string
+		AutoStart bool
+	}{}
+
+	// export makes a WorkflowExport gRPC call to a vtctld.
+	export = &cobra.Command{
+		Use:   "export",
+		Short: "Export the definition of a VReplication workflow as YAML.",
+		Long: `Export the definition of a VReplication workflow as YAML.
+
+The definition holds the settings of the workflow and the binlog source of each of its streams, along
+with the state of the streams when it was exported. It can be versioned with the migration runbooks,
+and imported again to recreate the workflow on the same or on a different cluster.`,
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer export --workflow commerce2customer --output-file commerce2customer.yaml`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Export"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandExport,
+	}
+
+	// importWorkflow makes a WorkflowImport gRPC call to a vtctld.
+	importWorkflow = &cobra.Command{
+		Use:   "import",
+		Short: "Create a VReplication workflow from its exported YAML definition.",
+		Long: `Create a VReplication workflow from its exported YAML definition.
+
+The streams of the workflow are created in the given keyspace, which can differ from the keyspace
+it was exported from. The streams start over from the copy phase: the state they were in when the
+workflow was exported is not restored. The tables and the routing rules of the workflow must
+already exist.`,
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer import --file commerce2customer.yaml`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Import"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandImport,
+	}
+)
+
+func commandExport(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowExportRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+	}
+	resp, err := common.GetClient().WorkflowExport(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	// The unset fields are left out, so that the definition only holds
+	// what the workflow needs.
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp.Definition)
+	if err != nil {
+		return err
+	}
+	data, err = yaml2.JSONToYAML(data)
+	if err != nil {
+		return err
+	}
+
+	if exportOptions.OutputFile != "" {
+		return os.WriteFile(exportOptions.OutputFile, data, 0o644)
+	}
+	fmt.Printf("%s", data)
+
+	return nil
+}
+
+func commandImport(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(importOptions.File)
+	if err != nil {
+		return err
+	}
+	data, err = yaml2.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("invalid workflow definition in %s: %w", importOptions.File, err)
+	}
+	def := &vtctldatapb.WorkflowDefinition{}
+	if err := protojson.Unmarshal(data, def); err != nil {
+		return fmt.Errorf("invalid workflow definition in %s: %w", importOptions.File, err)
+	}
+	def.Keyspace = baseOptions.Keyspace
+
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowImportRequest{
+		Definition: def,
+		AutoStart:  importOptions.AutoStart,
+	}
+	resp, err := common.GetClient().WorkflowImport(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err = cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
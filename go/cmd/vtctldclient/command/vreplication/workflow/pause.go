@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"vitess.io/vitess/go/cmd/vtctldclient/cli"
+	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
+	"vitess.io/vitess/go/protoutil"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+var (
+	pauseOptions = struct {
+		Timeout time.Duration
+	}{}
+
+	// pause makes a WorkflowPause gRPC call to a vtctld.
+	pause = &cobra.Command{
+		Use:   "pause",
+		Short: "Pause a VReplication workflow at a consistent position of its source shards.",
+		Long: `Pause a VReplication workflow at a consistent position of its source shards.
+
+The positions of all the source shard primaries are read at the same time, and every stream of the
+workflow is then stopped once it reaches the position of its source shard. The target keyspace thus
+holds a well-defined snapshot of the source keyspace until the workflow is resumed, whereas stop
+leaves each stream wherever it happens to be. A VDiff of a paused workflow leaves it paused.`,
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer pause --workflow commerce2customer`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Pause"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandPause,
+	}
+
+	// resume makes a WorkflowResume gRPC call to a vtctld.
+	resume = &cobra.Command{
+		Use:                   "resume",
+		Short:                 "Resume a paused VReplication workflow.",
+		Example:               `vtctldclient --server localhost:15999 workflow --keyspace customer resume --workflow commerce2customer`,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"Resume"},
+		Args:                  cobra.NoArgs,
+		RunE:                  commandResume,
+	}
+)
+
+func commandPause(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowPauseRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+		Timeout:  protoutil.DurationToProto(pauseOptions.Timeout),
+	}
+	resp, err := common.GetClient().WorkflowPause(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
+
+func commandResume(cmd *cobra.Command, args []string) error {
+	cli.FinishedParsing(cmd)
+
+	req := &vtctldatapb.WorkflowResumeRequest{
+		Keyspace: baseOptions.Keyspace,
+		Workflow: baseOptions.Workflow,
+	}
+	resp, err := common.GetClient().WorkflowResume(common.GetCommandCtx(), req)
+	if err != nil {
+		return err
+	}
+
+	data, err := cli.MarshalJSONPretty(resp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", data)
+
+	return nil
+}
@@ -61,6 +61,9 @@ func commandShow(cmd *cobra.Command, args []string) error {
 		IncludeLogs: workflowShowOptions.IncludeLogs,
 		Shards:      baseOptions.Shards,
 	}
+	if strings.ToLower(cmd.Name()) == "show" {
+		return common.ShowWorkflowsWithProgress(common.GetCommandCtx(), req, workflowShowOptions.ProgressSampleInterval)
+	}
 	resp, err := common.GetClient().GetWorkflows(common.GetCommandCtx(), req)
 	if err != nil {
 		return err
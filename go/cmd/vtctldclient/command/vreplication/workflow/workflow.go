@@ -17,11 +17,14 @@ limitations under the License.
 package workflow
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/common"
 	"vitess.io/vitess/go/cmd/vtctldclient/command/vreplication/movetables"
 	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
 )
 
 // base is a parent command for Workflow commands.
@@ -42,7 +45,8 @@ var (
 	}{}
 
 	workflowShowOptions = struct {
-		IncludeLogs bool
+		IncludeLogs            bool
+		ProgressSampleInterval time.Duration
 	}{}
 )
 
@@ -64,12 +68,32 @@ func registerCommands(root *cobra.Command) {
 	common.AddShardSubsetFlag(delete, &baseOptions.Shards)
 	base.AddCommand(delete)
 
+	export.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to export.")
+	export.MarkFlagRequired("workflow")
+	export.Flags().StringVar(&exportOptions.OutputFile, "output-file", "", "File in which to write the YAML definition of the workflow, instead of standard output.")
+	base.AddCommand(export)
+
+	importWorkflow.Flags().StringVarP(&importOptions.File, "file", "f", "", "File with the YAML definition of the workflow, as exported by the export command.")
+	importWorkflow.MarkFlagRequired("file")
+	importWorkflow.Flags().BoolVar(&importOptions.AutoStart, "auto-start", true, "Start the workflow after creating it.")
+	base.AddCommand(importWorkflow)
+
 	common.AddShardSubsetFlag(workflowList, &baseOptions.Shards)
 	base.AddCommand(workflowList)
 
+	pause.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to pause.")
+	pause.MarkFlagRequired("workflow")
+	pause.Flags().DurationVar(&pauseOptions.Timeout, "timeout", workflow.DefaultTimeout, "Specifies the maximum time to wait for all the streams to reach the consistent position. If not all of them reach it in time, the workflow is left as it was.")
+	base.AddCommand(pause)
+
+	resume.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want to resume.")
+	resume.MarkFlagRequired("workflow")
+	base.AddCommand(resume)
+
 	show.Flags().StringVarP(&baseOptions.Workflow, "workflow", "w", "", "The workflow you want the details for.")
 	show.MarkFlagRequired("workflow")
 	show.Flags().BoolVar(&workflowShowOptions.IncludeLogs, "include-logs", true, "Include recent logs for the workflow.")
+	common.AddProgressSampleIntervalFlag(show, &workflowShowOptions.ProgressSampleInterval)
 	common.AddShardSubsetFlag(show, &baseOptions.Shards)
 	base.AddCommand(show)
 
@@ -88,7 +112,7 @@ func registerCommands(root *cobra.Command) {
 	update.Flags().StringSliceVarP(&updateOptions.Cells, "cells", "c", nil, "New Cell(s) or CellAlias(es) (comma-separated) to replicate from.")
 	update.Flags().VarP((*topoproto.TabletTypeListFlag)(&updateOptions.TabletTypes), "tablet-types", "t", "New source tablet types to replicate from (e.g. PRIMARY,REPLICA,RDONLY).")
 	update.Flags().BoolVar(&updateOptions.TabletTypesInPreferenceOrder, "tablet-types-in-order", true, "When performing source tablet selection, look for candidates in the type order as they are listed in the tablet-types flag.")
-	update.Flags().StringVar(&updateOptions.OnDDL, "on-ddl", "", "New instruction on what to do when DDL is encountered in the VReplication stream. Possible values are IGNORE, STOP, EXEC, and EXEC_IGNORE.")
+	update.Flags().StringVar(&updateOptions.OnDDL, "on-ddl", "", "New instruction on what to do when DDL is encountered in the VReplication stream. Possible values are IGNORE, STOP, EXEC, EXEC_IGNORE, and REMATERIALIZE (Materialize only).")
 	update.Flags().StringSliceVar(&updateOptions.ConfigOverrides, "config-overrides", nil, "Specify one or more VReplication config flags to override as a comma-separated list of key=value pairs.")
 
 	common.AddShardSubsetFlag(update, &baseOptions.Shards)
@@ -41,7 +41,7 @@ var (
 	}
 	// ApplyVSchema makes an ApplyVSchema gRPC call to a vtctld.
 	ApplyVSchema = &cobra.Command{
-		Use:                   "ApplyVSchema {--vschema=<vschema> || --vschema-file=<vschema file> || --sql=<sql> || --sql-file=<sql file>} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run] [--strict] <keyspace>",
+		Use:                   "ApplyVSchema {--vschema=<vschema> || --vschema-file=<vschema file> || --sql=<sql> || --sql-file=<sql file>} [--cells=c1,c2,...] [--skip-rebuild] [--dry-run] [--strict] [--validate-schema] [--canary-percent=<percent>] <keyspace>",
 		Short:                 "Applies the VTGate routing schema to the provided keyspace. Shows the result after application.",
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ExactArgs(1),
@@ -50,14 +50,16 @@ var (
 )
 
 var applyVSchemaOptions = struct {
-	VSchema     string
-	VSchemaFile string
-	SQL         string
-	SQLFile     string
-	DryRun      bool
-	SkipRebuild bool
-	Cells       []string
-	Strict      bool
+	VSchema        string
+	VSchemaFile    string
+	SQL            string
+	SQLFile        string
+	DryRun         bool
+	SkipRebuild    bool
+	Cells          []string
+	Strict         bool
+	ValidateSchema bool
+	CanaryPercent  uint32
 }{}
 
 func commandApplyVSchema(cmd *cobra.Command, args []string) error {
@@ -73,11 +75,13 @@ func commandApplyVSchema(cmd *cobra.Command, args []string) error {
 	}
 
 	req := &vtctldatapb.ApplyVSchemaRequest{
-		Keyspace:    cmd.Flags().Arg(0),
-		SkipRebuild: applyVSchemaOptions.SkipRebuild,
-		Cells:       applyVSchemaOptions.Cells,
-		DryRun:      applyVSchemaOptions.DryRun,
-		Strict:      applyVSchemaOptions.Strict,
+		Keyspace:       cmd.Flags().Arg(0),
+		SkipRebuild:    applyVSchemaOptions.SkipRebuild,
+		Cells:          applyVSchemaOptions.Cells,
+		DryRun:         applyVSchemaOptions.DryRun,
+		Strict:         applyVSchemaOptions.Strict,
+		ValidateSchema: applyVSchemaOptions.ValidateSchema,
+		CanaryPercent:  applyVSchemaOptions.CanaryPercent,
 	}
 
 	var err error
@@ -120,6 +124,9 @@ func commandApplyVSchema(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if applyVSchemaOptions.CanaryPercent > 0 && !applyVSchemaOptions.DryRun {
+		fmt.Printf("Applied as a canary for %d%% of the sessions, apply it again without --canary-percent to use it for all of them.\n", applyVSchemaOptions.CanaryPercent)
+	}
 	fmt.Printf("New VSchema object:\n%s\nIf this is not what you expected, check the input data (as JSON parsing will skip unexpected fields).\n", vsData)
 	for vdxName, ups := range res.UnknownVindexParams {
 		for _, param := range ups.Params {
@@ -160,6 +167,8 @@ func init() {
 	ApplyVSchema.Flags().BoolVar(&applyVSchemaOptions.SkipRebuild, "skip-rebuild", false, "Skip rebuilding the SrvSchema objects.")
 	ApplyVSchema.Flags().StringSliceVar(&applyVSchemaOptions.Cells, "cells", nil, "Limits the rebuild to the specified cells, after application. Ignored if --skip-rebuild is set.")
 	ApplyVSchema.Flags().BoolVar(&applyVSchemaOptions.Strict, "strict", false, "If set, treat unknown vindex params as errors.")
+	ApplyVSchema.Flags().BoolVar(&applyVSchemaOptions.ValidateSchema, "validate-schema", false, "If set, validate the vschema against the schema of the keyspace, and treat references to nonexistent tables or columns and vindex type mismatches as errors.")
+	ApplyVSchema.Flags().Uint32Var(&applyVSchemaOptions.CanaryPercent, "canary-percent", 0, "If set, apply the vschema as a canary used by this percentage of the vtgate sessions, between 1 and 99. The canary cannot change the vindexes or the routing of the tables. Saving any other vschema of the keyspace ends the canary.")
 	Root.AddCommand(ApplyVSchema)
 
 	Root.AddCommand(GetVSchema)
@@ -18,6 +18,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -48,6 +49,11 @@ var (
 	dbName             string
 	plannerVersionStr  string
 
+	proposedSchemaFlag      string
+	proposedSchemaFileFlag  string
+	proposedVSchemaFlag     string
+	proposedVSchemaFileFlag string
+
 	numShards       = 2
 	replicationMode = "ROW"
 	executionMode   = "multi"
@@ -82,7 +88,9 @@ If no keyspace name is present, VTExplain will return the following error:
 		Example: "Explain how Vitess will execute the query `SELECT * FROM users` using the VSchema contained in `vschemas.json` and the database schema `schema.sql`:\n\n" +
 			"```\nvtexplain --vschema-file vschema.json --schema-file schema.sql --sql \"SELECT * FROM users\"\n```\n\n" +
 			"Explain how the example will execute on 128 shards using Row-based replication:\n\n" +
-			"```\nvtexplain -- -shards 128 --vschema-file vschema.json --schema-file schema.sql --replication-mode \"ROW\" --output-mode text --sql \"INSERT INTO users (user_id, name) VALUES(1, 'john')\"\n```\n",
+			"```\nvtexplain -- -shards 128 --vschema-file vschema.json --schema-file schema.sql --replication-mode \"ROW\" --output-mode text --sql \"INSERT INTO users (user_id, name) VALUES(1, 'john')\"\n```\n\n" +
+			"Report which of the queries in `queries.sql` break, degrade to scatter queries, or change their plans if the VSchema is replaced with `new-vschema.json` and the schema changed by `ALTER TABLE users DROP COLUMN nickname`:\n\n" +
+			"```\nvtexplain --vschema-file vschema.json --schema-file schema.sql --sql-file queries.sql --proposed-vschema-file new-vschema.json --proposed-schema \"ALTER TABLE users DROP COLUMN nickname\"\n```\n",
 		Args:    cobra.NoArgs,
 		PreRunE: servenv.CobraPreRunE,
 		Version: servenv.AppVersion.String(),
@@ -107,6 +115,10 @@ func init() {
 	Main.Flags().IntVar(&numShards, "shards", numShards, "Number of shards per keyspace. Passing --ks-shard-map/--ks-shard-map-file causes this flag to be ignored.")
 	Main.Flags().StringVar(&executionMode, "execution-mode", executionMode, "The execution mode to simulate -- must be set to multi, legacy-autocommit, or twopc")
 	Main.Flags().StringVar(&outputMode, "output-mode", outputMode, "Output in human-friendly text or json")
+	Main.Flags().StringVar(&proposedSchemaFlag, "proposed-schema", proposedSchemaFlag, "DDL statements of a proposed schema change. If set, reports how the change affects the plans of the queries instead of explaining them, and fails if any of them breaks or degrades")
+	Main.Flags().StringVar(&proposedSchemaFileFlag, "proposed-schema-file", proposedSchemaFileFlag, "Identifies the file that contains the DDL statements of a proposed schema change")
+	Main.Flags().StringVar(&proposedVSchemaFlag, "proposed-vschema", proposedVSchemaFlag, "Proposed VTGate routing schema. If set, reports how it affects the plans of the queries instead of explaining them, and fails if any of them breaks or degrades")
+	Main.Flags().StringVar(&proposedVSchemaFileFlag, "proposed-vschema-file", proposedVSchemaFileFlag, "Identifies the file that contains the proposed VTGate routing schema")
 
 	acl.RegisterFlags(Main.Flags())
 }
@@ -168,6 +180,16 @@ func parseAndRun(ctx context.Context) error {
 		return err
 	}
 
+	proposedSchema, err := getFileParam(proposedSchemaFlag, proposedSchemaFileFlag, "proposed-schema", false)
+	if err != nil {
+		return err
+	}
+
+	proposedVSchema, err := getFileParam(proposedVSchemaFlag, proposedVSchemaFileFlag, "proposed-vschema", false)
+	if err != nil {
+		return err
+	}
+
 	opts := &vtexplain.Options{
 		ExecutionMode:   executionMode,
 		PlannerVersion:  plannerVersion,
@@ -185,6 +207,10 @@ func parseAndRun(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if proposedSchema != "" || proposedVSchema != "" {
+		return analyzeImpact(ctx, env, sql, schema, vschema, ksShardMap, proposedSchema, proposedVSchema, opts)
+	}
+
 	ts := memorytopo.NewServer(ctx, vtexplain.Cell)
 	srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
 	vte, err := vtexplain.Init(ctx, env, ts, vschema, schema, ksShardMap, opts, srvTopoCounts)
@@ -206,3 +232,55 @@ func parseAndRun(ctx context.Context) error {
 
 	return nil
 }
+
+// analyzeImpact reports how the proposed schema or vschema change affects the
+// plans of the queries, and fails if any of them breaks or degrades.
+func analyzeImpact(ctx context.Context, env *vtenv.Environment, sql, schema, vschema, ksShardMap, proposedSchema, proposedVSchema string, opts *vtexplain.Options) error {
+	newSchema := schema
+	if proposedSchema != "" {
+		var err error
+		newSchema, err = vtexplain.ApplySchemaChange(env, schema, proposedSchema)
+		if err != nil {
+			return err
+		}
+	}
+	newVSchema := vschema
+	if proposedVSchema != "" {
+		newVSchema = proposedVSchema
+	}
+
+	// vtgate must know the columns of the tables for the schema change to
+	// affect the plans.
+	opts.SchemaTracking = true
+	initExplain := func(schema, vschema string) (*vtexplain.VTExplain, error) {
+		ts := memorytopo.NewServer(ctx, vtexplain.Cell)
+		srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
+		return vtexplain.Init(ctx, env, ts, vschema, schema, ksShardMap, opts, srvTopoCounts)
+	}
+	before, err := initExplain(schema, vschema)
+	if err != nil {
+		return err
+	}
+	defer before.Stop()
+	after, err := initExplain(newSchema, newVSchema)
+	if err != nil {
+		return fmt.Errorf("proposed change: %v", err)
+	}
+	defer after.Stop()
+
+	results, err := vtexplain.CompareQueryPlans(before, after, sql)
+	if err != nil {
+		return err
+	}
+
+	if outputMode == "text" {
+		fmt.Print(vtexplain.QueryImpactsAsText(results))
+	} else {
+		fmt.Print(vtexplain.QueryImpactsAsJSON(results))
+	}
+
+	if vtexplain.HasRegressions(results) {
+		return errors.New("some queries break or degrade with the proposed change")
+	}
+	return nil
+}
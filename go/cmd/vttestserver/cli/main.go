@@ -209,6 +209,9 @@ func New() (cmd *cobra.Command) {
 	utils.SetFlagStringVar(cmd.Flags(), &config.TransactionMode, "transaction-mode", "MULTI", "Transaction mode MULTI (default), SINGLE or TWOPC ")
 	cmd.Flags().DurationVar(&config.TransactionTimeout, "queryserver-config-transaction-timeout", 30*time.Second, "query server transaction timeout, a transaction will be killed if it takes longer than this value")
 
+	cmd.Flags().StringVar(&config.FakeTime, "fake-time", "", "If set, freezes the clock at the given RFC 3339 time (e.g. '2024-01-01T00:00:00Z'), so that NOW() and similar functions are reproducible")
+	cmd.Flags().BoolVar(&config.DeterministicSequences, "deterministic-sequences", false, "If set, sequence values are allocated one at a time, so that generated values do not depend on restarts")
+
 	utils.SetFlagStringVar(cmd.Flags(), &config.TabletHostName, "tablet-hostname", "localhost", "The hostname to use for the tablet otherwise it will be derived from OS' hostname")
 
 	utils.SetFlagStringVar(cmd.Flags(), &config.VSchemaDDLAuthorizedUsers, "vschema-ddl-authorized-users", "", "Comma separated list of users authorized to execute vschema ddl operations via vtgate")
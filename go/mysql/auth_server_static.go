@@ -23,6 +23,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -33,6 +34,8 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
@@ -75,6 +78,29 @@ type AuthServerStaticEntry struct {
 	UserData            string
 	SourceHost          string
 	Groups              []string
+	// SessionDefaults are applied by vtgate to the session of new connections authenticated with this entry.
+	SessionDefaults *SessionDefaults `json:",omitempty"`
+}
+
+// SessionDefaults holds default session settings for a user, e.g. to have analytics users
+// land on rdonly tablets with an OLAP workload without any application changes.
+type SessionDefaults struct {
+	// Workload is one of OLTP, OLAP or DBA.
+	Workload string `json:",omitempty"`
+	// TabletType is the target tablet type, e.g. replica or rdonly.
+	TabletType string `json:",omitempty"`
+	// TransactionMode is one of SINGLE, MULTI or TWOPC.
+	TransactionMode string `json:",omitempty"`
+	// QueryTimeout is the query timeout, in milliseconds.
+	QueryTimeout int64 `json:",omitempty"`
+	// MaxQueryTimeout is the maximum query timeout, in milliseconds. The user cannot raise
+	// the timeout of its queries above it with SET query_timeout or the QUERY_TIMEOUT_MS directive.
+	MaxQueryTimeout int64 `json:",omitempty"`
+}
+
+// SessionDefaultsGetter is implemented by user data which carries default session settings.
+type SessionDefaultsGetter interface {
+	GetSessionDefaults() *SessionDefaults
 }
 
 // InitAuthServerStatic Handles initializing the AuthServerStatic if necessary.
@@ -171,7 +197,7 @@ func (a *AuthServerStatic) UserEntryWithPassword(conn *Conn, user string, passwo
 	for _, entry := range entries {
 		// Validate the password.
 		if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare([]byte(password), []byte(entry.Password)) == 1 {
-			return &StaticUserData{entry.UserData, entry.Groups}, nil
+			return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, nil
 		}
 	}
 	return &StaticUserData{}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
@@ -192,18 +218,18 @@ func (a *AuthServerStatic) UserEntryWithHash(conn *Conn, salt []byte, user strin
 		if entry.MysqlNativePassword != "" {
 			hash, err := DecodePasswordHex(entry.MysqlNativePassword)
 			if err != nil {
-				return &StaticUserData{entry.UserData, entry.Groups}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 			}
 
 			isPass := VerifyHashedMysqlNativePassword(authResponse, salt, hash)
 			if MatchSourceHost(remoteAddr, entry.SourceHost) && isPass {
-				return &StaticUserData{entry.UserData, entry.Groups}, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, nil
 			}
 		} else {
 			computedAuthResponse := ScrambleMysqlNativePassword(salt, []byte(entry.Password))
 			// Validate the password.
 			if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare(authResponse, computedAuthResponse) == 1 {
-				return &StaticUserData{entry.UserData, entry.Groups}, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, nil
 			}
 		}
 	}
@@ -225,19 +251,19 @@ func (a *AuthServerStatic) UserEntryWithCacheHash(conn *Conn, salt []byte, user
 		if entry.CachingSha2Password != "" {
 			hash, err := DecodePasswordHex(entry.CachingSha2Password)
 			if err != nil {
-				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, AuthAccepted, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 			}
 
 			isPass := VerifyHashedCachingSha2Password(authResponse, salt, hash)
 			if MatchSourceHost(remoteAddr, entry.SourceHost) && isPass {
-				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, AuthAccepted, nil
 			}
 		} else {
 			computedAuthResponse := ScrambleCachingSha2Password(salt, []byte(entry.Password))
 
 			// Validate the password.
 			if MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare(authResponse, computedAuthResponse) == 1 {
-				return &StaticUserData{entry.UserData, entry.Groups}, AuthAccepted, nil
+				return &StaticUserData{entry.UserData, entry.Groups, entry.SessionDefaults}, AuthAccepted, nil
 			}
 		}
 	}
@@ -356,8 +382,42 @@ func validateConfig(config map[string][]*AuthServerStaticEntry) error {
 			if entry.SourceHost != "" && entry.SourceHost != localhostName {
 				return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid SourceHost found (only localhost is supported): %v", entry.SourceHost)
 			}
+			if err := validateSessionDefaults(entry.SessionDefaults); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateSessionDefaults(defaults *SessionDefaults) error {
+	if defaults == nil {
+		return nil
+	}
+	if defaults.Workload != "" {
+		if _, ok := querypb.ExecuteOptions_Workload_value[strings.ToUpper(defaults.Workload)]; !ok {
+			return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid Workload found in SessionDefaults: %v", defaults.Workload)
+		}
+	}
+	if defaults.TabletType != "" {
+		if _, ok := topodatapb.TabletType_value[strings.ToUpper(defaults.TabletType)]; !ok {
+			return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid TabletType found in SessionDefaults: %v", defaults.TabletType)
+		}
+	}
+	if defaults.TransactionMode != "" {
+		if _, ok := vtgatepb.TransactionMode_value[strings.ToUpper(defaults.TransactionMode)]; !ok {
+			return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid TransactionMode found in SessionDefaults: %v", defaults.TransactionMode)
 		}
 	}
+	if defaults.QueryTimeout < 0 {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid QueryTimeout found in SessionDefaults: %v", defaults.QueryTimeout)
+	}
+	if defaults.MaxQueryTimeout < 0 {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "invalid MaxQueryTimeout found in SessionDefaults: %v", defaults.MaxQueryTimeout)
+	}
+	if defaults.MaxQueryTimeout > 0 && defaults.QueryTimeout > defaults.MaxQueryTimeout {
+		return vterrors.Errorf(vtrpc.Code_INVALID_ARGUMENT, "QueryTimeout %v found in SessionDefaults exceeds MaxQueryTimeout %v", defaults.QueryTimeout, defaults.MaxQueryTimeout)
+	}
 	return nil
 }
 
@@ -376,10 +436,16 @@ func MatchSourceHost(remoteAddr net.Addr, targetSourceHost string) bool {
 	return false
 }
 
-// StaticUserData holds the username and groups
+// StaticUserData holds the username, groups and default session settings
 type StaticUserData struct {
-	Username string
-	Groups   []string
+	Username        string
+	Groups          []string
+	SessionDefaults *SessionDefaults
+}
+
+// GetSessionDefaults returns the default session settings of the user, if any
+func (sud *StaticUserData) GetSessionDefaults() *SessionDefaults {
+	return sud.SessionDefaults
 }
 
 // Get returns the wrapped username and groups
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/test/utils"
@@ -68,6 +69,31 @@ func TestJsonConfigParser(t *testing.T) {
 	require.Error(t, err, "Invalid config should have errored, but didn't")
 }
 
+func TestJsonConfigSessionDefaults(t *testing.T) {
+	_ = utils.LeakCheckContext(t)
+	config := make(map[string][]*AuthServerStaticEntry)
+	jsonConfig := `{"analytics":[
+		{"Password": "123", "UserData": "analytics", "SessionDefaults": {"Workload": "OLAP", "TabletType": "rdonly", "TransactionMode": "SINGLE", "QueryTimeout": 60000, "MaxQueryTimeout": 120000}}
+	]}`
+	err := ParseConfig([]byte(jsonConfig), &config)
+	require.NoError(t, err)
+	require.Equal(t, &SessionDefaults{Workload: "OLAP", TabletType: "rdonly", TransactionMode: "SINGLE", QueryTimeout: 60000, MaxQueryTimeout: 120000}, config["analytics"][0].SessionDefaults)
+
+	for _, invalid := range []string{
+		`{"Workload": "BATCH"}`,
+		`{"TabletType": "secondary"}`,
+		`{"TransactionMode": "MULTIPLE"}`,
+		`{"QueryTimeout": -1}`,
+		`{"MaxQueryTimeout": -1}`,
+		`{"QueryTimeout": 2000, "MaxQueryTimeout": 1000}`,
+	} {
+		config := make(map[string][]*AuthServerStaticEntry)
+		jsonConfig := `{"analytics": [{"Password": "123", "SessionDefaults": ` + invalid + `}]}`
+		err := ParseConfig([]byte(jsonConfig), &config)
+		assert.ErrorContains(t, err, "SessionDefaults", "expected error for %s", invalid)
+	}
+}
+
 func TestValidateHashGetter(t *testing.T) {
 	_ = utils.LeakCheckContext(t)
 	jsonConfig := `{"mysql_user": [{"Password": "password", "UserData": "user.name", "Groups": ["user_group"]}]}`
@@ -204,6 +204,33 @@ func (c *Conn) Ping() error {
 	return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected packet type: %d", data[0])
 }
 
+// ChangeUser authenticates the connection as another user, with
+// COM_CHANGE_USER. The server resets the session, and uses the database of
+// the params as the new default database.
+// Returns a SQLError.
+func (c *Conn) ChangeUser(params *ConnParams) error {
+	// This is a new command, need to reset the sequence.
+	c.sequence = 0
+	length := 1 + // command
+		lenNullString(params.Uname) +
+		1 + // length of the auth response, which is empty
+		lenNullString(params.DbName) +
+		2 + // character set
+		lenNullString(string(c.authPluginName))
+	data, pos := c.startEphemeralPacketWithHeader(length)
+	pos = writeByte(data, pos, ComChangeUser)
+	pos = writeNullString(data, pos, params.Uname)
+	pos = writeByte(data, pos, 0)
+	pos = writeNullString(data, pos, params.DbName)
+	pos = writeUint16(data, pos, uint16(c.CharacterSet))
+	_ = writeNullString(data, pos, string(c.authPluginName))
+
+	if err := c.writeEphemeralPacket(); err != nil {
+		return sqlerror.NewSQLErrorf(sqlerror.CRServerGone, sqlerror.SSUnknownSQLState, "%v", err)
+	}
+	return c.handleAuthResponse(params)
+}
+
 // clientHandshake handles the client side of the handshake.
 // Note the connection can be closed while this is running.
 // Returns a SQLError.
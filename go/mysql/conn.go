@@ -131,6 +131,11 @@ type Conn struct {
 	// connection handshake.
 	Attributes ConnectionAttributes
 
+	// QueryAttributes stores the query attributes the client sent along with
+	// the query or statement being executed. It is only set while the handler
+	// executes it.
+	QueryAttributes map[string]string
+
 	bufferedReader *bufio.Reader
 	flushTimer     *time.Timer
 	flushDelay     time.Duration
@@ -943,6 +948,8 @@ func (c *Conn) handleNextCommand(handler Handler) bool {
 	case ComResetConnection:
 		c.handleComResetConnection(handler)
 		return true
+	case ComChangeUser:
+		return c.handleComChangeUser(handler, data)
 	case ComFieldList:
 		c.recycleReadPacket()
 		if !c.writeErrorAndLog(sqlerror.ERUnknownComError, sqlerror.SSNetError, "command handling not implemented yet: %v", data[0]) {
@@ -1043,6 +1050,61 @@ func (c *Conn) handleComResetConnection(handler Handler) {
 	}
 }
 
+// handleComChangeUser authenticates the connection as another user. On
+// success the session is reset, as for COM_RESET_CONNECTION, and the initial
+// database of the new user is set. The connection is closed if the
+// authentication fails.
+func (c *Conn) handleComChangeUser(handler Handler, data []byte) bool {
+	user, clientAuthMethod, schemaName, ok := parseComChangeUser(data)
+	c.recycleReadPacket()
+	if !ok {
+		log.Errorf("Got malformed COM_CHANGE_USER packet from %s", c)
+		c.writeErrorPacket(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "error parsing COM_CHANGE_USER packet")
+		return false
+	}
+
+	userData, err := c.listener.authenticateChangeUser(c, user, clientAuthMethod)
+	if err != nil {
+		log.Warningf("Error changing user of %s to %s: %v", c, user, err)
+		c.writeErrorPacketFromError(err)
+		return false
+	}
+
+	// The state of the session of the previous user must not leak to the
+	// new one. Unlike COM_RESET_CONNECTION, the data of the handler, such as
+	// the database it keeps, is dropped too: the new user starts as on a new
+	// connection.
+	handler.ComResetConnection(c)
+	c.ClientData = nil
+	c.PrepareData = make(map[uint32]*PrepareData)
+
+	if c.User != "" {
+		connCountPerUser.Add(c.User, -1)
+	}
+	c.User = user
+	c.UserData = userData
+	c.schemaName = schemaName
+	if c.User != "" {
+		connCountPerUser.Add(c.User, 1)
+	}
+
+	if c.schemaName != "" {
+		err = handler.ComQuery(c, "use "+sqlescape.EscapeID(c.schemaName), func(result *sqltypes.Result) error {
+			return nil
+		})
+		if err != nil {
+			c.writeErrorPacketFromError(err)
+			return false
+		}
+	}
+
+	if err := c.writeOKPacket(&PacketOK{statusFlags: c.StatusFlags}); err != nil {
+		log.Errorf("Cannot write OK packet to %s: %v", c, err)
+		return false
+	}
+	return true
+}
+
 func (c *Conn) handleComStmtReset(data []byte) bool {
 	stmtID, ok := c.parseComStmtReset(data)
 	c.recycleReadPacket()
@@ -1113,7 +1175,7 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		}
 	}()
 	queryStart := time.Now()
-	stmtID, _, err := c.parseComStmtExecute(c.PrepareData, data)
+	stmtID, _, attributes, err := c.parseComStmtExecute(c.PrepareData, data)
 	c.recycleReadPacket()
 
 	if stmtID != uint32(0) {
@@ -1128,6 +1190,11 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 		return c.writeErrorPacketFromErrorAndLog(err)
 	}
 
+	c.QueryAttributes = attributes
+	defer func() {
+		c.QueryAttributes = nil
+	}()
+
 	receivedResult := false
 	// sendFinished is set if the response should just be an OK packet.
 	sendFinished := false
@@ -1194,6 +1261,10 @@ func (c *Conn) handleComStmtExecute(handler Handler, data []byte) (kontinue bool
 	return true
 }
 
+// errPrepareMultipleStatements is returned when a batch of statements is
+// prepared, as only a single statement can be prepared at a time.
+var errPrepareMultipleStatements = sqlerror.NewSQLError(sqlerror.ERParseError, sqlerror.SSClientError, "You have an error in your SQL syntax; only a single statement can be prepared")
+
 func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 	c.startWriterBuffering()
 	defer func() {
@@ -1214,7 +1285,7 @@ func (c *Conn) handleComPrepare(handler Handler, data []byte) (kontinue bool) {
 		}
 		if len(queries) != 1 {
 			log.Errorf("Conn %v: can not prepare multiple statements", c)
-			return c.writeErrorPacketFromErrorAndLog(err)
+			return c.writeErrorPacketFromErrorAndLog(errPrepareMultipleStatements)
 		}
 		query = queries[0]
 	}
@@ -1299,8 +1370,16 @@ func (c *Conn) handleComQueryMulti(handler Handler, data []byte) (kontinue bool)
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, attributes, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	c.QueryAttributes = attributes
+	defer func() {
+		c.QueryAttributes = nil
+	}()
 
 	res := c.execQueryMulti(query, handler)
 	if res != execSuccess {
@@ -1432,11 +1511,18 @@ func (c *Conn) handleComQuery(handler Handler, data []byte) (kontinue bool) {
 	}()
 
 	queryStart := time.Now()
-	query := c.parseComQuery(data)
+	query, attributes, err := c.parseComQuery(data)
 	c.recycleReadPacket()
+	if err != nil {
+		return c.writeErrorPacketFromErrorAndLog(err)
+	}
+
+	c.QueryAttributes = attributes
+	defer func() {
+		c.QueryAttributes = nil
+	}()
 
 	var queries []string
-	var err error
 	if c.Capabilities&CapabilityClientMultiStatements != 0 {
 		queries, err = handler.Env().Parser().SplitStatementToPieces(query)
 		if err != nil {
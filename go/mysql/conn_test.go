@@ -944,6 +944,29 @@ func TestMultiStatementOnSplitError(t *testing.T) {
 	}
 }
 
+func TestPrepareMultipleStatements(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	sConn.Capabilities |= CapabilityClientMultiStatements
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	err := cConn.writePacket(preparePacket(t, "select 1;select 2"))
+	require.NoError(t, err)
+
+	handler := &testRun{}
+	res := sConn.handleNextCommand(handler)
+	require.True(t, res, "we should not break the connection because of prepare errors")
+	require.Empty(t, sConn.PrepareData)
+
+	data, err := cConn.ReadPacket()
+	require.NoError(t, err)
+	require.EqualValues(t, ErrPacket, data[0])
+	require.EqualError(t, ParseErrorPacket(data), "You have an error in your SQL syntax; only a single statement can be prepared (errno 1064) (sqlstate 42000)")
+}
+
 func TestInitDbAgainstWrongDbDoesNotDropConnection(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	sConn.Capabilities |= CapabilityClientMultiStatements
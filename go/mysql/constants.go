@@ -152,6 +152,10 @@ const (
 	// CapabilityClientDeprecateEOF is CLIENT_DEPRECATE_EOF
 	// Expects an OK (instead of EOF) after the resultset rows of a Text Resultset.
 	CapabilityClientDeprecateEOF = 1 << 24
+
+	// CapabilityClientQueryAttributes is CLIENT_QUERY_ATTRIBUTES
+	// Can send query attributes along with COM_QUERY and COM_STMT_EXECUTE.
+	CapabilityClientQueryAttributes = 1 << 27
 )
 
 // Status flags. They are returned by the server in a few cases.
@@ -195,6 +199,14 @@ const (
 	SessionTrackGtids uint8 = 0x03
 )
 
+// COM_STMT_EXECUTE flags.
+// Originally found in include/mysql/mysql_com.h
+const (
+	// ParameterCountAvailable is PARAMETER_COUNT_AVAILABLE.
+	// The parameter count is sent, even if the statement has no parameters.
+	ParameterCountAvailable byte = 0x08
+)
+
 // Packet types.
 // Originally found in include/mysql/mysql_com.h
 const (
@@ -213,6 +225,9 @@ const (
 	// ComPing is COM_PING.
 	ComPing = 0x0e
 
+	// ComChangeUser is COM_CHANGE_USER.
+	ComChangeUser = 0x11
+
 	// ComBinlogDump is COM_BINLOG_DUMP.
 	ComBinlogDump = 0x12
 
@@ -575,8 +575,88 @@ func (c *Conn) readComQueryResponse(packetOk *PacketOK) (int, error) {
 // Server side methods.
 //
 
-func (c *Conn) parseComQuery(data []byte) string {
-	return string(data[1:])
+// parseComQuery parses a COM_QUERY packet, and returns its query and the
+// query attributes sent along with it, if any.
+func (c *Conn) parseComQuery(data []byte) (string, map[string]string, error) {
+	payload := data[1:]
+	if c.Capabilities&CapabilityClientQueryAttributes == 0 {
+		return string(payload), nil, nil
+	}
+
+	paramsCount, pos, ok := readLenEncInt(payload, 0)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+	}
+	// The parameter set count is always 1.
+	_, pos, ok = readLenEncInt(payload, pos)
+	if !ok {
+		return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter set count failed")
+	}
+
+	var attributes map[string]string
+	if paramsCount > 0 {
+		bitMap, newPos, ok := readBytes(payload, pos, (int(paramsCount)+7)/8)
+		if !ok {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+		}
+		pos = newPos
+
+		newParamsBoundFlag, newPos, ok := readByte(payload, pos)
+		if !ok || newParamsBoundFlag != 0x01 {
+			return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes sent without their types")
+		}
+		pos = newPos
+
+		types := make([]querypb.Type, paramsCount)
+		names := make([]string, paramsCount)
+		for i := range paramsCount {
+			types[i], names[i], pos, ok = c.parseStmtParamType(payload, pos, true)
+			if !ok {
+				return "", nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading query attribute type failed")
+			}
+		}
+
+		attributes = make(map[string]string, paramsCount)
+		for i := range paramsCount {
+			if (bitMap[i/8] & (1 << uint(i%8))) > 0 {
+				continue
+			}
+			var val sqltypes.Value
+			val, pos, ok = c.parseStmtArgs(payload, types[i], pos)
+			if !ok {
+				return "", nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", types[i])
+			}
+			attributes[names[i]] = val.ToString()
+		}
+	}
+
+	return string(payload[pos:]), attributes, nil
+}
+
+// parseStmtParamType parses the type of a parameter of a COM_STMT_EXECUTE or
+// COM_QUERY packet, followed by its name if withName is set.
+func (c *Conn) parseStmtParamType(data []byte, pos int, withName bool) (querypb.Type, string, int, bool) {
+	mysqlType, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, "", 0, false
+	}
+	flags, pos, ok := readByte(data, pos)
+	if !ok {
+		return 0, "", 0, false
+	}
+	// convert MySQL type to internal type.
+	valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
+	if err != nil {
+		return 0, "", 0, false
+	}
+	var name string
+	if withName {
+		name, pos, ok = readLenEncString(data, pos)
+		if !ok {
+			return 0, "", 0, false
+		}
+	}
+	return valType, name, pos, true
 }
 
 func (c *Conn) parseComSetOption(data []byte) (uint16, bool) {
@@ -588,7 +668,10 @@ func (c *Conn) parseComPrepare(data []byte) string {
 	return string(data[1:])
 }
 
-func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []byte) (uint32, byte, error) {
+// parseComStmtExecute parses a COM_STMT_EXECUTE packet into the bind
+// variables of its prepared statement, and returns the statement ID, the
+// cursor type flags and the query attributes sent along with it, if any.
+func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []byte) (uint32, byte, map[string]string, error) {
 	pos := 0
 	payload := data[1:]
 	bitMap := make([]byte, 0)
@@ -596,57 +679,76 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 	// statement ID
 	stmtID, pos, ok := readUint32(payload, 0)
 	if !ok {
-		return 0, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading statement ID failed")
+		return 0, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading statement ID failed")
 	}
 	prepare, ok := prepareData[stmtID]
 	if !ok {
-		return 0, 0, sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "statement ID is not found from record")
+		return 0, 0, nil, sqlerror.NewSQLError(sqlerror.CRCommandsOutOfSync, sqlerror.SSUnknownSQLState, "statement ID is not found from record")
 	}
 
 	// cursor type flags
 	cursorType, pos, ok := readByte(payload, pos)
 	if !ok {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading cursor type flags failed")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading cursor type flags failed")
 	}
 
 	// iteration count
 	iterCount, pos, ok := readUint32(payload, pos)
 	if !ok {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading iteration count failed")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading iteration count failed")
 	}
 	if iterCount != uint32(1) {
-		return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "iteration count is not equal to 1")
+	}
+
+	// With query attributes, the parameter count is sent, and the query
+	// attributes follow the parameters of the statement.
+	paramsCount := prepare.ParamsCount
+	withAttributes := c.Capabilities&CapabilityClientQueryAttributes != 0
+	if withAttributes && (paramsCount > 0 || cursorType&ParameterCountAvailable != 0) {
+		count, newPos, ok := readLenEncInt(payload, pos)
+		if !ok {
+			return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter count failed")
+		}
+		if count < uint64(paramsCount) || count > math.MaxUint16 {
+			return stmtID, 0, nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "invalid parameter count %d for a statement with %d parameters", count, paramsCount)
+		}
+		pos = newPos
+		paramsCount = uint16(count)
 	}
+	attributesCount := paramsCount - prepare.ParamsCount
 
-	if prepare.ParamsCount > 0 {
-		bitMap, pos, ok = readBytes(payload, pos, (int(prepare.ParamsCount)+7)/8)
+	if paramsCount > 0 {
+		bitMap, pos, ok = readBytes(payload, pos, (int(paramsCount)+7)/8)
 		if !ok {
-			return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
+			return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading NULL-bitmap failed")
 		}
 	}
 
+	var (
+		attributesTypes []querypb.Type
+		attributesNames []string
+	)
 	newParamsBoundFlag, pos, ok := readByte(payload, pos)
 	if ok && newParamsBoundFlag == 0x01 {
-		var mysqlType, flags byte
-		for i := range prepare.ParamsCount {
-			mysqlType, pos, ok = readByte(payload, pos)
-			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
-			}
-
-			flags, pos, ok = readByte(payload, pos)
+		attributesTypes = make([]querypb.Type, attributesCount)
+		attributesNames = make([]string, attributesCount)
+		for i := range paramsCount {
+			valType, name, newPos, ok := c.parseStmtParamType(payload, pos, withAttributes)
 			if !ok {
-				return stmtID, 0, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter flags failed")
+				return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "reading parameter type failed")
 			}
+			pos = newPos
 
-			// convert MySQL type to internal type.
-			valType, err := sqltypes.MySQLToType(mysqlType, int64(flags))
-			if err != nil {
-				return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "MySQLToType(%v,%v) failed: %v", mysqlType, flags, err)
+			if i < prepare.ParamsCount {
+				prepare.ParamsType[i] = int32(valType)
+			} else {
+				attributesTypes[i-prepare.ParamsCount] = valType
+				attributesNames[i-prepare.ParamsCount] = name
 			}
-
-			prepare.ParamsType[i] = int32(valType)
 		}
+	} else if attributesCount > 0 {
+		return stmtID, 0, nil, sqlerror.NewSQLError(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "query attributes sent without their types")
 	}
 
 	for i := range prepare.ParamsCount {
@@ -664,13 +766,30 @@ func (c *Conn) parseComStmtExecute(prepareData map[uint32]*PrepareData, data []b
 			val, pos, ok = c.parseStmtArgs(payload, querypb.Type(prepare.ParamsType[i]), pos)
 		}
 		if !ok {
-			return stmtID, 0, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding parameter value failed: %v", prepare.ParamsType[i])
+			return stmtID, 0, nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding parameter value failed: %v", prepare.ParamsType[i])
 		}
 
 		prepare.BindVars[parameterID] = sqltypes.ValueBindVariable(val)
 	}
 
-	return stmtID, cursorType, nil
+	var attributes map[string]string
+	if attributesCount > 0 {
+		attributes = make(map[string]string, attributesCount)
+		for i := range attributesCount {
+			j := prepare.ParamsCount + i
+			if (bitMap[j/8] & (1 << uint(j%8))) > 0 {
+				continue
+			}
+			var val sqltypes.Value
+			val, pos, ok = c.parseStmtArgs(payload, attributesTypes[i], pos)
+			if !ok {
+				return stmtID, 0, nil, sqlerror.NewSQLErrorf(sqlerror.CRMalformedPacket, sqlerror.SSUnknownSQLState, "decoding query attribute value failed: %v", attributesTypes[i])
+			}
+			attributes[attributesNames[i]] = val.ToString()
+		}
+	}
+
+	return stmtID, cursorType, attributes, nil
 }
 
 func (c *Conn) parseStmtArgs(data []byte, typ querypb.Type, pos int) (sqltypes.Value, int, bool) {
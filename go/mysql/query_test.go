@@ -241,7 +241,7 @@ func TestComStmtExecute(t *testing.T) {
 	// This is simulated packets for `select * from test_table where id = ?`
 	data := []byte{23, 18, 0, 0, 0, 128, 1, 0, 0, 0, 0, 1, 1, 128, 1}
 
-	stmtID, _, err := sConn.parseComStmtExecute(cConn.PrepareData, data)
+	stmtID, _, _, err := sConn.parseComStmtExecute(cConn.PrepareData, data)
 	require.NoError(t, err, "parseComStmtExeute failed: %v", err)
 	require.Equal(t, uint32(18), stmtID, "Parsed incorrect values")
 }
@@ -286,7 +286,7 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 		0x9e, 0x03, 0x66, 0x6f, 0x6f, 0x07, 0x66, 0x6f, 0x6f, 0x2c, 0x62, 0x61, 0x72,
 	}
 
-	stmtID, _, err := sConn.parseComStmtExecute(prepareDataMap, data[4:]) // first 4 are header
+	stmtID, _, _, err := sConn.parseComStmtExecute(prepareDataMap, data[4:]) // first 4 are header
 	require.NoError(t, err)
 	require.EqualValues(t, 1, stmtID)
 
@@ -322,6 +322,106 @@ func TestComStmtExecuteUpdStmt(t *testing.T) {
 	assert.EqualValues(t, querypb.Type_CHAR, prepData.ParamsType[28], "got: %s", querypb.Type(prepData.ParamsType[28]))
 }
 
+func TestComQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+
+	data := []byte{ComQuery}
+	data = append(data, []byte("select 1")...)
+	query, attributes, err := sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, attributes)
+
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	// Two attributes, the second one being NULL.
+	data = []byte{ComQuery, 0x02, 0x01, 0x02, 0x01}
+	data = append(data, 0xfe, 0x00, 14)
+	data = append(data, []byte("vt_tablet_type")...)
+	data = append(data, 0xfe, 0x00, 4)
+	data = append(data, []byte("none")...)
+	data = append(data, 7)
+	data = append(data, []byte("replica")...)
+	data = append(data, []byte("select 1")...)
+	query, attributes, err = sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Equal(t, map[string]string{"vt_tablet_type": "replica"}, attributes)
+
+	// No attributes.
+	data = []byte{ComQuery, 0x00, 0x01}
+	data = append(data, []byte("select 1")...)
+	query, attributes, err = sConn.parseComQuery(data)
+	require.NoError(t, err)
+	assert.Equal(t, "select 1", query)
+	assert.Nil(t, attributes)
+
+	// Attributes without their types.
+	data = []byte{ComQuery, 0x01, 0x01, 0x00, 0x00}
+	_, _, err = sConn.parseComQuery(data)
+	assert.ErrorContains(t, err, "query attributes sent without their types")
+}
+
+func TestComStmtExecuteQueryAttributes(t *testing.T) {
+	listener, sConn, cConn := createSocketPair(t)
+	defer func() {
+		listener.Close()
+		sConn.Close()
+		cConn.Close()
+	}()
+	sConn.Capabilities |= CapabilityClientQueryAttributes
+
+	prepareDataMap := map[uint32]*PrepareData{
+		1: {
+			StatementID: 1,
+			ParamsCount: 1,
+			ParamsType:  make([]int32, 1),
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+		2: {
+			StatementID: 2,
+			BindVars:    map[string]*querypb.BindVariable{},
+		},
+	}
+
+	// One parameter of type INT32 followed by one attribute.
+	data := []byte{ComStmtExecute, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x01}
+	data = append(data, 0x03, 0x00, 0x00)
+	data = append(data, 0xfe, 0x00, 14)
+	data = append(data, []byte("vt_tablet_type")...)
+	data = append(data, 0x2a, 0x00, 0x00, 0x00)
+	data = append(data, 7)
+	data = append(data, []byte("replica")...)
+	stmtID, _, attributes, err := sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stmtID)
+	assert.Equal(t, map[string]string{"vt_tablet_type": "replica"}, attributes)
+	assert.EqualValues(t, querypb.Type_INT32, prepareDataMap[1].ParamsType[0])
+	assert.Equal(t, sqltypes.Int64BindVariable(42), prepareDataMap[1].BindVars["v1"])
+
+	// A statement without parameters, with the parameter count sent.
+	data = []byte{ComStmtExecute, 0x02, 0x00, 0x00, 0x00, ParameterCountAvailable, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01}
+	data = append(data, 0xfe, 0x00, 4)
+	data = append(data, []byte("team")...)
+	data = append(data, 5)
+	data = append(data, []byte("infra")...)
+	stmtID, _, attributes, err = sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, stmtID)
+	assert.Equal(t, map[string]string{"team": "infra"}, attributes)
+
+	// A statement without parameters, without the parameter count sent.
+	data = []byte{ComStmtExecute, 0x02, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00}
+	_, _, attributes, err = sConn.parseComStmtExecute(prepareDataMap, data)
+	require.NoError(t, err)
+	assert.Nil(t, attributes)
+}
+
 func TestComStmtClose(t *testing.T) {
 	listener, sConn, cConn := createSocketPair(t)
 	defer func() {
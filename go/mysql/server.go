@@ -17,6 +17,7 @@ limitations under the License.
 package mysql
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"io"
@@ -273,13 +274,34 @@ func NewListener(
 		return nil, err
 	}
 	if proxyProtocol {
-		proxyListener := &proxyproto.Listener{Listener: listener}
+		proxyListener, err := NewProxyProtocolListener(listener, nil)
+		if err != nil {
+			return nil, err
+		}
 		return NewFromListener(proxyListener, authServer, handler, connReadTimeout, connWriteTimeout, connBufferPooling, keepAlivePeriod, flushDelay, multiQuery)
 	}
 
 	return NewFromListener(listener, authServer, handler, connReadTimeout, connWriteTimeout, connBufferPooling, keepAlivePeriod, flushDelay, multiQuery)
 }
 
+// NewProxyProtocolListener wraps the listener to read the v1 or v2 PROXY
+// protocol header sent by the load balancers in front of it, so that the
+// remote address of the connections is the one of the client.
+// If trustedProxies, a list of IP addresses and CIDR ranges, is not empty,
+// only the load balancers in it are trusted: the connections from any other
+// address which send a header are rejected.
+func NewProxyProtocolListener(listener net.Listener, trustedProxies []string) (net.Listener, error) {
+	proxyListener := &proxyproto.Listener{Listener: listener}
+	if len(trustedProxies) > 0 {
+		policy, err := proxyproto.ConnStrictWhiteListPolicy(trustedProxies)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid trusted proxies %v", trustedProxies)
+		}
+		proxyListener.ConnPolicy = policy
+	}
+	return proxyListener, nil
+}
+
 // ListenerConfig should be used with NewListenerWithConfig to specify listener parameters.
 type ListenerConfig struct {
 	// Protocol-Address pair and Listener are mutually exclusive parameters
@@ -524,8 +546,13 @@ func (l *Listener) handle(conn net.Conn, connectionID uint32, acceptTime time.Ti
 
 	if c.User != "" {
 		connCountPerUser.Add(c.User, 1)
-		defer connCountPerUser.Add(c.User, -1)
 	}
+	// The user can change with COM_CHANGE_USER.
+	defer func() {
+		if c.User != "" {
+			connCountPerUser.Add(c.User, -1)
+		}
+	}()
 
 	// Set initial db name.
 	if c.schemaName != "" {
@@ -595,7 +622,8 @@ func (c *Conn) writeHandshakeV10(serverVersion string, authServer AuthServer, ch
 		CapabilityClientPluginAuth |
 		CapabilityClientPluginAuthLenencClientData |
 		CapabilityClientDeprecateEOF |
-		CapabilityClientConnAttr
+		CapabilityClientConnAttr |
+		CapabilityClientQueryAttributes
 	if enableTLS {
 		capabilities |= CapabilityClientSSL
 	}
@@ -715,7 +743,7 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 	// later in the protocol. If we re-received the handshake packet
 	// after SSL negotiation, do not overwrite capabilities.
 	if firstTime {
-		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows)
+		c.Capabilities = clientFlags & (CapabilityClientDeprecateEOF | CapabilityClientFoundRows | CapabilityClientQueryAttributes)
 	}
 
 	// set connection capability for executing multi statements
@@ -825,6 +853,77 @@ func (l *Listener) parseClientHandshakePacket(c *Conn, firstTime bool, data []by
 	return username, AuthMethodDescription(authMethod), authResponse, nil
 }
 
+// parseComChangeUser parses a COM_CHANGE_USER packet. The auth response of
+// the client is ignored, see authenticateChangeUser.
+func parseComChangeUser(data []byte) (user string, authMethod AuthMethodDescription, schemaName string, ok bool) {
+	pos := 1
+	user, pos, ok = readNullString(data, pos)
+	if !ok {
+		return "", "", "", false
+	}
+	authResponseLen, pos, ok := readByte(data, pos)
+	if !ok {
+		return "", "", "", false
+	}
+	_, pos, ok = readBytes(data, pos, int(authResponseLen))
+	if !ok {
+		return "", "", "", false
+	}
+	schemaName, pos, ok = readNullString(data, pos)
+	if !ok {
+		return "", "", "", false
+	}
+
+	// The character set and the auth method are only sent by newer clients.
+	if _, pos, ok = readUint16(data, pos); !ok {
+		return user, "", schemaName, true
+	}
+	authMethodStr, _, ok := readNullString(data, pos)
+	if !ok {
+		return user, "", schemaName, true
+	}
+	return user, AuthMethodDescription(authMethodStr), schemaName, true
+}
+
+// authenticateChangeUser authenticates the new user of a connection which
+// received a COM_CHANGE_USER. The auth response in that packet is computed
+// from the plugin data of the initial handshake, which is not kept, so the
+// client is always asked to switch to the negotiated auth method with new
+// plugin data.
+func (l *Listener) authenticateChangeUser(c *Conn, user string, clientAuthMethod AuthMethodDescription) (Getter, error) {
+	authMethod, err := negotiateAuthMethod(c, l.authServer, user, clientAuthMethod)
+	if err != nil {
+		for _, m := range l.authServer.AuthMethods() {
+			if m.HandleUser(c, user) {
+				authMethod = m
+				break
+			}
+		}
+	}
+	if authMethod == nil {
+		return nil, sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "No authentication methods available for authentication.")
+	}
+	if !l.AllowClearTextWithoutTLS.Load() && !c.TLSEnabled() && !authMethod.AllowClearTextWithoutTLS() {
+		return nil, sqlerror.NewSQLError(sqlerror.CRServerHandshakeErr, sqlerror.SSUnknownSQLState, "Cannot use clear text authentication over non-SSL connections.")
+	}
+
+	serverAuthPluginData, err := authMethod.AuthPluginData()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.writeAuthSwitchRequest(string(authMethod.Name()), serverAuthPluginData); err != nil {
+		return nil, err
+	}
+	clientAuthResponse, err := c.readEphemeralPacket()
+	if err != nil {
+		return nil, err
+	}
+	clientAuthResponse = bytes.Clone(clientAuthResponse)
+	c.recycleReadPacket()
+
+	return authMethod.HandleAuthPluginData(c, user, serverAuthPluginData, clientAuthResponse, c.RemoteAddr())
+}
+
 func parseConnAttrs(data []byte, pos int) (ConnectionAttributes, int, error) {
 	attrs := make(map[string]string)
 
@@ -624,6 +624,62 @@ func TestConnCounts(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 }
 
+func TestChangeUser(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	th := &testHandler{}
+
+	authServer := NewAuthServerStatic("", "", 0)
+	authServer.entries["changeUser1"] = []*AuthServerStaticEntry{{
+		Password: "password1",
+		UserData: "userData1",
+	}}
+	authServer.entries["changeUser2"] = []*AuthServerStaticEntry{{
+		Password: "password2",
+		UserData: "userData2",
+	}}
+	defer authServer.close()
+
+	l, err := NewListener("tcp", "127.0.0.1:", authServer, th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	host, port := getHostPort(t, l.Addr())
+	params := &ConnParams{
+		Host:  host,
+		Port:  port,
+		Uname: "changeUser1",
+		Pass:  "password1",
+	}
+	go l.Accept()
+	defer cleanupListener(ctx, l, params)
+
+	c, err := Connect(ctx, params)
+	require.NoError(t, err)
+	defer c.Close()
+	checkCountsForUser(t, "changeUser1", 1)
+
+	err = c.ChangeUser(&ConnParams{Uname: "changeUser2", Pass: "password2", DbName: "db2"})
+	require.NoError(t, err)
+	assert.Equal(t, "changeUser2", c.User)
+	checkCountsForUser(t, "changeUser1", 0)
+	checkCountsForUser(t, "changeUser2", 1)
+
+	result, err := c.ExecuteFetch("userData echo", 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "changeUser2", result.Rows[0][0].ToString())
+	assert.Equal(t, "userData2", result.Rows[0][1].ToString())
+	result, err = c.ExecuteFetch("schema echo", 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, "db2", result.Rows[0][0].ToString())
+
+	// A failed authentication closes the connection.
+	err = c.ChangeUser(&ConnParams{Uname: "changeUser1", Pass: "bad"})
+	assert.ErrorContains(t, err, "Access denied for user 'changeUser1'")
+	_, err = c.ExecuteFetch("userData echo", 1, false)
+	assert.Error(t, err)
+	assert.EventuallyWithT(t, func(t *assert.CollectT) {
+		checkCountsForUser(t, "changeUser2", 0)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
 func checkCountsForUser(t assert.TestingT, user string, expected int64) {
 	connCounts := connCountPerUser.Counts()
 
@@ -1638,3 +1694,60 @@ func TestTcpKeepAlive(t *testing.T) {
 	err = setTcpConnProperties(th.lastConn.conn.(*net.TCPConn), 0)
 	require.ErrorContains(t, err, "unable to enable keepalive on tcp connection")
 }
+
+func TestProxyProtocolListener(t *testing.T) {
+	_, err := NewProxyProtocolListener(nil, []string{"not-an-ip"})
+	require.ErrorContains(t, err, "invalid trusted proxies")
+
+	tcs := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		wantErr        bool
+	}{
+		{
+			name:       "no allowlist",
+			remoteAddr: "192.0.2.1:5000",
+		},
+		{
+			name:           "trusted proxy",
+			trustedProxies: []string{"127.0.0.1", "10.0.0.0/8"},
+			remoteAddr:     "192.0.2.1:5000",
+		},
+		{
+			name:           "untrusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			wantErr:        true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			proxyListener, err := NewProxyProtocolListener(listener, tc.trustedProxies)
+			require.NoError(t, err)
+
+			client, err := net.Dial("tcp", listener.Addr().String())
+			require.NoError(t, err)
+			defer client.Close()
+			_, err = client.Write([]byte("PROXY TCP4 192.0.2.1 127.0.0.1 5000 3306\r\nhello"))
+			require.NoError(t, err)
+
+			conn, err := proxyListener.Accept()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			buf := make([]byte, 5)
+			_, err = conn.Read(buf)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(buf))
+			assert.Equal(t, tc.remoteAddr, conn.RemoteAddr().String())
+		})
+	}
+}
@@ -253,6 +253,7 @@ const (
 	ERWrongValue                   = ErrorCode(1525)
 	ERWrongParamcountToNativeFct   = ErrorCode(1582)
 	ERDataOutOfRange               = ErrorCode(1690)
+	ERNonDefaultValueForGenerated  = ErrorCode(3105)
 	ERInvalidJSONText              = ErrorCode(3140)
 	ERInvalidJSONTextInParams      = ErrorCode(3141)
 	ERInvalidJSONBinaryData        = ErrorCode(3142)
@@ -267,6 +268,7 @@ const (
 	ERCTERecursiveForbiddenJoinOrder      = ErrorCode(3576)
 	ERCTERecursiveRequiresSingleReference = ErrorCode(3577)
 	ERCTEMaxRecursionDepth                = ErrorCode(3636)
+	ERCheckConstraintViolated             = ErrorCode(3819)
 	ERRegexpStringNotTerminated           = ErrorCode(3684)
 	ERRegexpBufferOverflow                = ErrorCode(3684)
 	ERRegexpIllegalArgument               = ErrorCode(3685)
@@ -53,6 +53,26 @@ func TestDemuxResourceExhaustedErrors(t *testing.T) {
 	}
 }
 
+func TestVtRpcErrorCode(t *testing.T) {
+	tCases := []struct {
+		num  ErrorCode
+		want vtrpc.Code
+	}{
+		{num: ERLockWaitTimeout, want: vtrpc.Code_DEADLINE_EXCEEDED},
+		// NOWAIT fails right away on a locked row, instead of waiting for it.
+		{num: ERLockNowait, want: vtrpc.Code_DEADLINE_EXCEEDED},
+		{num: ERLockDeadlock, want: vtrpc.Code_ABORTED},
+		{num: ERDupEntry, want: vtrpc.Code_ALREADY_EXISTS},
+	}
+
+	for _, tc := range tCases {
+		t.Run(tc.num.ToString(), func(t *testing.T) {
+			err := NewSQLError(tc.num, SSUnknownSQLState, "error")
+			assert.Equal(t, tc.want, err.VtRpcErrorCode())
+		})
+	}
+}
+
 func TestNewSQLErrorFromError(t *testing.T) {
 	tCases := []struct {
 		err error
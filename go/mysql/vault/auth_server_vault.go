@@ -167,17 +167,17 @@ func (a *AuthServerVault) UserEntryWithHash(conn *mysql.Conn, salt []byte, user
 		if entry.MysqlNativePassword != "" {
 			hash, err := mysql.DecodePasswordHex(entry.MysqlNativePassword)
 			if err != nil {
-				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
+				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups, SessionDefaults: entry.SessionDefaults}, sqlerror.NewSQLErrorf(sqlerror.ERAccessDeniedError, sqlerror.SSAccessDeniedError, "Access denied for user '%v'", user)
 			}
 			isPass := mysql.VerifyHashedMysqlNativePassword(authResponse, salt, hash)
 			if mysql.MatchSourceHost(remoteAddr, entry.SourceHost) && isPass {
-				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups}, nil
+				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups, SessionDefaults: entry.SessionDefaults}, nil
 			}
 		} else {
 			computedAuthResponse := mysql.ScrambleMysqlNativePassword(salt, []byte(entry.Password))
 			// Validate the password.
 			if mysql.MatchSourceHost(remoteAddr, entry.SourceHost) && subtle.ConstantTimeCompare(authResponse, computedAuthResponse) == 1 {
-				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups}, nil
+				return &mysql.StaticUserData{Username: entry.UserData, Groups: entry.Groups, SessionDefaults: entry.SessionDefaults}, nil
 			}
 		}
 	}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CPUTimes is the time spent by all the CPUs of the system since boot, in
+// clock ticks.
+type CPUTimes struct {
+	Busy  uint64
+	Total uint64
+}
+
+// UtilizationSince returns the ratio of time the CPUs were busy between prev
+// and t. Range: 0.0 (idle) - 1.0 (fully busy)
+func (t CPUTimes) UtilizationSince(prev CPUTimes) float64 {
+	if t.Total <= prev.Total || t.Busy < prev.Busy {
+		return 0
+	}
+	return min(float64(t.Busy-prev.Busy)/float64(t.Total-prev.Total), 1)
+}
+
+// parseCPUTimes parses the aggregated CPU line of /proc/stat.
+// Input such as "cpu  4705 356 584 3699176 23060 0 277 0 0 0"
+func parseCPUTimes(content string) (CPUTimes, error) {
+	line, _, _ := strings.Cut(content, "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return CPUTimes{}, fmt.Errorf("unexpected cpu stat content: %s", line)
+	}
+	var times CPUTimes
+	// user, nice, system, idle, iowait, irq, softirq, steal. The guest times
+	// are already accounted for in the user times.
+	for i, field := range fields[1:min(len(fields), 9)] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return CPUTimes{}, fmt.Errorf("unexpected cpu stat content: %s: %w", line, err)
+		}
+		times.Total += value
+		if i != 3 && i != 4 {
+			// not idle nor iowait
+			times.Busy += value
+		}
+	}
+	return times, nil
+}
+
+// parseDiskIOTicks parses the time spent doing I/Os, in milliseconds, out of
+// the content of a block device stat file.
+// Input such as "  1597  54  131862  757  1022  2271  28680  1529  0  1812  2286  0  0  0  0"
+func parseDiskIOTicks(content string) (uint64, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 10 {
+		return 0, fmt.Errorf("unexpected block device stat content: %s", content)
+	}
+	return strconv.ParseUint(fields[9], 10, 64)
+}
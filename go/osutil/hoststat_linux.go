@@ -0,0 +1,56 @@
+//go:build linux
+
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// ReadCPUTimes returns the time spent by all the CPUs since boot. This works on linux systems.
+// On other systems, it returns zero times with no error.
+func ReadCPUTimes() (CPUTimes, error) {
+	content, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return CPUTimes{}, err
+	}
+	return parseCPUTimes(string(content))
+}
+
+// DiskIOTime returns the time spent doing I/Os since boot by the block device on which the given
+// path is located. This works on linux systems. On other systems, it returns 0 with no error.
+func DiskIOTime(path string) (time.Duration, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, err
+	}
+	statFile := fmt.Sprintf("/sys/dev/block/%d:%d/stat", unix.Major(st.Dev), unix.Minor(st.Dev))
+	content, err := os.ReadFile(statFile)
+	if err != nil {
+		return 0, err
+	}
+	ticks, err := parseDiskIOTicks(string(content))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ticks) * time.Millisecond, nil
+}
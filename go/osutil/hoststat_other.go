@@ -0,0 +1,35 @@
+//go:build !linux
+
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"time"
+)
+
+// ReadCPUTimes returns the time spent by all the CPUs since boot. This works on linux systems.
+// On other systems, it returns zero times with no error.
+func ReadCPUTimes() (CPUTimes, error) {
+	return CPUTimes{}, nil
+}
+
+// DiskIOTime returns the time spent doing I/Os since boot by the block device on which the given
+// path is located. This works on linux systems. On other systems, it returns 0 with no error.
+func DiskIOTime(path string) (time.Duration, error) {
+	return 0, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPUTimes(t *testing.T) {
+	times, err := parseCPUTimes("cpu  100 10 40 800 50 0 0 0 5 0\ncpu0 100 10 40 800 50 0 0 0 5 0\n")
+	require.NoError(t, err)
+	assert.EqualValues(t, 150, times.Busy)
+	assert.EqualValues(t, 1000, times.Total)
+
+	_, err = parseCPUTimes("cpu0 100 10 40 800")
+	assert.Error(t, err)
+	_, err = parseCPUTimes("cpu  100 x 40 800 50")
+	assert.Error(t, err)
+
+	next := CPUTimes{Busy: 200, Total: 1100}
+	assert.Equal(t, 0.5, next.UtilizationSince(times))
+	assert.Equal(t, 0.0, times.UtilizationSince(times))
+	assert.Equal(t, 0.0, times.UtilizationSince(next))
+}
+
+func TestParseDiskIOTicks(t *testing.T) {
+	ticks, err := parseDiskIOTicks("  1597  54  131862  757  1022  2271  28680  1529  0  1812  2286  0  0  0  0\n")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1812, ticks)
+
+	_, err = parseDiskIOTicks("1597 54 131862")
+	assert.Error(t, err)
+}
+
+func TestReadCPUTimes(t *testing.T) {
+	times, err := ReadCPUTimes()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, times.Total, times.Busy)
+}
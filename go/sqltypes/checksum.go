@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqltypes
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// RowsChecksum updates the checksum crc with the rows, and returns it.
+// The checksum of rows is the CRC-32C of their values, in order, each one
+// encoded as in the rows of the MySQL text protocol: a length-encoded string,
+// or 0xfb for NULL. The checksum of rows streamed in several results is the
+// one of the rows of the first result, updated with the ones of the next ones.
+func RowsChecksum(crc uint32, rows []Row) uint32 {
+	var header [9]byte
+	for _, row := range rows {
+		for _, val := range row {
+			if val.IsNull() {
+				header[0] = 0xfb
+				crc = crc32.Update(crc, castagnoliTable, header[:1])
+				continue
+			}
+			raw := val.Raw()
+			crc = crc32.Update(crc, castagnoliTable, appendLenEncInt(header[:0], uint64(len(raw))))
+			crc = crc32.Update(crc, castagnoliTable, raw)
+		}
+	}
+	return crc
+}
+
+// appendLenEncInt appends the length-encoded integer i to buf.
+func appendLenEncInt(buf []byte, i uint64) []byte {
+	switch {
+	case i < 251:
+		return append(buf, byte(i))
+	case i < 1<<16:
+		return binary.LittleEndian.AppendUint16(append(buf, 0xfc), uint16(i))
+	case i < 1<<24:
+		return append(buf, 0xfd, byte(i), byte(i>>8), byte(i>>16))
+	default:
+		return binary.LittleEndian.AppendUint64(append(buf, 0xfe), i)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqltypes
+
+import (
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowsChecksum(t *testing.T) {
+	rows := []Row{
+		{NewVarChar("a"), NULL},
+		{NewInt64(12), NewVarChar("")},
+	}
+	want := crc32.Checksum([]byte{1, 'a', 0xfb, 2, '1', '2', 0}, crc32.MakeTable(crc32.Castagnoli))
+	assert.Equal(t, want, RowsChecksum(0, rows))
+
+	// The checksum of streamed rows is updated with each result.
+	assert.Equal(t, want, RowsChecksum(RowsChecksum(0, rows[:1]), rows[1:]))
+
+	// NULL and the empty string differ.
+	assert.NotEqual(t, RowsChecksum(0, []Row{{NULL}}), RowsChecksum(0, []Row{{NewVarChar("")}}))
+
+	assert.Zero(t, RowsChecksum(0, nil))
+
+	long := strings.Repeat("x", 300)
+	want = crc32.Checksum(append([]byte{0xfc, 0x2c, 0x01}, long...), crc32.MakeTable(crc32.Castagnoli))
+	assert.Equal(t, want, RowsChecksum(0, []Row{{NewVarChar(long)}}))
+}
@@ -107,6 +107,7 @@ func ResultToProto3(qr *Result) *querypb.QueryResult {
 		Rows:                RowsToProto3(qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Checksum:            qr.Checksum,
 	}
 }
 
@@ -124,6 +125,7 @@ func Proto3ToResult(qr *querypb.QueryResult) *Result {
 		Rows:                proto3ToRows(qr.Fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Checksum:            qr.Checksum,
 	}
 }
 
@@ -142,6 +144,7 @@ func CustomProto3ToResult(fields []*querypb.Field, qr *querypb.QueryResult) *Res
 		Rows:                proto3ToRows(fields, qr.Rows),
 		Info:                qr.Info,
 		SessionStateChanges: qr.SessionStateChanges,
+		Checksum:            qr.Checksum,
 	}
 }
 
@@ -36,6 +36,7 @@ type Result struct {
 	Rows                []Row            `json:"rows"`
 	SessionStateChanges string           `json:"session_state_changes"`
 	StatusFlags         uint16           `json:"status_flags"`
+	Checksum            uint32           `json:"checksum,omitempty"`
 	Info                string           `json:"info"`
 }
 
@@ -106,6 +107,7 @@ func (result *Result) Copy() *Result {
 		InsertIDChanged:     result.InsertIDChanged,
 		SessionStateChanges: result.SessionStateChanges,
 		StatusFlags:         result.StatusFlags,
+		Checksum:            result.Checksum,
 		Info:                result.Info,
 	}
 	if result.Fields != nil {
@@ -133,6 +135,7 @@ func (result *Result) ShallowCopy() *Result {
 		Info:                result.Info,
 		SessionStateChanges: result.SessionStateChanges,
 		Rows:                result.Rows,
+		Checksum:            result.Checksum,
 	}
 }
 
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streamlog
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+)
+
+var (
+	fileRotations   = stats.NewCountersWithSingleLabel("StreamlogFileRotations", "Rotations of the stream log files", "Log")
+	fileWriteErrors = stats.NewCountersWithSingleLabel("StreamlogFileWriteErrors", "Stream log records dropped because they could not be written to the log file", "Log")
+)
+
+// rotatedTimeFormat is the format of the suffix of the rotated files, which
+// sorts them in the order they were rotated.
+const rotatedTimeFormat = "20060102T150405.000000000"
+
+// compressedSuffix is the suffix of the rotated files compressed with zstd.
+const compressedSuffix = ".zst"
+
+// FileRotationConfig configures the built-in rotation of the stream log
+// files. A log file is rotated when it reaches MaxSize or when it has been
+// written to for longer than Interval, by renaming it with the time of the
+// rotation as suffix. With none of them set, the log file is only reopened on
+// SIGUSR2, for external tools such as logrotate.
+type FileRotationConfig struct {
+	// MaxSize is the size in bytes after which the log file is rotated.
+	MaxSize int64
+	// Interval is the duration after which the log file is rotated.
+	Interval time.Duration
+	// Compress compresses the rotated files with zstd.
+	Compress bool
+	// MaxFiles is the number of rotated files kept, 0 to keep them all.
+	MaxFiles int
+	// MaxAge is how long the rotated files are kept, 0 to keep them forever.
+	MaxAge time.Duration
+}
+
+func (config FileRotationConfig) enabled() bool {
+	return config.MaxSize > 0 || config.Interval > 0
+}
+
+// rotatingFile is a log file rotated according to its FileRotationConfig.
+// It is not safe for concurrent use, except for its background compression
+// and cleanup of the rotated files.
+type rotatingFile struct {
+	name   string
+	path   string
+	config FileRotationConfig
+	now    func() time.Time
+
+	f      *os.File
+	size   int64
+	opened time.Time
+
+	// wg tracks the compression and cleanup of the rotated files.
+	wg sync.WaitGroup
+}
+
+func openRotatingFile(name, path string, config FileRotationConfig) (*rotatingFile, error) {
+	rf := &rotatingFile{
+		name:   name,
+		path:   path,
+		config: config,
+		now:    time.Now,
+	}
+	if err := rf.reopen(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// reopen closes the log file if it is open, and opens the file at its path
+// for appending.
+func (rf *rotatingFile) reopen() error {
+	if rf.f != nil {
+		rf.f.Close()
+		rf.f = nil
+	}
+	f, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	rf.f = f
+	rf.size = 0
+	if fi, err := f.Stat(); err == nil {
+		rf.size = fi.Size()
+	}
+	rf.opened = rf.now()
+	return nil
+}
+
+// Write writes a record to the log file, after rotating it if the record
+// would make it exceed its max size or if its interval has elapsed.
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	if rf.shouldRotate(len(p)) {
+		if err := rf.rotate(); err != nil {
+			log.Errorf("Failed to rotate the %s log file %s: %v", rf.name, rf.path, err)
+		}
+	}
+	if rf.f == nil {
+		if err := rf.reopen(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+func (rf *rotatingFile) shouldRotate(n int) bool {
+	if rf.size == 0 {
+		return false
+	}
+	if rf.config.MaxSize > 0 && rf.size+int64(n) > rf.config.MaxSize {
+		return true
+	}
+	return rf.config.Interval > 0 && rf.now().Sub(rf.opened) >= rf.config.Interval
+}
+
+// rotate renames the log file and opens a new one at its path. The rotated
+// file is then compressed and the old rotated files are removed in the
+// background.
+func (rf *rotatingFile) rotate() error {
+	if rf.f != nil {
+		rf.f.Close()
+		rf.f = nil
+	}
+	rotated := rf.path + "." + rf.now().UTC().Format(rotatedTimeFormat)
+	if err := os.Rename(rf.path, rotated); err != nil {
+		return err
+	}
+	fileRotations.Add(rf.name, 1)
+
+	rf.wg.Add(1)
+	go func() {
+		defer rf.wg.Done()
+		if rf.config.Compress {
+			if err := compressFile(rotated); err != nil {
+				log.Errorf("Failed to compress the rotated %s log file %s: %v", rf.name, rotated, err)
+			}
+		}
+		rf.removeExpired()
+	}()
+	return rf.reopen()
+}
+
+// Close closes the log file, and waits for the rotated files to be
+// compressed.
+func (rf *rotatingFile) Close() error {
+	var err error
+	if rf.f != nil {
+		err = rf.f.Close()
+		rf.f = nil
+	}
+	rf.wg.Wait()
+	return err
+}
+
+// compressFile replaces the file with its zstd compressed version.
+func compressFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(path+compressedSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw, err := zstd.NewWriter(out, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		out.Close()
+		return err
+	}
+	if _, err := io.Copy(zw, in); err != nil {
+		zw.Close()
+		out.Close()
+		os.Remove(path + compressedSuffix)
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		out.Close()
+		os.Remove(path + compressedSuffix)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(path + compressedSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// rotatedFiles returns the rotated files of the log file, oldest first. The
+// files being compressed are not returned.
+func (rf *rotatingFile) rotatedFiles() ([]string, error) {
+	dir, base := filepath.Split(rf.path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		suffix, ok := strings.CutPrefix(entry.Name(), base+".")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		suffix = strings.TrimSuffix(suffix, compressedSuffix)
+		if _, err := time.Parse(rotatedTimeFormat, suffix); err != nil {
+			continue
+		}
+		if rf.config.Compress && !strings.HasSuffix(entry.Name(), compressedSuffix) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// removeExpired removes the rotated files beyond the max number of files or
+// older than the max age.
+func (rf *rotatingFile) removeExpired() {
+	if rf.config.MaxFiles <= 0 && rf.config.MaxAge <= 0 {
+		return
+	}
+	files, err := rf.rotatedFiles()
+	if err != nil {
+		log.Errorf("Failed to list the rotated %s log files: %v", rf.name, err)
+		return
+	}
+	for i, file := range files {
+		expired := rf.config.MaxFiles > 0 && i < len(files)-rf.config.MaxFiles
+		if !expired && rf.config.MaxAge > 0 {
+			if fi, err := os.Stat(file); err == nil {
+				expired = rf.now().Sub(fi.ModTime()) > rf.config.MaxAge
+			}
+		}
+		if !expired {
+			continue
+		}
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			log.Errorf("Failed to remove the rotated %s log file %s: %v", rf.name, file, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package streamlog
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a clock which advances by a second on every call.
+func fakeClock() func() time.Time {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func readCompressed(t *testing.T, file string) string {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	zr, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer zr.Close()
+	contents, err := zr.DecodeAll(data, nil)
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func TestRotatingFileMaxSize(t *testing.T) {
+	logPath := path.Join(t.TempDir(), "test.log")
+	rf, err := openRotatingFile("TestRotatingFileMaxSize", logPath, FileRotationConfig{
+		MaxSize:  10,
+		Compress: true,
+		MaxFiles: 2,
+	})
+	require.NoError(t, err)
+	rf.now = fakeClock()
+	rotations := fileRotations.Counts()["TestRotatingFileMaxSize"]
+
+	for _, record := range []string{"record 1\n", "record 2\n", "record 3\n", "record 4\n"} {
+		_, err := rf.Write([]byte(record))
+		require.NoError(t, err)
+	}
+	require.NoError(t, rf.Close())
+
+	contents, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "record 4\n", string(contents))
+
+	// The first rotated file was removed, and the others were compressed.
+	rotated, err := rf.rotatedFiles()
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	assert.Equal(t, "record 2\n", readCompressed(t, rotated[0]))
+	assert.Equal(t, "record 3\n", readCompressed(t, rotated[1]))
+	assert.EqualValues(t, 3, fileRotations.Counts()["TestRotatingFileMaxSize"]-rotations)
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	logPath := path.Join(t.TempDir(), "test.log")
+	// A pre-existing log file is appended to.
+	require.NoError(t, os.WriteFile(logPath, []byte("record 0\n"), 0o644))
+	rf, err := openRotatingFile("TestRotatingFileInterval", logPath, FileRotationConfig{
+		Interval: time.Minute,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	rf.now = func() time.Time { return now }
+
+	_, err = rf.Write([]byte("record 1\n"))
+	require.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = rf.Write([]byte("record 2\n"))
+	require.NoError(t, err)
+	require.NoError(t, rf.Close())
+
+	contents, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "record 2\n", string(contents))
+
+	rotated, err := rf.rotatedFiles()
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+	contents, err = os.ReadFile(rotated[0])
+	require.NoError(t, err)
+	assert.Equal(t, "record 0\nrecord 1\n", string(contents))
+}
+
+func TestRotatingFileMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	logPath := path.Join(dir, "test.log")
+	old := logPath + "." + time.Now().Add(-time.Hour).UTC().Format(rotatedTimeFormat)
+	require.NoError(t, os.WriteFile(old, []byte("old\n"), 0o644))
+	require.NoError(t, os.Chtimes(old, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+	// Files which were not rotated by the logger are left alone.
+	other := logPath + ".bak"
+	require.NoError(t, os.WriteFile(other, []byte("other\n"), 0o644))
+
+	rf, err := openRotatingFile("TestRotatingFileMaxAge", logPath, FileRotationConfig{
+		MaxSize: 1,
+		MaxAge:  time.Minute,
+	})
+	require.NoError(t, err)
+	for _, record := range []string{"record 1\n", "record 2\n"} {
+		_, err := rf.Write([]byte(record))
+		require.NoError(t, err)
+	}
+	require.NoError(t, rf.Close())
+
+	rotated, err := rf.rotatedFiles()
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+	assert.NotEqual(t, old, rotated[0])
+	assert.FileExists(t, other)
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package streamlog
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math/rand/v2"
@@ -72,6 +73,8 @@ type QueryLogConfig struct {
 	TimeThreshold         time.Duration
 	sampleRate            float64
 	EmitOnAnyConditionMet bool
+	// FileRotation configures the rotation of the query log files.
+	FileRotation FileRotationConfig
 }
 
 var queryLogConfigInstance = QueryLogConfig{
@@ -119,6 +122,13 @@ func registerStreamLogFlags(fs *pflag.FlagSet) {
 
 	// EmitOnAnyConditionMet logs queries on any condition met (time/row/filtertag)
 	fs.BoolVar(&queryLogConfigInstance.EmitOnAnyConditionMet, "querylog-emit-on-any-condition-met", queryLogConfigInstance.EmitOnAnyConditionMet, "Emit to query log when any of the conditions (row-threshold, time-threshold, filter-tag) is met (default false)")
+
+	// FileRotation controls the built-in rotation of the query log files
+	fs.Int64Var(&queryLogConfigInstance.FileRotation.MaxSize, "querylog-rotate-max-size", queryLogConfigInstance.FileRotation.MaxSize, "Size in bytes after which the query log file is rotated. 0 disables size based rotation.")
+	fs.DurationVar(&queryLogConfigInstance.FileRotation.Interval, "querylog-rotate-interval", queryLogConfigInstance.FileRotation.Interval, "Duration after which the query log file is rotated. 0 disables time based rotation.")
+	fs.BoolVar(&queryLogConfigInstance.FileRotation.Compress, "querylog-rotate-compress", queryLogConfigInstance.FileRotation.Compress, "Compress the rotated query log files with zstd.")
+	fs.IntVar(&queryLogConfigInstance.FileRotation.MaxFiles, "querylog-rotate-max-files", queryLogConfigInstance.FileRotation.MaxFiles, "Number of rotated query log files to keep. 0 keeps all of them.")
+	fs.DurationVar(&queryLogConfigInstance.FileRotation.MaxAge, "querylog-rotate-max-age", queryLogConfigInstance.FileRotation.MaxAge, "Duration after which the rotated query log files are removed. 0 keeps them forever.")
 }
 
 // StreamLogger is a non-blocking broadcaster of messages.
@@ -225,30 +235,44 @@ func (logger *StreamLogger[T]) ServeLogs(url string, logf LogFormatter) {
 }
 
 // LogToFile starts logging to the specified file path and will reopen the
-// file in response to SIGUSR2.
+// file in response to SIGUSR2. The file is also rotated, compressed and
+// cleaned up as configured by the querylog-rotate-* flags.
 //
 // Returns the channel used for the subscription which can be used to close
 // it.
 func (logger *StreamLogger[T]) LogToFile(path string, logf LogFormatter) (chan T, error) {
+	return logger.logToFile(path, logf, queryLogConfigInstance.FileRotation)
+}
+
+func (logger *StreamLogger[T]) logToFile(path string, logf LogFormatter, rotation FileRotationConfig) (chan T, error) {
 	rotateChan := make(chan os.Signal, 1)
 	setupRotate(rotateChan)
 
 	logChan := logger.Subscribe("FileLog")
 	formatParams := map[string][]string{"full": {}}
 
-	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	f, err := openRotatingFile(logger.name, path, rotation)
 	if err != nil {
 		return nil, err
 	}
 
 	go func() {
+		// Each record is written at once, so that it is not split across
+		// rotated files.
+		var buf bytes.Buffer
 		for {
 			select {
 			case record := <-logChan:
-				logf(f, formatParams, record) //nolint:errcheck
+				buf.Reset()
+				if err := logf(&buf, formatParams, record); err != nil {
+					fileWriteErrors.Add(logger.name, 1)
+					continue
+				}
+				if _, err := f.Write(buf.Bytes()); err != nil {
+					fileWriteErrors.Add(logger.name, 1)
+				}
 			case <-rotateChan:
-				f.Close()
-				f, _ = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+				f.reopen() //nolint:errcheck
 			}
 		}
 	}()
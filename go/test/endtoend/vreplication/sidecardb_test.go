@@ -40,7 +40,7 @@ var (
 
 func init() {
 	sidecarDBTables = []string{
-		"copy_state", "dt_participant", "dt_state", "heartbeat", "post_copy_action",
+		"copy_state", "dt_participant", "dt_state", "heartbeat", "post_copy_action", "query_stats",
 		"redo_state", "redo_statement", "reparent_journal", "resharding_journal", "schema_migrations", "schema_version", "semisync_heartbeat",
 		"tables", "udfs", "vdiff", "vdiff_log", "vdiff_table", "views", "vreplication", "vreplication_log",
 	}
@@ -46,17 +46,19 @@ var _ plancontext.VSchema = (*VSchemaWrapper)(nil)
 // VSchemaWrapper is a wrapper around VSchema that implements the ContextVSchema interface.
 // It is used in tests to provide a VSchema implementation.
 type VSchemaWrapper struct {
-	Vcursor               *econtext.VCursorImpl
-	V                     *vindexes.VSchema
-	Keyspace              *vindexes.Keyspace
-	TabletType_           topodatapb.TabletType
-	Dest                  key.ShardDestination
-	SysVarEnabled         bool
-	ForeignKeyChecksState *bool
-	Version               plancontext.PlannerVersion
-	EnableViews           bool
-	TestBuilder           func(query string, vschema plancontext.VSchema, keyspace string) (*engine.Plan, error)
-	Env                   *vtenv.Environment
+	Vcursor                *econtext.VCursorImpl
+	V                      *vindexes.VSchema
+	Keyspace               *vindexes.Keyspace
+	TabletType_            topodatapb.TabletType
+	Dest                   key.ShardDestination
+	SysVarEnabled          bool
+	ForeignKeyChecksState  *bool
+	Version                plancontext.PlannerVersion
+	EnableViews            bool
+	HashJoinRowsThreshold_ int64
+	JoinOrderByTableStats_ bool
+	TestBuilder            func(query string, vschema plancontext.VSchema, keyspace string) (*engine.Plan, error)
+	Env                    *vtenv.Environment
 }
 
 func NewVschemaWrapper(
@@ -333,6 +335,14 @@ func (vw *VSchemaWrapper) IsViewsEnabled() bool {
 	return vw.EnableViews
 }
 
+func (vw *VSchemaWrapper) HashJoinRowsThreshold() int64 {
+	return vw.HashJoinRowsThreshold_
+}
+
+func (vw *VSchemaWrapper) JoinOrderByTableStats() bool {
+	return vw.JoinOrderByTableStats_
+}
+
 // FindMirrorRule finds the mirror rule for the requested keyspace, table
 // name, and the tablet type in the VSchema.
 func (vw *VSchemaWrapper) FindMirrorRule(tab sqlparser.TableName) (*vindexes.MirrorRule, error) {
@@ -44,6 +44,8 @@ import (
 var (
 	binlogStreamerErrors = stats.NewCountersWithSingleLabel("BinlogStreamerErrors", "error count when streaming binlog", "state")
 
+	binlogStreamerCompressedTransactions = stats.NewCounter("BinlogStreamerCompressedTransactions", "number of compressed transaction payloads decoded when streaming binlog")
+
 	// ErrClientEOF is returned by Streamer if the stream ended because the
 	// consumer of the stream indicated it doesn't want any more events.
 	ErrClientEOF = errors.New("binlog stream consumer ended the reply stream")
@@ -290,23 +292,46 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 		return nil
 	}
 
+	// When binlog_transaction_compression is enabled, a transaction is
+	// wrapped in a single TRANSACTION_PAYLOAD_EVENT. While we're reading
+	// the internal events of such a payload, tp is non-nil.
+	var tp *mysql.TransactionPayload
+	defer func() {
+		if tp != nil {
+			tp.Close()
+		}
+	}()
+
 	// Parse events.
 	for {
 		var ev mysql.BinlogEvent
 		var ok bool
 
-		select {
-		case ev, ok = <-events:
-			if !ok {
-				// events channel has been closed, which means the connection died.
-				log.Infof("reached end of binlog event stream")
-				return pos, ErrServerEOF
+		inPayload := tp != nil
+		if inPayload {
+			ev, err = tp.GetNextEvent()
+			if err != nil {
+				tp.Close()
+				tp = nil
+				if err == io.EOF {
+					continue
+				}
+				return pos, fmt.Errorf("can't read event from compressed transaction payload: %v", err)
+			}
+		} else {
+			select {
+			case ev, ok = <-events:
+				if !ok {
+					// events channel has been closed, which means the connection died.
+					log.Infof("reached end of binlog event stream")
+					return pos, ErrServerEOF
+				}
+			case err = <-errs:
+				return pos, err
+			case <-ctx.Done():
+				log.Infof("stopping early due to binlog Streamer service shutdown or client disconnect")
+				return pos, ctx.Err()
 			}
-		case err = <-errs:
-			return pos, err
-		case <-ctx.Done():
-			log.Infof("stopping early due to binlog Streamer service shutdown or client disconnect")
-			return pos, ctx.Err()
 		}
 
 		// Validate the buffer before reading fields from it.
@@ -338,12 +363,24 @@ func (bls *Streamer) parseEvents(ctx context.Context, events <-chan mysql.Binlog
 		}
 
 		// Strip the checksum, if any. We don't actually verify the checksum, so discard it.
-		ev, _, err = ev.StripChecksum(format)
-		if err != nil {
-			return pos, fmt.Errorf("can't strip checksum from binlog event: %v, event data: %#v", err, ev)
+		// Events inside a compressed transaction payload don't have their own checksum.
+		if !inPayload {
+			ev, _, err = ev.StripChecksum(format)
+			if err != nil {
+				return pos, fmt.Errorf("can't strip checksum from binlog event: %v, event data: %#v", err, ev)
+			}
 		}
 
 		switch {
+		case ev.IsTransactionPayload(): // TRANSACTION_PAYLOAD_EVENT
+			if inPayload {
+				return pos, fmt.Errorf("nested TRANSACTION_PAYLOAD_EVENT in compressed transaction payload")
+			}
+			tp, err = ev.TransactionPayload(format)
+			if err != nil {
+				return pos, fmt.Errorf("can't parse TRANSACTION_PAYLOAD_EVENT: %v", err)
+			}
+			binlogStreamerCompressedTransactions.Add(1)
 		case ev.IsPseudo():
 			gtid, _, _, _, err = ev.GTID(format)
 			if err != nil {
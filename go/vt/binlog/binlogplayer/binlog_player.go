@@ -124,6 +124,17 @@ type Stats struct {
 
 	DDLEventActions *stats.CountersWithSingleLabel
 
+	// TableDMLCounts counts the row changes applied by the vplayer, by
+	// target table and type of DML: insert, update or delete.
+	TableDMLCounts *stats.CountersWithMultiLabels
+	TableDMLRates  *stats.Rates
+	// TableApplyTimings times the statements applied by the vplayer, by
+	// target table.
+	TableApplyTimings *stats.Timings
+	// TrxBatchSizes is the distribution of the number of row changes applied
+	// by the vplayer in each transaction.
+	TrxBatchSizes *stats.Histogram
+
 	WorkflowConfig string
 }
 
@@ -172,6 +183,7 @@ func (bps *Stats) MessageHistory() []string {
 // cannot be restarted.
 func (bps *Stats) Stop() {
 	bps.Rates.Stop()
+	bps.TableDMLRates.Stop()
 	bps.VReplicationLagGauges.Stop()
 }
 
@@ -198,6 +210,10 @@ func NewStats() *Stats {
 	bps.PartialQueryCount = stats.NewCountersWithMultiLabels("", "", []string{"type"})
 	bps.ThrottledCounts = stats.NewCountersWithMultiLabels("", "", []string{"throttler", "component"})
 	bps.DDLEventActions = stats.NewCountersWithSingleLabel("", "", "action")
+	bps.TableDMLCounts = stats.NewCountersWithMultiLabels("", "", []string{"table", "type"})
+	bps.TableDMLRates = stats.NewRates("", bps.TableDMLCounts, 15*60/5, 5*time.Second)
+	bps.TableApplyTimings = stats.NewTimings("", "", "Table")
+	bps.TrxBatchSizes = stats.NewHistogram("", "", []int64{1, 10, 100, 1000, 10000})
 	return bps
 }
 
@@ -702,6 +718,11 @@ func StartVReplicationUntil(uid int32, pos string) string {
 		encodeString(binlogdatapb.VReplicationWorkflowState_Running.String()), encodeString(pos), uid)
 }
 
+// PausedMessage is the message of the streams of a paused workflow. They are
+// stopped at a stop position which is consistent across all the streams of
+// the workflow, and have to be resumed rather than started.
+const PausedMessage = "PAUSED"
+
 // StopVReplication returns a statement to stop the replication.
 func StopVReplication(uid int32, message string) string {
 	return fmt.Sprintf(
@@ -42,7 +42,10 @@ type CallInfo interface {
 // internal type and value
 type key int
 
-var callInfoKey key = 0
+var (
+	callInfoKey        key = 0
+	queryAttributesKey key = 1
+)
 
 // NewContext adds the provided CallInfo to the context
 func NewContext(ctx context.Context, ci CallInfo) context.Context {
@@ -55,6 +58,18 @@ func FromContext(ctx context.Context) (CallInfo, bool) {
 	return ci, ok
 }
 
+// NewContextWithQueryAttributes adds the query attributes the client sent
+// along with the query to the context
+func NewContextWithQueryAttributes(ctx context.Context, attributes map[string]string) context.Context {
+	return context.WithValue(ctx, queryAttributesKey, attributes)
+}
+
+// QueryAttributesFromContext returns the query attributes stored in ctx, if any.
+func QueryAttributesFromContext(ctx context.Context) map[string]string {
+	attributes, _ := ctx.Value(queryAttributesKey).(map[string]string)
+	return attributes
+}
+
 // HTMLFromContext returns that value of HTML() from the context, or "" if we're
 // not able to recover one
 func HTMLFromContext(ctx context.Context) safehtml.HTML {
@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package configsnapshot defines the normalized snapshot of the effective
+// configuration of a tablet, which vttablet serves on HTTPPath, and the logic
+// to detect configuration drift between tablets by comparing snapshots.
+package configsnapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// HTTPPath is the path on which vttablet serves its configuration snapshot.
+const HTTPPath = "/debug/config_snapshot"
+
+// Redacted replaces the values of the sensitive flags which are set. Even a
+// hash of a secret would let it be guessed offline, so the snapshots only
+// tell whether it is set, and a drift of its value is not detected.
+const Redacted = "********"
+
+const (
+	flagPrefix    = "flag."
+	mysqlPrefix   = "mysql."
+	sidecarPrefix = "sidecar."
+)
+
+// MySQLVariables are the MySQL global variables which matter to Vitess and so
+// are part of the snapshot. Variables which depend on the role of the tablet,
+// like read_only, are left out on purpose.
+var MySQLVariables = []string{
+	"binlog_expire_logs_seconds",
+	"binlog_format",
+	"binlog_row_image",
+	"binlog_row_metadata",
+	"binlog_transaction_compression",
+	"character_set_server",
+	"collation_server",
+	"enforce_gtid_consistency",
+	"explicit_defaults_for_timestamp",
+	"gtid_mode",
+	"innodb_flush_log_at_trx_commit",
+	"log_bin",
+	"log_replica_updates",
+	"log_slave_updates",
+	"lower_case_table_names",
+	"max_allowed_packet",
+	"max_connections",
+	"sql_mode",
+	"sync_binlog",
+	"time_zone",
+	"transaction_isolation",
+	"version",
+}
+
+// DefaultIgnoredFlags are the flags which are expected to differ between the
+// tablets of a shard, and so are not reported as drift by default.
+var DefaultIgnoredFlags = []string{
+	"db-port",
+	"db-socket",
+	"grpc-port",
+	"init-shard",
+	"init-tablet-type",
+	"log_dir",
+	"mycnf-file",
+	"mycnf-server-id",
+	"mycnf-socket-file",
+	"mysqlctl-socket",
+	"pid-file",
+	"port",
+	"tablet-dir",
+	"tablet-hostname",
+	"tablet-path",
+}
+
+// Snapshot is the normalized effective configuration of a tablet.
+type Snapshot struct {
+	Tablet string `json:"tablet,omitempty"`
+	// Flags has the value of every flag of the tablet, with the values of
+	// sensitive flags replaced by Redacted.
+	Flags map[string]string `json:"flags,omitempty"`
+	// MySQL has the value of the MySQLVariables on the tablet's mysqld.
+	MySQL map[string]string `json:"mysql,omitempty"`
+	// Sidecar has a hash of the schema of each table in the sidecar database.
+	Sidecar map[string]string `json:"sidecar,omitempty"`
+}
+
+// Drift is a configuration value which differs from the golden configuration.
+type Drift struct {
+	Key    string `json:"key"`
+	Golden string `json:"golden"`
+	Actual string `json:"actual"`
+}
+
+// FlagValues returns the value of every flag in fs, redacting the values of
+// the sensitive ones.
+func FlagValues(fs *pflag.FlagSet) map[string]string {
+	flags := make(map[string]string)
+	fs.VisitAll(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if isSensitiveFlag(f.Name) && value != "" {
+			value = Redacted
+		}
+		flags[f.Name] = value
+	})
+	return flags
+}
+
+func isSensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range []string{"password", "secret", "token", "credential"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash returns a short, stable hash of the value. It must not be used for
+// secrets.
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// Flatten returns all the values of the snapshot, keyed by their kind and name,
+// e.g. "flag.port" or "mysql.gtid_mode".
+func (s *Snapshot) Flatten() map[string]string {
+	values := make(map[string]string, len(s.Flags)+len(s.MySQL)+len(s.Sidecar))
+	for k, v := range s.Flags {
+		values[flagPrefix+k] = v
+	}
+	for k, v := range s.MySQL {
+		values[mysqlPrefix+k] = v
+	}
+	for k, v := range s.Sidecar {
+		values[sidecarPrefix+k] = v
+	}
+	return values
+}
+
+// Diff returns the values of the snapshot that differ from the golden one,
+// ordered by key. Flags named in ignoredFlags are not compared. A missing
+// value is reported as an empty one.
+func Diff(golden, actual *Snapshot, ignoredFlags []string) []Drift {
+	goldenValues, actualValues := golden.Flatten(), actual.Flatten()
+	keys := make(map[string]bool, len(goldenValues))
+	for k := range goldenValues {
+		keys[k] = true
+	}
+	for k := range actualValues {
+		keys[k] = true
+	}
+
+	var drifts []Drift
+	for k := range keys {
+		if name, ok := strings.CutPrefix(k, flagPrefix); ok && slices.Contains(ignoredFlags, name) {
+			continue
+		}
+		if goldenValues[k] != actualValues[k] {
+			drifts = append(drifts, Drift{Key: k, Golden: goldenValues[k], Actual: actualValues[k]})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Key < drifts[j].Key
+	})
+	return drifts
+}
+
+// Fetch gets the configuration snapshot of the tablet from its HTTP endpoint.
+func Fetch(ctx context.Context, client *http.Client, tablet *topodatapb.Tablet) (*Snapshot, error) {
+	url := "http://" + netutil.JoinHostPort(tablet.Hostname, tablet.PortMap["vt"]) + HTTPPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the configuration snapshot of tablet %s: %w", topoproto.TabletAliasString(tablet.Alias), err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the configuration snapshot of tablet %s: %s: %s",
+			topoproto.TabletAliasString(tablet.Alias), resp.Status, strings.TrimSpace(string(body)))
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(body, snapshot); err != nil {
+		return nil, fmt.Errorf("invalid configuration snapshot from tablet %s: %w", topoproto.TabletAliasString(tablet.Alias), err)
+	}
+	return snapshot, nil
+}
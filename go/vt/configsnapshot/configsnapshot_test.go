@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestFlagValues(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Int("port", 15000, "")
+	fs.String("db-app-password", "", "")
+	fs.String("s3-backup-aws-secret", "hunter2", "")
+
+	flags := FlagValues(fs)
+	assert.Equal(t, map[string]string{
+		"port":                 "15000",
+		"db-app-password":      "",
+		"s3-backup-aws-secret": Redacted,
+	}, flags)
+}
+
+func TestDiff(t *testing.T) {
+	golden := &Snapshot{
+		Flags:   map[string]string{"port": "15000", "queryserver-config-pool-size": "16", "enable-foo": "true"},
+		MySQL:   map[string]string{"gtid_mode": "ON", "binlog_format": "ROW"},
+		Sidecar: map[string]string{"vreplication": Hash("a")},
+	}
+	actual := &Snapshot{
+		Flags:   map[string]string{"port": "15001", "queryserver-config-pool-size": "32"},
+		MySQL:   map[string]string{"gtid_mode": "ON", "binlog_format": "MIXED"},
+		Sidecar: map[string]string{"vreplication": Hash("a"), "heartbeat": Hash("b")},
+	}
+
+	assert.Equal(t, []Drift{
+		{Key: "flag.enable-foo", Golden: "true"},
+		{Key: "flag.queryserver-config-pool-size", Golden: "16", Actual: "32"},
+		{Key: "mysql.binlog_format", Golden: "ROW", Actual: "MIXED"},
+		{Key: "sidecar.heartbeat", Actual: Hash("b")},
+	}, Diff(golden, actual, DefaultIgnoredFlags))
+
+	assert.Empty(t, Diff(golden, golden, nil))
+}
+
+func TestFetch(t *testing.T) {
+	want := &Snapshot{Tablet: "zone1-0000000100", Flags: map[string]string{"port": "15000"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != HTTPPath {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(want)
+	}))
+	defer server.Close()
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	tablet := &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Hostname: host,
+		PortMap:  map[string]int32{"vt": int32(port)},
+	}
+
+	got, err := Fetch(context.Background(), server.Client(), tablet)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	tablet.PortMap["vt"] = 0
+	_, err = Fetch(context.Background(), server.Client(), tablet)
+	assert.ErrorContains(t, err, "zone1-0000000100")
+}
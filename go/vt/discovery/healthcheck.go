@@ -99,6 +99,16 @@ var (
 	// refreshKnownTablets tells us whether to process all tablets or only new tablets.
 	refreshKnownTablets = true
 
+	// snapshotFile is the file to which vtgate persists its healthcheck state, and
+	// from which it loads it at startup.
+	snapshotFile string
+
+	// snapshotInterval is the interval at which the healthcheck state is persisted.
+	snapshotInterval = 30 * time.Second
+
+	// snapshotMaxAge is the maximum age of a healthcheck snapshot for it to be loaded at startup.
+	snapshotMaxAge = 15 * time.Minute
+
 	// How much to sleep between each check.
 	waitAvailableTabletInterval = 100 * time.Millisecond
 
@@ -178,6 +188,9 @@ func registerDiscoveryFlags(fs *pflag.FlagSet) {
 	fs.Var(&tabletFilterTags, "tablet-filter-tags", "Specifies a comma-separated list of tablet tags (as key:value pairs) to filter the tablets to watch.")
 	utils.SetFlagVar(fs, (*topoproto.TabletTypeListFlag)(&AllowedTabletTypes), "allowed-tablet-types", "Specifies the tablet types this vtgate is allowed to route queries to. Should be provided as a comma-separated set of tablet types.")
 	utils.SetFlagStringSliceVar(fs, &KeyspacesToWatch, "keyspaces-to-watch", []string{}, "Specifies which keyspaces this vtgate should have access to while routing queries or accessing the vschema.")
+	fs.StringVar(&snapshotFile, "healthcheck-snapshot-file", snapshotFile, "If set, the file to which the healthcheck state is periodically persisted, and from which it is loaded at startup so that tablets can be served from before the topology and their health are fully loaded. Tablets loaded from the snapshot are marked as stale until they confirm their health.")
+	fs.DurationVar(&snapshotInterval, "healthcheck-snapshot-interval", snapshotInterval, "The interval at which the healthcheck state is persisted to --healthcheck-snapshot-file.")
+	fs.DurationVar(&snapshotMaxAge, "healthcheck-snapshot-max-age", snapshotMaxAge, "The maximum age of the healthcheck snapshot for it to be loaded at startup.")
 }
 
 func registerWebUIFlags(fs *pflag.FlagSet) {
@@ -309,6 +322,10 @@ type HealthCheckImpl struct {
 	// options contains optional settings used to modify HealthCheckImpl
 	// behavior.
 	options Options
+	// stopSnapshots stops the Go routine that persists the healthcheck
+	// snapshot, and snapshotsDone is closed once it has returned.
+	stopSnapshots context.CancelFunc
+	snapshotsDone chan struct{}
 }
 
 // NewVTGateHealthCheckFilters returns healthcheck filters for vtgate.
@@ -332,6 +349,14 @@ func NewVTGateHealthCheckFilters() (filters TabletFilters, err error) {
 	return filters, nil
 }
 
+// NewVTGateHealthCheckOptions returns healthcheck options for vtgate.
+func NewVTGateHealthCheckOptions() []Option {
+	if snapshotFile == "" {
+		return nil
+	}
+	return []Option{WithSnapshot(snapshotFile, snapshotInterval, snapshotMaxAge)}
+}
+
 // NewHealthCheck creates a new HealthCheck object.
 // Parameters:
 // retryDelay.
@@ -399,6 +424,13 @@ func NewHealthCheck(
 		servenv.HTTPHandle("/debug/gateway", hc)
 	})
 
+	if hc.options.snapshotFile != "" {
+		// Load the snapshot before starting the topo watches, so that they
+		// confirm or replace the tablets loaded from it.
+		hc.loadSnapshot(ctx, filter)
+		hc.startSnapshots(ctx)
+	}
+
 	// start the topo watches here
 	for _, tw := range hc.topoWatchers {
 		tw.Start()
@@ -416,6 +448,10 @@ func (hc *HealthCheckImpl) AddTablet(tablet *topodata.Tablet) {
 		return
 	}
 
+	if hc.confirmSnapshotTablet(tablet) {
+		return
+	}
+
 	hc.logger().Infof("Adding tablet to healthcheck: %v", tablet)
 	hc.mu.Lock()
 	defer hc.mu.Unlock()
@@ -754,6 +790,14 @@ func tabletHealthToTabletCacheStatus(th *TabletHealth, tcsMap map[string]*Tablet
 
 // Close stops the healthcheck.
 func (hc *HealthCheckImpl) Close() error {
+	if hc.stopSnapshots != nil {
+		hc.stopSnapshots()
+		<-hc.snapshotsDone
+		if err := hc.writeSnapshot(); err != nil {
+			hc.logger().Warningf("failed to write healthcheck snapshot: %v", err)
+		}
+	}
+
 	hc.mu.Lock()
 	for _, th := range hc.healthByAlias {
 		th.cancelFunc()
@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+)
+
+// healthCheckSnapshot is the healthcheck state that is persisted to disk so that
+// a restarted vtgate can serve from it before the topology and the health of all
+// the tablets are loaded again.
+type healthCheckSnapshot struct {
+	Time    time.Time               `json:"time"`
+	Tablets []*tabletHealthSnapshot `json:"tablets"`
+}
+
+// tabletHealthSnapshot is the persisted health of a single tablet. The protos are
+// encoded with protojson.
+type tabletHealthSnapshot struct {
+	Tablet               json.RawMessage `json:"tablet"`
+	Target               json.RawMessage `json:"target"`
+	Stats                json.RawMessage `json:"stats"`
+	PrimaryTermStartTime int64           `json:"primary_term_start_time,omitempty"`
+	Serving              bool            `json:"serving"`
+}
+
+// startSnapshots starts the Go routine that periodically persists the healthcheck
+// snapshot, until Close is called.
+func (hc *HealthCheckImpl) startSnapshots(ctx context.Context) {
+	ctx, hc.stopSnapshots = context.WithCancel(ctx)
+	hc.snapshotsDone = make(chan struct{})
+	go func() {
+		defer close(hc.snapshotsDone)
+		ticker := time.NewTicker(hc.options.snapshotInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := hc.writeSnapshot(); err != nil {
+					hc.logger().Warningf("failed to write healthcheck snapshot: %v", err)
+				}
+			}
+		}
+	}()
+	go hc.removeUnconfirmedTablets(ctx)
+}
+
+// writeSnapshot persists the health of all the tablets that have confirmed it to
+// the snapshot file. The file is replaced atomically.
+func (hc *HealthCheckImpl) writeSnapshot() error {
+	hc.mu.Lock()
+	if hc.healthByAlias == nil {
+		// already closed.
+		hc.mu.Unlock()
+		return nil
+	}
+	ths := make([]*TabletHealth, 0, len(hc.healthByAlias))
+	for _, thc := range hc.healthByAlias {
+		ths = append(ths, thc.SimpleCopy())
+	}
+	hc.mu.Unlock()
+
+	sort.Slice(ths, func(i, j int) bool {
+		return topoproto.TabletAliasString(ths[i].Tablet.Alias) < topoproto.TabletAliasString(ths[j].Tablet.Alias)
+	})
+	snapshot := &healthCheckSnapshot{
+		Time:    time.Now(),
+		Tablets: make([]*tabletHealthSnapshot, 0, len(ths)),
+	}
+	for _, th := range ths {
+		// Tablets we have not heard from yet, or that have not confirmed the
+		// health we loaded from the previous snapshot, are not persisted so that
+		// we never serve from health that is older than the snapshot max age.
+		if th.Stale || th.Stats == nil {
+			continue
+		}
+		tablet, err := protojson.Marshal(th.Tablet)
+		if err != nil {
+			return err
+		}
+		target, err := protojson.Marshal(th.Target)
+		if err != nil {
+			return err
+		}
+		stats, err := protojson.Marshal(th.Stats)
+		if err != nil {
+			return err
+		}
+		snapshot.Tablets = append(snapshot.Tablets, &tabletHealthSnapshot{
+			Tablet:               tablet,
+			Target:               target,
+			Stats:                stats,
+			PrimaryTermStartTime: th.PrimaryTermStartTime,
+			Serving:              th.Serving && th.LastError == nil,
+		})
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	file := hc.options.snapshotFile
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// loadSnapshot adds the tablets of the snapshot file, if it exists and is recent
+// enough, to the healthcheck. They are marked as stale until their health is
+// confirmed by a health check response, and as unconfirmed until the topology
+// watchers see them.
+func (hc *HealthCheckImpl) loadSnapshot(ctx context.Context, filter TabletFilter) {
+	snapshot, err := readSnapshot(hc.options.snapshotFile)
+	if err != nil {
+		hc.logger().Warningf("failed to read healthcheck snapshot %s: %v", hc.options.snapshotFile, err)
+		return
+	}
+	if snapshot == nil {
+		return
+	}
+	if age := time.Since(snapshot.Time); age > hc.options.snapshotMaxAge {
+		hc.logger().Infof("ignoring healthcheck snapshot %s as it is %v old", hc.options.snapshotFile, age.Round(time.Second))
+		return
+	}
+
+	var loaded int
+	for _, ts := range snapshot.Tablets {
+		if ctx.Err() != nil {
+			return
+		}
+		thc, err := hc.newSnapshotTabletHealthCheck(ts)
+		if err != nil {
+			hc.logger().Warningf("skipping invalid tablet in healthcheck snapshot %s: %v", hc.options.snapshotFile, err)
+			continue
+		}
+		if thc.Tablet.PortMap["grpc"] == 0 || (filter != nil && !filter.IsIncluded(thc.Tablet)) {
+			thc.cancelFunc()
+			continue
+		}
+
+		hc.mu.Lock()
+		tabletAlias := tabletAliasString(topoproto.TabletAliasString(thc.Tablet.Alias))
+		if _, ok := hc.healthByAlias[tabletAlias]; ok {
+			hc.mu.Unlock()
+			thc.cancelFunc()
+			continue
+		}
+		hc.healthByAlias[tabletAlias] = thc
+		hc.mu.Unlock()
+
+		// This adds the tablet to the healthy list if it was serving.
+		hc.updateHealth(thc.SimpleCopy(), thc.Target, false, thc.Serving)
+		hc.connsWG.Add(1)
+		go thc.checkConn(hc)
+		loaded++
+	}
+	hc.logger().Infof("loaded %d tablets from healthcheck snapshot %s taken at %v", loaded, hc.options.snapshotFile, snapshot.Time)
+}
+
+// readSnapshot reads the given snapshot file. It returns nil if it does not exist.
+func readSnapshot(file string) (*healthCheckSnapshot, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &healthCheckSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (hc *HealthCheckImpl) newSnapshotTabletHealthCheck(ts *tabletHealthSnapshot) (*tabletHealthCheck, error) {
+	tablet := &topodata.Tablet{}
+	if err := protojson.Unmarshal(ts.Tablet, tablet); err != nil {
+		return nil, err
+	}
+	target := &query.Target{}
+	if err := protojson.Unmarshal(ts.Target, target); err != nil {
+		return nil, err
+	}
+	stats := &query.RealtimeStats{}
+	if err := protojson.Unmarshal(ts.Stats, stats); err != nil {
+		return nil, err
+	}
+	if tablet.Alias == nil {
+		return nil, errors.New("tablet has no alias")
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	thc := &tabletHealthCheck{
+		ctx:                  ctx,
+		cancelFunc:           cancelFunc,
+		Tablet:               tablet,
+		Target:               target,
+		Stats:                stats,
+		PrimaryTermStartTime: ts.PrimaryTermStartTime,
+		Serving:              ts.Serving,
+		unconfirmed:          true,
+		logger:               hc.logger(),
+	}
+	thc.stale.Store(true)
+	return thc, nil
+}
+
+// confirmSnapshotTablet is called when the topology watchers add a tablet. If the
+// tablet was loaded from the snapshot and has not changed since, it marks it as
+// confirmed and returns true, as it does not need to be added again. If it has
+// changed, the snapshot tablet is removed so that the new one can be added.
+func (hc *HealthCheckImpl) confirmSnapshotTablet(tablet *topodata.Tablet) bool {
+	hc.mu.Lock()
+	thc, ok := hc.healthByAlias[tabletAliasString(topoproto.TabletAliasString(tablet.Alias))]
+	if !ok || !thc.unconfirmed {
+		hc.mu.Unlock()
+		return false
+	}
+	if TabletToMapKey(thc.Tablet) == TabletToMapKey(tablet) {
+		thc.unconfirmed = false
+		hc.mu.Unlock()
+		return true
+	}
+	hc.mu.Unlock()
+	hc.deleteTablet(thc.Tablet)
+	return false
+}
+
+// removeUnconfirmedTablets waits for the first load of all the topology watchers,
+// and then removes the tablets loaded from the snapshot that they did not see, as
+// these no longer exist or are no longer watched.
+func (hc *HealthCheckImpl) removeUnconfirmedTablets(ctx context.Context) {
+	for _, tw := range hc.topoWatchers {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tw.firstLoadChan:
+		}
+	}
+
+	hc.mu.Lock()
+	var unconfirmed []*topodata.Tablet
+	for _, thc := range hc.healthByAlias {
+		if thc.unconfirmed {
+			unconfirmed = append(unconfirmed, thc.Tablet)
+		}
+	}
+	hc.mu.Unlock()
+
+	for _, tablet := range unconfirmed {
+		hc.deleteTablet(tablet)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestHealthCheckSnapshot(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	ts := memorytopo.NewServer(ctx, "cell")
+	defer ts.Close()
+	snapshotFile := filepath.Join(t.TempDir(), "healthcheck.json")
+	snapshot := WithSnapshot(snapshotFile, time.Hour, time.Hour)
+
+	tablet := createTestTablet(0, "cell", "a")
+	tablet.Type = topodatapb.TabletType_REPLICA
+	require.NoError(t, ts.CreateTablet(ctx, tablet))
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	shr := &querypb.StreamHealthResponse{
+		TabletAlias:   tablet.Alias,
+		Target:        target,
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: 1},
+	}
+
+	// The tablet is found by the topology watcher, and its health is persisted on Close.
+	input := make(chan *querypb.StreamHealthResponse)
+	createFakeConn(tablet, input)
+	hc := NewHealthCheck(ctx, time.Millisecond, time.Hour, ts, "cell", "", nil, snapshot)
+	resultChan := hc.Subscribe("TestHealthCheckSnapshot")
+	<-resultChan
+	input <- shr
+	<-resultChan
+	require.NoError(t, hc.Close())
+
+	data, err := os.ReadFile(snapshotFile)
+	require.NoError(t, err)
+	persisted, err := readSnapshot(snapshotFile)
+	require.NoError(t, err)
+	require.Len(t, persisted.Tablets, 1)
+	assert.True(t, persisted.Tablets[0].Serving)
+
+	// The tablet is served from the snapshot right away, as stale until it sends
+	// a health check response.
+	input = make(chan *querypb.StreamHealthResponse)
+	createFakeConn(tablet, input)
+	hc = NewHealthCheck(ctx, time.Millisecond, time.Hour, ts, "cell", "", nil, snapshot)
+	healthy := hc.GetHealthyTabletStats(target)
+	require.Len(t, healthy, 1)
+	assert.True(t, healthy[0].Stale)
+	assert.Equal(t, int64(1), int64(healthy[0].Stats.ReplicationLagSeconds))
+	input <- shr
+	assert.Eventually(t, func() bool {
+		healthy := hc.GetHealthyTabletStats(target)
+		return len(healthy) == 1 && !healthy[0].Stale
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, hc.Close())
+
+	// Tablets of the snapshot that are not in the topology are removed once it is loaded.
+	require.NoError(t, ts.DeleteTablet(ctx, tablet.Alias))
+	input = make(chan *querypb.StreamHealthResponse)
+	createFakeConn(tablet, input)
+	hc = NewHealthCheck(ctx, time.Millisecond, time.Hour, ts, "cell", "", nil, snapshot)
+	require.Len(t, hc.GetHealthyTabletStats(target), 1)
+	assert.Eventually(t, func() bool {
+		return len(hc.GetTabletStats(target)) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, hc.Close())
+
+	// Snapshots older than the max age are ignored.
+	require.NoError(t, os.WriteFile(snapshotFile, data, 0o644))
+	hc = NewHealthCheck(ctx, time.Millisecond, time.Hour, ts, "cell", "", nil, WithSnapshot(snapshotFile, time.Hour, time.Nanosecond))
+	assert.Empty(t, hc.GetTabletStats(target))
+	require.NoError(t, hc.Close())
+}
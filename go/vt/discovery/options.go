@@ -14,6 +14,8 @@ limitations under the License.
 package discovery
 
 import (
+	"time"
+
 	"vitess.io/vitess/go/vt/logutil"
 )
 
@@ -21,6 +23,10 @@ import (
 // values passed to the component constructors.
 type Options struct {
 	logger logutil.Logger
+
+	snapshotFile     string
+	snapshotInterval time.Duration
+	snapshotMaxAge   time.Duration
 }
 
 // Option configures how we perform certain operations.
@@ -65,3 +71,15 @@ func WithLogger(l logutil.Logger) Option {
 		o.logger = l
 	})
 }
+
+// WithSnapshot makes the healthcheck persist its view of the tablets' health to
+// the given file every interval, and load it at startup if it is not older than
+// maxAge. The tablets loaded from the snapshot are marked as stale until their
+// health is confirmed. This option is ignored by other discovery components.
+func WithSnapshot(file string, interval, maxAge time.Duration) Option {
+	return newFuncOption(func(o *Options) {
+		o.snapshotFile = file
+		o.snapshotInterval = interval
+		o.snapshotMaxAge = maxAge
+	})
+}
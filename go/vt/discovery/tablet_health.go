@@ -40,6 +40,9 @@ type TabletHealth struct {
 	PrimaryTermStartTime int64
 	LastError            error
 	Serving              bool
+	// Stale is true when the health was loaded from a healthcheck snapshot
+	// and has not been confirmed by the tablet yet.
+	Stale bool
 }
 
 func (th *TabletHealth) MarshalJSON() ([]byte, error) {
@@ -50,6 +53,7 @@ func (th *TabletHealth) MarshalJSON() ([]byte, error) {
 		PrimaryTermStartTime int64
 		Stats                *query.RealtimeStats
 		LastError            error
+		Stale                bool `json:",omitempty"`
 	}{
 		Tablet:               th.Tablet,
 		Target:               th.Target,
@@ -57,6 +61,7 @@ func (th *TabletHealth) MarshalJSON() ([]byte, error) {
 		PrimaryTermStartTime: th.PrimaryTermStartTime,
 		Stats:                th.Stats,
 		LastError:            th.LastError,
+		Stale:                th.Stale,
 	})
 }
 
@@ -67,6 +72,7 @@ func (th *TabletHealth) DeepEqual(other *TabletHealth) bool {
 		proto.Equal(th.Target, other.Target) &&
 		th.Serving == other.Serving &&
 		th.PrimaryTermStartTime == other.PrimaryTermStartTime &&
+		th.Stale == other.Stale &&
 		proto.Equal(th.Stats, other.Stats) &&
 		((th.LastError == nil && other.LastError == nil) ||
 			(th.LastError != nil && other.LastError != nil && th.LastError.Error() == other.LastError.Error()))
@@ -71,6 +71,13 @@ type tabletHealthCheck struct {
 	// possibly delete both these
 	loggedServingState    bool
 	lastResponseTimestamp time.Time // timestamp of the last healthcheck response
+	// stale is true while the health status was loaded from a healthcheck
+	// snapshot and the tablet has not confirmed it yet.
+	stale atomic.Bool
+	// unconfirmed is true while the tablet was loaded from a healthcheck
+	// snapshot and the topology watchers have not seen it yet.
+	// It is protected by the mutex of the HealthCheckImpl.
+	unconfirmed bool
 	// logger is used to log messages.
 	logger logutil.Logger
 }
@@ -95,6 +102,7 @@ func (thc *tabletHealthCheck) SimpleCopy() *TabletHealth {
 		LastError:            thc.LastError,
 		PrimaryTermStartTime: thc.PrimaryTermStartTime,
 		Serving:              thc.Serving,
+		Stale:                thc.stale.Load(),
 	}
 }
 
@@ -187,7 +195,7 @@ func (thc *tabletHealthCheck) processResponse(hc *HealthCheckImpl, shr *query.St
 
 	prevTarget := thc.Target
 	// check whether this is a trivial update so as to update healthy map
-	trivialUpdate := thc.LastError == nil && thc.Serving && shr.RealtimeStats.HealthError == "" && shr.Serving &&
+	trivialUpdate := !thc.stale.Load() && thc.LastError == nil && thc.Serving && shr.RealtimeStats.HealthError == "" && shr.Serving &&
 		prevTarget.TabletType != topodata.TabletType_PRIMARY && prevTarget.TabletType == shr.Target.TabletType && thc.isTrivialReplagChange(shr.RealtimeStats)
 	thc.lastResponseTimestamp = time.Now()
 	thc.Target = shr.Target
@@ -199,6 +207,7 @@ func (thc *tabletHealthCheck) processResponse(hc *HealthCheckImpl, shr *query.St
 		reason = "healthCheck update error: " + healthErr.Error()
 	}
 	thc.setServingState(serving, reason)
+	thc.stale.Store(false)
 
 	// notify downstream for primary change
 	hc.updateHealth(thc.SimpleCopy(), prevTarget, trivialUpdate, thc.Serving)
@@ -300,9 +309,16 @@ func (thc *tabletHealthCheck) checkConn(hc *HealthCheckImpl) {
 				hc.deleteTablet(thc.Tablet)
 				return
 			}
+			thc.stale.Store(false)
 			// trivialUpdate = false because this is an error
 			// up = false because we did not get a healthy response
 			hc.updateHealth(thc.SimpleCopy(), thc.Target, false, false)
+		} else if thc.stale.Load() {
+			// We could not connect to a tablet loaded from a healthcheck snapshot, so
+			// stop serving from it until it confirms its health.
+			thc.stale.Store(false)
+			thc.setServingState(false, "could not connect to tablet loaded from healthcheck snapshot")
+			hc.updateHealth(thc.SimpleCopy(), thc.Target, false, false)
 		}
 		// If there was a timeout send an error. We do this after stream has returned.
 		// This will ensure that this update prevails over any previous message that
@@ -310,6 +326,7 @@ func (thc *tabletHealthCheck) checkConn(hc *HealthCheckImpl) {
 		if timedout.Load() {
 			thc.LastError = fmt.Errorf("healthcheck timed out (latest %v)", thc.lastResponseTimestamp)
 			thc.setServingState(false, thc.LastError.Error())
+			thc.stale.Store(false)
 			hcErrorCounters.Add([]string{thc.Target.Keyspace, thc.Target.Shard, topoproto.TabletTypeLString(thc.Target.TabletType)}, 1)
 			// trivialUpdate = false because this is an error
 			// up = false because we did not get a healthy response within the timeout
@@ -86,6 +86,9 @@ func (tcs *TabletsCacheStatus) StatusAsHTML() safehtml.HTML {
 		} else {
 			extra = fmt.Sprintf(" (RepLag: %v)", ts.Stats.ReplicationLagSeconds)
 		}
+		if ts.Stale {
+			extra += " (Stale)"
+		}
 		name := topoproto.TabletAliasString(ts.Tablet.Alias)
 		tLinks = append(tLinks, link{
 			Link:  ts.getTabletDebugURL(),
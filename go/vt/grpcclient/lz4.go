@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/pierrec/lz4"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// lz4BlockMaxSize is the size of the blocks compressed by the LZ4 writers,
+// the smallest supported, as most gRPC messages are much smaller than the
+// default of 4MB.
+const lz4BlockMaxSize = 64 * 1024
+
+// Lz4Compressor is a gRPC compressor using the LZ4 algorithm, which
+// compresses less than zstd but costs less CPU. The writers and readers are
+// pooled, as their hash tables are expensive to allocate.
+type Lz4Compressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// Name is "lz4"
+func (c *Lz4Compressor) Name() string {
+	return "lz4"
+}
+
+// Compress returns a writer compressing into w.
+func (c *Lz4Compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	lw, ok := c.writers.Get().(*lz4.Writer)
+	if !ok {
+		lw = lz4.NewWriter(w)
+		lw.Header.BlockMaxSize = lz4BlockMaxSize
+	} else {
+		lw.Reset(w)
+	}
+	return &lz4Writer{w: lw, pool: &c.writers}, nil
+}
+
+// Decompress returns a reader decompressing from r.
+func (c *Lz4Compressor) Decompress(r io.Reader) (io.Reader, error) {
+	lr, ok := c.readers.Get().(*lz4.Reader)
+	if !ok {
+		lr = lz4.NewReader(r)
+	} else {
+		lr.Reset(r)
+	}
+	return &lz4Reader{r: lr, pool: &c.readers}, nil
+}
+
+type lz4Writer struct {
+	w    *lz4.Writer
+	pool *sync.Pool
+}
+
+func (w *lz4Writer) Write(p []byte) (int, error) {
+	return w.w.Write(p)
+}
+
+// Close flushes the compressed data and returns the writer to the pool.
+func (w *lz4Writer) Close() error {
+	err := w.w.Close()
+	w.pool.Put(w.w)
+	return err
+}
+
+type lz4Reader struct {
+	r    *lz4.Reader
+	pool *sync.Pool
+}
+
+// Read returns the reader to the pool once all the data was read.
+func (r *lz4Reader) Read(p []byte) (int, error) {
+	if r.r == nil {
+		return 0, io.EOF
+	}
+	n, err := r.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.pool.Put(r.r)
+		r.r = nil
+	}
+	return n, err
+}
+
+func init() {
+	encoding.RegisterCompressor(instrumentedCompressor{&Lz4Compressor{}})
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestLz4CompressDecompress(t *testing.T) {
+	comp := encoding.GetCompressor("lz4")
+	require.NotNil(t, comp)
+
+	data := []byte(strings.Repeat("vitess query result row\n", 1000))
+	rawBefore := compressionBytes.Counts()["lz4.Compress.Raw"]
+	compressedBefore := compressionBytes.Counts()["lz4.Compress.Compressed"]
+
+	// The encoders and decoders are reused across messages.
+	for range 3 {
+		var buf bytes.Buffer
+		writer, err := comp.Compress(&buf)
+		require.NoError(t, err)
+		_, err = writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		assert.Less(t, buf.Len(), len(data))
+
+		reader, err := comp.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	}
+
+	counts := compressionBytes.Counts()
+	assert.EqualValues(t, 3*len(data), counts["lz4.Compress.Raw"]-rawBefore)
+	assert.Less(t, counts["lz4.Compress.Compressed"]-compressedBefore, counts["lz4.Compress.Raw"]-rawBefore)
+	assert.EqualValues(t, counts["lz4.Compress.Compressed"], counts["lz4.Decompress.Compressed"])
+	assert.EqualValues(t, counts["lz4.Compress.Raw"], counts["lz4.Decompress.Raw"])
+}
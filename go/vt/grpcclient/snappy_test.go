@@ -53,10 +53,24 @@ func TestAppendCompression(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(dialOpts))
 
+	// Change the compression to zstd
+	compression = "zstd"
+
+	dialOpts, err = appendCompression(dialOpts)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dialOpts))
+
+	// Change the compression to lz4
+	compression = "lz4"
+
+	dialOpts, err = appendCompression(dialOpts)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(dialOpts))
+
 	// Change the compression to some unknown value
 	compression = "unknown"
 
 	dialOpts, err = appendCompression(dialOpts)
 	require.NoError(t, err)
-	require.Equal(t, 1, len(dialOpts))
+	require.Equal(t, 3, len(dialOpts))
 }
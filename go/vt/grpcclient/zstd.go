@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcclient
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"google.golang.org/grpc/encoding"
+
+	"vitess.io/vitess/go/stats"
+)
+
+var (
+	compressionBytes = stats.NewCountersWithMultiLabels(
+		"GrpcCompressionBytes",
+		"Number of bytes of the gRPC messages which were compressed or decompressed, before (raw) and after (compressed) compression",
+		[]string{"Compressor", "Operation", "Kind"})
+	compressionTimings = stats.NewMultiTimings(
+		"GrpcCompressionTimings",
+		"Time spent compressing and decompressing gRPC messages",
+		[]string{"Compressor", "Operation"})
+)
+
+// ZstdCompressor is a gRPC compressor using the Zstandard algorithm. The
+// encoders and decoders are pooled, as they are expensive to create.
+type ZstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+// Name is "zstd"
+func (z *ZstdCompressor) Name() string {
+	return "zstd"
+}
+
+// Compress returns a writer compressing into w.
+func (z *ZstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := z.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedFastest))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &zstdWriter{enc: enc, pool: &z.encoders}, nil
+}
+
+// Decompress returns a reader decompressing from r.
+func (z *ZstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := z.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		z.decoders.Put(dec)
+		return nil, err
+	}
+	return &zstdReader{dec: dec, pool: &z.decoders}, nil
+}
+
+type zstdWriter struct {
+	enc  *zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Write(p []byte) (int, error) {
+	return w.enc.Write(p)
+}
+
+// Close flushes the compressed data and returns the encoder to the pool.
+func (w *zstdWriter) Close() error {
+	err := w.enc.Close()
+	w.pool.Put(w.enc)
+	return err
+}
+
+type zstdReader struct {
+	dec  *zstd.Decoder
+	pool *sync.Pool
+}
+
+// Read returns the decoder to the pool once all the data was read.
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.dec == nil {
+		return 0, io.EOF
+	}
+	n, err := r.dec.Read(p)
+	if errors.Is(err, io.EOF) {
+		r.pool.Put(r.dec)
+		r.dec = nil
+	}
+	return n, err
+}
+
+// instrumentedCompressor records the number of bytes processed by a gRPC
+// compressor and the time it spends, which give its compression ratio and
+// its CPU cost.
+type instrumentedCompressor struct {
+	encoding.Compressor
+}
+
+func (c instrumentedCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	cw := &countingWriter{w: w}
+	wc, err := c.Compressor.Compress(cw)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedWriter{name: c.Name(), wc: wc, compressed: cw}, nil
+}
+
+func (c instrumentedCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	cr := &countingReader{r: r}
+	dr, err := c.Compressor.Decompress(cr)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedReader{name: c.Name(), r: dr, compressed: cr}, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+type instrumentedWriter struct {
+	name       string
+	wc         io.WriteCloser
+	compressed *countingWriter
+	raw        int64
+	elapsed    time.Duration
+}
+
+func (w *instrumentedWriter) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := w.wc.Write(p)
+	w.elapsed += time.Since(start)
+	w.raw += int64(n)
+	return n, err
+}
+
+func (w *instrumentedWriter) Close() error {
+	start := time.Now()
+	err := w.wc.Close()
+	w.elapsed += time.Since(start)
+	compressionBytes.Add([]string{w.name, "Compress", "Raw"}, w.raw)
+	compressionBytes.Add([]string{w.name, "Compress", "Compressed"}, w.compressed.n)
+	compressionTimings.Add([]string{w.name, "Compress"}, w.elapsed)
+	return err
+}
+
+type instrumentedReader struct {
+	name       string
+	r          io.Reader
+	compressed *countingReader
+	raw        int64
+	elapsed    time.Duration
+	done       bool
+}
+
+func (r *instrumentedReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := r.r.Read(p)
+	r.elapsed += time.Since(start)
+	r.raw += int64(n)
+	if err != nil && !r.done {
+		r.done = true
+		compressionBytes.Add([]string{r.name, "Decompress", "Raw"}, r.raw)
+		compressionBytes.Add([]string{r.name, "Decompress", "Compressed"}, r.compressed.n)
+		compressionTimings.Add([]string{r.name, "Decompress"}, r.elapsed)
+	}
+	return n, err
+}
+
+func init() {
+	encoding.RegisterCompressor(instrumentedCompressor{&ZstdCompressor{}})
+}
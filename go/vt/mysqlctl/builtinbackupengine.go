@@ -136,6 +136,9 @@ type builtinBackupManifest struct {
 	// ExternalDecompressor will be used. If neither are set, the restore will
 	// abort.
 	ExternalDecompressor string
+
+	// Keyring describes the MySQL keyring stored in the backup, if any.
+	Keyring *KeyringDetails `json:",omitempty"`
 }
 
 // FileEntry is one file to backup
@@ -655,10 +658,24 @@ func (be *BuiltinBackupEngine) backupFiles(
 		}
 	}
 
+	// The keyring is only needed to read the tablespaces of full backups.
+	var keyring *KeyringDetails
+	if !isIncrementalBackup(params) {
+		file, err := keyringPath(params.Cnf)
+		if err != nil {
+			return err
+		}
+		if file != "" {
+			if keyring, err = backupKeyring(ctx, params, bh, file); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Backup the MANIFEST file and apply retry logic.
 	var manifestErr error
 	for currentRetry := 0; currentRetry <= maxRetriesPerFile; currentRetry++ {
-		manifestErr = be.backupManifest(ctx, params, bh, backupPosition, purgedPosition, fromPosition, fromBackupName, serverUUID, mysqlVersion, incrDetails, fes, currentRetry)
+		manifestErr = be.backupManifest(ctx, params, bh, backupPosition, purgedPosition, fromPosition, fromBackupName, serverUUID, mysqlVersion, incrDetails, fes, keyring, currentRetry)
 		if manifestErr == nil || vterrors.Code(manifestErr) == vtrpcpb.Code_FAILED_PRECONDITION {
 			break
 		}
@@ -982,6 +999,7 @@ func (be *BuiltinBackupEngine) backupManifest(
 	mysqlVersion string,
 	incrDetails *IncrementalBackupDetails,
 	fes []FileEntry,
+	keyring *KeyringDetails,
 	currentAttempt int,
 ) (finalErr error) {
 	retryStr := retryToString(currentAttempt)
@@ -1042,6 +1060,7 @@ func (be *BuiltinBackupEngine) backupManifest(
 			SkipCompress:         !backupStorageCompress,
 			CompressionEngine:    CompressionEngineName,
 			ExternalDecompressor: ManifestExternalDecompressorCmd,
+			Keyring:              keyring,
 		}
 		data, err := json.MarshalIndent(bm, "", "  ")
 		if err != nil {
@@ -1077,6 +1096,11 @@ func (be *BuiltinBackupEngine) executeRestoreFullBackup(ctx context.Context, par
 		// don't delete the file here because that is how we detect an interrupted restore
 		return vterrors.Wrap(err, "failed to restore files")
 	}
+	if bm.Keyring != nil {
+		if err := restoreKeyring(ctx, params, bh, bm.Keyring); err != nil {
+			return vterrors.Wrap(err, "failed to restore keyring")
+		}
+	}
 	return nil
 }
 
@@ -30,6 +30,7 @@ import (
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/log"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -45,17 +46,28 @@ import (
 const (
 	clonePluginStatusQuery = "SELECT PLUGIN_STATUS FROM information_schema.PLUGINS WHERE PLUGIN_NAME = 'clone'"
 	cloneStatusQuery       = "SELECT STATE, ERROR_NO, ERROR_MESSAGE FROM performance_schema.clone_status ORDER BY ID DESC LIMIT 1"
+	cloneProgressQuery     = "SELECT STAGE, STATE, ESTIMATE, DATA FROM performance_schema.clone_progress"
 )
 
 var (
 	cloneFromPrimary        = false
 	cloneFromTablet         = ""
 	cloneRestartWaitTimeout = 5 * time.Minute
+	cloneMaxDataBandwidth   = 0
+	cloneMaxNetBandwidth    = 0
+
+	// cloneProgressInterval is how often the progress of a running clone is
+	// polled from performance_schema.clone_progress.
+	cloneProgressInterval = 10 * time.Second
+
+	statsCloneStage          = stats.NewString("CloneStage")
+	statsCloneBytesEstimated = stats.NewGauge("CloneBytesEstimated", "Number of bytes the running MySQL CLONE is estimated to transfer")
+	statsCloneBytesCopied    = stats.NewGauge("CloneBytesCopied", "Number of bytes transferred so far by the running MySQL CLONE")
 )
 
 func init() {
-	// TODO: enable these flags for vttablet and vtbackup.
-	for _, cmd := range []string{ /*"vttablet", "vtbackup"*/ } {
+	// TODO: enable these flags for vtbackup.
+	for _, cmd := range []string{"vttablet"} {
 		servenv.OnParseFor(cmd, registerCloneFlags)
 	}
 }
@@ -64,6 +76,14 @@ func registerCloneFlags(fs *pflag.FlagSet) {
 	utils.SetFlagBoolVar(fs, &cloneFromPrimary, "clone-from-primary", cloneFromPrimary, "Clone data from the primary tablet in the shard using MySQL CLONE REMOTE instead of restoring from backup. Requires MySQL 8.0.17+. Mutually exclusive with --clone-from-tablet.")
 	utils.SetFlagStringVar(fs, &cloneFromTablet, "clone-from-tablet", cloneFromTablet, "Clone data from this tablet using MySQL CLONE REMOTE instead of restoring from backup (tablet alias, e.g., zone1-123). Requires MySQL 8.0.17+. Mutually exclusive with --clone-from-primary.")
 	utils.SetFlagDurationVar(fs, &cloneRestartWaitTimeout, "clone-restart-wait-timeout", cloneRestartWaitTimeout, "Timeout for waiting for MySQL to restart after CLONE REMOTE.")
+	utils.SetFlagIntVar(fs, &cloneMaxDataBandwidth, "clone-max-data-bandwidth", cloneMaxDataBandwidth, "Maximum rate in MiB per second at which data is written on the recipient during CLONE REMOTE (0 means unlimited).")
+	utils.SetFlagIntVar(fs, &cloneMaxNetBandwidth, "clone-max-network-bandwidth", cloneMaxNetBandwidth, "Maximum rate in MiB per second at which data is transferred from the donor during CLONE REMOTE (0 means unlimited).")
+}
+
+// CloneFromDonorEnabled returns whether a donor to clone from was configured with
+// --clone-from-primary or --clone-from-tablet.
+func CloneFromDonorEnabled() bool {
+	return cloneFromPrimary || cloneFromTablet != ""
 }
 
 // CloneFromDonor clones data from the specified donor tablet using MySQL CLONE REMOTE.
@@ -117,6 +137,9 @@ func CloneFromDonor(ctx context.Context, topoServer *topo.Server, mysqld MysqlDa
 		DonorUser:     cloneConfig.User,
 		DonorPassword: cloneConfig.Password,
 		UseSSL:        cloneConfig.UseSSL,
+
+		MaxDataBandwidth:    cloneMaxDataBandwidth,
+		MaxNetworkBandwidth: cloneMaxNetBandwidth,
 	}
 
 	log.Infof("Clone executor configured for donor %s:%d", executor.DonorHost, executor.DonorPort)
@@ -151,6 +174,12 @@ type CloneExecutor struct {
 	DonorPassword string
 	// UseSSL indicates whether to use SSL for the clone connection.
 	UseSSL bool
+	// MaxDataBandwidth throttles the rate in MiB per second at which data is
+	// written on the recipient. Zero means unlimited.
+	MaxDataBandwidth int
+	// MaxNetworkBandwidth throttles the rate in MiB per second at which data is
+	// transferred from the donor. Zero means unlimited.
+	MaxNetworkBandwidth int
 }
 
 // validateRecipient checks that the recipient MySQL instance meets all prerequisites for cloning.
@@ -267,8 +296,8 @@ func (c *CloneExecutor) donorConnParams() *mysql.ConnParams {
 
 // ExecuteClone performs CLONE REMOTE from the donor to the recipient.
 // This will:
-// 1. Set clone_valid_donor_list on the recipient
-// 2. Execute CLONE INSTANCE FROM on the recipient
+// 1. Set clone_valid_donor_list and the bandwidth limits on the recipient
+// 2. Execute CLONE INSTANCE FROM on the recipient, reporting its progress
 // 3. Wait for MySQL to restart and verify clone completed successfully
 //
 // The waitTimeout specifies how long to wait for MySQL to restart and
@@ -299,16 +328,32 @@ func (c *CloneExecutor) ExecuteClone(ctx context.Context, mysqld MysqlDaemon, wa
 	if err := mysqld.ExecuteSuperQuery(ctx, setDonorListQuery); err != nil {
 		return vterrors.Wrapf(err, "failed to set clone_valid_donor_list")
 	}
+	for _, limit := range c.bandwidthQueries() {
+		if err := mysqld.ExecuteSuperQuery(ctx, limit); err != nil {
+			return vterrors.Wrapf(err, "failed to set clone bandwidth limit")
+		}
+	}
 
 	// Build the CLONE INSTANCE command
 	cloneCmd := c.buildCloneCommand()
 
 	log.Infof("Executing CLONE INSTANCE FROM %s:%d (this may take a while)", c.DonorHost, c.DonorPort)
 
+	// Report the progress of the clone while the command runs.
+	progressCtx, cancelProgress := context.WithCancel(ctx)
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		c.monitorCloneProgress(progressCtx, mysqld)
+	}()
+
 	// Execute the clone command. When clone completes, MySQL restarts automatically
 	// which will cause the connection to drop. We ignore this error and verify
 	// success by checking clone_status after MySQL comes back up.
-	if err := mysqld.ExecuteSuperQuery(ctx, cloneCmd); err != nil {
+	err := mysqld.ExecuteSuperQuery(ctx, cloneCmd)
+	cancelProgress()
+	<-progressDone
+	if err != nil {
 		if !isCloneConnError(err) {
 			return vterrors.Wrapf(err, "clone command failed")
 		}
@@ -342,6 +387,19 @@ func (c *CloneExecutor) buildCloneCommand() string {
 	return sb.String()
 }
 
+// bandwidthQueries returns the queries setting the throttling limits of the
+// clone on the recipient. Limits which are not set are left to the server.
+func (c *CloneExecutor) bandwidthQueries() []string {
+	var queries []string
+	if c.MaxDataBandwidth > 0 {
+		queries = append(queries, fmt.Sprintf("SET GLOBAL clone_max_data_bandwidth = %d", c.MaxDataBandwidth))
+	}
+	if c.MaxNetworkBandwidth > 0 {
+		queries = append(queries, fmt.Sprintf("SET GLOBAL clone_max_network_bandwidth = %d", c.MaxNetworkBandwidth))
+	}
+	return queries
+}
+
 func isCloneConnError(err error) bool {
 	var sqlErr *sqlerror.SQLError
 	if !errors.As(err, &sqlErr) {
@@ -441,3 +499,76 @@ func (c *CloneExecutor) waitForCloneComplete(ctx context.Context, mysqld MysqlDa
 		}
 	}
 }
+
+// cloneProgress is the progress of a clone, as reported by
+// performance_schema.clone_progress.
+type cloneProgress struct {
+	// Stage is the stage being executed, or the last one if none is running.
+	Stage string
+	// Estimated is the number of bytes all the stages are expected to transfer.
+	Estimated int64
+	// Copied is the number of bytes transferred so far.
+	Copied int64
+}
+
+// String returns the progress in a form suitable for logging.
+func (p *cloneProgress) String() string {
+	if p.Estimated == 0 {
+		return fmt.Sprintf("stage %s, %d bytes copied", p.Stage, p.Copied)
+	}
+	return fmt.Sprintf("stage %s, %d of %d bytes copied (%.1f%%)", p.Stage, p.Copied, p.Estimated, float64(p.Copied)*100/float64(p.Estimated))
+}
+
+// readCloneProgress reads the progress of the running clone.
+func readCloneProgress(ctx context.Context, mysqld MysqlDaemon) (*cloneProgress, error) {
+	result, err := mysqld.FetchSuperQuery(ctx, cloneProgressQuery)
+	if err != nil {
+		return nil, err
+	}
+	progress := &cloneProgress{}
+	for _, row := range result.Rows {
+		if len(row) < 4 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected clone_progress row format: got %d columns, expected 4", len(row))
+		}
+		estimate, err := row[2].ToCastInt64()
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid clone_progress estimate")
+		}
+		data, err := row[3].ToCastInt64()
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid clone_progress data")
+		}
+		progress.Estimated += estimate
+		progress.Copied += data
+		// Stages are listed in the order they run, the last one which was
+		// started is the one being executed.
+		state := row[1].ToString()
+		if progress.Stage == "" || !strings.EqualFold(state, "Not Started") {
+			progress.Stage = row[0].ToString()
+		}
+	}
+	return progress, nil
+}
+
+// monitorCloneProgress logs and exports the progress of the running clone
+// until the context is done.
+func (c *CloneExecutor) monitorCloneProgress(ctx context.Context, mysqld MysqlDaemon) {
+	ticker := time.NewTicker(cloneProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			progress, err := readCloneProgress(ctx, mysqld)
+			if err != nil {
+				log.Infof("Failed to read clone progress: %v", err)
+				continue
+			}
+			statsCloneStage.Set(progress.Stage)
+			statsCloneBytesEstimated.Set(progress.Estimated)
+			statsCloneBytesCopied.Set(progress.Copied)
+			log.Infof("Clone from %s:%d in progress: %v", c.DonorHost, c.DonorPort, progress)
+		}
+	}
+}
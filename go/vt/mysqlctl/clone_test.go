@@ -320,6 +320,97 @@ func Test_waitForCloneComplete_ContextCanceled(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+func TestBandwidthQueries(t *testing.T) {
+	assert.Empty(t, (&CloneExecutor{}).bandwidthQueries())
+	assert.Equal(t, []string{
+		"SET GLOBAL clone_max_data_bandwidth = 100",
+		"SET GLOBAL clone_max_network_bandwidth = 50",
+	}, (&CloneExecutor{MaxDataBandwidth: 100, MaxNetworkBandwidth: 50}).bandwidthQueries())
+	assert.Equal(t, []string{
+		"SET GLOBAL clone_max_network_bandwidth = 50",
+	}, (&CloneExecutor{MaxNetworkBandwidth: 50}).bandwidthQueries())
+}
+
+func TestReadCloneProgress(t *testing.T) {
+	fields := sqltypes.MakeTestFields("STAGE|STATE|ESTIMATE|DATA", "varchar|varchar|int64|int64")
+	tests := []struct {
+		name         string
+		result       *sqltypes.Result
+		want         *cloneProgress
+		wantString   string
+		errorContain string
+	}{
+		{
+			name:       "not started",
+			result:     sqltypes.MakeTestResult(fields, "DROP DATA|Not Started|0|0", "FILE COPY|Not Started|0|0"),
+			want:       &cloneProgress{Stage: "DROP DATA"},
+			wantString: "stage DROP DATA, 0 bytes copied",
+		},
+		{
+			name: "copying pages",
+			result: sqltypes.MakeTestResult(fields,
+				"DROP DATA|Completed|0|0",
+				"FILE COPY|Completed|3000|3000",
+				"PAGE COPY|In Progress|1000|500",
+				"REDO COPY|Not Started|0|0",
+			),
+			want:       &cloneProgress{Stage: "PAGE COPY", Estimated: 4000, Copied: 3500},
+			wantString: "stage PAGE COPY, 3500 of 4000 bytes copied (87.5%)",
+		},
+		{
+			name:         "invalid row",
+			result:       sqltypes.MakeTestResult(sqltypes.MakeTestFields("STAGE", "varchar"), "DROP DATA"),
+			errorContain: "unexpected clone_progress row format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fmd := NewFakeMysqlDaemon(nil)
+			defer fmd.Close()
+			fmd.FetchSuperQueryMap = map[string]*sqltypes.Result{cloneProgressQuery: tt.result}
+
+			progress, err := readCloneProgress(context.Background(), fmd)
+			if tt.errorContain != "" {
+				assert.ErrorContains(t, err, tt.errorContain)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, progress)
+			assert.Equal(t, tt.wantString, progress.String())
+		})
+	}
+}
+
+func TestMonitorCloneProgress(t *testing.T) {
+	oldInterval := cloneProgressInterval
+	cloneProgressInterval = 10 * time.Millisecond
+	defer func() {
+		cloneProgressInterval = oldInterval
+	}()
+
+	fmd := NewFakeMysqlDaemon(nil)
+	defer fmd.Close()
+	fmd.FetchSuperQueryMap = map[string]*sqltypes.Result{
+		cloneProgressQuery: sqltypes.MakeTestResult(sqltypes.MakeTestFields("STAGE|STATE|ESTIMATE|DATA", "varchar|varchar|int64|int64"),
+			"FILE COPY|In Progress|2048|1024",
+		),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		(&CloneExecutor{}).monitorCloneProgress(ctx, fmd)
+	}()
+	assert.Eventually(t, func() bool {
+		return statsCloneBytesCopied.Get() == 1024
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+	assert.Equal(t, "FILE COPY", statsCloneStage.Get())
+	assert.EqualValues(t, 2048, statsCloneBytesEstimated.Get())
+}
+
 func TestValidateRecipient(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -674,6 +765,21 @@ func TestCloneFromDonor(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "failed to get position after clone",
 		},
+		{
+			name:            "success with bandwidth limits",
+			cloneFromTablet: "cell1-100",
+			setup: func(t *testing.T, env *cloneFromDonorTestEnv) {
+				cloneMaxDataBandwidth = 100
+				cloneMaxNetBandwidth = 50
+				env.mysqld.ExpectedExecuteSuperQueryList = []string{
+					env.mysqld.ExpectedExecuteSuperQueryList[0],
+					"SET GLOBAL clone_max_data_bandwidth = 100",
+					"SET GLOBAL clone_max_network_bandwidth = 50",
+					env.mysqld.ExpectedExecuteSuperQueryList[1],
+				}
+			},
+			wantErr: false,
+		},
 		{
 			name:             "success with clone-from-primary",
 			cloneFromPrimary: true,
@@ -695,7 +801,11 @@ func TestCloneFromDonor(t *testing.T) {
 			oldCloneFromTablet := cloneFromTablet
 			oldCloneUser := dbconfigs.GlobalDBConfigs.CloneUser
 			oldMysqlCloneEnabled := mysqlCloneEnabled
+			oldCloneMaxDataBandwidth := cloneMaxDataBandwidth
+			oldCloneMaxNetBandwidth := cloneMaxNetBandwidth
 			defer func() {
+				cloneMaxDataBandwidth = oldCloneMaxDataBandwidth
+				cloneMaxNetBandwidth = oldCloneMaxNetBandwidth
 				cloneFromPrimary = oldCloneFromPrimary
 				cloneFromTablet = oldCloneFromTablet
 				dbconfigs.GlobalDBConfigs.CloneUser = oldCloneUser
@@ -724,6 +834,7 @@ func TestCloneFromDonor(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.NotEmpty(t, pos)
+				require.NoError(t, env.mysqld.CheckSuperQueryList())
 			}
 		})
 	}
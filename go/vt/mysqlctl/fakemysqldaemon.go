@@ -809,6 +809,12 @@ func (fmd *FakeMysqlDaemon) HostMetrics(ctx context.Context, cnf *Mycnf) (*mysql
 			"datadir-used-ratio": {
 				Value: 0.2,
 			},
+			"cpu-util": {
+				Value: 0.3,
+			},
+			"datadir-io-util": {
+				Value: 0.1,
+			},
 		},
 	}, nil
 }
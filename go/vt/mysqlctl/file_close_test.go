@@ -492,7 +492,7 @@ func TestBackupManifestCloseError(t *testing.T) {
 			}
 			fes := []FileEntry{}
 
-			err := be.backupManifest(testCtx, params, bh, testPosition(), testPosition(), testPosition(), "", "test-uuid", "8.0.32", nil, fes, 0)
+			err := be.backupManifest(testCtx, params, bh, testPosition(), testPosition(), testPosition(), "", "test-uuid", "8.0.32", nil, fes, nil, 0)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/osutil"
+)
+
+const (
+	// utilizationSampleInterval is how long a utilization is measured over
+	// when there is no recent enough previous sample to compare with.
+	utilizationSampleInterval = 200 * time.Millisecond
+	// utilizationSampleMaxAge is the age above which a previous sample is
+	// considered too old to give the current utilization.
+	utilizationSampleMaxAge = time.Minute
+)
+
+var (
+	cpuUtilization = &utilizationSampler{
+		read: func() (busy, total float64, err error) {
+			times, err := osutil.ReadCPUTimes()
+			return float64(times.Busy), float64(times.Total), err
+		},
+	}
+	// datadirIOUtilizations maps a datadir to the sampler of the I/O
+	// utilization of its device.
+	datadirIOUtilizations sync.Map
+)
+
+// utilizationSample is a reading of a resource: the time it was busy, and the
+// total time it could have been busy, both cumulative.
+type utilizationSample struct {
+	busy      float64
+	total     float64
+	sampledAt time.Time
+}
+
+// utilizationSampler measures the utilization of a resource as the ratio
+// between the increase of its busy time and of its total time, from one
+// call to the next.
+type utilizationSampler struct {
+	mu   sync.Mutex
+	read func() (busy, total float64, err error)
+	last *utilizationSample
+}
+
+func (s *utilizationSampler) sample() (*utilizationSample, error) {
+	busy, total, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return &utilizationSample{busy: busy, total: total, sampledAt: time.Now()}, nil
+}
+
+// utilization returns the utilization of the resource since the previous
+// call. Range: 0.0 (idle) - 1.0 (saturated)
+func (s *utilizationSampler) utilization(ctx context.Context) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.last
+	if prev == nil || time.Since(prev.sampledAt) > utilizationSampleMaxAge {
+		var err error
+		if prev, err = s.sample(); err != nil {
+			return 0, err
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(utilizationSampleInterval):
+		}
+	}
+	cur, err := s.sample()
+	if err != nil {
+		return 0, err
+	}
+	s.last = cur
+	if cur.total <= prev.total || cur.busy < prev.busy {
+		return 0, nil
+	}
+	return min((cur.busy-prev.busy)/(cur.total-prev.total), 1), nil
+}
+
+// datadirIOUtilization returns the ratio of time the device holding the
+// datadir spent doing I/Os since the previous call.
+func datadirIOUtilization(ctx context.Context, dataDir string) (float64, error) {
+	sampler, _ := datadirIOUtilizations.LoadOrStore(dataDir, &utilizationSampler{
+		read: func() (busy, total float64, err error) {
+			ioTime, err := osutil.DiskIOTime(dataDir)
+			return float64(ioTime), float64(time.Now().UnixNano()), err
+		},
+	})
+	return sampler.(*utilizationSampler).utilization(ctx)
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/hook"
+	"vitess.io/vitess/go/vt/mysqlctl/backupstorage"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// This file contains the management of the MySQL keyring by the builtin
+// backup engine. The keyring holds the master keys of the InnoDB tablespaces
+// which are encrypted at rest, so it is stored in the full backups, and
+// restored before MySQL starts, for the restored tablespaces to be readable.
+// The keyring is encrypted in the backups with a key from a KMS, so that the
+// backups do not hold the keys of their own data. The keyring file is either
+// given by --keyring-file, or found in the configuration of the MySQL keyring
+// component which keeps it.
+
+const (
+	// keyringFileName is the name of the keyring in a backup.
+	keyringFileName = "KEYRING"
+
+	// kmsGetKeyHook is the hook which returns the base64 encoded 256 bit
+	// key of a KMS key ID on its stdout.
+	kmsGetKeyHook = "kms_get_key"
+)
+
+// keyringComponents are the MySQL keyring components which keep the keyring
+// in a file. A component is configured by a <component>.cnf JSON file in the
+// plugin directory, or in the data directory when that file sets
+// read_local_config.
+var keyringComponents = []string{"component_keyring_file", "component_keyring_encrypted_file"}
+
+// keyringComponentConfig is the configuration of a keyring component.
+type keyringComponentConfig struct {
+	Path            string `json:"path"`
+	ReadLocalConfig bool   `json:"read_local_config"`
+}
+
+var (
+	// keyringFile is the path of the MySQL keyring file, such as the one
+	// of the component_keyring_file component.
+	keyringFile string
+
+	// keyringKMSKeyID is the ID of the KMS key which encrypts the keyring
+	// in the backups.
+	keyringKMSKeyID string
+)
+
+// KeyringDetails describes the MySQL keyring stored in a backup.
+type KeyringDetails struct {
+	// Hash is the hash of the keyring, as stored in the backup.
+	Hash string
+
+	// KMSKeyID is the ID of the KMS key which encrypted the keyring. The
+	// keyring of older backups is not encrypted when it is empty. It is
+	// recorded, so that the backup can be restored after the key used by new
+	// backups changed.
+	KMSKeyID string `json:",omitempty"`
+}
+
+func init() {
+	for _, cmd := range []string{"vtbackup", "vtcombo", "vttablet", "vttestserver"} {
+		servenv.OnParseFor(cmd, registerKeyringFlags)
+	}
+}
+
+func registerKeyringFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&keyringFile, "keyring-file", keyringFile, "Path of the MySQL keyring file, which holds the master keys of the InnoDB tablespaces encrypted at rest. The builtin backup engine stores the keyring in the full backups, and restores it before starting MySQL. When empty, the path of the keyring file of the component_keyring_file or component_keyring_encrypted_file configuration is used, if any.")
+	fs.StringVar(&keyringKMSKeyID, "keyring-kms-key-id", keyringKMSKeyID, "ID of the KMS key which encrypts the keyring in the backups. The key is the base64 encoded 256 bit key printed by the kms_get_key hook, which is called with --key-id=<ID>. Required to back up a MySQL server with a keyring, which is never stored unencrypted.")
+}
+
+// keyringPath returns the path of the MySQL keyring file, which is empty if
+// there is none: --keyring-file if set, or else the path of the configuration
+// of a keyring component.
+func keyringPath(cnf *Mycnf) (string, error) {
+	if keyringFile != "" || cnf == nil {
+		return keyringFile, nil
+	}
+	for _, component := range keyringComponents {
+		config, err := readKeyringComponentConfig(cnf.lookup("plugin-dir"), component)
+		if err != nil {
+			return "", err
+		}
+		if config == nil || config.ReadLocalConfig {
+			if config, err = readKeyringComponentConfig(cnf.DataDir, component); err != nil {
+				return "", err
+			}
+		}
+		if config != nil && config.Path != "" {
+			return config.Path, nil
+		}
+	}
+	return "", nil
+}
+
+// readKeyringComponentConfig reads the configuration of a keyring component
+// in a directory. It returns nil if there is none.
+func readKeyringComponentConfig(dir, component string) (*keyringComponentConfig, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	name := filepath.Join(dir, component+".cnf")
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read keyring component configuration %v", name)
+	}
+	config := &keyringComponentConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot parse keyring component configuration %v", name)
+	}
+	return config, nil
+}
+
+// backupKeyring stores the keyring file in the backup, encrypted with the KMS
+// key. It refuses to store it unencrypted.
+func backupKeyring(ctx context.Context, params BackupParams, bh backupstorage.BackupHandle, path string) (*KeyringDetails, error) {
+	if keyringKMSKeyID == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot store keyring file %v unencrypted in the backup, --keyring-kms-key-id is not set", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot read keyring file %v", path)
+	}
+	details := &KeyringDetails{KMSKeyID: keyringKMSKeyID}
+	if data, err = encryptKeyring(ctx, details.KMSKeyID, data); err != nil {
+		return nil, err
+	}
+
+	params.Logger.Infof("Backing up keyring file %v", path)
+	wc, err := bh.AddFile(ctx, keyringFileName, int64(len(data)))
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot add %v to backup", keyringFileName)
+	}
+	if _, err := wc.Write(data); err != nil {
+		_ = wc.Close()
+		return nil, vterrors.Wrapf(err, "cannot write %v", keyringFileName)
+	}
+	if err := closeWithRetry(ctx, params.Logger, wc, keyringFileName); err != nil {
+		return nil, vterrors.Wrapf(err, "cannot close %v", keyringFileName)
+	}
+	details.Hash = keyringHash(data)
+	return details, nil
+}
+
+// restoreKeyring restores the keyring of the backup to the keyring file,
+// decrypting it with the KMS key it was encrypted with, if any.
+func restoreKeyring(ctx context.Context, params RestoreParams, bh backupstorage.BackupHandle, details *KeyringDetails) error {
+	path, err := keyringPath(params.Cnf)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "backup %v holds a keyring, but neither --keyring-file nor a keyring component is configured", bh.Name())
+	}
+
+	params.Logger.Infof("Restoring keyring file %v", path)
+	rc, err := bh.ReadFile(ctx, keyringFileName)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot open %v in backup", keyringFileName)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return vterrors.Wrapf(err, "cannot read %v", keyringFileName)
+	}
+	if hash := keyringHash(data); hash != details.Hash {
+		return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "hash mismatch for %v, got %v expected %v", keyringFileName, hash, details.Hash)
+	}
+	if details.KMSKeyID != "" {
+		if data, err = decryptKeyring(ctx, details.KMSKeyID, data); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func keyringHash(data []byte) string {
+	return hex.EncodeToString(binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(data)))
+}
+
+// kmsKey returns the key of the KMS key ID, from the kms_get_key hook.
+func kmsKey(ctx context.Context, keyID string) (cipher.AEAD, error) {
+	hr := hook.NewHook(kmsGetKeyHook, []string{"--key-id=" + keyID}).ExecuteContext(ctx)
+	if hr.ExitStatus != hook.HOOK_SUCCESS {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%v hook failed for KMS key %v: %v", kmsGetKeyHook, keyID, hr.String())
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(hr.Stdout))
+	if err != nil || len(key) != 32 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "%v hook returned an invalid key for KMS key %v: want a base64 encoded 256 bit key", kmsGetKeyHook, keyID)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptKeyring encrypts the keyring with AES-GCM, and prepends the nonce.
+func encryptKeyring(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	aead, err := kmsKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func decryptKeyring(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	aead, err := kmsKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "keyring of the backup is too short to be encrypted")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	data, err = aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "cannot decrypt the keyring of the backup with KMS key %v", keyID)
+	}
+	return data, nil
+}
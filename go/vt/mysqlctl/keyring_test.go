@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlctl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+type keyringWriteCloser struct {
+	bytes.Buffer
+}
+
+func (kwc *keyringWriteCloser) Close() error {
+	return nil
+}
+
+func TestBackupRestoreKeyring(t *testing.T) {
+	// The kms_get_key hook only knows the key1 KMS key.
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "vthook"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "vthook", kmsGetKeyHook), []byte(`#!/bin/sh
+if [ "$1" = "--key-id=key1" ]; then
+  echo "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+else
+  exit 1
+fi
+`), 0o755))
+	t.Setenv("VTROOT", root)
+
+	oldKeyringFile, oldKeyringKMSKeyID := keyringFile, keyringKMSKeyID
+	defer func() {
+		keyringFile, keyringKMSKeyID = oldKeyringFile, oldKeyringKMSKeyID
+	}()
+	keyringFile = filepath.Join(t.TempDir(), "keyring", "component_keyring_file")
+	keyringKMSKeyID = "key1"
+	keyring := []byte("keyring data")
+
+	ctx := context.Background()
+	logger := logutil.NewMemoryLogger()
+	require.NoError(t, os.MkdirAll(filepath.Dir(keyringFile), 0o755))
+	require.NoError(t, os.WriteFile(keyringFile, keyring, 0o600))
+
+	stored := &keyringWriteCloser{}
+	bh := &FakeBackupHandle{
+		NameV:         "backup",
+		AddFileReturn: FakeBackupHandleAddFileReturn{WriteCloser: stored},
+		ReadFileReturnF: func(ctx context.Context, filename string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(stored.Bytes())), nil
+		},
+	}
+	details, err := backupKeyring(ctx, BackupParams{Logger: logger}, bh, keyringFile)
+	require.NoError(t, err)
+	assert.Equal(t, "key1", details.KMSKeyID)
+	require.Len(t, bh.AddFileCalls, 1)
+	assert.Equal(t, keyringFileName, bh.AddFileCalls[0].Filename)
+	assert.NotContains(t, stored.String(), string(keyring))
+
+	// The keyring is restored on a new host.
+	require.NoError(t, os.RemoveAll(filepath.Dir(keyringFile)))
+	require.NoError(t, restoreKeyring(ctx, RestoreParams{Logger: logger}, bh, details))
+	data, err := os.ReadFile(keyringFile)
+	require.NoError(t, err)
+	assert.Equal(t, keyring, data)
+
+	// The keyring is not restored when its hash does not match.
+	err = restoreKeyring(ctx, RestoreParams{Logger: logger}, bh, &KeyringDetails{Hash: "00000000", KMSKeyID: "key1"})
+	require.ErrorContains(t, err, "hash mismatch")
+
+	t.Run("unencrypted backup", func(t *testing.T) {
+		// The keyring of older backups may not be encrypted.
+		stored := &keyringWriteCloser{}
+		stored.Write(keyring)
+		bh := &FakeBackupHandle{
+			ReadFileReturnF: func(ctx context.Context, filename string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(stored.Bytes())), nil
+			},
+		}
+		require.NoError(t, os.Remove(keyringFile))
+		require.NoError(t, restoreKeyring(ctx, RestoreParams{Logger: logger}, bh, &KeyringDetails{Hash: keyringHash(keyring)}))
+		data, err := os.ReadFile(keyringFile)
+		require.NoError(t, err)
+		assert.Equal(t, keyring, data)
+
+		// But the keyring is never backed up unencrypted.
+		keyringKMSKeyID = ""
+		defer func() {
+			keyringKMSKeyID = "key1"
+		}()
+		_, err = backupKeyring(ctx, BackupParams{Logger: logger}, &FakeBackupHandle{}, keyringFile)
+		require.ErrorContains(t, err, "--keyring-kms-key-id is not set")
+		assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+	})
+
+	t.Run("unknown KMS key", func(t *testing.T) {
+		keyringKMSKeyID = "key2"
+		defer func() {
+			keyringKMSKeyID = "key1"
+		}()
+		_, err := backupKeyring(ctx, BackupParams{Logger: logger}, &FakeBackupHandle{}, keyringFile)
+		require.ErrorContains(t, err, "kms_get_key hook failed for KMS key key2")
+	})
+
+	t.Run("no keyring file", func(t *testing.T) {
+		keyringFile = ""
+		err := restoreKeyring(ctx, RestoreParams{Logger: logger}, &FakeBackupHandle{NameV: "backup"}, &KeyringDetails{})
+		require.ErrorContains(t, err, "backup backup holds a keyring, but neither --keyring-file nor a keyring component is configured")
+		assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+	})
+}
+
+func TestKeyringPath(t *testing.T) {
+	oldKeyringFile := keyringFile
+	defer func() {
+		keyringFile = oldKeyringFile
+	}()
+	keyringFile = ""
+
+	pluginDir, dataDir := t.TempDir(), t.TempDir()
+	cnf := &Mycnf{DataDir: dataDir, mycnfMap: map[string]string{"plugin-dir": pluginDir}}
+	path, err := keyringPath(cnf)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	// The global configuration of the component is in the plugin directory.
+	globalConfig := filepath.Join(pluginDir, "component_keyring_encrypted_file.cnf")
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`{"path": "/keyring/global", "password": "pw"}`), 0o600))
+	path, err = keyringPath(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, "/keyring/global", path)
+
+	// It can defer to a local configuration in the data directory.
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`{"read_local_config": true}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "component_keyring_encrypted_file.cnf"), []byte(`{"path": "/keyring/local"}`), 0o600))
+	path, err = keyringPath(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, "/keyring/local", path)
+
+	// --keyring-file takes precedence.
+	keyringFile = "/keyring/flag"
+	path, err = keyringPath(cnf)
+	require.NoError(t, err)
+	assert.Equal(t, "/keyring/flag", path)
+
+	keyringFile = ""
+	require.NoError(t, os.WriteFile(globalConfig, []byte(`{`), 0o600))
+	_, err = keyringPath(cnf)
+	require.ErrorContains(t, err, "cannot parse keyring component configuration")
+}
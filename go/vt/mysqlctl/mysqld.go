@@ -1395,6 +1395,28 @@ func hostMetrics(ctx context.Context, cnf *Mycnf) (*mysqlctlpb.HostMetricsRespon
 		return nil
 	}()
 
+	_ = func() error {
+		metric := newMetric("cpu-util")
+		// 0.0 for idle CPUs, 1.0 for fully busy CPUs
+		util, err := cpuUtilization.utilization(ctx)
+		if err != nil {
+			return withError(metric, err)
+		}
+		metric.Value = util
+		return nil
+	}()
+
+	_ = func() error {
+		metric := newMetric("datadir-io-util")
+		// 0.0 for an idle device, 1.0 for a device busy with I/Os all the time
+		util, err := datadirIOUtilization(ctx, cnf.DataDir)
+		if err != nil {
+			return withError(metric, err)
+		}
+		metric.Value = util
+		return nil
+	}()
+
 	return resp, nil
 }
 
@@ -18,6 +18,7 @@ package mysqlctl
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strconv"
 	"strings"
@@ -317,6 +318,42 @@ func TestHostMetrics(t *testing.T) {
 	metric := resp.Metrics["datadir-used-ratio"]
 	assert.Equal(t, "datadir-used-ratio", metric.Name)
 	assert.Empty(t, metric.Error)
+	assert.Contains(t, resp.Metrics, "datadir-io-util")
+	metric = resp.Metrics["cpu-util"]
+	require.NotNil(t, metric)
+	assert.Empty(t, metric.Error)
+	assert.GreaterOrEqual(t, metric.Value, 0.0)
+	assert.LessOrEqual(t, metric.Value, 1.0)
+}
+
+func TestUtilizationSampler(t *testing.T) {
+	ctx := context.Background()
+	var busy, total float64
+	sampler := &utilizationSampler{
+		read: func() (float64, float64, error) {
+			return busy, total, nil
+		},
+	}
+	// Without a previous sample, the utilization is measured over a short interval.
+	util, err := sampler.utilization(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, util)
+
+	busy, total = 30, 100
+	util, err = sampler.utilization(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, util)
+
+	busy, total = 130, 150
+	util, err = sampler.utilization(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, util)
+
+	sampler.read = func() (float64, float64, error) {
+		return 0, 0, errors.New("no such device")
+	}
+	_, err = sampler.utilization(ctx)
+	assert.ErrorContains(t, err, "no such device")
 }
 
 func TestGetMycnfTemplateMySQL9(t *testing.T) {
@@ -31,27 +31,45 @@ var (
 	cutOverThresholdFlagRegexp  = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, cutOverThresholdFlag))
 	forceCutOverAfterFlagRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, forceCutOverAfterFlag))
 	retainArtifactsFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, retainArtifactsFlag))
+	executeAfterFlagRegexp      = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, executeAfterFlag))
+	maintenanceWindowFlagRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, maintenanceWindowFlag))
+	autoRevertOnLagFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, autoRevertOnLagFlag))
+	autoRevertOnErrorRateRegexp = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, autoRevertOnErrorRateFlag))
+	autoRevertWatchFlagRegexp   = regexp.MustCompile(fmt.Sprintf(`^[-]{1,2}%s=(.*?)$`, autoRevertWatchFlag))
 )
 
 const (
-	declarativeFlag        = "declarative"
-	skipTopoFlag           = "skip-topo" // legacy. Kept for backwards compatibility, but unused
-	singletonFlag          = "singleton"
-	singletonContextFlag   = "singleton-context"
-	singletonTableFlag     = "singleton-table"
-	allowZeroInDateFlag    = "allow-zero-in-date"
-	postponeLaunchFlag     = "postpone-launch"
-	postponeCompletionFlag = "postpone-completion"
-	inOrderCompletionFlag  = "in-order-completion"
-	allowConcurrentFlag    = "allow-concurrent"
-	preferInstantDDL       = "prefer-instant-ddl"
-	fastRangeRotationFlag  = "fast-range-rotation"
-	cutOverThresholdFlag   = "cut-over-threshold"
-	forceCutOverAfterFlag  = "force-cut-over-after"
-	retainArtifactsFlag    = "retain-artifacts"
-	vreplicationTestSuite  = "vreplication-test-suite"
-	allowForeignKeysFlag   = "unsafe-allow-foreign-keys"
-	analyzeTableFlag       = "analyze-table"
+	declarativeFlag           = "declarative"
+	skipTopoFlag              = "skip-topo" // legacy. Kept for backwards compatibility, but unused
+	singletonFlag             = "singleton"
+	singletonContextFlag      = "singleton-context"
+	singletonTableFlag        = "singleton-table"
+	allowZeroInDateFlag       = "allow-zero-in-date"
+	postponeLaunchFlag        = "postpone-launch"
+	postponeCompletionFlag    = "postpone-completion"
+	inOrderCompletionFlag     = "in-order-completion"
+	allowConcurrentFlag       = "allow-concurrent"
+	preferInstantDDL          = "prefer-instant-ddl"
+	preferInplaceDDL          = "prefer-inplace-ddl"
+	fastRangeRotationFlag     = "fast-range-rotation"
+	cutOverThresholdFlag      = "cut-over-threshold"
+	forceCutOverAfterFlag     = "force-cut-over-after"
+	retainArtifactsFlag       = "retain-artifacts"
+	vreplicationTestSuite     = "vreplication-test-suite"
+	allowForeignKeysFlag      = "unsafe-allow-foreign-keys"
+	analyzeTableFlag          = "analyze-table"
+	executeAfterFlag          = "execute-after"
+	maintenanceWindowFlag     = "maintenance-window"
+	autoRevertOnLagFlag       = "auto-revert-on-lag"
+	autoRevertOnErrorRateFlag = "auto-revert-on-error-rate"
+	autoRevertWatchFlag       = "auto-revert-watch"
+	requireRowValidationFlag  = "require-row-validation"
+)
+
+const (
+	// DefaultAutoRevertWatchPeriod is the time following migration completion during which auto-revert
+	// thresholds are evaluated, unless overridden by --auto-revert-watch
+	DefaultAutoRevertWatchPeriod = 10 * time.Minute
 )
 
 // DDLStrategy suggests how an ALTER TABLE should run (e.g. "direct", "online", "mysql")
@@ -115,16 +133,37 @@ func ParseDDLStrategy(strategyVariable string) (*DDLStrategySetting, error) {
 	if _, err := setting.RetainArtifactsDuration(); err != nil {
 		return nil, err
 	}
+	if _, err := setting.ExecuteAfter(); err != nil {
+		return nil, err
+	}
+	if _, err := setting.MaintenanceWindow(); err != nil {
+		return nil, err
+	}
 	cutoverAfter, err := setting.ForceCutOverAfter()
 	if err != nil {
 		return nil, err
 	}
+	if _, err := setting.AutoRevertOnLag(); err != nil {
+		return nil, err
+	}
+	if _, err := setting.AutoRevertOnErrorRate(); err != nil {
+		return nil, err
+	}
+	if _, err := setting.AutoRevertWatchPeriod(); err != nil {
+		return nil, err
+	}
 	switch setting.Strategy {
 	case DDLStrategyVitess, DDLStrategyOnline:
 	default:
 		if cutoverAfter != 0 {
 			return nil, fmt.Errorf("--force-cut-over-after is only valid in 'vitess' strategy. Found %v value in '%v' strategy", cutoverAfter, setting.Strategy)
 		}
+		if setting.IsRequireRowValidation() {
+			return nil, fmt.Errorf("--%s is only valid in 'vitess' strategy. Found in '%v' strategy", requireRowValidationFlag, setting.Strategy)
+		}
+		if setting.IsAutoRevert() {
+			return nil, fmt.Errorf("--%s and --%s are only valid in 'vitess' strategy. Found in '%v' strategy", autoRevertOnLagFlag, autoRevertOnErrorRateFlag, setting.Strategy)
+		}
 	}
 
 	switch setting.Strategy {
@@ -209,6 +248,11 @@ func (setting *DDLStrategySetting) IsPreferInstantDDL() bool {
 	return setting.hasFlag(preferInstantDDL)
 }
 
+// IsPreferInplaceDDL checks if strategy options include --prefer-inplace-ddl
+func (setting *DDLStrategySetting) IsPreferInplaceDDL() bool {
+	return setting.hasFlag(preferInplaceDDL)
+}
+
 // isCutOverThresholdFlag returns true when given option denotes a `--cut-over-threshold=[...]` flag
 func isCutOverThresholdFlag(opt string) (string, bool) {
 	submatch := cutOverThresholdFlagRegexp.FindStringSubmatch(opt)
@@ -236,6 +280,51 @@ func isRetainArtifactsFlag(opt string) (string, bool) {
 	return submatch[1], true
 }
 
+// isExecuteAfterFlag returns true when given option denotes a `--execute-after=[...]` flag
+func isExecuteAfterFlag(opt string) (string, bool) {
+	submatch := executeAfterFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
+// isMaintenanceWindowFlag returns true when given option denotes a `--maintenance-window=[...]` flag
+func isMaintenanceWindowFlag(opt string) (string, bool) {
+	submatch := maintenanceWindowFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
+// isAutoRevertOnLagFlag returns true when given option denotes a `--auto-revert-on-lag=[...]` flag
+func isAutoRevertOnLagFlag(opt string) (string, bool) {
+	submatch := autoRevertOnLagFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
+// isAutoRevertOnErrorRateFlag returns true when given option denotes a `--auto-revert-on-error-rate=[...]` flag
+func isAutoRevertOnErrorRateFlag(opt string) (string, bool) {
+	submatch := autoRevertOnErrorRateRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
+// isAutoRevertWatchFlag returns true when given option denotes a `--auto-revert-watch=[...]` flag
+func isAutoRevertWatchFlag(opt string) (string, bool) {
+	submatch := autoRevertWatchFlagRegexp.FindStringSubmatch(opt)
+	if len(submatch) == 0 {
+		return "", false
+	}
+	return submatch[1], true
+}
+
 // CutOverThreshold returns a the duration threshold indicated by --cut-over-threshold
 func (setting *DDLStrategySetting) CutOverThreshold() (d time.Duration, err error) {
 	// We do some ugly manual parsing of --cut-over-threshold value
@@ -290,6 +379,132 @@ func (setting *DDLStrategySetting) RetainArtifactsDuration() (d time.Duration, e
 	return d, err
 }
 
+// ExecuteAfter returns the timestamp indicated by --execute-after, or a zero time if not specified.
+// The value is expected in RFC3339 format, e.g. 2026-01-31T02:00:00Z
+func (setting *DDLStrategySetting) ExecuteAfter() (t time.Time, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isExecuteAfter := isExecuteAfterFlag(opt); isExecuteAfter {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				t, err = time.Parse(time.RFC3339, val)
+			}
+		}
+	}
+	return t, err
+}
+
+// MaintenanceWindow returns the recurring window indicated by --maintenance-window, or nil if not specified.
+func (setting *DDLStrategySetting) MaintenanceWindow() (w *MaintenanceWindow, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isMaintenanceWindow := isMaintenanceWindowFlag(opt); isMaintenanceWindow {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				w, err = ParseMaintenanceWindow(val)
+			}
+		}
+	}
+	return w, err
+}
+
+// IsInExecutionWindow returns true when the given time satisfies both --execute-after and
+// --maintenance-window, if specified. When false, it also returns a human readable reason.
+func (setting *DDLStrategySetting) IsInExecutionWindow(now time.Time) (bool, string) {
+	// Both flags are validated when DDL strategy is first parsed. Should there be an error
+	// here nonetheless, we ignore the flag, otherwise the migration would never run.
+	if executeAfter, err := setting.ExecuteAfter(); err == nil && now.Before(executeAfter) {
+		return false, fmt.Sprintf("waiting for window: --execute-after=%s", executeAfter.Format(time.RFC3339))
+	}
+	if w, err := setting.MaintenanceWindow(); err == nil && w != nil && !w.Contains(now) {
+		return false, fmt.Sprintf("waiting for window: --maintenance-window=%s", w)
+	}
+	return true, ""
+}
+
+// AutoRevertOnLag returns the replication lag threshold indicated by --auto-revert-on-lag, or zero if not specified.
+// A completed migration is automatically reverted if replication lag exceeds this threshold during the watch period.
+func (setting *DDLStrategySetting) AutoRevertOnLag() (d time.Duration, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isAutoRevertOnLag := isAutoRevertOnLagFlag(opt); isAutoRevertOnLag {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				d, err = time.ParseDuration(val)
+			}
+		}
+	}
+	if err == nil && d < 0 {
+		err = fmt.Errorf("invalid negative value for --%s: %v", autoRevertOnLagFlag, d)
+	}
+	return d, err
+}
+
+// AutoRevertOnErrorRate returns the query error rate threshold (errors per second) indicated by --auto-revert-on-error-rate,
+// or zero if not specified. A completed migration is automatically reverted if the query error rate of its table exceeds
+// this threshold during the watch period.
+func (setting *DDLStrategySetting) AutoRevertOnErrorRate() (rate float64, err error) {
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isAutoRevertOnErrorRate := isAutoRevertOnErrorRateFlag(opt); isAutoRevertOnErrorRate {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				rate, err = strconv.ParseFloat(val, 64)
+			}
+		}
+	}
+	if err == nil && rate < 0 {
+		err = fmt.Errorf("invalid negative value for --%s: %v", autoRevertOnErrorRateFlag, rate)
+	}
+	return rate, err
+}
+
+// AutoRevertWatchPeriod returns the duration indicated by --auto-revert-watch, or DefaultAutoRevertWatchPeriod if
+// not specified. This is the time, following migration completion, during which auto-revert thresholds are evaluated.
+func (setting *DDLStrategySetting) AutoRevertWatchPeriod() (d time.Duration, err error) {
+	d = DefaultAutoRevertWatchPeriod
+	opts, _ := shlex.Split(setting.Options)
+	for _, opt := range opts {
+		if val, isAutoRevertWatch := isAutoRevertWatchFlag(opt); isAutoRevertWatch {
+			// value is possibly quoted
+			if s, err := strconv.Unquote(val); err == nil {
+				val = s
+			}
+			if val != "" {
+				d, err = time.ParseDuration(val)
+			}
+		}
+	}
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("invalid non-positive value for --%s: %v", autoRevertWatchFlag, d)
+	}
+	return d, err
+}
+
+// IsAutoRevert returns true when either --auto-revert-on-lag or --auto-revert-on-error-rate is specified
+func (setting *DDLStrategySetting) IsAutoRevert() bool {
+	maxLag, _ := setting.AutoRevertOnLag()
+	maxErrorRate, _ := setting.AutoRevertOnErrorRate()
+	return maxLag > 0 || maxErrorRate > 0
+}
+
+// IsRequireRowValidation checks if strategy options include --require-row-validation
+func (setting *DDLStrategySetting) IsRequireRowValidation() bool {
+	return setting.hasFlag(requireRowValidationFlag)
+}
+
 // IsVreplicationTestSuite checks if strategy options include --vreplicatoin-test-suite
 func (setting *DDLStrategySetting) IsVreplicationTestSuite() bool {
 	return setting.hasFlag(vreplicationTestSuite)
@@ -319,6 +534,21 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		if _, ok := isRetainArtifactsFlag(opt); ok {
 			continue
 		}
+		if _, ok := isExecuteAfterFlag(opt); ok {
+			continue
+		}
+		if _, ok := isMaintenanceWindowFlag(opt); ok {
+			continue
+		}
+		if _, ok := isAutoRevertOnLagFlag(opt); ok {
+			continue
+		}
+		if _, ok := isAutoRevertOnErrorRateFlag(opt); ok {
+			continue
+		}
+		if _, ok := isAutoRevertWatchFlag(opt); ok {
+			continue
+		}
 		switch {
 		case isFlag(opt, declarativeFlag):
 		case isFlag(opt, skipTopoFlag): // deprecated flag, parsed for backwards compatibility
@@ -331,10 +561,12 @@ func (setting *DDLStrategySetting) RuntimeOptions() []string {
 		case isFlag(opt, inOrderCompletionFlag):
 		case isFlag(opt, allowConcurrentFlag):
 		case isFlag(opt, preferInstantDDL):
+		case isFlag(opt, preferInplaceDDL):
 		case isFlag(opt, fastRangeRotationFlag): // deprecated flag, parsed for backwards compatibility
 		case isFlag(opt, vreplicationTestSuite):
 		case isFlag(opt, allowForeignKeysFlag):
 		case isFlag(opt, analyzeTableFlag):
+		case isFlag(opt, requireRowValidationFlag):
 		default:
 			validOpts = append(validOpts, opt)
 		}
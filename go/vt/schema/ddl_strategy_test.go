@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsDirect(t *testing.T) {
@@ -192,9 +193,11 @@ func TestParseDDLStrategy(t *testing.T) {
 		isInOrderCompletion  bool
 		isAllowConcurrent    bool
 		fastOverRevertible   bool
+		preferInplace        bool
 		fastRangeRotation    bool
 		allowForeignKeys     bool
 		analyzeTable         bool
+		requireRowValidation bool
 		cutOverThreshold     time.Duration
 		forceCutOverAfter    time.Duration
 		expireArtifacts      time.Duration
@@ -291,6 +294,13 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:     "",
 			fastOverRevertible: true,
 		},
+		{
+			strategyVariable: "vitess --prefer-inplace-ddl",
+			strategy:         DDLStrategyVitess,
+			options:          "--prefer-inplace-ddl",
+			runtimeOptions:   "",
+			preferInplace:    true,
+		},
 		{
 			strategyVariable:  "vitess --fast-range-rotation",
 			strategy:          DDLStrategyVitess,
@@ -352,6 +362,19 @@ func TestParseDDLStrategy(t *testing.T) {
 			runtimeOptions:   "",
 			analyzeTable:     true,
 		},
+		{
+			strategyVariable:     "vitess --require-row-validation",
+			strategy:             DDLStrategyVitess,
+			options:              "--require-row-validation",
+			runtimeOptions:       "",
+			requireRowValidation: true,
+		},
+		{
+			strategyVariable: "mysql --require-row-validation",
+			strategy:         DDLStrategyMySQL,
+			runtimeOptions:   "",
+			expectError:      "--require-row-validation is only valid in 'vitess' strategy",
+		},
 
 		{
 			strategyVariable: "vitess --alow-concrrnt", // intentional typo
@@ -384,8 +407,10 @@ func TestParseDDLStrategy(t *testing.T) {
 			assert.Equal(t, ts.isPostponeLaunch, setting.IsPostponeLaunch())
 			assert.Equal(t, ts.isAllowConcurrent, setting.IsAllowConcurrent())
 			assert.Equal(t, ts.fastOverRevertible, setting.IsPreferInstantDDL())
+			assert.Equal(t, ts.preferInplace, setting.IsPreferInplaceDDL())
 			assert.Equal(t, ts.allowForeignKeys, setting.IsAllowForeignKeysFlag())
 			assert.Equal(t, ts.analyzeTable, setting.IsAnalyzeTableFlag())
+			assert.Equal(t, ts.requireRowValidation, setting.IsRequireRowValidation())
 			cutOverThreshold, err := setting.CutOverThreshold()
 			assert.NoError(t, err)
 			assert.Equal(t, ts.cutOverThreshold, cutOverThreshold)
@@ -414,3 +439,130 @@ func TestParseDDLStrategy(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestIsInExecutionWindow(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2026-10-16T03:00:00Z")
+	require.NoError(t, err)
+	tt := []struct {
+		options      string
+		expectError  string
+		inWindow     bool
+		expectReason string
+	}{
+		{
+			inWindow: true,
+		},
+		{
+			options:     "--execute-after=tomorrow",
+			expectError: "cannot parse",
+		},
+		{
+			options:     "--maintenance-window=01:00",
+			expectError: "expected HH:MM-HH:MM",
+		},
+		{
+			options:  "--execute-after=2026-10-16T02:00:00Z",
+			inWindow: true,
+		},
+		{
+			options:      "--execute-after=2026-10-16T04:00:00Z",
+			expectReason: "waiting for window: --execute-after=2026-10-16T04:00:00Z",
+		},
+		{
+			options:  "--maintenance-window=01:00-05:00",
+			inWindow: true,
+		},
+		{
+			options:      "--maintenance-window='sat,sun@01:00-05:00'",
+			expectReason: "waiting for window: --maintenance-window=sat,sun@01:00-05:00",
+		},
+		{
+			options:      "--execute-after=2026-10-16T02:00:00Z --maintenance-window=04:00-05:00",
+			expectReason: "waiting for window: --maintenance-window=04:00-05:00",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.options, func(t *testing.T) {
+			setting, err := ParseDDLStrategy("vitess " + tc.options)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, setting.RuntimeOptions())
+			inWindow, reason := setting.IsInExecutionWindow(now)
+			assert.Equal(t, tc.inWindow, inWindow)
+			assert.Equal(t, tc.expectReason, reason)
+		})
+	}
+}
+
+func TestAutoRevertSettings(t *testing.T) {
+	tt := []struct {
+		strategyVariable string
+		expectError      string
+		maxLag           time.Duration
+		maxErrorRate     float64
+		watchPeriod      time.Duration
+		isAutoRevert     bool
+	}{
+		{
+			strategyVariable: "vitess",
+			watchPeriod:      DefaultAutoRevertWatchPeriod,
+		},
+		{
+			strategyVariable: "vitess --auto-revert-on-lag=30s",
+			maxLag:           30 * time.Second,
+			watchPeriod:      DefaultAutoRevertWatchPeriod,
+			isAutoRevert:     true,
+		},
+		{
+			strategyVariable: "vitess --auto-revert-on-error-rate=2.5 --auto-revert-watch=1h",
+			maxErrorRate:     2.5,
+			watchPeriod:      time.Hour,
+			isAutoRevert:     true,
+		},
+		{
+			strategyVariable: "vitess --auto-revert-watch=1h",
+			watchPeriod:      time.Hour,
+		},
+		{
+			strategyVariable: "vitess --auto-revert-on-lag=3",
+			expectError:      "missing unit in duration",
+		},
+		{
+			strategyVariable: "vitess --auto-revert-on-error-rate=-1",
+			expectError:      "invalid negative value",
+		},
+		{
+			strategyVariable: "vitess --auto-revert-on-lag=30s --auto-revert-watch=0s",
+			expectError:      "invalid non-positive value",
+		},
+		{
+			strategyVariable: "mysql --auto-revert-on-lag=30s",
+			expectError:      "only valid in 'vitess' strategy",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.strategyVariable, func(t *testing.T) {
+			setting, err := ParseDDLStrategy(tc.strategyVariable)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, setting.RuntimeOptions())
+			assert.Equal(t, tc.isAutoRevert, setting.IsAutoRevert())
+
+			maxLag, err := setting.AutoRevertOnLag()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.maxLag, maxLag)
+			maxErrorRate, err := setting.AutoRevertOnErrorRate()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.maxErrorRate, maxErrorRate)
+			watchPeriod, err := setting.AutoRevertWatchPeriod()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.watchPeriod, watchPeriod)
+		})
+	}
+}
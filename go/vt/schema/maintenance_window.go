@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// MaintenanceWindow is a recurring, daily time window, in UTC, optionally limited to specific days of the week.
+// The textual format is `[<days>@]HH:MM-HH:MM`, where <days> is a comma separated list of days or day ranges,
+// e.g.:
+//
+//	01:00-05:00
+//	sat,sun@00:00-23:59
+//	mon-fri@22:00-02:00
+//
+// A window whose end is earlier than its start wraps around midnight. In such case, the days apply
+// to the day on which the window opens.
+type MaintenanceWindow struct {
+	spec  string
+	days  map[time.Weekday]bool // nil means every day
+	start time.Duration         // offset from midnight
+	end   time.Duration         // offset from midnight
+}
+
+// ParseMaintenanceWindow parses a maintenance window specification. See MaintenanceWindow for the format.
+func ParseMaintenanceWindow(spec string) (*MaintenanceWindow, error) {
+	w := &MaintenanceWindow{spec: strings.TrimSpace(spec)}
+	hours := w.spec
+	if daysSpec, hoursSpec, ok := strings.Cut(hours, "@"); ok {
+		days, err := parseWeekdays(daysSpec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %w", spec, err)
+		}
+		w.days = days
+		hours = hoursSpec
+	}
+	startSpec, endSpec, ok := strings.Cut(hours, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid maintenance window %q: expected HH:MM-HH:MM", spec)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(startSpec); err != nil {
+		return nil, fmt.Errorf("invalid maintenance window %q: %w", spec, err)
+	}
+	if w.end, err = parseTimeOfDay(endSpec); err != nil {
+		return nil, fmt.Errorf("invalid maintenance window %q: %w", spec, err)
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("invalid maintenance window %q: empty window", spec)
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	day, ok := weekdayNames[name]
+	if !ok && len(name) > 3 {
+		// A full day name, e.g. "friday", but not any word with the same first letters.
+		day, ok = weekdayNames[name[:3]]
+		ok = ok && name == strings.ToLower(day.String())
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid day of week %q", s)
+	}
+	return day, nil
+}
+
+func parseWeekdays(s string) (map[time.Weekday]bool, error) {
+	days := map[time.Weekday]bool{}
+	for token := range strings.SplitSeq(s, ",") {
+		fromSpec, toSpec, isRange := strings.Cut(token, "-")
+		from, err := parseWeekday(fromSpec)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			if to, err = parseWeekday(toSpec); err != nil {
+				return nil, err
+			}
+		}
+		for day := from; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == to {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// String returns the window specification
+func (w *MaintenanceWindow) String() string {
+	return w.spec
+}
+
+// Contains returns true when the given time falls within the window.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	sinceMidnight := t.Sub(midnight)
+	openedOn := t.Weekday()
+	if w.start < w.end {
+		if sinceMidnight < w.start || sinceMidnight >= w.end {
+			return false
+		}
+	} else {
+		// Window wraps around midnight
+		switch {
+		case sinceMidnight >= w.start:
+		case sinceMidnight < w.end:
+			// The window opened on the previous day
+			openedOn = (openedOn + 6) % 7
+		default:
+			return false
+		}
+	}
+	return w.days == nil || w.days[openedOn]
+}
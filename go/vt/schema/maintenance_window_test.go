@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	// 2026-10-16 is a Friday
+	friday := func(hhmm string) time.Time {
+		tm, err := time.Parse(time.RFC3339, "2026-10-16T"+hhmm+":00Z")
+		require.NoError(t, err)
+		return tm
+	}
+	tt := []struct {
+		spec        string
+		expectError string
+		inside      []time.Time
+		outside     []time.Time
+	}{
+		{
+			spec:        "",
+			expectError: "expected HH:MM-HH:MM",
+		},
+		{
+			spec:        "01:00",
+			expectError: "expected HH:MM-HH:MM",
+		},
+		{
+			spec:        "01:00-25:00",
+			expectError: "invalid time of day",
+		},
+		{
+			spec:        "01:00-01:00",
+			expectError: "empty window",
+		},
+		{
+			spec:        "someday@01:00-02:00",
+			expectError: "invalid day of week",
+		},
+		{
+			spec:        "Monkey@01:00-02:00",
+			expectError: "invalid day of week",
+		},
+		{
+			spec:        "sat,Sunshine@01:00-02:00",
+			expectError: "invalid day of week",
+		},
+		{
+			spec:    "01:00-05:00",
+			inside:  []time.Time{friday("01:00"), friday("04:59")},
+			outside: []time.Time{friday("00:59"), friday("05:00"), friday("12:00")},
+		},
+		{
+			spec:    "22:00-02:00",
+			inside:  []time.Time{friday("22:00"), friday("23:59"), friday("01:59")},
+			outside: []time.Time{friday("02:00"), friday("21:59")},
+		},
+		{
+			spec:    "fri@10:00-12:00",
+			inside:  []time.Time{friday("11:00")},
+			outside: []time.Time{friday("12:00"), friday("11:00").AddDate(0, 0, 1)},
+		},
+		{
+			spec:    "sat,sun@00:00-23:59",
+			inside:  []time.Time{friday("11:00").AddDate(0, 0, 1), friday("11:00").AddDate(0, 0, 2)},
+			outside: []time.Time{friday("11:00"), friday("11:00").AddDate(0, 0, 3)},
+		},
+		{
+			spec: "thu-sat@22:00-02:00",
+			// Friday 01:00 belongs to Thursday's window; Sunday 01:00 belongs to Saturday's window.
+			inside:  []time.Time{friday("01:00"), friday("23:00"), friday("01:00").AddDate(0, 0, 2)},
+			outside: []time.Time{friday("01:00").AddDate(0, 0, 3), friday("23:00").AddDate(0, 0, 2)},
+		},
+		{
+			spec:   "Friday@10:00-12:00",
+			inside: []time.Time{friday("10:30")},
+		},
+		{
+			spec:    "THURSDAY-Fri@10:00-12:00",
+			inside:  []time.Time{friday("10:30"), friday("10:30").AddDate(0, 0, -1)},
+			outside: []time.Time{friday("10:30").AddDate(0, 0, 1)},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.spec, func(t *testing.T) {
+			w, err := ParseMaintenanceWindow(tc.spec)
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.spec, w.String())
+			for _, tm := range tc.inside {
+				assert.True(t, w.Contains(tm), "expected %v to be inside window", tm)
+			}
+			for _, tm := range tc.outside {
+				assert.False(t, w.Contains(tm), "expected %v to be outside window", tm)
+			}
+		})
+	}
+}
@@ -271,3 +271,133 @@ func AlterTableCapableOfInstantDDL(alterTable *sqlparser.AlterTable, createTable
 	}
 	return true, nil
 }
+
+// charsetMaxBytesPerChar maps the character sets whose VARCHAR columns we know how to
+// extend in place to their maximum number of bytes per character.
+var charsetMaxBytesPerChar = map[string]int{
+	"utf8mb4": 4,
+	"utf8mb3": 3,
+	"utf8":    3,
+	"latin1":  1,
+	"ascii":   1,
+	"binary":  1,
+}
+
+func alterOptionCapableOfInplaceMetadataDDL(alterOption sqlparser.AlterOption, createTable *sqlparser.CreateTable) bool {
+	findColumn := func(colName string) *sqlparser.ColumnDefinition {
+		for _, col := range createTable.TableSpec.Columns {
+			if strings.EqualFold(colName, col.Name.String()) {
+				return col
+			}
+		}
+		return nil
+	}
+	findIndex := func(indexName string) *sqlparser.IndexDefinition {
+		for _, index := range createTable.TableSpec.Indexes {
+			if strings.EqualFold(indexName, index.Info.Name.String()) {
+				return index
+			}
+		}
+		return nil
+	}
+	columnCharset := func(col *sqlparser.ColumnDefinition) string {
+		if col.Type.Charset.Name != "" {
+			return strings.ToLower(col.Type.Charset.Name)
+		}
+		for _, opt := range createTable.TableSpec.Options {
+			if strings.EqualFold(opt.Name, "charset") {
+				return strings.ToLower(opt.String)
+			}
+		}
+		return ""
+	}
+	// varcharExtendedInPlace checks whether the only change to the column is an extension
+	// of its VARCHAR length that does not change the number of length bytes, which MySQL
+	// does in place without rebuilding the table.
+	varcharExtendedInPlace := func(col *sqlparser.ColumnDefinition, newCol *sqlparser.ColumnDefinition) bool {
+		if !strings.EqualFold(col.Type.Type, "varchar") || !strings.EqualFold(newCol.Type.Type, "varchar") {
+			return false
+		}
+		if col.Type.Length == nil || newCol.Type.Length == nil || *newCol.Type.Length < *col.Type.Length {
+			return false
+		}
+		if !strings.EqualFold(col.Name.String(), newCol.Name.String()) {
+			return false
+		}
+		maxBytesPerChar, ok := charsetMaxBytesPerChar[columnCharset(col)]
+		if !ok {
+			return false
+		}
+		if (*col.Type.Length*maxBytesPerChar > 255) != (*newCol.Type.Length*maxBytesPerChar > 255) {
+			// The number of length bytes changes, which requires a table copy.
+			return false
+		}
+		strippedCol := sqlparser.Clone(col)
+		strippedCol.Type.Length = nil
+		strippedNewCol := sqlparser.Clone(newCol)
+		strippedNewCol.Type.Length = nil
+		return sqlparser.CanonicalString(strippedCol) == sqlparser.CanonicalString(strippedNewCol)
+	}
+
+	switch opt := alterOption.(type) {
+	case *sqlparser.RenameIndex:
+		return findIndex(opt.OldName.String()) != nil
+	case *sqlparser.DropKey:
+		if opt.Type != sqlparser.NormalKeyType {
+			// Dropping the PRIMARY KEY rebuilds the table, and we leave constraints alone.
+			return false
+		}
+		index := findIndex(opt.Name.String())
+		return index != nil && index.Info.Type != sqlparser.IndexTypePrimary
+	case *sqlparser.AlterColumn:
+		return opt.DropDefault || opt.DefaultLiteral || opt.DefaultVal != nil || opt.Invisible != nil
+	case *sqlparser.ChangeColumn:
+		if opt.First || opt.After != nil {
+			return false
+		}
+		col := findColumn(opt.OldColumn.Name.String())
+		return col != nil && varcharExtendedInPlace(col, opt.NewColDefinition)
+	case *sqlparser.ModifyColumn:
+		if opt.First || opt.After != nil {
+			return false
+		}
+		col := findColumn(opt.NewColDefinition.Name.String())
+		return col != nil && varcharExtendedInPlace(col, opt.NewColDefinition)
+	case sqlparser.TableOptions:
+		// Changing the persistent statistics settings of a table only changes its metadata.
+		for _, tableOption := range opt {
+			switch strings.ToUpper(tableOption.Name) {
+			case "STATS_PERSISTENT", "STATS_AUTO_RECALC", "STATS_SAMPLE_PAGES":
+			default:
+				return false
+			}
+		}
+		return true
+	case sqlparser.AlgorithmValue:
+		// We accept an explicit ALGORITHM=INPLACE option.
+		return strings.EqualFold(string(opt), sqlparser.InplaceStr)
+	case *sqlparser.LockOption:
+		return opt.Type == sqlparser.NoneType || opt.Type == sqlparser.DefaultType
+	default:
+		return false
+	}
+}
+
+// AlterTableCapableOfInplaceMetadataDDL checks if the specific ALTER TABLE is eligible to run via ALGORITHM=INPLACE, LOCK=NONE
+// while only modifying the table's metadata, i.e. without rebuilding the table, given the existing table schema. Examples are
+// renaming or dropping a secondary index, or extending a VARCHAR column without changing the number of its length bytes.
+// The function is intentionally public, as it is intended to be used by other packages, such as onlineddl.
+func AlterTableCapableOfInplaceMetadataDDL(alterTable *sqlparser.AlterTable, createTable *sqlparser.CreateTable) (bool, error) {
+	if createTable == nil || len(alterTable.AlterOptions) == 0 {
+		return false, nil
+	}
+	if alterTable.PartitionOption != nil || alterTable.PartitionSpec != nil {
+		return false, nil
+	}
+	for _, alterOption := range alterTable.AlterOptions {
+		if !alterOptionCapableOfInplaceMetadataDDL(alterOption, createTable) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -385,3 +385,129 @@ func TestAlterTableCapableOfInstantDDL(t *testing.T) {
 		})
 	}
 }
+
+func TestAlterTableCapableOfInplaceMetadataDDL(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+
+	tcases := []struct {
+		name          string
+		create        string
+		alter         string
+		expectCapable bool
+	}{
+		{
+			name:          "rename index",
+			create:        "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:         "alter table t1 rename index i1_idx to i1_idx2",
+			expectCapable: true,
+		},
+		{
+			name:   "rename nonexistent index",
+			create: "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:  "alter table t1 rename index i2_idx to i1_idx2",
+		},
+		{
+			name:          "drop index",
+			create:        "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:         "alter table t1 drop key i1_idx",
+			expectCapable: true,
+		},
+		{
+			name:   "drop primary key",
+			create: "create table t1 (id int primary key, i1 int)",
+			alter:  "alter table t1 drop primary key",
+		},
+		{
+			name:   "add index",
+			create: "create table t1 (id int primary key, i1 int)",
+			alter:  "alter table t1 add key i1_idx (i1)",
+		},
+		{
+			name:          "change column default",
+			create:        "create table t1 (id int primary key, i1 int)",
+			alter:         "alter table t1 alter column i1 set default 5",
+			expectCapable: true,
+		},
+		{
+			name:          "extend varchar, utf8mb4, within 1 length byte",
+			create:        "create table t1 (id int primary key, v varchar(20)) charset utf8mb4",
+			alter:         "alter table t1 modify column v varchar(60)",
+			expectCapable: true,
+		},
+		{
+			name:   "extend varchar, utf8mb4, across length bytes",
+			create: "create table t1 (id int primary key, v varchar(20)) charset utf8mb4",
+			alter:  "alter table t1 modify column v varchar(64)",
+		},
+		{
+			name:          "extend varchar, latin1 column, within 2 length bytes",
+			create:        "create table t1 (id int primary key, v varchar(300) charset latin1) charset utf8mb4",
+			alter:         "alter table t1 change column v v varchar(400) charset latin1",
+			expectCapable: true,
+		},
+		{
+			name:   "extend varchar, unknown charset",
+			create: "create table t1 (id int primary key, v varchar(20))",
+			alter:  "alter table t1 modify column v varchar(30)",
+		},
+		{
+			name:   "shrink varchar",
+			create: "create table t1 (id int primary key, v varchar(20)) charset utf8mb4",
+			alter:  "alter table t1 modify column v varchar(10)",
+		},
+		{
+			name:   "extend varchar and change nullability",
+			create: "create table t1 (id int primary key, v varchar(20)) charset utf8mb4",
+			alter:  "alter table t1 modify column v varchar(30) not null",
+		},
+		{
+			name:   "extend varchar and reorder",
+			create: "create table t1 (id int primary key, v varchar(20)) charset utf8mb4",
+			alter:  "alter table t1 modify column v varchar(30) first",
+		},
+		{
+			name:          "stats options",
+			create:        "create table t1 (id int primary key)",
+			alter:         "alter table t1 stats_persistent=1, stats_sample_pages=50",
+			expectCapable: true,
+		},
+		{
+			name:   "engine option",
+			create: "create table t1 (id int primary key)",
+			alter:  "alter table t1 engine=innodb",
+		},
+		{
+			name:          "explicit algorithm and lock",
+			create:        "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:         "alter table t1 drop key i1_idx, algorithm=inplace, lock=none",
+			expectCapable: true,
+		},
+		{
+			name:   "explicit copy algorithm",
+			create: "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:  "alter table t1 drop key i1_idx, algorithm=copy",
+		},
+		{
+			name:   "mixed with add column",
+			create: "create table t1 (id int primary key, i1 int, key i1_idx (i1))",
+			alter:  "alter table t1 drop key i1_idx, add column i2 int",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			createTable, err := parser.ParseStrictDDL(tcase.create)
+			require.NoError(t, err, "failed to parse a CREATE TABLE statement from %q", tcase.create)
+			createTableStmt, ok := createTable.(*sqlparser.CreateTable)
+			require.True(t, ok)
+
+			alterTable, err := parser.ParseStrictDDL(tcase.alter)
+			require.NoError(t, err, "failed to parse a ALTER TABLE statement from %q", tcase.alter)
+			alterTableStmt, ok := alterTable.(*sqlparser.AlterTable)
+			require.True(t, ok)
+
+			isCapable, err := AlterTableCapableOfInplaceMetadataDDL(alterTableStmt, createTableStmt)
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectCapable, isCapable)
+		})
+	}
+}
@@ -741,6 +741,31 @@ func AddInstantAlgorithm(alterTable *sqlparser.AlterTable) {
 	alterTable.AlterOptions = append(alterTable.AlterOptions, instantOpt)
 }
 
+// AddInplaceAlgorithm adds or modifies the AlterTable's ALGORITHM to INPLACE, and its LOCK to NONE
+func AddInplaceAlgorithm(alterTable *sqlparser.AlterTable) {
+	inplaceOpt := sqlparser.AlgorithmValue("INPLACE")
+	lockOpt := &sqlparser.LockOption{Type: sqlparser.NoneType}
+	var hasAlgorithm, hasLock bool
+	for i, opt := range alterTable.AlterOptions {
+		switch opt.(type) {
+		case sqlparser.AlgorithmValue:
+			// replace an existing algorithm
+			alterTable.AlterOptions[i] = inplaceOpt
+			hasAlgorithm = true
+		case *sqlparser.LockOption:
+			// replace an existing lock
+			alterTable.AlterOptions[i] = lockOpt
+			hasLock = true
+		}
+	}
+	if !hasAlgorithm {
+		alterTable.AlterOptions = append(alterTable.AlterOptions, inplaceOpt)
+	}
+	if !hasLock {
+		alterTable.AlterOptions = append(alterTable.AlterOptions, lockOpt)
+	}
+}
+
 // DuplicateCreateTable parses the given `CREATE TABLE` statement, and returns:
 // - The format CreateTable AST
 // - A new CreateTable AST, with the table renamed as `newTableName`, and with constraints renamed deterministically
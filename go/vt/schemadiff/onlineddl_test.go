@@ -1293,6 +1293,45 @@ func TestAddInstantAlgorithm(t *testing.T) {
 	}
 }
 
+func TestAddInplaceAlgorithm(t *testing.T) {
+	tt := []struct {
+		alter  string
+		expect string
+	}{
+		{
+			alter:  "alter table t drop key i1_idx",
+			expect: "ALTER TABLE `t` DROP KEY `i1_idx`, ALGORITHM = INPLACE, LOCK NONE",
+		},
+		{
+			alter:  "alter table t drop key i1_idx, lock=shared",
+			expect: "ALTER TABLE `t` DROP KEY `i1_idx`, LOCK NONE, ALGORITHM = INPLACE",
+		},
+		{
+			alter:  "alter table t drop key i1_idx, algorithm=copy, lock=none",
+			expect: "ALTER TABLE `t` DROP KEY `i1_idx`, ALGORITHM = INPLACE, LOCK NONE",
+		},
+	}
+	env := NewTestEnv()
+	for _, tc := range tt {
+		t.Run(tc.alter, func(t *testing.T) {
+			stmt, err := env.Parser().ParseStrictDDL(tc.alter)
+			require.NoError(t, err)
+			alterTable, ok := stmt.(*sqlparser.AlterTable)
+			require.True(t, ok)
+
+			AddInplaceAlgorithm(alterTable)
+			alterInplace := sqlparser.CanonicalString(alterTable)
+
+			assert.Equal(t, tc.expect, alterInplace)
+
+			stmt, err = env.Parser().ParseStrictDDL(alterInplace)
+			require.NoError(t, err)
+			_, ok = stmt.(*sqlparser.AlterTable)
+			require.True(t, ok)
+		})
+	}
+}
+
 func TestDuplicateCreateTable(t *testing.T) {
 	baseUUID := "a5a563da_dc1a_11ec_a416_0a43f95f28a3"
 	allowForeignKeys := true
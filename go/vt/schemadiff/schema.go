@@ -778,6 +778,17 @@ func (s *Schema) copy() *Schema {
 // apply attempts to apply given list of diffs to this object.
 // These diffs are CREATE/DROP/ALTER TABLE/VIEW.
 func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
+	if err := s.applyDiffs(diffs); err != nil {
+		return err
+	}
+	if err := s.normalize(hints); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applyDiffs applies the given diffs onto the schema entities, without normalizing the schema.
+func (s *Schema) applyDiffs(diffs []EntityDiff) error {
 	for _, diff := range diffs {
 		switch diff := diff.(type) {
 		case *CreateTableEntityDiff:
@@ -887,9 +898,6 @@ func (s *Schema) apply(diffs []EntityDiff, hints *DiffHints) error {
 			return &UnsupportedApplyOperationError{Statement: diff.CanonicalStatementString()}
 		}
 	}
-	if err := s.normalize(hints); err != nil {
-		return err
-	}
 	return nil
 }
 
@@ -904,6 +912,125 @@ func (s *Schema) Apply(diffs []EntityDiff) (*Schema, error) {
 	return dup, nil
 }
 
+// ApplyStatements attempts to apply the given DDL statements, in order, to the schema described by this object.
+// Supported statements are CREATE/ALTER/DROP TABLE/VIEW and RENAME TABLE. IF [NOT] EXISTS and CREATE OR REPLACE
+// are honored. The schema is only validated once all statements are applied, hence the statements
+// need not be ordered by their dependencies (e.g. a view may be created before the table it reads from).
+// The operation does not modify this object. Instead, if successful, a new (modified) Schema is returned.
+func (s *Schema) ApplyStatements(statements []sqlparser.Statement) (*Schema, error) {
+	dup := s.copy()
+	for _, statement := range statements {
+		diffs, err := dup.statementDiffs(statement)
+		if err != nil {
+			return nil, err
+		}
+		if err := dup.applyDiffs(diffs); err != nil {
+			return nil, err
+		}
+	}
+	if err := dup.normalize(EmptyDiffHints()); err != nil {
+		return nil, err
+	}
+	return dup, nil
+}
+
+// statementDiffs converts a DDL statement into the diffs that apply it onto this schema.
+func (s *Schema) statementDiffs(statement sqlparser.Statement) ([]EntityDiff, error) {
+	switch stmt := statement.(type) {
+	case *sqlparser.CreateTable:
+		if stmt.IfNotExists && s.Table(stmt.Table.Name.String()) != nil {
+			return nil, nil
+		}
+		c, err := NewCreateTableEntity(s.env, stmt)
+		if err != nil {
+			return nil, err
+		}
+		return []EntityDiff{&CreateTableEntityDiff{to: c, createTable: c.CreateTable}}, nil
+	case *sqlparser.CreateView:
+		// The resulting schema holds a plain CREATE VIEW
+		createView := sqlparser.CloneRefOfCreateView(stmt)
+		createView.IsReplace = false
+		v, err := NewCreateViewEntity(s.env, createView)
+		if err != nil {
+			return nil, err
+		}
+		if from := s.View(stmt.ViewName.Name.String()); from != nil && stmt.IsReplace {
+			return []EntityDiff{
+				&DropViewEntityDiff{from: from, dropView: &sqlparser.DropView{FromTables: sqlparser.TableNames{stmt.ViewName}}},
+				&CreateViewEntityDiff{createView: v.CreateView},
+			}, nil
+		}
+		return []EntityDiff{&CreateViewEntityDiff{createView: v.CreateView}}, nil
+	case *sqlparser.AlterTable:
+		from := s.Table(stmt.Table.Name.String())
+		if from == nil {
+			return nil, &ApplyTableNotFoundError{Table: stmt.Table.Name.String()}
+		}
+		return []EntityDiff{&AlterTableEntityDiff{from: from, alterTable: stmt}}, nil
+	case *sqlparser.AlterView:
+		from := s.View(stmt.ViewName.Name.String())
+		if from == nil {
+			return nil, &ApplyViewNotFoundError{View: stmt.ViewName.Name.String()}
+		}
+		return []EntityDiff{&AlterViewEntityDiff{from: from, alterView: stmt}}, nil
+	case *sqlparser.DropTable:
+		var diffs []EntityDiff
+		for _, name := range stmt.FromTables {
+			from := s.Table(name.Name.String())
+			if from == nil {
+				if stmt.IfExists {
+					continue
+				}
+				return nil, &ApplyTableNotFoundError{Table: name.Name.String()}
+			}
+			diffs = append(diffs, &DropTableEntityDiff{from: from, dropTable: &sqlparser.DropTable{FromTables: sqlparser.TableNames{name}}})
+		}
+		return diffs, nil
+	case *sqlparser.DropView:
+		var diffs []EntityDiff
+		for _, name := range stmt.FromTables {
+			from := s.View(name.Name.String())
+			if from == nil {
+				if stmt.IfExists {
+					continue
+				}
+				return nil, &ApplyViewNotFoundError{View: name.Name.String()}
+			}
+			diffs = append(diffs, &DropViewEntityDiff{from: from, dropView: &sqlparser.DropView{FromTables: sqlparser.TableNames{name}}})
+		}
+		return diffs, nil
+	case *sqlparser.RenameTable:
+		// Renames are applied sequentially, so that a table may be renamed in one pair and referenced in the next.
+		// We therefore track the tables as they would be after each pair.
+		tables := map[string]*CreateTableEntity{}
+		for _, t := range s.tables {
+			tables[t.Name()] = t
+		}
+		var diffs []EntityDiff
+		for _, pair := range stmt.TablePairs {
+			fromName, toName := pair.FromTable.Name.String(), pair.ToTable.Name.String()
+			from, ok := tables[fromName]
+			if !ok {
+				return nil, &ApplyTableNotFoundError{Table: fromName}
+			}
+			if _, ok := tables[toName]; ok {
+				return nil, &ApplyDuplicateEntityError{Entity: toName}
+			}
+			to := from.Clone().(*CreateTableEntity)
+			to.Table = sqlparser.TableName{Name: pair.ToTable.Name}
+			delete(tables, fromName)
+			tables[toName] = to
+			diffs = append(diffs, &RenameTableEntityDiff{
+				from:        from,
+				to:          to,
+				renameTable: &sqlparser.RenameTable{TablePairs: []*sqlparser.RenameTablePair{pair}},
+			})
+		}
+		return diffs, nil
+	}
+	return nil, &UnsupportedStatementError{Statement: sqlparser.CanonicalString(statement)}
+}
+
 // SchemaDiff calculates a rich diff between this schema and the given schema. It builds on top of diff():
 // on top of the list of diffs that can take this schema into the given schema, this function also
 // evaluates the dependencies between those diffs, if any, and the resulting SchemaDiff object offers OrderedDiffs(),
@@ -254,6 +254,102 @@ func TestCopy(t *testing.T) {
 	assert.False(t, schema == schemaClone)
 }
 
+func TestApplyStatements(t *testing.T) {
+	tcs := []struct {
+		name       string
+		statements []string
+		expectSQL  string
+		expectErr  error
+	}{
+		{
+			name:       "create table",
+			statements: []string{"create table t4(id int)"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t2` (\n\t`id` int\n);\nCREATE TABLE `t4` (\n\t`id` int\n);\nCREATE VIEW `v1` AS SELECT * FROM `t1`;\n",
+		},
+		{
+			name:       "create view before table",
+			statements: []string{"create view v2 as select * from t4", "create table t4(id int)"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t2` (\n\t`id` int\n);\nCREATE TABLE `t4` (\n\t`id` int\n);\nCREATE VIEW `v1` AS SELECT * FROM `t1`;\nCREATE VIEW `v2` AS SELECT * FROM `t4`;\n",
+		},
+		{
+			name:       "create existing table",
+			statements: []string{"create table t1(id int)"},
+			expectErr:  &ApplyDuplicateEntityError{Entity: "t1"},
+		},
+		{
+			name:       "create table if not exists",
+			statements: []string{"create table if not exists t1(id bigint)"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t2` (\n\t`id` int\n);\nCREATE VIEW `v1` AS SELECT * FROM `t1`;\n",
+		},
+		{
+			name:       "alter table",
+			statements: []string{"alter table t2 add column i int, add key i_idx(i)"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t2` (\n\t`id` int,\n\t`i` int,\n\tKEY `i_idx` (`i`)\n);\nCREATE VIEW `v1` AS SELECT * FROM `t1`;\n",
+		},
+		{
+			name:       "alter missing table",
+			statements: []string{"alter table t9 add column i int"},
+			expectErr:  &ApplyTableNotFoundError{Table: "t9"},
+		},
+		{
+			name:       "drop tables",
+			statements: []string{"drop view v1", "drop table if exists t1, t9"},
+			expectSQL:  "CREATE TABLE `t2` (\n\t`id` int\n);\n",
+		},
+		{
+			name:       "drop missing table",
+			statements: []string{"drop table t9"},
+			expectErr:  &ApplyTableNotFoundError{Table: "t9"},
+		},
+		{
+			name:       "create or replace view",
+			statements: []string{"create or replace view v1 as select * from t2"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t2` (\n\t`id` int\n);\nCREATE VIEW `v1` AS SELECT * FROM `t2`;\n",
+		},
+		{
+			name:       "rename tables",
+			statements: []string{"drop view v1", "rename table t1 to t3, t2 to t1"},
+			expectSQL:  "CREATE TABLE `t1` (\n\t`id` int\n);\nCREATE TABLE `t3` (\n\t`id` int\n);\n",
+		},
+		{
+			name:       "rename onto existing table",
+			statements: []string{"rename table t1 to t2"},
+			expectErr:  &ApplyDuplicateEntityError{Entity: "t2"},
+		},
+		{
+			name:       "unsupported statement",
+			statements: []string{"truncate table t1"},
+			expectErr:  &UnsupportedStatementError{Statement: "TRUNCATE TABLE `t1`"},
+		},
+	}
+	env := NewTestEnv()
+	schema, err := NewSchemaFromQueries(env, []string{
+		"create table t1(id int)",
+		"create table t2(id int)",
+		"create view v1 as select * from t1",
+	})
+	require.NoError(t, err)
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var statements []sqlparser.Statement
+			for _, query := range tc.statements {
+				stmt, err := env.Parser().Parse(query)
+				require.NoError(t, err)
+				statements = append(statements, stmt)
+			}
+			applied, err := schema.ApplyStatements(statements)
+			if tc.expectErr != nil {
+				assert.Equal(t, tc.expectErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectSQL, applied.ToSQL())
+		})
+	}
+	// The original schema is unmodified
+	assert.Equal(t, []string{"t1", "t2", "v1"}, schema.EntityNames())
+}
+
 func TestGetViewDependentTableNames(t *testing.T) {
 	tt := []struct {
 		name   string
@@ -60,6 +60,9 @@ type TabletExecutor struct {
 	uuids               []string
 	batchSize           int64
 	parser              *sqlparser.Parser
+	// shardFilter, if set, restricts the executor to the shards for which it
+	// returns true.
+	shardFilter func(shard string) bool
 }
 
 // NewTabletExecutor creates a new TabletExecutor instance
@@ -102,6 +105,12 @@ func (exec *TabletExecutor) SetUUIDList(uuids []string) error {
 	return nil
 }
 
+// SetShardFilter restricts the executor to the shards for which the filter
+// returns true, such as the canary shard of a schema change.
+func (exec *TabletExecutor) SetShardFilter(filter func(shard string) bool) {
+	exec.shardFilter = filter
+}
+
 // hasProvidedUUIDs returns true when UUIDs were provided
 func (exec *TabletExecutor) hasProvidedUUIDs() bool {
 	return len(exec.uuids) != 0
@@ -119,6 +128,9 @@ func (exec *TabletExecutor) Open(ctx context.Context, keyspace string) error {
 	}
 	exec.tablets = make([]*topodatapb.Tablet, 0, len(shards))
 	for shardName, shardInfo := range shards {
+		if exec.shardFilter != nil && !exec.shardFilter(shardName) {
+			continue
+		}
 		if !shardInfo.HasPrimary() {
 			return fmt.Errorf("shard: %s does not have a primary", shardName)
 		}
@@ -50,6 +50,25 @@ func TestTabletExecutorOpen(t *testing.T) {
 	require.NoError(t, err, "open an opened executor should also succeed")
 }
 
+func TestTabletExecutorShardFilter(t *testing.T) {
+	executor := newFakeExecutor(t)
+	executor.SetShardFilter(func(shard string) bool { return shard != "1" })
+	err := executor.Open(t.Context(), "test_keyspace")
+	require.NoError(t, err)
+	defer executor.Close()
+
+	var shards []string
+	for _, tablet := range executor.tablets {
+		shards = append(shards, tablet.Shard)
+	}
+	assert.ElementsMatch(t, []string{"0", "2"}, shards)
+
+	executor = newFakeExecutor(t)
+	executor.SetShardFilter(func(shard string) bool { return false })
+	err = executor.Open(t.Context(), "test_keyspace")
+	assert.EqualError(t, err, "keyspace: test_keyspace does not contain any primary tablets")
+}
+
 func TestTabletExecutorOpenWithEmptyPrimaryAlias(t *testing.T) {
 	ctx := t.Context()
 	ts := memorytopo.NewServer(ctx, "test_cell")
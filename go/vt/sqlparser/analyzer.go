@@ -83,7 +83,7 @@ func ASTToStatementType(stmt Statement) StatementType {
 		return StmtShow
 	case DDLStatement, DBDDLStatement, *AlterVschema:
 		return StmtDDL
-	case *AlterMigration, *RevertMigration, *ShowMigrationLogs:
+	case *AlterMigration, *RevertMigration, *ShowMigrationLogs, *AlterWorkflow:
 		return StmtMigration
 	case *Use:
 		return StmtUse
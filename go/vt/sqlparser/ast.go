@@ -529,6 +529,15 @@ type (
 		Shards    string
 	}
 
+	// AlterWorkflowType represents the type of operation in an ALTER VITESS_WORKFLOW statement
+	AlterWorkflowType int8
+
+	// AlterWorkflow represents a ALTER VITESS_WORKFLOW statement
+	AlterWorkflow struct {
+		Type     AlterWorkflowType
+		Workflow string
+	}
+
 	// CreateProcedure represents a CREATE PROCEDURE statement.
 	CreateProcedure struct {
 		Name        TableName
@@ -943,6 +952,7 @@ func (*UnlockTables) iStatement()          {}
 func (*AlterTable) iStatement()            {}
 func (*AlterVschema) iStatement()          {}
 func (*AlterMigration) iStatement()        {}
+func (*AlterWorkflow) iStatement()         {}
 func (*CreateProcedure) iStatement()       {}
 func (*RevertMigration) iStatement()       {}
 func (*ShowMigrationLogs) iStatement()     {}
@@ -53,6 +53,8 @@ func CloneSQLNode(in SQLNode) SQLNode {
 		return CloneRefOfAlterView(in)
 	case *AlterVschema:
 		return CloneRefOfAlterVschema(in)
+	case *AlterWorkflow:
+		return CloneRefOfAlterWorkflow(in)
 	case *Analyze:
 		return CloneRefOfAnalyze(in)
 	case *AndExpr:
@@ -769,6 +771,15 @@ func CloneRefOfAlterVschema(n *AlterVschema) *AlterVschema {
 	return &out
 }
 
+// CloneRefOfAlterWorkflow creates a deep clone of the input.
+func CloneRefOfAlterWorkflow(n *AlterWorkflow) *AlterWorkflow {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	return &out
+}
+
 // CloneRefOfAnalyze creates a deep clone of the input.
 func CloneRefOfAnalyze(n *Analyze) *Analyze {
 	if n == nil {
@@ -4507,6 +4518,8 @@ func CloneStatement(in Statement) Statement {
 		return CloneRefOfAlterView(in)
 	case *AlterVschema:
 		return CloneRefOfAlterVschema(in)
+	case *AlterWorkflow:
+		return CloneRefOfAlterWorkflow(in)
 	case *Analyze:
 		return CloneRefOfAnalyze(in)
 	case *Begin:
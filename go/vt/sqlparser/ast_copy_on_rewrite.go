@@ -52,6 +52,8 @@ func (c *cow) copyOnRewriteSQLNode(n SQLNode, parent SQLNode) (out SQLNode, chan
 		return c.copyOnRewriteRefOfAlterView(n, parent)
 	case *AlterVschema:
 		return c.copyOnRewriteRefOfAlterVschema(n, parent)
+	case *AlterWorkflow:
+		return c.copyOnRewriteRefOfAlterWorkflow(n, parent)
 	case *Analyze:
 		return c.copyOnRewriteRefOfAnalyze(n, parent)
 	case *AndExpr:
@@ -984,6 +986,19 @@ func (c *cow) copyOnRewriteRefOfAlterVschema(n *AlterVschema, parent SQLNode) (o
 	return
 }
 
+func (c *cow) copyOnRewriteRefOfAlterWorkflow(n *AlterWorkflow, parent SQLNode) (out SQLNode, changed bool) {
+	if n == nil || c.cursor.stop {
+		return n, false
+	}
+	out = n
+	if c.pre == nil || c.pre(n, parent) {
+	}
+	if c.post != nil {
+		out, changed = c.postVisit(out, parent, changed)
+	}
+	return
+}
+
 func (c *cow) copyOnRewriteRefOfAnalyze(n *Analyze, parent SQLNode) (out SQLNode, changed bool) {
 	if n == nil || c.cursor.stop {
 		return n, false
@@ -8516,6 +8531,8 @@ func (c *cow) copyOnRewriteStatement(n Statement, parent SQLNode) (out SQLNode,
 		return c.copyOnRewriteRefOfAlterView(n, parent)
 	case *AlterVschema:
 		return c.copyOnRewriteRefOfAlterVschema(n, parent)
+	case *AlterWorkflow:
+		return c.copyOnRewriteRefOfAlterWorkflow(n, parent)
 	case *Analyze:
 		return c.copyOnRewriteRefOfAnalyze(n, parent)
 	case *Begin:
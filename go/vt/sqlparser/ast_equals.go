@@ -116,6 +116,12 @@ func (cmp *Comparator) SQLNode(inA, inB SQLNode) bool {
 			return false
 		}
 		return cmp.RefOfAlterVschema(a, b)
+	case *AlterWorkflow:
+		b, ok := inB.(*AlterWorkflow)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfAlterWorkflow(a, b)
 	case *Analyze:
 		b, ok := inB.(*Analyze)
 		if !ok {
@@ -1965,6 +1971,18 @@ func (cmp *Comparator) RefOfAlterVschema(a, b *AlterVschema) bool {
 		cmp.RefOfAutoIncSpec(a.AutoIncSpec, b.AutoIncSpec)
 }
 
+// RefOfAlterWorkflow does deep equals between the two objects.
+func (cmp *Comparator) RefOfAlterWorkflow(a, b *AlterWorkflow) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Workflow == b.Workflow &&
+		a.Type == b.Type
+}
+
 // RefOfAnalyze does deep equals between the two objects.
 func (cmp *Comparator) RefOfAnalyze(a, b *Analyze) bool {
 	if a == b {
@@ -7366,6 +7384,12 @@ func (cmp *Comparator) Statement(inA, inB Statement) bool {
 			return false
 		}
 		return cmp.RefOfAlterVschema(a, b)
+	case *AlterWorkflow:
+		b, ok := inB.(*AlterWorkflow)
+		if !ok {
+			return false
+		}
+		return cmp.RefOfAlterWorkflow(a, b)
 	case *Analyze:
 		b, ok := inB.(*Analyze)
 		if !ok {
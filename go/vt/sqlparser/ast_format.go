@@ -363,6 +363,21 @@ func (node *AlterMigration) Format(buf *TrackedBuffer) {
 	}
 }
 
+// Format formats the node.
+func (node *AlterWorkflow) Format(buf *TrackedBuffer) {
+	buf.astPrintf(node, "alter vitess_workflow '%#s'", node.Workflow)
+	var alterType string
+	switch node.Type {
+	case StopWorkflowType:
+		alterType = "stop"
+	case StartWorkflowType:
+		alterType = "start"
+	case SwitchTrafficWorkflowType:
+		alterType = "switch traffic"
+	}
+	buf.astPrintf(node, " %#s", alterType)
+}
+
 // Format formats the node.
 func (node *CreateProcedure) Format(buf *TrackedBuffer) {
 	buf.astPrintf(node, "create %v", node.Comments)
@@ -1900,7 +1915,7 @@ func (node *WindowSpecification) Format(buf *TrackedBuffer) {
 		buf.astPrintf(node, "%v", node.Name)
 		hasContent = true
 	}
-	if node.PartitionClause != nil {
+	if len(node.PartitionClause) > 0 {
 		if hasContent {
 			buf.astPrintf(node, " partition by %n", node.PartitionClause)
 		} else {
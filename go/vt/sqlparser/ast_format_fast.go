@@ -497,6 +497,24 @@ func (node *AlterMigration) FormatFast(buf *TrackedBuffer) {
 	}
 }
 
+// FormatFast formats the node.
+func (node *AlterWorkflow) FormatFast(buf *TrackedBuffer) {
+	buf.WriteString("alter vitess_workflow '")
+	buf.WriteString(node.Workflow)
+	buf.WriteByte('\'')
+	var alterType string
+	switch node.Type {
+	case StopWorkflowType:
+		alterType = "stop"
+	case StartWorkflowType:
+		alterType = "start"
+	case SwitchTrafficWorkflowType:
+		alterType = "switch traffic"
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(alterType)
+}
+
 // FormatFast formats the node.
 func (node *CreateProcedure) FormatFast(buf *TrackedBuffer) {
 	buf.WriteString("create ")
@@ -2493,7 +2511,7 @@ func (node *WindowSpecification) FormatFast(buf *TrackedBuffer) {
 		node.Name.FormatFast(buf)
 		hasContent = true
 	}
-	if node.PartitionClause != nil {
+	if len(node.PartitionClause) > 0 {
 		if hasContent {
 			buf.WriteString(" partition by ")
 			buf.formatExprs(node.PartitionClause)
@@ -631,6 +631,26 @@ func NewTimestampLiteral(in string) *Literal {
 	return &Literal{Type: TimestampVal, Val: in}
 }
 
+// NewODBCEscapeExpr builds the expression of an ODBC escape sequence
+// {ident expr}. As in MySQL, {d 'str'}, {t 'str'} and {ts 'str'} are date
+// and time literals, and any other escape sequence is its expression, such
+// as {fn concat(a, b)}.
+func NewODBCEscapeExpr(ident IdentifierCI, expr Expr) Expr {
+	lit, ok := expr.(*Literal)
+	if !ok || lit.Type != StrVal {
+		return expr
+	}
+	switch ident.Lowered() {
+	case "d":
+		return NewDateLiteral(lit.Val)
+	case "t":
+		return NewTimeLiteral(lit.Val)
+	case "ts":
+		return NewTimestampLiteral(lit.Val)
+	}
+	return expr
+}
+
 // NewArgument builds a new ValArg.
 func NewArgument(in string) *Argument {
 	return &Argument{Name: in, Type: sqltypes.Unknown}
@@ -2273,6 +2293,8 @@ func (ty ShowCommandType) ToString() string {
 		return VariableSessionStr
 	case VGtidExecGlobal:
 		return VGtidExecGlobalStr
+	case VitessBuffers:
+		return VitessBuffersStr
 	case VitessMigrations:
 		return VitessMigrationsStr
 	case VitessReplicationStatus:
@@ -2285,6 +2307,8 @@ func (ty ShowCommandType) ToString() string {
 		return VitessTargetStr
 	case VitessVariables:
 		return VitessVariablesStr
+	case VitessWorkflows:
+		return VitessWorkflowsStr
 	case VschemaTables:
 		return VschemaTablesStr
 	case VschemaKeyspaces:
@@ -53,6 +53,8 @@ func (a *application) rewriteSQLNode(parent SQLNode, node SQLNode, replacer repl
 		return a.rewriteRefOfAlterView(parent, node, replacer)
 	case *AlterVschema:
 		return a.rewriteRefOfAlterVschema(parent, node, replacer)
+	case *AlterWorkflow:
+		return a.rewriteRefOfAlterWorkflow(parent, node, replacer)
 	case *Analyze:
 		return a.rewriteRefOfAnalyze(parent, node, replacer)
 	case *AndExpr:
@@ -1361,6 +1363,37 @@ func (a *application) rewriteRefOfAlterVschema(parent SQLNode, node *AlterVschem
 	return true
 }
 
+// Function Generation Source: PtrToStructMethod
+func (a *application) rewriteRefOfAlterWorkflow(parent SQLNode, node *AlterWorkflow, replacer replacerFunc) bool {
+	if node == nil {
+		return true
+	}
+	if a.pre != nil {
+		a.cur.replacer = replacer
+		a.cur.parent = parent
+		a.cur.node = node
+		kontinue := !a.pre(&a.cur)
+		if a.cur.revisit {
+			a.cur.revisit = false
+			return a.rewriteSQLNode(parent, a.cur.node, replacer)
+		}
+		if kontinue {
+			return true
+		}
+	}
+	if a.post != nil {
+		if a.pre == nil {
+			a.cur.replacer = replacer
+			a.cur.parent = parent
+			a.cur.node = node
+		}
+		if !a.post(&a.cur) {
+			return false
+		}
+	}
+	return true
+}
+
 // Function Generation Source: PtrToStructMethod
 func (a *application) rewriteRefOfAnalyze(parent SQLNode, node *Analyze, replacer replacerFunc) bool {
 	if node == nil {
@@ -15551,6 +15584,8 @@ func (a *application) rewriteStatement(parent SQLNode, node Statement, replacer
 		return a.rewriteRefOfAlterView(parent, node, replacer)
 	case *AlterVschema:
 		return a.rewriteRefOfAlterVschema(parent, node, replacer)
+	case *AlterWorkflow:
+		return a.rewriteRefOfAlterWorkflow(parent, node, replacer)
 	case *Analyze:
 		return a.rewriteRefOfAnalyze(parent, node, replacer)
 	case *Begin:
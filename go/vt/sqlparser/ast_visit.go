@@ -52,6 +52,8 @@ func VisitSQLNode(in SQLNode, f Visit) error {
 		return VisitRefOfAlterView(in, f)
 	case *AlterVschema:
 		return VisitRefOfAlterVschema(in, f)
+	case *AlterWorkflow:
+		return VisitRefOfAlterWorkflow(in, f)
 	case *Analyze:
 		return VisitRefOfAnalyze(in, f)
 	case *AndExpr:
@@ -852,6 +854,16 @@ func VisitRefOfAlterVschema(in *AlterVschema, f Visit) error {
 	return nil
 }
 
+func VisitRefOfAlterWorkflow(in *AlterWorkflow, f Visit) error {
+	if in == nil {
+		return nil
+	}
+	if cont, err := f(in); err != nil || !cont {
+		return err
+	}
+	return nil
+}
+
 func VisitRefOfAnalyze(in *Analyze, f Visit) error {
 	if in == nil {
 		return nil
@@ -5943,6 +5955,8 @@ func VisitStatement(in Statement, f Visit) error {
 		return VisitRefOfAlterView(in, f)
 	case *AlterVschema:
 		return VisitRefOfAlterVschema(in, f)
+	case *AlterWorkflow:
+		return VisitRefOfAlterWorkflow(in, f)
 	case *Analyze:
 		return VisitRefOfAnalyze(in, f)
 	case *Begin:
@@ -313,6 +313,19 @@ func (cached *AlterVschema) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *AlterWorkflow) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(24)
+	}
+	// field Workflow string
+	size += hack.RuntimeAllocSize(int64(len(cached.Workflow)))
+	return size
+}
+
 func (cached *Analyze) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -3953,6 +3966,8 @@ func (cached *QueryHints) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.Priority)))
 	// field Timeout *int
 	size += hack.RuntimeAllocSize(int64(8))
+	// field ScatterConcurrency *int
+	size += hack.RuntimeAllocSize(int64(8))
 	return size
 }
 
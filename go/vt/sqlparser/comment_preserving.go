@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// CommentedStatement is a statement parsed by ParsePreservingComments, along
+// with the comments which surround it. Formatting it gives back all the
+// comments of the SQL it was parsed from.
+type CommentedStatement struct {
+	Statement Statement
+	// Comments are the comments before and after the statement.
+	Comments MarginComments
+}
+
+// ParsePreservingComments parses a single statement like ParseStrictDDL, and
+// keeps all its comments so that tools which rewrite SQL do not lose them:
+// the /* ... */ comments before and after the statement are kept in the
+// Comments of the CommentedStatement, and those right after its first keyword,
+// such as the optimizer hints of SELECT /*+ ... */ or the Vitess directives,
+// are kept in the AST. It fails if the SQL has a comment anywhere else, or a
+// MySQL specific /*! ... */ comment, which the AST cannot hold, rather than
+// silently dropping it as Parse does.
+func (p *Parser) ParsePreservingComments(sql string) (*CommentedStatement, error) {
+	query, comments := SplitMarginComments(sql)
+	tokenizer := p.NewStringTokenizer(query)
+	tokenizer.keepDroppedComments = true
+	if yyParsePooled(tokenizer) != 0 {
+		return nil, tokenizer.LastError
+	}
+	if err := checkParseTreesError(tokenizer); err != nil {
+		return nil, err
+	}
+	if len(tokenizer.droppedComments) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "comment %s cannot be preserved at its position in the statement", tokenizer.droppedComments[0])
+	}
+	return &CommentedStatement{Statement: tokenizer.ParseTrees[0], Comments: comments}, nil
+}
+
+// String returns the statement formatted as by String, with its comments.
+func (cs *CommentedStatement) String() string {
+	return cs.Comments.Leading + String(cs.Statement) + cs.Comments.Trailing
+}
+
+// PrettyString returns the statement formatted as by PrettyString, with its
+// comments.
+func (cs *CommentedStatement) PrettyString() string {
+	return cs.Comments.Leading + PrettyString(cs.Statement) + cs.Comments.Trailing
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePreservingComments(t *testing.T) {
+	testCases := []struct {
+		input, output, pretty string
+	}{{
+		input:  "/* leading */ select /*+ SET_VAR(sort_buffer_size = 16M) */ a from t /* trailing */",
+		output: "/* leading */ select /*+ SET_VAR(sort_buffer_size = 16M) */ a from t /* trailing */",
+		pretty: "/* leading */ SELECT /*+ SET_VAR(sort_buffer_size = 16M) */ a\nFROM t /* trailing */",
+	}, {
+		input:  "/* a */ /* b */\ninsert /* vt+ QUERY_TIMEOUT_MS=10 */ into t(a) values (1);",
+		output: "/* a */ /* b */\ninsert /* vt+ QUERY_TIMEOUT_MS=10 */ into t(a) values (1)",
+		pretty: "/* a */ /* b */\nINSERT /* vt+ QUERY_TIMEOUT_MS=10 */ INTO t(a)\nVALUES (1)",
+	}, {
+		input:  "alter /* online */ table t add column b int",
+		output: "alter /* online */ table t add column b int",
+		pretty: "ALTER /* online */ TABLE t ADD COLUMN b int",
+	}}
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			stmt, err := parser.ParsePreservingComments(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.output, stmt.String())
+			assert.Equal(t, tc.pretty, stmt.PrettyString())
+
+			// Both forms parse back into the same statement and comments.
+			for _, sql := range []string{stmt.String(), stmt.PrettyString()} {
+				reparsed, err := parser.ParsePreservingComments(sql)
+				require.NoError(t, err)
+				assert.Equal(t, stmt.String(), reparsed.String())
+			}
+		})
+	}
+}
+
+func TestParsePreservingCommentsErrors(t *testing.T) {
+	testCases := []struct {
+		input, err string
+	}{{
+		input: "select a /* inline */ from t",
+		err:   "comment /* inline */ cannot be preserved at its position in the statement",
+	}, {
+		input: "select a from t where b = 1 -- trailing",
+		err:   "comment -- trailing cannot be preserved at its position in the statement",
+	}, {
+		input: "select /*!40001 SQL_NO_CACHE */ a from t",
+		err:   "comment /*!40001 SQL_NO_CACHE */ cannot be preserved at its position in the statement",
+	}, {
+		input: "select a from t; select b from t",
+		err:   "Expected a single statement",
+	}, {
+		input: "/* only a comment */",
+		err:   "Query was empty",
+	}, {
+		input: "select from",
+		err:   "syntax error at position 12 near 'from'",
+	}}
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := parser.ParsePreservingComments(tc.input)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -47,6 +47,9 @@ const (
 	DirectiveAllowScatter = "ALLOW_SCATTER"
 	// DirectiveAllowHashJoin lets the planner use hash join if possible
 	DirectiveAllowHashJoin = "ALLOW_HASH_JOIN"
+	// DirectiveAllowCoveringLookup lets the planner answer a point select from the lookup table of a covering vindex,
+	// whose covering columns can lag behind the owner table.
+	DirectiveAllowCoveringLookup = "ALLOW_COVERING_LOOKUP"
 	// DirectiveQueryPlanner lets the user specify per query which planner should be used
 	DirectiveQueryPlanner = "PLANNER"
 	// DirectiveVExplainRunDMLQueries tells vexplain queries/all that it is okay to also run the query.
@@ -58,6 +61,11 @@ const (
 	// DirectivePriority specifies the priority of a workload. It should be an integer between 0 and MaxPriorityValue,
 	// where 0 is the highest priority, and MaxPriorityValue is the lowest one.
 	DirectivePriority = "PRIORITY"
+	// DirectiveDMLBatchSize sets the number of rows updated or deleted by each DML a DML with input sends to the
+	// tablets.
+	DirectiveDMLBatchSize = "DML_BATCH_SIZE"
+	// DirectiveScatterConcurrency bounds the number of shards a scatter query is sent to in parallel.
+	DirectiveScatterConcurrency = "SCATTER_CONCURRENCY"
 
 	// MaxPriorityValue specifies the maximum value allowed for the priority query directive. Valid priority values are
 	// between zero and MaxPriorityValue.
@@ -571,6 +579,7 @@ type QueryHints struct {
 	ForeignKeyChecks    *bool
 	Priority            string
 	Timeout             *int
+	ScatterConcurrency  *int
 }
 
 func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
@@ -592,6 +601,10 @@ func BuildQueryHints(stmt Statement) (qh QueryHints, err error) {
 	qh.Workload = getWorkload(directives)
 	qh.ForeignKeyChecks = getForeignKeyChecksState(comment)
 	qh.Timeout = getQueryTimeout(directives)
+	qh.ScatterConcurrency, err = getScatterConcurrency(directives)
+	if err != nil {
+		return qh, err
+	}
 
 	return qh, nil
 }
@@ -664,3 +677,18 @@ func getQueryTimeout(directives *CommentDirectives) *int {
 	}
 	return &timeout
 }
+
+// getScatterConcurrency returns the number of shards a scatter query can be
+// sent to in parallel, if set in the directives.
+func getScatterConcurrency(directives *CommentDirectives) (*int, error) {
+	concurrencyString, ok := directives.GetString(DirectiveScatterConcurrency, "")
+	if !ok {
+		return nil, nil
+	}
+
+	concurrency, err := strconv.Atoi(concurrencyString)
+	if err != nil || concurrency <= 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s value: %s", DirectiveScatterConcurrency, concurrencyString)
+	}
+	return &concurrency, nil
+}
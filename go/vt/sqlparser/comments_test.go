@@ -698,3 +698,44 @@ func TestQueryTimeout(t *testing.T) {
 		})
 	}
 }
+
+// TestScatterConcurrency tests the extraction of SCATTER_CONCURRENCY from the comments.
+func TestScatterConcurrency(t *testing.T) {
+	testCases := []struct {
+		query          string
+		expConcurrency int
+		noConcurrency  bool
+		expErr         string
+	}{{
+		query:         "select * from a_table",
+		noConcurrency: true,
+	}, {
+		query:          "select /*vt+ SCATTER_CONCURRENCY=4 */ * from another_table",
+		expConcurrency: 4,
+	}, {
+		query:  "select /*vt+ SCATTER_CONCURRENCY=0 */ * from another_table",
+		expErr: "invalid SCATTER_CONCURRENCY value: 0",
+	}, {
+		query:  "select /*vt+ SCATTER_CONCURRENCY=all */ * from another_table",
+		expErr: "invalid SCATTER_CONCURRENCY value: all",
+	}}
+
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			qh, err := BuildQueryHints(stmt)
+			if tc.expErr != "" {
+				require.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.noConcurrency {
+				assert.Nil(t, qh.ScatterConcurrency)
+			} else {
+				assert.Equal(t, tc.expConcurrency, *qh.ScatterConcurrency)
+			}
+		})
+	}
+}
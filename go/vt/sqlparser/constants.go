@@ -337,12 +337,14 @@ const (
 	VariableSessionStr         = " variables"
 	VGtidExecGlobalStr         = " global vgtid_executed"
 	KeyspaceStr                = " keyspaces"
+	VitessBuffersStr           = " vitess_buffers"
 	VitessMigrationsStr        = " vitess_migrations"
 	VitessReplicationStatusStr = " vitess_replication_status"
 	VitessShardsStr            = " vitess_shards"
 	VitessTabletsStr           = " vitess_tablets"
 	VitessTargetStr            = " vitess_target"
 	VitessVariablesStr         = " vitess_metadata variables"
+	VitessWorkflowsStr         = " vitess_workflows"
 	VschemaTablesStr           = " vschema tables"
 	VschemaKeyspacesStr        = " vschema keyspaces"
 	VschemaVindexesStr         = " vschema vindexes"
@@ -925,12 +927,14 @@ const (
 	VariableGlobal
 	VariableSession
 	VGtidExecGlobal
+	VitessBuffers
 	VitessMigrations
 	VitessReplicationStatus
 	VitessShards
 	VitessTablets
 	VitessTarget
 	VitessVariables
+	VitessWorkflows
 	VschemaTables
 	VschemaKeyspaces
 	VschemaVindexes
@@ -977,6 +981,13 @@ const (
 	SetCutOverThresholdMigrationType
 )
 
+// AlterWorkflowType constants
+const (
+	StopWorkflowType AlterWorkflowType = iota
+	StartWorkflowType
+	SwitchTrafficWorkflowType
+)
+
 // ColumnStorage constants
 const (
 	VirtualStorage ColumnStorage = iota
@@ -487,6 +487,7 @@ var keywords = []keyword{
 	{"of", OF},
 	{"off", OFF},
 	{"offset", OFFSET},
+	{"oj", OJ},
 	{"on", ON},
 	{"only", ONLY},
 	{"open", OPEN},
@@ -638,6 +639,7 @@ var keywords = []keyword{
 	{"stddev", STDDEV},
 	{"stddev_pop", STDDEV_POP},
 	{"stddev_samp", STDDEV_SAMP},
+	{"stop", STOP},
 	{"storage", STORAGE},
 	{"stored", STORED},
 	{"straight_join", STRAIGHT_JOIN},
@@ -709,6 +711,7 @@ var keywords = []keyword{
 	{"subclass_origin", SUBCLASS_ORIGIN},
 	{"subdate", SUBDATE},
 	{"sum", SUM},
+	{"switch", SWITCH},
 	{"sysdate", SYSDATE},
 	{"system", UNUSED},
 	{"table", TABLE},
@@ -737,6 +740,7 @@ var keywords = []keyword{
 	{"transactions", TRANSACTIONS},
 	{"tree", TREE},
 	{"traditional", TRADITIONAL},
+	{"traffic", TRAFFIC},
 	{"trigger", TRIGGER},
 	{"triggers", TRIGGERS},
 	{"true", TRUE},
@@ -784,6 +788,7 @@ var keywords = []keyword{
 	{"vindexes", VINDEXES},
 	{"view", VIEW},
 	{"vitess", VITESS},
+	{"vitess_buffers", VITESS_BUFFERS},
 	{"vitess_keyspaces", VITESS_KEYSPACES},
 	{"vitess_metadata", VITESS_METADATA},
 	{"vitess_migration", VITESS_MIGRATION},
@@ -794,6 +799,8 @@ var keywords = []keyword{
 	{"vitess_target", VITESS_TARGET},
 	{"vitess_throttled_apps", VITESS_THROTTLED_APPS},
 	{"vitess_throttler", VITESS_THROTTLER},
+	{"vitess_workflow", VITESS_WORKFLOW},
+	{"vitess_workflows", VITESS_WORKFLOWS},
 	{"vschema", VSCHEMA},
 	{"vstream", VSTREAM},
 	{"vtexplain", VTEXPLAIN},
@@ -735,6 +735,7 @@ func (nz *normalizer) sysVarRewrite(cursor *Cursor, node *Variable) {
 		sysvars.ReadAfterWriteGTID.Name,
 		sysvars.ReadAfterWriteTimeOut.Name,
 		sysvars.SessionEnableSystemSettings.Name,
+		sysvars.SessionToken.Name,
 		sysvars.SessionTrackGTIDs.Name,
 		sysvars.SessionUUID.Name,
 		sysvars.SkipQueryPlanCache.Name,
@@ -744,6 +745,10 @@ func (nz *normalizer) sysVarRewrite(cursor *Cursor, node *Variable) {
 		sysvars.VersionComment.Name,
 		sysvars.QueryTimeout.Name,
 		sysvars.TransactionTimeout.Name,
+		sysvars.ScatterConcurrency.Name,
+		sysvars.ResultChecksum.Name,
+		sysvars.LastResultChecksum.Name,
+		sysvars.StickyShards.Name,
 		sysvars.Workload.Name:
 		found = true
 	}
@@ -912,6 +912,21 @@ var validSQL = []struct {
 	input: "select /* not like */ 1 from t where a not like b",
 }, {
 	input: "select /* not like escape */ 1 from t where a not like b escape '$'",
+}, {
+	input:  "select /* odbc like escape */ 1 from t where a like b {escape '!'}",
+	output: "select /* odbc like escape */ 1 from t where a like b escape '!'",
+}, {
+	input:  "select /* odbc not like escape */ 1 from t where a not like b {escape '$'}",
+	output: "select /* odbc not like escape */ 1 from t where a not like b escape '$'",
+}, {
+	input:  "select /* odbc date and time */ {d '2024-01-01'}, {t '12:30:00'}, {ts '2024-01-01 12:30:00'} from t",
+	output: "select /* odbc date and time */ date'2024-01-01', time'12:30:00', timestamp'2024-01-01 12:30:00' from t",
+}, {
+	input:  "select /* odbc function */ {fn concat(a, {fn ucase(b)})}, {FN now()} from t where {d a} = 1",
+	output: "select /* odbc function */ concat(a, ucase(b)), now() from t where a = 1",
+}, {
+	input:  "select /* odbc outer join */ 1 from t1 join {oj t2 left outer join t3 on t2.a = t3.a} on t1.a = t2.a",
+	output: "select /* odbc outer join */ 1 from t1 join (t2 left join t3 on t2.a = t3.a) on t1.a = t2.a",
 }, {
 	input: "select /* regexp */ 1 from t where a regexp b",
 }, {
@@ -2515,6 +2530,10 @@ var validSQL = []struct {
 	input: "show vitess_replication_status",
 }, {
 	input: "show vitess_replication_status like '%'",
+}, {
+	input: "show vitess_buffers",
+}, {
+	input: "show vitess_buffers like 'ks/%'",
 }, {
 	input: "show vitess_shards",
 }, {
@@ -2548,6 +2567,23 @@ var validSQL = []struct {
 	input: `show vitess_migrations from ks like '%pattern'`,
 }, {
 	input: "show vitess_migrations like '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90'",
+}, {
+	input: "show vitess_workflows",
+}, {
+	input: "show vitess_workflows from ks",
+}, {
+	input: "show vitess_workflows from ks like 'wf%'",
+}, {
+	input: "show vitess_workflows where state = 'Running'",
+}, {
+	input: "alter vitess_workflow 'commerce2customer' stop",
+}, {
+	input: "alter vitess_workflow 'commerce2customer' start",
+}, {
+	input: "alter vitess_workflow 'commerce2customer' switch traffic",
+}, {
+	input:  "ALTER VITESS_WORKFLOW \"commerce2customer\" SWITCH TRAFFIC",
+	output: "alter vitess_workflow 'commerce2customer' switch traffic",
 }, {
 	input: "show vitess_migration '9748c3b7_7fdb_11eb_ac2c_f875a4d24e90' logs",
 }, {
@@ -3062,6 +3098,9 @@ var validSQL = []struct {
 	input: "call qualified.proc()",
 }, {
 	input: "call proc(1, 'foo')",
+}, {
+	input:  "{call proc(1, 'foo')}",
+	output: "call proc(1, 'foo')",
 }, {
 	input: "call proc(@param)",
 }, {
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PrettyString returns the canonical, human readable representation of the
+// statement. Its keywords are in upper case, and the clauses of its queries
+// and DMLs start on their own line, with the subqueries indented. It keeps the
+// comments of the statement, and parses back into the same statement.
+func PrettyString(stmt Statement) string {
+	if stmt == nil {
+		return ""
+	}
+	buf := NewTrackedBuffer(nil)
+	buf.SetUpperCase(true)
+	stmt.Format(buf)
+	switch stmt.(type) {
+	case TableStatement, *Insert, *Update, *Delete:
+		return breakClauses(buf.String())
+	}
+	return buf.String()
+}
+
+// prettyToken is a token of a formatted statement.
+type prettyToken struct {
+	typ int
+	// space is the whitespace before the token, and text the token as
+	// formatted.
+	space, text string
+}
+
+// breakClauses starts the clauses of the formatted query on their own line,
+// and indents its subqueries. Only the whitespace between its tokens changes.
+func breakClauses(sql string) string {
+	tokenizer := &Tokenizer{buf: sql, SkipSpecialComments: true}
+	var tokens []prettyToken
+	prev := 0
+	for {
+		typ, _ := tokenizer.Scan()
+		if typ == 0 {
+			break
+		}
+		if typ == LEX_ERROR {
+			return sql
+		}
+		raw := sql[prev:tokenizer.Pos]
+		text := strings.TrimLeftFunc(raw, unicode.IsSpace)
+		tokens = append(tokens, prettyToken{typ: typ, space: raw[:len(raw)-len(text)], text: text})
+		prev = tokenizer.Pos
+	}
+
+	var out strings.Builder
+	// parens tells, for each open parenthesis, whether it is a subquery. The
+	// clauses are only broken in the query itself and in its subqueries.
+	var parens []bool
+	newLine := func() {
+		out.WriteByte('\n')
+		out.WriteString(strings.Repeat("\t", countSubqueries(parens)))
+	}
+	prevTyp := 0
+	skipSpace := false
+	for i, token := range tokens {
+		nextTyp, nextSpace := 0, ""
+		if i+1 < len(tokens) {
+			nextTyp, nextSpace = tokens[i+1].typ, tokens[i+1].space
+		}
+		inQuery := len(parens) == 0 || parens[len(parens)-1]
+
+		breakBefore := false
+		switch token.typ {
+		case FROM:
+			breakBefore = inQuery && prevTyp != DELETE && prevTyp != IGNORE
+		case INTO:
+			breakBefore = inQuery && prevTyp != INSERT && prevTyp != REPLACE && prevTyp != IGNORE
+		case WHERE, GROUP, HAVING, ORDER, LIMIT, WINDOW, SET, UNION, EXCEPT:
+			breakBefore = inQuery
+		case SELECT:
+			breakBefore = inQuery && i > 0 && prevTyp != '('
+		case VALUES:
+			// The VALUES() function of ON DUPLICATE KEY UPDATE is formatted
+			// without a space before its arguments.
+			breakBefore = inQuery && (nextTyp != '(' || nextSpace != "")
+		case ON:
+			breakBefore = inQuery && nextTyp == DUPLICATE
+		case JOIN:
+			breakBefore = inQuery && !isJoinToken(prevTyp)
+		case STRAIGHT_JOIN:
+			// STRAIGHT_JOIN is also a hint of SELECT, which follows its
+			// other options.
+			switch prevTyp {
+			case SELECT, DISTINCT, SQL_CACHE, SQL_NO_CACHE, HIGH_PRIORITY:
+			default:
+				breakBefore = inQuery
+			}
+		case LEFT, RIGHT, INNER, CROSS, NATURAL:
+			// LEFT and RIGHT are also functions.
+			breakBefore = inQuery && !isJoinToken(prevTyp) && isJoinToken(nextTyp)
+		case ')':
+			if len(parens) > 0 {
+				subquery := parens[len(parens)-1]
+				parens = parens[:len(parens)-1]
+				if subquery {
+					newLine()
+					out.WriteString(token.text)
+					prevTyp = token.typ
+					continue
+				}
+			}
+		}
+
+		switch {
+		case breakBefore:
+			newLine()
+		case !skipSpace:
+			out.WriteString(token.space)
+		}
+		skipSpace = false
+		out.WriteString(token.text)
+
+		if token.typ == '(' {
+			subquery := nextTyp == SELECT || nextTyp == WITH
+			parens = append(parens, subquery)
+			if subquery {
+				newLine()
+				skipSpace = true
+			}
+		}
+		if token.typ != COMMENT {
+			prevTyp = token.typ
+		}
+	}
+	return out.String()
+}
+
+func countSubqueries(parens []bool) int {
+	count := 0
+	for _, subquery := range parens {
+		if subquery {
+			count++
+		}
+	}
+	return count
+}
+
+// isJoinToken returns whether the token is part of the keywords of a join.
+func isJoinToken(typ int) bool {
+	switch typ {
+	case JOIN, STRAIGHT_JOIN, LEFT, RIGHT, INNER, CROSS, NATURAL, OUTER:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrettyString(t *testing.T) {
+	testCases := []struct {
+		input, output string
+	}{{
+		input:  "select straight_join a, left(b, 1) from t1 left join t2 on t1.a = t2.a natural left join t3 join t4 using (b) where c in (select c from t5 where d = 'from x') group by a having count(*) > 1 order by a limit 10",
+		output: "SELECT STRAIGHT_JOIN a, left(b, 1)\nFROM t1\nLEFT JOIN t2 ON t1.a = t2.a\nNATURAL LEFT JOIN t3\nJOIN t4 USING (b)\nWHERE c IN (\n\tSELECT c\n\tFROM t5\n\tWHERE d = 'from x'\n)\nGROUP BY a\nHAVING count(*) > 1\nORDER BY a ASC\nLIMIT 10",
+	}, {
+		input:  "with cte as (select a from t) select * from cte union all select 1 from dual",
+		output: "WITH cte AS (\n\tSELECT a\n\tFROM t\n)\nSELECT *\nFROM cte\nUNION ALL\nSELECT 1\nFROM dual",
+	}, {
+		input:  "select a from t where exists (select 1 from (select b from u) as x)",
+		output: "SELECT a\nFROM t\nWHERE EXISTS (\n\tSELECT 1\n\tFROM (\n\t\tSELECT b\n\t\tFROM u\n\t) AS x\n)",
+	}, {
+		input:  "insert into t(a, b) values (1, 2), (3, 4) on duplicate key update a = values(a)",
+		output: "INSERT INTO t(a, b)\nVALUES (1, 2), (3, 4)\nON DUPLICATE KEY UPDATE a = VALUES(a)",
+	}, {
+		input:  "insert into t select * from u",
+		output: "INSERT INTO t\nSELECT *\nFROM u",
+	}, {
+		input:  "update /* vt+ PRIORITY=1 */ t set a = 1 where b = 2 order by c limit 1",
+		output: "UPDATE /* vt+ PRIORITY=1 */ t\nSET a = 1\nWHERE b = 2\nORDER BY c ASC\nLIMIT 1",
+	}, {
+		input:  "delete t1 from t1 join t2 on t1.a = t2.a where t2.b = 1",
+		output: "DELETE t1\nFROM t1\nJOIN t2 ON t1.a = t2.a\nWHERE t2.b = 1",
+	}, {
+		input:  "delete from t where a = 1",
+		output: "DELETE FROM t\nWHERE a = 1",
+	}, {
+		input:  "select a into outfile 'x' from t",
+		output: "SELECT a\nFROM t\nINTO OUTFILE 'x'",
+	}, {
+		input:  "create table t (a int, primary key (a))",
+		output: "CREATE TABLE t (\n\ta int,\n\tPRIMARY KEY (a)\n)",
+	}}
+	parser := NewTestParser()
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.input)
+			require.NoError(t, err)
+			pretty := PrettyString(stmt)
+			assert.Equal(t, tc.output, pretty)
+
+			reparsed, err := parser.Parse(pretty)
+			require.NoError(t, err)
+			assert.Equal(t, String(stmt), String(reparsed))
+		})
+	}
+}
@@ -365,6 +365,8 @@ func markBindVariable(yylex yyLexer, bvar string) {
 %token <str> VITESS_MIGRATION CANCEL RETRY LAUNCH COMPLETE CLEANUP THROTTLE UNTHROTTLE FORCE_CUTOVER CUTOVER_THRESHOLD EXPIRE RATIO POSTPONE
 // Throttler tokens
 %token <str> VITESS_THROTTLER
+// Workflow tokens
+%token <str> VITESS_WORKFLOW VITESS_WORKFLOWS STOP SWITCH TRAFFIC
 
 // Transaction Tokens
 %token <str> BEGIN START TRANSACTION COMMIT ROLLBACK SAVEPOINT RELEASE WORK
@@ -392,7 +394,7 @@ func markBindVariable(yylex yyLexer, bvar string) {
 // SHOW tokens
 %token <str> CODE COLLATION COLUMNS DATABASES ENGINES EVENT EXTENDED FIELDS FULL FUNCTION GTID_EXECUTED
 %token <str> KEYSPACES OPEN PLUGINS PRIVILEGES PROCESSLIST SCHEMAS TABLES TRIGGERS USER
-%token <str> VGTID_EXECUTED VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
+%token <str> VGTID_EXECUTED VITESS_BUFFERS VITESS_KEYSPACES VITESS_METADATA VITESS_MIGRATIONS VITESS_REPLICATION_STATUS VITESS_SHARDS VITESS_TABLETS VITESS_TARGET VSCHEMA VITESS_THROTTLED_APPS
 
 // SET tokens
 %token <str> NAMES GLOBAL SESSION ISOLATION LEVEL READ WRITE ONLY REPEATABLE COMMITTED UNCOMMITTED SERIALIZABLE
@@ -3837,6 +3839,27 @@ alter_statement:
       Threshold: $6,
     }
   }
+| ALTER comment_opt VITESS_WORKFLOW STRING STOP
+  {
+    $$ = &AlterWorkflow{
+      Type: StopWorkflowType,
+      Workflow: string($4),
+    }
+  }
+| ALTER comment_opt VITESS_WORKFLOW STRING START
+  {
+    $$ = &AlterWorkflow{
+      Type: StartWorkflowType,
+      Workflow: string($4),
+    }
+  }
+| ALTER comment_opt VITESS_WORKFLOW STRING SWITCH TRAFFIC
+  {
+    $$ = &AlterWorkflow{
+      Type: SwitchTrafficWorkflowType,
+      Workflow: string($4),
+    }
+  }
 
 partitions_options_opt:
   {
@@ -4585,6 +4608,10 @@ show_statement:
   {
     $$ = &ShowMigrationLogs{UUID: string($3)}
   }
+| SHOW VITESS_WORKFLOWS from_database_opt like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessWorkflows, Filter: $4, DbName: $3}}
+  }
 | SHOW VITESS_THROTTLED_APPS
   {
     $$ = &ShowThrottledApps{}
@@ -4617,6 +4644,10 @@ show_statement:
   {
     $$ = &Show{&ShowBasic{Command: Warnings}}
   }
+| SHOW VITESS_BUFFERS like_or_where_opt
+  {
+    $$ = &Show{&ShowBasic{Command: VitessBuffers, Filter: $3}}
+  }
 | SHOW VITESS_SHARDS like_or_where_opt
   {
     $$ = &Show{&ShowBasic{Command: VitessShards, Filter: $3}}
@@ -5426,6 +5457,11 @@ table_factor:
   {
     $$ = &ParenTableExpr{Exprs: $2}
   }
+| '{' OJ table_reference '}'
+  {
+    // ODBC escape sequence for outer joins: {oj t1 left join t2 on ...}
+    $$ = &ParenTableExpr{Exprs: TableExprs{$3}}
+  }
 | json_table_function
   {
     $$ = $1
@@ -5841,6 +5877,15 @@ bit_expr IN col_tuple
   {
     $$ = &ComparisonExpr{Left: $1, Operator: NotLikeOp, Right: $4, Escape: $6}
   }
+| bit_expr LIKE simple_expr '{' ESCAPE simple_expr '}' %prec LIKE
+  {
+    // ODBC escape sequence for the escape character of LIKE: {escape '!'}
+    $$ = &ComparisonExpr{Left: $1, Operator: LikeOp, Right: $3, Escape: $6}
+  }
+| bit_expr NOT LIKE simple_expr '{' ESCAPE simple_expr '}' %prec LIKE
+  {
+    $$ = &ComparisonExpr{Left: $1, Operator: NotLikeOp, Right: $4, Escape: $7}
+  }
 | bit_expr regexp_symbol bit_expr
   {
     $$ = &ComparisonExpr{Left: $1, Operator: RegexpOp, Right: $3}
@@ -5950,6 +5995,11 @@ function_call_keyword
   {
     $$ = $1
   }
+| '{' sql_id expression '}'
+  {
+    // ODBC escape sequences, such as {d '2024-01-01'} or {fn concat(a, b)}
+    $$ = NewODBCEscapeExpr($2, $3)
+  }
 | column_name_or_offset
   {
     $$ = $1
@@ -8539,6 +8589,11 @@ call_statement:
   {
     $$ = &CallProc{Name: $2, Params: $4}
   }
+| '{' CALL table_name openb expression_list_opt closeb '}'
+  {
+    // ODBC escape sequence for calling a stored procedure: {call proc(...)}
+    $$ = &CallProc{Name: $3, Params: $5}
+  }
 
 expression_list_opt:
   {
@@ -9162,6 +9217,7 @@ non_reserved_keyword:
 | STDDEV %prec FUNCTION_CALL_NON_KEYWORD
 | STDDEV_POP %prec FUNCTION_CALL_NON_KEYWORD
 | STDDEV_SAMP %prec FUNCTION_CALL_NON_KEYWORD
+| STOP
 | STREAM
 | ST_Area %prec FUNCTION_CALL_NON_KEYWORD
 | ST_AsBinary %prec FUNCTION_CALL_NON_KEYWORD
@@ -9214,6 +9270,7 @@ non_reserved_keyword:
 | SUBPARTITION
 | SUBPARTITIONS
 | SUM %prec FUNCTION_CALL_NON_KEYWORD
+| SWITCH
 | TABLE_NAME
 | TABLES
 | TABLESAMPLE
@@ -9235,6 +9292,7 @@ non_reserved_keyword:
 | TINYTEXT
 | TRACE
 | TRADITIONAL
+| TRAFFIC
 | TRANSACTION
 | TRANSACTIONS
 | TREE
@@ -9272,6 +9330,7 @@ non_reserved_keyword:
 | VINDEXES
 | VISIBLE
 | VITESS
+| VITESS_BUFFERS
 | VITESS_KEYSPACES
 | VITESS_METADATA
 | VITESS_MIGRATION
@@ -9282,6 +9341,8 @@ non_reserved_keyword:
 | VITESS_TARGET
 | VITESS_THROTTLED_APPS
 | VITESS_THROTTLER
+| VITESS_WORKFLOW
+| VITESS_WORKFLOWS
 | VSCHEMA
 | VTEXPLAIN
 | WAIT_FOR_EXECUTED_GTID_SET %prec FUNCTION_CALL_NON_KEYWORD
@@ -45,6 +45,11 @@ type Tokenizer struct {
 	multi          bool
 	specialComment *Tokenizer
 
+	// keepDroppedComments makes Lex keep in droppedComments the comments it
+	// skips because the grammar does not allow them where they are.
+	keepDroppedComments bool
+	droppedComments     []string
+
 	Pos    int
 	buf    string
 	parser *Parser
@@ -80,6 +85,9 @@ func (tkn *Tokenizer) Lex(lval *yySymType) int {
 		if tkn.AllowComments {
 			break
 		}
+		if tkn.keepDroppedComments {
+			tkn.droppedComments = append(tkn.droppedComments, val)
+		}
 		typ, val = tkn.Scan()
 	}
 	if typ == 0 || typ == ';' || typ == LEX_ERROR {
@@ -200,7 +208,7 @@ func (tkn *Tokenizer) Scan() (int, string) {
 
 		tkn.skip(1)
 		switch ch {
-		case '=', ',', '(', ')', '+', '*', '%', '^', '~':
+		case '=', ',', '(', ')', '+', '*', '%', '^', '~', '{', '}':
 			return int(ch), ""
 		case '&':
 			if tkn.cur() == '&' {
@@ -689,6 +697,11 @@ func (tkn *Tokenizer) scanMySQLSpecificComment() (int, string) {
 	}
 
 	commentVersion, sql := ExtractMysqlComment(tkn.buf[start:tkn.Pos])
+	if tkn.keepDroppedComments {
+		// The contents of the comment are parsed, or skipped for a later
+		// MySQL version, but the comment itself is not kept.
+		tkn.droppedComments = append(tkn.droppedComments, tkn.buf[start:tkn.Pos])
+	}
 
 	if tkn.parser.version >= commentVersion {
 		// Only add the special comment to the tokenizer if the version of MySQL is higher or equal to the comment version
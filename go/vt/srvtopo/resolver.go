@@ -96,6 +96,20 @@ func (rs *ResolvedShard) WithKeyspace(newKeyspace string) *ResolvedShard {
 	}
 }
 
+// WithCell returns a ResolvedShard with a new cell keeping other parameters the same.
+// The gateway prefers the tablets of the cell of the target, if any.
+func (rs *ResolvedShard) WithCell(newCell string) *ResolvedShard {
+	return &ResolvedShard{
+		Target: &querypb.Target{
+			Keyspace:   rs.Target.Keyspace,
+			Shard:      rs.Target.Shard,
+			TabletType: rs.Target.TabletType,
+			Cell:       newCell,
+		},
+		Gateway: rs.Gateway,
+	}
+}
+
 // GetKeyspaceShards return all the shards in a keyspace. It is only valid for the local cell.
 // Do not use it to further resolve shards, instead use the Resolve* methods.
 func (r *Resolver) GetKeyspaceShards(ctx context.Context, keyspace string, tabletType topodatapb.TabletType) (string, *topodatapb.SrvKeyspace, []*topodatapb.ShardReference, error) {
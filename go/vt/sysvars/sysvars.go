@@ -65,6 +65,7 @@ var (
 	SessionEnableSystemSettings = SystemVariable{Name: "enable_system_settings", IsBoolean: true, Default: on}
 	Names                       = SystemVariable{Name: "names", Default: utf8mb4, IdentifierAsString: true}
 	SessionUUID                 = SystemVariable{Name: "session_uuid", IdentifierAsString: true}
+	SessionToken                = SystemVariable{Name: "session_token", IdentifierAsString: true}
 	SkipQueryPlanCache          = SystemVariable{Name: "skip_query_plan_cache", IsBoolean: true, Default: off}
 	Socket                      = SystemVariable{Name: "socket", Default: off}
 	SQLSelectLimit              = SystemVariable{Name: "sql_select_limit", Default: off, SupportSetVar: true}
@@ -74,6 +75,10 @@ var (
 	Workload                    = SystemVariable{Name: "workload", IdentifierAsString: true}
 	QueryTimeout                = SystemVariable{Name: "query_timeout"}
 	TransactionTimeout          = SystemVariable{Name: "transaction_timeout"}
+	ScatterConcurrency          = SystemVariable{Name: "scatter_concurrency"}
+	ResultChecksum              = SystemVariable{Name: "result_checksum", IsBoolean: true, Default: off}
+	LastResultChecksum          = SystemVariable{Name: "last_result_checksum"}
+	StickyShards                = SystemVariable{Name: "sticky_shards", IsBoolean: true, Default: off}
 
 	// Online DDL
 	DDLStrategy      = SystemVariable{Name: "ddl_strategy", IdentifierAsString: true}
@@ -104,6 +109,7 @@ var (
 		Charset,
 		Names,
 		SessionUUID,
+		SessionToken,
 		MigrationContext,
 		SessionEnableSystemSettings,
 		ReadAfterWriteGTID,
@@ -111,12 +117,16 @@ var (
 		SessionTrackGTIDs,
 		QueryTimeout,
 		TransactionTimeout,
+		ScatterConcurrency,
+		ResultChecksum,
+		StickyShards,
 	}
 
 	ReadOnly = []SystemVariable{
 		Socket,
 		Version,
 		VersionComment,
+		LastResultChecksum,
 	}
 
 	IgnoreThese = []SystemVariable{
@@ -302,8 +312,12 @@ func SupportsSetVar(name string) bool {
 // GetInterestingVariables is used to return all the variables that may be listed in a SHOW VARIABLES command.
 func GetInterestingVariables() []string {
 	var res []string
-	// Add all the vitess aware variables
+	// Add all the vitess aware variables, except the session token which is
+	// only computed when selected.
 	for _, variable := range VitessAware {
+		if variable.Name == SessionToken.Name {
+			continue
+		}
 		res = append(res, variable.Name)
 	}
 	// Also add version and version comment
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file contains the cluster event log management code. The log is
+// stored in the global cell, so that a client can resume its stream of
+// events on any vtctld.
+
+// GetClusterEventLog returns the cluster event log, which is empty if it
+// does not exist.
+func (ts *Server) GetClusterEventLog(ctx context.Context) (*vtctldatapb.ClusterEventLog, error) {
+	eventLog := &vtctldatapb.ClusterEventLog{}
+	data, _, err := ts.globalCell.Get(ctx, ClusterEventLogFile)
+	switch {
+	case IsErrType(err, NoNode):
+		return eventLog, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = eventLog.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrap(err, "bad ClusterEventLog data")
+	}
+	return eventLog, nil
+}
+
+// UpdateClusterEventLog updates the cluster event log. It reads the log,
+// calls the update method, and writes it back. If the write fails because
+// the log changed in the meantime, it starts over, so the update method may
+// be called multiple times. If the update method returns ErrNoUpdateNeeded,
+// nothing is written and nil is returned. Other errors are returned as is.
+func (ts *Server) UpdateClusterEventLog(ctx context.Context, update func(*vtctldatapb.ClusterEventLog) error) error {
+	for {
+		data, version, err := ts.globalCell.Get(ctx, ClusterEventLogFile)
+		eventLog := &vtctldatapb.ClusterEventLog{}
+		switch {
+		case IsErrType(err, NoNode):
+			// Empty node, version is nil
+		case err == nil:
+			if err = eventLog.UnmarshalVT(data); err != nil {
+				return vterrors.Wrap(err, "bad ClusterEventLog data")
+			}
+		default:
+			return err
+		}
+
+		err = update(eventLog)
+		switch {
+		case IsErrType(err, NoUpdateNeeded):
+			return nil
+		case err == nil:
+			// keep going
+		default:
+			return err
+		}
+
+		data, err = eventLog.MarshalVT()
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			// We have to create, and we catch NodeExists.
+			_, err = ts.globalCell.Create(ctx, ClusterEventLogFile, data)
+			if IsErrType(err, NodeExists) {
+				// Node was created by another process, try
+				// again.
+				continue
+			}
+			return err
+		}
+
+		// We have to update, and we catch ErrBadVersion.
+		_, err = ts.globalCell.Update(ctx, ClusterEventLogFile, data, version)
+		if IsErrType(err, BadVersion) {
+			// Node was updated by another process, try again.
+			continue
+		}
+		return err
+	}
+}
+
+// WatchClusterEventLogData wraps the data we receive on the watch channel.
+// The WatchClusterEventLog API guarantees exactly one of Value or Err will
+// be set.
+type WatchClusterEventLogData struct {
+	Value *vtctldatapb.ClusterEventLog
+	Err   error
+}
+
+// WatchClusterEventLog will set a watch on the cluster event log.
+// It has the same contract as conn.Watch, but it also unpacks the
+// contents into a ClusterEventLog object.
+func (ts *Server) WatchClusterEventLog(ctx context.Context) (*WatchClusterEventLogData, <-chan *WatchClusterEventLogData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	current, wdChannel, err := ts.globalCell.Watch(ctx, ClusterEventLogFile)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	value := &vtctldatapb.ClusterEventLog{}
+	if err := value.UnmarshalVT(current.Contents); err != nil {
+		// Cancel the watch, drain channel.
+		cancel()
+		for range wdChannel {
+		}
+		return nil, nil, vterrors.Wrapf(err, "error unpacking initial ClusterEventLog object")
+	}
+
+	changes := make(chan *WatchClusterEventLogData, 10)
+	// The background routine reads any event from the watch channel,
+	// translates it, and sends it to the caller.
+	// If cancel() is called, the underlying Watch() code will
+	// send an ErrInterrupted and then close the channel. We'll
+	// just propagate that back to our caller.
+	go func() {
+		defer cancel()
+		defer close(changes)
+
+		for wd := range wdChannel {
+			if wd.Err != nil {
+				// Last error value, we're done.
+				// wdChannel will be closed right after
+				// this, no need to do anything.
+				changes <- &WatchClusterEventLogData{Err: wd.Err}
+				return
+			}
+
+			value := &vtctldatapb.ClusterEventLog{}
+			if err := value.UnmarshalVT(wd.Contents); err != nil {
+				cancel()
+				for range wdChannel {
+				}
+				changes <- &WatchClusterEventLogData{Err: vterrors.Wrapf(err, "error unpacking ClusterEventLog object")}
+				return
+			}
+
+			changes <- &WatchClusterEventLogData{Value: value}
+		}
+	}()
+
+	return &WatchClusterEventLogData{Value: value}, changes, nil
+}
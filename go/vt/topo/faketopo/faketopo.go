@@ -308,7 +308,13 @@ func (f *FakeConn) List(ctx context.Context, filePathPrefix string) ([]topo.KVIn
 
 // Delete implements the Conn interface
 func (f *FakeConn) Delete(ctx context.Context, filePath string, version topo.Version) error {
-	panic("implement me")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, isPresent := f.getResultMap[filePath]; !isPresent {
+		return topo.NewError(topo.NoNode, filePath)
+	}
+	delete(f.getResultMap, filePath)
+	return nil
 }
 
 // fakeLockDescriptor implements the topo.LockDescriptor interface
@@ -382,6 +382,9 @@ func (ts *Server) DeleteKeyspace(ctx context.Context, keyspace string) error {
 	if err := ts.DeleteVSchema(ctx, keyspace); err != nil && !IsErrType(err, NoNode) {
 		return err
 	}
+	if err := ts.DeleteVSchemaCanary(ctx, keyspace); err != nil {
+		return err
+	}
 
 	event.Dispatch(&events.KeyspaceChange{
 		KeyspaceName: keyspace,
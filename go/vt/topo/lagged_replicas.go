@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the LaggedReplicas management code, which lets the
+// replicas of a shard within a cell agree on which of them stopped serving
+// because of their replication lag.
+
+func laggedReplicasPath(keyspace, shard string) string {
+	return path.Join(KeyspacesPath, keyspace, ShardsPath, shard, LaggedReplicasFile)
+}
+
+// GetLaggedReplicas returns the LaggedReplicas object of a shard within a
+// cell, which is empty if it does not exist.
+func (ts *Server) GetLaggedReplicas(ctx context.Context, cell, keyspace, shard string) (*topodatapb.LaggedReplicas, error) {
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return nil, err
+	}
+
+	lr := &topodatapb.LaggedReplicas{}
+	data, _, err := conn.Get(ctx, laggedReplicasPath(keyspace, shard))
+	switch {
+	case IsErrType(err, NoNode):
+		return lr, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = lr.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrap(err, "bad LaggedReplicas data")
+	}
+	return lr, nil
+}
+
+// UpdateLaggedReplicasFields updates the LaggedReplicas object of a shard
+// within a cell. It reads the object, calls the update method, and writes it
+// back. If the write fails because the object changed in the meantime, it
+// starts over, so the update method may be called multiple times. If the
+// update method returns ErrNoUpdateNeeded, nothing is written and nil is
+// returned. Other errors are returned as is.
+func (ts *Server) UpdateLaggedReplicasFields(ctx context.Context, cell, keyspace, shard string, update func(*topodatapb.LaggedReplicas) error) error {
+	nodePath := laggedReplicasPath(keyspace, shard)
+
+	conn, err := ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+
+	for {
+		data, version, err := conn.Get(ctx, nodePath)
+		lr := &topodatapb.LaggedReplicas{}
+		switch {
+		case IsErrType(err, NoNode):
+			// Empty node, version is nil
+		case err == nil:
+			// Use any data we got.
+			if err = lr.UnmarshalVT(data); err != nil {
+				return vterrors.Wrap(err, "bad LaggedReplicas data")
+			}
+		default:
+			return err
+		}
+
+		err = update(lr)
+		switch {
+		case IsErrType(err, NoUpdateNeeded):
+			return nil
+		case err == nil:
+			// keep going
+		default:
+			return err
+		}
+
+		// marshall and save
+		data, err = lr.MarshalVT()
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			// We have to create, and we catch NodeExists.
+			_, err = conn.Create(ctx, nodePath, data)
+			if IsErrType(err, NodeExists) {
+				// Node was created by another process, try
+				// again.
+				continue
+			}
+			return err
+		}
+
+		// We have to update, and we catch ErrBadVersion.
+		_, err = conn.Update(ctx, nodePath, data, version)
+		if IsErrType(err, BadVersion) {
+			// Node was updated by another process, try again.
+			continue
+		}
+		return err
+	}
+}
@@ -76,7 +76,9 @@ const (
 	KeyspaceFile           = "Keyspace"
 	ShardFile              = "Shard"
 	VSchemaFile            = "VSchema"
+	VSchemaCanaryFile      = "VSchemaCanary"
 	ShardReplicationFile   = "ShardReplication"
+	LaggedReplicasFile     = "LaggedReplicas"
 	TabletFile             = "Tablet"
 	SrvVSchemaFile         = "SrvVSchema"
 	SrvKeyspaceFile        = "SrvKeyspace"
@@ -85,6 +87,9 @@ const (
 	ShardRoutingRulesFile  = "ShardRoutingRules"
 	CommonRoutingRulesFile = "Rules"
 	MirrorRulesFile        = "MirrorRules"
+	RateLimitRulesFile     = "RateLimitRules"
+	VTOrcStateFile         = "VTOrcState"
+	ClusterEventLogFile    = "ClusterEventLog"
 )
 
 // Path for all object types.
@@ -99,6 +104,7 @@ const (
 	RoutingRulesPath         = "routing_rules"
 	KeyspaceRoutingRulesPath = "keyspace"
 	NamedLocksPath           = "internal/named_locks"
+	VTGatesPath              = "vtgates"
 )
 
 // Factory is a factory interface to create Conn objects.
@@ -179,6 +179,10 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 					Keyspace: &vschemapb.Keyspace{},
 				}
 			}
+			var canary *vschemapb.VSchemaCanary
+			if err == nil {
+				canary, err = ts.GetVSchemaCanary(ctx, keyspace)
+			}
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -188,6 +192,12 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 				return
 			}
 			srvVSchema.Keyspaces[keyspace] = ksvs.Keyspace
+			if canary != nil {
+				if srvVSchema.Canaries == nil {
+					srvVSchema.Canaries = make(map[string]*vschemapb.VSchemaCanary)
+				}
+				srvVSchema.Canaries[keyspace] = canary
+			}
 		}(keyspace)
 	}
 	wg.Wait()
@@ -219,6 +229,12 @@ func (ts *Server) RebuildSrvVSchema(ctx context.Context, cells []string) error {
 	}
 	srvVSchema.MirrorRules = mr
 
+	rlr, err := ts.GetRateLimitRules(ctx)
+	if err != nil {
+		return fmt.Errorf("GetRateLimitRules failed: %v", err)
+	}
+	srvVSchema.RateLimitRules = rlr
+
 	// now save the SrvVSchema in all cells in parallel
 	for _, cell := range cells {
 		wg.Add(1)
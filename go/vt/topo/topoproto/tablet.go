@@ -41,6 +41,11 @@ import (
 const (
 	// VtDbPrefix + keyspace is the default name for databases.
 	VtDbPrefix = "vt_"
+
+	// StorageClassTag is the tag of the tablets naming the class of the
+	// storage backing their MySQL, such as nvme or capacity. vtgate can
+	// route the queries of each workload to the tablets of a storage class.
+	StorageClassTag = "storage_class"
 )
 
 // cache the conversion from tablet type enum to lower case string.
@@ -30,6 +30,7 @@ import (
 func TestRebuildVSchema(t *testing.T) {
 	emptySrvVSchema := &vschemapb.SrvVSchema{
 		MirrorRules:       &vschemapb.MirrorRules{},
+		RateLimitRules:    &vschemapb.RateLimitRules{},
 		RoutingRules:      &vschemapb.RoutingRules{},
 		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
 	}
@@ -53,6 +54,7 @@ func TestRebuildVSchema(t *testing.T) {
 	// create a keyspace, rebuild, should see an empty entry
 	emptyKs1SrvVSchema := &vschemapb.SrvVSchema{
 		MirrorRules:       &vschemapb.MirrorRules{},
+		RateLimitRules:    &vschemapb.RateLimitRules{},
 		RoutingRules:      &vschemapb.RoutingRules{},
 		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -86,6 +88,7 @@ func TestRebuildVSchema(t *testing.T) {
 	}
 	wanted1 := &vschemapb.SrvVSchema{
 		MirrorRules:       &vschemapb.MirrorRules{},
+		RateLimitRules:    &vschemapb.RateLimitRules{},
 		RoutingRules:      &vschemapb.RoutingRules{},
 		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -131,6 +134,7 @@ func TestRebuildVSchema(t *testing.T) {
 	}
 	wanted2 := &vschemapb.SrvVSchema{
 		MirrorRules:       &vschemapb.MirrorRules{},
+		RateLimitRules:    &vschemapb.RateLimitRules{},
 		RoutingRules:      &vschemapb.RoutingRules{},
 		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -170,6 +174,7 @@ func TestRebuildVSchema(t *testing.T) {
 	}
 	wanted3 := &vschemapb.SrvVSchema{
 		MirrorRules:       &vschemapb.MirrorRules{},
+		RateLimitRules:    &vschemapb.RateLimitRules{},
 		RoutingRules:      rr,
 		ShardRoutingRules: &vschemapb.ShardRoutingRules{},
 		Keyspaces: map[string]*vschemapb.Keyspace{
@@ -205,3 +210,48 @@ func TestRebuildVSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestRebuildVSchemaCanary(t *testing.T) {
+	cells := []string{"cell1"}
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, cells...)
+	defer ts.Close()
+
+	if err := ts.CreateKeyspace(ctx, "ks1", &topodatapb.Keyspace{}); err != nil {
+		t.Fatalf("CreateKeyspace(ks1) failed: %v", err)
+	}
+	canary := &vschemapb.VSchemaCanary{
+		Keyspace: &vschemapb.Keyspace{Sharded: true},
+		Percent:  10,
+	}
+	if err := ts.SaveVSchemaCanary(ctx, "ks1", canary); err != nil {
+		t.Fatalf("SaveVSchemaCanary(ks1) failed: %v", err)
+	}
+	if v, err := ts.GetVSchemaCanary(ctx, "ks1"); err != nil || !proto.Equal(v, canary) {
+		t.Errorf("unexpected GetVSchemaCanary result: %v %v", v, err)
+	}
+	if err := ts.RebuildSrvVSchema(ctx, cells); err != nil {
+		t.Errorf("RebuildVSchema failed: %v", err)
+	}
+	v, err := ts.GetSrvVSchema(ctx, "cell1")
+	if err != nil || !proto.Equal(v.Canaries["ks1"], canary) || !proto.Equal(v.Keyspaces["ks1"], &vschemapb.Keyspace{}) {
+		t.Errorf("unexpected GetSrvVSchema result: %v %v", v, err)
+	}
+
+	// Deleting the canary removes it from the SrvVSchema.
+	if err := ts.DeleteVSchemaCanary(ctx, "ks1"); err != nil {
+		t.Fatalf("DeleteVSchemaCanary(ks1) failed: %v", err)
+	}
+	if err := ts.DeleteVSchemaCanary(ctx, "ks1"); err != nil {
+		t.Fatalf("DeleteVSchemaCanary(ks1) failed: %v", err)
+	}
+	if v, err := ts.GetVSchemaCanary(ctx, "ks1"); err != nil || v != nil {
+		t.Errorf("unexpected GetVSchemaCanary result: %v %v", v, err)
+	}
+	if err := ts.RebuildSrvVSchema(ctx, cells); err != nil {
+		t.Errorf("RebuildVSchema failed: %v", err)
+	}
+	if v, err := ts.GetSrvVSchema(ctx, "cell1"); err != nil || len(v.Canaries) != 0 {
+		t.Errorf("unexpected GetSrvVSchema result: %v %v", v, err)
+	}
+}
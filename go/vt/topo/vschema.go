@@ -71,6 +71,12 @@ func (ts *Server) SaveVSchema(ctx context.Context, ksvs *KeyspaceVSchemaInfo) er
 	ksvs.version = version
 	log.Infof("successfully updated vschema for keyspace %s: %+v", ksvs.Name, ksvs.Keyspace)
 
+	// The canary vschema of the keyspace, if any, was validated against the
+	// previous vschema, so saving a vschema ends it.
+	if err := ts.DeleteVSchemaCanary(ctx, ksvs.Name); err != nil {
+		log.Errorf("failed to delete canary vschema for keyspace %s: %v", ksvs.Name, err)
+		return err
+	}
 	return nil
 }
 
@@ -108,6 +114,61 @@ func (ts *Server) GetVSchema(ctx context.Context, keyspace string) (*KeyspaceVSc
 	}, nil
 }
 
+// SaveVSchemaCanary saves the canary vschema of a keyspace.
+func (ts *Server) SaveVSchemaCanary(ctx context.Context, keyspace string, canary *vschemapb.VSchemaCanary) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	nodePath := path.Join(KeyspacesPath, keyspace, VSchemaCanaryFile)
+	data, err := canary.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.globalCell.Update(ctx, nodePath, data, nil); err != nil {
+		log.Errorf("failed to update canary vschema for keyspace %s: %v", keyspace, err)
+		return err
+	}
+	log.Infof("successfully updated canary vschema for keyspace %s with %d%% of the sessions: %+v", keyspace, canary.Percent, canary.Keyspace)
+	return nil
+}
+
+// GetVSchemaCanary fetches the canary vschema of a keyspace from the topo, or
+// returns nil if there is none.
+func (ts *Server) GetVSchemaCanary(ctx context.Context, keyspace string) (*vschemapb.VSchemaCanary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nodePath := path.Join(KeyspacesPath, keyspace, VSchemaCanaryFile)
+	data, _, err := ts.globalCell.Get(ctx, nodePath)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	canary := &vschemapb.VSchemaCanary{}
+	if err := canary.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrapf(err, "bad canary vschema data: %q", data)
+	}
+	return canary, nil
+}
+
+// DeleteVSchemaCanary deletes the canary vschema of a keyspace, if any.
+func (ts *Server) DeleteVSchemaCanary(ctx context.Context, keyspace string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	nodePath := path.Join(KeyspacesPath, keyspace, VSchemaCanaryFile)
+	if err := ts.globalCell.Delete(ctx, nodePath, nil); err != nil && !IsErrType(err, NoNode) {
+		return err
+	}
+	return nil
+}
+
 // EnsureVSchema makes sure that a vschema is present for this keyspace or creates a blank one if it is missing
 func (ts *Server) EnsureVSchema(ctx context.Context, keyspace string) error {
 	ksvs, err := ts.GetVSchema(ctx, keyspace)
@@ -327,3 +388,47 @@ func (ts *Server) SaveMirrorRules(ctx context.Context, mirrorRules *vschemapb.Mi
 	_, err = ts.globalCell.Update(ctx, MirrorRulesFile, data, nil)
 	return err
 }
+
+// GetRateLimitRules fetches the rate limit rules from the topo.
+func (ts *Server) GetRateLimitRules(ctx context.Context) (*vschemapb.RateLimitRules, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rlr := &vschemapb.RateLimitRules{}
+	data, _, err := ts.globalCell.Get(ctx, RateLimitRulesFile)
+	if err != nil {
+		if IsErrType(err, NoNode) {
+			return rlr, nil
+		}
+		return nil, err
+	}
+	err = rlr.UnmarshalVT(data)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "bad rate limit rules data: %q", data)
+	}
+	return rlr, nil
+}
+
+// SaveRateLimitRules saves the rate limit rules into the topo.
+func (ts *Server) SaveRateLimitRules(ctx context.Context, rateLimitRules *vschemapb.RateLimitRules) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := rateLimitRules.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		// No rules, remove the file.
+		if err := ts.globalCell.Delete(ctx, RateLimitRulesFile, nil); err != nil && !IsErrType(err, NoNode) {
+			return err
+		}
+		return nil
+	}
+
+	_, err = ts.globalCell.Update(ctx, RateLimitRulesFile, data, nil)
+	return err
+}
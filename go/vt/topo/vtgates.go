@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+	"path"
+)
+
+// RegisterVTGate records the address of a vtgate in the global topo, so that
+// the other vtgates can discover it. The address is its HTTP address.
+func (ts *Server) RegisterVTGate(ctx context.Context, addr string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, err := ts.globalCell.Update(ctx, path.Join(VTGatesPath, addr), []byte(addr), nil)
+	return err
+}
+
+// UnregisterVTGate removes the address of a vtgate from the global topo.
+// It does nothing if the vtgate is not registered.
+func (ts *Server) UnregisterVTGate(ctx context.Context, addr string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := ts.globalCell.Delete(ctx, path.Join(VTGatesPath, addr), nil)
+	if IsErrType(err, NoNode) {
+		return nil
+	}
+	return err
+}
+
+// GetVTGateAddresses returns the addresses of the vtgates registered in the
+// global topo. A vtgate which stopped without unregistering is still listed.
+func (ts *Server) GetVTGateAddresses(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := ts.globalCell.ListDir(ctx, VTGatesPath, false /*full*/)
+	switch {
+	case IsErrType(err, NoNode):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	addrs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		addrs = append(addrs, entry.Name)
+	}
+	return addrs, nil
+}
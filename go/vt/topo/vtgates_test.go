@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+func TestVTGateRegistration(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	addrs, err := ts.GetVTGateAddresses(ctx)
+	require.NoError(t, err)
+	require.Empty(t, addrs)
+
+	require.NoError(t, ts.RegisterVTGate(ctx, "vtgate1:15001"))
+	require.NoError(t, ts.RegisterVTGate(ctx, "vtgate2:15001"))
+	// Registering twice is fine, e.g. after a restart without unregistering.
+	require.NoError(t, ts.RegisterVTGate(ctx, "vtgate1:15001"))
+
+	addrs, err = ts.GetVTGateAddresses(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"vtgate1:15001", "vtgate2:15001"}, addrs)
+
+	require.NoError(t, ts.UnregisterVTGate(ctx, "vtgate1:15001"))
+	require.NoError(t, ts.UnregisterVTGate(ctx, "vtgate1:15001"))
+
+	addrs, err = ts.GetVTGateAddresses(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"vtgate2:15001"}, addrs)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topo
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtorcdatapb "vitess.io/vitess/go/vt/proto/vtorcdata"
+)
+
+// This file contains the VTOrc state management code. The state is stored
+// in the global cell, so that all the VTOrc instances share it, and it
+// survives the loss of any of them.
+
+// GetVTOrcState returns the VTOrc state, which is empty if it does not
+// exist.
+func (ts *Server) GetVTOrcState(ctx context.Context) (*vtorcdatapb.State, error) {
+	state := &vtorcdatapb.State{}
+	data, _, err := ts.globalCell.Get(ctx, VTOrcStateFile)
+	switch {
+	case IsErrType(err, NoNode):
+		return state, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = state.UnmarshalVT(data); err != nil {
+		return nil, vterrors.Wrap(err, "bad VTOrcState data")
+	}
+	return state, nil
+}
+
+// UpdateVTOrcStateFields updates the VTOrc state. It reads the state, calls
+// the update method, and writes it back. If the write fails because the
+// state changed in the meantime, it starts over, so the update method may be
+// called multiple times. If the update method returns ErrNoUpdateNeeded,
+// nothing is written and nil is returned. Other errors are returned as is.
+func (ts *Server) UpdateVTOrcStateFields(ctx context.Context, update func(*vtorcdatapb.State) error) error {
+	for {
+		data, version, err := ts.globalCell.Get(ctx, VTOrcStateFile)
+		state := &vtorcdatapb.State{}
+		switch {
+		case IsErrType(err, NoNode):
+			// Empty node, version is nil
+		case err == nil:
+			if err = state.UnmarshalVT(data); err != nil {
+				return vterrors.Wrap(err, "bad VTOrcState data")
+			}
+		default:
+			return err
+		}
+
+		err = update(state)
+		switch {
+		case IsErrType(err, NoUpdateNeeded):
+			return nil
+		case err == nil:
+			// keep going
+		default:
+			return err
+		}
+
+		data, err = state.MarshalVT()
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			// We have to create, and we catch NodeExists.
+			_, err = ts.globalCell.Create(ctx, VTOrcStateFile, data)
+			if IsErrType(err, NodeExists) {
+				// Node was created by another process, try
+				// again.
+				continue
+			}
+			return err
+		}
+
+		// We have to update, and we catch ErrBadVersion.
+		_, err = ts.globalCell.Update(ctx, VTOrcStateFile, data, version)
+		if IsErrType(err, BadVersion) {
+			// Node was updated by another process, try again.
+			continue
+		}
+		return err
+	}
+}
@@ -31,6 +31,9 @@ func GetMirrorRulesMap(rules *vschemapb.MirrorRules) map[string]map[string]float
 	}
 	rulesMap := make(map[string]map[string]float32)
 	for _, mr := range rules.Rules {
+		if mr.Split {
+			continue
+		}
 		if _, ok := rulesMap[mr.FromTable]; !ok {
 			rulesMap[mr.FromTable] = make(map[string]float32)
 		}
@@ -40,7 +43,7 @@ func GetMirrorRulesMap(rules *vschemapb.MirrorRules) map[string]map[string]float
 }
 
 // GetMirrorRules fetches mirror rules from the topology server and returns a
-// mapping of fromTable=>toTable=>percent.
+// mapping of fromTable=>toTable=>percent. Split rules are not included.
 func GetMirrorRules(ctx context.Context, ts *topo.Server) (map[string]map[string]float32, error) {
 	mrs, err := ts.GetMirrorRules(ctx)
 	if err != nil {
@@ -53,11 +56,22 @@ func GetMirrorRules(ctx context.Context, ts *topo.Server) (map[string]map[string
 }
 
 // SaveMirrorRules converts a mapping of fromTable=>[]toTables into a
-// vschemapb.MirrorRules protobuf message and saves it in the topology.
+// vschemapb.MirrorRules protobuf message and saves it in the topology. The
+// split rules of the other from tables are kept.
 func SaveMirrorRules(ctx context.Context, ts *topo.Server, rules map[string]map[string]float32) error {
 	log.V(2).Infof("Saving mirror rules %v\n", rules)
 
+	existing, err := ts.GetMirrorRules(ctx)
+	if err != nil {
+		return err
+	}
+
 	rrs := &vschemapb.MirrorRules{Rules: make([]*vschemapb.MirrorRule, 0)}
+	for _, mr := range existing.Rules {
+		if _, ok := rules[mr.FromTable]; mr.Split && !ok {
+			rrs.Rules = append(rrs.Rules, mr)
+		}
+	}
 	for fromTable, mrs := range rules {
 		for toTable, percent := range mrs {
 			rrs.Rules = append(rrs.Rules, &vschemapb.MirrorRule{
@@ -70,3 +84,34 @@ func SaveMirrorRules(ctx context.Context, ts *topo.Server, rules map[string]map[
 
 	return ts.SaveMirrorRules(ctx, rrs)
 }
+
+// SaveSplitRules saves split rules, which serve the percent of the queries on
+// each fromTable from its toTable, replacing the rules of these from tables.
+// A percent of 0 removes the rules of these from tables.
+func SaveSplitRules(ctx context.Context, ts *topo.Server, rules map[string]string, percent float32) error {
+	log.V(2).Infof("Saving split rules %v at %v%%\n", rules, percent)
+
+	existing, err := ts.GetMirrorRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	rrs := &vschemapb.MirrorRules{Rules: make([]*vschemapb.MirrorRule, 0)}
+	for _, mr := range existing.Rules {
+		if _, ok := rules[mr.FromTable]; !ok {
+			rrs.Rules = append(rrs.Rules, mr)
+		}
+	}
+	if percent > 0 {
+		for fromTable, toTable := range rules {
+			rrs.Rules = append(rrs.Rules, &vschemapb.MirrorRule{
+				FromTable: fromTable,
+				Percent:   percent,
+				ToTable:   toTable,
+				Split:     true,
+			})
+		}
+	}
+
+	return ts.SaveMirrorRules(ctx, rrs)
+}
@@ -49,6 +49,65 @@ func TestMirrorRulesRoundTrip(t *testing.T) {
 	assert.Equal(t, rules, roundtripRules)
 }
 
+func TestSplitRules(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	err := SaveMirrorRules(ctx, ts, map[string]map[string]float32{
+		"k1.t1": {"k2.t1": 50.0},
+	})
+	require.NoError(t, err)
+	err = SaveSplitRules(ctx, ts, map[string]string{
+		"k1.t2@replica": "k2.t2",
+		"k1.t3@replica": "k2.t3",
+	}, 10)
+	require.NoError(t, err)
+
+	// The split rules are kept by the mirror rules, and are not returned
+	// with them.
+	rules, err := GetMirrorRules(ctx, ts)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]float32{"k1.t1": {"k2.t1": 50.0}}, rules)
+	rules["k1.t4"] = map[string]float32{"k2.t4": 20.0}
+	err = SaveMirrorRules(ctx, ts, rules)
+	require.NoError(t, err)
+
+	mrs, err := ts.GetMirrorRules(ctx)
+	require.NoError(t, err)
+	split := make(map[string]float32)
+	for _, mr := range mrs.Rules {
+		if mr.Split {
+			split[mr.FromTable] = mr.Percent
+		}
+	}
+	assert.Equal(t, map[string]float32{"k1.t2@replica": 10, "k1.t3@replica": 10}, split)
+	assert.Len(t, mrs.Rules, 4)
+
+	// The split rules are replaced, and then removed.
+	err = SaveSplitRules(ctx, ts, map[string]string{
+		"k1.t2@replica": "k2.t2",
+		"k1.t3@replica": "k2.t3",
+	}, 50)
+	require.NoError(t, err)
+	mrs, err = ts.GetMirrorRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, mrs.Rules, 4)
+	for _, mr := range mrs.Rules {
+		if mr.Split {
+			assert.EqualValues(t, 50, mr.Percent)
+		}
+	}
+	err = SaveSplitRules(ctx, ts, map[string]string{
+		"k1.t2@replica": "k2.t2",
+		"k1.t3@replica": "k2.t3",
+	}, 0)
+	require.NoError(t, err)
+	mrs, err = ts.GetMirrorRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, mrs.Rules, 2)
+}
+
 func TestMirrorRulesErrors(t *testing.T) {
 	ctx := t.Context()
 	ts, factory := memorytopo.NewServerAndFactory(ctx, "zone1")
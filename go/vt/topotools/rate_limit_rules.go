@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"errors"
+	"fmt"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// GetRateLimitRuleKey returns a string which identifies the keyspace, table
+// and user a rate limit rule applies to.
+func GetRateLimitRuleKey(rule *vschemapb.RateLimitRule) string {
+	key := rule.Keyspace
+	if rule.Table != "" {
+		key += "." + rule.Table
+	}
+	if rule.User != "" {
+		key += " for user " + rule.User
+	}
+	return key
+}
+
+// ValidateRateLimitRules checks that the given rate limit rules have a
+// keyspace and a positive rate, and that no two rules apply to the same
+// keyspace, table and user.
+func ValidateRateLimitRules(rules *vschemapb.RateLimitRules) error {
+	var errs []error
+	keys := make(map[string]bool, len(rules.GetRules()))
+	for _, rule := range rules.GetRules() {
+		key := GetRateLimitRuleKey(rule)
+		if rule.Keyspace == "" {
+			errs = append(errs, fmt.Errorf("rate limit rule %q: keyspace is required", key))
+		}
+		if rule.QueriesPerSecond <= 0 {
+			errs = append(errs, fmt.Errorf("rate limit rule %q: queries_per_second must be positive", key))
+		}
+		if rule.MaxQueueWaitMs != 0 && rule.Policy != vschemapb.RateLimitRule_QUEUE {
+			errs = append(errs, fmt.Errorf("rate limit rule %q: max_queue_wait_ms is only used by the QUEUE policy", key))
+		}
+		if keys[key] {
+			errs = append(errs, fmt.Errorf("rate limit rule %q: duplicate rule", key))
+		}
+		keys[key] = true
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestValidateRateLimitRules(t *testing.T) {
+	valid := &vschemapb.RateLimitRules{
+		Rules: []*vschemapb.RateLimitRule{
+			{Keyspace: "ks", QueriesPerSecond: 100},
+			{Keyspace: "ks", Table: "t1", QueriesPerSecond: 10},
+			{Keyspace: "ks", Table: "t1", User: "u1", QueriesPerSecond: 1, Policy: vschemapb.RateLimitRule_QUEUE, MaxQueueWaitMs: 1000},
+		},
+	}
+	assert.NoError(t, ValidateRateLimitRules(valid))
+	assert.NoError(t, ValidateRateLimitRules(nil))
+	assert.Equal(t, "ks.t1 for user u1", GetRateLimitRuleKey(valid.Rules[2]))
+
+	invalid := &vschemapb.RateLimitRules{
+		Rules: []*vschemapb.RateLimitRule{
+			{Table: "t1", QueriesPerSecond: 1},
+			{Keyspace: "ks", Table: "t1"},
+			{Keyspace: "ks", QueriesPerSecond: 1, MaxQueueWaitMs: 1000},
+			{Keyspace: "ks", Table: "t1", QueriesPerSecond: 1},
+		},
+	}
+	assert.EqualError(t, ValidateRateLimitRules(invalid), `rate limit rule ".t1": keyspace is required
+rate limit rule "ks.t1": queries_per_second must be positive
+rate limit rule "ks": max_queue_wait_ms is only used by the QUEUE policy
+rate limit rule "ks.t1": duplicate rule`)
+}
@@ -78,6 +78,11 @@ func RebuildKeyspaceLocked(ctx context.Context, log logutil.Logger, ts *topo.Ser
 	if err != nil {
 		return err
 	}
+	if ki.DeletionTombstone != nil {
+		// A soft-deleted keyspace is not served until it is restored.
+		log.Infof("Not rebuilding the serving graph of soft-deleted keyspace %v", keyspace)
+		return nil
+	}
 
 	// The caller intents to update all cells in this case
 	if len(cells) == 0 {
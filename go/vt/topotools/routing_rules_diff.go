@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// RoutingRuleChange describes how a single routing rule, identified by the value it
+// routes from, differs between two sets of routing rules. An empty Before means that
+// the rule is added, and an empty After that it is removed.
+type RoutingRuleChange struct {
+	From   string `json:"from"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// DiffRoutingRules returns the changes needed to go from the current to the desired
+// routing rules, ordered by the value they route from.
+func DiffRoutingRules(current, desired *vschemapb.RoutingRules) []*RoutingRuleChange {
+	return diffRulesMaps(joinedRoutingRulesMap(current), joinedRoutingRulesMap(desired))
+}
+
+// DiffShardRoutingRules returns the changes needed to go from the current to the desired
+// shard routing rules, ordered by the keyspace and shard they route from.
+func DiffShardRoutingRules(current, desired *vschemapb.ShardRoutingRules) []*RoutingRuleChange {
+	return diffRulesMaps(GetShardRoutingRulesMap(current), GetShardRoutingRulesMap(desired))
+}
+
+// DiffKeyspaceRoutingRules returns the changes needed to go from the current to the
+// desired keyspace routing rules, ordered by the keyspace they route from.
+func DiffKeyspaceRoutingRules(current, desired *vschemapb.KeyspaceRoutingRules) []*RoutingRuleChange {
+	return diffRulesMaps(GetKeyspaceRoutingRulesMap(current), GetKeyspaceRoutingRulesMap(desired))
+}
+
+func joinedRoutingRulesMap(rules *vschemapb.RoutingRules) map[string]string {
+	rulesMap := make(map[string]string)
+	for from, to := range GetRoutingRulesMap(rules) {
+		rulesMap[from] = strings.Join(to, ",")
+	}
+	return rulesMap
+}
+
+func diffRulesMaps(current, desired map[string]string) []*RoutingRuleChange {
+	var changes []*RoutingRuleChange
+	for from, before := range current {
+		after, ok := desired[from]
+		switch {
+		case !ok:
+			changes = append(changes, &RoutingRuleChange{From: from, Before: before})
+		case after != before:
+			changes = append(changes, &RoutingRuleChange{From: from, Before: before, After: after})
+		}
+	}
+	for from, after := range desired {
+		if _, ok := current[from]; !ok {
+			changes = append(changes, &RoutingRuleChange{From: from, After: after})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].From < changes[j].From
+	})
+	return changes
+}
+
+// ValidateRoutingRules checks that the given routing rules only route to tables that
+// exist. The vschemas map must contain the vschema of every keyspace in the topo. Tables
+// of unsharded keyspaces do not need to be listed in the keyspace's vschema.
+func ValidateRoutingRules(rules *vschemapb.RoutingRules, vschemas map[string]*vschemapb.Keyspace) error {
+	var errs []error
+	for _, rule := range rules.GetRules() {
+		from, tabletType, ok := strings.Cut(rule.FromTable, "@")
+		if ok {
+			if _, err := topoproto.ParseTabletType(tabletType); err != nil {
+				errs = append(errs, fmt.Errorf("invalid tablet type in routing rule %s: %v", rule.FromTable, err))
+			}
+		}
+		if fromKeyspace, _, ok := strings.Cut(from, "."); ok {
+			if _, exists := vschemas[fromKeyspace]; !exists {
+				errs = append(errs, fmt.Errorf("routing rule %s: keyspace %s does not exist", rule.FromTable, fromKeyspace))
+			}
+		}
+		if len(rule.ToTables) == 0 {
+			errs = append(errs, fmt.Errorf("routing rule %s has no target tables", rule.FromTable))
+		}
+		for _, to := range rule.ToTables {
+			toKeyspace, toTable, ok := strings.Cut(to, ".")
+			if !ok {
+				errs = append(errs, fmt.Errorf("routing rule %s: target table %s must be qualified with a keyspace", rule.FromTable, to))
+				continue
+			}
+			vschema, exists := vschemas[toKeyspace]
+			if !exists {
+				errs = append(errs, fmt.Errorf("routing rule %s: keyspace %s does not exist", rule.FromTable, toKeyspace))
+				continue
+			}
+			if vschema.GetSharded() && vschema.Tables[toTable] == nil {
+				errs = append(errs, fmt.Errorf("routing rule %s: table %s not found in the vschema of keyspace %s", rule.FromTable, toTable, toKeyspace))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateShardRoutingRules checks that the given shard routing rules only refer to
+// keyspaces and shards that exist. The shards map must contain the shard names of every
+// keyspace in the topo.
+func ValidateShardRoutingRules(rules *vschemapb.ShardRoutingRules, shards map[string][]string) error {
+	var errs []error
+	for _, rule := range rules.GetRules() {
+		key := GetShardRoutingRuleKey(rule.FromKeyspace, rule.Shard)
+		if _, exists := shards[rule.FromKeyspace]; !exists {
+			errs = append(errs, fmt.Errorf("shard routing rule %s: keyspace %s does not exist", key, rule.FromKeyspace))
+		}
+		toShards, exists := shards[rule.ToKeyspace]
+		if !exists {
+			errs = append(errs, fmt.Errorf("shard routing rule %s: keyspace %s does not exist", key, rule.ToKeyspace))
+			continue
+		}
+		if !slices.Contains(toShards, rule.Shard) {
+			errs = append(errs, fmt.Errorf("shard routing rule %s: shard %s does not exist in keyspace %s", key, rule.Shard, rule.ToKeyspace))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateKeyspaceRoutingRules checks that the given keyspace routing rules only route to
+// keyspaces that exist.
+func ValidateKeyspaceRoutingRules(rules *vschemapb.KeyspaceRoutingRules, keyspaces []string) error {
+	var errs []error
+	for _, rule := range rules.GetRules() {
+		if !slices.Contains(keyspaces, rule.ToKeyspace) {
+			errs = append(errs, fmt.Errorf("keyspace routing rule %s: keyspace %s does not exist", rule.FromKeyspace, rule.ToKeyspace))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topotools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestDiffRoutingRules(t *testing.T) {
+	current := &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks1.t1"}},
+			{FromTable: "t2", ToTables: []string{"ks1.t2"}},
+			{FromTable: "t3", ToTables: []string{"ks1.t3"}},
+		},
+	}
+	desired := &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"ks1.t1"}},
+			{FromTable: "t2", ToTables: []string{"ks2.t2"}},
+			{FromTable: "t4", ToTables: []string{"ks2.t4", "ks2.t5"}},
+		},
+	}
+	assert.Equal(t, []*RoutingRuleChange{
+		{From: "t2", Before: "ks1.t2", After: "ks2.t2"},
+		{From: "t3", Before: "ks1.t3"},
+		{From: "t4", After: "ks2.t4,ks2.t5"},
+	}, DiffRoutingRules(current, desired))
+	assert.Empty(t, DiffRoutingRules(current, current))
+	assert.Len(t, DiffRoutingRules(nil, current), 3)
+
+	shardChanges := DiffShardRoutingRules(
+		&vschemapb.ShardRoutingRules{Rules: []*vschemapb.ShardRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks2", Shard: "-80"}}},
+		&vschemapb.ShardRoutingRules{Rules: []*vschemapb.ShardRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks1", Shard: "-80"}}},
+	)
+	assert.Equal(t, []*RoutingRuleChange{{From: "ks1.-80", Before: "ks2", After: "ks1"}}, shardChanges)
+
+	keyspaceChanges := DiffKeyspaceRoutingRules(
+		nil,
+		&vschemapb.KeyspaceRoutingRules{Rules: []*vschemapb.KeyspaceRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks2"}}},
+	)
+	assert.Equal(t, []*RoutingRuleChange{{From: "ks1", After: "ks2"}}, keyspaceChanges)
+}
+
+func TestValidateRoutingRules(t *testing.T) {
+	vschemas := map[string]*vschemapb.Keyspace{
+		"unsharded": {},
+		"sharded": {
+			Sharded: true,
+			Tables:  map[string]*vschemapb.Table{"t1": {}},
+		},
+	}
+	tcs := []struct {
+		name      string
+		rules     []*vschemapb.RoutingRule
+		expectErr []string
+	}{
+		{
+			name: "valid",
+			rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1", ToTables: []string{"sharded.t1"}},
+				{FromTable: "unsharded.t1@replica", ToTables: []string{"sharded.t1"}},
+				{FromTable: "sharded.t1", ToTables: []string{"unsharded.t1"}},
+				{FromTable: "t2", ToTables: []string{"unsharded.t2"}},
+			},
+		},
+		{
+			name: "invalid",
+			rules: []*vschemapb.RoutingRule{
+				{FromTable: "t1@bogus", ToTables: []string{"sharded.t1"}},
+				{FromTable: "nosuchks.t1", ToTables: []string{"sharded.t1"}},
+				{FromTable: "t2", ToTables: []string{"t2"}},
+				{FromTable: "t3", ToTables: []string{"nosuchks.t3"}},
+				{FromTable: "t4", ToTables: []string{"sharded.t4"}},
+				{FromTable: "t5"},
+			},
+			expectErr: []string{
+				"invalid tablet type in routing rule t1@bogus",
+				"routing rule nosuchks.t1: keyspace nosuchks does not exist",
+				"routing rule t2: target table t2 must be qualified with a keyspace",
+				"routing rule t3: keyspace nosuchks does not exist",
+				"routing rule t4: table t4 not found in the vschema of keyspace sharded",
+				"routing rule t5 has no target tables",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRoutingRules(&vschemapb.RoutingRules{Rules: tc.rules}, vschemas)
+			if len(tc.expectErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, expectErr := range tc.expectErr {
+				assert.ErrorContains(t, err, expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateShardRoutingRules(t *testing.T) {
+	shards := map[string][]string{
+		"ks1": {"0"},
+		"ks2": {"-80", "80-"},
+	}
+	assert.NoError(t, ValidateShardRoutingRules(&vschemapb.ShardRoutingRules{
+		Rules: []*vschemapb.ShardRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks2", Shard: "-80"}},
+	}, shards))
+
+	err := ValidateShardRoutingRules(&vschemapb.ShardRoutingRules{
+		Rules: []*vschemapb.ShardRoutingRule{
+			{FromKeyspace: "ks3", ToKeyspace: "ks2", Shard: "80-"},
+			{FromKeyspace: "ks1", ToKeyspace: "ks4", Shard: "80-"},
+			{FromKeyspace: "ks1", ToKeyspace: "ks2", Shard: "0"},
+		},
+	}, shards)
+	assert.ErrorContains(t, err, "shard routing rule ks3.80-: keyspace ks3 does not exist")
+	assert.ErrorContains(t, err, "shard routing rule ks1.80-: keyspace ks4 does not exist")
+	assert.ErrorContains(t, err, "shard routing rule ks1.0: shard 0 does not exist in keyspace ks2")
+}
+
+func TestValidateKeyspaceRoutingRules(t *testing.T) {
+	keyspaces := []string{"ks1", "ks2"}
+	assert.NoError(t, ValidateKeyspaceRoutingRules(&vschemapb.KeyspaceRoutingRules{
+		Rules: []*vschemapb.KeyspaceRoutingRule{{FromKeyspace: "ks3", ToKeyspace: "ks2"}},
+	}, keyspaces))
+	assert.EqualError(t, ValidateKeyspaceRoutingRules(&vschemapb.KeyspaceRoutingRules{
+		Rules: []*vschemapb.KeyspaceRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks3"}},
+	}, keyspaces), "keyspace routing rule ks1: keyspace ks3 does not exist")
+}
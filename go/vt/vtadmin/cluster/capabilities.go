@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/vtctl/grpcclientcommon"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+// GetCapabilities returns the capabilities of the vtctlds and VTGates in the
+// cluster. A component which cannot be queried does not fail the request, its
+// capabilities have their Error field set instead.
+func (c *Cluster) GetCapabilities(ctx context.Context) (*vtadminpb.ClusterCapabilities, error) {
+	vtctlds, err := c.GetVtctlds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gates, err := c.GetGates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &vtadminpb.ClusterCapabilities{
+		Cluster: c.ToProto(),
+		Vtctlds: make([]*vtadminpb.ComponentCapabilities, len(vtctlds)),
+		Vtgates: make([]*vtadminpb.ComponentCapabilities, len(gates)),
+	}
+
+	var wg sync.WaitGroup
+	for i, vtctld := range vtctlds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			capabilities.Vtctlds[i] = getComponentCapabilities(ctx, vtctld.Hostname, vtctld.FQDN)
+		}()
+	}
+	for i, gate := range gates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			capabilities.Vtgates[i] = getComponentCapabilities(ctx, gate.Hostname, gate.FQDN)
+		}()
+	}
+	wg.Wait()
+
+	return capabilities, nil
+}
+
+// getComponentCapabilities returns the capabilities of a component, from its
+// /debug/vars and /debug/config HTTP endpoints at the given FQDN, and from the
+// gRPC reflection service at the given hostname.
+func getComponentCapabilities(ctx context.Context, hostname string, fqdn string) *vtadminpb.ComponentCapabilities {
+	capabilities := &vtadminpb.ComponentCapabilities{
+		Hostname: hostname,
+	}
+
+	var errs []string
+	if fqdn == "" {
+		errs = append(errs, "no FQDN to query the debug endpoints")
+	} else {
+		baseURL := fqdn
+		if !strings.Contains(baseURL, "://") {
+			baseURL = "http://" + baseURL
+		}
+
+		var vars struct {
+			BuildVersion string
+			BuildGitRev  string
+		}
+		if err := getJSON(ctx, baseURL+"/debug/vars", &vars); err != nil {
+			errs = append(errs, err.Error())
+		}
+		capabilities.Version = vars.BuildVersion
+		capabilities.GitRevision = vars.BuildGitRev
+
+		var config map[string]any
+		if err := getJSON(ctx, baseURL+"/debug/config?format=json", &config); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if len(config) > 0 {
+			capabilities.Config = make(map[string]string)
+			flattenConfig("", config, capabilities.Config)
+		}
+	}
+
+	rpcs, err := listRPCs(ctx, hostname)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("failed to list the gRPC methods of %s: %v", hostname, err))
+	}
+	capabilities.Rpcs = rpcs
+
+	capabilities.Error = strings.Join(errs, "; ")
+	return capabilities
+}
+
+func getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s: %s", url, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("GET %s: %w", url, err)
+	}
+	return nil
+}
+
+// flattenConfig adds the values of the config to the flattened map, keyed by
+// their dotted path.
+func flattenConfig(prefix string, config map[string]any, flattened map[string]string) {
+	for k, v := range config {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			flattenConfig(k, v, flattened)
+		case string:
+			flattened[k] = v
+		default:
+			data, _ := json.Marshal(v)
+			flattened[k] = string(data)
+		}
+	}
+}
+
+// listRPCs returns the sorted gRPC methods served at the given address, as
+// reported by its gRPC reflection service.
+func listRPCs(ctx context.Context, addr string) ([]string, error) {
+	tlsOpt, err := grpcclientcommon.SecureDialOption()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpcclient.DialContext(ctx, addr, grpcclient.FailFast(true), tlsOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return listRPCsWithConn(ctx, conn)
+}
+
+func listRPCsWithConn(ctx context.Context, conn grpc.ClientConnInterface) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	send := func(req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("%s", errResp.ErrorMessage)
+		}
+		return resp, nil
+	}
+
+	resp, err := send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcs []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		if strings.HasPrefix(service.Name, "grpc.reflection.") {
+			continue
+		}
+
+		resp, err := send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+				FileContainingSymbol: service.Name,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", service.Name, err)
+		}
+
+		for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var fd descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(data, &fd); err != nil {
+				return nil, fmt.Errorf("service %s: %w", service.Name, err)
+			}
+			for _, sd := range fd.GetService() {
+				name := sd.GetName()
+				if fd.GetPackage() != "" {
+					name = fd.GetPackage() + "." + name
+				}
+				if name != service.Name {
+					continue
+				}
+				for _, md := range sd.GetMethod() {
+					rpcs = append(rpcs, name+"/"+md.GetName())
+				}
+			}
+		}
+	}
+
+	sort.Strings(rpcs)
+	return rpcs, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"vitess.io/vitess/go/vt/vtadmin/cluster/discovery/fakediscovery"
+
+	vtadminpb "vitess.io/vitess/go/vt/proto/vtadmin"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/vars", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"BuildVersion": "24.0.0", "BuildGitRev": "abc123", "QueryCount": 10}`))
+	})
+	mux.HandleFunc("/debug/config", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		w.Write([]byte(`{"enable-views": true, "healthcheck": {"timeout": "1m0s"}, "port": 15000}`))
+	})
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	reflection.Register(grpcServer)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	disco := fakediscovery.New()
+	disco.AddTaggedVtctlds(nil, &vtadminpb.Vtctld{
+		Hostname: lis.Addr().String(),
+		FQDN:     httpServer.URL,
+	})
+	// A VTGate running a version without the debug endpoints.
+	disco.AddTaggedGates(nil, &vtadminpb.VTGate{
+		Hostname: lis.Addr().String(),
+		FQDN:     httpServer.URL + "/old",
+	})
+	c := &Cluster{
+		ID:        "c1",
+		Name:      "one",
+		Discovery: disco,
+	}
+
+	capabilities, err := c.GetCapabilities(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "c1", capabilities.Cluster.Id)
+
+	require.Len(t, capabilities.Vtctlds, 1)
+	vtctld := capabilities.Vtctlds[0]
+	assert.Empty(t, vtctld.Error)
+	assert.Equal(t, "24.0.0", vtctld.Version)
+	assert.Equal(t, "abc123", vtctld.GitRevision)
+	assert.Equal(t, map[string]string{
+		"enable-views":        "true",
+		"healthcheck.timeout": "1m0s",
+		"port":                "15000",
+	}, vtctld.Config)
+	assert.Contains(t, vtctld.Rpcs, "grpc.health.v1.Health/Check")
+	assert.NotContains(t, vtctld.Rpcs, "grpc.reflection.v1.ServerReflection/ServerReflectionInfo")
+
+	require.Len(t, capabilities.Vtgates, 1)
+	gate := capabilities.Vtgates[0]
+	assert.Contains(t, gate.Error, "/old/debug/vars: 404 Not Found")
+	assert.Empty(t, gate.Version)
+	assert.Contains(t, gate.Rpcs, "grpc.health.v1.Health/Check")
+
+	disco.SetVtctldsError(true)
+	_, err = c.GetCapabilities(ctx)
+	assert.Error(t, err)
+}
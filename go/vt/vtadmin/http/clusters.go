@@ -32,6 +32,15 @@ func GetClusters(ctx context.Context, r Request, api *API) *JSONResponse {
 	return NewJSONResponse(clusters, err)
 }
 
+// GetClusterCapabilities implements the http wrapper for
+// /clusters/capabilities[?cluster_id=[&cluster_id=]].
+func GetClusterCapabilities(ctx context.Context, r Request, api *API) *JSONResponse {
+	capabilities, err := api.server.GetClusterCapabilities(ctx, &vtadminpb.GetClusterCapabilitiesRequest{
+		ClusterIds: r.URL.Query()["cluster_id"],
+	})
+	return NewJSONResponse(capabilities, err)
+}
+
 // GetTopologyPath implements the http wrapper for /cluster/{cluster_id}/topology
 //
 // Query params:
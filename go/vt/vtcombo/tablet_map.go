@@ -933,6 +933,14 @@ func (itmc *internalTabletManagerClient) MysqlHostMetrics(context.Context, *topo
 	return nil, errors.New("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) GetMysqlErrorLogEvents(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error) {
+	return nil, errors.New("not implemented in vtcombo")
+}
+
+func (itmc *internalTabletManagerClient) GetQueryPlans(context.Context, *topodatapb.Tablet, *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	return nil, errors.New("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error) {
 	return nil, errors.New("not implemented in vtcombo")
 }
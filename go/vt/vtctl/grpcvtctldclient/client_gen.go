@@ -47,6 +47,15 @@ func (client *gRPCVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldata
 	return client.c.AddCellsAlias(ctx, in, opts...)
 }
 
+// AddTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) AddTabletTags(ctx context.Context, in *vtctldatapb.AddTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.AddTabletTagsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.AddTabletTags(ctx, in, opts...)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -56,6 +65,15 @@ func (client *gRPCVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, i
 	return client.c.ApplyKeyspaceRoutingRules(ctx, in, opts...)
 }
 
+// ApplyRateLimitRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) ApplyRateLimitRules(ctx context.Context, in *vtctldatapb.ApplyRateLimitRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRateLimitRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.ApplyRateLimitRules(ctx, in, opts...)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -398,6 +416,24 @@ func (client *gRPCVtctldClient) GetMirrorRules(ctx context.Context, in *vtctldat
 	return client.c.GetMirrorRules(ctx, in, opts...)
 }
 
+// GetMysqlErrorLogEvents is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetMysqlErrorLogEvents(ctx context.Context, in *vtctldatapb.GetMysqlErrorLogEventsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMysqlErrorLogEventsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetMysqlErrorLogEvents(ctx, in, opts...)
+}
+
+// GetTabletQueryPlans is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetTabletQueryPlans(ctx context.Context, in *vtctldatapb.GetTabletQueryPlansRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletQueryPlansResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetTabletQueryPlans(ctx, in, opts...)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	if client.c == nil {
@@ -407,6 +443,15 @@ func (client *gRPCVtctldClient) GetPermissions(ctx context.Context, in *vtctldat
 	return client.c.GetPermissions(ctx, in, opts...)
 }
 
+// GetRateLimitRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) GetRateLimitRules(ctx context.Context, in *vtctldatapb.GetRateLimitRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRateLimitRulesResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.GetRateLimitRules(ctx, in, opts...)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	if client.c == nil {
@@ -596,6 +641,15 @@ func (client *gRPCVtctldClient) LaunchSchemaMigration(ctx context.Context, in *v
 	return client.c.LaunchSchemaMigration(ctx, in, opts...)
 }
 
+// LookupVindexCancel is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) LookupVindexCancel(ctx context.Context, in *vtctldatapb.LookupVindexCancelRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCancelResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.LookupVindexCancel(ctx, in, opts...)
+}
+
 // LookupVindexComplete is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) LookupVindexComplete(ctx context.Context, in *vtctldatapb.LookupVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCompleteResponse, error) {
 	if client.c == nil {
@@ -632,6 +686,24 @@ func (client *gRPCVtctldClient) LookupVindexInternalize(ctx context.Context, in
 	return client.c.LookupVindexInternalize(ctx, in, opts...)
 }
 
+// LookupVindexProgress is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) LookupVindexProgress(ctx context.Context, in *vtctldatapb.LookupVindexProgressRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexProgressResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.LookupVindexProgress(ctx, in, opts...)
+}
+
+// LookupVindexUpdateThrottle is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) LookupVindexUpdateThrottle(ctx context.Context, in *vtctldatapb.LookupVindexUpdateThrottleRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexUpdateThrottleResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.LookupVindexUpdateThrottle(ctx, in, opts...)
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
 	if client.c == nil {
@@ -722,6 +794,33 @@ func (client *gRPCVtctldClient) PlannedReparentShard(ctx context.Context, in *vt
 	return client.c.PlannedReparentShard(ctx, in, opts...)
 }
 
+// PrimaryVindexCancel is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PrimaryVindexCancel(ctx context.Context, in *vtctldatapb.PrimaryVindexCancelRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCancelResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PrimaryVindexCancel(ctx, in, opts...)
+}
+
+// PrimaryVindexComplete is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PrimaryVindexComplete(ctx context.Context, in *vtctldatapb.PrimaryVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCompleteResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PrimaryVindexComplete(ctx, in, opts...)
+}
+
+// PrimaryVindexCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) PrimaryVindexCreate(ctx context.Context, in *vtctldatapb.PrimaryVindexCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCreateResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.PrimaryVindexCreate(ctx, in, opts...)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	if client.c == nil {
@@ -812,6 +911,15 @@ func (client *gRPCVtctldClient) RemoveShardCell(ctx context.Context, in *vtctlda
 	return client.c.RemoveShardCell(ctx, in, opts...)
 }
 
+// RemoveTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RemoveTabletTags(ctx context.Context, in *vtctldatapb.RemoveTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveTabletTagsResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RemoveTabletTags(ctx, in, opts...)
+}
+
 // ReparentTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) ReparentTablet(ctx context.Context, in *vtctldatapb.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.ReparentTabletResponse, error) {
 	if client.c == nil {
@@ -839,6 +947,15 @@ func (client *gRPCVtctldClient) RestoreFromBackup(ctx context.Context, in *vtctl
 	return client.c.RestoreFromBackup(ctx, in, opts...)
 }
 
+// RestoreKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) RestoreKeyspace(ctx context.Context, in *vtctldatapb.RestoreKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.RestoreKeyspaceResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.RestoreKeyspace(ctx, in, opts...)
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) RetrySchemaMigration(ctx context.Context, in *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
 	if client.c == nil {
@@ -1037,6 +1154,15 @@ func (client *gRPCVtctldClient) VDiffDelete(ctx context.Context, in *vtctldatapb
 	return client.c.VDiffDelete(ctx, in, opts...)
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) VDiffRepair(ctx context.Context, in *vtctldatapb.VDiffRepairRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffRepairResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.VDiffRepair(ctx, in, opts...)
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) VDiffResume(ctx context.Context, in *vtctldatapb.VDiffResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffResumeResponse, error) {
 	if client.c == nil {
@@ -1136,6 +1262,15 @@ func (client *gRPCVtctldClient) ValidateVersionShard(ctx context.Context, in *vt
 	return client.c.ValidateVersionShard(ctx, in, opts...)
 }
 
+// WatchClusterEvents is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WatchClusterEvents(ctx context.Context, in *vtctldatapb.WatchClusterEventsRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_WatchClusterEventsClient, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WatchClusterEvents(ctx, in, opts...)
+}
+
 // WorkflowAddTables is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) WorkflowAddTables(ctx context.Context, in *vtctldatapb.WorkflowAddTablesRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowAddTablesResponse, error) {
 	if client.c == nil {
@@ -1163,6 +1298,42 @@ func (client *gRPCVtctldClient) WorkflowMirrorTraffic(ctx context.Context, in *v
 	return client.c.WorkflowMirrorTraffic(ctx, in, opts...)
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowExport(ctx context.Context, in *vtctldatapb.WorkflowExportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowExportResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowExport(ctx, in, opts...)
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowImport(ctx context.Context, in *vtctldatapb.WorkflowImportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowImportResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowImport(ctx, in, opts...)
+}
+
+// WorkflowPause is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowPause(ctx context.Context, in *vtctldatapb.WorkflowPauseRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowPauseResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowPause(ctx, in, opts...)
+}
+
+// WorkflowResume is part of the vtctlservicepb.VtctldClient interface.
+func (client *gRPCVtctldClient) WorkflowResume(ctx context.Context, in *vtctldatapb.WorkflowResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowResumeResponse, error) {
+	if client.c == nil {
+		return nil, status.Error(codes.Unavailable, connClosedMsg)
+	}
+
+	return client.c.WorkflowResume(ctx, in, opts...)
+}
+
 // WorkflowStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *gRPCVtctldClient) WorkflowStatus(ctx context.Context, in *vtctldatapb.WorkflowStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
 	if client.c == nil {
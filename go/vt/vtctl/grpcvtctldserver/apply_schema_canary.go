@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// defaultCanaryTimeout is how long ApplySchema waits for the Online DDL
+// migrations on the canary shard to complete, when the request does not set
+// a positive CanaryTimeout.
+const defaultCanaryTimeout = time.Hour
+
+// canaryPollInterval is how often ApplySchema checks the status of the Online
+// DDL migrations on the canary shard.
+var canaryPollInterval = 5 * time.Second
+
+// applySchemaWithCanary applies the schema change of the request on its
+// canary shard, and on the remaining shards of the keyspace once approved.
+//
+// Without CanaryApproved, the schema change is applied on the canary shard
+// only, waiting for its Online DDL migrations there to complete. It then
+// proceeds with the remaining shards only with CanaryAutoProceed, reusing the
+// UUIDs of the canary migrations so that each migration keeps the same UUID
+// across the keyspace.
+//
+// With CanaryApproved, the schema change was already applied on the canary
+// shard by a previous request, whose returned UUIDs are passed in the
+// UuidList, and is applied on the remaining shards once its migrations are
+// verified to be complete on the canary shard.
+func (s *VtctldServer) applySchemaWithCanary(ctx context.Context, req *vtctldatapb.ApplySchemaRequest, migrationContext string, waitReplicasTimeout time.Duration) (*vtctldatapb.ApplySchemaResponse, error) {
+	if req.CanaryAutoProceed && req.CanaryApproved {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "CanaryAutoProceed and CanaryApproved are mutually exclusive")
+	}
+
+	shards, err := s.ts.GetShardNames(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(shards, req.CanaryShard) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "canary shard %s not found in keyspace %s", req.CanaryShard, req.Keyspace)
+	}
+
+	canaryTimeout, ok, err := protoutil.DurationFromProto(req.CanaryTimeout)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse CanaryTimeout into a valid duration")
+	} else if !ok || canaryTimeout <= 0 {
+		canaryTimeout = defaultCanaryTimeout
+	}
+
+	resp := &vtctldatapb.ApplySchemaResponse{
+		RowsAffectedByShard: make(map[string]uint64, len(shards)),
+		Canary:              &vtctldatapb.ApplySchemaCanary{Shard: req.CanaryShard},
+	}
+
+	uuids := req.UuidList
+	if req.CanaryApproved {
+		if err := s.checkCanaryMigrations(ctx, req.Keyspace, req.CanaryShard, uuids); err != nil {
+			return nil, err
+		}
+	} else {
+		start := time.Now()
+		execResult, err := s.runApplySchema(ctx, req, migrationContext, waitReplicasTimeout, req.UuidList, func(shard string) bool {
+			return shard == req.CanaryShard
+		})
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "schema change failed on canary shard %s", req.CanaryShard)
+		}
+		addRowsAffectedByShard(resp, execResult)
+		uuids = execResult.UUIDs
+
+		if err := s.waitForCanaryMigrations(ctx, req.Keyspace, req.CanaryShard, uuids, canaryTimeout); err != nil {
+			return nil, err
+		}
+		resp.Canary.Duration = protoutil.DurationToProto(time.Since(start))
+		log.Infof("ApplySchema: schema change succeeded on canary shard %s/%s in %v", req.Keyspace, req.CanaryShard, time.Since(start))
+
+		if !req.CanaryAutoProceed {
+			resp.UuidList = uuids
+			return resp, nil
+		}
+		if len(uuids) > 0 && len(uuids) != len(req.Sql) {
+			// The UUIDs can only be reused if each statement maps to a
+			// single migration.
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot proceed with the %d migrations of the canary shard for %d statements, apply them on the remaining shards with CanaryApproved and their UuidList", len(uuids), len(req.Sql))
+		}
+	}
+	resp.UuidList = uuids
+
+	if len(shards) > 1 {
+		execResult, err := s.runApplySchema(ctx, req, migrationContext, waitReplicasTimeout, uuids, func(shard string) bool {
+			return shard != req.CanaryShard
+		})
+		if err != nil {
+			return nil, err
+		}
+		addRowsAffectedByShard(resp, execResult)
+	}
+	resp.Canary.Proceeded = true
+
+	return resp, nil
+}
+
+// waitForCanaryMigrations waits until the Online DDL migrations with the
+// given UUIDs are complete on the canary shard, or the timeout expires.
+func (s *VtctldServer) waitForCanaryMigrations(ctx context.Context, keyspace string, shard string, uuids []string, timeout time.Duration) error {
+	if len(uuids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(canaryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := s.pendingCanaryMigrations(ctx, keyspace, shard, uuids)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "timed out waiting for migrations %v to complete on canary shard %s", pending, shard)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkCanaryMigrations returns an error unless the Online DDL migrations
+// with the given UUIDs are complete on the canary shard.
+func (s *VtctldServer) checkCanaryMigrations(ctx context.Context, keyspace string, shard string, uuids []string) error {
+	pending, err := s.pendingCanaryMigrations(ctx, keyspace, shard, uuids)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "migrations %v are not complete on canary shard %s", pending, shard)
+	}
+	return nil
+}
+
+// pendingCanaryMigrations returns the UUIDs of the Online DDL migrations
+// which are not yet complete on the canary shard. It returns an error if any
+// of them failed, was cancelled, or does not exist there.
+func (s *VtctldServer) pendingCanaryMigrations(ctx context.Context, keyspace string, shard string, uuids []string) ([]string, error) {
+	var pending []string
+	for _, uuid := range uuids {
+		resp, err := s.GetSchemaMigrations(ctx, &vtctldatapb.GetSchemaMigrationsRequest{
+			Keyspace: keyspace,
+			Uuid:     uuid,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(resp.Migrations, func(migration *vtctldatapb.SchemaMigration) bool {
+			return migration.Shard == shard
+		})
+		if idx < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "migration %s not found on canary shard %s", uuid, shard)
+		}
+
+		switch migration := resp.Migrations[idx]; migration.Status {
+		case vtctldatapb.SchemaMigration_COMPLETE:
+		case vtctldatapb.SchemaMigration_FAILED, vtctldatapb.SchemaMigration_CANCELLED:
+			return nil, vterrors.Errorf(vtrpcpb.Code_ABORTED, "migration %s is %s on canary shard %s: %s",
+				uuid, vtctldatapb.SchemaMigration_Status_name[int32(migration.Status)], shard, migration.Message)
+		default:
+			pending = append(pending, uuid)
+		}
+	}
+	return pending, nil
+}
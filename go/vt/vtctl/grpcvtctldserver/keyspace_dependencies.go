@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/concurrency"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// checkKeyspaceDependencies returns an error listing what still depends on a
+// keyspace about to be deleted: the routing rules which reference it, the
+// vreplication workflows which write to or read from it, including those
+// migrating it from a mounted cluster, and, if trafficWindow is set, its
+// tablets which served queries within that window.
+func (s *VtctldServer) checkKeyspaceDependencies(ctx context.Context, keyspace string, trafficWindow time.Duration) error {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.checkKeyspaceDependencies")
+	defer span.Finish()
+
+	span.Annotate("keyspace", keyspace)
+
+	dependencies, err := s.routingRuleDependencies(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+
+	workflowDependencies, err := s.workflowDependencies(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	dependencies = append(dependencies, workflowDependencies...)
+
+	if trafficWindow > 0 {
+		trafficDependencies, err := s.trafficDependencies(ctx, keyspace, trafficWindow)
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, trafficDependencies...)
+	}
+
+	if len(dependencies) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v still has dependencies: %s; remove them or use SkipDependencyChecks=true",
+			keyspace, strings.Join(dependencies, "; "))
+	}
+	return nil
+}
+
+// routingRuleDependencies returns the routing, shard routing, keyspace routing
+// and mirror rules which reference the keyspace.
+func (s *VtctldServer) routingRuleDependencies(ctx context.Context, keyspace string) ([]string, error) {
+	var dependencies []string
+
+	rrs, err := s.ts.GetRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rr := range rrs.Rules {
+		if tableInKeyspace(rr.FromTable, keyspace) || slices.ContainsFunc(rr.ToTables, func(table string) bool { return tableInKeyspace(table, keyspace) }) {
+			dependencies = append(dependencies, fmt.Sprintf("routing rule %s", rr.FromTable))
+		}
+	}
+
+	srrs, err := s.ts.GetShardRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, srr := range srrs.Rules {
+		if srr.FromKeyspace == keyspace || srr.ToKeyspace == keyspace {
+			dependencies = append(dependencies, fmt.Sprintf("shard routing rule %s.%s", srr.FromKeyspace, srr.Shard))
+		}
+	}
+
+	krrs, err := s.ts.GetKeyspaceRoutingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, krr := range krrs.GetRules() {
+		if fromKeyspace, _, _ := strings.Cut(krr.FromKeyspace, "@"); fromKeyspace == keyspace || krr.ToKeyspace == keyspace {
+			dependencies = append(dependencies, fmt.Sprintf("keyspace routing rule %s", krr.FromKeyspace))
+		}
+	}
+
+	mrs, err := s.ts.GetMirrorRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, mr := range mrs.Rules {
+		if tableInKeyspace(mr.FromTable, keyspace) || tableInKeyspace(mr.ToTable, keyspace) {
+			dependencies = append(dependencies, fmt.Sprintf("mirror rule %s", mr.FromTable))
+		}
+	}
+
+	return dependencies, nil
+}
+
+// tableInKeyspace returns true if a table of a routing rule, qualified as
+// keyspace.table or keyspace@tablet_type.table, is in the keyspace.
+func tableInKeyspace(table string, keyspace string) bool {
+	qualifier, _, ok := strings.Cut(table, ".")
+	if !ok {
+		return false
+	}
+	qualifier, _, _ = strings.Cut(qualifier, "@")
+	return qualifier == keyspace
+}
+
+// workflowDependencies returns the unfrozen vreplication workflows which write
+// to the keyspace or read from it, as found on the primaries of all keyspaces.
+// The primaries are queried in parallel, each within the remote operation
+// timeout, so that an unreachable primary fails the check instead of blocking
+// it.
+func (s *VtctldServer) workflowDependencies(ctx context.Context, keyspace string) ([]string, error) {
+	keyspaces, err := s.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaries []*topo.TabletInfo
+	for _, ks := range keyspaces {
+		shards, err := s.ts.GetShardNames(ctx, ks)
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shards {
+			si, err := s.ts.GetShard(ctx, ks, shard)
+			if err != nil {
+				return nil, err
+			}
+			if si.PrimaryAlias == nil {
+				continue
+			}
+			primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+			if err != nil {
+				return nil, err
+			}
+			primaries = append(primaries, primary)
+		}
+	}
+
+	var (
+		m            sync.Mutex
+		wg           sync.WaitGroup
+		rec          concurrency.AllErrorRecorder
+		dependencies = make(map[string]bool)
+	)
+	for _, primary := range primaries {
+		wg.Add(1)
+		go func(primary *topo.TabletInfo) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+			defer cancel()
+
+			resp, err := s.tmc.ReadVReplicationWorkflows(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowsRequest{
+				ExcludeFrozen: true,
+			})
+			if err != nil {
+				rec.RecordError(vterrors.Wrapf(err, "failed to read the workflows on %s", topoproto.TabletAliasString(primary.Alias)))
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			for _, wf := range resp.Workflows {
+				if dependency := workflowDependency(primary.Keyspace, keyspace, wf); dependency != "" {
+					dependencies[dependency] = true
+				}
+			}
+		}(primary)
+	}
+
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	return slices.Sorted(maps.Keys(dependencies)), nil
+}
+
+// workflowDependency describes how a workflow of the target keyspace depends
+// on the keyspace, or returns an empty string if it does not.
+func workflowDependency(target string, keyspace string, wf *tabletmanagerdatapb.ReadVReplicationWorkflowResponse) string {
+	for _, stream := range wf.Streams {
+		bls := stream.Bls
+		switch {
+		case target == keyspace && bls.GetExternalCluster() != "":
+			return fmt.Sprintf("workflow %s.%s migrating from mounted cluster %s", target, wf.Workflow, bls.ExternalCluster)
+		case target == keyspace:
+			return fmt.Sprintf("workflow %s.%s", target, wf.Workflow)
+		case bls.GetKeyspace() == keyspace && bls.GetExternalCluster() == "":
+			return fmt.Sprintf("workflow %s.%s reading from it", target, wf.Workflow)
+		}
+	}
+	return ""
+}
+
+// trafficDependencies returns the serving tablets of the keyspace which ran
+// queries within the traffic window. The tablets are queried in parallel, each
+// within the remote operation timeout. The check fails for a tablet whose
+// statement digests cannot be trusted, as performance_schema or its digest
+// consumer is disabled or digests were lost because the digest table is full.
+func (s *VtctldServer) trafficDependencies(ctx context.Context, keyspace string, trafficWindow time.Duration) ([]string, error) {
+	shards, err := s.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var tablets []*topo.TabletInfo
+	for _, shard := range shards {
+		tabletMap, err := s.ts.GetTabletMapForShard(ctx, keyspace, shard)
+		if err != nil && !topo.IsErrType(err, topo.PartialResult) {
+			return nil, err
+		}
+		for _, ti := range tabletMap {
+			switch ti.Type {
+			case topodatapb.TabletType_PRIMARY, topodatapb.TabletType_REPLICA, topodatapb.TabletType_RDONLY:
+				tablets = append(tablets, ti)
+			}
+		}
+	}
+
+	var (
+		m            sync.Mutex
+		wg           sync.WaitGroup
+		rec          concurrency.AllErrorRecorder
+		dependencies []string
+	)
+	for _, ti := range tablets {
+		wg.Add(1)
+		go func(ti *topo.TabletInfo) {
+			defer wg.Done()
+
+			served, err := s.tabletServedQueries(ctx, ti, trafficWindow)
+			if err != nil {
+				rec.RecordError(err)
+				return
+			}
+			if !served {
+				return
+			}
+
+			m.Lock()
+			defer m.Unlock()
+
+			dependencies = append(dependencies, fmt.Sprintf("tablet %s served queries within the last %v", topoproto.TabletAliasString(ti.Alias), trafficWindow))
+		}(ti)
+	}
+
+	wg.Wait()
+	if rec.HasErrors() {
+		return nil, rec.Error()
+	}
+
+	slices.Sort(dependencies)
+	return dependencies, nil
+}
+
+// tabletServedQueries returns true if the tablet ran queries on its database,
+// other than those of vreplication, within the traffic window.
+func (s *VtctldServer) tabletServedQueries(ctx context.Context, ti *topo.TabletInfo, trafficWindow time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+	defer cancel()
+
+	alias := topoproto.TabletAliasString(ti.Alias)
+	query, err := selectRecentQueriesQuery(topoproto.TabletDbName(ti.Tablet), trafficWindow)
+	if err != nil {
+		return false, err
+	}
+	qr, err := s.tmc.ExecuteFetchAsDba(ctx, ti.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+		Query:   []byte(query),
+		MaxRows: 1,
+	})
+	if err != nil {
+		return false, vterrors.Wrapf(err, "failed to read the recent queries of %s", alias)
+	}
+
+	rows := sqltypes.Proto3ToResult(qr).Named().Rows
+	if len(rows) != 1 {
+		return false, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected number of rows reading the recent queries of %s: %d", alias, len(rows))
+	}
+	row := rows[0]
+	if row.AsInt64("performance_schema", 0) != 1 {
+		return false, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot check the traffic of %s: performance_schema is disabled", alias)
+	}
+	if consumer := row.AsString("digest_consumer", ""); consumer != "YES" {
+		return false, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot check the traffic of %s: the statements_digest consumer of performance_schema is disabled", alias)
+	}
+	// The status variables are strings.
+	if lost, _ := row["digests_lost"].ToCastInt64(); lost > 0 {
+		return false, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot check the traffic of %s: %d statement digests were lost; truncate performance_schema.events_statements_summary_by_digest or raise performance_schema_digests_size",
+			alias, lost)
+	}
+	return row.AsInt64("recent_queries", 0) > 0, nil
+}
@@ -39,6 +39,21 @@ const (
 	*
 	from _vt.schema_migrations where %s %s %s`
 	AllMigrationsIndicator = "all"
+
+	// selectRecentQueriesSql counts the statement digests of a database which
+	// were last seen within a number of seconds, leaving out the metadata and
+	// sidecar queries run by the tablets themselves and the copy and VDiff
+	// queries of vreplication, which carry a MAX_EXECUTION_TIME hint. It also
+	// returns whether the digests can be trusted: performance_schema and its
+	// digest consumer must be enabled, and no digest must have been lost.
+	selectRecentQueriesSql = "select @@global.performance_schema as performance_schema" +
+		", (select enabled from performance_schema.setup_consumers where name = 'statements_digest') as digest_consumer" +
+		", (select variable_value from performance_schema.global_status where variable_name = 'Performance_schema_digest_lost') as digests_lost" +
+		", (select count(*) from performance_schema.events_statements_summary_by_digest" +
+		" where schema_name = %a and last_seen >= now(6) - interval %a second" +
+		" and digest_text regexp '^(SELECT|INSERT|UPDATE|DELETE|REPLACE) '" +
+		" and digest_text not regexp 'MAX_EXECUTION_TIME'" +
+		" and digest_text not regexp 'information_schema|performance_schema|`_vt`|`mysql`') as recent_queries"
 )
 
 func alterSchemaMigrationQuery(command, uuid string) (string, error) {
@@ -48,6 +63,10 @@ func alterSchemaMigrationQuery(command, uuid string) (string, error) {
 	return sqlparser.ParseAndBind(alterSingleSchemaMigrationSql+command, sqltypes.StringBindVariable(uuid))
 }
 
+func selectRecentQueriesQuery(dbName string, window time.Duration) (string, error) {
+	return sqlparser.ParseAndBind(selectRecentQueriesSql, sqltypes.StringBindVariable(dbName), sqltypes.Int64BindVariable(int64(window.Seconds())))
+}
+
 func selectSchemaMigrationsQuery(condition, order, skipLimit string) string {
 	return fmt.Sprintf(selectSchemaMigrationsSql, condition, order, skipLimit)
 }
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"path/filepath"
 	"runtime/debug"
@@ -31,9 +32,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"vitess.io/vitess/go/event"
 	"vitess.io/vitess/go/netutil"
@@ -174,6 +177,92 @@ func (s *VtctldServer) AddCellsAlias(ctx context.Context, req *vtctldatapb.AddCe
 	return &vtctldatapb.AddCellsAliasResponse{}, nil
 }
 
+// AddTabletTags is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) AddTabletTags(ctx context.Context, req *vtctldatapb.AddTabletTagsRequest) (resp *vtctldatapb.AddTabletTagsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.AddTabletTags")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("tablet_type", topoproto.TabletTypeLString(req.TabletType))
+	span.Annotate("concurrency", req.Concurrency)
+
+	if len(req.Tags) == 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no tags to add")
+		return nil, err
+	}
+	templates, err := parseTabletTagsTemplates(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tabletsResp, err := s.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Keyspace:   req.Keyspace,
+		Shard:      req.Shard,
+		Cells:      req.Cells,
+		TabletType: req.TabletType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.changeTabletsTags(ctx, tabletsResp.Tablets, req.Concurrency, func(tablet *topodatapb.Tablet) (map[string]string, error) {
+		return renderTabletTags(templates, tablet)
+	})
+	return &vtctldatapb.AddTabletTagsResponse{Results: results}, nil
+}
+
+// ApplyRateLimitRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) ApplyRateLimitRules(ctx context.Context, req *vtctldatapb.ApplyRateLimitRulesRequest) (resp *vtctldatapb.ApplyRateLimitRulesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRateLimitRules")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("skip_rebuild", req.SkipRebuild)
+	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
+
+	if err = topotools.ValidateRateLimitRules(req.RateLimitRules); err != nil {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid rate limit rules: %v", err)
+		return nil, err
+	}
+
+	if err = s.ts.SaveRateLimitRules(ctx, req.RateLimitRules); err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.ApplyRateLimitRulesResponse{}
+
+	if req.SkipRebuild {
+		log.Warningf("Skipping rebuild of SrvVSchema, will need to run RebuildVSchemaGraph for changes to take effect")
+		return resp, nil
+	}
+
+	if err = s.ts.RebuildSrvVSchema(ctx, req.RebuildCells); err != nil {
+		err = vterrors.Wrapf(err, "RebuildSrvVSchema(%v) failed: %v", req.RebuildCells, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+const (
+	// routingRulesLockName and shardRoutingRulesLockName are the names of the
+	// topo locks which serialize the updates of the routing rules and shard
+	// routing rules. The keyspace routing rules have their own lock.
+	routingRulesLockName      = "RoutingRules"
+	shardRoutingRulesLockName = "ShardRoutingRules"
+)
+
+// errRoutingRulesChanged returns the error of an update of the rules of the
+// given kind which were changed since the update was computed.
+func errRoutingRulesChanged(kind string) error {
+	return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s were changed since they were read, not applying the new rules; review the current rules and try again", kind)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.ApplyRoutingRulesRequest) (resp *vtctldatapb.ApplyRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyRoutingRules")
@@ -184,6 +273,25 @@ func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.A
 	span.Annotate("skip_rebuild", req.SkipRebuild)
 	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
 
+	lctx, unlock, lerr := s.ts.LockName(ctx, routingRulesLockName, "ApplyRoutingRules")
+	if lerr != nil {
+		err = lerr
+		return nil, err
+	}
+	ctx = lctx
+	defer unlock(&err)
+
+	if req.ExpectedRoutingRules != nil {
+		var current *vschemapb.RoutingRules
+		if current, err = s.ts.GetRoutingRules(ctx); err != nil {
+			return nil, err
+		}
+		if !proto.Equal(current, req.ExpectedRoutingRules) {
+			err = errRoutingRulesChanged("RoutingRules")
+			return nil, err
+		}
+	}
+
 	if err = s.ts.SaveRoutingRules(ctx, req.RoutingRules); err != nil {
 		return nil, err
 	}
@@ -204,18 +312,35 @@ func (s *VtctldServer) ApplyRoutingRules(ctx context.Context, req *vtctldatapb.A
 }
 
 // ApplyShardRoutingRules is part of the vtctlservicepb.VtctldServer interface.
-func (s *VtctldServer) ApplyShardRoutingRules(ctx context.Context, req *vtctldatapb.ApplyShardRoutingRulesRequest) (*vtctldatapb.ApplyShardRoutingRulesResponse, error) {
+func (s *VtctldServer) ApplyShardRoutingRules(ctx context.Context, req *vtctldatapb.ApplyShardRoutingRulesRequest) (resp *vtctldatapb.ApplyShardRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ApplyShardRoutingRules")
 	defer span.Finish()
 
 	span.Annotate("skip_rebuild", req.SkipRebuild)
 	span.Annotate("rebuild_cells", strings.Join(req.RebuildCells, ","))
 
+	lctx, unlock, lerr := s.ts.LockName(ctx, shardRoutingRulesLockName, "ApplyShardRoutingRules")
+	if lerr != nil {
+		return nil, lerr
+	}
+	ctx = lctx
+	defer unlock(&err)
+
+	if req.ExpectedShardRoutingRules != nil {
+		current, err := s.ts.GetShardRoutingRules(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !proto.Equal(current, req.ExpectedShardRoutingRules) {
+			return nil, errRoutingRulesChanged("ShardRoutingRules")
+		}
+	}
+
 	if err := s.ts.SaveShardRoutingRules(ctx, req.ShardRoutingRules); err != nil {
 		return nil, err
 	}
 
-	resp := &vtctldatapb.ApplyShardRoutingRulesResponse{}
+	resp = &vtctldatapb.ApplyShardRoutingRulesResponse{}
 
 	if req.SkipRebuild {
 		log.Warningf("Skipping rebuild of SrvVSchema as requested, you will need to run RebuildVSchemaGraph for changes to take effect")
@@ -271,6 +396,31 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 		waitReplicasTimeout = time.Second * 30
 	}
 
+	if req.CanaryShard != "" {
+		span.Annotate("canary_shard", req.CanaryShard)
+		return s.applySchemaWithCanary(ctx, req, migrationContext, waitReplicasTimeout)
+	} else if req.CanaryAutoProceed || req.CanaryApproved {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "CanaryAutoProceed and CanaryApproved require a CanaryShard")
+		return nil, err
+	}
+
+	execResult, err := s.runApplySchema(ctx, req, migrationContext, waitReplicasTimeout, req.UuidList, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &vtctldatapb.ApplySchemaResponse{
+		UuidList:            execResult.UUIDs,
+		RowsAffectedByShard: make(map[string]uint64, len(execResult.SuccessShards)),
+	}
+	addRowsAffectedByShard(resp, execResult)
+
+	return resp, err
+}
+
+// runApplySchema applies the schema changes of the request to the shards of
+// its keyspace accepted by shardFilter, or to all of them if it is nil.
+func (s *VtctldServer) runApplySchema(ctx context.Context, req *vtctldatapb.ApplySchemaRequest, migrationContext string, waitReplicasTimeout time.Duration, uuidList []string, shardFilter func(shard string) bool) (*schemamanager.ExecuteResult, error) {
 	m := sync.RWMutex{}
 	logstream := []*logutilpb.Event{}
 	logger := logutil.NewCallbackLogger(func(e *logutilpb.Event) {
@@ -282,39 +432,33 @@ func (s *VtctldServer) ApplySchema(ctx context.Context, req *vtctldatapb.ApplySc
 
 	executor := schemamanager.NewTabletExecutor(migrationContext, s.ts, s.tmc, logger, waitReplicasTimeout, req.BatchSize, s.ws.SQLParser())
 
-	if err = executor.SetDDLStrategy(req.DdlStrategy); err != nil {
-		err = vterrors.Wrapf(err, "invalid DdlStrategy: %s", req.DdlStrategy)
-		return resp, err
+	if err := executor.SetDDLStrategy(req.DdlStrategy); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid DdlStrategy: %s", req.DdlStrategy)
 	}
 
-	if len(req.UuidList) > 0 {
-		if err = executor.SetUUIDList(req.UuidList); err != nil {
-			err = vterrors.Wrapf(err, "invalid UuidList: %s", req.UuidList)
-			return resp, err
+	if len(uuidList) > 0 {
+		if err := executor.SetUUIDList(uuidList); err != nil {
+			return nil, vterrors.Wrapf(err, "invalid UuidList: %s", uuidList)
 		}
 	}
 
-	execResult, err := schemamanager.Run(
+	if shardFilter != nil {
+		executor.SetShardFilter(shardFilter)
+	}
+
+	return schemamanager.Run(
 		ctx,
 		schemamanager.NewPlainController(req.Sql, req.Keyspace),
 		executor,
 	)
-	if err != nil {
-		return nil, err
-	}
-
-	resp = &vtctldatapb.ApplySchemaResponse{
-		UuidList:            execResult.UUIDs,
-		RowsAffectedByShard: make(map[string]uint64, len(execResult.SuccessShards)),
-	}
+}
 
+func addRowsAffectedByShard(resp *vtctldatapb.ApplySchemaResponse, execResult *schemamanager.ExecuteResult) {
 	for _, shard := range execResult.SuccessShards {
 		for _, result := range shard.Results {
 			resp.RowsAffectedByShard[shard.Shard] += result.RowsAffected
 		}
 	}
-
-	return resp, err
 }
 
 // ApplyVSchema is part of the vtctlservicepb.VtctldServer interface.
@@ -328,6 +472,13 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 	span.Annotate("cells", strings.Join(req.Cells, ","))
 	span.Annotate("skip_rebuild", req.SkipRebuild)
 	span.Annotate("dry_run", req.DryRun)
+	span.Annotate("validate_schema", req.ValidateSchema)
+	span.Annotate("canary_percent", req.CanaryPercent)
+
+	if req.CanaryPercent >= 100 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "canary percent must be between 1 and 99, got %d", req.CanaryPercent)
+		return nil, err
+	}
 
 	if _, err = s.ts.GetKeyspace(ctx, req.Keyspace); err != nil {
 		if topo.IsErrType(err, topo.NoNode) {
@@ -407,13 +558,66 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 		return response, err
 	}
 
+	if req.ValidateSchema {
+		response.SchemaErrors, err = s.validateVSchemaAgainstSchema(ctx, req.Keyspace, ksvs.Keyspace)
+		if err != nil {
+			return nil, err
+		}
+		if len(response.SchemaErrors) > 0 {
+			err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vschema does not match the schema of keyspace %s: %s", req.Keyspace, strings.Join(response.SchemaErrors, "; "))
+			return response, err
+		}
+	}
+
+	var current *topo.KeyspaceVSchemaInfo
+	if req.CanaryPercent > 0 {
+		current, err = s.ts.GetVSchema(ctx, req.Keyspace)
+		if err != nil {
+			err = vterrors.Wrapf(err, "GetVSchema(%s)", req.Keyspace)
+			return nil, err
+		}
+		if err = validateCanaryRouting(current.Keyspace, ksvs.Keyspace); err != nil {
+			return response, err
+		}
+	}
+
 	if req.DryRun { // return early if dry run
 		return response, err
 	}
 
-	if err = s.ts.SaveVSchema(ctx, ksvs); err != nil {
-		err = vterrors.Wrapf(err, "SaveVSchema(%s, %v)", req.Keyspace, req.VSchema)
-		return nil, err
+	if req.CanaryPercent > 0 {
+		// The vschema is only used for a percentage of the sessions until it
+		// is applied without a canary percent, or another vschema is saved.
+		canary := &vschemapb.VSchemaCanary{
+			Keyspace: ksvs.Keyspace,
+			Percent:  req.CanaryPercent,
+		}
+		if err = s.ts.SaveVSchemaCanary(ctx, req.Keyspace, canary); err != nil {
+			err = vterrors.Wrapf(err, "SaveVSchemaCanary(%s, %v)", req.Keyspace, canary)
+			return nil, err
+		}
+		// A vschema saved since the canary was validated may have deleted the
+		// canary before it was saved, in which case it is not valid anymore.
+		var latest *topo.KeyspaceVSchemaInfo
+		latest, err = s.ts.GetVSchema(ctx, req.Keyspace)
+		if err != nil {
+			err = vterrors.Wrapf(err, "GetVSchema(%s)", req.Keyspace)
+			return nil, err
+		}
+		if !proto.Equal(latest.Keyspace, current.Keyspace) {
+			if err = s.ts.DeleteVSchemaCanary(ctx, req.Keyspace); err != nil {
+				err = vterrors.Wrapf(err, "DeleteVSchemaCanary(%s)", req.Keyspace)
+				return nil, err
+			}
+			err = vterrors.Errorf(vtrpcpb.Code_ABORTED, "the vschema of keyspace %s changed while the canary vschema was applied", req.Keyspace)
+			return nil, err
+		}
+	} else {
+		// Saving the vschema ends the canary of the keyspace, if any.
+		if err = s.ts.SaveVSchema(ctx, ksvs); err != nil {
+			err = vterrors.Wrapf(err, "SaveVSchema(%s, %v)", req.Keyspace, req.VSchema)
+			return nil, err
+		}
 	}
 
 	if !req.SkipRebuild {
@@ -422,6 +626,9 @@ func (s *VtctldServer) ApplyVSchema(ctx context.Context, req *vtctldatapb.ApplyV
 			return nil, err
 		}
 	}
+	if req.CanaryPercent > 0 {
+		return response, nil
+	}
 	updatedVS, err := s.ts.GetVSchema(ctx, req.Keyspace)
 	if err != nil {
 		err = vterrors.Wrapf(err, "GetVSchema(%s)", req.Keyspace)
@@ -1137,6 +1344,18 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 	span.Annotate("keyspace", req.Keyspace)
 	span.Annotate("recursive", req.Recursive)
 	span.Annotate("force", req.Force)
+	span.Annotate("skip_dependency_checks", req.SkipDependencyChecks)
+
+	trafficWindow, _, err := protoutil.DurationFromProto(req.TrafficWindow)
+	if err != nil {
+		return nil, err
+	}
+	softDeleteWindow, softDelete, err := protoutil.DurationFromProto(req.SoftDeleteWindow)
+	if err != nil {
+		return nil, err
+	}
+	span.Annotate("traffic_window", trafficWindow.String())
+	span.Annotate("soft_delete_window", softDeleteWindow.String())
 
 	lctx, unlock, lerr := s.ts.LockKeyspace(ctx, req.Keyspace, "DeleteKeyspace")
 	switch {
@@ -1165,6 +1384,62 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 		}()
 	}
 
+	// A keyspace whose record is already gone can still have shards, tablets
+	// and serving graphs left over, which Force=true cleans up.
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	switch {
+	case err == nil:
+	case topo.IsErrType(err, topo.NoNode) && req.Force && !softDelete:
+		log.Warningf("keyspace %v has no record, but force=true, cleaning it up anyway ...", req.Keyspace)
+		err = nil
+	default:
+		return nil, err
+	}
+
+	if ki != nil && ki.DeletionTombstone != nil {
+		tombstone := ki.DeletionTombstone
+		if softDelete {
+			err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v is already soft-deleted", req.Keyspace)
+			return nil, err
+		}
+		if purgeAfter := protoutil.TimeFromProto(tombstone.PurgeAfter); time.Now().Before(purgeAfter) && !req.Force {
+			err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v is soft-deleted until %v; restore it with RestoreKeyspace, or use Force=true to purge it now", req.Keyspace, purgeAfter.UTC())
+			return nil, err
+		}
+	}
+
+	if !req.SkipDependencyChecks {
+		if err = s.checkKeyspaceDependencies(ctx, req.Keyspace, trafficWindow); err != nil {
+			return nil, err
+		}
+	}
+
+	cells, err := s.ts.GetKnownCells(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if softDelete {
+		now := time.Now()
+		ki.DeletionTombstone = &topodatapb.KeyspaceTombstone{
+			DeletedAt:  protoutil.TimeToProto(now),
+			PurgeAfter: protoutil.TimeToProto(now.Add(softDeleteWindow)),
+		}
+		if err = s.ts.UpdateKeyspace(ctx, ki); err != nil {
+			return nil, err
+		}
+
+		// The shards and tablets are kept for a restore, but the keyspace is
+		// no longer served.
+		for _, cell := range cells {
+			if err := s.ts.DeleteSrvKeyspace(ctx, cell, req.Keyspace); err != nil && !topo.IsErrType(err, topo.NoNode) {
+				log.Warningf("Cannot delete SrvKeyspace in cell %v for %v: %v", cell, req.Keyspace, err)
+			}
+		}
+
+		return &vtctldatapb.DeleteKeyspaceResponse{Tombstone: ki.DeletionTombstone}, nil
+	}
+
 	shards, err := s.ts.GetShardNames(ctx, req.Keyspace)
 	if err != nil {
 		return nil, err
@@ -1191,11 +1466,6 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 		}
 	}
 
-	cells, err := s.ts.GetKnownCells(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	for _, cell := range cells {
 		if err := s.ts.DeleteKeyspaceReplication(ctx, cell, req.Keyspace); err != nil && !topo.IsErrType(err, topo.NoNode) {
 			log.Warningf("Cannot delete KeyspaceReplication in cell %v for %v: %v", cell, req.Keyspace, err)
@@ -1206,9 +1476,11 @@ func (s *VtctldServer) DeleteKeyspace(ctx context.Context, req *vtctldatapb.Dele
 		}
 	}
 
-	err = s.ts.DeleteKeyspace(ctx, req.Keyspace)
-	if err != nil {
-		return nil, err
+	if ki != nil {
+		err = s.ts.DeleteKeyspace(ctx, req.Keyspace)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &vtctldatapb.DeleteKeyspaceResponse{}, nil
@@ -1659,6 +1931,72 @@ func (s *VtctldServer) GetFullStatus(ctx context.Context, req *vtctldatapb.GetFu
 	}, nil
 }
 
+// GetMysqlErrorLogEvents is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetMysqlErrorLogEvents(ctx context.Context, req *vtctldatapb.GetMysqlErrorLogEventsRequest) (resp *vtctldatapb.GetMysqlErrorLogEventsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetMysqlErrorLogEvents")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("category", req.Category)
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.tmc.GetMysqlErrorLogEvents(ctx, ti.Tablet, &tabletmanagerdatapb.GetMysqlErrorLogEventsRequest{
+		Category: req.Category,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetMysqlErrorLogEventsResponse{
+		Events:      res.Events,
+		EventCounts: res.EventCounts,
+		Enabled:     res.Enabled,
+	}, nil
+}
+
+// GetTabletQueryPlans is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetTabletQueryPlans(ctx context.Context, req *vtctldatapb.GetTabletQueryPlansRequest) (resp *vtctldatapb.GetTabletQueryPlansResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetTabletQueryPlans")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("tablet_alias", topoproto.TabletAliasString(req.TabletAlias))
+	span.Annotate("filter", req.Filter)
+	span.Annotate("evict_all", req.EvictAll)
+
+	ti, err := s.ts.GetTablet(ctx, req.TabletAlias)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.tmc.GetQueryPlans(ctx, ti.Tablet, &tabletmanagerdatapb.GetQueryPlansRequest{
+		Filter:   req.Filter,
+		Limit:    req.Limit,
+		Evict:    req.Evict,
+		Pin:      req.Pin,
+		Unpin:    req.Unpin,
+		EvictAll: req.EvictAll,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetTabletQueryPlansResponse{
+		Plans:    res.Plans,
+		Evicted:  res.Evicted,
+		Pinned:   res.Pinned,
+		Unpinned: res.Unpinned,
+	}, nil
+}
+
 // GetKeyspace is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetKeyspace(ctx context.Context, req *vtctldatapb.GetKeyspaceRequest) (resp *vtctldatapb.GetKeyspaceResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetKeyspace")
@@ -1732,6 +2070,23 @@ func (s *VtctldServer) GetPermissions(ctx context.Context, req *vtctldatapb.GetP
 	}, nil
 }
 
+// GetRateLimitRules is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) GetRateLimitRules(ctx context.Context, req *vtctldatapb.GetRateLimitRulesRequest) (resp *vtctldatapb.GetRateLimitRulesResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRateLimitRules")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	rlr, err := s.ts.GetRateLimitRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.GetRateLimitRulesResponse{
+		RateLimitRules: rlr,
+	}, nil
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) GetRoutingRules(ctx context.Context, req *vtctldatapb.GetRoutingRulesRequest) (resp *vtctldatapb.GetRoutingRulesResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.GetRoutingRules")
@@ -3051,6 +3406,22 @@ func (s *VtctldServer) LaunchSchemaMigration(ctx context.Context, req *vtctldata
 	return resp, nil
 }
 
+// LookupVindexCancel is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LookupVindexCancel(ctx context.Context, req *vtctldatapb.LookupVindexCancelRequest) (resp *vtctldatapb.LookupVindexCancelResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexCancel")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+	span.Annotate("delete_data", req.DeleteData)
+
+	resp, err = s.ws.LookupVindexCancel(ctx, req)
+	return resp, err
+}
+
 // LookupVindexComplete is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) LookupVindexComplete(ctx context.Context, req *vtctldatapb.LookupVindexCompleteRequest) (resp *vtctldatapb.LookupVindexCompleteResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexComplete")
@@ -3113,6 +3484,34 @@ func (s *VtctldServer) LookupVindexInternalize(ctx context.Context, req *vtctlda
 	return resp, err
 }
 
+// LookupVindexProgress is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LookupVindexProgress(ctx context.Context, req *vtctldatapb.LookupVindexProgressRequest) (resp *vtctldatapb.LookupVindexProgressResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexProgress")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	resp, err = s.ws.LookupVindexProgress(ctx, req)
+	return resp, err
+}
+
+// LookupVindexUpdateThrottle is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) LookupVindexUpdateThrottle(ctx context.Context, req *vtctldatapb.LookupVindexUpdateThrottleRequest) (resp *vtctldatapb.LookupVindexUpdateThrottleResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.LookupVindexUpdateThrottle")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	resp, err = s.ws.LookupVindexUpdateThrottle(ctx, req)
+	return resp, err
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) MaterializeCreate(ctx context.Context, req *vtctldatapb.MaterializeCreateRequest) (resp *vtctldatapb.MaterializeCreateResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.MaterializeCreate")
@@ -3351,6 +3750,53 @@ func (s *VtctldServer) PlannedReparentShard(ctx context.Context, req *vtctldatap
 	return resp, err
 }
 
+// PrimaryVindexCancel is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PrimaryVindexCancel(ctx context.Context, req *vtctldatapb.PrimaryVindexCancelRequest) (resp *vtctldatapb.PrimaryVindexCancelResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PrimaryVindexCancel")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("keep_data", req.KeepData)
+
+	resp, err = s.ws.PrimaryVindexCancel(ctx, req)
+	return resp, err
+}
+
+// PrimaryVindexComplete is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PrimaryVindexComplete(ctx context.Context, req *vtctldatapb.PrimaryVindexCompleteRequest) (resp *vtctldatapb.PrimaryVindexCompleteResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PrimaryVindexComplete")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	resp, err = s.ws.PrimaryVindexComplete(ctx, req)
+	return resp, err
+}
+
+// PrimaryVindexCreate is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) PrimaryVindexCreate(ctx context.Context, req *vtctldatapb.PrimaryVindexCreateRequest) (resp *vtctldatapb.PrimaryVindexCreateResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.PrimaryVindexCreate")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("table", req.Table)
+	span.Annotate("shadow_table", req.ShadowTable)
+	span.Annotate("cells", req.Cells)
+	span.Annotate("tablet_types", req.TabletTypes)
+
+	resp, err = s.ws.PrimaryVindexCreate(ctx, req)
+	return resp, err
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RebuildKeyspaceGraph(ctx context.Context, req *vtctldatapb.RebuildKeyspaceGraphRequest) (resp *vtctldatapb.RebuildKeyspaceGraphResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RebuildKeyspaceGraph")
@@ -3649,6 +4095,49 @@ func (s *VtctldServer) RemoveShardCell(ctx context.Context, req *vtctldatapb.Rem
 	return &vtctldatapb.RemoveShardCellResponse{}, nil
 }
 
+// RemoveTabletTags is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RemoveTabletTags(ctx context.Context, req *vtctldatapb.RemoveTabletTagsRequest) (resp *vtctldatapb.RemoveTabletTagsResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RemoveTabletTags")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("shard", req.Shard)
+	span.Annotate("cells", strings.Join(req.Cells, ","))
+	span.Annotate("tablet_type", topoproto.TabletTypeLString(req.TabletType))
+	span.Annotate("tags", strings.Join(req.Tags, ","))
+	span.Annotate("concurrency", req.Concurrency)
+
+	if len(req.Tags) == 0 {
+		err = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no tags to remove")
+		return nil, err
+	}
+
+	tabletsResp, err := s.GetTablets(ctx, &vtctldatapb.GetTabletsRequest{
+		Keyspace:   req.Keyspace,
+		Shard:      req.Shard,
+		Cells:      req.Cells,
+		TabletType: req.TabletType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.changeTabletsTags(ctx, tabletsResp.Tablets, req.Concurrency, func(tablet *topodatapb.Tablet) (map[string]string, error) {
+		// Only the tags the tablet has are removed, as merging tags into a
+		// tablet without any replaces them instead.
+		tags := make(map[string]string)
+		for _, key := range req.Tags {
+			if _, ok := tablet.Tags[key]; ok {
+				tags[key] = ""
+			}
+		}
+		return tags, nil
+	})
+	return &vtctldatapb.RemoveTabletTagsResponse{Results: results}, nil
+}
+
 // ReparentTablet is part of the vtctldservicepb.VtctldServer interface.
 func (s *VtctldServer) ReparentTablet(ctx context.Context, req *vtctldatapb.ReparentTabletRequest) (resp *vtctldatapb.ReparentTabletResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.ReparentTablet")
@@ -3817,6 +4306,48 @@ func (s *VtctldServer) RestoreFromBackup(req *vtctldatapb.RestoreFromBackupReque
 	}
 }
 
+// RestoreKeyspace is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) RestoreKeyspace(ctx context.Context, req *vtctldatapb.RestoreKeyspaceRequest) (resp *vtctldatapb.RestoreKeyspaceResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.RestoreKeyspace")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+
+	ctx, unlock, lerr := s.ts.LockKeyspace(ctx, req.Keyspace, "RestoreKeyspace")
+	if lerr != nil {
+		return nil, lerr
+	}
+	defer unlock(&err)
+
+	ki, err := s.ts.GetKeyspace(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if ki.DeletionTombstone == nil {
+		err = vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "keyspace %v is not soft-deleted", req.Keyspace)
+		return nil, err
+	}
+
+	ki.DeletionTombstone = nil
+	if err = s.ts.UpdateKeyspace(ctx, ki); err != nil {
+		return nil, err
+	}
+
+	if err = topotools.RebuildKeyspaceLocked(ctx, logutil.NewCallbackLogger(func(e *logutilpb.Event) {}), s.ts, req.Keyspace, nil, false); err != nil {
+		return nil, err
+	}
+
+	return &vtctldatapb.RestoreKeyspaceResponse{
+		Keyspace: &vtctldatapb.Keyspace{
+			Name:     req.Keyspace,
+			Keyspace: ki.Keyspace,
+		},
+	}, nil
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) RetrySchemaMigration(ctx context.Context, req *vtctldatapb.RetrySchemaMigrationRequest) (resp *vtctldatapb.RetrySchemaMigrationResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.RetrySchemaMigration")
@@ -5592,6 +6123,23 @@ func (s *VtctldServer) VDiffShow(ctx context.Context, req *vtctldatapb.VDiffShow
 	return resp, err
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) VDiffRepair(ctx context.Context, req *vtctldatapb.VDiffRepairRequest) (resp *vtctldatapb.VDiffRepairResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.VDiffRepair")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("shards", req.TargetShards)
+	span.Annotate("apply", req.Apply)
+
+	resp, err = s.ws.VDiffRepair(ctx, req)
+	return resp, err
+}
+
 // VDiffStop is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStopRequest) (resp *vtctldatapb.VDiffStopResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.VDiffStop")
@@ -5608,6 +6156,82 @@ func (s *VtctldServer) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStop
 	return resp, err
 }
 
+// WatchClusterEvents is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WatchClusterEvents(req *vtctldatapb.WatchClusterEventsRequest, stream vtctlservicepb.Vtctld_WatchClusterEventsServer) (err error) {
+	span, ctx := trace.NewSpan(stream.Context(), "VtctldServer.WatchClusterEvents")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("resume_token", req.ResumeToken)
+
+	// The log is created if it does not exist yet, so that it can be watched.
+	err = s.ts.UpdateClusterEventLog(ctx, func(eventLog *vtctldatapb.ClusterEventLog) error {
+		if eventLog.Id != "" {
+			return topo.NewError(topo.NoUpdateNeeded, topo.ClusterEventLogFile)
+		}
+		eventLog.Id = uuid.New().String()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	current, changes, err := s.ts.WatchClusterEventLog(ctx)
+	if err != nil {
+		return err
+	}
+
+	logID := current.Value.Id
+	last := current.Value.LastSequence
+	if req.ResumeToken != "" {
+		id, seqStr, ok := strings.Cut(req.ResumeToken, ":")
+		seq, err := strconv.ParseUint(seqStr, 10, 64)
+		if !ok || err != nil {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid resume token %q", req.ResumeToken)
+		}
+		if id != logID || seq > last {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "resume token %q is not from the current cluster event log", req.ResumeToken)
+		}
+		last = seq
+	}
+
+	eventLog := current.Value
+	for {
+		if eventLog.Id != logID {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the cluster event log was reset")
+		}
+		// The sequence numbers of the events of the log are contiguous, and
+		// the one of the last event is LastSequence.
+		first := eventLog.LastSequence - uint64(len(eventLog.Events)) + 1
+		if last+1 < first {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the events following sequence number %d are not in the cluster event log anymore", last)
+		}
+		if eventLog.LastSequence > last {
+			for _, ev := range eventLog.Events[last+1-first:] {
+				if err := stream.Send(&vtctldatapb.WatchClusterEventsResponse{Event: ev}); err != nil {
+					return err
+				}
+			}
+			last = eventLog.LastSequence
+		}
+
+		wd, ok := <-changes
+		if !ok {
+			return ctx.Err()
+		}
+		if wd.Err != nil {
+			if topo.IsErrType(wd.Err, topo.Interrupted) {
+				return ctx.Err()
+			}
+			return wd.Err
+		}
+		eventLog = wd.Value
+	}
+}
+
 // WorkflowDelete is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) WorkflowDelete(ctx context.Context, req *vtctldatapb.WorkflowDeleteRequest) (resp *vtctldatapb.WorkflowDeleteResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowDelete")
@@ -5625,6 +6249,62 @@ func (s *VtctldServer) WorkflowDelete(ctx context.Context, req *vtctldatapb.Work
 	return resp, err
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowExport(ctx context.Context, req *vtctldatapb.WorkflowExportRequest) (resp *vtctldatapb.WorkflowExportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowExport")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	resp, err = s.ws.WorkflowExport(ctx, req)
+	return resp, err
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowImport(ctx context.Context, req *vtctldatapb.WorkflowImportRequest) (resp *vtctldatapb.WorkflowImportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowImport")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.GetDefinition().GetKeyspace())
+	span.Annotate("workflow", req.GetDefinition().GetWorkflow())
+
+	resp, err = s.ws.WorkflowImport(ctx, req)
+	return resp, err
+}
+
+// WorkflowPause is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowPause(ctx context.Context, req *vtctldatapb.WorkflowPauseRequest) (resp *vtctldatapb.WorkflowPauseResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowPause")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	resp, err = s.ws.WorkflowPause(ctx, req)
+	return resp, err
+}
+
+// WorkflowResume is part of the vtctlservicepb.VtctldServer interface.
+func (s *VtctldServer) WorkflowResume(ctx context.Context, req *vtctldatapb.WorkflowResumeRequest) (resp *vtctldatapb.WorkflowResumeResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowResume")
+	defer span.Finish()
+
+	defer panicHandler(&err)
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	resp, err = s.ws.WorkflowResume(ctx, req)
+	return resp, err
+}
+
 // WorkflowStatus is part of the vtctlservicepb.VtctldServer interface.
 func (s *VtctldServer) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowStatusRequest) (resp *vtctldatapb.WorkflowStatusResponse, err error) {
 	span, ctx := trace.NewSpan(ctx, "VtctldServer.WorkflowStatus")
@@ -5844,6 +6524,9 @@ func (s *VtctldServer) ApplyKeyspaceRoutingRules(ctx context.Context, req *vtctl
 	update := func() error {
 		return topotools.UpdateKeyspaceRoutingRules(ctx, s.ts, "ApplyKeyspaceRoutingRules",
 			func(ctx context.Context, rules *map[string]string) error {
+				if req.ExpectedKeyspaceRoutingRules != nil && !maps.Equal(*rules, topotools.GetKeyspaceRoutingRulesMap(req.ExpectedKeyspaceRoutingRules)) {
+					return errRoutingRulesChanged("KeyspaceRoutingRules")
+				}
 				clear(*rules)
 				for _, rule := range req.GetKeyspaceRoutingRules().Rules {
 					(*rules)[rule.FromKeyspace] = rule.ToKeyspace
@@ -60,6 +60,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 	"vitess.io/vitess/go/vt/vttablet/tmclienttest"
 
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
 	logutilpb "vitess.io/vitess/go/vt/proto/logutil"
 	mysqlctlpb "vitess.io/vitess/go/vt/proto/mysqlctl"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -69,6 +70,7 @@ import (
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
 	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 func init() {
@@ -239,6 +241,140 @@ func TestAddCellsAlias(t *testing.T) {
 	}
 }
 
+func TestAddTabletTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1", "zone2")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		TopoServer: ts,
+		ChangeTagsResult: map[string]struct {
+			Response *tabletmanagerdatapb.ChangeTagsResponse
+			Error    error
+		}{
+			"zone2-0000000201": {Error: assert.AnError},
+		},
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, nil,
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "ks",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_PRIMARY,
+			Hostname: "host100",
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace: "ks",
+			Shard:    "-80",
+			Type:     topodatapb.TabletType_REPLICA,
+			Hostname: "host101",
+			Tags:     map[string]string{"pool": "old", "keep": "me"},
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 200},
+			Keyspace: "ks",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_REPLICA,
+			Hostname: "host200",
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone2", Uid: 201},
+			Keyspace: "ks",
+			Shard:    "80-",
+			Type:     topodatapb.TabletType_REPLICA,
+			Hostname: "host201",
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 300},
+			Keyspace: "other",
+			Shard:    "0",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+	)
+
+	_, err := vtctld.AddTabletTags(ctx, &vtctldatapb.AddTabletTagsRequest{Keyspace: "ks"})
+	assert.ErrorContains(t, err, "no tags to add")
+	_, err = vtctld.AddTabletTags(ctx, &vtctldatapb.AddTabletTagsRequest{
+		Keyspace: "ks",
+		Tags:     map[string]string{"rack": "{{.Cell"},
+	})
+	assert.ErrorContains(t, err, "invalid template for tag rack")
+
+	resp, err := vtctld.AddTabletTags(ctx, &vtctldatapb.AddTabletTagsRequest{
+		Keyspace:    "ks",
+		TabletType:  topodatapb.TabletType_REPLICA,
+		Tags:        map[string]string{"pool": "reporting", "rack": "{{.Cell}}-{{.Hostname}}-{{.Shard}}"},
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+
+	results := make(map[string]*vtctldatapb.TabletTagsResult, len(resp.Results))
+	for _, result := range resp.Results {
+		results[topoproto.TabletAliasString(result.TabletAlias)] = result
+	}
+	utils.MustMatch(t, map[string]string{"pool": "reporting", "rack": "zone1-host101--80", "keep": "me"}, results["zone1-0000000101"].AfterTags)
+	utils.MustMatch(t, map[string]string{"pool": "old", "keep": "me"}, results["zone1-0000000101"].BeforeTags)
+	utils.MustMatch(t, map[string]string{"pool": "reporting", "rack": "zone2-host200-80-"}, results["zone2-0000000200"].AfterTags)
+	assert.Empty(t, results["zone2-0000000200"].Error)
+	assert.NotEmpty(t, results["zone2-0000000201"].Error)
+
+	tablet, err := ts.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "zone2", Uid: 200})
+	require.NoError(t, err)
+	utils.MustMatch(t, map[string]string{"pool": "reporting", "rack": "zone2-host200-80-"}, tablet.Tags)
+	tablet, err = ts.GetTablet(ctx, &topodatapb.TabletAlias{Cell: "zone1", Uid: 100})
+	require.NoError(t, err)
+	assert.Empty(t, tablet.Tags)
+}
+
+func TestApplyRateLimitRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	rules := &vschemapb.RateLimitRules{
+		Rules: []*vschemapb.RateLimitRule{
+			{Keyspace: "ks", QueriesPerSecond: 100},
+			{Keyspace: "ks", Table: "t1", User: "u1", QueriesPerSecond: 10, Policy: vschemapb.RateLimitRule_QUEUE},
+		},
+	}
+	_, err := vtctld.ApplyRateLimitRules(ctx, &vtctldatapb.ApplyRateLimitRulesRequest{RateLimitRules: rules})
+	require.NoError(t, err)
+
+	resp, err := vtctld.GetRateLimitRules(ctx, &vtctldatapb.GetRateLimitRulesRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, resp.RateLimitRules)
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, srvVSchema.RateLimitRules)
+
+	// Invalid rules are not saved.
+	_, err = vtctld.ApplyRateLimitRules(ctx, &vtctldatapb.ApplyRateLimitRulesRequest{
+		RateLimitRules: &vschemapb.RateLimitRules{
+			Rules: []*vschemapb.RateLimitRule{{Keyspace: "ks"}},
+		},
+	})
+	assert.Equal(t, vtrpc.Code_INVALID_ARGUMENT, vterrors.Code(err))
+	resp, err = vtctld.GetRateLimitRules(ctx, &vtctldatapb.GetRateLimitRulesRequest{})
+	require.NoError(t, err)
+	utils.MustMatch(t, rules, resp.RateLimitRules)
+
+	// Saving no rules removes them.
+	_, err = vtctld.ApplyRateLimitRules(ctx, &vtctldatapb.ApplyRateLimitRulesRequest{RateLimitRules: &vschemapb.RateLimitRules{}})
+	require.NoError(t, err)
+	resp, err = vtctld.GetRateLimitRules(ctx, &vtctldatapb.GetRateLimitRulesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.RateLimitRules.Rules)
+}
+
 func TestApplyRoutingRules(t *testing.T) {
 	t.Parallel()
 
@@ -351,6 +487,179 @@ func TestApplyRoutingRules(t *testing.T) {
 	}
 }
 
+func TestApplyRoutingRulesExpectedRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	rr := &vschemapb.RoutingRules{Rules: []*vschemapb.RoutingRule{{FromTable: "t1", ToTables: []string{"ks1.t1"}}}}
+	_, err := vtctld.ApplyRoutingRules(ctx, &vtctldatapb.ApplyRoutingRulesRequest{
+		RoutingRules:         rr,
+		ExpectedRoutingRules: &vschemapb.RoutingRules{},
+	})
+	require.NoError(t, err)
+	_, err = vtctld.ApplyRoutingRules(ctx, &vtctldatapb.ApplyRoutingRulesRequest{
+		RoutingRules:         &vschemapb.RoutingRules{},
+		ExpectedRoutingRules: &vschemapb.RoutingRules{},
+	})
+	assert.EqualError(t, err, "the RoutingRules were changed since they were read, not applying the new rules; review the current rules and try again")
+	current, err := ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	utils.MustMatch(t, rr, current)
+
+	srr := &vschemapb.ShardRoutingRules{Rules: []*vschemapb.ShardRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks2", Shard: "-"}}}
+	_, err = vtctld.ApplyShardRoutingRules(ctx, &vtctldatapb.ApplyShardRoutingRulesRequest{
+		ShardRoutingRules:         srr,
+		ExpectedShardRoutingRules: &vschemapb.ShardRoutingRules{},
+	})
+	require.NoError(t, err)
+	_, err = vtctld.ApplyShardRoutingRules(ctx, &vtctldatapb.ApplyShardRoutingRulesRequest{
+		ShardRoutingRules:         &vschemapb.ShardRoutingRules{},
+		ExpectedShardRoutingRules: &vschemapb.ShardRoutingRules{},
+	})
+	assert.ErrorContains(t, err, "the ShardRoutingRules were changed since they were read")
+	_, err = vtctld.ApplyShardRoutingRules(ctx, &vtctldatapb.ApplyShardRoutingRulesRequest{
+		ShardRoutingRules:         &vschemapb.ShardRoutingRules{},
+		ExpectedShardRoutingRules: srr,
+	})
+	require.NoError(t, err)
+
+	krr := &vschemapb.KeyspaceRoutingRules{Rules: []*vschemapb.KeyspaceRoutingRule{{FromKeyspace: "ks1", ToKeyspace: "ks2"}}}
+	_, err = vtctld.ApplyKeyspaceRoutingRules(ctx, &vtctldatapb.ApplyKeyspaceRoutingRulesRequest{
+		KeyspaceRoutingRules:         krr,
+		ExpectedKeyspaceRoutingRules: &vschemapb.KeyspaceRoutingRules{},
+	})
+	require.NoError(t, err)
+	_, err = vtctld.ApplyKeyspaceRoutingRules(ctx, &vtctldatapb.ApplyKeyspaceRoutingRulesRequest{
+		KeyspaceRoutingRules:         &vschemapb.KeyspaceRoutingRules{},
+		ExpectedKeyspaceRoutingRules: &vschemapb.KeyspaceRoutingRules{},
+	})
+	assert.ErrorContains(t, err, "the KeyspaceRoutingRules were changed since they were read")
+
+	// Without expected rules, the rules are overwritten.
+	_, err = vtctld.ApplyRoutingRules(ctx, &vtctldatapb.ApplyRoutingRulesRequest{
+		RoutingRules: &vschemapb.RoutingRules{},
+	})
+	require.NoError(t, err)
+	current, err = ts.GetRoutingRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, current.Rules)
+}
+
+func TestApplySchemaCanary(t *testing.T) {
+	t.Parallel()
+
+	const uuid = "a0638f6b_ec7b_11ea_9bf8_000d3a9b8a9a"
+	migrations := func(shard string, status string) *querypb.QueryResult {
+		return sqltypes.ResultToProto3(sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("migration_uuid|keyspace|shard|migration_status|message", "varchar|varchar|varchar|varchar|varchar"),
+			fmt.Sprintf("%s|ks|%s|%s|boom", uuid, shard, status),
+		))
+	}
+
+	tests := []struct {
+		name          string
+		req           *vtctldatapb.ApplySchemaRequest
+		canaryResult  *querypb.QueryResult
+		expectedError string
+	}{
+		{
+			name: "approved without canary shard",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryApproved: true,
+			},
+			expectedError: "require a CanaryShard",
+		},
+		{
+			name: "auto proceed and approved",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryShard:       "-80",
+				CanaryAutoProceed: true,
+				CanaryApproved:    true,
+			},
+			expectedError: "mutually exclusive",
+		},
+		{
+			name: "unknown canary shard",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryShard: "-40",
+			},
+			expectedError: "canary shard -40 not found in keyspace ks",
+		},
+		{
+			name: "canary migration running",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryShard:    "-80",
+				CanaryApproved: true,
+				UuidList:       []string{uuid},
+			},
+			canaryResult:  migrations("-80", "running"),
+			expectedError: "migrations [" + uuid + "] are not complete on canary shard -80",
+		},
+		{
+			name: "canary migration failed",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryShard:    "-80",
+				CanaryApproved: true,
+				UuidList:       []string{uuid},
+			},
+			canaryResult:  migrations("-80", "failed"),
+			expectedError: "migration " + uuid + " is FAILED on canary shard -80: boom",
+		},
+		{
+			name: "canary migration not found",
+			req: &vtctldatapb.ApplySchemaRequest{
+				CanaryShard:    "-80",
+				CanaryApproved: true,
+				UuidList:       []string{uuid},
+			},
+			canaryResult:  sqltypes.ResultToProto3(&sqltypes.Result{}),
+			expectedError: "migration " + uuid + " not found on canary shard -80",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			ts := memorytopo.NewServer(ctx, "zone1")
+			tmc := &testutil.TabletManagerClient{
+				ExecuteFetchAsDbaResults: map[string]struct {
+					Response *querypb.QueryResult
+					Error    error
+				}{
+					"zone1-0000000100": {Response: tt.canaryResult},
+					"zone1-0000000200": {Response: migrations("80-", "queued")},
+				},
+			}
+			testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+				Keyspace: "ks",
+				Shard:    "-80",
+				Type:     topodatapb.TabletType_PRIMARY,
+			}, &topodatapb.Tablet{
+				Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+				Keyspace: "ks",
+				Shard:    "80-",
+				Type:     topodatapb.TabletType_PRIMARY,
+			})
+			vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+				return NewVtctldServer(vtenv.NewTestEnv(), ts)
+			})
+
+			tt.req.Keyspace = "ks"
+			tt.req.Sql = []string{"alter table t add column c int"}
+			_, err := vtctld.ApplySchema(ctx, tt.req)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestApplyVSchema(t *testing.T) {
 	t.Parallel()
 
@@ -676,6 +985,9 @@ func TestApplyVSchema(t *testing.T) {
 					MirrorRules: &vschemapb.MirrorRules{
 						Rules: []*vschemapb.MirrorRule{},
 					},
+					RateLimitRules: &vschemapb.RateLimitRules{
+						Rules: []*vschemapb.RateLimitRule{},
+					},
 					RoutingRules: &vschemapb.RoutingRules{
 						Rules: []*vschemapb.RoutingRule{},
 					},
@@ -689,6 +1001,202 @@ func TestApplyVSchema(t *testing.T) {
 	}
 }
 
+func TestApplyVSchemaValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		GetSchemaResults: map[string]struct {
+			Schema *tabletmanagerdatapb.SchemaDefinition
+			Error  error
+		}{
+			"zone1-0000000100": {
+				Schema: &tabletmanagerdatapb.SchemaDefinition{
+					TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+						Name:    "t1",
+						Columns: []string{"id", "name"},
+						Fields: []*querypb.Field{
+							{Name: "id", Type: querypb.Type_INT64},
+							{Name: "name", Type: querypb.Type_VARCHAR},
+						},
+					}},
+				},
+			},
+		},
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "ks",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true}, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		Keyspace: "ks",
+		Shard:    "-",
+		Type:     topodatapb.TabletType_PRIMARY,
+	})
+
+	vschema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash":   {Type: "hash"},
+			"xxhash": {Type: "xxhash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{
+					{Column: "id", Name: "hash"},
+					{Column: "name", Name: "xxhash"},
+				},
+			},
+		},
+	}
+	resp, err := vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace:       "ks",
+		VSchema:        vschema,
+		ValidateSchema: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.SchemaErrors)
+
+	vschema.Tables["t1"].ColumnVindexes = append(vschema.Tables["t1"].ColumnVindexes,
+		&vschemapb.ColumnVindex{Column: "name", Name: "hash"},
+		&vschemapb.ColumnVindex{Columns: []string{"id", "missing"}, Name: "xxhash"},
+	)
+	vschema.Tables["t2"] = &vschemapb.Table{
+		ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}},
+	}
+	resp, err = vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace:       "ks",
+		VSchema:        vschema,
+		ValidateSchema: true,
+	})
+	assert.ErrorContains(t, err, "vschema does not match the schema of keyspace ks")
+	assert.Equal(t, []string{
+		"column name of table t1 has type VARCHAR, but vindex hash of type hash requires an integral column",
+		"column missing of vindex xxhash of table t1 does not exist",
+		"table t2 does not exist",
+	}, resp.SchemaErrors)
+
+	// The invalid vschema was not applied.
+	ksvs, err := ts.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	assert.Len(t, ksvs.Tables, 1)
+}
+
+func TestApplyVSchemaCanary(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddKeyspace(ctx, t, ts, &vtctldatapb.Keyspace{
+		Name:     "ks",
+		Keyspace: &topodatapb.Keyspace{},
+	})
+
+	current := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash"}}},
+		},
+	}
+	_, err := vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace: "ks",
+		VSchema:  current,
+	})
+	require.NoError(t, err)
+
+	_, err = vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace:      "ks",
+		VSchema:       current,
+		CanaryPercent: 100,
+	})
+	assert.ErrorContains(t, err, "canary percent must be between 1 and 99")
+
+	// A canary cannot change where the rows are.
+	for _, tc := range []struct {
+		change func(ks *vschemapb.Keyspace)
+		err    string
+	}{{
+		change: func(ks *vschemapb.Keyspace) { ks.Sharded = false },
+		err:    "a canary vschema cannot change whether the keyspace is sharded",
+	}, {
+		change: func(ks *vschemapb.Keyspace) { ks.Vindexes["hash"].Type = "xxhash" },
+		err:    "a canary vschema cannot add, remove or change vindex hash",
+	}, {
+		change: func(ks *vschemapb.Keyspace) { ks.Tables["t1"].ColumnVindexes[0].Column = "id2" },
+		err:    "a canary vschema cannot add or remove table t1, or change its vindexes or routing",
+	}, {
+		change: func(ks *vschemapb.Keyspace) { ks.Tables["t2"] = &vschemapb.Table{Type: "reference"} },
+		err:    "a canary vschema cannot add or remove table t2, or change its vindexes or routing",
+	}, {
+		change: func(ks *vschemapb.Keyspace) { ks.RequireExplicitRouting = true },
+		err:    "a canary vschema cannot change the routing of the keyspace",
+	}} {
+		canary := current.CloneVT()
+		tc.change(canary)
+		_, err = vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+			Keyspace:      "ks",
+			VSchema:       canary,
+			CanaryPercent: 10,
+			DryRun:        true,
+		})
+		assert.EqualError(t, err, tc.err)
+	}
+
+	canary := current.CloneVT()
+	canary.Tables["t1"].Columns = []*vschemapb.Column{{Name: "id", Type: querypb.Type_INT64}}
+	canary.Tables["t1"].ColumnListAuthoritative = true
+	resp, err := vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace:      "ks",
+		VSchema:       canary,
+		CanaryPercent: 10,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, canary, resp.VSchema)
+
+	// The canary is served next to the current vschema.
+	srvVSchema, err := ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, current, srvVSchema.Keyspaces["ks"])
+	utils.MustMatch(t, &vschemapb.VSchemaCanary{Keyspace: canary, Percent: 10}, srvVSchema.Canaries["ks"])
+
+	// Applying the vschema without a canary percent ends the canary.
+	_, err = vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace: "ks",
+		VSchema:  canary,
+	})
+	require.NoError(t, err)
+	srvVSchema, err = ts.GetSrvVSchema(ctx, "zone1")
+	require.NoError(t, err)
+	utils.MustMatch(t, canary, srvVSchema.Keyspaces["ks"])
+	assert.Empty(t, srvVSchema.Canaries)
+
+	// So does saving any other vschema of the keyspace, e.g. by a workflow.
+	_, err = vtctld.ApplyVSchema(ctx, &vtctldatapb.ApplyVSchemaRequest{
+		Keyspace:      "ks",
+		VSchema:       current,
+		CanaryPercent: 10,
+	})
+	require.NoError(t, err)
+	ksvs, err := ts.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	require.NoError(t, ts.SaveVSchema(ctx, ksvs))
+	canaryVSchema, err := ts.GetVSchemaCanary(ctx, "ks")
+	require.NoError(t, err)
+	assert.Nil(t, canaryVSchema)
+}
+
 func TestBackup(t *testing.T) {
 	ctx := t.Context()
 	tests := []struct {
@@ -3562,6 +4070,256 @@ func TestDeleteKeyspace(t *testing.T) {
 	}
 }
 
+func TestDeleteKeyspaceDependencies(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	tmc := &testutil.TabletManagerClient{
+		ExecuteFetchAsDbaResults: map[string]struct {
+			Response *querypb.QueryResult
+			Error    error
+		}{
+			"zone1-0000000100": {Response: recentQueriesResult("1", "YES", "0", "0")},
+			"zone1-0000000101": {Response: recentQueriesResult("1", "YES", "0", "3")},
+		},
+		ReadVReplicationWorkflowsResults: map[string]struct {
+			Response *tabletmanagerdatapb.ReadVReplicationWorkflowsResponse
+			Error    error
+		}{
+			"zone1-0000000200": {Response: &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+				Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{{
+					Workflow: "wf",
+					Streams:  []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{{Bls: &binlogdatapb.BinlogSource{Keyspace: "testkeyspace"}}},
+				}, {
+					Workflow: "other",
+					Streams:  []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{{Bls: &binlogdatapb.BinlogSource{Keyspace: "thirdkeyspace"}}},
+				}},
+			}},
+		},
+	}
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, tmc, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 200},
+			Keyspace: "otherkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	)
+	require.NoError(t, ts.SaveRoutingRules(ctx, &vschemapb.RoutingRules{
+		Rules: []*vschemapb.RoutingRule{
+			{FromTable: "t1", ToTables: []string{"testkeyspace.t1"}},
+			{FromTable: "otherkeyspace@replica.t2", ToTables: []string{"otherkeyspace.t2"}},
+		},
+	}))
+	require.NoError(t, ts.SaveShardRoutingRules(ctx, &vschemapb.ShardRoutingRules{
+		Rules: []*vschemapb.ShardRoutingRule{
+			{FromKeyspace: "otherkeyspace", ToKeyspace: "testkeyspace", Shard: "-"},
+		},
+	}))
+
+	_, err := vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:      "testkeyspace",
+		Recursive:     true,
+		TrafficWindow: protoutil.DurationToProto(24 * time.Hour),
+	})
+	assert.EqualError(t, err, "keyspace testkeyspace still has dependencies: routing rule t1; shard routing rule otherkeyspace.-; "+
+		"workflow otherkeyspace.wf reading from it; tablet zone1-0000000101 served queries within the last 24h0m0s; "+
+		"remove them or use SkipDependencyChecks=true")
+
+	// Without a traffic window, the traffic is not checked.
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:  "testkeyspace",
+		Recursive: true,
+	})
+	assert.ErrorContains(t, err, "still has dependencies: routing rule t1; shard routing rule otherkeyspace.-; workflow otherkeyspace.wf reading from it; remove them")
+
+	// The traffic cannot be checked on a tablet whose statement digests
+	// cannot be trusted.
+	for _, tt := range []struct {
+		result   *querypb.QueryResult
+		expected string
+	}{
+		{recentQueriesResult("0", "YES", "0", "0"), "cannot check the traffic of zone1-0000000100: performance_schema is disabled"},
+		{recentQueriesResult("1", "NO", "0", "0"), "cannot check the traffic of zone1-0000000100: the statements_digest consumer of performance_schema is disabled"},
+		{recentQueriesResult("1", "YES", "12", "0"), "cannot check the traffic of zone1-0000000100: 12 statement digests were lost"},
+	} {
+		tmc.ExecuteFetchAsDbaResults["zone1-0000000100"] = struct {
+			Response *querypb.QueryResult
+			Error    error
+		}{Response: tt.result}
+		_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+			Keyspace:      "testkeyspace",
+			Recursive:     true,
+			TrafficWindow: protoutil.DurationToProto(24 * time.Hour),
+		})
+		assert.ErrorContains(t, err, tt.expected)
+	}
+
+	// A primary whose workflows cannot be read fails the check.
+	tmc.ReadVReplicationWorkflowsResults["zone1-0000000200"] = struct {
+		Response *tabletmanagerdatapb.ReadVReplicationWorkflowsResponse
+		Error    error
+	}{Error: assert.AnError}
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:  "testkeyspace",
+		Recursive: true,
+	})
+	assert.ErrorContains(t, err, "failed to read the workflows on zone1-0000000200")
+
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:             "testkeyspace",
+		Recursive:            true,
+		SkipDependencyChecks: true,
+	})
+	require.NoError(t, err)
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otherkeyspace"}, keyspaces)
+}
+
+// recentQueriesResult returns the result of selectRecentQueriesSql.
+func recentQueriesResult(performanceSchema string, digestConsumer string, digestsLost string, recentQueries string) *querypb.QueryResult {
+	return sqltypes.ResultToProto3(sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("performance_schema|digest_consumer|digests_lost|recent_queries", "int64|varchar|varchar|int64"),
+		strings.Join([]string{performanceSchema, digestConsumer, digestsLost, recentQueries}, "|"),
+	))
+}
+
+func TestDeleteKeyspaceWithoutRecord(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	require.NoError(t, ts.CreateKeyspace(ctx, "testkeyspace", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateShard(ctx, "testkeyspace", "-"))
+	_, err := vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	// Leave the shards and serving graph of the keyspace behind.
+	require.NoError(t, ts.DeleteKeyspace(ctx, "testkeyspace"))
+
+	req := &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:  "testkeyspace",
+		Recursive: true,
+	}
+	_, err = vtctld.DeleteKeyspace(ctx, req)
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "expected a NoNode error, got %v", err)
+
+	req.Force = true
+	_, err = vtctld.DeleteKeyspace(ctx, req)
+	require.NoError(t, err)
+	shards, err := ts.GetShardNames(ctx, "testkeyspace")
+	if err == nil {
+		assert.Empty(t, shards)
+	} else {
+		assert.True(t, topo.IsErrType(err, topo.NoNode), "expected a NoNode error, got %v", err)
+	}
+	_, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "SrvKeyspace should be deleted, got %v", err)
+}
+
+func TestDeleteKeyspaceSoftDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, &testutil.AddTabletOptions{AlsoSetShardPrimary: true},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "testkeyspace",
+			Shard:    "-",
+			Type:     topodatapb.TabletType_PRIMARY,
+		},
+	)
+	_, err := vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+
+	_, err = vtctld.RestoreKeyspace(ctx, &vtctldatapb.RestoreKeyspaceRequest{Keyspace: "testkeyspace"})
+	assert.EqualError(t, err, "keyspace testkeyspace is not soft-deleted")
+
+	// The soft-deleted keyspace keeps its shards and tablets, but is no longer
+	// served.
+	resp, err := vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:         "testkeyspace",
+		SoftDeleteWindow: protoutil.DurationToProto(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Tombstone)
+	assert.Equal(t, time.Hour, protoutil.TimeFromProto(resp.Tombstone.PurgeAfter).Sub(protoutil.TimeFromProto(resp.Tombstone.DeletedAt)))
+	ki, err := ts.GetKeyspace(ctx, "testkeyspace")
+	require.NoError(t, err)
+	utils.MustMatch(t, resp.Tombstone, ki.DeletionTombstone)
+	_, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "SrvKeyspace should be deleted, got %v", err)
+	shards, err := ts.GetShardNames(ctx, "testkeyspace")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-"}, shards)
+
+	// Rebuilding the serving graph does not serve it again.
+	_, err = vtctld.RebuildKeyspaceGraph(ctx, &vtctldatapb.RebuildKeyspaceGraphRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	_, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	assert.True(t, topo.IsErrType(err, topo.NoNode), "SrvKeyspace should not be rebuilt, got %v", err)
+
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:         "testkeyspace",
+		SoftDeleteWindow: protoutil.DurationToProto(time.Hour),
+	})
+	assert.EqualError(t, err, "keyspace testkeyspace is already soft-deleted")
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:  "testkeyspace",
+		Recursive: true,
+	})
+	assert.ErrorContains(t, err, "keyspace testkeyspace is soft-deleted until")
+
+	// The restored keyspace is served again.
+	restoreResp, err := vtctld.RestoreKeyspace(ctx, &vtctldatapb.RestoreKeyspaceRequest{Keyspace: "testkeyspace"})
+	require.NoError(t, err)
+	assert.Nil(t, restoreResp.Keyspace.Keyspace.DeletionTombstone)
+	_, err = ts.GetSrvKeyspace(ctx, "zone1", "testkeyspace")
+	require.NoError(t, err)
+
+	// Once its recovery window has passed, the keyspace is purged.
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:         "testkeyspace",
+		SoftDeleteWindow: protoutil.DurationToProto(time.Nanosecond),
+	})
+	require.NoError(t, err)
+	_, err = vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+		Keyspace:  "testkeyspace",
+		Recursive: true,
+		Force:     true,
+	})
+	require.NoError(t, err)
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, keyspaces)
+}
+
 func TestDeleteShards(t *testing.T) {
 	t.Parallel()
 
@@ -10360,6 +11118,57 @@ func TestRemoveShardCell(t *testing.T) {
 	}
 }
 
+func TestRemoveTabletTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, &testutil.TabletManagerClient{
+		TopoServer: ts,
+	}, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+
+	testutil.AddTablets(ctx, t, ts, nil,
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     topodatapb.TabletType_REPLICA,
+			Tags:     map[string]string{"pool": "reporting", "rack": "a", "keep": "me"},
+		},
+		&topodatapb.Tablet{
+			Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: 101},
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     topodatapb.TabletType_REPLICA,
+		},
+	)
+
+	_, err := vtctld.RemoveTabletTags(ctx, &vtctldatapb.RemoveTabletTagsRequest{Keyspace: "ks"})
+	assert.ErrorContains(t, err, "no tags to remove")
+
+	resp, err := vtctld.RemoveTabletTags(ctx, &vtctldatapb.RemoveTabletTagsRequest{
+		Keyspace: "ks",
+		Shard:    "0",
+		Tags:     []string{"pool", "rack"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	for _, result := range resp.Results {
+		assert.Empty(t, result.Error)
+		tablet, err := ts.GetTablet(ctx, result.TabletAlias)
+		require.NoError(t, err)
+		utils.MustMatch(t, result.AfterTags, tablet.Tags)
+		if result.TabletAlias.Uid == 100 {
+			utils.MustMatch(t, map[string]string{"keep": "me"}, tablet.Tags)
+		} else {
+			// A tablet without the tags is left as is.
+			assert.Empty(t, tablet.Tags)
+		}
+	}
+}
+
 func TestReparentTablet(t *testing.T) {
 	t.Parallel()
 
@@ -14994,6 +15803,75 @@ func TestValidateShard(t *testing.T) {
 	}
 }
 
+func TestWatchClusterEvents(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	vtctld := testutil.NewVtctldServerWithTabletManagerClient(t, ts, nil, func(ts *topo.Server) vtctlservicepb.VtctldServer {
+		return NewVtctldServer(vtenv.NewTestEnv(), ts)
+	})
+	client := localvtctldclient.New(vtctld)
+
+	// appendEvents appends n events to the log, keeping only the last 3.
+	appendEvents := func(n int) {
+		err := ts.UpdateClusterEventLog(ctx, func(eventLog *vtctldatapb.ClusterEventLog) error {
+			eventLog.Id = "log1"
+			for range n {
+				eventLog.LastSequence++
+				eventLog.Events = append(eventLog.Events, &vtctldatapb.ClusterEvent{
+					Token:    fmt.Sprintf("log1:%d", eventLog.LastSequence),
+					Type:     vtctldatapb.ClusterEvent_VSCHEMA_CHANGED,
+					Keyspace: "ks",
+				})
+			}
+			if len(eventLog.Events) > 3 {
+				eventLog.Events = eventLog.Events[len(eventLog.Events)-3:]
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	watch := func(resumeToken string) vtctlservicepb.Vtctld_WatchClusterEventsClient {
+		stream, err := client.WatchClusterEvents(ctx, &vtctldatapb.WatchClusterEventsRequest{ResumeToken: resumeToken})
+		require.NoError(t, err)
+		return stream
+	}
+	recvToken := func(stream vtctlservicepb.Vtctld_WatchClusterEventsClient) string {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		return resp.Event.Token
+	}
+
+	appendEvents(2)
+
+	// The stream starts after the event of the resume token, and sends the
+	// events appended later.
+	stream := watch("log1:1")
+	assert.Equal(t, "log1:2", recvToken(stream))
+	appendEvents(1)
+	assert.Equal(t, "log1:3", recvToken(stream))
+
+	// All the events of the log can be resumed from.
+	stream = watch("log1:0")
+	assert.Equal(t, "log1:1", recvToken(stream))
+	assert.Equal(t, "log1:2", recvToken(stream))
+	assert.Equal(t, "log1:3", recvToken(stream))
+
+	// The events following the token are not in the log anymore.
+	appendEvents(2)
+	_, err := watch("log1:1").Recv()
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err), "%v", err)
+	assert.Equal(t, "log1:3", recvToken(watch("log1:2")))
+
+	// The token is from another log.
+	_, err = watch("log0:4").Recv()
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err), "%v", err)
+	_, err = watch("log1:6").Recv()
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err), "%v", err)
+
+	_, err = watch("invalid").Recv()
+	assert.Equal(t, vtrpcpb.Code_INVALID_ARGUMENT, vterrors.Code(err), "%v", err)
+}
+
 func TestMain(m *testing.M) {
 	_flag.ParseFlagsForTest()
 	os.Exit(m.Run())
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"text/template"
+
+	"golang.org/x/sync/semaphore"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// tabletTagsTemplateData is the data the tag value templates of AddTabletTags
+// are rendered with, for each tablet.
+type tabletTagsTemplateData struct {
+	Alias    string
+	Cell     string
+	Uid      uint32
+	Keyspace string
+	Shard    string
+	Type     string
+	Hostname string
+}
+
+// parseTabletTagsTemplates parses the values of the tags as templates.
+func parseTabletTagsTemplates(tags map[string]string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template, len(tags))
+	for key, value := range tags {
+		if key == "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tag keys cannot be empty")
+		}
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid template for tag %s: %v", key, err)
+		}
+		templates[key] = tmpl
+	}
+	return templates, nil
+}
+
+// renderTabletTags renders the tag templates for the tablet.
+func renderTabletTags(templates map[string]*template.Template, tablet *topodatapb.Tablet) (map[string]string, error) {
+	data := &tabletTagsTemplateData{
+		Alias:    topoproto.TabletAliasString(tablet.Alias),
+		Cell:     tablet.Alias.GetCell(),
+		Uid:      tablet.Alias.GetUid(),
+		Keyspace: tablet.Keyspace,
+		Shard:    tablet.Shard,
+		Type:     topoproto.TabletTypeLString(tablet.Type),
+		Hostname: tablet.Hostname,
+	}
+	tags := make(map[string]string, len(templates))
+	for key, tmpl := range templates {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return nil, err
+		}
+		if sb.Len() == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tag %s renders to an empty value", key)
+		}
+		tags[key] = sb.String()
+	}
+	return tags, nil
+}
+
+// changeTabletsTags changes the tags of the tablets, at most concurrency at a
+// time if concurrency is positive, and returns the result for each tablet in
+// the order of the tablets. changeFunc returns the tags to merge into the tags
+// of a tablet, where an empty value removes the tag, or no tags if the tablet
+// must be left as is.
+func (s *VtctldServer) changeTabletsTags(ctx context.Context, tablets []*topodatapb.Tablet, concurrency int32, changeFunc func(tablet *topodatapb.Tablet) (map[string]string, error)) []*vtctldatapb.TabletTagsResult {
+	var (
+		wg   sync.WaitGroup
+		sema *semaphore.Weighted
+	)
+	if concurrency > 0 {
+		sema = semaphore.NewWeighted(int64(concurrency))
+	}
+
+	results := make([]*vtctldatapb.TabletTagsResult, len(tablets))
+	for i, tablet := range tablets {
+		result := &vtctldatapb.TabletTagsResult{
+			TabletAlias: tablet.Alias,
+			BeforeTags:  tablet.Tags,
+		}
+		results[i] = result
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if sema != nil {
+				if err := sema.Acquire(ctx, 1); err != nil {
+					result.Error = err.Error()
+					return
+				}
+				defer sema.Release(1)
+			}
+
+			tags, err := changeFunc(tablet)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			if len(tags) == 0 {
+				result.AfterTags = tablet.Tags
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, topo.RemoteOperationTimeout)
+			defer cancel()
+
+			resp, err := s.tmc.ChangeTags(ctx, tablet, tags, false)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.AfterTags = resp.Tags
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
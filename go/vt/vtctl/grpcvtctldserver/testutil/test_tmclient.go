@@ -298,6 +298,11 @@ type TabletManagerClient struct {
 	// keyed by tablet alias
 	ReadReparentJournalInfoResults map[string]int32
 	// keyed by tablet alias.
+	ReadVReplicationWorkflowsResults map[string]struct {
+		Response *tabletmanagerdatapb.ReadVReplicationWorkflowsResponse
+		Error    error
+	}
+	// keyed by tablet alias.
 	PromoteReplicaDelays map[string]time.Duration
 	// keyed by tablet alias. injects a sleep to the end of the function
 	// regardless of parent context timeout or error result.
@@ -991,6 +996,20 @@ func (fake *TabletManagerClient) ReadReparentJournalInfo(ctx context.Context, ta
 	return 0, assert.AnError
 }
 
+// ReadVReplicationWorkflows is part of the tmclient.TabletManagerClient interface.
+func (fake *TabletManagerClient) ReadVReplicationWorkflows(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.ReadVReplicationWorkflowsRequest) (*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse, error) {
+	if fake.ReadVReplicationWorkflowsResults == nil {
+		return &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{}, nil
+	}
+
+	key := topoproto.TabletAliasString(tablet.Alias)
+	if result, ok := fake.ReadVReplicationWorkflowsResults[key]; ok {
+		return result.Response, result.Error
+	}
+
+	return &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{}, nil
+}
+
 // PromoteReplica is part of the tmclient.TabletManagerClient interface.
 func (fake *TabletManagerClient) PromoteReplica(ctx context.Context, tablet *topodatapb.Tablet, semiSync bool) (string, error) {
 	if fake.PromoteReplicaResults == nil {
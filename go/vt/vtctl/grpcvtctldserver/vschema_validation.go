@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcvtctldserver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// vindexColumnTypes are the checks of the type of the column of the vindex
+// types which only support some column types.
+var vindexColumnTypes = map[string]struct {
+	check func(querypb.Type) bool
+	kind  string
+}{
+	"hash":                 {sqltypes.IsIntegral, "an integral"},
+	"numeric":              {sqltypes.IsIntegral, "an integral"},
+	"numeric_static_map":   {sqltypes.IsIntegral, "an integral"},
+	"reverse_bits":         {sqltypes.IsIntegral, "an integral"},
+	"unicode_loose_md5":    {sqltypes.IsTextOrBinary, "a text"},
+	"unicode_loose_xxhash": {sqltypes.IsTextOrBinary, "a text"},
+}
+
+// validateVSchemaAgainstSchema validates the vschema of a keyspace against the
+// schema of the primary of its first shard, and returns the mismatches.
+func (s *VtctldServer) validateVSchemaAgainstSchema(ctx context.Context, keyspace string, vschema *vschemapb.Keyspace) ([]string, error) {
+	shards, err := s.ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "GetShardNames(%s)", keyspace)
+	}
+	slices.Sort(shards)
+	for _, shard := range shards {
+		si, err := s.ts.GetShard(ctx, keyspace, shard)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "GetShard(%s/%s)", keyspace, shard)
+		}
+		if si.PrimaryAlias == nil {
+			continue
+		}
+		sd, err := schematools.GetSchema(ctx, s.ts, s.tmc, si.PrimaryAlias, &tabletmanagerdatapb.GetSchemaRequest{IncludeViews: true})
+		if err != nil {
+			return nil, err
+		}
+		return validateVSchemaTables(vschema, sd), nil
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no primary tablet in keyspace %s to validate the vschema against", keyspace)
+}
+
+// validateVSchemaTables returns the tables and columns which the vschema
+// references but are not in the schema, and the columns whose type does not
+// match their vindex.
+func validateVSchemaTables(vschema *vschemapb.Keyspace, sd *tabletmanagerdatapb.SchemaDefinition) []string {
+	tables := make(map[string]*tabletmanagerdatapb.TableDefinition, len(sd.TableDefinitions))
+	for _, td := range sd.TableDefinitions {
+		tables[strings.ToLower(td.Name)] = td
+	}
+
+	var errs []string
+	names := make([]string, 0, len(vschema.Tables))
+	for name := range vschema.Tables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		table := vschema.Tables[name]
+		td, ok := tables[strings.ToLower(name)]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("table %s does not exist", name))
+			continue
+		}
+
+		columns := make(map[string]querypb.Type, len(td.Columns))
+		for _, column := range td.Columns {
+			columns[strings.ToLower(column)] = querypb.Type_NULL_TYPE
+		}
+		for _, field := range td.Fields {
+			columns[strings.ToLower(field.Name)] = field.Type
+		}
+		checkColumn := func(column, usage string) (querypb.Type, bool) {
+			typ, ok := columns[strings.ToLower(column)]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("column %s of %s of table %s does not exist", column, usage, name))
+			}
+			return typ, ok
+		}
+
+		for _, cv := range table.ColumnVindexes {
+			usage := "vindex " + cv.Name
+			if cv.Column != "" {
+				typ, ok := checkColumn(cv.Column, usage)
+				if !ok || typ == querypb.Type_NULL_TYPE {
+					continue
+				}
+				vindex := vschema.Vindexes[cv.Name]
+				if vindex == nil {
+					continue
+				}
+				if check, ok := vindexColumnTypes[vindex.Type]; ok && !check.check(typ) {
+					errs = append(errs, fmt.Sprintf("column %s of table %s has type %s, but vindex %s of type %s requires %s column", cv.Column, name, typ, cv.Name, vindex.Type, check.kind))
+				}
+			}
+			for _, column := range cv.Columns {
+				checkColumn(column, usage)
+			}
+		}
+		if table.AutoIncrement != nil {
+			checkColumn(table.AutoIncrement.Column, "auto increment")
+		}
+		for _, column := range table.Columns {
+			checkColumn(column.Name, "the vschema")
+		}
+	}
+	return errs
+}
+
+// validateCanaryRouting checks that a canary vschema routes the rows of the
+// keyspace like its current vschema, so that the sessions which use the canary
+// and those which do not find the rows on the same shards. A canary can only
+// change the columns and the auto increment of the tables, and the foreign key
+// mode of the keyspace.
+func validateCanaryRouting(current, canary *vschemapb.Keyspace) error {
+	current, canary = routingVSchema(current), routingVSchema(canary)
+	if current.Sharded != canary.Sharded {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a canary vschema cannot change whether the keyspace is sharded")
+	}
+	for _, name := range sortedKeys(current.Vindexes, canary.Vindexes) {
+		if !proto.Equal(current.Vindexes[name], canary.Vindexes[name]) {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a canary vschema cannot add, remove or change vindex %s", name)
+		}
+	}
+	for _, name := range sortedKeys(current.Tables, canary.Tables) {
+		if !proto.Equal(current.Tables[name], canary.Tables[name]) {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a canary vschema cannot add or remove table %s, or change its vindexes or routing", name)
+		}
+	}
+	if !proto.Equal(current, canary) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a canary vschema cannot change the routing of the keyspace")
+	}
+	return nil
+}
+
+// routingVSchema returns a copy of the vschema of a keyspace without what
+// does not change the routing of its rows.
+func routingVSchema(ks *vschemapb.Keyspace) *vschemapb.Keyspace {
+	ks = ks.CloneVT()
+	if ks == nil {
+		ks = &vschemapb.Keyspace{}
+	}
+	ks.ForeignKeyMode = vschemapb.Keyspace_unspecified
+	for _, table := range ks.Tables {
+		table.Columns = nil
+		table.ColumnListAuthoritative = false
+		table.AutoIncrement = nil
+	}
+	return ks
+}
+
+// sortedKeys returns the keys of both maps, sorted.
+func sortedKeys[V any](m1, m2 map[string]V) []string {
+	keys := slices.Collect(maps.Keys(m1))
+	for key := range m2 {
+		if _, ok := m1[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
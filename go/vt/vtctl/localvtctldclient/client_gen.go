@@ -39,11 +39,21 @@ func (client *localVtctldClient) AddCellsAlias(ctx context.Context, in *vtctldat
 	return client.s.AddCellsAlias(ctx, in)
 }
 
+// AddTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) AddTabletTags(ctx context.Context, in *vtctldatapb.AddTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.AddTabletTagsResponse, error) {
+	return client.s.AddTabletTags(ctx, in)
+}
+
 // ApplyKeyspaceRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyKeyspaceRoutingRules(ctx context.Context, in *vtctldatapb.ApplyKeyspaceRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyKeyspaceRoutingRulesResponse, error) {
 	return client.s.ApplyKeyspaceRoutingRules(ctx, in)
 }
 
+// ApplyRateLimitRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) ApplyRateLimitRules(ctx context.Context, in *vtctldatapb.ApplyRateLimitRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRateLimitRulesResponse, error) {
+	return client.s.ApplyRateLimitRules(ctx, in)
+}
+
 // ApplyRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ApplyRoutingRules(ctx context.Context, in *vtctldatapb.ApplyRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.ApplyRoutingRulesResponse, error) {
 	return client.s.ApplyRoutingRules(ctx, in)
@@ -326,11 +336,26 @@ func (client *localVtctldClient) GetMirrorRules(ctx context.Context, in *vtctlda
 	return client.s.GetMirrorRules(ctx, in)
 }
 
+// GetMysqlErrorLogEvents is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetMysqlErrorLogEvents(ctx context.Context, in *vtctldatapb.GetMysqlErrorLogEventsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetMysqlErrorLogEventsResponse, error) {
+	return client.s.GetMysqlErrorLogEvents(ctx, in)
+}
+
+// GetTabletQueryPlans is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetTabletQueryPlans(ctx context.Context, in *vtctldatapb.GetTabletQueryPlansRequest, opts ...grpc.CallOption) (*vtctldatapb.GetTabletQueryPlansResponse, error) {
+	return client.s.GetTabletQueryPlans(ctx, in)
+}
+
 // GetPermissions is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetPermissions(ctx context.Context, in *vtctldatapb.GetPermissionsRequest, opts ...grpc.CallOption) (*vtctldatapb.GetPermissionsResponse, error) {
 	return client.s.GetPermissions(ctx, in)
 }
 
+// GetRateLimitRules is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) GetRateLimitRules(ctx context.Context, in *vtctldatapb.GetRateLimitRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRateLimitRulesResponse, error) {
+	return client.s.GetRateLimitRules(ctx, in)
+}
+
 // GetRoutingRules is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) GetRoutingRules(ctx context.Context, in *vtctldatapb.GetRoutingRulesRequest, opts ...grpc.CallOption) (*vtctldatapb.GetRoutingRulesResponse, error) {
 	return client.s.GetRoutingRules(ctx, in)
@@ -436,6 +461,11 @@ func (client *localVtctldClient) LaunchSchemaMigration(ctx context.Context, in *
 	return client.s.LaunchSchemaMigration(ctx, in)
 }
 
+// LookupVindexCancel is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LookupVindexCancel(ctx context.Context, in *vtctldatapb.LookupVindexCancelRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCancelResponse, error) {
+	return client.s.LookupVindexCancel(ctx, in)
+}
+
 // LookupVindexComplete is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) LookupVindexComplete(ctx context.Context, in *vtctldatapb.LookupVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexCompleteResponse, error) {
 	return client.s.LookupVindexComplete(ctx, in)
@@ -456,6 +486,16 @@ func (client *localVtctldClient) LookupVindexInternalize(ctx context.Context, in
 	return client.s.LookupVindexInternalize(ctx, in)
 }
 
+// LookupVindexProgress is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LookupVindexProgress(ctx context.Context, in *vtctldatapb.LookupVindexProgressRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexProgressResponse, error) {
+	return client.s.LookupVindexProgress(ctx, in)
+}
+
+// LookupVindexUpdateThrottle is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) LookupVindexUpdateThrottle(ctx context.Context, in *vtctldatapb.LookupVindexUpdateThrottleRequest, opts ...grpc.CallOption) (*vtctldatapb.LookupVindexUpdateThrottleResponse, error) {
+	return client.s.LookupVindexUpdateThrottle(ctx, in)
+}
+
 // MaterializeCreate is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) MaterializeCreate(ctx context.Context, in *vtctldatapb.MaterializeCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.MaterializeCreateResponse, error) {
 	return client.s.MaterializeCreate(ctx, in)
@@ -506,6 +546,21 @@ func (client *localVtctldClient) PlannedReparentShard(ctx context.Context, in *v
 	return client.s.PlannedReparentShard(ctx, in)
 }
 
+// PrimaryVindexCancel is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PrimaryVindexCancel(ctx context.Context, in *vtctldatapb.PrimaryVindexCancelRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCancelResponse, error) {
+	return client.s.PrimaryVindexCancel(ctx, in)
+}
+
+// PrimaryVindexComplete is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PrimaryVindexComplete(ctx context.Context, in *vtctldatapb.PrimaryVindexCompleteRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCompleteResponse, error) {
+	return client.s.PrimaryVindexComplete(ctx, in)
+}
+
+// PrimaryVindexCreate is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) PrimaryVindexCreate(ctx context.Context, in *vtctldatapb.PrimaryVindexCreateRequest, opts ...grpc.CallOption) (*vtctldatapb.PrimaryVindexCreateResponse, error) {
+	return client.s.PrimaryVindexCreate(ctx, in)
+}
+
 // RebuildKeyspaceGraph is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RebuildKeyspaceGraph(ctx context.Context, in *vtctldatapb.RebuildKeyspaceGraphRequest, opts ...grpc.CallOption) (*vtctldatapb.RebuildKeyspaceGraphResponse, error) {
 	return client.s.RebuildKeyspaceGraph(ctx, in)
@@ -556,6 +611,11 @@ func (client *localVtctldClient) RemoveShardCell(ctx context.Context, in *vtctld
 	return client.s.RemoveShardCell(ctx, in)
 }
 
+// RemoveTabletTags is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RemoveTabletTags(ctx context.Context, in *vtctldatapb.RemoveTabletTagsRequest, opts ...grpc.CallOption) (*vtctldatapb.RemoveTabletTagsResponse, error) {
+	return client.s.RemoveTabletTags(ctx, in)
+}
+
 // ReparentTablet is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) ReparentTablet(ctx context.Context, in *vtctldatapb.ReparentTabletRequest, opts ...grpc.CallOption) (*vtctldatapb.ReparentTabletResponse, error) {
 	return client.s.ReparentTablet(ctx, in)
@@ -617,6 +677,11 @@ func (client *localVtctldClient) RestoreFromBackup(ctx context.Context, in *vtct
 	return stream, nil
 }
 
+// RestoreKeyspace is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) RestoreKeyspace(ctx context.Context, in *vtctldatapb.RestoreKeyspaceRequest, opts ...grpc.CallOption) (*vtctldatapb.RestoreKeyspaceResponse, error) {
+	return client.s.RestoreKeyspace(ctx, in)
+}
+
 // RetrySchemaMigration is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) RetrySchemaMigration(ctx context.Context, in *vtctldatapb.RetrySchemaMigrationRequest, opts ...grpc.CallOption) (*vtctldatapb.RetrySchemaMigrationResponse, error) {
 	return client.s.RetrySchemaMigration(ctx, in)
@@ -727,6 +792,11 @@ func (client *localVtctldClient) VDiffDelete(ctx context.Context, in *vtctldatap
 	return client.s.VDiffDelete(ctx, in)
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) VDiffRepair(ctx context.Context, in *vtctldatapb.VDiffRepairRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffRepairResponse, error) {
+	return client.s.VDiffRepair(ctx, in)
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) VDiffResume(ctx context.Context, in *vtctldatapb.VDiffResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.VDiffResumeResponse, error) {
 	return client.s.VDiffResume(ctx, in)
@@ -782,6 +852,57 @@ func (client *localVtctldClient) ValidateVersionShard(ctx context.Context, in *v
 	return client.s.ValidateVersionShard(ctx, in)
 }
 
+type watchClusterEventsStreamAdapter struct {
+	*grpcshim.BidiStream
+	ch chan *vtctldatapb.WatchClusterEventsResponse
+}
+
+func (stream *watchClusterEventsStreamAdapter) Recv() (*vtctldatapb.WatchClusterEventsResponse, error) {
+	select {
+	case <-stream.Context().Done():
+		return nil, stream.Context().Err()
+	case <-stream.Closed():
+		// Stream has been closed for future sends. If there are messages that
+		// have already been sent, receive them until there are no more. After
+		// all sent messages have been received, Recv will return the CloseErr.
+		select {
+		case msg := <-stream.ch:
+			return msg, nil
+		default:
+			return nil, stream.CloseErr()
+		}
+	case err := <-stream.ErrCh:
+		return nil, err
+	case msg := <-stream.ch:
+		return msg, nil
+	}
+}
+
+func (stream *watchClusterEventsStreamAdapter) Send(msg *vtctldatapb.WatchClusterEventsResponse) error {
+	select {
+	case <-stream.Context().Done():
+		return stream.Context().Err()
+	case <-stream.Closed():
+		return grpcshim.ErrStreamClosed
+	case stream.ch <- msg:
+		return nil
+	}
+}
+
+// WatchClusterEvents is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WatchClusterEvents(ctx context.Context, in *vtctldatapb.WatchClusterEventsRequest, opts ...grpc.CallOption) (vtctlservicepb.Vtctld_WatchClusterEventsClient, error) {
+	stream := &watchClusterEventsStreamAdapter{
+		BidiStream: grpcshim.NewBidiStream(ctx),
+		ch:         make(chan *vtctldatapb.WatchClusterEventsResponse, 1),
+	}
+	go func() {
+		err := client.s.WatchClusterEvents(in, stream)
+		stream.CloseWithError(err)
+	}()
+
+	return stream, nil
+}
+
 // WorkflowAddTables is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) WorkflowAddTables(ctx context.Context, in *vtctldatapb.WorkflowAddTablesRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowAddTablesResponse, error) {
 	return client.s.WorkflowAddTables(ctx, in)
@@ -797,6 +918,26 @@ func (client *localVtctldClient) WorkflowMirrorTraffic(ctx context.Context, in *
 	return client.s.WorkflowMirrorTraffic(ctx, in)
 }
 
+// WorkflowExport is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowExport(ctx context.Context, in *vtctldatapb.WorkflowExportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowExportResponse, error) {
+	return client.s.WorkflowExport(ctx, in)
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowImport(ctx context.Context, in *vtctldatapb.WorkflowImportRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowImportResponse, error) {
+	return client.s.WorkflowImport(ctx, in)
+}
+
+// WorkflowPause is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowPause(ctx context.Context, in *vtctldatapb.WorkflowPauseRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowPauseResponse, error) {
+	return client.s.WorkflowPause(ctx, in)
+}
+
+// WorkflowResume is part of the vtctlservicepb.VtctldClient interface.
+func (client *localVtctldClient) WorkflowResume(ctx context.Context, in *vtctldatapb.WorkflowResumeRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowResumeResponse, error) {
+	return client.s.WorkflowResume(ctx, in)
+}
+
 // WorkflowStatus is part of the vtctlservicepb.VtctldClient interface.
 func (client *localVtctldClient) WorkflowStatus(ctx context.Context, in *vtctldatapb.WorkflowStatusRequest, opts ...grpc.CallOption) (*vtctldatapb.WorkflowStatusResponse, error) {
 	return client.s.WorkflowStatus(ctx, in)
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schematools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// PlannedSchemaChange is a single step in a schema change plan.
+type PlannedSchemaChange struct {
+	// Action is the DDL action, e.g. "create", "alter", "drop", "rename".
+	Action string
+	// Entity is the name of the affected table or view.
+	Entity string
+	// Statement is the canonical statement that applies the change.
+	Statement string
+	// InstantDDLCapable is true when the change is known to be eligible for ALGORITHM=INSTANT.
+	InstantDDLCapable bool
+	// RowCount and DataLength are the estimated size of the affected table, as reported by the
+	// current schema. They are zero for new entities and for views.
+	RowCount   uint64
+	DataLength uint64
+}
+
+// PlanSchemaChange computes the semantic changes that the given DDL statements would make to the
+// given current schema, without executing anything. The statements are first applied, in order, onto
+// the current schema to produce the desired schema. The returned changes are then computed as the
+// diff between the current and the desired schema, and are sorted in an order that is safe to apply.
+func PlanSchemaChange(ctx context.Context, env *schemadiff.Environment, current *tabletmanagerdatapb.SchemaDefinition, sql []string) ([]*PlannedSchemaChange, error) {
+	currentSchema, err := loadSchemaDefinition(env, current)
+	if err != nil {
+		return nil, err
+	}
+	var statements []sqlparser.Statement
+	for _, query := range sql {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		stmt, err := env.Parser().Parse(query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", query, err)
+		}
+		statements = append(statements, stmt)
+	}
+	desiredSchema, err := currentSchema.ApplyStatements(statements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply statements to current schema: %w", err)
+	}
+	hints := &schemadiff.DiffHints{TableRenameStrategy: schemadiff.TableRenameHeuristicStatement}
+	return planSchemaDiff(ctx, current, currentSchema, desiredSchema, hints)
+}
+
+// PlanDeclarativeSchemaChange computes the semantic changes that take the given current schema to
+// the desired schema, which is given as the full list of CREATE TABLE and CREATE VIEW statements
+// describing the desired end state. Entities that exist in the current schema but not in the desired
+// schema are dropped. Renames are never inferred: a renamed table is planned as a drop and a create.
+// The returned changes are sorted in an order that is safe to apply.
+func PlanDeclarativeSchemaChange(ctx context.Context, env *schemadiff.Environment, current *tabletmanagerdatapb.SchemaDefinition, desired []string) ([]*PlannedSchemaChange, error) {
+	currentSchema, err := loadSchemaDefinition(env, current)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, query := range desired {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		queries = append(queries, query)
+	}
+	desiredSchema, err := schemadiff.NewSchemaFromQueries(env, queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load desired schema: %w", err)
+	}
+	hints := &schemadiff.DiffHints{TableRenameStrategy: schemadiff.TableRenameAssumeDifferent}
+	return planSchemaDiff(ctx, current, currentSchema, desiredSchema, hints)
+}
+
+func loadSchemaDefinition(env *schemadiff.Environment, sd *tabletmanagerdatapb.SchemaDefinition) (*schemadiff.Schema, error) {
+	var queries []string
+	for _, td := range sd.GetTableDefinitions() {
+		queries = append(queries, td.Schema)
+	}
+	schema, err := schemadiff.NewSchemaFromQueries(env, queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current schema: %w", err)
+	}
+	return schema, nil
+}
+
+func planSchemaDiff(ctx context.Context, current *tabletmanagerdatapb.SchemaDefinition, currentSchema *schemadiff.Schema, desiredSchema *schemadiff.Schema, hints *schemadiff.DiffHints) ([]*PlannedSchemaChange, error) {
+	tableDefinitions := make(map[string]*tabletmanagerdatapb.TableDefinition)
+	for _, td := range current.GetTableDefinitions() {
+		tableDefinitions[td.Name] = td
+	}
+	schemaDiff, err := currentSchema.SchemaDiff(desiredSchema, hints)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := schemaDiff.OrderedDiffs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*PlannedSchemaChange, 0, len(diffs))
+	for _, diff := range diffs {
+		action, err := schemadiff.DDLActionStr(diff)
+		if err != nil {
+			return nil, err
+		}
+		change := &PlannedSchemaChange{
+			Action:            action,
+			Entity:            diff.EntityName(),
+			Statement:         diff.CanonicalStatementString(),
+			InstantDDLCapable: diff.InstantDDLCapability() == schemadiff.InstantDDLCapabilityPossible,
+		}
+		if td, ok := tableDefinitions[change.Entity]; ok {
+			change.RowCount = td.RowCount
+			change.DataLength = td.DataLength
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
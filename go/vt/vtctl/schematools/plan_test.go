@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schematools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+func TestPlanSchemaChange(t *testing.T) {
+	current := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:       "parent",
+				Schema:     "create table parent (id int primary key)",
+				RowCount:   10,
+				DataLength: 16384,
+			},
+			{
+				Name:       "t1",
+				Schema:     "create table t1 (id int primary key, name varchar(64))",
+				RowCount:   1000,
+				DataLength: 65536,
+			},
+			{
+				Name:   "v1",
+				Schema: "create view v1 as select id from t1",
+			},
+		},
+	}
+
+	tcs := []struct {
+		name      string
+		sql       []string
+		expect    []*PlannedSchemaChange
+		expectErr string
+	}{
+		{
+			name: "no changes",
+			sql:  []string{"create table if not exists t1 (id int primary key)"},
+		},
+		{
+			name: "alter table",
+			sql:  []string{"alter table t1 add column ts timestamp"},
+			expect: []*PlannedSchemaChange{
+				{
+					Action:            "alter",
+					Entity:            "t1",
+					Statement:         "ALTER TABLE `t1` ADD COLUMN `ts` timestamp NULL",
+					InstantDDLCapable: true,
+					RowCount:          1000,
+					DataLength:        65536,
+				},
+			},
+		},
+		{
+			name: "dependency ordering",
+			sql: []string{
+				"create view v2 as select id from child",
+				"create table child (id int primary key, parent_id int, key parent_id_idx (parent_id), constraint child_parent_fk foreign key (parent_id) references parent (id))",
+			},
+			expect: []*PlannedSchemaChange{
+				{
+					Action:    "create",
+					Entity:    "child",
+					Statement: "CREATE TABLE `child` (\n\t`id` int,\n\t`parent_id` int,\n\tPRIMARY KEY (`id`),\n\tKEY `parent_id_idx` (`parent_id`),\n\tCONSTRAINT `child_parent_fk` FOREIGN KEY (`parent_id`) REFERENCES `parent` (`id`)\n)",
+				},
+				{
+					Action:    "create",
+					Entity:    "v2",
+					Statement: "CREATE VIEW `v2` AS SELECT `id` FROM `child`",
+				},
+			},
+		},
+		{
+			name: "drop",
+			sql:  []string{"drop view v1", "drop table t1"},
+			expect: []*PlannedSchemaChange{
+				{
+					Action:    "drop",
+					Entity:    "v1",
+					Statement: "DROP VIEW `v1`",
+				},
+				{
+					Action:     "drop",
+					Entity:     "t1",
+					Statement:  "DROP TABLE `t1`",
+					RowCount:   1000,
+					DataLength: 65536,
+				},
+			},
+		},
+		{
+			name:      "invalid resulting schema",
+			sql:       []string{"drop table t1"},
+			expectErr: "failed to apply statements to current schema",
+		},
+		{
+			name:      "missing table",
+			sql:       []string{"alter table t9 add column i int"},
+			expectErr: "table `t9` not found",
+		},
+	}
+	env := schemadiff.NewTestEnv()
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := PlanSchemaChange(context.Background(), env, current, tc.sql)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expect == nil {
+				assert.Empty(t, changes)
+				return
+			}
+			assert.Equal(t, tc.expect, changes)
+		})
+	}
+}
+
+func TestPlanDeclarativeSchemaChange(t *testing.T) {
+	current := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:       "t1",
+				Schema:     "create table t1 (id int primary key, name varchar(64))",
+				RowCount:   1000,
+				DataLength: 65536,
+			},
+			{
+				Name:       "t2",
+				Schema:     "create table t2 (id int primary key)",
+				RowCount:   10,
+				DataLength: 16384,
+			},
+			{
+				Name:   "v1",
+				Schema: "create view v1 as select id from t1",
+			},
+		},
+	}
+
+	tcs := []struct {
+		name      string
+		desired   []string
+		expect    []*PlannedSchemaChange
+		expectErr string
+	}{
+		{
+			name: "no changes",
+			desired: []string{
+				"create table t1 (id int primary key, name varchar(64))",
+				"create table t2 (id int primary key)",
+				"create view v1 as select id from t1",
+			},
+		},
+		{
+			name: "alter, create and drop",
+			desired: []string{
+				"create view v2 as select id from t3",
+				"create table t1 (id int primary key, name varchar(64), ts timestamp)",
+				"create table t3 (id int primary key)",
+				"create view v1 as select id from t1",
+			},
+			expect: []*PlannedSchemaChange{
+				{
+					Action:     "drop",
+					Entity:     "t2",
+					Statement:  "DROP TABLE `t2`",
+					RowCount:   10,
+					DataLength: 16384,
+				},
+				{
+					Action:            "alter",
+					Entity:            "t1",
+					Statement:         "ALTER TABLE `t1` ADD COLUMN `ts` timestamp NULL",
+					InstantDDLCapable: true,
+					RowCount:          1000,
+					DataLength:        65536,
+				},
+				{
+					Action:    "create",
+					Entity:    "t3",
+					Statement: "CREATE TABLE `t3` (\n\t`id` int,\n\tPRIMARY KEY (`id`)\n)",
+				},
+				{
+					Action:    "create",
+					Entity:    "v2",
+					Statement: "CREATE VIEW `v2` AS SELECT `id` FROM `t3`",
+				},
+			},
+		},
+		{
+			name:      "non create statement",
+			desired:   []string{"alter table t1 add column i int"},
+			expectErr: "failed to load desired schema",
+		},
+		{
+			name:      "invalid desired schema",
+			desired:   []string{"create view v1 as select id from t9"},
+			expectErr: "failed to load desired schema",
+		},
+	}
+	env := schemadiff.NewTestEnv()
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := PlanDeclarativeSchemaChange(context.Background(), env, current, tc.desired)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expect == nil {
+				assert.Empty(t, changes)
+				return
+			}
+			assert.Equal(t, tc.expect, changes)
+		})
+	}
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shardhealth aggregates the full status of the tablets of one or
+// more shards, and computes a health score for each shard along with the
+// anomalies which lowered it.
+package shardhealth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// MaxScore is the score of a shard without any anomaly.
+const MaxScore = 100
+
+// Severity is the severity of an anomaly.
+type Severity string
+
+const (
+	// SeverityCritical is the severity of the anomalies which put the
+	// availability or the consistency of the shard at risk.
+	SeverityCritical Severity = "CRITICAL"
+	// SeverityWarning is the severity of the anomalies which should be looked
+	// at, but do not put the shard at risk on their own.
+	SeverityWarning Severity = "WARNING"
+)
+
+// penalties are the points removed from the score of a shard for each of its
+// anomalies, per severity.
+var penalties = map[Severity]int{
+	SeverityCritical: 40,
+	SeverityWarning:  10,
+}
+
+// Options are the thresholds used to detect anomalies.
+type Options struct {
+	// MaxReplicationLag is the replication lag above which a replica is
+	// reported as lagging, on top of its configured SQL delay. Zero disables
+	// the check.
+	MaxReplicationLag time.Duration
+}
+
+// TabletStatus is the full status of a tablet, or the error returned while
+// fetching it.
+type TabletStatus struct {
+	Tablet *topodatapb.Tablet
+	Status *replicationdatapb.FullStatus
+	Err    error
+}
+
+// Anomaly is a problem found in a shard.
+type Anomaly struct {
+	Severity Severity `json:"severity"`
+	// Tablet is the alias of the tablet the anomaly was found on, and is
+	// empty for the anomalies of the shard as a whole.
+	Tablet  string `json:"tablet,omitempty"`
+	Message string `json:"message"`
+}
+
+// TabletSummary is the subset of the full status of a tablet which is
+// reported alongside the health of its shard.
+type TabletSummary struct {
+	Tablet                string `json:"tablet"`
+	Type                  string `json:"type"`
+	Version               string `json:"version,omitempty"`
+	Position              string `json:"position,omitempty"`
+	ReplicationLagSeconds uint32 `json:"replication_lag_seconds,omitempty"`
+	IOThread              string `json:"io_thread,omitempty"`
+	SQLThread             string `json:"sql_thread,omitempty"`
+	SemiSync              bool   `json:"semi_sync"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// ShardHealth is the health of a shard.
+type ShardHealth struct {
+	Keyspace  string          `json:"keyspace"`
+	Shard     string          `json:"shard"`
+	Score     int             `json:"score"`
+	Tablets   []TabletSummary `json:"tablets"`
+	Anomalies []Anomaly       `json:"anomalies,omitempty"`
+}
+
+// Name returns the keyspace/shard name of the shard.
+func (h *ShardHealth) Name() string {
+	return topoproto.KeyspaceShardString(h.Keyspace, h.Shard)
+}
+
+// Evaluate groups the statuses by shard and returns the health of each
+// shard, sorted by keyspace and shard name.
+func Evaluate(statuses []TabletStatus, opts Options) []*ShardHealth {
+	byShard := make(map[string][]TabletStatus)
+	for _, status := range statuses {
+		name := topoproto.KeyspaceShardString(status.Tablet.Keyspace, status.Tablet.Shard)
+		byShard[name] = append(byShard[name], status)
+	}
+
+	results := make([]*ShardHealth, 0, len(byShard))
+	for _, shardStatuses := range byShard {
+		results = append(results, evaluateShard(shardStatuses, opts))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Keyspace != results[j].Keyspace {
+			return results[i].Keyspace < results[j].Keyspace
+		}
+		return results[i].Shard < results[j].Shard
+	})
+	return results
+}
+
+// evaluator accumulates the anomalies of a single shard.
+type evaluator struct {
+	health *ShardHealth
+	opts   Options
+}
+
+func (e *evaluator) report(severity Severity, tablet *topodatapb.Tablet, format string, args ...any) {
+	anomaly := Anomaly{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if tablet != nil {
+		anomaly.Tablet = topoproto.TabletAliasString(tablet.Alias)
+	}
+	e.health.Anomalies = append(e.health.Anomalies, anomaly)
+}
+
+// evaluateShard computes the health of a shard from the statuses of all its
+// tablets.
+func evaluateShard(statuses []TabletStatus, opts Options) *ShardHealth {
+	sort.Slice(statuses, func(i, j int) bool {
+		return topoproto.TabletAliasString(statuses[i].Tablet.Alias) < topoproto.TabletAliasString(statuses[j].Tablet.Alias)
+	})
+
+	e := &evaluator{
+		health: &ShardHealth{
+			Keyspace: statuses[0].Tablet.Keyspace,
+			Shard:    statuses[0].Tablet.Shard,
+			Tablets:  make([]TabletSummary, 0, len(statuses)),
+		},
+		opts: opts,
+	}
+	for _, status := range statuses {
+		e.health.Tablets = append(e.health.Tablets, summarize(status))
+	}
+
+	var primaries []TabletStatus
+	for _, status := range statuses {
+		if status.Tablet.Type == topodatapb.TabletType_PRIMARY {
+			primaries = append(primaries, status)
+		}
+	}
+	var primary *TabletStatus
+	switch len(primaries) {
+	case 0:
+		e.report(SeverityCritical, nil, "the shard has no primary tablet")
+	case 1:
+		primary = &primaries[0]
+	default:
+		aliases := make([]string, 0, len(primaries))
+		for _, p := range primaries {
+			aliases = append(aliases, topoproto.TabletAliasString(p.Tablet.Alias))
+		}
+		e.report(SeverityCritical, nil, "the shard has %d primary tablets: %s", len(primaries), strings.Join(aliases, ", "))
+	}
+
+	e.checkConsistency(statuses)
+	if primary != nil {
+		e.checkPrimary(*primary)
+	}
+	for _, status := range statuses {
+		if status.Tablet.Type == topodatapb.TabletType_PRIMARY {
+			continue
+		}
+		e.checkReplica(status, primary)
+	}
+
+	score := MaxScore
+	for _, anomaly := range e.health.Anomalies {
+		score -= penalties[anomaly.Severity]
+	}
+	e.health.Score = max(score, 0)
+	return e.health
+}
+
+// checkConsistency reports the settings which differ between the reachable
+// tablets of the shard.
+func (e *evaluator) checkConsistency(statuses []TabletStatus) {
+	settings := []struct {
+		name  string
+		value func(*replicationdatapb.FullStatus) string
+	}{
+		{"MySQL version", func(s *replicationdatapb.FullStatus) string { return s.Version }},
+		{"gtid_mode", func(s *replicationdatapb.FullStatus) string { return s.GtidMode }},
+		{"binlog_format", func(s *replicationdatapb.FullStatus) string { return s.BinlogFormat }},
+		{"binlog_row_image", func(s *replicationdatapb.FullStatus) string { return s.BinlogRowImage }},
+	}
+	for _, setting := range settings {
+		counts := make(map[string]int)
+		for _, status := range statuses {
+			if status.Status == nil {
+				continue
+			}
+			counts[setting.value(status.Status)]++
+		}
+		if len(counts) < 2 {
+			continue
+		}
+		values := make([]string, 0, len(counts))
+		for value, count := range counts {
+			values = append(values, fmt.Sprintf("%q (%d)", value, count))
+		}
+		sort.Strings(values)
+		e.report(SeverityWarning, nil, "the tablets have different values of %s: %s", setting.name, strings.Join(values, ", "))
+	}
+}
+
+func (e *evaluator) checkPrimary(primary TabletStatus) {
+	if primary.Err != nil {
+		e.report(SeverityCritical, primary.Tablet, "unable to get the full status of the primary: %v", primary.Err)
+		return
+	}
+	status := primary.Status
+	if status.DiskStalled {
+		e.report(SeverityCritical, primary.Tablet, "the disk of the primary is stalled")
+	}
+	if status.ReadOnly || status.SuperReadOnly {
+		e.report(SeverityCritical, primary.Tablet, "the primary is read-only")
+	}
+	if status.SemiSyncBlocked {
+		e.report(SeverityCritical, primary.Tablet, "the primary is blocked waiting for semi-sync acknowledgements")
+	}
+	if status.SemiSyncPrimaryEnabled {
+		if !status.SemiSyncPrimaryStatus {
+			e.report(SeverityWarning, primary.Tablet, "semi-sync is enabled on the primary but is not active")
+		}
+		if status.SemiSyncPrimaryClients < status.SemiSyncWaitForReplicaCount {
+			e.report(SeverityWarning, primary.Tablet, "the primary has %d semi-sync replicas connected, but waits for %d acknowledgements",
+				status.SemiSyncPrimaryClients, status.SemiSyncWaitForReplicaCount)
+		}
+	}
+}
+
+func (e *evaluator) checkReplica(replica TabletStatus, primary *TabletStatus) {
+	// Only the tablets which serve from replication are expected to be
+	// replicating; backup, restore and drained tablets may have stopped it.
+	replicating := replica.Tablet.Type == topodatapb.TabletType_REPLICA || replica.Tablet.Type == topodatapb.TabletType_RDONLY
+	if replica.Err != nil {
+		e.report(SeverityWarning, replica.Tablet, "unable to get the full status: %v", replica.Err)
+		return
+	}
+	status := replica.Status
+	if status.DiskStalled {
+		e.report(SeverityWarning, replica.Tablet, "the disk is stalled")
+	}
+	if !replicating {
+		return
+	}
+
+	repl := status.ReplicationStatus
+	if repl == nil {
+		e.report(SeverityCritical, replica.Tablet, "replication is not configured")
+		return
+	}
+	ioState, sqlState := replication.ReplicationState(repl.IoState), replication.ReplicationState(repl.SqlState)
+	if ioState != replication.ReplicationStateRunning || sqlState != replication.ReplicationStateRunning {
+		msg := fmt.Sprintf("replication is not running (IO thread: %s, SQL thread: %s)", stateName(ioState), stateName(sqlState))
+		if repl.LastIoError != "" {
+			msg += fmt.Sprintf(", last IO error: %s", repl.LastIoError)
+		}
+		if repl.LastSqlError != "" {
+			msg += fmt.Sprintf(", last SQL error: %s", repl.LastSqlError)
+		}
+		e.report(SeverityCritical, replica.Tablet, "%s", msg)
+	}
+
+	switch {
+	case repl.ReplicationLagUnknown:
+		e.report(SeverityWarning, replica.Tablet, "the replication lag is unknown")
+	case e.opts.MaxReplicationLag > 0:
+		lag := time.Duration(repl.ReplicationLagSeconds) * time.Second
+		if lag > time.Duration(repl.SqlDelay)*time.Second+e.opts.MaxReplicationLag {
+			e.report(SeverityWarning, replica.Tablet, "the replication lag of %v is above %v", lag, e.opts.MaxReplicationLag)
+		}
+	}
+
+	if primary == nil || primary.Status == nil {
+		return
+	}
+	primaryStatus := primary.Status
+	primaryAlias := topoproto.TabletAliasString(primary.Tablet.Alias)
+	if repl.SourceUuid != "" && primaryStatus.ServerUuid != "" && repl.SourceUuid != primaryStatus.ServerUuid {
+		e.report(SeverityCritical, replica.Tablet, "replicating from %s instead of the primary %s (%s)", repl.SourceUuid, primaryAlias, primaryStatus.ServerUuid)
+	}
+	if replica.Tablet.Type == topodatapb.TabletType_REPLICA && primaryStatus.SemiSyncPrimaryEnabled && !status.SemiSyncReplicaEnabled {
+		e.report(SeverityWarning, replica.Tablet, "semi-sync is enabled on the primary but not on the replica")
+	}
+	if errant := errantGTIDs(repl.Position, primaryStatus); errant != "" {
+		e.report(SeverityCritical, replica.Tablet, "errant GTIDs not found on the primary %s: %s", primaryAlias, errant)
+	}
+}
+
+// errantGTIDs returns the GTIDs executed on a replica which are missing from
+// the primary, ignoring the ones of the primary itself. It returns an empty
+// string when the positions cannot be compared.
+func errantGTIDs(replicaPosition string, primaryStatus *replicationdatapb.FullStatus) string {
+	if primaryStatus.PrimaryStatus == nil || primaryStatus.ServerUuid == "" {
+		return ""
+	}
+	replicaPos, err := replication.DecodePosition(replicaPosition)
+	if err != nil {
+		return ""
+	}
+	primaryPos, err := replication.DecodePosition(primaryStatus.PrimaryStatus.Position)
+	if err != nil {
+		return ""
+	}
+	primarySID, err := replication.ParseSID(primaryStatus.ServerUuid)
+	if err != nil {
+		return ""
+	}
+	errant, err := replication.ErrantGTIDsOnReplica(replicaPos, primaryPos, primarySID)
+	if err != nil {
+		return ""
+	}
+	return errant
+}
+
+func summarize(status TabletStatus) TabletSummary {
+	summary := TabletSummary{
+		Tablet: topoproto.TabletAliasString(status.Tablet.Alias),
+		Type:   topoproto.TabletTypeLString(status.Tablet.Type),
+	}
+	if status.Err != nil {
+		summary.Error = status.Err.Error()
+		return summary
+	}
+	s := status.Status
+	summary.Version = s.Version
+	if s.PrimaryStatus != nil {
+		summary.Position = s.PrimaryStatus.Position
+	}
+	if repl := s.ReplicationStatus; repl != nil {
+		if summary.Position == "" {
+			summary.Position = repl.Position
+		}
+		summary.ReplicationLagSeconds = repl.ReplicationLagSeconds
+		summary.IOThread = stateName(replication.ReplicationState(repl.IoState))
+		summary.SQLThread = stateName(replication.ReplicationState(repl.SqlState))
+	}
+	if status.Tablet.Type == topodatapb.TabletType_PRIMARY {
+		summary.SemiSync = s.SemiSyncPrimaryEnabled
+	} else {
+		summary.SemiSync = s.SemiSyncReplicaEnabled
+	}
+	return summary
+}
+
+func stateName(state replication.ReplicationState) string {
+	switch state {
+	case replication.ReplicationStateStopped:
+		return "Stopped"
+	case replication.ReplicationStateConnecting:
+		return "Connecting"
+	case replication.ReplicationStateRunning:
+		return "Running"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shardhealth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+
+	replicationdatapb "vitess.io/vitess/go/vt/proto/replicationdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	primaryUUID = "00000000-0000-0000-0000-000000000100"
+	replicaUUID = "00000000-0000-0000-0000-000000000101"
+)
+
+func tablet(uid uint32, shard string, tabletType topodatapb.TabletType) *topodatapb.Tablet {
+	return &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "zone1", Uid: uid},
+		Keyspace: "ks",
+		Shard:    shard,
+		Type:     tabletType,
+	}
+}
+
+func primaryStatus() *replicationdatapb.FullStatus {
+	return &replicationdatapb.FullStatus{
+		ServerUuid: primaryUUID,
+		Version:    "8.0.40",
+		GtidMode:   "ON",
+		PrimaryStatus: &replicationdatapb.PrimaryStatus{
+			Position: "MySQL56/" + primaryUUID + ":1-100",
+		},
+		SemiSyncPrimaryEnabled:      true,
+		SemiSyncPrimaryStatus:       true,
+		SemiSyncPrimaryClients:      1,
+		SemiSyncWaitForReplicaCount: 1,
+	}
+}
+
+func replicaStatus() *replicationdatapb.FullStatus {
+	return &replicationdatapb.FullStatus{
+		ServerUuid: replicaUUID,
+		Version:    "8.0.40",
+		GtidMode:   "ON",
+		ReadOnly:   true,
+		ReplicationStatus: &replicationdatapb.Status{
+			Position:   "MySQL56/" + primaryUUID + ":1-98",
+			SourceUuid: primaryUUID,
+			IoState:    int32(replication.ReplicationStateRunning),
+			SqlState:   int32(replication.ReplicationStateRunning),
+		},
+		SemiSyncReplicaEnabled: true,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	opts := Options{MaxReplicationLag: 10 * time.Second}
+	tcases := []struct {
+		name          string
+		mutate        func(primary, replica *TabletStatus)
+		wantScore     int
+		wantAnomalies []Anomaly
+	}{
+		{
+			name:      "healthy",
+			mutate:    func(primary, replica *TabletStatus) {},
+			wantScore: MaxScore,
+		},
+		{
+			name: "read-only primary",
+			mutate: func(primary, replica *TabletStatus) {
+				primary.Status.SuperReadOnly = true
+			},
+			wantScore:     60,
+			wantAnomalies: []Anomaly{{SeverityCritical, "zone1-0000000100", "the primary is read-only"}},
+		},
+		{
+			name: "unreachable primary",
+			mutate: func(primary, replica *TabletStatus) {
+				primary.Status, primary.Err = nil, errors.New("connection refused")
+			},
+			wantScore:     60,
+			wantAnomalies: []Anomaly{{SeverityCritical, "zone1-0000000100", "unable to get the full status of the primary: connection refused"}},
+		},
+		{
+			name: "stopped replication",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Status.ReplicationStatus.SqlState = int32(replication.ReplicationStateStopped)
+				replica.Status.ReplicationStatus.LastSqlError = "duplicate key"
+			},
+			wantScore: 60,
+			wantAnomalies: []Anomaly{{SeverityCritical, "zone1-0000000101",
+				"replication is not running (IO thread: Running, SQL thread: Stopped), last SQL error: duplicate key"}},
+		},
+		{
+			name: "lagging replica",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Status.ReplicationStatus.ReplicationLagSeconds = 30
+			},
+			wantScore:     90,
+			wantAnomalies: []Anomaly{{SeverityWarning, "zone1-0000000101", "the replication lag of 30s is above 10s"}},
+		},
+		{
+			name: "delayed replica",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Status.ReplicationStatus.ReplicationLagSeconds = 3600
+				replica.Status.ReplicationStatus.SqlDelay = 3600
+			},
+			wantScore: MaxScore,
+		},
+		{
+			name: "errant GTIDs",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Status.ReplicationStatus.Position = "MySQL56/" + primaryUUID + ":1-98," + replicaUUID + ":1-2"
+			},
+			wantScore:     60,
+			wantAnomalies: []Anomaly{{SeverityCritical, "zone1-0000000101", "errant GTIDs not found on the primary zone1-0000000100: " + replicaUUID + ":1-2"}},
+		},
+		{
+			name: "semi-sync replicas missing",
+			mutate: func(primary, replica *TabletStatus) {
+				primary.Status.SemiSyncPrimaryClients = 0
+				primary.Status.SemiSyncPrimaryStatus = false
+				replica.Status.SemiSyncReplicaEnabled = false
+			},
+			wantScore: 70,
+			wantAnomalies: []Anomaly{
+				{SeverityWarning, "zone1-0000000100", "semi-sync is enabled on the primary but is not active"},
+				{SeverityWarning, "zone1-0000000100", "the primary has 0 semi-sync replicas connected, but waits for 1 acknowledgements"},
+				{SeverityWarning, "zone1-0000000101", "semi-sync is enabled on the primary but not on the replica"},
+			},
+		},
+		{
+			name: "version mismatch",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Status.Version = "8.4.3"
+			},
+			wantScore:     90,
+			wantAnomalies: []Anomaly{{SeverityWarning, "", `the tablets have different values of MySQL version: "8.0.40" (1), "8.4.3" (1)`}},
+		},
+		{
+			name: "no primary",
+			mutate: func(primary, replica *TabletStatus) {
+				primary.Tablet.Type = topodatapb.TabletType_REPLICA
+				primary.Status = replicaStatus()
+			},
+			wantScore:     60,
+			wantAnomalies: []Anomaly{{SeverityCritical, "", "the shard has no primary tablet"}},
+		},
+		{
+			name: "backup tablet",
+			mutate: func(primary, replica *TabletStatus) {
+				replica.Tablet.Type = topodatapb.TabletType_BACKUP
+				replica.Status.ReplicationStatus.IoState = int32(replication.ReplicationStateStopped)
+				replica.Status.ReplicationStatus.SqlState = int32(replication.ReplicationStateStopped)
+			},
+			wantScore: MaxScore,
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			primary := TabletStatus{Tablet: tablet(100, "0", topodatapb.TabletType_PRIMARY), Status: primaryStatus()}
+			replica := TabletStatus{Tablet: tablet(101, "0", topodatapb.TabletType_REPLICA), Status: replicaStatus()}
+			tcase.mutate(&primary, &replica)
+
+			results := Evaluate([]TabletStatus{replica, primary}, opts)
+			require.Len(t, results, 1)
+			assert.Equal(t, "ks/0", results[0].Name())
+			assert.Equal(t, tcase.wantScore, results[0].Score)
+			assert.Equal(t, tcase.wantAnomalies, results[0].Anomalies)
+			require.Len(t, results[0].Tablets, 2)
+			assert.Equal(t, "zone1-0000000100", results[0].Tablets[0].Tablet)
+		})
+	}
+}
+
+func TestEvaluateShards(t *testing.T) {
+	statuses := []TabletStatus{
+		{Tablet: tablet(200, "80-", topodatapb.TabletType_REPLICA), Err: errors.New("timeout")},
+		{Tablet: tablet(100, "-80", topodatapb.TabletType_PRIMARY), Status: primaryStatus()},
+		{Tablet: tablet(101, "-80", topodatapb.TabletType_REPLICA), Status: replicaStatus()},
+	}
+	statuses[2].Status.ReplicationStatus.ReplicationLagUnknown = true
+
+	results := Evaluate(statuses, Options{})
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "ks/-80", results[0].Name())
+	assert.Equal(t, 90, results[0].Score)
+	assert.Equal(t, []Anomaly{{SeverityWarning, "zone1-0000000101", "the replication lag is unknown"}}, results[0].Anomalies)
+
+	assert.Equal(t, "ks/80-", results[1].Name())
+	assert.Equal(t, 50, results[1].Score)
+	assert.Equal(t, []Anomaly{
+		{SeverityCritical, "", "the shard has no primary tablet"},
+		{SeverityWarning, "zone1-0000000200", "unable to get the full status: timeout"},
+	}, results[1].Anomalies)
+	assert.Equal(t, []TabletSummary{{Tablet: "zone1-0000000200", Type: "replica", Error: "timeout"}}, results[1].Tablets)
+}
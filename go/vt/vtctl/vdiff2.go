@@ -64,10 +64,14 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 
 	autoRetry := subFlags.Bool("auto-retry", true, "Should this vdiff automatically retry and continue in case of recoverable errors")
 	checksum := subFlags.Bool("checksum", false, "Use row-level checksums to compare, not yet implemented")
-	samplePct := subFlags.Int64("sample_pct", 100, "How many rows to sample, not yet implemented")
+	samplePct := subFlags.Int64("sample-percent", 100, "Only diff a deterministic sample of this percentage of the ranges of each table's first primary key column, which must be an integral column, and report an estimate of the mismatched rows for the whole table")
+	subFlags.Int64Var(samplePct, "sample_pct", 100, "")
+	subFlags.MarkDeprecated("sample_pct", "use --sample-percent instead")
 	verbose := subFlags.Bool("verbose", false, "Show verbose vdiff output in summaries")
 	wait := subFlags.Bool("wait", false, "When creating or resuming a vdiff, wait for it to finish before exiting")
 	waitUpdateInterval := subFlags.Duration("wait-update-interval", time.Duration(1*time.Minute), "When waiting on a vdiff to finish, check and display the current status this often")
+	restart := subFlags.Bool("restart", false, "When resuming a vdiff, discard the progress that was checkpointed for its tables and diff them from the start, rather than continuing where it left off")
+	apply := subFlags.Bool("apply", false, "When repairing a vdiff, execute the corrective statements on the target shards rather than only reporting them")
 	updateTableStats := subFlags.Bool("update-table-stats", false, "Update the table statistics, using ANALYZE TABLE, on each table involved in the VDiff during initialization. This will ensure that progress estimates are as accurate as possible -- but it does involve locks and can potentially impact query processing on the target keyspace.")
 
 	if err := subFlags.Parse(args); err != nil {
@@ -96,11 +100,23 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 	if action == "" {
 		return fmt.Errorf("invalid action '%s'; %s", subFlags.Arg(1), usage)
 	}
+	if *restart {
+		if action != vdiff.ResumeAction {
+			return fmt.Errorf("--restart can only be used with the %s action", vdiff.ResumeAction)
+		}
+		action = vdiff.RestartAction
+	}
+	if *apply && action != vdiff.RepairAction {
+		return fmt.Errorf("--apply can only be used with the %s action", vdiff.RepairAction)
+	}
 	keyspace, workflowName, err := splitKeyspaceWorkflow(subFlags.Arg(0))
 	if err != nil {
 		return err
 	}
 
+	if *samplePct <= 0 || *samplePct > 100 {
+		return fmt.Errorf("invalid --sample-percent value (%d), it needs to be between 1 and 100", *samplePct)
+	}
 	if *maxRows <= 0 {
 		return fmt.Errorf("invalid --limit value (%d), maximum number of rows to compare needs to be greater than 0", *maxRows)
 	}
@@ -149,11 +165,17 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 				return fmt.Errorf("can only show a specific vdiff, please provide a valid UUID; view all with: VDiff -- %s.%s show all", keyspace, workflowName)
 			}
 		}
-	case vdiff.StopAction, vdiff.ResumeAction:
+	case vdiff.StopAction, vdiff.ResumeAction, vdiff.RestartAction, vdiff.RepairAction:
 		vdiffUUID, err = uuid.Parse(actionArg)
 		if err != nil {
 			return fmt.Errorf("can only %s a specific vdiff, please provide a valid UUID; view all with: VDiff -- %s.%s show all", action, keyspace, workflowName)
 		}
+		if action == vdiff.RepairAction {
+			actionArg = vdiff.RepairDryRunActionArg
+			if *apply {
+				actionArg = vdiff.RepairApplyActionArg
+			}
+		}
 	case vdiff.DeleteAction:
 		switch actionArg {
 		case vdiff.AllActionArg:
@@ -174,7 +196,7 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 	}
 
 	switch action {
-	case vdiff.CreateAction, vdiff.ResumeAction:
+	case vdiff.CreateAction, vdiff.ResumeAction, vdiff.RestartAction:
 		if *wait {
 			tkr := time.NewTicker(*waitUpdateInterval)
 			defer tkr.Stop()
@@ -213,6 +235,10 @@ func commandVDiff2(ctx context.Context, wr *wrangler.Wrangler, subFlags *pflag.F
 			uuidToDisplay = vdiffUUID.String()
 		}
 		displayVDiff2ActionStatusResponse(wr, format, uuidToDisplay, action, vdiff.CompletedState)
+	case vdiff.RepairAction:
+		if err := displayVDiff2RepairResponse(wr, format, output); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid action %s; %s", action, usage)
 	}
@@ -232,7 +258,11 @@ type vdiffTableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
-	LastUpdated     string `json:"LastUpdated,omitempty"`
+	// SamplePercent is set when only a sample of the table was diffed, in
+	// which case EstimatedMismatchedRows is an estimate for the whole table.
+	SamplePercent           int64  `json:"SamplePercent,omitempty"`
+	EstimatedMismatchedRows int64  `json:"EstimatedMismatchedRows,omitempty"`
+	LastUpdated             string `json:"LastUpdated,omitempty"`
 }
 type vdiffSummary struct {
 	Workflow, Keyspace string
@@ -271,6 +301,7 @@ Table {{$table.TableName}}:
 {{if $table.MismatchedRows}}	MismatchedRows:   {{$table.MismatchedRows}}{{end}}
 {{if $table.ExtraRowsSource}}	ExtraRowsSource:  {{$table.ExtraRowsSource}}{{end}}
 {{if $table.ExtraRowsTarget}}	ExtraRowsTarget:  {{$table.ExtraRowsTarget}}{{end}}
+{{if $table.SamplePercent}}	SamplePercent:    {{$table.SamplePercent}}%% (EstimatedMismatchedRows: {{$table.EstimatedMismatchedRows}}){{end}}
 {{end}}
  
 Use "--format=json" for more detailed output.
@@ -560,6 +591,10 @@ func buildVDiff2SingleSummary(wr *wrangler.Wrangler, keyspace, workflow, uuid st
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						if dr.SamplePercent > 0 {
+							ts.SamplePercent = dr.SamplePercent
+							ts.EstimatedMismatchedRows += dr.EstimatedMismatchedRows
+						}
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -631,7 +666,7 @@ func displayVDiff2ScheduledResponse(wr *wrangler.Wrangler, format, uuid string,
 		wr.Logger().Printf(string(jsonText) + "\n")
 	} else {
 		addtlMsg := ""
-		if typ == vdiff.ResumeAction {
+		if typ == vdiff.ResumeAction || typ == vdiff.RestartAction {
 			addtlMsg = "to resume "
 		}
 		msg := fmt.Sprintf("VDiff %s scheduled %son target shards, use show to view progress\n", uuid, addtlMsg)
@@ -655,6 +690,51 @@ func displayVDiff2ActionStatusResponse(wr *wrangler.Wrangler, format, uuid strin
 	}
 }
 
+// vdiffRepairStatement is a statement that repairs a row of a target shard, as reported
+// by the repair action.
+type vdiffRepairStatement struct {
+	Shard, Table, Operation, Statement, Status string
+}
+
+func displayVDiff2RepairResponse(wr *wrangler.Wrangler, format string, output *wrangler.VDiffOutput) error {
+	var stmts []*vdiffRepairStatement
+	for shard, resp := range output.Responses {
+		if resp == nil || resp.Output == nil {
+			continue
+		}
+		for _, row := range sqltypes.Proto3ToResult(resp.Output).Named().Rows {
+			stmts = append(stmts, &vdiffRepairStatement{
+				Shard:     shard,
+				Table:     row.AsString("table_name", ""),
+				Operation: row.AsString("operation", ""),
+				Statement: row.AsString("statement", ""),
+				Status:    row.AsString("status", ""),
+			})
+		}
+	}
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return stmts[i].Shard < stmts[j].Shard
+	})
+	if format == "json" {
+		jsonText, err := json.MarshalIndent(stmts, "", "\t")
+		if err != nil {
+			return err
+		}
+		wr.Logger().Printf("%s\n", jsonText)
+		return nil
+	}
+	if len(stmts) == 0 {
+		wr.Logger().Printf("VDiff %s found no rows to repair on target shards\n", output.Request.VdiffUuid)
+		return nil
+	}
+	rows := [][]string{getStructFieldNames(vdiffRepairStatement{})}
+	for _, stmt := range stmts {
+		rows = append(rows, []string{stmt.Shard, stmt.Table, stmt.Operation, stmt.Statement, stmt.Status})
+	}
+	wr.Logger().Printf("%s\n", gotabulate.Create(rows).Render("grid"))
+	return nil
+}
+
 func buildProgressReport(summary *vdiffSummary, rowsToCompare int64) {
 	report := &vdiff.ProgressReport{}
 	if summary.RowsCompared >= 1 {
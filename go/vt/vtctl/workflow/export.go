@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// WorkflowExport is part of the vtctlservicepb.VtctldServer interface.
+// It reads the definition of the workflow, and the state of its streams,
+// from the primaries of the target shards.
+func (s *Server) WorkflowExport(ctx context.Context, req *vtctldatapb.WorkflowExportRequest) (*vtctldatapb.WorkflowExportResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowExport")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	shards, err := s.ts.FindAllShardsInKeyspace(ctx, req.Keyspace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	workflows := make(map[string]*tabletmanagerdatapb.ReadVReplicationWorkflowResponse, len(shards))
+	if err := forAllShards(slices.Collect(maps.Values(shards)), func(si *topo.ShardInfo) error {
+		if si.PrimaryAlias == nil {
+			return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s currently has no PRIMARY tablet", req.Keyspace, si.ShardName())
+		}
+		primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return err
+		}
+		wf, err := s.tmc.ReadVReplicationWorkflow(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: req.Workflow,
+		})
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to read the %s workflow on shard %s/%s", req.Workflow, req.Keyspace, si.ShardName())
+		}
+		if wf == nil {
+			// The workflow has no streams on this shard.
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		workflows[si.ShardName()] = wf
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(workflows) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "workflow %s not found in keyspace %s", req.Workflow, req.Keyspace)
+	}
+
+	shardNames := slices.Sorted(maps.Keys(workflows))
+	// The settings of the workflow are the same on all the shards.
+	wf := workflows[shardNames[0]]
+	def := &vtctldatapb.WorkflowDefinition{
+		Workflow:                  req.Workflow,
+		Keyspace:                  req.Keyspace,
+		WorkflowType:              wf.WorkflowType,
+		WorkflowSubType:           wf.WorkflowSubType,
+		TabletTypes:               wf.TabletTypes,
+		TabletSelectionPreference: wf.TabletSelectionPreference,
+		DeferSecondaryKeys:        wf.DeferSecondaryKeys,
+		Options:                   wf.Options,
+	}
+	for cell := range strings.SplitSeq(wf.Cells, ",") {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			def.Cells = append(def.Cells, cell)
+		}
+	}
+	for _, shard := range shardNames {
+		streams := workflows[shard].Streams
+		sort.Slice(streams, func(i, j int) bool {
+			return streams[i].Id < streams[j].Id
+		})
+		for _, stream := range streams {
+			def.Streams = append(def.Streams, &vtctldatapb.WorkflowDefinition_Stream{
+				Shard:        shard,
+				Bls:          stream.Bls,
+				State:        stream.State,
+				Position:     stream.Pos,
+				StopPosition: stream.StopPos,
+				Message:      stream.Message,
+				RowsCopied:   stream.RowsCopied,
+				TimeUpdated:  stream.TimeUpdated,
+			})
+		}
+	}
+	return &vtctldatapb.WorkflowExportResponse{Definition: def}, nil
+}
+
+// WorkflowImport is part of the vtctlservicepb.VtctldServer interface.
+// It creates the streams of the workflow definition on the primaries of
+// their target shards. The streams start over from the copy phase, as the
+// positions they were at when the workflow was exported may not exist on
+// this cluster. The tables and the routing rules of the workflow are not
+// part of its definition, so they must already exist.
+func (s *Server) WorkflowImport(ctx context.Context, req *vtctldatapb.WorkflowImportRequest) (resp *vtctldatapb.WorkflowImportResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowImport")
+	defer span.Finish()
+
+	def := req.GetDefinition()
+	if def.GetWorkflow() == "" || def.GetKeyspace() == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the workflow definition needs a workflow and a keyspace")
+	}
+	if len(def.Streams) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the definition of the %s workflow has no streams", def.Workflow)
+	}
+	span.Annotate("keyspace", def.Keyspace)
+	span.Annotate("workflow", def.Workflow)
+	span.Annotate("auto_start", req.AutoStart)
+
+	lockName := fmt.Sprintf("%s/%s", def.Keyspace, def.Workflow)
+	ctx, workflowUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowImport")
+	if lockErr != nil {
+		return nil, vterrors.Wrapf(lockErr, "failed to lock the %s workflow", lockName)
+	}
+	defer workflowUnlock(&err)
+
+	if err := validateNewWorkflow(ctx, s.ts, s.tmc, def.Keyspace, def.Workflow); err != nil {
+		return nil, err
+	}
+
+	// All the target shards are checked before any stream is created.
+	var targets []*topo.ShardInfo
+	sources := make(map[string][]*vtctldatapb.WorkflowDefinition_Stream)
+	for _, stream := range def.Streams {
+		if stream.Bls == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a stream of the %s workflow on shard %s has no binlog source", def.Workflow, stream.Shard)
+		}
+		if _, ok := sources[stream.Shard]; !ok {
+			si, err := s.ts.GetShard(ctx, def.Keyspace, stream.Shard)
+			if err != nil {
+				return nil, err
+			}
+			if si.PrimaryAlias == nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s currently has no PRIMARY tablet", def.Keyspace, stream.Shard)
+			}
+			targets = append(targets, si)
+		}
+		sources[stream.Shard] = append(sources[stream.Shard], stream)
+	}
+
+	resp = &vtctldatapb.WorkflowImportResponse{}
+	var mu sync.Mutex
+	if err := forAllShards(targets, func(si *topo.ShardInfo) error {
+		primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return err
+		}
+		tabletReq := &tabletmanagerdatapb.CreateVReplicationWorkflowRequest{
+			Workflow:                  def.Workflow,
+			Cells:                     def.Cells,
+			TabletTypes:               def.TabletTypes,
+			TabletSelectionPreference: def.TabletSelectionPreference,
+			WorkflowType:              def.WorkflowType,
+			WorkflowSubType:           def.WorkflowSubType,
+			DeferSecondaryKeys:        def.DeferSecondaryKeys,
+			AutoStart:                 req.AutoStart,
+			Options:                   def.Options,
+		}
+		for _, stream := range sources[si.ShardName()] {
+			tabletReq.BinlogSource = append(tabletReq.BinlogSource, stream.Bls)
+		}
+		if _, err := s.tmc.CreateVReplicationWorkflow(ctx, primary.Tablet, tabletReq); err != nil {
+			return vterrors.Wrapf(err, "failed to create the streams of the %s workflow on shard %s/%s", def.Workflow, def.Keyspace, si.ShardName())
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		resp.Details = append(resp.Details, &vtctldatapb.WorkflowImportResponse_TabletInfo{
+			Tablet:  primary.Alias,
+			Streams: int32(len(tabletReq.BinlogSource)),
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(resp.Details, func(i, j int) bool {
+		return resp.Details[i].Tablet.Uid < resp.Details[j].Tablet.Uid
+	})
+	resp.Summary = fmt.Sprintf("Successfully imported the %s workflow with %d streams in the %s keyspace", def.Workflow, len(def.Streams), def.Keyspace)
+	return resp, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestWorkflowExportImport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	sourceKeyspace := &testKeyspace{KeyspaceName: "sourceks", ShardNames: []string{"0"}}
+	targetKeyspace := &testKeyspace{KeyspaceName: "targetks", ShardNames: []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	resp, err := env.ws.WorkflowExport(ctx, &vtctldatapb.WorkflowExportRequest{
+		Keyspace: targetKeyspace.KeyspaceName,
+		Workflow: workflowName,
+	})
+	require.NoError(t, err)
+	def := resp.Definition
+	assert.Equal(t, workflowName, def.Workflow)
+	assert.Equal(t, targetKeyspace.KeyspaceName, def.Keyspace)
+	assert.Equal(t, binlogdatapb.VReplicationWorkflowType_MoveTables, def.WorkflowType)
+	// Each target shard streams from the source shard.
+	require.Len(t, def.Streams, 2)
+	for i, stream := range def.Streams {
+		assert.Equal(t, targetKeyspace.ShardNames[i], stream.Shard)
+		assert.Equal(t, sourceKeyspace.KeyspaceName, stream.Bls.Keyspace)
+		assert.Equal(t, "0", stream.Bls.Shard)
+	}
+
+	// The streams of each shard are created on its primary.
+	for _, stream := range def.Streams {
+		tablet := env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]
+		if stream.Shard == "80-" {
+			tablet = env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID+tabletUIDStep]
+		}
+		env.tmc.expectCreateVReplicationWorkflowRequest(tablet.Alias.Uid, &createVReplicationWorkflowRequestResponse{
+			req: &tabletmanagerdatapb.CreateVReplicationWorkflowRequest{
+				Workflow:     workflowName,
+				BinlogSource: []*binlogdatapb.BinlogSource{stream.Bls},
+				WorkflowType: binlogdatapb.VReplicationWorkflowType_MoveTables,
+				AutoStart:    true,
+				TabletTypes:  def.TabletTypes,
+				Cells:        def.Cells,
+				Options:      def.Options,
+			},
+		})
+	}
+	importResp, err := env.ws.WorkflowImport(ctx, &vtctldatapb.WorkflowImportRequest{
+		Definition: def,
+		AutoStart:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Successfully imported the wf1 workflow with 2 streams in the targetks keyspace", importResp.Summary)
+	require.Len(t, importResp.Details, 2)
+	for _, details := range importResp.Details {
+		assert.EqualValues(t, 1, details.Streams)
+	}
+}
+
+func TestWorkflowImportErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{KeyspaceName: "sourceks", ShardNames: []string{"0"}}
+	targetKeyspace := &testKeyspace{KeyspaceName: "targetks", ShardNames: []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	bls := &binlogdatapb.BinlogSource{Keyspace: sourceKeyspace.KeyspaceName, Shard: "0"}
+	testcases := []struct {
+		name     string
+		def      *vtctldatapb.WorkflowDefinition
+		wantErr  string
+		wantCode vtrpcpb.Code
+	}{
+		{
+			name:     "no workflow",
+			def:      &vtctldatapb.WorkflowDefinition{Keyspace: targetKeyspace.KeyspaceName},
+			wantErr:  "the workflow definition needs a workflow and a keyspace",
+			wantCode: vtrpcpb.Code_INVALID_ARGUMENT,
+		},
+		{
+			name:     "no streams",
+			def:      &vtctldatapb.WorkflowDefinition{Workflow: "wf1", Keyspace: targetKeyspace.KeyspaceName},
+			wantErr:  "the definition of the wf1 workflow has no streams",
+			wantCode: vtrpcpb.Code_INVALID_ARGUMENT,
+		},
+		{
+			name: "no binlog source",
+			def: &vtctldatapb.WorkflowDefinition{
+				Workflow: "wf1",
+				Keyspace: targetKeyspace.KeyspaceName,
+				Streams:  []*vtctldatapb.WorkflowDefinition_Stream{{Shard: "-80"}},
+			},
+			wantErr:  "a stream of the wf1 workflow on shard -80 has no binlog source",
+			wantCode: vtrpcpb.Code_INVALID_ARGUMENT,
+		},
+		{
+			name: "unknown shard",
+			def: &vtctldatapb.WorkflowDefinition{
+				Workflow: "wf1",
+				Keyspace: targetKeyspace.KeyspaceName,
+				Streams:  []*vtctldatapb.WorkflowDefinition_Stream{{Shard: "-40", Bls: bls}},
+			},
+			wantErr: "node doesn't exist",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := env.ws.WorkflowImport(ctx, &vtctldatapb.WorkflowImportRequest{Definition: tc.def})
+			require.ErrorContains(t, err, tc.wantErr)
+			if tc.wantCode != vtrpcpb.Code_OK {
+				assert.Equal(t, tc.wantCode, vterrors.Code(err))
+			}
+		})
+	}
+}
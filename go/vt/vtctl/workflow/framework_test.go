@@ -278,6 +278,7 @@ type testTMClient struct {
 	updateVReplicationWorklowRequests  map[uint32][]*updateVReplicationWorkflowRequestResponse
 	applySchemaRequests                map[uint32][]*applySchemaRequestResponse
 	primaryPositions                   map[uint32]string
+	deleteTableDataRequests            map[uint32][]*tabletmanagerdatapb.DeleteTableDataRequest
 	vdiffRequests                      map[uint32]*vdiffRequestResponse
 	refreshStateErrors                 map[uint32]error
 
@@ -307,6 +308,7 @@ func newTestTMClient(env *testEnv) *testTMClient {
 		applySchemaRequests:                make(map[uint32][]*applySchemaRequestResponse),
 		readVReplicationWorkflowsResponses: make(map[string][]*tabletmanagerdatapb.ReadVReplicationWorkflowsResponse),
 		primaryPositions:                   make(map[uint32]string),
+		deleteTableDataRequests:            make(map[uint32][]*tabletmanagerdatapb.DeleteTableDataRequest),
 		vdiffRequests:                      make(map[uint32]*vdiffRequestResponse),
 		refreshStateErrors:                 make(map[uint32]error),
 		env:                                env,
@@ -388,7 +390,11 @@ func (tmc *testTMClient) ReadVReplicationWorkflow(ctx context.Context, tablet *t
 }
 
 func (tmc *testTMClient) DeleteTableData(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.DeleteTableDataRequest) (response *tabletmanagerdatapb.DeleteTableDataResponse, err error) {
-	return &tabletmanagerdatapb.DeleteTableDataResponse{}, nil
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+	tmc.deleteTableDataRequests[tablet.Alias.Uid] = append(tmc.deleteTableDataRequests[tablet.Alias.Uid], req)
+	// Pretend that each table had 10 rows to delete.
+	return &tabletmanagerdatapb.DeleteTableDataResponse{RowsDeleted: uint64(10 * len(req.TableFilters))}, nil
 }
 
 func (tmc *testTMClient) DeleteVReplicationWorkflow(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.DeleteVReplicationWorkflowRequest) (response *tabletmanagerdatapb.DeleteVReplicationWorkflowResponse, err error) {
@@ -92,6 +92,9 @@ func (lv *lookupVindex) prepareCreate(ctx context.Context, workflow, keyspace st
 	}
 
 	targetVSchemaChanged := false
+	// The workflow keeps running after the copy phase if any vindex has
+	// covering columns, which it keeps in sync.
+	hasCoveringCols := false
 
 	// If we are about to backfill multiple vindexes, we should validate if
 	// all the vindexes are owned, as creating a backfilling workflow with a
@@ -215,6 +218,9 @@ func (lv *lookupVindex) prepareCreate(ctx context.Context, workflow, keyspace st
 
 		// Generate vreplication query.
 		materializeQuery = generateMaterializeQuery(vInfo, vindex, sourceVindexColumns)
+		if len(vInfo.coveringCols) > 0 {
+			hasCoveringCols = true
+		}
 
 		// Update targetVSchema.
 		targetTable := specs.Tables[vInfo.targetTableName]
@@ -280,7 +286,7 @@ func (lv *lookupVindex) prepareCreate(ctx context.Context, workflow, keyspace st
 		MaterializationIntent: vtctldatapb.MaterializationIntent_CREATELOOKUPINDEX,
 		SourceKeyspace:        keyspace,
 		TargetKeyspace:        targetKeyspace,
-		StopAfterCopy:         !continueAfterCopyWithOwner,
+		StopAfterCopy:         !continueAfterCopyWithOwner && !hasCoveringCols,
 		TableSettings:         tableSettings,
 	}
 
@@ -295,6 +301,9 @@ type vindexInfo struct {
 	fromCols        []string
 	toCol           string
 	ignoreNulls     bool
+	// coveringCols are the columns of the source table also stored in the
+	// lookup table, which the workflow keeps in sync.
+	coveringCols []string
 
 	// sourceTable is the supplied table info.
 	sourceTable     *vschemapb.Table
@@ -325,6 +334,19 @@ func (lv *lookupVindex) validateAndGetVindexInfo(vindexName string, vindex *vsch
 	}
 
 	vindexToCol := vindex.Params["to"]
+
+	var coveringCols []string
+	if coveringColsStr, ok := vindex.Params["covering_columns"]; ok {
+		// The rows of a consistent lookup table can be orphaned, so only the
+		// lookup_unique vindexes can answer selects from their lookup table.
+		if !strings.EqualFold(vindex.Type, "lookup_unique") {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "covering_columns is only supported by lookup_unique vindexes, not %s", vindex.Type)
+		}
+		coveringCols = strings.Split(coveringColsStr, ",")
+		for i, col := range coveringCols {
+			coveringCols[i] = strings.TrimSpace(col)
+		}
+	}
 	// Make the vindex write_only. If one exists already in the vschema,
 	// it will need to match this vindex exactly, including the write_only setting.
 	vindex.Params["write_only"] = "true"
@@ -361,6 +383,7 @@ func (lv *lookupVindex) validateAndGetVindexInfo(vindexName string, vindex *vsch
 		fromCols:        vindexFromCols,
 		toCol:           vindexToCol,
 		ignoreNulls:     ignoreNulls,
+		coveringCols:    coveringCols,
 	}, nil
 }
 
@@ -448,6 +471,13 @@ func (lv *lookupVindex) generateCreateDDLStatement(tableSchema *tabletmanagerdat
 	} else {
 		modified = append(modified, fmt.Sprintf("  %s %s,", sqlescape.EscapeID(vInfo.toCol), sqlescape.EscapeID(vindex.Params["data_type"])))
 	}
+	for _, col := range vInfo.coveringCols {
+		line, err := generateCoveringColDef(lines, col)
+		if err != nil {
+			return "", err
+		}
+		modified = append(modified, line)
+	}
 
 	buf := sqlparser.NewTrackedBuffer(nil)
 	fmt.Fprintf(buf, "  PRIMARY KEY (")
@@ -477,6 +507,11 @@ func generateMaterializeQuery(vInfo *vindexInfo, vindex *vschemapb.Vindex, sourc
 	for i := range vInfo.fromCols {
 		buf.Myprintf("%s as %s, ", sqlparser.String(sqlparser.NewIdentifierCI(sourceVindexColumns[i])), sqlparser.String(sqlparser.NewIdentifierCI(vInfo.fromCols[i])))
 	}
+	// The covering columns are not grouped, so that their changes are
+	// applied to the existing rows of the lookup table.
+	for _, col := range vInfo.coveringCols {
+		buf.Myprintf("%s as %s, ", sqlparser.String(sqlparser.NewIdentifierCI(col)), sqlparser.String(sqlparser.NewIdentifierCI(col)))
+	}
 	if strings.EqualFold(vInfo.toCol, "keyspace_id") || strings.EqualFold(vindex.Type, "consistent_lookup_unique") || strings.EqualFold(vindex.Type, "consistent_lookup") {
 		buf.Myprintf("keyspace_id() as %s ", sqlparser.String(sqlparser.NewIdentifierCI(vInfo.toCol)))
 	} else {
@@ -590,6 +625,17 @@ func generateColDef(lines []string, sourceVindexCol, vindexFromCol string) (stri
 	return "", fmt.Errorf("column %s not found in schema %v", sourceVindexCol, lines)
 }
 
+// generateCoveringColDef returns the definition of a covering column in the
+// lookup table. It must be nullable, as the lookup rows are created by vtgate
+// without it, and it is set to NULL when the source row is deleted.
+func generateCoveringColDef(lines []string, col string) (string, error) {
+	line, err := generateColDef(lines, col, col)
+	if err != nil {
+		return "", err
+	}
+	return strings.Replace(line, " NOT NULL", "", 1), nil
+}
+
 // getTargetVindex returns the targetVindex. We choose a primary vindex type
 // for the lookup table based on the source definition if one was not explicitly specified.
 func getTargetVindex(sourceTableDefinition *tabletmanagerdatapb.TableDefinition, sourceVindexColumn string, targetTable *vschemapb.Table) (
@@ -779,6 +825,18 @@ func (lv *lookupVindex) getVindexesAndVSchema(ctx context.Context, keyspace stri
 	return vindexByName, vschema, nil
 }
 
+// hasCoveringColumns returns true if any of the vindexes has covering
+// columns, which the workflow backfilling them keeps in sync, so it must not
+// be stopped or deleted.
+func hasCoveringColumns(vindexByName map[string]*vschemapb.Vindex) bool {
+	for _, vindex := range vindexByName {
+		if vindex.Params["covering_columns"] != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // IsBackfillingOwnedVindexes returns if the VReplication workflow is
 // backfilling owned lookup vindexes. Also, returns error in case the
 // workflow backfills a mix of owned and unowned vindexes.
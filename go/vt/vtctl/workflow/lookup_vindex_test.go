@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/ptr"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/textutil"
+	"vitess.io/vitess/go/vt/topo"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+const lookupVindexFilter = "select c1 as c1, keyspace_id() as keyspace_id from t1 group by c1, keyspace_id"
+
+func lookupVindexSourceVSchema(writeOnly bool) *vschemapb.Keyspace {
+	params := map[string]string{
+		"table": "targetks.lookup",
+		"from":  "c1",
+		"to":    "keyspace_id",
+	}
+	if writeOnly {
+		params["write_only"] = "true"
+	}
+	return &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash": {Type: "xxhash"},
+			"lookup": {Type: "consistent_lookup_unique", Params: params, Owner: "t1"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{
+					{Name: "xxhash", Column: "id"},
+					{Name: "lookup", Column: "c1"},
+				},
+			},
+		},
+	}
+}
+
+func TestLookupVindexCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+	workflowResponse := &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+		Workflow:     "lookup",
+		WorkflowType: binlogdatapb.VReplicationWorkflowType_CreateLookupIndex,
+		Options:      `{"lookup_vindexes": ["lookup"]}`,
+		Streams: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+			{
+				Id: 1,
+				Bls: &binlogdatapb.BinlogSource{
+					Keyspace: sourceKeyspace.KeyspaceName,
+					Shard:    "0",
+					Filter: &binlogdatapb.Filter{
+						Rules: []*binlogdatapb.Rule{{Match: "lookup", Filter: lookupVindexFilter}},
+					},
+				},
+			},
+		},
+	}
+	cleanedVSchema := lookupVindexSourceVSchema(true)
+	delete(cleanedVSchema.Vindexes, "lookup")
+	cleanedVSchema.Tables["t1"].ColumnVindexes = cleanedVSchema.Tables["t1"].ColumnVindexes[:1]
+
+	testcases := []struct {
+		name        string
+		vschema     *vschemapb.Keyspace
+		deleteData  bool
+		want        *vtctldatapb.LookupVindexCancelResponse
+		wantVSchema *vschemapb.Keyspace
+		wantDeletes bool
+		wantErr     string
+	}{
+		{
+			name:        "vindex and backfilled rows deleted",
+			vschema:     lookupVindexSourceVSchema(true),
+			deleteData:  true,
+			want:        &vtctldatapb.LookupVindexCancelResponse{RowsDeleted: 20},
+			wantVSchema: cleanedVSchema,
+			wantDeletes: true,
+		},
+		{
+			name:        "data kept by default",
+			vschema:     lookupVindexSourceVSchema(true),
+			want:        &vtctldatapb.LookupVindexCancelResponse{},
+			wantVSchema: lookupVindexSourceVSchema(true),
+		},
+		{
+			name:        "externalized vindex",
+			vschema:     lookupVindexSourceVSchema(false),
+			wantVSchema: lookupVindexSourceVSchema(false),
+			wantErr:     "vindex lookup has already been externalized",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+			defer env.close()
+			env.tmc.expectReadVReplicationWorkflowRequestOnTargetTablets(&readVReplicationWorkflowRequestResponse{
+				req: &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{Workflow: "lookup"},
+				res: workflowResponse,
+			})
+			require.NoError(t, env.ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+				Name:     sourceKeyspace.KeyspaceName,
+				Keyspace: tc.vschema,
+			}))
+
+			got, err := env.ws.LookupVindexCancel(ctx, &vtctldatapb.LookupVindexCancelRequest{
+				Keyspace:        sourceKeyspace.KeyspaceName,
+				Name:            "lookup",
+				TableKeyspace:   targetKeyspace.KeyspaceName,
+				DeleteData:      tc.deleteData,
+				DeleteBatchSize: 100,
+			})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				utils.MustMatch(t, tc.want, got)
+			}
+
+			vschema, err := env.ts.GetVSchema(ctx, sourceKeyspace.KeyspaceName)
+			require.NoError(t, err)
+			utils.MustMatch(t, tc.wantVSchema, vschema.Keyspace)
+
+			env.tmc.mu.Lock()
+			defer env.tmc.mu.Unlock()
+			for _, uid := range []uint32{200, 210} {
+				if !tc.wantDeletes {
+					require.Empty(t, env.tmc.deleteTableDataRequests[uid])
+					continue
+				}
+				require.Len(t, env.tmc.deleteTableDataRequests[uid], 1)
+				utils.MustMatch(t, &tabletmanagerdatapb.DeleteTableDataRequest{
+					TableFilters: map[string]string{"`lookup`": ""},
+					BatchSize:    100,
+				}, env.tmc.deleteTableDataRequests[uid][0])
+			}
+		})
+	}
+}
+
+func TestLookupVindexProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	now := time.Now()
+	throttledAt := protoutil.TimeToProto(now.Add(-time.Minute))
+	stream := func(id int32, state binlogdatapb.VReplicationWorkflowState, rowsCopied int64) *tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream {
+		return &tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+			Id: id,
+			Bls: &binlogdatapb.BinlogSource{
+				Keyspace: sourceKeyspace.KeyspaceName,
+				Shard:    "0",
+				Filter: &binlogdatapb.Filter{
+					Rules: []*binlogdatapb.Rule{{Match: "lookup", Filter: lookupVindexFilter}},
+				},
+			},
+			State:       state,
+			RowsCopied:  rowsCopied,
+			TimeUpdated: protoutil.TimeToProto(now),
+		}
+	}
+	copying := stream(1, binlogdatapb.VReplicationWorkflowState_Running, 30)
+	copying.ComponentThrottled = "vcopier:rowstreamer"
+	copying.TimeThrottled = throttledAt
+	failed := stream(2, binlogdatapb.VReplicationWorkflowState_Error, 10)
+	failed.Message = "Error: Duplicate entry '1' for key 'lookup.PRIMARY'"
+	for shard, streams := range map[string][]*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+		"-80": {copying, failed},
+		"80-": {stream(1, binlogdatapb.VReplicationWorkflowState_Running, 60)},
+	} {
+		env.tmc.AddVReplicationWorkflowsResponse(env.tmc.GetWorkflowKey(targetKeyspace.KeyspaceName, shard), &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+			Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{{
+				Workflow:     "lookup",
+				WorkflowType: binlogdatapb.VReplicationWorkflowType_CreateLookupIndex,
+				Streams:      streams,
+			}},
+		})
+	}
+	env.tmc.expectVRQuery(200, "select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1, 2) and id in (select max(id) from _vt.copy_state where vrepl_id in (1, 2) group by vrepl_id, table_name)",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("vrepl_id|table_name|lastpk", "int64|varchar|varbinary"), "1|lookup|"))
+	env.tmc.expectVRQuery(210, "select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name)",
+		&sqltypes.Result{})
+	env.tmc.expectVRQuery(100, "select sum(table_rows) from information_schema.tables where table_schema = 'vt_sourceks' and table_name in ('t1')",
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("sum(table_rows)", "decimal"), "200"))
+
+	got, err := env.ws.LookupVindexProgress(ctx, &vtctldatapb.LookupVindexProgressRequest{
+		Name:          "lookup",
+		TableKeyspace: targetKeyspace.KeyspaceName,
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.LookupVindexProgressResponse{
+		Shards: []*vtctldatapb.LookupVindexProgressResponse_ShardProgress{
+			{
+				Shard:              "-80",
+				State:              binlogdatapb.VReplicationWorkflowState_Error.String(),
+				RowsCopied:         40,
+				StreamsCopying:     1,
+				Streams:            2,
+				Messages:           []string{"stream 2: Error: Duplicate entry '1' for key 'lookup.PRIMARY'"},
+				ComponentThrottled: "vcopier:rowstreamer",
+				TimeThrottled:      throttledAt,
+				RowsTotal:          100,
+				RowsRemaining:      60,
+			},
+			{
+				Shard:      "80-",
+				State:      binlogdatapb.VReplicationWorkflowState_Running.String(),
+				RowsCopied: 60,
+				Streams:    1,
+				RowsTotal:  100,
+			},
+		},
+		RowsCopied:     100,
+		RowsTotal:      200,
+		RowsPercentage: 50,
+		RowsRemaining:  60,
+	}, got)
+
+	env.tmc.mu.Lock()
+	defer env.tmc.mu.Unlock()
+	for uid, queries := range env.tmc.vrQueries {
+		require.Empty(t, queries, "unused queries on tablet %d", uid)
+	}
+}
+
+func TestLookupVindexUpdateThrottle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+
+	testcases := []struct {
+		name          string
+		req           *vtctldatapb.LookupVindexUpdateThrottleRequest
+		wantOverrides map[string]string
+		wantErr       string
+	}{
+		{
+			name: "both limits",
+			req: &vtctldatapb.LookupVindexUpdateThrottleRequest{
+				MaxReplicationLag: protoutil.DurationToProto(30 * time.Second),
+				MaxRowsPerSecond:  ptr.Of(int64(500)),
+			},
+			wantOverrides: map[string]string{
+				"vreplication-copy-phase-max-replication-lag": "30s",
+				"vreplication-copy-phase-max-rows-per-second": "500",
+			},
+		},
+		{
+			name: "limit removed",
+			req: &vtctldatapb.LookupVindexUpdateThrottleRequest{
+				MaxRowsPerSecond: ptr.Of(int64(0)),
+			},
+			wantOverrides: map[string]string{
+				"vreplication-copy-phase-max-rows-per-second": "",
+			},
+		},
+		{
+			name:    "no limit",
+			req:     &vtctldatapb.LookupVindexUpdateThrottleRequest{},
+			wantErr: "no throttle limit specified to update",
+		},
+		{
+			name: "negative limit",
+			req: &vtctldatapb.LookupVindexUpdateThrottleRequest{
+				MaxRowsPerSecond: ptr.Of(int64(-1)),
+			},
+			wantErr: "invalid max rows per second -1: must not be negative",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+			defer env.close()
+			for _, uid := range []uint32{200, 210} {
+				env.tmc.AddUpdateVReplicationWorkflowRequestResponse(uid, &updateVReplicationWorkflowRequestResponse{
+					req: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+						Workflow:        "lookup",
+						Cells:           textutil.SimulatedNullStringSlice,
+						TabletTypes:     textutil.SimulatedNullTabletTypeSlice,
+						ConfigOverrides: tc.wantOverrides,
+					},
+					res: &tabletmanagerdatapb.UpdateVReplicationWorkflowResponse{
+						Result: &querypb.QueryResult{RowsAffected: 1},
+					},
+				})
+			}
+
+			tc.req.Name = "lookup"
+			tc.req.TableKeyspace = targetKeyspace.KeyspaceName
+			got, err := env.ws.LookupVindexUpdateThrottle(ctx, tc.req)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			utils.MustMatch(t, &vtctldatapb.LookupVindexUpdateThrottleResponse{
+				Details: []*vtctldatapb.WorkflowUpdateResponse_TabletInfo{
+					{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 200}, Changed: true},
+					{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 210}, Changed: true},
+				},
+			}, got)
+			env.tmc.mu.Lock()
+			defer env.tmc.mu.Unlock()
+			for uid, requests := range env.tmc.updateVReplicationWorklowRequests {
+				require.Empty(t, requests, "unused UpdateVReplicationWorkflow requests on tablet %d", uid)
+			}
+		})
+	}
+}
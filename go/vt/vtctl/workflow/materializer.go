@@ -353,20 +353,30 @@ func (mz *materializer) deploySchema() error {
 			createDDL := ts.CreateDdl
 			// Make any necessary adjustments to the create DDL.
 			if removeAutoInc || createDDL == createDDLAsCopy || createDDL == createDDLAsCopyDropConstraint || createDDL == createDDLAsCopyDropForeignKeys {
+				sourceTable := ts.TargetTable
 				if ts.SourceExpression != "" {
 					// Check for table if non-empty SourceExpression.
 					sourceTableName, err := mz.env.Parser().TableFromStatement(ts.SourceExpression)
 					if err != nil {
 						return err
 					}
-					if sourceTableName.Name.String() != ts.TargetTable {
+					sourceTable = sourceTableName.Name.String()
+					// MoveTables workflows can rename the tables they move.
+					if sourceTable != ts.TargetTable && mz.ms.MaterializationIntent != vtctldatapb.MaterializationIntent_MOVETABLES {
 						return fmt.Errorf("source and target table names must match for copying schema: %v vs %v", sqlparser.String(sourceTableName), ts.TargetTable)
 					}
 				}
 
-				ddl, ok := sourceDDLs[ts.TargetTable]
+				ddl, ok := sourceDDLs[sourceTable]
 				if !ok {
-					return fmt.Errorf("source table %v does not exist", ts.TargetTable)
+					return fmt.Errorf("source table %v does not exist", sourceTable)
+				}
+				if sourceTable != ts.TargetTable {
+					renamedDDL, err := renameCreateTable(ddl, ts.TargetTable, mz.env.Parser())
+					if err != nil {
+						return err
+					}
+					ddl = renamedDDL
 				}
 
 				if createDDL == createDDLAsCopyDropConstraint {
@@ -228,6 +228,9 @@ type testMaterializerTMClient struct {
 	fetchAsAllPrivsQueries             map[int]map[string]*queryResult
 	createVReplicationWorkflowRequests map[uint32]*createVReplicationWorkflowRequestResponse
 
+	// The last CreateVReplicationWorkflow request received by each tablet.
+	createdVReplicationWorkflows map[uint32]*tabletmanagerdatapb.CreateVReplicationWorkflowRequest
+
 	// Used to confirm the number of times WorkflowDelete was called.
 	workflowDeleteCalls int
 
@@ -247,6 +250,7 @@ func newTestMaterializerTMClient(keyspace string, sourceShards []string, tableSe
 		vrQueries:                          make(map[int][]*queryResult),
 		fetchAsAllPrivsQueries:             make(map[int]map[string]*queryResult),
 		createVReplicationWorkflowRequests: make(map[uint32]*createVReplicationWorkflowRequestResponse),
+		createdVReplicationWorkflows:       make(map[uint32]*tabletmanagerdatapb.CreateVReplicationWorkflowRequest),
 		getSchemaResponses:                 make(map[uint32]*tabletmanagerdatapb.SchemaDefinition),
 	}
 }
@@ -254,6 +258,7 @@ func newTestMaterializerTMClient(keyspace string, sourceShards []string, tableSe
 func (tmc *testMaterializerTMClient) CreateVReplicationWorkflow(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.CreateVReplicationWorkflowRequest) (*tabletmanagerdatapb.CreateVReplicationWorkflowResponse, error) {
 	tmc.mu.Lock()
 	defer tmc.mu.Unlock()
+	tmc.createdVReplicationWorkflows[tablet.Alias.Uid] = request
 	if expect := tmc.createVReplicationWorkflowRequests[tablet.Alias.Uid]; expect != nil {
 		if expect.req != nil && !proto.Equal(expect.req, request) {
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unexpected CreateVReplicationWorkflow request on tablet %s: got %+v, want %+v",
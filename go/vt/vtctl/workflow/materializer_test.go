@@ -18,6 +18,7 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
@@ -31,11 +32,13 @@ import (
 	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/proto"
 
+	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
@@ -500,7 +503,7 @@ func TestAddTablesToVSchema(t *testing.T) {
 				Keyspace: tt.sourceVSchema,
 			})
 			require.NoError(t, err)
-			err = ws.addTablesToVSchema(ctx, srcks, tt.inTargetVSchema, tt.tables, tt.copyVSchema)
+			err = ws.addTablesToVSchema(ctx, srcks, tt.inTargetVSchema, tt.tables, nil, tt.copyVSchema)
 			require.NoError(t, err)
 			require.Equal(t, tt.wantTargetVSchema, tt.inTargetVSchema)
 		})
@@ -568,6 +571,10 @@ func TestMoveTablesDDLFlag(t *testing.T) {
 	}
 
 	for onDDLAction := range binlogdatapb.OnDDLAction_value {
+		if onDDLAction == binlogdatapb.OnDDLAction_REMATERIALIZE.String() {
+			// Only supported for Materialize workflows.
+			continue
+		}
 		t.Run(fmt.Sprintf("OnDDL Flag:%v", onDDLAction), func(t *testing.T) {
 			ctx := t.Context()
 			env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
@@ -896,6 +903,61 @@ func TestShardedAutoIncHandling(t *testing.T) {
 				fmt.Sprintf(`create table %s (
 	id int not null primary key,
 	c1 varchar(10)
+)`, tableName),
+			},
+			expectAllPrivsQueries: []string{
+				validateEmptyTableQuery,
+			},
+		},
+		{
+			// The unsharded source keyspace is used for the sequence tables.
+			name:         "replace without global keyspace",
+			targetShards: []string{"-80", "80-"},
+			targetVSchema: &vschemapb.Keyspace{
+				Sharded: true,
+				Tables: map[string]*vschemapb.Table{
+					tableName: {
+						ColumnVindexes: []*vschemapb.ColumnVindex{
+							{
+								Name:   "xxhash",
+								Column: "id",
+							},
+						},
+					},
+				},
+				Vindexes: map[string]*vschemapb.Vindex{
+					"xxhash": {
+						Type: "xxhash",
+					},
+				},
+			},
+			value: vtctldatapb.ShardedAutoIncrementHandling_REPLACE,
+			wantTargetVSchema: &vschemapb.Keyspace{
+				Sharded: true,
+				Tables: map[string]*vschemapb.Table{
+					tableName: {
+						ColumnVindexes: []*vschemapb.ColumnVindex{
+							{
+								Name:   "xxhash",
+								Column: "id",
+							},
+						},
+						AutoIncrement: &vschemapb.AutoIncrement{ // AutoIncrement definition added
+							Column:   "id",
+							Sequence: fmt.Sprintf("`%s`.`%s`", ms.SourceKeyspace, fmt.Sprintf(autoSequenceTableFormat, strings.ReplaceAll(tableName, "`", ""))),
+						},
+					},
+				},
+				Vindexes: map[string]*vschemapb.Vindex{
+					"xxhash": {
+						Type: "xxhash",
+					},
+				},
+			},
+			expectQueries: []string{ // auto_increment clause removed
+				fmt.Sprintf(`create table %s (
+	id int not null primary key,
+	c1 varchar(10)
 )`, tableName),
 			},
 			expectAllPrivsQueries: []string{
@@ -1028,6 +1090,64 @@ func TestMoveTablesNoRoutingRules(t *testing.T) {
 	require.Zerof(t, len(rr.Rules), "routing rules should be empty, found %+v", rr.Rules)
 }
 
+func TestMoveTablesCreateRenamedTables(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "workflow",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      "t1_v2",
+			SourceExpression: "select * from t1",
+		}},
+	}
+
+	ctx := t.Context()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	env.tmc.expectVRQuery(100, mzCheckJournal, &sqltypes.Result{})
+	env.tmc.expectFetchAsAllPrivsQuery(200, "select 1 from `t1_v2` limit 1", &sqltypes.Result{})
+	env.tmc.expectVRQuery(200, mzGetCopyState, &sqltypes.Result{})
+	env.tmc.expectVRQuery(200, mzGetLatestCopyState, &sqltypes.Result{})
+
+	_, err := env.ws.MoveTablesCreate(ctx, &vtctldatapb.MoveTablesCreateRequest{
+		Workflow:       ms.Workflow,
+		SourceKeyspace: ms.SourceKeyspace,
+		TargetKeyspace: ms.TargetKeyspace,
+		IncludeTables:  []string{"t1:t1_v2"},
+	})
+	require.NoError(t, err)
+
+	rules, err := topotools.GetRoutingRules(ctx, env.ws.ts)
+	require.NoError(t, err)
+	want := make(map[string][]string)
+	for _, key := range []string{"t1", "sourceks.t1", "t1_v2", "targetks.t1_v2"} {
+		for _, suffix := range tabletTypeSuffixes {
+			want[key+suffix] = []string{"sourceks.t1"}
+		}
+	}
+	require.Equal(t, want, rules)
+
+	vschema, err := env.ws.ts.GetVSchema(ctx, ms.TargetKeyspace)
+	require.NoError(t, err)
+	require.Contains(t, vschema.Tables, "t1_v2")
+	require.NotContains(t, vschema.Tables, "t1")
+
+	ts, err := env.ws.buildTrafficSwitcher(ctx, ms.TargetKeyspace, ms.Workflow)
+	require.NoError(t, err)
+	require.Equal(t, []string{"t1_v2"}, ts.Tables())
+	require.Equal(t, []string{"t1"}, ts.sourceTables())
+
+	_, err = env.ws.MoveTablesCreate(ctx, &vtctldatapb.MoveTablesCreateRequest{
+		Workflow:       ms.Workflow,
+		SourceKeyspace: ms.SourceKeyspace,
+		TargetKeyspace: ms.TargetKeyspace,
+		IncludeTables:  []string{"t1:t1_v2"},
+		SourceShards:   []string{"0"},
+	})
+	require.ErrorContains(t, err, "tables cannot be renamed in a shard-by-shard migration")
+}
+
 func TestMoveTablesCreateShardedVSchemaRollback(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:       "workflow",
@@ -1372,6 +1492,99 @@ func TestCreateLookupVindexFull(t *testing.T) {
 	utils.MustMatch(t, wantvschema, vschema.Keyspace)
 }
 
+func TestCreateLookupVindexThrottling(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "lookup",
+		SourceKeyspace: "sourceks",
+		TargetKeyspace: "targetks",
+	}
+	ctx := t.Context()
+
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	specs := &vschemapb.Keyspace{
+		Vindexes: map[string]*vschemapb.Vindex{
+			"v": {
+				Type: "lookup_unique",
+				Params: map[string]string{
+					"table": "targetks.lookup",
+					"from":  "c1",
+					"to":    "c2",
+				},
+				Owner: "t1",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Name:   "v",
+					Column: "col2",
+				}},
+			},
+		},
+	}
+	env.tmc.schema[ms.SourceKeyspace+".t1"] = &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Fields: []*querypb.Field{{
+				Name: "col1",
+				Type: querypb.Type_INT64,
+			}, {
+				Name: "col2",
+				Type: querypb.Type_INT64,
+			}},
+			Schema: "CREATE TABLE `t1` (\n  `col1` int(11) NOT NULL,\n  `col2` int(11) DEFAULT NULL,\n  PRIMARY KEY (`col1`)\n) ENGINE=InnoDB",
+		}},
+	}
+	err := env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: ms.SourceKeyspace,
+		Keyspace: &vschemapb.Keyspace{
+			Sharded:  true,
+			Vindexes: map[string]*vschemapb.Vindex{"xxhash": {Type: "xxhash"}},
+			Tables: map[string]*vschemapb.Table{
+				"t1": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "col1"}}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	req := &vtctldatapb.LookupVindexCreateRequest{
+		Workflow:          ms.Workflow,
+		Keyspace:          ms.SourceKeyspace,
+		Cells:             []string{"cell"},
+		TabletTypes:       []topodatapb.TabletType{topodatapb.TabletType_PRIMARY},
+		Vindex:            specs,
+		MaxReplicationLag: protoutil.DurationToProto(-time.Second),
+	}
+	_, err = env.ws.LookupVindexCreate(ctx, req)
+	require.EqualError(t, err, "invalid max replication lag -1s: must not be negative")
+
+	req.MaxReplicationLag = protoutil.DurationToProto(30 * time.Second)
+	req.MaxRowsPerSecond = -1
+	_, err = env.ws.LookupVindexCreate(ctx, req)
+	require.EqualError(t, err, "invalid max rows per second -1: must not be negative")
+
+	// The limits are passed on to the streams in their VReplication config.
+	env.tmc.expectFetchAsAllPrivsQuery(200, "select 1 from `lookup` limit 1", &sqltypes.Result{})
+	env.tmc.expectVRQuery(200, "/CREATE TABLE `lookup`", &sqltypes.Result{})
+
+	req.MaxRowsPerSecond = 500
+	_, err = env.ws.LookupVindexCreate(ctx, req)
+	require.NoError(t, err)
+	env.tmc.verifyQueries(t)
+
+	env.tmc.mu.Lock()
+	defer env.tmc.mu.Unlock()
+	created := env.tmc.createdVReplicationWorkflows[200]
+	require.NotNil(t, created)
+	options := &vtctldatapb.WorkflowOptions{}
+	require.NoError(t, json.Unmarshal([]byte(created.Options), options))
+	require.Equal(t, map[string]string{
+		"vreplication-copy-phase-max-replication-lag": "30s",
+		"vreplication-copy-phase-max-rows-per-second": "500",
+	}, options.Config)
+}
+
 func TestCreateLookupVindexMultipleCreate(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		Workflow:       "lookup",
@@ -2831,6 +3044,113 @@ func TestCreateLookupVindexIgnoreNulls(t *testing.T) {
 	require.Equal(t, wantQuery, ms.TableSettings[0].SourceExpression, "unexpected query")
 }
 
+func TestCreateLookupVindexCoveringColumns(t *testing.T) {
+	ms := &vtctldatapb.MaterializeSettings{
+		SourceKeyspace: "ks",
+		TargetKeyspace: "ks",
+	}
+	ctx := t.Context()
+
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"0"}, []string{"0"})
+	defer env.close()
+
+	specs := &vschemapb.Keyspace{
+		Vindexes: map[string]*vschemapb.Vindex{
+			"v": {
+				Type: "lookup_unique",
+				Params: map[string]string{
+					"table":            "ks.lkp",
+					"from":             "c2",
+					"to":               "keyspace_id",
+					"covering_columns": "col3, col4",
+				},
+				Owner: "t1",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Name:   "v",
+					Column: "col2",
+				}},
+			},
+		},
+	}
+	sourceSchema := "CREATE TABLE `t1` (\n" +
+		"  `col1` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `col2` varchar(64) NOT NULL,\n" +
+		"  `col3` varchar(64) NOT NULL,\n" +
+		"  `col4` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`col1`)\n" +
+		") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=latin1"
+
+	vschema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash": {
+				Type: "xxhash",
+			},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"t1": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{
+					Name:   "xxhash",
+					Column: "col1",
+				}},
+			},
+		},
+	}
+	env.tmc.schema[ms.SourceKeyspace+".t1"] = &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Fields: []*querypb.Field{{
+				Name: "col1",
+				Type: querypb.Type_INT64,
+			}, {
+				Name: "col2",
+				Type: querypb.Type_VARCHAR,
+			}, {
+				Name: "col3",
+				Type: querypb.Type_VARCHAR,
+			}, {
+				Name: "col4",
+				Type: querypb.Type_INT64,
+			}},
+			Schema: sourceSchema,
+		}},
+	}
+	err := env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name:     ms.SourceKeyspace,
+		Keyspace: vschema,
+	})
+	require.NoError(t, err)
+
+	wantDDL := "CREATE TABLE `lkp` (\n" +
+		"  `c2` varchar(64) NOT NULL,\n" +
+		"  `keyspace_id` varbinary(128),\n" +
+		"  `col3` varchar(64),\n" +
+		"  `col4` int(11),\n" +
+		"  PRIMARY KEY (`c2`)\n" +
+		")"
+	// The covering columns are not grouped, so that they are updated by the
+	// workflow after the copy phase.
+	wantQuery := "select col2 as c2, col3 as col3, col4 as col4, keyspace_id() as keyspace_id from t1 group by c2, keyspace_id"
+
+	lv := newLookupVindex(env.ws)
+	ms, _, _, _, err = lv.prepareCreate(ctx, "workflow", ms.TargetKeyspace, specs, false)
+	require.NoError(t, err)
+	require.Len(t, ms.TableSettings, 1)
+	require.Equal(t, wantDDL, ms.TableSettings[0].CreateDdl)
+	require.Equal(t, wantQuery, ms.TableSettings[0].SourceExpression)
+	// The workflow keeps the covering columns in sync after the copy phase.
+	require.False(t, ms.StopAfterCopy)
+
+	// The rows of a consistent lookup table can be orphaned.
+	specs.Vindexes["v"].Type = "consistent_lookup_unique"
+	delete(specs.Vindexes["v"].Params, "write_only")
+	_, _, _, _, err = lv.prepareCreate(ctx, "workflow", ms.TargetKeyspace, specs, false)
+	require.EqualError(t, err, "covering_columns is only supported by lookup_unique vindexes, not consistent_lookup_unique")
+}
+
 func TestStopAfterCopyFlag(t *testing.T) {
 	ms := &vtctldatapb.MaterializeSettings{
 		SourceKeyspace: "ks",
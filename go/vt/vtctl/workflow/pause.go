@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vtctl/workflow/vexec"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	sqlResumeWorkflow = "update _vt.vreplication set state='Running', stop_pos=null, message='' where db_name=%a and workflow=%a and message=%a"
+	// sqlRestoreStream puts a stream back in the state it was in before a
+	// failed pause.
+	sqlRestoreStream = "update _vt.vreplication set state=%a, stop_pos=null where id=%a"
+)
+
+// WorkflowPause is part of the vtctlservicepb.VtctldServer interface.
+// It stops all the streams of the workflow at a barrier made of the
+// positions of the source primaries, which are read at the same time. All
+// the streams replicating from the same source shard stop at the same
+// position, so that the target keyspace holds a well-defined snapshot of
+// the source keyspace until the workflow is resumed.
+func (s *Server) WorkflowPause(ctx context.Context, req *vtctldatapb.WorkflowPauseRequest) (resp *vtctldatapb.WorkflowPauseResponse, err error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowPause")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("timeout", req.Timeout)
+
+	timeout, set, err := protoutil.DurationFromProto(req.GetTimeout())
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse Timeout into a valid duration")
+	}
+	if !set {
+		timeout = DefaultTimeout
+	}
+
+	ts, err := s.buildTrafficSwitcher(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if ts.frozen {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot pause the %s workflow in the %s keyspace: %s",
+			req.Workflow, req.Keyspace, cannotSwitchFrozen)
+	}
+
+	// Lock the workflow so that it cannot be paused while its traffic is
+	// switched or while it is being diffed.
+	lockName := fmt.Sprintf("%s/%s", ts.TargetKeyspaceName(), ts.WorkflowName())
+	ctx, workflowUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowPause")
+	if lockErr != nil {
+		return nil, vterrors.Wrapf(lockErr, "failed to lock the %s workflow", lockName)
+	}
+	defer workflowUnlock(&err)
+
+	// A stop position is only honored once the copy phase is done, and the
+	// states of the streams are restored if the pause fails.
+	var mu sync.Mutex
+	states := make(map[string]map[int32]binlogdatapb.VReplicationWorkflowState, len(ts.Targets()))
+	if err := ts.ForAllTargets(func(target *MigrationTarget) error {
+		wf, err := s.tmc.ReadVReplicationWorkflow(ctx, target.GetPrimary().Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: ts.WorkflowName(),
+		})
+		if err != nil {
+			return err
+		}
+		tabletStates := make(map[int32]binlogdatapb.VReplicationWorkflowState, len(wf.Streams))
+		for _, stream := range wf.Streams {
+			if stream.State == binlogdatapb.VReplicationWorkflowState_Copying {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot pause the %s workflow in the %s keyspace: %s",
+					req.Workflow, req.Keyspace, cannotSwitchCopyIncomplete)
+			}
+			tabletStates[stream.Id] = stream.State
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		states[target.GetShard().ShardName()] = tabletStates
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := ts.gatherSourcePositions(ctx); err != nil {
+		return nil, err
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, timeout)
+	defer waitCancel()
+	var details []*vtctldatapb.WorkflowPauseResponse_StreamInfo
+	if err := ts.ForAllUIDs(func(target *MigrationTarget, uid int32) error {
+		primary := target.GetPrimary()
+		source := ts.Sources()[target.Sources[uid].Shard]
+		if _, err := s.tmc.VReplicationExec(waitCtx, primary.Tablet, binlogplayer.StartVReplicationUntil(uid, source.Position)); err != nil {
+			return err
+		}
+		if err := s.tmc.VReplicationWaitForPos(waitCtx, primary.Tablet, uid, source.Position); err != nil {
+			return vterrors.Wrapf(err, "stream %d on %s did not reach the position %s of its source shard %s",
+				uid, topoproto.TabletAliasString(primary.Alias), source.Position, source.GetShard().ShardName())
+		}
+		if _, err := s.tmc.VReplicationExec(waitCtx, primary.Tablet, binlogplayer.StopVReplication(uid, binlogplayer.PausedMessage)); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		details = append(details, &vtctldatapb.WorkflowPauseResponse_StreamInfo{
+			Tablet:      primary.Alias,
+			Id:          uid,
+			SourceShard: fmt.Sprintf("%s/%s", source.GetShard().Keyspace(), source.GetShard().ShardName()),
+			Position:    source.Position,
+		})
+		return nil
+	}); err != nil {
+		// Do not leave the workflow partially paused.
+		if restoreErr := s.restorePausedStreams(ts, states); restoreErr != nil {
+			ts.Logger().Errorf("Failed to restore the streams of the %s workflow after a failed pause: %v", lockName, restoreErr)
+		}
+		return nil, vterrors.Wrapf(err, "failed to pause the %s workflow", lockName)
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		if details[i].Tablet.String() != details[j].Tablet.String() {
+			return details[i].Tablet.String() < details[j].Tablet.String()
+		}
+		return details[i].Id < details[j].Id
+	})
+	return &vtctldatapb.WorkflowPauseResponse{
+		Summary: fmt.Sprintf("Successfully paused the %s workflow on (%d) streams in the %s keyspace", req.Workflow, len(details), req.Keyspace),
+		Details: details,
+	}, nil
+}
+
+// restorePausedStreams clears the stop position of the streams of a workflow
+// which could not be paused, and puts them back in their previous state.
+func (s *Server) restorePausedStreams(ts *trafficSwitcher, states map[string]map[int32]binlogdatapb.VReplicationWorkflowState) error {
+	// We use a new context as the streams have to be restored even when the
+	// pause failed because its context expired.
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	return ts.ForAllUIDs(func(target *MigrationTarget, uid int32) error {
+		state := states[target.GetShard().ShardName()][uid]
+		if state == binlogdatapb.VReplicationWorkflowState_Unknown {
+			state = binlogdatapb.VReplicationWorkflowState_Running
+		}
+		query, err := sqlparser.ParseAndBind(sqlRestoreStream,
+			sqltypes.StringBindVariable(state.String()),
+			sqltypes.Int32BindVariable(uid),
+		)
+		if err != nil {
+			return err
+		}
+		_, err = s.tmc.VReplicationExec(ctx, target.GetPrimary().Tablet, query)
+		return err
+	})
+}
+
+// WorkflowResume is part of the vtctlservicepb.VtctldServer interface.
+// It clears the stop position of the streams of a paused workflow and
+// restarts them.
+func (s *Server) WorkflowResume(ctx context.Context, req *vtctldatapb.WorkflowResumeRequest) (*vtctldatapb.WorkflowResumeResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.WorkflowResume")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+
+	vx := vexec.NewVExec(req.Keyspace, req.Workflow, s.ts, s.tmc, s.env.Parser())
+	callback := func(ctx context.Context, tablet *topo.TabletInfo) (*querypb.QueryResult, error) {
+		query, err := sqlparser.ParseAndBind(sqlResumeWorkflow,
+			sqltypes.StringBindVariable(tablet.DbName()),
+			sqltypes.StringBindVariable(req.Workflow),
+			sqltypes.StringBindVariable(binlogplayer.PausedMessage),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return s.tmc.VReplicationExec(ctx, tablet.Tablet, query)
+	}
+	res, err := vx.CallbackContext(ctx, callback)
+	if err != nil {
+		if topo.IsErrType(err, topo.NoNode) {
+			return nil, vterrors.Wrapf(err, "%s keyspace does not exist", req.Keyspace)
+		}
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s workflow does not exist in the %s keyspace", req.Workflow, req.Keyspace)
+	}
+
+	response := &vtctldatapb.WorkflowResumeResponse{}
+	response.Summary = fmt.Sprintf("Successfully resumed the %s workflow on (%d) target primary tablets in the %s keyspace", req.Workflow, len(res), req.Keyspace)
+	details := make([]*vtctldatapb.WorkflowResumeResponse_TabletInfo, 0, len(res))
+	for tinfo, tres := range res {
+		details = append(details, &vtctldatapb.WorkflowResumeResponse_TabletInfo{
+			Tablet:  tinfo.Alias,
+			Resumed: tres.RowsAffected > 0,
+		})
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].Tablet.String() < details[j].Tablet.String()
+	})
+	response.Details = details
+	return response, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestWorkflowPause(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+	sourcePosition := "MySQL56/9d10e6ec-07a0-11ee-ae73-8e53f4cf3083:1-100"
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		"t1": {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{Name: "t1", Schema: "CREATE TABLE t1 (id BIGINT, PRIMARY KEY (id))"},
+			},
+		},
+	}
+	stopAtBarrier := fmt.Sprintf("update _vt.vreplication set state='Running', stop_pos='%s' where id=1", sourcePosition)
+	markPaused := "update _vt.vreplication set state='Stopped', message='PAUSED' where id=1"
+	restore := "update _vt.vreplication set state='Running', stop_pos=null where id=1"
+
+	testcases := []struct {
+		name    string
+		preFunc func(env *testEnv)
+		want    *vtctldatapb.WorkflowPauseResponse
+		wantErr string
+	}{
+		{
+			name: "all streams paused at the same source position",
+			preFunc: func(env *testEnv) {
+				for _, uid := range []int{200, 210} {
+					env.tmc.expectVRQuery(uid, stopAtBarrier, &sqltypes.Result{})
+					env.tmc.expectVRQuery(uid, markPaused, &sqltypes.Result{})
+				}
+			},
+			want: &vtctldatapb.WorkflowPauseResponse{
+				Summary: "Successfully paused the wf1 workflow on (2) streams in the targetks keyspace",
+				Details: []*vtctldatapb.WorkflowPauseResponse_StreamInfo{
+					{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 200}, Id: 1, SourceShard: "sourceks/0", Position: sourcePosition},
+					{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 210}, Id: 1, SourceShard: "sourceks/0", Position: sourcePosition},
+				},
+			},
+		},
+		{
+			name: "streams are restored when the pause fails",
+			preFunc: func(env *testEnv) {
+				env.tmc.expectVRQuery(200, stopAtBarrier, &sqltypes.Result{})
+				env.tmc.expectVRQuery(200, markPaused, &sqltypes.Result{})
+				env.tmc.expectVRQuery(200, restore, &sqltypes.Result{})
+				env.tmc.mu.Lock()
+				env.tmc.vrQueries[210] = append(env.tmc.vrQueries[210],
+					&queryResult{query: stopAtBarrier, err: errors.New("tablet is down")},
+					&queryResult{query: restore, result: &querypb.QueryResult{}},
+				)
+				env.tmc.mu.Unlock()
+			},
+			wantErr: "failed to pause the targetks/wf1 workflow: tablet is down",
+		},
+		{
+			name: "frozen workflow",
+			preFunc: func(env *testEnv) {
+				env.tmc.frozen.Store(true)
+			},
+			wantErr: "cannot pause the wf1 workflow in the targetks keyspace: workflow is frozen",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+			defer env.close()
+			env.tmc.schema = schema
+			env.tmc.setPrimaryPosition(env.tablets[sourceKeyspace.KeyspaceName][startingSourceTabletUID], sourcePosition)
+			tc.preFunc(env)
+
+			got, err := env.ws.WorkflowPause(ctx, &vtctldatapb.WorkflowPauseRequest{
+				Keyspace: targetKeyspace.KeyspaceName,
+				Workflow: workflowName,
+			})
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				utils.MustMatch(t, tc.want, got)
+			}
+			env.tmc.mu.Lock()
+			defer env.tmc.mu.Unlock()
+			for uid, queries := range env.tmc.vrQueries {
+				require.Empty(t, queries, "unused queries on tablet %d", uid)
+			}
+		})
+	}
+}
+
+func TestWorkflowResume(t *testing.T) {
+	ctx := context.Background()
+
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	env.tmc.expectVRQuery(200, "update _vt.vreplication set state='Running', stop_pos=null, message='' where db_name='vt_targetks' and workflow='wf1' and message='PAUSED'", &sqltypes.Result{RowsAffected: 1})
+	env.tmc.expectVRQuery(210, "update _vt.vreplication set state='Running', stop_pos=null, message='' where db_name='vt_targetks' and workflow='wf1' and message='PAUSED'", &sqltypes.Result{})
+
+	got, err := env.ws.WorkflowResume(ctx, &vtctldatapb.WorkflowResumeRequest{
+		Keyspace: targetKeyspace.KeyspaceName,
+		Workflow: "wf1",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.WorkflowResumeResponse{
+		Summary: "Successfully resumed the wf1 workflow on (2) target primary tablets in the targetks keyspace",
+		Details: []*vtctldatapb.WorkflowResumeResponse_TabletInfo{
+			{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 200}, Resumed: true},
+			{Tablet: &topodatapb.TabletAlias{Cell: defaultCellName, Uid: 210}, Resumed: false},
+		},
+	}, got)
+}
@@ -0,0 +1,463 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/sqlescape"
+	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vtctl/schematools"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// The primary vindex of a table is changed without moving it to another
+// keyspace: a shadow table, sharded by the new primary vindex, is created
+// in the same keyspace and filled by a Materialize workflow which copies
+// the table and then replicates its writes. Once the shadow table has been
+// verified with VDiff, the traffic of the table is routed to the shadow
+// table with routing rules, and a reverse workflow keeps the table up to
+// date with the writes to the shadow table so that the change can be
+// reverted.
+
+// shadowTableSuffix is appended to the name of the table to name its shadow
+// table when no name is given.
+const shadowTableSuffix = "_shadow"
+
+// PrimaryVindexCreate is part of the vtctlservicepb.VtctldServer interface.
+// It creates the shadow table of a table with the new primary vindex, and
+// the workflow which copies the table into it and keeps it up to date.
+func (s *Server) PrimaryVindexCreate(ctx context.Context, req *vtctldatapb.PrimaryVindexCreateRequest) (*vtctldatapb.PrimaryVindexCreateResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.PrimaryVindexCreate")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("table", req.Table)
+	span.Annotate("shadow_table", req.ShadowTable)
+	span.Annotate("cells", req.Cells)
+	span.Annotate("tablet_types", req.TabletTypes)
+
+	switch {
+	case req.Workflow == "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a workflow name must be specified")
+	case req.Table == "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "a table must be specified")
+	case req.ColumnVindex.GetName() == "" || (req.ColumnVindex.GetColumn() == "" && len(req.ColumnVindex.GetColumns()) == 0):
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the new primary vindex must have a name and columns")
+	}
+	shadowTable := req.ShadowTable
+	if shadowTable == "" {
+		shadowTable = req.Table + shadowTableSuffix
+	}
+	if shadowTable == req.Table {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the shadow table must have another name than the %s table", req.Table)
+	}
+
+	ksVSchema, err := s.ts.GetVSchema(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if !ksVSchema.Sharded {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s keyspace is not sharded", req.Keyspace)
+	}
+	table, ok := ksVSchema.Tables[req.Table]
+	if !ok || table.Type != "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s keyspace has no sharded table named %s", req.Keyspace, req.Table)
+	}
+	if _, ok := ksVSchema.Tables[shadowTable]; ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "the %s table already exists in the %s keyspace", shadowTable, req.Keyspace)
+	}
+	// The lookup vindexes owned by the table map its rows to the keyspace
+	// ids of its current primary vindex, which the shadow table does not use.
+	for name, vindex := range ksVSchema.Vindexes {
+		if vindex.Owner == req.Table {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the primary vindex of the %s table cannot be changed while it owns the %s vindex",
+				req.Table, name)
+		}
+	}
+
+	// We do NOT want to clone the key version as we explicitly want to go
+	// back in time if the workflow cannot be created.
+	origVSchema := &topo.KeyspaceVSchemaInfo{
+		Name:     req.Keyspace,
+		Keyspace: ksVSchema.Keyspace.CloneVT(),
+	}
+	if existing, ok := ksVSchema.Vindexes[req.ColumnVindex.Name]; ok {
+		if req.Vindex != nil && !proto.Equal(existing, req.Vindex) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "a conflicting vindex named %s already exists in the %s keyspace",
+				req.ColumnVindex.Name, req.Keyspace)
+		}
+	} else {
+		if req.Vindex == nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the %s keyspace has no vindex named %s and no vindex type was given",
+				req.Keyspace, req.ColumnVindex.Name)
+		}
+		if ksVSchema.Vindexes == nil {
+			ksVSchema.Vindexes = make(map[string]*vschemapb.Vindex)
+		}
+		ksVSchema.Vindexes[req.ColumnVindex.Name] = req.Vindex
+	}
+	// The secondary vindexes of the table are kept, as its rows are still
+	// looked up through them once its traffic is routed to the shadow table.
+	columnVindexes := []*vschemapb.ColumnVindex{req.ColumnVindex}
+	for _, cv := range table.ColumnVindexes[1:] {
+		if cv.Name != req.ColumnVindex.Name {
+			columnVindexes = append(columnVindexes, cv)
+		}
+	}
+	ksVSchema.Tables[shadowTable] = &vschemapb.Table{
+		ColumnVindexes:          columnVindexes,
+		AutoIncrement:           table.AutoIncrement,
+		Columns:                 table.Columns,
+		ColumnListAuthoritative: table.ColumnListAuthoritative,
+	}
+	if _, err := vindexes.BuildKeyspace(ksVSchema.Keyspace, s.env.Parser()); err != nil {
+		return nil, vterrors.Wrapf(err, "invalid primary vindex for the %s table", req.Table)
+	}
+
+	shards, err := s.ts.GetServingShards(ctx, req.Keyspace)
+	if err != nil {
+		return nil, err
+	}
+	if shards[0].PrimaryAlias == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s/%s has no primary", req.Keyspace, shards[0].ShardName())
+	}
+	schema, err := schematools.GetSchema(ctx, s.ts, s.tmc, shards[0].PrimaryAlias, &tabletmanagerdatapb.GetSchemaRequest{Tables: []string{req.Table}})
+	if err != nil {
+		return nil, err
+	}
+	if len(schema.TableDefinitions) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s table was not found on shard %s/%s",
+			req.Table, req.Keyspace, shards[0].ShardName())
+	}
+	createDDL, err := renameCreateTable(schema.TableDefinitions[0].Schema, shadowTable, s.env.Parser())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ts.SaveVSchema(ctx, ksVSchema); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to save updated vschema '%v' in the %s keyspace", ksVSchema, req.Keyspace)
+	}
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:                  req.Workflow,
+		MaterializationIntent:     vtctldatapb.MaterializationIntent_CUSTOM,
+		SourceKeyspace:            req.Keyspace,
+		TargetKeyspace:            req.Keyspace,
+		Cell:                      strings.Join(req.Cells, ","),
+		TabletTypes:               topoproto.MakeStringTypeCSV(req.TabletTypes),
+		TabletSelectionPreference: req.TabletSelectionPreference,
+		TableSettings: []*vtctldatapb.TableMaterializeSettings{{
+			TargetTable:      shadowTable,
+			SourceExpression: "select * from " + sqlescape.EscapeID(req.Table),
+			CreateDdl:        createDDL,
+		}},
+	}
+	if err := s.Materialize(ctx, ms); err != nil {
+		if rerr := s.ts.SaveVSchema(ctx, origVSchema); rerr != nil {
+			err = vterrors.Wrapf(err, "failed to restore original vschema '%v' in the %s keyspace: %v", origVSchema, req.Keyspace, rerr)
+		}
+		return nil, err
+	}
+
+	if err := s.ts.RebuildSrvVSchema(ctx, nil); err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.PrimaryVindexCreateResponse{ShadowTable: shadowTable}, nil
+}
+
+// PrimaryVindexComplete is part of the vtctlservicepb.VtctldServer interface.
+// It requires a VDiff of the workflow that found no differences, stops the
+// writes to the table, waits for the shadow table to catch up, creates the
+// reverse workflow, routes the traffic of the table to the shadow table and
+// deletes the workflow. The reverse workflow is kept, and completing it with
+// PrimaryVindexComplete routes the traffic back to the table.
+func (s *Server) PrimaryVindexComplete(ctx context.Context, req *vtctldatapb.PrimaryVindexCompleteRequest) (*vtctldatapb.PrimaryVindexCompleteResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.PrimaryVindexComplete")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("timeout", req.Timeout)
+
+	timeout, set, err := protoutil.DurationFromProto(req.GetTimeout())
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse Timeout into a valid duration")
+	}
+	if !set {
+		timeout = DefaultTimeout
+	}
+
+	ts, err := s.buildTrafficSwitcher(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	table, shadowTable, err := s.primaryVindexTables(ts)
+	if err != nil {
+		return nil, err
+	}
+	if ts.frozen {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot complete the %s workflow in the %s keyspace: %s",
+			req.Workflow, req.Keyspace, cannotSwitchFrozen)
+	}
+
+	if err := s.checkPrimaryVindexVDiff(ctx, ts, table, shadowTable); err != nil {
+		return nil, err
+	}
+
+	if err := s.switchPrimaryVindexTraffic(ctx, ts, table, shadowTable, timeout); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to complete the %s workflow in the %s keyspace", req.Workflow, req.Keyspace)
+	}
+
+	// The workflow is deleted without WorkflowDelete, which would also delete
+	// the reverse workflow.
+	if err := ts.ForAllTargets(func(target *MigrationTarget) error {
+		primary := target.GetPrimary().Tablet
+		if _, err := s.tmc.DeleteVReplicationWorkflow(ctx, primary, &tabletmanagerdatapb.DeleteVReplicationWorkflowRequest{
+			Workflow: req.Workflow,
+		}); err != nil {
+			return err
+		}
+		s.deleteWorkflowVDiffData(ctx, primary, req.Workflow)
+		s.optimizeCopyStateTable(primary)
+		return nil
+	}); err != nil {
+		return nil, vterrors.Wrapf(err, "the traffic of the %s table is routed to the %s table, but the %s workflow could not be deleted",
+			table, shadowTable, req.Workflow)
+	}
+	return &vtctldatapb.PrimaryVindexCompleteResponse{
+		Table:       table,
+		ShadowTable: shadowTable,
+	}, nil
+}
+
+// checkPrimaryVindexVDiff returns an error unless the last VDiff of the
+// workflow completed without finding any difference between the table and
+// its shadow table.
+func (s *Server) checkPrimaryVindexVDiff(ctx context.Context, ts *trafficSwitcher, table, shadowTable string) error {
+	resp, err := s.VDiffShow(ctx, &vtctldatapb.VDiffShowRequest{
+		Workflow:       ts.WorkflowName(),
+		TargetKeyspace: ts.TargetKeyspaceName(),
+		Arg:            vdiff.LastActionArg,
+	})
+	if err != nil {
+		return err
+	}
+	summary, err := BuildSummary(ts.TargetKeyspaceName(), ts.WorkflowName(), "", resp, true)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(summary.TableSummaryMap) == 0:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s workflow has no VDiff, the %s table must be verified with a VDiff before completing it",
+			ts.WorkflowName(), shadowTable)
+	case summary.State != vdiff.CompletedState:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the last VDiff of the %s workflow is %s, it must be completed before completing the workflow",
+			ts.WorkflowName(), summary.State)
+	case summary.HasMismatch:
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the last VDiff of the %s workflow found differences between the %s and %s tables",
+			ts.WorkflowName(), table, shadowTable)
+	}
+	return nil
+}
+
+// switchPrimaryVindexTraffic routes the traffic of the table to its shadow
+// table once the workflow has replicated all the writes to the table, and
+// starts the reverse workflow which replicates the writes to the shadow
+// table back to the table. The workflow is left as it was if the traffic
+// cannot be switched.
+func (s *Server) switchPrimaryVindexTraffic(ctx context.Context, ts *trafficSwitcher, table, shadowTable string, timeout time.Duration) (err error) {
+	ctx, unlock, lockErr := s.ts.LockKeyspace(ctx, ts.TargetKeyspaceName(), "PrimaryVindexComplete")
+	if lockErr != nil {
+		return lockErr
+	}
+	defer unlock(&err)
+
+	var mu sync.Mutex
+	states := make(map[string]map[int32]binlogdatapb.VReplicationWorkflowState, len(ts.Targets()))
+	if err := ts.ForAllTargets(func(target *MigrationTarget) error {
+		wf, err := s.tmc.ReadVReplicationWorkflow(ctx, target.GetPrimary().Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowRequest{
+			Workflow: ts.WorkflowName(),
+		})
+		if err != nil {
+			return err
+		}
+		tabletStates := make(map[int32]binlogdatapb.VReplicationWorkflowState, len(wf.Streams))
+		for _, stream := range wf.Streams {
+			if stream.State == binlogdatapb.VReplicationWorkflowState_Copying {
+				return vterrors.New(vtrpcpb.Code_FAILED_PRECONDITION, cannotSwitchCopyIncomplete)
+			}
+			tabletStates[stream.Id] = stream.State
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		states[target.GetShard().ShardName()] = tabletStates
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.denyPrimaryVindexWrites(ctx, ts, table, false); err != nil {
+		return err
+	}
+	// The reverse workflow reads from the shadow table and writes to the
+	// table, as a MoveTables workflow which renamed the table would.
+	ts.renamedTables = map[string]string{shadowTable: table}
+	err = ts.gatherSourcePositions(ctx)
+	if err == nil {
+		err = ts.waitForCatchup(ctx, timeout)
+	}
+	if err == nil {
+		err = ts.createReverseVReplication(ctx)
+	}
+	if err == nil {
+		err = s.routeToShadowTable(ctx, ts.TargetKeyspaceName(), table, shadowTable)
+	}
+	if err != nil {
+		if deleteErr := ts.deleteReverseVReplication(ctx); deleteErr != nil {
+			ts.Logger().Errorf("Failed to delete the reverse workflow of the %s workflow: %v", ts.WorkflowName(), deleteErr)
+		}
+		if restoreErr := s.restorePausedStreams(ts, states); restoreErr != nil {
+			ts.Logger().Errorf("Failed to restore the streams of the %s workflow: %v", ts.WorkflowName(), restoreErr)
+		}
+		if allowErr := s.denyPrimaryVindexWrites(ctx, ts, table, true); allowErr != nil {
+			ts.Logger().Errorf("Failed to allow the writes to the %s table again: %v", table, allowErr)
+		}
+		return err
+	}
+	if err := ts.startReverseVReplication(ctx); err != nil {
+		return err
+	}
+	// The table is only written to by the reverse workflow from now on, as
+	// its traffic is routed to the shadow table.
+	return s.denyPrimaryVindexWrites(ctx, ts, table, true)
+}
+
+// routeToShadowTable routes the traffic of the table to its shadow table, for
+// all the tablet types. The other routing rules are kept, and the rules which
+// routed another table name to the table follow it to the shadow table.
+func (s *Server) routeToShadowTable(ctx context.Context, keyspace, table, shadowTable string) error {
+	rules, err := topotools.GetRoutingRules(ctx, s.ts)
+	if err != nil {
+		return err
+	}
+	from := fmt.Sprintf("%s.%s", keyspace, table)
+	to := fmt.Sprintf("%s.%s", keyspace, shadowTable)
+	tabletTypes := []string{"", "@replica", "@rdonly"}
+	for _, tt := range tabletTypes {
+		for _, name := range []string{table + tt, from + tt} {
+			if existing, ok := rules[name]; ok && !(len(existing) == 1 && (existing[0] == from || existing[0] == to)) {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the traffic of the %s table is routed to %s by the %s routing rule",
+					table, strings.Join(existing, ","), name)
+			}
+		}
+	}
+	for name, targets := range rules {
+		if len(targets) == 1 && targets[0] == from {
+			rules[name] = []string{to}
+		}
+	}
+	for _, tt := range tabletTypes {
+		rules[table+tt] = []string{to}
+		rules[from+tt] = []string{to}
+	}
+	if err := topotools.SaveRoutingRules(ctx, s.ts, rules); err != nil {
+		return err
+	}
+	return s.ts.RebuildSrvVSchema(ctx, nil)
+}
+
+// denyPrimaryVindexWrites adds the table to the denied tables of the
+// primaries of the keyspace, or removes it from them if allow is true.
+func (s *Server) denyPrimaryVindexWrites(ctx context.Context, ts *trafficSwitcher, table string, allow bool) error {
+	return ts.ForAllSources(func(source *MigrationSource) error {
+		if _, err := s.ts.UpdateShardFields(ctx, ts.SourceKeyspaceName(), source.GetShard().ShardName(), func(si *topo.ShardInfo) error {
+			return si.UpdateDeniedTables(ctx, topodatapb.TabletType_PRIMARY, nil, allow, []string{table})
+		}); err != nil {
+			return err
+		}
+		rtbsCtx, cancel := context.WithTimeout(ctx, shardTabletRefreshTimeout)
+		defer cancel()
+		_, _, err := topotools.RefreshTabletsByShard(rtbsCtx, s.ts, s.tmc, source.GetShard(), nil, ts.Logger())
+		return err
+	})
+}
+
+// PrimaryVindexCancel is part of the vtctlservicepb.VtctldServer interface.
+// It deletes the workflow and, unless the data is kept, the shadow table.
+func (s *Server) PrimaryVindexCancel(ctx context.Context, req *vtctldatapb.PrimaryVindexCancelRequest) (*vtctldatapb.PrimaryVindexCancelResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.PrimaryVindexCancel")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("keep_data", req.KeepData)
+
+	ts, err := s.buildTrafficSwitcher(ctx, req.Keyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := s.primaryVindexTables(ts); err != nil {
+		return nil, err
+	}
+	if _, err := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{
+		Keyspace:         req.Keyspace,
+		Workflow:         req.Workflow,
+		KeepData:         req.KeepData,
+		KeepRoutingRules: true,
+	}); err != nil {
+		return nil, err
+	}
+	return &vtctldatapb.PrimaryVindexCancelResponse{}, nil
+}
+
+// primaryVindexTables returns the table whose primary vindex is changed by
+// the workflow, and its shadow table.
+func (s *Server) primaryVindexTables(ts *trafficSwitcher) (table, shadowTable string, err error) {
+	if ts.workflowType == binlogdatapb.VReplicationWorkflowType_Materialize && ts.SourceKeyspaceName() == ts.TargetKeyspaceName() && len(ts.tables) == 1 {
+		for _, target := range ts.Targets() {
+			for _, bls := range target.Sources {
+				rule := bls.Filter.Rules[0]
+				sourceTable, err := s.env.Parser().TableFromStatement(rule.Filter)
+				if err == nil && sourceTable.Name.String() != rule.Match {
+					return sourceTable.Name.String(), rule.Match, nil
+				}
+			}
+		}
+	}
+	return "", "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s workflow in the %s keyspace does not change the primary vindex of a table",
+		ts.WorkflowName(), ts.TargetKeyspaceName())
+}
@@ -0,0 +1,437 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func newPrimaryVindexTestEnv(t *testing.T) *testMaterializerEnv {
+	ms := &vtctldatapb.MaterializeSettings{
+		Workflow:       "corder_by_sku",
+		SourceKeyspace: "ks",
+		TargetKeyspace: "ks",
+	}
+	ctx := t.Context()
+	env := newTestMaterializerEnv(t, ctx, ms, []string{"-80", "80-"}, []string{"-80", "80-"})
+	t.Cleanup(env.close)
+
+	env.tmc.schema["ks.corder"] = &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name: "corder",
+			Schema: "CREATE TABLE `corder` (\n" +
+				"  `id` bigint NOT NULL,\n" +
+				"  `sku` varbinary(128) DEFAULT NULL,\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				") ENGINE=InnoDB",
+		}},
+	}
+	require.NoError(t, env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: "ks",
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"xxhash":  {Type: "xxhash"},
+				"sku_idx": {Type: "consistent_lookup", Params: map[string]string{"table": "ks.sku_lookup", "from": "sku", "to": "keyspace_id"}},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"corder": {
+					ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}, {Name: "sku_idx", Column: "sku"}},
+				},
+			},
+		},
+	}))
+	return env
+}
+
+func TestPrimaryVindexCreate(t *testing.T) {
+	ctx := t.Context()
+	env := newPrimaryVindexTestEnv(t)
+
+	for _, uid := range []int{100, 110} {
+		env.tmc.expectFetchAsAllPrivsQuery(uid, "select 1 from `corder_shadow` limit 1", &sqltypes.Result{})
+		env.tmc.expectVRQuery(uid, "/create table corder_shadow", &sqltypes.Result{})
+	}
+
+	resp, err := env.ws.PrimaryVindexCreate(ctx, &vtctldatapb.PrimaryVindexCreateRequest{
+		Keyspace:     "ks",
+		Workflow:     "corder_by_sku",
+		Table:        "corder",
+		ColumnVindex: &vschemapb.ColumnVindex{Name: "sku_hash", Columns: []string{"sku"}},
+		Vindex:       &vschemapb.Vindex{Type: "unicode_loose_xxhash"},
+		TabletTypes:  []topodatapb.TabletType{topodatapb.TabletType_PRIMARY},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "corder_shadow", resp.ShadowTable)
+	env.tmc.verifyQueries(t)
+
+	vschema, err := env.topoServ.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	utils.MustMatch(t, &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"xxhash":   {Type: "xxhash"},
+			"sku_idx":  {Type: "consistent_lookup", Params: map[string]string{"table": "ks.sku_lookup", "from": "sku", "to": "keyspace_id"}},
+			"sku_hash": {Type: "unicode_loose_xxhash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"corder": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}, {Name: "sku_idx", Column: "sku"}},
+			},
+			// The secondary vindexes of the table are kept.
+			"corder_shadow": {
+				ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "sku_hash", Columns: []string{"sku"}}, {Name: "sku_idx", Column: "sku"}},
+			},
+		},
+	}, vschema.Keyspace)
+
+	// Each shard of the shadow table is filled from all the shards of the
+	// table, filtered by the new primary vindex.
+	for uid, keyRange := range map[uint32]string{100: "-80", 110: "80-"} {
+		req := env.tmc.createdVReplicationWorkflows[uid]
+		require.NotNil(t, req)
+		require.Len(t, req.BinlogSource, 2)
+		for _, bls := range req.BinlogSource {
+			assert.Equal(t, "ks", bls.Keyspace)
+			require.Len(t, bls.Filter.Rules, 1)
+			assert.Equal(t, "corder_shadow", bls.Filter.Rules[0].Match)
+			assert.Equal(t, "select * from corder where in_keyrange(sku, 'ks.sku_hash', '"+keyRange+"')", bls.Filter.Rules[0].Filter)
+		}
+	}
+}
+
+func TestPrimaryVindexCreateErrors(t *testing.T) {
+	testcases := []struct {
+		name    string
+		req     *vtctldatapb.PrimaryVindexCreateRequest
+		vschema *vschemapb.Keyspace
+		wantErr string
+	}{
+		{
+			name: "no column vindex",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table: "corder",
+			},
+			wantErr: "the new primary vindex must have a name and columns",
+		},
+		{
+			name: "shadow table named as the table",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ShadowTable:  "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "xxhash", Column: "sku"},
+			},
+			wantErr: "the shadow table must have another name than the corder table",
+		},
+		{
+			name: "unknown table",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "customer",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "xxhash", Column: "sku"},
+			},
+			wantErr: "the ks keyspace has no sharded table named customer",
+		},
+		{
+			name: "existing shadow table",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "xxhash", Column: "sku"},
+			},
+			vschema: &vschemapb.Keyspace{
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"xxhash": {Type: "xxhash"},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"corder":        {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}}},
+					"corder_shadow": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "sku"}}},
+				},
+			},
+			wantErr: "the corder_shadow table already exists in the ks keyspace",
+		},
+		{
+			name: "owned lookup vindex",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "xxhash", Column: "sku"},
+			},
+			vschema: &vschemapb.Keyspace{
+				Sharded: true,
+				Vindexes: map[string]*vschemapb.Vindex{
+					"xxhash":     {Type: "xxhash"},
+					"sku_lookup": {Type: "consistent_lookup_unique", Params: map[string]string{"table": "ks.sku_lookup", "from": "sku", "to": "keyspace_id"}, Owner: "corder"},
+				},
+				Tables: map[string]*vschemapb.Table{
+					"corder": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}, {Name: "sku_lookup", Column: "sku"}}},
+				},
+			},
+			wantErr: "the primary vindex of the corder table cannot be changed while it owns the sku_lookup vindex",
+		},
+		{
+			name: "unknown vindex without spec",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "sku_hash", Column: "sku"},
+			},
+			wantErr: "the ks keyspace has no vindex named sku_hash and no vindex type was given",
+		},
+		{
+			name: "conflicting vindex",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "xxhash", Column: "sku"},
+				Vindex:       &vschemapb.Vindex{Type: "unicode_loose_xxhash"},
+			},
+			wantErr: "a conflicting vindex named xxhash already exists in the ks keyspace",
+		},
+		{
+			name: "not a primary vindex",
+			req: &vtctldatapb.PrimaryVindexCreateRequest{
+				Table:        "corder",
+				ColumnVindex: &vschemapb.ColumnVindex{Name: "sku_lookup", Column: "sku"},
+				Vindex:       &vschemapb.Vindex{Type: "lookup", Params: map[string]string{"table": "ks.sku_lookup", "from": "sku", "to": "keyspace_id"}},
+			},
+			wantErr: "invalid primary vindex for the corder table",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			env := newPrimaryVindexTestEnv(t)
+			if tc.vschema != nil {
+				require.NoError(t, env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{Name: "ks", Keyspace: tc.vschema}))
+			}
+			before, err := env.topoServ.GetVSchema(ctx, "ks")
+			require.NoError(t, err)
+
+			tc.req.Keyspace = "ks"
+			tc.req.Workflow = "corder_by_sku"
+			_, err = env.ws.PrimaryVindexCreate(ctx, tc.req)
+			require.ErrorContains(t, err, tc.wantErr)
+
+			after, err := env.topoServ.GetVSchema(ctx, "ks")
+			require.NoError(t, err)
+			utils.MustMatch(t, before.Keyspace, after.Keyspace)
+		})
+	}
+}
+
+func TestPrimaryVindexWorkflowType(t *testing.T) {
+	ctx := t.Context()
+	env := newPrimaryVindexTestEnv(t)
+
+	// The test tablet manager reports a MoveTables workflow, which the
+	// PrimaryVindex commands must not act upon.
+	_, err := env.ws.PrimaryVindexCancel(ctx, &vtctldatapb.PrimaryVindexCancelRequest{
+		Keyspace: "ks",
+		Workflow: "corder_by_sku",
+	})
+	require.ErrorContains(t, err, "the corder_by_sku workflow in the ks keyspace does not change the primary vindex of a table")
+	_, err = env.ws.PrimaryVindexComplete(ctx, &vtctldatapb.PrimaryVindexCompleteRequest{
+		Keyspace: "ks",
+		Workflow: "corder_by_sku",
+	})
+	require.ErrorContains(t, err, "the corder_by_sku workflow in the ks keyspace does not change the primary vindex of a table")
+	assert.Zero(t, env.tmc.workflowDeleteCalls)
+}
+
+// primaryVindexTMClient answers the VDiff show requests with the given
+// output, and the position requests of the traffic switch.
+type primaryVindexTMClient struct {
+	*testMaterializerTMClient
+	vdiffOutput *querypb.QueryResult
+}
+
+func (tmc *primaryVindexTMClient) VDiff(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.VDiffRequest) (*tabletmanagerdatapb.VDiffResponse, error) {
+	if req.Action != string(vdiff.ShowAction) {
+		return tmc.testMaterializerTMClient.VDiff(ctx, tablet, req)
+	}
+	return &tabletmanagerdatapb.VDiffResponse{Output: tmc.vdiffOutput}, nil
+}
+
+func (tmc *primaryVindexTMClient) PrimaryPosition(ctx context.Context, tablet *topodatapb.Tablet) (string, error) {
+	return position, nil
+}
+
+func (tmc *primaryVindexTMClient) VReplicationWaitForPos(ctx context.Context, tablet *topodatapb.Tablet, id int32, pos string) error {
+	return nil
+}
+
+// newPrimaryVindexCompleteTestEnv returns an environment with the workflow
+// created by PrimaryVindexCreate, whose last VDiff has the given output.
+func newPrimaryVindexCompleteTestEnv(t *testing.T, vdiffOutput *sqltypes.Result) (*testMaterializerEnv, *primaryVindexTMClient) {
+	ctx := t.Context()
+	env := newPrimaryVindexTestEnv(t)
+	tmc := &primaryVindexTMClient{
+		testMaterializerTMClient: env.tmc,
+		vdiffOutput:              sqltypes.ResultToProto3(vdiffOutput),
+	}
+	env.ws = NewServer(env.venv, env.topoServ, tmc)
+
+	require.NoError(t, env.topoServ.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{
+		Name: "ks",
+		Keyspace: &vschemapb.Keyspace{
+			Sharded: true,
+			Vindexes: map[string]*vschemapb.Vindex{
+				"xxhash":   {Type: "xxhash"},
+				"sku_hash": {Type: "unicode_loose_xxhash"},
+			},
+			Tables: map[string]*vschemapb.Table{
+				"corder":        {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "xxhash", Column: "id"}}},
+				"corder_shadow": {ColumnVindexes: []*vschemapb.ColumnVindex{{Name: "sku_hash", Column: "sku"}}},
+			},
+		},
+	}))
+	keyRanges := map[uint32]string{100: "-80", 110: "80-"}
+	env.tmc.readVReplicationWorkflow = func(ctx context.Context, tablet *topodatapb.Tablet, request *tabletmanagerdatapb.ReadVReplicationWorkflowRequest) (*tabletmanagerdatapb.ReadVReplicationWorkflowResponse, error) {
+		var streams []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream
+		for i, shard := range []string{"-80", "80-"} {
+			streams = append(streams, &tabletmanagerdatapb.ReadVReplicationWorkflowResponse_Stream{
+				Id:    int32(i + 1),
+				State: binlogdatapb.VReplicationWorkflowState_Running,
+				Bls: &binlogdatapb.BinlogSource{
+					Keyspace: "ks",
+					Shard:    shard,
+					Filter: &binlogdatapb.Filter{
+						Rules: []*binlogdatapb.Rule{{
+							Match:  "corder_shadow",
+							Filter: "select * from corder where in_keyrange(sku, 'ks.sku_hash', '" + keyRanges[tablet.Alias.Uid] + "')",
+						}},
+					},
+				},
+			})
+		}
+		return &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+			Workflow:     request.Workflow,
+			WorkflowType: binlogdatapb.VReplicationWorkflowType_Materialize,
+			Streams:      streams,
+		}, nil
+	}
+	return env, tmc
+}
+
+func TestPrimaryVindexComplete(t *testing.T) {
+	ctx := t.Context()
+	env, _ := newPrimaryVindexCompleteTestEnv(t, sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("vdiff_state|table_name|table_state|has_mismatch|report", "varchar|varchar|varchar|int64|json"),
+		"completed|corder_shadow|completed|0|{}",
+	))
+	require.NoError(t, topotools.SaveRoutingRules(ctx, env.topoServ, map[string][]string{
+		"order":  {"ks.corder"},
+		"ks2.t1": {"ks.t1"},
+	}))
+
+	for uid, keyRange := range map[int]string{100: "-80", 110: "80-"} {
+		env.tmc.expectVRQuery(uid, "/update _vt.vreplication set state='Stopped', message='stopped for cutover' where id=", &sqltypes.Result{})
+		env.tmc.expectVRQuery(uid, "/update _vt.vreplication set state='Stopped', message='stopped for cutover' where id=", &sqltypes.Result{})
+		env.tmc.expectVRQuery(uid, "delete from _vt.vreplication where db_name = 'vt_ks' and workflow = 'corder_by_sku_reverse'", &sqltypes.Result{})
+		// The reverse workflow reads the shadow table and writes the rows of
+		// the shard to the table.
+		reverseFilter := `/insert into _vt.vreplication.*'corder_by_sku_reverse'.*match:"corder" filter:"select \* from .corder_shadow. where in_keyrange\(id, .'ks.xxhash.', .'` +
+			keyRange + `.'\)`
+		env.tmc.expectVRQuery(uid, reverseFilter, &sqltypes.Result{})
+		env.tmc.expectVRQuery(uid, reverseFilter, &sqltypes.Result{})
+		env.tmc.expectVRQuery(uid, "update _vt.vreplication set state='Running', message='' where db_name='vt_ks' and workflow='corder_by_sku_reverse'", &sqltypes.Result{})
+	}
+
+	resp, err := env.ws.PrimaryVindexComplete(ctx, &vtctldatapb.PrimaryVindexCompleteRequest{
+		Keyspace: "ks",
+		Workflow: "corder_by_sku",
+	})
+	require.NoError(t, err)
+	utils.MustMatch(t, &vtctldatapb.PrimaryVindexCompleteResponse{Table: "corder", ShadowTable: "corder_shadow"}, resp)
+	env.tmc.verifyQueries(t)
+	assert.Equal(t, 2, env.tmc.workflowDeleteCalls)
+
+	rules, err := topotools.GetRoutingRules(ctx, env.topoServ)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"order":             {"ks.corder_shadow"},
+		"ks2.t1":            {"ks.t1"},
+		"corder":            {"ks.corder_shadow"},
+		"corder@replica":    {"ks.corder_shadow"},
+		"corder@rdonly":     {"ks.corder_shadow"},
+		"ks.corder":         {"ks.corder_shadow"},
+		"ks.corder@replica": {"ks.corder_shadow"},
+		"ks.corder@rdonly":  {"ks.corder_shadow"},
+	}, rules)
+
+	// The table is written to by the reverse workflow.
+	for _, shard := range []string{"-80", "80-"} {
+		si, err := env.topoServ.GetShard(ctx, "ks", shard)
+		require.NoError(t, err)
+		assert.NotContains(t, si.GetTabletControl(topodatapb.TabletType_PRIMARY).GetDeniedTables(), "corder")
+	}
+}
+
+func TestPrimaryVindexCompleteVDiff(t *testing.T) {
+	fields := sqltypes.MakeTestFields("vdiff_state|table_name|table_state|has_mismatch|report", "varchar|varchar|varchar|int64|json")
+	testcases := []struct {
+		name    string
+		output  *sqltypes.Result
+		wantErr string
+	}{
+		{
+			name:    "no vdiff",
+			output:  &sqltypes.Result{},
+			wantErr: "the corder_by_sku workflow has no VDiff, the corder_shadow table must be verified with a VDiff before completing it",
+		},
+		{
+			name:    "running vdiff",
+			output:  sqltypes.MakeTestResult(fields, "started|corder_shadow|started|0|{}"),
+			wantErr: "the last VDiff of the corder_by_sku workflow is started, it must be completed before completing the workflow",
+		},
+		{
+			name:    "mismatch",
+			output:  sqltypes.MakeTestResult(fields, "completed|corder_shadow|completed|1|{}"),
+			wantErr: "the last VDiff of the corder_by_sku workflow found differences between the corder and corder_shadow tables",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			env, _ := newPrimaryVindexCompleteTestEnv(t, tc.output)
+
+			_, err := env.ws.PrimaryVindexComplete(ctx, &vtctldatapb.PrimaryVindexCompleteRequest{
+				Keyspace: "ks",
+				Workflow: "corder_by_sku",
+			})
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.Zero(t, env.tmc.workflowDeleteCalls)
+			rules, err := topotools.GetRoutingRules(ctx, env.topoServ)
+			require.NoError(t, err)
+			assert.Empty(t, rules)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// DefaultRampStepDuration is how long each percentage of a traffic ramp is
+// served, when the request does not specify it.
+const DefaultRampStepDuration = time.Minute
+
+// tabletQueryCounts are the counts of the queries executed by a tablet, and
+// of the queries which failed, by table and plan.
+type tabletQueryCounts struct {
+	QueryCounts      map[string]int64
+	QueryErrorCounts map[string]int64
+}
+
+// getTabletQueryCounts fetches the query counts of a tablet from its debug
+// vars. It is a variable so that tests can replace it.
+var getTabletQueryCounts = func(ctx context.Context, tablet *topodatapb.Tablet) (*tabletQueryCounts, error) {
+	url := "http://" + netutil.JoinHostPort(tablet.Hostname, tablet.PortMap["vt"]) + "/debug/vars"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &tabletQueryCounts{}
+	if err := json.Unmarshal(body, counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// validateRamp checks that the traffic of the workflow can be ramped up
+// before its reads are switched.
+func validateRamp(req *vtctldatapb.WorkflowSwitchTrafficRequest, ts *trafficSwitcher, state *State, direction TrafficSwitchDirection) error {
+	// A ramp serves the traffic with mirror rules, so it has the same
+	// restrictions as traffic mirroring.
+	if state.WorkflowType != TypeMoveTables {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for %s workflow", string(state.WorkflowType))
+	}
+	if direction != DirectionForward || state.IsReverse {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for reverse workflow")
+	}
+	if ts.MigrationType() != binlogdatapb.MigrationType_TABLES {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for %s migration type", binlogdatapb.MigrationType_name[int32(ts.MigrationType())])
+	}
+	if ts.IsPartialMigration() {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for partial migration")
+	}
+	if ts.IsMultiTenantMigration() {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for multi-tenant migration")
+	}
+	// Mirror rules apply to all the cells.
+	if len(req.Cells) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic for a subset of the cells")
+	}
+	if len(rampTabletTypes(req)) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot ramp traffic without switching REPLICA or RDONLY reads")
+	}
+	if len(state.ReplicaCellsSwitched) > 0 || len(state.RdonlyCellsSwitched) > 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot ramp traffic for workflow %s: reads are already switched", state.Workflow)
+	}
+	var previous float32
+	for _, percent := range req.RampPercentages {
+		if percent <= previous || percent > 100 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "ramp percentages must be increasing, between 0 and 100: %v", req.RampPercentages)
+		}
+		previous = percent
+	}
+	if req.RampMaxErrorRate < 0 || req.RampMaxErrorRate > 1 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "ramp max error rate must be between 0 and 1: %v", req.RampMaxErrorRate)
+	}
+	return nil
+}
+
+// rampTabletTypes returns the tablet types of the reads which are ramped up.
+func rampTabletTypes(req *vtctldatapb.WorkflowSwitchTrafficRequest) []topodatapb.TabletType {
+	var tabletTypes []topodatapb.TabletType
+	for _, tabletType := range req.TabletTypes {
+		if tabletType == topodatapb.TabletType_REPLICA || tabletType == topodatapb.TabletType_RDONLY {
+			tabletTypes = append(tabletTypes, tabletType)
+		}
+	}
+	return tabletTypes
+}
+
+// rampReads serves increasing percentages of the reads of the workflow
+// tables from the target keyspace, before the reads are switched. Each
+// percentage is served for the step duration, after which the error rate of
+// the queries on these tables on the target tablets is checked. If it is
+// above the maximum, the reads are served by the source keyspace again and
+// an error is returned.
+func (s *Server) rampReads(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, ts *trafficSwitcher) (dryRunResults *[]string, err error) {
+	stepDuration, set, err := protoutil.DurationFromProto(req.GetRampStepDuration())
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse RampStepDuration into a valid duration")
+	}
+	if !set {
+		stepDuration = DefaultRampStepDuration
+	}
+	tabletTypes := rampTabletTypes(req)
+
+	var sw iswitcher
+	if req.DryRun {
+		sw = &switcherDryRun{ts: ts, drLog: NewLogRecorder()}
+	} else {
+		sw = &switcher{ts: ts, s: s}
+	}
+
+	// When the ramp fails, the reads are served by the source keyspace again.
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rerr := sw.splitTableTraffic(context.WithoutCancel(ctx), tabletTypes, 0); rerr != nil {
+			ts.Logger().Errorf("Failed to remove the split rules of the %s.%s workflow: %v", ts.TargetKeyspaceName(), ts.WorkflowName(), rerr)
+		}
+	}()
+
+	for _, percent := range req.RampPercentages {
+		s.Logger().Infof("Ramping reads of the %s.%s workflow to %.2f percent", ts.TargetKeyspaceName(), ts.WorkflowName(), percent)
+		if err := sw.splitTableTraffic(ctx, tabletTypes, percent); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to serve %.2f percent of the reads from the %s keyspace", percent, ts.TargetKeyspaceName())
+		}
+		if req.DryRun {
+			continue
+		}
+
+		before, err := s.getTargetQueryCounts(ctx, ts, tabletTypes)
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, vterrors.Wrapf(ctx.Err(), "ramp of the reads interrupted at %.2f percent", percent)
+		case <-time.After(stepDuration):
+		}
+		after, err := s.getTargetQueryCounts(ctx, ts, tabletTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		queries, failed := after.queries-before.queries, after.errors-before.errors
+		if queries > 0 && float64(failed)/float64(queries) > req.RampMaxErrorRate {
+			return nil, vterrors.Errorf(vtrpcpb.Code_ABORTED,
+				"ramp of the reads rolled back at %.2f percent: %d of the %d queries on the target tablets failed, above the max error rate of %v",
+				percent, failed, queries, req.RampMaxErrorRate)
+		}
+	}
+
+	// The split rules are removed, as the reads are switched next.
+	if err := sw.splitTableTraffic(ctx, tabletTypes, 0); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to remove the split rules of the %s.%s workflow", ts.TargetKeyspaceName(), ts.WorkflowName())
+	}
+	if req.DryRun {
+		return sw.logs(), nil
+	}
+	return nil, nil
+}
+
+// targetQueryCounts are the numbers of queries on the workflow tables on the
+// target tablets, and of those which failed.
+type targetQueryCounts struct {
+	queries, errors int64
+}
+
+// getTargetQueryCounts sums the query counts of the workflow tables on the
+// target tablets of the tablet types.
+func (s *Server) getTargetQueryCounts(ctx context.Context, ts *trafficSwitcher, tabletTypes []topodatapb.TabletType) (*targetQueryCounts, error) {
+	sum := func(counts map[string]int64) int64 {
+		var total int64
+		for key, count := range counts {
+			// The keys are made of the table and of the plan.
+			table, _, _ := strings.Cut(key, ".")
+			if slices.Contains(ts.tables, table) {
+				total += count
+			}
+		}
+		return total
+	}
+
+	total := &targetQueryCounts{}
+	for _, target := range ts.Targets() {
+		tablets, err := s.ts.GetTabletsByShard(ctx, ts.TargetKeyspaceName(), target.GetShard().ShardName())
+		if err != nil {
+			return nil, err
+		}
+		for _, tablet := range tablets {
+			if !slices.Contains(tabletTypes, tablet.Type) {
+				continue
+			}
+			counts, err := getTabletQueryCounts(ctx, tablet.Tablet)
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "failed to get the query counts of tablet %s", topoproto.TabletAliasString(tablet.Alias))
+			}
+			total.queries += sum(counts.QueryCounts)
+			total.errors += sum(counts.QueryErrorCounts)
+		}
+	}
+	return total, nil
+}
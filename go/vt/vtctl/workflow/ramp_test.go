@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestSwitchTrafficRamp(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	workflowName := "wf1"
+	tableName := "t1"
+	sourceKeyspace := &testKeyspace{KeyspaceName: "sourceks", ShardNames: []string{"0"}}
+	targetKeyspace := &testKeyspace{KeyspaceName: "targetks", ShardNames: []string{"-80", "80-"}}
+	schema := map[string]*tabletmanagerdatapb.SchemaDefinition{
+		tableName: {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   tableName,
+					Schema: fmt.Sprintf("CREATE TABLE %s (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))", tableName),
+				},
+			},
+		},
+	}
+	copyTableQR := &queryResult{
+		query:  "select vrepl_id, table_name, lastpk from _vt.copy_state where vrepl_id in (1) and id in (select max(id) from _vt.copy_state where vrepl_id in (1) group by vrepl_id, table_name)",
+		result: &querypb.QueryResult{},
+	}
+	journalQR := &queryResult{
+		query:  "/select val from _vt.resharding_journal.*",
+		result: &querypb.QueryResult{},
+	}
+	newRequest := func() *vtctldatapb.WorkflowSwitchTrafficRequest {
+		return &vtctldatapb.WorkflowSwitchTrafficRequest{
+			Keyspace:         targetKeyspace.KeyspaceName,
+			Workflow:         workflowName,
+			Direction:        int32(DirectionForward),
+			TabletTypes:      roTabletTypes,
+			RampPercentages:  []float32{10, 50},
+			RampStepDuration: protoutil.DurationToProto(time.Millisecond),
+			RampMaxErrorRate: 0.1,
+		}
+	}
+
+	testcases := []struct {
+		name string
+		// errors are the query errors on each replica for every 100 queries.
+		errors  int64
+		wantErr string
+	}{
+		{
+			name:   "ramp then switch reads",
+			errors: 10,
+		},
+		{
+			name:    "rollback",
+			errors:  20,
+			wantErr: "ramp of the reads rolled back at 10.00 percent: 40 of the 200 queries on the target tablets failed",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+			defer env.close()
+			env.tmc.schema = schema
+			env.tmc.expectVRQueryResultOnKeyspaceTablets(targetKeyspace.KeyspaceName, copyTableQR)
+			env.tmc.expectVRQueryResultOnKeyspaceTablets(sourceKeyspace.KeyspaceName, journalQR)
+			for i, shard := range targetKeyspace.ShardNames {
+				env.addTablet(t, ctx, startingTargetTabletUID+tabletUIDStep*(len(targetKeyspace.ShardNames)+i), targetKeyspace.KeyspaceName, shard, topodatapb.TabletType_REPLICA, true)
+			}
+
+			var percents []float32
+			counts := make(map[uint32]int64)
+			defer func(f func(context.Context, *topodatapb.Tablet) (*tabletQueryCounts, error)) {
+				getTabletQueryCounts = f
+			}(getTabletQueryCounts)
+			getTabletQueryCounts = func(ctx context.Context, tablet *topodatapb.Tablet) (*tabletQueryCounts, error) {
+				require.Equal(t, topodatapb.TabletType_REPLICA, tablet.Type)
+				mrs, err := env.ts.GetMirrorRules(ctx)
+				require.NoError(t, err)
+				require.Len(t, mrs.Rules, 2)
+				require.True(t, mrs.Rules[0].Split)
+				if tablet.Shard == "-80" {
+					percents = append(percents, mrs.Rules[0].Percent)
+				}
+
+				n := counts[tablet.Alias.Uid]
+				counts[tablet.Alias.Uid]++
+				return &tabletQueryCounts{
+					// The queries on the other tables are not counted.
+					QueryCounts:      map[string]int64{tableName + ".Select": 100 * n, "t2.Select": 1000 * n},
+					QueryErrorCounts: map[string]int64{tableName + ".Select": tc.errors * n, "t2.Select": 1000 * n},
+				}, nil
+			}
+
+			_, err := env.ws.WorkflowSwitchTraffic(ctx, newRequest())
+			mrs, merr := env.ts.GetMirrorRules(ctx)
+			require.NoError(t, merr)
+			assert.Empty(t, mrs.Rules)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Equal(t, vtrpcpb.Code_ABORTED, vterrors.Code(err))
+				assert.Equal(t, []float32{10, 10}, percents)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []float32{10, 10, 50, 50}, percents)
+			_, state, err := env.ws.getWorkflowState(ctx, targetKeyspace.KeyspaceName, workflowName)
+			require.NoError(t, err)
+			assert.Equal(t, "All Reads Switched. Writes Not Switched", state.String())
+		})
+	}
+}
+
+func TestSwitchTrafficRampValidation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{KeyspaceName: "sourceks", ShardNames: []string{"0"}}
+	targetKeyspace := &testKeyspace{KeyspaceName: "targetks", ShardNames: []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+	env.tmc.schema = map[string]*tabletmanagerdatapb.SchemaDefinition{
+		"t1": {
+			TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+				{
+					Name:   "t1",
+					Schema: "CREATE TABLE t1 (id BIGINT, name VARCHAR(64), PRIMARY KEY (id))",
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name    string
+		req     *vtctldatapb.WorkflowSwitchTrafficRequest
+		wantErr string
+	}{
+		{
+			name: "percentages not increasing",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				TabletTypes:     roTabletTypes,
+				RampPercentages: []float32{10, 10},
+			},
+			wantErr: "ramp percentages must be increasing, between 0 and 100: [10 10]",
+		},
+		{
+			name: "percentage above 100",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				TabletTypes:     roTabletTypes,
+				RampPercentages: []float32{50, 200},
+			},
+			wantErr: "ramp percentages must be increasing, between 0 and 100: [50 200]",
+		},
+		{
+			name: "writes only",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				TabletTypes:     []topodatapb.TabletType{topodatapb.TabletType_PRIMARY},
+				RampPercentages: []float32{10},
+			},
+			wantErr: "cannot ramp traffic without switching REPLICA or RDONLY reads",
+		},
+		{
+			name: "subset of the cells",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				TabletTypes:     roTabletTypes,
+				Cells:           []string{defaultCellName},
+				RampPercentages: []float32{10},
+			},
+			wantErr: "cannot ramp traffic for a subset of the cells",
+		},
+		{
+			name: "invalid max error rate",
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				TabletTypes:      roTabletTypes,
+				RampPercentages:  []float32{10},
+				RampMaxErrorRate: 2,
+			},
+			wantErr: "ramp max error rate must be between 0 and 1: 2",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Keyspace = targetKeyspace.KeyspaceName
+			tc.req.Workflow = "wf1"
+			tc.req.Direction = int32(DirectionForward)
+			_, err := env.ws.WorkflowSwitchTraffic(ctx, tc.req)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
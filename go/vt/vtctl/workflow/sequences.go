@@ -622,6 +622,6 @@ func (ts *trafficSwitcher) resetSequences(ctx context.Context) error {
 	return ts.ForAllSources(func(source *MigrationSource) error {
 		ts.Logger().Infof("Resetting sequences for source shard %s.%s on tablet %s",
 			source.GetShard().Keyspace(), source.GetShard().ShardName(), topoproto.TabletAliasString(source.GetPrimary().GetAlias()))
-		return ts.TabletManagerClient().ResetSequences(ctx, source.GetPrimary().Tablet, ts.Tables())
+		return ts.TabletManagerClient().ResetSequences(ctx, source.GetPrimary().Tablet, ts.sourceTables())
 	})
 }
@@ -18,13 +18,16 @@ package workflow
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"text/template"
 	"time"
 
@@ -38,8 +41,11 @@ import (
 	"vitess.io/vitess/go/protoutil"
 	"vitess.io/vitess/go/ptr"
 	"vitess.io/vitess/go/sets"
+	"vitess.io/vitess/go/sqlescape"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/textutil"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/concurrency"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
@@ -105,6 +111,7 @@ const (
 	cannotSwitchHighLag             = "replication lag %ds is higher than allowed lag %ds"
 	cannotSwitchFailedTabletRefresh = "could not refresh all of the tablets involved in the operation:\n%s"
 	cannotSwitchFrozen              = "workflow is frozen"
+	cannotSwitchPaused              = "workflow is paused"
 
 	// Number of LOCK TABLES cycles to perform on the sources during SwitchWrites.
 	lockTablesCycles = 2
@@ -457,7 +464,7 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 		if len(ts.Tables()) == 0 {
 			return nil, nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "no tables in workflow %s.%s", targetKeyspace, workflowName)
 		}
-		table := ts.Tables()[0]
+		table := ts.sourceTableName(ts.Tables()[0])
 
 		if ts.IsMultiTenantMigration() {
 			// Deduce which traffic has been switched by looking at the current keyspace routing rules.
@@ -498,7 +505,7 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 			for _, table := range ts.Tables() {
 				// If a rule for the primary tablet type exists for any table and points to the target keyspace,
 				// then writes have been switched.
-				ruleKey := fmt.Sprintf("%s.%s", sourceKeyspace, table)
+				ruleKey := fmt.Sprintf("%s.%s", sourceKeyspace, ts.sourceTableName(table))
 				rr := globalRules[ruleKey]
 				if len(rr) > 0 && rr[0] != ruleKey {
 					state.WritesSwitched = true
@@ -538,6 +545,104 @@ func (s *Server) getWorkflowState(ctx context.Context, targetKeyspace, workflowN
 	return ts, state, nil
 }
 
+// LookupVindexCancel deletes the VReplication workflow that backfills the
+// lookup vindex(es). Unless the data is to be kept, it also removes the
+// vindexes from the VSchema and deletes the rows that the backfill created
+// in the lookup tables, as they must have been empty when it started.
+func (s *Server) LookupVindexCancel(ctx context.Context, req *vtctldatapb.LookupVindexCancelRequest) (*vtctldatapb.LookupVindexCancelResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexCancel")
+	defer span.Finish()
+
+	span.Annotate("keyspace", req.Keyspace)
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+	span.Annotate("delete_data", req.DeleteData)
+	span.Annotate("delete_batch_size", req.DeleteBatchSize)
+
+	targetShards, err := s.ts.GetServingShards(ctx, req.TableKeyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	lv := newLookupVindex(s)
+	vindexByName, sourceKsVS, err := lv.getVindexesAndVSchema(ctx, req.Keyspace, req.Name, targetShards)
+	if err != nil {
+		return nil, err
+	}
+
+	// Once externalized, the lookup tables are kept up to date by the
+	// VTGates and are used by queries, so their data cannot be removed.
+	tableFilters := make(map[string]string, len(vindexByName))
+	for vindexName, vindex := range vindexByName {
+		if vindex.Params["write_only"] != "true" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vindex %s has already been externalized, internalize it before canceling the %s workflow",
+				vindexName, req.Name)
+		}
+		_, table, err := lv.parser.ParseTable(vindex.Params["table"])
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid lookup table %q for vindex %s", vindex.Params["table"], vindexName)
+		}
+		tableFilters[sqlescape.EscapeID(table)] = ""
+	}
+
+	// The vindexes have to be removed before the workflow is deleted, as the
+	// VTGates would otherwise keep adding rows to the lookup tables of the
+	// owned ones, and the workflow could not be canceled again if saving the
+	// VSchema failed.
+	if req.DeleteData {
+		for vindexName := range vindexByName {
+			delete(sourceKsVS.Vindexes, vindexName)
+			for _, table := range sourceKsVS.Tables {
+				table.ColumnVindexes = slices.DeleteFunc(table.ColumnVindexes, func(cv *vschemapb.ColumnVindex) bool {
+					return cv.Name == vindexName
+				})
+			}
+		}
+		if err := s.ts.SaveVSchema(ctx, sourceKsVS); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to save updated vschema in the %s keyspace", req.Keyspace)
+		}
+		if err := s.ts.RebuildSrvVSchema(ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{
+		Keyspace:         req.TableKeyspace,
+		Workflow:         req.Name,
+		KeepData:         true, // Not relevant
+		KeepRoutingRules: true, // Not relevant
+	}); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to delete workflow %s", req.Name)
+	}
+
+	resp := &vtctldatapb.LookupVindexCancelResponse{}
+	if !req.DeleteData {
+		return resp, nil
+	}
+
+	var rowsDeleted atomic.Uint64
+	err = forAllShards(targetShards, func(si *topo.ShardInfo) error {
+		primary, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return err
+		}
+		res, err := s.tmc.DeleteTableData(ctx, primary.Tablet, &tabletmanagerdatapb.DeleteTableDataRequest{
+			TableFilters: tableFilters,
+			BatchSize:    req.DeleteBatchSize,
+		})
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to delete the lookup table data on shard %s/%s", si.Keyspace(), si.ShardName())
+		}
+		rowsDeleted.Add(res.GetRowsDeleted())
+		return nil
+	})
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to fully delete the lookup table data, please delete the remaining rows manually")
+	}
+	resp.RowsDeleted = int64(rowsDeleted.Load())
+	return resp, nil
+}
+
 // LookupVindexComplete checks if the lookup vindex has been externalized,
 // and if the vindex has an owner, it deletes the workflow.
 func (s *Server) LookupVindexComplete(ctx context.Context, req *vtctldatapb.LookupVindexCompleteRequest) (*vtctldatapb.LookupVindexCompleteResponse, error) {
@@ -554,12 +659,29 @@ func (s *Server) LookupVindexComplete(ctx context.Context, req *vtctldatapb.Look
 	}
 
 	lv := newLookupVindex(s)
-	vindexByName, _, err := lv.getVindexesAndVSchema(ctx, req.Keyspace, req.Name, targetShards)
+	vindexByName, sourceKsVS, err := lv.getVindexesAndVSchema(ctx, req.Keyspace, req.Name, targetShards)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = lv.validateExternalized(ctx, vindexByName, req.Name, targetShards); err != nil {
+	// The workflow keeping covering columns in sync was not frozen when the
+	// vindexes were externalized. Once it is deleted, the covering columns of
+	// the lookup tables are no longer kept in sync, so the vindexes stop
+	// covering them first.
+	if hasCoveringColumns(vindexByName) {
+		for vindexName, vindex := range vindexByName {
+			if err := lv.validateExternalizedVindex(vindex); err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vindex %s has not been externalized yet: %v", vindexName, err)
+			}
+			delete(vindex.Params, "covering_columns")
+		}
+		if err := s.ts.SaveVSchema(ctx, sourceKsVS); err != nil {
+			return nil, err
+		}
+		if err := s.ts.RebuildSrvVSchema(ctx, nil); err != nil {
+			return nil, err
+		}
+	} else if err = lv.validateExternalized(ctx, vindexByName, req.Name, targetShards); err != nil {
 		return nil, err
 	}
 
@@ -587,6 +709,19 @@ func (s *Server) LookupVindexCreate(ctx context.Context, req *vtctldatapb.Lookup
 	span.Annotate("continue_after_copy_with_owner", req.ContinueAfterCopyWithOwner)
 	span.Annotate("cells", req.Cells)
 	span.Annotate("tablet_types", req.TabletTypes)
+	span.Annotate("max_rows_per_second", req.MaxRowsPerSecond)
+
+	maxReplicationLag, _, err := protoutil.DurationFromProto(req.MaxReplicationLag)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "unable to parse MaxReplicationLag into a valid duration")
+	}
+	span.Annotate("max_replication_lag", maxReplicationLag.String())
+	if maxReplicationLag < 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid max replication lag %v: must not be negative", maxReplicationLag)
+	}
+	if req.MaxRowsPerSecond < 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid max rows per second %d: must not be negative", req.MaxRowsPerSecond)
+	}
 
 	lv := newLookupVindex(s)
 
@@ -605,6 +740,19 @@ func (s *Server) LookupVindexCreate(ctx context.Context, req *vtctldatapb.Lookup
 	} else {
 		ms.WorkflowOptions.LookupVindexes = maps.Keys(req.Vindex.Vindexes)
 	}
+	// The backfill intensity is controlled through the VReplication config
+	// of the workflow's streams, which throttle their own copy phase.
+	if maxReplicationLag > 0 || req.MaxRowsPerSecond > 0 {
+		if ms.WorkflowOptions.Config == nil {
+			ms.WorkflowOptions.Config = make(map[string]string)
+		}
+		if maxReplicationLag > 0 {
+			ms.WorkflowOptions.Config[copyPhaseMaxReplicationLagConfig] = maxReplicationLag.String()
+		}
+		if req.MaxRowsPerSecond > 0 {
+			ms.WorkflowOptions.Config[copyPhaseMaxRowsPerSecondConfig] = strconv.FormatInt(req.MaxRowsPerSecond, 10)
+		}
+	}
 
 	if err := s.ts.SaveVSchema(ctx, targetVSchema); err != nil {
 		return nil, vterrors.Wrapf(err, "failed to save updated vschema '%v' in the %s keyspace",
@@ -635,6 +783,13 @@ func (s *Server) LookupVindexCreate(ctx context.Context, req *vtctldatapb.Lookup
 	return &vtctldatapb.LookupVindexCreateResponse{}, nil
 }
 
+// The VReplication config keys that throttle the copy phase of a lookup
+// vindex backfill.
+const (
+	copyPhaseMaxReplicationLagConfig = "vreplication-copy-phase-max-replication-lag"
+	copyPhaseMaxRowsPerSecondConfig  = "vreplication-copy-phase-max-rows-per-second"
+)
+
 // LookupVindexExternalize externalizes a lookup vindex that's
 // finished backfilling or has caught up. If the vindex has an
 // owner then the workflow will also be stopped.
@@ -668,10 +823,11 @@ func (s *Server) LookupVindexExternalize(ctx context.Context, req *vtctldatapb.L
 	}
 
 	resp := &vtctldatapb.LookupVindexExternalizeResponse{}
-	if isBackfillingOwned {
+	if isBackfillingOwned && !hasCoveringColumns(vindexByName) {
 		// If there is an owner, we have to stop/delete the streams. Once we
 		// externalize it the VTGate will now be responsible for keeping the
-		// lookup table up to date with the owner table.
+		// lookup table up to date with the owner table. The streams keeping
+		// covering columns in sync keep running.
 		if req.DeleteWorkflow {
 			// Delete the workflow.
 			if _, derr := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{
@@ -739,7 +895,16 @@ func (s *Server) LookupVindexInternalize(ctx context.Context, req *vtctldatapb.L
 		return nil, err
 	}
 
-	if err = lv.validateExternalized(ctx, vindexByName, req.Name, targetShards); err != nil {
+	// The workflow keeping covering columns in sync was not frozen when the
+	// vindexes were externalized.
+	covering := hasCoveringColumns(vindexByName)
+	if covering {
+		for vindexName, vindex := range vindexByName {
+			if err := lv.validateExternalizedVindex(vindex); err != nil {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "vindex %s has not been externalized yet: %v", vindexName, err)
+			}
+		}
+	} else if err = lv.validateExternalized(ctx, vindexByName, req.Name, targetShards); err != nil {
 		return nil, err
 	}
 
@@ -752,6 +917,9 @@ func (s *Server) LookupVindexInternalize(ctx context.Context, req *vtctldatapb.L
 	}
 
 	resp := &vtctldatapb.LookupVindexInternalizeResponse{}
+	if covering {
+		return resp, s.ts.RebuildSrvVSchema(ctx, nil)
+	}
 	err = forAllShards(targetShards, func(si *topo.ShardInfo) error {
 		tabletInfo, err := s.ts.GetTablet(ctx, si.PrimaryAlias)
 		if err != nil {
@@ -774,6 +942,256 @@ func (s *Server) LookupVindexInternalize(ctx context.Context, req *vtctldatapb.L
 	return resp, s.ts.RebuildSrvVSchema(ctx, nil)
 }
 
+// LookupVindexProgress reports the progress of the VReplication workflow
+// that backfills the lookup vindex(es) on each shard of the lookup tables.
+func (s *Server) LookupVindexProgress(ctx context.Context, req *vtctldatapb.LookupVindexProgressRequest) (*vtctldatapb.LookupVindexProgressResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexProgress")
+	defer span.Finish()
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	res, err := s.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{
+		Keyspace: req.TableKeyspace,
+		Workflow: req.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Workflows) != 1 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "workflow %s not found in the %s keyspace", req.Name, req.TableKeyspace)
+	}
+	wf := res.Workflows[0]
+
+	targetShards, err := s.ts.FindAllShardsInKeyspace(ctx, req.TableKeyspace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &vtctldatapb.LookupVindexProgressResponse{}
+	sourceTables := make(map[string]struct{})
+	for _, shardStream := range wf.ShardStreams {
+		if len(shardStream.Streams) == 0 {
+			continue
+		}
+		progress := &vtctldatapb.LookupVindexProgressResponse_ShardProgress{
+			Shard: shardStream.Streams[0].Shard,
+			State: shardStream.Streams[0].State,
+		}
+		var timeThrottled time.Time
+		for _, stream := range shardStream.Streams {
+			progress.Streams++
+			progress.RowsCopied += stream.RowsCopied
+			switch stream.State {
+			case binlogdatapb.VReplicationWorkflowState_Copying.String():
+				progress.StreamsCopying++
+				if progress.State != binlogdatapb.VReplicationWorkflowState_Error.String() {
+					progress.State = stream.State
+				}
+			case binlogdatapb.VReplicationWorkflowState_Error.String():
+				progress.State = stream.State
+			}
+			if stream.Message != "" {
+				progress.Messages = append(progress.Messages, fmt.Sprintf("stream %d: %s", stream.Id, stream.Message))
+			}
+			if ts := stream.ThrottlerStatus; ts != nil && ts.ComponentThrottled != "" {
+				if t := protoutil.TimeFromProto(ts.TimeThrottled); t.After(timeThrottled) {
+					timeThrottled = t
+					progress.ComponentThrottled = ts.ComponentThrottled
+					progress.TimeThrottled = ts.TimeThrottled
+				}
+			}
+			for _, rule := range stream.GetBinlogSource().GetFilter().GetRules() {
+				if table := s.getFilterSourceTable(rule.Filter); table != "" {
+					sourceTables[table] = struct{}{}
+				}
+			}
+		}
+		resp.RowsCopied += progress.RowsCopied
+		resp.Shards = append(resp.Shards, progress)
+	}
+	sort.Slice(resp.Shards, func(i, j int) bool {
+		return resp.Shards[i].Shard < resp.Shards[j].Shard
+	})
+
+	if resp.RowsTotal, err = s.getSourceRowCount(ctx, wf.GetSource(), maps.Keys(sourceTables)); err != nil {
+		return nil, vterrors.Wrapf(err, "failed to get the row count of the source tables of the %s workflow", req.Name)
+	}
+	if resp.RowsTotal > 0 {
+		resp.RowsPercentage = float32(resp.RowsCopied) * 100 / float32(resp.RowsTotal)
+	}
+	// The lookup rows are spread over the shards by the hash of their column,
+	// so each shard is expected to hold the share of the rows matching the
+	// size of its key range.
+	for _, progress := range resp.Shards {
+		if si, ok := targetShards[progress.Shard]; ok {
+			progress.RowsTotal = int64(float64(resp.RowsTotal) * keyRangeShare(si.KeyRange))
+		}
+		if progress.StreamsCopying > 0 || progress.State != binlogdatapb.VReplicationWorkflowState_Running.String() {
+			progress.RowsRemaining = max(progress.RowsTotal-progress.RowsCopied, 0)
+		}
+		resp.RowsRemaining += progress.RowsRemaining
+	}
+	return resp, nil
+}
+
+// keyRangeShare returns the share of the keyspace ID space covered by the
+// given key range, from the first 8 bytes of its bounds.
+func keyRangeShare(keyRange *topodatapb.KeyRange) float64 {
+	if key.KeyRangeIsComplete(keyRange) {
+		return 1
+	}
+	bound := func(id []byte, defaultValue float64) float64 {
+		if len(id) == 0 {
+			return defaultValue
+		}
+		var b [8]byte
+		copy(b[:], id)
+		return float64(binary.BigEndian.Uint64(b[:]))
+	}
+	start := bound(keyRange.Start, 0)
+	end := bound(keyRange.End, math.MaxUint64)
+	return (end - start) / math.MaxUint64
+}
+
+// LookupVindexUpdateThrottle changes the limits of the copy phase of a lookup
+// vindex backfill while it runs. The streams of the workflow are restarted
+// with the new limits.
+func (s *Server) LookupVindexUpdateThrottle(ctx context.Context, req *vtctldatapb.LookupVindexUpdateThrottleRequest) (*vtctldatapb.LookupVindexUpdateThrottleResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.LookupVindexUpdateThrottle")
+	defer span.Finish()
+
+	span.Annotate("name", req.Name)
+	span.Annotate("table_keyspace", req.TableKeyspace)
+
+	configOverrides := make(map[string]string)
+	if req.MaxReplicationLag != nil {
+		maxReplicationLag, _, err := protoutil.DurationFromProto(req.MaxReplicationLag)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "unable to parse MaxReplicationLag into a valid duration")
+		}
+		span.Annotate("max_replication_lag", maxReplicationLag.String())
+		if maxReplicationLag < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid max replication lag %v: must not be negative", maxReplicationLag)
+		}
+		// An empty value removes the override, and with it the limit.
+		configOverrides[copyPhaseMaxReplicationLagConfig] = ""
+		if maxReplicationLag > 0 {
+			configOverrides[copyPhaseMaxReplicationLagConfig] = maxReplicationLag.String()
+		}
+	}
+	if req.MaxRowsPerSecond != nil {
+		span.Annotate("max_rows_per_second", *req.MaxRowsPerSecond)
+		if *req.MaxRowsPerSecond < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid max rows per second %d: must not be negative", *req.MaxRowsPerSecond)
+		}
+		configOverrides[copyPhaseMaxRowsPerSecondConfig] = ""
+		if *req.MaxRowsPerSecond > 0 {
+			configOverrides[copyPhaseMaxRowsPerSecondConfig] = strconv.FormatInt(*req.MaxRowsPerSecond, 10)
+		}
+	}
+	if len(configOverrides) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no throttle limit specified to update")
+	}
+
+	res, err := s.WorkflowUpdate(ctx, &vtctldatapb.WorkflowUpdateRequest{
+		Keyspace: req.TableKeyspace,
+		TabletRequest: &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+			Workflow:        req.Name,
+			Cells:           textutil.SimulatedNullStringSlice,
+			TabletTypes:     textutil.SimulatedNullTabletTypeSlice,
+			ConfigOverrides: configOverrides,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res.Details, func(i, j int) bool {
+		return topoproto.TabletAliasString(res.Details[i].Tablet) < topoproto.TabletAliasString(res.Details[j].Tablet)
+	})
+	return &vtctldatapb.LookupVindexUpdateThrottleResponse{Details: res.Details}, nil
+}
+
+// getFilterSourceTable returns the table that the given stream filter
+// selects from, or an empty string if the filter is not a simple select.
+func (s *Server) getFilterSourceTable(filter string) string {
+	stmt, err := s.SQLParser().Parse(filter)
+	if err != nil {
+		return ""
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.From) != 1 {
+		return ""
+	}
+	ate, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return ""
+	}
+	tableName, err := ate.TableName()
+	if err != nil {
+		return ""
+	}
+	return tableName.Name.String()
+}
+
+// getSourceRowCount returns the number of rows of the given tables across
+// the source shards, as estimated by the table statistics of their primaries.
+func (s *Server) getSourceRowCount(ctx context.Context, source *vtctldatapb.Workflow_ReplicationLocation, tables []string) (int64, error) {
+	if source == nil || len(tables) == 0 {
+		return 0, nil
+	}
+	sort.Strings(tables)
+	tableList := make([]string, len(tables))
+	for i, table := range tables {
+		tableList[i] = encodeString(table)
+	}
+	var (
+		rowCount int64
+		mu       sync.Mutex
+	)
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, shard := range source.Shards {
+		eg.Go(func() error {
+			si, err := s.ts.GetShard(egCtx, source.Keyspace, shard)
+			if err != nil {
+				return err
+			}
+			if si.PrimaryAlias == nil {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "source shard %s/%s currently has no PRIMARY tablet", source.Keyspace, shard)
+			}
+			primary, err := s.ts.GetTablet(egCtx, si.PrimaryAlias)
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("select sum(table_rows) from information_schema.tables where table_schema = %s and table_name in (%s)",
+				encodeString(primary.DbName()), strings.Join(tableList, ","))
+			p3qr, err := s.tmc.ExecuteFetchAsDba(egCtx, primary.Tablet, true, &tabletmanagerdatapb.ExecuteFetchAsDbaRequest{
+				Query:   []byte(query),
+				MaxRows: 1,
+			})
+			if err != nil {
+				return err
+			}
+			qr := sqltypes.Proto3ToResult(p3qr)
+			if len(qr.Rows) == 0 || qr.Rows[0][0].IsNull() {
+				return nil
+			}
+			rows, err := qr.Rows[0][0].ToCastInt64()
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			rowCount += rows
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+	return rowCount, nil
+}
+
 // Materialize performs the steps needed to materialize a list of
 // tables based on the materialization specs.
 func (s *Server) Materialize(ctx context.Context, ms *vtctldatapb.MaterializeSettings) error {
@@ -1029,6 +1447,9 @@ func validateMaterializeSettings(ms *vtctldatapb.MaterializeSettings) error {
 // It passes the embedded TabletRequest object to the given keyspace's
 // target primary tablets that will be executing the workflow.
 func (s *Server) MoveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (res *vtctldatapb.WorkflowStatusResponse, err error) {
+	if len(req.SourceKeyspaces) > 0 {
+		return s.moveTablesCreateGroup(ctx, req)
+	}
 	return s.moveTablesCreate(ctx, req, binlogdatapb.VReplicationWorkflowType_MoveTables)
 }
 
@@ -1052,12 +1473,50 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 	if workflowType == binlogdatapb.VReplicationWorkflowType_MoveTables && sourceKeyspace == targetKeyspace {
 		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source and target keyspace must be different for MoveTables workflows")
 	}
+	if strings.EqualFold(req.OnDdl, binlogdatapb.OnDDLAction_REMATERIALIZE.String()) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the %s on-ddl action is only supported for Materialize workflows", req.OnDdl)
+	}
 
 	var (
-		tables       = req.IncludeTables
 		externalTopo *topo.Server
 		sourceTopo   = s.ts
 	)
+	tables, renamedTables, err := ParseTableMappings(req.IncludeTables)
+	if err != nil {
+		return nil, err
+	}
+	if len(renamedTables) > 0 {
+		switch {
+		case workflowType != binlogdatapb.VReplicationWorkflowType_MoveTables:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables can only be renamed in MoveTables workflows")
+		case req.GetWorkflowOptions().GetTenantId() != "":
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables cannot be renamed in a multi-tenant migration")
+		case len(req.SourceShards) > 0:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables cannot be renamed in a shard-by-shard migration")
+		}
+	}
+	targetTableName := func(table string) string {
+		if targetTable, ok := renamedTables[table]; ok {
+			return targetTable
+		}
+		return table
+	}
+
+	// When auto_increment clauses are replaced by sequences and no
+	// global-keyspace was provided, we create the sequence tables in the
+	// source keyspace if it is unsharded, as it remains after the move.
+	if workflowType == binlogdatapb.VReplicationWorkflowType_MoveTables && req.ExternalClusterName == "" &&
+		req.GetWorkflowOptions().GetShardedAutoIncrementHandling() == vtctldatapb.ShardedAutoIncrementHandling_REPLACE &&
+		req.WorkflowOptions.GlobalKeyspace == "" {
+		svs, err := s.ts.GetVSchema(ctx, sourceKeyspace)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to get vschema for source keyspace %s", sourceKeyspace)
+		}
+		if !svs.Sharded {
+			s.Logger().Infof("Using source keyspace %s as the global-keyspace for the sequence tables", sourceKeyspace)
+			req.WorkflowOptions.GlobalKeyspace = sourceKeyspace
+		}
+	}
 
 	if req.GetWorkflowOptions() != nil && req.WorkflowOptions.GlobalKeyspace != "" {
 		// Confirm that the keyspace exists and it is unsharded.
@@ -1147,7 +1606,7 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 			Name:     targetKeyspace,
 			Keyspace: vschema.Keyspace.CloneVT(),
 		}
-		if err := s.addTablesToVSchema(ctx, sourceKeyspace, vschema.Keyspace, tables, externalTopo == nil); err != nil {
+		if err := s.addTablesToVSchema(ctx, sourceKeyspace, vschema.Keyspace, tables, renamedTables, externalTopo == nil); err != nil {
 			return nil, err
 		}
 		if err := s.ts.SaveVSchema(ctx, vschema); err != nil {
@@ -1183,7 +1642,7 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 		buf := sqlparser.NewTrackedBuffer(nil)
 		buf.Myprintf("select * from %v", sqlparser.NewIdentifierCS(table))
 		ms.TableSettings = append(ms.TableSettings, &vtctldatapb.TableMaterializeSettings{
-			TargetTable:      table,
+			TargetTable:      targetTableName(table),
 			SourceExpression: buf.String(),
 			CreateDdl:        createDDLMode,
 		})
@@ -1261,7 +1720,7 @@ func (s *Server) moveTablesCreate(ctx context.Context, req *vtctldatapb.MoveTabl
 	// Now that the streams have been successfully created, let's put the associated
 	// routing rules and denied tables entries in place.
 	if externalTopo == nil {
-		if err := s.setupInitialRoutingRules(ctx, req, mz, tables); err != nil {
+		if err := s.setupInitialRoutingRules(ctx, req, mz, tables, renamedTables); err != nil {
 			return nil, err
 		}
 	}
@@ -1350,7 +1809,7 @@ func setupInitialDeniedTables(ctx context.Context, ts *trafficSwitcher) error {
 	})
 }
 
-func (s *Server) setupInitialRoutingRules(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest, mz *materializer, tables []string) error {
+func (s *Server) setupInitialRoutingRules(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest, mz *materializer, tables []string, renamedTables map[string]string) error {
 	if err := validateRoutingRuleFlags(req, mz); err != nil {
 		return err
 	}
@@ -1391,9 +1850,9 @@ func (s *Server) setupInitialRoutingRules(ctx context.Context, req *vtctldatapb.
 	if err != nil {
 		return err
 	}
-	routeTableToSource := func(keyspace, table string) {
+	routeTableToSource := func(keyspace, table, sourceTable string) {
 		key := table
-		route := fmt.Sprintf("%s.%s", sourceKeyspace, table)
+		route := fmt.Sprintf("%s.%s", sourceKeyspace, sourceTable)
 		if keyspace != "" {
 			key = fmt.Sprintf("%s.%s", keyspace, table)
 		}
@@ -1402,9 +1861,19 @@ func (s *Server) setupInitialRoutingRules(ctx context.Context, req *vtctldatapb.
 		}
 	}
 	for _, table := range tables {
-		for _, ks := range []string{globalTableQualifier, targetKeyspace, sourceKeyspace} {
-			routeTableToSource(ks, table)
+		targetTable, renamed := renamedTables[table]
+		if !renamed {
+			for _, ks := range []string{globalTableQualifier, targetKeyspace, sourceKeyspace} {
+				routeTableToSource(ks, table, table)
+			}
+			continue
 		}
+		// A renamed table is routed to the source table using both its source
+		// and target names.
+		routeTableToSource(globalTableQualifier, table, table)
+		routeTableToSource(sourceKeyspace, table, table)
+		routeTableToSource(globalTableQualifier, targetTable, table)
+		routeTableToSource(targetKeyspace, targetTable, table)
 	}
 	if err := topotools.SaveRoutingRules(ctx, s.ts, rules); err != nil {
 		return err
@@ -1426,6 +1895,9 @@ func (s *Server) MoveTablesComplete(ctx context.Context, req *vtctldatapb.MoveTa
 
 	ts, state, err := s.getWorkflowState(ctx, req.GetTargetKeyspace(), req.GetWorkflow(), opts...)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.GetTargetKeyspace(), req.GetWorkflow(), err); len(members) > 0 {
+			return s.workflowGroupComplete(ctx, req, members)
+		}
 		return nil, err
 	}
 
@@ -1501,6 +1973,9 @@ func (s *Server) ReshardCreate(ctx context.Context, req *vtctldatapb.ReshardCrea
 	keyspace := req.Keyspace
 	cells := req.Cells
 	// TODO: validate workflow does not exist.
+	if strings.EqualFold(req.OnDdl, binlogdatapb.OnDDLAction_REMATERIALIZE.String()) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the %s on-ddl action is only supported for Materialize workflows", req.OnDdl)
+	}
 
 	if err := s.ts.ValidateSrvKeyspace(ctx, keyspace, strings.Join(cells, ",")); err != nil {
 		err2 := vterrors.Wrapf(err, "SrvKeyspace for keyspace %s is corrupt for cell(s) %s", keyspace, cells)
@@ -1557,6 +2032,9 @@ func (s *Server) WorkflowDelete(ctx context.Context, req *vtctldatapb.WorkflowDe
 
 	ts, state, err := s.getWorkflowState(ctx, req.GetKeyspace(), req.GetWorkflow(), opts...)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.GetKeyspace(), req.GetWorkflow(), err); len(members) > 0 {
+			return s.workflowGroupDelete(ctx, req, members)
+		}
 		s.Logger().Errorf("failed to get VReplication workflow state for %s.%s: %v", req.GetKeyspace(), req.GetWorkflow(), err)
 		return nil, err
 	}
@@ -1658,6 +2136,9 @@ func (s *Server) WorkflowDelete(ctx context.Context, req *vtctldatapb.WorkflowDe
 func (s *Server) WorkflowStatus(ctx context.Context, req *vtctldatapb.WorkflowStatusRequest) (*vtctldatapb.WorkflowStatusResponse, error) {
 	ts, state, err := s.getWorkflowState(ctx, req.Keyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.Keyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupStatus(ctx, req, members)
+		}
 		return nil, vterrors.Wrapf(err, "failed to get workflow state for %s.%s workflow", req.Keyspace, req.Workflow)
 	}
 	copyProgress, err := s.getCopyProgress(ctx, ts)
@@ -1876,6 +2357,14 @@ func (s *Server) getCopyProgress(ctx context.Context, ts *trafficSwitcher) (*cop
 		return nil, vterrors.Wrapf(err, "failed to get table metrics on target shards")
 	}
 
+	if len(ts.renamedTables) > 0 {
+		sourceTableList := make([]string, 0, len(workflowTables))
+		for _, table := range workflowTables {
+			sourceTableList = append(sourceTableList, encodeString(ts.sourceTableName(table)))
+		}
+		sort.Strings(sourceTableList)
+		tablesStr = strings.Join(sourceTableList, ",")
+	}
 	query = fmt.Sprintf(getRowCountQuery, encodeString(sourceDbName), tablesStr)
 	err = ts.ForAllSources(func(source *MigrationSource) error {
 		primary := source.GetPrimary()
@@ -1901,8 +2390,8 @@ func (s *Server) getCopyProgress(ctx context.Context, ts *trafficSwitcher) (*cop
 		copyProgress[table] = &tableCopyProgress{
 			TargetRowCount:  rowCount,
 			TargetTableSize: targetTableSizes[table],
-			SourceRowCount:  sourceRowCounts[table],
-			SourceTableSize: sourceTableSizes[table],
+			SourceRowCount:  sourceRowCounts[ts.sourceTableName(table)],
+			SourceTableSize: sourceTableSizes[ts.sourceTableName(table)],
 			Phase:           phase,
 		}
 	}
@@ -1967,31 +2456,38 @@ func (s *Server) WorkflowUpdate(ctx context.Context, req *vtctldatapb.WorkflowUp
 // otherwise we create empty ones.
 // For a migrate workflow we do not copy the vschema since the source keyspace is just a
 // proxy to import data into Vitess.
-func (s *Server) addTablesToVSchema(ctx context.Context, sourceKeyspace string, targetVSchema *vschemapb.Keyspace, tables []string, copyVSchema bool) error {
+func (s *Server) addTablesToVSchema(ctx context.Context, sourceKeyspace string, targetVSchema *vschemapb.Keyspace, tables []string, renamedTables map[string]string, copyVSchema bool) error {
 	if targetVSchema.Tables == nil {
 		targetVSchema.Tables = make(map[string]*vschemapb.Table)
 	}
+	targetTableName := func(table string) string {
+		if targetTable, ok := renamedTables[table]; ok {
+			return targetTable
+		}
+		return table
+	}
 	if copyVSchema {
 		srcVSchema, err := s.ts.GetVSchema(ctx, sourceKeyspace)
 		if err != nil {
 			return vterrors.Wrapf(err, "failed to get vschema for source keyspace %s", sourceKeyspace)
 		}
 		for _, table := range tables {
+			targetTable := targetTableName(table)
 			srcTable, sok := srcVSchema.Tables[table]
-			if _, tok := targetVSchema.Tables[table]; sok && !tok {
-				targetVSchema.Tables[table] = srcTable
+			if _, tok := targetVSchema.Tables[targetTable]; sok && !tok {
+				targetVSchema.Tables[targetTable] = srcTable
 				// If going from sharded to unsharded, then we need to remove the
 				// column vindexes as they are not valid for unsharded tables.
 				if srcVSchema.Sharded {
-					targetVSchema.Tables[table].ColumnVindexes = nil
+					targetVSchema.Tables[targetTable].ColumnVindexes = nil
 				}
 			}
 		}
 	}
 	// Ensure that each table at least has an empty definition on the target.
 	for _, table := range tables {
-		if _, tok := targetVSchema.Tables[table]; !tok {
-			targetVSchema.Tables[table] = &vschemapb.Table{}
+		if _, tok := targetVSchema.Tables[targetTableName(table)]; !tok {
+			targetVSchema.Tables[targetTableName(table)] = &vschemapb.Table{}
 		}
 	}
 	return nil
@@ -2317,6 +2813,17 @@ func (s *Server) buildTrafficSwitcher(ctx context.Context, targetKeyspace, workf
 			if ts.tables == nil {
 				for _, rule := range bls.Filter.Rules {
 					ts.tables = append(ts.tables, rule.Match)
+					if ts.workflowType != binlogdatapb.VReplicationWorkflowType_MoveTables || strings.HasPrefix(rule.Match, "/") {
+						continue
+					}
+					// MoveTables workflows can rename the tables they move, in which
+					// case the filter selects from a table with a different name.
+					if sourceTable, err := s.env.Parser().TableFromStatement(rule.Filter); err == nil && sourceTable.Name.String() != rule.Match {
+						if ts.renamedTables == nil {
+							ts.renamedTables = make(map[string]string)
+						}
+						ts.renamedTables[rule.Match] = sourceTable.Name.String()
+					}
 				}
 				sort.Strings(ts.tables)
 			} else {
@@ -2717,6 +3224,7 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	span.Annotate("enable-reverse-replication", req.EnableReverseReplication)
 	span.Annotate("shards", req.Shards)
 	span.Annotate("force", req.Force)
+	span.Annotate("ramp-percentages", req.RampPercentages)
 
 	var (
 		dryRunResults                              []string
@@ -2752,6 +3260,9 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 	}
 	ts, startState, err := s.getWorkflowState(ctx, req.Keyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.Keyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupSwitchTraffic(ctx, req, members)
+		}
 		return nil, err
 	}
 
@@ -2794,6 +3305,12 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 		direction = DirectionForward
 	}
 
+	if len(req.RampPercentages) > 0 {
+		if err := validateRamp(req, ts, startState, direction); err != nil {
+			return nil, err
+		}
+	}
+
 	// Lock the workflow for the traffic switching work.
 	lockName := fmt.Sprintf("%s/%s", ts.TargetKeyspaceName(), ts.WorkflowName())
 	ctx, workflowUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowSwitchTraffic")
@@ -2819,6 +3336,17 @@ func (s *Server) WorkflowSwitchTraffic(ctx context.Context, req *vtctldatapb.Wor
 		}
 	}
 
+	if len(req.RampPercentages) > 0 {
+		var rampDryRunResults *[]string
+		if rampDryRunResults, err = s.rampReads(ctx, req, ts); err != nil {
+			return nil, err
+		}
+		if rampDryRunResults != nil {
+			dryRunResults = append(dryRunResults, *rampDryRunResults...)
+		}
+		s.Logger().Infof("Ramp of the reads done for workflow %s.%s", req.Keyspace, req.Workflow)
+	}
+
 	if switchReplica || switchRdonly {
 		// If we're going to switch writes immediately after then we don't need to
 		// rebuild the SrvVSchema here as we will do it after switching writes.
@@ -3144,7 +3672,12 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 	sequenceMetadata := make(map[string]*sequenceMetadata)
 	// For sharded to sharded migrations the sequence must already be setup.
 	// For reshards the sequence usage is not changed.
-	if req.InitializeTargetSequences && ts.workflowType == binlogdatapb.VReplicationWorkflowType_MoveTables &&
+	// When the workflow replaced the auto_increment clauses with sequences,
+	// they are always initialized as the target cannot accept writes without
+	// them.
+	initializeTargetSequences := req.InitializeTargetSequences ||
+		ts.options.GetShardedAutoIncrementHandling() == vtctldatapb.ShardedAutoIncrementHandling_REPLACE
+	if initializeTargetSequences && ts.workflowType == binlogdatapb.VReplicationWorkflowType_MoveTables &&
 		ts.SourceKeyspaceSchema() != nil && ts.SourceKeyspaceSchema().Keyspace != nil &&
 		!ts.SourceKeyspaceSchema().Keyspace.Sharded {
 		sequenceMetadata, err = ts.getTargetSequenceMetadata(ctx)
@@ -3277,7 +3810,7 @@ func (s *Server) switchWrites(ctx context.Context, req *vtctldatapb.WorkflowSwit
 			return handleError("locks were lost", err)
 		}
 		// Initialize any target sequences, if there are any, before allowing new writes.
-		if req.InitializeTargetSequences && len(sequenceMetadata) > 0 {
+		if initializeTargetSequences && len(sequenceMetadata) > 0 {
 			ts.Logger().Infof("Initializing target sequences")
 			// Writes are blocked so we can safely initialize the sequence tables but
 			// we also want to use a shorter timeout than the the default.
@@ -3364,6 +3897,9 @@ func (s *Server) canSwitch(ctx context.Context, ts *trafficSwitcher, maxAllowedR
 			if st.Message == Frozen {
 				return cannotSwitchFrozen, nil
 			}
+			if st.Message == binlogplayer.PausedMessage {
+				return cannotSwitchPaused, nil
+			}
 			switch st.State {
 			case binlogdatapb.VReplicationWorkflowState_Copying.String():
 				return cannotSwitchCopyIncomplete, nil
@@ -1744,6 +1744,36 @@ func TestMoveTablesTrafficSwitchingDryRun(t *testing.T) {
 				"Unlock keyspace " + targetKeyspaceName,
 			},
 		},
+		{
+			name: "forward for read-only tablets with a ramp",
+			sourceKeyspace: &testKeyspace{
+				KeyspaceName: sourceKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			targetKeyspace: &testKeyspace{
+				KeyspaceName: targetKeyspaceName,
+				ShardNames:   []string{"-80", "80-"},
+			},
+			req: &vtctldatapb.WorkflowSwitchTrafficRequest{
+				Keyspace:        targetKeyspaceName,
+				Workflow:        workflowName,
+				Direction:       int32(DirectionForward),
+				TabletTypes:     roTabletTypes,
+				DryRun:          true,
+				RampPercentages: []float32{10, 50},
+			},
+			want: []string{
+				fmt.Sprintf("Serving 10.00 percent of traffic from keyspace %s by keyspace %s for tablet types [REPLICA,RDONLY]", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Serving 50.00 percent of traffic from keyspace %s by keyspace %s for tablet types [REPLICA,RDONLY]", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Serving 0.00 percent of traffic from keyspace %s by keyspace %s for tablet types [REPLICA,RDONLY]", sourceKeyspaceName, targetKeyspaceName),
+				"Lock keyspace " + sourceKeyspaceName,
+				fmt.Sprintf("Mirroring 0.00 percent of traffic from keyspace %s to keyspace %s for tablet types [REPLICA,RDONLY]", sourceKeyspaceName, targetKeyspaceName),
+				fmt.Sprintf("Switch reads for tables [%s] to keyspace %s for tablet types [REPLICA,RDONLY]", tablesStr, targetKeyspaceName),
+				fmt.Sprintf("Routing rules for tables [%s] will be updated", tablesStr),
+				fmt.Sprintf("Serving VSchema will be rebuilt for the %s keyspace", targetKeyspaceName),
+				"Unlock keyspace " + sourceKeyspaceName,
+			},
+		},
 		{
 			name: "backward for read-only tablets",
 			sourceKeyspace: &testKeyspace{
@@ -2929,6 +2959,29 @@ func TestMoveTablesPreventsSourceEqualsTarget(t *testing.T) {
 	require.ErrorContains(t, err, "source and target keyspace must be different for MoveTables workflows")
 }
 
+func TestRematerializeOnDDLOnlyForMaterialize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "cell1")
+	s := NewServer(vtenv.NewTestEnv(), ts, nil)
+
+	_, err := s.MoveTablesCreate(ctx, &vtctldatapb.MoveTablesCreateRequest{
+		SourceKeyspace: "ks1",
+		TargetKeyspace: "ks2",
+		Workflow:       "wf1",
+		OnDdl:          binlogdatapb.OnDDLAction_REMATERIALIZE.String(),
+	})
+	require.ErrorContains(t, err, "the REMATERIALIZE on-ddl action is only supported for Materialize workflows")
+
+	_, err = s.ReshardCreate(ctx, &vtctldatapb.ReshardCreateRequest{
+		Keyspace: "ks1",
+		Workflow: "wf1",
+		OnDdl:    "rematerialize",
+	})
+	require.ErrorContains(t, err, "on-ddl action is only supported for Materialize workflows")
+}
+
 func TestMigrateAllowsSourceEqualsTarget(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
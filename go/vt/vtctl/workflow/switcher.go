@@ -167,3 +167,7 @@ func (r *switcher) initializeTargetSequences(ctx context.Context, sequencesByBac
 func (r *switcher) mirrorTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
 	return r.ts.mirrorTableTraffic(ctx, types, percent)
 }
+
+func (r *switcher) splitTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
+	return r.ts.splitTableTraffic(ctx, types, percent)
+}
@@ -76,6 +76,17 @@ func (dr *switcherDryRun) mirrorTableTraffic(ctx context.Context, types []topoda
 	return nil
 }
 
+func (dr *switcherDryRun) splitTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
+	var tabletTypes []string
+	for _, servedType := range types {
+		tabletTypes = append(tabletTypes, servedType.String())
+	}
+	dr.drLog.Logf("Serving %.2f percent of traffic from keyspace %s by keyspace %s for tablet types [%s]",
+		percent, dr.ts.SourceKeyspaceName(), dr.ts.TargetKeyspaceName(), strings.Join(tabletTypes, ","))
+
+	return nil
+}
+
 func (dr *switcherDryRun) switchKeyspaceReads(ctx context.Context, types []topodatapb.TabletType) error {
 	var tabletTypes []string
 	for _, servedType := range types {
@@ -323,7 +334,7 @@ func (dr *switcherDryRun) removeSourceTables(ctx context.Context, removalType Ta
 		return sources[i].GetPrimary().Alias.Uid < sources[j].GetPrimary().Alias.Uid
 	})
 	for _, source := range sources {
-		for _, tableName := range dr.ts.Tables() {
+		for _, tableName := range dr.ts.sourceTables() {
 			logs = append(logs, fmt.Sprintf("keyspace:%s;shard:%s;dbname:%s;tablet:%d;table:%s",
 				source.GetPrimary().Keyspace, source.GetPrimary().Shard, source.GetPrimary().DbName(), source.GetPrimary().Alias.Uid, tableName))
 		}
@@ -238,6 +238,7 @@ type trafficSwitcher struct {
 	sourceKeyspace   string
 	targetKeyspace   string
 	tables           []string
+	renamedTables    map[string]string // target table name -> source table name, for the tables renamed by the workflow
 	keepRoutingRules bool
 	sourceKSSchema   *vindexes.KeyspaceSchema
 	optCells         string // cells option passed to MoveTables/Reshard Create
@@ -277,6 +278,27 @@ func (ts *trafficSwitcher) WorkflowName() string                           { ret
 func (ts *trafficSwitcher) SourceTimeZone() string                         { return ts.sourceTimeZone }
 func (ts *trafficSwitcher) TargetTimeZone() string                         { return ts.targetTimeZone }
 
+// sourceTableName returns the name of the given target table in the source
+// keyspace, which differs when the workflow renames the table.
+func (ts *trafficSwitcher) sourceTableName(table string) string {
+	if sourceTable, ok := ts.renamedTables[table]; ok {
+		return sourceTable
+	}
+	return table
+}
+
+// sourceTables returns the names of the tables in the source keyspace.
+func (ts *trafficSwitcher) sourceTables() []string {
+	if len(ts.renamedTables) == 0 {
+		return ts.tables
+	}
+	tables := make([]string, 0, len(ts.tables))
+	for _, table := range ts.tables {
+		tables = append(tables, ts.sourceTableName(table))
+	}
+	return tables
+}
+
 func (ts *trafficSwitcher) ForAllSources(f func(source *MigrationSource) error) error {
 	var wg sync.WaitGroup
 	allErrors := &concurrency.AllErrorRecorder{}
@@ -414,15 +436,19 @@ func (ts *trafficSwitcher) deleteRoutingRules(ctx context.Context) error {
 		return err
 	}
 	for _, table := range ts.Tables() {
+		sourceTable := ts.sourceTableName(table)
 		delete(rules, table)
 		delete(rules, table+"@replica")
 		delete(rules, table+"@rdonly")
+		delete(rules, sourceTable)
+		delete(rules, sourceTable+"@replica")
+		delete(rules, sourceTable+"@rdonly")
 		delete(rules, ts.TargetKeyspaceName()+"."+table)
 		delete(rules, ts.TargetKeyspaceName()+"."+table+"@replica")
 		delete(rules, ts.TargetKeyspaceName()+"."+table+"@rdonly")
-		delete(rules, ts.SourceKeyspaceName()+"."+table)
-		delete(rules, ts.SourceKeyspaceName()+"."+table+"@replica")
-		delete(rules, ts.SourceKeyspaceName()+"."+table+"@rdonly")
+		delete(rules, ts.SourceKeyspaceName()+"."+sourceTable)
+		delete(rules, ts.SourceKeyspaceName()+"."+sourceTable+"@replica")
+		delete(rules, ts.SourceKeyspaceName()+"."+sourceTable+"@rdonly")
 	}
 	if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
 		return err
@@ -469,7 +495,7 @@ func (ts *trafficSwitcher) deleteKeyspaceRoutingRules(ctx context.Context) error
 func (ts *trafficSwitcher) dropSourceDeniedTables(ctx context.Context) error {
 	return ts.ForAllSources(func(source *MigrationSource) error {
 		if _, err := ts.TopoServer().UpdateShardFields(ctx, ts.SourceKeyspaceName(), source.GetShard().ShardName(), func(si *topo.ShardInfo) error {
-			return si.UpdateDeniedTables(ctx, topodatapb.TabletType_PRIMARY, nil, true, ts.Tables())
+			return si.UpdateDeniedTables(ctx, topodatapb.TabletType_PRIMARY, nil, true, ts.sourceTables())
 		}); err != nil {
 			return err
 		}
@@ -531,7 +557,11 @@ func (ts *trafficSwitcher) dropParticipatingTablesFromKeyspace(ctx context.Conte
 	if vschema.Sharded && keyspace == ts.TargetKeyspaceName() {
 		return nil
 	}
-	for _, tableName := range ts.Tables() {
+	tables := ts.Tables()
+	if keyspace == ts.SourceKeyspaceName() {
+		tables = ts.sourceTables()
+	}
+	for _, tableName := range tables {
 		delete(vschema.Tables, tableName)
 	}
 	return ts.TopoServer().SaveVSchema(ctx, vschema)
@@ -539,7 +569,7 @@ func (ts *trafficSwitcher) dropParticipatingTablesFromKeyspace(ctx context.Conte
 
 func (ts *trafficSwitcher) removeSourceTables(ctx context.Context, removalType TableRemovalType) error {
 	err := ts.ForAllSources(func(source *MigrationSource) error {
-		for _, tableName := range ts.Tables() {
+		for _, tableName := range ts.sourceTables() {
 			primaryDbName, err := sqlescape.EnsureEscaped(source.GetPrimary().DbName())
 			if err != nil {
 				return err
@@ -659,17 +689,15 @@ func (ts *trafficSwitcher) switchTableReads(ctx context.Context, cells []string,
 		}
 		tt := strings.ToLower(servedType.String())
 		for _, table := range ts.Tables() {
-			if direction == DirectionForward {
-				toTarget := []string{ts.TargetKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toTarget
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toTarget
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toTarget
-			} else {
-				toSource := []string{ts.SourceKeyspaceName() + "." + table}
-				rules[table+"@"+tt] = toSource
-				rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = toSource
-				rules[ts.SourceKeyspaceName()+"."+table+"@"+tt] = toSource
+			sourceTable := ts.sourceTableName(table)
+			to := []string{ts.TargetKeyspaceName() + "." + table}
+			if direction == DirectionBackward {
+				to = []string{ts.SourceKeyspaceName() + "." + sourceTable}
 			}
+			rules[table+"@"+tt] = to
+			rules[sourceTable+"@"+tt] = to
+			rules[ts.TargetKeyspaceName()+"."+table+"@"+tt] = to
+			rules[ts.SourceKeyspaceName()+"."+sourceTable+"@"+tt] = to
 		}
 	}
 	if err := topotools.SaveRoutingRules(ctx, ts.TopoServer(), rules); err != nil {
@@ -788,11 +816,13 @@ func (ts *trafficSwitcher) changeWriteRoute(ctx context.Context) error {
 			return err
 		}
 		for _, table := range ts.Tables() {
+			sourceTable := ts.sourceTableName(table)
 			targetKsTable := fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), table)
-			sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
+			sourceKsTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), sourceTable)
 			delete(rules, targetKsTable)
 			ts.Logger().Infof("Deleted routing: %s", targetKsTable)
 			rules[table] = []string{targetKsTable}
+			rules[sourceTable] = []string{targetKsTable}
 			rules[sourceKsTable] = []string{targetKsTable}
 			ts.Logger().Infof("Added routing: %v %v", table, sourceKsTable)
 		}
@@ -914,6 +944,9 @@ func (ts *trafficSwitcher) createReverseVReplication(ctx context.Context) error
 				continue
 			}
 			var filter string
+			// The reverse stream reads from the target table and writes to the
+			// source one, whose name differs if the table was renamed.
+			match := ts.sourceTableName(rule.Match)
 			if strings.HasPrefix(rule.Match, "/") {
 				if ts.SourceKeyspaceSchema().Keyspace.Sharded {
 					filter = key.KeyRangeString(source.GetShard().KeyRange)
@@ -921,9 +954,9 @@ func (ts *trafficSwitcher) createReverseVReplication(ctx context.Context) error
 			} else {
 				var inKeyrange string
 				if ts.SourceKeyspaceSchema().Keyspace.Sharded {
-					vtable, ok := ts.SourceKeyspaceSchema().Tables[rule.Match]
+					vtable, ok := ts.SourceKeyspaceSchema().Tables[match]
 					if !ok {
-						return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "table %s not found in vschema", rule.Match)
+						return vterrors.Errorf(vtrpcpb.Code_INTERNAL, "table %s not found in vschema", match)
 					}
 					// We currently assume the primary vindex is the best way to filter rows
 					// for the table, which may not always be true.
@@ -952,7 +985,7 @@ func (ts *trafficSwitcher) createReverseVReplication(ctx context.Context) error
 				}
 			}
 			reverseBls.Filter.Rules = append(reverseBls.Filter.Rules, &binlogdatapb.Rule{
-				Match:  rule.Match,
+				Match:  match,
 				Filter: filter,
 			})
 		}
@@ -1088,7 +1121,7 @@ func (ts *trafficSwitcher) switchDeniedTables(ctx context.Context, backward bool
 	egrp.Go(func() error {
 		return ts.ForAllSources(func(source *MigrationSource) error {
 			if _, err := ts.TopoServer().UpdateShardFields(ctx, ts.SourceKeyspaceName(), source.GetShard().ShardName(), func(si *topo.ShardInfo) error {
-				return si.UpdateDeniedTables(ectx, topodatapb.TabletType_PRIMARY, nil, rmsource, ts.Tables())
+				return si.UpdateDeniedTables(ectx, topodatapb.TabletType_PRIMARY, nil, rmsource, ts.sourceTables())
 			}); err != nil {
 				return err
 			}
@@ -1471,7 +1504,7 @@ func (ts *trafficSwitcher) executeLockTablesOnSource(ctx context.Context) error
 
 	sb := strings.Builder{}
 	sb.WriteString("LOCK TABLES ")
-	for _, tableName := range ts.Tables() {
+	for _, tableName := range ts.sourceTables() {
 		sb.WriteString(sqlescape.EscapeID(tableName) + " READ,")
 	}
 	// trim extra trailing comma
@@ -1549,7 +1582,7 @@ func (ts *trafficSwitcher) mirrorTableTraffic(ctx context.Context, types []topod
 	var numExisting int
 	for _, table := range ts.tables {
 		for _, tabletType := range types {
-			fromTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table)
+			fromTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), ts.sourceTableName(table))
 			if tabletType != topodatapb.TabletType_PRIMARY {
 				fromTable = fmt.Sprintf("%s@%s", fromTable, topoproto.TabletTypeLString(tabletType))
 			}
@@ -1587,3 +1620,25 @@ func (ts *trafficSwitcher) mirrorTableTraffic(ctx context.Context, types []topod
 
 	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
 }
+
+// splitTableTraffic serves the percent of the traffic on the source tables
+// for the tablet types from the target tables, with split mirror rules. A
+// percent of 0 removes the split rules.
+func (ts *trafficSwitcher) splitTableTraffic(ctx context.Context, types []topodatapb.TabletType, percent float32) error {
+	rules := make(map[string]string)
+	for _, table := range ts.tables {
+		for _, tabletType := range types {
+			fromTable := fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), ts.sourceTableName(table))
+			if tabletType != topodatapb.TabletType_PRIMARY {
+				fromTable = fmt.Sprintf("%s@%s", fromTable, topoproto.TabletTypeLString(tabletType))
+			}
+			rules[fromTable] = fmt.Sprintf("%s.%s", ts.TargetKeyspaceName(), table)
+		}
+	}
+
+	if err := topotools.SaveSplitRules(ctx, ts.TopoServer(), rules, percent); err != nil {
+		return err
+	}
+
+	return ts.TopoServer().RebuildSrvVSchema(ctx, nil)
+}
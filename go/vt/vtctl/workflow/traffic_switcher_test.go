@@ -618,3 +618,47 @@ func TestDeleteKeyspaceRoutingRulesPreservesUnrelated(t *testing.T) {
 		"otherks": "otherks",
 	}, got)
 }
+
+func TestRenamedTablesRouting(t *testing.T) {
+	ctx := t.Context()
+	env := newTestEnv(t, ctx, defaultCellName, &testKeyspace{KeyspaceName: "sourceks", ShardNames: []string{"0"}},
+		&testKeyspace{KeyspaceName: "targetks", ShardNames: []string{"0"}})
+	defer env.close()
+
+	ts := &trafficSwitcher{
+		ws:             env.ws,
+		migrationType:  binlogdata.MigrationType_TABLES,
+		sourceKeyspace: "sourceks",
+		targetKeyspace: "targetks",
+		tables:         []string{"t1_v2", "t2"},
+		renamedTables:  map[string]string{"t1_v2": "t1"},
+	}
+	require.Equal(t, []string{"t1", "t2"}, ts.sourceTables())
+
+	err := ts.switchTableReads(ctx, nil, []topodatapb.TabletType{topodatapb.TabletType_REPLICA}, false, DirectionForward)
+	require.NoError(t, err)
+	err = ts.changeWriteRoute(ctx)
+	require.NoError(t, err)
+	rules, err := topotools.GetRoutingRules(ctx, env.ts)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"t1":                     {"targetks.t1_v2"},
+		"t1@replica":             {"targetks.t1_v2"},
+		"t1_v2":                  {"targetks.t1_v2"},
+		"t1_v2@replica":          {"targetks.t1_v2"},
+		"sourceks.t1":            {"targetks.t1_v2"},
+		"sourceks.t1@replica":    {"targetks.t1_v2"},
+		"targetks.t1_v2@replica": {"targetks.t1_v2"},
+		"t2":                     {"targetks.t2"},
+		"t2@replica":             {"targetks.t2"},
+		"sourceks.t2":            {"targetks.t2"},
+		"sourceks.t2@replica":    {"targetks.t2"},
+		"targetks.t2@replica":    {"targetks.t2"},
+	}, rules)
+
+	err = ts.deleteRoutingRules(ctx)
+	require.NoError(t, err)
+	rules, err = topotools.GetRoutingRules(ctx, env.ts)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+}
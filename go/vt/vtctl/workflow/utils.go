@@ -220,6 +220,21 @@ func stripTableForeignKeys(ddl string, parser *sqlparser.Parser) (string, error)
 	return newDDL, nil
 }
 
+// renameCreateTable changes the name of the table created by the given
+// CREATE TABLE statement.
+func renameCreateTable(ddl, table string, parser *sqlparser.Parser) (string, error) {
+	ast, err := parser.ParseStrictDDL(ddl)
+	if err != nil {
+		return "", err
+	}
+	createTable, ok := ast.(*sqlparser.CreateTable)
+	if !ok {
+		return "", fmt.Errorf("expected a CREATE TABLE statement, got: %s", ddl)
+	}
+	createTable.Table = sqlparser.TableName{Name: sqlparser.NewIdentifierCS(table)}
+	return sqlparser.String(createTable), nil
+}
+
 // stripAutoIncrement will strip any MySQL auto_increment clause in the given
 // table definition. If an optional replace function is specified then that
 // callback will be used to e.g. replace the MySQL clause with a Vitess
@@ -591,7 +606,7 @@ func doValidateWorkflowHasCompleted(ctx context.Context, ts *trafficSwitcher) er
 			for fromTable, toTables := range rules {
 				for _, toTable := range toTables {
 					for _, table := range ts.Tables() {
-						if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), table) {
+						if toTable == fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), ts.sourceTableName(table)) {
 							rec.RecordError(fmt.Errorf("routing still exists from keyspace %s table %s to %s", ts.SourceKeyspaceName(), table, fromTable))
 						}
 					}
@@ -1018,6 +1033,38 @@ func applyTargetShards(ts *trafficSwitcher, targetShards []string) error {
 	return nil
 }
 
+// ParseTableMappings parses the tables of a MoveTables workflow. A table can
+// be given as source_table:target_table to rename it in the target keyspace.
+// It returns the source table names, along with the target table name of each
+// renamed source table.
+func ParseTableMappings(tables []string) ([]string, map[string]string, error) {
+	sourceTables := make([]string, 0, len(tables))
+	renamedTables := make(map[string]string)
+	targetTables := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		sourceTable, targetTable, renamed := strings.Cut(table, ":")
+		sourceTable, targetTable = strings.TrimSpace(sourceTable), strings.TrimSpace(targetTable)
+		if !renamed {
+			targetTable = sourceTable
+		}
+		if sourceTable == "" || targetTable == "" {
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid table mapping %q, expected source_table[:target_table]", table)
+		}
+		if slices.Contains(sourceTables, sourceTable) {
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "table %s is specified more than once", sourceTable)
+		}
+		if targetTables[targetTable] {
+			return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "more than one table is moved to the target table %s", targetTable)
+		}
+		sourceTables = append(sourceTables, sourceTable)
+		targetTables[targetTable] = true
+		if targetTable != sourceTable {
+			renamedTables[sourceTable] = targetTable
+		}
+	}
+	return sourceTables, renamedTables, nil
+}
+
 // validateSourceTablesExist validates that tables provided are present
 // in the source keyspace.
 func validateSourceTablesExist(sourceKeyspace string, ksTables, tables []string) error {
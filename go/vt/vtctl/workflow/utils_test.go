@@ -19,6 +19,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/testfiles"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/etcd2topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
@@ -281,6 +282,65 @@ func TestValidateSourceTablesExist(t *testing.T) {
 	}
 }
 
+func TestParseTableMappings(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tables        []string
+		sourceTables  []string
+		renamedTables map[string]string
+		errContains   string
+	}{
+		{
+			name:          "no renames",
+			tables:        []string{"t1", "t2"},
+			sourceTables:  []string{"t1", "t2"},
+			renamedTables: map[string]string{},
+		},
+		{
+			name:          "renames",
+			tables:        []string{"orders:orders_v2", "users", "t1:t1"},
+			sourceTables:  []string{"orders", "users", "t1"},
+			renamedTables: map[string]string{"orders": "orders_v2"},
+		},
+		{
+			name:        "missing target table",
+			tables:      []string{"orders:"},
+			errContains: "invalid table mapping",
+		},
+		{
+			name:        "duplicate source table",
+			tables:      []string{"orders:orders_v2", "orders"},
+			errContains: "table orders is specified more than once",
+		},
+		{
+			name:        "duplicate target table",
+			tables:      []string{"orders:users", "users"},
+			errContains: "more than one table is moved to the target table users",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sourceTables, renamedTables, err := ParseTableMappings(tc.tables)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.sourceTables, sourceTables)
+			assert.Equal(t, tc.renamedTables, renamedTables)
+		})
+	}
+}
+
+func TestRenameCreateTable(t *testing.T) {
+	ddl, err := renameCreateTable("CREATE TABLE `orders` (`id` bigint NOT NULL, PRIMARY KEY (`id`))", "orders_v2", sqlparser.NewTestParser())
+	require.NoError(t, err)
+	assert.Equal(t, "create table orders_v2 (\n\tid bigint not null,\n\tprimary key (id)\n)", ddl)
+
+	_, err = renameCreateTable("CREATE VIEW v1 AS SELECT 1", "v2", sqlparser.NewTestParser())
+	assert.ErrorContains(t, err, "expected a CREATE TABLE statement")
+}
+
 func TestLegacyBuildTargets(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
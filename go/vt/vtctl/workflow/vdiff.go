@@ -48,7 +48,11 @@ type TableSummary struct {
 	MismatchedRows  int64
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
-	LastUpdated     string `json:"LastUpdated,omitempty"`
+	// SamplePercent is set when only a sample of the table was diffed, in
+	// which case EstimatedMismatchedRows is an estimate for the whole table.
+	SamplePercent           int64  `json:"SamplePercent,omitempty"`
+	EstimatedMismatchedRows int64  `json:"EstimatedMismatchedRows,omitempty"`
+	LastUpdated             string `json:"LastUpdated,omitempty"`
 }
 
 // Summary aggregates the current state of the vdiff from all shards.
@@ -219,6 +223,10 @@ func BuildSummary(keyspace, workflow, uuid string, resp *vtctldatapb.VDiffShowRe
 						ts.MatchingRows += dr.MatchingRows
 						ts.ExtraRowsTarget += dr.ExtraRowsTarget
 						ts.ExtraRowsSource += dr.ExtraRowsSource
+						if dr.SamplePercent > 0 {
+							ts.SamplePercent = dr.SamplePercent
+							ts.EstimatedMismatchedRows += dr.EstimatedMismatchedRows
+						}
 					}
 					if _, ok := reports[table]; !ok {
 						reports[table] = make(map[string]vdiff.DiffReport)
@@ -324,6 +332,13 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 		span.Annotate("auto_start", req.GetAutoStart())
 	}
 
+	return s.vdiffCreate(ctx, req, nil)
+}
+
+// vdiffCreate creates the VDiff of the request. The target tables in
+// sharedTables also hold the rows of other workflows, so the target rows of
+// those tables which are not on the source are not differences.
+func (s *Server) vdiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRequest, sharedTables []string) (*vtctldatapb.VDiffCreateResponse, error) {
 	var err error
 	req.Uuid = strings.TrimSpace(req.Uuid)
 	if req.Uuid == "" { // Generate a UUID
@@ -377,6 +392,7 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 			UpdateTableStats:      req.UpdateTableStats,
 			MaxDiffSeconds:        req.MaxDiffDuration.Seconds,
 			AutoStart:             &autoStart,
+			SharedTables:          sharedTables,
 		},
 		ReportOptions: &tabletmanagerdatapb.VDiffReportOptions{
 			OnlyPks:                 req.OnlyPKs,
@@ -396,6 +412,9 @@ func (s *Server) VDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRe
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.TargetKeyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupVDiffCreate(ctx, req, members)
+		}
 		return nil, err
 	}
 	if ts.frozen {
@@ -445,6 +464,9 @@ func (s *Server) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRe
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.TargetKeyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupVDiffDelete(ctx, req, members)
+		}
 		return nil, err
 	}
 
@@ -460,6 +482,62 @@ func (s *Server) VDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRe
 	return &vtctldatapb.VDiffDeleteResponse{}, nil
 }
 
+// VDiffRepair is part of the vtctlservicepb.VtctldServer interface.
+func (s *Server) VDiffRepair(ctx context.Context, req *vtctldatapb.VDiffRepairRequest) (*vtctldatapb.VDiffRepairResponse, error) {
+	span, ctx := trace.NewSpan(ctx, "workflow.Server.VDiffRepair")
+	defer span.Finish()
+
+	targetShards := req.GetTargetShards()
+
+	span.Annotate("keyspace", req.TargetKeyspace)
+	span.Annotate("workflow", req.Workflow)
+	span.Annotate("uuid", req.Uuid)
+	span.Annotate("target_shards", targetShards)
+	span.Annotate("apply", req.Apply)
+
+	actionArg := vdiff.RepairDryRunActionArg
+	if req.Apply {
+		actionArg = vdiff.RepairApplyActionArg
+	}
+	tabletreq := &tabletmanagerdatapb.VDiffRequest{
+		Keyspace:  req.TargetKeyspace,
+		Workflow:  req.Workflow,
+		Action:    string(vdiff.RepairAction),
+		ActionArg: actionArg,
+		VdiffUuid: req.Uuid,
+	}
+
+	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targetShards) > 0 {
+		if err := applyTargetShards(ts, targetShards); err != nil {
+			return nil, err
+		}
+	}
+
+	output := &vdiffOutput{
+		responses: make(map[string]*tabletmanagerdatapb.VDiffResponse, len(ts.targets)),
+		err:       nil,
+	}
+	output.err = ts.ForAllTargets(func(target *MigrationTarget) error {
+		resp, err := s.tmc.VDiff(ctx, target.GetPrimary().Tablet, tabletreq)
+		output.mu.Lock()
+		defer output.mu.Unlock()
+		output.responses[target.GetShard().ShardName()] = resp
+		return err
+	})
+	if output.err != nil {
+		s.Logger().Errorf("Error executing vdiff repair action: %v", output.err)
+		return nil, output.err
+	}
+	return &vtctldatapb.VDiffRepairResponse{
+		TabletResponses: output.responses,
+	}, nil
+}
+
 // VDiffResume is part of the vtctlservicepb.VtctldServer interface.
 func (s *Server) VDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRequest) (*vtctldatapb.VDiffResumeResponse, error) {
 	span, ctx := trace.NewSpan(ctx, "workflow.Server.VDiffResume")
@@ -481,6 +559,9 @@ func (s *Server) VDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRe
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.TargetKeyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupVDiffResume(ctx, req, members)
+		}
 		return nil, err
 	}
 
@@ -520,6 +601,9 @@ func (s *Server) VDiffShow(ctx context.Context, req *vtctldatapb.VDiffShowReques
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.TargetKeyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupVDiffShow(ctx, req, members)
+		}
 		return nil, err
 	}
 
@@ -564,6 +648,9 @@ func (s *Server) VDiffStop(ctx context.Context, req *vtctldatapb.VDiffStopReques
 
 	ts, err := s.buildTrafficSwitcher(ctx, req.TargetKeyspace, req.Workflow)
 	if err != nil {
+		if members := s.workflowGroup(ctx, req.TargetKeyspace, req.Workflow, err); len(members) > 0 {
+			return s.workflowGroupVDiffStop(ctx, req, members)
+		}
 		return nil, err
 	}
 
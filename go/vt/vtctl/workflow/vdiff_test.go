@@ -25,7 +25,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
 
+	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -421,6 +423,99 @@ func TestVDiffStop(t *testing.T) {
 	}
 }
 
+func TestVDiffRepair(t *testing.T) {
+	ctx := context.Background()
+	sourceKeyspace := &testKeyspace{
+		KeyspaceName: "sourceks",
+		ShardNames:   []string{"0"},
+	}
+	targetKeyspace := &testKeyspace{
+		KeyspaceName: "targetks",
+		ShardNames:   []string{"-80", "80-"},
+	}
+	workflow := "testwf"
+	uuid := uuid.New().String()
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	env.tmc.strict = true
+	action := string(vdiff.RepairAction)
+	output := &querypb.QueryResult{Fields: []*querypb.Field{{Name: "table_name", Type: sqltypes.VarChar}}}
+
+	tests := []struct {
+		name                  string
+		req                   *vtctldatapb.VDiffRepairRequest              // vtctld requests
+		expectedVDiffRequests map[*topodatapb.Tablet]*vdiffRequestResponse // tablet requests
+		wantShards            []string
+	}{
+		{
+			name: "dry run", // Both target shards
+			req: &vtctldatapb.VDiffRepairRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				Workflow:       workflow,
+				Uuid:           uuid,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    action,
+						ActionArg: vdiff.RepairDryRunActionArg,
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID+tabletUIDStep]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    action,
+						ActionArg: vdiff.RepairDryRunActionArg,
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+			},
+			wantShards: []string{"-80", "80-"},
+		},
+		{
+			name: "apply on first shard",
+			req: &vtctldatapb.VDiffRepairRequest{
+				TargetKeyspace: targetKeyspace.KeyspaceName,
+				TargetShards:   targetKeyspace.ShardNames[:1],
+				Workflow:       workflow,
+				Uuid:           uuid,
+				Apply:          true,
+			},
+			expectedVDiffRequests: map[*topodatapb.Tablet]*vdiffRequestResponse{
+				env.tablets[targetKeyspace.KeyspaceName][startingTargetTabletUID]: {
+					req: &tabletmanagerdatapb.VDiffRequest{
+						Keyspace:  targetKeyspace.KeyspaceName,
+						Workflow:  workflow,
+						Action:    action,
+						ActionArg: vdiff.RepairApplyActionArg,
+						VdiffUuid: uuid,
+					},
+					res: &tabletmanagerdatapb.VDiffResponse{Output: output},
+				},
+			},
+			wantShards: []string{"-80"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for tab, vdr := range tt.expectedVDiffRequests {
+				env.tmc.expectVDiffRequest(tab, vdr)
+			}
+			got, err := env.ws.VDiffRepair(ctx, tt.req)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.wantShards, maps.Keys(got.TabletResponses))
+			env.tmc.confirmVDiffRequests(t)
+		})
+	}
+}
+
 func TestVDiffDelete(t *testing.T) {
 	ctx := context.Background()
 	sourceKeyspace := &testKeyspace{
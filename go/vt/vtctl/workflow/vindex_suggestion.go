@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+// VindexSuggestion is a proposed primary vindex for a table that is being moved
+// into a sharded keyspace.
+type VindexSuggestion struct {
+	Table      string
+	Column     string
+	VindexType string
+	// Reason is a human readable explanation of why the column was chosen.
+	Reason string
+}
+
+// SuggestVindexes proposes a primary vindex for each of the given source tables that
+// does not yet have one in the given target keyspace vschema. Candidate columns come
+// from the table's primary and unique keys, and they are ranked by how often they are
+// used in equality predicates of the given queries, which map a query to the number of
+// times it was seen (e.g. the query consolidator stats of the source tablets). Ties,
+// including the case where no query stats are available, are broken in favor of the
+// primary key.
+//
+// The returned draft vschema is a copy of the target vschema with the suggested vindexes
+// added, and is meant to be reviewed before being applied. Tables for which no candidate
+// column could be found are returned separately.
+func SuggestVindexes(env *vtenv.Environment, targetVSchema *vschemapb.Keyspace, tables []*tabletmanagerdatapb.TableDefinition,
+	queryCounts map[string]int64) (*vschemapb.Keyspace, []*VindexSuggestion, []string, error) {
+	draft := &vschemapb.Keyspace{}
+	if targetVSchema != nil {
+		draft = proto.Clone(targetVSchema).(*vschemapb.Keyspace)
+	}
+	draft.Sharded = true
+	if draft.Vindexes == nil {
+		draft.Vindexes = make(map[string]*vschemapb.Vindex)
+	}
+	if draft.Tables == nil {
+		draft.Tables = make(map[string]*vschemapb.Table)
+	}
+
+	usage := columnEqualityUsage(env.Parser(), queryCounts)
+	diffEnv := schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset())
+	var suggestions []*VindexSuggestion
+	var unresolved []string
+	for _, td := range tables {
+		if table := draft.Tables[td.Name]; table != nil && len(table.ColumnVindexes) > 0 {
+			continue
+		}
+		entity, err := schemadiff.NewCreateTableEntityFromSQL(diffEnv, td.Schema)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse schema of table %s: %v", td.Name, err)
+		}
+		suggestion := suggestVindex(entity, usage[strings.ToLower(td.Name)])
+		if suggestion == nil {
+			unresolved = append(unresolved, td.Name)
+			continue
+		}
+		suggestion.Table = td.Name
+		vindexName := draftVindexName(draft, suggestion.VindexType)
+		if draft.Vindexes[vindexName] == nil {
+			draft.Vindexes[vindexName] = &vschemapb.Vindex{Type: suggestion.VindexType}
+		}
+		table := draft.Tables[td.Name]
+		if table == nil {
+			table = &vschemapb.Table{}
+			draft.Tables[td.Name] = table
+		}
+		table.ColumnVindexes = []*vschemapb.ColumnVindex{{Column: suggestion.Column, Name: vindexName}}
+		suggestions = append(suggestions, suggestion)
+	}
+	return draft, suggestions, unresolved, nil
+}
+
+// suggestVindex picks the best vindex column of the given table, using the given per column
+// equality predicate usage counts.
+func suggestVindex(entity *schemadiff.CreateTableEntity, usage map[string]int64) *VindexSuggestion {
+	var best *VindexSuggestion
+	var bestScore int64
+	seen := make(map[string]bool)
+	for _, key := range schemadiff.PrioritizedUniqueKeys(entity).Entities {
+		if key.HasFloat() || key.HasColumnPrefix() {
+			continue
+		}
+		for _, col := range key.ColumnList.Entities {
+			if seen[col.NameLowered()] {
+				continue
+			}
+			seen[col.NameLowered()] = true
+			score := usage[col.NameLowered()]
+			if best != nil && score <= bestScore {
+				continue
+			}
+			reason := "unique key " + key.Name()
+			if key.IsPrimary() {
+				reason = "primary key"
+			}
+			if score > 0 {
+				reason = fmt.Sprintf("%s, used in %d equality predicates", reason, score)
+			}
+			best = &VindexSuggestion{
+				Column:     col.Name(),
+				VindexType: vindexTypeForColumn(col),
+				Reason:     reason,
+			}
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// vindexTypeForColumn returns the functional vindex type best suited to the given column.
+func vindexTypeForColumn(col *schemadiff.ColumnDefinitionEntity) string {
+	switch {
+	case col.IsIntegralType():
+		return "hash"
+	case col.IsTextual():
+		return "unicode_loose_xxhash"
+	default:
+		return "xxhash"
+	}
+}
+
+// draftVindexName returns the name of a vindex of the given type in the draft vschema,
+// reusing an existing parameterless vindex of that type when there is one.
+func draftVindexName(draft *vschemapb.Keyspace, vindexType string) string {
+	names := make([]string, 0, len(draft.Vindexes))
+	for name := range draft.Vindexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		vindex := draft.Vindexes[name]
+		if vindex.Type == vindexType && len(vindex.Params) == 0 && vindex.Owner == "" {
+			return name
+		}
+	}
+	name := vindexType
+	for i := 1; draft.Vindexes[name] != nil; i++ {
+		name = fmt.Sprintf("%s_%d", vindexType, i)
+	}
+	return name
+}
+
+// columnEqualityUsage returns, per lowered table and column name, how many times the column
+// is compared for equality in the WHERE clause of the given queries. Queries that cannot be
+// parsed are ignored.
+func columnEqualityUsage(parser *sqlparser.Parser, queryCounts map[string]int64) map[string]map[string]int64 {
+	usage := make(map[string]map[string]int64)
+	for query, count := range queryCounts {
+		stmt, err := parser.Parse(query)
+		if err != nil {
+			continue
+		}
+		var from []sqlparser.TableExpr
+		var where *sqlparser.Where
+		switch stmt := stmt.(type) {
+		case *sqlparser.Select:
+			from, where = stmt.From, stmt.Where
+		case *sqlparser.Update:
+			from, where = stmt.TableExprs, stmt.Where
+		case *sqlparser.Delete:
+			from, where = stmt.TableExprs, stmt.Where
+		default:
+			continue
+		}
+		if where == nil {
+			continue
+		}
+		// Map the qualifiers that can be used in the query to the table names.
+		tables := make(map[string]string)
+		var onlyTable string
+		for _, expr := range from {
+			aliased, ok := expr.(*sqlparser.AliasedTableExpr)
+			if !ok {
+				continue
+			}
+			tableName, ok := aliased.Expr.(sqlparser.TableName)
+			if !ok {
+				continue
+			}
+			name := strings.ToLower(tableName.Name.String())
+			tables[name] = name
+			if !aliased.As.IsEmpty() {
+				tables[strings.ToLower(aliased.As.String())] = name
+			}
+			onlyTable = name
+		}
+		if len(from) != 1 {
+			onlyTable = ""
+		}
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			cmp, ok := node.(*sqlparser.ComparisonExpr)
+			if !ok || (cmp.Operator != sqlparser.EqualOp && cmp.Operator != sqlparser.InOp) {
+				return true, nil
+			}
+			col, ok := cmp.Left.(*sqlparser.ColName)
+			if !ok && cmp.Operator == sqlparser.EqualOp {
+				col, ok = cmp.Right.(*sqlparser.ColName)
+			}
+			if !ok {
+				return true, nil
+			}
+			table := onlyTable
+			if !col.Qualifier.IsEmpty() {
+				table = tables[strings.ToLower(col.Qualifier.Name.String())]
+			}
+			if table == "" {
+				return true, nil
+			}
+			if usage[table] == nil {
+				usage[table] = make(map[string]int64)
+			}
+			usage[table][col.Name.Lowered()] += count
+			return true, nil
+		}, where)
+	}
+	return usage
+}
+
+// ParseConsolidations parses the output of a tablet's /debug/consolidations page, which
+// lists one `<count>: <query>` entry per line, into a map of query to count.
+func ParseConsolidations(text string) map[string]int64 {
+	queryCounts := make(map[string]int64)
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		countStr, query, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseInt(countStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		queryCounts[query] += count
+	}
+	return queryCounts
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtenv"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestSuggestVindexes(t *testing.T) {
+	tables := []*tabletmanagerdatapb.TableDefinition{
+		{
+			Name:   "customer",
+			Schema: "create table customer (customer_id bigint not null, email varchar(128) not null, primary key (customer_id), unique key email_idx (email))",
+		},
+		{
+			Name:   "corder",
+			Schema: "create table corder (order_id bigint not null, customer_id bigint not null, sku varbinary(128), primary key (order_id, customer_id))",
+		},
+		{
+			Name:   "product",
+			Schema: "create table product (sku varbinary(128) not null, description varchar(128), primary key (sku))",
+		},
+		{
+			Name:   "nokeys",
+			Schema: "create table nokeys (id int, val varchar(16))",
+		},
+		{
+			Name:   "existing",
+			Schema: "create table existing (id int primary key)",
+		},
+	}
+	targetVSchema := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"hash_vdx": {Type: "hash"},
+		},
+		Tables: map[string]*vschemapb.Table{
+			"existing": {ColumnVindexes: []*vschemapb.ColumnVindex{{Column: "id", Name: "hash_vdx"}}},
+		},
+	}
+	queryCounts := map[string]int64{
+		"select * from customer where email = :email":                          7,
+		"select * from customer where customer_id = :id":                       3,
+		"select o.sku from corder as o where o.customer_id in ::ids and 1 = 1": 5,
+		"update corder set sku = :sku where order_id = :oid":                   2,
+		"select * from corder join product on corder.sku = product.sku":        100,
+		"not a valid query": 10,
+	}
+
+	draft, suggestions, unresolved, err := SuggestVindexes(vtenv.NewTestEnv(), targetVSchema, tables, queryCounts)
+	require.NoError(t, err)
+	assert.Equal(t, []*VindexSuggestion{
+		{Table: "customer", Column: "email", VindexType: "unicode_loose_xxhash", Reason: "unique key email_idx, used in 7 equality predicates"},
+		{Table: "corder", Column: "customer_id", VindexType: "hash", Reason: "primary key, used in 5 equality predicates"},
+		{Table: "product", Column: "sku", VindexType: "xxhash", Reason: "primary key"},
+	}, suggestions)
+	assert.Equal(t, []string{"nokeys"}, unresolved)
+
+	assert.True(t, draft.Sharded)
+	assert.Equal(t, map[string]*vschemapb.Vindex{
+		"hash_vdx":             {Type: "hash"},
+		"unicode_loose_xxhash": {Type: "unicode_loose_xxhash"},
+		"xxhash":               {Type: "xxhash"},
+	}, draft.Vindexes)
+	assert.Len(t, draft.Tables, 4)
+	assert.Equal(t, []*vschemapb.ColumnVindex{{Column: "email", Name: "unicode_loose_xxhash"}}, draft.Tables["customer"].ColumnVindexes)
+	assert.Equal(t, []*vschemapb.ColumnVindex{{Column: "customer_id", Name: "hash_vdx"}}, draft.Tables["corder"].ColumnVindexes)
+	assert.Equal(t, []*vschemapb.ColumnVindex{{Column: "sku", Name: "xxhash"}}, draft.Tables["product"].ColumnVindexes)
+	// The target vschema itself is left untouched.
+	assert.Len(t, targetVSchema.Tables, 1)
+	assert.Len(t, targetVSchema.Vindexes, 1)
+}
+
+func TestParseConsolidations(t *testing.T) {
+	text := "Length: 3\n12: select * from t1 where id = :id\n3: select * from t2 where a: b = 1\nbogus line\n"
+	assert.Equal(t, map[string]int64{
+		"select * from t1 where id = :id": 12,
+		"select * from t2 where a: b = 1": 3,
+	}, ParseConsolidations(text))
+}
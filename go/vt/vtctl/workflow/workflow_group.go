@@ -0,0 +1,745 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// A workflow group is the set of MoveTables workflows which merge the tables
+// of several source keyspaces into one target keyspace. Each member workflow
+// moves the tables of one source keyspace, and is a regular MoveTables
+// workflow. The members record the name of their group in their
+// WorkflowOptions, and the group name can be used as the workflow name to
+// get the status of, switch the traffic of, VDiff, complete, or cancel all
+// of the members at once.
+
+// WorkflowGroupMemberName returns the name of the workflow of the workflow
+// group which moves the tables of the source keyspace.
+func WorkflowGroupMemberName(group, sourceKeyspace string) string {
+	return fmt.Sprintf("%s_%s", group, sourceKeyspace)
+}
+
+// workflowGroupMemberVDiffUUID returns the UUID of the VDiff of the member
+// workflow that is part of the VDiff of its workflow group with the UUID.
+// VDiff UUIDs must be unique on a tablet, so each member gets its own UUID,
+// which is derived from the group's UUID so that it can be found again.
+func workflowGroupMemberVDiffUUID(groupUUID, member string) (string, error) {
+	u, err := uuid.Parse(groupUUID)
+	if err != nil {
+		return "", vterrors.Wrapf(err, "invalid UUID provided: %s", groupUUID)
+	}
+	return uuid.NewSHA1(u, []byte(member)).String(), nil
+}
+
+// getWorkflowGroupMembers returns the names of the member workflows of the
+// workflow group in the keyspace, in sorted order.
+func (s *Server) getWorkflowGroupMembers(ctx context.Context, keyspace, group string) ([]string, error) {
+	w := &workflowFetcher{
+		ts:     s.ts,
+		tmc:    s.tmc,
+		parser: s.SQLParser(),
+		logger: s.Logger(),
+	}
+	workflowsByShard, err := w.fetchWorkflowsByShard(ctx, &vtctldatapb.GetWorkflowsRequest{Keyspace: keyspace})
+	if err != nil {
+		return nil, err
+	}
+	var members []string
+	for _, res := range workflowsByShard {
+		for _, wf := range res.GetWorkflows() {
+			if wf.Options == "" || slices.Contains(members, wf.Workflow) {
+				continue
+			}
+			options := &vtctldatapb.WorkflowOptions{}
+			if err := json.Unmarshal([]byte(wf.Options), options); err != nil {
+				return nil, err
+			}
+			if options.WorkflowGroup == group {
+				members = append(members, wf.Workflow)
+			}
+		}
+	}
+	slices.Sort(members)
+	return members, nil
+}
+
+// workflowGroup returns the members of the workflow group with the workflow
+// name in the keyspace if err shows that there is no workflow with that name.
+// It returns nil if there is no such group.
+func (s *Server) workflowGroup(ctx context.Context, keyspace, workflow string, err error) []string {
+	if !errors.Is(err, ErrNoStreams) {
+		return nil
+	}
+	members, gerr := s.getWorkflowGroupMembers(ctx, keyspace, workflow)
+	if gerr != nil {
+		s.Logger().Warningf("Failed to look for a workflow group named %s in keyspace %s: %v", workflow, keyspace, gerr)
+		return nil
+	}
+	return members
+}
+
+// moveTablesCreateGroup creates a workflow group with a MoveTables workflow
+// for each of the source keyspaces of the request. If the creation of one of
+// them fails, the ones which were already created are deleted.
+func (s *Server) moveTablesCreateGroup(ctx context.Context, req *vtctldatapb.MoveTablesCreateRequest) (res *vtctldatapb.WorkflowStatusResponse, err error) {
+	switch {
+	case req.SourceKeyspace != "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source_keyspace and source_keyspaces cannot both be specified")
+	case len(req.SourceKeyspaces) < 2:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "at least two source keyspaces are needed to merge tables into the target keyspace")
+	case req.ExternalClusterName != "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables cannot be merged from an external cluster")
+	case len(req.SourceShards) > 0:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables cannot be merged in a shard-by-shard migration")
+	case req.GetWorkflowOptions().GetTenantId() != "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "tables cannot be merged in a multi-tenant migration")
+	}
+	for i, sourceKeyspace := range req.SourceKeyspaces {
+		if sourceKeyspace == req.TargetKeyspace {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source and target keyspace must be different for MoveTables workflows")
+		}
+		if slices.Contains(req.SourceKeyspaces[:i], sourceKeyspace) {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "source keyspace %s is specified more than once", sourceKeyspace)
+		}
+	}
+
+	members, err := s.getWorkflowGroupMembers(ctx, req.TargetKeyspace, req.Workflow)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_ALREADY_EXISTS, "workflow group %s already exists in keyspace %s", req.Workflow, req.TargetKeyspace)
+	}
+
+	created := false
+	defer func() {
+		if err == nil || created {
+			return
+		}
+		for _, member := range members {
+			if _, cerr := s.WorkflowDelete(ctx, &vtctldatapb.WorkflowDeleteRequest{Keyspace: req.TargetKeyspace, Workflow: member}); cerr != nil {
+				err = vterrors.Wrapf(err, "failed to cleanup the %s workflow: %v", member, cerr)
+			}
+		}
+	}()
+	for _, sourceKeyspace := range req.SourceKeyspaces {
+		memberReq := req.CloneVT()
+		memberReq.SourceKeyspaces = nil
+		memberReq.SourceKeyspace = sourceKeyspace
+		memberReq.Workflow = WorkflowGroupMemberName(req.Workflow, sourceKeyspace)
+		if memberReq.WorkflowOptions == nil {
+			memberReq.WorkflowOptions = &vtctldatapb.WorkflowOptions{}
+		}
+		memberReq.WorkflowOptions.WorkflowGroup = req.Workflow
+		if _, err = s.moveTablesCreate(ctx, memberReq, binlogdatapb.VReplicationWorkflowType_MoveTables); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to create the %s workflow for source keyspace %s", memberReq.Workflow, sourceKeyspace)
+		}
+		members = append(members, memberReq.Workflow)
+	}
+	if !req.NoRoutingRules {
+		if err = s.updateWorkflowGroupRoutingRules(ctx, req.TargetKeyspace, members); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to merge the routing rules of workflow group %s.%s", req.TargetKeyspace, req.Workflow)
+		}
+	}
+	created = true
+	return s.workflowGroupStatus(ctx, &vtctldatapb.WorkflowStatusRequest{Keyspace: req.TargetKeyspace, Workflow: req.Workflow}, members)
+}
+
+// workflowGroupStatus returns the combined status of the members of the
+// workflow group.
+func (s *Server) workflowGroupStatus(ctx context.Context, req *vtctldatapb.WorkflowStatusRequest, members []string) (*vtctldatapb.WorkflowStatusResponse, error) {
+	responses := make(map[string]*vtctldatapb.WorkflowStatusResponse, len(members))
+	for _, member := range members {
+		res, err := s.WorkflowStatus(ctx, &vtctldatapb.WorkflowStatusRequest{
+			Keyspace: req.Keyspace,
+			Workflow: member,
+			Shards:   req.Shards,
+		})
+		if err != nil {
+			return nil, err
+		}
+		responses[member] = res
+	}
+	return mergeWorkflowGroupStatus(members, responses), nil
+}
+
+// mergeWorkflowGroupStatus merges the statuses of the members of a workflow
+// group. The copy state of a table that is merged from several source
+// keyspaces counts the rows and bytes of all of them.
+func mergeWorkflowGroupStatus(members []string, responses map[string]*vtctldatapb.WorkflowStatusResponse) *vtctldatapb.WorkflowStatusResponse {
+	resp := &vtctldatapb.WorkflowStatusResponse{
+		ShardStreams: make(map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams),
+	}
+	trafficStates := make([]string, 0, len(members))
+	for _, member := range members {
+		res := responses[member]
+		for table, state := range res.TableCopyState {
+			if resp.TableCopyState == nil {
+				resp.TableCopyState = make(map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState)
+			}
+			merged, ok := resp.TableCopyState[table]
+			if !ok {
+				resp.TableCopyState[table] = state.CloneVT()
+				continue
+			}
+			// The rows and bytes copied are those of the target table, which
+			// are the same for all of the members.
+			merged.RowsTotal += state.RowsTotal
+			merged.BytesTotal += state.BytesTotal
+			merged.RowsPercentage = copyPercentage(merged.RowsCopied, merged.RowsTotal)
+			merged.BytesPercentage = copyPercentage(merged.BytesCopied, merged.BytesTotal)
+			if state.Phase != vtctldatapb.TableCopyPhase_COMPLETE {
+				merged.Phase = state.Phase
+			}
+		}
+		for ksShard, streams := range res.ShardStreams {
+			merged, ok := resp.ShardStreams[ksShard]
+			if !ok {
+				merged = &vtctldatapb.WorkflowStatusResponse_ShardStreams{}
+				resp.ShardStreams[ksShard] = merged
+			}
+			merged.Streams = append(merged.Streams, streams.Streams...)
+		}
+		trafficStates = append(trafficStates, fmt.Sprintf("%s: %s", member, res.TrafficState))
+	}
+	resp.TrafficState = strings.Join(trafficStates, "; ")
+	return resp
+}
+
+func copyPercentage(copied, total int64) float32 {
+	if total <= 0 {
+		return 100.0
+	}
+	return float32(100.0 * float64(copied) / float64(total))
+}
+
+// getWorkflowGroupMergedTables returns the target tables which more than one
+// member of the workflow group moves into.
+func (s *Server) getWorkflowGroupMergedTables(ctx context.Context, keyspace string, members []string) ([]string, error) {
+	sources, err := s.getWorkflowGroupMergedSources(ctx, keyspace, members)
+	if err != nil {
+		return nil, err
+	}
+	merged := make([]string, 0, len(sources))
+	for table := range sources {
+		merged = append(merged, table)
+	}
+	slices.Sort(merged)
+	return merged, nil
+}
+
+// getWorkflowGroupMergedSources returns the source tables, qualified by their
+// keyspace, of each target table which more than one member of the workflow
+// group moves into. The source tables are in the order of the members.
+func (s *Server) getWorkflowGroupMergedSources(ctx context.Context, keyspace string, members []string) (map[string][]string, error) {
+	sources := make(map[string][]string)
+	for _, member := range members {
+		ts, err := s.buildTrafficSwitcher(ctx, keyspace, member)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range ts.Tables() {
+			sources[table] = append(sources[table], fmt.Sprintf("%s.%s", ts.SourceKeyspaceName(), ts.sourceTableName(table)))
+		}
+	}
+	for table, tableSources := range sources {
+		if len(tableSources) < 2 {
+			delete(sources, table)
+		}
+	}
+	return sources, nil
+}
+
+// updateWorkflowGroupRoutingRules merges the routing rules of the members of
+// the workflow group for the tables which they merge. Each member routes the
+// unqualified and the target keyspace names of its tables to its own source
+// keyspace until its traffic is switched, so the members overwrite each
+// other's rules for the tables which they share.
+func (s *Server) updateWorkflowGroupRoutingRules(ctx context.Context, keyspace string, members []string) error {
+	sources, err := s.getWorkflowGroupMergedSources(ctx, keyspace, members)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	rules, err := topotools.GetRoutingRules(ctx, s.ts)
+	if err != nil {
+		return err
+	}
+	mergeWorkflowGroupRoutingRules(rules, keyspace, sources)
+	if err := topotools.SaveRoutingRules(ctx, s.ts, rules); err != nil {
+		return err
+	}
+	return s.ts.RebuildSrvVSchema(ctx, nil)
+}
+
+// mergeWorkflowGroupRoutingRules routes the unqualified and the target
+// keyspace names of each merged table, for each tablet type, to the target
+// keyspace once the traffic of any of the members is switched for the tablet
+// type, as the rows of that member are then only in the target keyspace.
+// Until then they are routed to the source table of the first member. The
+// rules of the source keyspace names are left as each member set them.
+func mergeWorkflowGroupRoutingRules(rules map[string][]string, targetKeyspace string, sources map[string][]string) {
+	for table, tableSources := range sources {
+		targetTable := fmt.Sprintf("%s.%s", targetKeyspace, table)
+		for _, suffix := range tabletTypeSuffixes {
+			to := tableSources[0]
+			for _, source := range tableSources {
+				if route := rules[source+suffix]; len(route) == 1 && route[0] == targetTable {
+					to = targetTable
+					break
+				}
+			}
+			rules[table+suffix] = []string{to}
+			rules[targetTable+suffix] = []string{to}
+		}
+	}
+}
+
+// workflowGroupSwitchTraffic switches the traffic of all of the members of
+// the workflow group. When both reads and writes are switched forward, the
+// reads of all of the members are switched before the writes of any of them,
+// so that all of the source keyspaces stop taking writes together. If the
+// traffic of a member cannot be switched, the traffic of the members which
+// were switched is switched back.
+func (s *Server) workflowGroupSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, members []string) (resp *vtctldatapb.WorkflowSwitchTrafficResponse, err error) {
+	if len(req.RampPercentages) > 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "reads cannot be ramped for workflow group %s.%s", req.Keyspace, req.Workflow)
+	}
+	switchReplica, switchRdonly, switchPrimary, err := parseTabletTypes(req.TabletTypes)
+	if err != nil {
+		return nil, err
+	}
+	direction := TrafficSwitchDirection(req.Direction)
+	if direction == DirectionForward && switchPrimary && req.EnableReverseReplication {
+		merged, err := s.getWorkflowGroupMergedTables(ctx, req.Keyspace, members)
+		if err != nil {
+			return nil, err
+		}
+		if len(merged) > 0 {
+			// The reverse workflow of each member would copy the rows of all of
+			// the source keyspaces into its own source keyspace.
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+				"tables %s of workflow group %s.%s are merged from multiple source keyspaces, so writes can only be switched with reverse replication disabled",
+				strings.Join(merged, ","), req.Keyspace, req.Workflow)
+		}
+	}
+
+	cmd := "SwitchTraffic"
+	if direction == DirectionBackward {
+		cmd = "ReverseTraffic"
+	}
+	resp = &vtctldatapb.WorkflowSwitchTrafficResponse{}
+	if req.DryRun {
+		for _, member := range members {
+			memberReq := req.CloneVT()
+			memberReq.Workflow = member
+			res, err := s.WorkflowSwitchTraffic(ctx, memberReq)
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "failed to switch the traffic of the %s workflow", member)
+			}
+			resp.DryRunResults = append(resp.DryRunResults, fmt.Sprintf("Workflow %s:", member))
+			resp.DryRunResults = append(resp.DryRunResults, res.DryRunResults...)
+		}
+		resp.Summary = fmt.Sprintf("%s dry run results for workflow group %s.%s at %v",
+			cmd, req.Keyspace, req.Workflow, time.Now().UTC().Format(time.RFC822))
+		return resp, nil
+	}
+
+	lockName := fmt.Sprintf("%s/%s", req.Keyspace, req.Workflow)
+	ctx, groupUnlock, lockErr := s.ts.LockName(ctx, lockName, "WorkflowSwitchTraffic")
+	if lockErr != nil {
+		return nil, vterrors.Wrapf(lockErr, "failed to lock the %s workflow group", lockName)
+	}
+	defer groupUnlock(&err)
+
+	phases := [][]topodatapb.TabletType{req.TabletTypes}
+	if direction == DirectionForward && switchPrimary && (switchReplica || switchRdonly) {
+		var readTypes []topodatapb.TabletType
+		for _, tabletType := range req.TabletTypes {
+			if tabletType != topodatapb.TabletType_PRIMARY {
+				readTypes = append(readTypes, tabletType)
+			}
+		}
+		phases = [][]topodatapb.TabletType{readTypes, {topodatapb.TabletType_PRIMARY}}
+	}
+
+	var done []switchedGroupMember
+	startStates := make(map[string]string, len(members))
+	currentStates := make(map[string]string, len(members))
+	for _, tabletTypes := range phases {
+		for _, member := range members {
+			memberReq := req.CloneVT()
+			memberReq.Workflow = member
+			memberReq.TabletTypes = tabletTypes
+			res, err := s.WorkflowSwitchTraffic(ctx, memberReq)
+			if err != nil {
+				err = vterrors.Wrapf(err, "failed to switch the traffic of the %s workflow", member)
+				return nil, s.revertWorkflowGroupSwitchTraffic(ctx, req, members, done, err)
+			}
+			done = append(done, switchedGroupMember{member: member, tabletTypes: tabletTypes})
+			if _, ok := startStates[member]; !ok {
+				startStates[member] = res.StartState
+			}
+			currentStates[member] = res.CurrentState
+		}
+	}
+	if err := s.updateWorkflowGroupRoutingRules(ctx, req.Keyspace, members); err != nil {
+		err = vterrors.Wrapf(err, "failed to merge the routing rules of workflow group %s.%s", req.Keyspace, req.Workflow)
+		return nil, s.revertWorkflowGroupSwitchTraffic(ctx, req, members, done, err)
+	}
+
+	var startState, currentState []string
+	for _, member := range members {
+		startState = append(startState, fmt.Sprintf("%s: %s", member, startStates[member]))
+		currentState = append(currentState, fmt.Sprintf("%s: %s", member, currentStates[member]))
+	}
+	resp.Summary = fmt.Sprintf("%s was successful for workflow group %s.%s", cmd, req.Keyspace, req.Workflow)
+	resp.StartState = strings.Join(startState, "\n")
+	resp.CurrentState = strings.Join(currentState, "\n")
+	return resp, nil
+}
+
+// switchedGroupMember is a member of a workflow group whose traffic for the
+// tablet types was switched.
+type switchedGroupMember struct {
+	member      string
+	tabletTypes []topodatapb.TabletType
+}
+
+// revertWorkflowGroupSwitchTraffic switches back the traffic of the members
+// of a workflow group which was switched before the switch failed with err.
+func (s *Server) revertWorkflowGroupSwitchTraffic(ctx context.Context, req *vtctldatapb.WorkflowSwitchTrafficRequest, members []string, done []switchedGroupMember, err error) error {
+	direction := DirectionBackward
+	if TrafficSwitchDirection(req.Direction) == DirectionBackward {
+		direction = DirectionForward
+	}
+	for i := len(done) - 1; i >= 0; i-- {
+		revertReq := req.CloneVT()
+		revertReq.Workflow = done[i].member
+		revertReq.TabletTypes = done[i].tabletTypes
+		revertReq.Direction = int32(direction)
+		if _, rerr := s.WorkflowSwitchTraffic(ctx, revertReq); rerr != nil {
+			s.Logger().Errorf("Failed to switch back the %s traffic of the %s workflow: %v",
+				topoproto.MakeStringTypeCSV(done[i].tabletTypes), done[i].member, rerr)
+			err = vterrors.Wrapf(err, "failed to switch back the traffic of the %s workflow: %v", done[i].member, rerr)
+		}
+	}
+	if rerr := s.updateWorkflowGroupRoutingRules(ctx, req.Keyspace, members); rerr != nil {
+		s.Logger().Errorf("Failed to merge the routing rules of workflow group %s.%s: %v", req.Keyspace, req.Workflow, rerr)
+		err = vterrors.Wrapf(err, "failed to merge the routing rules of workflow group %s.%s: %v", req.Keyspace, req.Workflow, rerr)
+	}
+	return err
+}
+
+// workflowGroupComplete completes all of the members of the workflow group,
+// once the traffic of all of them has been switched.
+func (s *Server) workflowGroupComplete(ctx context.Context, req *vtctldatapb.MoveTablesCompleteRequest, members []string) (resp *vtctldatapb.MoveTablesCompleteResponse, err error) {
+	opts := []WorkflowActionOption{}
+	if req.IgnoreSourceKeyspace {
+		opts = append(opts, IgnoreSourceKeyspace())
+	}
+	for _, member := range members {
+		_, state, err := s.getWorkflowState(ctx, req.TargetKeyspace, member, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if !state.WritesSwitched || len(state.ReplicaCellsNotSwitched) > 0 || len(state.RdonlyCellsNotSwitched) > 0 {
+			return nil, vterrors.Wrapf(ErrWorkflowCompleteNotFullySwitched, "workflow %s", member)
+		}
+	}
+
+	lockName := fmt.Sprintf("%s/%s", req.TargetKeyspace, req.Workflow)
+	ctx, groupUnlock, lockErr := s.ts.LockName(ctx, lockName, "MoveTablesComplete")
+	if lockErr != nil {
+		return nil, vterrors.Wrapf(lockErr, "failed to lock the %s workflow group", lockName)
+	}
+	defer groupUnlock(&err)
+
+	resp = &vtctldatapb.MoveTablesCompleteResponse{}
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		res, err := s.MoveTablesComplete(ctx, memberReq)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to complete the %s workflow", member)
+		}
+		resp.DryRunResults = append(resp.DryRunResults, res.DryRunResults...)
+	}
+	if req.DryRun {
+		resp.Summary = fmt.Sprintf("Complete dry run results for workflow group %s.%s at %v", req.TargetKeyspace, req.Workflow, time.Now().UTC().Format(time.RFC822))
+	} else {
+		resp.Summary = fmt.Sprintf("Successfully completed the %s workflow group in the %s keyspace", req.Workflow, req.TargetKeyspace)
+	}
+	return resp, nil
+}
+
+// workflowGroupDelete deletes all of the members of the workflow group, as
+// long as the writes of none of them have been switched.
+func (s *Server) workflowGroupDelete(ctx context.Context, req *vtctldatapb.WorkflowDeleteRequest, members []string) (*vtctldatapb.WorkflowDeleteResponse, error) {
+	opts := []WorkflowActionOption{}
+	if req.IgnoreSourceKeyspace {
+		opts = append(opts, IgnoreSourceKeyspace())
+	}
+	for _, member := range members {
+		_, state, err := s.getWorkflowState(ctx, req.Keyspace, member, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if state.WritesSwitched {
+			return nil, vterrors.Wrapf(ErrWorkflowDeleteWritesSwitched, "workflow %s", member)
+		}
+	}
+
+	resp := &vtctldatapb.WorkflowDeleteResponse{}
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		res, err := s.WorkflowDelete(ctx, memberReq)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to cancel the %s workflow", member)
+		}
+		resp.Details = append(resp.Details, res.Details...)
+	}
+	sort.Slice(resp.Details, func(i, j int) bool {
+		return topoproto.TabletAliasString(resp.Details[i].Tablet) < topoproto.TabletAliasString(resp.Details[j].Tablet)
+	})
+	resp.Summary = fmt.Sprintf("Successfully cancelled the %s workflow group in the %s keyspace", req.Workflow, req.Keyspace)
+	return resp, nil
+}
+
+// workflowGroupVDiffCreate creates a VDiff of each of the members of the
+// workflow group, with UUIDs derived from the UUID of the request. The target
+// tables which the members merge hold the rows of all of the members, so the
+// VDiff of each member only diffs the rows of its own source keyspace.
+func (s *Server) workflowGroupVDiffCreate(ctx context.Context, req *vtctldatapb.VDiffCreateRequest, members []string) (*vtctldatapb.VDiffCreateResponse, error) {
+	merged, err := s.getWorkflowGroupMergedTables(ctx, req.TargetKeyspace, members)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		memberUUID, err := workflowGroupMemberVDiffUUID(req.Uuid, member)
+		if err != nil {
+			return nil, err
+		}
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		memberReq.Uuid = memberUUID
+		if _, err := s.vdiffCreate(ctx, memberReq, merged); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to create the VDiff of the %s workflow", member)
+		}
+	}
+	return &vtctldatapb.VDiffCreateResponse{
+		UUID: req.Uuid,
+	}, nil
+}
+
+// workflowGroupVDiffArg returns the VDiff action argument for the member of
+// a workflow group: the UUID of the member's VDiff if arg is the UUID of a
+// VDiff of the group, and arg otherwise.
+func workflowGroupVDiffArg(arg, member string) string {
+	if uuid.Validate(arg) != nil {
+		return arg
+	}
+	memberUUID, err := workflowGroupMemberVDiffUUID(arg, member)
+	if err != nil {
+		return arg
+	}
+	return memberUUID
+}
+
+// workflowGroupVDiffShow shows the VDiffs of the members of the workflow
+// group together. The responses are keyed by member/shard, so that they are
+// summarized like the shards of a single VDiff. The rows of the merged tables
+// which are on a target shard but on none of the source keyspaces are
+// reported as extra target rows.
+func (s *Server) workflowGroupVDiffShow(ctx context.Context, req *vtctldatapb.VDiffShowRequest, members []string) (*vtctldatapb.VDiffShowResponse, error) {
+	resp := &vtctldatapb.VDiffShowResponse{
+		TabletResponses: make(map[string]*tabletmanagerdatapb.VDiffResponse),
+	}
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		memberReq.Arg = workflowGroupVDiffArg(req.Arg, member)
+		res, err := s.VDiffShow(ctx, memberReq)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to show the VDiff of the %s workflow", member)
+		}
+		for shard, tabletResp := range res.TabletResponses {
+			resp.TabletResponses[fmt.Sprintf("%s/%s", member, shard)] = tabletResp
+		}
+	}
+	if err := accountWorkflowGroupVDiffRows(members, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// workflowGroupTableDiff is the diff of a merged table on a target shard by
+// the VDiff of a member of a workflow group.
+type workflowGroupTableDiff struct {
+	key    string
+	qr     *sqltypes.Result
+	row    int
+	report vdiff.DiffReport
+}
+
+// accountWorkflowGroupVDiffRows accounts for the rows of the tables which the
+// members of a workflow group merge. The VDiff of each member does not count
+// the target rows which are not on its source as extra rows, as they can be
+// the rows of the other members. So once the diff of a table is completed by
+// all of the members on a target shard, the rows of the shard which none of
+// the members found on its source are added to the extra target rows of the
+// first member. The diffs of the members are not taken at the same time, so
+// the fewest rows that any of them found on the shard are used.
+func accountWorkflowGroupVDiffRows(members []string, resp *vtctldatapb.VDiffShowResponse) error {
+	results := make(map[string]*sqltypes.Result, len(resp.TabletResponses))
+	diffs := make(map[string][]*workflowGroupTableDiff) // Keyed by shard/table
+	var keys []string
+	for _, member := range members {
+		var memberKeys []string
+		for key, tabletResp := range resp.TabletResponses {
+			if strings.HasPrefix(key, member+"/") && tabletResp.GetOutput() != nil {
+				memberKeys = append(memberKeys, key)
+			}
+		}
+		slices.Sort(memberKeys)
+		keys = append(keys, memberKeys...)
+	}
+	for _, key := range keys {
+		_, shard, _ := strings.Cut(key, "/")
+		qr := sqltypes.Proto3ToResult(resp.TabletResponses[key].Output)
+		results[key] = qr
+		for i, row := range qr.Named().Rows {
+			table := row.AsString("table_name", "")
+			report := row.AsString("report", "")
+			if table == "" || report == "" {
+				continue
+			}
+			diff := &workflowGroupTableDiff{key: key, qr: qr, row: i}
+			if err := json.Unmarshal([]byte(report), &diff.report); err != nil {
+				return err
+			}
+			if !strings.EqualFold(row.AsString("table_state", ""), string(vdiff.CompletedState)) || diff.report.SamplePercent > 0 {
+				diff = nil
+			}
+			diffs[shard+"/"+table] = append(diffs[shard+"/"+table], diff)
+		}
+	}
+
+	changed := make(map[string]bool)
+	for _, tableDiffs := range diffs {
+		if len(tableDiffs) < 2 || slices.Contains(tableDiffs, nil) {
+			continue
+		}
+		var sourceRows int64
+		targetRows := int64(math.MaxInt64)
+		for _, diff := range tableDiffs {
+			matched := diff.report.MatchingRows + diff.report.MismatchedRows
+			sourceRows += matched
+			targetRows = min(targetRows, matched+diff.report.ExtraRowsTarget+diff.report.ExtraRowsTargetOtherSources)
+		}
+		if targetRows <= sourceRows {
+			continue
+		}
+		first := tableDiffs[0]
+		first.report.ExtraRowsTarget += targetRows - sourceRows
+		report, err := json.Marshal(first.report)
+		if err != nil {
+			return err
+		}
+		for i, field := range first.qr.Fields {
+			switch field.Name {
+			case "report":
+				first.qr.Rows[first.row][i] = sqltypes.MakeTrusted(field.Type, report)
+			case "has_mismatch":
+				first.qr.Rows[first.row][i] = sqltypes.MakeTrusted(field.Type, []byte("1"))
+			}
+		}
+		changed[first.key] = true
+	}
+	for key := range changed {
+		resp.TabletResponses[key].Output = sqltypes.ResultToProto3(results[key])
+	}
+	return nil
+}
+
+// workflowGroupVDiffDelete deletes the VDiffs of the members of the workflow
+// group.
+func (s *Server) workflowGroupVDiffDelete(ctx context.Context, req *vtctldatapb.VDiffDeleteRequest, members []string) (*vtctldatapb.VDiffDeleteResponse, error) {
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		memberReq.Arg = workflowGroupVDiffArg(req.Arg, member)
+		if _, err := s.VDiffDelete(ctx, memberReq); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to delete the VDiff of the %s workflow", member)
+		}
+	}
+	return &vtctldatapb.VDiffDeleteResponse{}, nil
+}
+
+// workflowGroupVDiffResume resumes the VDiffs of the members of the workflow
+// group.
+func (s *Server) workflowGroupVDiffResume(ctx context.Context, req *vtctldatapb.VDiffResumeRequest, members []string) (*vtctldatapb.VDiffResumeResponse, error) {
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		memberReq.Uuid = workflowGroupVDiffArg(req.Uuid, member)
+		if _, err := s.VDiffResume(ctx, memberReq); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to resume the VDiff of the %s workflow", member)
+		}
+	}
+	return &vtctldatapb.VDiffResumeResponse{}, nil
+}
+
+// workflowGroupVDiffStop stops the VDiffs of the members of the workflow
+// group.
+func (s *Server) workflowGroupVDiffStop(ctx context.Context, req *vtctldatapb.VDiffStopRequest, members []string) (*vtctldatapb.VDiffStopResponse, error) {
+	for _, member := range members {
+		memberReq := req.CloneVT()
+		memberReq.Workflow = member
+		memberReq.Uuid = workflowGroupVDiffArg(req.Uuid, member)
+		if _, err := s.VDiffStop(ctx, memberReq); err != nil {
+			return nil, vterrors.Wrapf(err, "failed to stop the VDiff of the %s workflow", member)
+		}
+	}
+	return &vtctldatapb.VDiffStopResponse{}, nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestMoveTablesCreateGroupValidation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	ts := memorytopo.NewServer(ctx, "cell1")
+	s := NewServer(vtenv.NewTestEnv(), ts, nil)
+
+	testCases := []struct {
+		name    string
+		req     *vtctldatapb.MoveTablesCreateRequest
+		wantErr string
+	}{
+		{
+			name: "source keyspace and source keyspaces",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspace:  "ks1",
+				SourceKeyspaces: []string{"ks1", "ks2"},
+			},
+			wantErr: "source_keyspace and source_keyspaces cannot both be specified",
+		},
+		{
+			name: "single source keyspace",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspaces: []string{"ks1"},
+			},
+			wantErr: "at least two source keyspaces are needed to merge tables into the target keyspace",
+		},
+		{
+			name: "source shards",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspaces: []string{"ks1", "ks2"},
+				SourceShards:    []string{"-80"},
+			},
+			wantErr: "tables cannot be merged in a shard-by-shard migration",
+		},
+		{
+			name: "tenant id",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspaces: []string{"ks1", "ks2"},
+				WorkflowOptions: &vtctldatapb.WorkflowOptions{TenantId: "1"},
+			},
+			wantErr: "tables cannot be merged in a multi-tenant migration",
+		},
+		{
+			name: "source is target",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspaces: []string{"ks1", "ks3"},
+			},
+			wantErr: "source and target keyspace must be different for MoveTables workflows",
+		},
+		{
+			name: "duplicate source",
+			req: &vtctldatapb.MoveTablesCreateRequest{
+				SourceKeyspaces: []string{"ks1", "ks2", "ks1"},
+			},
+			wantErr: "source keyspace ks1 is specified more than once",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.Workflow = "wf1"
+			tc.req.TargetKeyspace = "ks3"
+			_, err := s.MoveTablesCreate(ctx, tc.req)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestGetWorkflowGroupMembers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	sourceKeyspace := &testKeyspace{"sourceks", []string{"0"}}
+	targetKeyspace := &testKeyspace{"targetks", []string{"-80", "80-"}}
+	env := newTestEnv(t, ctx, defaultCellName, sourceKeyspace, targetKeyspace)
+	defer env.close()
+
+	workflow := func(name, options string) *tabletmanagerdatapb.ReadVReplicationWorkflowResponse {
+		return &tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+			Workflow:     name,
+			WorkflowType: binlogdatapb.VReplicationWorkflowType_MoveTables,
+			Options:      options,
+		}
+	}
+	for _, shard := range targetKeyspace.ShardNames {
+		env.tmc.AddVReplicationWorkflowsResponse(env.tmc.GetWorkflowKey(targetKeyspace.KeyspaceName, shard), &tabletmanagerdatapb.ReadVReplicationWorkflowsResponse{
+			Workflows: []*tabletmanagerdatapb.ReadVReplicationWorkflowResponse{
+				workflow("merge_ks2", `{"workflow_group": "merge"}`),
+				workflow("merge_ks1", `{"workflow_group": "merge"}`),
+				workflow("other_ks1", `{"workflow_group": "other"}`),
+				workflow("merge", ""),
+			},
+		})
+	}
+
+	members, err := env.ws.getWorkflowGroupMembers(ctx, targetKeyspace.KeyspaceName, "merge")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"merge_ks1", "merge_ks2"}, members)
+	assert.Equal(t, "merge_ks1", WorkflowGroupMemberName("merge", "ks1"))
+
+	// Only a missing workflow is looked up as a workflow group.
+	assert.Nil(t, env.ws.workflowGroup(ctx, targetKeyspace.KeyspaceName, "merge", assert.AnError))
+}
+
+func TestMergeWorkflowGroupStatus(t *testing.T) {
+	shardStreams := func(sourceKeyspace string) map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams {
+		return map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams{
+			"targetks/-80": {
+				Streams: []*vtctldatapb.WorkflowStatusResponse_ShardStreamState{{Id: 1, SourceShard: sourceKeyspace + "/0"}},
+			},
+		}
+	}
+	responses := map[string]*vtctldatapb.WorkflowStatusResponse{
+		"merge_ks1": {
+			TableCopyState: map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState{
+				"t1": {RowsCopied: 50, RowsTotal: 100, RowsPercentage: 50, BytesCopied: 500, BytesTotal: 1000, BytesPercentage: 50, Phase: vtctldatapb.TableCopyPhase_COMPLETE},
+				"t2": {RowsCopied: 10, RowsTotal: 10, RowsPercentage: 100, BytesCopied: 100, BytesTotal: 100, BytesPercentage: 100, Phase: vtctldatapb.TableCopyPhase_COMPLETE},
+			},
+			ShardStreams: shardStreams("ks1"),
+			TrafficState: "Reads Not Switched. Writes Not Switched",
+		},
+		"merge_ks2": {
+			TableCopyState: map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState{
+				"t1": {RowsCopied: 50, RowsTotal: 100, RowsPercentage: 50, BytesCopied: 500, BytesTotal: 1000, BytesPercentage: 50, Phase: vtctldatapb.TableCopyPhase_IN_PROGRESS},
+			},
+			ShardStreams: shardStreams("ks2"),
+			TrafficState: "Reads Not Switched. Writes Not Switched",
+		},
+	}
+
+	got := mergeWorkflowGroupStatus([]string{"merge_ks1", "merge_ks2"}, responses)
+	utils.MustMatch(t, &vtctldatapb.WorkflowStatusResponse{
+		TableCopyState: map[string]*vtctldatapb.WorkflowStatusResponse_TableCopyState{
+			"t1": {RowsCopied: 50, RowsTotal: 200, RowsPercentage: 25, BytesCopied: 500, BytesTotal: 2000, BytesPercentage: 25, Phase: vtctldatapb.TableCopyPhase_IN_PROGRESS},
+			"t2": {RowsCopied: 10, RowsTotal: 10, RowsPercentage: 100, BytesCopied: 100, BytesTotal: 100, BytesPercentage: 100, Phase: vtctldatapb.TableCopyPhase_COMPLETE},
+		},
+		ShardStreams: map[string]*vtctldatapb.WorkflowStatusResponse_ShardStreams{
+			"targetks/-80": {
+				Streams: []*vtctldatapb.WorkflowStatusResponse_ShardStreamState{
+					{Id: 1, SourceShard: "ks1/0"},
+					{Id: 1, SourceShard: "ks2/0"},
+				},
+			},
+		},
+		TrafficState: "merge_ks1: Reads Not Switched. Writes Not Switched; merge_ks2: Reads Not Switched. Writes Not Switched",
+	}, got)
+	// The responses of the members are not modified.
+	assert.EqualValues(t, 100, responses["merge_ks1"].TableCopyState["t1"].RowsTotal)
+}
+
+func TestWorkflowGroupVDiffArg(t *testing.T) {
+	groupUUID := uuid.New().String()
+	ks1 := workflowGroupVDiffArg(groupUUID, "merge_ks1")
+	ks2 := workflowGroupVDiffArg(groupUUID, "merge_ks2")
+	require.NoError(t, uuid.Validate(ks1))
+	require.NoError(t, uuid.Validate(ks2))
+	assert.NotEqual(t, groupUUID, ks1)
+	assert.NotEqual(t, ks1, ks2)
+	// The UUIDs of the members can be derived again from the UUID of the group.
+	assert.Equal(t, ks1, workflowGroupVDiffArg(groupUUID, "merge_ks1"))
+
+	assert.Equal(t, "last", workflowGroupVDiffArg("last", "merge_ks1"))
+	assert.Equal(t, "all", workflowGroupVDiffArg("all", "merge_ks1"))
+}
+
+func TestMergeWorkflowGroupRoutingRules(t *testing.T) {
+	sources := map[string][]string{"t1": {"ks1.t1", "ks2.t1"}}
+	// The second member overwrote the rules of the first member when it was
+	// created, and then its reads were switched.
+	rules := map[string][]string{
+		"t1":                  {"ks2.t1"},
+		"t1@replica":          {"targetks.t1"},
+		"t1@rdonly":           {"ks2.t1"},
+		"targetks.t1":         {"ks2.t1"},
+		"targetks.t1@replica": {"targetks.t1"},
+		"targetks.t1@rdonly":  {"ks2.t1"},
+		"ks1.t1":              {"ks1.t1"},
+		"ks1.t1@replica":      {"ks1.t1"},
+		"ks1.t1@rdonly":       {"ks1.t1"},
+		"ks2.t1":              {"ks2.t1"},
+		"ks2.t1@replica":      {"targetks.t1"},
+		"ks2.t1@rdonly":       {"ks2.t1"},
+		"t2":                  {"ks1.t2"},
+	}
+	mergeWorkflowGroupRoutingRules(rules, "targetks", sources)
+	assert.Equal(t, map[string][]string{
+		"t1":                  {"ks1.t1"},
+		"t1@replica":          {"targetks.t1"},
+		"t1@rdonly":           {"ks1.t1"},
+		"targetks.t1":         {"ks1.t1"},
+		"targetks.t1@replica": {"targetks.t1"},
+		"targetks.t1@rdonly":  {"ks1.t1"},
+		"ks1.t1":              {"ks1.t1"},
+		"ks1.t1@replica":      {"ks1.t1"},
+		"ks1.t1@rdonly":       {"ks1.t1"},
+		"ks2.t1":              {"ks2.t1"},
+		"ks2.t1@replica":      {"targetks.t1"},
+		"ks2.t1@rdonly":       {"ks2.t1"},
+		"t2":                  {"ks1.t2"},
+	}, rules)
+
+	// The traffic of the second member is switched back while the one of the
+	// first member stays switched.
+	rules["ks1.t1@replica"] = []string{"targetks.t1"}
+	rules["ks2.t1@replica"] = []string{"ks2.t1"}
+	rules["t1@replica"] = []string{"ks2.t1"}
+	rules["targetks.t1@replica"] = []string{"ks2.t1"}
+	mergeWorkflowGroupRoutingRules(rules, "targetks", sources)
+	assert.Equal(t, []string{"targetks.t1"}, rules["t1@replica"])
+	assert.Equal(t, []string{"targetks.t1"}, rules["targetks.t1@replica"])
+	assert.Equal(t, []string{"ks2.t1"}, rules["ks2.t1@replica"])
+}
+
+func TestAccountWorkflowGroupVDiffRows(t *testing.T) {
+	fields := "vdiff_state|table_name|table_state|has_mismatch|report"
+	types := "varchar|varchar|varchar|int64|json"
+	output := func(state string, report string) *querypb.QueryResult {
+		return sqltypes.ResultToProto3(sqltypes.MakeTestResult(sqltypes.MakeTestFields(fields, types),
+			"completed|t1|"+state+"|0|"+report))
+	}
+	report := func(t *testing.T, resp *vtctldatapb.VDiffShowResponse, key string) (vdiff.DiffReport, bool) {
+		row := sqltypes.Proto3ToResult(resp.TabletResponses[key].Output).Named().Row()
+		dr := vdiff.DiffReport{}
+		require.NoError(t, json.Unmarshal([]byte(row.AsString("report", "")), &dr))
+		mismatch, err := row.ToBool("has_mismatch")
+		require.NoError(t, err)
+		return dr, mismatch
+	}
+	members := []string{"merge_ks1", "merge_ks2"}
+
+	testCases := []struct {
+		name          string
+		ks2State      string
+		ks2Report     string
+		wantExtraRows int64
+		wantMismatch  bool
+	}{
+		{
+			name:      "all target rows are on a source",
+			ks2State:  "completed",
+			ks2Report: `{"TableName":"t1","ProcessedRows":10,"MatchingRows":4,"ExtraRowsTargetOtherSources":6}`,
+		},
+		{
+			name:          "target rows on no source",
+			ks2State:      "completed",
+			ks2Report:     `{"TableName":"t1","ProcessedRows":12,"MatchingRows":4,"ExtraRowsTargetOtherSources":8}`,
+			wantExtraRows: 2,
+			wantMismatch:  true,
+		},
+		{
+			name:      "diff still running",
+			ks2State:  "started",
+			ks2Report: `{"TableName":"t1","ProcessedRows":3,"MatchingRows":1,"ExtraRowsTargetOtherSources":2}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &vtctldatapb.VDiffShowResponse{
+				TabletResponses: map[string]*tabletmanagerdatapb.VDiffResponse{
+					"merge_ks1/-80": {Output: output("completed", `{"TableName":"t1","ProcessedRows":12,"MatchingRows":6,"ExtraRowsTargetOtherSources":6}`)},
+					"merge_ks2/-80": {Output: output(tc.ks2State, tc.ks2Report)},
+				},
+			}
+			require.NoError(t, accountWorkflowGroupVDiffRows(members, resp))
+			dr, mismatch := report(t, resp, "merge_ks1/-80")
+			assert.Equal(t, tc.wantExtraRows, dr.ExtraRowsTarget)
+			assert.Equal(t, tc.wantMismatch, mismatch)
+			dr, mismatch = report(t, resp, "merge_ks2/-80")
+			assert.Zero(t, dr.ExtraRowsTarget)
+			assert.False(t, mismatch)
+		})
+	}
+}
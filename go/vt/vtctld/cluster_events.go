@@ -0,0 +1,680 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/grpcclient"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/utils"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+	"vitess.io/vitess/go/vt/vttablet/tabletconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+// This file implements the writer of the cluster event log, which every
+// vtctld serves with the WatchClusterEvents RPC, so that external controllers
+// can react to the changes made to the cluster without polling the topology
+// themselves.
+//
+// The vtctlds elect one of them to watch the cluster. It appends the events to
+// the ClusterEventLog of the global topo, along with the state of the cluster
+// as of the last event, so that the next elected vtctld sends the events
+// which were missed while no vtctld was watching.
+
+var clusterEventsWorkflowPollInterval = time.Minute
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtctld"} {
+		servenv.OnParseFor(cmd, registerClusterEventsFlags)
+	}
+}
+
+func registerClusterEventsFlags(fs *pflag.FlagSet) {
+	utils.SetFlagDurationVar(fs, &clusterEventsWorkflowPollInterval, "cluster-events-workflow-poll-interval", clusterEventsWorkflowPollInterval, "how often the vtctld watching the cluster gets the state of the workflows, in addition to when a primary tablet signals that its vreplication streams changed")
+}
+
+const (
+	// clusterEventsElectionName is the name of the election of the vtctld
+	// which watches the cluster.
+	clusterEventsElectionName = "vtctld_cluster_events"
+	// clusterEventsLogSize is the number of events kept in the log to serve
+	// the clients which resume their stream.
+	clusterEventsLogSize = 1000
+	// clusterEventsRetryDelay is how long to wait before watching the
+	// topology or a tablet again after a watch failed.
+	clusterEventsRetryDelay = 5 * time.Second
+)
+
+// errClusterEventLogChanged is returned when the log was written by another
+// vtctld, which happens when this one lost the election without noticing it
+// yet.
+var errClusterEventLogChanged = errors.New("the cluster event log was written by another vtctld")
+
+// clusterStateField is a field of the ClusterEventState.
+type clusterStateField int
+
+const (
+	shardPrimariesField clusterStateField = iota
+	tabletsField
+	vschemaVersionsField
+	workflowStatesField
+)
+
+// clusterStateUpdate is the current value of the keys of a field of the
+// cluster state for which inScope returns true. The keys which are in scope
+// but not in values do not exist anymore.
+type clusterStateUpdate struct {
+	field   clusterStateField
+	inScope func(key string) bool
+	values  map[string]string
+	// snapshot is true when the update is the state read by a watcher when
+	// it starts, rather than a change it was notified of.
+	snapshot bool
+}
+
+// newKeyUpdate returns the update of a single key of a field, which does not
+// exist anymore if deleted is true.
+func newKeyUpdate(field clusterStateField, key, value string, deleted bool) *clusterStateUpdate {
+	u := &clusterStateUpdate{
+		field:   field,
+		inScope: func(k string) bool { return k == key },
+		values:  map[string]string{},
+	}
+	if !deleted {
+		u.values[key] = value
+	}
+	return u
+}
+
+// clusterEventWriter watches the topology and the workflows for changes, and
+// appends the resulting events to the cluster event log.
+type clusterEventWriter struct {
+	ts *topo.Server
+	// workflowStates returns the state of every workflow, keyed by keyspace
+	// and workflow name.
+	workflowStates     func(ctx context.Context) (map[string]string, error)
+	workflowPollPeriod time.Duration
+	// streamHealth streams the health of a tablet until the context is done
+	// or the callback returns an error.
+	streamHealth func(ctx context.Context, alias *topodatapb.TabletAlias, callback func(*querypb.StreamHealthResponse) error) error
+}
+
+func newClusterEventWriter(ts *topo.Server, workflowStates func(ctx context.Context) (map[string]string, error)) *clusterEventWriter {
+	return &clusterEventWriter{
+		ts:                 ts,
+		workflowStates:     workflowStates,
+		workflowPollPeriod: clusterEventsWorkflowPollInterval,
+		streamHealth: func(ctx context.Context, alias *topodatapb.TabletAlias, callback func(*querypb.StreamHealthResponse) error) error {
+			ti, err := ts.GetTablet(ctx, alias)
+			if err != nil {
+				return err
+			}
+			conn, err := tabletconn.GetDialer()(ctx, ti.Tablet, grpcclient.FailFast(true))
+			if err != nil {
+				return err
+			}
+			defer conn.Close(ctx)
+			return conn.StreamHealth(ctx, callback)
+		},
+	}
+}
+
+// newWorkflowStates returns a function listing the state of the workflows of
+// all keyspaces, using the workflow server.
+func newWorkflowStates(ts *topo.Server, wfs *workflow.Server) func(ctx context.Context) (map[string]string, error) {
+	return func(ctx context.Context) (map[string]string, error) {
+		keyspaces, err := ts.GetKeyspaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		states := make(map[string]string)
+		for _, keyspace := range keyspaces {
+			resp, err := wfs.GetWorkflows(ctx, &vtctldatapb.GetWorkflowsRequest{Keyspace: keyspace})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the workflows of keyspace %s: %w", keyspace, err)
+			}
+			for _, wf := range resp.Workflows {
+				states[workflowKey(keyspace, wf.Name)] = workflowState(wf)
+			}
+		}
+		return states, nil
+	}
+}
+
+func workflowKey(keyspace, workflow string) string {
+	return keyspace + "." + workflow
+}
+
+// workflowState returns the state of the streams of the workflow, or the
+// sorted list of their distinct states when they differ.
+func workflowState(wf *vtctldatapb.Workflow) string {
+	var states []string
+	for _, shardStreams := range wf.ShardStreams {
+		for _, stream := range shardStreams.Streams {
+			if !slices.Contains(states, stream.State) {
+				states = append(states, stream.State)
+			}
+		}
+	}
+	slices.Sort(states)
+	return strings.Join(states, ",")
+}
+
+// runClusterEventWriter takes part in the election of the vtctld which
+// watches the cluster, and runs the writer whenever this vtctld is elected.
+// It returns when the process terminates.
+func runClusterEventWriter(ts *topo.Server, w *clusterEventWriter, id string) {
+	conn, err := ts.ConnForCell(context.Background(), topo.GlobalCell)
+	if err != nil {
+		log.Errorf("cluster events: cannot connect to the global topo, cluster events are disabled: %v", err)
+		return
+	}
+	mp, err := conn.NewLeaderParticipation(clusterEventsElectionName, id)
+	if err != nil {
+		log.Errorf("cluster events: cannot take part in the election, cluster events are disabled: %v", err)
+		return
+	}
+	servenv.OnTermSync(mp.Stop)
+
+	for {
+		ctx, err := mp.WaitForLeadership()
+		switch {
+		case err == nil:
+			log.Infof("cluster events: elected to watch the cluster")
+			w.retry(ctx, "the cluster", w.run)
+			log.Infof("cluster events: not watching the cluster anymore")
+		case topo.IsErrType(err, topo.Interrupted):
+			return
+		default:
+			log.Errorf("cluster events: election failed, retrying in %v: %v", clusterEventsRetryDelay, err)
+			time.Sleep(clusterEventsRetryDelay)
+		}
+	}
+}
+
+// retry runs watch until the context is done, waiting between attempts.
+func (w *clusterEventWriter) retry(ctx context.Context, name string, watch func(ctx context.Context) error) {
+	for {
+		err := watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("cluster events: watching %s failed, retrying in %v: %v", name, clusterEventsRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(clusterEventsRetryDelay):
+		}
+	}
+}
+
+// run watches the cluster and appends the events to the log until the
+// context is done, or the log cannot be written.
+func (w *clusterEventWriter) run(ctx context.Context) error {
+	eventLog, err := w.ts.GetClusterEventLog(ctx)
+	if err != nil {
+		return err
+	}
+	// A new log has no state to compare the cluster to, so the state read
+	// by the watchers when they start is recorded without sending events.
+	hasBaseline := eventLog.State != nil
+	state := eventLog.State
+	if state == nil {
+		state = &vtctldatapb.ClusterEventState{}
+	}
+	lastSequence := eventLog.LastSequence
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	updates := make(chan *clusterStateUpdate, 100)
+	refreshWorkflows := make(chan struct{}, 1)
+
+	go w.retry(ctx, "keyspaces", func(ctx context.Context) error {
+		return w.watchKeyspaces(ctx, updates)
+	})
+	go w.retry(ctx, "workflows", func(ctx context.Context) error {
+		return w.pollWorkflows(ctx, updates, refreshWorkflows)
+	})
+	cells, err := w.ts.GetCellInfoNames(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		go w.retry(ctx, "tablets in cell "+cell, func(ctx context.Context) error {
+			return w.watchTablets(ctx, cell, updates)
+		})
+	}
+
+	// The health of the primaries is streamed to refresh the state of the
+	// workflows as soon as their vreplication streams change.
+	healthStreams := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range healthStreams {
+			cancel()
+		}
+	}()
+
+	for {
+		var u *clusterStateUpdate
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case u = <-updates:
+		}
+		var events []*vtctldatapb.ClusterEvent
+		recordState := false
+		// The pending updates are applied as well, so that a burst of changes
+		// is written at once.
+		for u != nil {
+			updateEvents := applyClusterStateUpdate(state, u)
+			if u.snapshot && !hasBaseline {
+				recordState = true
+			} else {
+				events = append(events, updateEvents...)
+			}
+			select {
+			case u = <-updates:
+			default:
+				u = nil
+			}
+		}
+		if len(events) > 0 || recordState {
+			if lastSequence, err = w.appendEvents(ctx, lastSequence, events, state); err != nil {
+				return err
+			}
+		}
+		w.syncHealthStreams(ctx, state, healthStreams, refreshWorkflows)
+	}
+}
+
+// appendEvents appends the events to the log, with the state of the cluster
+// after them, and returns the new last sequence number. It fails if the log
+// was written by someone else since lastSequence.
+func (w *clusterEventWriter) appendEvents(ctx context.Context, lastSequence uint64, events []*vtctldatapb.ClusterEvent, state *vtctldatapb.ClusterEventState) (uint64, error) {
+	now := protoutil.TimeToProto(time.Now().UTC())
+	err := w.ts.UpdateClusterEventLog(ctx, func(eventLog *vtctldatapb.ClusterEventLog) error {
+		if eventLog.LastSequence != lastSequence {
+			return errClusterEventLogChanged
+		}
+		if eventLog.Id == "" {
+			eventLog.Id = uuid.New().String()
+		}
+		for _, ev := range events {
+			eventLog.LastSequence++
+			ev.Token = clusterEventToken(eventLog.Id, eventLog.LastSequence)
+			ev.Time = now
+			eventLog.Events = append(eventLog.Events, ev)
+		}
+		if len(eventLog.Events) > clusterEventsLogSize {
+			eventLog.Events = eventLog.Events[len(eventLog.Events)-clusterEventsLogSize:]
+		}
+		eventLog.State = state
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return lastSequence + uint64(len(events)), nil
+}
+
+// clusterEventToken returns the resume token of the event with the given
+// sequence number in the log.
+func clusterEventToken(logID string, sequence uint64) string {
+	return fmt.Sprintf("%s:%d", logID, sequence)
+}
+
+// applyClusterStateUpdate applies the update to the state, and returns the
+// events describing the changes.
+func applyClusterStateUpdate(state *vtctldatapb.ClusterEventState, u *clusterStateUpdate) []*vtctldatapb.ClusterEvent {
+	var m *map[string]string
+	switch u.field {
+	case shardPrimariesField:
+		m = &state.ShardPrimaries
+	case tabletsField:
+		m = &state.Tablets
+	case vschemaVersionsField:
+		m = &state.VschemaVersions
+	case workflowStatesField:
+		m = &state.WorkflowStates
+	}
+	if *m == nil {
+		*m = make(map[string]string)
+	}
+
+	keys := make([]string, 0, len(u.values))
+	for key := range u.values {
+		keys = append(keys, key)
+	}
+	for key := range *m {
+		if _, ok := u.values[key]; !ok && u.inScope(key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var events []*vtctldatapb.ClusterEvent
+	for _, key := range keys {
+		previous, existed := (*m)[key]
+		current, exists := u.values[key]
+		if existed == exists && previous == current {
+			continue
+		}
+		if exists {
+			(*m)[key] = current
+		} else {
+			delete(*m, key)
+		}
+		if ev := clusterEventFor(u.field, key, previous, current, existed, exists); ev != nil {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// clusterEventFor returns the event describing the change of a key of the
+// state, or nil if the change is not an event.
+func clusterEventFor(field clusterStateField, key, previous, current string, existed, exists bool) *vtctldatapb.ClusterEvent {
+	switch field {
+	case shardPrimariesField:
+		keyspace, shard, _ := topoproto.ParseKeyspaceShard(key)
+		ev := &vtctldatapb.ClusterEvent{Keyspace: keyspace, Shard: shard, Primary: parseAlias(current)}
+		switch {
+		case !existed:
+			ev.Type = vtctldatapb.ClusterEvent_SHARD_CREATED
+		case !exists:
+			ev.Type = vtctldatapb.ClusterEvent_SHARD_DELETED
+			ev.Primary = nil
+		default:
+			ev.Type = vtctldatapb.ClusterEvent_PRIMARY_CHANGED
+			ev.PreviousPrimary = parseAlias(previous)
+		}
+		return ev
+	case tabletsField:
+		if existed && exists {
+			// The tablet moved to another shard, which is not an event.
+			return nil
+		}
+		alias := parseAlias(key)
+		ev := &vtctldatapb.ClusterEvent{Type: vtctldatapb.ClusterEvent_TABLET_ADDED, Tablet: alias, Cell: alias.GetCell()}
+		keyspaceShard := current
+		if !exists {
+			ev.Type = vtctldatapb.ClusterEvent_TABLET_REMOVED
+			keyspaceShard = previous
+		}
+		ev.Keyspace, ev.Shard, _ = topoproto.ParseKeyspaceShard(keyspaceShard)
+		return ev
+	case vschemaVersionsField:
+		return &vtctldatapb.ClusterEvent{Type: vtctldatapb.ClusterEvent_VSCHEMA_CHANGED, Keyspace: key}
+	case workflowStatesField:
+		keyspace, name, _ := strings.Cut(key, ".")
+		return &vtctldatapb.ClusterEvent{Type: vtctldatapb.ClusterEvent_WORKFLOW_STATE_CHANGED, Keyspace: keyspace, Workflow: name, State: current, PreviousState: previous}
+	}
+	return nil
+}
+
+// parseAlias parses a tablet alias of the state, which is empty for no
+// tablet.
+func parseAlias(alias string) *topodatapb.TabletAlias {
+	if alias == "" {
+		return nil
+	}
+	ta, err := topoproto.ParseTabletAlias(alias)
+	if err != nil {
+		return nil
+	}
+	return ta
+}
+
+func primaryAlias(shard *topodatapb.Shard) string {
+	if shard.PrimaryAlias == nil {
+		return ""
+	}
+	return topoproto.TabletAliasString(shard.PrimaryAlias)
+}
+
+// watchKeyspaces watches the shard and vschema records of all keyspaces.
+func (w *clusterEventWriter) watchKeyspaces(ctx context.Context, updates chan<- *clusterStateUpdate) error {
+	conn, err := w.ts.ConnForCell(ctx, topo.GlobalCell)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The watch is started before reading the current state, so that no
+	// change is missed.
+	_, changes, err := conn.WatchRecursive(ctx, topo.KeyspacesPath)
+	if err != nil {
+		return err
+	}
+
+	primaries := make(map[string]string)
+	vschemaVersions := make(map[string]string)
+	keyspaces, err := w.ts.GetKeyspaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, keyspace := range keyspaces {
+		shards, err := w.ts.FindAllShardsInKeyspace(ctx, keyspace, nil)
+		if err != nil {
+			return err
+		}
+		for _, si := range shards {
+			primaries[topoproto.KeyspaceShardString(keyspace, si.ShardName())] = primaryAlias(si.Shard)
+		}
+		_, version, err := conn.Get(ctx, path.Join(topo.KeyspacesPath, keyspace, topo.VSchemaFile))
+		switch {
+		case err == nil:
+			vschemaVersions[keyspace] = version.String()
+		case !topo.IsErrType(err, topo.NoNode):
+			return err
+		}
+	}
+	all := func(string) bool { return true }
+	if !sendUpdate(ctx, updates, &clusterStateUpdate{field: shardPrimariesField, inScope: all, values: primaries, snapshot: true}) ||
+		!sendUpdate(ctx, updates, &clusterStateUpdate{field: vschemaVersionsField, inScope: all, values: vschemaVersions, snapshot: true}) {
+		return ctx.Err()
+	}
+
+	for wd := range changes {
+		if wd.Err != nil && !topo.IsErrType(wd.Err, topo.NoNode) {
+			return wd.Err
+		}
+		segments := strings.Split(strings.Trim(wd.Path, "/"), "/")
+		n := len(segments)
+		var u *clusterStateUpdate
+		switch {
+		case n >= 4 && segments[n-1] == topo.ShardFile && segments[n-3] == topo.ShardsPath:
+			key := topoproto.KeyspaceShardString(segments[n-4], segments[n-2])
+			if wd.Err != nil {
+				u = newKeyUpdate(shardPrimariesField, key, "", true)
+				break
+			}
+			value := &topodatapb.Shard{}
+			if err := value.UnmarshalVT(wd.Contents); err != nil {
+				log.Warningf("cluster events: cannot unmarshal shard record %s: %v", key, err)
+				continue
+			}
+			u = newKeyUpdate(shardPrimariesField, key, primaryAlias(value), false)
+		case n >= 3 && segments[n-1] == topo.VSchemaFile && segments[n-3] == topo.KeyspacesPath:
+			if wd.Err != nil {
+				u = newKeyUpdate(vschemaVersionsField, segments[n-2], "", true)
+				break
+			}
+			u = newKeyUpdate(vschemaVersionsField, segments[n-2], wd.Version.String(), false)
+		default:
+			continue
+		}
+		if !sendUpdate(ctx, updates, u) {
+			return ctx.Err()
+		}
+	}
+	return errors.New("watch closed")
+}
+
+// watchTablets watches the tablet records of a cell.
+func (w *clusterEventWriter) watchTablets(ctx context.Context, cell string, updates chan<- *clusterStateUpdate) error {
+	conn, err := w.ts.ConnForCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, changes, err := conn.WatchRecursive(ctx, topo.TabletsPath)
+	if err != nil {
+		return err
+	}
+
+	// The tablets are keyed by alias, with their keyspace/shard as value.
+	tablets := make(map[string]string)
+	aliases, err := w.ts.GetTabletAliasesByCell(ctx, cell)
+	if err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		ti, err := w.ts.GetTablet(ctx, alias)
+		if err != nil {
+			if topo.IsErrType(err, topo.NoNode) {
+				continue
+			}
+			return err
+		}
+		tablets[topoproto.TabletAliasString(alias)] = topoproto.KeyspaceShardString(ti.Keyspace, ti.Shard)
+	}
+	inCell := func(key string) bool {
+		return parseAlias(key).GetCell() == cell
+	}
+	if !sendUpdate(ctx, updates, &clusterStateUpdate{field: tabletsField, inScope: inCell, values: tablets, snapshot: true}) {
+		return ctx.Err()
+	}
+
+	for wd := range changes {
+		if wd.Err != nil && !topo.IsErrType(wd.Err, topo.NoNode) {
+			return wd.Err
+		}
+		segments := strings.Split(strings.Trim(wd.Path, "/"), "/")
+		n := len(segments)
+		if n < 3 || segments[n-1] != topo.TabletFile || segments[n-3] != topo.TabletsPath {
+			continue
+		}
+		alias := segments[n-2]
+		var u *clusterStateUpdate
+		if wd.Err != nil {
+			u = newKeyUpdate(tabletsField, alias, "", true)
+		} else {
+			tablet := &topodatapb.Tablet{}
+			if err := tablet.UnmarshalVT(wd.Contents); err != nil {
+				log.Warningf("cluster events: cannot unmarshal tablet record %s: %v", alias, err)
+				continue
+			}
+			u = newKeyUpdate(tabletsField, alias, topoproto.KeyspaceShardString(tablet.Keyspace, tablet.Shard), false)
+		}
+		if !sendUpdate(ctx, updates, u) {
+			return ctx.Err()
+		}
+	}
+	return errors.New("watch closed")
+}
+
+// pollWorkflows gets the state of the workflows periodically, and whenever
+// refresh is signaled.
+func (w *clusterEventWriter) pollWorkflows(ctx context.Context, updates chan<- *clusterStateUpdate, refresh <-chan struct{}) error {
+	ticker := time.NewTicker(w.workflowPollPeriod)
+	defer ticker.Stop()
+	for snapshot := true; ; snapshot = false {
+		states, err := w.workflowStates(ctx)
+		if err != nil {
+			return err
+		}
+		if !sendUpdate(ctx, updates, &clusterStateUpdate{field: workflowStatesField, inScope: func(string) bool { return true }, values: states, snapshot: snapshot}) {
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-refresh:
+		}
+	}
+}
+
+func sendUpdate(ctx context.Context, updates chan<- *clusterStateUpdate, u *clusterStateUpdate) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case updates <- u:
+		return true
+	}
+}
+
+// syncHealthStreams streams the health of the current primaries, and stops
+// streaming the health of the tablets which are not primary anymore. When a
+// primary signals that its vreplication streams changed, refresh is
+// signaled.
+func (w *clusterEventWriter) syncHealthStreams(ctx context.Context, state *vtctldatapb.ClusterEventState, streams map[string]context.CancelFunc, refresh chan<- struct{}) {
+	primaries := make(map[string]bool)
+	for _, primary := range state.ShardPrimaries {
+		if primary != "" {
+			primaries[primary] = true
+		}
+	}
+	for primary, cancel := range streams {
+		if !primaries[primary] {
+			cancel()
+			delete(streams, primary)
+		}
+	}
+	for primary := range primaries {
+		if _, ok := streams[primary]; ok {
+			continue
+		}
+		alias := parseAlias(primary)
+		if alias == nil {
+			continue
+		}
+		streamCtx, cancel := context.WithCancel(ctx)
+		streams[primary] = cancel
+		go w.retry(streamCtx, "the health of tablet "+primary, func(ctx context.Context) error {
+			return w.streamHealth(ctx, alias, func(shr *querypb.StreamHealthResponse) error {
+				if shr.GetRealtimeStats().GetVreplicationStateChanged() {
+					select {
+					case refresh <- struct{}{}:
+					default:
+					}
+				}
+				return nil
+			})
+		})
+	}
+}
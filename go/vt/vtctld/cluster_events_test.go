@@ -0,0 +1,217 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+)
+
+func TestClusterEventWriter(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+	require.NoError(t, ts.CreateKeyspace(ctx, "ks", &topodatapb.Keyspace{}))
+	// memorytopo only notifies the recursive watches of the updates of
+	// existing files, so the vschema has to exist beforehand.
+	require.NoError(t, ts.SaveVSchema(ctx, &topo.KeyspaceVSchemaInfo{Name: "ks", Keyspace: &vschemapb.Keyspace{}}))
+	require.NoError(t, ts.CreateShard(ctx, "ks", "-80"))
+	alias100 := &topodatapb.TabletAlias{Cell: "cell1", Uid: 100}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{Alias: alias100, Keyspace: "ks", Shard: "-80", Type: topodatapb.TabletType_PRIMARY}))
+
+	var mu sync.Mutex
+	workflows := map[string]string{"ks.wf": "Copying"}
+	w := newClusterEventWriter(ts, func(ctx context.Context) (map[string]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return maps.Clone(workflows), nil
+	})
+	// The state of the workflows is only refreshed when the primary signals
+	// a change of its vreplication streams.
+	w.workflowPollPeriod = time.Hour
+	vreplicationChanged := make(chan struct{})
+	w.streamHealth = func(ctx context.Context, alias *topodatapb.TabletAlias, callback func(*querypb.StreamHealthResponse) error) error {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-vreplicationChanged:
+				if err := callback(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{VreplicationStateChanged: true}}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	start := func() context.CancelFunc {
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			w.retry(runCtx, "the cluster", w.run)
+		}()
+		return func() {
+			cancel()
+			<-done
+		}
+	}
+	readLog := func() *vtctldatapb.ClusterEventLog {
+		eventLog, err := ts.GetClusterEventLog(ctx)
+		require.NoError(t, err)
+		return eventLog
+	}
+	// waitForEvents waits until the log has n events, and returns them.
+	waitForEvents := func(n int) []*vtctldatapb.ClusterEvent {
+		var events []*vtctldatapb.ClusterEvent
+		require.Eventually(t, func() bool {
+			events = readLog().Events
+			return len(events) >= n
+		}, 10*time.Second, 10*time.Millisecond)
+		require.Len(t, events, n)
+		return events
+	}
+
+	stop := start()
+	// The log is new, so the current state of the cluster is recorded
+	// without any event.
+	require.Eventually(t, func() bool {
+		state := readLog().State
+		return state.GetShardPrimaries()["ks/-80"] == "" && len(state.GetShardPrimaries()) == 1 &&
+			state.GetTablets()["cell1-0000000100"] == "ks/-80" &&
+			state.GetWorkflowStates()["ks.wf"] == "Copying" &&
+			state.GetVschemaVersions()["ks"] != ""
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Empty(t, readLog().Events)
+
+	require.NoError(t, ts.CreateShard(ctx, "ks", "80-"))
+	ev := waitForEvents(1)[0]
+	assert.Equal(t, vtctldatapb.ClusterEvent_SHARD_CREATED, ev.Type)
+	assert.Equal(t, "ks", ev.Keyspace)
+	assert.Equal(t, "80-", ev.Shard)
+
+	_, err := ts.UpdateShardFields(ctx, "ks", "-80", func(si *topo.ShardInfo) error {
+		si.PrimaryAlias = alias100
+		return nil
+	})
+	require.NoError(t, err)
+	ev = waitForEvents(2)[1]
+	assert.Equal(t, vtctldatapb.ClusterEvent_PRIMARY_CHANGED, ev.Type)
+	assert.Equal(t, "-80", ev.Shard)
+	assert.True(t, topoproto.TabletAliasEqual(alias100, ev.Primary))
+	assert.Nil(t, ev.PreviousPrimary)
+
+	vs, err := ts.GetVSchema(ctx, "ks")
+	require.NoError(t, err)
+	vs.Keyspace.Sharded = true
+	require.NoError(t, ts.SaveVSchema(ctx, vs))
+	ev = waitForEvents(3)[2]
+	assert.Equal(t, vtctldatapb.ClusterEvent_VSCHEMA_CHANGED, ev.Type)
+	assert.Equal(t, "ks", ev.Keyspace)
+
+	mu.Lock()
+	workflows["ks.wf"] = "Running"
+	mu.Unlock()
+	vreplicationChanged <- struct{}{}
+	ev = waitForEvents(4)[3]
+	assert.Equal(t, vtctldatapb.ClusterEvent_WORKFLOW_STATE_CHANGED, ev.Type)
+	assert.Equal(t, "ks", ev.Keyspace)
+	assert.Equal(t, "wf", ev.Workflow)
+	assert.Equal(t, "Running", ev.State)
+	assert.Equal(t, "Copying", ev.PreviousState)
+
+	alias101 := &topodatapb.TabletAlias{Cell: "cell1", Uid: 101}
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{Alias: alias101, Keyspace: "ks", Shard: "80-", Type: topodatapb.TabletType_REPLICA}))
+	ev = waitForEvents(5)[4]
+	assert.Equal(t, vtctldatapb.ClusterEvent_TABLET_ADDED, ev.Type)
+	assert.True(t, topoproto.TabletAliasEqual(alias101, ev.Tablet))
+	assert.Equal(t, "cell1", ev.Cell)
+	assert.Equal(t, "80-", ev.Shard)
+
+	// The changes made while no vtctld watches the cluster are sent by the
+	// next one.
+	stop()
+	require.NoError(t, ts.DeleteTablet(ctx, alias101))
+	stop = start()
+	defer stop()
+	ev = waitForEvents(6)[5]
+	assert.Equal(t, vtctldatapb.ClusterEvent_TABLET_REMOVED, ev.Type)
+	assert.True(t, topoproto.TabletAliasEqual(alias101, ev.Tablet))
+	assert.Equal(t, "80-", ev.Shard)
+
+	eventLog := readLog()
+	assert.EqualValues(t, 6, eventLog.LastSequence)
+	for i, ev := range eventLog.Events {
+		assert.Equal(t, fmt.Sprintf("%s:%d", eventLog.Id, i+1), ev.Token)
+		assert.NotNil(t, ev.Time)
+	}
+}
+
+func TestApplyClusterStateUpdate(t *testing.T) {
+	state := &vtctldatapb.ClusterEventState{}
+	all := func(string) bool { return true }
+
+	events := applyClusterStateUpdate(state, &clusterStateUpdate{field: tabletsField, inScope: all, values: map[string]string{"zone1-0000000100": "ks/-", "zone1-0000000101": "ks/-"}})
+	require.Len(t, events, 2)
+	assert.Equal(t, vtctldatapb.ClusterEvent_TABLET_ADDED, events[0].Type)
+
+	// Moving a tablet to another shard is not an event, and only the keys in
+	// scope are removed.
+	events = applyClusterStateUpdate(state, &clusterStateUpdate{
+		field:   tabletsField,
+		inScope: func(key string) bool { return key == "zone1-0000000100" },
+		values:  map[string]string{"zone1-0000000100": "ks/-80"},
+	})
+	assert.Empty(t, events)
+	assert.Equal(t, map[string]string{"zone1-0000000100": "ks/-80", "zone1-0000000101": "ks/-"}, state.Tablets)
+
+	events = applyClusterStateUpdate(state, newKeyUpdate(shardPrimariesField, "ks/-", "", false))
+	require.Len(t, events, 1)
+	assert.Equal(t, vtctldatapb.ClusterEvent_SHARD_CREATED, events[0].Type)
+	events = applyClusterStateUpdate(state, newKeyUpdate(shardPrimariesField, "ks/-", "zone1-0000000100", false))
+	require.Len(t, events, 1)
+	assert.Equal(t, vtctldatapb.ClusterEvent_PRIMARY_CHANGED, events[0].Type)
+	events = applyClusterStateUpdate(state, newKeyUpdate(shardPrimariesField, "ks/-", "", true))
+	require.Len(t, events, 1)
+	assert.Equal(t, vtctldatapb.ClusterEvent_SHARD_DELETED, events[0].Type)
+	assert.Equal(t, "ks", events[0].Keyspace)
+	assert.Equal(t, "-", events[0].Shard)
+	assert.Nil(t, events[0].Primary)
+
+	// A workflow which is deleted has an empty state.
+	applyClusterStateUpdate(state, newKeyUpdate(workflowStatesField, "ks.wf", "Running", false))
+	events = applyClusterStateUpdate(state, &clusterStateUpdate{field: workflowStatesField, inScope: all, values: map[string]string{}})
+	require.Len(t, events, 1)
+	assert.Equal(t, "wf", events[0].Workflow)
+	assert.Empty(t, events[0].State)
+	assert.Equal(t, "Running", events[0].PreviousState)
+}
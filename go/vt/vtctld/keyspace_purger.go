@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/utils"
+
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtctlservicepb "vitess.io/vitess/go/vt/proto/vtctlservice"
+)
+
+var keyspacePurgeInterval = time.Hour
+
+func init() {
+	for _, cmd := range []string{"vtcombo", "vtctld"} {
+		servenv.OnParseFor(cmd, registerKeyspacePurgerFlags)
+	}
+}
+
+func registerKeyspacePurgerFlags(fs *pflag.FlagSet) {
+	utils.SetFlagDurationVar(fs, &keyspacePurgeInterval, "keyspace-purge-interval", keyspacePurgeInterval, "how often to purge the soft-deleted keyspaces whose recovery window has passed; 0 disables purging")
+}
+
+// keyspacePurger purges the soft-deleted keyspaces whose recovery window has
+// passed, as DeleteKeyspace would with Recursive=true.
+type keyspacePurger struct {
+	ts     *topo.Server
+	vtctld vtctlservicepb.VtctldServer
+}
+
+func newKeyspacePurger(ts *topo.Server, vtctld vtctlservicepb.VtctldServer) *keyspacePurger {
+	return &keyspacePurger{
+		ts:     ts,
+		vtctld: vtctld,
+	}
+}
+
+// run purges the expired keyspaces every interval until the context is done.
+func (kp *keyspacePurger) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		kp.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge deletes the soft-deleted keyspaces whose recovery window has passed,
+// and returns their names. A keyspace which cannot be deleted, for instance
+// because something depends on it again, is logged and retried on the next
+// run.
+func (kp *keyspacePurger) purge(ctx context.Context) []string {
+	keyspaces, err := kp.ts.GetKeyspaces(ctx)
+	if err != nil {
+		log.Warningf("Cannot list the keyspaces to purge: %v", err)
+		return nil
+	}
+
+	var purged []string
+	now := time.Now()
+	for _, keyspace := range keyspaces {
+		ki, err := kp.ts.GetKeyspace(ctx, keyspace)
+		if err != nil {
+			if !topo.IsErrType(err, topo.NoNode) {
+				log.Warningf("Cannot read keyspace %v to purge it: %v", keyspace, err)
+			}
+			continue
+		}
+		if ki.DeletionTombstone == nil || now.Before(protoutil.TimeFromProto(ki.DeletionTombstone.PurgeAfter)) {
+			continue
+		}
+
+		log.Infof("Purging keyspace %v, soft-deleted at %v", keyspace, protoutil.TimeFromProto(ki.DeletionTombstone.DeletedAt).UTC())
+		if _, err := kp.vtctld.DeleteKeyspace(ctx, &vtctldatapb.DeleteKeyspaceRequest{
+			Keyspace:  keyspace,
+			Recursive: true,
+		}); err != nil {
+			log.Warningf("Cannot purge keyspace %v: %v", keyspace, err)
+			continue
+		}
+		purged = append(purged, keyspace)
+	}
+	return purged
+}
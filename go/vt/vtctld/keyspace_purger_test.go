@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtctld
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestKeyspacePurger(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+
+	now := time.Now()
+	require.NoError(t, ts.CreateKeyspace(ctx, "live", &topodatapb.Keyspace{}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "recoverable", &topodatapb.Keyspace{
+		DeletionTombstone: &topodatapb.KeyspaceTombstone{
+			DeletedAt:  protoutil.TimeToProto(now),
+			PurgeAfter: protoutil.TimeToProto(now.Add(time.Hour)),
+		},
+	}))
+	require.NoError(t, ts.CreateKeyspace(ctx, "expired", &topodatapb.Keyspace{
+		DeletionTombstone: &topodatapb.KeyspaceTombstone{
+			DeletedAt:  protoutil.TimeToProto(now.Add(-2 * time.Hour)),
+			PurgeAfter: protoutil.TimeToProto(now.Add(-time.Hour)),
+		},
+	}))
+	require.NoError(t, ts.CreateShard(ctx, "expired", "-"))
+
+	kp := newKeyspacePurger(ts, grpcvtctldserver.NewVtctldServer(vtenv.NewTestEnv(), ts))
+	assert.Equal(t, []string{"expired"}, kp.purge(ctx))
+
+	keyspaces, err := ts.GetKeyspaces(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"live", "recoverable"}, keyspaces)
+
+	assert.Empty(t, kp.purge(ctx))
+}
@@ -30,6 +30,9 @@ import (
 
 	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtctl/grpcvtctldserver"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
 	"vitess.io/vitess/go/vt/wrangler"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -126,6 +129,16 @@ func InitVtctld(env *vtenv.Environment, ts *topo.Server) error {
 			return "", err
 		})
 
+	if keyspacePurgeInterval > 0 {
+		go newKeyspacePurger(ts, grpcvtctldserver.NewVtctldServer(env, ts)).run(context.Background(), keyspacePurgeInterval)
+	}
+
+	// Watch the cluster for the WatchClusterEvents RPC, if elected to.
+	servenv.OnRun(func() {
+		wfs := workflow.NewServer(env, ts, tmclient.NewTabletManagerClient())
+		go runClusterEventWriter(ts, newClusterEventWriter(ts, newWorkflowStates(ts, wfs)), servenv.ListeningURL.Host)
+	})
+
 	// Serve the REST API
 	initAPI(context.Background(), ts, actionRepo)
 
@@ -25,7 +25,7 @@ func (cached *Environment) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(48)
+		size += int64(80)
 	}
 	// field collationEnv *vitess.io/vitess/go/mysql/collations.Environment
 	size += cached.collationEnv.CachedSize(true)
@@ -17,6 +17,8 @@ limitations under the License.
 package vtenv
 
 import (
+	"time"
+
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/config"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -28,12 +30,24 @@ type Environment struct {
 	mysqlVersion   string
 	truncateUILen  int
 	truncateErrLen int
+
+	fakeTime               time.Time
+	deterministicSequences bool
 }
 
 type Options struct {
 	MySQLServerVersion string
 	TruncateUILen      int
 	TruncateErrLen     int
+
+	// FakeTime, if set, freezes the clock at the given instant: NOW() and similar functions
+	// evaluate to it, both when evaluated by vtgate and in the MySQL sessions opened by tablets.
+	// This is only meant for reproducible tests.
+	FakeTime time.Time
+	// DeterministicSequences makes tablets allocate sequence values one at a time, ignoring the
+	// cache of the sequence table, so that generated values do not depend on process restarts.
+	// This is only meant for reproducible tests.
+	DeterministicSequences bool
 }
 
 func New(cfg Options) (*Environment, error) {
@@ -54,6 +68,9 @@ func New(cfg Options) (*Environment, error) {
 		mysqlVersion:   cfg.MySQLServerVersion,
 		truncateUILen:  cfg.TruncateUILen,
 		truncateErrLen: cfg.TruncateErrLen,
+
+		fakeTime:               cfg.FakeTime,
+		deterministicSequences: cfg.DeterministicSequences,
 	}, nil
 }
 
@@ -101,3 +118,22 @@ func (e *Environment) TruncateForLog(query string) string {
 func (e *Environment) TruncateErrLen() int {
 	return e.truncateErrLen
 }
+
+// Now returns the current time, or the fake time if the environment has one.
+func (e *Environment) Now() time.Time {
+	if !e.fakeTime.IsZero() {
+		return e.fakeTime
+	}
+	return time.Now()
+}
+
+// FakeTime returns the instant at which the clock is frozen, or the zero time if the
+// environment uses the real clock.
+func (e *Environment) FakeTime() time.Time {
+	return e.fakeTime
+}
+
+// DeterministicSequences returns true if sequence values must be allocated one at a time.
+func (e *Environment) DeterministicSequences() bool {
+	return e.deterministicSequences
+}
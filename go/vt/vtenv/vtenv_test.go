@@ -2,6 +2,7 @@ package vtenv
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -47,3 +48,20 @@ func TestNewTestEnv(t *testing.T) {
 	assert.Equal(t, collations.MySQL8(), e.CollationEnv())
 	assert.Equal(t, sqlparser.NewTestParser(), e.Parser())
 }
+
+func TestFakeTime(t *testing.T) {
+	e := NewTestEnv()
+	assert.True(t, e.FakeTime().IsZero())
+	assert.WithinDuration(t, time.Now(), e.Now(), time.Minute)
+	assert.False(t, e.DeterministicSequences())
+
+	fakeTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	e, err := New(Options{
+		FakeTime:               fakeTime,
+		DeterministicSequences: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, fakeTime, e.FakeTime())
+	assert.Equal(t, fakeTime, e.Now())
+	assert.True(t, e.DeterministicSequences())
+}
@@ -127,6 +127,9 @@ const (
 
 	VectorConversion
 
+	NonDefaultValueForGenerated
+	CheckConstraintViolated
+
 	// No state should be added below NumOfStates
 	NumOfStates
 )
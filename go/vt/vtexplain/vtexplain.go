@@ -41,6 +41,8 @@ import (
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/schema"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -93,6 +95,10 @@ type (
 		// Target is used to override the "database" target in the
 		// vtgate session to simulate `USE <target>`
 		Target string
+
+		// SchemaTracking makes vtgate know the columns of the tables of the
+		// schema, as if schema tracking was enabled
+		SchemaTracking bool
 	}
 
 	// TabletQuery defines a query that was sent to a given tablet and how it was
@@ -149,6 +155,9 @@ type (
 		batchTime       *sync2.Batcher
 		globalTabletEnv *tabletEnv
 
+		// tables of the schema known by vtgate, when SchemaTracking is set
+		tables map[string]*vindexes.TableInfo
+
 		env *vtenv.Environment
 	}
 )
@@ -207,6 +216,14 @@ func Init(ctx context.Context, env *vtenv.Environment, ts *topo.Server, vSchemaS
 		},
 		env: env,
 	}
+	if opts.SchemaTracking {
+		vte.tables = make(map[string]*vindexes.TableInfo, len(parsedDDLs))
+		for _, ddl := range parsedDDLs {
+			if spec := ddl.GetTableSpec(); spec != nil {
+				vte.tables[ddl.GetTable().Name.String()] = schema.NewTableInfo(spec)
+			}
+		}
+	}
 	vte.setGlobalTabletEnv(tabletEnv)
 	err = vte.initVtgateExecutor(ctx, ts, vSchemaStr, ksShardMapStr, opts, srvTopoCounts)
 	if err != nil {
@@ -218,11 +235,8 @@ func Init(ctx context.Context, env *vtenv.Environment, ts *topo.Server, vSchemaS
 
 // Stop and cleans up fake execution environment
 func (vte *VTExplain) Stop() {
-	if vte.vtgateExecutor != nil {
-		vte.vtgateExecutor.Close()
-	}
-
-	// Cleanup all created fake dbs.
+	// Cleanup all created fake dbs. The tablets are stopped first since
+	// closing the executor closes the topo server they use.
 	if vte.explainTopo != nil {
 		for _, conn := range vte.explainTopo.TabletConns {
 			conn.tsv.StopService()
@@ -232,6 +246,10 @@ func (vte *VTExplain) Stop() {
 			conn.db.Close()
 		}
 	}
+
+	if vte.vtgateExecutor != nil {
+		vte.vtgateExecutor.Close()
+	}
 }
 
 func parseSchema(sqlSchema string, opts *Options, parser *sqlparser.Parser) ([]sqlparser.DDLStatement, error) {
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtexplain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/jsonutil"
+	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+)
+
+// Impact is the effect of a proposed schema or vschema change on the plan of
+// a query.
+type Impact string
+
+const (
+	// ImpactUnchanged is for a query whose plan does not change.
+	ImpactUnchanged = Impact("unchanged")
+	// ImpactChanged is for a query whose plan changes without routing to
+	// more shards.
+	ImpactChanged = Impact("changed")
+	// ImpactDegraded is for a query whose plan scatters where it did not.
+	ImpactDegraded = Impact("degraded")
+	// ImpactBroken is for a query which cannot be planned anymore.
+	ImpactBroken = Impact("broken")
+	// ImpactFixed is for a query which could not be planned and can be.
+	ImpactFixed = Impact("fixed")
+)
+
+var impacts = []Impact{ImpactUnchanged, ImpactChanged, ImpactDegraded, ImpactBroken, ImpactFixed}
+
+// QueryImpact describes the effect of a proposed change on the plan of a
+// query.
+type QueryImpact struct {
+	SQL    string
+	Impact Impact
+	// Reason explains the impact, it is empty if the plan does not change.
+	Reason string `json:",omitempty"`
+
+	// Before and After are the plans of the query before and after the
+	// change, they are nil if the query cannot be planned.
+	Before *engine.PrimitiveDescription `json:",omitempty"`
+	After  *engine.PrimitiveDescription `json:",omitempty"`
+}
+
+// ApplySchemaChange returns the schema resulting from applying the given DDL
+// statements to the given schema.
+func ApplySchemaChange(env *vtenv.Environment, sqlSchema, ddls string) (string, error) {
+	schema, err := schemadiff.NewSchemaFromSQL(schemadiff.NewEnv(env, env.CollationEnv().DefaultConnectionCharset()), sqlSchema)
+	if err != nil {
+		return "", fmt.Errorf("cannot load the schema: %v", err)
+	}
+	var statements []sqlparser.Statement
+	pieces, err := env.Parser().SplitStatementToPieces(ddls)
+	if err != nil {
+		return "", err
+	}
+	for _, piece := range pieces {
+		stmt, err := env.Parser().ParseStrictDDL(piece)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse the schema change %q: %v", piece, err)
+		}
+		statements = append(statements, stmt)
+	}
+	schema, err = schema.ApplyStatements(statements)
+	if err != nil {
+		return "", fmt.Errorf("cannot apply the schema change: %v", err)
+	}
+	return schema.ToSQL(), nil
+}
+
+// Plan returns the vtgate plan of the given query, without executing it.
+func (vte *VTExplain) Plan(sql string) (*engine.Plan, error) {
+	defer vte.vtgateExecutor.ClearPlans()
+	return vte.vtgateExecutor.PlanPrepareStmt(context.Background(), econtext.NewSafeSession(vte.vtgateSession), sql)
+}
+
+// CompareQueryPlans plans the given queries with both environments, the
+// environment before a proposed schema or vschema change and the one after,
+// and reports how the change affects each of their plans.
+func CompareQueryPlans(before, after *VTExplain, sql string) ([]*QueryImpact, error) {
+	queries, err := before.env.Parser().SplitStatementToPieces(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*QueryImpact
+	for _, query := range queries {
+		query, _ = sqlparser.SplitMarginComments(query)
+		if query == "" {
+			continue
+		}
+		beforePlan, beforeErr := before.Plan(query)
+		afterPlan, afterErr := after.Plan(query)
+
+		qi := &QueryImpact{SQL: query}
+		if beforeErr == nil {
+			pd := engine.PrimitiveToPlanDescription(beforePlan.Instructions, nil)
+			qi.Before = &pd
+		}
+		if afterErr == nil {
+			pd := engine.PrimitiveToPlanDescription(afterPlan.Instructions, nil)
+			qi.After = &pd
+		}
+		switch {
+		case beforeErr != nil && afterErr != nil:
+			qi.Impact = ImpactUnchanged
+			qi.Reason = fmt.Sprintf("cannot be planned before nor after the change: %v", afterErr)
+		case afterErr != nil:
+			qi.Impact = ImpactBroken
+			qi.Reason = afterErr.Error()
+		case beforeErr != nil:
+			qi.Impact = ImpactFixed
+			qi.Reason = fmt.Sprintf("could not be planned before the change: %v", beforeErr)
+		default:
+			qi.Impact, qi.Reason, err = compareDescriptions(*qi.Before, *qi.After)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, qi)
+	}
+	return results, nil
+}
+
+func compareDescriptions(before, after engine.PrimitiveDescription) (Impact, string, error) {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return "", "", err
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return "", "", err
+	}
+	if string(beforeJSON) == string(afterJSON) {
+		return ImpactUnchanged, "", nil
+	}
+
+	beforeVariants, beforeScatters := routeVariants(before)
+	afterVariants, afterScatters := routeVariants(after)
+	reason := fmt.Sprintf("routes: %s -> %s", strings.Join(beforeVariants, ", "), strings.Join(afterVariants, ", "))
+	if afterScatters > beforeScatters {
+		return ImpactDegraded, reason, nil
+	}
+	return ImpactChanged, reason, nil
+}
+
+// routeVariants returns the variants of the primitives of a plan which send
+// queries to the tablets, and how many of them scatter.
+func routeVariants(pd engine.PrimitiveDescription) (variants []string, scatters int) {
+	engine.WalkPrimitiveDescription(pd, func(pd engine.PrimitiveDescription) {
+		if pd.Keyspace == nil || pd.Variant == "" {
+			return
+		}
+		variants = append(variants, fmt.Sprintf("%s(%s)", pd.OperatorType, pd.Variant))
+		if pd.Variant == engine.Scatter.String() {
+			scatters++
+		}
+	})
+	return variants, scatters
+}
+
+// HasRegressions returns true if a query breaks or degrades.
+func HasRegressions(results []*QueryImpact) bool {
+	for _, qi := range results {
+		if qi.Impact == ImpactBroken || qi.Impact == ImpactDegraded {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryImpactsAsText returns a text representation of the impact of a change
+// on the plans of the queries, followed by a summary.
+func QueryImpactsAsText(results []*QueryImpact) string {
+	var b strings.Builder
+	counts := make(map[Impact]int, len(impacts))
+	for _, qi := range results {
+		counts[qi.Impact]++
+		fmt.Fprintf(&b, "%-10s %s\n", qi.Impact, qi.SQL)
+		if qi.Reason != "" {
+			fmt.Fprintf(&b, "%-10s %s\n", "", qi.Reason)
+		}
+	}
+	fmt.Fprintf(&b, "----------------------------------------------------------------------\n")
+	summary := make([]string, 0, len(impacts))
+	for _, impact := range impacts {
+		summary = append(summary, fmt.Sprintf("%d %s", counts[impact], impact))
+	}
+	fmt.Fprintf(&b, "%d queries: %s\n", len(results), strings.Join(summary, ", "))
+	return b.String()
+}
+
+// QueryImpactsAsJSON returns a json representation of the impact of a change
+// on the plans of the queries.
+func QueryImpactsAsJSON(results []*QueryImpact) string {
+	resultsJSON, _ := jsonutil.MarshalIndentNoEscape(results, "", "    ")
+	return string(resultsJSON)
+}
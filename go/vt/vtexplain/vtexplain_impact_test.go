@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtexplain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtenv"
+)
+
+func TestCompareQueryPlans(t *testing.T) {
+	ctx := t.Context()
+	env := vtenv.NewTestEnv()
+
+	schema := `create table user (id bigint, name varchar(64), nickname varchar(64), primary key (id));
+create table t1 (id bigint, primary key (id));`
+	vschema := `{
+	"ks_unsharded": {"sharded": false, "tables": {"t1": {}}},
+	"ks_sharded": {
+		"sharded": true,
+		"vindexes": {"hash": {"type": "hash"}},
+		"tables": {"user": {"column_vindexes": [{"column": "%s", "name": "hash"}]}}
+	}
+}`
+	proposedSchema, err := ApplySchemaChange(env, schema, "alter table user drop column nickname, add column nickname2 varchar(64)")
+	require.NoError(t, err)
+	assert.Contains(t, proposedSchema, "`nickname2` varchar(64)")
+	assert.NotContains(t, proposedSchema, "`nickname` varchar(64)")
+
+	_, err = ApplySchemaChange(env, schema, "alter table missing add column c int")
+	assert.ErrorContains(t, err, "cannot apply the schema change")
+
+	opts := &Options{
+		ReplicationMode: "ROW",
+		NumShards:       2,
+		SchemaTracking:  true,
+	}
+	initEnv := func(schema, vschema string) *VTExplain {
+		ts := memorytopo.NewServer(ctx, Cell)
+		srvTopoCounts := stats.NewCountersWithSingleLabel("", "Resilient srvtopo server operations", "type")
+		vte, err := Init(ctx, env, ts, vschema, schema, "", opts, srvTopoCounts)
+		require.NoError(t, err)
+		return vte
+	}
+	before := initEnv(schema, fmt.Sprintf(vschema, "id"))
+	defer before.Stop()
+	after := initEnv(proposedSchema, fmt.Sprintf(vschema, "name"))
+	defer after.Stop()
+
+	results, err := CompareQueryPlans(before, after, `select id from user where id = :vtg1;
+select id from user where name = :vtg1;
+select nickname from user where id = 1;
+select nickname2 from user;
+/* comment */ select id from t1`)
+	require.NoError(t, err)
+
+	var got []Impact
+	for _, qi := range results {
+		got = append(got, qi.Impact)
+	}
+	assert.Equal(t, []Impact{ImpactDegraded, ImpactChanged, ImpactBroken, ImpactFixed, ImpactUnchanged}, got)
+	assert.Equal(t, "routes: Route(EqualUnique) -> Route(Scatter)", results[0].Reason)
+	assert.Equal(t, "routes: Route(Scatter) -> Route(EqualUnique)", results[1].Reason)
+	assert.Contains(t, results[2].Reason, "nickname")
+	assert.Nil(t, results[2].After)
+	assert.Nil(t, results[3].Before)
+	assert.Equal(t, "select id from t1", results[4].SQL)
+	assert.True(t, HasRegressions(results))
+	assert.False(t, HasRegressions(results[1:2]))
+
+	text := QueryImpactsAsText(results)
+	assert.Contains(t, text, "degraded   select id from user where id = :vtg1\n           routes: Route(EqualUnique) -> Route(Scatter)\n")
+	assert.Contains(t, text, "5 queries: 1 unchanged, 1 changed, 1 degraded, 1 broken, 1 fixed\n")
+}
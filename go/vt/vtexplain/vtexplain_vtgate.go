@@ -37,6 +37,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -70,7 +71,10 @@ func (vte *VTExplain) initVtgateExecutor(ctx context.Context, ts *topo.Server, v
 	}
 
 	streamSize := 10
-	var schemaTracker vtgate.SchemaInfo // no schema tracker for these tests
+	var schemaTracker vtgate.SchemaInfo // no schema tracker unless the tables are known
+	if vte.tables != nil {
+		schemaTracker = &explainSchema{tables: vte.tables, keyspaces: vte.explainTopo.Keyspaces}
+	}
 	queryLogBufferSize := 10
 	plans := theine.NewStore[vtgate.PlanCacheKey, *engine.Plan](4*1024*1024, false)
 	eConfig := vtgate.ExecutorConfig{
@@ -85,6 +89,37 @@ func (vte *VTExplain) initVtgateExecutor(ctx context.Context, ts *topo.Server, v
 	return nil
 }
 
+// explainSchema provides vtgate with the tables of the schema, as the schema
+// tracker does. Since all the keyspaces share the same schema, only the tables
+// of the vschema of a keyspace are provided for it.
+type explainSchema struct {
+	tables    map[string]*vindexes.TableInfo
+	keyspaces map[string]*vschemapb.Keyspace
+}
+
+var _ vtgate.SchemaInfo = (*explainSchema)(nil)
+
+// Tables implements the vtgate.SchemaInfo interface.
+func (es *explainSchema) Tables(ks string) map[string]*vindexes.TableInfo {
+	tables := make(map[string]*vindexes.TableInfo)
+	for name := range es.keyspaces[ks].GetTables() {
+		if info, ok := es.tables[name]; ok {
+			tables[name] = info
+		}
+	}
+	return tables
+}
+
+// Views implements the vtgate.SchemaInfo interface.
+func (es *explainSchema) Views(string) map[string]sqlparser.TableStatement {
+	return nil
+}
+
+// UDFs implements the vtgate.SchemaInfo interface.
+func (es *explainSchema) UDFs(string) []string {
+	return nil
+}
+
 func (vte *VTExplain) newFakeResolver(ctx context.Context, opts *Options, serv srvtopo.Server, cell string) *vtgate.Resolver {
 	gw := vtgate.NewTabletGateway(ctx, vte.healthCheck, serv, cell)
 	_ = gw.WaitForTablets(ctx, []topodatapb.TabletType{topodatapb.TabletType_REPLICA})
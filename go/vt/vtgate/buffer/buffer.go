@@ -28,8 +28,10 @@ package buffer
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 
@@ -217,6 +219,77 @@ func (b *Buffer) HandleKeyspaceEvent(ksevent *discovery.KeyspaceEvent) {
 	}
 }
 
+// ShardStatus is the buffering state of a shard.
+type ShardStatus struct {
+	Keyspace string
+	Shard    string
+	State    string
+	// BufferedRequests is the number of requests which are currently buffered.
+	BufferedRequests int
+	// OldestRequestAge is how long the oldest buffered request has been waiting.
+	OldestRequestAge time.Duration
+	// BufferingDuration is how long the shard has been buffering, if it is.
+	BufferingDuration time.Duration
+}
+
+// Status returns the buffering state of all the shards which had a request go
+// through the buffer, ordered by keyspace and shard.
+func (b *Buffer) Status() []*ShardStatus {
+	b.mu.RLock()
+	sbs := make([]*shardBuffer, 0, len(b.buffers))
+	for _, sb := range b.buffers {
+		sbs = append(sbs, sb)
+	}
+	b.mu.RUnlock()
+
+	statuses := make([]*ShardStatus, 0, len(sbs))
+	for _, sb := range sbs {
+		statuses = append(statuses, sb.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Keyspace != statuses[j].Keyspace {
+			return statuses[i].Keyspace < statuses[j].Keyspace
+		}
+		return statuses[i].Shard < statuses[j].Shard
+	})
+	return statuses
+}
+
+// ForceDrain stops the ongoing buffering for keyspace/shard and retries the
+// buffered requests right away.
+func (b *Buffer) ForceDrain(keyspace, shard string) error {
+	sb, err := b.getBuffer(keyspace, shard)
+	if err != nil {
+		return err
+	}
+	return sb.forceDrain()
+}
+
+// ExtendBuffering extends the ongoing buffering for keyspace/shard, as well as
+// the buffering window of the requests which are currently buffered, by d.
+func (b *Buffer) ExtendBuffering(keyspace, shard string, d time.Duration) error {
+	if d <= 0 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "buffering can only be extended by a positive duration, got: %v", d)
+	}
+	sb, err := b.getBuffer(keyspace, shard)
+	if err != nil {
+		return err
+	}
+	return sb.extendBuffering(d)
+}
+
+// getBuffer returns the existing ShardBuffer for the given keyspace and shard.
+func (b *Buffer) getBuffer(keyspace, shard string) (*shardBuffer, error) {
+	key := topoproto.KeyspaceShardString(keyspace, shard)
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	sb, ok := b.buffers[key]
+	if !ok || b.stopped {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "shard %s is not buffering", key)
+	}
+	return sb, nil
+}
+
 // getOrCreateBuffer returns the ShardBuffer for the given keyspace and shard.
 // It returns nil if Buffer is shut down and all calls should be ignored.
 func (b *Buffer) getOrCreateBuffer(keyspace, shard string) *shardBuffer {
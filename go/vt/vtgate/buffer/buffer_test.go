@@ -822,6 +822,107 @@ func testShutdown1(t *testing.T, fail failover) {
 	}
 }
 
+// TestForceDrain tests that buffering can be stopped on demand and that the
+// buffered requests are retried right away.
+func TestForceDrain(t *testing.T) {
+	resetVariables()
+	defer checkVariables(t)
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	b := New(cfg)
+	defer b.Shutdown()
+
+	// Force draining a shard which never buffered fails.
+	err := b.ForceDrain(keyspace, shard)
+	assert.ErrorContains(t, err, "shard ks1/0 is not buffering")
+
+	stopped1 := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := b.Status()
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, keyspace, statuses[0].Keyspace)
+		assert.Equal(t, shard, statuses[0].Shard)
+		assert.Equal(t, "BUFFERING", statuses[0].State)
+		assert.Equal(t, 1, statuses[0].BufferedRequests)
+	}
+
+	if err := b.ForceDrain(keyspace, shard); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-stopped1; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stops.Counts()[statsKeyJoined+"."+string(stopForceDrained)], int64(1); got != want {
+		t.Fatalf("wrong ForceDrained stops count: got = %v, want = %v", got, want)
+	}
+
+	// Once drained, the shard can no longer be drained.
+	err = b.ForceDrain(keyspace, shard)
+	assert.ErrorContains(t, err, "not buffering")
+	assert.Equal(t, 0, b.Status()[0].BufferedRequests)
+
+	if err := waitForPoolSlots(b, cfg.Size); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestExtendBuffering tests that buffering and the window of the buffered
+// requests can be extended past the configured maximum.
+func TestExtendBuffering(t *testing.T) {
+	testAllImplementations(t, testExtendBuffering)
+}
+
+func testExtendBuffering(t *testing.T, fail failover) {
+	resetVariables()
+	defer checkVariables(t)
+
+	cfg := NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.Window = 100 * time.Millisecond
+	cfg.MaxFailoverDuration = 100 * time.Millisecond
+	b := New(cfg)
+	defer b.Shutdown()
+
+	err := b.ExtendBuffering(keyspace, shard, 0)
+	assert.ErrorContains(t, err, "positive duration")
+
+	stopped1 := issueRequest(context.Background(), t, b, failoverErr)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ExtendBuffering(keyspace, shard, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the extension, the request would have been evicted by now.
+	time.Sleep(2 * cfg.Window)
+	if err := waitForRequestsInFlight(b, 1); err != nil {
+		t.Fatal(err)
+	}
+	status := b.Status()[0]
+	assert.Equal(t, "BUFFERING", status.State)
+	assert.GreaterOrEqual(t, status.OldestRequestAge, 2*cfg.Window)
+
+	// An explicit end of the failover still stops the extended buffering.
+	fail(b, newPrimary, keyspace, shard, time.Unix(1, 0))
+	if err := <-stopped1; err != nil {
+		t.Fatalf("request should have been buffered and not returned an error: %v", err)
+	}
+	if err := waitForState(b, stateIdle); err != nil {
+		t.Fatal(err)
+	}
+	if err := waitForPoolSlots(b, cfg.Size); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestParallelRangeIndex(t *testing.T) {
 	suite := []struct {
 		max         int
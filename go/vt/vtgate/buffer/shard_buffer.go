@@ -32,6 +32,7 @@ import (
 	"vitess.io/vitess/go/vt/vtgate/errorsanitizer"
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 // bufferState represents the different states a shardBuffer object can be in.
@@ -54,8 +55,12 @@ type entry struct {
 	// ShardBuffer queue such that nobody else tries to close it.
 	done chan struct{}
 
+	// start is the time when the request was buffered.
+	start time.Time
+
 	// deadline is the time when the entry is out of the buffering window and it
 	// must be canceled.
+	// It is guarded by shardBuffer.mu as it can be extended by extendBuffering().
 	deadline time.Time
 
 	// err is set if the buffering failed e.g. when the entry was evicted.
@@ -339,9 +344,11 @@ func (sb *shardBuffer) bufferRequestLocked(ctx context.Context) (*entry, error)
 		requestsEvicted.Add(statsKeyWithReason, 1)
 	}
 
+	now := sb.timeNow()
 	e := &entry{
 		done:     make(chan struct{}),
-		deadline: sb.timeNow().Add(sb.buf.config.Window),
+		start:    now,
+		deadline: now.Add(sb.buf.config.Window),
 	}
 	e.bufferCtx, e.bufferCancel = context.WithCancel(ctx)
 	sb.queue = append(sb.queue, e)
@@ -419,6 +426,13 @@ func (sb *shardBuffer) oldestEntry() *entry {
 	return nil
 }
 
+// entryDeadline returns the time when the given entry exceeds its buffering window.
+func (sb *shardBuffer) entryDeadline(e *entry) time.Time {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+	return e.deadline
+}
+
 // evictOldestEntry is used by timeoutThread to evict the head entry of the
 // queue if it exceeded its buffering window.
 func (sb *shardBuffer) evictOldestEntry(e *entry) {
@@ -631,6 +645,65 @@ func (sb *shardBuffer) drain(q []*entry, err error) {
 	sb.timeoutThread = nil
 }
 
+// forceDrain stops buffering, if in progress, and retries the buffered requests
+// right away, e.g. when an operator knows that a failover is over before it was
+// detected.
+func (sb *shardBuffer) forceDrain() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.state != stateBuffering {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s is not buffering (state: %s)",
+			topoproto.KeyspaceShardString(sb.keyspace, sb.shard), sb.state)
+	}
+	sb.stopBufferingLocked(stopForceDrained, "buffering was force drained")
+	return nil
+}
+
+// extendBuffering pushes out, by the given duration, both the end of the ongoing
+// buffering and the buffering window of the requests which are currently buffered,
+// e.g. when a failover takes longer than --buffer-max-failover-duration.
+func (sb *shardBuffer) extendBuffering(d time.Duration) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.state != stateBuffering {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "shard %s is not buffering (state: %s)",
+			topoproto.KeyspaceShardString(sb.keyspace, sb.shard), sb.state)
+	}
+	for _, e := range sb.queue {
+		e.deadline = e.deadline.Add(d)
+	}
+	sb.timeoutThread.extend(d)
+	log.Infof("Extended buffering for shard: %s by: %v for: %d buffered requests.",
+		topoproto.KeyspaceShardString(sb.keyspace, sb.shard), d, len(sb.queue))
+	return nil
+}
+
+// status returns the current buffering state of the shard.
+func (sb *shardBuffer) status() *ShardStatus {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	status := &ShardStatus{
+		Keyspace:         sb.keyspace,
+		Shard:            sb.shard,
+		State:            string(sb.state),
+		BufferedRequests: len(sb.queue),
+	}
+	if sb.mode == bufferModeDryRun {
+		status.State += " (dry-run)"
+	}
+	now := sb.timeNow()
+	if sb.state == stateBuffering {
+		status.BufferingDuration = now.Sub(sb.lastStart)
+	}
+	if len(sb.queue) > 0 {
+		status.OldestRequestAge = now.Sub(sb.queue[0].start)
+	}
+	return status
+}
+
 func (sb *shardBuffer) shutdown() {
 	sb.mu.Lock()
 	sb.stopBufferingLocked(stopShutdown, "shutdown")
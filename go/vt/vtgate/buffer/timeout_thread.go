@@ -41,14 +41,22 @@ type timeoutThread struct {
 	// state changes from empty to non-empty. After it's closed, a new object will
 	// be assigned to this field.
 	queueNotEmpty chan struct{}
+	// maxDurationEnd is when maxDuration fires, unless buffering was extended.
+	maxDurationEnd time.Time
+	// extended will be closed to notify the timeout thread when buffering was
+	// extended, such that it picks up the new deadline of the oldest entry. After
+	// it's closed, a new object will be assigned to this field.
+	extended chan struct{}
 }
 
 func newTimeoutThread(sb *shardBuffer, maxFailoverDuration time.Duration) *timeoutThread {
 	return &timeoutThread{
-		sb:            sb,
-		maxDuration:   time.NewTimer(maxFailoverDuration),
-		stopChan:      make(chan struct{}),
-		queueNotEmpty: make(chan struct{}),
+		sb:             sb,
+		maxDuration:    time.NewTimer(maxFailoverDuration),
+		stopChan:       make(chan struct{}),
+		queueNotEmpty:  make(chan struct{}),
+		maxDurationEnd: time.Now().Add(maxFailoverDuration),
+		extended:       make(chan struct{}),
 	}
 }
 
@@ -72,6 +80,24 @@ func (tt *timeoutThread) notifyQueueNotEmpty() {
 	tt.queueNotEmpty = make(chan struct{})
 }
 
+// extend pushes out the end of the max failover duration by d and notifies the
+// thread that the deadlines of the buffered entries have changed.
+func (tt *timeoutThread) extend(d time.Duration) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.maxDurationEnd = tt.maxDurationEnd.Add(d)
+	tt.maxDuration.Reset(time.Until(tt.maxDurationEnd))
+	close(tt.extended)
+	tt.extended = make(chan struct{})
+}
+
+func (tt *timeoutThread) extendedChan() chan struct{} {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	return tt.extended
+}
+
 func (tt *timeoutThread) run() {
 	defer tt.wg.Done()
 	defer tt.maxDuration.Stop()
@@ -95,7 +121,8 @@ func (tt *timeoutThread) run() {
 // waitForEntry blocks until "e" exceeds its buffering window or buffering stops
 // in general. It returns true if the timeout thread should stop.
 func (tt *timeoutThread) waitForEntry(e *entry) bool {
-	windowExceeded := time.NewTimer(time.Until(e.deadline))
+	extended := tt.extendedChan()
+	windowExceeded := time.NewTimer(time.Until(tt.sb.entryDeadline(e)))
 	defer windowExceeded.Stop()
 
 	select {
@@ -111,6 +138,9 @@ func (tt *timeoutThread) waitForEntry(e *entry) bool {
 	case <-e.done:
 		// Entry was drained or evicted. Get the next entry.
 		return false
+	case <-extended:
+		// Buffering was extended. Wait for the new deadline of the entry.
+		return false
 	// NOTE: We're not waiting for e.bufferCtx here (which triggers when the
 	// request was externally aborted e.g. due to context canceled) because then
 	// this thread would race with the request thread which runs
@@ -105,7 +105,7 @@ var (
 // stopReason is used in "stopsByReason" as "Reason" label.
 type stopReason string
 
-var stopReasons = []stopReason{stopShardMissing, stopFailoverEndDetected, stopMaxFailoverDurationExceeded, stopShutdown}
+var stopReasons = []stopReason{stopShardMissing, stopFailoverEndDetected, stopMaxFailoverDurationExceeded, stopShutdown, stopForceDrained}
 
 const (
 	stopShardMissing                stopReason = "ReshardingComplete"
@@ -113,6 +113,7 @@ const (
 	stopMaxFailoverDurationExceeded stopReason = "MaxDurationExceeded"
 	stopShutdown                    stopReason = "Shutdown"
 	stopMoveTablesSwitchingTraffic  stopReason = "MoveTablesSwitchedTraffic"
+	stopForceDrained                stopReason = "ForceDrained"
 
 	stopMoveTablesSwitchingTrafficMessage = "MoveTables has switched writes"
 	stopFailoverEndDetectedMessage        = "a primary promotion has been detected"
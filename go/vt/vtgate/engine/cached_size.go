@@ -122,6 +122,25 @@ func (cached *Condition) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *CoveringLookup) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field Owner vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Owner.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field Covering vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Covering.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
 func (cached *DBDDL) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -480,7 +499,7 @@ func (cached *Insert) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(224)
+		size += int64(256)
 	}
 	// field InsertCommon vitess.io/vitess/go/vt/vtgate/engine.InsertCommon
 	size += cached.InsertCommon.CachedSize(false)
@@ -521,6 +540,35 @@ func (cached *Insert) CachedSize(alloc bool) int64 {
 	}
 	// field Alias string
 	size += hack.RuntimeAllocSize(int64(len(cached.Alias)))
+	// field CheckConstraints []*vitess.io/vitess/go/vt/vtgate/engine.InsertCheck
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.CheckConstraints)) * int64(8))
+		for _, elem := range cached.CheckConstraints {
+			size += elem.CachedSize(true)
+		}
+	}
+	return size
+}
+
+func (cached *InsertCheck) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	// field Rows []vitess.io/vitess/go/vt/vtgate/evalengine.Expr
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Rows)) * int64(16))
+		for _, elem := range cached.Rows {
+			if cc, ok := elem.(cachedObject); ok {
+				size += cc.CachedSize(true)
+			}
+		}
+	}
 	return size
 }
 
@@ -918,7 +966,7 @@ func (cached *RecurseCTE) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(48)
+		size += int64(64)
 	}
 	// field Seed vitess.io/vitess/go/vt/vtgate/engine.Primitive
 	if cc, ok := cached.Seed.(cachedObject); ok {
@@ -935,6 +983,13 @@ func (cached *RecurseCTE) CachedSize(alloc bool) int64 {
 			size += hack.RuntimeAllocSize(int64(len(k)))
 		}
 	}
+	// field Distinct []vitess.io/vitess/go/vt/vtgate/engine.CheckCol
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Distinct)) * int64(48))
+		for _, elem := range cached.Distinct {
+			size += elem.CachedSize(false)
+		}
+	}
 	return size
 }
 
@@ -1679,6 +1734,53 @@ func (cached *VitessMetadata) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *Window) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Funcs []*vitess.io/vitess/go/vt/vtgate/engine.WindowFunc
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Funcs)) * int64(8))
+		for _, elem := range cached.Funcs {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field Input vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.Input.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
+func (cached *WindowFunc) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field PartitionBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.PartitionBy)) * int64(56))
+		for _, elem := range cached.PartitionBy {
+			size += elem.CachedSize(false)
+		}
+	}
+	// field OrderBy vitess.io/vitess/go/vt/vtgate/evalengine.Comparison
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.OrderBy)) * int64(56))
+		for _, elem := range cached.OrderBy {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
+
 func (cached *percentBasedMirror) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -1698,6 +1800,25 @@ func (cached *percentBasedMirror) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *percentBasedSplit) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field primitive vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.primitive.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	// field target vitess.io/vitess/go/vt/vtgate/engine.Primitive
+	if cc, ok := cached.target.(cachedObject); ok {
+		size += cc.CachedSize(true)
+	}
+	return size
+}
+
 //go:nocheckptr
 func (cached *shardRoute) CachedSize(alloc bool) int64 {
 	if cached == nil {
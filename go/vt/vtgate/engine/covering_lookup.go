@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var _ Primitive = (*CoveringLookup)(nil)
+
+// CoveringLookup answers a point select from the lookup table of a covering
+// vindex, which also stores the selected columns of the owner table.
+//
+// The lookup table is kept in sync by vreplication, so its reads can lag
+// behind the owner table: the plan on the owner table is used instead in
+// transactions, which must read their own writes. Outside of them, the fields
+// of the plan on the owner table are fetched along with the lookup, so that
+// its tablets check the table ACLs of the owner table.
+type CoveringLookup struct {
+	noTxNeeded
+
+	// Owner is the plan of the select on the owner table.
+	Owner Primitive
+	// Covering is the plan of the select on the lookup table.
+	Covering Primitive
+}
+
+// GetFields implements the Primitive interface.
+func (c *CoveringLookup) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return c.Owner.GetFields(ctx, vcursor, bindVars)
+}
+
+// TryExecute implements the Primitive interface.
+func (c *CoveringLookup) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	if vcursor.Session().InTransaction() {
+		return vcursor.ExecutePrimitive(ctx, c.Owner, bindVars, wantfields)
+	}
+	var result *sqltypes.Result
+	err := c.checkOwnerAccess(ctx, vcursor, bindVars, func(ctx context.Context) error {
+		var err error
+		result, err = vcursor.ExecutePrimitive(ctx, c.Covering, bindVars, wantfields)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TryStreamExecute implements the Primitive interface.
+func (c *CoveringLookup) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	if vcursor.Session().InTransaction() {
+		return vcursor.StreamExecutePrimitive(ctx, c.Owner, bindVars, wantfields, callback)
+	}
+	// The rows are only sent once the access to the owner table is checked.
+	var results []*sqltypes.Result
+	err := c.checkOwnerAccess(ctx, vcursor, bindVars, func(ctx context.Context) error {
+		return vcursor.StreamExecutePrimitive(ctx, c.Covering, bindVars, wantfields, func(qr *sqltypes.Result) error {
+			results = append(results, qr)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, qr := range results {
+		if err := callback(qr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOwnerAccess runs the lookup while getting the fields of the select on
+// the owner table, and fails if either fails.
+func (c *CoveringLookup) checkOwnerAccess(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, lookup func(ctx context.Context) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		_, err := c.Owner.GetFields(ctx, vcursor, bindVars)
+		return err
+	})
+	g.Go(func() error {
+		return lookup(ctx)
+	})
+	return g.Wait()
+}
+
+// Inputs implements the Primitive interface.
+func (c *CoveringLookup) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{c.Covering, c.Owner}, []map[string]any{{
+		inputName: "Covering",
+	}, {
+		inputName: "Owner",
+	}}
+}
+
+func (c *CoveringLookup) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "CoveringLookup",
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestCoveringLookup(t *testing.T) {
+	fields := sqltypes.MakeTestFields("email", "varchar")
+	ownerResult := sqltypes.MakeTestResult(fields, "owner@example.com")
+	coveringResult := sqltypes.MakeTestResult(fields, "covering@example.com")
+
+	newPlan := func(ownerErr error) (*CoveringLookup, *fakePrimitive, *fakePrimitive) {
+		owner := &fakePrimitive{results: []*sqltypes.Result{ownerResult}, sendErr: ownerErr}
+		if ownerErr != nil {
+			owner.results = nil
+		}
+		covering := &fakePrimitive{results: []*sqltypes.Result{coveringResult}}
+		return &CoveringLookup{Owner: owner, Covering: covering}, owner, covering
+	}
+
+	// Outside of transactions, the lookup table is read, and the owner table
+	// only gives its fields.
+	plan, owner, covering := newPlan(nil)
+	qr, err := plan.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, coveringResult, qr)
+	owner.ExpectLog(t, []string{"GetFields ", "Execute  true"})
+	covering.ExpectLog(t, []string{"Execute  true"})
+
+	plan, _, _ = newPlan(nil)
+	qr, err = wrapStreamExecute(plan, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, coveringResult, qr)
+
+	// Transactions read their own writes from the owner table.
+	plan, owner, covering = newPlan(nil)
+	qr, err = plan.TryExecute(context.Background(), &noopVCursor{inTx: true}, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, ownerResult, qr)
+	owner.ExpectLog(t, []string{"Execute  true"})
+	covering.ExpectLog(t, nil)
+
+	// The access to the owner table is denied by its tablets.
+	denied := errors.New("table acl error: select command denied to user")
+	plan, _, _ = newPlan(denied)
+	_, err = plan.TryExecute(context.Background(), &noopVCursor{}, nil, true)
+	require.ErrorIs(t, err, denied)
+
+	plan, _, _ = newPlan(denied)
+	var streamed []*sqltypes.Result
+	err = plan.TryStreamExecute(context.Background(), &noopVCursor{}, nil, true, func(qr *sqltypes.Result) error {
+		streamed = append(streamed, qr)
+		return nil
+	})
+	require.ErrorIs(t, err, denied)
+	require.Empty(t, streamed)
+}
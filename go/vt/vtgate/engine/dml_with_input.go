@@ -19,6 +19,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -38,6 +39,12 @@ type DMLWithInput struct {
 	DMLs       []Primitive
 	OutputCols [][]int
 	BVList     []map[string]int
+
+	// BatchSize is the maximum number of input rows each DML is executed for.
+	// All the rows are sent in a single DML when it is zero. The DMLs are
+	// executed in the transaction of the session, so that the statement is
+	// still atomic.
+	BatchSize int
 }
 
 func (dml *DMLWithInput) Inputs() ([]Primitive, []map[string]any) {
@@ -61,7 +68,7 @@ func (dml *DMLWithInput) TryExecute(ctx context.Context, vcursor VCursor, bindVa
 	for idx, prim := range dml.DMLs {
 		var qr *sqltypes.Result
 		if len(dml.BVList) == 0 || len(dml.BVList[idx]) == 0 {
-			qr, err = executeLiteralUpdate(ctx, vcursor, bindVars, prim, inputRes, dml.OutputCols[idx])
+			qr, err = dml.executeLiteralUpdateInBatches(ctx, vcursor, bindVars, prim, inputRes, dml.OutputCols[idx])
 		} else {
 			qr, err = executeNonLiteralUpdate(ctx, vcursor, bindVars, prim, inputRes, dml.OutputCols[idx], dml.BVList[idx])
 		}
@@ -78,6 +85,26 @@ func (dml *DMLWithInput) TryExecute(ctx context.Context, vcursor VCursor, bindVa
 	return res, nil
 }
 
+// executeLiteralUpdateInBatches executes the primitive for each batch of rows of the input result.
+func (dml *DMLWithInput) executeLiteralUpdateInBatches(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, prim Primitive, inputRes *sqltypes.Result, outputCols []int) (*sqltypes.Result, error) {
+	if dml.BatchSize <= 0 || len(inputRes.Rows) <= dml.BatchSize {
+		return executeLiteralUpdate(ctx, vcursor, bindVars, prim, inputRes, outputCols)
+	}
+	var res *sqltypes.Result
+	for rows := range slices.Chunk(inputRes.Rows, dml.BatchSize) {
+		qr, err := executeLiteralUpdate(ctx, vcursor, bindVars, prim, &sqltypes.Result{Rows: rows}, outputCols)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = qr
+		} else {
+			res.RowsAffected += qr.RowsAffected
+		}
+	}
+	return res, nil
+}
+
 // executeLiteralUpdate executes the primitive that can be executed with a single bind variable from the input result.
 // The column updated have same value for all rows in the input result.
 func executeLiteralUpdate(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, prim Primitive, inputRes *sqltypes.Result, outputCols []int) (*sqltypes.Result, error) {
@@ -168,6 +195,9 @@ func (dml *DMLWithInput) description() PrimitiveDescription {
 	if len(bvList) > 0 {
 		other["BindVars"] = bvList
 	}
+	if dml.BatchSize > 0 {
+		other["BatchSize"] = dml.BatchSize
+	}
 	return PrimitiveDescription{
 		OperatorType: "DMLWithInput",
 		Other:        other,
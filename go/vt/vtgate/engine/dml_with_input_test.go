@@ -126,6 +126,44 @@ func TestDeleteWithInputMultiOffset(t *testing.T) {
 	})
 }
 
+func TestDeleteWithInputBatchSize(t *testing.T) {
+	input := &fakePrimitive{results: []*sqltypes.Result{
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1", "2", "3"),
+	}}
+
+	del := &DMLWithInput{
+		Input: input,
+		DMLs: []Primitive{&Delete{
+			DML: &DML{
+				RoutingParameters: &RoutingParameters{
+					Opcode: Scatter,
+					Keyspace: &vindexes.Keyspace{
+						Name:    "ks",
+						Sharded: true,
+					},
+				},
+				Query: "dummy_delete",
+			},
+		}},
+		OutputCols: [][]int{{0}},
+		BatchSize:  2,
+	}
+
+	vc := newTestVCursor("-20", "20-")
+	_, err := del.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	firstBatch := &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: querypb.Type_INT64, Value: []byte("1")}, {Type: querypb.Type_INT64, Value: []byte("2")}}}
+	secondBatch := &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: querypb.Type_INT64, Value: []byte("3")}}}
+	vc.ExpectLog(t, []string{
+		`InDMLExecution set to true`,
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		fmt.Sprintf(`ExecuteMultiShard ks.-20: dummy_delete {dml_vals: %v} ks.20-: dummy_delete {dml_vals: %v} true false`, firstBatch, firstBatch),
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		fmt.Sprintf(`ExecuteMultiShard ks.-20: dummy_delete {dml_vals: %v} ks.20-: dummy_delete {dml_vals: %v} true false`, secondBatch, secondBatch),
+		`InDMLExecution set to false`,
+	})
+}
+
 func TestDeleteWithMultiTarget(t *testing.T) {
 	input := &fakePrimitive{results: []*sqltypes.Result{
 		sqltypes.MakeTestResult(
@@ -49,6 +49,7 @@ import (
 var (
 	testMaxMemoryRows       = 100
 	testIgnoreMaxMemoryRows = false
+	testSpillDir            = ""
 )
 
 var (
@@ -223,6 +224,10 @@ func (t *noopVCursor) SetMigrationContext(migrationContext string) {
 	panic("implement me")
 }
 
+func (t *noopVCursor) RestoreSessionToken(ctx context.Context, token string) error {
+	panic("implement me")
+}
+
 func (t *noopVCursor) GetMigrationContext() string {
 	panic("implement me")
 }
@@ -318,11 +323,27 @@ func (t *noopVCursor) SetClientFoundRows(context.Context, bool) error {
 	panic("implement me")
 }
 
+func (t *noopVCursor) SetResultChecksum(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (t *noopVCursor) SetStickyShards(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (t *noopVCursor) PinnedShards(string) []string {
+	return nil
+}
+
+func (t *noopVCursor) PinShards(string, []string) {}
+
 func (t *noopVCursor) SetQueryTimeout(maxExecutionTime int64) {
 }
 
 func (t *noopVCursor) SetTransactionTimeout(timeout int64) {}
 
+func (t *noopVCursor) SetScatterConcurrency(int64) {}
+
 func (t *noopVCursor) SetSkipQueryPlanCache(context.Context, bool) error {
 	panic("implement me")
 }
@@ -367,6 +388,10 @@ func (t *noopVCursor) ExceedsMaxMemoryRows(numRows int) bool {
 	return !testIgnoreMaxMemoryRows && numRows > testMaxMemoryRows
 }
 
+func (t *noopVCursor) SpillDir() string {
+	return testSpillDir
+}
+
 func (t *noopVCursor) GetKeyspace() string {
 	return "test_ks"
 }
@@ -462,12 +487,16 @@ type loggingVCursor struct {
 	inReservedConn  bool
 	systemVariables map[string]string
 	disableSetVar   bool
+	sqlMode         string
 
 	// map different shards to keyspaces in the test.
 	ksShardMap map[string][]string
 
 	shardSession []*srvtopo.ResolvedShard
 
+	stickyShards bool
+	pinnedShards map[string][]string
+
 	parser *sqlparser.Parser
 
 	onMirrorClonesFn       func(context.Context) VCursor
@@ -478,6 +507,13 @@ type loggingVCursor struct {
 	metrics *Metrics
 }
 
+func (f *loggingVCursor) SQLMode() string {
+	if f.sqlMode != "" {
+		return f.sqlMode
+	}
+	return f.noopVCursor.SQLMode()
+}
+
 func (f *loggingVCursor) GetExecutionMetrics() *Metrics {
 	return f.metrics
 }
@@ -838,6 +874,35 @@ func (f *loggingVCursor) SetClientFoundRows(context.Context, bool) error {
 	panic("implement me")
 }
 
+func (f *loggingVCursor) SetResultChecksum(context.Context, bool) error {
+	panic("implement me")
+}
+
+func (f *loggingVCursor) SetStickyShards(_ context.Context, stickyShards bool) error {
+	f.stickyShards = stickyShards
+	f.pinnedShards = nil
+	return nil
+}
+
+func (f *loggingVCursor) PinnedShards(pin string) []string {
+	return f.pinnedShards[pin]
+}
+
+func (f *loggingVCursor) PinShards(pin string, shards []string) {
+	if !f.stickyShards {
+		return
+	}
+	if f.pinnedShards == nil {
+		f.pinnedShards = make(map[string][]string)
+	}
+	f.log = append(f.log, fmt.Sprintf("PinShards %s %v", pin, shards))
+	if len(shards) == 0 {
+		delete(f.pinnedShards, pin)
+		return
+	}
+	f.pinnedShards[pin] = shards
+}
+
 func (f *loggingVCursor) SetSkipQueryPlanCache(context.Context, bool) error {
 	panic("implement me")
 }
@@ -135,6 +135,16 @@ func (hj *HashJoin) TryExecute(ctx context.Context, vcursor VCursor, bindVars ma
 func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
 	// build the probe table from the LHS result
 	pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+	// When the probe table does not fit in memory, the rows of both inputs
+	// are spilled to disk in partitions, which are joined once all the rows
+	// are read.
+	var spill *hashJoinSpill
+	defer func() {
+		if spill != nil {
+			spill.close()
+		}
+	}()
+	var leftRows int
 	var lfields []*querypb.Field
 	var mu sync.Mutex
 	err := vcursor.StreamExecutePrimitive(ctx, hj.Left, bindVars, wantfields, func(result *sqltypes.Result) error {
@@ -144,11 +154,31 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			lfields = result.Fields
 		}
 		for _, current := range result.Rows {
-			err := pt.addLeftRow(current)
+			var err error
+			if spill != nil {
+				err = spill.addLeftRow(pt, current)
+			} else {
+				err = pt.addLeftRow(current)
+			}
 			if err != nil {
 				return err
 			}
 		}
+		leftRows += len(result.Rows)
+		if spill == nil && vcursor.SpillDir() != "" && vcursor.ExceedsMaxMemoryRows(leftRows) {
+			var err error
+			if spill, err = newHashJoinSpill(vcursor.SpillDir()); err != nil {
+				return err
+			}
+			for _, e := range pt.innerMap {
+				for ; e != nil; e = e.next {
+					if err := spill.addLeftRow(pt, e.row); err != nil {
+						return err
+					}
+				}
+			}
+			pt = newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+		}
 		return nil
 	})
 	if err != nil {
@@ -167,6 +197,12 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 			res.Fields = joinFields(lfields, result.Fields, hj.Cols)
 		}
 		for _, currentRHSRow := range result.Rows {
+			if spill != nil {
+				if err := spill.addRightRow(pt, currentRHSRow); err != nil {
+					return err
+				}
+				continue
+			}
 			results, err := pt.get(currentRHSRow)
 			if err != nil {
 				return err
@@ -182,17 +218,20 @@ func (hj *HashJoin) TryStreamExecute(ctx context.Context, vcursor VCursor, bindV
 		return err
 	}
 
-	if hj.Opcode == LeftJoin {
-		res := &sqltypes.Result{}
-		if sendFields.CompareAndSwap(true, false) {
-			// If we still have not sent the fields, we need to fetch
-			// the fields from the RHS to be able to build the result fields
-			rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
-			if err != nil {
-				return err
-			}
-			res.Fields = joinFields(lfields, rres.Fields, hj.Cols)
+	res := &sqltypes.Result{}
+	if hj.Opcode == LeftJoin && sendFields.CompareAndSwap(true, false) {
+		// If we still have not sent the fields, we need to fetch
+		// the fields from the RHS to be able to build the result fields
+		rres, err := hj.Right.GetFields(ctx, vcursor, bindVars)
+		if err != nil {
+			return err
 		}
+		res.Fields = joinFields(lfields, rres.Fields, hj.Cols)
+	}
+	if spill != nil {
+		return spill.join(hj, res.Fields, callback)
+	}
+	if hj.Opcode == LeftJoin {
 		// this will only be called when all the concurrent access to the pt has
 		// ceased, so we don't need to lock it here
 		res.Rows = pt.notFetched()
@@ -18,8 +18,10 @@ package engine
 
 import (
 	"context"
+	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -158,6 +160,23 @@ func TestHashJoinVariations(t *testing.T) {
 			require.NoError(t, err)
 			expectResultAnyOrder(t, r, expected)
 		})
+		t.Run("Spilling "+tc.name, func(t *testing.T) {
+			saveMax, saveSpillDir := testMaxMemoryRows, testSpillDir
+			defer func() {
+				testMaxMemoryRows, testSpillDir = saveMax, saveSpillDir
+			}()
+			testMaxMemoryRows = 1
+			testSpillDir = t.TempDir()
+
+			jn.Left = first()
+			jn.Right = last()
+			r, err := wrapStreamExecute(jn, &noopVCursor{}, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			expectResultAnyOrder(t, r, expected)
+			files, err := os.ReadDir(testSpillDir)
+			require.NoError(t, err)
+			assert.Empty(t, files)
+		})
 	}
 }
 
@@ -57,6 +57,24 @@ type Insert struct {
 
 	// Alias represents the row alias with columns if specified in the query.
 	Alias string
+
+	// CheckConstraints are the CHECK constraints of the table which can be
+	// verified by vtgate before sending the rows to MySQL.
+	CheckConstraints []*InsertCheck
+
+	// GeneratedVindexValues is set when vtgate computes the values of generated
+	// vindex columns to route the rows. MySQL only stores the same values in a
+	// strict sql_mode, as it adjusts the invalid values in the other modes.
+	GeneratedVindexValues bool
+}
+
+// InsertCheck is a CHECK constraint evaluated against the rows of an insert.
+type InsertCheck struct {
+	// Name is the name of the constraint.
+	Name string
+	// Rows holds the constraint expression for each row of the insert, in which
+	// the columns are replaced by the values of the row.
+	Rows []evalengine.Expr
 }
 
 // newQueryInsert creates an Insert with a query string. Used in testing.
@@ -130,6 +148,9 @@ func (ins *Insert) insertIntoUnshardedTable(ctx context.Context, vcursor VCursor
 	if err != nil {
 		return nil, err
 	}
+	if err := ins.checkConstraints(ctx, vcursor, bindVars); err != nil {
+		return nil, err
+	}
 
 	return ins.executeUnshardedTableQuery(ctx, vcursor, ins, bindVars, ins.Query, uint64(insertID))
 }
@@ -139,10 +160,17 @@ func (ins *Insert) insertIntoShardedTable(
 	vcursor VCursor,
 	bindVars map[string]*querypb.BindVariable,
 ) (*sqltypes.Result, error) {
+	// The rows could be routed by other values than the ones stored by MySQL.
+	if ins.GeneratedVindexValues && !evalengine.ParseSQLMode(vcursor.SQLMode()).Strict() {
+		return nil, vterrors.VT12001("generated vindex columns when the sql_mode is not strict")
+	}
 	insertID, err := ins.processGenerateFromValues(ctx, vcursor, ins, bindVars)
 	if err != nil {
 		return nil, err
 	}
+	if err := ins.checkConstraints(ctx, vcursor, bindVars); err != nil {
+		return nil, err
+	}
 	rss, queries, err := ins.getInsertShardedQueries(ctx, vcursor, bindVars)
 	if err != nil {
 		return nil, err
@@ -151,6 +179,31 @@ func (ins *Insert) insertIntoShardedTable(
 	return ins.executeInsertQueries(ctx, vcursor, rss, queries, uint64(insertID))
 }
 
+// checkConstraints returns an error if a row of the insert violates one of
+// the CHECK constraints, so that no row is sent to MySQL. As in MySQL, a
+// constraint which evaluates to NULL is not violated. A constraint which
+// cannot be evaluated is left for MySQL to verify, and so are all of them
+// when the sql_mode is not strict, as MySQL then verifies them against the
+// values adjusted to the types of the columns.
+func (ins *Insert) checkConstraints(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) error {
+	if len(ins.CheckConstraints) == 0 || !evalengine.ParseSQLMode(vcursor.SQLMode()).Strict() {
+		return nil
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	for _, check := range ins.CheckConstraints {
+		for _, row := range check.Rows {
+			result, err := env.Evaluate(row)
+			if err != nil {
+				continue
+			}
+			if !result.Value(vcursor.ConnCollation()).IsNull() && !result.ToBoolean() {
+				return vterrors.VT03035(check.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func (ins *Insert) executeInsertQueries(
 	ctx context.Context,
 	vcursor VCursor,
@@ -380,6 +433,21 @@ func (ins *Insert) description() PrimitiveDescription {
 		other["FetchLastInsertID"] = true
 	}
 
+	if len(ins.CheckConstraints) > 0 {
+		checks := map[string]string{}
+		for _, check := range ins.CheckConstraints {
+			var rows []string
+			for _, row := range check.Rows {
+				rows = append(rows, sqlparser.String(row))
+			}
+			checks[check.Name] = strings.Join(rows, ", ")
+		}
+		other["CheckConstraints"] = checks
+	}
+	if ins.GeneratedVindexValues {
+		other["GeneratedVindexValues"] = true
+	}
+
 	return PrimitiveDescription{
 		OperatorType: "Insert",
 		Keyspace:     ins.Keyspace,
@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -68,6 +69,78 @@ func TestInsertUnsharded(t *testing.T) {
 	require.EqualError(t, err, `VT09022: Destination does not have exactly one shard: []`)
 }
 
+func TestInsertCheckConstraints(t *testing.T) {
+	ins := newQueryInsert(
+		InsertUnsharded,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: false,
+		},
+		"dummy_insert",
+	)
+	ins.CheckConstraints = []*InsertCheck{{
+		Name: "chk_null",
+		Rows: []evalengine.Expr{evalengine.NullExpr},
+	}, {
+		Name: "chk_value",
+		Rows: []evalengine.Expr{
+			evalengine.NewLiteralInt(1),
+			evalengine.NewBindVar("missing", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID)),
+		},
+	}}
+
+	// Rows which cannot be evaluated are left for MySQL to verify.
+	vc := newTestVCursor("0")
+	vc.results = []*sqltypes.Result{{}}
+	_, err := ins.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: dummy_insert {} true true`,
+	})
+
+	ins.CheckConstraints[1].Rows[1] = evalengine.NewLiteralInt(0)
+	vc = newTestVCursor("0")
+	_, err = ins.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "VT03035: Check constraint 'chk_value' is violated.")
+	vc.ExpectLog(t, nil)
+
+	// MySQL verifies the constraints against the values adjusted to the types
+	// of the columns when the sql_mode is not strict.
+	vc = newTestVCursor("0")
+	vc.sqlMode = "NO_ENGINE_SUBSTITUTION"
+	vc.results = []*sqltypes.Result{{}}
+	_, err = ins.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`ExecuteMultiShard ks.0: dummy_insert {} true true`,
+	})
+}
+
+func TestInsertGeneratedVindexValuesNotStrict(t *testing.T) {
+	ins := newInsert(
+		InsertSharded,
+		false,
+		&vindexes.Keyspace{
+			Name:    "sharded",
+			Sharded: true,
+		},
+		[][][]evalengine.Expr{{{evalengine.NewLiteralInt(1)}}},
+		&vindexes.BaseTable{},
+		"prefix",
+		sqlparser.Values{{&sqlparser.Argument{Name: "_id_0", Type: sqltypes.Int64}}},
+		nil,
+	)
+	ins.GeneratedVindexValues = true
+
+	vc := newTestVCursor("-20", "20-")
+	vc.sqlMode = "NO_ENGINE_SUBSTITUTION"
+	_, err := ins.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.EqualError(t, err, "VT12001: unsupported: generated vindex columns when the sql_mode is not strict")
+	vc.ExpectLog(t, nil)
+}
+
 func TestInsertUnshardedGenerate(t *testing.T) {
 	ins := newQueryInsert(
 		InsertUnsharded,
@@ -82,6 +82,14 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 		Compare: ms.OrderBy,
 		Limit:   count,
 	}
+	// When the rows do not fit in memory, they are spilled to disk in sorted
+	// runs, which are merged once all the rows are read.
+	var spill *sortSpill
+	defer func() {
+		if spill != nil {
+			spill.close()
+		}
+	}()
 
 	var mu sync.Mutex
 	err = vcursor.StreamExecutePrimitive(ctx, ms.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
@@ -96,13 +104,29 @@ func (ms *MemorySort) TryStreamExecute(ctx context.Context, vcursor VCursor, bin
 			sorter.Push(row)
 		}
 		if vcursor.ExceedsMaxMemoryRows(sorter.Len()) {
-			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			dir := vcursor.SpillDir()
+			if dir == "" {
+				return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+			}
+			if spill == nil {
+				spill = &sortSpill{dir: dir, compare: ms.OrderBy, limit: count}
+			}
+			if err := spill.spill(sorter.Sorted()); err != nil {
+				return err
+			}
+			sorter = &evalengine.Sorter{
+				Compare: ms.OrderBy,
+				Limit:   count,
+			}
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	if spill != nil {
+		return spill.merge(sorter.Sorted(), cb)
+	}
 	return cb(&sqltypes.Result{Rows: sorter.Sorted()})
 }
 
@@ -18,8 +18,10 @@ package engine
 
 import (
 	"context"
+	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/mysql/collations"
@@ -610,6 +612,120 @@ func TestMemorySortMaxMemoryRows(t *testing.T) {
 	}
 }
 
+func TestMemorySortSpill(t *testing.T) {
+	saveMax, saveSpillDir := testMaxMemoryRows, testSpillDir
+	defer func() {
+		testMaxMemoryRows, testSpillDir = saveMax, saveSpillDir
+	}()
+	testMaxMemoryRows = 3
+	testSpillDir = t.TempDir()
+
+	fields := sqltypes.MakeTestFields(
+		"c1|c2",
+		"varchar|int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"a|5",
+			"b|2",
+			"|7",
+			"c|4",
+			"null|3",
+			"e|1",
+			"f|6",
+			"g|null",
+		)},
+	}
+	ms := &MemorySort{
+		OrderBy: []evalengine.OrderByParams{{
+			WeightStringCol: -1,
+			Col:             1,
+			Type:            evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+		}},
+		Input: fp,
+	}
+
+	result, err := wrapStreamExecute(ms, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"g|null",
+		"e|1",
+		"b|2",
+		"null|3",
+		"c|4",
+		"a|5",
+		"f|6",
+		"|7",
+	), result)
+
+	// The limit applies to the merged rows.
+	fp.rewind()
+	ms.UpperLimit = evalengine.NewBindVar("__upper_limit", evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID))
+	bv := map[string]*querypb.BindVariable{"__upper_limit": sqltypes.Int64BindVariable(5)}
+	result, err = wrapStreamExecute(ms, &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields,
+		"g|null",
+		"e|1",
+		"b|2",
+		"null|3",
+		"c|4",
+	), result)
+
+	files, err := os.ReadDir(testSpillDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestSortSpillMaxRuns(t *testing.T) {
+	dir := t.TempDir()
+	ss := &sortSpill{
+		dir: dir,
+		compare: evalengine.Comparison{{
+			WeightStringCol: -1,
+			Col:             0,
+			Type:            evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+		}},
+		limit: 1000,
+	}
+
+	// More runs than the sort keeps, in reverse order.
+	const runs = 3*sortSpillMaxRuns + 10
+	for i := runs; i > 0; i-- {
+		require.NoError(t, ss.spill([]sqltypes.Row{{sqltypes.NewInt64(int64(2 * i))}}))
+		assert.LessOrEqual(t, len(ss.runs), sortSpillMaxRuns)
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(files), sortSpillMaxRuns)
+	}
+
+	var got []int64
+	rows := []sqltypes.Row{{sqltypes.NewInt64(1)}, {sqltypes.NewInt64(2*runs + 1)}}
+	err := ss.merge(rows, func(qr *sqltypes.Result) error {
+		for _, row := range qr.Rows {
+			v, err := row[0].ToInt64()
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	want := []int64{1}
+	for i := 1; i <= runs; i++ {
+		want = append(want, int64(2*i))
+	}
+	want = append(want, 2*runs+1)
+	assert.Equal(t, want, got)
+
+	ss.close()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
 func TestMemorySortExecuteNoVarChar(t *testing.T) {
 	fields := sqltypes.MakeTestFields(
 		"c1|c2",
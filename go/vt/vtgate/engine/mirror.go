@@ -39,6 +39,15 @@ type (
 		target    Primitive
 	}
 
+	// percentBasedSplit represents the instructions to execute, based on
+	// whether a die-roll exceeds a percentage, either a target Primitive or
+	// an authoritative primitive.
+	percentBasedSplit struct {
+		percent   float32
+		primitive Primitive
+		target    Primitive
+	}
+
 	mirrorResult struct {
 		execTime time.Duration
 		err      error
@@ -51,7 +60,10 @@ const (
 	maxMirrorTargetLag = 100 * time.Millisecond
 )
 
-var _ Primitive = (*percentBasedMirror)(nil)
+var (
+	_ Primitive = (*percentBasedMirror)(nil)
+	_ Primitive = (*percentBasedSplit)(nil)
+)
 
 // NewPercentBasedMirror creates a Mirror.
 func NewPercentBasedMirror(percentage float32, primitive Primitive, target Primitive) Primitive {
@@ -179,5 +191,57 @@ func (m *percentBasedMirror) description() PrimitiveDescription {
 }
 
 func (m *percentBasedMirror) percentAtLeastDieRoll() bool {
-	return m.percent >= (rand.Float32() * 100.0)
+	return percentAtLeastDieRoll(m.percent)
+}
+
+// NewPercentBasedSplit creates a Split.
+func NewPercentBasedSplit(percentage float32, primitive Primitive, target Primitive) Primitive {
+	return &percentBasedSplit{percent: percentage, primitive: primitive, target: target}
+}
+
+func (s *percentBasedSplit) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return s.primitive.GetFields(ctx, vcursor, bindVars)
+}
+
+func (s *percentBasedSplit) NeedsTransaction() bool {
+	return s.primitive.NeedsTransaction()
+}
+
+func (s *percentBasedSplit) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	return vcursor.ExecutePrimitive(ctx, s.choose(), bindVars, wantfields)
+}
+
+func (s *percentBasedSplit) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	return vcursor.StreamExecutePrimitive(ctx, s.choose(), bindVars, wantfields, callback)
+}
+
+// Inputs is a slice containing the inputs to this Primitive.
+// The returned map has additional information about the inputs, that is used in the description.
+func (s *percentBasedSplit) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{s.primitive, s.target}, nil
+}
+
+// description is the description, sans the inputs, of this Primitive.
+// to get the plan description with all children, use PrimitiveToPlanDescription()
+func (s *percentBasedSplit) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "Split",
+		Variant:      "PercentBased",
+		Other: map[string]any{
+			"Percent": s.percent,
+		},
+	}
+}
+
+// choose returns the target if the die-roll passes, and the primitive
+// otherwise.
+func (s *percentBasedSplit) choose() Primitive {
+	if percentAtLeastDieRoll(s.percent) {
+		return s.target
+	}
+	return s.primitive
+}
+
+func percentAtLeastDieRoll(percent float32) bool {
+	return percent >= (rand.Float32() * 100.0)
 }
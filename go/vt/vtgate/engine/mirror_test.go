@@ -548,3 +548,67 @@ func TestMirror(t *testing.T) {
 		require.ErrorContains(t, *targetErr.Load(), "Mirror target query took too long")
 	})
 }
+
+func TestSplit(t *testing.T) {
+	primitive := NewRoute(
+		Unsharded,
+		&vindexes.Keyspace{
+			Name: "ks1",
+		},
+		"select f.bar from foo f where f.id = 1",
+		"select 1 from foo f where f.id = 1 and 1 != 1",
+	)
+	target := NewRoute(
+		Unsharded,
+		&vindexes.Keyspace{
+			Name: "ks2",
+		},
+		"select f.bar from foo f where f.id = 1",
+		"select 1 from foo f where f.id = 1 and 1 != 1",
+	)
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("bar", "varchar"), "hello")
+
+	testCases := []struct {
+		name     string
+		percent  float32
+		keyspace string
+	}{{
+		name:     "served by target",
+		percent:  100,
+		keyspace: "ks2",
+	}, {
+		name:     "served by primitive",
+		percent:  0,
+		keyspace: "ks1",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			split := NewPercentBasedSplit(tc.percent, primitive, target)
+			vc := &loggingVCursor{
+				shards:  []string{"0"},
+				results: []*sqltypes.Result{result},
+				onMirrorClonesFn: func(ctx context.Context) VCursor {
+					require.FailNow(t, "split queries must not be mirrored")
+					return nil
+				},
+			}
+
+			res, err := split.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			require.Equal(t, result, res)
+			vc.ExpectLog(t, []string{
+				fmt.Sprintf("ResolveDestinations %s [] Destinations:DestinationAllShards()", tc.keyspace),
+				fmt.Sprintf("ExecuteMultiShard %s.0: select f.bar from foo f where f.id = 1 {} false false", tc.keyspace),
+			})
+
+			vc.Rewind()
+			res, err = wrapStreamExecute(split, vc, map[string]*querypb.BindVariable{}, true)
+			require.NoError(t, err)
+			require.Equal(t, result, res)
+			vc.ExpectLog(t, []string{
+				fmt.Sprintf("ResolveDestinations %s [] Destinations:DestinationAllShards()", tc.keyspace),
+				fmt.Sprintf("StreamExecuteMulti select f.bar from foo f where f.id = 1 %s.0: {} ", tc.keyspace),
+			})
+		})
+	}
+}
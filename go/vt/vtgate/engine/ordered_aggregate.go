@@ -49,6 +49,12 @@ type OrderedAggregate struct {
 	// from the result received. If 0, no truncation happens.
 	TruncateColumnCount int
 
+	// WithRollup is set for GROUP BY ... WITH ROLLUP. After the rows of
+	// each group, the super-aggregate rows of the groups it belongs to
+	// are produced, with NULL in the rolled up grouping columns, in the
+	// same order as MySQL does.
+	WithRollup bool
+
 	// Input is the primitive that will feed into this Primitive.
 	Input Primitive
 }
@@ -129,9 +135,12 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 	if err != nil {
 		return nil, err
 	}
-	if len(oa.Aggregates) == 0 {
+	if len(oa.Aggregates) == 0 && !oa.WithRollup {
 		return oa.executeGroupBy(result)
 	}
+	if oa.WithRollup {
+		return oa.executeWithRollup(result, env, vcursor.ConnCollation())
+	}
 
 	agg, fields, err := newAggregation(result.Fields, oa.Aggregates, env, vcursor.ConnCollation())
 	if err != nil {
@@ -177,6 +186,160 @@ func (oa *OrderedAggregate) execute(ctx context.Context, vcursor VCursor, bindVa
 	return out, nil
 }
 
+func (oa *OrderedAggregate) executeWithRollup(result *sqltypes.Result, env *evalengine.ExpressionEnv, coll collations.ID) (*sqltypes.Result, error) {
+	rollup, fields, err := oa.newRollup(result.Fields, env, coll)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &sqltypes.Result{
+		Fields: fields,
+		Rows:   make([][]sqltypes.Value, 0, len(result.Rows)),
+	}
+	for _, row := range result.Rows {
+		rows, err := rollup.add(row)
+		if err != nil {
+			return nil, err
+		}
+		out.Rows = append(out.Rows, rows...)
+	}
+	rows, err := rollup.finish()
+	if err != nil {
+		return nil, err
+	}
+	out.Rows = append(out.Rows, rows...)
+	return out, nil
+}
+
+func (oa *OrderedAggregate) streamExecuteWithRollup(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, callback func(*sqltypes.Result) error) error {
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(oa.TruncateColumnCount))
+	}
+
+	var rollup *rollupState
+	visitor := func(qr *sqltypes.Result) error {
+		if rollup == nil && len(qr.Fields) != 0 {
+			var fields []*querypb.Field
+			var err error
+			rollup, fields, err = oa.newRollup(qr.Fields, env, vcursor.ConnCollation())
+			if err != nil {
+				return err
+			}
+			if err := cb(&sqltypes.Result{Fields: fields}); err != nil {
+				return err
+			}
+		}
+		var out []sqltypes.Row
+		for _, row := range qr.Rows {
+			rows, err := rollup.add(row)
+			if err != nil {
+				return err
+			}
+			out = append(out, rows...)
+		}
+		if len(out) == 0 {
+			return nil
+		}
+		return cb(&sqltypes.Result{Rows: out})
+	}
+
+	/* we need the input fields types to correctly calculate the output types */
+	if err := vcursor.StreamExecutePrimitive(ctx, oa.Input, bindVars, true, visitor); err != nil {
+		return err
+	}
+	if rollup == nil {
+		return nil
+	}
+	rows, err := rollup.finish()
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	return cb(&sqltypes.Result{Rows: rows})
+}
+
+// rollupState aggregates the rows of the current group, and of each of the
+// groups it belongs to in the ROLLUP: levels[i] aggregates the rows which
+// share the values of the first i grouping keys, so levels[0] is the grand
+// total and levels[len(GroupByKeys)] is the current group itself.
+type rollupState struct {
+	oa         *OrderedAggregate
+	levels     []*aggregationState
+	currentKey []sqltypes.Value
+}
+
+func (oa *OrderedAggregate) newRollup(fields []*querypb.Field, env *evalengine.ExpressionEnv, coll collations.ID) (*rollupState, []*querypb.Field, error) {
+	rollup := &rollupState{oa: oa}
+	var outFields []*querypb.Field
+	for range len(oa.GroupByKeys) + 1 {
+		agg, aggFields, err := newAggregation(fields, oa.Aggregates, env, coll)
+		if err != nil {
+			return nil, nil, err
+		}
+		rollup.levels = append(rollup.levels, agg)
+		outFields = aggFields
+	}
+	return rollup, outFields, nil
+}
+
+// add aggregates the row, and returns the rows of the groups which ended
+// before it.
+func (r *rollupState) add(row sqltypes.Row) ([]sqltypes.Row, error) {
+	var out []sqltypes.Row
+	if r.currentKey != nil {
+		changed, err := r.oa.changedGroupByKey(r.currentKey, row)
+		if err != nil {
+			return nil, err
+		}
+		if changed < len(r.oa.GroupByKeys) {
+			out, err = r.finishLevels(changed + 1)
+			if err != nil {
+				return nil, err
+			}
+			r.currentKey = row
+		}
+	} else {
+		r.currentKey = row
+	}
+	for _, agg := range r.levels {
+		if err := agg.add(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// finish returns the rows of the last group and of all the groups it belongs to,
+// ending with the grand total. Nothing is returned if there were no rows.
+func (r *rollupState) finish() ([]sqltypes.Row, error) {
+	if r.currentKey == nil {
+		return nil, nil
+	}
+	return r.finishLevels(0)
+}
+
+// finishLevels returns the rows of the levels from the current group up to
+// the given level, which are then reset.
+func (r *rollupState) finishLevels(upTo int) ([]sqltypes.Row, error) {
+	var out []sqltypes.Row
+	for level := len(r.levels) - 1; level >= upTo; level-- {
+		values, err := r.levels[level].finish()
+		if err != nil {
+			return nil, err
+		}
+		for _, gb := range r.oa.GroupByKeys[level:] {
+			values[gb.KeyCol] = sqltypes.NULL
+			if gb.WeightStringCol != -1 {
+				values[gb.WeightStringCol] = sqltypes.NULL
+			}
+		}
+		out = append(out, values)
+		r.levels[level].reset()
+	}
+	return out, nil
+}
+
 func (oa *OrderedAggregate) executeStreamGroupBy(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, callback func(*sqltypes.Result) error) error {
 	cb := func(qr *sqltypes.Result) error {
 		return callback(qr.Truncate(oa.TruncateColumnCount))
@@ -230,6 +393,9 @@ func (oa *OrderedAggregate) executeStreamGroupBy(ctx context.Context, vcursor VC
 
 // TryStreamExecute is a Primitive function.
 func (oa *OrderedAggregate) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, _ bool, callback func(*sqltypes.Result) error) error {
+	if oa.WithRollup {
+		return oa.streamExecuteWithRollup(ctx, vcursor, bindVars, callback)
+	}
 	if len(oa.Aggregates) == 0 {
 		return oa.executeStreamGroupBy(ctx, vcursor, bindVars, callback)
 	}
@@ -334,30 +500,44 @@ func (oa *OrderedAggregate) nextGroupBy(currentKey, nextRow []sqltypes.Value) (n
 		return nextRow, false, nil
 	}
 
-	for _, gb := range oa.GroupByKeys {
+	changed, err := oa.changedGroupByKey(currentKey, nextRow)
+	if err != nil {
+		return nil, false, err
+	}
+	if changed < len(oa.GroupByKeys) {
+		return nextRow, true, nil
+	}
+	return currentKey, false, nil
+}
+
+// changedGroupByKey returns the index of the first grouping key which differs
+// between the two rows, or len(GroupByKeys) if they belong to the same group.
+func (oa *OrderedAggregate) changedGroupByKey(currentKey, nextRow []sqltypes.Value) (int, error) {
+	for i, gb := range oa.GroupByKeys {
 		v1 := currentKey[gb.KeyCol]
 		v2 := nextRow[gb.KeyCol]
 		if v1.TinyWeightCmp(v2) != 0 {
-			return nextRow, true, nil
+			return i, nil
 		}
 
 		cmp, err := evalengine.NullsafeCompare(v1, v2, gb.CollationEnv, gb.Type.Collation(), gb.Type.Values())
 		if err != nil {
 			_, isCollationErr := err.(evalengine.UnsupportedCollationError)
 			if !isCollationErr || gb.WeightStringCol == -1 {
-				return nil, false, err
+				return 0, err
 			}
-			gb.KeyCol = gb.WeightStringCol
+			// Compare the weight strings instead. The key column is left
+			// as is, as a ROLLUP needs it to set the super-aggregate rows.
 			cmp, err = evalengine.NullsafeCompare(currentKey[gb.WeightStringCol], nextRow[gb.WeightStringCol], gb.CollationEnv, gb.Type.Collation(), gb.Type.Values())
 			if err != nil {
-				return nil, false, err
+				return 0, err
 			}
 		}
 		if cmp != 0 {
-			return nextRow, true, nil
+			return i, nil
 		}
 	}
-	return currentKey, false, nil
+	return len(oa.GroupByKeys), nil
 }
 
 func aggregateParamsToString(in any) string {
@@ -378,6 +558,9 @@ func (oa *OrderedAggregate) description() PrimitiveDescription {
 	if oa.TruncateColumnCount > 0 {
 		other["ResultColumns"] = oa.TruncateColumnCount
 	}
+	if oa.WithRollup {
+		other["WithRollup"] = true
+	}
 	return PrimitiveDescription{
 		OperatorType: "Aggregate",
 		Variant:      "Ordered",
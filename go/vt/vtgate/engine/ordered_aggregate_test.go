@@ -1195,3 +1195,63 @@ func TestGroupConcat(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderedAggregateWithRollup(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"a|b|count(*)|weight_string(a)|weight_string(b)",
+		"varchar|varchar|int64|varbinary|varbinary",
+	)
+	input := sqltypes.MakeTestResult(
+		fields,
+		"x|1|1|X|1",
+		"x|1|2|X|1",
+		"x|2|3|X|2",
+		"y|1|4|Y|1",
+	)
+
+	newAggregate := func(fp Primitive) *OrderedAggregate {
+		aggr := NewAggregateParam(AggregateSum, 2, nil, "", collations.MySQL8())
+		aggr.OrigOpcode = AggregateCountStar
+		return &OrderedAggregate{
+			Aggregates:          []*AggregateParams{aggr},
+			GroupByKeys:         []*GroupByParams{{KeyCol: 0, WeightStringCol: 3}, {KeyCol: 1, WeightStringCol: 4}},
+			TruncateColumnCount: 3,
+			WithRollup:          true,
+			Input:               fp,
+		}
+	}
+	wantRows := sqltypes.MakeTestResult(
+		fields[:3],
+		"x|1|3",
+		"x|2|3",
+		"x|null|6",
+		"y|1|4",
+		"y|null|4",
+		"null|null|10",
+	).Rows
+
+	t.Run("execute", func(t *testing.T) {
+		oa := newAggregate(&fakePrimitive{results: []*sqltypes.Result{input}})
+		result, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, wantRows, result.Rows)
+	})
+
+	t.Run("stream execute", func(t *testing.T) {
+		oa := newAggregate(&fakePrimitive{results: []*sqltypes.Result{input}})
+		var rows []sqltypes.Row
+		err := oa.TryStreamExecute(context.Background(), &noopVCursor{}, nil, true, func(qr *sqltypes.Result) error {
+			rows = append(rows, qr.Rows...)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, wantRows, rows)
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		oa := newAggregate(&fakePrimitive{results: []*sqltypes.Result{sqltypes.MakeTestResult(fields)}})
+		result, err := oa.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+		require.NoError(t, err)
+		assert.Empty(t, result.Rows)
+	})
+}
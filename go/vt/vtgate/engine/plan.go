@@ -40,16 +40,17 @@ type (
 	// Primitive may form a subtree, combining results from its children to
 	// achieve the overall query result.
 	Plan struct {
-		Type         PlanType                // Type of plan (Passthrough, Scatter, JoinOp, Complex, etc.)
-		QueryType    sqlparser.StatementType // QueryType indicates the SQL statement type (SELECT, UPDATE, etc.)
-		Original     string                  // Original holds the raw query text
-		Instructions Primitive               // Instructions define how the query is executed.
-		BindVarNeeds *sqlparser.BindVarNeeds // BindVarNeeds lists required bind vars discovered during planning.
-		Warnings     []*query.QueryWarning   // Warnings accumulates any warnings generated for this plan.
-		TablesUsed   []string                // TablesUsed enumerates the tables this query accesses.
-		QueryHints   sqlparser.QueryHints    // QueryHints stores any SET_VAR hints that influenced plan generation.
-		ParamsCount  uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
-		Optimized    atomic.Bool             // Prepared queries need to be optimized before the first execution
+		Type             PlanType                // Type of plan (Passthrough, Scatter, JoinOp, Complex, etc.)
+		QueryType        sqlparser.StatementType // QueryType indicates the SQL statement type (SELECT, UPDATE, etc.)
+		Original         string                  // Original holds the raw query text
+		Instructions     Primitive               // Instructions define how the query is executed.
+		BindVarNeeds     *sqlparser.BindVarNeeds // BindVarNeeds lists required bind vars discovered during planning.
+		Warnings         []*query.QueryWarning   // Warnings accumulates any warnings generated for this plan.
+		TablesUsed       []string                // TablesUsed enumerates the tables this query accesses.
+		QueryHints       sqlparser.QueryHints    // QueryHints stores any SET_VAR hints that influenced plan generation.
+		ParamsCount      uint16                  // ParamsCount is the total number of bind parameters (?) in the query.
+		NonDeterministic bool                    // NonDeterministic is true if the query calls functions such as now() or rand().
+		Optimized        atomic.Bool             // Prepared queries need to be optimized before the first execution
 
 		ExecCount    uint64 // ExecCount is how many times this plan has been executed.
 		ExecTime     uint64 // ExecTime is the total accumulated execution time in nanoseconds.
@@ -68,6 +69,7 @@ type (
 		Query           string                // Query is the original or normalized SQL statement used to build the plan.
 		SetVarComment   string                // SetVarComment holds any embedded SET_VAR hints within the query.
 		Collation       collations.ID         // Collation is the character collation ID that governs string comparison.
+		VSchemaCanary   uint32                // VSchemaCanary is the percent of the canary vschema the plan is built with, if any.
 	}
 )
 
@@ -139,6 +141,8 @@ func getPlanType(p Primitive) PlanType {
 		return getPlanType(prim.Input)
 	case *VindexLookup:
 		return PlanLookup
+	case *CoveringLookup:
+		return getPlanType(prim.Owner)
 	case *Join:
 		return PlanJoinOp
 	case *FkCascade, *FkVerify:
@@ -266,6 +270,9 @@ func (pk PlanKey) Hash() theine.HashKey256 {
 	_, _ = hasher.WriteString(pk.Destination)
 	_, _ = hasher.WriteString(pk.SetVarComment)
 	_, _ = hasher.WriteString(pk.Query)
+	if pk.VSchemaCanary != 0 {
+		_, _ = hasher.WriteUint16(uint16(pk.VSchemaCanary))
+	}
 
 	var planKey theine.HashKey256
 	hasher.Sum(planKey[:0])
@@ -280,9 +287,48 @@ func NewPlan(query string, stmt sqlparser.Statement, primitive Primitive, bindVa
 		Instructions: primitive,
 		BindVarNeeds: bindVarNeeds,
 		TablesUsed:   tablesUsed,
+
+		NonDeterministic: isNonDeterministic(stmt),
 	}
 }
 
+// nonDeterministicFuncs are the functions whose results vary between the
+// executions of a query on the same rows.
+var nonDeterministicFuncs = map[string]bool{
+	"benchmark":         true,
+	"connection_id":     true,
+	"current_role":      true,
+	"current_user":      true,
+	"rand":              true,
+	"random_bytes":      true,
+	"release_all_locks": true,
+	"session_user":      true,
+	"sleep":             true,
+	"system_user":       true,
+	"unix_timestamp":    true,
+	"user":              true,
+	"uuid":              true,
+	"uuid_short":        true,
+}
+
+// isNonDeterministic returns true if the statement calls a function whose
+// results vary between executions, such as now(), rand() or uuid().
+func isNonDeterministic(stmt sqlparser.Statement) bool {
+	nonDeterministic := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.CurTimeFuncExpr, *sqlparser.LockingFunc:
+			nonDeterministic = true
+		case *sqlparser.FuncExpr:
+			if nonDeterministicFuncs[node.Name.Lowered()] {
+				nonDeterministic = true
+			}
+		}
+		return !nonDeterministic, nil
+	}, stmt)
+	return nonDeterministic
+}
+
 // MarshalJSON serializes the plan into a JSON representation.
 func (p *Plan) MarshalJSON() ([]byte, error) {
 	var instructions *PrimitiveDescription
@@ -56,6 +56,11 @@ type (
 		// if the max memory rows override directive is set to true
 		ExceedsMaxMemoryRows(numRows int) bool
 
+		// SpillDir returns the directory in which the primitives spill
+		// the rows exceeding maxMemoryRows. It is empty when spilling
+		// is disabled.
+		SpillDir() string
+
 		Execute(ctx context.Context, method string, query string, bindVars map[string]*querypb.BindVariable, rollbackOnError bool, co vtgatepb.CommitOrder) (*sqltypes.Result, error)
 		AutocommitApproval() bool
 
@@ -177,6 +182,7 @@ type (
 		SetAutocommit(ctx context.Context, autocommit bool) error
 		SetClientFoundRows(context.Context, bool) error
 		SetSkipQueryPlanCache(context.Context, bool) error
+		SetResultChecksum(context.Context, bool) error
 		SetSQLSelectLimit(int64) error
 		SetTransactionMode(vtgatepb.TransactionMode)
 		SetWorkload(querypb.ExecuteOptions_Workload)
@@ -193,6 +199,10 @@ type (
 		SetMigrationContext(string)
 		GetMigrationContext() string
 
+		// RestoreSessionToken restores the state of the session from a token
+		// of the user of the context.
+		RestoreSessionToken(ctx context.Context, token string) error
+
 		GetSessionUUID() string
 
 		SetSessionEnableSystemSettings(context.Context, bool) error
@@ -234,6 +244,19 @@ type (
 		// SetTransactionTimeout sets the transaction timeout.
 		SetTransactionTimeout(transactionTimeout int64)
 
+		// SetScatterConcurrency sets the number of shards the scatter queries are sent to in parallel.
+		SetScatterConcurrency(scatterConcurrency int64)
+
+		// SetStickyShards sets whether the queries routed by an equality on a lookup vindex
+		// are pinned to the shards resolved for the first of them with the same value, and
+		// releases the pinned shards.
+		SetStickyShards(context.Context, bool) error
+		// PinnedShards returns the shards to which the queries routed by the pin key are pinned, if any.
+		PinnedShards(pin string) []string
+		// PinShards pins the queries routed by the pin key to the shards, if the session has
+		// sticky shards, or unpins them if there are no shards.
+		PinShards(pin string, shards []string)
+
 		// InTransaction returns true if the session has already opened transaction or
 		// will start a transaction on the query execution.
 		InTransaction() bool
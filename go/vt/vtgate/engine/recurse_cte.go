@@ -18,6 +18,7 @@ package engine
 
 import (
 	"context"
+	"sync"
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -33,8 +34,17 @@ type RecurseCTE struct {
 	Seed, Term Primitive
 
 	Vars map[string]int
+
+	// Distinct is set when the seed and the term are combined with UNION DISTINCT.
+	// It holds the columns of the CTE: the rows already produced are discarded,
+	// and are not used to start a new recursion.
+	Distinct []CheckCol
 }
 
+// maxRecursionDepth is the number of iterations after which a recursion is aborted,
+// like the default value of cte_max_recursion_depth in MySQL.
+const maxRecursionDepth = 1000
+
 var _ Primitive = (*RecurseCTE)(nil)
 
 func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
@@ -43,11 +53,21 @@ func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 		return nil, err
 	}
 
+	filter := r.newRowFilter(vcursor)
+	res.Rows, err = filter(res.Rows)
+	if err != nil {
+		return nil, err
+	}
+
 	// recurseRows contains the rows used in the next recursion
 	recurseRows := res.Rows
 	joinVars := make(map[string]*querypb.BindVariable)
-	loops := 0
+	depth := 0
 	for len(recurseRows) > 0 {
+		depth++
+		if depth > maxRecursionDepth { // TODO: This should be controlled with a system variable setting
+			return nil, vterrors.VT09030("")
+		}
 		// copy over the results from the previous recursion
 		theseRows := recurseRows
 		recurseRows = nil
@@ -63,12 +83,12 @@ func (r *RecurseCTE) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 			if err != nil {
 				return nil, err
 			}
-			recurseRows = append(recurseRows, rresult.Rows...)
-			res.Rows = append(res.Rows, rresult.Rows...)
-			loops++
-			if loops > 1000 { // TODO: This should be controlled with a system variable setting
-				return nil, vterrors.VT09030("")
+			rows, err := filter(rresult.Rows)
+			if err != nil {
+				return nil, err
 			}
+			recurseRows = append(recurseRows, rows...)
+			res.Rows = append(res.Rows, rows...)
 		}
 	}
 	return res, nil
@@ -82,19 +102,35 @@ func (r *RecurseCTE) TryStreamExecute(ctx context.Context, vcursor VCursor, bind
 		}
 		return callback(res)
 	}
+	filter := r.newRowFilter(vcursor)
 	return vcursor.StreamExecutePrimitive(ctx, r.Seed, bindVars, wantfields, func(result *sqltypes.Result) error {
-		err := callback(result)
+		result, err := filterResult(result, filter)
+		if err != nil {
+			return err
+		}
+		err = callback(result)
 		if err != nil {
 			return err
 		}
-		return r.recurse(ctx, vcursor, bindVars, result, callback)
+		return r.recurse(ctx, vcursor, bindVars, result, callback, filter, 1)
 	})
 }
 
-func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[string]*querypb.BindVariable, result *sqltypes.Result, callback func(*sqltypes.Result) error) error {
+func (r *RecurseCTE) recurse(
+	ctx context.Context,
+	vcursor VCursor,
+	bindvars map[string]*querypb.BindVariable,
+	result *sqltypes.Result,
+	callback func(*sqltypes.Result) error,
+	filter func([]sqltypes.Row) ([]sqltypes.Row, error),
+	depth int,
+) error {
 	if len(result.Rows) == 0 {
 		return nil
 	}
+	if depth > maxRecursionDepth {
+		return vterrors.VT09030("")
+	}
 	joinVars := make(map[string]*querypb.BindVariable)
 	for _, row := range result.Rows {
 		for k, col := range r.Vars {
@@ -102,11 +138,15 @@ func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[
 		}
 
 		err := vcursor.StreamExecutePrimitive(ctx, r.Term, combineVars(bindvars, joinVars), false, func(result *sqltypes.Result) error {
-			err := callback(result)
+			result, err := filterResult(result, filter)
+			if err != nil {
+				return err
+			}
+			err = callback(result)
 			if err != nil {
 				return err
 			}
-			return r.recurse(ctx, vcursor, bindvars, result, callback)
+			return r.recurse(ctx, vcursor, bindvars, result, callback, filter, depth+1)
 		})
 		if err != nil {
 			return err
@@ -115,6 +155,44 @@ func (r *RecurseCTE) recurse(ctx context.Context, vcursor VCursor, bindvars map[
 	return nil
 }
 
+// newRowFilter returns a function removing the rows that were already produced
+// when the CTE is distinct. Otherwise, the function returns the rows unchanged.
+func (r *RecurseCTE) newRowFilter(vcursor VCursor) func([]sqltypes.Row) ([]sqltypes.Row, error) {
+	if len(r.Distinct) == 0 {
+		return func(rows []sqltypes.Row) ([]sqltypes.Row, error) {
+			return rows, nil
+		}
+	}
+	var mu sync.Mutex
+	pt := newProbeTable(r.Distinct, vcursor.Environment().CollationEnv())
+	return func(rows []sqltypes.Row) ([]sqltypes.Row, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var newRows []sqltypes.Row
+		for _, row := range rows {
+			newRow, err := pt.exists(row)
+			if err != nil {
+				return nil, err
+			}
+			if newRow != nil {
+				newRows = append(newRows, newRow)
+			}
+		}
+		return newRows, nil
+	}
+}
+
+func filterResult(result *sqltypes.Result, filter func([]sqltypes.Row) ([]sqltypes.Row, error)) (*sqltypes.Result, error) {
+	rows, err := filter(result.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{
+		Fields: result.Fields,
+		Rows:   rows,
+	}, nil
+}
+
 func (r *RecurseCTE) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
 	return r.Seed.GetFields(ctx, vcursor, bindVars)
 }
@@ -131,6 +209,9 @@ func (r *RecurseCTE) description() PrimitiveDescription {
 	other := map[string]any{
 		"JoinVars": orderedStringIntMap(r.Vars),
 	}
+	if len(r.Distinct) > 0 {
+		other["Distinct"] = true
+	}
 
 	return PrimitiveDescription{
 		OperatorType: "RecurseCTE",
@@ -19,12 +19,15 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
 
 func TestRecurseDualQuery(t *testing.T) {
@@ -127,3 +130,94 @@ func TestRecurseDualQuery(t *testing.T) {
 	})
 	expectResult(t, r, wantRes)
 }
+
+func TestRecurseDistinct(t *testing.T) {
+	// The test is testing something like this, on a graph with a cycle:
+	// WITH RECURSIVE cte AS (SELECT 1 as id UNION SELECT e.dst FROM edges e JOIN cte ON e.src = cte.id) SELECT * FROM cte;
+	fields := sqltypes.MakeTestFields("id", "int64")
+	leftPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1"),
+		},
+	}
+	rightPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "2"),
+			sqltypes.MakeTestResult(fields, "1", "3"),
+			sqltypes.MakeTestResult(fields),
+		},
+	}
+	bv := map[string]*querypb.BindVariable{}
+
+	cte := &RecurseCTE{
+		Seed: leftPrim,
+		Term: rightPrim,
+		Vars: map[string]int{"cte_id": 0},
+		Distinct: []CheckCol{{
+			Col:          0,
+			Type:         evalengine.NewType(sqltypes.Int64, collations.CollationBinaryID),
+			CollationEnv: collations.MySQL8(),
+		}},
+	}
+
+	r, err := cte.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+
+	rightPrim.ExpectLog(t, []string{
+		fmt.Sprintf(`Execute cte_id: %v false`, sqltypes.Int64BindVariable(1)),
+		fmt.Sprintf(`Execute cte_id: %v false`, sqltypes.Int64BindVariable(2)),
+		fmt.Sprintf(`Execute cte_id: %v false`, sqltypes.Int64BindVariable(3)),
+	})
+	wantRes := sqltypes.MakeTestResult(fields, "1", "2", "3")
+	expectResult(t, r, wantRes)
+
+	// testing the streaming mode.
+
+	leftPrim.rewind()
+	rightPrim.rewind()
+
+	r, err = wrapStreamExecute(cte, &noopVCursor{}, bv, true)
+	require.NoError(t, err)
+
+	rightPrim.ExpectLog(t, []string{
+		fmt.Sprintf(`StreamExecute cte_id: %v false`, sqltypes.Int64BindVariable(1)),
+		fmt.Sprintf(`StreamExecute cte_id: %v false`, sqltypes.Int64BindVariable(2)),
+		fmt.Sprintf(`StreamExecute cte_id: %v false`, sqltypes.Int64BindVariable(3)),
+	})
+	expectResult(t, r, wantRes)
+}
+
+func TestRecurseMaxDepth(t *testing.T) {
+	// The recursion never ends, each iteration producing a single row:
+	// WITH RECURSIVE cte AS (SELECT 1 as n UNION ALL SELECT n + 1 FROM cte) SELECT * FROM cte;
+	fields := sqltypes.MakeTestFields("n", "int64")
+	leftPrim := &fakePrimitive{
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(fields, "1"),
+		},
+	}
+	rightPrim := &fakePrimitive{noLog: true}
+	for i := 2; i <= maxRecursionDepth+2; i++ {
+		rightPrim.results = append(rightPrim.results, sqltypes.MakeTestResult(fields, strconv.Itoa(i)))
+	}
+	bv := map[string]*querypb.BindVariable{}
+
+	cte := &RecurseCTE{
+		Seed: leftPrim,
+		Term: rightPrim,
+		Vars: map[string]int{"n": 0},
+	}
+
+	_, err := cte.TryExecute(context.Background(), &noopVCursor{}, bv, true)
+	require.ErrorContains(t, err, "Recursive query aborted after 1000 iterations")
+	require.Equal(t, maxRecursionDepth, rightPrim.curResult)
+
+	// testing the streaming mode.
+
+	leftPrim.rewind()
+	rightPrim.rewind()
+
+	_, err = wrapStreamExecute(cte, &noopVCursor{}, bv, true)
+	require.ErrorContains(t, err, "Recursive query aborted after 1000 iterations")
+	require.Equal(t, maxRecursionDepth, rightPrim.curResult)
+}
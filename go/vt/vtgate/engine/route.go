@@ -105,10 +105,11 @@ var partialSuccessScatterQueries = stats.NewCounter("PartialSuccessScatterQuerie
 
 // TryExecute performs a non-streaming exec.
 func (route *Route) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
-	rss, bvs, err := route.findRoute(ctx, vcursor, bindVars)
+	rss, bvs, err := route.findStickyRoute(ctx, vcursor, bindVars)
 	if err != nil {
 		return nil, err
 	}
+	rss = route.withPreferredCells(rss)
 
 	return route.executeShards(ctx, vcursor, bindVars, wantfields, rss, bvs)
 }
@@ -208,10 +209,11 @@ func (route *Route) TryStreamExecute(
 		ctx, cancel = context.WithTimeout(ctx, time.Duration(route.QueryTimeout)*time.Millisecond)
 		defer cancel()
 	}
-	rss, bvs, err := route.findRoute(ctx, vcursor, bindVars)
+	rss, bvs, err := route.findStickyRoute(ctx, vcursor, bindVars)
 	if err != nil {
 		return err
 	}
+	rss = route.withPreferredCells(rss)
 
 	return route.streamExecuteShards(ctx, vcursor, bindVars, wantfields, callback, rss, bvs)
 }
@@ -416,51 +418,28 @@ func (route *Route) description() PrimitiveDescription {
 	}
 }
 
-func (route *Route) executeAfterLookup(
+// resolveAfterLookup resolves the shards of the destinations which the ids were
+// looked up to.
+func (route *Route) resolveAfterLookup(
 	ctx context.Context,
 	vcursor VCursor,
 	bindVars map[string]*querypb.BindVariable,
-	wantfields bool,
 	ids []sqltypes.Value,
 	dest []key.ShardDestination,
-) (*sqltypes.Result, error) {
+) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
 	protoIds := make([]*querypb.Value, 0, len(ids))
 	for _, id := range ids {
 		protoIds = append(protoIds, sqltypes.ValueToProto(id))
 	}
 	rss, _, err := vcursor.ResolveDestinations(ctx, route.Keyspace.Name, protoIds, dest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	bvs := make([]map[string]*querypb.BindVariable, len(rss))
 	for i := range bvs {
 		bvs[i] = bindVars
 	}
-	return route.executeShards(ctx, vcursor, bindVars, wantfields, rss, bvs)
-}
-
-func (route *Route) streamExecuteAfterLookup(
-	ctx context.Context,
-	vcursor VCursor,
-	bindVars map[string]*querypb.BindVariable,
-	wantfields bool,
-	callback func(*sqltypes.Result) error,
-	ids []sqltypes.Value,
-	dest []key.ShardDestination,
-) error {
-	protoIds := make([]*querypb.Value, 0, len(ids))
-	for _, id := range ids {
-		protoIds = append(protoIds, sqltypes.ValueToProto(id))
-	}
-	rss, _, err := vcursor.ResolveDestinations(ctx, route.Keyspace.Name, protoIds, dest)
-	if err != nil {
-		return err
-	}
-	bvs := make([]map[string]*querypb.BindVariable, len(rss))
-	for i := range bvs {
-		bvs[i] = bindVars
-	}
-	return route.streamExecuteShards(ctx, vcursor, bindVars, wantfields, callback, rss, bvs)
+	return rss, bvs, nil
 }
 
 func execShard(
@@ -30,8 +30,10 @@ import (
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
@@ -417,6 +419,102 @@ func TestSelectEqual(t *testing.T) {
 	expectResult(t, result, defaultSelectResult)
 }
 
+func TestSelectEqualStickyShards(t *testing.T) {
+	vindex, _ := vindexes.CreateVindex("lookup", "lkp", map[string]string{
+		"table": "lkp",
+		"from":  "from",
+		"to":    "toc",
+	})
+	sel := NewRoute(
+		Equal,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex.(vindexes.SingleColumn)
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralInt(1),
+	}
+	vc := &loggingVCursor{
+		shards:       []string{"-20", "20-"},
+		stickyShards: true,
+		results: []*sqltypes.Result{
+			sqltypes.MakeTestResult(
+				sqltypes.MakeTestFields(
+					"fromc|toc",
+					"int64|varbinary",
+				),
+				"1|\x00",
+			),
+			defaultSelectResult,
+		},
+	}
+	result, err := sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		fmt.Sprintf(`Execute select from, toc from lkp where from in ::from from: %v false`, &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: sqltypes.Int64, Value: []byte("1")}}}),
+		fmt.Sprintf(`ResolveDestinations ks [%v] Destinations:DestinationKeyspaceIDs(00)`, sqltypes.Int64BindVariable(1)),
+		`PinShards ks.lkp.INT64(1) [-20]`,
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+	})
+	expectResult(t, result, defaultSelectResult)
+
+	// The next queries are sent to the pinned shard without a lookup.
+	vc.Rewind()
+	vc.results = []*sqltypes.Result{defaultSelectResult}
+	result, err = wrapStreamExecute(sel, vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`StreamExecuteMulti dummy_select ks.-20: {} `,
+	})
+	expectResult(t, result, defaultSelectResult)
+
+	// The queries with another value are not sent to the pinned shard.
+	vc.Rewind()
+	vc.results = []*sqltypes.Result{
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("fromc|toc", "int64|varbinary"), "2|\x30"),
+		defaultSelectResult,
+	}
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralInt(2),
+	}
+	_, err = sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		fmt.Sprintf(`Execute select from, toc from lkp where from in ::from from: %v false`, &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: sqltypes.Int64, Value: []byte("2")}}}),
+		fmt.Sprintf(`ResolveDestinations ks [%v] Destinations:DestinationKeyspaceIDs(30)`, sqltypes.Int64BindVariable(2)),
+		`PinShards ks.lkp.INT64(2) [20-]`,
+		`ExecuteMultiShard ks.20-: dummy_select {} false false`,
+	})
+
+	// The pinned shard is released once it is no longer serving, and the
+	// vindex is looked up again. The keyspace ids still resolve to the same
+	// shard names in the fake vcursor.
+	vc.Rewind()
+	vc.shards = []string{"-10", "10-20", "20-"}
+	vc.results = []*sqltypes.Result{
+		sqltypes.MakeTestResult(sqltypes.MakeTestFields("fromc|toc", "int64|varbinary"), "1|\x00"),
+		defaultSelectResult,
+	}
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralInt(1),
+	}
+	_, err = sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	vc.ExpectLog(t, []string{
+		`ResolveDestinations ks [] Destinations:DestinationAllShards()`,
+		`PinShards ks.lkp.INT64(1) []`,
+		fmt.Sprintf(`Execute select from, toc from lkp where from in ::from from: %v false`, &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: []*querypb.Value{{Type: sqltypes.Int64, Value: []byte("1")}}}),
+		fmt.Sprintf(`ResolveDestinations ks [%v] Destinations:DestinationKeyspaceIDs(00)`, sqltypes.Int64BindVariable(1)),
+		`PinShards ks.lkp.INT64(1) [-20]`,
+		`ExecuteMultiShard ks.-20: dummy_select {} false false`,
+	})
+}
+
 func TestSelectEqualNoRoute(t *testing.T) {
 	vindex, _ := vindexes.CreateVindex("lookup_unique", "", map[string]string{
 		"table": "lkp",
@@ -1562,6 +1660,51 @@ func TestSelectEqualUniqueMultiColumnVindex(t *testing.T) {
 	expectResult(t, result, defaultSelectResult)
 }
 
+func TestSelectEqualUniqueCellAffineVindex(t *testing.T) {
+	vindex, _ := vindexes.CreateVindex("region_cell", "", map[string]string{"region_bytes": "1", "region_cells": "1:cell1"})
+	sel := NewRoute(
+		EqualUnique,
+		&vindexes.Keyspace{
+			Name:    "ks",
+			Sharded: true,
+		},
+		"dummy_select",
+		"dummy_select_field",
+	)
+	sel.Vindex = vindex
+	sel.Values = []evalengine.Expr{
+		evalengine.NewLiteralInt(1),
+		evalengine.NewLiteralInt(2),
+	}
+
+	for _, tabletType := range []topodatapb.TabletType{topodatapb.TabletType_REPLICA, topodatapb.TabletType_PRIMARY} {
+		var cells []string
+		vc := &loggingVCursor{
+			shards:                   []string{"-01", "01-02", "02-"},
+			shardForKsid:             []string{"01-02"},
+			results:                  []*sqltypes.Result{defaultSelectResult},
+			resolvedTargetTabletType: tabletType,
+			onExecuteMultiShardFn: func(_ context.Context, _ Primitive, rss []*srvtopo.ResolvedShard, _ []*querypb.BoundQuery, _, _ bool) {
+				for _, rs := range rss {
+					cells = append(cells, rs.Target.Cell)
+				}
+			},
+		}
+		_, err := sel.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+		require.NoError(t, err)
+		vc.ExpectLog(t, []string{
+			`ResolveDestinationsMultiCol ks [[INT64(1) INT64(2)]] Destinations:DestinationKeyspaceID(0106e7ea22ce92708f)`,
+			`ExecuteMultiShard ks.01-02: dummy_select {} false false`,
+		})
+		// Only the reads from replicas prefer the cell of the region.
+		if tabletType == topodatapb.TabletType_PRIMARY {
+			assert.Equal(t, []string{""}, cells)
+		} else {
+			assert.Equal(t, []string{"cell1"}, cells)
+		}
+	}
+}
+
 func TestSelectEqualMultiColumnVindex(t *testing.T) {
 	vindex, _ := vindexes.CreateVindex("region_experimental", "", map[string]string{"region_bytes": "1"})
 	vc := &loggingVCursor{
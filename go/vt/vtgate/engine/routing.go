@@ -19,13 +19,16 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
@@ -183,6 +186,112 @@ func (rp *RoutingParameters) findRoute(ctx context.Context, vcursor VCursor, bin
 	}
 }
 
+// findStickyRoute finds the shards of the query like findRoute, except that the
+// queries routed by an equality on a lookup vindex are sent to the shards
+// pinned for the same value of the vindex by the sticky shards of the session,
+// if any, without looking up the vindex. Otherwise, the shards they are routed
+// to are pinned for the value.
+func (rp *RoutingParameters) findStickyRoute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	sticky := isStickyRoute(rp.Opcode) && rp.Vindex != nil && rp.Vindex.NeedsVCursor()
+	if !sticky {
+		return rp.findRoute(ctx, vcursor, bindVars)
+	}
+	env := evalengine.NewExpressionEnv(ctx, bindVars, vcursor)
+	value, err := env.Evaluate(rp.Values[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	pin := pinKey(rp.Keyspace.Name, rp.Vindex, value.Value(vcursor.ConnCollation()))
+	rss, bvs, err := pinnedRoute(ctx, vcursor, rp.Keyspace.Name, pin, bindVars)
+	if err != nil || rss != nil {
+		return rss, bvs, err
+	}
+	rss, bvs, err = rp.findRoute(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, nil, err
+	}
+	pinRoute(vcursor, pin, rss)
+	return rss, bvs, nil
+}
+
+// isStickyRoute returns true if the routes with the opcode can be pinned by
+// the sticky shards of the session.
+func isStickyRoute(code Opcode) bool {
+	return code == Equal || code == EqualUnique
+}
+
+// pinKey returns the key of the shards pinned for the queries routed by an
+// equality on the value of the vindex of the keyspace. The value keeps its
+// type, as the vindex may map values of different types differently.
+func pinKey(keyspace string, vindex fmt.Stringer, value sqltypes.Value) string {
+	return keyspace + "." + vindex.String() + "." + value.String()
+}
+
+// pinnedRoute returns the shards of the keyspace pinned for the key by the
+// sticky shards of the session, or nil if there are none. The shards are
+// unpinned if any of them is no longer serving, as the keyspace was resharded.
+func pinnedRoute(ctx context.Context, vcursor VCursor, keyspace, pin string, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	shards := vcursor.Session().PinnedShards(pin)
+	if len(shards) == 0 {
+		return nil, nil, nil
+	}
+	allShards, _, err := vcursor.ResolveDestinations(ctx, keyspace, nil, []key.ShardDestination{key.DestinationAllShards{}})
+	if err != nil {
+		return nil, nil, err
+	}
+	rss := make([]*srvtopo.ResolvedShard, 0, len(shards))
+	for _, rs := range allShards {
+		if slices.Contains(shards, rs.Target.Shard) {
+			rss = append(rss, rs)
+		}
+	}
+	if len(rss) != len(shards) {
+		vcursor.Session().PinShards(pin, nil)
+		return nil, nil, nil
+	}
+	multiBindVars := make([]map[string]*querypb.BindVariable, len(rss))
+	for i := range multiBindVars {
+		multiBindVars[i] = bindVars
+	}
+	return rss, multiBindVars, nil
+}
+
+// pinRoute pins the queries routed by the key to the shards of the route, if
+// the session has sticky shards.
+func pinRoute(vcursor VCursor, pin string, rss []*srvtopo.ResolvedShard) {
+	if len(rss) == 0 {
+		return
+	}
+	shards := make([]string, 0, len(rss))
+	for _, rs := range rss {
+		shards = append(shards, rs.Target.Shard)
+	}
+	vcursor.Session().PinShards(pin, shards)
+}
+
+// withPreferredCells sets the cell of the targets of the shards which are read
+// from replicas, if the vindex of the route places their rows in a cell. The
+// gateway then prefers the tablets in that cell.
+func (rp *RoutingParameters) withPreferredCells(rss []*srvtopo.ResolvedShard) []*srvtopo.ResolvedShard {
+	cellAffine, ok := rp.Vindex.(vindexes.CellAffine)
+	if !ok {
+		return rss
+	}
+	for i, rs := range rss {
+		if rs.Target.TabletType == topodatapb.TabletType_PRIMARY {
+			continue
+		}
+		keyRanges, err := key.ParseShardingSpec(rs.Target.Shard)
+		if err != nil || len(keyRanges) != 1 {
+			continue
+		}
+		if cell := cellAffine.PreferredCell(keyRanges[0]); cell != "" {
+			rss[i] = rs.WithCell(cell)
+		}
+	}
+	return rss
+}
+
 func (rp *RoutingParameters) systemQuery(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
 	destinations, err := rp.routeInfoSchemaQuery(ctx, vcursor, bindVars)
 	if err != nil {
@@ -559,8 +668,15 @@ func resolveShardsBetween(ctx context.Context, vcursor VCursor, vindex vindexes.
 		ids[i] = sqltypes.ValueToProto(vik)
 	}
 
-	// RangeMap using the Vindex
-	destinations, err := vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	// RangeMap using the Vindex, with the open ranges of the comparisons
+	// when the vindex supports them.
+	var destinations []key.ShardDestination
+	var err error
+	if rc, ok := vindex.(vindexes.RangeComparable); ok {
+		destinations, err = rc.OpenRangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	} else {
+		destinations, err = vindex.RangeMap(ctx, vcursor, vindexKeys[0], vindexKeys[1])
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -419,6 +419,10 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetClientFoundRows)
 	case sysvars.SkipQueryPlanCache.Name:
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetSkipQueryPlanCache)
+	case sysvars.ResultChecksum.Name:
+		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetResultChecksum)
+	case sysvars.StickyShards.Name:
+		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetStickyShards)
 	case sysvars.TxReadOnly.Name,
 		sysvars.TransactionReadOnly.Name:
 		// TODO (4127): This is a dangerous NOP.
@@ -468,6 +472,12 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid migration_context: %s", str)
 		}
 		vcursor.Session().SetMigrationContext(str)
+	case sysvars.SessionToken.Name:
+		str, err := svss.evalAsString(env, vcursor)
+		if err != nil {
+			return err
+		}
+		return vcursor.Session().RestoreSessionToken(ctx, str)
 	case sysvars.QueryTimeout.Name:
 		queryTimeout, err := svss.evalAsInt64(env, vcursor)
 		if err != nil {
@@ -480,6 +490,15 @@ func (svss *SysVarSetAware) Execute(ctx context.Context, vcursor VCursor, env *e
 			return err
 		}
 		vcursor.Session().SetTransactionTimeout(transactionTimeout)
+	case sysvars.ScatterConcurrency.Name:
+		scatterConcurrency, err := svss.evalAsInt64(env, vcursor)
+		if err != nil {
+			return err
+		}
+		if scatterConcurrency < 0 {
+			return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid scatter_concurrency: %d", scatterConcurrency)
+		}
+		vcursor.Session().SetScatterConcurrency(scatterConcurrency)
 	case sysvars.SessionEnableSystemSettings.Name:
 		err = svss.setBoolSysVar(ctx, env, vcursor.Session().SetSessionEnableSystemSettings)
 	case sysvars.Charset.Name, sysvars.Names.Name:
@@ -0,0 +1,382 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// spillBatchSize is the number of rows in each result sent from the rows
+// read back from the spill files.
+const spillBatchSize = 1000
+
+// spillFile is a temporary file holding rows which do not fit in memory.
+// The rows are written first, and then read back in the same order.
+type spillFile struct {
+	file *os.File
+	w    *bufio.Writer
+	r    *bufio.Reader
+	buf  []byte
+	rows int
+}
+
+func newSpillFile(dir string) (*spillFile, error) {
+	file, err := os.CreateTemp(dir, "vtgate-spill-")
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed to create a spill file")
+	}
+	return &spillFile{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+// write appends the row to the file. Each value is stored with its type and
+// length, as the fields are not always known.
+func (sf *spillFile) write(row sqltypes.Row) error {
+	sf.buf = binary.AppendUvarint(sf.buf[:0], uint64(len(row)))
+	for _, val := range row {
+		sf.buf = binary.AppendUvarint(sf.buf, uint64(val.Type()))
+		sf.buf = binary.AppendUvarint(sf.buf, uint64(len(val.Raw())))
+		sf.buf = append(sf.buf, val.Raw()...)
+	}
+	sf.rows++
+	_, err := sf.w.Write(sf.buf)
+	return err
+}
+
+// rewind flushes the rows written to the file, and starts reading them back.
+func (sf *spillFile) rewind() error {
+	if err := sf.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := sf.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	sf.r = bufio.NewReader(sf.file)
+	return nil
+}
+
+// next returns the next row of the file, or nil when all the rows were read.
+func (sf *spillFile) next() (sqltypes.Row, error) {
+	if sf.rows == 0 {
+		return nil, nil
+	}
+	sf.rows--
+
+	n, err := binary.ReadUvarint(sf.r)
+	if err != nil {
+		return nil, err
+	}
+	row := make(sqltypes.Row, n)
+	for i := range row {
+		typ, err := binary.ReadUvarint(sf.r)
+		if err != nil {
+			return nil, err
+		}
+		length, err := binary.ReadUvarint(sf.r)
+		if err != nil {
+			return nil, err
+		}
+		var raw []byte
+		if length > 0 {
+			raw = make([]byte, length)
+			if _, err := io.ReadFull(sf.r, raw); err != nil {
+				return nil, err
+			}
+		}
+		row[i] = sqltypes.MakeTrusted(querypb.Type(typ), raw)
+	}
+	return row, nil
+}
+
+// close closes and removes the file.
+func (sf *spillFile) close() {
+	_ = sf.file.Close()
+	_ = os.Remove(sf.file.Name())
+}
+
+// sortSpillMaxRuns is the number of sorted runs a sort keeps at most, as
+// each of them is an open file. Once it has that many, they are merged into
+// a single larger run.
+const sortSpillMaxRuns = 64
+
+// sortSpill holds the sorted runs of rows that a memory sort spilled to
+// disk, once it had more rows than it can hold in memory.
+type sortSpill struct {
+	dir     string
+	compare evalengine.Comparison
+	// limit is the number of rows the sort returns, and thus the number of
+	// rows of a merged run which are needed.
+	limit int
+	runs  []*spillFile
+}
+
+// spill writes the sorted rows to a new run.
+func (ss *sortSpill) spill(rows []sqltypes.Row) error {
+	run, err := newSpillFile(ss.dir)
+	if err != nil {
+		return err
+	}
+	ss.runs = append(ss.runs, run)
+	for _, row := range rows {
+		if err := run.write(row); err != nil {
+			return err
+		}
+	}
+	if len(ss.runs) < sortSpillMaxRuns {
+		return nil
+	}
+	return ss.compact()
+}
+
+// compact merges all the runs into a single one, and removes them.
+func (ss *sortSpill) compact() error {
+	run, err := newSpillFile(ss.dir)
+	if err != nil {
+		return err
+	}
+	runs := ss.runs
+	ss.runs = []*spillFile{run}
+	defer func() {
+		for _, r := range runs {
+			r.close()
+		}
+	}()
+	return mergeSortedRuns(ss.compare, runs, nil, ss.limit, run.write)
+}
+
+// merge merges the spilled runs with the sorted rows that are still in
+// memory, and sends the first limit rows to the callback.
+func (ss *sortSpill) merge(rows []sqltypes.Row, callback func(*sqltypes.Result) error) error {
+	batch := make([]sqltypes.Row, 0, spillBatchSize)
+	err := mergeSortedRuns(ss.compare, ss.runs, rows, ss.limit, func(row sqltypes.Row) error {
+		batch = append(batch, row)
+		if len(batch) < spillBatchSize {
+			return nil
+		}
+		if err := callback(&sqltypes.Result{Rows: batch}); err != nil {
+			return err
+		}
+		batch = make([]sqltypes.Row, 0, spillBatchSize)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return callback(&sqltypes.Result{Rows: batch})
+	}
+	return nil
+}
+
+// mergeSortedRuns merges the runs with the sorted rows in memory, and sends
+// the first limit rows to emit.
+func mergeSortedRuns(compare evalengine.Comparison, runs []*spillFile, rows []sqltypes.Row, limit int, emit func(sqltypes.Row) error) error {
+	merger := &evalengine.Merger{Compare: compare}
+	for i, run := range runs {
+		if err := run.rewind(); err != nil {
+			return err
+		}
+		row, err := run.next()
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			merger.Push(row, i)
+		}
+	}
+	// The rows in memory are the last source.
+	memory := len(runs)
+	if len(rows) > 0 {
+		merger.Push(rows[0], memory)
+		rows = rows[1:]
+	}
+	merger.Init()
+
+	for merger.Len() > 0 && limit > 0 {
+		row, source := merger.Pop()
+		if err := emit(row); err != nil {
+			return err
+		}
+		limit--
+
+		if source == memory {
+			if len(rows) > 0 {
+				merger.Push(rows[0], memory)
+				rows = rows[1:]
+			}
+			continue
+		}
+		next, err := runs[source].next()
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			merger.Push(next, source)
+		}
+	}
+	return nil
+}
+
+// close removes the spilled runs.
+func (ss *sortSpill) close() {
+	for _, run := range ss.runs {
+		run.close()
+	}
+}
+
+// hashJoinSpillPartitions is the number of partitions in which a hash join
+// spills the rows of its inputs, so that the probe table of each partition
+// fits in memory.
+const hashJoinSpillPartitions = 16
+
+// hashJoinSpill holds the rows of both inputs of a hash join, partitioned to
+// disk by the hash of their join key, once the probe table had more rows
+// than it can hold in memory. The rows which match are in the same partition,
+// so the partitions are joined one at a time.
+type hashJoinSpill struct {
+	left, right []*spillFile
+}
+
+func newHashJoinSpill(dir string) (*hashJoinSpill, error) {
+	hs := &hashJoinSpill{}
+	for range hashJoinSpillPartitions {
+		left, err := newSpillFile(dir)
+		if err != nil {
+			hs.close()
+			return nil, err
+		}
+		hs.left = append(hs.left, left)
+		right, err := newSpillFile(dir)
+		if err != nil {
+			hs.close()
+			return nil, err
+		}
+		hs.right = append(hs.right, right)
+	}
+	return hs, nil
+}
+
+func (hs *hashJoinSpill) partition(pt *hashJoinProbeTable, val sqltypes.Value) (int, error) {
+	hash, err := pt.hash(val)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.LittleEndian.Uint64(hash[:8]) % hashJoinSpillPartitions), nil
+}
+
+func (hs *hashJoinSpill) addLeftRow(pt *hashJoinProbeTable, row sqltypes.Row) error {
+	p, err := hs.partition(pt, row[pt.lhsKey])
+	if err != nil {
+		return err
+	}
+	return hs.left[p].write(row)
+}
+
+func (hs *hashJoinSpill) addRightRow(pt *hashJoinProbeTable, row sqltypes.Row) error {
+	val := row[pt.rhsKey]
+	if val.IsNull() {
+		// A NULL key never matches.
+		return nil
+	}
+	p, err := hs.partition(pt, val)
+	if err != nil {
+		return err
+	}
+	return hs.right[p].write(row)
+}
+
+// join joins the rows of each partition, and sends the result to the
+// callback, starting with the fields, if any.
+func (hs *hashJoinSpill) join(hj *HashJoin, fields []*querypb.Field, callback func(*sqltypes.Result) error) error {
+	res := &sqltypes.Result{Fields: fields}
+	send := func() error {
+		if err := callback(res); err != nil {
+			return err
+		}
+		res = &sqltypes.Result{}
+		return nil
+	}
+
+	for p := range hashJoinSpillPartitions {
+		pt := newHashJoinProbeTable(hj.Collation, hj.ComparisonType, hj.LHSKey, hj.RHSKey, hj.Cols, hj.Values)
+		left, right := hs.left[p], hs.right[p]
+		if err := left.rewind(); err != nil {
+			return err
+		}
+		for {
+			row, err := left.next()
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				break
+			}
+			if err := pt.addLeftRow(row); err != nil {
+				return err
+			}
+		}
+
+		if err := right.rewind(); err != nil {
+			return err
+		}
+		for {
+			row, err := right.next()
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				break
+			}
+			matches, err := pt.get(row)
+			if err != nil {
+				return err
+			}
+			res.Rows = append(res.Rows, matches...)
+			if len(res.Rows) >= spillBatchSize {
+				if err := send(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if hj.Opcode == LeftJoin {
+			res.Rows = append(res.Rows, pt.notFetched()...)
+		}
+	}
+	if len(res.Rows) > 0 || len(res.Fields) > 0 {
+		return send()
+	}
+	return nil
+}
+
+// close removes the partitions.
+func (hs *hashJoinSpill) close() {
+	for _, sf := range hs.left {
+		sf.close()
+	}
+	for _, sf := range hs.right {
+		sf.close()
+	}
+}
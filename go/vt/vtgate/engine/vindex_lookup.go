@@ -18,9 +18,11 @@ package engine
 
 import (
 	"context"
+	"time"
 
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/srvtopo"
 
 	"vitess.io/vitess/go/vt/key"
 
@@ -67,21 +69,49 @@ func (vr *VindexLookup) NeedsTransaction() bool {
 
 // TryExecute implements the Primitive interface
 func (vr *VindexLookup) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
-	ids, err := vr.generateIds(ctx, vcursor, bindVars)
+	rss, bvs, err := vr.findRoute(ctx, vcursor, bindVars)
 	if err != nil {
 		return nil, err
 	}
+	return vr.SendTo.executeShards(ctx, vcursor, bindVars, wantfields, rss, bvs)
+}
+
+// findRoute looks up the ids and resolves the shards they map to. The queries
+// routed by an equality are sent to the shards pinned for the same id by the
+// sticky shards of the session instead, if any, and otherwise pin the shards
+// they are routed to for the id.
+func (vr *VindexLookup) findRoute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) ([]*srvtopo.ResolvedShard, []map[string]*querypb.BindVariable, error) {
+	ids, err := vr.generateIds(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pin string
+	if isStickyRoute(vr.Opcode) {
+		pin = pinKey(vr.Keyspace.Name, vr.Vindex, ids[0])
+		rss, bvs, err := pinnedRoute(ctx, vcursor, vr.Keyspace.Name, pin, bindVars)
+		if err != nil || rss != nil {
+			return rss, bvs, err
+		}
+	}
+
 	results, err := vr.lookup(ctx, vcursor, ids)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	dest, err := vr.mapVindexToDestination(ids, results, bindVars)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return vr.SendTo.executeAfterLookup(ctx, vcursor, bindVars, wantfields, ids, dest)
+	rss, bvs, err := vr.SendTo.resolveAfterLookup(ctx, vcursor, bindVars, ids, dest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pin != "" {
+		pinRoute(vcursor, pin, rss)
+	}
+	return rss, bvs, nil
 }
 
 func (vr *VindexLookup) mapVindexToDestination(ids []sqltypes.Value, results []*sqltypes.Result, bindVars map[string]*querypb.BindVariable) ([]key.ShardDestination, error) {
@@ -103,22 +133,11 @@ func (vr *VindexLookup) mapVindexToDestination(ids []sqltypes.Value, results []*
 
 // TryStreamExecute implements the Primitive interface
 func (vr *VindexLookup) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
-	ids, err := vr.generateIds(ctx, vcursor, bindVars)
+	rss, bvs, err := vr.findRoute(ctx, vcursor, bindVars)
 	if err != nil {
 		return err
 	}
-
-	results, err := vr.lookup(ctx, vcursor, ids)
-	if err != nil {
-		return err
-	}
-
-	dest, err := vr.mapVindexToDestination(ids, results, bindVars)
-	if err != nil {
-		return err
-	}
-
-	return vr.SendTo.streamExecuteAfterLookup(ctx, vcursor, bindVars, wantfields, callback, ids, dest)
+	return vr.SendTo.streamExecuteShards(ctx, vcursor, bindVars, wantfields, callback, rss, bvs)
 }
 
 // Inputs implements the Primitive interface
@@ -156,10 +175,18 @@ func (vr *VindexLookup) lookup(ctx context.Context, vcursor VCursor, ids []sqlty
 		vcursor.Session().SetCommitOrder(co)
 		defer vcursor.Session().SetCommitOrder(vtgatepb.CommitOrder_NORMAL)
 	}
-	if ids[0].IsIntegral() || vr.Vindex.AllowBatch() {
-		return vr.executeBatch(ctx, vcursor, ids)
+	// The lookup cache is not used in transactions, which can have changed
+	// the mappings of the ids without committing them.
+	var lookupCache *vindexes.LookupCache
+	if lc, ok := vr.Vindex.(vindexes.LookupCacheable); ok && !vcursor.Session().InTransaction() {
+		lookupCache = lc.LookupCache()
 	}
-	return vr.executeNonBatch(ctx, vcursor, ids)
+	return lookupCache.Lookup(ids, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		if ids[0].IsIntegral() || vr.Vindex.AllowBatch() {
+			return vr.executeBatch(ctx, vcursor, ids)
+		}
+		return vr.executeNonBatch(ctx, vcursor, ids)
+	})
 }
 
 func (vr *VindexLookup) executeNonBatch(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]*sqltypes.Result, error) {
@@ -175,11 +202,13 @@ func (vr *VindexLookup) executeNonBatch(ctx context.Context, vcursor VCursor, id
 		}
 
 		var result *sqltypes.Result
+		startTime := time.Now()
 		if vr.Vindex.AutoCommitEnabled() {
 			result, err = vcursor.ExecutePrimitiveStandalone(ctx, vr.Lookup, bindVars, false)
 		} else {
 			result, err = vcursor.ExecutePrimitive(ctx, vr.Lookup, bindVars, false)
 		}
+		vindexes.RecordLookupQuery(vr.Vindex.String(), vindexes.LookupOperationMap, startTime, 1, result, err)
 		if err != nil {
 			return nil, err
 		}
@@ -207,11 +236,13 @@ func (vr *VindexLookup) executeBatch(ctx context.Context, vcursor VCursor, ids [
 	}
 
 	var result *sqltypes.Result
+	startTime := time.Now()
 	if vr.Vindex.AutoCommitEnabled() {
 		result, err = vcursor.ExecutePrimitiveStandalone(ctx, vr.Lookup, bindVars, false)
 	} else {
 		result, err = vcursor.ExecutePrimitive(ctx, vr.Lookup, bindVars, false)
 	}
+	vindexes.RecordLookupQuery(vr.Vindex.String(), vindexes.LookupOperationMap, startTime, len(ids), result, err)
 	if err != nil {
 		return nil, vterrors.Wrapf(err, "failed while running the lookup query")
 	}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+var _ Primitive = (*Window)(nil)
+
+// WindowFunc is a window function evaluated by a Window.
+type WindowFunc struct {
+	Opcode opcode.WindowOpcode
+
+	// Col is the column of the input in which the value of the function is set.
+	Col int
+
+	// PartitionBy and OrderBy are the partition and ordering of the window.
+	PartitionBy evalengine.Comparison
+	OrderBy     evalengine.Comparison
+}
+
+func (wf *WindowFunc) String() string {
+	var over []string
+	if len(wf.PartitionBy) > 0 {
+		over = append(over, "partition by "+GenericJoin(wf.PartitionBy, orderByParamsToString))
+	}
+	if len(wf.OrderBy) > 0 {
+		over = append(over, "order by "+GenericJoin(wf.OrderBy, orderByParamsToString))
+	}
+	return fmt.Sprintf("%s() over (%s) AS %d", wf.Opcode, strings.Join(over, " "), wf.Col)
+}
+
+// Window is a primitive that evaluates window functions in memory, when the
+// rows of their partitions come from several shards.
+type Window struct {
+	Funcs []*WindowFunc
+	Input Primitive
+
+	// TruncateColumnCount specifies the number of columns to return
+	// in the final result. Rest of the columns are truncated
+	// from the result received. If 0, no truncation happens.
+	TruncateColumnCount int
+}
+
+// TryExecute satisfies the Primitive interface.
+func (w *Window) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	result, err := vcursor.ExecutePrimitive(ctx, w.Input, bindVars, wantfields)
+	if err != nil {
+		return nil, err
+	}
+	result = result.ShallowCopy()
+	if result.Rows, err = w.evaluate(result.Fields, result.Rows); err != nil {
+		return nil, err
+	}
+	return result.Truncate(w.TruncateColumnCount), nil
+}
+
+// TryStreamExecute satisfies the Primitive interface.
+func (w *Window) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	cb := func(qr *sqltypes.Result) error {
+		return callback(qr.Truncate(w.TruncateColumnCount))
+	}
+
+	var (
+		mu     sync.Mutex
+		fields []*querypb.Field
+		rows   []sqltypes.Row
+	)
+	err := vcursor.StreamExecutePrimitive(ctx, w.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(qr.Fields) != 0 {
+			fields = qr.Fields
+			if err := cb(&sqltypes.Result{Fields: qr.Fields}); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, qr.Rows...)
+		if vcursor.ExceedsMaxMemoryRows(len(rows)) {
+			return fmt.Errorf("in-memory row count exceeded allowed limit of %d", vcursor.MaxMemoryRows())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if rows, err = w.evaluate(fields, rows); err != nil {
+		return err
+	}
+	return cb(&sqltypes.Result{Rows: rows})
+}
+
+// evaluate sets the values of the window functions in the rows, and returns
+// them sorted by the window of the first function.
+func (w *Window) evaluate(fields []*querypb.Field, rows []sqltypes.Row) (sorted []sqltypes.Row, err error) {
+	defer evalengine.PanicHandler(&err)
+
+	// The rows are copied, as their values are replaced.
+	rows = slices.Clone(rows)
+	for i, row := range rows {
+		rows[i] = slices.Clone(row)
+	}
+	for i, wf := range w.Funcs {
+		order := make([]sqltypes.Row, len(rows))
+		copy(order, rows)
+		slices.SortStableFunc(order, func(a, b sqltypes.Row) int {
+			if cmp := wf.PartitionBy.Compare(a, b); cmp != 0 {
+				return cmp
+			}
+			return wf.OrderBy.Compare(a, b)
+		})
+		wf.evaluate(order, windowValue(fields, wf.Col))
+		if i == 0 {
+			sorted = order
+		}
+	}
+	if sorted == nil {
+		sorted = rows
+	}
+	return sorted, nil
+}
+
+// evaluate sets the values of the window function in the rows sorted by its
+// window.
+func (wf *WindowFunc) evaluate(rows []sqltypes.Row, value func(int64) sqltypes.Value) {
+	var number, rank, denseRank int64
+	for i, row := range rows {
+		switch {
+		case i == 0 || wf.PartitionBy.Compare(rows[i-1], row) != 0:
+			number, rank, denseRank = 1, 1, 1
+		case wf.OrderBy.Compare(rows[i-1], row) != 0:
+			number++
+			rank = number
+			denseRank++
+		default:
+			number++
+		}
+		switch wf.Opcode {
+		case opcode.WindowRowNumber:
+			row[wf.Col] = value(number)
+		case opcode.WindowRank:
+			row[wf.Col] = value(rank)
+		case opcode.WindowDenseRank:
+			row[wf.Col] = value(denseRank)
+		}
+	}
+}
+
+// windowValue returns a function creating the values of a window function
+// with the type of its column.
+func windowValue(fields []*querypb.Field, col int) func(int64) sqltypes.Value {
+	if col < len(fields) && fields[col].Type == sqltypes.Uint64 {
+		return func(v int64) sqltypes.Value { return sqltypes.NewUint64(uint64(v)) }
+	}
+	return sqltypes.NewInt64
+}
+
+// GetFields satisfies the Primitive interface.
+func (w *Window) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	qr, err := w.Input.GetFields(ctx, vcursor, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Truncate(w.TruncateColumnCount), nil
+}
+
+// Inputs returns the input to the window functions.
+func (w *Window) Inputs() ([]Primitive, []map[string]any) {
+	return []Primitive{w.Input}, nil
+}
+
+// NeedsTransaction implements the Primitive interface
+func (w *Window) NeedsTransaction() bool {
+	return w.Input.NeedsTransaction()
+}
+
+func (w *Window) description() PrimitiveDescription {
+	other := map[string]any{
+		"Functions": GenericJoin(w.Funcs, func(i any) string { return i.(*WindowFunc).String() }),
+	}
+	if w.TruncateColumnCount > 0 {
+		other["ResultColumns"] = w.TruncateColumnCount
+	}
+	return PrimitiveDescription{
+		OperatorType: "Window",
+		Other:        other,
+	}
+}
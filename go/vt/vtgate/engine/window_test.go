@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	. "vitess.io/vitess/go/vt/vtgate/engine/opcode"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+)
+
+func TestWindowExecute(t *testing.T) {
+	fields := sqltypes.MakeTestFields(
+		"c1|c2|rn|rnk|drnk",
+		"varbinary|int64|int64|int64|int64",
+	)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			fields,
+			"b|2|1|1|1",
+			"a|3|1|1|1",
+			"a|1|1|1|1",
+			"b|2|1|1|1",
+			"a|1|1|1|1",
+			"b|5|1|1|1",
+		)},
+	}
+	window := func(code WindowOpcode, col int) *WindowFunc {
+		return &WindowFunc{
+			Opcode:      code,
+			Col:         col,
+			PartitionBy: evalengine.Comparison{{Col: 0, WeightStringCol: -1}},
+			OrderBy:     evalengine.Comparison{{Col: 1, WeightStringCol: -1}},
+		}
+	}
+
+	w := &Window{
+		Funcs: []*WindowFunc{
+			window(WindowRowNumber, 2),
+			window(WindowRank, 3),
+			window(WindowDenseRank, 4),
+		},
+		Input: fp,
+	}
+
+	wantResult := sqltypes.MakeTestResult(
+		fields,
+		"a|1|1|1|1",
+		"a|1|2|1|1",
+		"a|3|3|3|2",
+		"b|2|1|1|1",
+		"b|2|2|1|1",
+		"b|5|3|3|2",
+	)
+	result, err := w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	utils.MustMatch(t, wantResult, result)
+
+	fp.rewind()
+	result, err = wrapStreamExecute(w, &noopVCursor{}, nil, true)
+	require.NoError(t, err)
+	utils.MustMatch(t, wantResult, result)
+
+	// The column values of the input are not modified.
+	fp.rewind()
+	w.Funcs = []*WindowFunc{{Opcode: WindowRowNumber, Col: 2, OrderBy: evalengine.Comparison{{Col: 1, Desc: true, WeightStringCol: -1}}}}
+	w.TruncateColumnCount = 3
+	result, err = w.TryExecute(context.Background(), &noopVCursor{}, nil, false)
+	require.NoError(t, err)
+	utils.MustMatch(t, sqltypes.MakeTestResult(
+		fields[:3],
+		"b|5|1",
+		"a|3|2",
+		"b|2|3",
+		"b|2|4",
+		"a|1|5",
+		"a|1|6",
+	), result)
+	utils.MustMatch(t, "1", fp.results[0].Rows[0][2].ToString())
+}
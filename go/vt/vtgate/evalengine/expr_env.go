@@ -156,7 +156,7 @@ func EmptyExpressionEnv(env *vtenv.Environment) *ExpressionEnv {
 func NewExpressionEnv(ctx context.Context, bindVars map[string]*querypb.BindVariable, vc VCursor) *ExpressionEnv {
 	env := &ExpressionEnv{BindVars: bindVars, vc: vc}
 	env.user = callerid.ImmediateCallerIDFromContext(ctx)
-	env.SetTime(time.Now())
+	env.SetTime(vc.Environment().Now())
 	env.sqlmode = ParseSQLMode(vc.SQLMode())
 	env.collationEnv = vc.Environment().CollationEnv()
 	return env
@@ -165,6 +165,7 @@ func NewExpressionEnv(ctx context.Context, bindVars map[string]*querypb.BindVari
 const (
 	sqlModeParsed = 1 << iota
 	sqlModeNoZeroDate
+	sqlModeStrict
 )
 
 type SQLMode uint32
@@ -177,11 +178,24 @@ func (mode SQLMode) AllowZeroDate() bool {
 	return (mode & sqlModeNoZeroDate) == 0
 }
 
+// Strict returns whether invalid or out of range values are rejected instead
+// of being adjusted to the type of the column they are stored in.
+func (mode SQLMode) Strict() bool {
+	if mode == 0 {
+		// default: the default sqlmode is strict
+		return true
+	}
+	return (mode & sqlModeStrict) != 0
+}
+
 func ParseSQLMode(sqlmode string) SQLMode {
 	var mode SQLMode
 	if strings.Contains(sqlmode, "NO_ZERO_DATE") {
 		mode |= sqlModeNoZeroDate
 	}
+	if strings.Contains(sqlmode, "STRICT_TRANS_TABLES") || strings.Contains(sqlmode, "STRICT_ALL_TABLES") || strings.Contains(sqlmode, "TRADITIONAL") {
+		mode |= sqlModeStrict
+	}
 	mode |= sqlModeParsed
 	return mode
 }
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -135,7 +136,23 @@ type (
 		vschemaStats *VSchemaStats
 
 		plans *PlanCache
-		epoch atomic.Uint32
+		// preparedPlans caches the plans of prepared statements, so that they
+		// are not evicted by the plans of ad-hoc queries. It is nil when the
+		// prepared plan cache is disabled, and plans are cached in plans.
+		preparedPlans *PlanCache
+		epoch         atomic.Uint32
+
+		// resultCache caches the results of the queries on reference tables.
+		// It is nil when the result cache is disabled.
+		resultCache *resultCache
+
+		// lookupCacheInvalidator keeps the caches of the consistent lookup
+		// vindexes up to date with the changes of their lookup tables.
+		lookupCacheInvalidator *lookupCacheInvalidator
+
+		// rateLimiter limits the rate of the queries with the rate limit
+		// rules of the vschema.
+		rateLimiter *rateLimiter
 
 		vm            *VSchemaManager
 		schemaTracker SchemaInfo
@@ -173,6 +190,16 @@ func DefaultPlanCache() *PlanCache {
 	return theine.NewStore[PlanCacheKey, *engine.Plan](queryPlanCacheMemory, doorkeeper)
 }
 
+// DefaultPreparedPlanCache returns the cache for the plans of prepared
+// statements, or nil if it is disabled. A statement being prepared is
+// expected to be executed many times, so its plan is admitted on first use.
+func DefaultPreparedPlanCache() *PlanCache {
+	if preparedPlanCacheMemory <= 0 {
+		return nil
+	}
+	return theine.NewStore[PlanCacheKey, *engine.Plan](preparedPlanCacheMemory, false)
+}
+
 // NewExecutor creates a new Executor.
 func NewExecutor(
 	ctx context.Context,
@@ -199,8 +226,15 @@ func NewExecutor(
 
 		schemaTracker:       schemaTracker,
 		plans:               plans,
+		preparedPlans:       DefaultPreparedPlanCache(),
+		rateLimiter:         newRateLimiter(),
 		warmingReadsChannel: make(chan bool, warmingReadsConcurrency),
 		ddlConfig:           ddlConfig,
+
+		lookupCacheInvalidator: newLookupCacheInvalidator(),
+	}
+	if referenceResultCacheTTL > 0 {
+		e.resultCache = newResultCache(referenceResultCacheTTL, referenceResultCacheMemory)
 	}
 	// setting the vcursor config.
 	e.initVConfig(warnOnShardedOnly, pv)
@@ -237,6 +271,42 @@ func NewExecutor(
 		stats.NewCounterFunc("QueryPlanCacheMisses", "Query plan cache misses", func() int64 {
 			return e.plans.Metrics.Misses()
 		})
+		stats.NewGaugeFunc("PreparedPlanCacheLength", "Prepared statement plan cache length", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return int64(e.preparedPlans.Len())
+		})
+		stats.NewGaugeFunc("PreparedPlanCacheSize", "Prepared statement plan cache size", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return int64(e.preparedPlans.UsedCapacity())
+		})
+		stats.NewGaugeFunc("PreparedPlanCacheCapacity", "Prepared statement plan cache capacity", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return int64(e.preparedPlans.MaxCapacity())
+		})
+		stats.NewCounterFunc("PreparedPlanCacheEvictions", "Prepared statement plan cache evictions", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return e.preparedPlans.Metrics.Evicted()
+		})
+		stats.NewCounterFunc("PreparedPlanCacheHits", "Prepared statement plan cache hits", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return e.preparedPlans.Metrics.Hits()
+		})
+		stats.NewCounterFunc("PreparedPlanCacheMisses", "Prepared statement plan cache misses", func() int64 {
+			if e.preparedPlans == nil {
+				return 0
+			}
+			return e.preparedPlans.Metrics.Misses()
+		})
 		servenv.HTTPHandle(pathQueryPlans, e)
 		servenv.HTTPHandle(pathScatterStats, e)
 		servenv.HTTPHandle(pathVSchema, e)
@@ -262,6 +332,9 @@ func (e *Executor) Execute(
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
 	stmtType, result, err := e.execute(ctx, mysqlCtx, safeSession, sql, bindVars, prepared, logStats)
 	logStats.Error = err
+	if err == nil && result != nil && safeSession.GetOptions().GetResultChecksum() {
+		safeSession.SetLastResultChecksum(sqltypes.RowsChecksum(0, result.Rows))
+	}
 	if result == nil {
 		saveSessionStats(safeSession, stmtType, 0, 0, err)
 	} else {
@@ -323,6 +396,17 @@ func (e *Executor) StreamExecute(
 	defer span.Finish()
 
 	logStats := logstats.NewLogStats(ctx, method, sql, safeSession.GetSessionUUID(), bindVars, streamlog.GetQueryLogConfig())
+	// The checksum of the result is computed over the rows of all the
+	// streamed results, before they are sent.
+	var checksum uint32
+	resultChecksum := safeSession.GetOptions().GetResultChecksum()
+	if resultChecksum {
+		send := callback
+		callback = func(qr *sqltypes.Result) error {
+			checksum = sqltypes.RowsChecksum(checksum, qr.Rows)
+			return send(qr)
+		}
+	}
 	srr := &streaminResultReceiver{callback: callback}
 	var err error
 
@@ -406,6 +490,9 @@ func (e *Executor) StreamExecute(
 	err = e.newExecute(ctx, mysqlCtx, safeSession, sql, bindVars, false, logStats, resultHandler, srr.storeResultStats)
 
 	logStats.Error = err
+	if err == nil && resultChecksum {
+		safeSession.SetLastResultChecksum(checksum)
+	}
 	saveSessionStats(safeSession, srr.stmtType, srr.rowsAffected, srr.rowsReturned, err)
 	if srr.rowsReturned > warnMemoryRows {
 		warnings.Add("ResultsExceeded", 1)
@@ -473,7 +560,7 @@ func (e *Executor) execute(ctx context.Context, mysqlCtx vtgateservice.MySQLConn
 }
 
 // addNeededBindVars adds bind vars that are needed by the plan
-func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds *sqlparser.BindVarNeeds, bindVars map[string]*querypb.BindVariable, session *econtext.SafeSession) error {
+func (e *Executor) addNeededBindVars(ctx context.Context, vcursor *econtext.VCursorImpl, bindVarNeeds *sqlparser.BindVarNeeds, bindVars map[string]*querypb.BindVariable, session *econtext.SafeSession) error {
 	for _, funcName := range bindVarNeeds.NeedFunctionResult {
 		switch funcName {
 		case sqlparser.DBVarName:
@@ -494,6 +581,8 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[key] = sqltypes.BoolBindVariable(session.Autocommit)
 		case sysvars.QueryTimeout.Name:
 			bindVars[key] = sqltypes.Int64BindVariable(session.GetQueryTimeout())
+		case sysvars.ScatterConcurrency.Name:
+			bindVars[key] = sqltypes.Int64BindVariable(session.GetScatterConcurrency())
 		case sysvars.TransactionTimeout.Name:
 			var v int64
 			ifOptionsExist(session, func(options *querypb.ExecuteOptions) {
@@ -506,6 +595,16 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 				v = options.ClientFoundRows
 			})
 			bindVars[key] = sqltypes.BoolBindVariable(v)
+		case sysvars.ResultChecksum.Name:
+			var v bool
+			ifOptionsExist(session, func(options *querypb.ExecuteOptions) {
+				v = options.ResultChecksum
+			})
+			bindVars[key] = sqltypes.BoolBindVariable(v)
+		case sysvars.LastResultChecksum.Name:
+			bindVars[key] = sqltypes.Uint64BindVariable(uint64(session.GetLastResultChecksum()))
+		case sysvars.StickyShards.Name:
+			bindVars[key] = sqltypes.BoolBindVariable(session.GetStickyShards())
 		case sysvars.SkipQueryPlanCache.Name:
 			var v bool
 			ifOptionsExist(session, func(options *querypb.ExecuteOptions) {
@@ -536,6 +635,12 @@ func (e *Executor) addNeededBindVars(vcursor *econtext.VCursorImpl, bindVarNeeds
 			bindVars[key] = sqltypes.StringBindVariable(session.MigrationContext)
 		case sysvars.SessionUUID.Name:
 			bindVars[key] = sqltypes.StringBindVariable(session.SessionUUID)
+		case sysvars.SessionToken.Name:
+			token, err := vcursor.SessionToken(ctx)
+			if err != nil {
+				return err
+			}
+			bindVars[key] = sqltypes.StringBindVariable(token)
 		case sysvars.SessionEnableSystemSettings.Name:
 			bindVars[key] = sqltypes.BoolBindVariable(session.EnableSystemSettings)
 		case sysvars.ReadAfterWriteGTID.Name:
@@ -970,6 +1075,38 @@ func (e *Executor) ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Result,
 	}, nil
 }
 
+// ShowVitessBuffers returns the buffering state of each shard. A LIKE filter
+// is matched against keyspace/shard.
+func (e *Executor) ShowVitessBuffers(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	var shardRegexp *regexp.Regexp
+	if filter != nil {
+		if filter.Like != "" {
+			shardRegexp = sqlparser.LikeToRegexp(filter.Like)
+		} else if filter.Filter != nil {
+			log.Infof("SHOW VITESS_BUFFERS where clause: %+v. Ignoring this (for now).", filter.Filter)
+		}
+	}
+
+	rows := [][]sqltypes.Value{}
+	for _, s := range e.scatterConn.GetBufferStatus() {
+		if shardRegexp != nil && !shardRegexp.MatchString(topoproto.KeyspaceShardString(s.Keyspace, s.Shard)) {
+			continue
+		}
+		rows = append(rows, buildVarCharRow(
+			s.Keyspace,
+			s.Shard,
+			s.State,
+			strconv.Itoa(s.BufferedRequests),
+			strconv.FormatInt(s.OldestRequestAge.Milliseconds(), 10),
+			strconv.FormatInt(s.BufferingDuration.Milliseconds(), 10),
+		))
+	}
+	return &sqltypes.Result{
+		Fields: buildVarCharFields("Keyspace", "Shard", "State", "BufferedRequests", "OldestRequestAgeMs", "BufferingDurationMs"),
+		Rows:   rows,
+	}, nil
+}
+
 func (e *Executor) ShowVitessReplicationStatus(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
 	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
 	defer cancel()
@@ -1101,6 +1238,13 @@ func (e *Executor) SaveVSchema(vschema *vindexes.VSchema, stats *VSchemaStats) {
 	}
 	e.vschemaStats = stats
 	e.ClearPlans()
+	if e.resultCache != nil && vschema != nil {
+		e.resultCache.vschemaUpdated(vschema)
+	}
+	if vschema != nil {
+		e.rateLimiter.setRules(vschema.RateLimitRules)
+		e.lookupCacheInvalidator.vschemaUpdated(vschema)
+	}
 
 	if vschemaCounters != nil {
 		vschemaCounters.Add("Reload", 1)
@@ -1147,7 +1291,7 @@ func (e *Executor) fetchOrCreatePlan(
 	var planKey engine.PlanKey
 	if preparedPlan {
 		planKey = buildPlanKey(ctx, vcursor, query, setVarComment)
-		plan, logStats.CachedPlan = e.plans.Get(planKey.Hash(), e.epoch.Load())
+		plan, logStats.CachedPlan = e.planCache(true).Get(planKey.Hash(), e.epoch.Load())
 	}
 
 	if plan == nil {
@@ -1168,7 +1312,7 @@ func (e *Executor) fetchOrCreatePlan(
 			if sp, ok := optimizedPlan.Instructions.(*engine.PlanSwitcher); ok {
 				sp.Baseline = plan.Instructions
 				optimizedPlan.Optimized.Store(true)
-				e.plans.Set(planKey.Hash(), optimizedPlan, 0, e.epoch.Load())
+				e.planCache(true).Set(planKey.Hash(), optimizedPlan, 0, e.epoch.Load())
 				plan = optimizedPlan
 			}
 		}
@@ -1184,7 +1328,8 @@ func (e *Executor) fetchOrCreatePlan(
 }
 
 func (e *Executor) newVCursor(safeSession *econtext.SafeSession, comments sqlparser.MarginComments, logStats *logstats.LogStats) (*econtext.VCursorImpl, error) {
-	return econtext.NewVCursorImpl(safeSession, comments, e, logStats, e.vm, e.VSchema(), e.resolver.resolver, e.serv, nullResultsObserver{}, e.vConfig, e.metrics)
+	vschema := e.VSchema().ForSession(safeSession.GetSessionUUID())
+	return econtext.NewVCursorImpl(safeSession, comments, e, logStats, e.vm, vschema, e.resolver.resolver, e.serv, nullResultsObserver{}, e.vConfig, e.metrics)
 }
 
 func (e *Executor) tryOptimizedPlan(
@@ -1204,7 +1349,7 @@ func (e *Executor) tryOptimizedPlan(
 		if sp, ok := sPlan.Instructions.(*engine.PlanSwitcher); ok {
 			sp.BaselineErr = prevErr
 			sPlan.Optimized.Store(true)
-			e.plans.Set(planKey.Hash(), sPlan, 0, e.epoch.Load())
+			e.planCache(true).Set(planKey.Hash(), sPlan, 0, e.epoch.Load())
 			return sPlan, nil
 		}
 	}
@@ -1232,6 +1377,7 @@ func (e *Executor) applyQueryHints(vcursor *econtext.VCursorImpl, plan *engine.P
 	vcursor.SetWorkloadName(qh.Workload)
 	vcursor.SetPriority(qh.Priority)
 	vcursor.SetExecQueryTimeout(qh.Timeout)
+	vcursor.SetExecScatterConcurrency(qh.ScatterConcurrency)
 }
 
 func (e *Executor) getCachedOrBuildPlan(
@@ -1303,7 +1449,7 @@ func (e *Executor) getCachedOrBuildPlan(
 			// build Plan key
 			planKey = buildPlanKey(ctx, vcursor, query, setVarComment)
 		}
-		plan, cached, err = e.plans.GetOrLoad(planKey.Hash(), e.epoch.Load(), func() (*engine.Plan, error) {
+		plan, cached, err = e.planCache(preparedPlan).GetOrLoad(planKey.Hash(), e.epoch.Load(), func() (*engine.Plan, error) {
 			return e.buildStatement(ctx, vcursor, query, stmt, reservedVars, bindVarNeeds, qh, paramsCount)
 		})
 		return plan, cached, stmt, err
@@ -1322,6 +1468,7 @@ func buildPlanKey(ctx context.Context, vcursor *econtext.VCursorImpl, query stri
 		Query:           query,
 		SetVarComment:   setVarComment,
 		Collation:       vcursor.ConnCollation(),
+		VSchemaCanary:   vcursor.VSchemaCanaryPercent(),
 	}
 }
 
@@ -1420,8 +1567,26 @@ func (e *Executor) Plans() *PlanCache {
 	return e.plans
 }
 
+// planCache returns the cache holding the plans of prepared statements if
+// prepared is true, and the cache holding the plans of ad-hoc queries otherwise.
+func (e *Executor) planCache(prepared bool) *PlanCache {
+	if prepared && e.preparedPlans != nil {
+		return e.preparedPlans
+	}
+	return e.plans
+}
+
 func (e *Executor) ForEachPlan(each func(plan *engine.Plan) bool) {
-	e.plans.Range(e.epoch.Load(), func(_ PlanCacheKey, value *engine.Plan) bool {
+	epoch := e.epoch.Load()
+	done := false
+	e.plans.Range(epoch, func(_ PlanCacheKey, value *engine.Plan) bool {
+		done = !each(value)
+		return !done
+	})
+	if done || e.preparedPlans == nil {
+		return
+	}
+	e.preparedPlans.Range(epoch, func(_ PlanCacheKey, value *engine.Plan) bool {
 		return each(value)
 	})
 }
@@ -1554,6 +1719,13 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 
 		QueryTimeout:  queryTimeout,
 		MaxMemoryRows: maxMemoryRows,
+		SpillDir:      spillDir,
+
+		DefaultScatterConcurrency: defaultScatterConcurrency,
+		MaxScatterConcurrency:     maxScatterConcurrency,
+
+		HashJoinRowsThreshold: hashJoinRowsThreshold,
+		JoinOrderByTableStats: joinOrderByTableStats,
 
 		SetVarEnabled:      sysVarSetEnabled,
 		EnableViews:        enableViews,
@@ -1566,6 +1738,9 @@ func (e *Executor) initVConfig(warnOnShardedOnly bool, pv plancontext.PlannerVer
 		WarmingReadsPercent: e.config.WarmingReadsPercent,
 		WarmingReadsTimeout: warmingReadsQueryTimeout,
 		WarmingReadsChannel: e.warmingReadsChannel,
+
+		SessionTokenSecret: []byte(sessionTokenSecret),
+		SessionTokenTTL:    sessionTokenTTL,
 	}
 }
 
@@ -1611,7 +1786,7 @@ func (e *Executor) handlePrepare(ctx context.Context, safeSession *econtext.Safe
 	}
 
 	bindVars := prepareBindVars(plan.ParamsCount)
-	err = e.addNeededBindVars(vcursor, plan.BindVarNeeds, bindVars, safeSession)
+	err = e.addNeededBindVars(ctx, vcursor, plan.BindVarNeeds, bindVars, safeSession)
 	if err != nil {
 		logStats.Error = err
 		return nil, 0, err
@@ -1805,6 +1980,13 @@ func (e *Executor) Close() {
 	}
 	topo.Close()
 	e.plans.Close()
+	if e.preparedPlans != nil {
+		e.preparedPlans.Close()
+	}
+	if e.resultCache != nil {
+		e.resultCache.Release()
+	}
+	e.lookupCacheInvalidator.Close()
 }
 
 func (e *Executor) Environment() *vtenv.Environment {
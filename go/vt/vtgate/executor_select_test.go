@@ -883,6 +883,37 @@ func TestSelectInitializedVitessAwareVariable(t *testing.T) {
 	utils.MustMatch(t, wantResult, result, "Mismatch")
 }
 
+func TestSelectLastResultChecksum(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+
+	_, err := executorExecSession(ctx, executor, session, "set @@result_checksum = 1", nil)
+	require.NoError(t, err)
+	require.True(t, session.Options.ResultChecksum)
+
+	result, err := executorExecSession(ctx, executor, session, "select id from user", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Rows)
+	want := sqltypes.RowsChecksum(0, result.Rows)
+	assert.Equal(t, want, session.GetLastResultChecksum())
+
+	result, err = executorExecSession(ctx, executor, session, "select @@last_result_checksum", nil)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(want), result.Rows[0][0].ToString())
+
+	// The checksum of streamed results covers the rows of all of them.
+	var checksum uint32
+	err = executor.StreamExecute(ctx, nil, "TestSelectLastResultChecksum", session, "select id from user", nil, func(qr *sqltypes.Result) error {
+		checksum = sqltypes.RowsChecksum(checksum, qr.Rows)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, checksum, session.GetLastResultChecksum())
+
+	_, err = executorExecSession(ctx, executor, session, "set @@last_result_checksum = 1", nil)
+	require.ErrorContains(t, err, "variable 'last_result_checksum' is a read only variable")
+}
+
 func TestSelectUserDefinedVariable(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 	logChan := executor.queryLogger.Subscribe("Test")
@@ -1275,6 +1306,84 @@ func TestSelectEqual(t *testing.T) {
 	utils.MustMatch(t, wantQueries, sbclookup.Queries)
 }
 
+func TestSelectStickyShards(t *testing.T) {
+	executor, sbc1, sbc2, sbclookup, ctx := createExecutorEnv(t)
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary", Autocommit: true})
+
+	lookupResult := sqltypes.MakeTestResult(sqltypes.MakeTestFields("b|a", "varbinary|varbinary"), "foo|1")
+	selectByName := func(name string) (lookups, queries int64) {
+		t.Helper()
+		sbclookup.SetResults([]*sqltypes.Result{lookupResult})
+		lookupCount, execCount := sbclookup.ExecCount.Load(), sbc1.ExecCount.Load()
+		_, err := executorExecSession(ctx, executor, session, "select id from user where name = '"+name+"'", nil)
+		require.NoError(t, err)
+		assert.Zero(t, sbc2.ExecCount.Load())
+		return sbclookup.ExecCount.Load() - lookupCount, sbc1.ExecCount.Load() - execCount
+	}
+
+	// Without sticky shards, every query looks up the vindex.
+	lookups, queries := selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+	assert.EqualValues(t, 1, queries)
+	assert.Empty(t, session.PinnedShards)
+
+	_, err := executorExecSession(ctx, executor, session, "set @@sticky_shards = 1", nil)
+	require.NoError(t, err)
+	require.True(t, session.StickyShards)
+	result, err := executorExecSession(ctx, executor, session, "select @@sticky_shards", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1", result.Rows[0][0].ToString())
+
+	// The first query pins the shard it is routed to for the value, and the
+	// next ones with the same value are sent to it without looking up the
+	// vindex.
+	fooPin := KsTestSharded + `.name_user_map.VARCHAR("foo")`
+	lookups, queries = selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+	assert.EqualValues(t, 1, queries)
+	assert.Equal(t, []string{"-20"}, session.GetPinnedShards(fooPin))
+	lookups, queries = selectByName("foo")
+	assert.Zero(t, lookups)
+	assert.EqualValues(t, 1, queries)
+
+	// The queries with another value look up the vindex.
+	lookups, queries = selectByName("bar")
+	assert.EqualValues(t, 1, lookups)
+	assert.EqualValues(t, 1, queries)
+	assert.Len(t, session.PinnedShards, 2)
+
+	// The pinned shards are released when a transaction ends.
+	_, err = executorExecSession(ctx, executor, session, "begin", nil)
+	require.NoError(t, err)
+	lookups, _ = selectByName("foo")
+	assert.Zero(t, lookups)
+	_, err = executorExecSession(ctx, executor, session, "commit", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.PinnedShards)
+	lookups, _ = selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+
+	// Setting the target with use releases the pinned shards.
+	_, err = executorExecSession(ctx, executor, session, "use @primary", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.PinnedShards)
+	lookups, _ = selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+
+	// Setting sticky_shards releases the pinned shards.
+	_, err = executorExecSession(ctx, executor, session, "set @@sticky_shards = 1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, session.PinnedShards)
+	lookups, _ = selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+
+	_, err = executorExecSession(ctx, executor, session, "set @@sticky_shards = 0", nil)
+	require.NoError(t, err)
+	lookups, _ = selectByName("foo")
+	assert.EqualValues(t, 1, lookups)
+	assert.Empty(t, session.PinnedShards)
+}
+
 func TestSelectINFromOR(t *testing.T) {
 	executor, sbc1, _, _, ctx := createExecutorEnv(t)
 
@@ -3303,7 +3412,7 @@ func TestOnlyOptimizedPlan(t *testing.T) {
 func TestPrepareWithUnsupportedQuery(t *testing.T) {
 	executor, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 
-	sql := "select a, b, c, row_number() over (partition by x) from user where c1 = ? and c2 = ?"
+	sql := "select a, b, c, lag(d) over (partition by x) from user where c1 = ? and c2 = ?"
 	session := econtext.NewAutocommitSession(&vtgatepb.Session{})
 	fields, paramsCount, err := executorPrepare(ctx, executor, session.Session, sql)
 	require.NoError(t, err)
@@ -3312,7 +3421,7 @@ func TestPrepareWithUnsupportedQuery(t *testing.T) {
 		{Name: "a", Type: querypb.Type_NULL_TYPE},
 		{Name: "b", Type: querypb.Type_NULL_TYPE},
 		{Name: "c", Type: querypb.Type_NULL_TYPE},
-		{Name: "row_number() over (partition by x)", Type: querypb.Type_NULL_TYPE},
+		{Name: "lag(d) over (partition by x)", Type: querypb.Type_NULL_TYPE},
 	}
 	require.Equal(t, wantFields, fields)
 
@@ -19,6 +19,7 @@ package vtgate
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/ptr"
@@ -30,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/vtgate/vschemaacl"
 
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
@@ -271,6 +273,12 @@ func TestExecutorSet(t *testing.T) {
 	}, {
 		in:  "set @@transaction_timeout = 50, transaction_timeout = 75",
 		out: &vtgatepb.Session{Autocommit: true, Options: &querypb.ExecuteOptions{TransactionTimeout: ptr.Of(int64(75))}},
+	}, {
+		in:  "set @@scatter_concurrency = 4",
+		out: &vtgatepb.Session{Autocommit: true, ScatterConcurrency: 4},
+	}, {
+		in:  "set @@scatter_concurrency = -1",
+		err: "invalid scatter_concurrency: -1",
 	}}
 	for i, tcase := range testcases {
 		t.Run(fmt.Sprintf("%d-%s", i, tcase.in), func(t *testing.T) {
@@ -652,3 +660,46 @@ func TestExecutorTimeZone(t *testing.T) {
 
 	assert.False(t, qr.Rows[0][0].Equal(qrWith.Rows[0][0]), "%v vs %v", qr.Rows[0][0].ToString(), qrWith.Rows[0][0].ToString())
 }
+
+func TestExecutorSessionToken(t *testing.T) {
+	e, _, _, _, ctx := createExecutorEnv(t)
+
+	session := econtext.NewAutocommitSession(&vtgatepb.Session{TargetString: KsTestUnsharded, EnableSystemSettings: true})
+	_, err := executorExecSession(ctx, e, session, "select @@session_token", nil)
+	require.ErrorContains(t, err, "session tokens are disabled")
+
+	e.vConfig.SessionTokenSecret = []byte("secret")
+	e.vConfig.SessionTokenTTL = time.Hour
+	session.SetSystemVariable("sql_mode", "''")
+	_, err = executorExecSession(ctx, e, session, "set @@ddl_strategy = 'online', @@migration_context = 'ctx1', sql_select_limit = 10", nil)
+	require.NoError(t, err)
+	qr, err := executorExecSession(ctx, e, session, "select @@session_token", nil)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+	token := qr.Rows[0][0].ToString()
+
+	restored := econtext.NewAutocommitSession(&vtgatepb.Session{})
+	_, err = executorExecSession(ctx, e, restored, fmt.Sprintf("set @@session_token = '%s'", token), nil)
+	require.NoError(t, err)
+	assert.Equal(t, KsTestUnsharded, restored.TargetString)
+	assert.EqualValues(t, 10, restored.GetOptions().GetSqlSelectLimit())
+	assert.Equal(t, "online", restored.DDLStrategy)
+	assert.Equal(t, "ctx1", restored.MigrationContext)
+	assert.Equal(t, map[string]string{"sql_mode": "''"}, restored.SystemVariables)
+
+	_, err = executorExecSession(ctx, e, econtext.NewAutocommitSession(&vtgatepb.Session{}), fmt.Sprintf("set @@session_token = '%sx'", token), nil)
+	require.ErrorContains(t, err, "invalid session_token")
+
+	otherUserCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("other"))
+	_, err = executorExecSession(otherUserCtx, e, econtext.NewAutocommitSession(&vtgatepb.Session{}), fmt.Sprintf("set @@session_token = '%s'", token), nil)
+	require.ErrorContains(t, err, "session_token belongs to another user")
+
+	e.vConfig.SessionTokenSecret = []byte("rotated")
+	_, err = executorExecSession(ctx, e, econtext.NewAutocommitSession(&vtgatepb.Session{}), fmt.Sprintf("set @@session_token = '%s'", token), nil)
+	require.ErrorContains(t, err, "invalid session_token")
+
+	_, err = executorExecSession(ctx, e, session, "begin", nil)
+	require.NoError(t, err)
+	_, err = executorExecSession(ctx, e, session, "select @@session_token", nil)
+	require.ErrorContains(t, err, "cannot export the session_token while in a transaction")
+}
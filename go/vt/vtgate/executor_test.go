@@ -944,6 +944,16 @@ func TestExecutorShow(t *testing.T) {
 	}
 	utils.MustMatch(t, wantqr, qr, query)
 
+	// Buffering is not enabled in the test gateway.
+	query = "show vitess_buffers"
+	qr, err = executorExecSession(ctx, executor, session, query, nil)
+	require.NoError(t, err)
+	wantqr = &sqltypes.Result{
+		Fields: buildVarCharFields("Keyspace", "Shard", "State", "BufferedRequests", "OldestRequestAgeMs", "BufferingDurationMs"),
+		Rows:   [][]sqltypes.Value{},
+	}
+	utils.MustMatch(t, wantqr, qr, query)
+
 	query = "show vschema vindexes"
 	qr, err = executorExecSession(ctx, executor, session, query, nil)
 	require.NoError(t, err)
@@ -1771,6 +1781,58 @@ func TestGetPlanCacheNormalized(t *testing.T) {
 	})
 }
 
+func TestGetPlanCachePrepared(t *testing.T) {
+	getPreparedPlan := func(t *testing.T, ctx context.Context, e *Executor, session *econtext.SafeSession, sql string, isExecutePath bool) *logstats.LogStats {
+		logStats := logstats.NewLogStats(ctx, "Test", "", "", nil, streamlog.NewQueryLogConfigForTest())
+		_, _, _, err := e.fetchOrCreatePlan(ctx, session, sql, map[string]*querypb.BindVariable{"v1": sqltypes.Int64BindVariable(1)}, false, true, logStats, isExecutePath)
+		require.NoError(t, err)
+
+		// Wait for cache to settle
+		time.Sleep(100 * time.Millisecond)
+		return logStats
+	}
+	query := "select * from music_user_map where id = ?"
+
+	t.Run("Dedicated cache", func(t *testing.T) {
+		r, _, _, _, ctx := createExecutorEnv(t)
+		require.NotNil(t, r.preparedPlans)
+		session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+		logStats := getPreparedPlan(t, ctx, r, session, query, false)
+		assert.False(t, logStats.CachedPlan)
+		assertCacheSize(t, r.preparedPlans, 1)
+		assertCacheSize(t, r.plans, 0)
+
+		// Executing the statement from another connection reuses the plan.
+		logStats = getPreparedPlan(t, ctx, r, econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"}), query, true)
+		assert.True(t, logStats.CachedPlan)
+
+		// Ad-hoc queries are cached separately.
+		getPlanCached(t, ctx, r, session, "select * from music_user_map where id = 1", makeComments(""), map[string]*querypb.BindVariable{}, false)
+		assertCacheSize(t, r.preparedPlans, 1)
+		assertCacheSize(t, r.plans, 1)
+
+		var plans int
+		r.ForEachPlan(func(*engine.Plan) bool {
+			plans++
+			return true
+		})
+		assert.Equal(t, 2, plans)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		r, _, _, _, ctx := createExecutorEnv(t)
+		r.preparedPlans.Close()
+		r.preparedPlans = nil
+		session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: "@primary"})
+
+		getPreparedPlan(t, ctx, r, session, query, false)
+		assertCacheSize(t, r.plans, 1)
+		logStats := getPreparedPlan(t, ctx, r, session, query, true)
+		assert.True(t, logStats.CachedPlan)
+	})
+}
+
 func TestGetPlanNormalized(t *testing.T) {
 	r, _, _, _, ctx := createExecutorEnvWithConfig(t, createExecutorConfigWithNormalizer())
 
@@ -34,6 +34,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/sysvars"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 )
@@ -70,6 +71,10 @@ type (
 		// Note: This is stored in the Go wrapper, not in the protobuf Session.
 		targetTabletAlias *topodatapb.TabletAlias
 
+		// execScatterConcurrency is the number of shards the scatter queries of
+		// the current execution are sent to in parallel, 0 meaning all of them.
+		execScatterConcurrency int
+
 		*vtgatepb.Session
 	}
 
@@ -115,6 +120,11 @@ const (
 	autocommitted
 )
 
+// maxPinnedShards is the number of vindex values for which a session with
+// sticky shards pins shards at most. The queries with other values are routed
+// by their vindex without being pinned.
+const maxPinnedShards = 1000
+
 const (
 	savepointStateNotSet = savepointState(iota)
 	// savepointNotNeeded - savepoint is not required
@@ -199,6 +209,10 @@ func (session *SafeSession) ResetAll() {
 }
 
 func (session *SafeSession) resetCommonLocked() {
+	// The shards pinned by sticky shards are released when a transaction ends.
+	if session.Session.InTransaction {
+		session.PinnedShards = nil
+	}
 	session.mustRollback = false
 	session.autocommitState = notAutocommittable
 	session.Session.InTransaction = false
@@ -267,6 +281,109 @@ func (session *SafeSession) GetQueryTimeout() int64 {
 	return session.QueryTimeout
 }
 
+// GetMaxQueryTimeout gets the maximum query timeout
+func (session *SafeSession) GetMaxQueryTimeout() int64 {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.MaxQueryTimeout
+}
+
+// SetScatterConcurrency sets the scatter concurrency of the session
+func (session *SafeSession) SetScatterConcurrency(scatterConcurrency int64) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.ScatterConcurrency = scatterConcurrency
+}
+
+// GetScatterConcurrency gets the scatter concurrency of the session
+func (session *SafeSession) GetScatterConcurrency() int64 {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ScatterConcurrency
+}
+
+// SetLastResultChecksum sets the checksum of the last result returned to the client
+func (session *SafeSession) SetLastResultChecksum(checksum uint32) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.LastResultChecksum = checksum
+}
+
+// GetLastResultChecksum gets the checksum of the last result returned to the client
+func (session *SafeSession) GetLastResultChecksum() uint32 {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.LastResultChecksum
+}
+
+// SetStickyShards sets whether the queries of the session are pinned to the
+// shards resolved for the first of them with the same lookup vindex value, and
+// releases the pinned shards.
+func (session *SafeSession) SetStickyShards(stickyShards bool) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.StickyShards = stickyShards
+	session.PinnedShards = nil
+}
+
+// GetStickyShards gets whether the queries of the session are pinned to the
+// shards resolved for the first of them with the same lookup vindex value
+func (session *SafeSession) GetStickyShards() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.StickyShards
+}
+
+// PinShards pins the queries of the session routed by the pin key to the
+// shards, if the session has sticky shards and fewer than maxPinnedShards
+// pins, or unpins them if there are no shards
+func (session *SafeSession) PinShards(pin string, shards []string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.StickyShards {
+		return
+	}
+	if len(shards) == 0 {
+		delete(session.PinnedShards, pin)
+		return
+	}
+	if session.PinnedShards == nil {
+		session.PinnedShards = make(map[string]*vtgatepb.Session_PinnedShards)
+	}
+	if _, ok := session.PinnedShards[pin]; !ok && len(session.PinnedShards) >= maxPinnedShards {
+		return
+	}
+	session.PinnedShards[pin] = &vtgatepb.Session_PinnedShards{Shards: shards}
+}
+
+// GetPinnedShards gets the shards to which the queries of the session routed
+// by the pin key are pinned
+func (session *SafeSession) GetPinnedShards(pin string) []string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.StickyShards {
+		return nil
+	}
+	return session.PinnedShards[pin].GetShards()
+}
+
+// SetExecScatterConcurrency sets the scatter concurrency of the current execution
+func (session *SafeSession) SetExecScatterConcurrency(scatterConcurrency int) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	session.execScatterConcurrency = scatterConcurrency
+}
+
+// GetExecScatterConcurrency gets the scatter concurrency of the current execution
+func (session *SafeSession) GetExecScatterConcurrency() int {
+	if session == nil {
+		return 0
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.execScatterConcurrency
+}
+
 // SavePoints returns the save points of the session. It's safe to use concurrently
 func (session *SafeSession) SavePoints() []string {
 	session.mu.Lock()
@@ -627,6 +744,10 @@ func (session *SafeSession) SetUserDefinedVariable(key string, value *querypb.Bi
 func (session *SafeSession) SetTargetString(target string) {
 	session.mu.Lock()
 	defer session.mu.Unlock()
+	// The shards pinned by sticky shards are released when the target is set,
+	// by `use <db>` in particular, as they may have been resolved for another
+	// keyspace or tablet type.
+	session.PinnedShards = nil
 	session.TargetString = target
 }
 
@@ -683,6 +804,23 @@ func (session *SafeSession) TimeZone() *time.Location {
 	return loc
 }
 
+// SQLMode returns the sql_mode stored in system_variables map in the session,
+// and false if the session did not change it.
+func (session *SafeSession) SQLMode() (string, bool) {
+	session.mu.Lock()
+	sqlModeSQL, ok := session.SystemVariables["sql_mode"]
+	session.mu.Unlock()
+
+	if !ok {
+		return "", false
+	}
+	sqlMode, err := sqltypes.DecodeStringSQL(sqlModeSQL)
+	if err != nil {
+		return "", false
+	}
+	return sqlMode, true
+}
+
 // ForeignKeyChecks returns the foreign_key_checks stored in system_variables map in the session.
 func (session *SafeSession) ForeignKeyChecks() *bool {
 	session.mu.Lock()
@@ -909,6 +1047,53 @@ func (session *SafeSession) SetSessionTrackGtids(enable bool) {
 		session.ReadAfterWrite = &vtgatepb.ReadAfterWrite{}
 	}
 	session.ReadAfterWrite.SessionTrackGtids = enable
+	if !enable {
+		session.ReadAfterWrite.ShardGtids = nil
+	}
+}
+
+// SetShardGTIDs records the GTID set executed by the primary of a shard after
+// a write of the session, if the session tracks its GTIDs.
+func (session *SafeSession) SetShardGTIDs(keyspace, shard, gtids string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.ReadAfterWrite.GetSessionTrackGtids() {
+		return
+	}
+	if session.ReadAfterWrite.ShardGtids == nil {
+		session.ReadAfterWrite.ShardGtids = make(map[string]string)
+	}
+	session.ReadAfterWrite.ShardGtids[topoproto.KeyspaceShardString(keyspace, shard)] = gtids
+}
+
+// GetShardGTIDs returns the GTID set a replica of a shard must have applied to
+// read the writes of the session, it is empty if the session does not track
+// its GTIDs or has not written to the shard.
+func (session *SafeSession) GetShardGTIDs(keyspace, shard string) string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.ReadAfterWrite.GetSessionTrackGtids() {
+		return ""
+	}
+	return session.ReadAfterWrite.ShardGtids[topoproto.KeyspaceShardString(keyspace, shard)]
+}
+
+// TracksGTIDs returns true if the session tracks the GTIDs of its writes.
+func (session *SafeSession) TracksGTIDs() bool {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.ReadAfterWrite.GetSessionTrackGtids()
+}
+
+// GetReadAfterWriteTimeout returns how long a replica read waits for the
+// writes of the session to be applied, or the given default if it is not set.
+func (session *SafeSession) GetReadAfterWriteTimeout(defaultTimeout time.Duration) time.Duration {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if timeout := session.ReadAfterWrite.GetReadAfterWriteTimeout(); timeout > 0 {
+		return time.Duration(timeout * float64(time.Second))
+	}
+	return defaultTimeout
 }
 
 func removeShard(tabletAlias *topodatapb.TabletAlias, sessions []*vtgatepb.Session_ShardSession) ([]*vtgatepb.Session_ShardSession, error) {
@@ -17,6 +17,7 @@ limitations under the License.
 package executorcontext
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -206,6 +207,48 @@ func TestTimeZone(t *testing.T) {
 	}
 }
 
+func TestSQLMode(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	_, ok := session.SQLMode()
+	assert.False(t, ok)
+
+	session = NewSafeSession(&vtgatepb.Session{
+		SystemVariables: map[string]string{"sql_mode": "''"},
+	})
+	sqlMode, ok := session.SQLMode()
+	assert.True(t, ok)
+	assert.Empty(t, sqlMode)
+
+	session = NewSafeSession(&vtgatepb.Session{
+		SystemVariables: map[string]string{"sql_mode": "'STRICT_TRANS_TABLES,NO_ZERO_DATE'"},
+	})
+	sqlMode, ok = session.SQLMode()
+	assert.True(t, ok)
+	assert.Equal(t, "STRICT_TRANS_TABLES,NO_ZERO_DATE", sqlMode)
+}
+
+func TestPinShards(t *testing.T) {
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.PinShards("ks.vdx.INT64(0)", []string{"-80"})
+	assert.Empty(t, session.PinnedShards)
+
+	session.SetStickyShards(true)
+	for i := range maxPinnedShards + 1 {
+		session.PinShards(fmt.Sprintf("ks.vdx.INT64(%d)", i), []string{"-80"})
+	}
+	// The pins beyond the cap are not kept, but the existing ones can change.
+	assert.Len(t, session.PinnedShards, maxPinnedShards)
+	assert.Nil(t, session.GetPinnedShards(fmt.Sprintf("ks.vdx.INT64(%d)", maxPinnedShards)))
+	session.PinShards("ks.vdx.INT64(0)", []string{"80-"})
+	assert.Equal(t, []string{"80-"}, session.GetPinnedShards("ks.vdx.INT64(0)"))
+	session.PinShards("ks.vdx.INT64(1)", nil)
+	assert.Len(t, session.PinnedShards, maxPinnedShards-1)
+
+	// Setting the target releases the pins.
+	session.SetTargetString("ks@replica")
+	assert.Empty(t, session.PinnedShards)
+}
+
 // TestTargetTabletAlias tests the SetTargetTabletAlias and GetTargetTabletAlias methods.
 func TestTargetTabletAlias(t *testing.T) {
 	session := NewSafeSession(&vtgatepb.Session{})
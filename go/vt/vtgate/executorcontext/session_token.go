@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// A session token carries the state of a session which can be moved to
+// another connection of the same user: the target, the MySQL system
+// variables and the Vitess-aware settings. It is made of the base64 encoded
+// user, expiry time, nonce and state, and of their HMAC-SHA256 signature, all
+// separated by dots. The signature guarantees that the system variables,
+// which are sent to MySQL as they are, were set through vtgate by the user.
+// The nonce makes every token unique.
+const (
+	sessionTokenSeparator = "."
+	sessionTokenNonceSize = 16
+)
+
+// SessionToken returns the state of the session as a token of the given user,
+// valid for ttl and signed with the given secret. The session must not be in
+// a transaction, nor hold reserved connections or advisory locks, as these
+// cannot be moved to another connection.
+func (session *SafeSession) SessionToken(secret []byte, user string, ttl time.Duration) (string, error) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if err := session.checkSessionTokenLocked(secret, "export"); err != nil {
+		return "", err
+	}
+
+	state := &vtgatepb.Session{
+		Autocommit:           session.Autocommit,
+		TargetString:         session.TargetString,
+		Options:              session.Options,
+		TransactionMode:      session.TransactionMode,
+		SystemVariables:      session.SystemVariables,
+		ReadAfterWrite:       session.ReadAfterWrite,
+		DDLStrategy:          session.DDLStrategy,
+		EnableSystemSettings: session.EnableSystemSettings,
+		QueryTimeout:         session.QueryTimeout,
+		ScatterConcurrency:   session.ScatterConcurrency,
+		MigrationContext:     session.MigrationContext,
+	}
+	payload, err := state.MarshalVT()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, sessionTokenNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	expiry := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+
+	signed := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(user)),
+		base64.RawURLEncoding.EncodeToString([]byte(expiry)),
+		base64.RawURLEncoding.EncodeToString(nonce),
+		base64.RawURLEncoding.EncodeToString(payload),
+	}, sessionTokenSeparator)
+	return signed + sessionTokenSeparator + base64.RawURLEncoding.EncodeToString(signSessionToken(secret, signed)), nil
+}
+
+// RestoreSessionToken verifies the signature, the user and the expiry of a
+// token returned by SessionToken and replaces the state of the session with
+// the one it carries. The options negotiated by the connection and the
+// maximum query timeout of the user are kept.
+func (session *SafeSession) RestoreSessionToken(secret []byte, user string, token string) error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if err := session.checkSessionTokenLocked(secret, "restore"); err != nil {
+		return err
+	}
+
+	invalid := vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "invalid session_token")
+	signed, encodedSignature, ok := cutLast(token, sessionTokenSeparator)
+	if !ok {
+		return invalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signSessionToken(secret, signed)) {
+		return invalid
+	}
+	parts := strings.Split(signed, sessionTokenSeparator)
+	if len(parts) != 4 {
+		return invalid
+	}
+	fields := make([][]byte, len(parts))
+	for i, part := range parts {
+		if fields[i], err = base64.RawURLEncoding.DecodeString(part); err != nil {
+			return invalid
+		}
+	}
+	if string(fields[0]) != user {
+		return vterrors.NewErrorf(vtrpcpb.Code_PERMISSION_DENIED, vterrors.WrongValueForVar, "session_token belongs to another user")
+	}
+	expiry, err := strconv.ParseInt(string(fields[1]), 10, 64)
+	if err != nil {
+		return invalid
+	}
+	if time.Now().Unix() > expiry {
+		return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.WrongValueForVar, "session_token has expired")
+	}
+	state := &vtgatepb.Session{}
+	if err := state.UnmarshalVT(fields[3]); err != nil {
+		return invalid
+	}
+
+	options := state.Options
+	if options == nil {
+		options = &querypb.ExecuteOptions{}
+	}
+	if session.Options != nil {
+		options.IncludedFields = session.Options.IncludedFields
+		options.ClientFoundRows = session.Options.ClientFoundRows
+		options.ResultChecksum = session.Options.ResultChecksum
+	}
+	queryTimeout := state.QueryTimeout
+	if session.MaxQueryTimeout > 0 && queryTimeout > session.MaxQueryTimeout {
+		queryTimeout = session.MaxQueryTimeout
+	}
+
+	session.Autocommit = state.Autocommit
+	session.TargetString = state.TargetString
+	session.Options = options
+	session.TransactionMode = state.TransactionMode
+	session.SystemVariables = state.SystemVariables
+	session.ReadAfterWrite = state.ReadAfterWrite
+	session.DDLStrategy = state.DDLStrategy
+	session.EnableSystemSettings = state.EnableSystemSettings
+	session.QueryTimeout = queryTimeout
+	session.ScatterConcurrency = state.ScatterConcurrency
+	session.MigrationContext = state.MigrationContext
+	return nil
+}
+
+// checkSessionTokenLocked returns an error if session tokens are disabled, or
+// if the session holds state which cannot be moved to another connection.
+func (session *SafeSession) checkSessionTokenLocked(secret []byte, action string) error {
+	if len(secret) == 0 {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "session tokens are disabled, --session-token-secret is not set")
+	}
+	if session.Session.InTransaction || len(session.Savepoints) > 0 {
+		return vterrors.NewErrorf(vtrpcpb.Code_FAILED_PRECONDITION, vterrors.LockOrActiveTransaction, "cannot %s the session_token while in a transaction", action)
+	}
+	if session.Session.InReservedConn {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot %s the session_token while holding reserved connections", action)
+	}
+	for _, shardSessions := range [][]*vtgatepb.Session_ShardSession{session.PreSessions, session.ShardSessions, session.PostSessions} {
+		for _, shardSession := range shardSessions {
+			if shardSession.ReservedId != 0 {
+				return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot %s the session_token while holding reserved connections", action)
+			}
+		}
+	}
+	if session.LockSession != nil {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "cannot %s the session_token while holding advisory locks", action)
+	}
+	return nil
+}
+
+func signSessionToken(secret []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
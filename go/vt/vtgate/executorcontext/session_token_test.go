@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executorcontext
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestSessionToken(t *testing.T) {
+	secret := []byte("secret")
+	session := NewSafeSession(&vtgatepb.Session{
+		TargetString:    "ks@replica",
+		Autocommit:      true,
+		Options:         &querypb.ExecuteOptions{Workload: querypb.ExecuteOptions_OLAP, SqlSelectLimit: 10, ClientFoundRows: true},
+		TransactionMode: vtgatepb.TransactionMode_SINGLE,
+		SystemVariables: map[string]string{"sql_mode": "''"},
+		DDLStrategy:     "online",
+		QueryTimeout:    100,
+		// The state tied to the connection is not part of the token.
+		SessionUUID:          "uuid",
+		UserDefinedVariables: map[string]*querypb.BindVariable{"x": {Type: querypb.Type_INT64, Value: []byte("1")}},
+		ShardSessions:        []*vtgatepb.Session_ShardSession{{Target: &querypb.Target{Keyspace: "ks", Shard: "0"}}},
+	})
+
+	token, err := session.SessionToken(secret, "user1", time.Hour)
+	require.NoError(t, err)
+	other, err := session.SessionToken(secret, "user1", time.Hour)
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+
+	// The options negotiated by the connection and the maximum query timeout
+	// of the user are kept.
+	restored := NewSafeSession(&vtgatepb.Session{
+		SessionUUID:     "other",
+		Options:         &querypb.ExecuteOptions{IncludedFields: querypb.ExecuteOptions_ALL},
+		MaxQueryTimeout: 50,
+	})
+	require.NoError(t, restored.RestoreSessionToken(secret, "user1", token))
+	utils.MustMatch(t, &vtgatepb.Session{
+		TargetString:    "ks@replica",
+		Autocommit:      true,
+		Options:         &querypb.ExecuteOptions{IncludedFields: querypb.ExecuteOptions_ALL, Workload: querypb.ExecuteOptions_OLAP, SqlSelectLimit: 10},
+		TransactionMode: vtgatepb.TransactionMode_SINGLE,
+		SystemVariables: map[string]string{"sql_mode": "''"},
+		DDLStrategy:     "online",
+		QueryTimeout:    50,
+		MaxQueryTimeout: 50,
+		SessionUUID:     "other",
+	}, restored.Session)
+
+	require.ErrorContains(t, restored.RestoreSessionToken(secret, "user2", token), "session_token belongs to another user")
+	require.ErrorContains(t, restored.RestoreSessionToken([]byte("other"), "user1", token), "invalid session_token")
+	require.ErrorContains(t, restored.RestoreSessionToken(secret, "user1", "garbage"), "invalid session_token")
+	require.ErrorContains(t, restored.RestoreSessionToken(secret, "user1", strings.Replace(token, ".", "", 1)), "invalid session_token")
+	require.ErrorContains(t, restored.RestoreSessionToken(nil, "user1", token), "session tokens are disabled")
+
+	expired, err := session.SessionToken(secret, "user1", -time.Minute)
+	require.NoError(t, err)
+	require.ErrorContains(t, restored.RestoreSessionToken(secret, "user1", expired), "session_token has expired")
+}
+
+func TestSessionTokenUnmovableState(t *testing.T) {
+	secret := []byte("secret")
+	tcases := []struct {
+		name    string
+		session *vtgatepb.Session
+		wantErr string
+	}{{
+		name:    "transaction",
+		session: &vtgatepb.Session{InTransaction: true},
+		wantErr: "cannot export the session_token while in a transaction",
+	}, {
+		name:    "savepoints",
+		session: &vtgatepb.Session{Savepoints: []string{"savepoint a"}},
+		wantErr: "cannot export the session_token while in a transaction",
+	}, {
+		name:    "reserved connection",
+		session: &vtgatepb.Session{InReservedConn: true, PostSessions: []*vtgatepb.Session_ShardSession{{ReservedId: 1}}},
+		wantErr: "cannot export the session_token while holding reserved connections",
+	}, {
+		name:    "settings needing a reserved connection",
+		session: &vtgatepb.Session{InReservedConn: true},
+		wantErr: "cannot export the session_token while holding reserved connections",
+	}, {
+		name:    "advisory lock",
+		session: &vtgatepb.Session{LockSession: &vtgatepb.Session_ShardSession{ReservedId: 1}},
+		wantErr: "cannot export the session_token while holding advisory locks",
+	}}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			_, err := NewSafeSession(tcase.session).SessionToken(secret, "user1", time.Hour)
+			require.ErrorContains(t, err, tcase.wantErr)
+		})
+	}
+}
@@ -88,9 +88,34 @@ type (
 		WarnShardedOnly    bool
 		PlannerVersion     plancontext.PlannerVersion
 
+		// SpillDir is the directory in which the rows exceeding
+		// MaxMemoryRows are spilled, which is disabled when it is empty.
+		SpillDir string
+
+		// DefaultScatterConcurrency is the number of shards the scatter
+		// queries are sent to in parallel when neither the session nor the
+		// query sets it, all of them when 0. MaxScatterConcurrency caps it,
+		// including when set by the session or the query, unless 0.
+		DefaultScatterConcurrency int
+		MaxScatterConcurrency     int
+
+		// HashJoinRowsThreshold is the estimated number of rows of the inputs
+		// of a cross-shard join above which a hash join is used, which is
+		// disabled when 0.
+		HashJoinRowsThreshold int64
+		// JoinOrderByTableStats drives the nested-loop joins from the input
+		// with fewer estimated rows.
+		JoinOrderByTableStats bool
+
 		WarmingReadsPercent int
 		WarmingReadsTimeout time.Duration
 		WarmingReadsChannel chan bool
+
+		// SessionTokenSecret signs the session tokens, which are disabled
+		// when it is empty.
+		SessionTokenSecret []byte
+		// SessionTokenTTL is how long the session tokens are valid.
+		SessionTokenTTL time.Duration
 	}
 
 	// vcursor_impl needs these facilities to be able to be able to execute queries for vindexes
@@ -107,6 +132,7 @@ type (
 		ShowVitessReplicationStatus(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		ShowShards(ctx context.Context, filter *sqlparser.ShowFilter, destTabletType topodatapb.TabletType) (*sqltypes.Result, error)
 		ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
+		ShowVitessBuffers(filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error)
 		SetVitessMetadata(ctx context.Context, name, value string) error
 
@@ -298,6 +324,15 @@ func (vc *VCursorImpl) CloneForMirroring(ctx context.Context) engine.VCursor {
 	return v
 }
 
+// VSchemaCanaryPercent returns the percent of the canary vschema used by the
+// session, or 0 if it does not use one.
+func (vc *VCursorImpl) VSchemaCanaryPercent() uint32 {
+	if vc.vschema == nil {
+		return 0
+	}
+	return vc.vschema.CanaryPercent
+}
+
 func (vc *VCursorImpl) CloneForReplicaWarming(ctx context.Context) engine.VCursor {
 	callerId := callerid.EffectiveCallerIDFromContext(ctx)
 	immediateCallerId := callerid.ImmediateCallerIDFromContext(ctx)
@@ -389,8 +424,10 @@ func (vc *VCursorImpl) TimeZone() *time.Location {
 }
 
 func (vc *VCursorImpl) SQLMode() string {
-	// TODO: Implement return the current sql_mode.
-	// This is currently hardcoded to the default in MySQL 8.0.
+	if sqlMode, ok := vc.SafeSession.SQLMode(); ok {
+		return sqlMode
+	}
+	// The sql_mode was not changed by the session, so it is the default in MySQL 8.0.
 	return config.DefaultSQLMode
 }
 
@@ -405,6 +442,11 @@ func (vc *VCursorImpl) ExceedsMaxMemoryRows(numRows int) bool {
 	return !vc.ignoreMaxMemoryRows && numRows > vc.config.MaxMemoryRows
 }
 
+// SpillDir returns the spillDir flag value.
+func (vc *VCursorImpl) SpillDir() string {
+	return vc.config.SpillDir
+}
+
 // SetIgnoreMaxMemoryRows sets the ignoreMaxMemoryRows value.
 func (vc *VCursorImpl) SetIgnoreMaxMemoryRows(ignoreMaxMemoryRows bool) {
 	vc.ignoreMaxMemoryRows = ignoreMaxMemoryRows
@@ -1031,7 +1073,19 @@ func (vc *VCursorImpl) Session() engine.SessionActions {
 	return vc
 }
 
+// SetTarget sets the target of the session, as `use <db>` does. A target
+// without a tablet type takes the default tablet type of the user, if the
+// session defaults of the user have one.
 func (vc *VCursorImpl) SetTarget(target string) error {
+	if defaultTabletType := vc.SafeSession.GetDefaultTabletType(); defaultTabletType != topodatapb.TabletType_UNKNOWN {
+		if _, tabletType, _, _, err := topoprotopb.ParseDestination(target, topodatapb.TabletType_UNKNOWN); err == nil && tabletType == topodatapb.TabletType_UNKNOWN {
+			target += "@" + topoprotopb.TabletTypeLString(defaultTabletType)
+		}
+	}
+	return vc.setTarget(target)
+}
+
+func (vc *VCursorImpl) setTarget(target string) error {
 	keyspace, tabletType, destination, tabletAlias, err := topoprotopb.ParseDestination(target, vc.config.DefaultTabletType)
 	if err != nil {
 		return err
@@ -1169,6 +1223,11 @@ func (vc *VCursorImpl) SetQueryTimeout(maxExecutionTime int64) {
 	vc.SafeSession.QueryTimeout = maxExecutionTime
 }
 
+// SetScatterConcurrency implements the SessionActions interface
+func (vc *VCursorImpl) SetScatterConcurrency(scatterConcurrency int64) {
+	vc.SafeSession.SetScatterConcurrency(scatterConcurrency)
+}
+
 // SetTransactionTimeout implements the SessionActions interface
 func (vc *VCursorImpl) SetTransactionTimeout(transactionTimeout int64) {
 	vc.SafeSession.GetOrCreateOptions().TransactionTimeout = &transactionTimeout
@@ -1180,6 +1239,28 @@ func (vc *VCursorImpl) SetClientFoundRows(_ context.Context, clientFoundRows boo
 	return nil
 }
 
+// SetResultChecksum implements the SessionActions interface
+func (vc *VCursorImpl) SetResultChecksum(_ context.Context, resultChecksum bool) error {
+	vc.SafeSession.GetOrCreateOptions().ResultChecksum = resultChecksum
+	return nil
+}
+
+// SetStickyShards implements the SessionActions interface
+func (vc *VCursorImpl) SetStickyShards(_ context.Context, stickyShards bool) error {
+	vc.SafeSession.SetStickyShards(stickyShards)
+	return nil
+}
+
+// PinnedShards implements the SessionActions interface
+func (vc *VCursorImpl) PinnedShards(pin string) []string {
+	return vc.SafeSession.GetPinnedShards(pin)
+}
+
+// PinShards implements the SessionActions interface
+func (vc *VCursorImpl) PinShards(pin string, shards []string) {
+	vc.SafeSession.PinShards(pin, shards)
+}
+
 // SetSkipQueryPlanCache implements the SessionActions interface
 func (vc *VCursorImpl) SetSkipQueryPlanCache(_ context.Context, skipQueryPlanCache bool) error {
 	vc.SafeSession.GetOrCreateOptions().SkipQueryPlanCache = skipQueryPlanCache
@@ -1223,6 +1304,10 @@ func (vc *VCursorImpl) SetExecQueryTimeout(timeout *int) {
 	} else if sessionTimeout := vc.getQueryTimeout(); sessionTimeout > 0 {
 		execTimeout = &sessionTimeout
 	}
+	// The maximum query timeout of the session caps the timeout, including the infinite one
+	if maxTimeout := int(vc.SafeSession.GetMaxQueryTimeout()); maxTimeout > 0 && (execTimeout == nil || *execTimeout == 0 || *execTimeout > maxTimeout) {
+		execTimeout = &maxTimeout
+	}
 
 	// If no effective timeout and no session options, return early
 	if execTimeout == nil {
@@ -1240,6 +1325,26 @@ func (vc *VCursorImpl) SetExecQueryTimeout(timeout *int) {
 	}
 }
 
+// SetExecScatterConcurrency sets the number of shards the scatter queries of
+// this execution are sent to in parallel: the query hint if set, otherwise
+// the one of the session, otherwise the default of the config. It is capped
+// by the maximum of the config.
+func (vc *VCursorImpl) SetExecScatterConcurrency(concurrency *int) {
+	var c int
+	switch {
+	case concurrency != nil:
+		c = *concurrency
+	case vc.SafeSession.GetScatterConcurrency() != 0:
+		c = int(vc.SafeSession.GetScatterConcurrency())
+	default:
+		c = vc.config.DefaultScatterConcurrency
+	}
+	if limit := vc.config.MaxScatterConcurrency; limit > 0 && (c == 0 || c > limit) {
+		c = limit
+	}
+	vc.SafeSession.SetExecScatterConcurrency(c)
+}
+
 // getQueryTimeout returns timeout based on the priority
 // session setting > global default specified by a flag.
 func (vc *VCursorImpl) getQueryTimeout() int {
@@ -1296,6 +1401,22 @@ func (vc *VCursorImpl) GetMigrationContext() string {
 	return vc.SafeSession.GetMigrationContext()
 }
 
+// SessionToken returns the state of the session as a token signed for the
+// user of the context.
+func (vc *VCursorImpl) SessionToken(ctx context.Context) (string, error) {
+	user := callerid.ImmediateCallerIDFromContext(ctx).GetUsername()
+	return vc.SafeSession.SessionToken(vc.config.SessionTokenSecret, user, vc.config.SessionTokenTTL)
+}
+
+// RestoreSessionToken implements the SessionActions interface
+func (vc *VCursorImpl) RestoreSessionToken(ctx context.Context, token string) error {
+	user := callerid.ImmediateCallerIDFromContext(ctx).GetUsername()
+	if err := vc.SafeSession.RestoreSessionToken(vc.config.SessionTokenSecret, user, token); err != nil {
+		return err
+	}
+	return vc.setTarget(vc.SafeSession.GetTargetString())
+}
+
 // GetSessionUUID implements the SessionActions interface
 func (vc *VCursorImpl) GetSessionUUID() string {
 	return vc.SafeSession.GetSessionUUID()
@@ -1515,6 +1636,8 @@ func (vc *VCursorImpl) VStream(ctx context.Context, rss []*srvtopo.ResolvedShard
 
 func (vc *VCursorImpl) ShowExec(ctx context.Context, command sqlparser.ShowCommandType, filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
 	switch command {
+	case sqlparser.VitessBuffers:
+		return vc.executor.ShowVitessBuffers(filter)
 	case sqlparser.VitessReplicationStatus:
 		return vc.executor.ShowVitessReplicationStatus(ctx, filter)
 	case sqlparser.VitessShards:
@@ -1620,6 +1743,14 @@ func (vc *VCursorImpl) IsViewsEnabled() bool {
 	return vc.config.EnableViews
 }
 
+func (vc *VCursorImpl) HashJoinRowsThreshold() int64 {
+	return vc.config.HashJoinRowsThreshold
+}
+
+func (vc *VCursorImpl) JoinOrderByTableStats() bool {
+	return vc.config.JoinOrderByTableStats
+}
+
 func (vc *VCursorImpl) GetUDV(name string) *querypb.BindVariable {
 	return vc.SafeSession.GetUDV(name)
 }
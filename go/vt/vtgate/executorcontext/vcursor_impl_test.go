@@ -307,6 +307,79 @@ func TestSetExecQueryTimeout(t *testing.T) {
 	require.Equal(t, 0*time.Millisecond, vc.queryTimeout)
 	// this should be reset.
 	require.Nil(t, safeSession.Options.Timeout)
+
+	// maximum session timeout caps the infinite timeout
+	safeSession.MaxQueryTimeout = 30
+	vc.SetExecQueryTimeout(nil)
+	require.Equal(t, 30*time.Millisecond, vc.queryTimeout)
+	require.EqualValues(t, 30, safeSession.Options.GetAuthoritativeTimeout())
+
+	// maximum session timeout caps the session and query hint timeouts
+	safeSession.SetQueryTimeout(40)
+	vc.SetExecQueryTimeout(nil)
+	require.Equal(t, 30*time.Millisecond, vc.queryTimeout)
+	timeoutQueryHint = 60
+	vc.SetExecQueryTimeout(&timeoutQueryHint)
+	require.Equal(t, 30*time.Millisecond, vc.queryTimeout)
+	require.EqualValues(t, 30, safeSession.Options.GetAuthoritativeTimeout())
+
+	// lower timeouts are kept
+	timeoutQueryHint = 10
+	vc.SetExecQueryTimeout(&timeoutQueryHint)
+	require.Equal(t, 10*time.Millisecond, vc.queryTimeout)
+	require.EqualValues(t, 10, safeSession.Options.GetAuthoritativeTimeout())
+}
+
+func TestSetExecScatterConcurrency(t *testing.T) {
+	safeSession := NewSafeSession(nil)
+	vc, err := NewVCursorImpl(safeSession, sqlparser.MarginComments{}, nil, nil, nil, &vindexes.VSchema{}, nil, nil, fakeObserver{}, VCursorConfig{}, nil)
+	require.NoError(t, err)
+
+	// no limit by default
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 0, safeSession.GetExecScatterConcurrency())
+
+	// session scatter concurrency
+	safeSession.SetScatterConcurrency(8)
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 8, safeSession.GetExecScatterConcurrency())
+
+	// query hint overrides the session
+	concurrencyQueryHint := 4
+	vc.SetExecScatterConcurrency(&concurrencyQueryHint)
+	require.Equal(t, 4, safeSession.GetExecScatterConcurrency())
+
+	// the next query without the hint uses the session again
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 8, safeSession.GetExecScatterConcurrency())
+}
+
+func TestSetExecScatterConcurrencyConfig(t *testing.T) {
+	safeSession := NewSafeSession(nil)
+	vc, err := NewVCursorImpl(safeSession, sqlparser.MarginComments{}, nil, nil, nil, &vindexes.VSchema{}, nil, nil, fakeObserver{}, VCursorConfig{
+		DefaultScatterConcurrency: 4,
+		MaxScatterConcurrency:     16,
+	}, nil)
+	require.NoError(t, err)
+
+	// the default of the config
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 4, safeSession.GetExecScatterConcurrency())
+
+	// the session and the query hint can raise it up to the maximum
+	safeSession.SetScatterConcurrency(8)
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 8, safeSession.GetExecScatterConcurrency())
+
+	concurrencyQueryHint := 64
+	vc.SetExecScatterConcurrency(&concurrencyQueryHint)
+	require.Equal(t, 16, safeSession.GetExecScatterConcurrency())
+
+	// the maximum applies when the concurrency is otherwise unbounded
+	vc.config.DefaultScatterConcurrency = 0
+	safeSession.SetScatterConcurrency(0)
+	vc.SetExecScatterConcurrency(nil)
+	require.Equal(t, 16, safeSession.GetExecScatterConcurrency())
 }
 
 func TestRecordMirrorStats(t *testing.T) {
@@ -383,6 +456,11 @@ func (f fakeExecutor) ShowTablets(filter *sqlparser.ShowFilter) (*sqltypes.Resul
 	panic("implement me")
 }
 
+func (f fakeExecutor) ShowVitessBuffers(filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (f fakeExecutor) ShowVitessMetadata(ctx context.Context, filter *sqlparser.ShowFilter) (*sqltypes.Result, error) {
 	// TODO implement me
 	panic("implement me")
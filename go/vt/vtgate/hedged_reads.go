@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// hedgeWindowSize is the number of recent latencies of a target from
+	// which the hedging delay is computed.
+	hedgeWindowSize = 1000
+	// hedgeMinSamples is the number of latencies needed before reads on a
+	// target are hedged, and how often the hedging delay is recomputed.
+	hedgeMinSamples = 100
+)
+
+var (
+	// hedgedReadsPercentile is the percentile of the latencies of a target
+	// after which a read is also sent to another tablet, 0 disables hedging.
+	hedgedReadsPercentile float64
+	// hedgedReadsMinDelay is the minimum time to wait for a response before
+	// hedging a read.
+	hedgedReadsMinDelay = 5 * time.Millisecond
+
+	hedgedReads    = stats.NewCountersWithMultiLabels("HedgedReads", "Reads also sent to another tablet because the first one was slow or failed", []string{"Keyspace", "ShardName", "DbType"})
+	hedgedReadsWon = stats.NewCountersWithMultiLabels("HedgedReadsWon", "Hedged reads answered by the other tablet first", []string{"Keyspace", "ShardName", "DbType"})
+)
+
+// hedger keeps the recent latencies of the reads of each target, to decide
+// how long to wait for a tablet before sending a read to another one.
+type hedger struct {
+	percentile float64
+	minDelay   time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*latencyWindow
+}
+
+type latencyWindow struct {
+	samples  []time.Duration
+	next     int
+	recorded int
+	delay    time.Duration
+}
+
+func newHedger(percentile float64, minDelay time.Duration) *hedger {
+	return &hedger{
+		percentile: percentile,
+		minDelay:   minDelay,
+		windows:    make(map[string]*latencyWindow),
+	}
+}
+
+func hedgerKey(target *querypb.Target) string {
+	return fmt.Sprintf("%v/%v/%v", target.Keyspace, target.Shard, target.TabletType.String())
+}
+
+// delay returns how long to wait for a read on the target before hedging it,
+// and false if there are not enough latencies yet to tell.
+func (h *hedger) delay(target *querypb.Target) (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	w := h.windows[hedgerKey(target)]
+	if w == nil || w.delay == 0 {
+		return 0, false
+	}
+	return max(w.delay, h.minDelay), true
+}
+
+// record adds the latency of a successful read on the target.
+func (h *hedger) record(target *querypb.Target, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := hedgerKey(target)
+	w := h.windows[key]
+	if w == nil {
+		w = &latencyWindow{samples: make([]time.Duration, 0, hedgeWindowSize)}
+		h.windows[key] = w
+	}
+	if len(w.samples) < hedgeWindowSize {
+		w.samples = append(w.samples, latency)
+	} else {
+		w.samples[w.next] = latency
+		w.next = (w.next + 1) % hedgeWindowSize
+	}
+	w.recorded++
+	if w.recorded%hedgeMinSamples != 0 {
+		return
+	}
+	sorted := slices.Clone(w.samples)
+	slices.Sort(sorted)
+	i := int(math.Ceil(h.percentile/100*float64(len(sorted)))) - 1
+	w.delay = sorted[max(0, min(i, len(sorted)-1))]
+}
+
+// canHedgeAfterError returns true if a read which failed with the error
+// can be sent to another tablet right away.
+func canHedgeAfterError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_UNAVAILABLE, vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_CLUSTER_EVENT, vtrpcpb.Code_RESOURCE_EXHAUSTED:
+		return true
+	}
+	return false
+}
+
+// Execute is part of the QueryService interface. Reads on replica and rdonly
+// tablets outside of transactions are hedged when it is enabled.
+func (gw *TabletGateway) Execute(ctx context.Context, session queryservice.Session, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	if gw.hedger == nil || transactionID != 0 || reservedID != 0 || target == nil ||
+		(target.TabletType != topodatapb.TabletType_REPLICA && target.TabletType != topodatapb.TabletType_RDONLY) {
+		return gw.QueryService.Execute(ctx, session, target, query, bindVars, transactionID, reservedID, options)
+	}
+	return gw.hedgedExecute(ctx, session, target, query, bindVars, options)
+}
+
+// hedgedExecute sends the read to a tablet, and to another one if the first
+// one fails or does not respond within the hedging delay of the target. The
+// first successful response is used and the other read is canceled.
+func (gw *TabletGateway) hedgedExecute(ctx context.Context, session queryservice.Session, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
+	execute := func() (*sqltypes.Result, error) {
+		return gw.QueryService.Execute(ctx, session, target, query, bindVars, 0, 0, options)
+	}
+	if len(discovery.AllowedTabletTypes) > 0 && !slices.Contains(discovery.AllowedTabletTypes, target.TabletType) {
+		return execute()
+	}
+	tablets := gw.hc.GetHealthyTabletStats(target)
+	delay, ok := gw.hedger.delay(target)
+	if !ok || len(tablets) < 2 {
+		// Reads which are not hedged still record their latency, so that
+		// the hedging delay of the target can be computed.
+		start := time.Now()
+		qr, err := execute()
+		if err == nil {
+			gw.hedger.record(target, time.Since(start))
+		}
+		return qr, err
+	}
+
+	type response struct {
+		qr    *sqltypes.Result
+		err   error
+		hedge bool
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	responses := make(chan response, 2)
+	tried := make(map[string]bool)
+	opts := queryservice.WrapOpts{Session: session, Workload: options.GetWorkload()}
+	send := func(hedge bool) bool {
+		th := gw.getBalancerTablet(target, tablets, tried, opts)
+		if th == nil || th.Conn == nil {
+			return false
+		}
+		tried[topoproto.TabletAliasString(th.Tablet.Alias)] = true
+		gw.updateDefaultConnCollation(th.Tablet)
+		go func() {
+			startTime := time.Now()
+			qr, err := th.Conn.Execute(ctx, session, target, query, bindVars, 0, 0, options)
+			// The read which lost the race is canceled, it is not counted.
+			if err == nil || ctx.Err() == nil {
+				gw.updateStats(target, startTime, err)
+			}
+			if err == nil {
+				gw.hedger.record(target, time.Since(startTime))
+			}
+			responses <- response{qr: qr, err: err, hedge: hedge}
+		}()
+		return true
+	}
+	if !send(false) {
+		return execute()
+	}
+
+	labels := []string{target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType)}
+	hedge := func() bool {
+		if !send(true) {
+			return false
+		}
+		hedgedReads.Add(labels, 1)
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending, hedged := 1, false
+	var err error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if !hedged {
+				hedged = true
+				if hedge() {
+					pending++
+				}
+			}
+		case r := <-responses:
+			pending--
+			if r.err == nil {
+				if r.hedge {
+					hedgedReadsWon.Add(labels, 1)
+				}
+				return r.qr, nil
+			}
+			err = r.err
+			if !hedged && canHedgeAfterError(ctx, r.err) {
+				hedged = true
+				if hedge() {
+					pending++
+				}
+			}
+		}
+	}
+	return nil, NewShardError(err, target)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/discovery"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestHedger(t *testing.T) {
+	h := newHedger(90, 5*time.Millisecond)
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	other := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_RDONLY}
+
+	for i := 1; i < hedgeMinSamples; i++ {
+		h.record(target, time.Duration(i)*time.Millisecond)
+	}
+	_, ok := h.delay(target)
+	assert.False(t, ok, "not enough latencies yet")
+
+	h.record(target, hedgeMinSamples*time.Millisecond)
+	delay, ok := h.delay(target)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Millisecond, delay)
+	_, ok = h.delay(other)
+	assert.False(t, ok)
+
+	// The delay is never shorter than the minimum.
+	for range hedgeWindowSize {
+		h.record(target, time.Millisecond)
+	}
+	delay, ok = h.delay(target)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Millisecond, delay)
+}
+
+func TestTabletGatewayHedgedExecute(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+	tg.hedger = newHedger(50, time.Millisecond)
+
+	// Reads are not hedged until the latencies of the target are known.
+	slow := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	slow.ExecuteDelay = 10 * time.Millisecond
+	for range hedgeMinSamples {
+		_, err := tg.Execute(ctx, nil, target, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	delay, ok := tg.hedger.delay(target)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+
+	// Once the slow tablet takes much longer than usual, its reads are
+	// answered by the other tablet.
+	slow.ExecuteDelay = time.Minute
+	fast := hc.AddTestTablet("cell", "1.1.1.2", 1002, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	key := "ks.0.replica"
+	hedgedBefore, wonBefore := hedgedReads.Counts()[key], hedgedReadsWon.Counts()[key]
+	start := time.Now()
+	for range 20 {
+		_, err := tg.Execute(ctx, nil, target, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.EqualValues(t, 20, fast.ExecCount.Load())
+	assert.Positive(t, hedgedReadsWon.Counts()[key]-wonBefore)
+	assert.GreaterOrEqual(t, hedgedReads.Counts()[key]-hedgedBefore, hedgedReadsWon.Counts()[key]-wonBefore)
+
+	// Reads in transactions are never hedged.
+	_, err := tg.Execute(ctx, nil, target, "select 1", nil, 1, 0, nil)
+	assert.ErrorContains(t, err, "query service can only be used for non-transactional queries on replicas")
+}
+
+func TestTabletGatewayHedgedExecuteError(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+	target := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	hc := discovery.NewFakeHealthCheck(nil)
+	tg := NewTabletGateway(ctx, hc, &econtext.FakeTopoServer{}, "cell")
+	defer tg.Close(ctx)
+	tg.hedger = newHedger(50, time.Millisecond)
+	for range hedgeMinSamples {
+		tg.hedger.record(target, time.Minute)
+	}
+
+	// A failed read is sent to the other tablet right away, instead of
+	// waiting for the hedging delay.
+	failing := hc.AddTestTablet("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	failing.MustFailCodes[vtrpcpb.Code_RESOURCE_EXHAUSTED] = 20
+	hc.AddTestTablet("cell", "1.1.1.2", 1002, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil)
+	start := time.Now()
+	for range 20 {
+		_, err := tg.Execute(ctx, nil, target, "select 1", nil, 0, 0, nil)
+		require.NoError(t, err)
+	}
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Positive(t, failing.ExecCount.Load())
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/google/safehtml"
@@ -57,19 +58,21 @@ type LogStats struct {
 	MirrorSourceExecuteTime time.Duration
 	MirrorTargetExecuteTime time.Duration
 	MirrorTargetError       error
+	QueryAttributes         map[string]string
 }
 
 // NewLogStats constructs a new LogStats with supplied Method and ctx
 // field values, and the StartTime field set to the present time.
 func NewLogStats(ctx context.Context, methodName, sql, sessionUUID string, bindVars map[string]*querypb.BindVariable, config streamlog.QueryLogConfig) *LogStats {
 	return &LogStats{
-		Ctx:           ctx,
-		Method:        methodName,
-		SQL:           sql,
-		SessionUUID:   sessionUUID,
-		BindVariables: bindVars,
-		StartTime:     time.Now(),
-		Config:        config,
+		Ctx:             ctx,
+		Method:          methodName,
+		SQL:             sql,
+		SessionUUID:     sessionUUID,
+		BindVariables:   bindVars,
+		StartTime:       time.Now(),
+		Config:          config,
+		QueryAttributes: callinfo.QueryAttributesFromContext(ctx),
 	}
 }
 
@@ -113,6 +116,17 @@ func (stats *LogStats) ErrorStr() string {
 	return ""
 }
 
+// QueryAttributesStrings returns the query attributes as sorted key=value
+// strings.
+func (stats *LogStats) QueryAttributesStrings() []string {
+	attributes := make([]string, 0, len(stats.QueryAttributes))
+	for key, value := range stats.QueryAttributes {
+		attributes = append(attributes, key+"="+value)
+	}
+	slices.Sort(attributes)
+	return attributes
+}
+
 // RemoteAddrUsername returns some parts of CallInfo if set
 func (stats *LogStats) RemoteAddrUsername() (string, string) {
 	ci, ok := callinfo.FromContext(stats.Ctx)
@@ -199,6 +213,8 @@ func (stats *LogStats) Logf(w io.Writer, params url.Values) error {
 	log.String(stats.MirrorTargetErrorStr())
 	log.Key("EmitReason")
 	log.String(emitReason)
+	log.Key("QueryAttributes")
+	log.Strings(stats.QueryAttributesStrings())
 
 	return log.Flush(w)
 }
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	lookupCacheInvalidations = stats.NewCountersWithSingleLabel("VindexLookupCacheInvalidations", "Ids of the lookup vindexes invalidated in their lookup cache by the changes of their lookup table", "Vindex")
+
+	// lookupCacheRetryDelay is how long to wait before restarting the VStream
+	// which invalidates the lookup caches when it fails.
+	lookupCacheRetryDelay = 5 * time.Second
+)
+
+// lookupCacheInvalidator keeps the lookup caches of the consistent lookup vindexes
+// up to date with the changes of their lookup tables made by other vtgates,
+// which are received through a VStream on the keyspaces of the lookup tables.
+type lookupCacheInvalidator struct {
+	mu sync.Mutex
+	// caches are the caches of the vindexes of each lookup table, qualified by
+	// its keyspace. They are replaced on every vschema update, as the vindexes
+	// and their caches are then created anew.
+	caches map[string][]lookupTableCache
+	// fields are the fields of each lookup table, from its last field event.
+	fields   map[string][]*querypb.Field
+	streamer resultCacheStreamer
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// lookupTableCache is the cache of a vindex, and the column of the ids of its
+// lookup table.
+type lookupTableCache struct {
+	vindex string
+	column string
+	cache  *vindexes.LookupCache
+}
+
+func newLookupCacheInvalidator() *lookupCacheInvalidator {
+	return &lookupCacheInvalidator{
+		caches: make(map[string][]lookupTableCache),
+		fields: make(map[string][]*querypb.Field),
+	}
+}
+
+// Open starts invalidating the lookup caches with the changes streamed by
+// the streamer.
+func (lci *lookupCacheInvalidator) Open(streamer resultCacheStreamer) {
+	lci.mu.Lock()
+	defer lci.mu.Unlock()
+	lci.streamer = streamer
+	lci.restartStreamLocked()
+}
+
+// Close stops the invalidation stream and waits for it to finish.
+func (lci *lookupCacheInvalidator) Close() {
+	lci.mu.Lock()
+	lci.streamer = nil
+	if lci.cancel != nil {
+		lci.cancel()
+		lci.cancel = nil
+	}
+	lci.mu.Unlock()
+	lci.wg.Wait()
+}
+
+// vstreamLookupCacheStreamer streams the changes from the primary tablets,
+// with the table names qualified by their keyspace.
+func vstreamLookupCacheStreamer(vsm *vstreamManager) resultCacheStreamer {
+	return func(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+		return vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, filter, &vtgatepb.VStreamFlags{}, send)
+	}
+}
+
+// vschemaUpdated switches to the caches of the vindexes of the new vschema,
+// and restarts the invalidation stream if their lookup tables have changed.
+func (lci *lookupCacheInvalidator) vschemaUpdated(vschema *vindexes.VSchema) {
+	caches := make(map[string][]lookupTableCache)
+	for _, ks := range vschema.Keyspaces {
+		for name, vindex := range ks.Vindexes {
+			lv, ok := vindex.(vindexes.LookupStreamed)
+			if !ok || lv.LookupCache() == nil {
+				continue
+			}
+			keyspace, table, column := lv.LookupTable()
+			if keyspace == "" {
+				// The vschema of such a vindex fails to build.
+				continue
+			}
+			qualified := keyspace + "." + table
+			caches[qualified] = append(caches[qualified], lookupTableCache{vindex: name, column: column, cache: lv.LookupCache()})
+		}
+	}
+
+	lci.mu.Lock()
+	defer lci.mu.Unlock()
+	sameTables := slices.Equal(slices.Sorted(maps.Keys(caches)), slices.Sorted(maps.Keys(lci.caches)))
+	lci.caches = caches
+	if sameTables {
+		return
+	}
+	lci.restartStreamLocked()
+}
+
+// restartStreamLocked starts a new invalidation stream for the current
+// lookup tables, after stopping the previous one.
+func (lci *lookupCacheInvalidator) restartStreamLocked() {
+	if lci.cancel != nil {
+		lci.cancel()
+		lci.cancel = nil
+	}
+	if lci.streamer == nil || len(lci.caches) == 0 {
+		return
+	}
+
+	tables := make(map[string][]string)
+	for _, qualified := range slices.Sorted(maps.Keys(lci.caches)) {
+		keyspace, table, _ := strings.Cut(qualified, ".")
+		tables[keyspace] = append(tables[keyspace], table)
+	}
+	vgtid := &binlogdatapb.VGtid{}
+	filter := &binlogdatapb.Filter{}
+	names := make(map[string]bool)
+	for _, keyspace := range slices.Sorted(maps.Keys(tables)) {
+		vgtid.ShardGtids = append(vgtid.ShardGtids, &binlogdatapb.ShardGtid{Keyspace: keyspace, Gtid: "current"})
+		for _, table := range tables[keyspace] {
+			if !names[table] {
+				names[table] = true
+				filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: table})
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lci.cancel = cancel
+	lci.wg.Add(1)
+	go lci.stream(ctx, lci.streamer, vgtid, filter)
+}
+
+// stream runs the invalidation stream until the context is canceled,
+// restarting it when it fails.
+func (lci *lookupCacheInvalidator) stream(ctx context.Context, streamer resultCacheStreamer, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter) {
+	defer lci.wg.Done()
+	for {
+		// The changes made before the stream is positioned are not streamed,
+		// so the ids cached until its first event may be stale: they are
+		// cleared when it arrives.
+		positioned := false
+		err := streamer(ctx, vgtid, filter, func(events []*binlogdatapb.VEvent) error {
+			if !positioned {
+				positioned = true
+				lci.clearAll()
+			}
+			return lci.handleEvents(events)
+		})
+		// Changes may be missed until the stream is restarted.
+		lci.clearAll()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("Lookup vindex cache invalidation stream failed, restarting in %v: %v", lookupCacheRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lookupCacheRetryDelay):
+		}
+	}
+}
+
+// clearAll removes all the ids from the lookup caches.
+func (lci *lookupCacheInvalidator) clearAll() {
+	lci.mu.Lock()
+	defer lci.mu.Unlock()
+	for _, caches := range lci.caches {
+		for _, c := range caches {
+			c.cache.Clear()
+		}
+	}
+}
+
+func (lci *lookupCacheInvalidator) handleEvents(events []*binlogdatapb.VEvent) error {
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_FIELD:
+			lci.mu.Lock()
+			lci.fields[event.FieldEvent.TableName] = event.FieldEvent.Fields
+			lci.mu.Unlock()
+		case binlogdatapb.VEventType_ROW:
+			lci.invalidateRows(event.RowEvent)
+		case binlogdatapb.VEventType_DDL:
+			lci.clearAll()
+		}
+	}
+	return nil
+}
+
+// invalidateRows invalidates the ids of the changed rows of a lookup table,
+// both before and after the change. The ids of the columns which have a
+// collation other than binary are text, and clear the caches.
+func (lci *lookupCacheInvalidator) invalidateRows(rowEvent *binlogdatapb.RowEvent) {
+	lci.mu.Lock()
+	defer lci.mu.Unlock()
+	fields := lci.fields[rowEvent.TableName]
+	for _, c := range lci.caches[rowEvent.TableName] {
+		col := slices.IndexFunc(fields, func(field *querypb.Field) bool {
+			return strings.EqualFold(field.Name, c.column)
+		})
+		if col < 0 {
+			// The ids cannot be found without the fields of the table.
+			c.cache.Clear()
+			continue
+		}
+		for _, change := range rowEvent.RowChanges {
+			for _, row := range []*querypb.Row{change.Before, change.After} {
+				if row == nil {
+					continue
+				}
+				values := sqltypes.MakeRowTrusted(fields, row)
+				if col >= len(values) {
+					c.cache.Clear()
+					continue
+				}
+				c.cache.Invalidate(values[col])
+				lookupCacheInvalidations.Add(c.vindex, 1)
+			}
+		}
+	}
+}
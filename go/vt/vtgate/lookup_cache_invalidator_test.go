@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+)
+
+func TestLookupCacheInvalidator(t *testing.T) {
+	buildVSchema := func() *vindexes.VSchema {
+		return vindexes.BuildVSchema(&vschemapb.SrvVSchema{
+			Keyspaces: map[string]*vschemapb.Keyspace{
+				"user": {
+					Sharded: true,
+					Vindexes: map[string]*vschemapb.Vindex{
+						"hash": {Type: "hash"},
+						"email_lookup": {
+							Type: "consistent_lookup_unique",
+							Params: map[string]string{
+								"table":         "lookup.email_idx",
+								"from":          "email",
+								"to":            "keyspace_id",
+								"cache_lookups": "true",
+							},
+							Owner: "user",
+						},
+						"name_lookup": {
+							Type: "consistent_lookup",
+							Params: map[string]string{
+								"table": "lookup.name_idx",
+								"from":  "name",
+								"to":    "keyspace_id",
+							},
+							Owner: "user",
+						},
+					},
+					Tables: map[string]*vschemapb.Table{
+						"user": {
+							ColumnVindexes: []*vschemapb.ColumnVindex{
+								{Column: "id", Name: "hash"},
+								{Column: "email", Name: "email_lookup"},
+								{Column: "name", Name: "name_lookup"},
+							},
+						},
+					},
+				},
+				"lookup": {},
+			},
+		}, sqlparser.NewTestParser())
+	}
+	vschema := buildVSchema()
+	cache := vschema.Keyspaces["user"].Vindexes["email_lookup"].(vindexes.LookupCacheable).LookupCache()
+	require.NotNil(t, cache)
+	cached := func(email string) bool {
+		found := true
+		_, err := cache.Lookup([]sqltypes.Value{sqltypes.NewVarChar(email)}, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+			found = false
+			return []*sqltypes.Result{{Rows: [][]sqltypes.Value{{sqltypes.NewVarBinary("ksid")}}}}, nil
+		})
+		require.NoError(t, err)
+		return found
+	}
+
+	lci := newLookupCacheInvalidator()
+	streams := make(chan []*binlogdatapb.VEvent)
+	processed := make(chan *binlogdatapb.VGtid)
+	filters := make(chan *binlogdatapb.Filter, 1)
+	lci.Open(func(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+		filters <- filter
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case events := <-streams:
+				if err := send(events); err != nil {
+					return err
+				}
+				processed <- vgtid
+			}
+		}
+	})
+	defer lci.Close()
+	lci.vschemaUpdated(vschema)
+	// Only the lookup tables of the vindexes which cache their lookups are
+	// streamed.
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "email_idx"}}}, <-filters)
+
+	// The ids cached before the first event are cleared by it.
+	cached("a@example.com")
+	streams <- []*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_VGTID}}
+	vgtid := <-processed
+	utils.MustMatch(t, &binlogdatapb.VGtid{ShardGtids: []*binlogdatapb.ShardGtid{{Keyspace: "lookup", Gtid: "current"}}}, vgtid)
+	assert.False(t, cached("a@example.com"))
+
+	cached("b@example.com")
+	cached("c@example.com")
+	assert.True(t, cached("a@example.com"))
+	assert.True(t, cached("b@example.com"))
+
+	// The ids of a column with the binary collation are invalidated one by
+	// one.
+	fields := sqltypes.MakeTestFields("email|keyspace_id", "varbinary|varbinary")
+	row := func(email string) *querypb.Row {
+		return sqltypes.RowToProto3([]sqltypes.Value{sqltypes.NewVarChar(email), sqltypes.NewVarBinary("ksid")})
+	}
+	streams <- []*binlogdatapb.VEvent{{
+		Type:       binlogdatapb.VEventType_FIELD,
+		FieldEvent: &binlogdatapb.FieldEvent{TableName: "lookup.email_idx", Fields: fields},
+	}, {
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{TableName: "lookup.email_idx", RowChanges: []*binlogdatapb.RowChange{{
+			Before: row("a@example.com"),
+			After:  row("b@example.com"),
+		}}},
+	}}
+	<-processed
+	assert.False(t, cached("a@example.com"))
+	assert.False(t, cached("b@example.com"))
+	assert.True(t, cached("c@example.com"))
+
+	streams <- []*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_DDL}}
+	<-processed
+	assert.False(t, cached("c@example.com"))
+
+	// A new vschema with the same lookup tables keeps the stream, and
+	// invalidates the caches of its vindexes.
+	vschema = buildVSchema()
+	lci.vschemaUpdated(vschema)
+	cache = vschema.Keyspaces["user"].Vindexes["email_lookup"].(vindexes.LookupCacheable).LookupCache()
+	cached("d@example.com")
+	streams <- []*binlogdatapb.VEvent{{
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{TableName: "lookup.email_idx", RowChanges: []*binlogdatapb.RowChange{{
+			Before: row("d@example.com"),
+		}}},
+	}}
+	<-processed
+	assert.False(t, cached("d@example.com"))
+
+	// With another collation, the ids which differ only in case may match the
+	// same rows, so the changes clear the caches.
+	cached("E@example.com")
+	cached("f@example.com")
+	streams <- []*binlogdatapb.VEvent{{
+		Type:       binlogdatapb.VEventType_FIELD,
+		FieldEvent: &binlogdatapb.FieldEvent{TableName: "lookup.email_idx", Fields: sqltypes.MakeTestFields("email|keyspace_id", "varchar|varbinary")},
+	}, {
+		Type: binlogdatapb.VEventType_ROW,
+		RowEvent: &binlogdatapb.RowEvent{TableName: "lookup.email_idx", RowChanges: []*binlogdatapb.RowChange{{
+			Before: row("e@example.com"),
+		}}},
+	}}
+	<-processed
+	assert.False(t, cached("E@example.com"))
+	assert.False(t, cached("f@example.com"))
+	assert.Empty(t, filters)
+}
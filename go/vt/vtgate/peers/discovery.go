@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package peers discovers the other vtgates of the cluster, so that the
+// vtgate tier can coordinate the rate of the queries allowed by the global
+// rate limit rules, which is otherwise enforced by every vtgate on its own.
+// Only the rate limits are coordinated: the buffering of the queries during
+// failovers and the invalidation of the plan cache remain local to every
+// vtgate.
+package peers
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/topo"
+)
+
+// Discovery lists the HTTP addresses, as host:port, of the vtgates of the
+// cluster. The addresses may include the vtgate itself, and vtgates which
+// are down: the Tracker probes them to find the live ones.
+type Discovery interface {
+	Addresses(ctx context.Context) ([]string, error)
+}
+
+// registrar is implemented by the discoveries in which a vtgate has to record
+// its own address to be discovered.
+type registrar interface {
+	register(ctx context.Context) error
+	unregister(ctx context.Context) error
+}
+
+// StaticDiscovery is a fixed list of addresses.
+type StaticDiscovery []string
+
+// Addresses is part of the Discovery interface.
+func (d StaticDiscovery) Addresses(context.Context) ([]string, error) {
+	return d, nil
+}
+
+// DNSDiscovery resolves the addresses with the SRV records of a name, like
+// _vtgate._tcp.example.com.
+type DNSDiscovery struct {
+	Name string
+
+	lookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// NewDNSDiscovery returns a DNSDiscovery using the default resolver.
+func NewDNSDiscovery(name string) *DNSDiscovery {
+	return &DNSDiscovery{
+		Name:      name,
+		lookupSRV: net.DefaultResolver.LookupSRV,
+	}
+}
+
+// Addresses is part of the Discovery interface.
+func (d *DNSDiscovery) Addresses(ctx context.Context) ([]string, error) {
+	_, records, err := d.lookupSRV(ctx, "", "", d.Name)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, 0, len(records))
+	for _, record := range records {
+		addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+	}
+	return addrs, nil
+}
+
+// TopoDiscovery lists the addresses registered in the global topo. The vtgate
+// registers its own address while the Tracker runs.
+type TopoDiscovery struct {
+	ts   *topo.Server
+	addr string
+}
+
+// NewTopoDiscovery returns a TopoDiscovery registering the given address.
+func NewTopoDiscovery(ts *topo.Server, addr string) *TopoDiscovery {
+	return &TopoDiscovery{ts: ts, addr: addr}
+}
+
+// Addresses is part of the Discovery interface.
+func (d *TopoDiscovery) Addresses(ctx context.Context) ([]string, error) {
+	return d.ts.GetVTGateAddresses(ctx)
+}
+
+func (d *TopoDiscovery) register(ctx context.Context) error {
+	return d.ts.RegisterVTGate(ctx, d.addr)
+}
+
+func (d *TopoDiscovery) unregister(ctx context.Context) error {
+	return d.ts.UnregisterVTGate(ctx, d.addr)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+)
+
+func TestDNSDiscovery(t *testing.T) {
+	d := NewDNSDiscovery("_vtgate._tcp.example.com")
+	d.lookupSRV = func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		assert.Empty(t, service)
+		assert.Empty(t, proto)
+		assert.Equal(t, "_vtgate._tcp.example.com", name)
+		return name, []*net.SRV{
+			{Target: "vtgate1.example.com.", Port: 15001},
+			{Target: "vtgate2.example.com.", Port: 15002},
+		}, nil
+	}
+	addrs, err := d.Addresses(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vtgate1.example.com:15001", "vtgate2.example.com:15002"}, addrs)
+}
+
+func TestTopoDiscovery(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "zone1")
+	defer ts.Close()
+
+	require.NoError(t, ts.RegisterVTGate(ctx, "vtgate2:15001"))
+	d := NewTopoDiscovery(ts, "vtgate1:15001")
+	require.NoError(t, d.register(ctx))
+	addrs, err := d.Addresses(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"vtgate1:15001", "vtgate2:15001"}, addrs)
+
+	require.NoError(t, d.unregister(ctx))
+	addrs, err = d.Addresses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vtgate2:15001"}, addrs)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/netutil"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+)
+
+var (
+	discoveryMode    string
+	staticAddresses  []string
+	srvName          string
+	advertiseAddress string
+	refreshInterval  = 10 * time.Second
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&discoveryMode, "peer-discovery", discoveryMode, "How vtgate discovers the other vtgates, to share the global rate limits with them: static (--peer-discovery-addresses), dns (--peer-discovery-srv-name) or topo (the vtgates register in the global topo). All the vtgates must enable it. Only the global rate limits are shared: the query buffering and the plan cache stay per vtgate. Disabled when empty.")
+	fs.StringSliceVar(&staticAddresses, "peer-discovery-addresses", staticAddresses, "Comma-separated list of the HTTP addresses (host:port) of the vtgates, with --peer-discovery=static.")
+	fs.StringVar(&srvName, "peer-discovery-srv-name", srvName, "DNS name whose SRV records are the HTTP addresses of the vtgates, with --peer-discovery=dns.")
+	fs.StringVar(&advertiseAddress, "peer-discovery-advertise-address", advertiseAddress, "HTTP address (host:port) of this vtgate registered in the global topo with --peer-discovery=topo. Defaults to the hostname and the HTTP port.")
+	fs.DurationVar(&refreshInterval, "peer-discovery-interval", refreshInterval, "How often the vtgates are discovered and probed.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerFlags)
+	servenv.OnParseFor("vtcombo", registerFlags)
+}
+
+// NewTrackerFromFlags returns a Tracker for the discovery set with the flags,
+// or nil when the peer discovery is disabled.
+func NewTrackerFromFlags(ts *topo.Server, cell string) (*Tracker, error) {
+	if discoveryMode == "" {
+		return nil, nil
+	}
+	if refreshInterval <= 0 {
+		return nil, fmt.Errorf("--peer-discovery-interval must be positive (specified value: %v)", refreshInterval)
+	}
+	var discovery Discovery
+	switch discoveryMode {
+	case "static":
+		if len(staticAddresses) == 0 {
+			return nil, errors.New("--peer-discovery=static requires --peer-discovery-addresses")
+		}
+		discovery = StaticDiscovery(staticAddresses)
+	case "dns":
+		if srvName == "" {
+			return nil, errors.New("--peer-discovery=dns requires --peer-discovery-srv-name")
+		}
+		discovery = NewDNSDiscovery(srvName)
+	case "topo":
+		addr := advertiseAddress
+		if addr == "" {
+			addr = netutil.JoinHostPort(netutil.FullyQualifiedHostnameOrPanic(), int32(servenv.Port()))
+		}
+		discovery = NewTopoDiscovery(ts, addr)
+	default:
+		return nil, fmt.Errorf("invalid value for --peer-discovery: %q, expected static, dns or topo", discoveryMode)
+	}
+	return NewTracker(discovery, cell, refreshInterval), nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+)
+
+// StatusPath is the HTTP path on which a vtgate reports its Status to the
+// vtgates probing it.
+const StatusPath = "/debug/peer"
+
+var (
+	livePeers      = stats.NewGauge("VtgatePeers", "Number of live vtgates discovered, excluding this one")
+	discoveryErrs  = stats.NewCounter("VtgatePeerDiscoveryErrors", "Number of failures to discover the vtgates")
+	peerProbeFails = stats.NewCounter("VtgatePeerProbeErrors", "Number of failures to probe a vtgate")
+)
+
+// Status is what a vtgate reports to the vtgates probing it.
+type Status struct {
+	// ID identifies the vtgate process, so that a vtgate recognizes itself
+	// among the discovered addresses.
+	ID   string `json:"id"`
+	Cell string `json:"cell"`
+	// Peers are the addresses of the live vtgates known by the vtgate.
+	// The vtgates probing it probe them too, so that a vtgate also finds
+	// the vtgates discovered by its peers.
+	Peers []string `json:"peers"`
+}
+
+// Tracker periodically discovers the vtgates and probes them, to know which
+// ones are live. It notifies its listeners when their number changes.
+type Tracker struct {
+	discovery Discovery
+	id, cell  string
+	interval  time.Duration
+	client    *http.Client
+
+	mu sync.Mutex
+	// live are the addresses of the live vtgates, other than this one.
+	live []string
+	// learned are the addresses reported by the live vtgates.
+	learned   []string
+	listeners []func(vtgates int)
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewTracker returns a Tracker for the vtgates found by the discovery,
+// which are probed at the given interval.
+func NewTracker(discovery Discovery, cell string, interval time.Duration) *Tracker {
+	return &Tracker{
+		discovery: discovery,
+		id:        rand.Text(),
+		cell:      cell,
+		interval:  interval,
+		client:    &http.Client{},
+	}
+}
+
+// OnChange registers a function called with the number of live vtgates,
+// this one included, when it changes. It must be called before Start.
+func (t *Tracker) OnChange(f func(vtgates int)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.listeners = append(t.listeners, f)
+}
+
+// Start registers the vtgate if the discovery requires it, and starts
+// discovering and probing the vtgates.
+func (t *Tracker) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.done = make(chan struct{})
+
+	if r, ok := t.discovery.(registrar); ok {
+		if err := r.register(ctx); err != nil {
+			log.Errorf("Cannot register the vtgate for the peer discovery: %v", err)
+		}
+	}
+	go t.run(ctx, t.done)
+}
+
+// Stop stops probing the vtgates, and unregisters the vtgate if the
+// discovery requires it.
+func (t *Tracker) Stop() {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.cancel, t.done = nil, nil
+	t.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	if r, ok := t.discovery.(registrar); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+		defer cancel()
+		if err := r.unregister(ctx); err != nil {
+			log.Errorf("Cannot unregister the vtgate from the peer discovery: %v", err)
+		}
+	}
+}
+
+func (t *Tracker) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		t.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Peers returns the addresses of the live vtgates, other than this one.
+func (t *Tracker) Peers() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.live)
+}
+
+// ServeHTTP reports the Status of the vtgate.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status := Status{ID: t.id, Cell: t.cell, Peers: t.Peers()}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// refresh discovers the vtgates, adds the ones reported by the live vtgates,
+// and probes them all.
+func (t *Tracker) refresh(runCtx context.Context) {
+	addrs, err := t.discovery.Addresses(runCtx)
+	if err != nil {
+		discoveryErrs.Add(1)
+		log.Warningf("Cannot discover the vtgates: %v", err)
+	}
+	t.mu.Lock()
+	addrs = append(addrs, t.learned...)
+	t.mu.Unlock()
+	slices.Sort(addrs)
+	addrs = slices.Compact(addrs)
+
+	ctx, cancel := context.WithTimeout(runCtx, t.interval)
+	defer cancel()
+	statuses := make([]*Status, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := t.probe(ctx, addr)
+			if err != nil {
+				peerProbeFails.Add(1)
+				log.V(2).Infof("Cannot probe the vtgate %s: %v", addr, err)
+				return
+			}
+			statuses[i] = status
+		}()
+	}
+	wg.Wait()
+	if runCtx.Err() != nil {
+		// The tracker is stopping: the vtgates were not really probed.
+		return
+	}
+
+	var live, learned []string
+	for i, status := range statuses {
+		if status == nil || status.ID == t.id {
+			continue
+		}
+		live = append(live, addrs[i])
+		learned = append(learned, status.Peers...)
+	}
+
+	t.mu.Lock()
+	changed := len(live) != len(t.live)
+	t.live, t.learned = live, learned
+	listeners := slices.Clone(t.listeners)
+	t.mu.Unlock()
+
+	livePeers.Set(int64(len(live)))
+	if changed {
+		log.Infof("Found %d live vtgates besides this one: %v", len(live), live)
+		for _, f := range listeners {
+			f(len(live) + 1)
+		}
+	}
+}
+
+func (t *Tracker) probe(ctx context.Context, addr string) (*Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+StatusPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	status := &Status{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
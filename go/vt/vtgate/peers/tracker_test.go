@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testVTGate is a Tracker serving its status on a test HTTP server.
+type testVTGate struct {
+	*Tracker
+	addr      string
+	discovery *StaticDiscovery
+	vtgates   []int
+}
+
+func newTestVTGate(t *testing.T) *testVTGate {
+	vtg := &testVTGate{discovery: &StaticDiscovery{}}
+	vtg.Tracker = NewTracker(discoveryFunc(func(ctx context.Context) ([]string, error) {
+		return vtg.discovery.Addresses(ctx)
+	}), "zone1", time.Second)
+	vtg.OnChange(func(vtgates int) {
+		vtg.vtgates = append(vtg.vtgates, vtgates)
+	})
+	mux := http.NewServeMux()
+	mux.Handle(StatusPath, vtg.Tracker)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	vtg.addr = strings.TrimPrefix(srv.URL, "http://")
+	return vtg
+}
+
+type discoveryFunc func(ctx context.Context) ([]string, error)
+
+func (f discoveryFunc) Addresses(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+func TestTracker(t *testing.T) {
+	ctx := t.Context()
+	vtg1, vtg2, vtg3 := newTestVTGate(t), newTestVTGate(t), newTestVTGate(t)
+
+	// vtg1 discovers itself and vtg2, and vtg2 discovers vtg3 and a vtgate
+	// which is down.
+	*vtg1.discovery = StaticDiscovery{vtg1.addr, vtg2.addr}
+	*vtg2.discovery = StaticDiscovery{vtg3.addr, "127.0.0.1:1"}
+
+	vtg1.refresh(ctx)
+	assert.Equal(t, []string{vtg2.addr}, vtg1.Peers())
+	assert.Equal(t, []int{2}, vtg1.vtgates)
+
+	// vtg1 learns about vtg3 from vtg2, and probes it in the next round.
+	vtg2.refresh(ctx)
+	assert.Equal(t, []string{vtg3.addr}, vtg2.Peers())
+	vtg1.refresh(ctx)
+	assert.Equal(t, []string{vtg2.addr}, vtg1.Peers())
+	vtg1.refresh(ctx)
+	assert.ElementsMatch(t, []string{vtg2.addr, vtg3.addr}, vtg1.Peers())
+	assert.Equal(t, []int{2, 3}, vtg1.vtgates)
+
+	// The listeners are only called when the number of vtgates changes.
+	vtg1.refresh(ctx)
+	assert.Equal(t, []int{2, 3}, vtg1.vtgates)
+
+	// vtg1 stops finding vtg3 when vtg2 does not report it anymore.
+	*vtg2.discovery = nil
+	vtg2.refresh(ctx)
+	assert.Empty(t, vtg2.Peers())
+	vtg1.refresh(ctx)
+	vtg1.refresh(ctx)
+	assert.Equal(t, []string{vtg2.addr}, vtg1.Peers())
+	assert.Equal(t, []int{2, 3, 2}, vtg1.vtgates)
+}
+
+func TestTrackerStatus(t *testing.T) {
+	vtg := newTestVTGate(t)
+	resp, err := http.Get("http://" + vtg.addr + StatusPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status Status
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, vtg.id, status.ID)
+	assert.Equal(t, "zone1", status.Cell)
+	assert.Empty(t, status.Peers)
+}
+
+func TestTrackerStartStop(t *testing.T) {
+	vtg1, vtg2 := newTestVTGate(t), newTestVTGate(t)
+	*vtg1.discovery = StaticDiscovery{vtg2.addr}
+
+	vtg1.Start()
+	assert.Eventually(t, func() bool {
+		return len(vtg1.Peers()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	vtg1.Stop()
+	vtg1.Stop()
+}
@@ -0,0 +1,505 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// protocolVersion is the version 3.0 of the protocol, the only one
+	// supported.
+	protocolVersion = 196608
+
+	// The special codes sent instead of a protocol version in the startup
+	// message.
+	cancelRequestCode = 80877102
+	sslRequestCode    = 80877103
+	gssEncRequestCode = 80877104
+
+	// maxStartupMessageSize is the maximum size of a startup message, and
+	// of the other messages sent by the client before it is authenticated,
+	// as enforced by PostgreSQL.
+	maxStartupMessageSize = 10000
+
+	// maxMessageSize is the maximum size of a message sent by the client
+	// once it is authenticated.
+	maxMessageSize = 1 << 30
+)
+
+// The types of the messages sent by the frontend.
+const (
+	msgQuery    = 'Q'
+	msgTerm     = 'X'
+	msgPassword = 'p'
+	msgParse    = 'P'
+	msgBind     = 'B'
+	msgDescribe = 'D'
+	msgExecute  = 'E'
+	msgClose    = 'C'
+	msgFlush    = 'H'
+	msgSync     = 'S'
+)
+
+// The types of the messages sent by the backend.
+const (
+	msgAuthentication  = 'R'
+	msgParameterStatus = 'S'
+	msgBackendKeyData  = 'K'
+	msgReadyForQuery   = 'Z'
+	msgRowDescription  = 'T'
+	msgDataRow         = 'D'
+	msgCommandComplete = 'C'
+	msgEmptyQuery      = 'I'
+	msgErrorResponse   = 'E'
+)
+
+const (
+	authOK                = 0
+	authCleartextPassword = 3
+)
+
+// errCancelRequest is returned by startup when the client sent a cancel
+// request, which is not supported.
+var errCancelRequest = errors.New("cancel requests are not supported")
+
+// Conn is a PostgreSQL protocol connection from a client.
+type Conn struct {
+	// ConnectionID is the id of the connection, unique for the listener.
+	ConnectionID uint32
+
+	// User is the user name sent by the client in the startup message.
+	User string
+
+	// Database is the database name sent by the client in the startup
+	// message, if any.
+	Database string
+
+	// Params are the other run-time parameters sent by the client in the
+	// startup message, such as application_name.
+	Params map[string]string
+
+	// InTransaction is set by the Handler to report whether the connection
+	// is in a transaction after a query. It is sent to the client after
+	// each query, and keeps the connection open when the listener is shut
+	// down.
+	InTransaction bool
+
+	// ClientData is a place where the Handler can store per-connection data.
+	ClientData any
+
+	conn     net.Conn
+	listener *Listener
+	reader   *bufio.Reader
+	writer   *bufio.Writer
+
+	// msg is the buffer in which the message being written is built.
+	msg []byte
+
+	// discarding is set after an error in an extended query message, until
+	// the next Sync message.
+	discarding bool
+
+	// tlsEnabled is set once the connection switched to TLS.
+	tlsEnabled bool
+}
+
+func newConn(conn net.Conn, listener *Listener, connectionID uint32) *Conn {
+	return &Conn{
+		ConnectionID: connectionID,
+		conn:         conn,
+		listener:     listener,
+		reader:       bufio.NewReader(conn),
+		writer:       bufio.NewWriter(conn),
+	}
+}
+
+// RemoteAddr returns the underlying socket RemoteAddr().
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close closes the connection.
+func (c *Conn) Close() {
+	c.conn.Close()
+}
+
+// TLSEnabled returns true if the connection switched to TLS.
+func (c *Conn) TLSEnabled() bool {
+	return c.tlsEnabled
+}
+
+// startup reads the startup message of the client, authenticates it, and
+// tells it the connection is ready for queries.
+func (c *Conn) startup() error {
+	var params map[string]string
+	for params == nil {
+		payload, err := c.readStartupMessage()
+		if err != nil {
+			return err
+		}
+		if len(payload) < 4 {
+			return fmt.Errorf("invalid startup message of %d bytes", len(payload))
+		}
+		switch code := binary.BigEndian.Uint32(payload); code {
+		case sslRequestCode:
+			if err := c.startTLS(); err != nil {
+				return err
+			}
+		case gssEncRequestCode:
+			// Tell the client to go on without encryption.
+			if _, err := c.conn.Write([]byte{'N'}); err != nil {
+				return err
+			}
+		case cancelRequestCode:
+			return errCancelRequest
+		case protocolVersion:
+			params, err = parseStartupParams(payload[4:])
+			if err != nil {
+				return err
+			}
+		default:
+			err := NewError(CodeFeatureNotSupported, "unsupported frontend protocol %d.%d: server supports 3.0", code>>16, code&0xffff)
+			return c.writeFatal(err)
+		}
+	}
+
+	c.User = params["user"]
+	delete(params, "user")
+	c.Database = params["database"]
+	delete(params, "database")
+	c.Params = params
+	if c.User == "" {
+		return c.writeFatal(NewError(CodeInvalidAuthorization, "no PostgreSQL user name specified in startup packet"))
+	}
+	if c.listener.RequireSecureTransport && !c.tlsEnabled {
+		return c.writeFatal(NewError(CodeInvalidAuthorization, "SSL connection is required"))
+	}
+
+	if err := c.listener.handler.Authenticate(c, c.User, c.readPassword); err != nil {
+		var pgErr *Error
+		if !errors.As(err, &pgErr) {
+			pgErr = NewError(CodeInvalidPassword, "password authentication failed for user \"%s\"", c.User)
+		}
+		_ = c.writeFatal(pgErr)
+		return err
+	}
+
+	c.startMessage(msgAuthentication)
+	c.writeInt32(authOK)
+	c.finishMessage()
+	for _, param := range [][2]string{
+		{"server_version", c.listener.serverVersion},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO, MDY"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+	} {
+		c.startMessage(msgParameterStatus)
+		c.writeString(param[0])
+		c.writeString(param[1])
+		c.finishMessage()
+	}
+	c.startMessage(msgBackendKeyData)
+	c.writeInt32(c.ConnectionID)
+	c.writeInt32(0)
+	c.finishMessage()
+	return c.writeReadyForQuery()
+}
+
+// startTLS answers an SSLRequest, and switches the connection to TLS if the
+// listener has a TLS config. Otherwise, the client is told to go on without
+// encryption.
+func (c *Conn) startTLS() error {
+	config := c.listener.TLSConfig.Load()
+	if config == nil || c.tlsEnabled {
+		_, err := c.conn.Write([]byte{'N'})
+		return err
+	}
+	// The messages sent before the TLS handshake could be injected by a
+	// man in the middle, so none is expected.
+	if c.reader.Buffered() > 0 {
+		return c.writeFatal(NewError(CodeProtocolViolation, "received unencrypted data after SSL request"))
+	}
+	if _, err := c.conn.Write([]byte{'S'}); err != nil {
+		return err
+	}
+	conn := tls.Server(c.conn, config)
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+	c.conn = conn
+	c.reader.Reset(conn)
+	c.writer.Reset(conn)
+	c.tlsEnabled = true
+	return nil
+}
+
+// readPassword asks the client for its password in clear text, which is only
+// allowed over TLS unless the listener allows it without.
+func (c *Conn) readPassword() (string, error) {
+	if !c.tlsEnabled && !c.listener.AllowClearTextWithoutTLS.Load() {
+		return "", NewError(CodeInvalidAuthorization, "password authentication requires an SSL connection")
+	}
+	c.startMessage(msgAuthentication)
+	c.writeInt32(authCleartextPassword)
+	c.finishMessage()
+	if err := c.writer.Flush(); err != nil {
+		return "", err
+	}
+	typ, payload, err := c.readMessage(maxStartupMessageSize)
+	if err != nil {
+		return "", err
+	}
+	if typ != msgPassword {
+		return "", fmt.Errorf("expected a password message, got a message of type %q", typ)
+	}
+	return strings.TrimSuffix(string(payload), "\x00"), nil
+}
+
+// serve reads the messages of the client and runs its queries, until the
+// client terminates the connection.
+func (c *Conn) serve() error {
+	for {
+		typ, payload, err := c.readMessage(maxMessageSize)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		switch typ {
+		case msgQuery:
+			if c.listener.shutdown.Load() && !c.InTransaction {
+				return c.writeFatal(NewError(CodeAdminShutdown, "terminating connection due to administrator command"))
+			}
+			if err := c.handleQuery(strings.TrimSuffix(string(payload), "\x00")); err != nil {
+				return err
+			}
+		case msgTerm:
+			return nil
+		case msgParse, msgBind, msgDescribe, msgExecute, msgClose:
+			if !c.discarding {
+				c.discarding = true
+				c.writeError(NewError(CodeFeatureNotSupported, "the extended query protocol is not supported"))
+			}
+		case msgFlush:
+			if err := c.writer.Flush(); err != nil {
+				return err
+			}
+		case msgSync:
+			c.discarding = false
+			if err := c.writeReadyForQuery(); err != nil {
+				return err
+			}
+		default:
+			return c.writeFatal(NewError(CodeProtocolViolation, "invalid frontend message type %d", typ))
+		}
+	}
+}
+
+// handleQuery runs a query of the simple query protocol, and sends its
+// results to the client.
+func (c *Conn) handleQuery(query string) error {
+	if strings.TrimSpace(strings.TrimRight(strings.TrimSpace(query), ";")) == "" {
+		c.startMessage(msgEmptyQuery)
+		c.finishMessage()
+		return c.writeReadyForQuery()
+	}
+
+	var writeErr error
+	err := c.listener.handler.ComQuery(c, query, func(result *sqltypes.Result, tag string) error {
+		if len(result.Fields) > 0 {
+			c.writeRowDescription(result.Fields)
+			for _, row := range result.Rows {
+				c.writeDataRow(result.Fields, row)
+			}
+		}
+		c.startMessage(msgCommandComplete)
+		c.writeString(tag)
+		c.finishMessage()
+		writeErr = c.writer.Flush()
+		return writeErr
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		c.writeError(err)
+	}
+	return c.writeReadyForQuery()
+}
+
+func (c *Conn) writeRowDescription(fields []*querypb.Field) {
+	c.startMessage(msgRowDescription)
+	c.writeInt16(uint16(len(fields)))
+	for _, field := range fields {
+		oid, size := typeOID(field.Type)
+		c.writeString(field.Name)
+		c.writeInt32(0) // table OID
+		c.writeInt16(0) // column attribute number
+		c.writeInt32(oid)
+		c.writeInt16(uint16(size))
+		c.writeInt32(0xffffffff) // type modifier
+		c.writeInt16(0)          // text format
+	}
+	c.finishMessage()
+}
+
+func (c *Conn) writeDataRow(fields []*querypb.Field, row []sqltypes.Value) {
+	c.startMessage(msgDataRow)
+	c.writeInt16(uint16(len(row)))
+	for i, value := range row {
+		if value.IsNull() {
+			c.writeInt32(0xffffffff)
+			continue
+		}
+		data := encodeValue(fields[i].Type, value)
+		c.writeInt32(uint32(len(data)))
+		c.msg = append(c.msg, data...)
+	}
+	c.finishMessage()
+}
+
+// writeError sends an error to the client.
+func (c *Conn) writeError(err error) {
+	c.writeErrorResponse("ERROR", toError(err))
+}
+
+// writeFatal sends an error to the client before closing the connection,
+// and returns it.
+func (c *Conn) writeFatal(err *Error) error {
+	c.writeErrorResponse("FATAL", err)
+	_ = c.writer.Flush()
+	return err
+}
+
+func (c *Conn) writeErrorResponse(severity string, err *Error) {
+	c.startMessage(msgErrorResponse)
+	for _, field := range []struct {
+		typ   byte
+		value string
+	}{
+		{'S', severity},
+		{'V', severity},
+		{'C', err.Code},
+		{'M', err.Message},
+	} {
+		c.msg = append(c.msg, field.typ)
+		c.writeString(field.value)
+	}
+	c.msg = append(c.msg, 0)
+	c.finishMessage()
+}
+
+func (c *Conn) writeReadyForQuery() error {
+	status := byte('I')
+	if c.InTransaction {
+		status = 'T'
+	}
+	c.startMessage(msgReadyForQuery)
+	c.msg = append(c.msg, status)
+	c.finishMessage()
+	return c.writer.Flush()
+}
+
+// readStartupMessage reads a startup message, which has no type.
+func (c *Conn) readStartupMessage() ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length < 4 || length > maxStartupMessageSize {
+		return nil, fmt.Errorf("invalid startup message length %d", length)
+	}
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// readMessage reads a message with its type, of at most maxSize bytes.
+func (c *Conn) readMessage(maxSize uint32) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length < 4 || length > maxSize {
+		return 0, nil, fmt.Errorf("invalid message length %d", length)
+	}
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+// parseStartupParams parses the name and value pairs of a startup message.
+func parseStartupParams(data []byte) (map[string]string, error) {
+	params := make(map[string]string)
+	for len(data) > 0 && data[0] != 0 {
+		parts := bytes.SplitN(data, []byte{0}, 3)
+		if len(parts) < 3 {
+			return nil, errors.New("invalid startup message parameters")
+		}
+		params[string(parts[0])] = string(parts[1])
+		data = parts[2]
+	}
+	return params, nil
+}
+
+// startMessage starts building a message of the given type in c.msg.
+func (c *Conn) startMessage(typ byte) {
+	c.msg = append(c.msg[:0], typ, 0, 0, 0, 0)
+}
+
+// finishMessage sets the length of the message built in c.msg, and writes
+// it to the buffered writer.
+func (c *Conn) finishMessage() {
+	binary.BigEndian.PutUint32(c.msg[1:5], uint32(len(c.msg)-1))
+	_, _ = c.writer.Write(c.msg)
+}
+
+func (c *Conn) writeInt16(v uint16) {
+	c.msg = binary.BigEndian.AppendUint16(c.msg, v)
+}
+
+func (c *Conn) writeInt32(v uint32) {
+	c.msg = binary.BigEndian.AppendUint32(c.msg, v)
+}
+
+func (c *Conn) writeString(s string) {
+	c.msg = append(c.msg, s...)
+	c.msg = append(c.msg, 0)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"errors"
+	"fmt"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// The PostgreSQL error codes (SQLSTATE) sent to the clients.
+const (
+	CodeSyntaxOrAccessRule   = "42000"
+	CodeInsufficientPriv     = "42501"
+	CodeUndefinedObject      = "42704"
+	CodeDuplicateObject      = "42710"
+	CodeFeatureNotSupported  = "0A000"
+	CodeInvalidAuthorization = "28000"
+	CodeInvalidPassword      = "28P01"
+	CodeProtocolViolation    = "08P01"
+	CodeSerializationFailure = "40001"
+	CodeQueryCanceled        = "57014"
+	CodeAdminShutdown        = "57P01"
+	CodeCannotConnectNow     = "57P03"
+	CodeInsufficientResource = "53000"
+	CodePrerequisiteState    = "55000"
+	CodeInternalError        = "XX000"
+)
+
+// Error is an error sent to the client with its PostgreSQL error code.
+type Error struct {
+	Code    string
+	Message string
+}
+
+// NewError returns a new Error with the given code.
+func NewError(code string, format string, args ...any) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// toError converts an error to an Error, mapping the Vitess error codes to
+// the closest PostgreSQL error code.
+func toError(err error) *Error {
+	var pgErr *Error
+	if errors.As(err, &pgErr) {
+		return pgErr
+	}
+	code := CodeInternalError
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_INVALID_ARGUMENT:
+		code = CodeSyntaxOrAccessRule
+	case vtrpcpb.Code_UNIMPLEMENTED:
+		code = CodeFeatureNotSupported
+	case vtrpcpb.Code_PERMISSION_DENIED:
+		code = CodeInsufficientPriv
+	case vtrpcpb.Code_UNAUTHENTICATED:
+		code = CodeInvalidAuthorization
+	case vtrpcpb.Code_NOT_FOUND:
+		code = CodeUndefinedObject
+	case vtrpcpb.Code_ALREADY_EXISTS:
+		code = CodeDuplicateObject
+	case vtrpcpb.Code_DEADLINE_EXCEEDED, vtrpcpb.Code_CANCELED:
+		code = CodeQueryCanceled
+	case vtrpcpb.Code_RESOURCE_EXHAUSTED:
+		code = CodeInsufficientResource
+	case vtrpcpb.Code_UNAVAILABLE:
+		code = CodeCannotConnectNow
+	case vtrpcpb.Code_ABORTED:
+		code = CodeSerializationFailure
+	case vtrpcpb.Code_FAILED_PRECONDITION:
+		code = CodePrerequisiteState
+	}
+	return &Error{Code: code, Message: err.Error()}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pgwire implements the server side of the PostgreSQL frontend/backend
+// protocol (version 3.0), so that clients which only speak PostgreSQL can send
+// queries to vtgate.
+//
+// This is experimental. Only the simple query protocol is supported: the
+// extended query protocol (prepared statements and portals), COPY, GSSAPI
+// encryption and cancel requests are not. Results are always sent in the
+// text format.
+package pgwire
+
+import (
+	"crypto/tls"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/tb"
+	"vitess.io/vitess/go/vt/log"
+)
+
+var (
+	connCount  = stats.NewGauge("PgServerConnCount", "Active PostgreSQL protocol server connections")
+	connAccept = stats.NewCounter("PgServerConnAccepted", "Connections accepted by the PostgreSQL protocol server")
+)
+
+// A Handler is an interface used by Listener to authenticate the clients and
+// execute their queries. The implementation of this interface may store data
+// in the ClientData field of the Conn.
+type Handler interface {
+	// Authenticate is called when a client connects, with the user name
+	// sent in the startup message. The password function asks the client
+	// for its password, and is only to be called if a password is needed
+	// to authenticate the user. The connection is closed if an error is
+	// returned.
+	Authenticate(c *Conn, user string, password func() (string, error)) error
+
+	// ComQuery is called when a client sends a query. It calls the callback
+	// once for each statement of the query with its result and its command
+	// tag, such as "SELECT 2" or "BEGIN". If an error is returned, it is
+	// sent to the client and the remaining statements are not run.
+	ComQuery(c *Conn, query string, callback func(result *sqltypes.Result, tag string) error) error
+
+	// ConnectionClosed is called when an authenticated connection is closed.
+	ConnectionClosed(c *Conn)
+}
+
+// Listener is the PostgreSQL protocol server. It accepts connections and
+// hands their queries to the Handler.
+type Listener struct {
+	listener net.Listener
+	handler  Handler
+
+	// serverVersion is reported to the clients in the server_version
+	// parameter.
+	serverVersion string
+
+	// connectionID is the id of the next connection.
+	connectionID atomic.Uint32
+
+	// shutdown indicates that Shutdown() was called.
+	shutdown atomic.Bool
+
+	// TLSConfig is the server TLS config. If set, the connections of the
+	// clients which send an SSLRequest are switched to TLS.
+	TLSConfig atomic.Pointer[tls.Config]
+
+	// RequireSecureTransport rejects the connections which were not
+	// switched to TLS.
+	RequireSecureTransport bool
+
+	// AllowClearTextWithoutTLS allows the clients to send their password in
+	// clear text over connections which were not switched to TLS.
+	AllowClearTextWithoutTLS atomic.Bool
+
+	// StartupTimeout is the time the clients have to authenticate once
+	// connected, or 0 for no limit.
+	StartupTimeout time.Duration
+}
+
+// NewListener creates a new Listener on the given address.
+func NewListener(protocol, address string, handler Handler, serverVersion string) (*Listener, error) {
+	listener, err := net.Listen(protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromListener(listener, handler, serverVersion), nil
+}
+
+// NewFromListener creates a new Listener from an existing net.Listener.
+func NewFromListener(listener net.Listener, handler Handler, serverVersion string) *Listener {
+	l := &Listener{
+		listener:      listener,
+		handler:       handler,
+		serverVersion: serverVersion,
+	}
+	l.connectionID.Store(1)
+	return l
+}
+
+// Addr returns the listener address.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Accept runs an accept loop until the listener is closed.
+func (l *Listener) Accept() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			// Close() was probably called.
+			return
+		}
+		connectionID := l.connectionID.Add(1) - 1
+		connCount.Add(1)
+		connAccept.Add(1)
+		go l.handle(conn, connectionID)
+	}
+}
+
+// handle is called in a go routine for each client connection.
+func (l *Listener) handle(conn net.Conn, connectionID uint32) {
+	c := newConn(conn, l, connectionID)
+
+	// Catch panics, and close the connection in any case.
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("pg_server caught panic:\n%v\n%s", x, tb.Stack(4))
+		}
+		c.Close()
+		connCount.Add(-1)
+	}()
+
+	if l.StartupTimeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(l.StartupTimeout))
+	}
+	if err := c.startup(); err != nil {
+		log.Infof("Cannot start PostgreSQL protocol connection %v from %s: %v", connectionID, c.RemoteAddr(), err)
+		return
+	}
+	if l.StartupTimeout > 0 {
+		_ = conn.SetDeadline(time.Time{})
+	}
+	defer l.handler.ConnectionClosed(c)
+
+	if err := c.serve(); err != nil {
+		log.Infof("PostgreSQL protocol connection %v from %s closed: %v", connectionID, c.RemoteAddr(), err)
+	}
+}
+
+// Close stops the listener, which prevents accepting any new connections.
+// Existing connections won't be closed.
+func (l *Listener) Close() {
+	l.listener.Close()
+}
+
+// Shutdown closes the listener, and makes the existing connections which
+// are not in a transaction terminate on their next query.
+func (l *Listener) Shutdown() {
+	if l.shutdown.CompareAndSwap(false, true) {
+		l.Close()
+	}
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/tlstest"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+type testHandler struct {
+	mu      sync.Mutex
+	queries []string
+	closed  []uint32
+}
+
+func (th *testHandler) Authenticate(c *Conn, user string, password func() (string, error)) error {
+	switch user {
+	case "bad":
+		return errors.New("unknown user")
+	case "pw":
+		pw, err := password()
+		if err != nil {
+			return err
+		}
+		if pw != "secret" {
+			return errors.New("wrong password")
+		}
+	}
+	return nil
+}
+
+func (th *testHandler) ComQuery(c *Conn, query string, callback func(result *sqltypes.Result, tag string) error) error {
+	th.mu.Lock()
+	th.queries = append(th.queries, query)
+	th.mu.Unlock()
+
+	switch query {
+	case "select":
+		result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name|data", "int64|varchar|varbinary"),
+			"1|abc|\x01\x02",
+			"2|null|null",
+		)
+		return callback(result, "SELECT 2")
+	case "begin":
+		c.InTransaction = true
+		return callback(&sqltypes.Result{}, "BEGIN")
+	case "commit":
+		c.InTransaction = false
+		return callback(&sqltypes.Result{}, "COMMIT")
+	default:
+		return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "unsupported query: %s", query)
+	}
+}
+
+func (th *testHandler) ConnectionClosed(c *Conn) {
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	th.closed = append(th.closed, c.ConnectionID)
+}
+
+// testClient speaks the frontend side of the protocol.
+type testClient struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func newTestListener(t *testing.T) (*Listener, *testHandler) {
+	th := &testHandler{}
+	l, err := NewListener("tcp", "127.0.0.1:", th, "16.0 (Vitess)")
+	require.NoError(t, err)
+	go l.Accept()
+	t.Cleanup(l.Close)
+	return l, th
+}
+
+func dial(t *testing.T, l *Listener) *testClient {
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &testClient{t: t, conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (tc *testClient) sendStartup(code uint32, params ...string) {
+	var payload []byte
+	payload = binary.BigEndian.AppendUint32(payload, code)
+	for _, param := range params {
+		payload = append(payload, param...)
+		payload = append(payload, 0)
+	}
+	if len(params) > 0 {
+		payload = append(payload, 0)
+	}
+	msg := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+4))
+	_, err := tc.conn.Write(append(msg, payload...))
+	require.NoError(tc.t, err)
+}
+
+func (tc *testClient) send(typ byte, payload string) {
+	msg := binary.BigEndian.AppendUint32([]byte{typ}, uint32(len(payload)+4))
+	_, err := tc.conn.Write(append(msg, payload...))
+	require.NoError(tc.t, err)
+}
+
+func (tc *testClient) read() (byte, []byte) {
+	var header [5]byte
+	_, err := io.ReadFull(tc.reader, header[:])
+	require.NoError(tc.t, err)
+	payload := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+	_, err = io.ReadFull(tc.reader, payload)
+	require.NoError(tc.t, err)
+	return header[0], payload
+}
+
+// readUntilReady reads the messages until a ReadyForQuery, and returns
+// their types and the transaction status.
+func (tc *testClient) readUntilReady() (string, byte) {
+	var types []byte
+	for {
+		typ, payload := tc.read()
+		if typ == msgReadyForQuery {
+			return string(types), payload[0]
+		}
+		types = append(types, typ)
+	}
+}
+
+// readError reads an ErrorResponse and returns its fields.
+func (tc *testClient) readError() map[byte]string {
+	typ, payload := tc.read()
+	require.EqualValues(tc.t, msgErrorResponse, typ)
+	fields := make(map[byte]string)
+	for len(payload) > 1 {
+		end := bytes.IndexByte(payload, 0)
+		fields[payload[0]] = string(payload[1:end])
+		payload = payload[end+1:]
+	}
+	return fields
+}
+
+// expectClosed checks that the server closes the connection.
+func (tc *testClient) expectClosed() {
+	require.NoError(tc.t, tc.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err := io.ReadAll(tc.reader)
+	var netErr net.Error
+	require.False(tc.t, errors.As(err, &netErr) && netErr.Timeout(), "the connection was not closed")
+}
+
+// startup connects the client as the given user, and reads the messages up
+// to the first ReadyForQuery.
+func (tc *testClient) startup(user string) {
+	tc.sendStartup(protocolVersion, "user", user, "database", "commerce", "application_name", "test")
+	typ, payload := tc.read()
+	require.EqualValues(tc.t, msgAuthentication, typ)
+	require.EqualValues(tc.t, authOK, binary.BigEndian.Uint32(payload))
+	types, status := tc.readUntilReady()
+	assert.Equal(tc.t, "SSSSSSK", types)
+	assert.EqualValues(tc.t, 'I', status)
+}
+
+func TestQuery(t *testing.T) {
+	l, th := newTestListener(t)
+	tc := dial(t, l)
+	tc.startup("user1")
+
+	tc.send(msgQuery, "select\x00")
+	typ, payload := tc.read()
+	require.EqualValues(t, msgRowDescription, typ)
+	assert.EqualValues(t, 3, binary.BigEndian.Uint16(payload))
+	assert.True(t, bytes.HasPrefix(payload[2:], []byte("id\x00")))
+
+	typ, payload = tc.read()
+	require.EqualValues(t, msgDataRow, typ)
+	assert.Equal(t, "\x00\x03"+"\x00\x00\x00\x011"+"\x00\x00\x00\x03abc"+"\x00\x00\x00\x06\\x0102", string(payload))
+	typ, payload = tc.read()
+	require.EqualValues(t, msgDataRow, typ)
+	assert.Equal(t, "\x00\x03"+"\x00\x00\x00\x012"+"\xff\xff\xff\xff"+"\xff\xff\xff\xff", string(payload))
+	typ, payload = tc.read()
+	require.EqualValues(t, msgCommandComplete, typ)
+	assert.Equal(t, "SELECT 2\x00", string(payload))
+	types, status := tc.readUntilReady()
+	assert.Empty(t, types)
+	assert.EqualValues(t, 'I', status)
+
+	tc.send(msgQuery, "begin\x00")
+	types, status = tc.readUntilReady()
+	assert.Equal(t, "C", types)
+	assert.EqualValues(t, 'T', status)
+
+	tc.send(msgQuery, "update\x00")
+	fields := tc.readError()
+	assert.Equal(t, "ERROR", fields['S'])
+	assert.Equal(t, CodeFeatureNotSupported, fields['C'])
+	assert.Equal(t, "unsupported query: update", fields['M'])
+	types, status = tc.readUntilReady()
+	assert.Empty(t, types)
+	assert.EqualValues(t, 'T', status)
+
+	tc.send(msgQuery, " ; \x00")
+	types, _ = tc.readUntilReady()
+	assert.Equal(t, "I", types)
+
+	tc.send(msgTerm, "")
+	_, err := tc.reader.ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	assert.Equal(t, []string{"select", "begin", "update"}, th.queries)
+	assert.Equal(t, []uint32{1}, th.closed)
+}
+
+func TestSSLRequest(t *testing.T) {
+	l, _ := newTestListener(t)
+	tc := dial(t, l)
+
+	tc.sendStartup(sslRequestCode)
+	b, err := tc.reader.ReadByte()
+	require.NoError(t, err)
+	assert.EqualValues(t, 'N', b)
+	tc.startup("user1")
+}
+
+// TestSSLRequestWithTLS tests that the connections switch to TLS when the
+// listener has a TLS config, and that the passwords are only sent over TLS.
+func TestSSLRequestWithTLS(t *testing.T) {
+	root := t.TempDir()
+	tlstest.CreateCA(root)
+	tlstest.CreateSignedCert(root, tlstest.CA, "01", "server", "server.example.com")
+	serverConfig, err := vttls.ServerConfig(path.Join(root, "server-cert.pem"), path.Join(root, "server-key.pem"), "", "", "", tls.VersionTLS12)
+	require.NoError(t, err)
+	clientConfig, err := vttls.ClientConfig(vttls.VerifyIdentity, "", "", path.Join(root, "ca-cert.pem"), "", "server.example.com", tls.VersionTLS12)
+	require.NoError(t, err)
+
+	l, _ := newTestListener(t)
+	l.TLSConfig.Store(serverConfig)
+
+	tc := dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	fields := tc.readError()
+	assert.Equal(t, CodeInvalidAuthorization, fields['C'])
+	assert.Equal(t, "password authentication requires an SSL connection", fields['M'])
+
+	tc = dial(t, l)
+	tc.sendStartup(sslRequestCode)
+	b, err := tc.reader.ReadByte()
+	require.NoError(t, err)
+	require.EqualValues(t, 'S', b)
+	conn := tls.Client(tc.conn, clientConfig)
+	require.NoError(t, conn.Handshake())
+	tc.conn, tc.reader = conn, bufio.NewReader(conn)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	typ, payload := tc.read()
+	require.EqualValues(t, msgAuthentication, typ)
+	require.EqualValues(t, authCleartextPassword, binary.BigEndian.Uint32(payload))
+	tc.send(msgPassword, "secret\x00")
+	typ, payload = tc.read()
+	require.EqualValues(t, msgAuthentication, typ)
+	require.EqualValues(t, authOK, binary.BigEndian.Uint32(payload))
+
+	// The connections without TLS are rejected if it is required.
+	l.RequireSecureTransport = true
+	tc = dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "user1")
+	fields = tc.readError()
+	assert.Equal(t, CodeInvalidAuthorization, fields['C'])
+	assert.Equal(t, "SSL connection is required", fields['M'])
+}
+
+func TestPassword(t *testing.T) {
+	l, _ := newTestListener(t)
+	l.AllowClearTextWithoutTLS.Store(true)
+
+	tc := dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	typ, payload := tc.read()
+	require.EqualValues(t, msgAuthentication, typ)
+	require.EqualValues(t, authCleartextPassword, binary.BigEndian.Uint32(payload))
+	tc.send(msgPassword, "secret\x00")
+	typ, payload = tc.read()
+	require.EqualValues(t, msgAuthentication, typ)
+	require.EqualValues(t, authOK, binary.BigEndian.Uint32(payload))
+
+	tc = dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	tc.read()
+	tc.send(msgPassword, "wrong\x00")
+	fields := tc.readError()
+	assert.Equal(t, "FATAL", fields['S'])
+	assert.Equal(t, CodeInvalidPassword, fields['C'])
+	assert.Equal(t, `password authentication failed for user "pw"`, fields['M'])
+	_, err := tc.reader.ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestStartupErrors(t *testing.T) {
+	l, th := newTestListener(t)
+
+	tc := dial(t, l)
+	tc.sendStartup(protocolVersion, "database", "commerce")
+	assert.Equal(t, CodeInvalidAuthorization, tc.readError()['C'])
+
+	tc = dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "bad")
+	assert.Equal(t, CodeInvalidPassword, tc.readError()['C'])
+
+	tc = dial(t, l)
+	tc.sendStartup(2 << 16)
+	fields := tc.readError()
+	assert.Equal(t, CodeFeatureNotSupported, fields['C'])
+	assert.Equal(t, "unsupported frontend protocol 2.0: server supports 3.0", fields['M'])
+
+	// The connections which were not authenticated are not reported as
+	// closed to the handler.
+	th.mu.Lock()
+	defer th.mu.Unlock()
+	assert.Empty(t, th.closed)
+}
+
+// TestStartupLimits tests that the clients cannot send large messages before
+// they are authenticated, nor take too long to authenticate.
+func TestStartupLimits(t *testing.T) {
+	l, _ := newTestListener(t)
+	l.AllowClearTextWithoutTLS.Store(true)
+	l.StartupTimeout = 100 * time.Millisecond
+
+	tc := dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	tc.read()
+	tc.send(msgPassword, strings.Repeat("x", maxStartupMessageSize))
+	tc.expectClosed()
+
+	tc = dial(t, l)
+	tc.sendStartup(protocolVersion, "user", "pw")
+	tc.read()
+	tc.expectClosed()
+
+	// The deadline is lifted once the client is authenticated.
+	tc = dial(t, l)
+	tc.startup("user1")
+	time.Sleep(2 * l.StartupTimeout)
+	tc.send(msgQuery, "select\x00")
+	types, _ := tc.readUntilReady()
+	assert.Equal(t, "TDDC", types)
+}
+
+func TestExtendedQueryProtocol(t *testing.T) {
+	l, _ := newTestListener(t)
+	tc := dial(t, l)
+	tc.startup("user1")
+
+	tc.send(msgParse, "\x00select\x00\x00\x00")
+	tc.send(msgBind, "\x00\x00\x00\x00\x00\x00\x00\x00")
+	tc.send(msgExecute, "\x00\x00\x00\x00\x00")
+	tc.send(msgSync, "")
+	fields := tc.readError()
+	assert.Equal(t, CodeFeatureNotSupported, fields['C'])
+	assert.Equal(t, "the extended query protocol is not supported", fields['M'])
+	types, _ := tc.readUntilReady()
+	assert.Empty(t, types)
+
+	// The connection is still usable with the simple query protocol.
+	tc.send(msgQuery, "select\x00")
+	types, _ = tc.readUntilReady()
+	assert.Equal(t, "TDDC", types)
+}
+
+func TestShutdown(t *testing.T) {
+	l, _ := newTestListener(t)
+	tc := dial(t, l)
+	tc.startup("user1")
+
+	tc.send(msgQuery, "begin\x00")
+	tc.readUntilReady()
+	l.Shutdown()
+
+	// The transaction can complete.
+	tc.send(msgQuery, "select\x00")
+	types, _ := tc.readUntilReady()
+	assert.Equal(t, "TDDC", types)
+	tc.send(msgQuery, "commit\x00")
+	tc.readUntilReady()
+
+	tc.send(msgQuery, "select\x00")
+	fields := tc.readError()
+	assert.Equal(t, "FATAL", fields['S'])
+	assert.Equal(t, CodeAdminShutdown, fields['C'])
+	_, err := tc.reader.ReadByte()
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = net.Dial("tcp", l.Addr().String())
+	require.Error(t, err)
+}
+
+func TestToError(t *testing.T) {
+	assert.Equal(t, &Error{Code: CodeInsufficientPriv, Message: "denied"}, toError(vterrors.New(vtrpcpb.Code_PERMISSION_DENIED, "denied")))
+	assert.Equal(t, &Error{Code: CodeInternalError, Message: "oops"}, toError(errors.New("oops")))
+	assert.Equal(t, &Error{Code: CodeQueryCanceled, Message: "canceled"}, toError(NewError(CodeQueryCanceled, "canceled")))
+}
+
+func TestEncodeValue(t *testing.T) {
+	testcases := []struct {
+		value sqltypes.Value
+		oid   uint32
+		size  int16
+		text  string
+	}{
+		{sqltypes.NewInt8(-1), oidInt2, 2, "-1"},
+		{sqltypes.NewUint32(42), oidInt8, 8, "42"},
+		{sqltypes.NewUint64(18446744073709551615), oidNumeric, -1, "18446744073709551615"},
+		{sqltypes.NewFloat64(1.5), oidFloat8, 8, "1.5"},
+		{sqltypes.NewDecimal("3.14"), oidNumeric, -1, "3.14"},
+		{sqltypes.NewVarChar("abc"), oidVarchar, -1, "abc"},
+		{sqltypes.NewVarBinary("\xff"), oidBytea, -1, `\xff`},
+		{sqltypes.NewDatetime("2026-01-02 03:04:05"), oidTimestamp, 8, "2026-01-02 03:04:05"},
+		{sqltypes.MakeTrusted(sqltypes.Enum, []byte("red")), oidText, -1, "red"},
+	}
+	for _, tc := range testcases {
+		t.Run(strings.ToLower(tc.value.Type().String()), func(t *testing.T) {
+			oid, size := typeOID(tc.value.Type())
+			assert.EqualValues(t, tc.oid, oid)
+			assert.Equal(t, tc.size, size)
+			assert.Equal(t, tc.text, string(encodeValue(tc.value.Type(), tc.value)))
+		})
+	}
+}
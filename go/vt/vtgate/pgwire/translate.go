@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"strings"
+)
+
+// TranslateQuery rewrites the lexical constructs of a PostgreSQL query which
+// MySQL reads differently, so that the queries written in the SQL subset
+// common to both are understood by vtgate the same way:
+//   - identifiers in double quotes are put in backquotes;
+//   - backslashes in standard string literals are escaped, since they are
+//     not escape characters in PostgreSQL;
+//   - escape string literals (E'...') become standard MySQL string literals;
+//   - a space is added after the "--" of comments, which MySQL requires.
+//
+// Other constructs, such as dollar quoting or :: casts, are left as is.
+func TranslateQuery(query string) string {
+	var buf strings.Builder
+	buf.Grow(len(query))
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == '"':
+			end, ident := readQuoted(query, i, '"')
+			buf.WriteByte('`')
+			buf.WriteString(strings.ReplaceAll(ident, "`", "``"))
+			buf.WriteByte('`')
+			i = end
+		case ch == '\'':
+			end, str := readQuoted(query, i, '\'')
+			buf.WriteByte('\'')
+			buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(str, `\`, `\\`), "'", "''"))
+			buf.WriteByte('\'')
+			i = end
+		case (ch == 'E' || ch == 'e') && i+1 < len(query) && query[i+1] == '\'' && (i == 0 || !isIdentChar(query[i-1])):
+			end := readEscapeString(query, i+1)
+			buf.WriteString(query[i+1 : end])
+			i = end
+		case ch == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query)
+			} else {
+				end += i
+			}
+			comment := query[i+2 : end]
+			buf.WriteString("--")
+			if comment != "" && comment[0] != ' ' && comment[0] != '\t' {
+				buf.WriteByte(' ')
+			}
+			buf.WriteString(comment)
+			i = end
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query)
+			} else {
+				end += i + 4
+			}
+			buf.WriteString(query[i:end])
+			i = end
+		default:
+			buf.WriteByte(ch)
+			i++
+		}
+	}
+	return buf.String()
+}
+
+// readQuoted reads the text quoted with the given quote character starting
+// at the given position, where a doubled quote stands for the quote itself.
+// It returns the position after the closing quote and the unquoted text.
+func readQuoted(query string, start int, quote byte) (int, string) {
+	var text strings.Builder
+	i := start + 1
+	for i < len(query) {
+		if query[i] == quote {
+			if i+1 < len(query) && query[i+1] == quote {
+				text.WriteByte(quote)
+				i += 2
+				continue
+			}
+			return i + 1, text.String()
+		}
+		text.WriteByte(query[i])
+		i++
+	}
+	return i, text.String()
+}
+
+// readEscapeString returns the position after the escape string literal
+// starting with the quote at the given position, in which a quote can be
+// escaped with a backslash or doubled.
+func readEscapeString(query string, start int) int {
+	i := start + 1
+	for i < len(query) {
+		switch query[i] {
+		case '\\':
+			i += 2
+			continue
+		case '\'':
+			if i+1 < len(query) && query[i+1] == '\'' {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return len(query)
+}
+
+func isIdentChar(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateQuery(t *testing.T) {
+	testcases := []struct {
+		query string
+		want  string
+	}{{
+		query: `select id, name from customer where id = 1`,
+		want:  `select id, name from customer where id = 1`,
+	}, {
+		query: `select "Id", "weird""name", "back` + "`" + `tick" from "Customer"`,
+		want:  "select `Id`, `weird\"name`, `back``tick` from `Customer`",
+	}, {
+		query: `select 'it''s', 'C:\dir', '"quoted"'`,
+		want:  `select 'it''s', 'C:\\dir', '"quoted"'`,
+	}, {
+		query: `select E'a\nb\'c', e'x', name from t where name = 'E'`,
+		want:  `select 'a\nb\'c', 'x', name from t where name = 'E'`,
+	}, {
+		query: `select code, type'x' from t`,
+		want:  `select code, type'x' from t`,
+	}, {
+		query: "select 1 --comment\nfrom dual -- other\n--",
+		want:  "select 1 -- comment\nfrom dual -- other\n--",
+	}, {
+		query: `select /* "not" 'changed' */ 1`,
+		want:  `select /* "not" 'changed' */ 1`,
+	}, {
+		query: `select 1 as "unterminated`,
+		want:  "select 1 as `unterminated`",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.want, TranslateQuery(tc.query))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgwire
+
+import (
+	"encoding/hex"
+
+	"vitess.io/vitess/go/sqltypes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// The OIDs of the PostgreSQL types the MySQL types are mapped to.
+const (
+	oidBytea     = 17
+	oidInt8      = 20
+	oidInt2      = 21
+	oidInt4      = 23
+	oidText      = 25
+	oidJSON      = 114
+	oidFloat4    = 700
+	oidFloat8    = 701
+	oidVarchar   = 1043
+	oidDate      = 1082
+	oidTime      = 1083
+	oidTimestamp = 1114
+	oidNumeric   = 1700
+)
+
+// typeOID returns the OID and the size of the PostgreSQL type which a MySQL
+// type is sent as. The size is -1 for the variable length types. The unsigned
+// types are sent as the next larger signed type, since PostgreSQL has no
+// unsigned types.
+func typeOID(typ querypb.Type) (uint32, int16) {
+	switch typ {
+	case sqltypes.Int8, sqltypes.Uint8, sqltypes.Int16, sqltypes.Year:
+		return oidInt2, 2
+	case sqltypes.Uint16, sqltypes.Int24, sqltypes.Uint24, sqltypes.Int32:
+		return oidInt4, 4
+	case sqltypes.Uint32, sqltypes.Int64:
+		return oidInt8, 8
+	case sqltypes.Uint64, sqltypes.Decimal:
+		return oidNumeric, -1
+	case sqltypes.Float32:
+		return oidFloat4, 4
+	case sqltypes.Float64:
+		return oidFloat8, 8
+	case sqltypes.Date:
+		return oidDate, 4
+	case sqltypes.Time:
+		return oidTime, 8
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		return oidTimestamp, 8
+	case sqltypes.VarChar:
+		return oidVarchar, -1
+	case sqltypes.Binary, sqltypes.VarBinary, sqltypes.Blob, sqltypes.Bit, sqltypes.Geometry:
+		return oidBytea, -1
+	case sqltypes.TypeJSON:
+		return oidJSON, -1
+	default:
+		return oidText, -1
+	}
+}
+
+// encodeValue returns the text format of a value of the given type. This is
+// the MySQL text format, except for the binary values which are sent in the
+// hex format of bytea.
+func encodeValue(typ querypb.Type, value sqltypes.Value) []byte {
+	if oid, _ := typeOID(typ); oid != oidBytea {
+		return value.Raw()
+	}
+	raw := value.Raw()
+	data := make([]byte, 2+hex.EncodedLen(len(raw)))
+	data[0], data[1] = '\\', 'x'
+	hex.Encode(data[2:], raw)
+	return data
+}
@@ -22,7 +22,9 @@ import (
 	"strings"
 	"time"
 
+	"vitess.io/vitess/go/cache/theine"
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
@@ -158,8 +160,15 @@ func (e *Executor) newExecute(
 			return recResult(plan.QueryType, result)
 		}
 
+		// Queries over a rate limit are rejected or delayed.
+		err = e.rateLimiter.wait(ctx, callerid.ImmediateCallerIDFromContext(ctx).GetUsername(), plan.TablesUsed)
+		if err != nil {
+			logStats.Error = err
+			return err
+		}
+
 		// Prepare for execution.
-		err = e.addNeededBindVars(vcursor, plan.BindVarNeeds, bindVars, safeSession)
+		err = e.addNeededBindVars(ctx, vcursor, plan.BindVarNeeds, bindVars, safeSession)
 		if err != nil {
 			logStats.Error = err
 			return err
@@ -329,6 +338,25 @@ func (e *Executor) executePlan(
 	logStats *logstats.LogStats,
 	execStart time.Time,
 ) (*sqltypes.Result, error) {
+	// Serve the queries on reference tables from the result cache.
+	var (
+		cacheKey     theine.HashKey256
+		cachedTables []string
+		cacheable    bool
+		generations  []uint64
+		resultEpoch  uint32
+	)
+	if e.resultCache != nil {
+		cacheKey, cachedTables, cacheable = e.resultCache.key(ctx, e.VSchema(), plan, vcursor, safeSession, bindVars)
+		if cacheable {
+			if qr, ok := e.resultCache.get(cacheKey); ok {
+				e.setLogStats(logStats, plan, vcursor, execStart, nil, qr)
+				return qr, nil
+			}
+			generations, resultEpoch = e.resultCache.snapshot(cachedTables)
+		}
+	}
+
 	// 4: Execute!
 	qr, err := vcursor.ExecutePrimitive(ctx, plan.Instructions, bindVars, true)
 
@@ -339,6 +367,17 @@ func (e *Executor) executePlan(
 	if err != nil {
 		return nil, e.rollbackExecIfNeeded(ctx, safeSession, bindVars, logStats, err)
 	}
+
+	if e.resultCache != nil {
+		switch {
+		case cacheable:
+			e.resultCache.set(cacheKey, cachedTables, generations, resultEpoch, qr)
+		case plan.QueryType == sqlparser.StmtInsert || plan.QueryType == sqlparser.StmtReplace ||
+			plan.QueryType == sqlparser.StmtUpdate || plan.QueryType == sqlparser.StmtDelete:
+			// Do not wait for the invalidation stream to invalidate the tables written by this vtgate.
+			e.resultCache.invalidate(plan.TablesUsed...)
+		}
+	}
 	return qr, nil
 }
 
@@ -176,6 +176,8 @@ func createInstructionFor(ctx context.Context, query string, stmt sqlparser.Stat
 		return buildRevertMigrationPlan(query, stmt, vschema, cfg)
 	case *sqlparser.ShowMigrationLogs:
 		return buildShowMigrationLogsPlan(query, vschema, cfg)
+	case *sqlparser.AlterWorkflow:
+		return buildAlterWorkflowPlan(query, stmt, vschema)
 	case *sqlparser.ShowThrottledApps:
 		return buildShowThrottledAppsPlan(query, vschema)
 	case *sqlparser.ShowThrottlerStatus:
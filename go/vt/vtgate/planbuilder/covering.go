@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planbuilder
+
+import (
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// coveringLookupSelect rewrites a point select on a single table by the
+// column of an owned covering vindex to read from the lookup table of the
+// vindex instead, if all the columns it references are stored there:
+//
+//	select /*vt+ ALLOW_COVERING_LOOKUP */ name from user where email = :email
+//
+// is planned as
+//
+//	select /*vt+ ALLOW_COVERING_LOOKUP */ name from lookup.email_idx as user where email = :email
+//
+// As the covering columns are kept in sync by vreplication, the rewritten
+// select can read their values with a small lag, so it is only used when the
+// query asks for it with the ALLOW_COVERING_LOOKUP directive. It returns nil
+// if the select cannot be answered from a lookup table.
+func coveringLookupSelect(sel *sqlparser.Select, vschema plancontext.VSchema) *sqlparser.Select {
+	if !sel.Comments.Directives().IsSet(sqlparser.DirectiveAllowCoveringLookup) {
+		return nil
+	}
+	if sel.With != nil || sel.Into != nil || sel.Lock != sqlparser.NoLock || sel.GroupBy != nil || sel.Having != nil ||
+		sel.Windows != nil || sel.SQLCalcFoundRows || sel.Where == nil || len(sel.From) != 1 || sel.SelectExprs.AllAggregation() {
+		return nil
+	}
+	ate, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok || ate.Partitions != nil || ate.Hints != nil || ate.Columns != nil {
+		return nil
+	}
+	tableName, ok := ate.Expr.(sqlparser.TableName)
+	if !ok {
+		return nil
+	}
+	table, _, _, _, err := vschema.FindTable(tableName)
+	if err != nil || table == nil || table.Keyspace == nil || !table.Keyspace.Sharded {
+		return nil
+	}
+	cmp, ok := sel.Where.Expr.(*sqlparser.ComparisonExpr)
+	if !ok || cmp.Operator != sqlparser.EqualOp || !sqlparser.IsValue(cmp.Right) {
+		return nil
+	}
+	col, ok := cmp.Left.(*sqlparser.ColName)
+	if !ok {
+		return nil
+	}
+
+	var lookupTable string
+	// columns maps the columns of the table stored in the lookup table to
+	// their name there.
+	var columns map[string]string
+	for _, cv := range table.ColumnVindexes {
+		if !cv.Owned || cv.IsBackfilling() || len(cv.Columns) != 1 || !cv.Columns[0].Equal(col.Name) {
+			continue
+		}
+		covering, ok := cv.Vindex.(vindexes.Covering)
+		if !ok {
+			continue
+		}
+		lkpTable, fromColumn, coveringColumns := covering.CoveringTable()
+		if len(coveringColumns) == 0 {
+			continue
+		}
+		lookupTable = lkpTable
+		columns = map[string]string{cv.Columns[0].Lowered(): fromColumn}
+		for _, column := range coveringColumns {
+			columns[sqlparser.NewIdentifierCI(column).Lowered()] = column
+		}
+		break
+	}
+	if columns == nil {
+		return nil
+	}
+
+	alias := ate.As
+	if alias.IsEmpty() {
+		alias = tableName.Name
+	}
+	covered := true
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case *sqlparser.ColName:
+			if !node.Qualifier.Qualifier.IsEmpty() ||
+				(!node.Qualifier.Name.IsEmpty() && node.Qualifier.Name.String() != alias.String()) {
+				covered = false
+			} else if _, ok := columns[node.Name.Lowered()]; !ok {
+				covered = false
+			}
+		case *sqlparser.StarExpr, *sqlparser.Subquery:
+			covered = false
+		}
+		return covered, nil
+	}, sel.SelectExprs, sel.Where, sel.OrderBy, sel.Limit)
+	if !covered {
+		return nil
+	}
+
+	keyspace, name, err := vschema.Environment().Parser().ParseTable(lookupTable)
+	if err != nil {
+		return nil
+	}
+	if keyspace == "" {
+		keyspace = table.Keyspace.Name
+	}
+	lookupTableName := sqlparser.NewTableNameWithQualifier(name, keyspace)
+	if _, _, _, _, err := vschema.FindTable(lookupTableName); err != nil {
+		return nil
+	}
+
+	covering := sqlparser.Clone(sel)
+	covering.From = []sqlparser.TableExpr{&sqlparser.AliasedTableExpr{Expr: lookupTableName, As: alias}}
+	// The columns renamed in the lookup table keep their name in the result.
+	for _, expr := range covering.SelectExprs.Exprs {
+		ae, ok := expr.(*sqlparser.AliasedExpr)
+		if !ok || !ae.As.IsEmpty() {
+			continue
+		}
+		if col, ok := ae.Expr.(*sqlparser.ColName); ok && col.Name.String() != columns[col.Name.Lowered()] {
+			ae.As = col.Name
+		}
+	}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if col, ok := node.(*sqlparser.ColName); ok {
+			col.Name = sqlparser.NewIdentifierCI(columns[col.Name.Lowered()])
+		}
+		return true, nil
+	}, covering.SelectExprs, covering.Where, covering.OrderBy)
+	return covering
+}
+
+// buildCoveringLookupPlan plans both the select on the owner table and the
+// one rewritten on the lookup table, as the former is used in transactions.
+func buildCoveringLookupPlan(
+	sel, covering *sqlparser.Select,
+	reservedVars *sqlparser.ReservedVars,
+	vschema plancontext.VSchema,
+	plannerVersion querypb.ExecuteOptions_PlannerVersion,
+) (*planResult, error) {
+	owner, tablesUsed, err := newBuildSelectPlan(sel, reservedVars, vschema, plannerVersion)
+	if err != nil {
+		return nil, err
+	}
+	lookup, lookupTablesUsed, err := newBuildSelectPlan(covering, reservedVars, vschema, plannerVersion)
+	if err != nil {
+		return nil, err
+	}
+	plan := &engine.CoveringLookup{Owner: owner, Covering: lookup}
+	return newPlanResult(plan, append(tablesUsed, lookupTablesUsed...)...), nil
+}
@@ -175,3 +175,38 @@ func buildShowMigrationLogsPlan(query string, vschema plancontext.VSchema, cfg d
 	}
 	return newPlanResult(send), nil
 }
+
+// buildAlterWorkflowPlan serves `ALTER VITESS_WORKFLOW ...` queries.
+// STOP and START are sent down to the PRIMARY tablets of all target shards, each of which
+// updates its own streams. SWITCH TRAFFIC is a keyspace-wide operation, and is sent to
+// a single PRIMARY tablet, which coordinates the switch across all shards.
+func buildAlterWorkflowPlan(query string, alterWorkflow *sqlparser.AlterWorkflow, vschema plancontext.VSchema) (*planResult, error) {
+	dest, ks, tabletType, err := vschema.TargetDestination("")
+	if err != nil {
+		return nil, err
+	}
+	if ks == nil {
+		return nil, vterrors.VT09005()
+	}
+
+	if tabletType != topodatapb.TabletType_PRIMARY {
+		return nil, vterrors.VT09006("ALTER")
+	}
+
+	if alterWorkflow.Type == sqlparser.SwitchTrafficWorkflowType {
+		if dest != nil {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot switch traffic of workflow %s on a shard target; use a keyspace target", alterWorkflow.Workflow)
+		}
+		dest = key.DestinationAnyShard{}
+	}
+	if dest == nil {
+		dest = key.DestinationAllShards{}
+	}
+
+	send := &engine.Send{
+		Keyspace:          ks,
+		TargetDestination: dest,
+		Query:             query,
+	}
+	return newPlanResult(send), nil
+}
@@ -113,6 +113,10 @@ func transformPercentBasedMirror(ctx *plancontext.PlanningContext, op *operators
 		return primitive, nil
 	}
 
+	if op.Split {
+		return engine.NewPercentBasedSplit(op.Percent, primitive, target), nil
+	}
+
 	return engine.NewPercentBasedMirror(op.Percent, primitive, target), nil
 }
 
@@ -136,6 +140,7 @@ func transformDMLWithInput(ctx *plancontext.PlanningContext, op *operators.DMLWi
 		Input:      input,
 		OutputCols: op.Offsets,
 		BVList:     op.BvList,
+		BatchSize:  op.BatchSize,
 	}, nil
 }
 
@@ -326,9 +331,6 @@ func transformFkVerify(ctx *plancontext.PlanningContext, fkv *operators.FkVerify
 }
 
 func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggregator) (engine.Primitive, error) {
-	if op.WithRollup {
-		return nil, vterrors.VT12001("GROUP BY WITH ROLLUP not supported for sharded queries")
-	}
 	src, err := transformToPrimitive(ctx, op.Source)
 	if err != nil {
 		return nil, err
@@ -338,6 +340,9 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 	var groupByKeys []*engine.GroupByParams
 
 	for _, aggr := range op.Aggregations {
+		if op.WithRollup && aggr.OpCode.IsDistinct() {
+			return nil, vterrors.VT12001(fmt.Sprintf("in scatter query: aggregation function '%s' with GROUP BY WITH ROLLUP", sqlparser.String(aggr.Original)))
+		}
 		switch aggr.OpCode {
 		case opcode.AggregateUnassigned:
 			return nil, vterrors.VT12001(fmt.Sprintf("in scatter query: aggregation function '%s'", sqlparser.String(aggr.Original)))
@@ -384,6 +389,9 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 	}
 
 	if len(groupByKeys) == 0 {
+		if op.WithRollup {
+			return nil, vterrors.VT12001("GROUP BY WITH ROLLUP without grouping columns in scatter query")
+		}
 		return &engine.ScalarAggregate{
 			Aggregates:          aggregates,
 			TruncateColumnCount: op.ResultColumns,
@@ -395,6 +403,7 @@ func transformAggregator(ctx *plancontext.PlanningContext, op *operators.Aggrega
 		Aggregates:          aggregates,
 		GroupByKeys:         groupByKeys,
 		TruncateColumnCount: op.ResultColumns,
+		WithRollup:          op.WithRollup,
 		Input:               src,
 	}, nil
 }
@@ -686,8 +695,10 @@ func buildInsertPrimitive(
 	}
 
 	eins := &engine.Insert{
-		InsertCommon: ic,
-		VindexValues: ins.VindexValues,
+		InsertCommon:          ic,
+		VindexValues:          ins.VindexValues,
+		CheckConstraints:      ins.CheckConstraints,
+		GeneratedVindexValues: ins.GeneratedVindexValues,
 	}
 
 	// we would need to generate the query on the fly. The only exception here is
@@ -1050,9 +1061,10 @@ func transformRecurseCTE(ctx *plancontext.PlanningContext, op *operators.Recurse
 		return nil, err
 	}
 	return &engine.RecurseCTE{
-		Seed: seed,
-		Term: term,
-		Vars: op.Vars,
+		Seed:     seed,
+		Term:     term,
+		Vars:     op.Vars,
+		Distinct: op.DistinctColumns,
 	}, nil
 }
 
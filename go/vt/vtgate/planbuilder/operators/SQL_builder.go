@@ -295,6 +295,7 @@ func (qb *queryBuilder) joinWith(other *queryBuilder, onCondition sqlparser.Expr
 		for _, expr := range otherSel.GetColumns() {
 			sel.AddSelectExpr(expr)
 		}
+		mergeWith(sel, otherSel)
 	}
 
 	qb.mergeWhereClauses(stmt, otherStmt)
@@ -313,6 +314,19 @@ func (qb *queryBuilder) joinWith(other *queryBuilder, onCondition sqlparser.Expr
 	stmt.SetFrom(newFromClause)
 }
 
+// mergeWith moves the common table expressions of the other SELECT, if any,
+// to the WITH clause of sel.
+func mergeWith(sel, otherSel *sqlparser.Select) {
+	if otherSel.With == nil || len(otherSel.With.CTEs) == 0 {
+		return
+	}
+	if sel.With == nil {
+		sel.With = &sqlparser.With{}
+	}
+	sel.With.Recursive = sel.With.Recursive || otherSel.With.Recursive
+	sel.With.CTEs = append(sel.With.CTEs, otherSel.With.CTEs...)
+}
+
 func (qb *queryBuilder) mergeWhereClauses(stmt, otherStmt FromStatement) {
 	predicate := stmt.GetWherePredicate()
 	if otherPredicate := otherStmt.GetWherePredicate(); otherPredicate != nil {
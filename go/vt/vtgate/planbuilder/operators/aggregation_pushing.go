@@ -39,8 +39,10 @@ func tryPushAggregator(ctx *plancontext.PlanningContext, aggregator *Aggregator)
 		return aggregator, NoRewrite
 	}
 
-	// this rewrite is always valid, and we should do it whenever possible
-	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || overlappingUniqueVindex(ctx, aggregator.Grouping)) {
+	// this rewrite is always valid, and we should do it whenever possible.
+	// The super-aggregate rows of a ROLLUP span all the shards, so it can't be
+	// pushed down to a scatter route even if the groups don't.
+	if route, ok := aggregator.Source.(*Route); ok && (route.IsSingleShard() || (!aggregator.WithRollup && overlappingUniqueVindex(ctx, aggregator.Grouping))) {
 		return Swap(aggregator, route, "push down aggregation under route - remove original")
 	}
 
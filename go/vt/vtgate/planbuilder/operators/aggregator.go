@@ -589,6 +589,9 @@ func (a *Aggregator) SplitAggregatorBelowOperators(ctx *plancontext.PlanningCont
 	newOp.Pushed = false
 	newOp.Original = false
 	newOp.DT = nil
+	// The super-aggregate rows of a ROLLUP can only be computed once all the
+	// rows have been aggregated, so it's only done by the original aggregator.
+	newOp.WithRollup = false
 
 	// We need to make sure that the columns are cloned so that the original operator is not affected
 	// by the changes we make to the new operator
@@ -55,7 +55,9 @@ func translateQueryToOpWithMirroring(ctx *plancontext.PlanningContext, stmt sqlp
 	if selStmt, ok := stmt.(sqlparser.SelectStatement); ok {
 		if mi := ctx.SemTable.GetMirrorInfo(); mi.Percent > 0 {
 			mirrorOp := translateQueryToOp(ctx.UseMirror(), selStmt)
-			op = NewPercentBasedMirror(mi.Percent, op, mirrorOp)
+			mirror := NewPercentBasedMirror(mi.Percent, op, mirrorOp)
+			mirror.Split = mi.Split
+			op = mirror
 		}
 	}
 
@@ -102,6 +104,10 @@ func addWherePredsToSubQueryBuilder(ctx *plancontext.PlanningContext, in sqlpars
 			expr = sqlparser.NewIntLiteral("0")
 		}
 
+		// if we are inside a CTE, we need to check if we depend on the recursion table
+		if cte := ctx.ActiveCTE(); cte != nil && ctx.SemTable.DirectDeps(expr).IsOverlapping(cte.Id) {
+			expr = addCTEPredicate(ctx, expr, cte)
+		}
 		op = op.AddPredicate(ctx, expr)
 		addColumnEquality(ctx, expr)
 	}
@@ -174,8 +180,13 @@ func createOperatorFromUnion(ctx *plancontext.PlanningContext, node *sqlparser.U
 	rexprs := ctx.SemTable.SelectExprs(node.Right)
 
 	unionCols := ctx.SemTable.SelectExprs(node)
-	union := newUnion([]Operator{opLHS, opRHS}, [][]sqlparser.SelectExpr{lexprs, rexprs}, unionCols, node.Distinct)
-	return newHorizon(union, node)
+	var op Operator = newUnion([]Operator{opLHS, opRHS}, [][]sqlparser.SelectExpr{lexprs, rexprs}, unionCols, node.Distinct)
+	if node.Lock != sqlparser.NoLock {
+		// When the UNION is not merged into a single route, all the SELECTs
+		// of the UNION lock their rows.
+		op = newLockAndComment(op, nil, node.Lock)
+	}
+	return newHorizon(op, node)
 }
 
 func translateQueryToOpForUnion(ctx *plancontext.PlanningContext, node sqlparser.TableStatement) Operator {
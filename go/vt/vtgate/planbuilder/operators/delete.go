@@ -66,7 +66,7 @@ func createOperatorFromDelete(ctx *plancontext.PlanningContext, deleteStmt *sqlp
 	// We check if delete with input plan is required. DML with input planning is generally
 	// slower, because it does a selection and then creates a delete statement wherein we have to
 	// list all the primary key values.
-	if deleteWithInputPlanningRequired(childFks, deleteStmt) {
+	if deleteWithInputPlanningRequired(childFks, deleteStmt) || subqueryInputPlanningRequired(ctx, deleteStmt.Where, deleteStmt.Limit) {
 		return createDeleteWithInputOp(ctx, deleteStmt)
 	}
 
@@ -117,7 +117,7 @@ func createDeleteWithInputOp(ctx *plancontext.PlanningContext, del *sqlparser.De
 
 	selectStmt := &sqlparser.Select{
 		From:    delClone.TableExprs,
-		Where:   delClone.Where,
+		Where:   del.Where,
 		OrderBy: delClone.OrderBy,
 		Limit:   delClone.Limit,
 		Lock:    sqlparser.ForUpdateLock,
@@ -142,9 +142,10 @@ func createDeleteWithInputOp(ctx *plancontext.PlanningContext, del *sqlparser.De
 	})
 
 	op = &DMLWithInput{
-		DML:    dmls,
-		Source: createOperatorFromSelect(ctx, selectStmt),
-		cols:   colsList,
+		DML:       dmls,
+		Source:    createOperatorFromSelect(ctx, selectStmt),
+		cols:      colsList,
+		BatchSize: dmlBatchSize(del.Comments),
 	}
 
 	if del.Comments != nil {
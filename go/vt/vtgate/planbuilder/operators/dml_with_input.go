@@ -18,11 +18,15 @@ package operators
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 // DMLWithInput is used to represent a DML Operator taking input from a Source Operator
@@ -36,6 +40,9 @@ type DMLWithInput struct {
 	updList []updList
 	BvList  []map[string]int
 
+	// BatchSize is the maximum number of input rows of each DML, all of them when it is zero.
+	BatchSize int
+
 	noColumns
 	noPredicates
 }
@@ -120,3 +127,48 @@ func (d *DMLWithInput) planOffsets(ctx *plancontext.PlanningContext) Operator {
 }
 
 var _ Operator = (*DMLWithInput)(nil)
+
+// subqueryInputPlanningRequired returns true if the WHERE clause of a single target DML has subqueries
+// that the DML route cannot evaluate: a subquery correlated with the target table, or any subquery
+// when the DML has a LIMIT. Such a DML is planned as a DML with input, which evaluates the WHERE clause
+// in a select of the primary keys of the rows, and then issues the DML for these keys.
+func subqueryInputPlanningRequired(ctx *plancontext.PlanningContext, where *sqlparser.Where, limit *sqlparser.Limit) bool {
+	if where == nil || ctx.SemTable.DMLTargets.NumberOfTables() != 1 {
+		return false
+	}
+	ti, err := ctx.SemTable.TableInfoFor(ctx.SemTable.DMLTargets)
+	if err != nil {
+		return false
+	}
+	// The rows can only be found again by their primary key.
+	vTbl := ti.GetVindexTable()
+	if vTbl == nil || len(vTbl.PrimaryKey) == 0 {
+		return false
+	}
+
+	required := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		subq, ok := node.(*sqlparser.Subquery)
+		if !ok {
+			return true, nil
+		}
+		if limit != nil || ctx.SemTable.RecursiveDeps(subq).IsOverlapping(ctx.SemTable.DMLTargets) {
+			required = true
+		}
+		return false, nil
+	}, where.Expr)
+	return required
+}
+
+// dmlBatchSize returns the value of the DML_BATCH_SIZE directive of the comments, or zero when it is not set.
+func dmlBatchSize(comments *sqlparser.ParsedComments) int {
+	val, ok := comments.Directives().GetString(sqlparser.DirectiveDMLBatchSize, "")
+	if !ok {
+		return 0
+	}
+	size, err := strconv.Atoi(val)
+	if err != nil || size <= 0 {
+		panic(vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s value: %s", sqlparser.DirectiveDMLBatchSize, val))
+	}
+	return size
+}
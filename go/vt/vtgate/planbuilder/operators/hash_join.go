@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
@@ -291,6 +292,120 @@ func canBeSolvedWithHashJoin(op sqlparser.ComparisonExprOperator) bool {
 	}
 }
 
+// hashJoinFor returns a hash join of the inputs, to use instead of the
+// nested-loop join, if the query asks for it with the ALLOW_HASH_JOIN hint,
+// or if both inputs are estimated to have more rows than the configured
+// threshold. It returns nil when a nested-loop join should be used, which is
+// always the case when an input is routed to a single row by a unique vindex,
+// as the nested-loop join then sends a single query to the other input.
+// The hash table is built from the LHS, so the smaller input of an inner
+// join is moved to the LHS.
+func hashJoinFor(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr, joinType sqlparser.JoinType) *HashJoin {
+	if !canUseHashJoin(ctx, lhs, rhs, joinPredicates) || isUniqueRoute(lhs) || isUniqueRoute(rhs) {
+		return nil
+	}
+
+	if !hashJoinHinted(ctx) {
+		threshold := ctx.VSchema.HashJoinRowsThreshold()
+		if threshold <= 0 || estimatedRows(ctx, lhs) <= threshold || estimatedRows(ctx, rhs) <= threshold {
+			return nil
+		}
+	}
+	if joinType.IsCommutative() && smallerOnRHS(ctx, lhs, rhs) {
+		lhs, rhs = rhs, lhs
+	}
+
+	join := NewHashJoin(lhs, rhs, !joinType.IsInner())
+	for _, pred := range joinPredicates {
+		join.AddJoinPredicate(ctx, pred, true)
+	}
+	ctx.SemTable.QuerySignature.HashJoin = true
+	return join
+}
+
+// canUseHashJoin returns true if the join can be solved with a hash join,
+// which needs a single equality predicate comparing a column of each side,
+// with known types.
+func canUseHashJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredicates []sqlparser.Expr) bool {
+	if len(joinPredicates) != 1 {
+		return false
+	}
+	cmp, ok := joinPredicates[0].(*sqlparser.ComparisonExpr)
+	if !ok || !canBeSolvedWithHashJoin(cmp.Operator) {
+		return false
+	}
+
+	lDeps := ctx.SemTable.RecursiveDeps(cmp.Left)
+	rDeps := ctx.SemTable.RecursiveDeps(cmp.Right)
+	if lDeps.IsEmpty() || rDeps.IsEmpty() {
+		return false
+	}
+	lID, rID := TableID(lhs), TableID(rhs)
+	if !(lDeps.IsSolvedBy(lID) && rDeps.IsSolvedBy(rID)) && !(lDeps.IsSolvedBy(rID) && rDeps.IsSolvedBy(lID)) {
+		return false
+	}
+
+	_, lFound := ctx.TypeForExpr(cmp.Left)
+	_, rFound := ctx.TypeForExpr(cmp.Right)
+	return lFound && rFound
+}
+
+func hashJoinHinted(ctx *plancontext.PlanningContext) bool {
+	stmt, ok := ctx.Statement.(sqlparser.Commented)
+	if !ok {
+		return false
+	}
+	return stmt.GetParsedComments().Directives().IsSet(sqlparser.DirectiveAllowHashJoin)
+}
+
+// estimatedRows returns the estimated number of rows of the operator, from
+// the table statistics of the schema tracking: a route to a unique vindex
+// value returns a single row, and otherwise the rows of the largest table of
+// the operator are reduced by its filters. It returns 0 when the number of
+// rows of any of the tables is not known.
+func estimatedRows(ctx *plancontext.PlanningContext, op Operator) int64 {
+	if isUniqueRoute(op) {
+		return 1
+	}
+	var rows int64
+	for _, id := range TableID(op).Constituents() {
+		ti, err := ctx.SemTable.TableInfoFor(id)
+		if err != nil {
+			return 0
+		}
+		vtbl := ti.GetVindexTable()
+		if vtbl == nil || vtbl.Rows == 0 {
+			return 0
+		}
+		rows = max(rows, vtbl.Rows)
+	}
+	// Without statistics on the columns, each predicate on a single table is
+	// assumed to keep a tenth of the rows, as MySQL does.
+	var predicates []sqlparser.Expr
+	_ = Visit(op, func(current Operator) error {
+		switch current := current.(type) {
+		case *Table:
+			predicates = append(predicates, current.QTable.Predicates...)
+		case *Filter:
+			predicates = append(predicates, current.Predicates...)
+		}
+		return nil
+	})
+	for _, pred := range sqlparser.SplitAndExpression(nil, sqlparser.AndExpressions(predicates...)) {
+		if ctx.SemTable.RecursiveDeps(pred).NumberOfTables() == 1 {
+			rows = max(rows/10, 1)
+		}
+	}
+	return rows
+}
+
+// isUniqueRoute returns true if the operator is a route to the single row of
+// a unique vindex value.
+func isUniqueRoute(op Operator) bool {
+	route, ok := op.(*Route)
+	return ok && route.Routing.OpCode() == engine.EqualUnique
+}
+
 func (c Comparison) String() string {
 	return sqlparser.String(c.LHS) + " = " + sqlparser.String(c.RHS)
 }
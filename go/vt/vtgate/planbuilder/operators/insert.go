@@ -51,6 +51,14 @@ type Insert struct {
 	// that will appear in the result set of the select query.
 	VindexValueOffset [][]int
 
+	// CheckConstraints are the CHECK constraints of the table verified by vtgate
+	// against the inserted rows.
+	CheckConstraints []*engine.InsertCheck
+
+	// GeneratedVindexValues is set when the values of generated vindex columns
+	// are computed by vtgate.
+	GeneratedVindexValues bool
+
 	nullaryOperator
 	noColumns
 	noPredicates
@@ -87,13 +95,15 @@ var _ Operator = (*Insert)(nil)
 
 func (i *Insert) Clone([]Operator) Operator {
 	return &Insert{
-		VTable:            i.VTable,
-		AST:               i.AST,
-		AutoIncrement:     i.AutoIncrement,
-		Ignore:            i.Ignore,
-		ColVindexes:       i.ColVindexes,
-		VindexValues:      i.VindexValues,
-		VindexValueOffset: i.VindexValueOffset,
+		VTable:                i.VTable,
+		AST:                   i.AST,
+		AutoIncrement:         i.AutoIncrement,
+		Ignore:                i.Ignore,
+		ColVindexes:           i.ColVindexes,
+		VindexValues:          i.VindexValues,
+		VindexValueOffset:     i.VindexValueOffset,
+		CheckConstraints:      i.CheckConstraints,
+		GeneratedVindexValues: i.GeneratedVindexValues,
 	}
 }
 
@@ -375,13 +385,16 @@ func createInsertOperator(ctx *plancontext.PlanningContext, insStmt *sqlparser.I
 	// Table column list is nil then add all the columns
 	// If the column list is empty then add only the auto-inc column and
 	// this happens on calling modifyForAutoinc
+	synthesized := false
 	if insStmt.Columns == nil && valuesProvided(insStmt.Rows) {
 		if vTbl.ColumnListAuthoritative {
 			insStmt = populateInsertColumnlist(insStmt, vTbl)
+			synthesized = true
 		} else {
 			panic(vterrors.VT09004())
 		}
 	}
+	omitGeneratedColumns(insStmt, vTbl, synthesized)
 
 	// modify column list or values for autoincrement column.
 	autoIncGen := modifyForAutoinc(ctx, insStmt, vTbl)
@@ -487,6 +500,10 @@ func insertRowsPlan(ctx *plancontext.PlanningContext, insOp *Insert, ins *sqlpar
 		}
 	}
 
+	if !insOp.Ignore {
+		insOp.CheckConstraints = insertCheckConstraints(ctx, ins, insOp.VTable, rows)
+	}
+
 	if len(insOp.ColVindexes) == 0 {
 		return insOp
 	}
@@ -498,6 +515,15 @@ func insertRowsPlan(ctx *plancontext.PlanningContext, insOp *Insert, ins *sqlpar
 		for colIdx, col := range colVindex.Columns {
 			checkAndErrIfVindexChanging(sqlparser.UpdateExprs(ins.OnDup), col)
 			routeValues[vIdx][colIdx] = make([]evalengine.Expr, len(rows))
+			if generated := findGeneratedColumn(insOp.VTable, col); generated != nil {
+				// The value of a generated column is computed by MySQL from the other
+				// columns of the row, so we compute it the same way to route the row.
+				insOp.GeneratedVindexValues = true
+				for rowNum, row := range rows {
+					routeValues[vIdx][colIdx][rowNum] = generatedVindexValue(ctx, ins, insOp.VTable, row, generated)
+				}
+				continue
+			}
 			colNum, _ := findOrAddColumn(ins, col)
 			for rowNum, row := range rows {
 				innerpv, err := evalengine.Translate(row[colNum], &evalengine.Config{
@@ -515,6 +541,9 @@ func insertRowsPlan(ctx *plancontext.PlanningContext, insOp *Insert, ins *sqlpar
 	// here we are replacing the row value with the argument.
 	for _, colVindex := range colVindexes {
 		for _, col := range colVindex.Columns {
+			if findGeneratedColumn(insOp.VTable, col) != nil {
+				continue
+			}
 			colNum, _ := findOrAddColumn(ins, col)
 			for rowNum, row := range rows {
 				name := engine.InsertVarName(col, rowNum)
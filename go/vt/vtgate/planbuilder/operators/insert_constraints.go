@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operators
+
+import (
+	"fmt"
+	"slices"
+
+	"vitess.io/vitess/go/ptr"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
+	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+)
+
+// findTableColumn returns the column of the table with the given name, or nil
+// if the table has no such column or its columns are not known.
+func findTableColumn(vTbl *vindexes.BaseTable, name sqlparser.IdentifierCI) *vindexes.Column {
+	for i := range vTbl.Columns {
+		if vTbl.Columns[i].Name.Equal(name) {
+			return &vTbl.Columns[i]
+		}
+	}
+	return nil
+}
+
+// findGeneratedColumn returns the column of the table with the given name if
+// it is a generated column, or nil otherwise.
+func findGeneratedColumn(vTbl *vindexes.BaseTable, name sqlparser.IdentifierCI) *vindexes.Column {
+	col := findTableColumn(vTbl, name)
+	if col == nil || !col.IsGenerated() {
+		return nil
+	}
+	return col
+}
+
+// errIfGeneratedColumnAssigned returns an error if the given assignments give
+// a generated column any other value than DEFAULT.
+func errIfGeneratedColumnAssigned(vTbl *vindexes.BaseTable, exprs sqlparser.UpdateExprs) {
+	for _, ue := range exprs {
+		col := findTableColumn(vTbl, ue.Name.Name)
+		if col == nil || !col.IsGenerated() {
+			continue
+		}
+		if _, isDefault := ue.Expr.(*sqlparser.Default); !isDefault {
+			panic(vterrors.VT03034(col.Name.String(), vTbl.Name.String()))
+		}
+	}
+}
+
+// omitGeneratedColumns removes the generated columns from the column list of
+// the insert, along with their values. MySQL computes the values of these
+// columns, so the only value which can be given for them is DEFAULT. When the
+// column list was synthesized by vtgate for an INSERT ... SELECT, the select
+// does not provide values for the generated columns.
+func omitGeneratedColumns(ins *sqlparser.Insert, vTbl *vindexes.BaseTable, synthesized bool) {
+	errIfGeneratedColumnAssigned(vTbl, sqlparser.UpdateExprs(ins.OnDup))
+
+	rows, isValues := ins.Rows.(sqlparser.Values)
+	for colIdx := len(ins.Columns) - 1; colIdx >= 0; colIdx-- {
+		col := findTableColumn(vTbl, ins.Columns[colIdx])
+		if col == nil || !col.IsGenerated() {
+			continue
+		}
+		if !isValues && !synthesized {
+			panic(vterrors.VT03034(col.Name.String(), vTbl.Name.String()))
+		}
+		for _, row := range rows {
+			if len(row) != len(ins.Columns) {
+				panic(vterrors.VT03006())
+			}
+			if _, isDefault := row[colIdx].(*sqlparser.Default); !isDefault {
+				panic(vterrors.VT03034(col.Name.String(), vTbl.Name.String()))
+			}
+		}
+		ins.Columns = slices.Delete(ins.Columns, colIdx, colIdx+1)
+		for i, row := range rows {
+			rows[i] = slices.Delete(row, colIdx, colIdx+1)
+		}
+	}
+}
+
+// insertedValue returns the expression of the value stored in the given column
+// by a row of the insert, cast to the type of the column. It returns nil if the
+// value cannot be computed by vtgate.
+func insertedValue(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTbl *vindexes.BaseTable, row sqlparser.ValTuple, col *vindexes.Column) sqlparser.Expr {
+	if col.IsGenerated() {
+		value := substituteInsertedValues(ctx, ins, vTbl, row, col.Generated)
+		if value == nil {
+			return nil
+		}
+		return castToColumnType(ctx, value, col)
+	}
+
+	var value sqlparser.Expr
+	if colNum := findColumn(ins, col.Name); colNum >= 0 {
+		value = row[colNum]
+	}
+	if _, isDefault := value.(*sqlparser.Default); value == nil || isDefault {
+		switch {
+		case col.Default != nil:
+			value = col.Default
+		case col.Nullable:
+			return &sqlparser.NullVal{}
+		default:
+			return nil
+		}
+	}
+	if _, isNull := value.(*sqlparser.NullVal); isNull {
+		return value
+	}
+	return castToColumnType(ctx, sqlparser.Clone(value), col)
+}
+
+// substituteInsertedValues replaces the columns of an expression of the insert
+// table by the values stored in them by a row of the insert. It returns nil if
+// one of these values cannot be computed by vtgate.
+func substituteInsertedValues(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTbl *vindexes.BaseTable, row sqlparser.ValTuple, expr sqlparser.Expr) sqlparser.Expr {
+	resolved := true
+	result := sqlparser.CopyOnRewrite(expr, nil, func(cursor *sqlparser.CopyOnWriteCursor) {
+		colName, isCol := cursor.Node().(*sqlparser.ColName)
+		if !isCol {
+			return
+		}
+		var value sqlparser.Expr
+		if col := findTableColumn(vTbl, colName.Name); col != nil {
+			value = insertedValue(ctx, ins, vTbl, row, col)
+		}
+		if value == nil {
+			resolved = false
+			cursor.StopTreeWalk()
+			return
+		}
+		cursor.Replace(value)
+	}, nil)
+	if !resolved {
+		return nil
+	}
+	return result.(sqlparser.Expr)
+}
+
+// castToColumnType casts the expression to the type of the column, so that it
+// is evaluated the way MySQL evaluates the value stored in the column. It
+// returns nil for the types which cannot be reproduced this way.
+func castToColumnType(ctx *plancontext.PlanningContext, expr sqlparser.Expr, col *vindexes.Column) sqlparser.Expr {
+	convert := &sqlparser.ConvertType{}
+	switch {
+	case col.Type == sqltypes.Year:
+		return nil
+	case sqltypes.IsSigned(col.Type):
+		convert.Type = "signed"
+	case sqltypes.IsUnsigned(col.Type):
+		convert.Type = "unsigned"
+	case sqltypes.IsFloat(col.Type):
+		convert.Type = "double"
+	case sqltypes.IsDecimal(col.Type):
+		convert.Type = "decimal"
+		if col.Size > 0 {
+			convert.Length = ptr.Of(int(col.Size))
+			convert.Scale = ptr.Of(int(col.Scale))
+		}
+	case col.Type == sqltypes.Date:
+		convert.Type = "date"
+	case col.Type == sqltypes.Datetime, col.Type == sqltypes.Timestamp:
+		convert.Type = "datetime"
+		if col.Scale > 0 {
+			convert.Length = ptr.Of(int(col.Scale))
+		}
+	case col.Type == sqltypes.Time:
+		convert.Type = "time"
+		if col.Scale > 0 {
+			convert.Length = ptr.Of(int(col.Scale))
+		}
+	case col.Type == sqltypes.Char:
+		// MySQL pads the values of CHAR columns with spaces and removes the
+		// trailing spaces when reading them back.
+		return nil
+	case sqltypes.IsText(col.Type):
+		return toColumnCollation(ctx, expr, col)
+	default:
+		return nil
+	}
+	return &sqlparser.CastExpr{Expr: expr, Type: convert}
+}
+
+// toColumnCollation converts the text expression to the character set of the
+// column and gives it the collation of the column, as MySQL does when storing
+// the value. The expression is left in the collation of the connection when
+// the collation of the column is not known.
+func toColumnCollation(ctx *plancontext.PlanningContext, expr sqlparser.Expr, col *vindexes.Column) sqlparser.Expr {
+	if col.CollationName == "" {
+		return expr
+	}
+	collationEnv := ctx.VSchema.Environment().CollationEnv()
+	coll, ok := collationEnv.LookupID(col.CollationName)
+	if !ok {
+		return nil
+	}
+	return &sqlparser.CollateExpr{
+		Expr: &sqlparser.ConvertUsingExpr{
+			Expr: expr,
+			Type: collationEnv.LookupCharsetName(coll),
+		},
+		Collation: col.CollationName,
+	}
+}
+
+// generatedVindexValue returns the value of a generated vindex column for a
+// row of the insert, computed from the values of the other columns.
+func generatedVindexValue(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTbl *vindexes.BaseTable, row sqlparser.ValTuple, col *vindexes.Column) evalengine.Expr {
+	value := insertedValue(ctx, ins, vTbl, row, col)
+	if value == nil {
+		panic(vterrors.VT12001(fmt.Sprintf("generated vindex column '%s' whose value cannot be computed by vtgate", col.Name.String())))
+	}
+	expr, err := evalengine.Translate(value, &evalengine.Config{
+		ResolveType: ctx.TypeForExpr,
+		Collation:   ctx.SemTable.Collation,
+		Environment: ctx.VSchema.Environment(),
+	})
+	if err != nil {
+		panic(vterrors.VT12001(fmt.Sprintf("generated vindex column '%s' whose value cannot be computed by vtgate: %v", col.Name.String(), err)))
+	}
+	return expr
+}
+
+// insertCheckConstraints returns the CHECK constraints of the table which can be
+// verified by vtgate against the rows of the insert. Constraints referring to
+// values which cannot be computed by vtgate are left for MySQL to verify.
+func insertCheckConstraints(ctx *plancontext.PlanningContext, ins *sqlparser.Insert, vTbl *vindexes.BaseTable, rows sqlparser.Values) []*engine.InsertCheck {
+	var checks []*engine.InsertCheck
+nextCheck:
+	for _, constraint := range vTbl.CheckConstraints {
+		check := &engine.InsertCheck{Name: constraint.Name}
+		for _, row := range rows {
+			value := substituteInsertedValues(ctx, ins, vTbl, row, constraint.Expr)
+			if value == nil {
+				continue nextCheck
+			}
+			expr, err := evalengine.Translate(value, &evalengine.Config{
+				ResolveType: ctx.TypeForExpr,
+				Collation:   ctx.SemTable.Collation,
+				Environment: ctx.VSchema.Environment(),
+			})
+			if err != nil {
+				continue nextCheck
+			}
+			check.Rows = append(check.Rows, expr)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
@@ -28,6 +28,9 @@ type (
 	PercentBasedMirror struct {
 		binaryOperator
 		Percent float32
+		// Split is true if the target serves the query instead of the
+		// operator, rather than running next to it.
+		Split bool
 	}
 )
 
@@ -80,6 +83,9 @@ func (m *PercentBasedMirror) GetSelectExprs(ctx *plancontext.PlanningContext) []
 }
 
 func (m *PercentBasedMirror) ShortDescription() string {
+	if m.Split {
+		return fmt.Sprintf("PercentBasedSplit (%.02f%%)", m.Percent)
+	}
 	return fmt.Sprintf("PercentBasedMirror (%.02f%%)", m.Percent)
 }
 
@@ -592,6 +592,11 @@ func setUpperLimit(in *Limit) (Operator, *ApplyResult) {
 				// we can't push limits down if we have a group by
 				return SkipChildren
 			}
+		case *Window:
+			if !op.evaluatedByMySQL() {
+				// the window functions have to see all the rows of their partitions
+				return SkipChildren
+			}
 		case *Route:
 			ast := &sqlparser.Limit{Rowcount: sqlparser.NewArgument(engine.UpperLimitStr)}
 			op.Source = newLimit(op.Source, ast, false)
@@ -624,6 +629,12 @@ func tryPushOrdering(ctx *plancontext.PlanningContext, in *Ordering) (Operator,
 	case *Projection:
 		return pushOrderingUnderProjection(ctx, in, src)
 	case *Aggregator:
+		if src.WithRollup {
+			// The super-aggregate rows of a ROLLUP are only produced by the aggregator,
+			// so they have to be ordered after it, and the grouping can't be reordered.
+			debugNoRewrite("ordering push blocked: GROUP BY WITH ROLLUP")
+			return in, NoRewrite
+		}
 		if !src.QP.AlignGroupByAndOrderBy(ctx) && !overlaps(ctx, in.Order, src.Grouping) {
 			debugNoRewrite("ordering push blocked: GROUP BY and ORDER BY cannot be aligned and don't overlap")
 			return in, NoRewrite
@@ -26,6 +26,7 @@ import (
 	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/engine"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	"vitess.io/vitess/go/vt/vtgate/semantics"
 )
@@ -59,6 +60,10 @@ type RecurseCTE struct {
 
 	// Distinct is used to determine if the result set should be distinct
 	Distinct bool
+
+	// DistinctColumns are the columns compared to find the duplicate rows when Distinct is set.
+	// It's filled in at offset planning time
+	DistinctColumns []engine.CheckCol
 }
 
 var _ Operator = (*RecurseCTE)(nil)
@@ -89,6 +94,7 @@ func (r *RecurseCTE) Clone(inputs []Operator) Operator {
 	klone.Vars = maps.Clone(r.Vars)
 	klone.Predicates = slices.Clone(r.Predicates)
 	klone.Projections = slices.Clone(r.Projections)
+	klone.DistinctColumns = slices.Clone(r.DistinctColumns)
 	return &klone
 }
 
@@ -191,9 +197,30 @@ func (r *RecurseCTE) planOffsets(ctx *plancontext.PlanningContext) Operator {
 			panic(vterrors.VT13001("couldn't find column"))
 		}
 	}
+	if r.Distinct {
+		r.planDistinctColumns(ctx, columns)
+	}
 	return r
 }
 
+// planDistinctColumns finds the types of the columns of the CTE, that are used to
+// discard the rows that were already produced.
+func (r *RecurseCTE) planDistinctColumns(ctx *plancontext.PlanningContext, columns []*sqlparser.AliasedExpr) {
+	firstSelect, err := sqlparser.GetFirstSelect(r.Def.Query)
+	if err != nil {
+		panic(err)
+	}
+	r.DistinctColumns = nil
+	for idx, col := range columns[:firstSelect.GetColumnCount()] {
+		typ, _ := ctx.TypeForExpr(col.Expr)
+		r.DistinctColumns = append(r.DistinctColumns, engine.CheckCol{
+			Col:          idx,
+			Type:         typ,
+			CollationEnv: ctx.VSchema.Environment().CollationEnv(),
+		})
+	}
+}
+
 func (r *RecurseCTE) introducesTableID() semantics.TableSet {
 	return r.OuterID
 }
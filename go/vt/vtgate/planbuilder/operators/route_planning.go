@@ -19,6 +19,7 @@ package operators
 import (
 	"bytes"
 	"io"
+	"slices"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -277,6 +278,16 @@ func getJoinFor(ctx *plancontext.PlanningContext, cm opCacheMap, lhs, rhs Operat
 	return join
 }
 
+// smallerOnRHS returns true if the table statistics estimate the RHS to have
+// fewer rows than the LHS. A nested-loop join runs a query on its RHS for
+// each row of its LHS, so it is cheaper when driven by the smaller input. An
+// LHS routed to a single row by a unique vindex is estimated to have a single
+// row, so that it is never moved to the RHS.
+func smallerOnRHS(ctx *plancontext.PlanningContext, lhs, rhs Operator) bool {
+	lhsRows, rhsRows := estimatedRows(ctx, lhs), estimatedRows(ctx, rhs)
+	return lhsRows > 0 && rhsRows > 0 && rhsRows < lhsRows
+}
+
 // requiresSwitchingSides will return true if any of the operators with the root from the given operator tree
 // is of the type that should not be on the RHS of a join
 func requiresSwitchingSides(ctx *plancontext.PlanningContext, op Operator) (required bool) {
@@ -318,6 +329,18 @@ func mergeOrJoin(ctx *plancontext.PlanningContext, lhs, rhs Operator, joinPredic
 		return join, Rewrote("logical join to applyJoin, switching side because LIMIT")
 	}
 
+	if hj := hashJoinFor(ctx, lhs, rhs, joinPredicates, joinType); hj != nil {
+		return hj, Rewrote("use a hash join instead of a nested-loop join")
+	}
+
+	if ctx.VSchema.JoinOrderByTableStats() && joinType.IsCommutative() && !requiresSwitchingSides(ctx, lhs) && smallerOnRHS(ctx, lhs, rhs) {
+		join := NewApplyJoin(ctx, Clone(rhs), Clone(lhs), nil, joinType, false)
+		for _, pred := range joinPredicates {
+			join.AddJoinPredicate(ctx, pred, true)
+		}
+		return join, Rewrote("logical join to applyJoin, switching side because of the table statistics")
+	}
+
 	join := NewApplyJoin(ctx, Clone(lhs), Clone(rhs), nil, joinType, false)
 	for _, pred := range joinPredicates {
 		join.AddJoinPredicate(ctx, pred, true)
@@ -457,16 +480,108 @@ func getColName(exp sqlparser.Expr) *sqlparser.ColName {
 }
 
 func canMergeOnFilters(ctx *plancontext.PlanningContext, a, b *Route, joinPredicates []sqlparser.Expr) bool {
+	var exprs []sqlparser.Expr
 	for _, predicate := range joinPredicates {
 		for _, expr := range sqlparser.SplitAndExpression(nil, predicate) {
 			if canMergeOnFilter(ctx, a, b, expr) {
 				return true
 			}
+			exprs = append(exprs, expr)
+		}
+	}
+	return canMergeOnMultiColumnFilters(ctx, a, b, exprs)
+}
+
+// multiColumnVindexPair is a unique multi-column vindex of a table of each
+// side of a join, whose columns are compared by the join predicates.
+type multiColumnVindexPair struct {
+	lhs, rhs semantics.TableSet
+	vindex   *vindexes.ColumnVindex
+}
+
+// canMergeOnMultiColumnFilters returns true if the predicates compare every
+// column of a unique multi-column vindex of a table of a with the same column
+// of the same vindex of a table of b, which means that the joined rows are in
+// the same shard. The columns must all be compared between the same two
+// tables, the equalities split across other tables do not tell anything.
+func canMergeOnMultiColumnFilters(ctx *plancontext.PlanningContext, a, b *Route, predicates []sqlparser.Expr) bool {
+	seen := map[multiColumnVindexPair][]bool{}
+	for _, predicate := range predicates {
+		comparison, ok := predicate.(*sqlparser.ComparisonExpr)
+		if !ok || comparison.Operator != sqlparser.EqualOp {
+			continue
+		}
+		left, right := getColName(comparison.Left), getColName(comparison.Right)
+		lVindex, lIdx, lTable := findMultiColumnVindex(ctx, a, left)
+		if lVindex == nil {
+			left, right = right, left
+			lVindex, lIdx, lTable = findMultiColumnVindex(ctx, a, left)
+		}
+		if lVindex == nil || !lVindex.IsUnique() {
+			continue
+		}
+		rVindex, rIdx, rTable := findMultiColumnVindex(ctx, b, right)
+		if rVindex == nil || rVindex.Vindex != lVindex.Vindex || rIdx != lIdx {
+			continue
+		}
+		pair := multiColumnVindexPair{lhs: lTable, rhs: rTable, vindex: lVindex}
+		if seen[pair] == nil {
+			seen[pair] = make([]bool, len(lVindex.Columns))
+		}
+		seen[pair][lIdx] = true
+		if !slices.Contains(seen[pair], false) {
+			return true
 		}
 	}
 	return false
 }
 
+// findMultiColumnVindex returns the multi-column vindex which has the column,
+// the index of the column in the columns of the vindex, and the table of the
+// column.
+func findMultiColumnVindex(ctx *plancontext.PlanningContext, a Operator, col *sqlparser.ColName) (*vindexes.ColumnVindex, int, semantics.TableSet) {
+	if col == nil {
+		return nil, -1, semantics.EmptyTableSet()
+	}
+	exp, ok := unwrapDerivedTables(ctx, col).(*sqlparser.ColName)
+	if !ok {
+		return nil, -1, semantics.EmptyTableSet()
+	}
+
+	var found *vindexes.ColumnVindex
+	idx := -1
+	table := semantics.EmptyTableSet()
+	deps := ctx.SemTable.RecursiveDeps(exp)
+	_ = Visit(a, func(rel Operator) error {
+		to, isTableOp := rel.(tableIDIntroducer)
+		if !isTableOp || !deps.IsSolvedBy(to.introducesTableID()) {
+			return nil
+		}
+		tableInfo, err := ctx.SemTable.TableInfoFor(to.introducesTableID())
+		if err != nil {
+			// an error here is OK, we just can't ask this operator about its column vindexes
+			return nil
+		}
+		vtable := tableInfo.GetVindexTable()
+		if vtable == nil {
+			return nil
+		}
+		for _, vindex := range vtable.ColumnVindexes {
+			if _, isMulti := vindex.Vindex.(vindexes.MultiColumn); !isMulti || vindex.IsPartialVindex() {
+				continue
+			}
+			for i, column := range vindex.Columns {
+				if column.Equal(exp.Name) {
+					found, idx, table = vindex, i, to.introducesTableID()
+					return io.EOF
+				}
+			}
+		}
+		return nil
+	})
+	return found, idx, table
+}
+
 func gen4ValuesEqual(ctx *plancontext.PlanningContext, a, b []sqlparser.Expr) (bool, []engine.Condition) {
 	if len(a) != len(b) {
 		return false, nil
@@ -283,10 +283,94 @@ func (tr *ShardedRouting) planComparison(ctx *plancontext.PlanningContext, cmp *
 	case sqlparser.LikeOp:
 		found := tr.planLikeOp(ctx, cmp)
 		return nil, found
+	case sqlparser.LessThanOp, sqlparser.LessEqualOp, sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+		found := tr.planRangeOp(ctx, cmp)
+		return nil, found
 	}
 	return nil, false
 }
 
+// planRangeOp plans a '<', '<=', '>' or '>=' comparison of a column with a
+// RangeComparable vindex as an open range, or as a closed one when the
+// opposite bound of the column was seen before.
+func (tr *ShardedRouting) planRangeOp(ctx *plancontext.PlanningContext, node *sqlparser.ComparisonExpr) bool {
+	column, value, lower, ok := rangeBound(node)
+	if !ok {
+		return false
+	}
+	var predicate sqlparser.Expr = node
+	from, to := value, sqlparser.Expr(&sqlparser.NullVal{})
+	if !lower {
+		from, to = to, from
+	}
+	for _, seen := range tr.SeenPredicates {
+		if jp, ok := seen.(*predicates.JoinPredicate); ok {
+			seen = jp.Current()
+		}
+		cmp, ok := seen.(*sqlparser.ComparisonExpr)
+		if !ok || cmp == node {
+			continue
+		}
+		otherColumn, otherValue, otherLower, ok := rangeBound(cmp)
+		if !ok || otherLower == lower || !ctx.SemTable.EqualsExprWithDeps(column, otherColumn) {
+			continue
+		}
+		if lower {
+			to = otherValue
+		} else {
+			from = otherValue
+		}
+		predicate = sqlparser.AndExpressions(node, cmp)
+		break
+	}
+
+	vdValue := sqlparser.ValTuple([]sqlparser.Expr{from, to})
+	val := makeEvalEngineExpr(ctx, vdValue)
+	if val == nil {
+		return false
+	}
+
+	opcode := func(vindex *vindexes.ColumnVindex) engine.Opcode {
+		if _, ok := vindex.Vindex.(vindexes.RangeComparable); ok {
+			return engine.Between
+		}
+		return engine.Scatter
+	}
+	rangeVdx := func(vindex *vindexes.ColumnVindex) vindexes.Vindex {
+		if _, ok := vindex.Vindex.(vindexes.RangeComparable); ok {
+			return vindex.Vindex
+		}
+		// if vindex is not of type RangeComparable, we can't use this vindex at all
+		return nil
+	}
+	return tr.haveMatchingVindex(ctx, predicate, vdValue, column, val, opcode, rangeVdx)
+}
+
+// rangeBound returns the column and the value of a '<', '<=', '>' or '>='
+// comparison, and whether the value is the lower bound of the column.
+func rangeBound(cmp *sqlparser.ComparisonExpr) (column *sqlparser.ColName, value sqlparser.Expr, lower bool, ok bool) {
+	op := cmp.Operator
+	column, ok = cmp.Left.(*sqlparser.ColName)
+	value = cmp.Right
+	if !ok {
+		column, ok = cmp.Right.(*sqlparser.ColName)
+		if !ok {
+			return nil, nil, false, false
+		}
+		value = cmp.Left
+		if op, ok = op.SwitchSides(); !ok {
+			return nil, nil, false, false
+		}
+	}
+	switch op {
+	case sqlparser.GreaterThanOp, sqlparser.GreaterEqualOp:
+		return column, value, true, true
+	case sqlparser.LessThanOp, sqlparser.LessEqualOp:
+		return column, value, false, true
+	}
+	return nil, nil, false, false
+}
+
 func (tr *ShardedRouting) planIsExpr(ctx *plancontext.PlanningContext, node *sqlparser.IsExpr) bool {
 	// we only handle IS NULL correct. IsExpr can contain other expressions as well
 	if node.Right != sqlparser.IsNullOp {
@@ -235,6 +235,9 @@ func createMergedUnion(
 		MergedWith:    []*Route{rhsRoute},
 		Routing:       routing,
 		Conditions:    conditions,
+		// The lock is set on the merged UNION, so the rows of all of its
+		// SELECTs are locked, even if only some of them asked for it.
+		Lock: lhsRoute.Lock.GetHighestOrderLock(rhsRoute.Lock),
 	}, selectExprs
 }
 
@@ -102,7 +102,7 @@ func createOperatorFromUpdate(ctx *plancontext.PlanningContext, updStmt *sqlpars
 	// We check if dml with input plan is required. DML with input planning is generally
 	// slower, because it does a selection and then creates an update statement wherein we have to
 	// list all the primary key values.
-	if updateWithInputPlanningRequired(ctx, childFks, parentFks, updStmt) {
+	if updateWithInputPlanningRequired(ctx, childFks, parentFks, updStmt) || subqueryInputPlanningRequired(ctx, updStmt.Where, updStmt.Limit) {
 		return createUpdateWithInputOp(ctx, updStmt)
 	}
 
@@ -173,7 +173,7 @@ func createUpdateWithInputOp(ctx *plancontext.PlanningContext, upd *sqlparser.Up
 
 	selectStmt := &sqlparser.Select{
 		From:    updClone.TableExprs,
-		Where:   updClone.Where,
+		Where:   upd.Where,
 		OrderBy: updClone.OrderBy,
 		Limit:   updClone.Limit,
 		Lock:    sqlparser.ForUpdateLock,
@@ -192,10 +192,11 @@ func createUpdateWithInputOp(ctx *plancontext.PlanningContext, upd *sqlparser.Up
 	})
 
 	op = &DMLWithInput{
-		DML:     dmls,
-		Source:  createOperatorFromSelect(ctx, selectStmt),
-		cols:    colsList,
-		updList: uList,
+		DML:       dmls,
+		Source:    createOperatorFromSelect(ctx, selectStmt),
+		cols:      colsList,
+		updList:   uList,
+		BatchSize: dmlBatchSize(upd.Comments),
 	}
 
 	if upd.Comments != nil {
@@ -306,6 +307,9 @@ func errIfUpdateNotSupported(ctx *plancontext.PlanningContext, stmt *sqlparser.U
 			}
 			panic(vterrors.VT03032(tblName))
 		}
+		if vTbl := tblInfo.GetVindexTable(); vTbl != nil {
+			errIfGeneratedColumnAssigned(vTbl, sqlparser.UpdateExprs{ue})
+		}
 	}
 
 	// Now we check if any of the foreign key columns that are being updated have dependencies on other updated columns.
@@ -17,13 +17,46 @@ limitations under the License.
 package operators
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"vitess.io/vitess/go/slice"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
 )
 
 type Window struct {
 	unaryOperator
 	QP *QueryProjection
+
+	// Funcs are the window functions evaluated on the vtgate, because the rows
+	// of their partitions can come from different shards. It is empty when the
+	// window functions are evaluated by MySQL.
+	Funcs []*WindowFunc
+
+	// ResultColumns is the number of columns to return, when columns were added
+	// to the source to evaluate Funcs. It is 0 otherwise.
+	ResultColumns int
+}
+
+// WindowFunc is a window function evaluated on the vtgate.
+type WindowFunc struct {
+	Func *sqlparser.ArgumentLessWindowExpr
+
+	// Offset is the column of the source holding the value of the function.
+	Offset int
+
+	// Order sorts the rows of the source by the partition expressions of the
+	// function, which are its first Partitions entries, then by its ordering.
+	Order      []OrderBy
+	Partitions int
+
+	// Offsets and WOffsets are the columns of the source holding the Order
+	// expressions and their weight strings, or -1 for the latter when not needed.
+	Offsets  []int
+	WOffsets []int
 }
 
 func newWindow(source Operator, qp *QueryProjection) *Window {
@@ -34,7 +67,13 @@ func newWindow(source Operator, qp *QueryProjection) *Window {
 }
 
 func (w *Window) Clone(inputs []Operator) Operator {
-	return newWindow(inputs[0], w.QP)
+	klone := *w
+	klone.Source = inputs[0]
+	klone.Funcs = slice.Map(w.Funcs, func(f *WindowFunc) *WindowFunc {
+		fk := *f
+		return &fk
+	})
+	return &klone
 }
 
 func (w *Window) AddPredicate(ctx *plancontext.PlanningContext, expr sqlparser.Expr) Operator {
@@ -55,17 +94,265 @@ func (w *Window) FindCol(ctx *plancontext.PlanningContext, expr sqlparser.Expr,
 }
 
 func (w *Window) GetColumns(ctx *plancontext.PlanningContext) []*sqlparser.AliasedExpr {
-	return w.Source.GetColumns(ctx)
+	columns := w.Source.GetColumns(ctx)
+	if w.ResultColumns > 0 {
+		columns = columns[:w.ResultColumns]
+	}
+	return columns
 }
 
 func (w *Window) GetSelectExprs(ctx *plancontext.PlanningContext) []sqlparser.SelectExpr {
-	return w.Source.GetSelectExprs(ctx)
+	exprs := w.Source.GetSelectExprs(ctx)
+	if w.ResultColumns > 0 {
+		exprs = exprs[:w.ResultColumns]
+	}
+	return exprs
 }
 
 func (w *Window) ShortDescription() string {
-	return "Window"
+	if len(w.Funcs) == 0 {
+		return "Window"
+	}
+	funcs := slice.Map(w.Funcs, func(f *WindowFunc) string {
+		return sqlparser.String(f.Func)
+	})
+	return fmt.Sprintf("Window (%s)", strings.Join(funcs, ", "))
 }
 
 func (w *Window) GetOrdering(ctx *plancontext.PlanningContext) []OrderBy {
+	if len(w.Funcs) > 0 {
+		// The rows are sorted to evaluate the window functions.
+		return nil
+	}
 	return w.Source.GetOrdering(ctx)
 }
+
+// planOffsets plans the evaluation of the window functions on the vtgate when
+// the source is a route to several shards, and the partitions of the window
+// functions are not guaranteed to be on a single shard. The functions are still
+// evaluated by each shard, and their values are replaced.
+func (w *Window) planOffsets(ctx *plancontext.PlanningContext) Operator {
+	if _, ok := w.Source.(*Route); !ok || w.evaluatedByMySQL() {
+		return nil
+	}
+
+	var funcs []*WindowFunc
+	for _, wf := range w.windowFuncs() {
+		argLess, ok := wf.(*sqlparser.ArgumentLessWindowExpr)
+		if !ok || !canEvaluateWindowFunc(argLess) {
+			return nil
+		}
+		offset := w.Source.FindCol(ctx, argLess, true)
+		if offset < 0 {
+			// The function is part of a larger expression.
+			return nil
+		}
+		if slices.ContainsFunc(funcs, func(f *WindowFunc) bool { return f.Offset == offset }) {
+			continue
+		}
+		spec := argLess.OverClause.WindowSpec
+		f := &WindowFunc{Func: argLess, Offset: offset, Partitions: len(spec.PartitionClause)}
+		for _, expr := range spec.PartitionClause {
+			f.Order = append(f.Order, OrderBy{Inner: &sqlparser.Order{Expr: expr, Direction: sqlparser.AscOrder}, SimplifiedExpr: expr})
+		}
+		for _, order := range spec.OrderClause {
+			f.Order = append(f.Order, OrderBy{Inner: order, SimplifiedExpr: order.Expr})
+		}
+		funcs = append(funcs, f)
+	}
+	if len(funcs) == 0 {
+		return nil
+	}
+
+	w.ResultColumns = len(w.Source.GetColumns(ctx))
+	for _, f := range funcs {
+		for _, order := range f.Order {
+			f.Offsets = append(f.Offsets, w.Source.AddColumn(ctx, true, false, aeWrap(order.SimplifiedExpr)))
+		}
+		for i, order := range f.Order {
+			if !ctx.NeedsWeightString(order.SimplifiedExpr) {
+				f.WOffsets = append(f.WOffsets, -1)
+				continue
+			}
+			f.WOffsets = append(f.WOffsets, w.Source.AddWSColumn(ctx, f.Offsets[i], false))
+		}
+	}
+	if len(w.Source.GetColumns(ctx)) == w.ResultColumns {
+		w.ResultColumns = 0
+	}
+	w.Funcs = funcs
+	return nil
+}
+
+// evaluatedByMySQL returns whether the window functions are evaluated by
+// MySQL, because the source is a route whose partitions are all on a single
+// shard. If the source is not planned into a route yet, it returns false.
+func (w *Window) evaluatedByMySQL() bool {
+	route, ok := w.Source.(*Route)
+	return ok && (route.IsSingleShard() || w.CanPushDown(route))
+}
+
+type windowTableInfo struct {
+	vTable *vindexes.BaseTable
+	alias  sqlparser.IdentifierCS
+}
+
+// CanPushDown checks if the window functions partition by a unique vindex.
+// Returns false if PARTITION BY is missing or covers non-vindex columns.
+// Examples:
+//
+//	OK: SELECT ... FROM user WHERE id=1 PARTITION BY id (single shard)
+//	OK: SELECT ... FROM user PARTITION BY id (id is primary vindex, same-shard partitions)
+//	NO: SELECT ... FROM user PARTITION BY region (region scattered across shards)
+func (w *Window) CanPushDown(route *Route) bool {
+	// Collect tables with their aliases
+	var tables []windowTableInfo
+	_ = Visit(route, func(o Operator) error {
+		if t, ok := o.(*Table); ok && t.VTable != nil {
+			alias := t.QTable.Alias.As
+			if alias.IsEmpty() {
+				alias = sqlparser.NewIdentifierCS(t.QTable.Table.Name.String())
+			}
+			tables = append(tables, windowTableInfo{vTable: t.VTable, alias: alias})
+		}
+		return nil
+	})
+
+	// Collect window functions from SELECT expressions
+	var windowFuncs []sqlparser.WindowFunc
+	for _, expr := range w.QP.SelectExprs {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			if wf, ok := node.(sqlparser.WindowFunc); ok {
+				windowFuncs = append(windowFuncs, wf)
+			}
+			return true, nil
+		}, expr.Col)
+	}
+
+	if len(windowFuncs) == 0 {
+		return true
+	}
+
+	// Validate each window function partitions by unique vindex
+	for _, wf := range windowFuncs {
+		if !isPartitionedByUniqueVindex(wf, tables) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isPartitionedByUniqueVindex checks if a window function's PARTITION BY covers:
+//  1. Primary vindex columns (ensures same-shard partitions), or
+//  2. Unique vindex columns (each partition has ≤1 row, trivially single-shard)
+func isPartitionedByUniqueVindex(wf sqlparser.WindowFunc, tables []windowTableInfo) bool {
+	overClause := wf.GetOverClause()
+	if overClause == nil || overClause.WindowSpec == nil || len(overClause.WindowSpec.PartitionClause) == 0 {
+		return false
+	}
+
+	partitionBy := overClause.WindowSpec.PartitionClause
+
+	for _, table := range tables {
+		if len(table.vTable.ColumnVindexes) == 0 {
+			continue
+		}
+
+		// Pre-build column lookup map for column validation
+		var columnSet map[string]bool
+		if table.vTable.ColumnListAuthoritative {
+			columnSet = make(map[string]bool, len(table.vTable.Columns))
+			for _, col := range table.vTable.Columns {
+				columnSet[col.Name.Lowered()] = true
+			}
+		}
+
+		// Build set of partition columns matching this table - O(p) where p = partition columns
+		coveredCols := make(map[string]bool)
+		for _, pExpr := range partitionBy {
+			colName, ok := pExpr.(*sqlparser.ColName)
+			if !ok {
+				continue
+			}
+
+			// Skip if qualified to different table
+			if !colName.Qualifier.IsEmpty() && colName.Qualifier.Name.String() != table.alias.String() {
+				continue
+			}
+
+			// Validate column exists in schema if authoritative - O(1) lookup instead of O(c)
+			if columnSet != nil {
+				if !columnSet[colName.Name.Lowered()] {
+					if !colName.Qualifier.IsEmpty() || len(tables) == 1 {
+						return false
+					}
+					continue
+				}
+			}
+
+			coveredCols[colName.Name.Lowered()] = true
+		}
+
+		checkVindex := func(vindex *vindexes.ColumnVindex) bool {
+			for _, vCol := range vindex.Columns {
+				if !coveredCols[vCol.Lowered()] {
+					return false
+				}
+			}
+			return true
+		}
+
+		// Check primary vindex (determines shard routing)
+		primaryVindex := table.vTable.ColumnVindexes[0]
+		if checkVindex(primaryVindex) {
+			return true
+		}
+
+		// Check unique vindexes (each partition has ≤1 row)
+		for _, vindex := range table.vTable.ColumnVindexes[1:] {
+			if vindex.IsUnique() && checkVindex(vindex) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canEvaluateWindowFunc returns true for the window functions which can be
+// evaluated on the vtgate.
+func canEvaluateWindowFunc(wf *sqlparser.ArgumentLessWindowExpr) bool {
+	switch wf.Type {
+	case sqlparser.RowNumberExprType, sqlparser.RankExprType, sqlparser.DenseRankExprType:
+	default:
+		return false
+	}
+	// Named windows are not resolved.
+	over := wf.OverClause
+	return over != nil && over.WindowName.IsEmpty() && over.WindowSpec != nil && over.WindowSpec.Name.IsEmpty()
+}
+
+// windowFuncs returns the window functions of the select and order by
+// expressions of the query.
+func (w *Window) windowFuncs() []sqlparser.WindowFunc {
+	var windowFuncs []sqlparser.WindowFunc
+	collect := func(node sqlparser.SQLNode) (bool, error) {
+		switch node := node.(type) {
+		case sqlparser.WindowFunc:
+			if node.GetOverClause() != nil {
+				windowFuncs = append(windowFuncs, node)
+				return false, nil
+			}
+		case *sqlparser.Subquery:
+			return false, nil
+		}
+		return true, nil
+	}
+	for _, expr := range w.QP.SelectExprs {
+		_ = sqlparser.Walk(collect, expr.Col)
+	}
+	for _, order := range w.QP.OrderExprs {
+		_ = sqlparser.Walk(collect, order.Inner.Expr)
+	}
+	return windowFuncs
+}
@@ -162,6 +162,50 @@ func (s *planTestSuite) TestForeignKeyChecksOff() {
 	s.testFile("foreignkey_checks_off_cases.json", vw, false)
 }
 
+// TestGeneratedColumnsPlanning tests the planning of DMLs on tables with
+// generated columns and check constraints.
+func (s *planTestSuite) TestGeneratedColumnsPlanning() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/generated_columns_schema.json", true)
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+
+	// Schema tracking provides the generated columns and the check constraints,
+	// which cannot be declared in the vschema.
+	parser := sqlparser.NewTestParser()
+	parseExpr := func(expr string) sqlparser.Expr {
+		e, err := parser.ParseExpr(expr)
+		require.NoError(s.T(), err)
+		return e
+	}
+	setGenerated := func(tbl *vindexes.BaseTable, col, expr string, stored bool) {
+		for i := range tbl.Columns {
+			if tbl.Columns[i].Name.EqualString(col) {
+				tbl.Columns[i].Generated = parseExpr(expr)
+				tbl.Columns[i].Stored = stored
+			}
+		}
+	}
+	genTbl := vschema.Keyspaces["sharded"].Tables["gen_tbl"]
+	setGenerated(genTbl, "sk", "a + 10", true)
+	setGenerated(genTbl, "b_upper", "upper(b)", false)
+	genStrTbl := vschema.Keyspaces["sharded"].Tables["gen_str_tbl"]
+	setGenerated(genStrTbl, "code", "concat(b, '-', id)", true)
+	genStrTbl.CheckConstraints = []*vindexes.CheckConstraint{
+		{Name: "chk_code", Expr: parseExpr("code != 'X-1'")},
+	}
+	setGenerated(vschema.Keyspaces["sharded"].Tables["gen_char_tbl"], "code_char", "concat('c', id)", true)
+	chkTbl := vschema.Keyspaces["sharded"].Tables["chk_tbl"]
+	setGenerated(chkTbl, "total", "price * qty", true)
+	chkTbl.CheckConstraints = []*vindexes.CheckConstraint{
+		{Name: "chk_price", Expr: parseExpr("price > 0")},
+		{Name: "chk_total", Expr: parseExpr("total < 1000")},
+		{Name: "chk_tag", Expr: parseExpr("tag != 'b' or price > 100")},
+	}
+
+	s.testFile("generated_columns_cases.json", vw, false)
+}
+
 func (s *planTestSuite) setFks(vschema *vindexes.VSchema) {
 	if vschema.Keyspaces["sharded_fk_allow"] != nil {
 		// FK from multicol_tbl2 referencing multicol_tbl1 that is shard scoped.
@@ -299,6 +343,22 @@ func (s *planTestSuite) TestViews() {
 	s.testFile("view_cases.json", vw, false)
 }
 
+func (s *planTestSuite) TestHashJoin() {
+	env := vtenv.NewTestEnv()
+	vschema := loadSchema(s.T(), "vschemas/schema.json", true)
+	tables := vschema.Keyspaces["user"].Tables
+	tables["user"].Rows = 1000000
+	tables["user_extra"].Rows = 50000
+	tables["music"].Rows = 100
+	vw, err := vschemawrapper.NewVschemaWrapper(env, vschema, TestBuilder)
+	require.NoError(s.T(), err)
+
+	vw.HashJoinRowsThreshold_ = 10000
+	vw.JoinOrderByTableStats_ = true
+
+	s.testFile("hash_join_cases.json", vw, false)
+}
+
 func (s *planTestSuite) TestOne() {
 	reset := operators.EnableDebugPrinting()
 	defer reset()
@@ -341,6 +341,16 @@ func (v *vschema) IsViewsEnabled() bool {
 	panic("implement me")
 }
 
+func (v *vschema) HashJoinRowsThreshold() int64 {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (v *vschema) JoinOrderByTableStats() bool {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (v *vschema) GetUDV(name string) *querypb.BindVariable {
 	// TODO implement me
 	panic("implement me")
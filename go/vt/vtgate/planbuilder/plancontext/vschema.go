@@ -83,6 +83,14 @@ type VSchema interface {
 	// IsViewsEnabled returns true if Vitess manages the views.
 	IsViewsEnabled() bool
 
+	// HashJoinRowsThreshold returns the estimated number of rows of the
+	// inputs of a join above which a hash join is used, 0 when disabled.
+	HashJoinRowsThreshold() int64
+
+	// JoinOrderByTableStats returns true if the nested-loop joins are driven
+	// by the input with fewer estimated rows.
+	JoinOrderByTableStats() bool
+
 	// GetUDV returns user defined value from the variable passed.
 	GetUDV(name string) *querypb.BindVariable
 
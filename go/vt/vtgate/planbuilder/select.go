@@ -60,6 +60,11 @@ func gen4SelectStmtPlanner(
 		}
 		// if there was no limit, we can safely ignore the SQLCalcFoundRows directive
 		sel.SQLCalcFoundRows = false
+
+		// point selects covered by a lookup table can be answered from it.
+		if covering := coveringLookupSelect(sel, vschema); covering != nil {
+			return buildCoveringLookupPlan(sel, covering, reservedVars, vschema, plannerVersion)
+		}
 	}
 
 	getPlan := func(selStatement sqlparser.SelectStatement) (engine.Primitive, []string, error) {
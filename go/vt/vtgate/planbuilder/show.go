@@ -106,7 +106,7 @@ func buildShowBasicPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema)
 		return buildPlanWithDB(show, vschema)
 	case sqlparser.StatusGlobal, sqlparser.StatusSession:
 		return buildSendAnywherePlan(show, vschema)
-	case sqlparser.VitessMigrations:
+	case sqlparser.VitessMigrations, sqlparser.VitessWorkflows:
 		return buildShowVitessMigrationsPlan(show, vschema)
 	case sqlparser.VGtidExecGlobal:
 		return buildShowVGtidPlan(show, vschema)
@@ -118,7 +118,7 @@ func buildShowBasicPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema)
 		return buildPluginsPlan()
 	case sqlparser.Engines:
 		return buildEnginesPlan()
-	case sqlparser.VitessReplicationStatus, sqlparser.VitessShards, sqlparser.VitessTablets, sqlparser.VitessVariables:
+	case sqlparser.VitessBuffers, sqlparser.VitessReplicationStatus, sqlparser.VitessShards, sqlparser.VitessTablets, sqlparser.VitessVariables:
 		return &engine.ShowExec{
 			Command:    show.Command,
 			ShowFilter: show.Filter,
@@ -253,7 +253,7 @@ func buildDBPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema) (engine
 	return engine.NewRowsPrimitive(rows, buildVarCharFields("Database")), nil
 }
 
-// buildShowVitessMigrationsPlan serves `SHOW VITESS_MIGRATIONS ...` queries.
+// buildShowVitessMigrationsPlan serves `SHOW VITESS_MIGRATIONS ...` and `SHOW VITESS_WORKFLOWS ...` queries.
 // It sends down the SHOW command to the PRIMARY shard tablets (on all shards)
 func buildShowVitessMigrationsPlan(show *sqlparser.ShowBasic, vschema plancontext.VSchema) (engine.Primitive, error) {
 	dest, ks, tabletType, err := vschema.TargetDestination(show.DbName.String())
@@ -149,7 +149,7 @@ func TestWindowFunctions(t *testing.T) {
 		},
 		{
 			name:          "Authoritative Table - Partition By Primary Vindex and Invalid Column",
-			sql:           "select rank() over (partition by user_id, invalid_col) from authoritative",
+			sql:           "select sum(col1) over (partition by user_id, invalid_col) from authoritative",
 			isSingleShard: false,
 		},
 	}
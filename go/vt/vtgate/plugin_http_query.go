@@ -0,0 +1,463 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/utils"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file implements the HTTP query API of vtgate, which executes the SQL
+// queries sent as JSON with POST requests to /query and streams their results
+// as JSON or CSV. It lets the environments in which a MySQL driver is
+// inconvenient, such as serverless functions, query the keyspaces.
+//
+// The request body is a JSON object:
+//
+//	{"sql": "select * from t where id = :id", "bind_variables": {"id": 1}, "target": "ks@replica"}
+//
+// The clients authenticate with HTTP basic authentication against the
+// --mysql-auth-server-impl auth server, over TLS unless
+// --mysql-allow-clear-text-without-tls is set, and each request is executed
+// in its own autocommit session. The requests must have the application/json
+// content type, which the browsers cannot send to another site without its
+// consent, so that a page cannot send queries with the credentials that a
+// browser keeps for vtgate.
+//
+// Unless the request accepts text/csv, the response is a JSON object whose
+// rows are streamed as they are received:
+//
+//	{"fields":[{"name":"id","type":"INT64"}],"rows":[[1]],"rows_affected":0,"insert_id":0}
+//
+// If the query fails once the response started, the object ends with an
+// "error" member, and the X-Vitess-Error trailer is set for both formats.
+
+var enableHTTPQueryAPI bool
+
+const (
+	// httpQueryPath is the path of the HTTP query API.
+	httpQueryPath = "/query"
+	// httpQueryMaxRequestSize is the maximum size of a request body.
+	httpQueryMaxRequestSize = 16 * 1024 * 1024
+	// httpQueryErrorTrailer is the trailer set to the error of a query which
+	// fails once its response started.
+	httpQueryErrorTrailer = "X-Vitess-Error"
+)
+
+func registerHTTPQueryFlags(fs *pflag.FlagSet) {
+	utils.SetFlagBoolVar(fs, &enableHTTPQueryAPI, "http-query-api", enableHTTPQueryAPI, "If set, execute the SQL queries sent as JSON with POST requests to /query on the HTTP port, and stream their results as JSON or CSV. The requests must have the application/json content type. The clients authenticate with HTTP basic authentication against the --mysql-auth-server-impl auth server, over HTTPS unless --mysql-allow-clear-text-without-tls is set.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerHTTPQueryFlags)
+}
+
+// httpQueryRequest is the body of a request to the HTTP query API.
+type httpQueryRequest struct {
+	SQL           string         `json:"sql"`
+	BindVariables map[string]any `json:"bind_variables"`
+	// Target is the target of the session, such as "ks@replica".
+	Target string `json:"target"`
+}
+
+// httpQueryHandler serves the HTTP query API.
+type httpQueryHandler struct {
+	vtg        *VTGate
+	authServer mysql.AuthServer
+}
+
+// initHTTPQueryAPI registers the HTTP query API if --http-query-api is set.
+func initHTTPQueryAPI(vtgate *VTGate) {
+	if !enableHTTPQueryAPI || vtgate == nil {
+		return
+	}
+	servenv.HTTPHandle(httpQueryPath, &httpQueryHandler{
+		vtg:        vtgate,
+		authServer: initAuthServer(),
+	})
+}
+
+func (hh *httpQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(w, "only application/json requests are supported", http.StatusUnsupportedMediaType)
+		return
+	}
+	if hh.vtg.draining.Load() {
+		http.Error(w, "vtgate is draining", http.StatusServiceUnavailable)
+		return
+	}
+
+	user, password, _ := r.BasicAuth()
+	var remoteAddr net.Addr
+	if addrPort, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		remoteAddr = net.TCPAddrFromAddrPort(addrPort)
+	}
+	getter, err := authenticateClearText(hh.authServer, "the HTTP query API", user, func() (string, error) {
+		if r.TLS == nil && !mysqlAllowClearTextWithoutTLS {
+			return "", vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "basic authentication requires an HTTPS connection")
+		}
+		return password, nil
+	}, remoteAddr)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="vtgate"`)
+		writeHTTPQueryError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var req httpQueryRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, httpQueryMaxRequestSize))
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		writeHTTPQueryError(w, http.StatusBadRequest, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid request: %v", err))
+		return
+	}
+	if req.SQL == "" {
+		writeHTTPQueryError(w, http.StatusBadRequest, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid request: sql is required"))
+		return
+	}
+	bindVars := make(map[string]*querypb.BindVariable, len(req.BindVariables))
+	for name, value := range req.BindVariables {
+		value, err := httpBindValue(value)
+		if err == nil {
+			bindVars[name], err = sqltypes.BuildBindVariable(value)
+		}
+		if err != nil {
+			writeHTTPQueryError(w, http.StatusBadRequest, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid bind variable %s: %v", name, err))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	if mysqlQueryTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+		defer cancel()
+	}
+	ef := callerid.NewEffectiveCallerID(
+		user,         /* principal: who */
+		r.RemoteAddr, /* component: running client process */
+		"VTGate HTTP Query API" /* subcomponent: part of the client */)
+	ctx = callerid.NewContext(ctx, ef, getter.Get())
+
+	var rw httpResultWriter
+	if acceptsCSV(r) {
+		rw = &csvResultWriter{w: w, csv: csv.NewWriter(w)}
+	} else {
+		rw = &jsonResultWriter{w: w}
+	}
+	w.Header().Set("Trailer", httpQueryErrorTrailer)
+
+	session := newClearTextSession(getter, req.Target)
+	session, err = hh.vtg.StreamExecute(ctx, hh, session, req.SQL, bindVars, rw.write)
+	if session != nil {
+		// The transactions and reserved connections cannot outlive the
+		// request.
+		_ = hh.vtg.CloseSession(context.Background(), session)
+	}
+	rw.finish(err)
+}
+
+// KillQuery is part of the vtgateservice.MySQLConnection interface. KILL
+// statements are not supported by the HTTP query API.
+func (hh *httpQueryHandler) KillQuery(uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "KILL is not supported by the HTTP query API")
+}
+
+// KillConnection is part of the vtgateservice.MySQLConnection interface.
+func (hh *httpQueryHandler) KillConnection(context.Context, uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "KILL is not supported by the HTTP query API")
+}
+
+// httpBindValue converts a value decoded from JSON to a value accepted by
+// sqltypes.BuildBindVariable.
+func httpBindValue(value any) (any, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return u, nil
+		}
+		return value.Float64()
+	case []any:
+		values := make([]any, len(value))
+		for i, v := range value {
+			var err error
+			if values[i], err = httpBindValue(v); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case map[string]any:
+		return nil, fmt.Errorf("objects are not supported")
+	}
+	return value, nil
+}
+
+// acceptsCSV returns true if the request accepts text/csv responses.
+func acceptsCSV(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(accept); err == nil && mediaType == "text/csv" {
+			return true
+		}
+	}
+	return false
+}
+
+// httpQueryStatus returns the HTTP status of a query error.
+func httpQueryStatus(err error) int {
+	switch vterrors.Code(err) {
+	case vtrpcpb.Code_INVALID_ARGUMENT, vtrpcpb.Code_FAILED_PRECONDITION, vtrpcpb.Code_OUT_OF_RANGE, vtrpcpb.Code_ALREADY_EXISTS:
+		return http.StatusBadRequest
+	case vtrpcpb.Code_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case vtrpcpb.Code_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case vtrpcpb.Code_NOT_FOUND:
+		return http.StatusNotFound
+	case vtrpcpb.Code_RESOURCE_EXHAUSTED:
+		return http.StatusTooManyRequests
+	case vtrpcpb.Code_UNIMPLEMENTED:
+		return http.StatusNotImplemented
+	case vtrpcpb.Code_UNAVAILABLE:
+		return http.StatusServiceUnavailable
+	case vtrpcpb.Code_DEADLINE_EXCEEDED:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// httpQueryErrorJSON is the JSON representation of a query error.
+type httpQueryErrorJSON struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newHTTPQueryErrorJSON(err error) *httpQueryErrorJSON {
+	return &httpQueryErrorJSON{Code: vterrors.Code(err).String(), Message: err.Error()}
+}
+
+// writeHTTPQueryError writes the response of a request which fails before its
+// response started.
+func writeHTTPQueryError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	data, _ := json.Marshal(struct {
+		Error *httpQueryErrorJSON `json:"error"`
+	}{newHTTPQueryErrorJSON(err)})
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		log.Warningf("Cannot write the response of the HTTP query API: %v", err)
+	}
+}
+
+// httpResultWriter streams the results of a query.
+type httpResultWriter interface {
+	// write is called with each of the results streamed by vtgate.
+	write(qr *sqltypes.Result) error
+	// finish is called once the query completes.
+	finish(err error)
+}
+
+// jsonResultWriter streams the results of a query as a JSON object.
+type jsonResultWriter struct {
+	w            http.ResponseWriter
+	started      bool
+	rows         int
+	rowsAffected uint64
+	insertID     uint64
+	buf          bytes.Buffer
+}
+
+type jsonField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// start writes the beginning of the response up to the rows.
+func (jw *jsonResultWriter) start(fields []*querypb.Field) {
+	jw.started = true
+	jw.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	jsonFields := make([]jsonField, len(fields))
+	for i, field := range fields {
+		jsonFields[i] = jsonField{Name: field.Name, Type: field.Type.String()}
+	}
+	data, _ := json.Marshal(jsonFields)
+	jw.buf.WriteString(`{"fields":`)
+	jw.buf.Write(data)
+	jw.buf.WriteString(`,"rows":[`)
+}
+
+func (jw *jsonResultWriter) write(qr *sqltypes.Result) error {
+	if !jw.started {
+		if len(qr.Fields) == 0 && len(qr.Rows) == 0 && qr.RowsAffected == 0 && qr.InsertID == 0 {
+			return nil
+		}
+		jw.start(qr.Fields)
+	}
+	for _, row := range qr.Rows {
+		if jw.rows > 0 {
+			jw.buf.WriteByte(',')
+		}
+		jw.rows++
+		jw.buf.WriteString("\n[")
+		for i, v := range row {
+			if i > 0 {
+				jw.buf.WriteByte(',')
+			}
+			appendJSONValue(&jw.buf, v)
+		}
+		jw.buf.WriteByte(']')
+	}
+	jw.rowsAffected += qr.RowsAffected
+	if qr.InsertIDChanged || qr.InsertID != 0 {
+		jw.insertID = qr.InsertID
+	}
+	return jw.flush()
+}
+
+func (jw *jsonResultWriter) flush() error {
+	if _, err := jw.w.Write(jw.buf.Bytes()); err != nil {
+		return err
+	}
+	jw.buf.Reset()
+	return http.NewResponseController(jw.w).Flush()
+}
+
+func (jw *jsonResultWriter) finish(err error) {
+	if !jw.started {
+		if err != nil {
+			writeHTTPQueryError(jw.w, httpQueryStatus(err), err)
+			return
+		}
+		jw.start(nil)
+	}
+	fmt.Fprintf(&jw.buf, "\n],\"rows_affected\":%d,\"insert_id\":%d", jw.rowsAffected, jw.insertID)
+	if err != nil {
+		data, _ := json.Marshal(newHTTPQueryErrorJSON(err))
+		jw.buf.WriteString(`,"error":`)
+		jw.buf.Write(data)
+		jw.w.Header().Set(httpQueryErrorTrailer, err.Error())
+	}
+	jw.buf.WriteString("}\n")
+	if err := jw.flush(); err != nil {
+		log.Warningf("Cannot write the response of the HTTP query API: %v", err)
+	}
+}
+
+// appendJSONValue appends the JSON representation of a value: the numbers
+// are written as JSON numbers, and the other values as JSON strings.
+func appendJSONValue(buf *bytes.Buffer, v sqltypes.Value) {
+	switch {
+	case v.IsNull():
+		buf.WriteString("null")
+	case v.IsIntegral() || v.IsFloat() || v.IsDecimal():
+		buf.Write(v.Raw())
+	default:
+		data, _ := json.Marshal(v.RawStr())
+		buf.Write(data)
+	}
+}
+
+// csvResultWriter streams the results of a query as CSV, with a header row
+// of the column names. NULL values are written as empty fields.
+type csvResultWriter struct {
+	w       http.ResponseWriter
+	csv     *csv.Writer
+	started bool
+}
+
+func (cw *csvResultWriter) start(fields []*querypb.Field) error {
+	cw.started = true
+	cw.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return cw.csv.Write(names)
+}
+
+func (cw *csvResultWriter) write(qr *sqltypes.Result) error {
+	if !cw.started {
+		if len(qr.Fields) == 0 && len(qr.Rows) == 0 {
+			return nil
+		}
+		if err := cw.start(qr.Fields); err != nil {
+			return err
+		}
+	}
+	record := make([]string, 0, len(qr.Fields))
+	for _, row := range qr.Rows {
+		record = record[:0]
+		for _, v := range row {
+			record = append(record, v.RawStr())
+		}
+		if err := cw.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.csv.Flush()
+	if err := cw.csv.Error(); err != nil {
+		return err
+	}
+	return http.NewResponseController(cw.w).Flush()
+}
+
+func (cw *csvResultWriter) finish(err error) {
+	if !cw.started {
+		if err != nil {
+			writeHTTPQueryError(cw.w, httpQueryStatus(err), err)
+			return
+		}
+		// The statements which return no rows have an empty response.
+		cw.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		return
+	}
+	if err != nil {
+		cw.w.Header().Set(httpQueryErrorTrailer, err.Error())
+	}
+	cw.csv.Flush()
+	if err := cw.csv.Error(); err != nil {
+		log.Warningf("Cannot write the response of the HTTP query API: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestHTTPQueryHandler(t *testing.T) {
+	executor, _, _, sbclookup, _ := createExecutorEnv(t)
+	vtg := &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed}
+	handler := &httpQueryHandler{vtg: vtg, authServer: mysql.NewAuthServerNone()}
+
+	newRequest := func(method, body string, header http.Header) *http.Request {
+		req := httptest.NewRequest(method, httpQueryPath, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		return req
+	}
+	serve := func(req *http.Request) *http.Response {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	query := func(method, body string, header http.Header) *http.Response {
+		return serve(newRequest(method, body, header))
+	}
+	readBody := func(resp *http.Response) string {
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name|price", "int64|varchar|decimal"), "1|abc|1.50", "2|d\"e,f|null")
+
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	resp := query(http.MethodPost, `{"sql": "select id, name, price from main1 where id in ::ids and name = :name", "bind_variables": {"ids": [1, 2], "name": "abc"}, "target": "`+KsTestUnsharded+`"}`, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"fields":[{"name":"id","type":"INT64"},{"name":"name","type":"VARCHAR"},{"name":"price","type":"DECIMAL"}],"rows":[
+[1,"abc",1.50],
+[2,"d\"e,f",null]
+],"rows_affected":0,"insert_id":0}
+`, readBody(resp))
+	assert.Empty(t, resp.Trailer.Get(httpQueryErrorTrailer))
+	require.Len(t, sbclookup.Queries, 1)
+	assert.Equal(t, sqltypes.TestBindVariable([]any{int64(1), int64(2)}), sbclookup.Queries[0].BindVariables["ids"])
+	assert.Equal(t, sqltypes.StringBindVariable("abc"), sbclookup.Queries[0].BindVariables["name"])
+
+	sbclookup.SetResults([]*sqltypes.Result{result})
+	resp = query(http.MethodPost, `{"sql": "select id, name, price from main1", "target": "`+KsTestUnsharded+`"}`, http.Header{"Accept": {"text/csv"}})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id,name,price\n1,abc,1.50\n2,\"d\"\"e,f\",\n", readBody(resp))
+
+	sbclookup.SetResults([]*sqltypes.Result{{RowsAffected: 1, InsertID: 5}})
+	resp = query(http.MethodPost, `{"sql": "insert into main1(id) values (5)", "target": "`+KsTestUnsharded+`"}`, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "{\"fields\":[],\"rows\":[\n],\"rows_affected\":1,\"insert_id\":5}\n", readBody(resp))
+
+	resp = query(http.MethodPost, `{"sql": "selec 1"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, readBody(resp), `{"error":{"code":"INVALID_ARGUMENT","message":"syntax error`)
+
+	resp = query(http.MethodPost, `{"sql": "select 1", "bind_variables": {"a": {"b": 1}}}`, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, readBody(resp), "invalid bind variable a: objects are not supported")
+
+	resp = query(http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	// The forms of other sites cannot send queries.
+	resp = query(http.MethodPost, `{"sql": "select 1"}`, http.Header{"Content-Type": {"text/plain"}})
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	vtg.draining.Store(true)
+	resp = query(http.MethodPost, `{"sql": "select 1"}`, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	vtg.draining.Store(false)
+
+	handler.authServer = mysql.NewAuthServerStatic("", `{"user1": [{"Password": "password1"}]}`, 0)
+	resp = query(http.MethodPost, `{"sql": "select 1"}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, `Basic realm="vtgate"`, resp.Header.Get("WWW-Authenticate"))
+
+	// The passwords are only accepted over HTTPS.
+	req := newRequest(http.MethodPost, `{"sql": "select id from main1", "target": "`+KsTestUnsharded+`"}`, nil)
+	req.SetBasicAuth("user1", "password1")
+	resp = serve(req)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Contains(t, readBody(resp), "basic authentication requires an HTTPS connection")
+
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"), "1")})
+	req = newRequest(http.MethodPost, `{"sql": "select id from main1", "target": "`+KsTestUnsharded+`"}`, nil)
+	req.SetBasicAuth("user1", "password1")
+	req.TLS = &tls.ConnectionState{}
+	resp = serve(req)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "{\"fields\":[{\"name\":\"id\",\"type\":\"INT64\"}],\"rows\":[\n[1]\n],\"rows_affected\":0,\"insert_id\":0}\n", readBody(resp))
+}
+
+func TestHTTPBindValue(t *testing.T) {
+	bv, err := httpBindValue([]any{"a", nil, true})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", nil, true}, bv)
+
+	for _, tc := range []struct {
+		in   string
+		want any
+	}{
+		{"1", int64(1)},
+		{"18446744073709551615", uint64(18446744073709551615)},
+		{"1.5", 1.5},
+	} {
+		got, err := httpBindValue(json.Number(tc.in))
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+}
@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"regexp"
@@ -32,6 +33,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/spf13/pflag"
 
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 
@@ -42,9 +45,12 @@ import (
 	"vitess.io/vitess/go/vt/callinfo"
 	"vitess.io/vitess/go/vt/log"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/utils"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
@@ -59,6 +65,7 @@ var (
 	mysqlAuthServerImpl               = "static"
 	mysqlAllowClearTextWithoutTLS     bool
 	mysqlProxyProtocol                bool
+	mysqlProxyProtocolTrustedProxies  []string
 	mysqlServerRequireSecureTransport bool
 	mysqlSslCert                      string
 	mysqlSslKey                       string
@@ -77,9 +84,14 @@ var (
 	mysqlDefaultWorkloadName = "OLTP"
 	mysqlDefaultWorkload     int32
 	mysqlDrainOnTerm         bool
+	mysqlDrainTimeout        time.Duration
+
+	mysqlDefaultResultChecksum bool
 
 	mysqlServerFlushDelay = 100 * time.Millisecond
 	mysqlServerMultiQuery = false
+
+	mysqlServerHealthCheckUser string
 )
 
 func registerPluginFlags(fs *pflag.FlagSet) {
@@ -90,6 +102,7 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &mysqlAuthServerImpl, "mysql-auth-server-impl", mysqlAuthServerImpl, "Which auth server implementation to use. Options: none, ldap, clientcert, static, vault.")
 	utils.SetFlagBoolVar(fs, &mysqlAllowClearTextWithoutTLS, "mysql-allow-clear-text-without-tls", mysqlAllowClearTextWithoutTLS, "If set, the server will allow the use of a clear text password over non-SSL connections.")
 	utils.SetFlagBoolVar(fs, &mysqlProxyProtocol, "proxy-protocol", mysqlProxyProtocol, "Enable HAProxy PROXY protocol on MySQL listener socket")
+	utils.SetFlagStringSliceVar(fs, &mysqlProxyProtocolTrustedProxies, "proxy-protocol-trusted-proxies", mysqlProxyProtocolTrustedProxies, "Comma-separated list of the IP addresses and CIDR ranges of the load balancers trusted to send a PROXY protocol header. If set, the connections from any other address which send a header are rejected.")
 	utils.SetFlagBoolVar(fs, &mysqlServerRequireSecureTransport, "mysql-server-require-secure-transport", mysqlServerRequireSecureTransport, "Reject insecure connections but only if mysql-server-ssl-cert and mysql-server-ssl-key are provided")
 	utils.SetFlagStringVar(fs, &mysqlSslCert, "mysql-server-ssl-cert", mysqlSslCert, "Path to the ssl cert for mysql server plugin SSL")
 	utils.SetFlagStringVar(fs, &mysqlSslKey, "mysql-server-ssl-key", mysqlSslKey, "Path to ssl key for mysql server plugin SSL")
@@ -105,8 +118,11 @@ func registerPluginFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&mysqlKeepAlivePeriod, "mysql-server-keepalive-period", mysqlKeepAlivePeriod, "TCP period between keep-alives")
 	utils.SetFlagDurationVar(fs, &mysqlServerFlushDelay, "mysql-server-flush-delay", mysqlServerFlushDelay, "Delay after which buffered response will be flushed to the client.")
 	utils.SetFlagStringVar(fs, &mysqlDefaultWorkloadName, "mysql-default-workload", mysqlDefaultWorkloadName, "Default session workload (OLTP, OLAP, DBA)")
+	utils.SetFlagBoolVar(fs, &mysqlDefaultResultChecksum, "mysql-default-result-checksum", mysqlDefaultResultChecksum, "If set, the sessions verify the checksums of the results of the tablets, and set the one of their last result in @@last_result_checksum, unless they set @@result_checksum to off")
 	fs.BoolVar(&mysqlDrainOnTerm, "mysql-server-drain-onterm", mysqlDrainOnTerm, "If set, the server waits for --onterm-timeout for already connected clients to complete their in flight work")
+	utils.SetFlagDurationVar(fs, &mysqlDrainTimeout, "mysql-server-drain-timeout", mysqlDrainTimeout, "If set, vtgate can be drained before a restart by sending it SIGUSR1 or a POST request to /drain: it stops accepting MySQL connections, reports itself unhealthy on /debug/health, waits up to this long for in flight queries and transactions to complete, and then exits")
 	utils.SetFlagBoolVar(fs, &mysqlServerMultiQuery, "mysql-server-multi-query-protocol", mysqlServerMultiQuery, "If set, the server will use the new implementation of handling queries where-in multiple queries are sent together.")
+	utils.SetFlagStringVar(fs, &mysqlServerHealthCheckUser, "mysql-server-health-check-user", mysqlServerHealthCheckUser, "If set, the queries of this user, authenticated by the auth server like any other, are not executed: they return a single ok row if vtgate is ready to serve queries, as reported by /debug/ready, and an error with the reason otherwise. Meant for the MySQL health checks of load balancers.")
 }
 
 // vtgateHandler implements the Listener interface.
@@ -150,6 +166,14 @@ func (vh *vtgateHandler) ComResetConnection(c *mysql.Conn) {
 	if err != nil {
 		log.Errorf("Error happened in transaction rollback: %v", err)
 	}
+	// Start a new session with the defaults of the user of the connection,
+	// so the user defined variables and the system settings are reset too.
+	// As with MySQL, the selected database, along with the tablet type, is
+	// kept.
+	target := session.TargetString
+	c.ClientData = nil
+	vh.session(c).TargetString = target
+	c.StatusFlags = c.StatusFlags&mysql.NoServerStatusInTrans | mysql.ServerStatusAutocommit
 }
 
 func (vh *vtgateHandler) ConnectionClosed(c *mysql.Conn) {
@@ -210,6 +234,68 @@ func startSpan(ctx context.Context, query, label string) (trace.Span, context.Co
 	return startSpanTestable(ctx, query, label, trace.NewSpan, trace.NewFromString)
 }
 
+// queryAttributeTabletType is the query attribute which routes a query to the
+// tablets of the given type, as if the session targeted them.
+const queryAttributeTabletType = "vt_tablet_type"
+
+// withQueryAttributes adds the query attributes of the session to the context
+// and its span, and applies the ones routing the query to the session. The
+// application-defined connection attributes, the ones which are not prefixed
+// with an underscore, are the defaults of the attributes sent with each query.
+// The returned function must be called once the query was executed.
+func withQueryAttributes(ctx context.Context, c *mysql.Conn, session *vtgatepb.Session) (context.Context, func(), error) {
+	var attributes map[string]string
+	for key, value := range c.Attributes {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if attributes == nil {
+			attributes = make(map[string]string)
+		}
+		attributes[key] = value
+	}
+	for key, value := range c.QueryAttributes {
+		if attributes == nil {
+			attributes = make(map[string]string, len(c.QueryAttributes))
+		}
+		attributes[key] = value
+	}
+	if len(attributes) == 0 {
+		return ctx, func() {}, nil
+	}
+
+	if span, ok := trace.FromContext(ctx); ok {
+		for key, value := range attributes {
+			span.Annotate("query_attribute."+key, value)
+		}
+	}
+	ctx = callinfo.NewContextWithQueryAttributes(ctx, attributes)
+
+	// The tablet type of a query cannot be changed within a transaction.
+	tabletType, ok := attributes[queryAttributeTabletType]
+	if !ok || session.InTransaction {
+		return ctx, func() {}, nil
+	}
+	tt, err := topoproto.ParseTabletType(tabletType)
+	if err != nil {
+		return nil, nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s query attribute: %v", queryAttributeTabletType, err)
+	}
+	original := session.TargetString
+	keyspace := original
+	if i := strings.LastIndexByte(keyspace, '@'); i >= 0 {
+		keyspace = keyspace[:i]
+	}
+	target := keyspace + "@" + topoproto.TabletTypeLString(tt)
+	session.TargetString = target
+	return ctx, func() {
+		// Keep the target if the query changed it, or opened a transaction
+		// against it.
+		if session.TargetString == target && !session.InTransaction {
+			session.TargetString = original
+		}
+	}, nil
+}
+
 func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
 	session := vh.session(c)
 	if c.IsShuttingDown() && !session.InTransaction {
@@ -217,6 +303,14 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
 	}
 
+	if isHealthCheckUser(c) {
+		result, err := vh.healthCheck()
+		if err != nil {
+			return err
+		}
+		return callback(result)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
 
@@ -245,6 +339,12 @@ func (vh *vtgateHandler) ComQuery(c *mysql.Conn, query string, callback func(*sq
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
 
+	ctx, done, err := withQueryAttributes(ctx, c, session)
+	if err != nil {
+		return sqlerror.NewSQLErrorFromError(err)
+	}
+	defer done()
+
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -279,6 +379,11 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		return sqlerror.NewSQLError(sqlerror.ERServerShutdown, sqlerror.SSNetError, "Server shutdown in progress")
 	}
 
+	if isHealthCheckUser(c) {
+		result, err := vh.healthCheck()
+		return callback(sqltypes.QueryResponse{QueryResult: result, QueryError: err}, false, true)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	c.UpdateCancelCtx(cancel)
 
@@ -302,6 +407,12 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 		"VTGate MySQL Connector" /* subcomponent: part of the client */)
 	ctx = callerid.NewContext(ctx, ef, im)
 
+	ctx, done, err := withQueryAttributes(ctx, c, session)
+	if err != nil {
+		return sqlerror.NewSQLErrorFromError(err)
+	}
+	defer done()
+
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -354,6 +465,23 @@ func (vh *vtgateHandler) ComQueryMulti(c *mysql.Conn, sql string, callback func(
 	return nil
 }
 
+func isHealthCheckUser(c *mysql.Conn) bool {
+	return mysqlServerHealthCheckUser != "" && c.User == mysqlServerHealthCheckUser
+}
+
+// healthCheck answers the queries of the --mysql-server-health-check-user
+// without executing them: it returns a single ok row if vtgate is ready to
+// serve queries, and an error with the reason otherwise.
+func (vh *vtgateHandler) healthCheck() (*sqltypes.Result, error) {
+	if err := vh.vtg.IsReady(); err != nil {
+		return nil, sqlerror.NewSQLErrorf(sqlerror.ERServerShutdown, sqlerror.SSNetError, "vtgate is not ready: %s", err.Error())
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{{Name: "health", Type: sqltypes.VarChar, Charset: uint32(collations.SystemCollation.Collation)}},
+		Rows:   [][]sqltypes.Value{{sqltypes.NewVarChar("ok")}},
+	}, nil
+}
+
 func fillInTxStatusFlags(c *mysql.Conn, session *vtgatepb.Session) {
 	if session.InTransaction {
 		c.StatusFlags |= mysql.ServerStatusInTrans
@@ -434,6 +562,12 @@ func (vh *vtgateHandler) ComStmtExecute(c *mysql.Conn, prepare *mysql.PrepareDat
 	ctx = callerid.NewContext(ctx, ef, im)
 
 	session := vh.session(c)
+	ctx, done, err := withQueryAttributes(ctx, c, session)
+	if err != nil {
+		return sqlerror.NewSQLErrorFromError(err)
+	}
+	defer done()
+
 	if !session.InTransaction {
 		vh.busyConnections.Add(1)
 	}
@@ -522,6 +656,7 @@ func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 			Options: &querypb.ExecuteOptions{
 				IncludedFields: querypb.ExecuteOptions_ALL,
 				Workload:       querypb.ExecuteOptions_Workload(mysqlDefaultWorkload),
+				ResultChecksum: mysqlDefaultResultChecksum,
 
 				// The collation field of ExecuteOption is set right before an execution.
 			},
@@ -534,18 +669,98 @@ func (vh *vtgateHandler) session(c *mysql.Conn) *vtgatepb.Session {
 		if c.Capabilities&mysql.CapabilityClientFoundRows != 0 {
 			session.Options.ClientFoundRows = true
 		}
+		if getter, ok := c.UserData.(mysql.SessionDefaultsGetter); ok {
+			applySessionDefaults(session, getter.GetSessionDefaults())
+		}
 		c.ClientData = session
 	}
 	return session
 }
 
+// applySessionDefaults applies the default session settings of the authenticated user, as found
+// in the auth server configuration, onto a new session. The defaults are validated when the
+// configuration is loaded.
+func applySessionDefaults(session *vtgatepb.Session, defaults *mysql.SessionDefaults) {
+	if defaults == nil {
+		return
+	}
+	if workload, ok := querypb.ExecuteOptions_Workload_value[strings.ToUpper(defaults.Workload)]; ok {
+		session.Options.Workload = querypb.ExecuteOptions_Workload(workload)
+	}
+	if defaults.TabletType != "" {
+		session.TargetString = "@" + strings.ToLower(defaults.TabletType)
+		session.DefaultTabletType, _ = topoproto.ParseTabletType(defaults.TabletType)
+	}
+	if transactionMode, ok := vtgatepb.TransactionMode_value[strings.ToUpper(defaults.TransactionMode)]; ok {
+		session.TransactionMode = vtgatepb.TransactionMode(transactionMode)
+	}
+	if defaults.QueryTimeout > 0 {
+		session.QueryTimeout = defaults.QueryTimeout
+	}
+	if defaults.MaxQueryTimeout > 0 {
+		session.MaxQueryTimeout = defaults.MaxQueryTimeout
+	}
+}
+
+// authenticateClearText checks the password of a user with the auth server
+// for the protocols which receive it in clear text, such as the PostgreSQL
+// protocol listener. The password is only read if the auth server checks it.
+func authenticateClearText(authServer mysql.AuthServer, protocol, user string, password func() (string, error), remoteAddr net.Addr) (mysql.Getter, error) {
+	switch as := authServer.(type) {
+	case *mysql.AuthServerNone:
+		return &mysql.NoneGetter{}, nil
+	case *mysql.AuthServerClientCert:
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "client certificates are not supported by %s", protocol)
+	case mysql.PlainTextStorage:
+		pw, err := password()
+		if err != nil {
+			return nil, err
+		}
+		return as.UserEntryWithPassword(nil, user, pw, remoteAddr)
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_UNAUTHENTICATED, "the auth server cannot check the passwords of %s", protocol)
+	}
+}
+
+// newClearTextSession returns a new autocommit session for a user
+// authenticated by authenticateClearText, which targets the given target with
+// the tablet type of the session defaults of the user if it has none.
+func newClearTextSession(getter mysql.Getter, target string) *vtgatepb.Session {
+	u, _ := uuid.NewUUID()
+	session := &vtgatepb.Session{
+		Options: &querypb.ExecuteOptions{
+			IncludedFields: querypb.ExecuteOptions_ALL,
+		},
+		Autocommit:  true,
+		SessionUUID: u.String(),
+	}
+	if getter, ok := getter.(mysql.SessionDefaultsGetter); ok {
+		applySessionDefaults(session, getter.GetSessionDefaults())
+	}
+	// The session defaults can set the tablet type of the target, unless it
+	// has its own. The invalid targets are left to fail the queries.
+	if _, tabletType, _, _, err := topoproto.ParseDestination(target, topodatapb.TabletType_UNKNOWN); err != nil || tabletType != topodatapb.TabletType_UNKNOWN {
+		session.TargetString = target
+	} else {
+		session.TargetString = target + session.TargetString
+	}
+	return session
+}
+
 type mysqlServer struct {
 	tcpListener  *mysql.Listener
 	unixListener *mysql.Listener
 	sigChan      chan os.Signal
+	drainSigChan chan os.Signal
 	vtgateHandle *vtgateHandler
 }
 
+// drainExit terminates vtgate once it has been drained. It is a variable so
+// tests can replace it.
+var drainExit = func() {
+	_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+}
+
 // initTLSConfig inits tls config for the given mysql listener
 func initTLSConfig(ctx context.Context, srv *mysqlServer, mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA string, mysqlServerRequireSecureTransport bool, mysqlMinTLSVersion uint16) error {
 	serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, mysqlMinTLSVersion)
@@ -589,11 +804,7 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 		return nil
 	}
 
-	// Initialize registered AuthServer implementations (or other plugins)
-	for _, initFn := range pluginInitializers {
-		initFn()
-	}
-	authServer := mysql.GetAuthServer(mysqlAuthServerImpl)
+	authServer := initAuthServer()
 
 	// Check mysql-default-workload
 	var ok bool
@@ -613,14 +824,22 @@ func initMySQLProtocol(vtgate *VTGate) *mysqlServer {
 	srv := &mysqlServer{}
 	srv.vtgateHandle = newVtgateHandler(vtgate)
 	if mysqlServerPort >= 0 {
-		srv.tcpListener, err = mysql.NewListener(
-			mysqlTCPVersion,
-			net.JoinHostPort(mysqlServerBindAddress, strconv.Itoa(mysqlServerPort)),
+		listener, err := net.Listen(mysqlTCPVersion, net.JoinHostPort(mysqlServerBindAddress, strconv.Itoa(mysqlServerPort)))
+		if err != nil {
+			log.Exitf("mysql.NewListener failed: %v", err)
+		}
+		if mysqlProxyProtocol {
+			listener, err = mysql.NewProxyProtocolListener(listener, mysqlProxyProtocolTrustedProxies)
+			if err != nil {
+				log.Exitf("mysql.NewListener failed: %v", err)
+			}
+		}
+		srv.tcpListener, err = mysql.NewFromListener(
+			listener,
 			authServer,
 			srv.vtgateHandle,
 			mysqlConnReadTimeout,
 			mysqlConnWriteTimeout,
-			mysqlProxyProtocol,
 			mysqlConnBufferPooling,
 			mysqlKeepAlivePeriod,
 			mysqlServerFlushDelay,
@@ -712,10 +931,79 @@ func newMysqlUnixSocket(address string, authServer mysql.AuthServer, handler mys
 	}
 }
 
+// registerDrain lets vtgate be drained on SIGUSR1 or a POST request to the
+// /drain endpoint, if --mysql-server-drain-timeout is set.
+func (srv *mysqlServer) registerDrain() {
+	if mysqlDrainTimeout <= 0 {
+		return
+	}
+	srv.drainSigChan = make(chan os.Signal, 1)
+	signal.Notify(srv.drainSigChan, syscall.SIGUSR1)
+	go func() {
+		if _, ok := <-srv.drainSigChan; ok {
+			srv.drain()
+		}
+	}()
+
+	servenv.HTTPHandleFunc("/drain", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		go srv.drain()
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("draining"))
+	})
+}
+
+// drain stops accepting MySQL connections, makes vtgate report itself as
+// unhealthy so load balancers stop routing to it, and waits up to
+// --mysql-server-drain-timeout for the in flight queries and transactions to
+// complete before terminating vtgate. Idle connections are told to reconnect
+// elsewhere on their next query.
+func (srv *mysqlServer) drain() {
+	if !srv.vtgateHandle.vtg.draining.CompareAndSwap(false, true) {
+		return
+	}
+	log.Infof("Draining vtgate, waiting up to %v for in flight work to complete", mysqlDrainTimeout)
+	stopListener(srv.unixListener, true)
+	stopListener(srv.tcpListener, true)
+
+	deadline := time.Now().Add(mysqlDrainTimeout)
+	reported := time.Now()
+	for busy := srv.vtgateHandle.busyConnections.Load(); busy > 0; busy = srv.vtgateHandle.busyConnections.Load() {
+		if time.Now().After(deadline) {
+			log.Warningf("Drain timed out with %d client connections still active", busy)
+			break
+		}
+		if time.Since(reported) > 2*time.Second {
+			log.Infof("Still waiting for client connections to be idle (%d active)...", busy)
+			reported = time.Now()
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	log.Infof("Drain complete, shutting down")
+	drainExit()
+}
+
 func (srv *mysqlServer) shutdownMysqlProtocolAndDrain() {
 	if srv.sigChan != nil {
 		signal.Stop(srv.sigChan)
 	}
+	if srv.drainSigChan != nil {
+		signal.Stop(srv.drainSigChan)
+	}
+	if srv.vtgateHandle.vtg.draining.Load() {
+		// The drain already waited for the in flight work, and may still
+		// be using the listeners.
+		stopListener(srv.unixListener, true)
+		stopListener(srv.tcpListener, true)
+		return
+	}
 	setListenerToNil := func() {
 		srv.tcpListener = nil
 		srv.unixListener = nil
@@ -822,6 +1110,17 @@ func init() {
 
 var pluginInitializers []func()
 
+// initAuthServer initializes the registered AuthServer implementations (or
+// other plugins) once, and returns the AuthServer selected by
+// --mysql-auth-server-impl. It is shared by the MySQL and PostgreSQL protocol
+// listeners.
+var initAuthServer = sync.OnceValue(func() mysql.AuthServer {
+	for _, initFn := range pluginInitializers {
+		initFn()
+	}
+	return mysql.GetAuthServer(mysqlAuthServerImpl)
+})
+
 // RegisterPluginInitializer lets plugins register themselves to be init'ed at servenv.OnRun-time
 func RegisterPluginInitializer(initializer func()) {
 	pluginInitializers = append(pluginInitializers, initializer)
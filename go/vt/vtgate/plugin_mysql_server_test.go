@@ -20,12 +20,16 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"strings"
 	"syscall"
 	"testing"
 	"testing/synctest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,7 +41,11 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/test/utils"
 	"vitess.io/vitess/go/trace"
+	"vitess.io/vitess/go/vt/callinfo"
+	"vitess.io/vitess/go/vt/discovery"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/tlstest"
 	"vitess.io/vitess/go/vt/vtenv"
@@ -289,6 +297,111 @@ func TestDefaultWorkloadOLAP(t *testing.T) {
 	}
 }
 
+func TestSessionDefaultsFromUserData(t *testing.T) {
+	vh := &vtgateHandler{}
+	mysqlDefaultWorkload = int32(querypb.ExecuteOptions_OLTP)
+	c := &mysql.Conn{
+		UserData: &mysql.StaticUserData{
+			Username: "analytics",
+			SessionDefaults: &mysql.SessionDefaults{
+				Workload:        "olap",
+				TabletType:      "RDONLY",
+				TransactionMode: "single",
+				QueryTimeout:    30000,
+				MaxQueryTimeout: 60000,
+			},
+		},
+	}
+	sess := vh.session(c)
+	assert.Equal(t, querypb.ExecuteOptions_OLAP, sess.Options.Workload)
+	assert.Equal(t, "@rdonly", sess.TargetString)
+	assert.Equal(t, vtgatepb.TransactionMode_SINGLE, sess.TransactionMode)
+	assert.EqualValues(t, 30000, sess.QueryTimeout)
+	assert.EqualValues(t, 60000, sess.MaxQueryTimeout)
+
+	// A user without defaults gets the global defaults
+	sess = vh.session(&mysql.Conn{UserData: &mysql.StaticUserData{Username: "app"}})
+	assert.Equal(t, querypb.ExecuteOptions_OLTP, sess.Options.Workload)
+	assert.Empty(t, sess.TargetString)
+	assert.Equal(t, vtgatepb.TransactionMode_UNSPECIFIED, sess.TransactionMode)
+	assert.Zero(t, sess.QueryTimeout)
+	assert.Zero(t, sess.MaxQueryTimeout)
+}
+
+func TestUseKeepsDefaultTabletType(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed})
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{Username: "analytics", SessionDefaults: &mysql.SessionDefaults{TabletType: "RDONLY"}}
+	vh.connections[1] = mysqlConn
+
+	tcases := []struct {
+		query  string
+		target string
+	}{
+		{query: "use " + KsTestUnsharded, target: KsTestUnsharded + "@rdonly"},
+		{query: "use `" + KsTestUnsharded + "@replica`", target: KsTestUnsharded + "@replica"},
+		{query: "use " + KsTestUnsharded, target: KsTestUnsharded + "@rdonly"},
+	}
+	for _, tcase := range tcases {
+		err = vh.ComQuery(mysqlConn, tcase.query, func(result *sqltypes.Result) error {
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tcase.target, vh.session(mysqlConn).TargetString, tcase.query)
+	}
+}
+
+func TestNewClearTextSession(t *testing.T) {
+	getter := &mysql.StaticUserData{Username: "analytics", SessionDefaults: &mysql.SessionDefaults{TabletType: "rdonly"}}
+	assert.Equal(t, "ks@rdonly", newClearTextSession(getter, "ks").TargetString)
+	assert.Equal(t, "@rdonly", newClearTextSession(getter, "").TargetString)
+	// The tablet type of the target wins over the one of the defaults.
+	assert.Equal(t, "ks@replica", newClearTextSession(getter, "ks@replica").TargetString)
+	assert.Equal(t, "ks:-80@primary", newClearTextSession(getter, "ks:-80@primary").TargetString)
+	assert.Equal(t, "ks", newClearTextSession(&mysql.StaticUserData{Username: "app"}, "ks").TargetString)
+}
+
+func TestWithQueryAttributes(t *testing.T) {
+	c := &mysql.Conn{
+		Attributes:      mysql.ConnectionAttributes{"_client_name": "libmysql", "team": "infra", "app": "api"},
+		QueryAttributes: map[string]string{"app": "worker", queryAttributeTabletType: "replica"},
+	}
+	session := &vtgatepb.Session{TargetString: "ks@primary"}
+	ctx, done, err := withQueryAttributes(context.Background(), c, session)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "infra", "app": "worker", queryAttributeTabletType: "replica"}, callinfo.QueryAttributesFromContext(ctx))
+	assert.Equal(t, "ks@replica", session.TargetString)
+	done()
+	assert.Equal(t, "ks@primary", session.TargetString)
+
+	// The tablet type cannot be changed within a transaction.
+	session.InTransaction = true
+	_, done, err = withQueryAttributes(context.Background(), c, session)
+	require.NoError(t, err)
+	assert.Equal(t, "ks@primary", session.TargetString)
+	done()
+
+	c.QueryAttributes[queryAttributeTabletType] = "bogus"
+	session.InTransaction = false
+	_, _, err = withQueryAttributes(context.Background(), c, session)
+	assert.ErrorContains(t, err, "invalid vt_tablet_type query attribute")
+
+	// Without attributes, the context is left as is.
+	ctx, done, err = withQueryAttributes(context.Background(), &mysql.Conn{}, session)
+	require.NoError(t, err)
+	assert.Nil(t, callinfo.QueryAttributesFromContext(ctx))
+	done()
+	assert.Equal(t, "ks@primary", session.TargetString)
+}
+
 func TestInitTLSConfigWithoutServerCA(t *testing.T) {
 	testInitTLSConfig(t, false)
 }
@@ -907,3 +1020,216 @@ func TestGracefulShutdownWithTransaction(t *testing.T) {
 
 	require.True(t, mysqlConn.IsMarkedForClose())
 }
+
+func TestComResetConnection(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vh := newVtgateHandler(&VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed})
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mysqlConn := mysql.GetTestServerConn(listener)
+	mysqlConn.ConnectionID = 1
+	mysqlConn.UserData = &mysql.StaticUserData{}
+	vh.connections[1] = mysqlConn
+
+	for _, query := range []string{"use " + KsTestUnsharded + "@replica", "set @foo = 1", "BEGIN", "select 1"} {
+		err = vh.ComQuery(mysqlConn, query, func(result *sqltypes.Result) error {
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	session := vh.session(mysqlConn)
+	assert.True(t, session.InTransaction)
+	assert.Contains(t, session.UserDefinedVariables, "foo")
+	assert.EqualValues(t, 1, vh.busyConnections.Load())
+
+	// The session is replaced, with the defaults of the user, but keeps the
+	// selected database.
+	mysqlConn.UserData = &mysql.StaticUserData{
+		Username:        "app",
+		SessionDefaults: &mysql.SessionDefaults{Workload: "OLAP"},
+	}
+	vh.ComResetConnection(mysqlConn)
+	assert.Zero(t, vh.busyConnections.Load())
+	assert.Zero(t, mysqlConn.StatusFlags&mysql.ServerStatusInTrans)
+
+	newSession := vh.session(mysqlConn)
+	assert.NotSame(t, session, newSession)
+	assert.False(t, newSession.InTransaction)
+	assert.Empty(t, newSession.UserDefinedVariables)
+	assert.Equal(t, KsTestUnsharded+"@replica", newSession.TargetString)
+	assert.Equal(t, querypb.ExecuteOptions_OLAP, newSession.Options.Workload)
+	assert.NotEqual(t, "", newSession.SessionUUID)
+	assert.NotEqual(t, session.SessionUUID, newSession.SessionUUID)
+}
+
+func TestDrainWithTransaction(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vtg := &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed}
+	vh := newVtgateHandler(vtg)
+	th := &testHandler{}
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), th, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+	srv := &mysqlServer{tcpListener: listener, vtgateHandle: vh}
+
+	exited := make(chan struct{})
+	defer func(old func()) { drainExit = old }(drainExit)
+	drainExit = func() { close(exited) }
+	defer func(old time.Duration) { mysqlDrainTimeout = old }(mysqlDrainTimeout)
+	mysqlDrainTimeout = time.Minute
+
+	// add a connection in a transaction, and an idle one
+	txConn := mysql.GetTestServerConn(listener)
+	txConn.ConnectionID = 1
+	txConn.UserData = &mysql.StaticUserData{}
+	vh.connections[1] = txConn
+	idleConn := mysql.GetTestServerConn(listener)
+	idleConn.ConnectionID = 2
+	idleConn.UserData = &mysql.StaticUserData{}
+	vh.connections[2] = idleConn
+
+	err = vh.ComQuery(txConn, "BEGIN", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, vtg.IsHealthy())
+
+	go srv.drain()
+	require.Eventually(t, func() bool {
+		return vtg.IsHealthy() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualError(t, vtg.IsHealthy(), "vtgate is draining")
+
+	// The idle connection is told to go away, while the transaction can
+	// complete.
+	err = vh.ComQuery(idleConn, "select 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.EqualError(t, err, "Server shutdown in progress (errno 1053) (sqlstate 08S01)")
+
+	err = vh.ComQuery(txConn, "select 1", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	select {
+	case <-exited:
+		t.Fatal("drain completed with a transaction in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	err = vh.ComQuery(txConn, "COMMIT", func(result *sqltypes.Result) error {
+		return nil
+	})
+	require.NoError(t, err)
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not complete after the transaction was committed")
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+
+	vtg := &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed}
+	vh := newVtgateHandler(vtg)
+	srv := &mysqlServer{vtgateHandle: vh}
+
+	exited := false
+	defer func(old func()) { drainExit = old }(drainExit)
+	drainExit = func() { exited = true }
+	defer func(old time.Duration) { mysqlDrainTimeout = old }(mysqlDrainTimeout)
+	mysqlDrainTimeout = 100 * time.Millisecond
+
+	vh.busyConnections.Add(1)
+	start := time.Now()
+	srv.drain()
+	assert.True(t, exited)
+	assert.GreaterOrEqual(t, time.Since(start), mysqlDrainTimeout)
+	assert.Error(t, vtg.IsHealthy())
+
+	// Draining again is a no-op.
+	exited = false
+	srv.drain()
+	assert.False(t, exited)
+
+	// A termination while draining does not wait for the busy connections.
+	srv.shutdownMysqlProtocolAndDrain()
+}
+
+func TestIsReady(t *testing.T) {
+	executor, _, _, _, _ := createExecutorEnv(t)
+	gw := executor.resolver.scatterConn.gateway
+	vtg := &VTGate{executor: executor, gw: gw, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed}
+	vh := newVtgateHandler(vtg)
+	listener, err := mysql.NewListener("tcp", "127.0.0.1:", mysql.NewAuthServerNone(), &testHandler{}, 0, 0, false, false, 0, 0, false)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	defer func(old string) { mysqlServerHealthCheckUser = old }(mysqlServerHealthCheckUser)
+	mysqlServerHealthCheckUser = "healthcheck"
+	conn := mysql.GetTestServerConn(listener)
+	conn.User = "healthcheck"
+	conn.UserData = &mysql.StaticUserData{}
+	healthCheck := func() (*sqltypes.Result, error) {
+		var result *sqltypes.Result
+		err := vh.ComQuery(conn, "select 1", func(qr *sqltypes.Result) error {
+			result = qr
+			return nil
+		})
+		return result, err
+	}
+	debugReady := func() (int, string) {
+		w := httptest.NewRecorder()
+		vtg.debugReadyHandler(w, httptest.NewRequest(http.MethodGet, "/debug/ready", nil))
+		return w.Code, w.Body.String()
+	}
+
+	// The healthcheck is not primed until WaitForTablets found the targets,
+	// and all of them have healthy tablets.
+	require.EqualError(t, vtg.IsReady(), "healthcheck not primed: some targets have no healthy tablets yet")
+	code, body := debugReady()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "not ready: healthcheck not primed: some targets have no healthy tablets yet", body)
+	_, err = healthCheck()
+	require.EqualError(t, err, "vtgate is not ready: healthcheck not primed: some targets have no healthy tablets yet (errno 1053) (sqlstate 08S01)")
+
+	gw.primeTargets = []*querypb.Target{
+		{Keyspace: KsTestUnsharded, Shard: "0", TabletType: topodatapb.TabletType_PRIMARY},
+		{Keyspace: KsTestUnsharded, Shard: "0", TabletType: topodatapb.TabletType_RDONLY},
+	}
+	require.Error(t, vtg.IsReady())
+	gw.hc.(*discovery.FakeHealthCheck).AddTestTablet("aa", "rdonly", 1, KsTestUnsharded, "0", topodatapb.TabletType_RDONLY, true, 1, nil)
+	require.NoError(t, vtg.IsReady())
+	code, body = debugReady()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body)
+	result, err := healthCheck()
+	require.NoError(t, err)
+	assert.Equal(t, `[[VARCHAR("ok")]]`, fmt.Sprintf("%v", result.Rows))
+
+	// The SrvVSchema watch must be connected to the topo.
+	srvVSchema := executor.vm.GetCurrentSrvVschema()
+	executor.vm.VSchemaUpdate(nil, errors.New("connection refused"))
+	require.EqualError(t, vtg.IsReady(), "topo not connected: SrvVSchema watch error: connection refused")
+	code, _ = debugReady()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	executor.vm.VSchemaUpdate(srvVSchema, nil)
+	require.NoError(t, vtg.IsReady())
+
+	// A draining vtgate is not ready.
+	vtg.draining.Store(true)
+	require.EqualError(t, vtg.IsReady(), "vtgate is draining")
+	vtg.draining.Store(false)
+
+	// The queries of the other users are executed.
+	conn.User = "user1"
+	result, err = healthCheck()
+	require.NoError(t, err)
+	assert.NotEqual(t, "health", result.Fields[0].Name)
+}
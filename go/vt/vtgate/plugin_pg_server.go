@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/utils"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/pgwire"
+	"vitess.io/vitess/go/vt/vttls"
+)
+
+// This file implements the experimental PostgreSQL protocol listener of
+// vtgate. It serves the read-only queries which are written in the SQL
+// subset common to PostgreSQL and MySQL, so that the clients which only speak
+// PostgreSQL can read from the keyspaces.
+
+var (
+	pgServerPort           = -1
+	pgServerBindAddress    = "localhost"
+	pgServerStartupTimeout = time.Minute
+)
+
+// pgServerVersion is the PostgreSQL version reported to the clients, which
+// some of them check before connecting.
+const pgServerVersion = "14.0 (Vitess)"
+
+// pgRuntimeParams are the run-time parameters of PostgreSQL which its
+// clients commonly set, and which MySQL does not know about.
+var pgRuntimeParams = map[string]bool{
+	"application_name":            true,
+	"client_encoding":             true,
+	"client_min_messages":         true,
+	"datestyle":                   true,
+	"extra_float_digits":          true,
+	"intervalstyle":               true,
+	"search_path":                 true,
+	"standard_conforming_strings": true,
+	"statement_timeout":           true,
+	"timezone":                    true,
+}
+
+func registerPgServerFlags(fs *pflag.FlagSet) {
+	utils.SetFlagIntVar(fs, &pgServerPort, "pg-server-port", pgServerPort, "Experimental: if set, also listen for PostgreSQL protocol connections on this port, to serve the read-only queries written in the SQL subset common to PostgreSQL and MySQL. The clients authenticate with the --mysql-auth-server-impl auth server, and the database they connect to is used as the target keyspace. The connections use the TLS configuration of the MySQL protocol listener (--mysql-server-ssl-*, --mysql-server-require-secure-transport and --mysql-allow-clear-text-without-tls).")
+	utils.SetFlagStringVar(fs, &pgServerBindAddress, "pg-server-bind-address", pgServerBindAddress, "Binds on this address when listening to the PostgreSQL protocol. Set it to an empty string to listen on all the interfaces.")
+	utils.SetFlagDurationVar(fs, &pgServerStartupTimeout, "pg-server-startup-timeout", pgServerStartupTimeout, "Time the PostgreSQL protocol clients have to authenticate once connected, or 0 for no limit.")
+}
+
+func init() {
+	servenv.OnParseFor("vtgate", registerPgServerFlags)
+}
+
+// pgHandler implements the pgwire.Handler interface. It stores a pgSession
+// in the ClientData of each connection.
+type pgHandler struct {
+	vtg        *VTGate
+	authServer mysql.AuthServer
+}
+
+// pgSession is the state of a PostgreSQL protocol connection.
+type pgSession struct {
+	session           *vtgatepb.Session
+	immediateCallerID *querypb.VTGateCallerID
+}
+
+var _ pgwire.Handler = (*pgHandler)(nil)
+
+// Authenticate is part of the pgwire.Handler interface. The password of the
+// user is checked by the auth server if it stores clear text passwords.
+func (ph *pgHandler) Authenticate(c *pgwire.Conn, user string, password func() (string, error)) error {
+	getter, err := authenticateClearText(ph.authServer, "the PostgreSQL protocol listener", user, password, c.RemoteAddr())
+	if err != nil {
+		return err
+	}
+	c.ClientData = &pgSession{
+		session:           newClearTextSession(getter, c.Database),
+		immediateCallerID: getter.Get(),
+	}
+	return nil
+}
+
+// ComQuery is part of the pgwire.Handler interface. The query is translated
+// to MySQL, and each of its statements is executed if it is read-only or
+// only sets session variables.
+func (ph *pgHandler) ComQuery(c *pgwire.Conn, query string, callback func(result *sqltypes.Result, tag string) error) error {
+	ps := c.ClientData.(*pgSession)
+	parser := ph.vtg.executor.env.Parser()
+	pieces, err := parser.SplitStatementToPieces(pgwire.TranslateQuery(query))
+	if err != nil {
+		return err
+	}
+	for _, sql := range pieces {
+		stmt, err := parser.Parse(sql)
+		if err != nil {
+			return err
+		}
+
+		var tag string
+		switch stmt := stmt.(type) {
+		case sqlparser.SelectStatement:
+			tag = "SELECT"
+		case *sqlparser.Show:
+			tag = "SHOW"
+		case sqlparser.Explain:
+			tag = "EXPLAIN"
+		case *sqlparser.Begin:
+			tag = "BEGIN"
+		case *sqlparser.Commit:
+			tag = "COMMIT"
+		case *sqlparser.Rollback:
+			tag = "ROLLBACK"
+		case *sqlparser.Set:
+			set, err := pgSessionSet(stmt)
+			if err != nil {
+				return err
+			}
+			if set == nil {
+				if err := callback(&sqltypes.Result{}, "SET"); err != nil {
+					return err
+				}
+				continue
+			}
+			sql = sqlparser.String(set)
+			tag = "SET"
+		default:
+			return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "only read-only statements are supported by the PostgreSQL protocol listener: %s", sqlparser.ASTToStatementType(stmt))
+		}
+
+		result, err := ph.execute(c, ps, sql)
+		if err != nil {
+			return err
+		}
+		if tag == "SELECT" {
+			tag = fmt.Sprintf("SELECT %d", len(result.Rows))
+		}
+		if err := callback(result, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pgSessionSet returns the SET statement without the PostgreSQL run-time
+// parameters, which are acknowledged without being set, or nil if it only
+// sets them. The other variables can only be set for the session.
+func pgSessionSet(set *sqlparser.Set) (*sqlparser.Set, error) {
+	var exprs sqlparser.SetExprs
+	for _, expr := range set.Exprs {
+		switch expr.Var.Scope {
+		case sqlparser.GlobalScope, sqlparser.PersistSysScope, sqlparser.PersistOnlySysScope:
+			return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "only the session variables can be set by the PostgreSQL protocol listener: %s", sqlparser.String(expr))
+		case sqlparser.NoScope, sqlparser.SessionScope:
+			if pgRuntimeParams[expr.Var.Name.Lowered()] {
+				continue
+			}
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	return &sqlparser.Set{Comments: set.Comments, Exprs: exprs}, nil
+}
+
+func (ph *pgHandler) execute(c *pgwire.Conn, ps *pgSession, sql string) (*sqltypes.Result, error) {
+	ctx := context.Background()
+	if mysqlQueryTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+		defer cancel()
+	}
+	ef := callerid.NewEffectiveCallerID(
+		c.User,                  /* principal: who */
+		c.RemoteAddr().String(), /* component: running client process */
+		"VTGate PostgreSQL Connector" /* subcomponent: part of the client */)
+	ctx = callerid.NewContext(ctx, ef, ps.immediateCallerID)
+
+	session, result, err := ph.vtg.Execute(ctx, ph, ps.session, sql, make(map[string]*querypb.BindVariable), false)
+	ps.session = session
+	c.InTransaction = session.InTransaction
+	return result, err
+}
+
+// ConnectionClosed is part of the pgwire.Handler interface. It rolls back
+// the ongoing transaction, if any.
+func (ph *pgHandler) ConnectionClosed(c *pgwire.Conn) {
+	ps := c.ClientData.(*pgSession)
+	ctx := context.Background()
+	if mysqlQueryTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, mysqlQueryTimeout)
+		defer cancel()
+	}
+	_ = ph.vtg.CloseSession(ctx, ps.session)
+}
+
+// KillQuery is part of the vtgateservice.MySQLConnection interface. KILL
+// statements are not supported by the PostgreSQL protocol listener.
+func (ph *pgHandler) KillQuery(uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "KILL is not supported by the PostgreSQL protocol listener")
+}
+
+// KillConnection is part of the vtgateservice.MySQLConnection interface.
+func (ph *pgHandler) KillConnection(context.Context, uint32) error {
+	return vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, "KILL is not supported by the PostgreSQL protocol listener")
+}
+
+// initPgProtocol starts the PostgreSQL protocol listener if --pg-server-port
+// is set.
+func initPgProtocol(vtgate *VTGate) *pgwire.Listener {
+	if pgServerPort < 0 || vtgate == nil {
+		return nil
+	}
+
+	handler := &pgHandler{
+		vtg:        vtgate,
+		authServer: initAuthServer(),
+	}
+	listener, err := pgwire.NewListener("tcp", net.JoinHostPort(pgServerBindAddress, strconv.Itoa(pgServerPort)), handler, pgServerVersion)
+	if err != nil {
+		log.Exitf("pgwire.NewListener failed: %v", err)
+	}
+	if mysqlSslCert != "" && mysqlSslKey != "" {
+		tlsVersion, err := vttls.TLSVersionToNumber(mysqlTLSMinVersion)
+		if err != nil {
+			log.Exitf("pgwire.NewListener failed: %v", err)
+		}
+		serverConfig, err := vttls.ServerConfig(mysqlSslCert, mysqlSslKey, mysqlSslCa, mysqlSslCrl, mysqlSslServerCA, tlsVersion)
+		if err != nil {
+			log.Exitf("pgwire.NewListener failed: %v", err)
+		}
+		listener.TLSConfig.Store(serverConfig)
+		listener.RequireSecureTransport = mysqlServerRequireSecureTransport
+	}
+	listener.AllowClearTextWithoutTLS.Store(mysqlAllowClearTextWithoutTLS)
+	listener.StartupTimeout = pgServerStartupTimeout
+	go listener.Accept()
+	return listener
+}
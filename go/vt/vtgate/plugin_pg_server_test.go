@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/pgwire"
+)
+
+// pgTestMessage is a message sent by the PostgreSQL protocol listener.
+type pgTestMessage struct {
+	typ     byte
+	payload []byte
+}
+
+// pgTestQuery sends a query with the simple query protocol, and returns the
+// messages sent back up to the ReadyForQuery message.
+func pgTestQuery(t *testing.T, conn net.Conn, reader *bufio.Reader, query string) []pgTestMessage {
+	msg := binary.BigEndian.AppendUint32([]byte{'Q'}, uint32(len(query)+5))
+	msg = append(append(msg, query...), 0)
+	_, err := conn.Write(msg)
+	require.NoError(t, err)
+	return pgTestReadUntilReady(t, reader)
+}
+
+func pgTestReadUntilReady(t *testing.T, reader *bufio.Reader) []pgTestMessage {
+	var msgs []pgTestMessage
+	for {
+		var header [5]byte
+		_, err := io.ReadFull(reader, header[:])
+		require.NoError(t, err)
+		payload := make([]byte, binary.BigEndian.Uint32(header[1:])-4)
+		_, err = io.ReadFull(reader, payload)
+		require.NoError(t, err)
+		msgs = append(msgs, pgTestMessage{typ: header[0], payload: payload})
+		if header[0] == 'Z' {
+			return msgs
+		}
+	}
+}
+
+func pgTestMessageTypes(msgs []pgTestMessage) string {
+	var types []byte
+	for _, msg := range msgs {
+		types = append(types, msg.typ)
+	}
+	return string(types)
+}
+
+func TestPgHandler(t *testing.T) {
+	executor, _, _, sbclookup, _ := createExecutorEnv(t)
+	vtg := &VTGate{executor: executor, timings: timings, rowsReturned: rowsReturned, rowsAffected: rowsAffected, queryTextCharsProcessed: queryTextCharsProcessed}
+	listener, err := pgwire.NewListener("tcp", "127.0.0.1:", &pgHandler{vtg: vtg, authServer: mysql.NewAuthServerNone()}, pgServerVersion)
+	require.NoError(t, err)
+	go listener.Accept()
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	var startup []byte
+	startup = binary.BigEndian.AppendUint32(startup, 196608)
+	for _, s := range []string{"user", "user1", "database", KsTestUnsharded} {
+		startup = append(append(startup, s...), 0)
+	}
+	startup = append(startup, 0)
+	_, err = conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(startup)+4)), startup...))
+	require.NoError(t, err)
+	msgs := pgTestReadUntilReady(t, reader)
+	require.Equal(t, "RSSSSSSKZ", pgTestMessageTypes(msgs))
+
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name", "int64|varchar"), "1|abc", "2|def")})
+	msgs = pgTestQuery(t, conn, reader, `select "id", "name" from main1 where "name" != 'a\b'`)
+	require.Equal(t, "TDDCZ", pgTestMessageTypes(msgs))
+	assert.Equal(t, "SELECT 2\x00", string(msgs[3].payload))
+	require.Len(t, sbclookup.Queries, 1)
+	assert.Equal(t, "select id, `name` from main1 where `name` != 'a\\\\b'", sbclookup.Queries[0].Sql)
+
+	// The run-time parameters set by the clients are ignored.
+	msgs = pgTestQuery(t, conn, reader, "SET extra_float_digits = 3")
+	require.Equal(t, "CZ", pgTestMessageTypes(msgs))
+	assert.Equal(t, "SET\x00", string(msgs[0].payload))
+
+	// The other variables are set for the session only.
+	msgs = pgTestQuery(t, conn, reader, "SET @x = 42, application_name = 'psql'")
+	require.Equal(t, "CZ", pgTestMessageTypes(msgs))
+	assert.Equal(t, "SET\x00", string(msgs[0].payload))
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"))})
+	msgs = pgTestQuery(t, conn, reader, "select id from main1 where id = @x")
+	require.Equal(t, "TCZ", pgTestMessageTypes(msgs))
+	assert.EqualValues(t, sqltypes.Int64BindVariable(42), sbclookup.Queries[len(sbclookup.Queries)-1].BindVariables["__vtudvx"])
+
+	msgs = pgTestQuery(t, conn, reader, "SET GLOBAL read_only = 0")
+	require.Equal(t, "EZ", pgTestMessageTypes(msgs))
+	assert.True(t, bytes.Contains(msgs[0].payload, []byte("only the session variables can be set by the PostgreSQL protocol listener")), string(msgs[0].payload))
+
+	sbclookup.SetResults([]*sqltypes.Result{sqltypes.MakeTestResult(sqltypes.MakeTestFields("id", "int64"))})
+	msgs = pgTestQuery(t, conn, reader, "begin; select id from main1")
+	require.Equal(t, "CTCZ", pgTestMessageTypes(msgs))
+	assert.Equal(t, "BEGIN\x00", string(msgs[0].payload))
+	assert.Equal(t, "SELECT 0\x00", string(msgs[2].payload))
+	assert.Equal(t, "T", string(msgs[3].payload))
+
+	msgs = pgTestQuery(t, conn, reader, "insert into main1(id) values (1)")
+	require.Equal(t, "EZ", pgTestMessageTypes(msgs))
+	assert.True(t, bytes.Contains(msgs[0].payload, []byte("only read-only statements are supported by the PostgreSQL protocol listener: INSERT")), string(msgs[0].payload))
+
+	msgs = pgTestQuery(t, conn, reader, "commit")
+	require.Equal(t, "CZ", pgTestMessageTypes(msgs))
+	assert.Equal(t, "I", string(msgs[1].payload))
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topotools"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	rateLimitRejected = stats.NewCountersWithMultiLabels("RateLimitRejected", "Queries rejected by a rate limit rule", []string{"Keyspace", "Table", "User"})
+	rateLimitQueued   = stats.NewCountersWithMultiLabels("RateLimitQueued", "Queries delayed by a rate limit rule", []string{"Keyspace", "Table", "User"})
+)
+
+// rateLimiter limits the rate of the queries on keyspaces and tables, with the
+// rate limit rules of the vschema. Every rule is a token bucket, from which
+// every query the rule applies to takes a token.
+//
+// The rules apply to the whole vtgate tier: when the vtgates discover each
+// other, every vtgate allows its share of the rate of a rule.
+type rateLimiter struct {
+	mu      sync.RWMutex
+	buckets map[rateLimitKey]*rateLimitBucket
+	// vtgates is the number of live vtgates sharing the rules.
+	vtgates int
+}
+
+// rateLimitKey identifies a rule. The table and the user are empty for the
+// rules which apply to all the tables of the keyspace or to all the users.
+type rateLimitKey struct {
+	keyspace, table, user string
+}
+
+type rateLimitBucket struct {
+	rule    *vschemapb.RateLimitRule
+	limiter *rate.Limiter
+	labels  []string
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{vtgates: 1}
+}
+
+// limits returns the rate and the burst of the bucket of a rule on this vtgate.
+func (rl *rateLimiter) limits(rule *vschemapb.RateLimitRule) (rate.Limit, int) {
+	qps := rule.QueriesPerSecond / float64(rl.vtgates)
+	burst := float64(rule.Burst)
+	if burst == 0 {
+		burst = rule.QueriesPerSecond
+	}
+	return rate.Limit(qps), max(1, int(math.Ceil(burst/float64(rl.vtgates))))
+}
+
+// setVTGates sets the number of live vtgates sharing the rules, and updates
+// the rate of the buckets accordingly.
+func (rl *rateLimiter) setVTGates(vtgates int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.vtgates = max(1, vtgates)
+	for _, bucket := range rl.buckets {
+		limit, burst := rl.limits(bucket.rule)
+		bucket.limiter.SetLimit(limit)
+		bucket.limiter.SetBurst(burst)
+	}
+}
+
+// setRules replaces the rules of the rate limiter. The buckets of the rules
+// which are kept keep their tokens.
+func (rl *rateLimiter) setRules(rules []*vschemapb.RateLimitRule) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	buckets := make(map[rateLimitKey]*rateLimitBucket, len(rules))
+	for _, rule := range rules {
+		// The rules are validated when they are applied, but a bad rule
+		// must not block all the queries.
+		if rule.Keyspace == "" || rule.QueriesPerSecond <= 0 {
+			log.Warningf("Ignoring invalid rate limit rule %s", topotools.GetRateLimitRuleKey(rule))
+			continue
+		}
+		limit, burst := rl.limits(rule)
+
+		key := rateLimitKey{keyspace: rule.Keyspace, table: rule.Table, user: rule.User}
+		bucket := &rateLimitBucket{
+			rule:   rule,
+			labels: []string{rule.Keyspace, rule.Table, rule.User},
+		}
+		if previous, ok := rl.buckets[key]; ok {
+			bucket.limiter = previous.limiter
+			bucket.limiter.SetLimit(limit)
+			bucket.limiter.SetBurst(burst)
+		} else {
+			bucket.limiter = rate.NewLimiter(limit, burst)
+		}
+		buckets[key] = bucket
+	}
+	rl.buckets = buckets
+}
+
+// matchingBuckets returns the buckets of the rules which apply to a query of
+// the user on the given tables, which are qualified with their keyspace.
+func (rl *rateLimiter) matchingBuckets(user string, tables []string) []*rateLimitBucket {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	if len(rl.buckets) == 0 {
+		return nil
+	}
+	var buckets []*rateLimitBucket
+	for _, table := range tables {
+		keyspace, name, _ := strings.Cut(table, ".")
+		for _, key := range []rateLimitKey{
+			{keyspace: keyspace, table: name, user: user},
+			{keyspace: keyspace, table: name},
+			{keyspace: keyspace, user: user},
+			{keyspace: keyspace},
+		} {
+			if bucket, ok := rl.buckets[key]; ok && !slices.Contains(buckets, bucket) {
+				buckets = append(buckets, bucket)
+			}
+		}
+	}
+	return buckets
+}
+
+// wait takes a token from the bucket of every rule which applies to a query of
+// the user on the given tables. The query is rejected if a bucket of a rule
+// with the REJECT policy is empty. Otherwise it waits until the buckets of the
+// rules with the QUEUE policy are refilled, unless it would wait longer than
+// the maximum wait of a rule or than the timeout of the query. No token is
+// taken from any bucket if the query is rejected.
+func (rl *rateLimiter) wait(ctx context.Context, user string, tables []string) error {
+	buckets := rl.matchingBuckets(user, tables)
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(buckets))
+	reject := func(bucket *rateLimitBucket) error {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+		rateLimitRejected.Add(bucket.labels, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "rate limit exceeded for %s", topotools.GetRateLimitRuleKey(bucket.rule))
+	}
+
+	var (
+		delay   time.Duration
+		slowest *rateLimitBucket
+		queued  []*rateLimitBucket
+	)
+	for _, bucket := range buckets {
+		r := bucket.limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if !r.OK() {
+			return reject(bucket)
+		}
+		d := r.DelayFrom(now)
+		if d == 0 {
+			continue
+		}
+		maxWait := time.Duration(bucket.rule.MaxQueueWaitMs) * time.Millisecond
+		if bucket.rule.Policy != vschemapb.RateLimitRule_QUEUE || (maxWait > 0 && d > maxWait) {
+			return reject(bucket)
+		}
+		queued = append(queued, bucket)
+		if d > delay {
+			delay, slowest = d, bucket
+		}
+	}
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		return reject(slowest)
+	}
+
+	for _, bucket := range queued {
+		rateLimitQueued.Add(bucket.labels, 1)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		for _, r := range reservations {
+			r.Cancel()
+		}
+		return vterrors.Errorf(vterrors.Code(ctx.Err()), "rate limit exceeded for %s: %v", topotools.GetRateLimitRuleKey(slowest.rule), ctx.Err())
+	}
+}
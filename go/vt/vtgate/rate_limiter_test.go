@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctx := t.Context()
+	rl := newRateLimiter()
+	assert.NoError(t, rl.wait(ctx, "u1", []string{"ks.t1"}))
+
+	rl.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: "ks", QueriesPerSecond: 0.001, Burst: 3},
+		{Keyspace: "ks", Table: "t1", QueriesPerSecond: 0.001, Burst: 2},
+		{Keyspace: "ks", Table: "t1", User: "u1", QueriesPerSecond: 0.001},
+		{Keyspace: "ks", Table: "t2", QueriesPerSecond: 1000, Burst: 1, Policy: vschemapb.RateLimitRule_QUEUE},
+		{Keyspace: "ks", Table: "t3", QueriesPerSecond: 0.001, Policy: vschemapb.RateLimitRule_QUEUE, MaxQueueWaitMs: 10},
+		{Keyspace: "ks", Table: "t4", QueriesPerSecond: 0},
+	})
+	rejectedFor := func(rule string, err error) {
+		t.Helper()
+		assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+		assert.ErrorContains(t, err, "rate limit exceeded for "+rule)
+	}
+
+	// The rule of the user is exhausted first, which does not take tokens from
+	// the other rules.
+	assert.NoError(t, rl.wait(ctx, "u1", []string{"ks.t1"}))
+	rejectedFor("ks.t1 for user u1", rl.wait(ctx, "u1", []string{"ks.t1"}))
+	assert.NoError(t, rl.wait(ctx, "u2", []string{"ks.t1"}))
+	rejectedFor("ks.t1", rl.wait(ctx, "u2", []string{"ks.t1"}))
+
+	// A query on two tables of the keyspace takes a single token from the
+	// rule of the keyspace.
+	assert.NoError(t, rl.wait(ctx, "u2", []string{"ks.t2", "ks.t4"}))
+	rejectedFor("ks", rl.wait(ctx, "u2", []string{"ks.t4"}))
+	assert.NoError(t, rl.wait(ctx, "u2", []string{"other.t1"}))
+
+	rl.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: "ks", Table: "t2", QueriesPerSecond: 1000, Burst: 1, Policy: vschemapb.RateLimitRule_QUEUE},
+		{Keyspace: "ks", Table: "t3", QueriesPerSecond: 0.001, Policy: vschemapb.RateLimitRule_QUEUE, MaxQueueWaitMs: 10},
+	})
+	// The rule of the table is kept with its tokens.
+	start := time.Now()
+	assert.NoError(t, rl.wait(ctx, "u2", []string{"ks.t2"}))
+	assert.NoError(t, rl.wait(ctx, "u2", []string{"ks.t2"}))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond)
+	assert.NoError(t, rl.wait(ctx, "u1", []string{"ks.t1"}))
+
+	// A query is rejected if it would wait longer than the maximum wait of
+	// the rule, or than its timeout.
+	assert.NoError(t, rl.wait(ctx, "u1", []string{"ks.t3"}))
+	rejectedFor("ks.t3", rl.wait(ctx, "u1", []string{"ks.t3"}))
+	rl.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: "ks", QueriesPerSecond: 1, Policy: vschemapb.RateLimitRule_QUEUE},
+	})
+	assert.NoError(t, rl.wait(ctx, "u1", []string{"ks.t3"}))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	rejectedFor("ks", rl.wait(timeoutCtx, "u1", []string{"ks.t3"}))
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err := rl.wait(cancelCtx, "u1", []string{"ks.t3"})
+	assert.Equal(t, vtrpcpb.Code_CANCELED, vterrors.Code(err))
+}
+
+func TestRateLimiterSharedByVTGates(t *testing.T) {
+	rl := newRateLimiter()
+	rl.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: "ks", QueriesPerSecond: 100, Burst: 10},
+	})
+	limiter := rl.buckets[rateLimitKey{keyspace: "ks"}].limiter
+	assert.EqualValues(t, 100, limiter.Limit())
+	assert.Equal(t, 10, limiter.Burst())
+
+	rl.setVTGates(4)
+	assert.EqualValues(t, 25, limiter.Limit())
+	assert.Equal(t, 3, limiter.Burst())
+
+	// The new rules are shared too, and a bucket keeps at least one token.
+	rl.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: "ks", QueriesPerSecond: 100, Burst: 10},
+		{Keyspace: "ks", Table: "t1", QueriesPerSecond: 2},
+	})
+	assert.Same(t, limiter, rl.buckets[rateLimitKey{keyspace: "ks"}].limiter)
+	assert.EqualValues(t, 25, limiter.Limit())
+	tableLimiter := rl.buckets[rateLimitKey{keyspace: "ks", table: "t1"}].limiter
+	assert.EqualValues(t, 0.5, tableLimiter.Limit())
+	assert.Equal(t, 1, tableLimiter.Burst())
+
+	rl.setVTGates(0)
+	assert.EqualValues(t, 100, limiter.Limit())
+	assert.Equal(t, 10, limiter.Burst())
+}
+
+func TestExecutorRateLimit(t *testing.T) {
+	executor, _, _, _, ctx := createExecutorEnv(t)
+	executor.rateLimiter.setRules([]*vschemapb.RateLimitRule{
+		{Keyspace: KsTestSharded, Table: "user", User: "limited", QueriesPerSecond: 0.001},
+	})
+	session := &vtgatepb.Session{TargetString: "@primary"}
+
+	limitedCtx := callerid.NewContext(ctx, nil, &querypb.VTGateCallerID{Username: "limited"})
+	_, err := executorExec(limitedCtx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+	_, err = executorExec(limitedCtx, executor, session, "select id from user where id = 1", nil)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	_, err = executorExec(limitedCtx, executor, session, "select id from music where id = 1", nil)
+	require.NoError(t, err)
+
+	_, err = executorExec(ctx, executor, session, "select id from user where id = 1", nil)
+	require.NoError(t, err)
+}
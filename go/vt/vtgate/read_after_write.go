@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/vterrors"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Sessions with session_track_gtids enabled read their own writes from the
+// replicas: after a write, the GTID set executed by the primary of the shard
+// is recorded in the session, and the replica reads of the shard wait for it
+// to be applied. A read which times out on the replica goes to the primary.
+
+const (
+	// defaultReadAfterWriteTimeout is how long a replica read waits for the
+	// writes of the session when read_after_write_timeout is not set.
+	defaultReadAfterWriteTimeout = time.Second
+
+	gtidExecutedQuery = "select @@global.gtid_executed"
+)
+
+var readAfterWriteFallbacks = stats.NewCountersWithMultiLabels("ReadAfterWriteFallbacks", "Replica reads sent to the primary because the replica did not apply the writes of the session in time", []string{"Keyspace", "ShardName"})
+
+// recordWriteGTIDs records in the session the GTID set executed by the primary
+// of the target after a write of the session.
+func recordWriteGTIDs(ctx context.Context, qs queryservice.QueryService, session *econtext.SafeSession, target *querypb.Target) {
+	if !session.TracksGTIDs() || target.TabletType != topodatapb.TabletType_PRIMARY {
+		return
+	}
+	qr, err := qs.Execute(ctx, session, target, gtidExecutedQuery, nil, 0, 0, nil)
+	if err == nil && len(qr.Rows) != 1 {
+		err = vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected result for %s: %v", gtidExecutedQuery, qr.Rows)
+	}
+	if err != nil {
+		session.RecordWarning(&querypb.QueryWarning{Message: fmt.Sprintf("cannot track the GTIDs of the write on %s/%s, replica reads may not see it: %v", target.Keyspace, target.Shard, err)})
+		return
+	}
+	gtids := strings.ReplaceAll(qr.Rows[0][0].ToString(), "\n", "")
+	session.SetShardGTIDs(target.Keyspace, target.Shard, gtids)
+}
+
+// readAfterWriteOptions returns the options of a read of the target which must
+// wait for the writes of the session, or the given options if it need not.
+func readAfterWriteOptions(session *econtext.SafeSession, target *querypb.Target, info *shardActionInfo, opts *querypb.ExecuteOptions) *querypb.ExecuteOptions {
+	if target.TabletType == topodatapb.TabletType_PRIMARY || info.transactionID != 0 || info.reservedID != 0 {
+		return opts
+	}
+	gtids := session.GetShardGTIDs(target.Keyspace, target.Shard)
+	if gtids == "" {
+		return opts
+	}
+	if opts == nil {
+		opts = &querypb.ExecuteOptions{}
+	} else {
+		opts = opts.CloneVT()
+	}
+	opts.ReadAfterWriteGtidSet = gtids
+	// The timeout is sent in milliseconds, and at least one, as a replica
+	// which gets none cannot wait at all.
+	opts.ReadAfterWriteTimeout = max(session.GetReadAfterWriteTimeout(defaultReadAfterWriteTimeout).Milliseconds(), 1)
+	return opts
+}
+
+// readFromPrimary returns the target to send a read to after it failed on
+// a replica which did not apply the writes of the session in time, or nil if
+// the error is not such a failure.
+func readFromPrimary(target *querypb.Target, opts *querypb.ExecuteOptions, err error) *querypb.Target {
+	if opts.GetReadAfterWriteGtidSet() == "" || vterrors.Code(err) != vtrpcpb.Code_FAILED_PRECONDITION {
+		return nil
+	}
+	readAfterWriteFallbacks.Add([]string{target.Keyspace, target.Shard}, 1)
+	primary := target.CloneVT()
+	primary.TabletType = topodatapb.TabletType_PRIMARY
+	return primary
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestReadAfterWrite(t *testing.T) {
+	var primary, replica *sandboxconn.SandboxConn
+	executor, ctx := createExecutorEnvCallback(t, createExecutorConfig(), func(shard, ks string, tabletType topodatapb.TabletType, conn *sandboxconn.SandboxConn) {
+		if ks != KsTestUnsharded {
+			return
+		}
+		switch tabletType {
+		case topodatapb.TabletType_PRIMARY:
+			primary = conn
+		case topodatapb.TabletType_REPLICA:
+			replica = conn
+		}
+	})
+	gtidResult := func(gtids string) *sqltypes.Result {
+		return sqltypes.MakeTestResult(sqltypes.MakeTestFields("@@global.gtid_executed", "varchar"), gtids)
+	}
+	lastQuery := func(sbc *sandboxconn.SandboxConn) string {
+		require.NotEmpty(t, sbc.Queries)
+		return sbc.Queries[len(sbc.Queries)-1].Sql
+	}
+	lastOptions := func(sbc *sandboxconn.SandboxConn) *querypb.ExecuteOptions {
+		require.NotEmpty(t, sbc.Options)
+		return sbc.Options[len(sbc.Options)-1]
+	}
+
+	session := &vtgatepb.Session{TargetString: KsTestUnsharded + "@primary", Autocommit: true}
+	exec := func(sql string) {
+		_, err := executorExec(ctx, executor, session, sql, nil)
+		require.NoError(t, err)
+	}
+
+	// Writes are not tracked by default.
+	exec("insert into main1(id) values (1)")
+	assert.Contains(t, lastQuery(primary), "insert into main1")
+
+	exec("set session_track_gtids = own_gtid")
+	primary.SetResults([]*sqltypes.Result{{RowsAffected: 1}, gtidResult("uuid1:1-10,\nuuid2:1-5")})
+	exec("insert into main1(id) values (2)")
+	assert.Equal(t, gtidExecutedQuery, lastQuery(primary))
+	assert.Equal(t, map[string]string{"TestUnsharded/0": "uuid1:1-10,uuid2:1-5"}, session.ReadAfterWrite.ShardGtids)
+
+	// Replica reads wait for the writes of the session.
+	session.TargetString = KsTestUnsharded + "@replica"
+	exec("select id from main1")
+	assert.Equal(t, "uuid1:1-10,uuid2:1-5", lastOptions(replica).ReadAfterWriteGtidSet)
+	assert.EqualValues(t, 1000, lastOptions(replica).ReadAfterWriteTimeout)
+
+	exec("set read_after_write_timeout = 0.25")
+	exec("select id from main1")
+	assert.EqualValues(t, 250, lastOptions(replica).ReadAfterWriteTimeout)
+
+	// A timeout below a millisecond is not sent as 0.
+	exec("set read_after_write_timeout = 0.0005")
+	exec("select id from main1")
+	assert.EqualValues(t, 1, lastOptions(replica).ReadAfterWriteTimeout)
+
+	// A replica which does not apply them in time leaves the read to the primary.
+	primary.Queries = nil
+	replica.MustFailCodes[vtrpcpb.Code_FAILED_PRECONDITION] = 1
+	exec("select id from main1 where id = 2")
+	assert.Equal(t, "select id from main1 where id = 2", lastQuery(primary))
+	assert.Empty(t, lastOptions(primary).GetReadAfterWriteGtidSet())
+
+	// Transactions are tracked when they commit.
+	session.TargetString = KsTestUnsharded + "@primary"
+	exec("begin")
+	exec("insert into main1(id) values (3)")
+	primary.SetResults([]*sqltypes.Result{gtidResult("uuid1:1-11,uuid2:1-5")})
+	exec("commit")
+	assert.Equal(t, gtidExecutedQuery, lastQuery(primary))
+	assert.Equal(t, map[string]string{"TestUnsharded/0": "uuid1:1-11,uuid2:1-5"}, session.ReadAfterWrite.ShardGtids)
+
+	// Read only transactions are not.
+	primary.Queries = nil
+	exec("begin")
+	exec("select id from main1")
+	exec("commit")
+	assert.Equal(t, "select id from main1", lastQuery(primary))
+
+	exec("set session_track_gtids = off")
+	assert.Empty(t, session.ReadAfterWrite.ShardGtids)
+	session.TargetString = KsTestUnsharded + "@replica"
+	exec("select id from main1")
+	assert.Empty(t, lastOptions(replica).GetReadAfterWriteGtidSet())
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"vitess.io/vitess/go/cache/theine"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/engine"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vthash"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	resultCacheHits          = stats.NewCounter("ReferenceResultCacheHits", "Queries on reference tables served from the result cache")
+	resultCacheMisses        = stats.NewCounter("ReferenceResultCacheMisses", "Queries on reference tables not found in the result cache")
+	resultCacheInvalidations = stats.NewCountersWithSingleLabel("ReferenceResultCacheInvalidations", "Invalidations of the reference table result cache by table", "Table")
+
+	// resultCacheRetryDelay is how long to wait before restarting the VStream
+	// which invalidates the result cache when it fails.
+	resultCacheRetryDelay = 5 * time.Second
+)
+
+// resultCacheStreamer streams the changes of the tables matched by the filter.
+type resultCacheStreamer func(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error
+
+// resultCache caches the results of the queries which only read reference
+// tables. A result is served from the cache until its TTL expires, or until
+// one of its tables changes: the changes are received through a VStream on the
+// keyspaces which have reference tables, and through the DMLs executed by this
+// vtgate.
+type resultCache struct {
+	ttl     time.Duration
+	results *theine.Store[theine.HashKey256, *cachedResult]
+	// epoch is incremented to invalidate all the cached results.
+	epoch atomic.Uint32
+
+	mu sync.Mutex
+	// generations are incremented to invalidate the cached results of a table.
+	// They are keyed by table name and not by keyspace, as a reference table is
+	// read from any keyspace which has a copy of it.
+	generations map[string]uint64
+	// tables are the reference tables of each keyspace in the vschema.
+	tables   map[string][]string
+	streamer resultCacheStreamer
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+type cachedResult struct {
+	result      *sqltypes.Result
+	expires     time.Time
+	tables      []string
+	generations []uint64
+}
+
+func (cr *cachedResult) CachedSize(alloc bool) int64 {
+	size := cr.result.CachedSize(alloc)
+	for _, table := range cr.tables {
+		size += int64(len(table)) + 16
+	}
+	return size + int64(len(cr.generations))*8
+}
+
+func newResultCache(ttl time.Duration, maxMemory int64) *resultCache {
+	return &resultCache{
+		ttl:         ttl,
+		results:     theine.NewStore[theine.HashKey256, *cachedResult](maxMemory, false),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Open starts invalidating the cached results with the changes streamed by
+// the streamer.
+func (rc *resultCache) Open(streamer resultCacheStreamer) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.streamer = streamer
+	rc.restartStreamLocked()
+}
+
+// Release stops the invalidation stream and frees the cached results. The
+// cache cannot be used afterwards.
+func (rc *resultCache) Release() {
+	rc.Close()
+	rc.results.Close()
+}
+
+// Close stops the invalidation stream and waits for it to finish.
+func (rc *resultCache) Close() {
+	rc.mu.Lock()
+	rc.streamer = nil
+	if rc.cancel != nil {
+		rc.cancel()
+		rc.cancel = nil
+	}
+	rc.mu.Unlock()
+	rc.wg.Wait()
+}
+
+// vstreamResultCacheStreamer streams the changes from the primary tablets.
+func vstreamResultCacheStreamer(vsm *vstreamManager) resultCacheStreamer {
+	return func(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+		flags := &vtgatepb.VStreamFlags{ExcludeKeyspaceFromTableName: true}
+		return vsm.VStream(ctx, topodatapb.TabletType_PRIMARY, vgtid, filter, flags, send)
+	}
+}
+
+// key returns the cache key of the result of the plan, and the tables it
+// reads. It returns false if the result cannot be cached: the plan must be a
+// deterministic SELECT on a primary outside of a transaction, which only reads
+// reference tables or their sources. The replicas are not cached, as their
+// reads can lag behind the invalidations by an unbounded delay.
+//
+// The key includes the caller, whose access to the tables is checked by the
+// tablets.
+func (rc *resultCache) key(
+	ctx context.Context,
+	vschema *vindexes.VSchema,
+	plan *engine.Plan,
+	vcursor *econtext.VCursorImpl,
+	safeSession *econtext.SafeSession,
+	bindVars map[string]*querypb.BindVariable,
+) (theine.HashKey256, []string, bool) {
+	var key theine.HashKey256
+	if vschema == nil || plan.QueryType != sqlparser.StmtSelect || plan.NonDeterministic || len(plan.TablesUsed) == 0 || safeSession.InTransaction() {
+		return key, nil, false
+	}
+	if vcursor.TabletType() != topodatapb.TabletType_PRIMARY {
+		return key, nil, false
+	}
+	tables := make([]string, 0, len(plan.TablesUsed))
+	for _, tableUsed := range plan.TablesUsed {
+		ks, name, ok := strings.Cut(tableUsed, ".")
+		if !ok || vschema.Keyspaces[ks] == nil {
+			return key, nil, false
+		}
+		if !isReferenceTable(vschema.Keyspaces[ks].Tables[name]) {
+			return key, nil, false
+		}
+		tables = append(tables, name)
+	}
+
+	hasher := vthash.New256()
+	// The names are prefixed by their length, so that the callers cannot
+	// collide by moving characters between their user name and groups.
+	immediateCaller := callerid.ImmediateCallerIDFromContext(ctx)
+	callerNames := append([]string{immediateCaller.GetUsername(), callerid.EffectiveCallerIDFromContext(ctx).GetPrincipal()}, immediateCaller.GetGroups()...)
+	_, _ = hasher.WriteUint16(uint16(len(callerNames)))
+	for _, name := range callerNames {
+		_, _ = hasher.WriteUint16(uint16(len(name)))
+		_, _ = hasher.WriteString(name)
+	}
+	_, _ = hasher.WriteUint16(uint16(vcursor.TabletType()))
+	_, _ = hasher.WriteString(safeSession.GetTargetString())
+	_, _ = hasher.WriteUint16(uint16(vcursor.ConnCollation()))
+	_, _ = hasher.WriteUint16(uint16(safeSession.GetOptions().GetIncludedFields()))
+	_, _ = hasher.WriteString(plan.Original)
+	sysVars := vcursor.GetSystemVariablesCopy()
+	for _, name := range slices.Sorted(maps.Keys(sysVars)) {
+		_, _ = hasher.WriteString(name)
+		_, _ = hasher.WriteString(sysVars[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(bindVars)) {
+		bv, err := bindVars[name].MarshalVT()
+		if err != nil {
+			return key, nil, false
+		}
+		_, _ = hasher.WriteString(name)
+		_, _ = hasher.Write(bv)
+	}
+	hasher.Sum(key[:0])
+	return key, tables, true
+}
+
+// isReferenceTable returns true if the table is a reference table, or the
+// source of reference tables.
+func isReferenceTable(table *vindexes.BaseTable) bool {
+	return table != nil && (table.Type == vindexes.TypeReference || len(table.ReferencedBy) > 0)
+}
+
+// get returns a copy of the cached result for the key, if it has neither
+// expired nor been invalidated.
+func (rc *resultCache) get(key theine.HashKey256) (*sqltypes.Result, bool) {
+	entry, ok := rc.results.Get(key, rc.epoch.Load())
+	if ok && !time.Now().Before(entry.expires) {
+		rc.results.Delete(key)
+		ok = false
+	}
+	if ok {
+		rc.mu.Lock()
+		for i, table := range entry.tables {
+			if rc.generations[table] != entry.generations[i] {
+				ok = false
+				break
+			}
+		}
+		rc.mu.Unlock()
+	}
+	if !ok {
+		resultCacheMisses.Add(1)
+		return nil, false
+	}
+	resultCacheHits.Add(1)
+	return entry.result.Copy(), true
+}
+
+// snapshot returns the current generations of the tables and the current
+// epoch. It must be called before executing the query whose result is cached,
+// so that a change applied during its execution invalidates the result.
+func (rc *resultCache) snapshot(tables []string) ([]uint64, uint32) {
+	epoch := rc.epoch.Load()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	generations := make([]uint64, len(tables))
+	for i, table := range tables {
+		generations[i] = rc.generations[table]
+	}
+	return generations, epoch
+}
+
+// set caches a copy of the result, read from the tables at the given
+// generations and epoch.
+func (rc *resultCache) set(key theine.HashKey256, tables []string, generations []uint64, epoch uint32, result *sqltypes.Result) {
+	rc.results.Set(key, &cachedResult{
+		result:      result.Copy(),
+		expires:     time.Now().Add(rc.ttl),
+		tables:      tables,
+		generations: generations,
+	}, 0, epoch)
+}
+
+// invalidate invalidates the cached results which read any of the tables.
+// The tables may be qualified by their keyspace.
+func (rc *resultCache) invalidate(tables ...string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, table := range tables {
+		if _, name, ok := strings.Cut(table, "."); ok {
+			table = name
+		}
+		rc.generations[table]++
+		resultCacheInvalidations.Add(table, 1)
+	}
+}
+
+// invalidateAll invalidates all the cached results.
+func (rc *resultCache) invalidateAll() {
+	rc.epoch.Add(1)
+}
+
+// vschemaUpdated invalidates all the cached results, as tables may no longer
+// be reference tables, and restarts the invalidation stream if the reference
+// tables have changed.
+func (rc *resultCache) vschemaUpdated(vschema *vindexes.VSchema) {
+	rc.invalidateAll()
+	tables := make(map[string][]string)
+	for ksName, ks := range vschema.Keyspaces {
+		for name, table := range ks.Tables {
+			if isReferenceTable(table) {
+				tables[ksName] = append(tables[ksName], name)
+			}
+		}
+		slices.Sort(tables[ksName])
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if maps.EqualFunc(tables, rc.tables, slices.Equal) {
+		return
+	}
+	rc.tables = tables
+	rc.restartStreamLocked()
+}
+
+// restartStreamLocked starts a new invalidation stream for the current
+// reference tables, after stopping the previous one.
+func (rc *resultCache) restartStreamLocked() {
+	if rc.cancel != nil {
+		rc.cancel()
+		rc.cancel = nil
+	}
+	if rc.streamer == nil || len(rc.tables) == 0 {
+		return
+	}
+
+	vgtid := &binlogdatapb.VGtid{}
+	filter := &binlogdatapb.Filter{}
+	names := make(map[string]bool)
+	for _, ks := range slices.Sorted(maps.Keys(rc.tables)) {
+		vgtid.ShardGtids = append(vgtid.ShardGtids, &binlogdatapb.ShardGtid{Keyspace: ks, Gtid: "current"})
+		for _, name := range rc.tables[ks] {
+			if !names[name] {
+				names[name] = true
+				filter.Rules = append(filter.Rules, &binlogdatapb.Rule{Match: name})
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rc.cancel = cancel
+	rc.wg.Add(1)
+	go rc.stream(ctx, rc.streamer, vgtid, filter)
+}
+
+// stream runs the invalidation stream until the context is canceled,
+// restarting it when it fails.
+func (rc *resultCache) stream(ctx context.Context, streamer resultCacheStreamer, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter) {
+	defer rc.wg.Done()
+	for {
+		err := streamer(ctx, vgtid, filter, rc.handleEvents)
+		// Changes may be missed until the stream is restarted.
+		rc.invalidateAll()
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warningf("Reference table result cache invalidation stream failed, restarting in %v: %v", resultCacheRetryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resultCacheRetryDelay):
+		}
+	}
+}
+
+func (rc *resultCache) handleEvents(events []*binlogdatapb.VEvent) error {
+	for _, event := range events {
+		switch event.Type {
+		case binlogdatapb.VEventType_ROW:
+			rc.invalidate(event.RowEvent.TableName)
+		case binlogdatapb.VEventType_FIELD:
+			rc.invalidate(event.FieldEvent.TableName)
+		case binlogdatapb.VEventType_DDL:
+			rc.invalidateAll()
+		}
+	}
+	return nil
+}
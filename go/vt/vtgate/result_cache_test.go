@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/callerid"
+	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+)
+
+func TestResultCache(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	executor.resultCache = newResultCache(time.Minute, 1024*1024)
+	wantResult := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|zip", "int64|varchar"), "1|94040")
+	sbclookup.SetResults(slices.Repeat([]*sqltypes.Result{wantResult}, 20))
+
+	query := "select id, zip from zip_detail where id = 1"
+	assertCached := func(t *testing.T, session *vtgatepb.Session, query string, cached bool) {
+		t.Helper()
+		execCount := sbclookup.ExecCount.Load()
+		qr, err := executorExec(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+		if cached {
+			assert.Equal(t, execCount, sbclookup.ExecCount.Load(), "the result should be served from the cache")
+			utils.MustMatch(t, wantResult, qr)
+		} else {
+			assert.Equal(t, execCount+1, sbclookup.ExecCount.Load(), "the query should be sent to the tablet")
+		}
+	}
+	session := &vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true}
+
+	assertCached(t, session, query, false)
+	assertCached(t, session, query, true)
+	assertCached(t, &vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true}, query, true)
+
+	// The query and the system variables are part of the key.
+	assertCached(t, session, "select id, zip from zip_detail where id = 2", false)
+	assertCached(t, &vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true, SystemVariables: map[string]string{"sql_mode": "''"}}, query, false)
+
+	// The callers do not share their results, as their access to the tables
+	// is checked by the tablets.
+	otherCtx := callerid.NewContext(ctx, nil, callerid.NewImmediateCallerID("other_user"))
+	execCount := sbclookup.ExecCount.Load()
+	_, err := executorExec(otherCtx, executor, session, query, nil)
+	require.NoError(t, err)
+	assert.Equal(t, execCount+1, sbclookup.ExecCount.Load(), "the result of another user should not be served")
+	assertCached(t, session, query, true)
+
+	// Replica reads and non-deterministic queries are not cached.
+	hits := resultCacheHits.Get()
+	replicaSession := &vtgatepb.Session{TargetString: KsTestUnsharded + "@replica", Autocommit: true}
+	for range 2 {
+		_, err := executorExec(ctx, executor, replicaSession, query, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, hits, resultCacheHits.Get(), "the replica reads should not be served from the cache")
+	assertCached(t, session, "select id, zip, now() from zip_detail where id = 1", false)
+	assertCached(t, session, "select id, zip, now() from zip_detail where id = 1", false)
+	assertCached(t, session, "select id, zip from zip_detail where id = 1 and rand() < 0.5", false)
+	assertCached(t, session, "select id, zip from zip_detail where id = 1 and rand() < 0.5", false)
+
+	// Transactions and tables which are not reference tables are not cached.
+	assertCached(t, &vtgatepb.Session{TargetString: KsTestUnsharded, InTransaction: true}, query, false)
+	assertCached(t, session, "select id from music_user_map where id = 1", false)
+	assertCached(t, session, "select id from music_user_map where id = 1", false)
+
+	// The DMLs of this vtgate invalidate the tables they write.
+	_, err = executorExec(ctx, executor, session, "delete from zip_detail where id = 3", nil)
+	require.NoError(t, err)
+	assertCached(t, session, query, false)
+	assertCached(t, session, query, true)
+
+	// Expired results are not served.
+	executor.resultCache.ttl = 0
+	query = "select id, zip from zip_detail where id = 3"
+	assertCached(t, session, query, false)
+	assertCached(t, session, query, false)
+}
+
+func TestResultCacheInvalidationStream(t *testing.T) {
+	executor, _, _, sbclookup, ctx := createExecutorEnv(t)
+	executor.resultCache = newResultCache(time.Minute, 1024*1024)
+
+	streams := make(chan []*binlogdatapb.VEvent)
+	processed := make(chan *binlogdatapb.VGtid)
+	filters := make(chan *binlogdatapb.Filter, 1)
+	executor.resultCache.Open(func(ctx context.Context, vgtid *binlogdatapb.VGtid, filter *binlogdatapb.Filter, send func([]*binlogdatapb.VEvent) error) error {
+		filters <- filter
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case events := <-streams:
+				if err := send(events); err != nil {
+					return err
+				}
+				processed <- vgtid
+			}
+		}
+	})
+	defer executor.resultCache.Close()
+	executor.SaveVSchema(executor.VSchema(), &VSchemaStats{})
+	utils.MustMatch(t, &binlogdatapb.Filter{Rules: []*binlogdatapb.Rule{{Match: "zip_detail"}}}, <-filters)
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{TargetString: KsTestUnsharded, Autocommit: true})
+	query := "select id, zip from zip_detail where id = 1"
+	execQuery := func() int64 {
+		execCount := sbclookup.ExecCount.Load()
+		_, err := executorExecSession(ctx, executor, session, query, nil)
+		require.NoError(t, err)
+		return sbclookup.ExecCount.Load() - execCount
+	}
+	assert.EqualValues(t, 1, execQuery())
+	assert.EqualValues(t, 0, execQuery())
+
+	// Changes of other tables are ignored.
+	streams <- []*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "music_user_map"}}}
+	vgtid := <-processed
+	assert.EqualValues(t, 0, execQuery())
+
+	streams <- []*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_ROW, RowEvent: &binlogdatapb.RowEvent{TableName: "zip_detail"}}}
+	<-processed
+	assert.EqualValues(t, 1, execQuery())
+	assert.EqualValues(t, 0, execQuery())
+
+	streams <- []*binlogdatapb.VEvent{{Type: binlogdatapb.VEventType_DDL}}
+	<-processed
+	assert.EqualValues(t, 1, execQuery())
+
+	var keyspaces []string
+	for _, shardGtid := range vgtid.ShardGtids {
+		assert.Equal(t, "current", shardGtid.Gtid)
+		keyspaces = append(keyspaces, shardGtid.Keyspace)
+	}
+	assert.Equal(t, []string{KsTestSharded, KsTestUnsharded}, keyspaces)
+}
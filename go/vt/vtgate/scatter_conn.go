@@ -40,6 +40,7 @@ import (
 	"vitess.io/vitess/go/vt/srvtopo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/buffer"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
 	"vitess.io/vitess/go/vt/vttablet/queryservice"
@@ -50,8 +51,13 @@ import (
 type ScatterConn struct {
 	timings              *stats.MultiTimings
 	tabletCallErrorCount *stats.CountersWithMultiLabels
-	txConn               *TxConn
-	gateway              *TabletGateway
+	// scatterQueueWaits times the shard queries which waited for one of the
+	// shards a scatter query is sent to in parallel to complete, and
+	// scatterQueued counts the ones waiting.
+	scatterQueueWaits *stats.Timings
+	scatterQueued     *stats.Gauge
+	txConn            *TxConn
+	gateway           *TabletGateway
 }
 
 // shardActionFunc defines the contract for a shard action
@@ -81,8 +87,12 @@ func (nullResultsObserver) Observe(*sqltypes.Result) {}
 func NewScatterConn(statsName string, txConn *TxConn, gw *TabletGateway) *ScatterConn {
 	// this only works with TabletGateway
 	tabletCallErrorCountStatsName := ""
+	scatterQueueWaitsStatsName := ""
+	scatterQueuedStatsName := ""
 	if statsName != "" {
 		tabletCallErrorCountStatsName = statsName + "ErrorCount"
+		scatterQueueWaitsStatsName = statsName + "ScatterQueueWaits"
+		scatterQueuedStatsName = statsName + "ScatterQueued"
 	}
 	return &ScatterConn{
 		timings: stats.NewMultiTimings(
@@ -93,6 +103,13 @@ func NewScatterConn(statsName string, txConn *TxConn, gw *TabletGateway) *Scatte
 			tabletCallErrorCountStatsName,
 			"Error count from tablet calls in scatter conns",
 			[]string{"Operation", "Keyspace", "ShardName", "DbType"}),
+		scatterQueueWaits: stats.NewTimings(
+			scatterQueueWaitsStatsName,
+			"Time the shard queries of scatter queries waited for their scatter concurrency",
+			"Operation"),
+		scatterQueued: stats.NewGauge(
+			scatterQueuedStatsName,
+			"Number of shard queries of scatter queries waiting for their scatter concurrency"),
 		txConn:  txConn,
 		gateway: gw,
 	}
@@ -223,7 +240,11 @@ func (stc *ScatterConn) ExecuteMultiShard(
 
 			switch info.actionNeeded {
 			case nothing:
-				innerqr, err = qs.Execute(ctx, session, rs.Target, queries[i].Sql, queries[i].BindVariables, info.transactionID, info.reservedID, opts)
+				rawOpts := readAfterWriteOptions(session, rs.Target, info, opts)
+				innerqr, err = qs.Execute(ctx, session, rs.Target, queries[i].Sql, queries[i].BindVariables, info.transactionID, info.reservedID, rawOpts)
+				if primary := readFromPrimary(rs.Target, rawOpts, err); primary != nil {
+					innerqr, err = rs.Gateway.Execute(ctx, session, primary, queries[i].Sql, queries[i].BindVariables, 0, 0, opts)
+				}
 				if err != nil {
 					retryRequest(func() {
 						// we seem to have lost our connection. it was a reserved connection, let's try to recreate it
@@ -273,6 +294,14 @@ func (stc *ScatterConn) ExecuteMultiShard(
 			if err != nil {
 				return newInfo, err
 			}
+			if opts.GetResultChecksum() {
+				if err := verifyResultChecksum(rs.Target, innerqr); err != nil {
+					return newInfo, err
+				}
+			}
+			if autocommit && innerqr.RowsAffected > 0 {
+				recordWriteGTIDs(ctx, rs.Gateway, session, rs.Target)
+			}
 			mu.Lock()
 			defer mu.Unlock()
 
@@ -438,6 +467,16 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				return nil, err
 			}
 
+			shardCallback := observedCallback
+			if opts.GetResultChecksum() {
+				shardCallback = func(reply *sqltypes.Result) error {
+					if err := verifyResultChecksum(rs.Target, reply); err != nil {
+						return err
+					}
+					return observedCallback(reply)
+				}
+			}
+
 			retryRequest := func(exec func()) {
 				retry := checkAndResetShardSession(info, err, session, rs.Target)
 				switch retry {
@@ -454,20 +493,24 @@ func (stc *ScatterConn) StreamExecuteMulti(
 
 			switch info.actionNeeded {
 			case nothing:
-				err = qs.StreamExecute(ctx, session, rs.Target, query, bindVars[i], transactionID, reservedID, opts, observedCallback)
+				rawOpts := readAfterWriteOptions(session, rs.Target, info, opts)
+				err = qs.StreamExecute(ctx, session, rs.Target, query, bindVars[i], transactionID, reservedID, rawOpts, shardCallback)
+				if primary := readFromPrimary(rs.Target, rawOpts, err); primary != nil {
+					err = rs.Gateway.StreamExecute(ctx, session, primary, query, bindVars[i], 0, 0, opts, shardCallback)
+				}
 				if err != nil {
 					retryRequest(func() {
 						// we seem to have lost our connection. it was a reserved connection, let's try to recreate it
 						info.actionNeeded = reserve
 						var state queryservice.ReservedState
-						state, err = qs.ReserveStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), query, bindVars[i], 0 /*transactionId*/, opts, observedCallback)
+						state, err = qs.ReserveStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), query, bindVars[i], 0 /*transactionId*/, opts, shardCallback)
 						reservedID = state.ReservedID
 						alias = state.TabletAlias
 					})
 				}
 			case begin:
 				var state queryservice.TransactionState
-				state, err = qs.BeginStreamExecute(ctx, session, rs.Target, session.SavePoints(), query, bindVars[i], reservedID, opts, observedCallback)
+				state, err = qs.BeginStreamExecute(ctx, session, rs.Target, session.SavePoints(), query, bindVars[i], reservedID, opts, shardCallback)
 				transactionID = state.TransactionID
 				alias = state.TabletAlias
 				if err != nil {
@@ -475,7 +518,7 @@ func (stc *ScatterConn) StreamExecuteMulti(
 						// we seem to have lost our connection. it was a reserved connection, let's try to recreate it
 						info.actionNeeded = reserveBegin
 						var state queryservice.ReservedTransactionState
-						state, err = qs.ReserveBeginStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, observedCallback)
+						state, err = qs.ReserveBeginStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, shardCallback)
 						transactionID = state.TransactionID
 						reservedID = state.ReservedID
 						alias = state.TabletAlias
@@ -483,12 +526,12 @@ func (stc *ScatterConn) StreamExecuteMulti(
 				}
 			case reserve:
 				var state queryservice.ReservedState
-				state, err = qs.ReserveStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), query, bindVars[i], transactionID, opts, observedCallback)
+				state, err = qs.ReserveStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), query, bindVars[i], transactionID, opts, shardCallback)
 				reservedID = state.ReservedID
 				alias = state.TabletAlias
 			case reserveBegin:
 				var state queryservice.ReservedTransactionState
-				state, err = qs.ReserveBeginStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, observedCallback)
+				state, err = qs.ReserveBeginStreamExecute(ctx, session, rs.Target, session.SetPreQueries(), session.SavePoints(), query, bindVars[i], opts, shardCallback)
 				transactionID = state.TransactionID
 				reservedID = state.ReservedID
 				alias = state.TabletAlias
@@ -612,6 +655,25 @@ func (stc *ScatterConn) GetHealthCheckCacheStatus() discovery.TabletsCacheStatus
 	return stc.gateway.TabletsCacheStatus()
 }
 
+// GetBufferStatus returns the buffering state of each shard.
+func (stc *ScatterConn) GetBufferStatus() []*buffer.ShardStatus {
+	return stc.gateway.BufferStatus()
+}
+
+// verifyResultChecksum returns an error if the checksum set by the tablet on
+// the result does not match its rows, which were then corrupted on their way.
+// A checksum of 0 is not verified, as the tablets which do not support result
+// checksums do not set it.
+func verifyResultChecksum(target *querypb.Target, qr *sqltypes.Result) error {
+	if qr == nil || qr.Checksum == 0 {
+		return nil
+	}
+	if checksum := sqltypes.RowsChecksum(0, qr.Rows); checksum != qr.Checksum {
+		return vterrors.Errorf(vtrpcpb.Code_DATA_LOSS, "checksum mismatch in the result from %s: got %08x, want %08x", topoproto.KeyspaceShardString(target.Keyspace, target.Shard), checksum, qr.Checksum)
+	}
+	return nil
+}
+
 // multiGo performs the requested 'action' on the specified
 // shards in parallel. This does not handle any transaction state.
 // The action function must match the shardActionFunc2 signature.
@@ -664,6 +726,8 @@ type panicData struct {
 // and updates the Session with the transaction id. If the session already
 // contains a transaction id for the shard, it reuses it.
 // The action function must match the shardActionTransactionFunc signature.
+// The scatter concurrency of the session, if any, bounds the number of shards
+// the action is performed on at the same time.
 //
 // It returns an error recorder in which each shard error is recorded positionally,
 // i.e. if rss[2] had an error, then the error recorder will store that error
@@ -719,10 +783,20 @@ func (stc *ScatterConn) multiGoTransaction(
 	} else {
 		var panicRecord atomic.Value
 		var wg sync.WaitGroup
+		var sem chan struct{}
+		if concurrency := session.GetExecScatterConcurrency(); concurrency > 0 && concurrency < numShards {
+			sem = make(chan struct{}, concurrency)
+		}
 		for i, rs := range rss {
+			if sem != nil {
+				stc.acquireScatterSlot(name, sem)
+			}
 			wg.Add(1)
 			go func(rs *srvtopo.ResolvedShard, i int) {
 				defer wg.Done()
+				if sem != nil {
+					defer func() { <-sem }()
+				}
 				defer func() {
 					if r := recover(); r != nil {
 						panicRecord.Store(&panicData{
@@ -747,6 +821,21 @@ func (stc *ScatterConn) multiGoTransaction(
 	return allErrors
 }
 
+// acquireScatterSlot waits for a slot of the scatter concurrency, recording
+// the wait if all the slots are taken.
+func (stc *ScatterConn) acquireScatterSlot(name string, sem chan struct{}) {
+	select {
+	case sem <- struct{}{}:
+		return
+	default:
+	}
+	startTime := time.Now()
+	stc.scatterQueued.Add(1)
+	sem <- struct{}{}
+	stc.scatterQueued.Add(-1)
+	stc.scatterQueueWaits.Record(name, startTime)
+}
+
 // ExecuteLock performs the requested 'action' on the specified
 // ResolvedShard. If the lock session already has a reserved connection,
 // it reuses it. Otherwise open a new reserved connection.
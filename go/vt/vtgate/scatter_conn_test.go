@@ -18,7 +18,10 @@ package vtgate
 
 import (
 	"fmt"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/stretchr/testify/assert"
@@ -290,6 +293,159 @@ func TestExecutePanic(t *testing.T) {
 	require.Contains(t, logMessage, "(*ScatterConn).multiGoTransaction")
 }
 
+func TestMultiGoTransactionScatterConcurrency(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "TestMultiGoTransactionScatterConcurrency"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	var rss []*srvtopo.ResolvedShard
+	for i := range 8 {
+		shard := strconv.Itoa(i)
+		sbc := hc.AddTestTablet("aa", shard, 1, keyspace, shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_PRIMARY},
+			Gateway: sbc,
+		})
+	}
+
+	testCases := []struct {
+		concurrency int
+		want        int64
+	}{
+		{concurrency: 0, want: 8},
+		{concurrency: 2, want: 2},
+		{concurrency: 16, want: 8},
+	}
+	for _, tc := range testCases {
+		t.Run(strconv.Itoa(tc.concurrency), func(t *testing.T) {
+			session := econtext.NewSafeSession(&vtgatepb.Session{Autocommit: true})
+			session.SetExecScatterConcurrency(tc.concurrency)
+
+			// Each action waits until all the shards are running or the
+			// concurrency limit is reached, to catch the peak.
+			var running, peak, done atomic.Int64
+			allErrors := sc.multiGoTransaction(ctx, "Execute", rss, session, true, func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				for peak.Load() < tc.want {
+					time.Sleep(time.Millisecond)
+				}
+				running.Add(-1)
+				done.Add(1)
+				return info, nil
+			})
+			require.NoError(t, allErrors.AggrError(vterrors.Aggregate))
+			assert.EqualValues(t, 8, done.Load())
+			assert.Equal(t, tc.want, peak.Load())
+		})
+	}
+}
+
+func TestMultiGoTransactionScatterQueue(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "TestMultiGoTransactionScatterQueue"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	var rss []*srvtopo.ResolvedShard
+	for i := range 4 {
+		shard := strconv.Itoa(i)
+		sbc := hc.AddTestTablet("aa", shard, 1, keyspace, shard, topodatapb.TabletType_PRIMARY, true, 1, nil)
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target:  &querypb.Target{Keyspace: keyspace, Shard: shard, TabletType: topodatapb.TabletType_PRIMARY},
+			Gateway: sbc,
+		})
+	}
+
+	session := econtext.NewSafeSession(&vtgatepb.Session{Autocommit: true})
+	session.SetExecScatterConcurrency(2)
+
+	// The shard queries block until released, so that the third one waits
+	// for a slot.
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		allErrors := sc.multiGoTransaction(ctx, "Execute", rss, session, true, func(rs *srvtopo.ResolvedShard, i int, info *shardActionInfo) (*shardActionInfo, error) {
+			<-release
+			return info, nil
+		})
+		assert.NoError(t, allErrors.AggrError(vterrors.Aggregate))
+	}()
+	assert.Eventually(t, func() bool {
+		return sc.scatterQueued.Get() == 1
+	}, 5*time.Second, time.Millisecond)
+	close(release)
+	<-finished
+
+	assert.Zero(t, sc.scatterQueued.Get())
+	assert.GreaterOrEqual(t, sc.scatterQueueWaits.Counts()["Execute"], int64(1))
+	assert.LessOrEqual(t, sc.scatterQueueWaits.Counts()["Execute"], int64(2))
+}
+
+func TestResultChecksum(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	keyspace := "TestResultChecksum"
+	createSandbox(keyspace)
+	hc := discovery.NewFakeHealthCheck(nil)
+	sc := newTestScatterConn(ctx, hc, newSandboxForCells(ctx, []string{"aa"}), "aa")
+	sbc := hc.AddTestTablet("aa", "0", 1, keyspace, "0", topodatapb.TabletType_PRIMARY, true, 1, nil)
+	rss := []*srvtopo.ResolvedShard{{
+		Target:  &querypb.Target{Keyspace: keyspace, Shard: "0", TabletType: topodatapb.TabletType_PRIMARY},
+		Gateway: sbc,
+	}}
+	queries := []*querypb.BoundQuery{{Sql: "select id from t"}}
+	rows := []sqltypes.Row{{sqltypes.NewInt64(1)}, {sqltypes.NewInt64(2)}}
+
+	testCases := []struct {
+		name     string
+		checksum uint32
+		wantErr  string
+	}{
+		{name: "valid checksum", checksum: sqltypes.RowsChecksum(0, rows)},
+		{name: "no checksum"},
+		{name: "invalid checksum", checksum: 1, wantErr: "checksum mismatch in the result from TestResultChecksum/0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			session := econtext.NewSafeSession(&vtgatepb.Session{
+				Autocommit: true,
+				Options:    &querypb.ExecuteOptions{ResultChecksum: true},
+			})
+
+			sbc.SetResults([]*sqltypes.Result{{Rows: rows, Checksum: tc.checksum}})
+			_, errs := sc.ExecuteMultiShard(ctx, nil, rss, queries, session, true, false, nullResultsObserver{}, false)
+			err := vterrors.Aggregate(errs)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Equal(t, vtrpcpb.Code_DATA_LOSS, vterrors.Code(err))
+			}
+
+			sbc.SetResults([]*sqltypes.Result{{Rows: rows, Checksum: tc.checksum}})
+			errs = sc.StreamExecuteMulti(ctx, nil, queries[0].Sql, rss, []map[string]*querypb.BindVariable{nil}, session, true, func(*sqltypes.Result) error {
+				return nil
+			}, nullResultsObserver{}, false)
+			err = vterrors.Aggregate(errs)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestReservedOnMultiReplica(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
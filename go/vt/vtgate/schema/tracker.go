@@ -35,6 +35,7 @@ import (
 
 type (
 	keyspaceStr  = string
+	shardStr     = string
 	tableNameStr = string
 	viewNameStr  = string
 
@@ -67,7 +68,7 @@ func NewTracker(ch chan *discovery.TabletHealth, enableViews, enableUDFs bool, p
 	t := &Tracker{
 		ctx:          context.Background(),
 		ch:           ch,
-		tables:       &tableMap{m: make(map[keyspaceStr]map[tableNameStr]*vindexes.TableInfo)},
+		tables:       &tableMap{m: make(map[keyspaceStr]map[tableNameStr]*vindexes.TableInfo), stats: make(map[keyspaceStr]map[shardStr]*shardStats)},
 		tracked:      map[keyspaceStr]*updateController{},
 		consumeDelay: defaultConsumeDelay,
 		parser:       parser,
@@ -118,7 +119,7 @@ func (t *Tracker) loadTables(conn queryservice.QueryService, target *querypb.Tar
 
 	var numTables int
 	err := conn.GetSchema(t.ctx, target, querypb.SchemaTableType_TABLES, nil, func(schemaRes *querypb.GetSchemaResponse) error {
-		t.updateTables(target.Keyspace, schemaRes.TableDefinition)
+		t.updateTables(target, schemaRes)
 		numTables += len(schemaRes.TableDefinition)
 		return nil
 	})
@@ -331,11 +332,15 @@ func (t *Tracker) updateSchema(th *discovery.TabletHealth) bool {
 		success = t.updatedViewSchema(th)
 	}
 
-	if !success || !th.Stats.UdfsChanged {
-		return success
+	if success && th.Stats.UdfsChanged {
+		success = t.loadUDFs(th.Conn, th.Target) == nil
 	}
 
-	return t.loadUDFs(th.Conn, th.Target) == nil
+	// the statistics of the tables were updated on the tablet
+	if success && th.Stats.TableStatsChanged {
+		success = t.updatedTableStats(th)
+	}
+	return success
 }
 
 func (t *Tracker) updatedTableSchema(th *discovery.TabletHealth) bool {
@@ -350,7 +355,7 @@ func (t *Tracker) updatedTableSchema(th *discovery.TabletHealth) bool {
 		t.tables.delete(th.Target.Keyspace, tbl)
 	}
 	err := th.Conn.GetSchema(t.ctx, th.Target, querypb.SchemaTableType_TABLES, tablesUpdated, func(schemaRes *querypb.GetSchemaResponse) error {
-		t.updateTables(th.Target.Keyspace, schemaRes.TableDefinition)
+		t.updateTables(th.Target, schemaRes)
 		return nil
 	})
 	if err != nil {
@@ -362,8 +367,8 @@ func (t *Tracker) updatedTableSchema(th *discovery.TabletHealth) bool {
 	return true
 }
 
-func (t *Tracker) updateTables(keyspace string, res map[string]string) {
-	for tableName, tableDef := range res {
+func (t *Tracker) updateTables(target *querypb.Target, res *querypb.GetSchemaResponse) {
+	for tableName, tableDef := range res.TableDefinition {
 		stmt, err := t.parser.ParseStrictDDL(tableDef)
 		if err != nil {
 			log.Warningf("error parsing table definition for %s: %v", tableName, err)
@@ -375,9 +380,38 @@ func (t *Tracker) updateTables(keyspace string, res map[string]string) {
 			continue
 		}
 
-		cols := getColumns(ddl.TableSpec)
-		fks := getForeignKeys(ddl.TableSpec)
-		t.tables.set(keyspace, tableName, cols, fks, ddl.TableSpec.Indexes)
+		info := NewTableInfo(ddl.TableSpec)
+		t.tables.setShardTableStats(target.Keyspace, target.Shard, tableName, res.TableRows[tableName], res.IndexCardinalities[tableName].GetCardinalities())
+		info.Rows, info.IndexCardinalities = t.tables.tableStats(target.Keyspace, tableName)
+		t.tables.set(target.Keyspace, tableName, info)
+	}
+}
+
+// updatedTableStats reloads the statistics of the tables of the shard, which
+// the tablet signaled as updated.
+func (t *Tracker) updatedTableStats(th *discovery.TabletHealth) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := th.Conn.GetSchema(t.ctx, th.Target, querypb.SchemaTableType_TABLE_STATS, nil, func(schemaRes *querypb.GetSchemaResponse) error {
+		t.tables.setStats(th.Target.Keyspace, th.Target.Shard, schemaRes.TableRows, schemaRes.IndexCardinalities)
+		return nil
+	})
+	if err != nil {
+		log.Warningf("error fetching the table statistics for keyspace %s: %v", th.Target.Keyspace, err)
+		return false
+	}
+	return true
+}
+
+// NewTableInfo returns the information the tracker records about a table
+// from its definition.
+func NewTableInfo(tblSpec *sqlparser.TableSpec) *vindexes.TableInfo {
+	return &vindexes.TableInfo{
+		Columns:          getColumns(tblSpec),
+		ForeignKeys:      getForeignKeys(tblSpec),
+		Indexes:          tblSpec.Indexes,
+		CheckConstraints: getCheckConstraints(tblSpec),
 	}
 }
 
@@ -400,6 +434,8 @@ func getColumns(tblSpec *sqlparser.TableSpec) []vindexes.Column {
 				Scale:         int32(scale),
 				Nullable:      nullable,
 				Values:        column.Type.EnumValues,
+				Generated:     column.Type.Options.As,
+				Stored:        column.Type.Options.Storage == sqlparser.StoredStorage,
 			})
 	}
 	return cols
@@ -420,6 +456,21 @@ func getForeignKeys(tblSpec *sqlparser.TableSpec) []*sqlparser.ForeignKeyDefinit
 	return fks
 }
 
+func getCheckConstraints(tblSpec *sqlparser.TableSpec) []*vindexes.CheckConstraint {
+	var checks []*vindexes.CheckConstraint
+	for _, constraint := range tblSpec.Constraints {
+		check, ok := constraint.Details.(*sqlparser.CheckConstraintDefinition)
+		if !ok || !check.Enforced {
+			continue
+		}
+		checks = append(checks, &vindexes.CheckConstraint{
+			Name: constraint.Name.String(),
+			Expr: check.Expr,
+		})
+	}
+	return checks
+}
+
 func getTableCollation(tblSpec *sqlparser.TableSpec) string {
 	if tblSpec.Options == nil {
 		return ""
@@ -500,15 +551,84 @@ func (t *Tracker) AddNewKeyspace(conn queryservice.QueryService, target *querypb
 
 type tableMap struct {
 	m map[keyspaceStr]map[tableNameStr]*vindexes.TableInfo
+	// stats are the statistics of the tables of each shard, which are summed
+	// up in the table infos.
+	stats map[keyspaceStr]map[shardStr]*shardStats
+}
+
+// shardStats are the statistics of the tables of a shard.
+type shardStats struct {
+	rows          map[tableNameStr]int64
+	cardinalities map[tableNameStr]map[string]int64
 }
 
-func (tm *tableMap) set(ks, tbl string, cols []vindexes.Column, fks []*sqlparser.ForeignKeyDefinition, indexes []*sqlparser.IndexDefinition) {
+func (tm *tableMap) set(ks, tbl string, info *vindexes.TableInfo) {
 	m := tm.m[ks]
 	if m == nil {
 		m = make(map[tableNameStr]*vindexes.TableInfo)
 		tm.m[ks] = m
 	}
-	m[tbl] = &vindexes.TableInfo{Columns: cols, ForeignKeys: fks, Indexes: indexes}
+	m[tbl] = info
+}
+
+// setStats sets the statistics of the tables of the shard, and sums them up
+// in the known tables of the keyspace. The table infos are replaced rather
+// than updated, as they are shared with the callers of Tables.
+func (tm *tableMap) setStats(ks, shard string, rows map[string]int64, cardinalities map[string]*querypb.IndexCardinalities) {
+	stats := &shardStats{rows: rows, cardinalities: make(map[tableNameStr]map[string]int64, len(cardinalities))}
+	for tbl, cards := range cardinalities {
+		stats.cardinalities[tbl] = cards.GetCardinalities()
+	}
+	tm.shardStats(ks)[shard] = stats
+
+	for tbl, info := range tm.m[ks] {
+		kopy := *info
+		kopy.Rows, kopy.IndexCardinalities = tm.tableStats(ks, tbl)
+		tm.m[ks][tbl] = &kopy
+	}
+}
+
+// setShardTableStats sets the statistics of the table on the shard.
+func (tm *tableMap) setShardTableStats(ks, shard, tbl string, rows int64, cardinalities map[string]int64) {
+	stats := tm.shardStats(ks)[shard]
+	if stats == nil {
+		stats = &shardStats{}
+		tm.shardStats(ks)[shard] = stats
+	}
+	if stats.rows == nil {
+		stats.rows = make(map[tableNameStr]int64)
+	}
+	if stats.cardinalities == nil {
+		stats.cardinalities = make(map[tableNameStr]map[string]int64)
+	}
+	stats.rows[tbl] = rows
+	stats.cardinalities[tbl] = cardinalities
+}
+
+func (tm *tableMap) shardStats(ks string) map[shardStr]*shardStats {
+	m := tm.stats[ks]
+	if m == nil {
+		m = make(map[shardStr]*shardStats)
+		tm.stats[ks] = m
+	}
+	return m
+}
+
+// tableStats returns the number of rows of the table and the cardinalities
+// of its indexes, summed up across the shards of the keyspace. The rows of
+// a sharded table are spread across the shards, as are the distinct values
+// of its indexes at most.
+func (tm *tableMap) tableStats(ks, tbl string) (rows int64, cardinalities map[string]int64) {
+	for _, stats := range tm.stats[ks] {
+		rows += stats.rows[tbl]
+		for idx, cardinality := range stats.cardinalities[tbl] {
+			if cardinalities == nil {
+				cardinalities = make(map[string]int64)
+			}
+			cardinalities[idx] += cardinality
+		}
+	}
+	return rows, cardinalities
 }
 
 func (tm *tableMap) get(ks, tbl string) *vindexes.TableInfo {
@@ -520,6 +640,10 @@ func (tm *tableMap) get(ks, tbl string) *vindexes.TableInfo {
 }
 
 func (tm *tableMap) delete(ks, tbl string) {
+	for _, stats := range tm.stats[ks] {
+		delete(stats.rows, tbl)
+		delete(stats.cardinalities, tbl)
+	}
 	m := tm.m[ks]
 	if m == nil {
 		return
@@ -460,6 +460,129 @@ func TestIndexInfoRetrieval(t *testing.T) {
 	testTracker(t, false, schemaDefResult, testcases)
 }
 
+// TestGeneratedColumnAndCheckConstraintRetrieval tests that the generated columns and the enforced check constraints
+// are retrieved from the ddl statement.
+func TestGeneratedColumnAndCheckConstraintRetrieval(t *testing.T) {
+	stmt, err := sqlparser.NewTestParser().ParseStrictDDL("CREATE TABLE `t` (" +
+		"`a` int DEFAULT NULL," +
+		"`b` int GENERATED ALWAYS AS ((`a` + 1)) STORED," +
+		"`c` int GENERATED ALWAYS AS ((`a` * 2)) VIRTUAL," +
+		"CONSTRAINT `chk_a` CHECK ((`a` > 0))," +
+		"CONSTRAINT `chk_b` CHECK ((`b` < 10)) /*!80016 NOT ENFORCED */) " +
+		"ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci")
+	require.NoError(t, err)
+	tblSpec := stmt.(*sqlparser.CreateTable).TableSpec
+
+	cols := getColumns(tblSpec)
+	require.Len(t, cols, 3)
+	assert.False(t, cols[0].IsGenerated())
+	assert.True(t, cols[1].IsGenerated())
+	assert.True(t, cols[1].Stored)
+	assert.Equal(t, "a + 1", sqlparser.String(cols[1].Generated))
+	assert.True(t, cols[2].IsGenerated())
+	assert.False(t, cols[2].Stored)
+
+	checks := getCheckConstraints(tblSpec)
+	require.Len(t, checks, 1)
+	assert.Equal(t, "chk_a", checks[0].Name)
+	assert.Equal(t, "a > 0", sqlparser.String(checks[0].Expr))
+}
+
+// TestTableStatsRetrieval tests that the tracker records the statistics of
+// the tables when the tablet reports them, and reloads them when the tablet
+// signals that they were updated.
+func TestTableStatsRetrieval(t *testing.T) {
+	ch := make(chan *discovery.TabletHealth)
+	tracker := NewTracker(ch, false, false, sqlparser.NewTestParser())
+	tracker.consumeDelay = 1 * time.Millisecond
+	tracker.Start()
+	defer tracker.Stop()
+
+	wg := sync.WaitGroup{}
+	tracker.RegisterSignalReceiver(func() {
+		wg.Done()
+	})
+
+	target := &querypb.Target{Cell: cell, Keyspace: keyspace, Shard: "-80", TabletType: topodatapb.TabletType_PRIMARY}
+	tablet := &topodatapb.Tablet{Keyspace: target.Keyspace, Shard: target.Shard, Type: target.TabletType}
+
+	sbc := sandboxconn.NewSandboxConn(tablet)
+	sbc.SetSchemaResult([]sandboxconn.SchemaResult{{
+		TablesAndViews: map[string]string{
+			"t1": "CREATE TABLE `t1` (`id` int NOT NULL, PRIMARY KEY (`id`))",
+			"t2": "CREATE TABLE `t2` (`id` int NOT NULL, PRIMARY KEY (`id`))",
+		},
+		TableRows: map[string]int64{"t1": 1000},
+		IndexCardinalities: map[string]*querypb.IndexCardinalities{
+			"t1": {Cardinalities: map[string]int64{"PRIMARY": 1000}},
+		},
+	}, {
+		TableRows: map[string]int64{"t1": 2000, "t2": 10},
+	}})
+
+	wg.Add(1)
+	ch <- &discovery.TabletHealth{
+		Conn:    sbc,
+		Tablet:  tablet,
+		Target:  target,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{},
+	}
+	require.False(t, waitTimeout(&wg, time.Second), "schema was updated but received no signal")
+
+	tables := tracker.Tables(keyspace)
+	require.Len(t, tables, 2)
+	assert.EqualValues(t, 1000, tables["t1"].Rows)
+	assert.Equal(t, map[string]int64{"PRIMARY": 1000}, tables["t1"].IndexCardinalities)
+	// The statistics of t2 are not known.
+	assert.Zero(t, tables["t2"].Rows)
+	assert.Nil(t, tables["t2"].IndexCardinalities)
+
+	wg.Add(1)
+	ch <- &discovery.TabletHealth{
+		Conn:    sbc,
+		Tablet:  tablet,
+		Target:  target,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{TableStatsChanged: true},
+	}
+	require.False(t, waitTimeout(&wg, time.Second), "table statistics were updated but received no signal")
+
+	tables = tracker.Tables(keyspace)
+	require.Len(t, tables, 2)
+	assert.EqualValues(t, 2000, tables["t1"].Rows)
+	assert.Nil(t, tables["t1"].IndexCardinalities)
+	assert.EqualValues(t, 10, tables["t2"].Rows)
+	// The definitions of the tables are kept.
+	assert.Len(t, tables["t2"].Columns, 1)
+
+	// The statistics of the shards are summed up.
+	target2 := &querypb.Target{Cell: cell, Keyspace: keyspace, Shard: "80-", TabletType: topodatapb.TabletType_PRIMARY}
+	tablet2 := &topodatapb.Tablet{Keyspace: target2.Keyspace, Shard: target2.Shard, Type: target2.TabletType}
+	sbc2 := sandboxconn.NewSandboxConn(tablet2)
+	sbc2.SetSchemaResult([]sandboxconn.SchemaResult{{
+		TableRows: map[string]int64{"t1": 500},
+		IndexCardinalities: map[string]*querypb.IndexCardinalities{
+			"t1": {Cardinalities: map[string]int64{"PRIMARY": 500}},
+		},
+	}})
+
+	wg.Add(1)
+	ch <- &discovery.TabletHealth{
+		Conn:    sbc2,
+		Tablet:  tablet2,
+		Target:  target2,
+		Serving: true,
+		Stats:   &querypb.RealtimeStats{TableStatsChanged: true},
+	}
+	require.False(t, waitTimeout(&wg, time.Second), "table statistics were updated but received no signal")
+
+	tables = tracker.Tables(keyspace)
+	assert.EqualValues(t, 2500, tables["t1"].Rows)
+	assert.Equal(t, map[string]int64{"PRIMARY": 500}, tables["t1"].IndexCardinalities)
+	assert.EqualValues(t, 10, tables["t2"].Rows)
+}
+
 func empty() sandboxconn.SchemaResult {
 	return sandboxconn.SchemaResult{TablesAndViews: map[string]string{}}
 }
@@ -236,6 +236,14 @@ func TestRecursiveCTEChecking(t *testing.T) {
 		name:  "use the same recursive cte twice in definition",
 		query: "with recursive x as (select 1 union select id+1 from x where id < 10 union select id+2 from x where id < 20) select t.id from x",
 		err:   "VT09029: In recursive query block of Recursive Common Table Expression x, the recursive table must be referenced only once, and not in any subquery",
+	}, {
+		name:  "recursive CTE with a LIMIT",
+		query: "with recursive x as (select 1 as id union select id+1 from x where id < 10 limit 5) select t.id from x t",
+		err:   "VT12001: unsupported: LIMIT in recursive CTE 'x'",
+	}, {
+		name:  "recursive query block that is not the last one",
+		query: "with recursive x as (select 1 as id union select id+1 from x where id < 10 union select 42) select t.id from x t",
+		err:   "VT12001: unsupported: recursive query block before the last UNION member in recursive CTE 'x'",
 	}}
 	for _, tc := range queries {
 		t.Run(tc.query, func(t *testing.T) {
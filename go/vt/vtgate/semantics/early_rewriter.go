@@ -53,9 +53,7 @@ func (r *earlyRewriter) down(cursor *sqlparser.Cursor) error {
 	case *sqlparser.ComparisonExpr:
 		return handleComparisonExpr(cursor, node)
 	case *sqlparser.With:
-		if !node.Recursive {
-			return r.handleWith(node)
-		}
+		return r.handleWith(node)
 	case *sqlparser.AliasedTableExpr:
 		return r.handleAliasedTable(node)
 	case *sqlparser.Delete:
@@ -149,15 +147,23 @@ func (r *earlyRewriter) handleAliasedTable(node *sqlparser.AliasedTableExpr) err
 	return nil
 }
 
+// handleWith inlines the common table expressions of the WITH clause. In a
+// WITH RECURSIVE clause, only the ones that do not reference themselves are
+// inlined; the recursive ones are planned as such.
 func (r *earlyRewriter) handleWith(node *sqlparser.With) error {
 	scope := r.scoper.currentScope()
+	var recursive []*sqlparser.CommonTableExpr
 	for _, cte := range node.CTEs {
+		if node.Recursive && referencesCTE(cte.Subquery, cte.ID.String()) {
+			recursive = append(recursive, cte)
+			continue
+		}
 		err := scope.addCTE(cte)
 		if err != nil {
 			return err
 		}
 	}
-	node.CTEs = nil
+	node.CTEs = recursive
 	return nil
 }
 
@@ -95,6 +95,9 @@ type (
 	// operators.
 	MirrorInfo struct {
 		Percent float32
+		// Split is true if the query is served by the mirror target,
+		// instead of being mirrored to it.
+		Split bool
 	}
 
 	// SemTable contains semantic analysis information about the query.
@@ -1028,14 +1031,20 @@ func (st *SemTable) ForeachExprEquality(e sqlparser.Expr, f func(expr sqlparser.
 // The idea here is that if you have two tables with mirror rules both involved
 // in a query, and one of those rules is 1% while the other is 100%, to mirror
 // the query with 1% chance.
+//
+// The query is split, instead of mirrored, only if all the rules are split
+// rules.
 func mirrorInfo(tableInfos []TableInfo) MirrorInfo {
 	mi := MirrorInfo{}
+	split := true
 	for _, t := range tableInfos {
 		if mr := t.GetMirrorRule(); mr != nil {
 			if mi.Percent == 0 || mr.Percent < mi.Percent {
 				mi.Percent = mr.Percent
 			}
+			split = split && mr.Split
 		}
 	}
+	mi.Split = mi.Percent > 0 && split
 	return mi
 }
@@ -415,6 +415,14 @@ func checkValidRecursiveCTE(cteDef *CTE) error {
 		return vterrors.VT09026(cteDef.Name)
 	}
 
+	if union.Limit != nil {
+		return vterrors.VT12001(fmt.Sprintf("LIMIT in recursive CTE '%s'", cteDef.Name))
+	}
+	if !referencesCTE(union.Right, cteDef.Name) {
+		// the recursive query block is not the last member of the UNION
+		return vterrors.VT12001(fmt.Sprintf("recursive query block before the last UNION member in recursive CTE '%s'", cteDef.Name))
+	}
+
 	firstSelect, err := sqlparser.GetFirstSelect(union.Right)
 	if err != nil {
 		return err
@@ -431,6 +439,19 @@ func checkValidRecursiveCTE(cteDef *CTE) error {
 	return nil
 }
 
+// referencesCTE returns true if the node uses the CTE with the given name.
+func referencesCTE(node sqlparser.SQLNode, name string) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		tbl, ok := node.(sqlparser.TableName)
+		if ok && tbl.Qualifier.IsEmpty() && tbl.Name.String() == name {
+			found = true
+		}
+		return !found, nil
+	}, node)
+	return found
+}
+
 func (tc *tableCollector) handleDerivedTable(node *sqlparser.AliasedTableExpr, t *sqlparser.DerivedTable) error {
 	switch sel := t.Select.(type) {
 	case *sqlparser.Select:
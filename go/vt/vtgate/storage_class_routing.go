@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtgate
+
+import (
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/flagutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/discovery"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+var (
+	// workloadStorageClasses maps the workloads to the storage class of the
+	// tablets preferred for their queries, e.g. olap:capacity,oltp:nvme.
+	workloadStorageClasses flagutil.StringMapValue
+
+	storageClassFallbacks = stats.NewCountersWithMultiLabels("StorageClassFallbacks", "Queries sent to a tablet of another storage class because no healthy tablet of the storage class of their workload was found", []string{"Keyspace", "ShardName", "DbType"})
+)
+
+// parseWorkloadStorageClasses returns the storage class of each workload
+// from the --workload-storage-classes flag.
+func parseWorkloadStorageClasses(classes map[string]string) (map[querypb.ExecuteOptions_Workload]string, error) {
+	if len(classes) == 0 {
+		return nil, nil
+	}
+	result := make(map[querypb.ExecuteOptions_Workload]string, len(classes))
+	for name, class := range classes {
+		workload, ok := querypb.ExecuteOptions_Workload_value[strings.ToUpper(name)]
+		if !ok || workload == int32(querypb.ExecuteOptions_UNSPECIFIED) {
+			return nil, fmt.Errorf("invalid workload %q in --workload-storage-classes", name)
+		}
+		if class == "" {
+			return nil, fmt.Errorf("empty storage class for workload %s in --workload-storage-classes", name)
+		}
+		result[querypb.ExecuteOptions_Workload(workload)] = class
+	}
+	return result, nil
+}
+
+// preferStorageClass returns the tablets of the storage class of the
+// workload, or all the tablets if the workload has no storage class or none
+// of the tablets is of its storage class. The primary is never filtered, as
+// it is the only tablet of its target.
+func (gw *TabletGateway) preferStorageClass(target *querypb.Target, tablets []*discovery.TabletHealth, workload querypb.ExecuteOptions_Workload) []*discovery.TabletHealth {
+	class, ok := gw.storageClasses[workload]
+	if !ok || target.TabletType == topodatapb.TabletType_PRIMARY {
+		return tablets
+	}
+	onClass := make([]*discovery.TabletHealth, 0, len(tablets))
+	for _, t := range tablets {
+		if t.Tablet.Tags[topoproto.StorageClassTag] == class {
+			onClass = append(onClass, t)
+		}
+	}
+	if len(onClass) == 0 {
+		storageClassFallbacks.Add([]string{target.Keyspace, target.Shard, topoproto.TabletTypeLString(target.TabletType)}, 1)
+		return tablets
+	}
+	return onClass
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"vitess.io/vitess/go/acl"
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/vt/discovery"
 	"vitess.io/vitess/go/vt/log"
@@ -76,6 +77,9 @@ func registerTabletGatewayFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&balancerModeFlag, "vtgate-balancer-mode", "", fmt.Sprintf("Tablet balancer mode (options: %s). Defaults to 'cell' which shuffles tablets in the local cell.", strings.Join(balancer.GetAvailableModeNames(), ", ")))
 	fs.StringSliceVar(&balancerVtgateCells, "balancer-vtgate-cells", []string{}, "Comma-separated list of cells that contain vttablets. For 'prefer-cell' mode, this is required. For 'random' mode, this is optional and filters tablets to those cells.")
 	fs.StringSliceVar(&balancerKeyspaces, "balancer-keyspaces", []string{}, "Comma-separated list of keyspaces for which to use the balancer (optional). If empty, applies to all keyspaces.")
+	fs.Float64Var(&hedgedReadsPercentile, "hedged-reads-percentile", 0, "If set, reads on replica and rdonly tablets outside of transactions are also sent to another healthy tablet when they take longer than this percentile (0-100) of the recent latencies of their shard, or fail, and the first response is used. 0 disables hedged reads.")
+	utils.SetFlagDurationVar(fs, &hedgedReadsMinDelay, "hedged-reads-min-delay", hedgedReadsMinDelay, "The minimum time to wait for a response before hedging a read.")
+	fs.Var(&workloadStorageClasses, "workload-storage-classes", "Comma-separated list of workload:storage_class pairs, e.g. olap:capacity,oltp:nvme. The queries of each listed workload are preferably sent to the replica and rdonly tablets with the storage_class tag of its storage class, falling back to the other tablets if none is healthy.")
 }
 
 func registerVtcomboTabletGatewayFlags(fs *pflag.FlagSet) {
@@ -112,6 +116,19 @@ type TabletGateway struct {
 
 	// balancerMode is the current tablet balancer mode.
 	balancerMode balancer.Mode
+
+	// hedger, if enabled, decides when reads on replicas are hedged.
+	hedger *hedger
+
+	// storageClasses maps the workloads to the storage class of the tablets
+	// preferred for their queries.
+	storageClasses map[querypb.ExecuteOptions_Workload]string
+
+	// primeTargets are the targets WaitForTablets waits for, until the
+	// healthcheck has found healthy tablets for all of them and primed is
+	// set.
+	primeTargets []*querypb.Target
+	primed       atomic.Bool
 }
 
 func createHealthCheck(ctx context.Context, retryDelay, timeout time.Duration, ts *topo.Server, cell, cellsToWatch string) discovery.HealthCheck {
@@ -119,7 +136,7 @@ func createHealthCheck(ctx context.Context, retryDelay, timeout time.Duration, t
 	if err != nil {
 		log.Exit(err)
 	}
-	return discovery.NewHealthCheck(ctx, retryDelay, timeout, ts, cell, cellsToWatch, filters)
+	return discovery.NewHealthCheck(ctx, retryDelay, timeout, ts, cell, cellsToWatch, filters, discovery.NewVTGateHealthCheckOptions()...)
 }
 
 // NewTabletGateway creates and returns a new TabletGateway
@@ -145,6 +162,17 @@ func NewTabletGateway(ctx context.Context, hc discovery.HealthCheck, serv srvtop
 	}
 	gw.setupBuffering(ctx)
 	gw.setupBalancer()
+	if hedgedReadsPercentile > 100 {
+		log.Exitf("--hedged-reads-percentile must be between 0 and 100")
+	}
+	if hedgedReadsPercentile > 0 {
+		gw.hedger = newHedger(hedgedReadsPercentile, hedgedReadsMinDelay)
+	}
+	storageClasses, err := parseWorkloadStorageClasses(workloadStorageClasses)
+	if err != nil {
+		log.Exitf("Unable to create new TabletGateway: %v", err)
+	}
+	gw.storageClasses = storageClasses
 	gw.QueryService = queryservice.Wrap(nil, gw.withRetry)
 	return gw
 }
@@ -260,6 +288,7 @@ func (gw *TabletGateway) WaitForTablets(ctx context.Context, tabletTypesToWait [
 
 	// Skip waiting for tablets if we are not told to do so.
 	if len(tabletTypesToWait) == 0 {
+		gw.primed.Store(true)
 		return nil
 	}
 
@@ -268,6 +297,11 @@ func (gw *TabletGateway) WaitForTablets(ctx context.Context, tabletTypesToWait [
 	if err != nil {
 		return err
 	}
+	// WaitForAllServingTablets clears the targets as it finds them, so
+	// IsPrimed gets its own copy.
+	gw.mu.Lock()
+	gw.primeTargets = append([]*querypb.Target{}, targets...)
+	gw.mu.Unlock()
 	err = gw.hc.WaitForAllServingTablets(ctx, targets)
 	if err != nil {
 		return err
@@ -284,6 +318,34 @@ func (gw *TabletGateway) WaitForTablets(ctx context.Context, tabletTypesToWait [
 	return nil
 }
 
+// IsPrimed returns whether the healthcheck has found healthy tablets for all
+// the targets waited for by WaitForTablets, even if it only did after
+// WaitForTablets timed out. Once primed, the gateway stays primed.
+func (gw *TabletGateway) IsPrimed() bool {
+	if gw.primed.Load() {
+		return true
+	}
+
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	if gw.primeTargets == nil {
+		// WaitForTablets has not found the targets yet.
+		return false
+	}
+	remaining := gw.primeTargets[:0]
+	for _, target := range gw.primeTargets {
+		if len(gw.hc.GetHealthyTabletStats(target)) == 0 {
+			remaining = append(remaining, target)
+		}
+	}
+	gw.primeTargets = remaining
+	if len(remaining) > 0 {
+		return false
+	}
+	gw.primed.Store(true)
+	return true
+}
+
 // Close shuts down underlying connections.
 // This function hides the inner implementation.
 func (gw *TabletGateway) Close(_ context.Context) error {
@@ -315,6 +377,65 @@ func (gw *TabletGateway) DebugBalancerHandler(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// BufferStatus returns the buffering state of the shards which went through
+// the buffer, or nil if buffering is disabled.
+func (gw *TabletGateway) BufferStatus() []*buffer.ShardStatus {
+	if gw.buffer == nil {
+		return nil
+	}
+	return gw.buffer.Status()
+}
+
+// DebugBufferHandler shows the buffering state of each shard on GET. On POST,
+// it force drains (action=drain) or extends (action=extend&duration=...) the
+// ongoing buffering of the given keyspace and shard, which can help during
+// failovers that take longer than expected or that are known to be complete.
+func (gw *TabletGateway) DebugBufferHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		returnAsJSON(w, gw.BufferStatus())
+		return
+	}
+
+	if err := acl.CheckAccessHTTP(r, acl.ADMIN); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if gw.buffer == nil {
+		http.Error(w, "buffering is not enabled", http.StatusBadRequest)
+		return
+	}
+	keyspace, shard := r.FormValue("keyspace"), r.FormValue("shard")
+	if keyspace == "" || shard == "" {
+		http.Error(w, "keyspace and shard are required", http.StatusBadRequest)
+		return
+	}
+	var err error
+	switch action := r.FormValue("action"); action {
+	case "drain":
+		err = gw.buffer.ForceDrain(keyspace, shard)
+	case "extend":
+		var d time.Duration
+		d, err = time.ParseDuration(r.FormValue("duration"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid duration: %v", err), http.StatusBadRequest)
+			return
+		}
+		err = gw.buffer.ExtendBuffering(keyspace, shard, d)
+	default:
+		http.Error(w, fmt.Sprintf("unknown action %q, must be one of: drain, extend", action), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	returnAsJSON(w, gw.BufferStatus())
+}
+
 // withRetry gets available connections and executes the action. If there are retryable errors,
 // it retries retryCount times before failing. It does not retry if the connection is in
 // the middle of a transaction. While returning the error check if it maybe a result of
@@ -454,6 +575,26 @@ func (gw *TabletGateway) getBalancerTablet(target *querypb.Target, tablets []*di
 		}
 	}
 
+	// Prefer the tablets in the cell of the target, if any. The cell is set by the
+	// routes of vindexes which place the rows of the shard in that cell.
+	if target.Cell != "" {
+		inCell := make([]*discovery.TabletHealth, 0, len(tablets))
+		for _, t := range tablets {
+			if t.Tablet.Alias.Cell == target.Cell {
+				inCell = append(inCell, t)
+			}
+		}
+		if len(inCell) > 0 {
+			tablets = inCell
+		}
+	}
+
+	// Prefer the tablets of the storage class of the workload, if any, e.g.
+	// capacity-optimized storage for OLAP queries.
+	if len(gw.storageClasses) > 0 {
+		tablets = gw.preferStorageClass(target, tablets, opts.Workload)
+	}
+
 	// Determine if we should use the balancer for this target
 	useBalancer := gw.balancer != nil
 	if useBalancer && len(balancerKeyspaces) > 0 {
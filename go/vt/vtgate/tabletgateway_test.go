@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 
@@ -36,6 +37,7 @@ import (
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/sandboxconn"
 )
@@ -169,6 +171,88 @@ func TestTabletGatewayShuffleTablets(t *testing.T) {
 	}
 }
 
+func TestTabletGatewayPreferredCell(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	ts := &econtext.FakeTopoServer{}
+	tg := NewTabletGateway(ctx, hc, ts, "cell1")
+	defer tg.Close(ctx)
+
+	newTablet := func(uid uint32, cell string) *discovery.TabletHealth {
+		return &discovery.TabletHealth{
+			Tablet:  topo.NewTablet(uid, cell, "host"),
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Serving: true,
+		}
+	}
+	ts1 := newTablet(1, "cell1")
+	ts2 := newTablet(2, "cell2")
+	ts3 := newTablet(3, "cell2")
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA, Cell: "cell2"}
+	for range 10 {
+		// The tablets in the cell of the target are preferred over the local cell.
+		tablet := tg.getBalancerTablet(target, []*discovery.TabletHealth{ts1, ts2, ts3}, nil, queryservice.WrapOpts{})
+		assert.Contains(t, []*discovery.TabletHealth{ts2, ts3}, tablet)
+
+		// Other tablets are used if the cell of the target has none left.
+		tablet = tg.getBalancerTablet(target, []*discovery.TabletHealth{ts1, ts2, ts3}, map[string]bool{
+			topoproto.TabletAliasString(ts2.Tablet.Alias): true,
+			topoproto.TabletAliasString(ts3.Tablet.Alias): true,
+		}, queryservice.WrapOpts{})
+		assert.Equal(t, ts1, tablet)
+	}
+}
+
+func TestTabletGatewayStorageClass(t *testing.T) {
+	ctx := utils.LeakCheckContext(t)
+
+	hc := discovery.NewFakeHealthCheck(nil)
+	ts := &econtext.FakeTopoServer{}
+	tg := NewTabletGateway(ctx, hc, ts, "cell1")
+	defer tg.Close(ctx)
+	var err error
+	tg.storageClasses, err = parseWorkloadStorageClasses(map[string]string{"olap": "capacity", "oltp": "nvme"})
+	require.NoError(t, err)
+
+	newTablet := func(uid uint32, storageClass string) *discovery.TabletHealth {
+		tablet := topo.NewTablet(uid, "cell1", "host")
+		tablet.Tags = map[string]string{topoproto.StorageClassTag: storageClass}
+		return &discovery.TabletHealth{
+			Tablet:  tablet,
+			Target:  &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Serving: true,
+		}
+	}
+	ts1 := newTablet(1, "nvme")
+	ts2 := newTablet(2, "capacity")
+	ts3 := newTablet(3, "capacity")
+	tablets := []*discovery.TabletHealth{ts1, ts2, ts3}
+
+	target := &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	olap := queryservice.WrapOpts{Workload: querypb.ExecuteOptions_OLAP}
+	oltp := queryservice.WrapOpts{Workload: querypb.ExecuteOptions_OLTP}
+	fallbacks := storageClassFallbacks.Counts()["k.s.replica"]
+	for range 10 {
+		// The tablets of the storage class of the workload are preferred.
+		assert.Contains(t, []*discovery.TabletHealth{ts2, ts3}, tg.getBalancerTablet(target, slices.Clone(tablets), nil, olap))
+		assert.Equal(t, ts1, tg.getBalancerTablet(target, slices.Clone(tablets), nil, oltp))
+
+		// Other tablets are used if the storage class has none left.
+		tablet := tg.getBalancerTablet(target, slices.Clone(tablets), map[string]bool{
+			topoproto.TabletAliasString(ts1.Tablet.Alias): true,
+		}, oltp)
+		assert.Contains(t, []*discovery.TabletHealth{ts2, ts3}, tablet)
+	}
+	assert.EqualValues(t, 10, storageClassFallbacks.Counts()["k.s.replica"]-fallbacks)
+
+	_, err = parseWorkloadStorageClasses(map[string]string{"batch": "capacity"})
+	assert.EqualError(t, err, `invalid workload "batch" in --workload-storage-classes`)
+	_, err = parseWorkloadStorageClasses(map[string]string{"olap": ""})
+	assert.EqualError(t, err, "empty storage class for workload olap in --workload-storage-classes")
+}
+
 func TestTabletGatewayReplicaTransactionError(t *testing.T) {
 	ctx := utils.LeakCheckContext(t)
 
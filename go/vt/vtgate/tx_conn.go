@@ -161,6 +161,11 @@ func (txc *TxConn) Commit(ctx context.Context, session *econtext.SafeSession) er
 		_ = txc.Release(ctx, session)
 		return err
 	}
+	for _, shardSession := range session.ShardSessions {
+		if shardSession.RowsAffected {
+			recordWriteGTIDs(ctx, txc.tabletGateway, session, shardSession.Target)
+		}
+	}
 
 	err = txc.runSessions(ctx, session.PostSessions, session.GetLogger(), txc.commitShard)
 	if err != nil {
@@ -104,6 +104,52 @@ func (cached *ColumnVindex) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *ConsistentHash) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(96)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field nodes []*vitess.io/vitess/go/vt/vtgate/vindexes.ConsistentHashNode
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.nodes)) * int64(8))
+		for _, elem := range cached.nodes {
+			size += elem.CachedSize(true)
+		}
+	}
+	// field ring []vitess.io/vitess/go/vt/vtgate/vindexes.consistentHashPoint
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.ring)) * int64(16))
+	}
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
+
+func (cached *ConsistentHashNode) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field Name string
+	size += hack.RuntimeAllocSize(int64(len(cached.Name)))
+	// field KeyRange *vitess.io/vitess/go/vt/proto/topodata.KeyRange
+	size += cached.KeyRange.CachedSize(true)
+	return size
+}
+
 func (cached *ConsistentLookup) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -177,6 +223,48 @@ func (cached *Keyspace) CachedSize(alloc bool) int64 {
 	return size
 }
 
+//go:nocheckptr
+func (cached *LookupCache) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field vindex string
+	size += hack.RuntimeAllocSize(int64(len(cached.vindex)))
+	// field entries map[string]map[vitess.io/vitess/go/vt/proto/query.Type]*container/list.Element
+	if cached.entries != nil {
+		size += hack.RuntimeMapSize(cached.entries)
+		for k, v := range cached.entries {
+			size += hack.RuntimeAllocSize(int64(len(k)))
+			if v != nil {
+				size += hack.RuntimeMapSize(v)
+				for _, v := range v {
+					if v != nil {
+						// WARNING: size of external type container/list.Element cannot be fully calculated
+						size += hack.RuntimeAllocSize(int64(40))
+					}
+				}
+			}
+		}
+	}
+	// field lru *container/list.List
+	if cached.lru != nil {
+		// WARNING: size of external type container/list.List cannot be fully calculated
+		size += hack.RuntimeAllocSize(int64(48))
+	}
+	// field fills map[*vitess.io/vitess/go/vt/vtgate/vindexes.lookupCacheFill]struct{}
+	if cached.fills != nil {
+		size += hack.RuntimeMapSize(cached.fills)
+		for k := range cached.fills {
+			size += k.CachedSize(true)
+		}
+	}
+	return size
+}
+
 func (cached *LookupCost) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -196,7 +284,7 @@ func (cached *LookupHash) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -218,7 +306,7 @@ func (cached *LookupHashUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -240,7 +328,7 @@ func (cached *LookupNonUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -262,7 +350,7 @@ func (cached *LookupUnicodeLooseMD5Hash) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -284,7 +372,7 @@ func (cached *LookupUnicodeLooseMD5HashUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -306,7 +394,7 @@ func (cached *LookupUnique) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(192)
+		size += int64(240)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -430,6 +518,34 @@ func (cached *NumericStaticMap) CachedSize(alloc bool) int64 {
 	return size
 }
 
+//go:nocheckptr
+func (cached *RegionCell) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(48)
+	}
+	// field RegionExperimental *vitess.io/vitess/go/vt/vtgate/vindexes.RegionExperimental
+	size += cached.RegionExperimental.CachedSize(true)
+	// field cells map[uint64]string
+	if cached.cells != nil {
+		size += hack.RuntimeMapSize(cached.cells)
+		for _, v := range cached.cells {
+			size += hack.RuntimeAllocSize(int64(len(v)))
+		}
+	}
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
+
 func (cached *RegionExperimental) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -510,6 +626,28 @@ func (cached *ReverseBits) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *TimeBucket) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field bucket string
+	size += hack.RuntimeAllocSize(int64(len(cached.bucket)))
+	// field unknownParams []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.unknownParams)) * int64(16))
+		for _, elem := range cached.unknownParams {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
+
 func (cached *UnicodeLooseMD5) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
@@ -600,7 +738,7 @@ func (cached *clCommon) CachedSize(alloc bool) int64 {
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(288)
+		size += int64(352)
 	}
 	// field name string
 	size += hack.RuntimeAllocSize(int64(len(cached.name)))
@@ -628,13 +766,32 @@ func (cached *clCommon) CachedSize(alloc bool) int64 {
 	return size
 }
 
+//go:nocheckptr
+func (cached *lookupCacheFill) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(8)
+	}
+	// field invalidated map[string]bool
+	if cached.invalidated != nil {
+		size += hack.RuntimeMapSize(cached.invalidated)
+		for k := range cached.invalidated {
+			size += hack.RuntimeAllocSize(int64(len(k)))
+		}
+	}
+	return size
+}
+
 func (cached *lookupInternal) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(144)
+		size += int64(192)
 	}
 	// field Table string
 	size += hack.RuntimeAllocSize(int64(len(cached.Table)))
@@ -649,6 +806,13 @@ func (cached *lookupInternal) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.To)))
 	// field ReadLock string
 	size += hack.RuntimeAllocSize(int64(len(cached.ReadLock)))
+	// field CoveringColumns []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.CoveringColumns)) * int64(16))
+		for _, elem := range cached.CoveringColumns {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
 	// field sel string
 	size += hack.RuntimeAllocSize(int64(len(cached.sel)))
 	// field selTxDml string
@@ -657,6 +821,10 @@ func (cached *lookupInternal) CachedSize(alloc bool) int64 {
 	size += hack.RuntimeAllocSize(int64(len(cached.ver)))
 	// field del string
 	size += hack.RuntimeAllocSize(int64(len(cached.del)))
+	// field name string
+	size += hack.RuntimeAllocSize(int64(len(cached.name)))
+	// field cache *vitess.io/vitess/go/vt/vtgate/vindexes.LookupCache
+	size += cached.cache.CachedSize(true)
 	return size
 }
 
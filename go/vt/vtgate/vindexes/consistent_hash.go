@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+const (
+	consistentHashParamNodes        = "nodes"
+	consistentHashParamVirtualNodes = "virtual_nodes"
+
+	defaultConsistentHashVirtualNodes = 256
+)
+
+var (
+	_ SingleColumn    = (*ConsistentHash)(nil)
+	_ Hashing         = (*ConsistentHash)(nil)
+	_ ParamValidating = (*ConsistentHash)(nil)
+
+	consistentHashParams = []string{
+		consistentHashParamNodes,
+		consistentHashParamVirtualNodes,
+	}
+)
+
+// ConsistentHash is a unique vindex which maps the ids onto a consistent
+// hashing ring. Every node of the ring owns a key range, and is placed on the
+// ring at virtual_nodes points. An id belongs to the node of the first point
+// which follows its xxhash64 on the ring, and its keyspace id is its hash
+// scaled into the key range of that node.
+//
+// The key range of a node must not change once rows are written, as the
+// keyspace ids of all its ids would change with it. Nodes can however be
+// added to the ring: the points of a node only depend on its name, so adding
+// a node to a ring of N nodes only moves the ids which now belong to the new
+// node, about 1/(N+1) of them taken evenly from all the other nodes, and the
+// other ids keep their keyspace id. The key ranges of the nodes do not need
+// to cover the full key range, so that a node can be given a part of a shard
+// and the rest be left for the nodes added later. Reshard plan computes the
+// nodes to add for the target shards of a Reshard workflow, and the ids which
+// they move.
+//
+// Moving the rows of these ids to their new node is not done by the Reshard
+// workflow, which copies the rows of its source shards by their current
+// keyspace id and thus keeps the ring: the new ring is applied by a MoveTables
+// workflow into a keyspace whose VSchema has the new nodes.
+//
+// The nodes are given as a comma separated list of name:keyrange, or of
+// keyrange only, in which case the key range is also the name of the node:
+//
+//	"vindexes": {
+//	  "ring": {
+//	    "type": "consistent_hash",
+//	    "params": {
+//	      "nodes": "n1:-40,n2:40-80,n3:80-",
+//	      "virtual_nodes": "256"
+//	    }
+//	  }
+//	}
+type ConsistentHash struct {
+	name          string
+	nodes         []*ConsistentHashNode
+	virtualNodes  int
+	ring          []consistentHashPoint
+	unknownParams []string
+}
+
+// ConsistentHashNode is a node of the ring of a ConsistentHash vindex.
+type ConsistentHashNode struct {
+	Name     string
+	KeyRange *topodatapb.KeyRange
+
+	start uint64
+	// width is the size of the key range, 0 meaning the full range.
+	width uint64
+}
+
+type consistentHashPoint struct {
+	hash uint64
+	node int
+}
+
+// newConsistentHash creates a new ConsistentHash.
+func newConsistentHash(name string, m map[string]string) (Vindex, error) {
+	virtualNodes := defaultConsistentHashVirtualNodes
+	if v := m[consistentHashParamVirtualNodes]; v != "" {
+		var err error
+		virtualNodes, err = strconv.Atoi(v)
+		if err != nil || virtualNodes <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "virtual_nodes value must be a positive integer: '%s'", v)
+		}
+	}
+	nodes, err := ParseConsistentHashNodes(m[consistentHashParamNodes])
+	if err != nil {
+		return nil, err
+	}
+	return &ConsistentHash{
+		name:          name,
+		nodes:         nodes,
+		virtualNodes:  virtualNodes,
+		ring:          buildConsistentHashRing(nodes, virtualNodes),
+		unknownParams: FindUnknownParams(m, consistentHashParams),
+	}, nil
+}
+
+// ParseConsistentHashNodes parses the nodes param of a ConsistentHash vindex.
+func ParseConsistentHashNodes(param string) ([]*ConsistentHashNode, error) {
+	if strings.TrimSpace(param) == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "consistent_hash vindex requires the nodes param")
+	}
+	var nodes []*ConsistentHashNode
+	names := make(map[string]bool)
+	for _, entry := range strings.Split(param, ",") {
+		entry = strings.TrimSpace(entry)
+		name, keyRange, found := strings.Cut(entry, ":")
+		if !found {
+			keyRange = name
+		}
+		node, err := newConsistentHashNode(name, keyRange)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "invalid consistent_hash node '%s'", entry)
+		}
+		if names[node.Name] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "consistent_hash node %s is specified more than once", node.Name)
+		}
+		for _, other := range nodes {
+			if key.KeyRangeIntersect(node.KeyRange, other.KeyRange) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the key ranges of the consistent_hash nodes %s and %s overlap", other.Name, node.Name)
+			}
+		}
+		names[node.Name] = true
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func newConsistentHashNode(name, keyRange string) (*ConsistentHashNode, error) {
+	if name == "" {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "the node name cannot be empty")
+	}
+	start, end, found := strings.Cut(keyRange, "-")
+	if !found {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "malformed key range: %s", keyRange)
+	}
+	kr, err := key.ParseKeyRangeParts(start, end)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "malformed key range %s: %v", keyRange, err)
+	}
+	if len(kr.Start) > 8 || len(kr.End) > 8 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "key range bounds longer than 8 bytes are not supported: %s", keyRange)
+	}
+	node := &ConsistentHashNode{
+		Name:     name,
+		KeyRange: kr,
+		start:    uint64FromKeyRangeBound(kr.Start),
+	}
+	// An end of 0 means 2^64, so the width wraps around accordingly.
+	node.width = uint64FromKeyRangeBound(kr.End) - node.start
+	if !key.Empty(kr.End) && (bytes.Compare(kr.Start, kr.End) >= 0 || node.width == 0) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "empty key range: %s", keyRange)
+	}
+	return node, nil
+}
+
+func uint64FromKeyRangeBound(bound []byte) uint64 {
+	var buf [8]byte
+	copy(buf[:], bound)
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+// String returns the key range of the node, prefixed by its name if they
+// differ, as in the nodes param.
+func (node *ConsistentHashNode) String() string {
+	kr := key.KeyRangeString(node.KeyRange)
+	if node.Name == kr {
+		return kr
+	}
+	return node.Name + ":" + kr
+}
+
+// keyspaceID scales the hash into the key range of the node.
+func (node *ConsistentHashNode) keyspaceID(hash uint64) []byte {
+	ksid := hash
+	if node.width != 0 {
+		scaled, _ := bits.Mul64(hash, node.width)
+		ksid = node.start + scaled
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], ksid)
+	return buf[:]
+}
+
+func buildConsistentHashRing(nodes []*ConsistentHashNode, virtualNodes int) []consistentHashPoint {
+	ring := make([]consistentHashPoint, 0, len(nodes)*virtualNodes)
+	for i, node := range nodes {
+		for v := 0; v < virtualNodes; v++ {
+			ring = append(ring, consistentHashPoint{
+				hash: consistentHashPointHash(node.Name, v),
+				node: i,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash != ring[j].hash {
+			return ring[i].hash < ring[j].hash
+		}
+		return ring[i].node < ring[j].node
+	})
+	return ring
+}
+
+func consistentHashPointHash(node string, virtualNode int) uint64 {
+	return xxhash.Sum64String(node + "#" + strconv.Itoa(virtualNode))
+}
+
+// nodeOf returns the index of the node which owns the hash on the ring.
+func (vind *ConsistentHash) nodeOf(hash uint64) int {
+	i := sort.Search(len(vind.ring), func(i int) bool {
+		return vind.ring[i].hash >= hash
+	})
+	if i == len(vind.ring) {
+		i = 0
+	}
+	return vind.ring[i].node
+}
+
+// String returns the name of the vindex.
+func (vind *ConsistentHash) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this index as 1.
+func (vind *ConsistentHash) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (vind *ConsistentHash) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (vind *ConsistentHash) NeedsVCursor() bool {
+	return false
+}
+
+// Map can map ids to key.ShardDestination objects.
+func (vind *ConsistentHash) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, key.DestinationKeyspaceID(ksid))
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (vind *ConsistentHash) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	for i, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, bytes.Equal(ksid, ksids[i]))
+	}
+	return out, nil
+}
+
+// Hash returns the keyspace id of the id in the key range of the node which
+// owns it.
+func (vind *ConsistentHash) Hash(id sqltypes.Value) ([]byte, error) {
+	idBytes, err := id.ToBytes()
+	if err != nil {
+		return nil, err
+	}
+	hash := xxhash.Sum64(idBytes)
+	return vind.nodes[vind.nodeOf(hash)].keyspaceID(hash), nil
+}
+
+// Nodes returns the nodes of the ring, in the order of the nodes param.
+func (vind *ConsistentHash) Nodes() []*ConsistentHashNode {
+	return vind.nodes
+}
+
+// VirtualNodes returns the number of points of every node on the ring.
+func (vind *ConsistentHash) VirtualNodes() int {
+	return vind.virtualNodes
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (vind *ConsistentHash) UnknownParams() []string {
+	return vind.unknownParams
+}
+
+// ConsistentHashMove is a fraction of the ids which move from a node of the
+// ring to a new one.
+type ConsistentHashMove struct {
+	FromNode     string  `json:"from_node"`
+	FromKeyRange string  `json:"from_key_range"`
+	ToNode       string  `json:"to_node"`
+	ToKeyRange   string  `json:"to_key_range"`
+	Fraction     float64 `json:"fraction"`
+}
+
+// ConsistentHashPlan is the new ring of a ConsistentHash vindex once its
+// keyspace is resharded, and the ids which move from a node to another.
+type ConsistentHashPlan struct {
+	Vindex string `json:"vindex"`
+	// Nodes is the nodes param of the vindex for the new ring.
+	Nodes string                `json:"nodes"`
+	Moves []*ConsistentHashMove `json:"moves,omitempty"`
+	// MovedFraction is the fraction of all the ids which move to another node.
+	MovedFraction float64 `json:"moved_fraction"`
+}
+
+// PlanConsistentHashReshard computes the ring of the vindex once its keyspace
+// is resharded into the target key ranges. Every node of the ring is kept
+// with its key range, which must thus be contained in a single target key
+// range if it overlaps any. The target key ranges which overlap no node are
+// added to the ring as new nodes named after them, so that every target shard
+// gets its share of the ids. Keeping the nodes, and thus their points on the
+// ring and their key ranges, is what minimizes the ids which move, and keeps
+// the keyspace ids of the ids which do not.
+func PlanConsistentHashReshard(vind *ConsistentHash, targets []*topodatapb.KeyRange) (*ConsistentHashPlan, error) {
+	if len(targets) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "no target key ranges")
+	}
+	newNodes := slices.Clone(vind.nodes)
+	names := make(map[string]bool)
+	for _, node := range newNodes {
+		names[node.Name] = true
+	}
+	for _, target := range targets {
+		overlaps := false
+		for _, node := range vind.nodes {
+			if !key.KeyRangeIntersect(target, node.KeyRange) {
+				continue
+			}
+			if !key.KeyRangeContainsKeyRange(target, node.KeyRange) {
+				return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the key range %s of node %s spans several target shards, and cannot change",
+					key.KeyRangeString(node.KeyRange), node.Name)
+			}
+			overlaps = true
+		}
+		if overlaps {
+			continue
+		}
+		kr := key.KeyRangeString(target)
+		if names[kr] {
+			return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "node %s of the new ring would be added twice", kr)
+		}
+		names[kr] = true
+		node, err := newConsistentHashNode(kr, kr)
+		if err != nil {
+			return nil, err
+		}
+		newNodes = append(newNodes, node)
+	}
+
+	plan := &ConsistentHashPlan{
+		Vindex: vind.name,
+		Nodes:  consistentHashNodesString(newNodes),
+	}
+	next := &ConsistentHash{
+		name:         vind.name,
+		nodes:        newNodes,
+		virtualNodes: vind.virtualNodes,
+		ring:         buildConsistentHashRing(newNodes, vind.virtualNodes),
+	}
+	// The owners of an arc of the ring can only change at the points of
+	// either ring, so the owners of every arc between two consecutive points
+	// are those of the point which ends it.
+	points := make([]uint64, 0, len(vind.ring)+len(next.ring))
+	for _, p := range vind.ring {
+		points = append(points, p.hash)
+	}
+	for _, p := range next.ring {
+		points = append(points, p.hash)
+	}
+	slices.Sort(points)
+
+	type move struct{ from, to int }
+	fractions := make(map[move]float64)
+	for i, point := range points {
+		// The arc of the first point wraps around from the last one.
+		arc := point - points[(i+len(points)-1)%len(points)]
+		if arc == 0 {
+			continue
+		}
+		// The nodes of the current ring keep their index in the new one.
+		from, to := vind.nodeOf(point), next.nodeOf(point)
+		if from == to {
+			continue
+		}
+		fractions[move{from, to}] += float64(arc) / (1 << 64)
+	}
+	for m, fraction := range fractions {
+		plan.Moves = append(plan.Moves, &ConsistentHashMove{
+			FromNode:     vind.nodes[m.from].Name,
+			FromKeyRange: key.KeyRangeString(vind.nodes[m.from].KeyRange),
+			ToNode:       next.nodes[m.to].Name,
+			ToKeyRange:   key.KeyRangeString(next.nodes[m.to].KeyRange),
+			Fraction:     fraction,
+		})
+		plan.MovedFraction += fraction
+	}
+	sort.Slice(plan.Moves, func(i, j int) bool {
+		if plan.Moves[i].FromNode != plan.Moves[j].FromNode {
+			return plan.Moves[i].FromNode < plan.Moves[j].FromNode
+		}
+		return plan.Moves[i].ToNode < plan.Moves[j].ToNode
+	})
+	return plan, nil
+}
+
+// consistentHashNodesString returns the nodes param of the ring.
+func consistentHashNodesString(nodes []*ConsistentHashNode) string {
+	out := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		out = append(out, node.String())
+	}
+	return strings.Join(out, ",")
+}
+
+func init() {
+	Register("consistent_hash", newConsistentHash)
+}
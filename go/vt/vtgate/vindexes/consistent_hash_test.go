@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func consistentHashCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "consistent_hash",
+		vindexName:   "consistent_hash",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "consistent_hash",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestConsistentHashCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		consistentHashCreateVindexTestCase(
+			"nodes",
+			map[string]string{
+				"nodes":         "n1:-40,n2:40-80,80-",
+				"virtual_nodes": "16",
+			},
+			nil,
+			nil,
+		),
+		consistentHashCreateVindexTestCase(
+			"unknown params",
+			map[string]string{
+				"nodes": "-",
+				"hello": "world",
+			},
+			nil,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func TestConsistentHashCreateVindexErrors(t *testing.T) {
+	testCases := []struct {
+		nodes        string
+		virtualNodes string
+		wantErr      string
+	}{{
+		nodes:   "",
+		wantErr: "consistent_hash vindex requires the nodes param",
+	}, {
+		nodes:        "-",
+		virtualNodes: "0",
+		wantErr:      "virtual_nodes value must be a positive integer: '0'",
+	}, {
+		nodes:   "n1:-80,n1:80-",
+		wantErr: "consistent_hash node n1 is specified more than once",
+	}, {
+		nodes:   "n1:-80,n2:40-",
+		wantErr: "the key ranges of the consistent_hash nodes n1 and n2 overlap",
+	}, {
+		nodes:   "n1:80-40",
+		wantErr: "invalid consistent_hash node 'n1:80-40': empty key range: 80-40",
+	}, {
+		nodes:   ":-80",
+		wantErr: "invalid consistent_hash node ':-80': the node name cannot be empty",
+	}, {
+		nodes:   "n1:0",
+		wantErr: "invalid consistent_hash node 'n1:0': malformed key range: 0",
+	}, {
+		nodes:   "n1:-0102030405060708090a",
+		wantErr: "invalid consistent_hash node 'n1:-0102030405060708090a': key range bounds longer than 8 bytes are not supported: -0102030405060708090a",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.nodes, func(t *testing.T) {
+			_, err := CreateVindex("consistent_hash", "ring", map[string]string{
+				"nodes":         tc.nodes,
+				"virtual_nodes": tc.virtualNodes,
+			})
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func createConsistentHash(t *testing.T, nodes string) *ConsistentHash {
+	vindex, err := CreateVindex("consistent_hash", "ring", map[string]string{"nodes": nodes})
+	require.NoError(t, err)
+	return vindex.(*ConsistentHash)
+}
+
+func TestConsistentHashMap(t *testing.T) {
+	ring := createConsistentHash(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-")
+	ids := make([]sqltypes.Value, 0, 4000)
+	for i := range 4000 {
+		ids = append(ids, sqltypes.NewInt64(int64(i)))
+	}
+	destinations, err := ring.Map(context.Background(), nil, ids)
+	require.NoError(t, err)
+
+	// The ids are spread evenly over the nodes, and every keyspace id is in
+	// the key range of its node.
+	counts := make(map[string]int)
+	ksids := make([][]byte, 0, len(ids))
+	for i, dest := range destinations {
+		ksid := []byte(dest.(key.DestinationKeyspaceID))
+		ksids = append(ksids, ksid)
+		hash, err := ring.Hash(ids[i])
+		require.NoError(t, err)
+		assert.Equal(t, ksid, hash)
+		for _, node := range ring.Nodes() {
+			if key.KeyRangeContains(node.KeyRange, ksid) {
+				counts[node.Name]++
+			}
+		}
+	}
+	require.Len(t, counts, 4)
+	for name, count := range counts {
+		assert.InDelta(t, 1000, count, 200, "node %s", name)
+	}
+
+	verified, err := ring.Verify(context.Background(), nil, ids, ksids)
+	require.NoError(t, err)
+	assert.NotContains(t, verified, false)
+
+	// A single node covering the full key range keeps the hash as it is.
+	full := createConsistentHash(t, "-")
+	ksid, err := full.Hash(sqltypes.NewInt64(1))
+	require.NoError(t, err)
+	assert.Equal(t, xxhashSum(t, sqltypes.NewInt64(1)), binary.BigEndian.Uint64(ksid))
+}
+
+func TestConsistentHashAddNode(t *testing.T) {
+	before := createConsistentHash(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-e0")
+	after := createConsistentHash(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-e0,n5:e0-")
+
+	const count = 10000
+	moved := 0
+	for i := range count {
+		id := sqltypes.NewInt64(int64(i))
+		beforeNode := before.Nodes()[before.nodeOf(xxhashSum(t, id))].Name
+		afterNode := after.Nodes()[after.nodeOf(xxhashSum(t, id))].Name
+		if beforeNode != afterNode {
+			// The ids only move to the new node.
+			require.Equal(t, "n5", afterNode)
+			moved++
+			continue
+		}
+		// The other ids keep their keyspace id.
+		beforeKsid, err := before.Hash(id)
+		require.NoError(t, err)
+		afterKsid, err := after.Hash(id)
+		require.NoError(t, err)
+		require.Equal(t, beforeKsid, afterKsid)
+	}
+	// About 1/5th of the ids move.
+	assert.InDelta(t, count/5, moved, count/25)
+}
+
+func TestPlanConsistentHashReshard(t *testing.T) {
+	ring := createConsistentHash(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-e0")
+	targets, err := key.ParseShardingSpec("c0-e0-")
+	require.NoError(t, err)
+
+	plan, err := PlanConsistentHashReshard(ring, targets)
+	require.NoError(t, err)
+	assert.Equal(t, "ring", plan.Vindex)
+	assert.Equal(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-e0,e0-", plan.Nodes)
+	// The ids only move to the new node, from all the other nodes.
+	require.Len(t, plan.Moves, 4)
+	for _, move := range plan.Moves {
+		assert.Equal(t, "e0-", move.ToNode)
+		assert.Equal(t, "e0-", move.ToKeyRange)
+		assert.InDelta(t, 0.05, move.Fraction, 0.02, "node %s", move.FromNode)
+	}
+	assert.Equal(t, "c0-e0", plan.Moves[3].FromKeyRange)
+	assert.InDelta(t, 0.2, plan.MovedFraction, 0.03)
+
+	// The new ring maps the ids as the plan says, and keeps the keyspace ids
+	// of those which do not move.
+	next := createConsistentHash(t, plan.Nodes)
+	moved := 0
+	for i := range 10000 {
+		id := sqltypes.NewInt64(int64(i))
+		hash := xxhashSum(t, id)
+		if ring.Nodes()[ring.nodeOf(hash)].Name != next.Nodes()[next.nodeOf(hash)].Name {
+			moved++
+			continue
+		}
+		ksid, err := ring.Hash(id)
+		require.NoError(t, err)
+		nextKsid, err := next.Hash(id)
+		require.NoError(t, err)
+		require.Equal(t, ksid, nextKsid)
+	}
+	assert.InDelta(t, plan.MovedFraction, float64(moved)/10000, 0.02)
+
+	// Merging shards keeps all their nodes, so no id moves.
+	plan, err = PlanConsistentHashReshard(ring, []*topodatapb.KeyRange{{End: []byte{0x80}}})
+	require.NoError(t, err)
+	assert.Equal(t, "n1:-40,n2:40-80,n3:80-c0,n4:c0-e0", plan.Nodes)
+	assert.Empty(t, plan.Moves)
+	assert.Zero(t, plan.MovedFraction)
+
+	// The key range of a node cannot be split.
+	targets, err = key.ParseShardingSpec("80-a0-c0")
+	require.NoError(t, err)
+	_, err = PlanConsistentHashReshard(ring, targets)
+	assert.EqualError(t, err, "the key range 80-c0 of node n3 spans several target shards, and cannot change")
+
+	_, err = PlanConsistentHashReshard(ring, nil)
+	assert.EqualError(t, err, "no target key ranges")
+}
+
+func xxhashSum(t *testing.T, id sqltypes.Value) uint64 {
+	idBytes, err := id.ToBytes()
+	require.NoError(t, err)
+	return xxhash.Sum64(idBytes)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cespare/xxhash/v2"
@@ -31,13 +32,15 @@ import (
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine"
 )
 
 const (
-	consistentLookupParamWriteOnly = "write_only"
+	consistentLookupParamWriteOnly    = "write_only"
+	consistentLookupParamCacheLookups = "cache_lookups"
 )
 
 var (
@@ -46,15 +49,19 @@ var (
 	_ WantOwnerInfo   = (*ConsistentLookupUnique)(nil)
 	_ LookupPlanable  = (*ConsistentLookupUnique)(nil)
 	_ ParamValidating = (*ConsistentLookupUnique)(nil)
+	_ LookupStreamed  = (*ConsistentLookupUnique)(nil)
 	_ SingleColumn    = (*ConsistentLookup)(nil)
 	_ Lookup          = (*ConsistentLookup)(nil)
 	_ WantOwnerInfo   = (*ConsistentLookup)(nil)
 	_ LookupPlanable  = (*ConsistentLookup)(nil)
 	_ ParamValidating = (*ConsistentLookup)(nil)
+	_ LookupStreamed  = (*ConsistentLookup)(nil)
 
 	consistentLookupParams = append(
 		append(make([]string, 0), lookupInternalParams...),
 		consistentLookupParamWriteOnly,
+		consistentLookupParamCacheLookups,
+		lookupCommonParamCacheSize,
 	)
 )
 
@@ -76,6 +83,13 @@ type ConsistentLookup struct {
 //	table: name of the backing table. It can be qualified by the keyspace.
 //	from: list of columns in the table that have the 'from' values of the lookup vindex.
 //	to: The 'to' column name of the table.
+//
+// The following fields are optional:
+//
+//	cache_lookups: if true, the lookups are cached until the lookup table changes. The writes of
+//	  the vindex invalidate the ids they change rather than caching the new mappings, which
+//	  are cached by the next lookups.
+//	cache_size: the maximum number of ids in the cache, 10000 by default.
 func newConsistentLookup(name string, m map[string]string) (Vindex, error) {
 	clc, err := newCLCommon(name, m)
 	if err != nil {
@@ -189,6 +203,13 @@ type ConsistentLookupUnique struct {
 //	table: name of the backing table. It can be qualified by the keyspace.
 //	from: list of columns in the table that have the 'from' values of the lookup vindex.
 //	to: The 'to' column name of the table.
+//
+// The following fields are optional:
+//
+//	cache_lookups: if true, the lookups are cached until the lookup table changes. The writes of
+//	  the vindex invalidate the ids they change rather than caching the new mappings, which
+//	  are cached by the next lookups.
+//	cache_size: the maximum number of ids in the cache, 10000 by default.
 func newConsistentLookupUnique(name string, m map[string]string) (Vindex, error) {
 	clc, err := newCLCommon(name, m)
 	if err != nil {
@@ -301,12 +322,35 @@ func newCLCommon(name string, m map[string]string) (*clCommon, error) {
 		return nil, err
 	}
 
-	if err := lu.lkp.Init(m, false /* autocommit */, false /* upsert */, false /* multiShardAutocommit */); err != nil {
+	if err := lu.lkp.Init(name, m, false /* autocommit */, false /* upsert */, false /* multiShardAutocommit */); err != nil {
+		return nil, err
+	}
+	if err := lu.initCache(m); err != nil {
 		return nil, err
 	}
 	return lu, nil
 }
 
+// initCache creates the cache of the vindex if the cache_lookups param
+// is set. The ids are invalidated when the vindex changes their mappings, and
+// again by the stream of the changes of the lookup table, which vtgate runs
+// for the vindexes implementing LookupStreamed, once the changes are committed.
+// The mappings are only cached by the lookups made after that.
+func (lu *clCommon) initCache(m map[string]string) error {
+	cacheLookups, err := boolFromMap(m, consistentLookupParamCacheLookups)
+	if err != nil || !cacheLookups {
+		return err
+	}
+	size := 0
+	if val, ok := m[lookupCommonParamCacheSize]; ok {
+		if size, err = strconv.Atoi(val); err != nil || size <= 0 {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s value must be a positive integer: '%s'", lookupCommonParamCacheSize, val)
+		}
+	}
+	lu.lkp.cache = NewLookupCache(lu.name, 0 /* ttl */, size)
+	return nil
+}
+
 func (lu *clCommon) SetOwnerInfo(keyspace, table string, cols []sqlparser.IdentifierCI) error {
 	lu.keyspace = keyspace
 	lu.ownerTable = sqlparser.String(sqlparser.NewIdentifierCS(table))
@@ -396,6 +440,8 @@ func (lu *clCommon) handleDup(ctx context.Context, vcursor VCursor, values []sql
 		bindVars[lu.lkp.FromColumns[colnum]] = sqltypes.ValueBindVariable(val)
 	}
 	bindVars[lu.lkp.To] = sqltypes.BytesBindVariable(ksid)
+	// The lookup row may have been written even if a write fails.
+	defer lu.lkp.cache.Invalidate(values[0])
 
 	// Lock the lookup row using pre priority.
 	qr, err := vcursor.Execute(ctx, "VindexCreate", lu.lockLookupQuery, bindVars, false /* rollbackOnError */, vtgatepb.CommitOrder_PRE)
@@ -512,6 +558,21 @@ func (lu *clCommon) addWhere(buf *strings.Builder, cols []string) {
 	}
 }
 
+// LookupCache implements the LookupCacheable interface.
+func (lu *clCommon) LookupCache() *LookupCache {
+	return lu.lkp.cache
+}
+
+// LookupTable implements the LookupStreamed interface. An unqualified lookup
+// table is assumed to be in the keyspace of its owner.
+func (lu *clCommon) LookupTable() (keyspace, table, fromColumn string) {
+	keyspace, table, ok := strings.Cut(lu.lkp.Table, ".")
+	if !ok {
+		return lu.keyspace, lu.lkp.Table, lu.lkp.FromColumns[0]
+	}
+	return keyspace, table, lu.lkp.FromColumns[0]
+}
+
 // GetCommitOrder implements the LookupPlanable interface
 func (lu *clCommon) GetCommitOrder() vtgatepb.CommitOrder {
 	return vtgatepb.CommitOrder_PRE
@@ -511,6 +511,83 @@ func TestConsistentLookupUpdateBecauseComparableTypes(t *testing.T) {
 	}
 }
 
+func TestConsistentLookupUniqueCacheLookups(t *testing.T) {
+	l, err := CreateVindex("consistent_lookup_unique", "cached", map[string]string{
+		"table":         "ks.t",
+		"from":          "fromc",
+		"to":            "toc",
+		"cache_lookups": "true",
+		"cache_size":    "10",
+	})
+	require.NoError(t, err)
+	require.Empty(t, l.(ParamValidating).UnknownParams())
+	require.NoError(t, l.(WantOwnerInfo).SetOwnerInfo("owner_ks", "t1", []sqlparser.IdentifierCI{sqlparser.NewIdentifierCI("fc")}))
+	lookup := l.(SingleColumn)
+	require.NotNil(t, lookup.(LookupCacheable).LookupCache())
+	keyspace, table, column := lookup.(LookupStreamed).LookupTable()
+	assert.Equal(t, []string{"ks", "t", "fromc"}, []string{keyspace, table, column})
+
+	vc := &loggingVCursor{}
+	vc.AddResult(makeTestResultLookup([]int{0, 1}), nil)
+	vc.AddResult(makeTestResultLookup([]int{0}), nil)
+	ctx := newTestContext()
+	ids := []sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}
+	want := []key.ShardDestination{
+		key.DestinationNone{},
+		key.DestinationKeyspaceID([]byte("1")),
+	}
+	for range 2 {
+		got, err := lookup.Map(ctx, vc, ids)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	// The second lookup is served from the cache, except for the id which has
+	// no rows.
+	assert.Len(t, vc.log, 2)
+
+	// The mappings created by the vindex are only cached once committed, by
+	// the lookups made after the stream invalidates them again.
+	vc.AddResult(&sqltypes.Result{}, nil)
+	err = lookup.(Lookup).Create(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, [][]byte{[]byte("test1")}, false)
+	require.NoError(t, err)
+	vc.AddResult(&sqltypes.Result{
+		Fields: sqltypes.MakeTestFields("fromc|toc", "int64|varbinary"),
+		Rows:   [][]sqltypes.Value{{sqltypes.NewInt64(1), sqltypes.NewVarBinary("test1")}},
+	}, nil)
+	got, err := lookup.Map(ctx, vc, ids)
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationKeyspaceID([]byte("test1")),
+		key.DestinationKeyspaceID([]byte("1")),
+	}, got)
+
+	// The deleted mappings are invalidated.
+	vc.AddResult(&sqltypes.Result{}, nil)
+	err = lookup.(Lookup).Delete(ctx, vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, []byte("test1"))
+	require.NoError(t, err)
+	vc.AddResult(makeTestResultLookup([]int{0}), nil)
+	got, err = lookup.Map(ctx, vc, ids)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	vc.verifyLog(t, []string{
+		"ExecutePre select fromc, toc from ks.t where fromc in ::fromc [{fromc }] false",
+		"ExecutePre select fromc, toc from ks.t where fromc in ::fromc [{fromc }] false",
+		"ExecutePre insert into ks.t(fromc, toc) values(:fromc_0, :toc_0) [{fromc_0 1} {toc_0 test1}] true",
+		"ExecutePre select fromc, toc from ks.t where fromc in ::fromc [{fromc }] false",
+		"ExecutePost delete from ks.t where fromc = :fromc and toc = :toc [{fromc 1} {toc test1}] true",
+		"ExecutePre select fromc, toc from ks.t where fromc in ::fromc [{fromc }] false",
+	})
+
+	_, err = CreateVindex("consistent_lookup_unique", "cached", map[string]string{
+		"table":         "t",
+		"from":          "fromc",
+		"to":            "toc",
+		"cache_lookups": "true",
+		"cache_size":    "0",
+	})
+	require.EqualError(t, err, "cache_size value must be a positive integer: '0'")
+}
+
 func createConsistentLookup(t *testing.T, name string, writeOnly bool) SingleColumn {
 	t.Helper()
 	write := "false"
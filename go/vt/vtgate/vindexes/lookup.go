@@ -36,10 +36,13 @@ var (
 	_ SingleColumn    = (*LookupUnique)(nil)
 	_ Lookup          = (*LookupUnique)(nil)
 	_ LookupPlanable  = (*LookupUnique)(nil)
+	_ LookupCacheable = (*LookupUnique)(nil)
 	_ ParamValidating = (*LookupUnique)(nil)
+	_ Covering        = (*LookupUnique)(nil)
 	_ SingleColumn    = (*LookupNonUnique)(nil)
 	_ Lookup          = (*LookupNonUnique)(nil)
 	_ LookupPlanable  = (*LookupNonUnique)(nil)
+	_ LookupCacheable = (*LookupNonUnique)(nil)
 	_ ParamValidating = (*LookupNonUnique)(nil)
 
 	lookupParams = append(
@@ -47,6 +50,11 @@ var (
 		lookupParamNoVerify,
 		lookupParamWriteOnly,
 	)
+
+	lookupUniqueParams = append(
+		append(make([]string, 0), lookupParams...),
+		lookupParamCoveringColumns,
+	)
 )
 
 func init() {
@@ -76,6 +84,11 @@ func (ln *LookupNonUnique) AutoCommitEnabled() bool {
 	return ln.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCacheable interface.
+func (ln *LookupNonUnique) LookupCache() *LookupCache {
+	return ln.lkp.cache
+}
+
 // String returns the name of the vindex.
 func (ln *LookupNonUnique) String() string {
 	return ln.name
@@ -208,6 +221,8 @@ func (ln *LookupNonUnique) UnknownParams() []string {
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
 //	no_verify: in this mode, Verify will always succeed.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
 func newLookup(name string, m map[string]string) (Vindex, error) {
 	lookup := &LookupNonUnique{
 		name:          name,
@@ -230,9 +245,10 @@ func newLookup(name string, m map[string]string) (Vindex, error) {
 
 	// if autocommit is on for non-unique lookup, upsert should also be on.
 	upsert := cc.autocommit || cc.multiShardAutocommit
-	if err := lookup.lkp.Init(m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
+	if err := lookup.lkp.Init(name, m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lookup.lkp.initCache(cc)
 	return lookup, nil
 }
 
@@ -269,6 +285,11 @@ func (lu *LookupUnique) AutoCommitEnabled() bool {
 	return lu.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCacheable interface.
+func (lu *LookupUnique) LookupCache() *LookupCache {
+	return lu.lkp.cache
+}
+
 // newLookupUnique creates a LookupUnique vindex.
 // The supplied map has the following required fields:
 //
@@ -280,10 +301,13 @@ func (lu *LookupUnique) AutoCommitEnabled() bool {
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
+//	covering_columns: comma separated columns of the owner table also stored in the lookup table.
 func newLookupUnique(name string, m map[string]string) (Vindex, error) {
 	lu := &LookupUnique{
 		name:          name,
-		unknownParams: FindUnknownParams(m, lookupParams),
+		unknownParams: FindUnknownParams(m, lookupUniqueParams),
 	}
 
 	cc, err := parseCommonConfig(m)
@@ -301,9 +325,13 @@ func newLookupUnique(name string, m map[string]string) (Vindex, error) {
 	}
 
 	// Don't allow upserts for unique vindexes.
-	if err := lu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
+	if err := lu.lkp.Init(name, m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
+		return nil, err
+	}
+	if err := lu.lkp.initCoveringColumns(m); err != nil {
 		return nil, err
 	}
+	lu.lkp.initCache(cc)
 	return lu, nil
 }
 
@@ -399,6 +427,11 @@ func (lu *LookupUnique) IsBackfilling() bool {
 	return lu.writeOnly
 }
 
+// CoveringTable implements the Covering interface.
+func (lu *LookupUnique) CoveringTable() (table, fromColumn string, columns []string) {
+	return lu.lkp.Table, lu.lkp.FromColumns[0], lu.lkp.CoveringColumns
+}
+
 func (lu *LookupUnique) LookupQuery() (string, error) {
 	return lu.lkp.sel, nil
 }
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	// LookupOperationMap is the operation of the lookup queries which map
+	// ids to their keyspace ids.
+	LookupOperationMap = "Map"
+	// LookupOperationVerify is the operation of the lookup queries which
+	// verify that ids map to keyspace ids.
+	LookupOperationVerify = "Verify"
+
+	defaultLookupCacheSize = 10000
+)
+
+var (
+	lookupStatsLabels = []string{"Vindex", "Operation"}
+
+	lookupTimings     = stats.NewMultiTimings("VindexLookupTimings", "Timings of the lookup queries sent by the lookup vindexes", lookupStatsLabels)
+	lookupIDs         = stats.NewCountersWithMultiLabels("VindexLookupIDs", "Number of ids sent in the lookup queries of the lookup vindexes", lookupStatsLabels)
+	lookupRows        = stats.NewCountersWithMultiLabels("VindexLookupRows", "Number of rows returned by the lookup queries of the lookup vindexes", lookupStatsLabels)
+	lookupErrors      = stats.NewCountersWithMultiLabels("VindexLookupErrors", "Number of failed lookup queries of the lookup vindexes", lookupStatsLabels)
+	lookupCacheHits   = stats.NewCountersWithSingleLabel("VindexLookupCacheHits", "Number of ids of the lookup vindexes found in their lookup cache", "Vindex")
+	lookupCacheMisses = stats.NewCountersWithSingleLabel("VindexLookupCacheMisses", "Number of ids of the lookup vindexes not found in their lookup cache", "Vindex")
+)
+
+// RecordLookupQuery records the metrics of a lookup query of the vindex which
+// was sent for ids ids. The number of ids per query shows how well the
+// lookups are batched.
+func RecordLookupQuery(vindex, operation string, startTime time.Time, ids int, result *sqltypes.Result, err error) {
+	labels := []string{vindex, operation}
+	lookupTimings.Record(labels, startTime)
+	lookupIDs.Add(labels, int64(ids))
+	if err != nil {
+		lookupErrors.Add(labels, 1)
+		return
+	}
+	lookupRows.Add(labels, int64(len(result.Rows)))
+}
+
+// LookupCacheable is implemented by the lookup vindexes which can cache the
+// results of their lookup queries.
+type LookupCacheable interface {
+	// LookupCache returns the cache of the vindex, or nil if it has none.
+	LookupCache() *LookupCache
+}
+
+// LookupStreamed is implemented by the lookup vindexes whose cache is kept up
+// to date with the changes of their lookup table, which vtgate streams.
+type LookupStreamed interface {
+	LookupCacheable
+	// LookupTable returns the keyspace and the name of the lookup table, and
+	// its column of the ids.
+	LookupTable() (keyspace, table, fromColumn string)
+}
+
+// LookupCache caches the rows of the lookup queries of a vindex per id, and
+// evicts the least recently used ids when it is full. With a ttl, an id is
+// cached for a limited time: this is meant for read-heavy workloads which can
+// tolerate reading a mapping up to the ttl after it was changed by another
+// vtgate. Without a ttl, an id is cached until it is invalidated, either by
+// this vtgate or by the stream of the changes of the lookup table.
+// The ids are cached by type and value, as values of different types which
+// have the same text, like 1 and '1.0', may not match the same rows. The ids
+// which have no rows are not cached, so that an id is found as soon as its
+// row is inserted.
+// The text ids are compared by the lookup table with the collation of its
+// column, which the cache does not know: the default collations ignore the
+// case and the trailing spaces, so that 'ABC' and 'abc ' find the row of
+// 'abc'. The invalidation of a text id thus clears the whole cache, as the ids
+// which match the same rows cannot be told apart.
+// A nil LookupCache caches nothing.
+type LookupCache struct {
+	vindex string
+	ttl    time.Duration
+	size   int
+
+	mu sync.Mutex
+	// entries are the cached ids by value and type.
+	entries map[string]map[querypb.Type]*list.Element
+	// lru orders the entries from the most to the least recently used.
+	lru *list.List
+	// fills are the lookups in progress, whose rows are cached once they
+	// return.
+	fills map[*lookupCacheFill]struct{}
+}
+
+// lookupCacheFill is a lookup in progress for the ids which were not cached.
+// Its rows of an id are not cached if the id is invalidated meanwhile, as they
+// may have been read before the change which invalidated it.
+type lookupCacheFill struct {
+	// invalidated tells, for each id value, whether it was invalidated during
+	// the lookup.
+	invalidated map[string]bool
+}
+
+type lookupCacheEntry struct {
+	value   string
+	typ     querypb.Type
+	rows    [][]sqltypes.Value
+	expires time.Time
+}
+
+// NewLookupCache creates a cache of at most size ids for the vindex, which
+// keeps the rows of an id for ttl, or until they are invalidated if ttl is 0.
+func NewLookupCache(vindex string, ttl time.Duration, size int) *LookupCache {
+	if size <= 0 {
+		size = defaultLookupCacheSize
+	}
+	return &LookupCache{
+		vindex:  vindex,
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]map[querypb.Type]*list.Element),
+		lru:     list.New(),
+		fills:   make(map[*lookupCacheFill]struct{}),
+	}
+}
+
+// Lookup returns the rows of each of the ids, from the cache if they are in
+// it, and by calling lookup with the ids which are not otherwise. The rows
+// returned by lookup are cached, unless there are none or their id is
+// invalidated before it returns.
+func (lc *LookupCache) Lookup(ids []sqltypes.Value, lookup func(ids []sqltypes.Value) ([]*sqltypes.Result, error)) ([]*sqltypes.Result, error) {
+	if lc == nil {
+		return lookup(ids)
+	}
+
+	results := make([]*sqltypes.Result, len(ids))
+	var missing []sqltypes.Value
+	var missingIdx []int
+	now := time.Now()
+	fill := &lookupCacheFill{invalidated: make(map[string]bool)}
+	lc.mu.Lock()
+	for i, id := range ids {
+		if entry, ok := lc.getLocked(id, now); ok {
+			results[i] = &sqltypes.Result{Rows: entry.rows}
+			continue
+		}
+		missing = append(missing, id)
+		missingIdx = append(missingIdx, i)
+		fill.invalidated[id.ToString()] = false
+	}
+	if len(missing) > 0 {
+		lc.fills[fill] = struct{}{}
+	}
+	lc.mu.Unlock()
+	lookupCacheHits.Add(lc.vindex, int64(len(ids)-len(missing)))
+	lookupCacheMisses.Add(lc.vindex, int64(len(missing)))
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	missingResults, err := lookup(missing)
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	delete(lc.fills, fill)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range missingResults {
+		if id := missing[i]; len(result.Rows) > 0 && !fill.invalidated[id.ToString()] {
+			lc.setLocked(id, result.Rows, now)
+		}
+		results[missingIdx[i]] = result
+	}
+	return results, nil
+}
+
+// getLocked returns the entry of the id and marks it as the most recently
+// used, unless it has expired.
+func (lc *LookupCache) getLocked(id sqltypes.Value, now time.Time) (*lookupCacheEntry, bool) {
+	elem, ok := lc.entries[id.ToString()][id.Type()]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lookupCacheEntry)
+	if lc.ttl != 0 && !now.Before(entry.expires) {
+		lc.removeLocked(elem)
+		return nil, false
+	}
+	lc.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// setLocked caches the rows of the id, evicting the least recently used
+// entry if the cache is full.
+func (lc *LookupCache) setLocked(id sqltypes.Value, rows [][]sqltypes.Value, now time.Time) {
+	entry := &lookupCacheEntry{value: id.ToString(), typ: id.Type(), rows: rows, expires: now.Add(lc.ttl)}
+	if elem, ok := lc.entries[entry.value][entry.typ]; ok {
+		elem.Value = entry
+		lc.lru.MoveToFront(elem)
+		return
+	}
+	if lc.lru.Len() >= lc.size {
+		lc.removeLocked(lc.lru.Back())
+	}
+	types, ok := lc.entries[entry.value]
+	if !ok {
+		types = make(map[querypb.Type]*list.Element)
+		lc.entries[entry.value] = types
+	}
+	types[entry.typ] = lc.lru.PushFront(entry)
+}
+
+// removeLocked removes the entry of elem from the cache.
+func (lc *LookupCache) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*lookupCacheEntry)
+	lc.lru.Remove(elem)
+	delete(lc.entries[entry.value], entry.typ)
+	if len(lc.entries[entry.value]) == 0 {
+		delete(lc.entries, entry.value)
+	}
+}
+
+// Invalidate removes the ids from the cache, whatever the type they were
+// looked up with, and keeps the lookups in progress from caching them. It is
+// called when their mappings change. A text id clears the cache.
+func (lc *LookupCache) Invalidate(ids ...sqltypes.Value) {
+	if lc == nil {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	for _, id := range ids {
+		if id.IsText() {
+			lc.clearLocked()
+			return
+		}
+		value := id.ToString()
+		for _, elem := range lc.entries[value] {
+			lc.removeLocked(elem)
+		}
+		for fill := range lc.fills {
+			if _, ok := fill.invalidated[value]; ok {
+				fill.invalidated[value] = true
+			}
+		}
+	}
+}
+
+// Clear removes all the ids from the cache. It is called when changes of the
+// lookup table may have been missed.
+func (lc *LookupCache) Clear() {
+	if lc == nil {
+		return
+	}
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.clearLocked()
+}
+
+func (lc *LookupCache) clearLocked() {
+	clear(lc.entries)
+	lc.lru.Init()
+	for fill := range lc.fills {
+		for key := range fill.invalidated {
+			fill.invalidated[key] = true
+		}
+	}
+}
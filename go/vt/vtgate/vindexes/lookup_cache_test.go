@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestLookupCache(t *testing.T) {
+	var looked [][]sqltypes.Value
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		looked = append(looked, ids)
+		results := make([]*sqltypes.Result, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, &sqltypes.Result{Rows: [][]sqltypes.Value{{id}}})
+		}
+		return results, nil
+	}
+	ids := func(vals ...int64) []sqltypes.Value {
+		out := make([]sqltypes.Value, 0, len(vals))
+		for _, v := range vals {
+			out = append(out, sqltypes.NewInt64(v))
+		}
+		return out
+	}
+
+	// A nil cache always looks up.
+	var nilCache *LookupCache
+	results, err := nilCache.Lookup(ids(1, 2), lookup)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, [][]sqltypes.Value{ids(1, 2)}, looked)
+	nilCache.Invalidate(sqltypes.NewInt64(1))
+
+	looked = nil
+	lc := NewLookupCache("test_cache", time.Hour, 3)
+	hits, misses := lookupCacheHits.Counts()["test_cache"], lookupCacheMisses.Counts()["test_cache"]
+	results, err = lc.Lookup(ids(1, 2), lookup)
+	require.NoError(t, err)
+	assert.Equal(t, ids(1), results[0].Rows[0])
+	assert.Equal(t, ids(2), results[1].Rows[0])
+
+	// Only the ids which are not cached are looked up, and the results are
+	// in the order of the ids.
+	results, err = lc.Lookup(ids(3, 2, 1), lookup)
+	require.NoError(t, err)
+	assert.Equal(t, ids(3), results[0].Rows[0])
+	assert.Equal(t, ids(2), results[1].Rows[0])
+	assert.Equal(t, ids(1), results[2].Rows[0])
+	assert.Equal(t, [][]sqltypes.Value{ids(1, 2), ids(3)}, looked)
+	assert.EqualValues(t, 2, lookupCacheHits.Counts()["test_cache"]-hits)
+	assert.EqualValues(t, 3, lookupCacheMisses.Counts()["test_cache"]-misses)
+
+	// Invalidated ids are looked up again.
+	looked = nil
+	lc.Invalidate(sqltypes.NewInt64(2))
+	_, err = lc.Lookup(ids(1, 2), lookup)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{ids(2)}, looked)
+
+	// The cache does not grow past its size.
+	_, err = lc.Lookup(ids(4, 5), lookup)
+	require.NoError(t, err)
+	assert.Len(t, lc.entries, 3)
+
+	// Failed lookups are not cached.
+	_, err = lc.Lookup(ids(6), func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		return nil, errors.New("lookup failed")
+	})
+	require.EqualError(t, err, "lookup failed")
+	assert.NotContains(t, lc.entries, "6")
+
+	// The ids without rows are not cached, so that they are found once their
+	// rows are inserted.
+	looked = nil
+	noRows := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		looked = append(looked, ids)
+		return []*sqltypes.Result{{}}, nil
+	}
+	_, err = lc.Lookup(ids(7), noRows)
+	require.NoError(t, err)
+	_, err = lc.Lookup(ids(7), noRows)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{ids(7), ids(7)}, looked)
+
+	// Expired ids are looked up again.
+	looked = nil
+	lc = NewLookupCache("test_cache", -time.Second, 0)
+	_, err = lc.Lookup(ids(1), lookup)
+	require.NoError(t, err)
+	_, err = lc.Lookup(ids(1), lookup)
+	require.NoError(t, err)
+	assert.Equal(t, [][]sqltypes.Value{ids(1), ids(1)}, looked)
+}
+
+func TestLookupCacheLRU(t *testing.T) {
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		results := make([]*sqltypes.Result, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, &sqltypes.Result{Rows: [][]sqltypes.Value{{id}}})
+		}
+		return results, nil
+	}
+	lc := NewLookupCache("test_lru", 0, 2)
+	_, err := lc.Lookup([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewInt64(2)}, lookup)
+	require.NoError(t, err)
+	// 1 is used after 2, so 2 is evicted by 3.
+	_, err = lc.Lookup([]sqltypes.Value{sqltypes.NewInt64(1)}, lookup)
+	require.NoError(t, err)
+	_, err = lc.Lookup([]sqltypes.Value{sqltypes.NewInt64(3)}, lookup)
+	require.NoError(t, err)
+	assert.Contains(t, lc.entries, "1")
+	assert.Contains(t, lc.entries, "3")
+	assert.NotContains(t, lc.entries, "2")
+
+	// Without a ttl, the entries do not expire.
+	_, ok := lc.getLocked(sqltypes.NewInt64(1), time.Now().Add(time.Hour))
+	assert.True(t, ok)
+
+	lc.Clear()
+	assert.Empty(t, lc.entries)
+	assert.Zero(t, lc.lru.Len())
+}
+
+func TestLookupCacheTypes(t *testing.T) {
+	var looked []sqltypes.Value
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		looked = append(looked, ids...)
+		results := make([]*sqltypes.Result, 0, len(ids))
+		for _, id := range ids {
+			results = append(results, &sqltypes.Result{Rows: [][]sqltypes.Value{{id}}})
+		}
+		return results, nil
+	}
+	lc := NewLookupCache("test_types", 0, 0)
+	// The same text of different types is cached separately.
+	results, err := lc.Lookup([]sqltypes.Value{sqltypes.NewInt64(1), sqltypes.NewVarChar("1")}, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.NewVarChar("1"), results[1].Rows[0][0])
+	results, err = lc.Lookup([]sqltypes.Value{sqltypes.NewVarChar("1")}, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, sqltypes.NewVarChar("1"), results[0].Rows[0][0])
+	assert.Len(t, looked, 2)
+	assert.Equal(t, 2, lc.lru.Len())
+
+	// An id is invalidated whatever its type.
+	lc.Invalidate(sqltypes.NewUint64(1))
+	assert.Empty(t, lc.entries)
+	assert.Zero(t, lc.lru.Len())
+}
+
+func TestLookupCacheTextInvalidation(t *testing.T) {
+	var looked []sqltypes.Value
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		looked = append(looked, ids...)
+		results := make([]*sqltypes.Result, 0, len(ids))
+		for range ids {
+			results = append(results, &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.NewVarBinary("ksid")}}})
+		}
+		return results, nil
+	}
+	lc := NewLookupCache("test_text", 0, 0)
+	// With the default collations, 'ABC' and 'abc ' find the row of 'abc'.
+	ids := []sqltypes.Value{sqltypes.NewVarChar("ABC"), sqltypes.NewVarChar("abc "), sqltypes.NewInt64(1)}
+	_, err := lc.Lookup(ids, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, 3, lc.lru.Len())
+
+	// The change of the row of 'abc' invalidates the ids which differ from it
+	// in case or trailing spaces.
+	lc.Invalidate(sqltypes.NewVarChar("abc"))
+	assert.Empty(t, lc.entries)
+	assert.Zero(t, lc.lru.Len())
+	looked = nil
+	_, err = lc.Lookup(ids[:1], lookup)
+	require.NoError(t, err)
+	assert.Equal(t, ids[:1], looked)
+
+	// The binary ids only match the same bytes.
+	_, err = lc.Lookup([]sqltypes.Value{sqltypes.NewVarBinary("abc"), sqltypes.NewVarBinary("def")}, lookup)
+	require.NoError(t, err)
+	lc.Invalidate(sqltypes.NewVarBinary("abc"))
+	assert.Contains(t, lc.entries, "ABC")
+	assert.Contains(t, lc.entries, "def")
+	assert.NotContains(t, lc.entries, "abc")
+}
+
+func TestLookupCacheInvalidatedFill(t *testing.T) {
+	id := sqltypes.NewInt64(1)
+	ksid := sqltypes.NewVarBinary("ksid")
+	lookup := func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		return []*sqltypes.Result{{Rows: [][]sqltypes.Value{{ksid}}}}, nil
+	}
+
+	// The rows of an id invalidated during its lookup may be stale, so they
+	// are returned but not cached.
+	for name, invalidate := range map[string]func(lc *LookupCache){
+		"invalidate": func(lc *LookupCache) { lc.Invalidate(id) },
+		"clear":      func(lc *LookupCache) { lc.Clear() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			lc := NewLookupCache("test_fill", 0, 0)
+			results, err := lc.Lookup([]sqltypes.Value{id}, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+				invalidate(lc)
+				return lookup(ids)
+			})
+			require.NoError(t, err)
+			assert.Equal(t, [][]sqltypes.Value{{ksid}}, results[0].Rows)
+			assert.Empty(t, lc.entries)
+			assert.Empty(t, lc.fills)
+
+			// The next lookup caches them.
+			_, err = lc.Lookup([]sqltypes.Value{id}, lookup)
+			require.NoError(t, err)
+			assert.Len(t, lc.entries, 1)
+			assert.Empty(t, lc.fills)
+		})
+	}
+
+	// The other ids are cached.
+	lc := NewLookupCache("test_fill", 0, 0)
+	_, err := lc.Lookup([]sqltypes.Value{id}, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		lc.Invalidate(sqltypes.NewInt64(2))
+		return lookup(ids)
+	})
+	require.NoError(t, err)
+	assert.Len(t, lc.entries, 1)
+
+	// A failed lookup is forgotten.
+	lc = NewLookupCache("test_fill", 0, 0)
+	_, err = lc.Lookup([]sqltypes.Value{id}, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		return nil, errors.New("lookup failed")
+	})
+	require.EqualError(t, err, "lookup failed")
+	assert.Empty(t, lc.fills)
+
+	var nilCache *LookupCache
+	nilCache.Invalidate(id)
+	nilCache.Clear()
+}
+
+func TestLookupNonUniqueCache(t *testing.T) {
+	vindex, err := CreateVindex("lookup", "lookup_cached", map[string]string{
+		"table":     "t",
+		"from":      "fromc",
+		"to":        "toc",
+		"cache_ttl": "1h",
+	})
+	require.NoError(t, err)
+	require.Empty(t, vindex.(ParamValidating).UnknownParams())
+	lnu := vindex.(*LookupNonUnique)
+	require.NotNil(t, lnu.LookupCache())
+	vc := &vcursor{numRows: 1}
+
+	rows := lookupRows.Counts()["lookup_cached.Map"]
+	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 1)
+	assert.EqualValues(t, 1, lookupRows.Counts()["lookup_cached.Map"]-rows)
+
+	// Changing the mapping of an id invalidates it.
+	err = lnu.Create(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, [][]byte{[]byte("test")}, false /* ignoreMode */)
+	require.NoError(t, err)
+	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 3)
+
+	// So does a failed change, which may have been made.
+	vc.mustFail = true
+	err = lnu.Delete(context.Background(), vc, [][]sqltypes.Value{{sqltypes.NewInt64(1)}}, []byte("test"))
+	require.EqualError(t, err, "lookup.Delete: execute failed")
+	vc.mustFail = false
+	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(1)})
+	require.NoError(t, err)
+	assert.Len(t, vc.queries, 5)
+
+	// Failed lookups are counted as errors.
+	errs := lookupErrors.Counts()["lookup_cached.Map"]
+	vc.mustFail = true
+	_, err = lnu.Map(context.Background(), vc, []sqltypes.Value{sqltypes.NewInt64(2)})
+	require.EqualError(t, err, "lookup.Map: execute failed")
+	assert.EqualValues(t, 1, lookupErrors.Counts()["lookup_cached.Map"]-errs)
+
+	_, err = CreateVindex("lookup", "lookup_cached", map[string]string{
+		"table":     "t",
+		"from":      "fromc",
+		"to":        "toc",
+		"cache_ttl": "bogus",
+	})
+	require.EqualError(t, err, "cache_ttl value must be a non-negative duration: 'bogus'")
+}
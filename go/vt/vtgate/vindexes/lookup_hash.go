@@ -36,10 +36,12 @@ var (
 	_ SingleColumn    = (*LookupHash)(nil)
 	_ Lookup          = (*LookupHash)(nil)
 	_ LookupPlanable  = (*LookupHash)(nil)
+	_ LookupCacheable = (*LookupHash)(nil)
 	_ ParamValidating = (*LookupHash)(nil)
 	_ SingleColumn    = (*LookupHashUnique)(nil)
 	_ Lookup          = (*LookupHashUnique)(nil)
 	_ LookupPlanable  = (*LookupHashUnique)(nil)
+	_ LookupCacheable = (*LookupHashUnique)(nil)
 	_ ParamValidating = (*LookupHashUnique)(nil)
 
 	lookupHashParams = append(
@@ -77,6 +79,8 @@ type LookupHash struct {
 //
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
 func newLookupHash(name string, m map[string]string) (Vindex, error) {
 	lh := &LookupHash{
 		name:          name,
@@ -94,9 +98,10 @@ func newLookupHash(name string, m map[string]string) (Vindex, error) {
 
 	// if autocommit is on for non-unique lookup, upsert should also be on.
 	upsert := cc.autocommit || cc.multiShardAutocommit
-	if err := lh.lkp.Init(m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
+	if err := lh.lkp.Init(name, m, cc.autocommit, upsert, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lh.lkp.initCache(cc)
 	return lh, nil
 }
 
@@ -189,6 +194,11 @@ func (lh *LookupHash) AutoCommitEnabled() bool {
 	return lh.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCacheable interface.
+func (lh *LookupHash) LookupCache() *LookupCache {
+	return lh.lkp.cache
+}
+
 // GetCommitOrder implements the LookupPlanable interface
 func (lh *LookupHash) GetCommitOrder() vtgatepb.CommitOrder {
 	return vtgatepb.CommitOrder_NORMAL
@@ -287,6 +297,8 @@ var _ LookupPlanable = (*LookupHashUnique)(nil)
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
 func newLookupHashUnique(name string, m map[string]string) (Vindex, error) {
 	lhu := &LookupHashUnique{
 		name:          name,
@@ -303,9 +315,10 @@ func newLookupHashUnique(name string, m map[string]string) (Vindex, error) {
 	}
 
 	// Don't allow upserts for unique vindexes.
-	if err := lhu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
+	if err := lhu.lkp.Init(name, m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lhu.lkp.initCache(cc)
 	return lhu, nil
 }
 
@@ -434,6 +447,11 @@ func (lhu *LookupHashUnique) AutoCommitEnabled() bool {
 	return lhu.lkp.Autocommit
 }
 
+// LookupCache implements the LookupCacheable interface.
+func (lhu *LookupHashUnique) LookupCache() *LookupCache {
+	return lhu.lkp.cache
+}
+
 func (lhu *LookupHashUnique) Query() (selQuery string, arguments []string) {
 	return lhu.lkp.query()
 }
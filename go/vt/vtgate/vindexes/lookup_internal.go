@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
@@ -39,6 +40,8 @@ const (
 
 	lookupCommonParamAutocommit           = "autocommit"
 	lookupCommonParamMultiShardAutocommit = "multi_shard_autocommit"
+	lookupCommonParamCacheTTL             = "cache_ttl"
+	lookupCommonParamCacheSize            = "cache_size"
 
 	lookupInternalParamTable       = "table"
 	lookupInternalParamFrom        = "from"
@@ -46,6 +49,10 @@ const (
 	lookupInternalParamIgnoreNulls = "ignore_nulls"
 	lookupInternalParamBatchLookup = "batch_lookup"
 	lookupInternalParamReadLock    = "read_lock"
+
+	// lookupParamCoveringColumns is only accepted by the unique lookup
+	// vindexes, as a row of their lookup table maps to a single owner row.
+	lookupParamCoveringColumns = "covering_columns"
 )
 
 var (
@@ -60,6 +67,8 @@ var (
 		append(make([]string, 0), lookupInternalParams...),
 		lookupCommonParamAutocommit,
 		lookupCommonParamMultiShardAutocommit,
+		lookupCommonParamCacheTTL,
+		lookupCommonParamCacheSize,
 	)
 
 	// lookupInternalParams are used by both lookup_* vindexes and the newer
@@ -85,10 +94,19 @@ type lookupInternal struct {
 	IgnoreNulls             bool     `json:"ignore_nulls,omitempty"`
 	BatchLookup             bool     `json:"batch_lookup,omitempty"`
 	ReadLock                string   `json:"read_lock,omitempty"`
+	CoveringColumns         []string `json:"covering_columns,omitempty"`
 	sel, selTxDml, ver, del string   // sel: map query, ver: verify query, del: delete query
+
+	// name is the name of the vindex, used in the lookup metrics.
+	name string
+	// cache caches the results of the lookups outside of DML transactions
+	// if the cache_ttl param, or the cache_lookups param of the
+	// consistent lookup vindexes, is set.
+	cache *LookupCache
 }
 
-func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit, upsert, multiShardAutocommit bool) error {
+func (lkp *lookupInternal) Init(name string, lookupQueryParams map[string]string, autocommit, upsert, multiShardAutocommit bool) error {
+	lkp.name = name
 	lkp.Table = lookupQueryParams[lookupInternalParamTable]
 	lkp.To = lookupQueryParams[lookupInternalParamTo]
 	var fromColumns []string
@@ -138,21 +156,55 @@ func (lkp *lookupInternal) Init(lookupQueryParams map[string]string, autocommit,
 	return nil
 }
 
+// initCoveringColumns parses the covering_columns param, the columns of the
+// owner table which are also stored in the lookup table.
+func (lkp *lookupInternal) initCoveringColumns(m map[string]string) error {
+	val, ok := m[lookupParamCoveringColumns]
+	if !ok {
+		return nil
+	}
+	if len(lkp.FromColumns) != 1 {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s requires a single 'from' column", lookupParamCoveringColumns)
+	}
+	for col := range strings.SplitSeq(val, ",") {
+		col = strings.TrimSpace(col)
+		if col == "" {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s value: '%s'", lookupParamCoveringColumns, val)
+		}
+		if strings.EqualFold(col, lkp.FromColumns[0]) || strings.EqualFold(col, lkp.To) {
+			return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s cannot contain the '%s' column of the lookup table", lookupParamCoveringColumns, col)
+		}
+		lkp.CoveringColumns = append(lkp.CoveringColumns, col)
+	}
+	return nil
+}
+
 // Lookup performs a lookup for the ids.
 func (lkp *lookupInternal) Lookup(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, co vtgatepb.CommitOrder) ([]*sqltypes.Result, error) {
 	if vcursor == nil {
 		return nil, vterrors.VT13001("cannot perform lookup: no vcursor provided")
 	}
-	results := make([]*sqltypes.Result, 0, len(ids))
 	if lkp.Autocommit {
 		co = vtgatepb.CommitOrder_AUTOCOMMIT
 	}
-	var sel string
 	if vcursor.InTransactionAndIsDML() {
-		sel = lkp.selTxDml
-	} else {
-		sel = lkp.sel
+		return lkp.lookup(ctx, vcursor, lkp.selTxDml, ids, co)
 	}
+	return lkp.cache.Lookup(ids, func(ids []sqltypes.Value) ([]*sqltypes.Result, error) {
+		return lkp.lookup(ctx, vcursor, lkp.sel, ids, co)
+	})
+}
+
+// initCache creates the lookup cache of the vindex if the cache_ttl param is
+// set.
+func (lkp *lookupInternal) initCache(cc *commonConfig) {
+	if cc.cacheTTL > 0 {
+		lkp.cache = NewLookupCache(lkp.name, cc.cacheTTL, cc.cacheSize)
+	}
+}
+
+func (lkp *lookupInternal) lookup(ctx context.Context, vcursor VCursor, sel string, ids []sqltypes.Value, co vtgatepb.CommitOrder) ([]*sqltypes.Result, error) {
+	results := make([]*sqltypes.Result, 0, len(ids))
 	if ids[0].IsIntegral() || lkp.BatchLookup {
 		// for integral types, batch query all ids and then map them back to the input order
 		vars, err := sqltypes.BuildBindVariable(ids)
@@ -162,7 +214,9 @@ func (lkp *lookupInternal) Lookup(ctx context.Context, vcursor VCursor, ids []sq
 		bindVars := map[string]*querypb.BindVariable{
 			lkp.FromColumns[0]: vars,
 		}
+		startTime := time.Now()
 		result, err := vcursor.Execute(ctx, "VindexLookup", sel, bindVars, false /* rollbackOnError */, co)
+		RecordLookupQuery(lkp.name, LookupOperationMap, startTime, len(ids), result, err)
 		if err != nil {
 			return nil, vterrors.Wrap(err, "lookup.Map")
 		}
@@ -187,7 +241,9 @@ func (lkp *lookupInternal) Lookup(ctx context.Context, vcursor VCursor, ids []sq
 				lkp.FromColumns[0]: vars,
 			}
 			var result *sqltypes.Result
+			startTime := time.Now()
 			result, err = vcursor.Execute(ctx, "VindexLookup", sel, bindVars, false /* rollbackOnError */, co)
+			RecordLookupQuery(lkp.name, LookupOperationMap, startTime, 1, result, err)
 			if err != nil {
 				return nil, vterrors.Wrap(err, "lookup.Map")
 			}
@@ -219,7 +275,9 @@ func (lkp *lookupInternal) VerifyCustom(ctx context.Context, vcursor VCursor, id
 			lkp.FromColumns[0]: sqltypes.ValueBindVariable(id),
 			lkp.To:             sqltypes.ValueBindVariable(values[i]),
 		}
+		startTime := time.Now()
 		result, err := vcursor.Execute(ctx, "VindexVerify", lkp.ver, bindVars, false /* rollbackOnError */, co)
+		RecordLookupQuery(lkp.name, LookupOperationVerify, startTime, 1, result, err)
 		if err != nil {
 			return nil, vterrors.Wrap(err, "lookup.Verify")
 		}
@@ -306,6 +364,8 @@ nextRow:
 		return vterrors.VT03030(lkp.FromColumns, len(trimmedRowsCols[0]))
 	}
 	sort.Sort(&sorter{rowsColValues: trimmedRowsCols, toValues: trimmedToValues})
+	// The rows may have been inserted even if the insert fails.
+	defer lkp.invalidate(trimmedRowsCols)
 
 	insStmt := "insert"
 	if lkp.MultiShardAutocommit {
@@ -352,6 +412,13 @@ nextRow:
 	return nil
 }
 
+// invalidate removes the ids of the rows from the lookup cache.
+func (lkp *lookupInternal) invalidate(rowsColValues [][]sqltypes.Value) {
+	for _, row := range rowsColValues {
+		lkp.cache.Invalidate(row[0])
+	}
+}
+
 // Delete deletes the association between ids and value.
 // rowsColValues contains all the rows that are being deleted.
 // For each row, we store the value of each column defined in the vindex.
@@ -381,6 +448,8 @@ func (lkp *lookupInternal) Delete(ctx context.Context, vcursor VCursor, rowsColV
 	if len(rowsColValues[0]) != len(lkp.FromColumns) {
 		return vterrors.VT03030(lkp.FromColumns, len(rowsColValues[0]))
 	}
+	// The rows may have been deleted even if a delete fails.
+	defer lkp.invalidate(rowsColValues)
 	for _, column := range rowsColValues {
 		bindVars := make(map[string]*querypb.BindVariable, len(rowsColValues))
 		for colIdx, columnValue := range column {
@@ -423,6 +492,8 @@ func (lkp *lookupInternal) query() (selQuery string, arguments []string) {
 type commonConfig struct {
 	autocommit           bool
 	multiShardAutocommit bool
+	cacheTTL             time.Duration
+	cacheSize            int
 }
 
 func parseCommonConfig(m map[string]string) (*commonConfig, error) {
@@ -434,6 +505,16 @@ func parseCommonConfig(m map[string]string) (*commonConfig, error) {
 	if c.multiShardAutocommit, err = boolFromMap(m, lookupCommonParamMultiShardAutocommit); err != nil {
 		return nil, err
 	}
+	if val, ok := m[lookupCommonParamCacheTTL]; ok {
+		if c.cacheTTL, err = time.ParseDuration(val); err != nil || c.cacheTTL < 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s value must be a non-negative duration: '%s'", lookupCommonParamCacheTTL, val)
+		}
+	}
+	if val, ok := m[lookupCommonParamCacheSize]; ok {
+		if c.cacheSize, err = strconv.Atoi(val); err != nil || c.cacheSize <= 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "%s value must be a positive integer: '%s'", lookupCommonParamCacheSize, val)
+		}
+	}
 	return &c, nil
 }
 
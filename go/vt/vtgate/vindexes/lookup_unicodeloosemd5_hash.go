@@ -75,6 +75,8 @@ type LookupUnicodeLooseMD5Hash struct {
 //
 //	autocommit: setting this to "true" will cause inserts to upsert and deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
 func newLookupUnicodeLooseMD5Hash(name string, m map[string]string) (Vindex, error) {
 	lh := &LookupUnicodeLooseMD5Hash{
 		name:          name,
@@ -91,9 +93,10 @@ func newLookupUnicodeLooseMD5Hash(name string, m map[string]string) (Vindex, err
 	}
 
 	// if autocommit is on for non-unique lookup, upsert should also be on.
-	if err := lh.lkp.Init(m, cc.autocommit, cc.autocommit || cc.multiShardAutocommit, cc.multiShardAutocommit); err != nil {
+	if err := lh.lkp.Init(name, m, cc.autocommit, cc.autocommit || cc.multiShardAutocommit, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lh.lkp.initCache(cc)
 	return lh, nil
 }
 
@@ -265,6 +268,8 @@ type LookupUnicodeLooseMD5HashUnique struct {
 //
 //	autocommit: setting this to "true" will cause deletes to be ignored.
 //	write_only: in this mode, Map functions return the full keyrange causing a full scatter.
+//	cache_ttl: if set, the results of the lookups outside of DML transactions are cached for this duration.
+//	cache_size: the maximum number of ids in the lookup cache, 10000 by default.
 func newLookupUnicodeLooseMD5HashUnique(name string, m map[string]string) (Vindex, error) {
 	lhu := &LookupUnicodeLooseMD5HashUnique{
 		name:          name,
@@ -281,9 +286,10 @@ func newLookupUnicodeLooseMD5HashUnique(name string, m map[string]string) (Vinde
 	}
 
 	// Don't allow upserts for unique vindexes.
-	if err := lhu.lkp.Init(m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
+	if err := lhu.lkp.Init(name, m, cc.autocommit, false /* upsert */, cc.multiShardAutocommit); err != nil {
 		return nil, err
 	}
+	lhu.lkp.initCache(cc)
 	return lhu, nil
 }
 
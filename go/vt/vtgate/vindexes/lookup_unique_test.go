@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -61,6 +62,47 @@ func TestLookupUniqueNew(t *testing.T) {
 	}
 }
 
+func TestLookupUniqueCoveringColumns(t *testing.T) {
+	vindex, err := CreateVindex("lookup_unique", "lookup_unique", map[string]string{
+		"table":            "ks.t",
+		"from":             "fromc",
+		"to":               "toc",
+		"covering_columns": "a, b",
+	})
+	require.NoError(t, err)
+	require.Empty(t, vindex.(ParamValidating).UnknownParams())
+	table, from, columns := vindex.(Covering).CoveringTable()
+	assert.Equal(t, "ks.t", table)
+	assert.Equal(t, "fromc", from)
+	assert.Equal(t, []string{"a", "b"}, columns)
+
+	_, err = CreateVindex("lookup_unique", "lookup_unique", map[string]string{
+		"table":            "t",
+		"from":             "fromc",
+		"to":               "toc",
+		"covering_columns": "a,toc",
+	})
+	assert.EqualError(t, err, "covering_columns cannot contain the 'toc' column of the lookup table")
+
+	_, err = CreateVindex("lookup_unique", "lookup_unique", map[string]string{
+		"table":            "t",
+		"from":             "fromc1,fromc2",
+		"to":               "toc",
+		"covering_columns": "a",
+	})
+	assert.EqualError(t, err, "covering_columns requires a single 'from' column")
+
+	// The rows of a non-unique lookup table don't map to a single owner row.
+	vindex, err = CreateVindex("lookup", "lookup", map[string]string{
+		"table":            "t",
+		"from":             "fromc",
+		"to":               "toc",
+		"covering_columns": "a",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"covering_columns"}, vindex.(ParamValidating).UnknownParams())
+}
+
 func TestLookupUniqueMap(t *testing.T) {
 	lookupUnique := createLookup(t, "lookup_unique", false)
 	vc := &vcursor{numRows: 1}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"vitess.io/vitess/go/vt/vterrors"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	regionCellParamRegionCells = "region_cells"
+)
+
+var (
+	_ MultiColumn     = (*RegionCell)(nil)
+	_ ParamValidating = (*RegionCell)(nil)
+	_ CellAffine      = (*RegionCell)(nil)
+
+	regionCellParams = []string{
+		regionExperimentalParamRegionBytes,
+		regionCellParamRegionCells,
+	}
+)
+
+func init() {
+	Register("region_cell", newRegionCell)
+}
+
+// RegionCell is a RegionExperimental vindex which also knows the cell of every
+// region: the first column is prefixed to the hash of the second column to
+// produce the keyspace id, and the reads of the shards of a region are
+// preferably routed to the tablets in its cell.
+type RegionCell struct {
+	*RegionExperimental
+	cells         map[uint64]string
+	unknownParams []string
+}
+
+// newRegionCell creates a RegionCell vindex.
+// The supplied map requires all the fields of "region_experimental".
+// Additionally, it requires a region_cells argument which maps every region
+// to its cell, as a comma separated list of region:cell pairs, like
+// "1:us_east,2:eu_west".
+func newRegionCell(name string, m map[string]string) (Vindex, error) {
+	re, err := newRegionExperimental(name, m)
+	if err != nil {
+		return nil, err
+	}
+	rcs, ok := m[regionCellParamRegionCells]
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "region_cell missing %s param", regionCellParamRegionCells)
+	}
+	rc := &RegionCell{
+		RegionExperimental: re.(*RegionExperimental),
+		cells:              make(map[uint64]string),
+		unknownParams:      FindUnknownParams(m, regionCellParams),
+	}
+	maxRegion := uint64(1)<<(8*rc.regionBytes) - 1
+	for _, pair := range strings.Split(rcs, ",") {
+		region, cell, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || cell == "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid region:cell pair in %s: %q", regionCellParamRegionCells, pair)
+		}
+		rn, err := strconv.ParseUint(region, 10, 64)
+		if err != nil || rn > maxRegion {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid region in %s: %q", regionCellParamRegionCells, region)
+		}
+		rc.cells[rn] = cell
+	}
+	return rc, nil
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (rc *RegionCell) UnknownParams() []string {
+	return rc.unknownParams
+}
+
+// PreferredCell implements the CellAffine interface. It returns the cell of
+// the region of the key range, or an empty string if the key range spans
+// several regions or its region has no cell.
+func (rc *RegionCell) PreferredCell(kr *topodatapb.KeyRange) string {
+	first := rc.region(kr.GetStart())
+	last := uint64(1)<<(8*rc.regionBytes) - 1
+	if end := kr.GetEnd(); len(end) > 0 {
+		// The end of a key range is exclusive, so it is in the last region
+		// only if it has more than the region prefix.
+		last = rc.region(end)
+		if len(end) <= rc.regionBytes || len(bytes.TrimRight(end[rc.regionBytes:], "\x00")) == 0 {
+			last--
+		}
+	}
+	if first != last {
+		return ""
+	}
+	return rc.cells[first]
+}
+
+// region returns the region of the prefix of a keyspace id.
+func (rc *RegionCell) region(ksid []byte) uint64 {
+	var region uint64
+	for i := range rc.regionBytes {
+		region <<= 8
+		if i < len(ksid) {
+			region |= uint64(ksid[i])
+		}
+	}
+	return region
+}
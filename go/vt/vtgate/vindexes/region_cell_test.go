@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func regionCellCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "region_cell",
+		vindexName:   "region_cell",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "region_cell",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestRegionCellCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		regionCellCreateVindexTestCase(
+			"region_bytes required",
+			map[string]string{"region_cells": "1:cell1"},
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "region_experimental missing region_bytes param"),
+			nil,
+		),
+		regionCellCreateVindexTestCase(
+			"region_cells required",
+			map[string]string{"region_bytes": "1"},
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "region_cell missing region_cells param"),
+			nil,
+		),
+		regionCellCreateVindexTestCase(
+			"region_cells pair without cell",
+			map[string]string{"region_bytes": "1", "region_cells": "1:cell1,2"},
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid region:cell pair in region_cells: \"2\""),
+			nil,
+		),
+		regionCellCreateVindexTestCase(
+			"region out of range",
+			map[string]string{"region_bytes": "1", "region_cells": "256:cell1"},
+			vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid region in region_cells: \"256\""),
+			nil,
+		),
+		regionCellCreateVindexTestCase(
+			"valid",
+			map[string]string{"region_bytes": "2", "region_cells": "1:cell1, 256:cell2"},
+			nil,
+			nil,
+		),
+		regionCellCreateVindexTestCase(
+			"unknown params",
+			map[string]string{"region_bytes": "1", "region_cells": "1:cell1", "hello": "world"},
+			nil,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func createRegionCell(t *testing.T, regionBytes, regionCells string) *RegionCell {
+	vindex, err := CreateVindex("region_cell", "region_cell", map[string]string{
+		"region_bytes": regionBytes,
+		"region_cells": regionCells,
+	})
+	require.NoError(t, err)
+	return vindex.(*RegionCell)
+}
+
+func TestRegionCellMap(t *testing.T) {
+	// The keyspace ids are the ones of region_experimental.
+	rc := createRegionCell(t, "1", "1:cell1")
+	got, err := rc.Map(context.Background(), nil, [][]sqltypes.Value{{
+		sqltypes.NewInt64(1), sqltypes.NewInt64(1),
+	}, {
+		sqltypes.NewInt64(1),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{
+		key.DestinationKeyspaceID([]byte("\x01\x16k@\xb4J\xbaK\xd6")),
+		NewKeyRangeFromPrefix([]byte{1}),
+	}, got)
+}
+
+func TestRegionCellPreferredCell(t *testing.T) {
+	testcases := []struct {
+		regionBytes string
+		shard       string
+		want        string
+	}{{
+		regionBytes: "1",
+		shard:       "01-02",
+		want:        "cell1",
+	}, {
+		regionBytes: "1",
+		shard:       "0180-02",
+		want:        "cell1",
+	}, {
+		regionBytes: "1",
+		shard:       "02-0280",
+		want:        "cell2",
+	}, {
+		regionBytes: "1",
+		shard:       "-01",
+		want:        "",
+	}, {
+		regionBytes: "1",
+		shard:       "01-03",
+		want:        "",
+	}, {
+		regionBytes: "1",
+		shard:       "0180-0201",
+		want:        "",
+	}, {
+		regionBytes: "1",
+		shard:       "03-04",
+		want:        "",
+	}, {
+		regionBytes: "1",
+		shard:       "ff-",
+		want:        "cell3",
+	}, {
+		regionBytes: "2",
+		shard:       "0001-0002",
+		want:        "cell1",
+	}, {
+		regionBytes: "2",
+		shard:       "01-02",
+		want:        "",
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.regionBytes+"/"+tc.shard, func(t *testing.T) {
+			rc := createRegionCell(t, tc.regionBytes, "1:cell1,2:cell2,255:cell3")
+			krs, err := key.ParseShardingSpec(tc.shard)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, rc.PreferredCell(krs[0]))
+		})
+	}
+}
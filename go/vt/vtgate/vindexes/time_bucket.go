@@ -0,0 +1,247 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"vitess.io/vitess/go/mysql/datetime"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	timeBucketParamBucket = "bucket"
+
+	// TimeBucketDay maps every day to its own keyspace id.
+	TimeBucketDay = "day"
+	// TimeBucketWeek maps every ISO 8601 week to its own keyspace id.
+	TimeBucketWeek = "week"
+	// TimeBucketMonth maps every month to its own keyspace id.
+	TimeBucketMonth = "month"
+)
+
+var (
+	_ SingleColumn    = (*TimeBucket)(nil)
+	_ Hashing         = (*TimeBucket)(nil)
+	_ ParamValidating = (*TimeBucket)(nil)
+	_ Sequential      = (*TimeBucket)(nil)
+	_ RangeComparable = (*TimeBucket)(nil)
+)
+
+// TimeBucket maps a date or datetime to the keyspace id of its day, week or
+// month, as set by the bucket param. The keyspace ids are 4
+// bytes long and sort in time order, so that the shards of a keyspace hold
+// contiguous time ranges:
+//   - day: the year on 2 bytes, the month and the day of the month.
+//   - week: the ISO 8601 year on 2 bytes, the ISO 8601 week and a zero byte.
+//   - month: the year on 2 bytes, the month and a zero byte.
+//
+// For example, with monthly buckets, the shard -07ea01 holds the rows before
+// 2026 and the shard 07ea01- the rows from 2026 onwards. The ranges of
+// comparisons on the column are mapped to the shards which hold them.
+//
+// The column must be a DATE or DATETIME column. MySQL converts TIMESTAMP
+// values from the time zone of the session to UTC, so the bucket of a
+// TIMESTAMP literal would depend on the session, and TIMESTAMP values are
+// rejected.
+type TimeBucket struct {
+	name          string
+	bucket        string
+	unknownParams []string
+}
+
+// newTimeBucket creates a TimeBucket vindex.
+func newTimeBucket(name string, m map[string]string) (Vindex, error) {
+	bucket := m[timeBucketParamBucket]
+	switch bucket {
+	case TimeBucketDay, TimeBucketWeek, TimeBucketMonth:
+	case "":
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "time_bucket vindex requires the %s param", timeBucketParamBucket)
+	default:
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid %s param for time_bucket vindex: %s, must be one of %s, %s or %s",
+			timeBucketParamBucket, bucket, TimeBucketDay, TimeBucketWeek, TimeBucketMonth)
+	}
+	return &TimeBucket{
+		name:          name,
+		bucket:        bucket,
+		unknownParams: FindUnknownParams(m, []string{timeBucketParamBucket}),
+	}, nil
+}
+
+// String returns the name of the vindex.
+func (vind *TimeBucket) String() string {
+	return vind.name
+}
+
+// Cost returns the cost of this vindex as 1.
+func (*TimeBucket) Cost() int {
+	return 1
+}
+
+// IsUnique returns true since the Vindex is unique.
+func (*TimeBucket) IsUnique() bool {
+	return true
+}
+
+// NeedsVCursor satisfies the Vindex interface.
+func (*TimeBucket) NeedsVCursor() bool {
+	return false
+}
+
+// Bucket returns the bucket of the vindex: day, week or month.
+func (vind *TimeBucket) Bucket() string {
+	return vind.bucket
+}
+
+// Map can map ids to key.ShardDestination objects.
+func (vind *TimeBucket) Map(ctx context.Context, vcursor VCursor, ids []sqltypes.Value) ([]key.ShardDestination, error) {
+	out := make([]key.ShardDestination, 0, len(ids))
+	for _, id := range ids {
+		if id.Type() == sqltypes.Timestamp {
+			return nil, errTimeBucketTimestamp(id)
+		}
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			out = append(out, key.DestinationNone{})
+			continue
+		}
+		out = append(out, key.DestinationKeyspaceID(ksid))
+	}
+	return out, nil
+}
+
+// Verify returns true if ids maps to ksids.
+func (vind *TimeBucket) Verify(ctx context.Context, vcursor VCursor, ids []sqltypes.Value, ksids [][]byte) ([]bool, error) {
+	out := make([]bool, 0, len(ids))
+	for i, id := range ids {
+		ksid, err := vind.Hash(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, bytes.Equal(ksid, ksids[i]))
+	}
+	return out, nil
+}
+
+// RangeMap implements Between.
+func (vind *TimeBucket) RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
+	return vind.OpenRangeMap(ctx, vcursor, startId, endId)
+}
+
+// OpenRangeMap maps the ids between startId and endId, both inclusive, to the
+// key range of their buckets. A NULL startId or endId leaves the range
+// unbounded on that side. MySQL compares the column with a bound which is not
+// a valid date in its own way, so the range is then the full range.
+func (vind *TimeBucket) OpenRangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error) {
+	fullRange := []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(nil, nil)}}
+	var start, end []byte
+	if !startId.IsNull() {
+		ksid, err := vind.Hash(startId)
+		if err != nil {
+			return fullRange, nil
+		}
+		start = ksid
+	}
+	if !endId.IsNull() {
+		ksid, err := vind.Hash(endId)
+		if err != nil {
+			return fullRange, nil
+		}
+		// The end of a key range is exclusive, and the keyspace id of a
+		// bucket is the only one in it, so the range ends right after it.
+		end = binary.BigEndian.AppendUint32(nil, binary.BigEndian.Uint32(ksid)+1)
+	}
+	if start != nil && end != nil && bytes.Compare(start, end) >= 0 {
+		return []key.ShardDestination{key.DestinationNone{}}, nil
+	}
+	return []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(start, end)}}, nil
+}
+
+// UnknownParams implements the ParamValidating interface.
+func (vind *TimeBucket) UnknownParams() []string {
+	return vind.unknownParams
+}
+
+// Hash returns the keyspace id of the bucket of the id.
+func (vind *TimeBucket) Hash(id sqltypes.Value) ([]byte, error) {
+	d, err := parseBucketDate(id)
+	if err != nil {
+		return nil, err
+	}
+	year, b3, b4 := d.Year(), d.Month(), d.Day()
+	switch vind.bucket {
+	case TimeBucketWeek:
+		year, b3 = d.ISOWeek()
+		b4 = 0
+	case TimeBucketMonth:
+		b4 = 0
+	}
+	return []byte{byte(year >> 8), byte(year), byte(b3), byte(b4)}, nil
+}
+
+// errTimeBucketTimestamp returns the error for a TIMESTAMP id.
+func errTimeBucketTimestamp(id sqltypes.Value) error {
+	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "cannot map %s to a time bucket: only DATE and DATETIME values can be bucketed", id.String())
+}
+
+// parseBucketDate returns the date of a date or datetime value, or of a
+// string or number with the format MySQL accepts for them.
+func parseBucketDate(id sqltypes.Value) (datetime.Date, error) {
+	var (
+		d  datetime.Date
+		ok bool
+	)
+	if id.Type() == sqltypes.Timestamp {
+		return d, errTimeBucketTimestamp(id)
+	}
+	if id.IsIntegral() {
+		i, err := id.ToInt64()
+		if err != nil {
+			return d, err
+		}
+		if i >= 100000000 {
+			var dt datetime.DateTime
+			dt, ok = datetime.ParseDateTimeInt64(i)
+			d = dt.Date
+		} else {
+			d, ok = datetime.ParseDateInt64(i)
+		}
+	} else {
+		s := id.ToString()
+		var dt datetime.DateTime
+		if dt, _, ok = datetime.ParseDateTime(s, -1); ok {
+			d = dt.Date
+		} else {
+			d, ok = datetime.ParseDate(s)
+		}
+	}
+	if !ok || d.Month() == 0 || d.Day() == 0 {
+		return d, fmt.Errorf("cannot map %s to a time bucket: invalid date", id.String())
+	}
+	return d, nil
+}
+
+func init() {
+	Register("time_bucket", newTimeBucket)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vindexes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+)
+
+func timeBucketCreateVindexTestCase(
+	testName string,
+	vindexParams map[string]string,
+	expectErr error,
+	expectUnknownParams []string,
+) createVindexTestCase {
+	return createVindexTestCase{
+		testName: testName,
+
+		vindexType:   "time_bucket",
+		vindexName:   "time_bucket",
+		vindexParams: vindexParams,
+
+		expectCost:          1,
+		expectErr:           expectErr,
+		expectIsUnique:      true,
+		expectNeedsVCursor:  false,
+		expectString:        "time_bucket",
+		expectUnknownParams: expectUnknownParams,
+	}
+}
+
+func TestTimeBucketCreateVindex(t *testing.T) {
+	cases := []createVindexTestCase{
+		timeBucketCreateVindexTestCase(
+			"no params",
+			nil,
+			errors.New("time_bucket vindex requires the bucket param"),
+			nil,
+		),
+		timeBucketCreateVindexTestCase(
+			"invalid bucket",
+			map[string]string{"bucket": "year"},
+			errors.New("invalid bucket param for time_bucket vindex: year, must be one of day, week or month"),
+			nil,
+		),
+		timeBucketCreateVindexTestCase(
+			"day bucket",
+			map[string]string{"bucket": "day"},
+			nil,
+			nil,
+		),
+		timeBucketCreateVindexTestCase(
+			"unknown params",
+			map[string]string{"bucket": "week", "hello": "world"},
+			nil,
+			[]string{"hello"},
+		),
+	}
+
+	testCreateVindexes(t, cases)
+}
+
+func createTimeBucket(t *testing.T, bucket string) *TimeBucket {
+	vindex, err := CreateVindex("time_bucket", "tb", map[string]string{"bucket": bucket})
+	require.NoError(t, err)
+	return vindex.(*TimeBucket)
+}
+
+func TestTimeBucketMap(t *testing.T) {
+	ids := []sqltypes.Value{
+		sqltypes.NewDatetime("2026-01-15 10:20:30"),
+		sqltypes.NewDate("2026-01-15"),
+		sqltypes.NewDatetime("2026-01-01 00:00:00.123456"),
+		sqltypes.NewVarChar("2025-12-31 23:59:59"),
+		sqltypes.NewInt64(20260301),
+		sqltypes.NewInt64(20260301102030),
+		sqltypes.NewVarChar("0000-00-00"),
+		sqltypes.NewVarChar("not a date"),
+		sqltypes.NULL,
+	}
+	testcases := []struct {
+		bucket string
+		want   []key.ShardDestination
+	}{{
+		bucket: TimeBucketDay,
+		want: []key.ShardDestination{
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 15}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 15}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 1}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xe9, 12, 31}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 1}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 1}),
+			key.DestinationNone{},
+			key.DestinationNone{},
+			key.DestinationNone{},
+		},
+	}, {
+		bucket: TimeBucketWeek,
+		want: []key.ShardDestination{
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 0}),
+			// 2025-12-31 is in the first ISO week of 2026.
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 9, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 9, 0}),
+			key.DestinationNone{},
+			key.DestinationNone{},
+			key.DestinationNone{},
+		},
+	}, {
+		bucket: TimeBucketMonth,
+		want: []key.ShardDestination{
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 1, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xe9, 12, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 0}),
+			key.DestinationKeyspaceID([]byte{0x07, 0xea, 3, 0}),
+			key.DestinationNone{},
+			key.DestinationNone{},
+			key.DestinationNone{},
+		},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.bucket, func(t *testing.T) {
+			got, err := createTimeBucket(t, tc.bucket).Map(context.Background(), nil, ids)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTimeBucketVerify(t *testing.T) {
+	vindex := createTimeBucket(t, TimeBucketMonth)
+	got, err := vindex.Verify(context.Background(), nil,
+		[]sqltypes.Value{sqltypes.NewDatetime("2026-01-15 10:20:30"), sqltypes.NewDate("2026-02-01")},
+		[][]byte{{0x07, 0xea, 1, 0}, {0x07, 0xea, 1, 0}})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, got)
+
+	_, err = vindex.Verify(context.Background(), nil, []sqltypes.Value{sqltypes.NewVarChar("not a date")}, [][]byte{{0}})
+	assert.EqualError(t, err, "cannot map VARCHAR(\"not a date\") to a time bucket: invalid date")
+}
+
+func TestTimeBucketRangeMap(t *testing.T) {
+	vindex := createTimeBucket(t, TimeBucketMonth)
+	testcases := []struct {
+		name       string
+		start, end sqltypes.Value
+		want       key.ShardDestination
+	}{{
+		name:  "closed range",
+		start: sqltypes.NewDate("2026-01-15"),
+		end:   sqltypes.NewDatetime("2026-03-31 23:59:59"),
+		want:  &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte{0x07, 0xea, 1, 0}, []byte{0x07, 0xea, 3, 1})},
+	}, {
+		name:  "single bucket",
+		start: sqltypes.NewDate("2026-12-01"),
+		end:   sqltypes.NewDate("2026-12-31"),
+		want:  &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte{0x07, 0xea, 12, 0}, []byte{0x07, 0xea, 12, 1})},
+	}, {
+		name:  "unbounded end",
+		start: sqltypes.NewDate("2026-01-15"),
+		end:   sqltypes.NULL,
+		want:  &key.DestinationKeyRange{KeyRange: key.NewKeyRange([]byte{0x07, 0xea, 1, 0}, nil)},
+	}, {
+		name:  "unbounded start",
+		start: sqltypes.NULL,
+		end:   sqltypes.NewDate("2026-01-15"),
+		want:  &key.DestinationKeyRange{KeyRange: key.NewKeyRange(nil, []byte{0x07, 0xea, 1, 1})},
+	}, {
+		name:  "empty range",
+		start: sqltypes.NewDate("2026-03-01"),
+		end:   sqltypes.NewDate("2026-01-01"),
+		want:  key.DestinationNone{},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := vindex.OpenRangeMap(context.Background(), nil, tc.start, tc.end)
+			require.NoError(t, err)
+			assert.Equal(t, []key.ShardDestination{tc.want}, got)
+		})
+	}
+
+	// A bound which is not a date maps to the full range.
+	got, err := vindex.RangeMap(context.Background(), nil, sqltypes.NewVarChar("not a date"), sqltypes.NewDate("2026-01-15"))
+	require.NoError(t, err)
+	assert.Equal(t, []key.ShardDestination{&key.DestinationKeyRange{KeyRange: key.NewKeyRange(nil, nil)}}, got)
+}
+
+func TestTimeBucketTimestamp(t *testing.T) {
+	vindex := createTimeBucket(t, TimeBucketDay)
+	ts := sqltypes.NewTimestamp("2026-01-01 00:30:00")
+	wantErr := "cannot map TIMESTAMP(\"2026-01-01 00:30:00\") to a time bucket: only DATE and DATETIME values can be bucketed"
+	_, err := vindex.Map(context.Background(), nil, []sqltypes.Value{ts})
+	assert.EqualError(t, err, wantErr)
+	_, err = vindex.Hash(ts)
+	assert.EqualError(t, err, wantErr)
+	_, err = vindex.Verify(context.Background(), nil, []sqltypes.Value{ts}, [][]byte{{0x07, 0xea, 1, 1}})
+	assert.EqualError(t, err, wantErr)
+}
+
+func TestTimeBucketShardRanges(t *testing.T) {
+	// With the keyspace split at the start of 2026, the rows before and from
+	// 2026 are in different shards, whatever the bucket.
+	split := []byte{0x07, 0xea, 1}
+	for _, bucket := range []string{TimeBucketDay, TimeBucketWeek, TimeBucketMonth} {
+		vindex := createTimeBucket(t, bucket)
+		before, err := vindex.Hash(sqltypes.NewDatetime("2025-11-30 23:59:59"))
+		require.NoError(t, err)
+		from, err := vindex.Hash(sqltypes.NewDatetime("2026-01-05 00:00:00"))
+		require.NoError(t, err)
+		assert.True(t, key.KeyRangeContains(key.NewKeyRange(nil, split), before), bucket)
+		assert.True(t, key.KeyRangeContains(key.NewKeyRange(split, nil), from), bucket)
+	}
+}
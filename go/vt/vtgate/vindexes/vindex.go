@@ -29,6 +29,7 @@ import (
 	"vitess.io/vitess/go/vt/vterrors"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vtgatepb "vitess.io/vitess/go/vt/proto/vtgate"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
@@ -137,6 +138,17 @@ type (
 		RangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error)
 	}
 
+	// A RangeComparable vindex is a Sequential vindex which also maps ranges
+	// that are unbounded on one side. It's being used to reduce the fan out for
+	// '<', '<=', '>' and '>=' expressions, on their own or combined into a range.
+	RangeComparable interface {
+		Sequential
+		// OpenRangeMap maps the ids from startId to endId, both inclusive, like
+		// RangeMap, except that a NULL startId or endId leaves the range
+		// unbounded on that side.
+		OpenRangeMap(ctx context.Context, vcursor VCursor, startId sqltypes.Value, endId sqltypes.Value) ([]key.ShardDestination, error)
+	}
+
 	// A Prefixable vindex is one that maps the prefix of a id to a keyspace range
 	// instead of a single keyspace id. It's being used to reduced the fan out for
 	// 'LIKE' expressions.
@@ -145,6 +157,15 @@ type (
 		PrefixVindex() SingleColumn
 	}
 
+	// A CellAffine vindex is an optional interface for the vindexes which
+	// place their rows in shards local to a cell. VTGate preferably routes
+	// the reads of those shards to the tablets in that cell.
+	CellAffine interface {
+		// PreferredCell returns the cell of the rows of the key range, or an
+		// empty string if there is none.
+		PreferredCell(kr *topodatapb.KeyRange) string
+	}
+
 	// A Lookup vindex is one that needs to lookup
 	// a previously stored map to compute the keyspace
 	// id from an id. This means that the creation of
@@ -179,6 +200,17 @@ type (
 		IsBackfilling() bool
 	}
 
+	// Covering is implemented by the unique lookup vindexes which can also
+	// store some columns of their owner table in their lookup table. The
+	// LookupVindex workflow keeps these covering columns in sync, and the
+	// point selects on the owner table which only need them can be answered
+	// from the lookup table alone, outside of transactions.
+	Covering interface {
+		// CoveringTable returns the lookup table, its 'from' column and the
+		// covering columns, if any.
+		CoveringTable() (table, fromColumn string, columns []string)
+	}
+
 	// WantOwnerInfo defines the interface that a vindex must
 	// satisfy to request info about the owner table. This information can
 	// be used to query the owner's table for the owning row's presence.
@@ -20,6 +20,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
 	"maps"
 	"os"
 	"sort"
@@ -75,16 +76,35 @@ type VSchema struct {
 	Keyspaces            map[string]*KeyspaceSchema `json:"keyspaces"`
 	ShardRoutingRules    map[string]string          `json:"shard_routing_rules"`
 	KeyspaceRoutingRules map[string]string          `json:"keyspace_routing_rules"`
+	// RateLimitRules limit the rate of the queries on keyspaces and tables.
+	RateLimitRules []*vschemapb.RateLimitRule `json:"rate_limit_rules,omitempty"`
+	// Canaries are the vschemas with the canary vschemas of the keyspaces,
+	// by ascending percent of the sessions which use them.
+	Canaries []*CanaryVSchema `json:"canaries,omitempty"`
+	// CanaryPercent is the percent of the sessions which use the vschema, if
+	// it is a canary vschema.
+	CanaryPercent uint32 `json:"canary_percent,omitempty"`
 	// created is the time when the VSchema object was created. Used to detect if a cached
 	// copy of the vschema is stale.
 	created time.Time
 }
 
+// CanaryVSchema is a vschema in which the keyspaces with a canary vschema
+// used by at least Percent of the sessions use it.
+type CanaryVSchema struct {
+	Percent   uint32   `json:"percent"`
+	Keyspaces []string `json:"keyspaces"`
+	VSchema   *VSchema `json:"-"`
+}
+
 // MirrorRule represents one mirror rule.
 type MirrorRule struct {
 	Error   error
 	Percent float32    `json:"percent,omitempty"`
 	Table   *BaseTable `json:"table,omitempty"`
+	// Split is true if the queries are served by Table, instead of being
+	// mirrored to it.
+	Split bool `json:"split,omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of MirrorRule.
@@ -95,9 +115,11 @@ func (mr *MirrorRule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Percent float32
 		Table   *BaseTable
+		Split   bool `json:",omitempty"`
 	}{
 		Percent: mr.Percent,
 		Table:   mr.Table,
+		Split:   mr.Split,
 	})
 }
 
@@ -157,6 +179,16 @@ type BaseTable struct {
 	// MySQL error message: ERROR 3756 (HY000): The primary key cannot be a functional index
 	PrimaryKey sqlparser.Columns  `json:"primary_key,omitempty"`
 	UniqueKeys [][]sqlparser.Expr `json:"unique_keys,omitempty"`
+
+	// CheckConstraints are the enforced CHECK constraints of the table.
+	CheckConstraints []*CheckConstraint `json:"check_constraints,omitempty"`
+
+	// Rows is the estimated number of rows of the table on a shard, as
+	// reported by the schema tracking. It is 0 when it is not known.
+	Rows int64 `json:"rows,omitempty"`
+	// IndexCardinalities is the estimated number of unique values of each
+	// index of the table on a shard, as reported by the schema tracking.
+	IndexCardinalities map[string]int64 `json:"index_cardinalities,omitempty"`
 }
 
 // GetTableName gets the sqlparser.TableName for the vindex Table.
@@ -185,9 +217,32 @@ type ColumnVindex struct {
 
 // TableInfo contains column and foreign key info for a table.
 type TableInfo struct {
-	Columns     []Column
-	ForeignKeys []*sqlparser.ForeignKeyDefinition
-	Indexes     []*sqlparser.IndexDefinition
+	Columns          []Column
+	ForeignKeys      []*sqlparser.ForeignKeyDefinition
+	Indexes          []*sqlparser.IndexDefinition
+	CheckConstraints []*CheckConstraint
+	// Rows is the estimated number of rows of the table, 0 when not known.
+	Rows int64
+	// IndexCardinalities is the estimated number of unique values of each
+	// index of the table, when known.
+	IndexCardinalities map[string]int64
+}
+
+// CheckConstraint describes an enforced CHECK constraint of a table.
+type CheckConstraint struct {
+	Name string
+	Expr sqlparser.Expr
+}
+
+// MarshalJSON returns a JSON representation of CheckConstraint.
+func (cc *CheckConstraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name string `json:"name"`
+		Expr string `json:"expr"`
+	}{
+		Name: cc.Name,
+		Expr: sqlparser.String(cc.Expr),
+	})
 }
 
 // IsUnique is used to tell whether the ColumnVindex
@@ -227,6 +282,11 @@ type Column struct {
 	Nullable  bool  `json:"nullable,omitempty"`
 	// Values contains the list of values for enum and set types.
 	Values []string `json:"values,omitempty"`
+	// Generated is the expression computing the values of a generated column,
+	// it is nil for the other columns.
+	Generated sqlparser.Expr `json:"generated,omitempty"`
+	// Stored marks a generated column whose values are stored in the table.
+	Stored bool `json:"stored,omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of Column.
@@ -240,6 +300,8 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale     int32    `json:"scale,omitempty"`
 		Nullable  bool     `json:"nullable,omitempty"`
 		Values    []string `json:"values,omitempty"`
+		Generated string   `json:"generated,omitempty"`
+		Stored    bool     `json:"stored,omitempty"`
 	}{
 		Name:      col.Name.String(),
 		Type:      querypb.Type_name[int32(col.Type)],
@@ -248,13 +310,22 @@ func (col *Column) MarshalJSON() ([]byte, error) {
 		Scale:     col.Scale,
 		Nullable:  col.Nullable,
 		Values:    col.Values,
+		Stored:    col.Stored,
 	}
 	if col.Default != nil {
 		cj.Default = sqlparser.String(col.Default)
 	}
+	if col.Generated != nil {
+		cj.Generated = sqlparser.String(col.Generated)
+	}
 	return json.Marshal(cj)
 }
 
+// IsGenerated returns true if the values of the column are generated by MySQL.
+func (col *Column) IsGenerated() bool {
+	return col.Generated != nil
+}
+
 func (col *Column) ToEvalengineType(collationEnv *collations.Environment) evalengine.Type {
 	var collation collations.ID
 	if sqltypes.IsText(col.Type) {
@@ -369,6 +440,7 @@ func BuildVSchema(source *vschemapb.SrvVSchema, parser *sqlparser.Parser) (vsche
 	buildShardRoutingRule(source, vschema)
 	buildKeyspaceRoutingRule(source, vschema)
 	buildMirrorRule(source, vschema, parser)
+	vschema.RateLimitRules = source.GetRateLimitRules().GetRules()
 	// Resolve auto-increments after routing rules are built since sequence tables also obey routing rules.
 	resolveAutoIncrement(source, vschema, parser)
 	return vschema
@@ -906,6 +978,24 @@ func buildTables(ks *vschemapb.Keyspace, vschema *VSchema, ksvschema *KeyspaceSc
 		ksvschema.Tables[tname] = t
 	}
 
+	// The cache of a lookup vindex is only invalidated by the stream of the
+	// changes of its lookup table, when this vtgate does not write it, which
+	// requires the keyspace of the table.
+	for vname, vindex := range ksvschema.Vindexes {
+		lv, ok := vindex.(LookupStreamed)
+		if !ok || lv.LookupCache() == nil {
+			continue
+		}
+		if lookupKeyspace, table, _ := lv.LookupTable(); lookupKeyspace == "" {
+			return vterrors.Errorf(
+				vtrpcpb.Code_INVALID_ARGUMENT,
+				"lookup vindex %s without an owner must qualify its table %s with its keyspace to be cached",
+				vname,
+				table,
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -1281,6 +1371,7 @@ func buildMirrorRule(source *vschemapb.SrvVSchema, vschema *VSchema, parser *sql
 		vschema.MirrorRules[rule.FromTable] = &MirrorRule{
 			Table:   t,
 			Percent: rule.Percent,
+			Split:   rule.Split,
 		}
 
 		//
@@ -1586,6 +1677,22 @@ func (vschema *VSchema) GetCreated() time.Time {
 	return vschema.created
 }
 
+// ForSession returns the vschema used by the session with the given UUID: the
+// canary vschema of the keyspaces whose canary is used by the percentage of
+// the sessions the session is part of, if any, or the vschema itself.
+func (vschema *VSchema) ForSession(sessionUUID string) *VSchema {
+	if vschema == nil || len(vschema.Canaries) == 0 || sessionUUID == "" {
+		return vschema
+	}
+	bucket := crc32.ChecksumIEEE([]byte(sessionUUID)) % 100
+	for _, canary := range vschema.Canaries {
+		if bucket < canary.Percent {
+			return canary.VSchema
+		}
+	}
+	return vschema
+}
+
 // ResetCreated resets the created time to zero value.
 // Used only in tests where vschema protos are compared.
 func (vschema *VSchema) ResetCreated() {
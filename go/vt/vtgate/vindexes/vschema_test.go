@@ -859,6 +859,13 @@ func TestVSchemaMirrorRules(t *testing.T) {
 					FromTable: "ks1.ks1t5@replica",
 					ToTable:   "ks2.ks2t5",
 				},
+				// OK, split unsharded@tablet-type => unsharded.
+				{
+					FromTable: "ks1.ks1t9@rdonly",
+					ToTable:   "ks2.ks2t9",
+					Percent:   10,
+					Split:     true,
+				},
 				// Invalid FromTable tablet type..
 				{
 					FromTable: "ks1.ks1t6@stone",
@@ -1033,6 +1040,13 @@ func TestVSchemaMirrorRules(t *testing.T) {
 			"ks1.ks1t6@stone": {
 				Error: errors.New("from table: invalid tablet type: 'ks1.ks1t6@stone'"),
 			},
+			"ks1.ks1t9@rdonly": {
+				Table: &BaseTable{
+					Name: sqlparser.NewIdentifierCS("ks2t9"),
+				},
+				Percent: 10,
+				Split:   true,
+			},
 			"ks3.ks3t1": {
 				Table:   ks4t1,
 				Percent: 50,
@@ -2941,6 +2955,46 @@ func TestBuildKeyspaceSchema(t *testing.T) {
 	}
 }
 
+func TestBuildKeyspaceSchemaCachedLookup(t *testing.T) {
+	ks := &vschemapb.Keyspace{
+		Sharded: true,
+		Vindexes: map[string]*vschemapb.Vindex{
+			"name_lookup": {
+				Type: "consistent_lookup",
+				Params: map[string]string{
+					"table":         "name_idx",
+					"from":          "name",
+					"to":            "keyspace_id",
+					"cache_lookups": "true",
+				},
+			},
+		},
+	}
+	// Without an owner, the keyspace of an unqualified lookup table is
+	// unknown, so its changes could not invalidate the cache.
+	_, err := BuildKeyspaceSchema(ks, "ks", sqlparser.NewTestParser())
+	require.EqualError(t, err, "lookup vindex name_lookup without an owner must qualify its table name_idx with its keyspace to be cached")
+
+	ks.Vindexes["name_lookup"].Params["table"] = "lookup.name_idx"
+	_, err = BuildKeyspaceSchema(ks, "ks", sqlparser.NewTestParser())
+	require.NoError(t, err)
+
+	// The lookup table of an owned vindex is in the keyspace of its owner.
+	ks.Vindexes["name_lookup"].Params["table"] = "name_idx"
+	ks.Vindexes["name_lookup"].Owner = "user"
+	ks.Vindexes["hash"] = &vschemapb.Vindex{Type: "hash"}
+	ks.Tables = map[string]*vschemapb.Table{
+		"user": {
+			ColumnVindexes: []*vschemapb.ColumnVindex{
+				{Column: "id", Name: "hash"},
+				{Column: "name", Name: "name_lookup"},
+			},
+		},
+	}
+	_, err = BuildKeyspaceSchema(ks, "ks", sqlparser.NewTestParser())
+	require.NoError(t, err)
+}
+
 func TestValidate(t *testing.T) {
 	good := &vschemapb.Keyspace{
 		Tables: map[string]*vschemapb.Table{
@@ -19,6 +19,7 @@ package vtgate
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
 	"vitess.io/vitess/go/vt/graph"
@@ -32,6 +33,7 @@ import (
 
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 	vschemapb "vitess.io/vitess/go/vt/proto/vschema"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 // The full SQL error that the user sees in their vtgate connection looks like this:
@@ -49,6 +51,11 @@ type VSchemaManager struct {
 	subscriber        func(vschema *vindexes.VSchema, stats *VSchemaStats)
 	schema            SchemaInfo
 	parser            *sqlparser.Parser
+
+	// received is set once the first update of the SrvVSchema watch is
+	// received, and watchErr is the error of the last one, if any.
+	received bool
+	watchErr error
 }
 
 // SchemaInfo is an interface to schema tracker.
@@ -66,6 +73,20 @@ func (vm *VSchemaManager) GetCurrentSrvVschema() *vschemapb.SrvVSchema {
 	return vm.currentSrvVschema.CloneVT()
 }
 
+// WatchError returns an error if the SrvVSchema watch has not received the
+// SrvVSchema from the topo yet, or if its last update was an error.
+func (vm *VSchemaManager) WatchError() error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if !vm.received {
+		return vterrors.New(vtrpcpb.Code_UNAVAILABLE, "SrvVSchema not received from the topo yet")
+	}
+	if vm.watchErr != nil {
+		return vterrors.Wrap(vm.watchErr, "SrvVSchema watch error")
+	}
+	return nil
+}
+
 // UpdateVSchema propagates the updated vschema to the topo. The entry for
 // the given keyspace is updated in the global topo, and the full SrvVSchema
 // is updated in all known cells.
@@ -115,6 +136,7 @@ func (vm *VSchemaManager) UpdateVSchema(ctx context.Context, ks *topo.KeyspaceVS
 // VSchemaUpdate builds the VSchema from SrvVschema and call subscribers.
 func (vm *VSchemaManager) VSchemaUpdate(v *vschemapb.SrvVSchema, err error) bool {
 	log.Infof("Received vschema update")
+	var watchErr error
 	switch {
 	case err == nil:
 		// Good case, we can try to save that value.
@@ -128,11 +150,15 @@ func (vm *VSchemaManager) VSchemaUpdate(v *vschemapb.SrvVSchema, err error) bool
 		if vschemaCounters != nil {
 			vschemaCounters.Add("WatchError", 1)
 		}
+		watchErr = err
 	}
 
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
 
+	vm.received = true
+	vm.watchErr = watchErr
+
 	// keep a copy of the latest SrvVschema and Vschema
 	vm.currentSrvVschema = v // TODO: should we do this locking?
 	vschema := vm.currentVschema
@@ -193,8 +219,54 @@ func (vm *VSchemaManager) Rebuild() {
 	}
 }
 
-// buildAndEnhanceVSchema builds a new VSchema and uses information from the schema tracker to update it
+// buildAndEnhanceVSchema builds a new VSchema and uses information from the schema tracker to update it,
+// along with the canary VSchemas of the keyspaces which have a canary vschema.
 func (vm *VSchemaManager) buildAndEnhanceVSchema(v *vschemapb.SrvVSchema) *vindexes.VSchema {
+	vschema := vm.buildAndEnhanceSingleVSchema(v)
+	if len(v.Canaries) == 0 {
+		return vschema
+	}
+
+	// A session uses the canaries whose percent is higher than the bucket of
+	// the session, so there is one canary VSchema for each distinct percent,
+	// with the canaries whose percent is at least that one.
+	var percents []uint32
+	for _, canary := range v.Canaries {
+		if !slices.Contains(percents, canary.Percent) {
+			percents = append(percents, canary.Percent)
+		}
+	}
+	slices.Sort(percents)
+	for _, percent := range percents {
+		source := v.CloneVT()
+		source.Canaries = nil
+		var keyspaces []string
+		for ksName, canary := range v.Canaries {
+			if canary.Percent < percent {
+				continue
+			}
+			if _, ok := source.Keyspaces[ksName]; !ok {
+				continue
+			}
+			source.Keyspaces[ksName] = canary.Keyspace
+			keyspaces = append(keyspaces, ksName)
+		}
+		if len(keyspaces) == 0 {
+			continue
+		}
+		slices.Sort(keyspaces)
+		canaryVSchema := vm.buildAndEnhanceSingleVSchema(source)
+		canaryVSchema.CanaryPercent = percent
+		vschema.Canaries = append(vschema.Canaries, &vindexes.CanaryVSchema{
+			Percent:   percent,
+			Keyspaces: keyspaces,
+			VSchema:   canaryVSchema,
+		})
+	}
+	return vschema
+}
+
+func (vm *VSchemaManager) buildAndEnhanceSingleVSchema(v *vschemapb.SrvVSchema) *vindexes.VSchema {
 	vschema := vindexes.BuildVSchema(v, vm.parser)
 	if vm.schema != nil {
 		vm.updateFromSchema(vschema)
@@ -274,6 +346,9 @@ func (vm *VSchemaManager) updateTableInfo(vschema *vindexes.VSchema, ks *vindexe
 			rTbl.ParentForeignKeys = append(rTbl.ParentForeignKeys, vindexes.NewParentFkInfo(parentTbl, fkDef))
 			parentTbl.ChildForeignKeys = append(parentTbl.ChildForeignKeys, vindexes.NewChildFkInfo(rTbl, fkDef))
 		}
+		rTbl.CheckConstraints = tblInfo.CheckConstraints
+		rTbl.Rows = tblInfo.Rows
+		rTbl.IndexCardinalities = tblInfo.IndexCardinalities
 		for _, idxDef := range tblInfo.Indexes {
 			switch idxDef.Info.Type {
 			case sqlparser.IndexTypePrimary:
@@ -1,6 +1,7 @@
 package vtgate
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -516,6 +517,59 @@ func TestVSchemaUDFsUpdate(t *testing.T) {
 	utils.MustMatch(t, vs, vm.currentVschema, "currentVschema does not match Vschema")
 }
 
+// TestVSchemaCanaries tests that the canary vschemas of the keyspaces are built
+// for each distinct canary percent.
+func TestVSchemaCanaries(t *testing.T) {
+	vm := &VSchemaManager{}
+	var vs *vindexes.VSchema
+	vm.subscriber = func(vschema *vindexes.VSchema, _ *VSchemaStats) {
+		vs = vschema
+	}
+	vm.VSchemaUpdate(&vschemapb.SrvVSchema{
+		Keyspaces: map[string]*vschemapb.Keyspace{
+			"ks1": {},
+			"ks2": {},
+		},
+		Canaries: map[string]*vschemapb.VSchemaCanary{
+			"ks1": {Keyspace: &vschemapb.Keyspace{Sharded: true}, Percent: 50},
+			"ks2": {Keyspace: &vschemapb.Keyspace{Sharded: true}, Percent: 10},
+			// Canaries of unknown keyspaces are ignored.
+			"ks3": {Keyspace: &vschemapb.Keyspace{Sharded: true}, Percent: 90},
+		},
+	}, nil)
+
+	require.NotNil(t, vs)
+	assert.False(t, vs.Keyspaces["ks1"].Keyspace.Sharded)
+	assert.False(t, vs.Keyspaces["ks2"].Keyspace.Sharded)
+	assert.Zero(t, vs.CanaryPercent)
+	require.Len(t, vs.Canaries, 2)
+
+	assert.EqualValues(t, 10, vs.Canaries[0].Percent)
+	assert.Equal(t, []string{"ks1", "ks2"}, vs.Canaries[0].Keyspaces)
+	assert.EqualValues(t, 10, vs.Canaries[0].VSchema.CanaryPercent)
+	assert.True(t, vs.Canaries[0].VSchema.Keyspaces["ks1"].Keyspace.Sharded)
+	assert.True(t, vs.Canaries[0].VSchema.Keyspaces["ks2"].Keyspace.Sharded)
+
+	assert.EqualValues(t, 50, vs.Canaries[1].Percent)
+	assert.Equal(t, []string{"ks1"}, vs.Canaries[1].Keyspaces)
+	assert.True(t, vs.Canaries[1].VSchema.Keyspaces["ks1"].Keyspace.Sharded)
+	assert.False(t, vs.Canaries[1].VSchema.Keyspaces["ks2"].Keyspace.Sharded)
+	assert.Nil(t, vs.Canaries[1].VSchema.Keyspaces["ks3"])
+
+	// Sessions are spread across the vschemas, and always use the same one.
+	used := make(map[uint32]int)
+	for i := range 1000 {
+		uuid := fmt.Sprintf("session-%d", i)
+		sessionVSchema := vs.ForSession(uuid)
+		assert.Same(t, sessionVSchema, vs.ForSession(uuid))
+		used[sessionVSchema.CanaryPercent]++
+	}
+	assert.InDelta(t, 100, used[10], 50)
+	assert.InDelta(t, 400, used[50], 100)
+	assert.InDelta(t, 500, used[0], 100)
+	assert.Same(t, vs, vs.ForSession(""))
+}
+
 // TestVSchemaViewsUpdate tests that the views are updated in the VSchema.
 func TestVSchemaViewsUpdate(t *testing.T) {
 	vm := &VSchemaManager{}
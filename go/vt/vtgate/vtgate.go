@@ -26,6 +26,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -58,6 +59,7 @@ import (
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
 	econtext "vitess.io/vitess/go/vt/vtgate/executorcontext"
+	"vitess.io/vitess/go/vt/vtgate/peers"
 	"vitess.io/vitess/go/vt/vtgate/planbuilder/plancontext"
 	vtschema "vitess.io/vitess/go/vt/vtgate/schema"
 	"vitess.io/vitess/go/vt/vtgate/txresolver"
@@ -74,16 +76,30 @@ var (
 	truncateErrorLen int
 
 	// plan cache related flag
-	queryPlanCacheMemory int64 = 32 * 1024 * 1024 // 32mb
+	queryPlanCacheMemory    int64 = 32 * 1024 * 1024 // 32mb
+	preparedPlanCacheMemory int64 = 8 * 1024 * 1024  // 8mb
+
+	// reference table result cache related flags
+	referenceResultCacheTTL    time.Duration
+	referenceResultCacheMemory int64 = 16 * 1024 * 1024 // 16mb
 
 	maxMemoryRows   = 300000
 	warnMemoryRows  = 30000
 	maxPayloadSize  int
 	warnPayloadSize int
+	spillDir        string
+
+	hashJoinRowsThreshold int64
+	joinOrderByTableStats bool
 
 	noScatter          bool
 	enableShardRouting bool
 
+	// defaultScatterConcurrency and maxScatterConcurrency bound the number of
+	// shards a scatter query is sent to in parallel.
+	defaultScatterConcurrency int
+	maxScatterConcurrency     int
+
 	// healthCheckRetryDelay is the time to wait before retrying healthcheck
 	healthCheckRetryDelay = 2 * time.Millisecond
 	// healthCheckTimeout is the timeout on the RPC call to tablets
@@ -168,6 +184,12 @@ var (
 	warmingReadsPercent      = 0
 	warmingReadsQueryTimeout = 5 * time.Second
 	warmingReadsConcurrency  = 500
+
+	// sessionTokenSecret signs the tokens returned by @@session_token.
+	sessionTokenSecret string
+	// sessionTokenTTL is how long the tokens returned by @@session_token
+	// are valid.
+	sessionTokenTTL = time.Hour
 )
 
 func registerFlags(fs *pflag.FlagSet) {
@@ -177,7 +199,13 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&truncateErrorLen, "truncate-error-len", truncateErrorLen, "truncate errors sent to client if they are longer than this value (0 means do not truncate)")
 	utils.SetFlagIntVar(fs, &streamBufferSize, "stream-buffer-size", streamBufferSize, "the number of bytes sent from vtgate for each stream call. It's recommended to keep this value in sync with vttablet's query-server-config-stream-buffer-size.")
 	utils.SetFlagInt64Var(fs, &queryPlanCacheMemory, "gate-query-cache-memory", queryPlanCacheMemory, "gate server query cache size in bytes, maximum amount of memory to be cached. vtgate analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
+	fs.Int64Var(&preparedPlanCacheMemory, "gate-prepared-plan-cache-memory", preparedPlanCacheMemory, "gate server prepared statement plan cache size in bytes. The plans of prepared statements are cached separately from the plans of other queries, and shared by all connections, so that they are not evicted by ad-hoc queries. Set to 0 to cache them with the other plans.")
+	fs.DurationVar(&referenceResultCacheTTL, "reference-result-cache-ttl", referenceResultCacheTTL, "(Experimental) How long the results of the queries which only read reference tables are cached, outside of transactions. The cached results are also invalidated when the tables change, through a VStream on their keyspaces. The result cache is disabled when 0.")
+	fs.Int64Var(&referenceResultCacheMemory, "reference-result-cache-memory", referenceResultCacheMemory, "Maximum amount of memory in bytes used by the reference table result cache.")
 	utils.SetFlagIntVar(fs, &maxMemoryRows, "max-memory-rows", maxMemoryRows, "Maximum number of rows that will be held in memory for intermediate results as well as the final result.")
+	fs.StringVar(&spillDir, "spill-dir", spillDir, "Directory in which the sorts and hash joins of streaming queries spill the rows exceeding --max-memory-rows, so that these queries complete instead of failing. Spilling to disk is disabled when empty.")
+	fs.Int64Var(&hashJoinRowsThreshold, "hash-join-rows-threshold", hashJoinRowsThreshold, "Cross-shard joins use a hash join instead of a nested-loop join when both of their inputs are estimated to have more rows than this, from the table statistics of the schema tracking. The hash table is built from the smaller input. Disabled when 0. The ALLOW_HASH_JOIN query hint requests a hash join regardless of this threshold.")
+	utils.SetFlagBoolVar(fs, &joinOrderByTableStats, "join-order-by-table-stats", joinOrderByTableStats, "Cross-shard nested-loop joins are driven by the input with fewer estimated rows, from the table statistics of the schema tracking, instead of by the first table of the query. Inputs routed to a single row by a unique vindex are never moved.")
 	utils.SetFlagIntVar(fs, &warnMemoryRows, "warn-memory-rows", warnMemoryRows, "Warning threshold for in-memory results. A row count higher than this amount will cause the VtGateWarnings.ResultsExceeded counter to be incremented.")
 	utils.SetFlagStringVar(fs, &defaultDDLStrategy, "ddl-strategy", defaultDDLStrategy, "Set default strategy for DDL statements. Override with @@ddl_strategy session variable")
 	utils.SetFlagStringVar(fs, &dbDDLPlugin, "dbddl-plugin", dbDDLPlugin, "controls how to handle CREATE/DROP DATABASE. use it if you are using your own database provisioning service")
@@ -196,6 +224,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.Bool("enable-direct-ddl", enableDirectDDL.Default(), "Allow users to submit direct DDL statements")
 	utils.SetFlagBoolVar(fs, &enableSchemaChangeSignal, "schema-change-signal", enableSchemaChangeSignal, "Enable the schema tracker; requires queryserver-config-schema-change-signal to be enabled on the underlying vttablets for this to work")
 	fs.IntVar(&queryTimeout, "query-timeout", queryTimeout, "Sets the default query timeout (in ms). Can be overridden by session variable (query_timeout) or comment directive (QUERY_TIMEOUT_MS)")
+	fs.IntVar(&defaultScatterConcurrency, "default-scatter-concurrency", defaultScatterConcurrency, "Sets the default number of shards a scatter query is sent to in parallel, 0 meaning all of them. Can be overridden by session variable (scatter_concurrency) or comment directive (SCATTER_CONCURRENCY), up to --max-scatter-concurrency.")
+	fs.IntVar(&maxScatterConcurrency, "max-scatter-concurrency", maxScatterConcurrency, "Caps the number of shards a scatter query is sent to in parallel, whatever its session variable (scatter_concurrency) or comment directive (SCATTER_CONCURRENCY). No cap when 0.")
 	utils.SetFlagStringVar(fs, &queryLogToFile, "log-queries-to-file", queryLogToFile, "Enable query logging to the specified file")
 	fs.IntVar(&queryLogBufferSize, "querylog-buffer-size", queryLogBufferSize, "Maximum number of buffered query logs before throttling log output")
 	utils.SetFlagDurationVar(fs, &messageStreamGracePeriod, "message-stream-grace-period", messageStreamGracePeriod, "the amount of time to give for a vttablet to resume if it ends a message stream, usually because of a reparent.")
@@ -205,6 +235,8 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&warmingReadsPercent, "warming-reads-percent", 0, "Percentage of reads on the primary to forward to replicas. Useful for keeping buffer pools warm")
 	fs.IntVar(&warmingReadsConcurrency, "warming-reads-concurrency", 500, "Number of concurrent warming reads allowed")
 	fs.DurationVar(&warmingReadsQueryTimeout, "warming-reads-query-timeout", 5*time.Second, "Timeout of warming read queries")
+	fs.StringVar(&sessionTokenSecret, "session-token-secret", sessionTokenSecret, "Secret used to sign the session state tokens returned by @@session_token, which restore a session on another connection when set with SET @@session_token. Session tokens are disabled when empty.")
+	fs.DurationVar(&sessionTokenTTL, "session-token-ttl", sessionTokenTTL, "How long the session state tokens returned by @@session_token can be restored.")
 
 	viperutil.BindFlags(fs,
 		enableOnlineDDL,
@@ -277,6 +309,10 @@ type VTGate struct {
 	logExecute       *logutil.ThrottledLogger
 	logPrepare       *logutil.ThrottledLogger
 	logStreamExecute *logutil.ThrottledLogger
+
+	// draining is set once vtgate started draining its MySQL connections
+	// before exiting, and makes it report itself as unhealthy.
+	draining atomic.Bool
 }
 
 // RegisterVTGate defines the type of registration mechanism.
@@ -394,6 +430,15 @@ func Init(
 		st.RegisterSignalReceiver(executor.vm.Rebuild)
 	}
 
+	peerTracker, err := peers.NewTrackerFromFlags(ts, cell)
+	if err != nil {
+		log.Fatalf("Unable to initialize the peer discovery: %v", err)
+	}
+	if peerTracker != nil {
+		peerTracker.OnChange(executor.rateLimiter.setVTGates)
+		servenv.HTTPHandle(peers.StatusPath, peerTracker)
+	}
+
 	vtgateInst := newVTGate(executor, resolver, vsm, tc, gw)
 	_ = stats.NewRates("QPSByOperation", stats.CounterForDimension(vtgateInst.timings, "Operation"), 15, 1*time.Minute)
 	_ = stats.NewRates("QPSByKeyspace", stats.CounterForDimension(vtgateInst.timings, "Keyspace"), 15, 1*time.Minute)
@@ -412,21 +457,42 @@ func Init(
 			st.Start()
 		}
 		tr.Start()
+		if executor.resultCache != nil {
+			executor.resultCache.Open(vstreamResultCacheStreamer(vsm))
+		}
+		executor.lookupCacheInvalidator.Open(vstreamLookupCacheStreamer(vsm))
+		if peerTracker != nil {
+			peerTracker.Start()
+		}
 		srv := initMySQLProtocol(vtgateInst)
 		if srv != nil {
+			srv.registerDrain()
 			servenv.OnTermSync(srv.shutdownMysqlProtocolAndDrain)
 			servenv.OnClose(srv.rollbackAtShutdown)
 		}
+		if pgListener := initPgProtocol(vtgateInst); pgListener != nil {
+			servenv.OnTermSync(pgListener.Shutdown)
+		}
+		initHTTPQueryAPI(vtgateInst)
 	})
 	servenv.OnTerm(func() {
 		if st != nil && enableSchemaChangeSignal {
 			st.Stop()
 		}
 		tr.Stop()
+		if executor.resultCache != nil {
+			executor.resultCache.Close()
+		}
+		executor.lookupCacheInvalidator.Close()
+		if peerTracker != nil {
+			peerTracker.Stop()
+		}
 	})
 	vtgateInst.registerDebugHealthHandler()
+	vtgateInst.registerDebugReadyHandler()
 	vtgateInst.registerDebugEnvHandler()
 	vtgateInst.registerDebugBalancerHandler()
+	vtgateInst.registerDebugBufferHandler()
 
 	initAPI(gw.hc)
 	return vtgateInst
@@ -524,6 +590,7 @@ func (vtg *VTGate) registerDebugHealthHandler() {
 		}
 		w.Header().Set("Content-Type", "text/plain")
 		if err := vtg.IsHealthy(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
 			w.Write([]byte("not ok"))
 			return
 		}
@@ -531,15 +598,62 @@ func (vtg *VTGate) registerDebugHealthHandler() {
 	})
 }
 
+// registerDebugReadyHandler registers /debug/ready for the load balancers:
+// unlike /debug/health, it only returns ok once vtgate can serve queries, with
+// the reason why not otherwise.
+func (vtg *VTGate) registerDebugReadyHandler() {
+	servenv.HTTPHandleFunc("/debug/ready", vtg.debugReadyHandler)
+}
+
+func (vtg *VTGate) debugReadyHandler(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	if err := vtg.IsReady(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "not ready: %s", err.Error())
+		return
+	}
+	w.Write([]byte("ok"))
+}
+
 func (vtg *VTGate) registerDebugBalancerHandler() {
 	servenv.HTTPHandleFunc("/debug/balancer", func(w http.ResponseWriter, r *http.Request) {
 		vtg.Gateway().DebugBalancerHandler(w, r)
 	})
 }
 
+func (vtg *VTGate) registerDebugBufferHandler() {
+	servenv.HTTPHandleFunc("/debug/buffers", func(w http.ResponseWriter, r *http.Request) {
+		vtg.Gateway().DebugBufferHandler(w, r)
+	})
+}
+
 // IsHealthy returns nil if server is healthy.
 // Otherwise, it returns an error indicating the reason.
 func (vtg *VTGate) IsHealthy() error {
+	if vtg.draining.Load() {
+		return vterrors.New(vtrpcpb.Code_UNAVAILABLE, "vtgate is draining")
+	}
+	return nil
+}
+
+// IsReady returns nil if vtgate can serve queries: it is not draining, its
+// SrvVSchema watch is connected to the topo, and its healthcheck has found
+// healthy tablets for all the targets it waited for at startup.
+// Otherwise, it returns an error indicating the reason.
+func (vtg *VTGate) IsReady() error {
+	if err := vtg.IsHealthy(); err != nil {
+		return err
+	}
+	if err := vtg.executor.vm.WatchError(); err != nil {
+		return vterrors.Wrap(err, "topo not connected")
+	}
+	if !vtg.gw.IsPrimed() {
+		return vterrors.New(vtrpcpb.Code_UNAVAILABLE, "healthcheck not primed: some targets have no healthy tablets yet")
+	}
 	return nil
 }
 
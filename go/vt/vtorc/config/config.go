@@ -231,6 +231,42 @@ var (
 			Dynamic:  true,
 		},
 	)
+
+	failoverWitnessURLs = viperutil.Configure(
+		"failover-witness-urls",
+		viperutil.Options[[]string]{
+			FlagName: "failover-witness-urls",
+			Default:  nil,
+			Dynamic:  true,
+		},
+	)
+
+	failoverWitnessMaxReplicas = viperutil.Configure(
+		"failover-witness-max-replicas",
+		viperutil.Options[int]{
+			FlagName: "failover-witness-max-replicas",
+			Default:  1,
+			Dynamic:  true,
+		},
+	)
+
+	failoverWitnessTimeout = viperutil.Configure(
+		"failover-witness-timeout",
+		viperutil.Options[time.Duration]{
+			FlagName: "failover-witness-timeout",
+			Default:  5 * time.Second,
+			Dynamic:  true,
+		},
+	)
+
+	persistStateInTopo = viperutil.Configure(
+		"persist-state-in-topo",
+		viperutil.Options[bool]{
+			FlagName: "persist-state-in-topo",
+			Default:  false,
+			Dynamic:  false,
+		},
+	)
 )
 
 func init() {
@@ -260,6 +296,10 @@ func registerFlags(fs *pflag.FlagSet) {
 	fs.Bool("allow-recovery", allowRecovery.Default(), "Whether VTOrc should be allowed to run recovery actions")
 	fs.Bool("change-tablets-with-errant-gtid-to-drained", convertTabletsWithErrantGTIDs.Default(), "Whether VTOrc should be changing the type of tablets with errant GTIDs to DRAINED")
 	fs.Bool("enable-primary-disk-stalled-recovery", enablePrimaryDiskStalledRecovery.Default(), "Whether VTOrc should detect a stalled disk on the primary and failover")
+	fs.StringSlice("failover-witness-urls", failoverWitnessURLs.Default(), "Comma-separated base URLs of the witnesses, such as VTOrc instances in other cells, which must confirm that a dead primary is unreachable before VTOrc fails over a shard with few replicas. A majority of the witnesses must confirm it")
+	fs.Int("failover-witness-max-replicas", failoverWitnessMaxReplicas.Default(), "Maximum number of replicas of a shard for which the failover of its dead primary requires the confirmation of the witnesses in --failover-witness-urls")
+	fs.Duration("failover-witness-timeout", failoverWitnessTimeout.Default(), "Timeout of the requests to the witnesses in --failover-witness-urls")
+	fs.Bool("persist-state-in-topo", persistStateInTopo.Default(), "Whether VTOrc should persist the global recovery disable and the recovery history in the global topo, so that they are shared by all the VTOrc instances and survive the loss of any of them")
 
 	viperutil.BindFlags(fs,
 		cell,
@@ -283,6 +323,10 @@ func registerFlags(fs *pflag.FlagSet) {
 		allowRecovery,
 		convertTabletsWithErrantGTIDs,
 		enablePrimaryDiskStalledRecovery,
+		failoverWitnessURLs,
+		failoverWitnessMaxReplicas,
+		failoverWitnessTimeout,
+		persistStateInTopo,
 	)
 }
 
@@ -431,6 +475,36 @@ func GetStalledDiskPrimaryRecovery() bool {
 	return enablePrimaryDiskStalledRecovery.Get()
 }
 
+// GetFailoverWitnessURLs is a getter function.
+func GetFailoverWitnessURLs() []string {
+	return failoverWitnessURLs.Get()
+}
+
+// SetFailoverWitnessURLs sets the value for the failoverWitnessURLs variable. This should only be used from tests.
+func SetFailoverWitnessURLs(urls []string) {
+	failoverWitnessURLs.Set(urls)
+}
+
+// GetFailoverWitnessMaxReplicas is a getter function.
+func GetFailoverWitnessMaxReplicas() int {
+	return failoverWitnessMaxReplicas.Get()
+}
+
+// GetFailoverWitnessTimeout is a getter function.
+func GetFailoverWitnessTimeout() time.Duration {
+	return failoverWitnessTimeout.Get()
+}
+
+// GetPersistStateInTopo is a getter function.
+func GetPersistStateInTopo() bool {
+	return persistStateInTopo.Get()
+}
+
+// SetPersistStateInTopo sets the value for the persistStateInTopo variable. This should only be used from tests.
+func SetPersistStateInTopo(persist bool) {
+	persistStateInTopo.Set(persist)
+}
+
 // MarkConfigurationLoaded is called once configuration has first been loaded.
 // Listeners on ConfigurationLoaded will get a notification
 func MarkConfigurationLoaded() {
@@ -60,6 +60,7 @@ var (
 	readTopologyInstanceCounter = stats.NewCounter("InstanceReadTopology", "Number of times an instance was read from the topology")
 	readInstanceCounter         = stats.NewCounter("InstanceRead", "Number of times an instance was read")
 	currentErrantGTIDCount      = stats.NewGaugesWithSingleLabel("CurrentErrantGTIDCount", "Number of errant GTIDs a vttablet currently has", "TabletAlias")
+	mysqlErrorLogEventCount     = stats.NewGaugesWithMultiLabels("MysqlErrorLogEventCount", "Number of recent notable events of the MySQL error log of a vttablet, per category, as reported in its full status", []string{"TabletAlias", "Category"})
 )
 
 var (
@@ -234,6 +235,7 @@ func ReadTopologyInstanceBufferable(tabletAlias string, latency *stopwatch.Named
 		instance.SemiSyncPrimaryStatus = fs.SemiSyncPrimaryStatus
 		instance.SemiSyncReplicaStatus = fs.SemiSyncReplicaStatus
 		instance.SemiSyncBlocked = fs.SemiSyncBlocked
+		setMysqlErrorLogEventCounts(tabletAlias, fs.MysqlErrorLogEventCounts)
 
 		if instance.IsOracleMySQL() || instance.IsPercona() {
 			// Stuff only supported on Oracle / Percona MySQL
@@ -1082,6 +1084,21 @@ func InstanceIsForgotten(tabletAlias string) bool {
 	return found
 }
 
+// setMysqlErrorLogEventCounts exports the counts of the recent notable events
+// of the MySQL error log of the tablet, and resets the counts of the
+// categories it no longer reports.
+func setMysqlErrorLogEventCounts(tabletAlias string, counts map[string]int64) {
+	prefix := mysqlErrorLogEventCount.GetLabelName(tabletAlias, "")
+	for key := range mysqlErrorLogEventCount.Counts() {
+		if category, ok := strings.CutPrefix(key, prefix); ok && counts[category] == 0 {
+			mysqlErrorLogEventCount.ResetKey(key)
+		}
+	}
+	for category, count := range counts {
+		mysqlErrorLogEventCount.Set([]string{tabletAlias, category}, count)
+	}
+}
+
 // ForgetInstance removes an instance entry from the vtorc backed database.
 // It may be auto-rediscovered through topology or requested for discovery by multiple means.
 func ForgetInstance(tabletAlias string) error {
@@ -1095,6 +1112,7 @@ func ForgetInstance(tabletAlias string) error {
 
 	// Remove this tablet from errant GTID count metric.
 	currentErrantGTIDCount.Reset(tabletAlias)
+	setMysqlErrorLogEventCounts(tabletAlias, nil)
 
 	// Delete from the 'vitess_tablet' table.
 	_, err := db.ExecVTOrc(`DELETE
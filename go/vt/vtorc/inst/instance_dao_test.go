@@ -1161,3 +1161,31 @@ func TestPrimaryErrantGTIDs(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, "", instance.GtidErrant)
 }
+
+func TestSetMysqlErrorLogEventCounts(t *testing.T) {
+	defer setMysqlErrorLogEventCounts("zone1-100", nil)
+	defer setMysqlErrorLogEventCounts("zone1-101", nil)
+
+	setMysqlErrorLogEventCounts("zone1-100", map[string]int64{"disk_error": 2, "deadlock": 30})
+	setMysqlErrorLogEventCounts("zone1-101", map[string]int64{"disk_error": 1})
+	require.Equal(t, map[string]int64{
+		"zone1-100.disk_error": 2,
+		"zone1-100.deadlock":   30,
+		"zone1-101.disk_error": 1,
+	}, mysqlErrorLogEventCount.Counts())
+
+	// The categories which are no longer reported are reset.
+	setMysqlErrorLogEventCounts("zone1-100", map[string]int64{"deadlock": 5})
+	require.Equal(t, map[string]int64{
+		"zone1-100.disk_error": 0,
+		"zone1-100.deadlock":   5,
+		"zone1-101.disk_error": 1,
+	}, mysqlErrorLogEventCount.Counts())
+
+	setMysqlErrorLogEventCounts("zone1-100", nil)
+	require.Equal(t, map[string]int64{
+		"zone1-100.disk_error": 0,
+		"zone1-100.deadlock":   0,
+		"zone1-101.disk_error": 1,
+	}, mysqlErrorLogEventCount.Counts())
+}
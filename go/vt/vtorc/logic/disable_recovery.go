@@ -28,6 +28,10 @@ package logic
 // but we won't be doing that many recoveries at once so the load
 // on this table is expected to be very low. It should be fine to
 // go to the database each time.
+//
+// When --persist-state-in-topo is set, the recoveries disabled through the
+// API are also recorded in the global topo, so that they are disabled for
+// all the VTOrc instances.
 
 import (
 	"errors"
@@ -35,6 +39,7 @@ import (
 
 	"vitess.io/vitess/go/vt/external/golib/sqlutils"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vtorc/config"
 	"vitess.io/vitess/go/vt/vtorc/db"
 )
 
@@ -55,13 +60,33 @@ func IsRecoveryDisabled() (disabled bool, err error) {
 	if err != nil {
 		errMsg := fmt.Sprintf("recovery.IsRecoveryDisabled(): %v", err)
 		log.Errorf(errMsg)
-		err = errors.New(errMsg)
+		return false, errors.New(errMsg)
+	}
+	if !disabled && config.GetPersistStateInTopo() {
+		disabled, err = isRecoveryDisabledInTopo()
+		if err != nil {
+			errMsg := fmt.Sprintf("recovery.IsRecoveryDisabled(): %v", err)
+			log.Errorf(errMsg)
+			err = errors.New(errMsg)
+		}
 	}
 	return disabled, err
 }
 
 // DisableRecovery ensures recoveries are disabled globally
 func DisableRecovery() error {
+	if err := disableLocalRecovery(); err != nil {
+		return err
+	}
+	if config.GetPersistStateInTopo() {
+		return setRecoveryDisabledInTopo(true)
+	}
+	return nil
+}
+
+// disableLocalRecovery ensures recoveries are disabled for this VTOrc
+// instance only.
+func disableLocalRecovery() error {
 	_, err := db.ExecVTOrc(`INSERT OR IGNORE
 		INTO global_recovery_disable (
 			disable_recovery
@@ -76,5 +101,11 @@ func EnableRecovery() error {
 		FROM global_recovery_disable
 		WHERE
 			disable_recovery >= 0`)
-	return err
+	if err != nil {
+		return err
+	}
+	if config.GetPersistStateInTopo() {
+		return setRecoveryDisabledInTopo(false)
+	}
+	return nil
 }
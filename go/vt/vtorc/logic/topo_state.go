@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+// This file holds the state of VTOrc which is persisted in the global topo
+// when --persist-state-in-topo is set: the global recovery disable, and the
+// history of the recoveries. All the VTOrc instances share it, so that it
+// survives the loss of any of them.
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/vt/external/golib/sqlutils"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/db"
+
+	vtorcdatapb "vitess.io/vitess/go/vt/proto/vtorcdata"
+)
+
+// maxTopoRecoveries is the maximum number of recoveries kept in the history
+// of the global topo, so that the size of the state stays bounded.
+const maxTopoRecoveries = 500
+
+// isRecoveryDisabledInTopo returns true if Recoveries are disabled globally
+// in the topo.
+func isRecoveryDisabledInTopo() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	state, err := ts.GetVTOrcState(ctx)
+	if err != nil {
+		return false, err
+	}
+	return state.RecoveriesDisabled, nil
+}
+
+// setRecoveryDisabledInTopo disables or enables Recoveries globally in the
+// topo.
+func setRecoveryDisabledInTopo(disabled bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	return ts.UpdateVTOrcStateFields(ctx, func(state *vtorcdatapb.State) error {
+		if state.RecoveriesDisabled == disabled {
+			return topo.NewError(topo.NoUpdateNeeded, topo.VTOrcStateFile)
+		}
+		state.RecoveriesDisabled = disabled
+		return nil
+	})
+}
+
+// writeRecoveryToTopo adds the resolved recovery to the history of the
+// topo, and removes the recoveries which are older than the audit purge
+// duration from it.
+func writeRecoveryToTopo(recoveryID int64) error {
+	recoveries, err := readRecoveries(`WHERE recovery_id = ?`, ``, sqlutils.Args(recoveryID))
+	if err != nil {
+		return err
+	}
+	if len(recoveries) == 0 {
+		return nil
+	}
+	recovery, err := recoveryToProto(recoveries[0])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	purgeTime := time.Now().Add(-time.Duration(config.GetAuditPurgeDays()) * 24 * time.Hour)
+	return ts.UpdateVTOrcStateFields(ctx, func(state *vtorcdatapb.State) error {
+		history := append(state.Recoveries, recovery)
+		for len(history) > 0 && (len(history) > maxTopoRecoveries || protoutil.TimeFromProto(history[0].StartTime).Before(purgeTime)) {
+			history = history[1:]
+		}
+		state.Recoveries = history
+		return nil
+	})
+}
+
+// importRecoveriesFromTopo adds the recoveries of the history of the topo
+// which are missing from the database, such as the ones run by the other
+// VTOrc instances, or before this instance started.
+func importRecoveriesFromTopo() error {
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+	state, err := ts.GetVTOrcState(ctx)
+	if err != nil {
+		return err
+	}
+	for _, recovery := range state.Recoveries {
+		// The timestamps are written in the format of the DATETIME function
+		// of sqlite, so that the recoveries which are already in the
+		// database are found.
+		startRecovery := protoutil.TimeFromProto(recovery.StartTime).UTC().Format(time.DateTime)
+		var endRecovery any
+		if recovery.EndTime != nil {
+			endRecovery = protoutil.TimeFromProto(recovery.EndTime).UTC().Format(time.DateTime)
+		}
+		_, err := db.ExecVTOrc(`INSERT
+			INTO topology_recovery (
+				alias,
+				start_recovery,
+				end_recovery,
+				successor_alias,
+				analysis,
+				keyspace,
+				shard,
+				is_successful,
+				all_errors
+			) SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?
+			WHERE NOT EXISTS (
+				SELECT 1 FROM topology_recovery WHERE alias = ? AND start_recovery = ?
+			)`,
+			recovery.Alias,
+			startRecovery,
+			endRecovery,
+			recovery.SuccessorAlias,
+			recovery.Analysis,
+			recovery.Keyspace,
+			recovery.Shard,
+			recovery.IsSuccessful,
+			strings.Join(recovery.Errors, "\n"),
+			recovery.Alias,
+			startRecovery,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("imported %d recoveries from the topo", len(state.Recoveries))
+	return nil
+}
+
+// recoveryToProto converts a recovery read from the database to its topo
+// representation.
+func recoveryToProto(topologyRecovery *TopologyRecovery) (*vtorcdatapb.Recovery, error) {
+	recovery := &vtorcdatapb.Recovery{
+		Alias:          topologyRecovery.AnalysisEntry.AnalyzedInstanceAlias,
+		Analysis:       string(topologyRecovery.AnalysisEntry.Analysis),
+		Keyspace:       topologyRecovery.AnalysisEntry.AnalyzedKeyspace,
+		Shard:          topologyRecovery.AnalysisEntry.AnalyzedShard,
+		IsSuccessful:   topologyRecovery.IsSuccessful,
+		SuccessorAlias: topologyRecovery.SuccessorAlias,
+	}
+	for _, e := range topologyRecovery.AllErrors {
+		if e != "" {
+			recovery.Errors = append(recovery.Errors, e)
+		}
+	}
+	start, err := parseRecoveryTimestamp(topologyRecovery.RecoveryStartTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	recovery.StartTime = protoutil.TimeToProto(start)
+	if topologyRecovery.RecoveryEndTimestamp != "" {
+		end, err := parseRecoveryTimestamp(topologyRecovery.RecoveryEndTimestamp)
+		if err != nil {
+			return nil, err
+		}
+		recovery.EndTime = protoutil.TimeToProto(end)
+	}
+	return recovery, nil
+}
+
+// parseRecoveryTimestamp parses a timestamp of the topology_recovery table.
+// The timestamps are in UTC, and are read back either as RFC3339 or in the
+// format of the DATETIME function of sqlite, depending on the query.
+func parseRecoveryTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateTime, s)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/db"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+func TestPersistStateInTopo(t *testing.T) {
+	orcDb, err := db.OpenVTOrc()
+	require.NoError(t, err)
+	oldTs := ts
+	config.SetPersistStateInTopo(true)
+	defer func() {
+		ts = oldTs
+		config.SetPersistStateInTopo(false)
+		_, err = orcDb.Exec("delete from topology_recovery")
+		require.NoError(t, err)
+		_, err = orcDb.Exec("delete from global_recovery_disable")
+		require.NoError(t, err)
+	}()
+	ts = memorytopo.NewServer(t.Context(), "zone1")
+
+	t.Run("recoveries disabled", func(t *testing.T) {
+		require.NoError(t, DisableRecovery())
+		state, err := ts.GetVTOrcState(t.Context())
+		require.NoError(t, err)
+		require.True(t, state.RecoveriesDisabled)
+
+		// The recoveries stay disabled for another VTOrc instance.
+		_, err = orcDb.Exec("delete from global_recovery_disable")
+		require.NoError(t, err)
+		disabled, err := IsRecoveryDisabled()
+		require.NoError(t, err)
+		require.True(t, disabled)
+
+		require.NoError(t, EnableRecovery())
+		disabled, err = IsRecoveryDisabled()
+		require.NoError(t, err)
+		require.False(t, disabled)
+	})
+
+	t.Run("recovery history", func(t *testing.T) {
+		topologyRecovery, err := writeTopologyRecovery(NewTopologyRecovery(inst.DetectionAnalysis{
+			AnalyzedInstanceAlias: "zone1-0000000100",
+			AnalyzedKeyspace:      keyspace,
+			AnalyzedShard:         shard,
+			Analysis:              inst.DeadPrimary,
+		}))
+		require.NoError(t, err)
+		require.NoError(t, resolveRecovery(topologyRecovery, &inst.Instance{InstanceAlias: "zone1-0000000101"}))
+
+		state, err := ts.GetVTOrcState(t.Context())
+		require.NoError(t, err)
+		require.Len(t, state.Recoveries, 1)
+		recovery := state.Recoveries[0]
+		assert.Equal(t, "zone1-0000000100", recovery.Alias)
+		assert.Equal(t, string(inst.DeadPrimary), recovery.Analysis)
+		assert.True(t, recovery.IsSuccessful)
+		assert.Equal(t, "zone1-0000000101", recovery.SuccessorAlias)
+		assert.NotNil(t, recovery.StartTime)
+		assert.NotNil(t, recovery.EndTime)
+
+		// The history is imported by a new VTOrc instance, only once.
+		_, err = orcDb.Exec("delete from topology_recovery")
+		require.NoError(t, err)
+		for range 2 {
+			require.NoError(t, importRecoveriesFromTopo())
+			recoveries, err := ReadRecentRecoveries(0)
+			require.NoError(t, err)
+			require.Len(t, recoveries, 1)
+			assert.Equal(t, "zone1-0000000100", recoveries[0].AnalysisEntry.AnalyzedInstanceAlias)
+			assert.Equal(t, "zone1-0000000101", recoveries[0].SuccessorAlias)
+			assert.True(t, recoveries[0].IsSuccessful)
+			assert.NotEmpty(t, recoveries[0].RecoveryEndTimestamp)
+		}
+	})
+}
@@ -69,6 +69,7 @@ const (
 	RecoverySkipERSDisabled
 	RecoverySkipStaleAnalysis
 	RecoverySkipPrimaryRecovery
+	RecoverySkipNoWitnessQuorum
 )
 
 // String represents a RecoverySkip as a string.
@@ -84,6 +85,8 @@ func (rsc RecoverySkipCode) String() string {
 		return "StaleAnalysis"
 	case RecoverySkipPrimaryRecovery:
 		return "PrimaryRecovery"
+	case RecoverySkipNoWitnessQuorum:
+		return "NoWitnessQuorum"
 	default:
 		return "None"
 	}
@@ -269,7 +272,15 @@ func resolveRecovery(topologyRecovery *TopologyRecovery, successorInstance *inst
 		topologyRecovery.SuccessorAlias = successorInstance.InstanceAlias
 		topologyRecovery.IsSuccessful = true
 	}
-	return writeResolveRecovery(topologyRecovery)
+	if err := writeResolveRecovery(topologyRecovery); err != nil {
+		return err
+	}
+	if config.GetPersistStateInTopo() {
+		if err := writeRecoveryToTopo(topologyRecovery.ID); err != nil {
+			log.Errorf("failed to write recovery %d to the topo: %+v", topologyRecovery.ID, err)
+		}
+	}
+	return nil
 }
 
 // recoverPrimaryHasPrimary resets the replication on the primary instance
@@ -865,6 +876,16 @@ func executeCheckAndRecoverFunction(analysisEntry *inst.DetectionAnalysis) (err
 			recoveriesSkippedCounter.Add(append(recoveryLabels, RecoverySkipStaleAnalysis.String()), 1)
 			return nil
 		}
+		// With too few replicas to tell a dead primary from a network partition, the witnesses
+		// must confirm that the primary is unreachable, or the failover could cause a split-brain.
+		if checkAndRecoverFunctionCode == recoverDeadPrimaryFunc && requiresWitnessConfirmation(analysisEntry) {
+			logger.Infof("Asking the witnesses to confirm that primary %v is unreachable", analysisEntry.AnalyzedInstanceAlias)
+			if confirmed, err := witnessesConfirmDeadPrimary(ctx, config.GetFailoverWitnessURLs(), analysisEntry.AnalyzedInstanceAlias); !confirmed {
+				logger.Warningf("Not recovering %v, the witnesses did not confirm it: %v", analysisEntry.Analysis, err)
+				recoveriesSkippedCounter.Add(append(recoveryLabels, RecoverySkipNoWitnessQuorum.String()), 1)
+				return nil
+			}
+		}
 	}
 
 	// Actually attempt recovery:
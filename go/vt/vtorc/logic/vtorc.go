@@ -247,7 +247,7 @@ func ContinuousDiscovery() {
 
 	if !config.GetAllowRecovery() {
 		log.Info("--allow-recovery is set to 'false', disabling recovery actions")
-		if err := DisableRecovery(); err != nil {
+		if err := disableLocalRecovery(); err != nil {
 			log.Errorf("failed to disable recoveries: %+v", err)
 			return
 		}
@@ -259,6 +259,11 @@ func ContinuousDiscovery() {
 	caretakingTick := time.Tick(time.Minute)
 	recoveryTick := time.Tick(config.GetRecoveryPollDuration())
 	tabletTopoTick := OpenTabletDiscovery()
+	if config.GetPersistStateInTopo() {
+		if err := importRecoveriesFromTopo(); err != nil {
+			log.Errorf("failed to import the recoveries from the topo: %+v", err)
+		}
+	}
 	var recoveryEntrance int64
 	var snapshotTopologiesTick <-chan time.Time
 	if config.GetSnapshotTopologyInterval() > 0 {
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// In a shard with a single replica, the replica not seeing the primary is not
+// enough to tell a dead primary from a network partition between the two of
+// them, and failing over in the latter case leaves two writable primaries.
+// Such failovers are therefore only run once a majority of witnesses, which
+// are VTOrc instances in other cells or any external service implementing the
+// same API, confirm that they cannot reach the primary either.
+
+// WitnessAPIPath is the path of the API a witness serves.
+const WitnessAPIPath = "/api/witness"
+
+// WitnessResponse is the response of a witness about the reachability of a
+// primary tablet.
+type WitnessResponse struct {
+	TabletAlias string `json:"tablet_alias"`
+	Reachable   bool   `json:"reachable"`
+}
+
+// requiresWitnessConfirmation returns whether the failover for the given
+// analysis must first be confirmed by the witnesses.
+func requiresWitnessConfirmation(analysisEntry *inst.DetectionAnalysis) bool {
+	if len(config.GetFailoverWitnessURLs()) == 0 {
+		return false
+	}
+	switch analysisEntry.Analysis {
+	case inst.DeadPrimary, inst.DeadPrimaryAndSomeReplicas:
+		return analysisEntry.CountReplicas <= uint(max(config.GetFailoverWitnessMaxReplicas(), 0))
+	default:
+		// The other failovers are not about the primary being unreachable.
+		return false
+	}
+}
+
+// witnessesConfirmDeadPrimary asks all the witnesses whether they can reach the
+// given primary tablet, and returns whether a majority of them confirmed that
+// they cannot. A witness which cannot be queried does not confirm anything.
+func witnessesConfirmDeadPrimary(ctx context.Context, witnessURLs []string, tabletAlias string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.GetFailoverWitnessTimeout())
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		confirmed int
+		errs      []error
+		reachedBy []string
+	)
+	for _, witnessURL := range witnessURLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reachable, err := queryWitness(ctx, witnessURL, tabletAlias)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				errs = append(errs, fmt.Errorf("witness %s: %w", witnessURL, err))
+			case reachable:
+				reachedBy = append(reachedBy, witnessURL)
+			default:
+				confirmed++
+			}
+		}()
+	}
+	wg.Wait()
+
+	if confirmed > len(witnessURLs)/2 {
+		return true, nil
+	}
+	if len(reachedBy) > 0 {
+		errs = append(errs, fmt.Errorf("primary %s is reachable by the witnesses %s", tabletAlias, strings.Join(reachedBy, ", ")))
+	}
+	return false, fmt.Errorf("only %d of %d witnesses confirmed that primary %s is unreachable: %w", confirmed, len(witnessURLs), tabletAlias, errors.Join(errs...))
+}
+
+// queryWitness asks a witness whether it can reach the given tablet.
+func queryWitness(ctx context.Context, witnessURL string, tabletAlias string) (bool, error) {
+	reqURL := strings.TrimSuffix(witnessURL, "/") + WitnessAPIPath + "?tablet=" + url.QueryEscape(tabletAlias)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+	var witnessResp WitnessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&witnessResp); err != nil {
+		return false, err
+	}
+	if witnessResp.TabletAlias != tabletAlias {
+		return false, fmt.Errorf("unexpected response for tablet %q", witnessResp.TabletAlias)
+	}
+	return witnessResp.Reachable, nil
+}
+
+// CheckTabletReachable discovers the given tablet right away, and returns
+// whether its MySQL could be reached. This is what a VTOrc answers when used
+// as a witness by another VTOrc.
+func CheckTabletReachable(tabletAlias string) (bool, error) {
+	if _, err := inst.ReadTablet(tabletAlias); err != nil {
+		return false, err
+	}
+	DiscoverInstance(tabletAlias, true)
+	instance, found, err := inst.ReadInstance(tabletAlias)
+	if err != nil {
+		return false, err
+	}
+	return found && instance.IsLastCheckValid, nil
+}
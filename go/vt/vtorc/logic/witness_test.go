@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/vtorc/config"
+	"vitess.io/vitess/go/vt/vtorc/inst"
+)
+
+// newTestWitness returns a witness which answers that it can reach the primary, or not.
+func newTestWitness(t *testing.T, reachable bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, WitnessAPIPath, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(&WitnessResponse{
+			TabletAlias: r.URL.Query().Get("tablet"),
+			Reachable:   reachable,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRequiresWitnessConfirmation(t *testing.T) {
+	defer config.SetFailoverWitnessURLs(nil)
+
+	analysisEntry := &inst.DetectionAnalysis{
+		Analysis:      inst.DeadPrimary,
+		CountReplicas: 1,
+	}
+	assert.False(t, requiresWitnessConfirmation(analysisEntry))
+
+	config.SetFailoverWitnessURLs([]string{"http://witness:15000"})
+	assert.True(t, requiresWitnessConfirmation(analysisEntry))
+
+	analysisEntry.Analysis = inst.DeadPrimaryAndSomeReplicas
+	assert.True(t, requiresWitnessConfirmation(analysisEntry))
+
+	// A stalled disk or a deleted primary tablet are not network partitions.
+	analysisEntry.Analysis = inst.PrimaryDiskStalled
+	assert.False(t, requiresWitnessConfirmation(analysisEntry))
+
+	// With enough replicas, their view of the primary is trusted.
+	analysisEntry.Analysis = inst.DeadPrimary
+	analysisEntry.CountReplicas = 2
+	assert.False(t, requiresWitnessConfirmation(analysisEntry))
+}
+
+func TestWitnessesConfirmDeadPrimary(t *testing.T) {
+	ctx := context.Background()
+	unreachable := newTestWitness(t, false).URL
+	reachable := newTestWitness(t, true).URL
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	tests := []struct {
+		name      string
+		witnesses []string
+		confirmed bool
+		wantErr   string
+	}{
+		{
+			name:      "single witness confirms",
+			witnesses: []string{unreachable},
+			confirmed: true,
+		}, {
+			name:      "single witness reaches the primary",
+			witnesses: []string{reachable},
+			wantErr:   "is reachable by the witnesses",
+		}, {
+			name:      "single witness is down",
+			witnesses: []string{down.URL},
+			wantErr:   "only 0 of 1 witnesses confirmed",
+		}, {
+			name:      "majority confirms",
+			witnesses: []string{unreachable, unreachable + "/", down.URL},
+			confirmed: true,
+		}, {
+			name:      "no majority",
+			witnesses: []string{unreachable, reachable},
+			wantErr:   "only 1 of 2 witnesses confirmed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confirmed, err := witnessesConfirmDeadPrimary(ctx, tt.witnesses, "zone1-0000000100")
+			assert.Equal(t, tt.confirmed, confirmed)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -45,6 +46,7 @@ const (
 	replicationAnalysisAPI     = "/api/replication-analysis" // TODO: remove in v24+
 	databaseStateAPI           = "/api/database-state"
 	configAPI                  = "/api/config"
+	witnessAPI                 = logic.WitnessAPIPath
 	healthAPI                  = "/debug/health"
 
 	shardWithoutKeyspaceFilteringErrorStr = "Filtering by shard without keyspace isn't supported"
@@ -62,6 +64,7 @@ var (
 		replicationAnalysisAPI,
 		databaseStateAPI,
 		configAPI,
+		witnessAPI,
 		healthAPI,
 	}
 )
@@ -91,6 +94,8 @@ func (v *vtorcAPI) ServeHTTP(response http.ResponseWriter, request *http.Request
 		databaseStateAPIHandler(response)
 	case configAPI:
 		configAPIHandler(response)
+	case witnessAPI:
+		witnessAPIHandler(response, request)
 	default:
 		// This should be unreachable. Any endpoint which isn't registered is automatically redirected to /debug/status.
 		// This code will only be reachable if we register an API but don't handle it here. That will be a bug.
@@ -107,7 +112,7 @@ func getACLPermissionLevelForAPI(apiEndpoint string) string {
 		return acl.ADMIN
 	case detectionAnalysisAPI, replicationAnalysisAPI, configAPI:
 		return acl.MONITORING
-	case healthAPI, databaseStateAPI:
+	case healthAPI, databaseStateAPI, witnessAPI:
 		return acl.MONITORING
 	}
 	return acl.ADMIN
@@ -230,6 +235,29 @@ func detectionAnalysisAPIHandler(response http.ResponseWriter, request *http.Req
 	returnAsJSON(response, http.StatusOK, analysis)
 }
 
+// witnessAPIHandler is the handler for the witnessAPI endpoint, through which another VTOrc
+// asks this one whether it can reach a primary before failing it over.
+func witnessAPIHandler(response http.ResponseWriter, request *http.Request) {
+	tabletAlias := request.URL.Query().Get("tablet")
+	if tabletAlias == "" {
+		http.Error(response, "Tablet alias is required", http.StatusBadRequest)
+		return
+	}
+	reachable, err := logic.CheckTabletReachable(tabletAlias)
+	if errors.Is(err, inst.ErrTabletAliasNil) {
+		http.Error(response, fmt.Sprintf("Tablet %s is not known to this VTOrc", tabletAlias), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	returnAsJSON(response, http.StatusOK, &logic.WitnessResponse{
+		TabletAlias: tabletAlias,
+		Reachable:   reachable,
+	})
+}
+
 // healthAPIHandler is the handler for the healthAPI endpoint
 func healthAPIHandler(response http.ResponseWriter, request *http.Request) {
 	health, discoveredOnce := process.HealthTest()
@@ -37,6 +37,9 @@ func TestGetACLPermissionLevelForAPI(t *testing.T) {
 		}, {
 			apiEndpoint: configAPI,
 			want:        acl.MONITORING,
+		}, {
+			apiEndpoint: witnessAPI,
+			want:        acl.MONITORING,
 		}, {
 			apiEndpoint: "gibberish",
 			want:        acl.ADMIN,
@@ -51,6 +51,19 @@ type VReplicationConfig struct {
 	ParallelInsertWorkers   int
 	TabletTypesStr          string
 	EnableHttpLog           bool // Enable the /debug/vrlog endpoint
+	// TargetSessionVariables is a comma separated list of session and user variable assignments, in the format of a
+	// SET statement, e.g. "sql_mode='STRICT_TRANS_TABLES', @skip_audit=1", which are applied on the connections used
+	// to write to the target. Only an allowlist of session variables can be set, as the connections are dba ones.
+	TargetSessionVariables string
+	// DisableTargetTriggers sets the @vreplication_disable_triggers user variable on the connections used to write to
+	// the target, so that triggers on the target tables which check it can skip the rows written by the workflow.
+	DisableTargetTriggers bool
+	// CopyPhaseMaxReplicationLag throttles the copy phase while the replication lag checked by the tablet throttler
+	// is above it, when it is lower than the threshold of the throttler. Zero leaves the threshold of the throttler.
+	CopyPhaseMaxReplicationLag time.Duration
+	// CopyPhaseMaxRowsPerSecond is the maximum number of rows copied per second by a stream in the copy phase. Zero
+	// means no limit.
+	CopyPhaseMaxRowsPerSecond int64
 
 	// Config parameters applicable to the source side (vstreamer)
 	// The coresponding Override fields are used to determine if the user has provided a value for the parameter so
@@ -220,6 +233,29 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 			} else {
 				c.ParallelInsertWorkers = value
 			}
+		case "vreplication-target-session-variables":
+			c.TargetSessionVariables = v
+		case "vreplication-disable-target-triggers":
+			value, err := strconv.ParseBool(v)
+			if err != nil {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.DisableTargetTriggers = value
+			}
+		case "vreplication-copy-phase-max-replication-lag":
+			value, err := time.ParseDuration(v)
+			if err != nil || value < 0 {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.CopyPhaseMaxReplicationLag = value
+			}
+		case "vreplication-copy-phase-max-rows-per-second":
+			value, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || value < 0 {
+				errors = append(errors, getError(k, v))
+			} else {
+				c.CopyPhaseMaxRowsPerSecond = value
+			}
 		case "vstream-packet-size", "vstream_packet_size":
 			value, err := strconv.Atoi(v)
 			if err != nil {
@@ -259,25 +295,29 @@ func NewVReplicationConfig(overrides map[string]string) (*VReplicationConfig, er
 // keys are one of those that are supported.
 func (c VReplicationConfig) Map() map[string]string {
 	return map[string]string{
-		"vreplication-experimental-flags":         strconv.FormatInt(c.ExperimentalFlags, 10),
-		"vreplication-net-read-timeout":           strconv.Itoa(c.NetReadTimeout),
-		"vreplication-net-write-timeout":          strconv.Itoa(c.NetWriteTimeout),
-		"vreplication-copy-phase-duration":        c.CopyPhaseDuration.String(),
-		"vreplication-retry-delay":                c.RetryDelay.String(),
-		"vreplication-max-time-to-retry-on-error": c.MaxTimeToRetryError.String(),
-		"relay-log-max-size":                      strconv.Itoa(c.RelayLogMaxSize),
-		"relay_log_max_size":                      strconv.Itoa(c.RelayLogMaxSize),
-		"relay-log-max-items":                     strconv.Itoa(c.RelayLogMaxItems),
-		"relay_log_max_items":                     strconv.Itoa(c.RelayLogMaxItems),
-		"vreplication-replica-lag-tolerance":      c.ReplicaLagTolerance.String(),
-		"vreplication-heartbeat-update-interval":  strconv.Itoa(c.HeartbeatUpdateInterval),
-		"vreplication-store-compressed-gtid":      strconv.FormatBool(c.StoreCompressedGTID),
-		"vreplication-parallel-insert-workers":    strconv.Itoa(c.ParallelInsertWorkers),
-		"vstream-packet-size":                     strconv.Itoa(c.VStreamPacketSize),
-		"vstream_packet_size":                     strconv.Itoa(c.VStreamPacketSize),
-		"vstream-dynamic-packet-size":             strconv.FormatBool(c.VStreamDynamicPacketSize),
-		"vstream_dynamic_packet_size":             strconv.FormatBool(c.VStreamDynamicPacketSize),
-		"vstream_binlog_rotation_threshold":       strconv.FormatInt(c.VStreamBinlogRotationThreshold, 10),
+		"vreplication-experimental-flags":             strconv.FormatInt(c.ExperimentalFlags, 10),
+		"vreplication-net-read-timeout":               strconv.Itoa(c.NetReadTimeout),
+		"vreplication-net-write-timeout":              strconv.Itoa(c.NetWriteTimeout),
+		"vreplication-copy-phase-duration":            c.CopyPhaseDuration.String(),
+		"vreplication-retry-delay":                    c.RetryDelay.String(),
+		"vreplication-max-time-to-retry-on-error":     c.MaxTimeToRetryError.String(),
+		"relay-log-max-size":                          strconv.Itoa(c.RelayLogMaxSize),
+		"relay_log_max_size":                          strconv.Itoa(c.RelayLogMaxSize),
+		"relay-log-max-items":                         strconv.Itoa(c.RelayLogMaxItems),
+		"relay_log_max_items":                         strconv.Itoa(c.RelayLogMaxItems),
+		"vreplication-replica-lag-tolerance":          c.ReplicaLagTolerance.String(),
+		"vreplication-heartbeat-update-interval":      strconv.Itoa(c.HeartbeatUpdateInterval),
+		"vreplication-store-compressed-gtid":          strconv.FormatBool(c.StoreCompressedGTID),
+		"vreplication-parallel-insert-workers":        strconv.Itoa(c.ParallelInsertWorkers),
+		"vreplication-target-session-variables":       c.TargetSessionVariables,
+		"vreplication-disable-target-triggers":        strconv.FormatBool(c.DisableTargetTriggers),
+		"vreplication-copy-phase-max-replication-lag": c.CopyPhaseMaxReplicationLag.String(),
+		"vreplication-copy-phase-max-rows-per-second": strconv.FormatInt(c.CopyPhaseMaxRowsPerSecond, 10),
+		"vstream-packet-size":                         strconv.Itoa(c.VStreamPacketSize),
+		"vstream_packet_size":                         strconv.Itoa(c.VStreamPacketSize),
+		"vstream-dynamic-packet-size":                 strconv.FormatBool(c.VStreamDynamicPacketSize),
+		"vstream_dynamic_packet_size":                 strconv.FormatBool(c.VStreamDynamicPacketSize),
+		"vstream_binlog_rotation_threshold":           strconv.FormatInt(c.VStreamBinlogRotationThreshold, 10),
 	}
 }
 
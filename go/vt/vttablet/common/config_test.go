@@ -50,6 +50,10 @@ func TestNewVReplicationConfig(t *testing.T) {
 				"vreplication-heartbeat-update-interval":            "2",
 				"vreplication-store-compressed-gtid":                "true",
 				"vreplication-parallel-insert-workers":              "4",
+				"vreplication-target-session-variables":             "@skip_audit=1",
+				"vreplication-disable-target-triggers":              "true",
+				"vreplication-copy-phase-max-replication-lag":       "5s",
+				"vreplication-copy-phase-max-rows-per-second":       "1000",
 				"vstream-packet-size":                               "1024",
 				"vstream_packet_size":                               "1024",
 				"vstream-dynamic-packet-size":                       "false",
@@ -70,6 +74,10 @@ func TestNewVReplicationConfig(t *testing.T) {
 				HeartbeatUpdateInterval:                2,
 				StoreCompressedGTID:                    true,
 				ParallelInsertWorkers:                  4,
+				TargetSessionVariables:                 "@skip_audit=1",
+				DisableTargetTriggers:                  true,
+				CopyPhaseMaxReplicationLag:             5 * time.Second,
+				CopyPhaseMaxRowsPerSecond:              1000,
 				VStreamPacketSize:                      1024,
 				VStreamDynamicPacketSize:               false,
 				VStreamBinlogRotationThreshold:         2048,
@@ -94,13 +102,16 @@ func TestNewVReplicationConfig(t *testing.T) {
 				"vreplication-heartbeat-update-interval":            "invalid",
 				"vreplication-store-compressed-gtid":                "nottrue",
 				"vreplication-parallel-insert-workers":              "invalid",
+				"vreplication-disable-target-triggers":              "invalid",
+				"vreplication-copy-phase-max-replication-lag":       "-1s",
+				"vreplication-copy-phase-max-rows-per-second":       "invalid",
 				"vstream-packet-size":                               "invalid",
 				"vstream_packet_size":                               "invalid",
 				"vstream-dynamic-packet-size":                       "waar",
 				"vstream_dynamic_packet_size":                       "waar",
 				"vstream_binlog_rotation_threshold":                 "invalid",
 			},
-			wantErr: 17,
+			wantErr: 20,
 		},
 		{
 			name: "Partial values",
@@ -240,6 +240,16 @@ func (client *FakeTabletManagerClient) MysqlHostMetrics(ctx context.Context, tab
 	return nil, nil
 }
 
+// GetMysqlErrorLogEvents is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetMysqlErrorLogEvents(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error) {
+	return &tabletmanagerdatapb.GetMysqlErrorLogEventsResponse{}, nil
+}
+
+// GetQueryPlans is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) GetQueryPlans(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	return &tabletmanagerdatapb.GetQueryPlansResponse{}, nil
+}
+
 // ReadTransaction is part of the tmclient.TabletManagerClient interface.
 func (client *FakeTabletManagerClient) ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error) {
 	return nil, nil
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcqueryservice
+
+import (
+	"context"
+	"slices"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+var (
+	// resultCompression is the compressor used for the large query results
+	// sent to vtgate. An empty value disables the compression.
+	resultCompression string
+	// resultCompressionMinRows and resultCompressionMinBytes are the
+	// thresholds above which a result is compressed, as compressing small
+	// results costs more CPU than it saves bandwidth.
+	resultCompressionMinRows  = 1000
+	resultCompressionMinBytes = 64 * 1024
+	// streamResultCompression enables the compression of the streams of
+	// results sent to vtgate. The size of a stream is not known when it
+	// starts, so all its messages are compressed.
+	streamResultCompression bool
+
+	resultCompressionCount = stats.NewCountersWithSingleLabel(
+		"QueryResultCompression",
+		"Number of query results sent to vtgate, by whether they were compressed",
+		"Compression")
+	streamResultCompressionCount = stats.NewCountersWithSingleLabel(
+		"QueryStreamResultCompression",
+		"Number of streams of query results sent to vtgate, by whether they were compressed",
+		"Compression")
+)
+
+func init() {
+	servenv.OnParseFor("vttablet", registerCompressionFlags)
+}
+
+func registerCompressionFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&resultCompression, "grpc-result-compression", resultCompression, "Compressor used for the query results sent to vtgate which are larger than the thresholds, if vtgate supports it. Supported: snappy, zstd, lz4. Empty disables the compression.")
+	fs.IntVar(&resultCompressionMinRows, "grpc-result-compression-min-rows", resultCompressionMinRows, "Minimum number of rows of a query result for it to be compressed with --grpc-result-compression.")
+	fs.IntVar(&resultCompressionMinBytes, "grpc-result-compression-min-bytes", resultCompressionMinBytes, "Minimum size in bytes of a query result for it to be compressed with --grpc-result-compression.")
+	fs.BoolVar(&streamResultCompression, "grpc-stream-result-compression", streamResultCompression, "Also compress the streams of query results sent to vtgate with --grpc-result-compression, if vtgate supports it. All the messages of a stream are compressed, regardless of the thresholds.")
+}
+
+// shouldCompressResult returns whether the result is large enough to be
+// worth compressing.
+func shouldCompressResult(result *querypb.QueryResult) bool {
+	if result == nil {
+		return false
+	}
+	return len(result.Rows) >= resultCompressionMinRows || result.SizeVT() >= resultCompressionMinBytes
+}
+
+// maybeCompressResult compresses the response of a unary call when it
+// carries a large result and the client advertised the configured compressor.
+func maybeCompressResult(ctx context.Context, result *querypb.QueryResult) {
+	if resultCompression == "" {
+		return
+	}
+	if !shouldCompressResult(result) {
+		resultCompressionCount.Add("None", 1)
+		return
+	}
+	resultCompressionCount.Add(setResultCompressor(ctx), 1)
+}
+
+// maybeCompressStream compresses all the messages of a stream of results
+// when the client advertised the configured compressor. It must be called
+// before the first message is sent.
+func maybeCompressStream(ctx context.Context) {
+	if resultCompression == "" || !streamResultCompression {
+		return
+	}
+	streamResultCompressionCount.Add(setResultCompressor(ctx), 1)
+}
+
+// setResultCompressor compresses the response of the call with the
+// configured compressor if the client advertised it in the accepted
+// encodings of the call, so that the compression is negotiated with every
+// vtgate. It returns the compressor used, or "Unsupported".
+func setResultCompressor(ctx context.Context) string {
+	if encoding.GetCompressor(resultCompression) == nil {
+		return "Unsupported"
+	}
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(supported, resultCompression) {
+		return "Unsupported"
+	}
+	if err := grpc.SetSendCompressor(ctx, resultCompression); err != nil {
+		log.Warningf("failed to compress the query result with %s: %v", resultCompression, err)
+		return "Unsupported"
+	}
+	return resultCompression
+}
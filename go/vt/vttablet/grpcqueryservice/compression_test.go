@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcqueryservice
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vttablet/queryservice"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	queryservicepb "vitess.io/vitess/go/vt/proto/queryservice"
+
+	// Registers the compressors.
+	_ "vitess.io/vitess/go/vt/grpcclient"
+)
+
+func TestShouldCompressResult(t *testing.T) {
+	oldRows, oldBytes := resultCompressionMinRows, resultCompressionMinBytes
+	defer func() {
+		resultCompressionMinRows, resultCompressionMinBytes = oldRows, oldBytes
+	}()
+	resultCompressionMinRows = 10
+	resultCompressionMinBytes = 1024
+
+	rows := func(count int, value string) *querypb.QueryResult {
+		qr := &querypb.QueryResult{}
+		for range count {
+			qr.Rows = append(qr.Rows, &querypb.Row{Lengths: []int64{int64(len(value))}, Values: []byte(value)})
+		}
+		return qr
+	}
+
+	assert.False(t, shouldCompressResult(nil))
+	assert.False(t, shouldCompressResult(rows(9, "a")))
+	assert.True(t, shouldCompressResult(rows(10, "a")))
+	assert.True(t, shouldCompressResult(rows(1, strings.Repeat("a", 1024))))
+}
+
+// streamingQueryService streams the same result for every query.
+type streamingQueryService struct {
+	queryservice.QueryService
+	result *sqltypes.Result
+}
+
+func (s *streamingQueryService) StreamExecute(ctx context.Context, session queryservice.Session, target *querypb.Target, sql string, bindVariables map[string]*querypb.BindVariable, transactionID int64, reservedID int64, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) error {
+	return callback(s.result)
+}
+
+func (s *streamingQueryService) HandlePanic(err *error) {}
+
+func TestStreamResultCompression(t *testing.T) {
+	oldCompression, oldStream := resultCompression, streamResultCompression
+	defer func() {
+		resultCompression, streamResultCompression = oldCompression, oldStream
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	result := sqltypes.MakeTestResult(sqltypes.MakeTestFields("id|name", "int64|varchar"), "1|"+strings.Repeat("a", 1024))
+	Register(server, &streamingQueryService{result: result})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := queryservicepb.NewQueryClient(conn)
+
+	streamExecute := func() {
+		stream, err := client.StreamExecute(t.Context(), &querypb.StreamExecuteRequest{Query: &querypb.BoundQuery{Sql: "select * from t1"}})
+		require.NoError(t, err)
+		reply, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, result, sqltypes.Proto3ToResult(reply.Result))
+		_, err = stream.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+	}
+
+	// The streams are only compressed with --grpc-stream-result-compression,
+	// and with a compressor advertised by the client.
+	resultCompression = "lz4"
+	counts := streamResultCompressionCount.Counts()
+	streamExecute()
+	streamResultCompression = true
+	streamExecute()
+	resultCompression = "unknown"
+	streamExecute()
+	assert.EqualValues(t, 1, streamResultCompressionCount.Counts()["lz4"]-counts["lz4"])
+	assert.EqualValues(t, 1, streamResultCompressionCount.Counts()["Unsupported"]-counts["Unsupported"])
+}
@@ -52,14 +52,17 @@ func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (r
 	if err != nil {
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	maybeCompressResult(ctx, qr)
 	return &querypb.ExecuteResponse{
-		Result: sqltypes.ResultToProto3(result),
+		Result: qr,
 	}, nil
 }
 
 // StreamExecute is part of the queryservice.QueryServer interface
 func (q *query) StreamExecute(request *querypb.StreamExecuteRequest, stream queryservicepb.Query_StreamExecuteServer) (err error) {
 	defer q.server.HandlePanic(&err)
+	maybeCompressStream(stream.Context())
 	ctx := callerid.NewContext(callinfo.GRPCCallInfo(stream.Context()),
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
@@ -264,8 +267,10 @@ func (q *query) BeginExecute(ctx context.Context, request *querypb.BeginExecuteR
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	maybeCompressResult(ctx, qr)
 	return &querypb.BeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		TabletAlias:         state.TabletAlias,
 		SessionStateChanges: state.SessionStateChanges,
@@ -275,6 +280,7 @@ func (q *query) BeginExecute(ctx context.Context, request *querypb.BeginExecuteR
 // BeginStreamExecute is part of the queryservice.QueryServer interface
 func (q *query) BeginStreamExecute(request *querypb.BeginStreamExecuteRequest, stream queryservicepb.Query_BeginStreamExecuteServer) (err error) {
 	defer q.server.HandlePanic(&err)
+	maybeCompressStream(stream.Context())
 	ctx := callerid.NewContext(callinfo.GRPCCallInfo(stream.Context()),
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
@@ -404,8 +410,10 @@ func (q *query) ReserveExecute(ctx context.Context, request *querypb.ReserveExec
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	maybeCompressResult(ctx, qr)
 	return &querypb.ReserveExecuteResponse{
-		Result:      sqltypes.ResultToProto3(result),
+		Result:      qr,
 		ReservedId:  state.ReservedID,
 		TabletAlias: state.TabletAlias,
 	}, nil
@@ -414,6 +422,7 @@ func (q *query) ReserveExecute(ctx context.Context, request *querypb.ReserveExec
 // ReserveStreamExecute is part of the queryservice.QueryServer interface
 func (q *query) ReserveStreamExecute(request *querypb.ReserveStreamExecuteRequest, stream queryservicepb.Query_ReserveStreamExecuteServer) (err error) {
 	defer q.server.HandlePanic(&err)
+	maybeCompressStream(stream.Context())
 	ctx := callerid.NewContext(callinfo.GRPCCallInfo(stream.Context()),
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
@@ -458,8 +467,10 @@ func (q *query) ReserveBeginExecute(ctx context.Context, request *querypb.Reserv
 		}
 		return nil, vterrors.ToGRPC(err)
 	}
+	qr := sqltypes.ResultToProto3(result)
+	maybeCompressResult(ctx, qr)
 	return &querypb.ReserveBeginExecuteResponse{
-		Result:              sqltypes.ResultToProto3(result),
+		Result:              qr,
 		TransactionId:       state.TransactionID,
 		ReservedId:          state.ReservedID,
 		TabletAlias:         state.TabletAlias,
@@ -470,6 +481,7 @@ func (q *query) ReserveBeginExecute(ctx context.Context, request *querypb.Reserv
 // ReserveBeginStreamExecute is part of the queryservice.QueryServer interface
 func (q *query) ReserveBeginStreamExecute(request *querypb.ReserveBeginStreamExecuteRequest, stream queryservicepb.Query_ReserveBeginStreamExecuteServer) (err error) {
 	defer q.server.HandlePanic(&err)
+	maybeCompressStream(stream.Context())
 	ctx := callerid.NewContext(callinfo.GRPCCallInfo(stream.Context()),
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
@@ -811,6 +811,36 @@ func (client *Client) MysqlHostMetrics(ctx context.Context, tablet *topodatapb.T
 	return resp, nil
 }
 
+// GetMysqlErrorLogEvents is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetMysqlErrorLogEvents(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	resp, err := c.GetMysqlErrorLogEvents(ctx, req)
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return resp, nil
+}
+
+// GetQueryPlans is part of the tmclient.TabletManagerClient interface.
+func (client *Client) GetQueryPlans(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	c, closer, err := client.dialer.dial(ctx, tablet)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	resp, err := c.GetQueryPlans(ctx, req)
+	if err != nil {
+		return nil, vterrors.FromGRPC(err)
+	}
+	return resp, nil
+}
+
 // ReadTransaction is part of the tmclient.TabletManagerClient interface.
 func (client *Client) ReadTransaction(ctx context.Context, tablet *topodatapb.Tablet, dtid string) (*querypb.TransactionMetadata, error) {
 	c, closer, err := client.dialer.dial(ctx, tablet)
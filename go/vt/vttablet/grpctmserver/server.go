@@ -347,6 +347,28 @@ func (s *server) MysqlHostMetrics(ctx context.Context, request *tabletmanagerdat
 	return resp, nil
 }
 
+func (s *server) GetMysqlErrorLogEvents(ctx context.Context, request *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (response *tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetMysqlErrorLogEvents", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+
+	resp, err := s.tm.GetMysqlErrorLogEvents(ctx, request)
+	if err != nil {
+		return nil, vterrors.ToGRPC(err)
+	}
+	return resp, nil
+}
+
+func (s *server) GetQueryPlans(ctx context.Context, request *tabletmanagerdatapb.GetQueryPlansRequest) (response *tabletmanagerdatapb.GetQueryPlansResponse, err error) {
+	defer s.tm.HandleRPCPanic(ctx, "GetQueryPlans", request, response, false /*verbose*/, &err)
+	ctx = callinfo.GRPCCallInfo(ctx)
+
+	resp, err := s.tm.GetQueryPlans(ctx, request)
+	if err != nil {
+		return nil, vterrors.ToGRPC(err)
+	}
+	return resp, nil
+}
+
 //
 // Replication related methods
 //
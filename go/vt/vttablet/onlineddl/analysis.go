@@ -32,6 +32,7 @@ type specialAlterOperation string
 
 const (
 	instantDDLSpecialOperation     specialAlterOperation = "instant-ddl"
+	inplaceDDLSpecialOperation     specialAlterOperation = "inplace-ddl"
 	rangePartitionSpecialOperation specialAlterOperation = "range-partition"
 )
 
@@ -82,6 +83,20 @@ func analyzeInstantDDL(alterTable *sqlparser.AlterTable, createTable *sqlparser.
 	return op, nil
 }
 
+// analyzeInplaceDDL takes declarative CreateTable and AlterTable, and checks whether it is possible to run the ALTER
+// using ALGORITHM=INPLACE, LOCK=NONE, as a metadata-only change that does not rebuild the table.
+func analyzeInplaceDDL(alterTable *sqlparser.AlterTable, createTable *sqlparser.CreateTable) (*SpecialAlterPlan, error) {
+	capable, err := schemadiff.AlterTableCapableOfInplaceMetadataDDL(alterTable, createTable)
+	if err != nil {
+		return nil, err
+	}
+	if !capable {
+		return nil, nil
+	}
+	op := NewSpecialAlterOperation(inplaceDDLSpecialOperation, alterTable, createTable)
+	return op, nil
+}
+
 // analyzeSpecialAlterPlan checks if the given ALTER onlineDDL, and for the current state of affected table,
 // can be executed in a special way. If so, it returns with a "special plan"
 func (e *Executor) analyzeSpecialAlterPlan(ctx context.Context, onlineDDL *schema.OnlineDDL, capableOf capabilities.CapableOf) (*SpecialAlterPlan, error) {
@@ -132,5 +147,14 @@ func (e *Executor) analyzeSpecialAlterPlan(ctx context.Context, onlineDDL *schem
 			return op, nil
 		}
 	}
+	if onlineDDL.StrategySetting().IsPreferInplaceDDL() {
+		op, err := analyzeInplaceDDL(alterTable, createTable)
+		if err != nil {
+			return nil, err
+		}
+		if op != nil {
+			return op, nil
+		}
+	}
 	return nil, nil
 }
@@ -238,3 +238,50 @@ func TestAnalyzeInstantDDL(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzeInplaceDDL(t *testing.T) {
+	tt := []struct {
+		create  string
+		alter   string
+		inplace bool
+	}{
+		{
+			create:  "create table t(id int, i1 int, primary key(id), key i1_idx(i1))",
+			alter:   "alter table t rename index i1_idx to i1_idx2",
+			inplace: true,
+		},
+		{
+			create:  "create table t(id int, c1 varchar(10), primary key(id)) charset utf8mb4",
+			alter:   "alter table t modify column c1 varchar(20)",
+			inplace: true,
+		},
+		{
+			create:  "create table t(id int, i1 int, primary key(id))",
+			alter:   "alter table t modify column i1 bigint",
+			inplace: false,
+		},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tc := range tt {
+		t.Run(tc.alter, func(t *testing.T) {
+			stmt, err := parser.ParseStrictDDL(tc.create)
+			require.NoError(t, err)
+			createTable, ok := stmt.(*sqlparser.CreateTable)
+			require.True(t, ok)
+
+			stmt, err = parser.ParseStrictDDL(tc.alter)
+			require.NoError(t, err)
+			alterTable, ok := stmt.(*sqlparser.AlterTable)
+			require.True(t, ok)
+
+			plan, err := analyzeInplaceDDL(alterTable, createTable)
+			assert.NoError(t, err)
+			if tc.inplace {
+				require.NotNil(t, plan)
+				assert.Equal(t, inplaceDDLSpecialOperation, plan.operation)
+			} else {
+				require.Nil(t, plan)
+			}
+		})
+	}
+}
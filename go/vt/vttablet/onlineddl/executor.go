@@ -60,6 +60,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/base"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
 	"vitess.io/vitess/go/vt/vttablet/tmclient"
 
@@ -79,6 +80,7 @@ var (
 )
 
 var staleMigrationMinutesStats = stats.NewGauge("OnlineDDLStaleMigrationMinutes", "longest stale migration in minutes")
+var autoRevertedMigrationsStats = stats.NewCounter("OnlineDDLAutoRevertedMigrations", "number of migrations automatically reverted due to --auto-revert-on-* thresholds")
 
 var (
 	emptyResult                           = &sqltypes.Result{}
@@ -93,6 +95,13 @@ var (
 
 	migrationNextCheckIntervals = []time.Duration{1 * time.Second, 5 * time.Second, 10 * time.Second, 20 * time.Second}
 	cutoverIntervals            = []time.Duration{0, 1 * time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute}
+
+	// The INSTANT and INPLACE fast paths are postponed for this long while the throttler refuses the ALTER
+	// before falling back to a full migration, and then wait this long for the replicas to catch up with
+	// the ALTER.
+	fastPathThrottleWaitTimeout   = 30 * time.Second
+	fastPathReplicaVerifyTimeout  = 1 * time.Minute
+	fastPathThrottleCheckInterval = 1 * time.Second
 )
 
 const (
@@ -153,10 +162,22 @@ type Executor struct {
 	// - be adopted by this executor (possible for vreplication migrations), or
 	// - be terminated
 	// The Executor auto-reviews the map and cleans up migrations thought to be running which are not running.
-	ownedRunningMigrations        sync.Map
+	ownedRunningMigrations sync.Map
+	// rowValidations maps UUIDs of migrations using --require-row-validation to their validation result (error or nil).
+	// A migration is validated once per executor lifetime.
+	rowValidations                sync.Map
 	vreplicationLastError         map[string]*vterrors.LastError
 	tickReentranceFlag            int64
 	reviewedRunningMigrationsFlag bool
+	// fastPathThrottledSince maps UUIDs of migrations postponed by the throttler on the INSTANT or
+	// INPLACE fast path to when they were first throttled. It is guarded by migrationMutex.
+	fastPathThrottledSince map[string]time.Time
+
+	// tableErrorCountFunc returns the number of the failed queries on a table
+	tableErrorCountFunc func(tableName string) int64
+	// queryErrorSamples are the last samples of the query error counts of the tables of the auto-revert
+	// candidate migrations, used to compute their error rates
+	queryErrorSamples map[string]queryErrorSample
 
 	ticks  *timer.Timer
 	isOpen int64
@@ -204,6 +225,7 @@ func NewExecutor(env tabletenv.Env, tabletAlias *topodatapb.TabletAlias, ts *top
 	toggleBufferTableFunc func(cancelCtx context.Context, tableName string, timeout time.Duration, bufferQueries bool),
 	requestGCChecksFunc func(),
 	isPreparedPoolEmpty func(tableName string) bool,
+	tableErrorCountFunc func(tableName string) int64,
 ) *Executor {
 	// sanitize flags
 	if maxConcurrentOnlineDDLs < 1 {
@@ -223,7 +245,11 @@ func NewExecutor(env tabletenv.Env, tabletAlias *topodatapb.TabletAlias, ts *top
 		toggleBufferTableFunc: toggleBufferTableFunc,
 		isPreparedPoolEmpty:   isPreparedPoolEmpty,
 		requestGCChecksFunc:   requestGCChecksFunc,
+		tableErrorCountFunc:   tableErrorCountFunc,
 		ticks:                 timer.NewTimer(migrationCheckInterval),
+
+		fastPathThrottledSince: make(map[string]time.Time),
+		queryErrorSamples:      make(map[string]queryErrorSample),
 		// Gracefully return an error if any caller tries to execute
 		// a query before the executor has been fully opened.
 		execQuery: func(ctx context.Context, query string) (result *sqltypes.Result, err error) {
@@ -1157,6 +1183,7 @@ func (e *Executor) cutOverVReplMigration(ctx context.Context, s *VReplStream, sh
 	}
 	e.updateMigrationStage(ctx, onlineDDL.UUID, "cut-over complete")
 	e.ownedRunningMigrations.Delete(onlineDDL.UUID)
+	e.rowValidations.Delete(onlineDDL.UUID)
 
 	go func() {
 		// Tables are swapped! Let's take the opportunity to ReloadSchema now
@@ -1555,6 +1582,7 @@ func (e *Executor) terminateMigration(ctx context.Context, onlineDDL *schema.Onl
 	// the logic will retry killing it later on.
 	// Whatever happens in this function, this executor stops owning the given migration.
 	defer e.ownedRunningMigrations.Delete(onlineDDL.UUID)
+	defer e.rowValidations.Delete(onlineDDL.UUID)
 
 	switch onlineDDL.Strategy {
 	case schema.DDLStrategyOnline, schema.DDLStrategyVitess:
@@ -1753,6 +1781,14 @@ func (e *Executor) scheduleNextMigration(ctx context.Context) error {
 			// We don't even look into this migration until its postpone_launch flag is cleared
 			continue
 		}
+		strategySetting := schema.NewDDLStrategySetting(schema.DDLStrategy(row["strategy"].ToString()), row["options"].ToString())
+		if inWindow, reason := strategySetting.IsInExecutionWindow(time.Now()); !inWindow {
+			// --execute-after or --maintenance-window: the migration may not start yet
+			if row["message"].ToString() != reason {
+				_ = e.updateMigrationMessage(ctx, uuid, reason)
+			}
+			continue
+		}
 
 		if !readyToComplete {
 			// see if we need to update ready_to_complete
@@ -2603,7 +2639,8 @@ func (e *Executor) executeSpecialAlterDirectDDLActionMigration(ctx context.Conte
 }
 
 // executeSpecialAlterDDLActionMigrationIfApplicable sees if the given migration can be executed via special execution path, that isn't a full blown online schema change process.
-func (e *Executor) executeSpecialAlterDDLActionMigrationIfApplicable(ctx context.Context, onlineDDL *schema.OnlineDDL) (specialMigrationExecuted bool, err error) {
+// It returns handled when the migration was executed via a special path, or postponed to do so.
+func (e *Executor) executeSpecialAlterDDLActionMigrationIfApplicable(ctx context.Context, onlineDDL *schema.OnlineDDL) (handled bool, err error) {
 	// Before we jump on to strategies... Some ALTERs can be optimized without having to run through
 	// a full online schema change process. Let's find out if this is the case!
 	conn, err := dbconnpool.NewDBConnection(ctx, e.env.Config().DB.DbaWithDB())
@@ -2617,17 +2654,40 @@ func (e *Executor) executeSpecialAlterDDLActionMigrationIfApplicable(ctx context
 	if err != nil {
 		return false, err
 	}
+	if onlineDDL.StrategySetting().IsPreferInstantDDL() && (specialPlan == nil || specialPlan.operation == inplaceDDLSpecialOperation) {
+		runAs := "a full migration"
+		if specialPlan != nil {
+			runAs = string(specialPlan.operation)
+		}
+		_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, "INSTANT DDL is not possible, running as "+runAs)
+	}
 	if specialPlan == nil {
 		return false, nil
 	}
 
 	switch specialPlan.operation {
-	case instantDDLSpecialOperation:
-		schemadiff.AddInstantAlgorithm(specialPlan.alterTable)
+	case instantDDLSpecialOperation, inplaceDDLSpecialOperation:
+		// These are optimizations over a full migration, which we happily fall back to if the
+		// shard is too busy to take the ALTER for a while.
+		postpone, throttled := e.fastPathThrottled(ctx, onlineDDL)
+		if postpone {
+			// The migration stays ready, and runs on a next check.
+			return true, nil
+		}
+		if throttled {
+			_ = e.updateMigrationMessage(ctx, onlineDDL.UUID, fmt.Sprintf("throttled for over %v, running %s as a full migration", fastPathThrottleWaitTimeout, specialPlan.operation))
+			return false, nil
+		}
+		if specialPlan.operation == instantDDLSpecialOperation {
+			schemadiff.AddInstantAlgorithm(specialPlan.alterTable)
+		} else {
+			schemadiff.AddInplaceAlgorithm(specialPlan.alterTable)
+		}
 		onlineDDL.SQL = sqlparser.CanonicalString(specialPlan.alterTable)
 		if err := e.executeSpecialAlterDirectDDLActionMigration(ctx, onlineDDL); err != nil {
 			return false, err
 		}
+		specialPlan.SetDetail("replicas-verified", "pending")
 	case rangePartitionSpecialOperation:
 		if err := e.executeSpecialAlterDirectDDLActionMigration(ctx, onlineDDL); err != nil {
 			return false, err
@@ -2639,6 +2699,10 @@ func (e *Executor) executeSpecialAlterDDLActionMigrationIfApplicable(ctx context
 		return true, err
 	}
 	_ = e.onSchemaMigrationStatus(ctx, onlineDDL.UUID, schema.OnlineDDLStatusComplete, false, progressPctFull, etaSecondsNow, rowsCopiedUnknown, emptyHint)
+	if specialPlan.operation != rangePartitionSpecialOperation {
+		// The migration is complete, so the replicas are verified without holding up the executor.
+		go e.verifyFastPathReplicas(onlineDDL, specialPlan)
+	}
 	return true, nil
 }
 
@@ -2674,11 +2738,11 @@ func (e *Executor) executeAlterDDLActionMigration(ctx context.Context, onlineDDL
 
 	// Before we jump on to strategies... Some ALTERs can be optimized without having to run through
 	// a full online schema change process. Let's find out if this is the case!
-	specialMigrationExecuted, err := e.executeSpecialAlterDDLActionMigrationIfApplicable(ctx, onlineDDL)
+	specialMigrationHandled, err := e.executeSpecialAlterDDLActionMigrationIfApplicable(ctx, onlineDDL)
 	if err != nil {
 		return failMigration(err)
 	}
-	if specialMigrationExecuted {
+	if specialMigrationHandled {
 		return nil
 	}
 
@@ -3314,6 +3378,36 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 					// override. Even if migration is ready, we do not complete it.
 					return nil
 				}
+				if inWindow, reason := strategySetting.IsInExecutionWindow(time.Now()); !inWindow && !shouldForceCutOver {
+					// --execute-after or --maintenance-window: cut-over is only allowed within the window,
+					// unless explicitly forced by the user.
+					if migrationRow["message"].ToString() != reason {
+						_ = e.updateMigrationMessage(ctx, uuid, reason)
+					}
+					return nil
+				}
+				if strategySetting.IsRequireRowValidation() && !shouldForceCutOver {
+					// --require-row-validation: cut-over is blocked until rows are validated. A forced cut-over
+					// overrides the validation.
+					result, validated := e.rowValidations.Load(uuid)
+					if !validated {
+						mismatch, err := e.validateVReplMigrationRows(ctx, s)
+						if err != nil {
+							// Validation could not complete, e.g. due to lock timeout. We will try again on next review.
+							log.Errorf("validateVReplMigrationRows failed %s: err=%v", uuid, err)
+							_ = e.updateMigrationMessage(ctx, uuid, err.Error())
+							return nil
+						}
+						e.rowValidations.Store(uuid, mismatch)
+						result = mismatch
+					}
+					if mismatch, _ := result.(error); mismatch != nil {
+						if migrationRow["message"].ToString() != mismatch.Error() {
+							_ = e.updateMigrationMessage(ctx, uuid, mismatch.Error())
+						}
+						return nil
+					}
+				}
 				shouldCutOver, shouldForceCutOver := shouldCutOverAccordingToBackoff(
 					shouldForceCutOver, forceCutOverAfter, sinceReadyToComplete, sinceLastCutoverAttempt, cutoverAttempts,
 				)
@@ -3363,6 +3457,12 @@ func (e *Executor) reviewRunningMigrations(ctx context.Context) (countRunnning i
 			}
 			return true
 		})
+		e.rowValidations.Range(func(k, _ any) bool {
+			if uuid, ok := k.(string); ok && !uuidsFoundRunning[uuid] && !uuidsFoundPending[uuid] {
+				e.rowValidations.Delete(uuid)
+			}
+			return true
+		})
 	}
 
 	e.reviewedRunningMigrationsFlag = true
@@ -3463,6 +3563,194 @@ func (e *Executor) reviewStaleMigrations(ctx context.Context) error {
 	return nil
 }
 
+// autoRevertReason evaluates the auto-revert thresholds of a completed migration against the observed
+// replication lag and query error rate. It returns a non-empty reason when the migration should be reverted.
+// A zero threshold is ignored.
+func autoRevertReason(maxLag time.Duration, maxErrorRate float64, lag time.Duration, errorRate float64) string {
+	if maxLag > 0 && lag > maxLag {
+		return fmt.Sprintf("replication lag %v exceeds --auto-revert-on-lag=%v", lag, maxLag)
+	}
+	if maxErrorRate > 0 && errorRate > maxErrorRate {
+		return fmt.Sprintf("query error rate %.2f/s exceeds --auto-revert-on-error-rate=%v", errorRate, maxErrorRate)
+	}
+	return ""
+}
+
+// replicationLag returns the shard's replication lag as seen by the throttler
+func (e *Executor) replicationLag(ctx context.Context) (time.Duration, error) {
+	if err := e.lagThrottler.CheckIsOpen(); err != nil {
+		return 0, err
+	}
+	checkResult := e.lagThrottler.Check(ctx, throttlerapp.OnlineDDLName.String(), base.MetricNames{base.LagMetricName}, &throttle.CheckFlags{Scope: base.ShardScope})
+	metric, ok := checkResult.Metrics[base.LagMetricName.String()]
+	if !ok {
+		return 0, vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "no %s metric in throttler check", base.LagMetricName)
+	}
+	if metric.Error != nil {
+		return 0, metric.Error
+	}
+	return time.Duration(metric.Value * float64(time.Second)), nil
+}
+
+// isFastPathThrottled checks whether the throttler currently denies online DDL access to the shard. A closed
+// throttler never throttles.
+func (e *Executor) isFastPathThrottled(ctx context.Context) bool {
+	if err := e.lagThrottler.CheckIsOpen(); err != nil {
+		return false
+	}
+	checkResult := e.lagThrottler.Check(ctx, throttlerapp.OnlineDDLName.String(), nil, &throttle.CheckFlags{Scope: base.ShardScope})
+	return !checkResult.IsOK()
+}
+
+// fastPathThrottled checks whether the throttler allows a migration to run directly via the INSTANT or
+// INPLACE fast path. It never waits, as it runs under migrationMutex: it returns postpone while the
+// migration is to be retried on a next check, and throttled once the migration was throttled for over
+// fastPathThrottleWaitTimeout.
+func (e *Executor) fastPathThrottled(ctx context.Context, onlineDDL *schema.OnlineDDL) (postpone bool, throttled bool) {
+	if !e.isFastPathThrottled(ctx) {
+		delete(e.fastPathThrottledSince, onlineDDL.UUID)
+		return false, false
+	}
+	since, ok := e.fastPathThrottledSince[onlineDDL.UUID]
+	if !ok {
+		since = time.Now()
+		e.fastPathThrottledSince[onlineDDL.UUID] = since
+	}
+	if time.Since(since) < fastPathThrottleWaitTimeout {
+		e.updateMigrationStage(ctx, onlineDDL.UUID, "waiting for throttler before running directly")
+		return true, false
+	}
+	delete(e.fastPathThrottledSince, onlineDDL.UUID)
+	return false, true
+}
+
+// verifyFastPathReplicas waits, up to fastPathReplicaVerifyTimeout, for the shard's replicas to catch up after
+// a migration ran directly via the INSTANT or INPLACE fast path, as seen by the throttler, and records in the
+// special plan whether they were verified. The migration has already completed at this point, so this runs
+// in the background, without the migration's context.
+func (e *Executor) verifyFastPathReplicas(onlineDDL *schema.OnlineDDL, specialPlan *SpecialAlterPlan) {
+	ctx, cancel := context.WithTimeout(context.Background(), fastPathReplicaVerifyTimeout)
+	defer cancel()
+	verified := !e.isFastPathThrottled(ctx)
+	for !verified && ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-time.After(fastPathThrottleCheckInterval):
+			verified = !e.isFastPathThrottled(ctx)
+		}
+	}
+	if !verified {
+		log.Warningf("replicas did not catch up within %v after migration %v", fastPathReplicaVerifyTimeout, onlineDDL.UUID)
+	}
+	specialPlan.SetDetail("replicas-verified", strconv.FormatBool(verified))
+	updateCtx, updateCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer updateCancel()
+	if err := e.updateMigrationSpecialPlan(updateCtx, onlineDDL.UUID, specialPlan.String()); err != nil {
+		log.Errorf("could not record replicas verification of migration %v: %v", onlineDDL.UUID, err)
+	}
+}
+
+// queryErrorSample is a sample of the query error count of a table
+type queryErrorSample struct {
+	count     int64
+	timestamp time.Time
+}
+
+// sampleQueryErrorRates returns the query error rate of each of the tables, in errors per second, since
+// their previous sample. The first sample of a table returns zero. The samples of the other tables are
+// dropped, so that a table which is sampled again after a while does not average its errors over that while.
+func (e *Executor) sampleQueryErrorRates(tables map[string]bool, now time.Time) map[string]float64 {
+	rates := make(map[string]float64, len(tables))
+	samples := make(map[string]queryErrorSample, len(tables))
+	for table := range tables {
+		sample := queryErrorSample{count: e.tableErrorCountFunc(table), timestamp: now}
+		if last, ok := e.queryErrorSamples[table]; ok {
+			if elapsed := now.Sub(last.timestamp); elapsed > 0 {
+				rates[table] = float64(sample.count-last.count) / elapsed.Seconds()
+			}
+		}
+		samples[table] = sample
+	}
+	e.queryErrorSamples = samples
+	return rates
+}
+
+// submitAutoRevert submits a REVERT for the given migration
+func (e *Executor) submitAutoRevert(ctx context.Context, uuid string, table string, strategy schema.DDLStrategy, migrationContext string) (revertUUID string, err error) {
+	parser := e.env.Environment().Parser()
+	revertSQL := fmt.Sprintf("revert vitess_migration '%s'", uuid)
+	// The revert does not inherit the original options, and specifically not the auto-revert flags.
+	onlineDDL, err := schema.NewOnlineDDL(e.keyspace, table, revertSQL, schema.NewDDLStrategySetting(strategy, ""), migrationContext, "", parser)
+	if err != nil {
+		return "", err
+	}
+	stmt, err := parser.Parse(onlineDDL.SQL)
+	if err != nil {
+		return "", err
+	}
+	if _, err := e.SubmitMigration(ctx, stmt); err != nil {
+		return "", err
+	}
+	return onlineDDL.UUID, nil
+}
+
+// reviewAutoRevertMigrations looks for recently completed migrations that use --auto-revert-on-lag or
+// --auto-revert-on-error-rate. If, within the migration's watch period, replication lag or the query error
+// rate of the migrated table exceed the migration's thresholds, a REVERT is submitted for the migration.
+func (e *Executor) reviewAutoRevertMigrations(ctx context.Context) error {
+	r, err := e.execQuery(ctx, sqlSelectAutoRevertCandidateMigrations)
+	if err != nil {
+		return err
+	}
+	rows := r.Named().Rows
+	// The error rates of the tables are sampled on each review, so that they reflect the most recent interval
+	tables := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		tables[row["mysql_table"].ToString()] = true
+	}
+	errorRates := e.sampleQueryErrorRates(tables, time.Now())
+	if len(rows) == 0 {
+		return nil
+	}
+	lag, lagErr := e.replicationLag(ctx)
+	for _, row := range rows {
+		uuid := row["migration_uuid"].ToString()
+		setting := schema.NewDDLStrategySetting(schema.DDLStrategy(row["strategy"].ToString()), row["options"].ToString())
+		if !setting.IsAutoRevert() {
+			continue
+		}
+		watchPeriod, err := setting.AutoRevertWatchPeriod()
+		if err != nil {
+			continue
+		}
+		if time.Duration(row.AsInt64("seconds_since_completed", 0))*time.Second > watchPeriod {
+			// Watch period is over
+			continue
+		}
+		maxLag, _ := setting.AutoRevertOnLag()
+		if lagErr != nil && maxLag > 0 {
+			log.Warningf("reviewAutoRevertMigrations: cannot evaluate replication lag for migration %s: %v", uuid, lagErr)
+			maxLag = 0
+		}
+		maxErrorRate, _ := setting.AutoRevertOnErrorRate()
+		reason := autoRevertReason(maxLag, maxErrorRate, lag, errorRates[row["mysql_table"].ToString()])
+		if reason == "" {
+			continue
+		}
+		log.Infof("reviewAutoRevertMigrations: reverting migration %s: %s", uuid, reason)
+		revertUUID, err := e.submitAutoRevert(ctx, uuid, row["mysql_table"].ToString(), setting.Strategy, row["migration_context"].ToString())
+		if err != nil {
+			_ = e.updateMigrationMessage(ctx, uuid, fmt.Sprintf("auto-revert: %s; failed submitting revert: %v", reason, err))
+			return err
+		}
+		autoRevertedMigrationsStats.Add(1)
+		if err := e.updateMigrationMessage(ctx, uuid, fmt.Sprintf("auto-revert: %s; submitted revert migration %s", reason, revertUUID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // retryTabletFailureMigrations looks for migrations failed by tablet failure (e.g. by failover)
 // and retry them (put them back in the queue)
 func (e *Executor) retryTabletFailureMigrations(ctx context.Context) error {
@@ -3665,6 +3953,9 @@ func (e *Executor) onMigrationCheckTick() {
 	if err := e.reviewStaleMigrations(ctx); err != nil {
 		log.Error(err)
 	}
+	if err := e.reviewAutoRevertMigrations(ctx); err != nil {
+		log.Error(err)
+	}
 	if err := e.gcArtifacts(ctx); err != nil {
 		log.Error(err)
 	}
@@ -263,6 +263,85 @@ func TestGetInOrderCompletionPendingCount(t *testing.T) {
 	}
 }
 
+func TestAutoRevertReason(t *testing.T) {
+	tcases := []struct {
+		name         string
+		maxLag       time.Duration
+		maxErrorRate float64
+		lag          time.Duration
+		errorRate    float64
+		expect       string
+	}{
+		{
+			name:      "no thresholds",
+			lag:       time.Hour,
+			errorRate: 1000,
+		},
+		{
+			name:   "lag within threshold",
+			maxLag: 10 * time.Second,
+			lag:    10 * time.Second,
+		},
+		{
+			name:   "lag exceeds threshold",
+			maxLag: 10 * time.Second,
+			lag:    11 * time.Second,
+			expect: "replication lag 11s exceeds --auto-revert-on-lag=10s",
+		},
+		{
+			name:         "error rate within threshold",
+			maxErrorRate: 5,
+			errorRate:    4.5,
+		},
+		{
+			name:         "error rate exceeds threshold",
+			maxLag:       10 * time.Second,
+			maxErrorRate: 5,
+			lag:          time.Second,
+			errorRate:    7.25,
+			expect:       "query error rate 7.25/s exceeds --auto-revert-on-error-rate=5",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			reason := autoRevertReason(tcase.maxLag, tcase.maxErrorRate, tcase.lag, tcase.errorRate)
+			assert.Equal(t, tcase.expect, reason)
+		})
+	}
+}
+
+func TestSampleQueryErrorRates(t *testing.T) {
+	errorCounts := map[string]int64{"t1": 10, "t2": 10}
+	executor := &Executor{
+		tableErrorCountFunc: func(tableName string) int64 {
+			return errorCounts[tableName]
+		},
+		queryErrorSamples: make(map[string]queryErrorSample),
+	}
+	tables := map[string]bool{"t1": true}
+	now := time.Now()
+	// The first sample of a table has no rate.
+	rates := executor.sampleQueryErrorRates(tables, now)
+	assert.Zero(t, rates["t1"])
+
+	// The errors on other tables do not count, and do not cause a revert.
+	errorCounts["t2"] += 100
+	now = now.Add(10 * time.Second)
+	rates = executor.sampleQueryErrorRates(tables, now)
+	assert.Zero(t, rates["t1"])
+	assert.Empty(t, autoRevertReason(0, 1, 0, rates["t1"]))
+
+	errorCounts["t1"] += 100
+	now = now.Add(10 * time.Second)
+	rates = executor.sampleQueryErrorRates(tables, now)
+	assert.Equal(t, 10.0, rates["t1"])
+	assert.Equal(t, "query error rate 10.00/s exceeds --auto-revert-on-error-rate=1", autoRevertReason(0, 1, 0, rates["t1"]))
+
+	// The samples of the tables which are no longer candidates are dropped.
+	executor.sampleQueryErrorRates(map[string]bool{"t2": true}, now)
+	assert.NotContains(t, executor.queryErrorSamples, "t1")
+}
+
 func TestInitDBConnectionLockWaitTimeout(t *testing.T) {
 	db := fakesqldb.New(t)
 	defer db.Close()
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// rowChecksum is the aggregated checksum of a table's rows, as computed by a row checksum query
+type rowChecksum struct {
+	rowCount uint64
+	checksum uint64
+}
+
+// rowChecksumQueries generates two queries that compute an order-independent checksum of all rows in the
+// original table and in the vreplication (shadow) table, respectively. The queries are based on the vreplication
+// filter query, which maps source expressions onto target columns. Hence, both queries hash the same logical values.
+// The checksum is the sum of the CRC32 of the rows: unlike a XOR, it does not cancel out identical rows, such as
+// a row which is duplicated on one side and another which is missing from it.
+func rowChecksumQueries(filterQuery string, vreplTable string, parser *sqlparser.Parser) (sourceQuery string, targetQuery string, err error) {
+	stmt, err := parser.Parse(filterQuery)
+	if err != nil {
+		return "", "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected vreplication filter query: %s", filterQuery)
+	}
+	if len(sel.From) != 1 {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected FROM clause in vreplication filter query: %s", filterQuery)
+	}
+	var sourceExprs, targetExprs []string
+	for _, selectExpr := range sel.GetColumns() {
+		aliasedExpr, ok := selectExpr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return "", "", vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected expression in vreplication filter query: %s", sqlparser.String(selectExpr))
+		}
+		targetName := aliasedExpr.ColumnName()
+		sourceExprs = append(sourceExprs, sqlparser.String(aliasedExpr.Expr))
+		targetExprs = append(targetExprs, escapeName(targetName))
+	}
+	if len(sourceExprs) == 0 {
+		return "", "", vterrors.Errorf(vtrpcpb.Code_INTERNAL, "empty column list in vreplication filter query: %s", filterQuery)
+	}
+	checksumQuery := func(exprs []string, from string) string {
+		var sb strings.Builder
+		sb.WriteString("select count(*) as row_count, cast(ifnull(sum(crc32(concat_ws('#'")
+		for _, expr := range exprs {
+			// ISNULL() distinguishes a NULL from an empty string, which concat_ws() would otherwise skip
+			fmt.Fprintf(&sb, ", isnull(%s), convert(%s using utf8mb4)", expr, expr)
+		}
+		fmt.Fprintf(&sb, "))), 0) as unsigned) as row_checksum from %s", from)
+		return sb.String()
+	}
+	sourceQuery = checksumQuery(sourceExprs, sqlparser.String(sel.From[0]))
+	targetQuery = checksumQuery(targetExprs, escapeName(vreplTable))
+	return sourceQuery, targetQuery, nil
+}
+
+// readRowChecksum reads the single row returned by a row checksum query
+func readRowChecksum(rs *sqltypes.Result) (*rowChecksum, error) {
+	row := rs.Named().Row()
+	if row == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "unexpected empty result for row checksum")
+	}
+	rowCount, err := row.ToUint64("row_count")
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := row.ToUint64("row_checksum")
+	if err != nil {
+		return nil, err
+	}
+	return &rowChecksum{rowCount: rowCount, checksum: checksum}, nil
+}
+
+// validateVReplMigrationRows compares the rows of the original table with those of the vreplication table,
+// as required by the --require-row-validation DDL strategy flag. It works similarly to VDiff:
+//   - writes to the original table are briefly blocked, while vreplication catches up and is then stopped.
+//     At that point, a consistent snapshot of the original table is taken.
+//   - writes are then resumed, and both tables are checksummed: the original table via the snapshot, and the
+//     vreplication table, which is static while vreplication is stopped.
+//   - vreplication is restarted.
+//
+// The function returns a non-nil mismatch error when the checksums do not match, and a non-nil err when
+// validation could not be completed.
+func (e *Executor) validateVReplMigrationRows(ctx context.Context, s *VReplStream) (mismatch error, err error) {
+	onlineDDL, _, err := e.readMigration(ctx, s.workflow)
+	if err != nil {
+		return nil, err
+	}
+	vreplTable, err := getVreplTable(s)
+	if err != nil {
+		return nil, err
+	}
+	sourceQuery, targetQuery, err := rowChecksumQueries(s.bls.Filter.Rules[0].Filter, vreplTable, e.env.Environment().Parser())
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "generating row checksum queries")
+	}
+	tablet, err := e.ts.GetTablet(ctx, e.tabletAlias)
+	if err != nil {
+		return nil, err
+	}
+	tmClient := e.tabletManagerClient()
+	defer tmClient.Close()
+
+	e.updateMigrationStage(ctx, onlineDDL.UUID, "row validation: locking original table")
+	snapshotConn, err := e.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "failed getting snapshot connection")
+	}
+	defer snapshotConn.Recycle()
+	defer snapshotConn.Conn.Exec(ctx, sqlRollback, 1, false)
+
+	lockConn, err := e.pool.Get(ctx, nil)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "failed getting locking connection")
+	}
+	defer lockConn.Recycle()
+	defer lockConn.Conn.Exec(ctx, sqlUnlockTables, 1, false)
+
+	lockConnRestoreLockWaitTimeout, err := e.initConnectionLockWaitTimeout(ctx, lockConn.Conn, onlineDDL.CutOverThreshold)
+	if err != nil {
+		return nil, vterrors.Wrap(err, "failed setting lock_wait_timeout on locking connection")
+	}
+	defer lockConnRestoreLockWaitTimeout()
+
+	err = func() error {
+		lockCtx, cancel := context.WithTimeout(ctx, onlineDDL.CutOverThreshold)
+		defer cancel()
+		lockTableQuery := sqlparser.BuildParsedQuery(sqlLockTableRead, onlineDDL.Table)
+		if _, err := lockConn.Conn.Exec(lockCtx, lockTableQuery.Query, 1, false); err != nil {
+			return vterrors.Wrapf(err, "failed locking table")
+		}
+		defer lockConn.Conn.Exec(ctx, sqlUnlockTables, 1, false)
+
+		// Writes to the original table are now blocked.
+		pos, err := e.primaryPosition(lockCtx)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed reading pos after locking")
+		}
+		if _, err := snapshotConn.Conn.Exec(lockCtx, sqlSetTransactionRepeatableRead, 1, false); err != nil {
+			return vterrors.Wrapf(err, "failed setting transaction isolation level")
+		}
+		if _, err := snapshotConn.Conn.Exec(lockCtx, sqlStartTransactionWithConsistentSnapshot, 1, false); err != nil {
+			return vterrors.Wrapf(err, "failed starting consistent snapshot")
+		}
+		e.updateMigrationStage(ctx, onlineDDL.UUID, "row validation: waiting for pos: %v", replication.EncodePosition(pos))
+		if err := tmClient.VReplicationWaitForPos(lockCtx, tablet.Tablet, s.id, replication.EncodePosition(pos)); err != nil {
+			return vterrors.Wrapf(err, "failed waiting for pos %v", replication.EncodePosition(pos))
+		}
+		if _, err := e.vreplicationExec(lockCtx, tablet.Tablet, binlogplayer.StopVReplication(s.id, "stopped for online DDL row validation")); err != nil {
+			return vterrors.Wrapf(err, "failed stopping vreplication")
+		}
+		return nil
+	}()
+	defer func() {
+		if err := e.startVReplication(ctx, tablet.Tablet, s.workflow); err != nil {
+			log.Errorf("validateVReplMigrationRows %v: failed restarting vreplication: %v", s.workflow, err)
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	// Writes are resumed. The original table is read via the consistent snapshot, and the vreplication table
+	// is static for as long as vreplication is stopped.
+	e.updateMigrationStage(ctx, onlineDDL.UUID, "row validation: computing checksums")
+	rs, err := snapshotConn.Conn.Exec(ctx, sourceQuery, 1, true)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed computing checksum for table %s", onlineDDL.Table)
+	}
+	sourceChecksum, err := readRowChecksum(rs)
+	if err != nil {
+		return nil, err
+	}
+	rs, err = e.execQuery(ctx, targetQuery)
+	if err != nil {
+		return nil, vterrors.Wrapf(err, "failed computing checksum for table %s", vreplTable)
+	}
+	targetChecksum, err := readRowChecksum(rs)
+	if err != nil {
+		return nil, err
+	}
+	if *sourceChecksum != *targetChecksum {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION,
+			"row validation failed: table %s has %d rows with checksum %d, vreplication table %s has %d rows with checksum %d",
+			onlineDDL.Table, sourceChecksum.rowCount, sourceChecksum.checksum,
+			vreplTable, targetChecksum.rowCount, targetChecksum.checksum,
+		), nil
+	}
+	e.updateMigrationStage(ctx, onlineDDL.UUID, "row validation: %d rows validated", sourceChecksum.rowCount)
+	return nil, nil
+}
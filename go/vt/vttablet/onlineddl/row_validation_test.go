@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onlineddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestRowChecksumQueries(t *testing.T) {
+	tcases := []struct {
+		name         string
+		filterQuery  string
+		expectSource string
+		expectTarget string
+		expectError  string
+	}{
+		{
+			name:         "simple",
+			filterQuery:  "select `id` as `id`, `name` as `name` from `t`",
+			expectSource: "select count(*) as row_count, cast(ifnull(sum(crc32(concat_ws('#', isnull(id), convert(id using utf8mb4), isnull(`name`), convert(`name` using utf8mb4)))), 0) as unsigned) as row_checksum from t",
+			expectTarget: "select count(*) as row_count, cast(ifnull(sum(crc32(concat_ws('#', isnull(`id`), convert(`id` using utf8mb4), isnull(`name`), convert(`name` using utf8mb4)))), 0) as unsigned) as row_checksum from `_vt_vrp_shadow`",
+		},
+		{
+			name:         "renamed and converted columns",
+			filterQuery:  "select `id` as `id`, CONCAT(`e`) as `e`, convert(`j` using utf8mb4) as `j2` from `t`",
+			expectSource: "select count(*) as row_count, cast(ifnull(sum(crc32(concat_ws('#', isnull(id), convert(id using utf8mb4), isnull(CONCAT(e)), convert(CONCAT(e) using utf8mb4), isnull(convert(j using utf8mb4)), convert(convert(j using utf8mb4) using utf8mb4)))), 0) as unsigned) as row_checksum from t",
+			expectTarget: "select count(*) as row_count, cast(ifnull(sum(crc32(concat_ws('#', isnull(`id`), convert(`id` using utf8mb4), isnull(`e`), convert(`e` using utf8mb4), isnull(`j2`), convert(`j2` using utf8mb4)))), 0) as unsigned) as row_checksum from `_vt_vrp_shadow`",
+		},
+		{
+			name:        "not a select",
+			filterQuery: "delete from t",
+			expectError: "unexpected vreplication filter query",
+		},
+		{
+			name:        "star",
+			filterQuery: "select * from t",
+			expectError: "unexpected expression",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			source, target, err := rowChecksumQueries(tcase.filterQuery, "_vt_vrp_shadow", sqlparser.NewTestParser())
+			if tcase.expectError != "" {
+				assert.ErrorContains(t, err, tcase.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectSource, source)
+			assert.Equal(t, tcase.expectTarget, target)
+		})
+	}
+}
+
+func TestReadRowChecksum(t *testing.T) {
+	rs := sqltypes.MakeTestResult(sqltypes.MakeTestFields("row_count|row_checksum", "int64|uint64"), "17|3735928559")
+	checksum, err := readRowChecksum(rs)
+	require.NoError(t, err)
+	assert.Equal(t, rowChecksum{rowCount: 17, checksum: 3735928559}, *checksum)
+
+	_, err = readRowChecksum(&sqltypes.Result{})
+	assert.Error(t, err)
+}
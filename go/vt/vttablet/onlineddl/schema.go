@@ -44,12 +44,15 @@ const (
 
 	sqlSelectQueuedMigrations = `SELECT
 			migration_uuid,
+			strategy,
+			options,
 			ddl_action,
 			is_view,
 			is_immediate_operation,
 			postpone_launch,
 			postpone_completion,
-			ready_to_complete
+			ready_to_complete,
+			message
 		FROM _vt.schema_migrations
 		WHERE
 			migration_status='queued'
@@ -372,6 +375,25 @@ const (
 			AND liveness_timestamp < NOW() - INTERVAL %a MINUTE
 		ORDER BY id
 	`
+	sqlSelectAutoRevertCandidateMigrations = `SELECT
+			migration_uuid,
+			keyspace,
+			mysql_table,
+			strategy,
+			options,
+			migration_context,
+			timestampdiff(second, completed_timestamp, now()) as seconds_since_completed
+		FROM _vt.schema_migrations
+		WHERE
+			migration_status='complete'
+			AND cleanup_timestamp IS NULL
+			AND reverted_uuid=''
+			AND options LIKE '%auto-revert-on-%'
+			AND migration_uuid NOT IN (
+				SELECT reverted_uuid FROM _vt.schema_migrations WHERE reverted_uuid != ''
+			)
+		ORDER BY id
+	`
 	sqlSelectFailedCancelledMigrationsInContextBeforeMigration = `SELECT
 			migration_uuid
 		FROM _vt.schema_migrations
@@ -554,14 +576,18 @@ const (
 			_vt.copy_state
 		WHERE vrepl_id=%a
 		`
-	sqlSwapTables              = "RENAME TABLE `%a` TO `%a`, `%a` TO `%a`, `%a` TO `%a`"
-	sqlRenameTable             = "RENAME TABLE `%a` TO `%a`"
-	sqlLockTwoTablesWrite      = "LOCK TABLES `%a` WRITE, `%a` WRITE"
-	sqlUnlockTables            = "UNLOCK TABLES"
-	sqlCreateSentryTable       = "CREATE TABLE IF NOT EXISTS `%a` (id INT PRIMARY KEY)"
-	sqlFindProcess             = "SELECT id, Info as info FROM information_schema.processlist WHERE id=%a AND Info LIKE %a"
-	sqlFindProcessByInfo       = "SELECT id, Info as info FROM information_schema.processlist WHERE Info LIKE %a and id != connection_id()"
-	sqlProcessWithLocksOnTable = `
+	sqlSwapTables                             = "RENAME TABLE `%a` TO `%a`, `%a` TO `%a`, `%a` TO `%a`"
+	sqlRenameTable                            = "RENAME TABLE `%a` TO `%a`"
+	sqlLockTwoTablesWrite                     = "LOCK TABLES `%a` WRITE, `%a` WRITE"
+	sqlUnlockTables                           = "UNLOCK TABLES"
+	sqlLockTableRead                          = "LOCK TABLES `%a` READ"
+	sqlRollback                               = "ROLLBACK"
+	sqlSetTransactionRepeatableRead           = "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ"
+	sqlStartTransactionWithConsistentSnapshot = "START TRANSACTION WITH CONSISTENT SNAPSHOT"
+	sqlCreateSentryTable                      = "CREATE TABLE IF NOT EXISTS `%a` (id INT PRIMARY KEY)"
+	sqlFindProcess                            = "SELECT id, Info as info FROM information_schema.processlist WHERE id=%a AND Info LIKE %a"
+	sqlFindProcessByInfo                      = "SELECT id, Info as info FROM information_schema.processlist WHERE Info LIKE %a and id != connection_id()"
+	sqlProcessWithLocksOnTable                = `
 		SELECT
 			DISTINCT innodb_trx.trx_mysql_thread_id
 		from
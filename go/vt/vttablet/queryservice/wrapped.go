@@ -41,6 +41,9 @@ type WrapOpts struct {
 	InTransaction bool
 
 	Session Session
+
+	// Workload is the workload of the query, from its execute options.
+	Workload querypb.ExecuteOptions_Workload
 }
 
 // Wrap returns a wrapped version of the original QueryService implementation.
@@ -117,7 +120,7 @@ type wrappedService struct {
 }
 
 func (ws *wrappedService) Begin(ctx context.Context, session Session, target *querypb.Target, options *querypb.ExecuteOptions) (state TransactionState, err error) {
-	opts := WrapOpts{InTransaction: false, Session: session}
+	opts := WrapOpts{InTransaction: false, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "Begin", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		state, innerErr = conn.Begin(ctx, session, target, options)
@@ -240,7 +243,7 @@ func (ws *wrappedService) UnresolvedTransactions(ctx context.Context, target *qu
 
 func (ws *wrappedService) Execute(ctx context.Context, session Session, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (qr *sqltypes.Result, err error) {
 	inDedicatedConn := transactionID != 0 || reservedID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "Execute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		qr, innerErr = conn.Execute(ctx, session, target, query, bindVars, transactionID, reservedID, options)
@@ -254,7 +257,7 @@ func (ws *wrappedService) Execute(ctx context.Context, session Session, target *
 // StreamExecute implements the QueryService interface
 func (ws *wrappedService) StreamExecute(ctx context.Context, session Session, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID int64, reservedID int64, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) error {
 	inDedicatedConn := transactionID != 0 || reservedID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err := ws.wrapper(ctx, target, ws.impl, "StreamExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		streamingStarted := false
 		innerErr := conn.StreamExecute(ctx, session, target, query, bindVars, transactionID, reservedID, options, func(qr *sqltypes.Result) error {
@@ -270,7 +273,7 @@ func (ws *wrappedService) StreamExecute(ctx context.Context, session Session, ta
 
 func (ws *wrappedService) BeginExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, query string, bindVars map[string]*querypb.BindVariable, reservedID int64, options *querypb.ExecuteOptions) (state TransactionState, qr *sqltypes.Result, err error) {
 	inDedicatedConn := reservedID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "BeginExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		state, qr, innerErr = conn.BeginExecute(ctx, session, target, preQueries, query, bindVars, reservedID, options)
@@ -282,7 +285,7 @@ func (ws *wrappedService) BeginExecute(ctx context.Context, session Session, tar
 // BeginStreamExecute implements the QueryService interface
 func (ws *wrappedService) BeginStreamExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, query string, bindVars map[string]*querypb.BindVariable, reservedID int64, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) (state TransactionState, err error) {
 	inDedicatedConn := reservedID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "BeginStreamExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		state, innerErr = conn.BeginStreamExecute(ctx, session, target, preQueries, query, bindVars, reservedID, options, callback)
@@ -355,7 +358,7 @@ func (ws *wrappedService) HandlePanic(err *error) {
 
 // ReserveBeginExecute implements the QueryService interface
 func (ws *wrappedService) ReserveBeginExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, postBeginQueries []string, sql string, bindVariables map[string]*querypb.BindVariable, options *querypb.ExecuteOptions) (state ReservedTransactionState, res *sqltypes.Result, err error) {
-	opts := WrapOpts{InTransaction: false, Session: session}
+	opts := WrapOpts{InTransaction: false, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "ReserveBeginExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var err error
 		state, res, err = conn.ReserveBeginExecute(ctx, session, target, preQueries, postBeginQueries, sql, bindVariables, options)
@@ -367,7 +370,7 @@ func (ws *wrappedService) ReserveBeginExecute(ctx context.Context, session Sessi
 
 // ReserveBeginStreamExecute implements the QueryService interface
 func (ws *wrappedService) ReserveBeginStreamExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, postBeginQueries []string, sql string, bindVariables map[string]*querypb.BindVariable, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) (state ReservedTransactionState, err error) {
-	opts := WrapOpts{InTransaction: false, Session: session}
+	opts := WrapOpts{InTransaction: false, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "ReserveBeginStreamExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		state, innerErr = conn.ReserveBeginStreamExecute(ctx, session, target, preQueries, postBeginQueries, sql, bindVariables, options, callback)
@@ -379,7 +382,7 @@ func (ws *wrappedService) ReserveBeginStreamExecute(ctx context.Context, session
 // ReserveExecute implements the QueryService interface
 func (ws *wrappedService) ReserveExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, sql string, bindVariables map[string]*querypb.BindVariable, transactionID int64, options *querypb.ExecuteOptions) (state ReservedState, res *sqltypes.Result, err error) {
 	inDedicatedConn := transactionID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "ReserveExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var err error
 		state, res, err = conn.ReserveExecute(ctx, session, target, preQueries, sql, bindVariables, transactionID, options)
@@ -392,7 +395,7 @@ func (ws *wrappedService) ReserveExecute(ctx context.Context, session Session, t
 // ReserveStreamExecute implements the QueryService interface
 func (ws *wrappedService) ReserveStreamExecute(ctx context.Context, session Session, target *querypb.Target, preQueries []string, sql string, bindVariables map[string]*querypb.BindVariable, transactionID int64, options *querypb.ExecuteOptions, callback func(*sqltypes.Result) error) (state ReservedState, err error) {
 	inDedicatedConn := transactionID != 0
-	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session}
+	opts := WrapOpts{InTransaction: inDedicatedConn, Session: session, Workload: options.GetWorkload()}
 	err = ws.wrapper(ctx, target, ws.impl, "ReserveStreamExecute", opts, func(ctx context.Context, target *querypb.Target, conn QueryService) (bool, error) {
 		var innerErr error
 		state, innerErr = conn.ReserveStreamExecute(ctx, session, target, preQueries, sql, bindVariables, transactionID, options, callback)
@@ -84,6 +84,8 @@ type SandboxConn struct {
 	ReleaseCount                atomic.Int64
 	GetSchemaCount              atomic.Int64
 	GetSchemaDelayResponse      time.Duration
+	// ExecuteDelay is the time Execute waits before running the query.
+	ExecuteDelay time.Duration
 
 	queriesRequireLocking bool
 	queriesMu             sync.Mutex
@@ -147,6 +149,9 @@ type SandboxConn struct {
 type SchemaResult struct {
 	TablesAndViews map[string]string
 	UDFs           []*querypb.UDFInfo
+	TableRows      map[string]int64
+
+	IndexCardinalities map[string]*querypb.IndexCardinalities
 }
 
 var _ queryservice.QueryService = (*SandboxConn)(nil) // compile-time interface check
@@ -267,6 +272,13 @@ func (sbc *SandboxConn) SetSchemaResult(r []SchemaResult) {
 // Execute is part of the QueryService interface.
 func (sbc *SandboxConn) Execute(ctx context.Context, session queryservice.Session, target *querypb.Target, query string, bindVars map[string]*querypb.BindVariable, transactionID, reservedID int64, options *querypb.ExecuteOptions) (*sqltypes.Result, error) {
 	sbc.panicIfNeeded()
+	if sbc.ExecuteDelay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sbc.ExecuteDelay):
+		}
+	}
 	sbc.execMu.Lock()
 	defer sbc.execMu.Unlock()
 	sbc.ExecCount.Add(1)
@@ -755,6 +767,9 @@ func (sbc *SandboxConn) GetSchema(ctx context.Context, target *querypb.Target, t
 	response := &querypb.GetSchemaResponse{
 		TableDefinition: resp.TablesAndViews,
 		Udfs:            resp.UDFs,
+		TableRows:       resp.TableRows,
+
+		IndexCardinalities: resp.IndexCardinalities,
 	}
 	return callback(response)
 }
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"errors"
+
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// This file handles the initial provisioning of the tablet with MySQL CLONE
+// upon startup. It is an alternative to restore-from-backup, enabled if
+// clone-from-primary or clone-from-tablet is set.
+
+// CloneData provisions the tablet by cloning the data of the donor tablet
+// with MySQL CLONE REMOTE, and starts replicating from the cloned position.
+// It takes the action lock so no RPC interferes.
+func (tm *TabletManager) CloneData(ctx context.Context, logger logutil.Logger) error {
+	if err := tm.lock(ctx); err != nil {
+		return err
+	}
+	defer tm.unlock()
+	if tm.Cnf == nil {
+		return errors.New("cannot perform clone without my.cnf, please restart vttablet with a my.cnf file specified")
+	}
+	return tm.cloneDataLocked(ctx, logger)
+}
+
+func (tm *TabletManager) cloneDataLocked(ctx context.Context, logger logutil.Logger) error {
+	tablet := tm.Tablet()
+	originalType := tablet.Type
+
+	keyspaceInfo, err := tm.TopoServer.GetKeyspace(ctx, tablet.Keyspace)
+	if err != nil {
+		return err
+	}
+
+	// Check whether we're going to clone before changing to RESTORE type,
+	// so we keep our PrimaryTermStartTime (if any) if we aren't actually cloning.
+	ok, err := mysqlctl.ShouldRestore(ctx, mysqlctl.RestoreParams{
+		Cnf:      tm.Cnf,
+		Mysqld:   tm.MysqlDaemon,
+		Logger:   logger,
+		DbName:   topoproto.TabletDbName(tablet),
+		Keyspace: tablet.Keyspace,
+		Shard:    tablet.Shard,
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Infof("Attempting to clone, but mysqld already contains data. Assuming vttablet was just restarted.")
+		return nil
+	}
+	// We should not become primary after the clone, because that would
+	// incorrectly start a new primary term.
+	if originalType == topodatapb.TabletType_PRIMARY {
+		originalType = tm.baseTabletType
+	}
+	if err := tm.tmState.ChangeTabletType(ctx, topodatapb.TabletType_RESTORE, DBActionNone); err != nil {
+		return err
+	}
+
+	pos, err := mysqlctl.CloneFromDonor(ctx, tm.TopoServer, tm.MysqlDaemon, tablet.Keyspace, tablet.Shard)
+	if err != nil {
+		// If anything failed, we should reset the original tablet type
+		if err := tm.tmState.ChangeTabletType(context.Background(), originalType, DBActionNone); err != nil {
+			log.Errorf("Could not change back to original tablet type %v: %v", originalType, err)
+		}
+		return vterrors.Wrap(err, "Can't clone data")
+	}
+	statsRestoreBackupPosition.Set(replication.EncodePosition(pos))
+	logger.Infof("Clone: pos=%v", replication.EncodePosition(pos))
+
+	// Reconnect to primary only for "NORMAL" keyspaces
+	if keyspaceInfo.KeyspaceType == topodatapb.KeyspaceType_NORMAL {
+		logger.Infof("Clone: starting replication at position %v", pos)
+		if err := tm.startReplication(ctx, pos, originalType); err != nil {
+			return err
+		}
+	}
+
+	// If we had type BACKUP or RESTORE it's better to set our type to the init-tablet-type to make result of the clone
+	// similar to completely clean start from scratch.
+	if (originalType == topodatapb.TabletType_BACKUP || originalType == topodatapb.TabletType_RESTORE) && initTabletType != "" {
+		initType, err := topoproto.ParseTabletType(initTabletType)
+		if err == nil {
+			originalType = initType
+		}
+	}
+	logger.Infof("Clone: changing tablet type to %v for %s", originalType, tm.tabletAlias.String())
+	return tm.tmState.ChangeTabletType(context.Background(), originalType, DBActionNone)
+}
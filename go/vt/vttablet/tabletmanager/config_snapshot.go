@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/configsnapshot"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+const (
+	sqlGetConfigSnapshotVariables = "select variable_name, variable_value from performance_schema.global_variables where variable_name in %a"
+	sqlGetSidecarTableColumns     = "select table_name, column_name, column_type, is_nullable, column_key from information_schema.columns where table_schema = %a order by table_name, ordinal_position"
+)
+
+var registerConfigSnapshotHandlerOnce sync.Once
+
+// registerConfigSnapshotHandler serves the configuration snapshot of the
+// tablet over HTTP, which is what vtctldclient ConfigDrift uses.
+func (tm *TabletManager) registerConfigSnapshotHandler() {
+	registerConfigSnapshotHandlerOnce.Do(func() {
+		servenv.HTTPHandleFunc(configsnapshot.HTTPPath, tm.handleConfigSnapshot)
+	})
+}
+
+func (tm *TabletManager) handleConfigSnapshot(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	snapshot, err := tm.ConfigSnapshot(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// ConfigSnapshot returns a normalized snapshot of the effective configuration
+// of the tablet: its flags, the MySQL variables relevant to Vitess and the
+// schema of the sidecar database tables.
+func (tm *TabletManager) ConfigSnapshot(ctx context.Context) (*configsnapshot.Snapshot, error) {
+	snapshot := &configsnapshot.Snapshot{
+		Tablet: topoproto.TabletAliasString(tm.tabletAlias),
+		Flags:  configsnapshot.FlagValues(pflag.CommandLine),
+		MySQL:  make(map[string]string),
+	}
+
+	names := make([]*querypb.Value, 0, len(configsnapshot.MySQLVariables))
+	for _, name := range configsnapshot.MySQLVariables {
+		names = append(names, sqltypes.ValueToProto(sqltypes.NewVarChar(name)))
+	}
+	query, err := sqlparser.ParseAndBind(sqlGetConfigSnapshotVariables, &querypb.BindVariable{Type: querypb.Type_TUPLE, Values: names})
+	if err != nil {
+		return nil, err
+	}
+	qr, err := tm.MysqlDaemon.FetchSuperQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the MySQL variables: %w", err)
+	}
+	for _, row := range qr.Rows {
+		snapshot.MySQL[strings.ToLower(row[0].ToString())] = row[1].ToString()
+	}
+
+	query, err = sqlparser.ParseAndBind(sqlGetSidecarTableColumns, sqltypes.StringBindVariable(sidecar.GetName()))
+	if err != nil {
+		return nil, err
+	}
+	qr, err = tm.MysqlDaemon.FetchSuperQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the sidecar database schema: %w", err)
+	}
+	tables := make(map[string]*strings.Builder)
+	for _, row := range qr.Rows {
+		table := row[0].ToString()
+		sb, ok := tables[table]
+		if !ok {
+			sb = &strings.Builder{}
+			tables[table] = sb
+		}
+		for _, v := range row[1:] {
+			sb.WriteString(v.ToString())
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('\n')
+	}
+	snapshot.Sidecar = make(map[string]string, len(tables))
+	for table, sb := range tables {
+		snapshot.Sidecar[table] = configsnapshot.Hash(sb.String())
+	}
+	return snapshot, nil
+}
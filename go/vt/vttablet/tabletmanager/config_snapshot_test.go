@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/configsnapshot"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+	mysqld := newTestMysqlDaemon(t, 1)
+	mysqld.FetchSuperQueryMap = map[string]*sqltypes.Result{
+		`select variable_name, variable_value from performance_schema\.global_variables where variable_name in .*`: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("variable_name|variable_value", "varchar|varchar"),
+			"GTID_MODE|ON",
+			"binlog_format|ROW",
+		),
+		`select table_name, column_name, column_type, is_nullable, column_key from information_schema\.columns where table_schema = '_vt' .*`: sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("table_name|column_name|column_type|is_nullable|column_key", "varchar|varchar|varchar|varchar|varchar"),
+			"heartbeat|ts|bigint unsigned|NO|",
+			"heartbeat|keyspaceShard|varbinary(256)|NO|PRI",
+			"vreplication|id|int|NO|PRI",
+		),
+	}
+	tm := &TabletManager{
+		tabletAlias: &topodatapb.TabletAlias{Cell: "zone1", Uid: 100},
+		MysqlDaemon: mysqld,
+	}
+
+	snapshot, err := tm.ConfigSnapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "zone1-0000000100", snapshot.Tablet)
+	assert.Equal(t, map[string]string{"gtid_mode": "ON", "binlog_format": "ROW"}, snapshot.MySQL)
+	require.Len(t, snapshot.Sidecar, 2)
+	assert.Equal(t, configsnapshot.Hash("id int NO PRI \n"), snapshot.Sidecar["vreplication"])
+	assert.NotEqual(t, snapshot.Sidecar["heartbeat"], snapshot.Sidecar["vreplication"])
+
+	mysqld.FetchSuperQueryMap = nil
+	_, err = tm.ConfigSnapshot(context.Background())
+	assert.ErrorContains(t, err, "failed to get the MySQL variables")
+}
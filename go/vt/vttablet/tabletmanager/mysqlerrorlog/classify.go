@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlerrorlog
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// CategoryCrashRecovery is the category of the lines logged when InnoDB
+	// recovers from a crash of mysqld.
+	CategoryCrashRecovery = "crash_recovery"
+	// CategoryDeadlock is the category of the deadlocks printed by InnoDB
+	// with innodb_print_all_deadlocks. They are only counted, and a
+	// CategoryDeadlockSpike event is recorded when there are too many of them.
+	CategoryDeadlock = "deadlock"
+	// CategoryDeadlockSpike is the category of the events recorded when the
+	// number of deadlocks in a minute reaches the spike threshold.
+	CategoryDeadlockSpike = "deadlock_spike"
+	// CategoryDiskError is the category of the lines reporting I/O errors and
+	// full disks.
+	CategoryDiskError = "disk_error"
+	// CategorySemiSyncTimeout is the category of the lines logged when the
+	// primary times out waiting for a semi-sync ACK.
+	CategorySemiSyncTimeout = "semi_sync_timeout"
+)
+
+// classifier classifies the error log lines which match its regexp.
+type classifier struct {
+	category string
+	re       *regexp.Regexp
+}
+
+var (
+	classifiers = []classifier{{
+		category: CategoryCrashRecovery,
+		re:       regexp.MustCompile(`(?i)database was not shut ?down normally|starting crash recovery`),
+	}, {
+		category: CategoryDeadlock,
+		re:       regexp.MustCompile(`(?i)transactions deadlock detected`),
+	}, {
+		category: CategoryDiskError,
+		re:       regexp.MustCompile(`(?i)no space left on device|disk is full|operating system error number|input/output error|read-only file system|errno: ?(5|28)\b`),
+	}, {
+		category: CategorySemiSyncTimeout,
+		re:       regexp.MustCompile(`(?i)timeout waiting for reply of binlog|semi-sync replication switched off`),
+	}}
+
+	severityRegexp = regexp.MustCompile(`\[(ERROR|Warning|Note|System)\]`)
+)
+
+// logLine is a line of the error log which was classified.
+type logLine struct {
+	time     time.Time
+	category string
+	severity string
+	message  string
+}
+
+// classify returns the category of the error log line, and false if the line
+// is not notable. The time of the line is the timestamp it starts with, or
+// now if it has none, as the continuation lines of a message.
+func classify(line string, now time.Time) (*logLine, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	for _, c := range classifiers {
+		if !c.re.MatchString(line) {
+			continue
+		}
+		l := &logLine{
+			time:     now,
+			category: c.category,
+			message:  line,
+		}
+		if ts, _, found := strings.Cut(line, " "); found {
+			if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+				l.time = t
+			}
+		}
+		if m := severityRegexp.FindStringSubmatch(line); m != nil {
+			l.severity = m[1]
+		}
+		return l, true
+	}
+	return nil, false
+}
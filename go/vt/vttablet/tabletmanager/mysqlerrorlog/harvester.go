@@ -0,0 +1,411 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mysqlerrorlog tails the error log of the MySQL server of a tablet,
+// and classifies its notable events, so that they can be looked up through
+// the GetMysqlErrorLogEvents RPC, the metrics and the full status of the
+// tablet instead of on the host of mysqld.
+package mysqlerrorlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/protoutil"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/mysqlctl"
+	"vitess.io/vitess/go/vt/servenv"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+const (
+	// maxEvents is the number of recent events a Harvester keeps.
+	maxEvents = 100
+	// initialScanBytes is how much of the end of the error log is read when
+	// the harvester starts, to classify the events logged while mysqld
+	// started, such as a crash recovery.
+	initialScanBytes = 1 << 20
+	// maxReadBytes is the most which is read from the error log per poll.
+	maxReadBytes = 4 << 20
+	// maxLineBytes is the longest line which is classified. The rest of
+	// longer lines is skipped.
+	maxLineBytes = 64 << 10
+
+	deadlockSpikeWindow = time.Minute
+	// fullStatusWindow is how far back the events are counted in the full
+	// status of the tablet.
+	fullStatusWindow = 10 * time.Minute
+)
+
+var (
+	harvest                bool
+	errorLogPath           string
+	pollInterval           = time.Second
+	deadlockSpikeThreshold = 10
+	inFullStatus           bool
+
+	eventCounts = stats.NewCountersWithSingleLabel("MysqlErrorLogEvents", "Number of notable events of the MySQL error log, per category", "Category")
+)
+
+func registerFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&harvest, "mysql-error-log-harvest", harvest, "Tail the MySQL error log and classify its notable events: crash recoveries, deadlock spikes, disk errors and semi-sync timeouts.")
+	fs.StringVar(&errorLogPath, "mysql-error-log-path", errorLogPath, "Path of the MySQL error log to harvest. Defaults to the error log of the mycnf of the tablet.")
+	fs.DurationVar(&pollInterval, "mysql-error-log-poll-interval", pollInterval, "How often the MySQL error log is read for new lines.")
+	fs.IntVar(&deadlockSpikeThreshold, "mysql-error-log-deadlock-spike-threshold", deadlockSpikeThreshold, "Number of deadlocks in a minute, as printed with innodb_print_all_deadlocks, from which a deadlock spike is reported. 0 disables the detection of deadlock spikes.")
+	fs.BoolVar(&inFullStatus, "mysql-error-log-in-full-status", inFullStatus, "Report the number of notable events of the MySQL error log of the last 10 minutes in the full status of the tablet, for VTOrc.")
+}
+
+func init() {
+	servenv.OnParseFor("vttablet", registerFlags)
+}
+
+// Harvester tails the MySQL error log and keeps its recent notable events.
+// A nil Harvester harvests nothing.
+type Harvester struct {
+	path                   string
+	pollInterval           time.Duration
+	deadlockSpikeThreshold int
+	inFullStatus           bool
+
+	mu              sync.Mutex
+	events          []*tabletmanagerdatapb.MysqlErrorLogEvent
+	counts          map[string]int64
+	deadlocks       []time.Time
+	inDeadlockSpike bool
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+
+	// The fields below are only used by the polling goroutine.
+	file    *os.File
+	partial []byte
+	// skipLine is set when the start of the current line was not read.
+	skipLine bool
+	// openErr is set while the error log cannot be opened, so that the
+	// error is only logged once.
+	openErr bool
+}
+
+// NewFromFlags creates a Harvester of the error log of the mycnf, or of
+// --mysql-error-log-path, if --mysql-error-log-harvest is set. It returns nil
+// otherwise, or if there is no error log to harvest.
+func NewFromFlags(cnf *mysqlctl.Mycnf) *Harvester {
+	if !harvest {
+		return nil
+	}
+	path := errorLogPath
+	if path == "" && cnf != nil {
+		path = cnf.ErrorLogPath
+	}
+	if path == "" {
+		log.Warningf("--mysql-error-log-harvest is set but the path of the MySQL error log is unknown, set --mysql-error-log-path")
+		return nil
+	}
+	h := NewHarvester(path, pollInterval, deadlockSpikeThreshold)
+	h.inFullStatus = inFullStatus
+	return h
+}
+
+// NewHarvester creates a Harvester of the error log at path, which is read
+// every pollInterval once the Harvester is open.
+func NewHarvester(path string, pollInterval time.Duration, deadlockSpikeThreshold int) *Harvester {
+	return &Harvester{
+		path:                   path,
+		pollInterval:           pollInterval,
+		deadlockSpikeThreshold: deadlockSpikeThreshold,
+		counts:                 make(map[string]int64),
+	}
+}
+
+// Open starts tailing the error log.
+func (h *Harvester) Open() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.run(ctx)
+	}()
+}
+
+// Close stops tailing the error log. The events harvested so far are kept.
+func (h *Harvester) Close() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	cancel := h.cancel
+	h.cancel = nil
+	h.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	h.wg.Wait()
+}
+
+func (h *Harvester) run(ctx context.Context) {
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+	defer h.closeFile()
+	for {
+		h.poll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll classifies the lines appended to the error log since the last poll.
+func (h *Harvester) poll() {
+	if h.file == nil {
+		if !h.openFile(true /* initial */) {
+			return
+		}
+	} else if h.rotated() {
+		// The lines appended to the rotated file after the last poll are
+		// lost, which is fine for the notable events.
+		h.closeFile()
+		if !h.openFile(false /* initial */) {
+			return
+		}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(h.file, maxReadBytes))
+	if err != nil {
+		log.Warningf("Cannot read the MySQL error log %s: %v", h.path, err)
+		h.closeFile()
+		return
+	}
+	h.processData(data, time.Now())
+}
+
+// openFile opens the error log, at its end if initial is set, less
+// initialScanBytes, or at its start otherwise. It returns false if the file
+// could not be opened.
+func (h *Harvester) openFile(initial bool) bool {
+	file, err := os.Open(h.path)
+	if err != nil {
+		if !h.openErr {
+			log.Warningf("Cannot open the MySQL error log %s: %v", h.path, err)
+			h.openErr = true
+		}
+		return false
+	}
+	h.openErr = false
+	h.partial = h.partial[:0]
+	h.skipLine = false
+	if initial {
+		fi, err := file.Stat()
+		if err == nil && fi.Size() > initialScanBytes {
+			if _, err := file.Seek(fi.Size()-initialScanBytes, io.SeekStart); err == nil {
+				h.skipLine = true
+			}
+		}
+	}
+	h.file = file
+	return true
+}
+
+// rotated returns true if the error log was rotated or truncated since it
+// was opened.
+func (h *Harvester) rotated() bool {
+	fi, err := os.Stat(h.path)
+	if err != nil {
+		return false
+	}
+	openFi, err := h.file.Stat()
+	if err != nil || !os.SameFile(fi, openFi) {
+		return true
+	}
+	offset, err := h.file.Seek(0, io.SeekCurrent)
+	return err == nil && fi.Size() < offset
+}
+
+func (h *Harvester) closeFile() {
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+}
+
+// processData classifies the complete lines of data, and keeps its last
+// incomplete line for the next poll.
+func (h *Harvester) processData(data []byte, now time.Time) {
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			if !h.skipLine {
+				h.partial = append(h.partial, data...)
+				if len(h.partial) > maxLineBytes {
+					h.processLine(string(h.partial), now)
+					h.partial = h.partial[:0]
+					h.skipLine = true
+				}
+			}
+			return
+		}
+		if !h.skipLine {
+			h.partial = append(h.partial, data[:i]...)
+			h.processLine(string(h.partial), now)
+		}
+		h.partial = h.partial[:0]
+		h.skipLine = false
+		data = data[i+1:]
+	}
+}
+
+func (h *Harvester) processLine(line string, now time.Time) {
+	l, ok := classify(line, now)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.countLocked(l.category)
+	if l.category == CategoryDeadlock {
+		h.recordDeadlockLocked(l.time)
+		return
+	}
+	h.addEventLocked(&tabletmanagerdatapb.MysqlErrorLogEvent{
+		Time:     protoutil.TimeToProto(l.time),
+		Category: l.category,
+		Severity: l.severity,
+		Message:  l.message,
+	})
+}
+
+func (h *Harvester) countLocked(category string) {
+	h.counts[category]++
+	eventCounts.Add(category, 1)
+}
+
+func (h *Harvester) addEventLocked(event *tabletmanagerdatapb.MysqlErrorLogEvent) {
+	if len(h.events) == maxEvents {
+		copy(h.events, h.events[1:])
+		h.events = h.events[:maxEvents-1]
+	}
+	h.events = append(h.events, event)
+}
+
+// recordDeadlockLocked records a deadlock spike event when the number of
+// deadlocks in the last deadlockSpikeWindow reaches the threshold. There is
+// a single event per spike, which ends when the number of deadlocks drops
+// below the threshold again.
+func (h *Harvester) recordDeadlockLocked(t time.Time) {
+	if h.deadlockSpikeThreshold <= 0 {
+		return
+	}
+	cutoff := t.Add(-deadlockSpikeWindow)
+	kept := h.deadlocks[:0]
+	for _, d := range h.deadlocks {
+		if d.After(cutoff) {
+			kept = append(kept, d)
+		}
+	}
+	h.deadlocks = append(kept, t)
+	if len(h.deadlocks) < h.deadlockSpikeThreshold {
+		h.inDeadlockSpike = false
+		return
+	}
+	if h.inDeadlockSpike {
+		return
+	}
+	h.inDeadlockSpike = true
+	h.countLocked(CategoryDeadlockSpike)
+	h.addEventLocked(&tabletmanagerdatapb.MysqlErrorLogEvent{
+		Time:     protoutil.TimeToProto(t),
+		Category: CategoryDeadlockSpike,
+		Message:  fmt.Sprintf("%d deadlocks in the last %v", len(h.deadlocks), deadlockSpikeWindow),
+	})
+}
+
+// Events returns the most recent events of the category, or of all the
+// categories if it is empty, the most recent first. A limit of 0 returns all
+// of them.
+func (h *Harvester) Events(category string, limit int) []*tabletmanagerdatapb.MysqlErrorLogEvent {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var events []*tabletmanagerdatapb.MysqlErrorLogEvent
+	for i := len(h.events) - 1; i >= 0; i-- {
+		if limit > 0 && len(events) == limit {
+			break
+		}
+		if category != "" && h.events[i].Category != category {
+			continue
+		}
+		events = append(events, h.events[i].CloneVT())
+	}
+	return events
+}
+
+// EventCounts returns the number of events of every category since the
+// Harvester was created.
+func (h *Harvester) EventCounts() map[string]int64 {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts := make(map[string]int64, len(h.counts))
+	for category, count := range h.counts {
+		counts[category] = count
+	}
+	return counts
+}
+
+// FullStatusEventCounts returns the number of events of every category in
+// the last 10 minutes, for the full status of the tablet. It returns nil if
+// --mysql-error-log-in-full-status is not set.
+func (h *Harvester) FullStatusEventCounts() map[string]int64 {
+	if h == nil || !h.inFullStatus {
+		return nil
+	}
+	return h.recentEventCounts(time.Now().Add(-fullStatusWindow))
+}
+
+func (h *Harvester) recentEventCounts(since time.Time) map[string]int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts := make(map[string]int64)
+	for _, event := range h.events {
+		if !protoutil.TimeFromProto(event.Time).Before(since) {
+			counts[event.Category]++
+		}
+	}
+	return counts
+}
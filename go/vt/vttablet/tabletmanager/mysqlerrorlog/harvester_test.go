@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlerrorlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/protoutil"
+)
+
+func TestClassify(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	testcases := []struct {
+		line     string
+		category string
+		severity string
+		time     time.Time
+	}{{
+		line:     "2026-01-01T10:00:00.123456Z 0 [System] [MY-013576] [InnoDB] InnoDB initialization has started.",
+		category: "",
+	}, {
+		line:     "2026-01-01T10:00:00.123456Z 1 [Note] [MY-012551] [InnoDB] Database was not shutdown normally!",
+		category: CategoryCrashRecovery,
+		severity: "Note",
+		time:     time.Date(2026, 1, 1, 10, 0, 0, 123456000, time.UTC),
+	}, {
+		line:     "2026-01-01T10:00:01Z 8 [Note] [MY-012468] [InnoDB] Transactions deadlock detected, dumping detailed information.",
+		category: CategoryDeadlock,
+		severity: "Note",
+		time:     time.Date(2026, 1, 1, 10, 0, 1, 0, time.UTC),
+	}, {
+		line:     "2026-01-01T10:00:02Z 0 [ERROR] [MY-012592] [InnoDB] Operating system error number 28 in a file operation.",
+		category: CategoryDiskError,
+		severity: "ERROR",
+		time:     time.Date(2026, 1, 1, 10, 0, 2, 0, time.UTC),
+	}, {
+		line:     "2026-01-01T10:00:03Z 0 [Warning] [MY-011153] [Repl] Timeout waiting for reply of binlog (file: binlog.000002, pos: 1234), semi-sync up to file , position 4.\n",
+		category: CategorySemiSyncTimeout,
+		severity: "Warning",
+		time:     time.Date(2026, 1, 1, 10, 0, 3, 0, time.UTC),
+	}, {
+		line:     "InnoDB: Error: write to file ./ibdata1 failed: No space left on device",
+		category: CategoryDiskError,
+		time:     now,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.line, func(t *testing.T) {
+			l, ok := classify(tc.line, now)
+			if tc.category == "" {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.category, l.category)
+			assert.Equal(t, tc.severity, l.severity)
+			assert.Equal(t, tc.time, l.time)
+			assert.NotContains(t, l.message, "\n")
+		})
+	}
+}
+
+func TestHarvesterProcessData(t *testing.T) {
+	h := NewHarvester("", time.Second, 3)
+	now := time.Now()
+
+	// A line split across polls is only classified once complete.
+	h.processData([]byte("2026-01-01T10:00:00Z 0 [ERROR] [MY-012592] [InnoDB] Operating system "), now)
+	assert.Empty(t, h.Events("", 0))
+	h.processData([]byte("error number 5 in a file operation.\n2026-01-01T10:00:01Z 0 [Note] Ready for connections.\n"), now)
+	events := h.Events("", 0)
+	require.Len(t, events, 1)
+	assert.Equal(t, CategoryDiskError, events[0].Category)
+	assert.Equal(t, "ERROR", events[0].Severity)
+	assert.Equal(t, "2026-01-01T10:00:00Z 0 [ERROR] [MY-012592] [InnoDB] Operating system error number 5 in a file operation.", events[0].Message)
+
+	// Deadlocks are only counted, with a single event per spike.
+	start := time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)
+	deadlock := func(t time.Time) string {
+		return fmt.Sprintf("%s 8 [Note] [MY-012468] [InnoDB] Transactions deadlock detected, dumping detailed information.\n", t.Format(time.RFC3339Nano))
+	}
+	for i := range 5 {
+		h.processData([]byte(deadlock(start.Add(time.Duration(i)*time.Second))), now)
+	}
+	spikes := h.Events(CategoryDeadlockSpike, 0)
+	require.Len(t, spikes, 1)
+	assert.Equal(t, "3 deadlocks in the last 1m0s", spikes[0].Message)
+	assert.Equal(t, start.Add(2*time.Second), protoutil.TimeFromProto(spikes[0].Time).UTC())
+
+	// Once the deadlocks drop below the threshold, the next spike is
+	// recorded again.
+	later := start.Add(10 * time.Minute)
+	for i := range 3 {
+		h.processData([]byte(deadlock(later.Add(time.Duration(i)*time.Second))), now)
+	}
+	assert.Len(t, h.Events(CategoryDeadlockSpike, 0), 2)
+
+	assert.Equal(t, map[string]int64{
+		CategoryDiskError:     1,
+		CategoryDeadlock:      8,
+		CategoryDeadlockSpike: 2,
+	}, h.EventCounts())
+
+	// The most recent events come first.
+	events = h.Events("", 2)
+	require.Len(t, events, 2)
+	assert.Equal(t, CategoryDeadlockSpike, events[0].Category)
+	assert.Equal(t, CategoryDeadlockSpike, events[1].Category)
+	events = h.Events(CategoryDiskError, 1)
+	require.Len(t, events, 1)
+	assert.Equal(t, CategoryDiskError, events[0].Category)
+
+	counts := h.recentEventCounts(later)
+	assert.Equal(t, map[string]int64{CategoryDeadlockSpike: 1}, counts)
+}
+
+func TestHarvesterMaxEvents(t *testing.T) {
+	h := NewHarvester("", time.Second, 0)
+	for i := range maxEvents + 10 {
+		h.processData(fmt.Appendf(nil, "[ERROR] No space left on device %d\n", i), time.Now())
+	}
+	events := h.Events("", 0)
+	require.Len(t, events, maxEvents)
+	assert.Equal(t, fmt.Sprintf("[ERROR] No space left on device %d", maxEvents+9), events[0].Message)
+	assert.Equal(t, "[ERROR] No space left on device 10", events[maxEvents-1].Message)
+	assert.Equal(t, int64(maxEvents+10), h.EventCounts()[CategoryDiskError])
+}
+
+func TestHarvesterTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "error.log")
+	appendLog := func(line string) {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		defer f.Close()
+		_, err = f.WriteString(line + "\n")
+		require.NoError(t, err)
+	}
+	appendLog("2026-01-01T10:00:00Z 0 [Note] [MY-012551] [InnoDB] Database was not shutdown normally!")
+
+	h := NewHarvester(path, 10*time.Millisecond, 0)
+	h.Open()
+	defer h.Close()
+
+	waitForEvents := func(n int) {
+		assert.Eventually(t, func() bool {
+			return len(h.Events("", 0)) == n
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	// The events logged before the harvester started are classified.
+	waitForEvents(1)
+
+	appendLog("2026-01-01T10:00:01Z 0 [Warning] [MY-011153] [Repl] Timeout waiting for reply of binlog (file: binlog.000002, pos: 1234)")
+	waitForEvents(2)
+
+	// The rotated error log is read from its start.
+	require.NoError(t, os.Rename(path, path+".1"))
+	appendLog("2026-01-01T10:00:02Z 0 [ERROR] [MY-012592] [InnoDB] Operating system error number 28 in a file operation.")
+	waitForEvents(3)
+
+	h.Close()
+	events := h.Events("", 0)
+	require.Len(t, events, 3)
+	assert.Equal(t, CategoryDiskError, events[0].Category)
+	assert.Equal(t, CategorySemiSyncTimeout, events[1].Category)
+	assert.Equal(t, CategoryCrashRecovery, events[2].Category)
+}
+
+func TestNilHarvester(t *testing.T) {
+	var h *Harvester
+	h.Open()
+	h.Close()
+	assert.Nil(t, h.Events("", 0))
+	assert.Nil(t, h.EventCounts())
+	assert.Nil(t, h.FullStatusEventCounts())
+}
@@ -95,6 +95,10 @@ type RPCTM interface {
 
 	MysqlHostMetrics(ctx context.Context, req *tabletmanagerdatapb.MysqlHostMetricsRequest) (*tabletmanagerdatapb.MysqlHostMetricsResponse, error)
 
+	GetMysqlErrorLogEvents(ctx context.Context, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error)
+
+	GetQueryPlans(ctx context.Context, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error)
+
 	// Replication related methods
 	PrimaryStatus(ctx context.Context) (*replicationdatapb.PrimaryStatus, error)
 
@@ -280,6 +280,22 @@ func (tm *TabletManager) MysqlHostMetrics(ctx context.Context, req *tabletmanage
 	return resp, nil
 }
 
+// GetMysqlErrorLogEvents returns the recent notable events of the MySQL error
+// log.
+func (tm *TabletManager) GetMysqlErrorLogEvents(ctx context.Context, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error) {
+	return &tabletmanagerdatapb.GetMysqlErrorLogEventsResponse{
+		Events:      tm.MysqlErrorLog.Events(req.Category, int(req.Limit)),
+		EventCounts: tm.MysqlErrorLog.EventCounts(),
+		Enabled:     tm.MysqlErrorLog != nil,
+	}, nil
+}
+
+// GetQueryPlans returns the query plans cached by the query service, after
+// evicting, pinning or unpinning the plans of the given queries.
+func (tm *TabletManager) GetQueryPlans(ctx context.Context, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	return tm.QueryServiceControl.GetQueryPlans(ctx, req)
+}
+
 // ExecuteQuery submits a new online DDL request
 func (tm *TabletManager) ExecuteQuery(ctx context.Context, req *tabletmanagerdatapb.ExecuteQueryRequest) (*querypb.QueryResult, error) {
 	if err := tm.waitForGrantsToHaveApplied(ctx); err != nil {
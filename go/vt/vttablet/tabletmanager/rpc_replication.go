@@ -191,6 +191,7 @@ func (tm *TabletManager) FullStatus(ctx context.Context) (*replicationdatapb.Ful
 		SuperReadOnly:               superReadOnly,
 		ReplicationConfiguration:    replConfiguration,
 		TabletType:                  tm.Tablet().Type,
+		MysqlErrorLogEventCounts:    tm.MysqlErrorLog.FullStatusEventCounts(),
 	}, nil
 }
 
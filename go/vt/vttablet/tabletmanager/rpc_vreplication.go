@@ -185,6 +185,7 @@ func (tm *TabletManager) DeleteTableData(ctx context.Context, req *tabletmanager
 		batchSize = movetables.DefaultDeleteBatchSize
 	}
 	limit := &sqlparser.Limit{Rowcount: sqlparser.NewIntLiteral(strconv.FormatInt(batchSize, 10))}
+	resp := &tabletmanagerdatapb.DeleteTableDataResponse{}
 	// We will log some progress info every 100 delete batches.
 	progressRows := uint64(batchSize * 100)
 
@@ -250,9 +251,10 @@ func (tm *TabletManager) DeleteTableData(ctx context.Context, req *tabletmanager
 		}
 		log.Infof("Completed deletion of data (%d rows) from table %s using query %q",
 			rowsDeleted, table, query)
+		resp.RowsDeleted += rowsDeleted
 	}
 
-	return &tabletmanagerdatapb.DeleteTableDataResponse{}, nil
+	return resp, nil
 }
 
 func (tm *TabletManager) DeleteVReplicationWorkflow(ctx context.Context, req *tabletmanagerdatapb.DeleteVReplicationWorkflowRequest) (*tabletmanagerdatapb.DeleteVReplicationWorkflowResponse, error) {
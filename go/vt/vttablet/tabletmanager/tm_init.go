@@ -75,6 +75,7 @@ import (
 	"vitess.io/vitess/go/vt/vtctl/reparentutil/policy"
 	"vitess.io/vitess/go/vt/vtenv"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/mysqlerrorlog"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/semisyncmonitor"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vdiff"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
@@ -98,6 +99,7 @@ var (
 	initDbNameOverride   string
 	skipBuildInfoTags    = "/.*/"
 	initTags             flagutil.StringMapValue
+	storageClass         string
 
 	initTimeout          = 1 * time.Minute
 	mysqlShutdownTimeout = mysqlctl.DefaultShutdownTimeout
@@ -112,6 +114,7 @@ func registerInitFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &initDbNameOverride, "init-db-name-override", initDbNameOverride, "(init parameter) override the name of the db used by vttablet. Without this flag, the db name defaults to vt_<keyspacename>")
 	utils.SetFlagStringVar(fs, &skipBuildInfoTags, "vttablet-skip-buildinfo-tags", skipBuildInfoTags, "comma-separated list of buildinfo tags to skip from merging with --init-tags. each tag is either an exact match or a regular expression of the form '/regexp/'.")
 	utils.SetFlagVar(fs, &initTags, "init-tags", "(init parameter) comma separated list of key:value pairs used to tag the tablet")
+	fs.StringVar(&storageClass, "storage-class", storageClass, "(init parameter) class of the storage backing the MySQL of the tablet, such as nvme or capacity, set as its storage_class tag. vtgate may route the queries of each workload to the tablets of a storage class with --workload-storage-classes.")
 	utils.SetFlagDurationVar(fs, &initTimeout, "init-timeout", initTimeout, "(init parameter) timeout to use for the init phase.")
 	fs.DurationVar(&mysqlShutdownTimeout, "mysql-shutdown-timeout", mysqlShutdownTimeout, "Timeout to use when MySQL is being shut down.")
 }
@@ -169,6 +172,7 @@ type TabletManager struct {
 	VREngine            *vreplication.Engine
 	SemiSyncMonitor     *semisyncmonitor.Monitor
 	VDiffEngine         *vdiff.Engine
+	MysqlErrorLog       *mysqlerrorlog.Harvester
 	Env                 *vtenv.Environment
 
 	// tmc is used to run an RPC against other vttablets.
@@ -265,6 +269,10 @@ func BuildTabletFromInput(alias *topodatapb.TabletAlias, port, grpcPort int32, d
 	if err != nil {
 		return nil, err
 	}
+	tags := mergeTags(buildTags, initTags)
+	if storageClass != "" {
+		tags[topoproto.StorageClassTag] = storageClass
+	}
 
 	var charset collations.ID
 	if db != nil && db.Charset != "" {
@@ -288,7 +296,7 @@ func BuildTabletFromInput(alias *topodatapb.TabletAlias, port, grpcPort int32, d
 		KeyRange:             keyRange,
 		Type:                 tabletType,
 		DbNameOverride:       initDbNameOverride,
-		Tags:                 mergeTags(buildTags, initTags),
+		Tags:                 tags,
 		DefaultConnCollation: uint32(charset),
 		TabletStartTime:      protoutil.TimeToProto(time.Now()),
 		TabletShutdownTime:   nil,
@@ -449,6 +457,7 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, config *tabletenv.Tabl
 
 	if tm.VREngine != nil {
 		tm.VREngine.InitDBConfig(tm.DBConfigs)
+		tm.VREngine.SetStateChangeNotifier(tm.QueryServiceControl.BroadcastVReplicationStateChange)
 		servenv.OnTerm(tm.VREngine.Close)
 	}
 
@@ -457,11 +466,17 @@ func (tm *TabletManager) Start(tablet *topodatapb.Tablet, config *tabletenv.Tabl
 		servenv.OnTerm(tm.VDiffEngine.Close)
 	}
 
+	if tm.MysqlErrorLog != nil {
+		tm.MysqlErrorLog.Open()
+		servenv.OnTerm(tm.MysqlErrorLog.Close)
+	}
+
 	// The following initializations don't need to be done
 	// in any specific order.
 	tm.startShardSync()
 	tm.exportStats()
 	servenv.OnRun(tm.registerTabletManager)
+	servenv.OnRun(tm.registerConfigSnapshotHandler)
 
 	restoring, err := tm.handleRestore(tm.BatchCtx, config)
 	if err != nil {
@@ -548,6 +563,8 @@ func (tm *TabletManager) Stop() {
 		tm.VDiffEngine.Close()
 	}
 
+	tm.MysqlErrorLog.Close()
+
 	tm.MysqlDaemon.Close()
 	tm.tmState.Close()
 }
@@ -884,6 +901,31 @@ func (tm *TabletManager) handleRestore(ctx context.Context, config *tabletenv.Ta
 	if restoreToTimestampStr != "" && restoreToPos != "" {
 		return false, errors.New("--restore-to-timestamp and --restore-to-pos are mutually exclusive")
 	}
+	if restoreFromBackup && mysqlctl.CloneFromDonorEnabled() {
+		return false, errors.New("--restore-from-backup and --clone-from-primary/--clone-from-tablet are mutually exclusive")
+	}
+	if tm.Cnf == nil && mysqlctl.CloneFromDonorEnabled() {
+		return false, errors.New("you cannot enable --clone-from-primary or --clone-from-tablet without a my.cnf file")
+	}
+
+	// Clone from a donor in the background
+	if mysqlctl.CloneFromDonorEnabled() {
+		go func() {
+			if err := tm.CloneData(ctx, logutil.NewConsoleLogger()); err != nil {
+				log.Exitf("CloneFromDonor failed: %v", err)
+			}
+
+			// Make sure we have the correct privileges for the DBA user before we start the state manager.
+			err := tm.waitForDBAGrants(config, mysqlctl.DbaGrantWaitTime)
+			if err != nil {
+				log.Exitf("Failed waiting for DBA grants: %v", err)
+			}
+
+			// Open the state manager after the clone is done.
+			tm.tmState.Open()
+		}()
+		return true, nil
+	}
 
 	// Restore in the background
 	if restoreFromBackup {
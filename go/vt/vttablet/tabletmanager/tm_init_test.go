@@ -39,6 +39,7 @@ import (
 	"vitess.io/vitess/go/vt/servenv"
 	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/topotools"
 	"vitess.io/vitess/go/vt/vttablet/tabletmanager/semisyncmonitor"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
@@ -179,6 +180,13 @@ func TestBuildTabletFromInputWithBuildTags(t *testing.T) {
 	// Zero out TabletStartTime for comparison since it's dynamic
 	gotTablet.TabletStartTime = &vttime.Time{}
 	assert.Equal(t, wantTablet, gotTablet)
+
+	// The storage class is set as a tag.
+	storageClass = "capacity"
+	defer func() { storageClass = "" }()
+	gotTablet, err = BuildTabletFromInput(alias, port, grpcport, nil, collations.MySQL8())
+	require.NoError(t, err)
+	assert.Equal(t, "capacity", gotTablet.Tags[topoproto.StorageClassTag])
 }
 
 func TestStartCreateKeyspaceShard(t *testing.T) {
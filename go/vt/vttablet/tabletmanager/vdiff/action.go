@@ -46,6 +46,8 @@ const (
 	StopAction    VDiffAction = "stop"
 	ResumeAction  VDiffAction = "resume"
 	DeleteAction  VDiffAction = "delete"
+	RestartAction VDiffAction = "restart" // resume, discarding the checkpointed progress
+	RepairAction  VDiffAction = "repair"
 	AllActionArg              = "all"
 	LastActionArg             = "last"
 
@@ -53,7 +55,7 @@ const (
 )
 
 var (
-	Actions    = []VDiffAction{CreateAction, ShowAction, StopAction, ResumeAction, DeleteAction}
+	Actions    = []VDiffAction{CreateAction, ShowAction, StopAction, ResumeAction, DeleteAction, RestartAction, RepairAction}
 	ActionArgs = []string{AllActionArg, LastActionArg}
 
 	// The real zero value has nested nil pointers.
@@ -93,7 +95,7 @@ func (vde *Engine) PerformVDiffAction(ctx context.Context, req *tabletmanagerdat
 
 	action := VDiffAction(req.Action)
 	switch action {
-	case CreateAction, ResumeAction:
+	case CreateAction, ResumeAction, RestartAction:
 		if err := vde.handleCreateResumeAction(ctx, dbClient, action, req, resp); err != nil {
 			return nil, err
 		}
@@ -109,6 +111,10 @@ func (vde *Engine) PerformVDiffAction(ctx context.Context, req *tabletmanagerdat
 		if err := vde.handleDeleteAction(ctx, dbClient, req, resp); err != nil {
 			return nil, err
 		}
+	case RepairAction:
+		if err := vde.handleRepairAction(ctx, dbClient, req, resp); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("action %s not supported", action)
 	}
@@ -244,6 +250,11 @@ func (vde *Engine) handleCreateResumeAction(ctx context.Context, dbClient binlog
 			}
 			return qr.RowsAffected, nil
 		}
+		if action == RestartAction {
+			if _, err := execResume(sqlResetVDiffTablesProgress); err != nil {
+				return err
+			}
+		}
 		rowsAffected, err := execResume(sqlResumeVDiff)
 		if err != nil {
 			return err
@@ -270,7 +281,7 @@ func (vde *Engine) handleCreateResumeAction(ctx context.Context, dbClient binlog
 		return fmt.Errorf("unable to %s vdiff for UUID %s as it was not found on tablet %v (%w)",
 			action, req.VdiffUuid, topoproto.TabletAliasString(vde.thisTablet.Alias), err)
 	}
-	if action == ResumeAction {
+	if action == ResumeAction || action == RestartAction {
 		// Use the existing options from the vdiff record.
 		options = optionsZeroVal
 		err = protojson.Unmarshal(vdiffRecord.AsBytes("options", []byte("{}")), options)
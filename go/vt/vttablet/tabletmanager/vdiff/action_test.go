@@ -226,6 +226,46 @@ func TestPerformVDiffAction(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "restart completed vdiff",
+			req: &tabletmanagerdatapb.VDiffRequest{
+				Action:    string(RestartAction),
+				VdiffUuid: uuid,
+				Keyspace:  keyspace,
+				Workflow:  workflow,
+			},
+			expectQueries: []queryAndResult{
+				{
+					query: "select id as id from _vt.vdiff where vdiff_uuid = " + encodeString(uuid),
+					result: sqltypes.MakeTestResult(
+						sqltypes.MakeTestFields(
+							"id",
+							"int64",
+						),
+						"1",
+					),
+				},
+				{
+					query: fmt.Sprintf(`update _vt.vdiff as vd, _vt.vdiff_table as vdt set vdt.lastpk = NULL, vdt.rows_compared = 0,
+					vdt.mismatch = false, vdt.report = NULL where vd.vdiff_uuid = %s and vd.id = vdt.vdiff_id
+					and vd.state in ('completed', 'stopped') and vdt.state in ('completed', 'stopped')`, encodeString(uuid)),
+					result: &sqltypes.Result{
+						RowsAffected: 1,
+					},
+				},
+				{
+					query: fmt.Sprintf(`update _vt.vdiff as vd, _vt.vdiff_table as vdt set vd.started_at = NULL, vd.completed_at = NULL, vd.state = 'pending',
+					vdt.state = 'pending' where vd.vdiff_uuid = %s and vd.id = vdt.vdiff_id and vd.state in ('completed', 'stopped')
+					and vdt.state in ('completed', 'stopped')`, encodeString(uuid)),
+					result: &sqltypes.Result{
+						RowsAffected: 1,
+					},
+				},
+				{
+					query: "select * from _vt.vdiff where id = 1",
+				},
+			},
+		},
 		{
 			name: "delete by uuid",
 			req: &tabletmanagerdatapb.VDiffRequest{
@@ -77,6 +77,7 @@ type controller struct {
 	sourceTimeZone, targetTimeZone string // Named time zones if conversions are necessary for datetime values
 
 	externalCluster string // For Mount+Migrate
+	externalMysql   string // For Migrate from an external MySQL, as defined in the tablet's externalConnections
 
 	// Information used in vdiff stats/metrics.
 	Errors                *stats.CountersWithSingleLabel
@@ -227,6 +228,9 @@ func (ct *controller) start(ctx context.Context, dbClient binlogplayer.DBClient)
 		if bls.ExternalCluster != "" {
 			ct.externalCluster = bls.ExternalCluster
 		}
+		if bls.ExternalMysql != "" {
+			ct.externalMysql = bls.ExternalMysql
+		}
 
 		ct.sources[source.shard] = source
 		if i == 0 {
@@ -173,6 +173,7 @@ func TestVDiff(t *testing.T) {
 	), nil)
 	vdenv.dbClient.ExpectRequest("update _vt.vdiff_table set state = 'started' where vdiff_id = 1 and table_name = 't1'", singleRowAffected, nil)
 	vdenv.dbClient.ExpectRequest(`insert into _vt.vdiff_log(vdiff_id, message) values (1, 'started: table \'t1\'')`, singleRowAffected, nil)
+	vdenv.dbClient.ExpectRequest(fmt.Sprintf("select id from _vt.vreplication where workflow = '%s' and db_name = '%s' and message = 'PAUSED'", vdiffenv.workflow, vdiffDBName), noResults, nil)
 	vdenv.dbClient.ExpectRequest(fmt.Sprintf("select id, source, pos from _vt.vreplication where workflow = '%s' and db_name = '%s'", vdiffenv.workflow, vdiffDBName), sqltypes.MakeTestResult(sqltypes.MakeTestFields(
 		"id|source|pos",
 		"int64|varbinary|varbinary",
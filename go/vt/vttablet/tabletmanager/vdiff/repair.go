@@ -0,0 +1,739 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+	"vitess.io/vitess/go/vt/vttablet/tabletmanager/vreplication"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const (
+	// RepairDryRunActionArg reports the statements that would repair the rows found
+	// to differ by a vdiff, without executing them.
+	RepairDryRunActionArg = "dry-run"
+	// RepairApplyActionArg executes the statements that repair the rows found to
+	// differ by a vdiff on the target.
+	RepairApplyActionArg = "apply"
+
+	repairInsert     = "insert"
+	repairUpdate     = "update"
+	repairDelete     = "delete"
+	repairUnsampled  = "unsampled"
+	repairStatusDry  = "dry-run"
+	repairStatusDone = "applied"
+)
+
+var (
+	// The data types whose values are reported by vdiff as hex strings.
+	repairBinaryDataTypes = map[string]bool{
+		"binary": true, "varbinary": true, "tinyblob": true, "blob": true, "mediumblob": true, "longblob": true,
+		"bit": true, "geometry": true, "point": true, "linestring": true, "polygon": true, "multipoint": true,
+		"multilinestring": true, "multipolygon": true, "geometrycollection": true, "geomcollection": true,
+	}
+	// The data types for which an empty value cannot be told apart from NULL in a vdiff report.
+	repairTextDataTypes = map[string]bool{
+		"char": true, "varchar": true, "tinytext": true, "text": true, "mediumtext": true, "longtext": true,
+		"enum": true, "set": true, "json": true,
+	}
+)
+
+// repairTable is the target schema information needed to repair the rows of a table.
+type repairTable struct {
+	name      string
+	columns   map[string]*repairColumn
+	pkColumns []string
+}
+
+type repairColumn struct {
+	dataType  string
+	nullable  bool
+	generated bool
+}
+
+// repairStatement is a statement that repairs a single row of the target. It is not
+// executed if skipReason is set, as the row could not be repaired.
+type repairStatement struct {
+	table      string
+	operation  string
+	query      string
+	skipReason string
+}
+
+// repairSource reads the current rows of a table on the source of a workflow.
+type repairSource interface {
+	// readRow returns the source row that matches the given primary key condition, or
+	// nil if there is no such row that belongs on this shard.
+	readRow(ctx context.Context, where string) (sqltypes.RowNamedValues, error)
+}
+
+// repairTargetReader executes a query on the target, within the repair transaction
+// when the statements are applied.
+type repairTargetReader func(query string) (*sqltypes.Result, error)
+
+// handleRepairAction generates the INSERT, UPDATE and DELETE statements that make the
+// rows of the target match the rows of the source, for the rows that a completed vdiff
+// found to differ on this shard, and executes them if requested.
+// The vdiff report is only used to find the primary keys of the rows that differed: the
+// rows are read again from the source and the target, as they may have changed since the
+// vdiff ran. Only the rows that are sampled in the report can be repaired, so the vdiff
+// should be created with a --max-report-sample-rows value that covers all the diffs.
+func (vde *Engine) handleRepairAction(ctx context.Context, dbClient binlogplayer.DBClient, req *tabletmanagerdatapb.VDiffRequest, resp *tabletmanagerdatapb.VDiffResponse) error {
+	var apply bool
+	switch req.ActionArg {
+	case RepairDryRunActionArg, "":
+	case RepairApplyActionArg:
+		apply = true
+	default:
+		return fmt.Errorf("action argument %s not supported", req.ActionArg)
+	}
+	query, err := sqlparser.ParseAndBind(sqlGetVDiffByKeyspaceWorkflowUUID,
+		sqltypes.StringBindVariable(req.Keyspace),
+		sqltypes.StringBindVariable(req.Workflow),
+		sqltypes.StringBindVariable(req.VdiffUuid),
+	)
+	if err != nil {
+		return err
+	}
+	qr, err := dbClient.ExecuteFetch(query, 1)
+	if err != nil {
+		return err
+	}
+	vdiffRecord := qr.Named().Row()
+	if vdiffRecord == nil {
+		return vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "no vdiff found for UUID %s keyspace %s and workflow %s on tablet %s",
+			req.VdiffUuid, req.Keyspace, req.Workflow, topoproto.TabletAliasString(vde.thisTablet.Alias))
+	}
+	if state := VDiffState(vdiffRecord.AsString("state", "")); state != CompletedState {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "can only repair a completed vdiff, vdiff %s is %s on tablet %s",
+			req.VdiffUuid, state, topoproto.TabletAliasString(vde.thisTablet.Alias))
+	}
+	vdiffID := vdiffRecord.AsInt64("id", 0)
+	dbName := vdiffRecord.AsString("db_name", topoproto.TabletDbName(vde.thisTablet))
+
+	query, err = sqlparser.ParseAndBind(sqlGetVDiffTableReports, sqltypes.Int64BindVariable(vdiffID))
+	if err != nil {
+		return err
+	}
+	if qr, err = dbClient.ExecuteFetch(query, -1); err != nil {
+		return err
+	}
+	var stmts []*repairStatement
+	for _, row := range qr.Named().Rows {
+		reportJSON := row.AsBytes("report", nil)
+		if len(reportJSON) == 0 {
+			continue
+		}
+		dr := &DiffReport{}
+		if err := json.Unmarshal(reportJSON, dr); err != nil {
+			return vterrors.Wrapf(err, "invalid report for table %s", row.AsString("table_name", ""))
+		}
+		if dr.MismatchedRows == 0 && dr.ExtraRowsSource == 0 && dr.ExtraRowsTarget == 0 {
+			continue
+		}
+		table, err := getRepairTable(dbClient, dbName, row.AsString("table_name", ""))
+		if err != nil {
+			return err
+		}
+		source, skipReason, err := vde.newRepairSource(ctx, dbClient, req.Workflow, dbName, table.name)
+		if err != nil {
+			return err
+		}
+		if skipReason != "" {
+			stmts = append(stmts, &repairStatement{table: table.name, skipReason: skipReason})
+			continue
+		}
+		tableStmts, err := vde.repairTableRows(ctx, dbClient, dbName, table, dr, source, apply)
+		if err != nil {
+			return vterrors.Wrapf(err, "failed to repair table %s", table.name)
+		}
+		stmts = append(stmts, tableStmts...)
+	}
+
+	resp.VdiffUuid = req.VdiffUuid
+	resp.Id = vdiffID
+	resp.Output = sqltypes.ResultToProto3(repairResult(stmts, apply))
+	return nil
+}
+
+// repairTableRows generates the statements that repair the reported rows of a table and, if
+// requested, executes them. When applying, the target rows are read and locked within the
+// transaction that repairs them.
+func (vde *Engine) repairTableRows(ctx context.Context, dbClient binlogplayer.DBClient, dbName string, table *repairTable, dr *DiffReport, source repairSource, apply bool) ([]*repairStatement, error) {
+	readTarget := func(query string) (*sqltypes.Result, error) {
+		return dbClient.ExecuteFetch(query, 2)
+	}
+	if !apply {
+		return genRepairStatements(ctx, vde.parser, dbName, table, dr, source, readTarget)
+	}
+	if err := dbClient.Begin(); err != nil {
+		return nil, err
+	}
+	stmts, err := genRepairStatements(ctx, vde.parser, dbName, table, dr, source, readTarget)
+	if err == nil {
+		err = applyRepairStatements(dbClient, stmts)
+	}
+	if err != nil {
+		_ = dbClient.Rollback()
+		return nil, err
+	}
+	return stmts, dbClient.Commit()
+}
+
+// getRepairTable reads the column types and the primary key of the given target table.
+func getRepairTable(dbClient binlogplayer.DBClient, dbName, tableName string) (*repairTable, error) {
+	table := &repairTable{
+		name:    tableName,
+		columns: make(map[string]*repairColumn),
+	}
+	query, err := sqlparser.ParseAndBind(sqlGetRepairTableColumns, sqltypes.StringBindVariable(dbName), sqltypes.StringBindVariable(tableName))
+	if err != nil {
+		return nil, err
+	}
+	qr, err := dbClient.ExecuteFetch(query, -1)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range qr.Named().Rows {
+		column := &repairColumn{
+			dataType:  strings.ToLower(row.AsString("data_type", "")),
+			nullable:  strings.EqualFold(row.AsString("is_nullable", ""), "yes"),
+			generated: strings.Contains(strings.ToUpper(row.AsString("extra", "")), "GENERATED"),
+		}
+		table.columns[strings.ToLower(row.AsString("column_name", ""))] = column
+		if strings.EqualFold(row.AsString("column_key", ""), "pri") {
+			table.pkColumns = append(table.pkColumns, row.AsString("column_name", ""))
+		}
+	}
+	if len(table.columns) == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "table %s not found in %s", tableName, dbName)
+	}
+	return table, nil
+}
+
+// genRepairStatements generates the statements that repair the rows sampled in the given
+// report of a table. The primary key of each reported row is used to read the current row
+// from both the source and the target, and the statement is generated from those values.
+// Rows whose primary key cannot be recovered from the report, e.g. because it was
+// truncated, and rows that no longer differ, result in a statement that is skipped, along
+// with the reason.
+func genRepairStatements(ctx context.Context, parser *sqlparser.Parser, dbName string, table *repairTable, dr *DiffReport,
+	source repairSource, readTarget repairTargetReader) ([]*repairStatement, error) {
+	var stmts []*repairStatement
+	add := func(operation string, query string, skipReason string) {
+		stmts = append(stmts, &repairStatement{
+			table:      table.name,
+			operation:  operation,
+			query:      query,
+			skipReason: skipReason,
+		})
+	}
+	if len(table.pkColumns) == 0 {
+		add("", "", "table has no primary key")
+		return stmts, nil
+	}
+	tableName := sqlparser.String(sqlparser.NewIdentifierCS(dbName)) + "." + sqlparser.String(sqlparser.NewIdentifierCS(table.name))
+
+	reported := make([]*RowDiff, 0, len(dr.ExtraRowsSourceDiffs)+len(dr.MismatchedRowsDiffs)+len(dr.ExtraRowsTargetDiffs))
+	reported = append(reported, dr.ExtraRowsSourceDiffs...)
+	for _, mismatch := range dr.MismatchedRowsDiffs {
+		if mismatch.Source != nil {
+			reported = append(reported, mismatch.Source)
+		} else if mismatch.Target != nil {
+			reported = append(reported, mismatch.Target)
+		}
+	}
+	reported = append(reported, dr.ExtraRowsTargetDiffs...)
+
+	seen := make(map[string]bool, len(reported))
+	for _, rd := range reported {
+		// Only the primary key is taken from the report, so the other values do not
+		// have to be recoverable.
+		values, err := repairRowValues(parser, table, &RowDiff{Row: pkOnlyRow(parser, table, rd)})
+		if err != nil {
+			add("", "", err.Error())
+			continue
+		}
+		where, err := repairWhereClause(table, values)
+		if err != nil {
+			add("", "", err.Error())
+			continue
+		}
+		if seen[where] {
+			continue
+		}
+		seen[where] = true
+
+		qr, err := readTarget(fmt.Sprintf("select * from %s where %s for update", tableName, where))
+		if err != nil {
+			return nil, err
+		}
+		targetRow := qr.Named().Row()
+		sourceRow, err := source.readRow(ctx, where)
+		if err != nil {
+			return nil, err
+		}
+		add(genRepairStatement(tableName, table, where, sourceRow, targetRow))
+	}
+
+	// The report only has a sample of the rows that differ.
+	unsampled := []struct {
+		operation string
+		count     int64
+	}{
+		{repairInsert, dr.ExtraRowsSource - int64(len(dr.ExtraRowsSourceDiffs))},
+		{repairUpdate, dr.MismatchedRows - int64(len(dr.MismatchedRowsDiffs))},
+		{repairDelete, dr.ExtraRowsTarget - int64(len(dr.ExtraRowsTargetDiffs))},
+	}
+	for _, u := range unsampled {
+		if u.count > 0 {
+			add(repairUnsampled, "", fmt.Sprintf("%d rows to %s were not sampled in the vdiff report, run a new vdiff with a higher --max-report-sample-rows value", u.count, u.operation))
+		}
+	}
+	return stmts, nil
+}
+
+// genRepairStatement returns the operation and the statement that make the current target
+// row with the given primary key match the current source row, or the reason why the row
+// is not repaired.
+func genRepairStatement(tableName string, table *repairTable, where string, sourceRow, targetRow sqltypes.RowNamedValues) (string, string, string) {
+	switch {
+	case sourceRow == nil && targetRow == nil:
+		return "", "", fmt.Sprintf("the row where %s no longer exists on the source or the target", where)
+	case sourceRow == nil:
+		return repairDelete, fmt.Sprintf("delete from %s where %s", tableName, where), ""
+	}
+	var columns []string
+	for column := range sourceRow {
+		col, ok := table.columns[strings.ToLower(column)]
+		if !ok {
+			return "", "", fmt.Sprintf("column %s not found in the target table", column)
+		}
+		if col.generated {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	if targetRow == nil {
+		buf.Myprintf("insert into %s(", tableName)
+		for i, column := range columns {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.Myprintf("%v", sqlparser.NewIdentifierCI(column))
+		}
+		buf.WriteString(") values (")
+		for i, column := range columns {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			sourceRow[column].EncodeSQL(buf)
+		}
+		buf.WriteString(")")
+		return repairInsert, buf.String(), ""
+	}
+
+	buf.Myprintf("update %s set ", tableName)
+	first := true
+	for _, column := range columns {
+		if isPKColumn(table, column) {
+			continue
+		}
+		sourceValue, targetValue := sourceRow[column], targetRow[column]
+		if sourceValue.IsNull() == targetValue.IsNull() && bytes.Equal(sourceValue.Raw(), targetValue.Raw()) {
+			continue
+		}
+		if !first {
+			buf.WriteString(", ")
+		}
+		first = false
+		buf.Myprintf("%v = ", sqlparser.NewIdentifierCI(column))
+		sourceValue.EncodeSQL(buf)
+	}
+	if first {
+		return "", "", fmt.Sprintf("the row where %s no longer differs", where)
+	}
+	buf.Myprintf(" where %s", where)
+	return repairUpdate, buf.String(), ""
+}
+
+// tabletRepairSource reads the source rows of a table from the primaries of the source
+// shards of a workflow.
+type tabletRepairSource struct {
+	tmc     tmclient.TabletManagerClient
+	tablets []*topodatapb.Tablet
+	table   string
+	// vindex is set when only the source rows that map to keyRange belong on this shard.
+	vindex   *vindexes.ColumnVindex
+	keyRange *topodatapb.KeyRange
+}
+
+// newRepairSource returns the source of the given table for the streams of the workflow on
+// this tablet. A reason is returned instead if the rows of the table cannot be read back
+// from the source, e.g. because the workflow filters or transforms them.
+func (vde *Engine) newRepairSource(ctx context.Context, dbClient binlogplayer.DBClient, workflow, dbName, tableName string) (repairSource, string, error) {
+	query := sqlparser.BuildParsedQuery(sqlGetVReplicationEntry,
+		fmt.Sprintf("where workflow = %s and db_name = %s", encodeString(workflow), encodeString(dbName)))
+	qr, err := dbClient.ExecuteFetch(query.Query, -1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(qr.Rows) == 0 {
+		return nil, "", vterrors.Errorf(vtrpcpb.Code_NOT_FOUND, "no streams found for workflow %s on tablet %s",
+			workflow, topoproto.TabletAliasString(vde.thisTablet.Alias))
+	}
+	source := &tabletRepairSource{
+		tmc:   vde.tmClientFactory(),
+		table: tableName,
+	}
+	var inKeyRange bool
+	for _, row := range qr.Named().Rows {
+		var bls binlogdatapb.BinlogSource
+		if err := prototext.Unmarshal(row.AsBytes("source", nil), &bls); err != nil {
+			return nil, "", err
+		}
+		if bls.ExternalCluster != "" || bls.ExternalMysql != "" {
+			return nil, "workflows from an external source are not supported", nil
+		}
+		rule, err := vreplication.MatchTable(tableName, bls.Filter)
+		if err != nil {
+			return nil, "", err
+		}
+		if rule == nil {
+			return nil, fmt.Sprintf("no filter found for the table in the stream from %s/%s", bls.Keyspace, bls.Shard), nil
+		}
+		sourceTable, keyRangeOnly, ok := repairSourceTable(vde.parser, tableName, rule.Filter)
+		if !ok {
+			return nil, fmt.Sprintf("the workflow filter %q is not supported, only the tables copied as is can be repaired", rule.Filter), nil
+		}
+		source.table = sourceTable
+		inKeyRange = inKeyRange || keyRangeOnly
+
+		si, err := vde.ts.GetShard(ctx, bls.Keyspace, bls.Shard)
+		if err != nil {
+			return nil, "", err
+		}
+		if si.PrimaryAlias == nil {
+			return nil, "", vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "source shard %s/%s has no primary", bls.Keyspace, bls.Shard)
+		}
+		ti, err := vde.ts.GetTablet(ctx, si.PrimaryAlias)
+		if err != nil {
+			return nil, "", err
+		}
+		source.tablets = append(source.tablets, ti.Tablet)
+	}
+	if !inKeyRange {
+		return source, "", nil
+	}
+
+	// The source shards can hold rows that belong on other target shards, so the rows
+	// read from the source are mapped with the primary vindex of the target table.
+	vschema, err := vde.ts.GetVSchema(ctx, vde.thisTablet.Keyspace)
+	if err != nil {
+		return nil, "", err
+	}
+	kschema, err := vindexes.BuildKeyspaceSchema(vschema.Keyspace, vde.thisTablet.Keyspace, vde.parser)
+	if err != nil {
+		return nil, "", err
+	}
+	table := kschema.Tables[tableName]
+	if table == nil || len(table.ColumnVindexes) == 0 {
+		return nil, "the table has no primary vindex in the target keyspace", nil
+	}
+	if table.ColumnVindexes[0].Vindex.NeedsVCursor() {
+		return nil, "the primary vindex of the table needs a lookup to map rows", nil
+	}
+	si, err := vde.ts.GetShard(ctx, vde.thisTablet.Keyspace, vde.thisTablet.Shard)
+	if err != nil {
+		return nil, "", err
+	}
+	source.vindex = table.ColumnVindexes[0]
+	source.keyRange = si.KeyRange
+	return source, "", nil
+}
+
+// repairSourceTable returns the source table of a filter that copies the rows of the table
+// as is, and whether the filter selects the rows of a key range.
+func repairSourceTable(parser *sqlparser.Parser, tableName, filter string) (string, bool, bool) {
+	switch {
+	case filter == "":
+		return tableName, false, true
+	case key.IsValidKeyRange(filter):
+		return tableName, true, true
+	}
+	stmt, err := parser.Parse(filter)
+	if err != nil {
+		return "", false, false
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.From) != 1 || len(sel.SelectExprs.Exprs) != 1 || sel.GroupBy != nil || sel.Having != nil {
+		return "", false, false
+	}
+	if _, ok := sel.SelectExprs.Exprs[0].(*sqlparser.StarExpr); !ok {
+		return "", false, false
+	}
+	aliased, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return "", false, false
+	}
+	name, err := aliased.TableName()
+	if err != nil {
+		return "", false, false
+	}
+	if sel.Where == nil {
+		return name.Name.String(), false, true
+	}
+	// Only the key range conditions that vreplication adds for sharded targets are
+	// supported, as the rows can then be mapped to this shard.
+	for _, expr := range sqlparser.SplitAndExpression(nil, sel.Where.Expr) {
+		fn, ok := expr.(*sqlparser.FuncExpr)
+		if !ok || !fn.Name.EqualString("in_keyrange") {
+			return "", false, false
+		}
+	}
+	return name.Name.String(), true, true
+}
+
+func (rs *tabletRepairSource) readRow(ctx context.Context, where string) (sqltypes.RowNamedValues, error) {
+	for _, tablet := range rs.tablets {
+		query := fmt.Sprintf("select * from %s.%s where %s", sqlparser.String(sqlparser.NewIdentifierCS(topoproto.TabletDbName(tablet))),
+			sqlparser.String(sqlparser.NewIdentifierCS(rs.table)), where)
+		res, err := rs.tmc.ExecuteFetchAsApp(ctx, tablet, false, &tabletmanagerdatapb.ExecuteFetchAsAppRequest{
+			Query:   []byte(query),
+			MaxRows: 2,
+		})
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "failed to read the source row from tablet %s", topoproto.TabletAliasString(tablet.Alias))
+		}
+		row := sqltypes.Proto3ToResult(res).Named().Row()
+		if row == nil {
+			continue
+		}
+		if rs.vindex != nil {
+			belongs, err := rs.belongsOnShard(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			if !belongs {
+				continue
+			}
+		}
+		return row, nil
+	}
+	return nil, nil
+}
+
+// belongsOnShard returns whether the primary vindex maps the row to the key range of this shard.
+func (rs *tabletRepairSource) belongsOnShard(ctx context.Context, row sqltypes.RowNamedValues) (bool, error) {
+	values := make([]sqltypes.Value, 0, len(rs.vindex.Columns))
+	for _, column := range rs.vindex.Columns {
+		value, ok := row[column.String()]
+		if !ok {
+			return false, fmt.Errorf("vindex column %s not found in the source row", column.String())
+		}
+		values = append(values, value)
+	}
+	destinations, err := vindexes.Map(ctx, rs.vindex.Vindex, nil, [][]sqltypes.Value{values})
+	if err != nil {
+		return false, err
+	}
+	ksid, ok := destinations[0].(key.DestinationKeyspaceID)
+	if !ok || len(ksid) == 0 {
+		return false, fmt.Errorf("could not map %v to a keyspace id, got destination %v", values, destinations[0])
+	}
+	return key.KeyRangeContains(rs.keyRange, ksid), nil
+}
+
+// repairRowValues maps the target columns of a reported row to their SQL encoded values.
+func repairRowValues(parser *sqlparser.Parser, table *repairTable, rd *RowDiff) (map[string]string, error) {
+	values := make(map[string]string, len(rd.Row))
+	for expr, val := range rd.Row {
+		column, err := reportedColumnName(parser, expr)
+		if err != nil {
+			return nil, err
+		}
+		col, ok := table.columns[strings.ToLower(column)]
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in the target table", column)
+		}
+		encoded, err := encodeReportedValue(col, val)
+		if err != nil {
+			return nil, fmt.Errorf("cannot recover the value of column %s: %v", column, err)
+		}
+		values[column] = encoded
+	}
+	return values, nil
+}
+
+// reportedColumnName returns the target column of a select expression in a vdiff report.
+func reportedColumnName(parser *sqlparser.Parser, expr string) (string, error) {
+	stmt, err := parser.Parse("select " + expr + " from dual")
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs.Exprs) != 1 {
+		return "", fmt.Errorf("unexpected column expression %s", expr)
+	}
+	aliased, ok := sel.SelectExprs.Exprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return "", fmt.Errorf("unexpected column expression %s", expr)
+	}
+	if !aliased.As.IsEmpty() {
+		return aliased.As.String(), nil
+	}
+	colName, ok := aliased.Expr.(*sqlparser.ColName)
+	if !ok {
+		return "", fmt.Errorf("column expression %s has no name", expr)
+	}
+	return colName.Name.String(), nil
+}
+
+// encodeReportedValue converts a value, as formatted in a vdiff report, back to SQL.
+func encodeReportedValue(col *repairColumn, val string) (string, error) {
+	if strings.HasSuffix(val, truncatedNotation) {
+		return "", fmt.Errorf("the value was truncated in the report")
+	}
+	if repairBinaryDataTypes[col.dataType] {
+		if val == "" {
+			// Binary values are always reported with a 0x prefix, even when empty.
+			return "null", nil
+		}
+		if !strings.HasPrefix(val, "0x") {
+			return "", fmt.Errorf("unexpected binary value %s", val)
+		}
+		if _, err := hex.DecodeString(val[2:]); err != nil {
+			return "", err
+		}
+		return "X'" + val[2:] + "'", nil
+	}
+	if val == "" && col.nullable {
+		if repairTextDataTypes[col.dataType] {
+			return "", fmt.Errorf("an empty value and NULL are reported the same way")
+		}
+		return "null", nil
+	}
+	return sqltypes.EncodeStringSQL(val), nil
+}
+
+// pkOnlyRow returns the reported values of the primary key columns of a row.
+func pkOnlyRow(parser *sqlparser.Parser, table *repairTable, rd *RowDiff) map[string]string {
+	row := make(map[string]string, len(table.pkColumns))
+	for expr, val := range rd.Row {
+		column, err := reportedColumnName(parser, expr)
+		if err != nil {
+			continue
+		}
+		if isPKColumn(table, column) {
+			row[expr] = val
+		}
+	}
+	return row
+}
+
+func repairWhereClause(table *repairTable, values map[string]string) (string, error) {
+	var conds []string
+	for _, pk := range table.pkColumns {
+		var found bool
+		for column, val := range values {
+			if strings.EqualFold(column, pk) {
+				conds = append(conds, sqlparser.String(sqlparser.NewIdentifierCI(pk))+" = "+val)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("primary key column %s not found in the vdiff report", pk)
+		}
+	}
+	return strings.Join(conds, " and "), nil
+}
+
+func isPKColumn(table *repairTable, column string) bool {
+	for _, pk := range table.pkColumns {
+		if strings.EqualFold(pk, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRepairStatements executes the statements that repair the rows of a table, within
+// the transaction of the caller.
+func applyRepairStatements(dbClient binlogplayer.DBClient, stmts []*repairStatement) error {
+	for _, stmt := range stmts {
+		if stmt.query == "" || stmt.skipReason != "" {
+			continue
+		}
+		if _, err := dbClient.ExecuteFetch(stmt.query, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func repairResult(stmts []*repairStatement, applied bool) *sqltypes.Result {
+	result := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "table_name", Type: sqltypes.VarChar},
+			{Name: "operation", Type: sqltypes.VarChar},
+			{Name: "statement", Type: sqltypes.VarChar},
+			{Name: "status", Type: sqltypes.VarChar},
+		},
+	}
+	for _, stmt := range stmts {
+		status := repairStatusDry
+		switch {
+		case stmt.skipReason != "":
+			status = "skipped: " + stmt.skipReason
+		case applied:
+			status = repairStatusDone
+		}
+		result.Rows = append(result.Rows, []sqltypes.Value{
+			sqltypes.NewVarChar(stmt.table),
+			sqltypes.NewVarChar(stmt.operation),
+			sqltypes.NewVarChar(stmt.query),
+			sqltypes.NewVarChar(status),
+		})
+	}
+	return result
+}
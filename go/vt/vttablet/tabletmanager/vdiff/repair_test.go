@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+type fakeRepairSource map[string]sqltypes.RowNamedValues
+
+func (rs fakeRepairSource) readRow(ctx context.Context, where string) (sqltypes.RowNamedValues, error) {
+	return rs[where], nil
+}
+
+func TestGenRepairStatements(t *testing.T) {
+	ctx := context.Background()
+	parser := sqlparser.NewTestParser()
+	table := &repairTable{
+		name: "t1",
+		columns: map[string]*repairColumn{
+			"id":  {dataType: "int"},
+			"c1":  {dataType: "varchar", nullable: true},
+			"i1":  {dataType: "int", nullable: true},
+			"bin": {dataType: "varbinary", nullable: true},
+			"g1":  {dataType: "int", nullable: true, generated: true},
+		},
+		pkColumns: []string{"id"},
+	}
+	row := func(id int64, c1 string, i1 sqltypes.Value) sqltypes.RowNamedValues {
+		return sqltypes.RowNamedValues{
+			"id":  sqltypes.NewInt64(id),
+			"c1":  sqltypes.NewVarChar(c1),
+			"i1":  i1,
+			"bin": sqltypes.NewVarBinary("\x01\x02"),
+			"g1":  sqltypes.NewInt64(id * 2),
+		}
+	}
+	// The rows as they are now, which differ from the ones in the report.
+	source := fakeRepairSource{
+		"id = '1'": row(1, "it's", sqltypes.NULL),
+		"id = '3'": row(3, "a", sqltypes.NewInt64(11)),
+		"id = '5'": row(5, "same", sqltypes.NewInt64(1)),
+	}
+	target := map[string]sqltypes.RowNamedValues{
+		"id = '3'": row(3, "b", sqltypes.NewInt64(10)),
+		"id = '4'": row(4, "x", sqltypes.NULL),
+		"id = '5'": row(5, "same", sqltypes.NewInt64(1)),
+	}
+	var targetQueries []string
+	readTarget := func(query string) (*sqltypes.Result, error) {
+		targetQueries = append(targetQueries, query)
+		for where, r := range target {
+			if query == "select * from vt_ks.t1 where "+where+" for update" {
+				result := &sqltypes.Result{}
+				for _, column := range []string{"id", "c1", "i1", "bin", "g1"} {
+					result.Fields = append(result.Fields, &querypb.Field{Name: column, Type: r[column].Type()})
+				}
+				result.Rows = [][]sqltypes.Value{{r["id"], r["c1"], r["i1"], r["bin"], r["g1"]}}
+				return result, nil
+			}
+		}
+		return &sqltypes.Result{}, nil
+	}
+	dr := &DiffReport{
+		TableName:       "t1",
+		ExtraRowsSource: 3,
+		ExtraRowsSourceDiffs: []*RowDiff{
+			{Row: map[string]string{"id": "1", "c1": "it's", "i1": "", "bin": "0x0102"}},
+			{Row: map[string]string{"id": "2", "c1": "", "i1": "1", "bin": ""}},
+		},
+		MismatchedRows: 2,
+		MismatchedRowsDiffs: []*DiffMismatch{
+			{
+				Source: &RowDiff{Row: map[string]string{"id": "3", "c1": "a", "i1": "10", "bin": "0x"}},
+				Target: &RowDiff{Row: map[string]string{"id": "3", "c1": "b", "i1": "10", "bin": "0x"}},
+			},
+			{
+				Source: &RowDiff{Row: map[string]string{"id": "5", "c1": "other", "i1": "1", "bin": "0x"}},
+				Target: &RowDiff{Row: map[string]string{"id": "5", "c1": "same", "i1": "1", "bin": "0x"}},
+			},
+		},
+		ExtraRowsTarget: 2,
+		ExtraRowsTargetDiffs: []*RowDiff{
+			{Row: map[string]string{"id": "4", "c1": "x" + truncatedNotation}},
+			{Row: map[string]string{"id": "1" + truncatedNotation}},
+		},
+	}
+
+	stmts, err := genRepairStatements(ctx, parser, "vt_ks", table, dr, source, readTarget)
+	require.NoError(t, err)
+	assert.Equal(t, []*repairStatement{
+		{table: "t1", operation: repairInsert, query: "insert into vt_ks.t1(bin, c1, i1, id) values (_binary'\x01\x02', 'it\\'s', null, 1)"},
+		{table: "t1", skipReason: "the row where id = '2' no longer exists on the source or the target"},
+		{table: "t1", operation: repairUpdate, query: "update vt_ks.t1 set c1 = 'a', i1 = 11 where id = '3'"},
+		{table: "t1", skipReason: "the row where id = '5' no longer differs"},
+		{table: "t1", operation: repairDelete, query: "delete from vt_ks.t1 where id = '4'"},
+		{table: "t1", skipReason: "cannot recover the value of column id: the value was truncated in the report"},
+		{table: "t1", operation: repairUnsampled, skipReason: "1 rows to insert were not sampled in the vdiff report, run a new vdiff with a higher --max-report-sample-rows value"},
+	}, stmts)
+	// The target rows are locked while they are read.
+	assert.Contains(t, targetQueries, "select * from vt_ks.t1 where id = '3' for update")
+
+	// Tables without a primary key cannot be repaired.
+	stmts, err = genRepairStatements(ctx, parser, "vt_ks", &repairTable{name: "t2", columns: table.columns}, dr, source, readTarget)
+	require.NoError(t, err)
+	assert.Equal(t, []*repairStatement{{table: "t2", skipReason: "table has no primary key"}}, stmts)
+
+	result := repairResult(stmts, true)
+	assert.Len(t, result.Rows, 1)
+	assert.Equal(t, "skipped: table has no primary key", result.Rows[0][3].ToString())
+}
+
+func TestRepairSourceTable(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	testCases := []struct {
+		filter     string
+		table      string
+		inKeyRange bool
+		ok         bool
+	}{
+		{filter: "", table: "t1", ok: true},
+		{filter: "-80", table: "t1", inKeyRange: true, ok: true},
+		{filter: "select * from t2", table: "t2", ok: true},
+		{filter: "select * from t2 where in_keyrange(id, 'xxhash', '-80')", table: "t2", inKeyRange: true, ok: true},
+		{filter: "select * from t2 where c1 = 'a'"},
+		{filter: "select id, c1 from t2"},
+		{filter: "exclude"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			table, inKeyRange, ok := repairSourceTable(parser, "t1", tc.filter)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.table, table)
+			assert.Equal(t, tc.inKeyRange, inKeyRange)
+		})
+	}
+}
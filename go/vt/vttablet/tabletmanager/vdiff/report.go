@@ -42,6 +42,17 @@ type DiffReport struct {
 	ExtraRowsSource int64
 	ExtraRowsTarget int64
 
+	// SamplePercent is set when only a sample of the table's PK ranges was
+	// diffed, in which case EstimatedMismatchedRows extrapolates the rows found
+	// to differ to the whole table.
+	SamplePercent           int64 `json:"SamplePercent,omitempty"`
+	EstimatedMismatchedRows int64 `json:"EstimatedMismatchedRows,omitempty"`
+
+	// ExtraRowsTargetOtherSources counts the rows of a table shared with other
+	// workflows which are on the target but not on the source. They are the
+	// rows of the other workflows, so they are not counted as extra rows.
+	ExtraRowsTargetOtherSources int64 `json:"ExtraRowsTargetOtherSources,omitempty"`
+
 	// actual data for a few sample rows
 	ExtraRowsSourceDiffs []*RowDiff      `json:"ExtraRowsSourceSample,omitempty"`
 	ExtraRowsTargetDiffs []*RowDiff      `json:"ExtraRowsTargetSample,omitempty"`
@@ -0,0 +1,246 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"fmt"
+	"math"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/binlog/binlogplayer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// sampleRangeCount is the number of equally sized ranges that the values of
+// the first primary key column are split into when only a sample of a table
+// is diffed. The sample is made of every range whose index i satisfies
+// (i * samplePct) % 100 < samplePct, which spreads the sampled ranges evenly
+// over the table and always picks the same ones for a given table.
+const sampleRangeCount = 100
+
+// sampleRange is an inclusive range of values of the first primary key column
+// of a table. The lowest and highest ranges are left unbounded so that rows
+// which fall outside of the bounds seen on the target are also diffed.
+type sampleRange struct {
+	low, high int64
+}
+
+func (r sampleRange) String() string {
+	return fmt.Sprintf("[%d, %d]", r.low, r.high)
+}
+
+// isSampled returns true if only a sample of the table is being diffed.
+func (td *tableDiffer) isSampled() bool {
+	return len(td.sampleRanges) > 0
+}
+
+// buildSampleRanges computes the PK ranges to diff when a sample percentage
+// is requested. Sampling is only possible when the first primary key column
+// is an integral column which is selected as is on the source, otherwise the
+// whole table is diffed.
+func (td *tableDiffer) buildSampleRanges(dbClient binlogplayer.DBClient, samplePct int64) error {
+	td.sampleRanges = nil
+	if samplePct <= 0 || samplePct >= 100 || len(td.tablePlan.comparePKs) == 0 {
+		return nil
+	}
+	pk := td.tablePlan.comparePKs[0]
+	var pkField *querypb.Field
+	for _, field := range td.table.Fields {
+		if field.Name == pk.colName {
+			pkField = field
+			break
+		}
+	}
+	if pkField == nil || !sqltypes.IsIntegral(pkField.Type) {
+		log.Infof("Not sampling table %s for vdiff %s as the %s column is not an integral column, diffing the whole table",
+			td.table.Name, td.wd.ct.uuid, pk.colName)
+		return nil
+	}
+	sourceCol, err := td.sourceColumn(pk.colIndex)
+	if err != nil {
+		return err
+	}
+	if sourceCol == "" {
+		log.Infof("Not sampling table %s for vdiff %s as the %s column is not selected as is from the source, diffing the whole table",
+			td.table.Name, td.wd.ct.uuid, pk.colName)
+		return nil
+	}
+
+	query := sqlparser.BuildParsedQuery(sqlGetSampleRangeBounds, pk.colName, pk.colName, td.wd.ct.vde.dbName, td.table.Name).Query
+	qr, err := dbClient.ExecuteFetch(query, 1)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) != 1 || qr.Rows[0][0].IsNull() {
+		log.Infof("Not sampling table %s for vdiff %s as it is empty on the target, diffing the whole table", td.table.Name, td.wd.ct.uuid)
+		return nil
+	}
+	low, err := qr.Rows[0][0].ToInt64()
+	if err != nil {
+		log.Infof("Not sampling table %s for vdiff %s as its lowest %s value %v cannot be used: %v",
+			td.table.Name, td.wd.ct.uuid, pk.colName, qr.Rows[0][0], err)
+		return nil
+	}
+	high, err := qr.Rows[0][1].ToInt64()
+	if err != nil {
+		log.Infof("Not sampling table %s for vdiff %s as its highest %s value %v cannot be used: %v",
+			td.table.Name, td.wd.ct.uuid, pk.colName, qr.Rows[0][1], err)
+		return nil
+	}
+	td.sampleRanges = sampleRanges(low, high, samplePct)
+	td.sampleSourceCol = sourceCol
+	td.sampleTargetCol = pk.colName
+	log.Infof("Sampling %d%% of table %s for vdiff %s using the %s column ranges %v",
+		samplePct, td.table.Name, td.wd.ct.uuid, pk.colName, td.sampleRanges)
+	return nil
+}
+
+// sourceColumn returns the name of the source column selected at the given
+// index in the source query, or an empty string if it is not a plain column.
+func (td *tableDiffer) sourceColumn(index int) (string, error) {
+	stmt, err := td.wd.ct.vde.parser.Parse(td.tablePlan.sourceQuery)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || index >= len(sel.GetColumns()) {
+		return "", nil
+	}
+	aliased, ok := sel.GetColumns()[index].(*sqlparser.AliasedExpr)
+	if !ok {
+		return "", nil
+	}
+	col, ok := aliased.Expr.(*sqlparser.ColName)
+	if !ok {
+		return "", nil
+	}
+	return col.Name.String(), nil
+}
+
+// pendingSampleRanges returns the sample ranges which have not been diffed yet,
+// based on the last PK that was diffed on the target.
+func (td *tableDiffer) pendingSampleRanges() []sampleRange {
+	if td.lastTargetPK == nil || len(td.lastTargetPK.Rows) == 0 || len(td.lastTargetPK.Rows[0].Lengths) == 0 {
+		return td.sampleRanges
+	}
+	lastPK := sqltypes.Proto3ToResult(td.lastTargetPK)
+	last, err := lastPK.Rows[0][0].ToInt64()
+	if err != nil {
+		return td.sampleRanges
+	}
+	for i, r := range td.sampleRanges {
+		if r.high >= last {
+			return td.sampleRanges[i:]
+		}
+	}
+	return nil
+}
+
+// sampleQueries returns the source and target queries restricted to the
+// given sample range.
+func (td *tableDiffer) sampleQueries(r sampleRange) (string, string, error) {
+	sourceQuery, err := addSampleRangeFilter(td.wd.ct.vde.parser, td.tablePlan.sourceQuery, td.sampleSourceCol, r)
+	if err != nil {
+		return "", "", err
+	}
+	targetQuery, err := addSampleRangeFilter(td.wd.ct.vde.parser, td.tablePlan.targetQuery, td.sampleTargetCol, r)
+	if err != nil {
+		return "", "", err
+	}
+	return sourceQuery, targetQuery, nil
+}
+
+// addSampleRangeFilter adds a predicate on col restricting the rows of the
+// query to the range. The predicate is simple enough to be pushed down to
+// MySQL by the row streamer, so only the rows in the range are read.
+func addSampleRangeFilter(parser *sqlparser.Parser, query, col string, r sampleRange) (string, error) {
+	stmt, err := parser.Parse(query)
+	if err != nil {
+		return "", err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return "", fmt.Errorf("unexpected: %v", sqlparser.String(stmt))
+	}
+	colName := sqlparser.NewColName(col)
+	low := sqlparser.NewIntLiteral(fmt.Sprintf("%d", r.low))
+	high := sqlparser.NewIntLiteral(fmt.Sprintf("%d", r.high))
+	switch {
+	case r.low == math.MinInt64 && r.high == math.MaxInt64:
+	case r.low == math.MinInt64:
+		sel.AddWhere(&sqlparser.ComparisonExpr{Operator: sqlparser.LessEqualOp, Left: colName, Right: high})
+	case r.high == math.MaxInt64:
+		sel.AddWhere(&sqlparser.ComparisonExpr{Operator: sqlparser.GreaterEqualOp, Left: colName, Right: low})
+	default:
+		sel.AddWhere(&sqlparser.BetweenExpr{IsBetween: true, Left: colName, From: low, To: high})
+	}
+	return sqlparser.String(sel), nil
+}
+
+// sampleRanges splits [low, high] in up to sampleRangeCount ranges and returns
+// the ones that make up a sample of samplePct percent, merging the adjacent ones.
+func sampleRanges(low, high, samplePct int64) []sampleRange {
+	if high < low {
+		return nil
+	}
+	span := uint64(high) - uint64(low) // the number of values minus one
+	count := uint64(sampleRangeCount)
+	if span < count {
+		count = span + 1
+	}
+	size := span/count + 1
+
+	var ranges []sampleRange
+	for i := uint64(0); i < count; i++ {
+		if (i*uint64(samplePct))%100 >= uint64(samplePct) {
+			continue
+		}
+		offset := i * size
+		if offset > span {
+			break
+		}
+		r := sampleRange{low: int64(uint64(low) + offset), high: high}
+		if i < count-1 && offset+size-1 < span {
+			r.high = int64(uint64(low) + offset + size - 1)
+		}
+		if i == 0 {
+			r.low = math.MinInt64
+		}
+		if r.high == high {
+			r.high = math.MaxInt64
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].high != math.MaxInt64 && ranges[n-1].high+1 == r.low {
+			ranges[n-1].high = r.high
+			continue
+		}
+		ranges = append(ranges, r)
+		if r.high == math.MaxInt64 {
+			break
+		}
+	}
+	return ranges
+}
+
+// estimateMismatchedRows extrapolates the number of rows which differ in the
+// sampled ranges to the whole table.
+func estimateMismatchedRows(dr *DiffReport, samplePct int64) int64 {
+	diffs := dr.MismatchedRows + dr.ExtraRowsSource + dr.ExtraRowsTarget
+	return int64(math.Round(float64(diffs) * 100 / float64(samplePct)))
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vdiff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestSampleRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		low, high int64
+		pct       int64
+		want      []sampleRange
+	}{
+		{
+			name: "ten percent",
+			low:  1,
+			high: 1000,
+			pct:  10,
+			want: []sampleRange{
+				{low: math.MinInt64, high: 10},
+				{low: 101, high: 110},
+				{low: 201, high: 210},
+				{low: 301, high: 310},
+				{low: 401, high: 410},
+				{low: 501, high: 510},
+				{low: 601, high: 610},
+				{low: 701, high: 710},
+				{low: 801, high: 810},
+				{low: 901, high: 910},
+			},
+		},
+		{
+			name: "adjacent ranges are merged",
+			low:  0,
+			high: 7,
+			pct:  75,
+			want: []sampleRange{
+				{low: math.MinInt64, high: 0},
+				{low: 2, high: 4},
+				{low: 6, high: math.MaxInt64},
+			},
+		},
+		{
+			name: "single value",
+			low:  5,
+			high: 5,
+			pct:  1,
+			want: []sampleRange{{low: math.MinInt64, high: math.MaxInt64}},
+		},
+		{
+			name: "whole int64 span",
+			low:  math.MinInt64,
+			high: math.MaxInt64,
+			pct:  50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges := sampleRanges(tt.low, tt.high, tt.pct)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, ranges)
+				return
+			}
+			assert.Len(t, ranges, 50)
+			for i := 1; i < len(ranges); i++ {
+				assert.Greater(t, ranges[i].low, ranges[i-1].high)
+			}
+		})
+	}
+}
+
+func TestAddSampleRangeFilter(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	query := "select c1, c2 from t1 where in_keyrange('-80') order by c1 asc"
+
+	got, err := addSampleRangeFilter(parser, query, "c1", sampleRange{low: 10, high: 20})
+	require.NoError(t, err)
+	assert.Equal(t, "select c1, c2 from t1 where in_keyrange('-80') and c1 between 10 and 20 order by c1 asc", got)
+
+	got, err = addSampleRangeFilter(parser, query, "c1", sampleRange{low: math.MinInt64, high: 20})
+	require.NoError(t, err)
+	assert.Equal(t, "select c1, c2 from t1 where in_keyrange('-80') and c1 <= 20 order by c1 asc", got)
+
+	got, err = addSampleRangeFilter(parser, "select c1, c2 from t1", "c1", sampleRange{low: -5, high: math.MaxInt64})
+	require.NoError(t, err)
+	assert.Equal(t, "select c1, c2 from t1 where c1 >= -5", got)
+}
+
+func TestEstimateMismatchedRows(t *testing.T) {
+	dr := &DiffReport{MismatchedRows: 3, ExtraRowsSource: 1, ExtraRowsTarget: 1}
+	assert.EqualValues(t, 50, estimateMismatchedRows(dr, 10))
+	assert.EqualValues(t, 15, estimateMismatchedRows(dr, 33))
+}
@@ -22,6 +22,9 @@ const (
 	sqlResumeVDiff  = `update _vt.vdiff as vd, _vt.vdiff_table as vdt set vd.started_at = NULL, vd.completed_at = NULL, vd.state = 'pending',
 					vdt.state = 'pending' where vd.vdiff_uuid = %a and vd.id = vdt.vdiff_id and vd.state in ('completed', 'stopped')
 					and vdt.state in ('completed', 'stopped')`
+	sqlResetVDiffTablesProgress = `update _vt.vdiff as vd, _vt.vdiff_table as vdt set vdt.lastpk = NULL, vdt.rows_compared = 0,
+					vdt.mismatch = false, vdt.report = NULL where vd.vdiff_uuid = %a and vd.id = vdt.vdiff_id
+					and vd.state in ('completed', 'stopped') and vdt.state in ('completed', 'stopped')`
 	sqlStartVDiff = `update _vt.vdiff as vd set vd.state = 'pending' where vd.vdiff_uuid = %a and vd.state = 'stopped' and
 					vd.started_at is NULL and vd.completed_at is NULL and
 					(select count(*) as cnt from _vt.vdiff_table as vdt where vd.id = vdt.vdiff_id) = 0`
@@ -65,5 +68,10 @@ const (
 	sqlUpdateTableStateAndReport = "update _vt.vdiff_table set state = %a, rows_compared = %a, report = %a where vdiff_id = %a and table_name = %a"
 	sqlUpdateTableMismatch       = "update _vt.vdiff_table set mismatch = true where vdiff_id = %a and table_name = %a"
 
+	sqlGetVDiffTableReports  = "select table_name as table_name, report as report from _vt.vdiff_table where vdiff_id = %a order by table_name"
+	sqlGetSampleRangeBounds  = "select min(`%s`) as low, max(`%s`) as high from `%s`.`%s`"
+	sqlGetRepairTableColumns = `select column_name as column_name, data_type as data_type, is_nullable as is_nullable, column_key as column_key, extra as extra
+								from information_schema.columns where table_schema = %a and table_name = %a order by ordinal_position`
+
 	sqlGetIncompleteTables = "select table_name as table_name from _vt.vdiff_table where vdiff_id = %a and state != 'completed' order by table_name"
 )
@@ -22,6 +22,7 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vtgate/engine"
 )
 
@@ -29,8 +30,9 @@ import (
 // shardStreamer satisfies engine.StreamExecutor, and can be added to Primitives of engine.MergeSort.
 // Each tableDiffer has one shardStreamer per source and one for the target.
 type shardStreamer struct {
-	tablet *topodatapb.Tablet // tablet currently picked to stream from
-	shard  string
+	tablet   *topodatapb.Tablet // tablet currently picked to stream from
+	shard    string
+	external string // name of the external MySQL connection to stream from, when not streaming from a tablet
 
 	snapshotPosition string                // gtid set of the current snapshot which is being streamed from
 	result           chan *sqltypes.Result // the next row is sent to this channel and received by the comparator
@@ -39,6 +41,14 @@ type shardStreamer struct {
 
 var _ engine.StreamExecutor = (*shardStreamer)(nil)
 
+// String returns a description of where the shardStreamer streams from, for logging purposes.
+func (sm *shardStreamer) String() string {
+	if sm.external != "" {
+		return "external mysql " + sm.external
+	}
+	return "tablet " + topoproto.TabletAliasString(sm.tablet.GetAlias())
+}
+
 // StreamExecute implements the StreamExecutor interface of the Primitive executor and
 // it simply waits for a result to be available for this shard and sends it to the merge sorter.
 func (sm *shardStreamer) StreamExecute(_ context.Context, _ engine.VCursor, _ map[string]*querypb.BindVariable, _, _ bool, callback func(*sqltypes.Result) error) error {
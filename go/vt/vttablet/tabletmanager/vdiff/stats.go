@@ -38,6 +38,7 @@ type vdiffStats struct {
 
 	Count               *stats.Gauge
 	ErrorCount          *stats.Counter
+	CheckpointCount     *stats.Counter
 	RestartedTableDiffs *stats.CountersWithSingleLabel
 	RowsDiffedCount     *stats.Counter
 }
@@ -51,6 +52,7 @@ func (vds *vdiffStats) initControllerStats() {
 func (vds *vdiffStats) register() {
 	globalStats.Count = stats.NewGauge("", "")
 	globalStats.ErrorCount = stats.NewCounter("", "")
+	globalStats.CheckpointCount = stats.NewCounter("", "")
 	globalStats.RestartedTableDiffs = stats.NewCountersWithSingleLabel("", "", "Table")
 	globalStats.RowsDiffedCount = stats.NewCounter("", "")
 	globalStats.initControllerStats()
@@ -85,6 +87,16 @@ func (vds *vdiffStats) register() {
 		},
 	)
 
+	stats.NewCounterFunc(
+		"VDiffCheckpointsTotal",
+		"Number of times the progress of a table diff was checkpointed",
+		func() int64 {
+			vds.mu.Lock()
+			defer vds.mu.Unlock()
+			return globalStats.CheckpointCount.Get()
+		},
+	)
+
 	stats.NewCounterFunc(
 		"VDiffRowsComparedTotal",
 		"Number of rows compared across all vdiffs",
@@ -70,6 +70,9 @@ const (
 // how long to wait for background operations to complete
 var BackgroundOperationTimeout = topo.RemoteOperationTimeout * 4
 
+// how often the progress of a table diff is checkpointed while it is running
+var checkpointInterval = 30 * time.Second
+
 var (
 	ErrMaxDiffDurationExceeded = vterrors.Errorf(vtrpcpb.Code_DEADLINE_EXCEEDED, "table diff was stopped due to exceeding the max-diff-duration time")
 	ErrVDiffStoppedByUser      = vterrors.Errorf(vtrpcpb.Code_CANCELED, "vdiff was stopped by user")
@@ -98,6 +101,18 @@ type tableDiffer struct {
 	lastSourcePK *querypb.QueryResult
 	lastTargetPK *querypb.QueryResult
 
+	// sampleRanges are the ranges of the first PK column which are diffed when
+	// only a sample of the table is diffed, and sampleRange is the one that is
+	// currently being diffed.
+	sampleRanges    []sampleRange
+	sampleRange     *sampleRange
+	sampleSourceCol string
+	sampleTargetCol string
+
+	// workflowPaused is set when the workflow was paused before the diff, in
+	// which case it is left paused rather than restarted afterwards.
+	workflowPaused bool
+
 	// wgShardStreamers is used, with a cancellable context, to wait for all shard streamers
 	// to finish after each diff is complete.
 	wgShardStreamers   sync.WaitGroup
@@ -206,7 +221,14 @@ func (td *tableDiffer) initialize(ctx context.Context) error {
 func (td *tableDiffer) stopTargetVReplicationStreams(ctx context.Context, dbClient binlogplayer.DBClient) error {
 	log.Infof("stopTargetVReplicationStreams for vdiff %s", td.wd.ct.uuid)
 	ct := td.wd.ct
-	query := "update _vt.vreplication set state = 'Stopped', message='for vdiff' " + ct.workflowFilter
+	query := "select id from _vt.vreplication " + ct.workflowFilter + " and message = " + encodeString(binlogplayer.PausedMessage)
+	qr, err := dbClient.ExecuteFetch(query, -1)
+	if err != nil {
+		return err
+	}
+	td.workflowPaused = len(qr.Rows) > 0
+
+	query = "update _vt.vreplication set state = 'Stopped', message='for vdiff' " + ct.workflowFilter
 	if _, err := ct.vde.vre.Exec(query); err != nil {
 		return err
 	}
@@ -214,7 +236,7 @@ func (td *tableDiffer) stopTargetVReplicationStreams(ctx context.Context, dbClie
 
 	// update position of all source streams
 	query = "select id, source, pos from _vt.vreplication " + ct.workflowFilter
-	qr, err := dbClient.ExecuteFetch(query, -1)
+	qr, err = dbClient.ExecuteFetch(query, -1)
 	if err != nil {
 		return err
 	}
@@ -280,6 +302,11 @@ func (td *tableDiffer) selectTablets(ctx context.Context) error {
 	tabletPickerOptions := discovery.TabletPickerOptions{}
 	wg.Go(func() {
 		sourceErr = td.forEachSource(func(source *migrationSource) error {
+			if td.wd.ct.externalMysql != "" {
+				// There are no source tablets: we stream directly from the external MySQL.
+				source.external = td.wd.ct.externalMysql
+				return nil
+			}
 			sourceTablet, err := td.pickTablet(ctx, sourceTopoServer, sourceCells, td.wd.ct.sourceKeyspace,
 				source.shard, td.wd.opts.PickerOptions.TabletTypes, tabletPickerOptions)
 			if err != nil {
@@ -335,6 +362,12 @@ func (td *tableDiffer) syncSourceStreams(ctx context.Context) error {
 	defer cancel()
 
 	if err := td.forEachSource(func(source *migrationSource) error {
+		if source.external != "" {
+			// There is no tablet to wait on: the position of the external MySQL
+			// is instead checked against that of the target streams once its
+			// snapshot is taken, in startSourceDataStreams.
+			return nil
+		}
 		if err := ct.tmc.WaitForPosition(waitCtx, source.tablet, replication.EncodePosition(source.position)); err != nil {
 			return vterrors.Wrapf(err, "WaitForPosition for tablet %v", topoproto.TabletAliasString(source.tablet.Alias))
 		}
@@ -373,11 +406,18 @@ func (td *tableDiffer) startTargetDataStream(ctx context.Context) error {
 	ct := td.wd.ct
 	gtidch := make(chan string, 1)
 	ct.targetShardStreamer.result = make(chan *sqltypes.Result, 1)
-	go td.streamOneShard(ctx, ct.targetShardStreamer, td.tablePlan.targetQuery, td.lastTargetPK, gtidch)
+	query := td.tablePlan.targetQuery
+	if td.sampleRange != nil {
+		var err error
+		if _, query, err = td.sampleQueries(*td.sampleRange); err != nil {
+			return err
+		}
+	}
+	go td.streamOneShard(ctx, ct.targetShardStreamer, query, td.lastTargetPK, gtidch)
 	gtid, ok := <-gtidch
 	if !ok {
-		log.Errorf("VDiff %s streaming error on target tablet %s: %v",
-			td.wd.ct.uuid, topoproto.TabletAliasString(ct.targetShardStreamer.tablet.Alias), ct.targetShardStreamer.err)
+		log.Errorf("VDiff %s streaming error on target %s: %v",
+			td.wd.ct.uuid, ct.targetShardStreamer, ct.targetShardStreamer.err)
 		return ct.targetShardStreamer.err
 	}
 	ct.targetShardStreamer.snapshotPosition = gtid
@@ -386,18 +426,28 @@ func (td *tableDiffer) startTargetDataStream(ctx context.Context) error {
 
 func (td *tableDiffer) startSourceDataStreams(ctx context.Context) error {
 	defer td.wd.ct.TableDiffPhaseTimings.Record(fmt.Sprintf("%s.%s", td.table.Name, startingSources), time.Now())
+	query := td.tablePlan.sourceQuery
+	if td.sampleRange != nil {
+		var err error
+		if query, _, err = td.sampleQueries(*td.sampleRange); err != nil {
+			return err
+		}
+	}
 	if err := td.forEachSource(func(source *migrationSource) error {
 		gtidch := make(chan string, 1)
 		source.result = make(chan *sqltypes.Result, 1)
-		go td.streamOneShard(ctx, source.shardStreamer, td.tablePlan.sourceQuery, td.lastSourcePK, gtidch)
+		go td.streamOneShard(ctx, source.shardStreamer, query, td.lastSourcePK, gtidch)
 
 		gtid, ok := <-gtidch
 		if !ok {
-			log.Errorf("VDiff %s streaming error on source tablet %s: %v",
-				td.wd.ct.uuid, topoproto.TabletAliasString(source.tablet.Alias), source.err)
+			log.Errorf("VDiff %s streaming error on source %s: %v",
+				td.wd.ct.uuid, source.shardStreamer, source.err)
 			return source.err
 		}
 		source.snapshotPosition = gtid
+		if source.external != "" {
+			return checkExternalSourcePosition(source)
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -405,11 +455,35 @@ func (td *tableDiffer) startSourceDataStreams(ctx context.Context) error {
 	return nil
 }
 
+// checkExternalSourcePosition checks that the snapshot of an external MySQL
+// source includes all the transactions the target streams have replicated
+// from it. Unlike with source tablets, VDiff cannot wait for the external
+// MySQL to catch up, which it may need to if the connection now points to
+// a lagging replica: comparing its snapshot to the target would then report
+// rows which are only missing on the source.
+func checkExternalSourcePosition(source *migrationSource) error {
+	snapshotPos, err := binlogplayer.DecodePosition(source.snapshotPosition)
+	if err != nil {
+		return vterrors.Wrapf(err, "invalid snapshot position of %s", source.shardStreamer)
+	}
+	if !snapshotPos.AtLeast(source.position) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the snapshot position %s of %s is behind the position %s of the target streams",
+			source.snapshotPosition, source.shardStreamer, replication.EncodePosition(source.position))
+	}
+	return nil
+}
+
 func (td *tableDiffer) restartTargetVReplicationStreams(ctx context.Context) error {
 	defer td.wd.ct.TableDiffPhaseTimings.Record(fmt.Sprintf("%s.%s", td.table.Name, restartingVreplication), time.Now())
 	ct := td.wd.ct
 	query := fmt.Sprintf("update _vt.vreplication set state='Running', message='', stop_pos='' where db_name=%s and workflow=%s",
 		encodeString(ct.vde.dbName), encodeString(ct.workflow))
+	if td.workflowPaused {
+		// The streams stay stopped where the diff synchronized them, which is
+		// the snapshot of their source shard that the target was compared to.
+		query = fmt.Sprintf("update _vt.vreplication set state='Stopped', message=%s where db_name=%s and workflow=%s",
+			encodeString(binlogplayer.PausedMessage), encodeString(ct.vde.dbName), encodeString(ct.workflow))
+	}
 	log.Infof("Restarting the %q VReplication workflow for vdiff %s using %q", ct.workflow, td.wd.ct.uuid, query)
 	var err error
 	// Let's retry a few times if we get a retryable error.
@@ -425,12 +499,11 @@ func (td *tableDiffer) restartTargetVReplicationStreams(ctx context.Context) err
 }
 
 func (td *tableDiffer) streamOneShard(ctx context.Context, participant *shardStreamer, query string, lastPK *querypb.QueryResult, gtidch chan string) {
-	tabletAliasString := topoproto.TabletAliasString(participant.tablet.Alias)
-	log.Infof("streamOneShard Start for vdiff %s on %s using query: %s", td.wd.ct.uuid, tabletAliasString, query)
+	log.Infof("streamOneShard Start for vdiff %s on %s using query: %s", td.wd.ct.uuid, participant, query)
 	td.wgShardStreamers.Add(1)
 
 	defer func() {
-		log.Infof("streamOneShard for vdiff %s End on %s (err: %v)", td.wd.ct.uuid, tabletAliasString, participant.err)
+		log.Infof("streamOneShard for vdiff %s End on %s (err: %v)", td.wd.ct.uuid, participant, participant.err)
 		select {
 		case <-ctx.Done():
 		default:
@@ -441,24 +514,11 @@ func (td *tableDiffer) streamOneShard(ctx context.Context, participant *shardStr
 	}()
 
 	participant.err = func() error {
-		conn, err := tabletconn.GetDialer()(ctx, participant.tablet, false)
-		if err != nil {
-			return err
-		}
-		defer conn.Close(ctx)
-
-		target := &querypb.Target{
-			Keyspace:   participant.tablet.Keyspace,
-			Shard:      participant.shard,
-			TabletType: participant.tablet.Type,
-		}
 		var fields []*querypb.Field
-		req := &binlogdatapb.VStreamRowsRequest{
-			// We pass the NoTimeouts options as otherwise the row streamer will add a MAX_EXECUTION_TIME
-			// query hint with a value based on the --vreplication-copy-phase-duration flag.
-			Target: target, Query: query, Lastpk: lastPK, Options: &binlogdatapb.VStreamOptions{NoTimeouts: true},
-		}
-		return conn.VStreamRows(ctx, req, func(vsrRaw *binlogdatapb.VStreamRowsResponse) error {
+		// We pass the NoTimeouts options as otherwise the row streamer will add a MAX_EXECUTION_TIME
+		// query hint with a value based on the --vreplication-copy-phase-duration flag.
+		options := &binlogdatapb.VStreamOptions{NoTimeouts: true}
+		send := func(vsrRaw *binlogdatapb.VStreamRowsResponse) error {
 			// We clone (deep copy) the VStreamRowsResponse -- which contains a vstream packet with N rows and
 			// their corresponding GTID position/snapshot along with the LastPK in the row set -- so that we
 			// can safely process it while the next VStreamRowsResponse message is getting prepared by the
@@ -496,7 +556,35 @@ func (td *tableDiffer) streamOneShard(ctx context.Context, participant *shardStr
 				return ErrVDiffStoppedByUser
 			}
 			return nil
-		})
+		}
+
+		if participant.external != "" {
+			vsClient, err := td.wd.ct.vde.vre.ExternalVStreamerClient(participant.external)
+			if err != nil {
+				return err
+			}
+			if err := vsClient.Open(ctx); err != nil {
+				return err
+			}
+			defer vsClient.Close(ctx)
+			return vsClient.VStreamRows(ctx, query, lastPK, send, options)
+		}
+
+		conn, err := tabletconn.GetDialer()(ctx, participant.tablet, false)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(ctx)
+
+		target := &querypb.Target{
+			Keyspace:   participant.tablet.Keyspace,
+			Shard:      participant.shard,
+			TabletType: participant.tablet.Type,
+		}
+		req := &binlogdatapb.VStreamRowsRequest{
+			Target: target, Query: query, Lastpk: lastPK, Options: options,
+		}
+		return conn.VStreamRows(ctx, req, send)
 	}()
 }
 
@@ -580,6 +668,7 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 	rowsToCompare := coreOpts.GetMaxRows()
 	maxExtraRowsToCompare := coreOpts.GetMaxExtraRowsToCompare()
 	maxReportSampleRows := reportOpts.GetMaxSampleRows()
+	lastCheckpoint := time.Now()
 
 	for {
 		lastProcessedRow = sourceRow
@@ -595,6 +684,16 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 		default:
 		}
 
+		// Periodically checkpoint our progress so that if the tablet is restarted
+		// before we finish, the diff resumes from here rather than from the start.
+		if lastProcessedRow != nil && time.Since(lastCheckpoint) >= checkpointInterval {
+			if err := td.saveTableProgress(dbClient, dr, lastProcessedRow); err != nil {
+				return nil, vterrors.Wrapf(err, "failed to checkpoint progress on table %s", td.table.Name)
+			}
+			globalStats.CheckpointCount.Add(1)
+			lastCheckpoint = time.Now()
+		}
+
 		if !mismatch && dr.MismatchedRows > 0 {
 			mismatch = true
 			log.Infof("Flagging mismatch in vdiff %s for %s: %+v", td.wd.ct.uuid, td.table.Name, dr)
@@ -630,6 +729,15 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 		advanceSource = true
 		advanceTarget = true
 		if sourceRow == nil {
+			if td.isShared(coreOpts) {
+				count, err := targetExecutor.drain(ctx)
+				if err != nil {
+					return nil, err
+				}
+				dr.ExtraRowsTargetOtherSources += 1 + count
+				dr.ProcessedRows += 1 + count
+				return dr, nil
+			}
 			diffRow, err := td.genRowDiff(td.tablePlan.sourceQuery, targetRow, reportOpts)
 			if err != nil {
 				return nil, vterrors.Wrap(err, "unexpected error generating diff")
@@ -680,6 +788,10 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 			dr.ExtraRowsSource++
 			advanceTarget = false
 			continue
+		case c > 0 && td.isShared(coreOpts):
+			dr.ExtraRowsTargetOtherSources++
+			advanceSource = false
+			continue
 		case c > 0:
 			if dr.ExtraRowsTarget < maxExtraRowsToCompare {
 				diffRow, err := td.genRowDiff(td.tablePlan.targetQuery, targetRow, reportOpts)
@@ -728,6 +840,13 @@ func (td *tableDiffer) diff(ctx context.Context, coreOpts *tabletmanagerdatapb.V
 	}
 }
 
+// isShared returns true if the target table also holds the rows of other
+// workflows, so that the target rows which are not on the source are not
+// differences.
+func (td *tableDiffer) isShared(coreOpts *tabletmanagerdatapb.VDiffCoreOptions) bool {
+	return slices.Contains(coreOpts.GetSharedTables(), td.table.Name)
+}
+
 func (td *tableDiffer) compare(sourceRow, targetRow []sqltypes.Value, cols []compareColInfo, compareOnlyNonPKs bool) (int, error) {
 	for _, col := range cols {
 		if col.isPK && compareOnlyNonPKs {
@@ -756,6 +875,16 @@ func (td *tableDiffer) compare(sourceRow, targetRow []sqltypes.Value, cols []com
 }
 
 func (td *tableDiffer) updateTableProgress(dbClient binlogplayer.DBClient, dr *DiffReport, lastRow []sqltypes.Value) error {
+	if err := td.saveTableProgress(dbClient, dr, lastRow); err != nil {
+		return err
+	}
+	td.wd.ct.TableDiffRowCounts.Add(td.table.Name, dr.ProcessedRows)
+	return nil
+}
+
+// saveTableProgress persists the report and the last PK that was diffed for the
+// table, which is where the diff is resumed from.
+func (td *tableDiffer) saveTableProgress(dbClient binlogplayer.DBClient, dr *DiffReport, lastRow []sqltypes.Value) error {
 	if dr == nil {
 		return errors.New("cannot update progress with a nil diff report")
 	}
@@ -808,8 +937,6 @@ func (td *tableDiffer) updateTableProgress(dbClient binlogplayer.DBClient, dr *D
 	if _, err := dbClient.ExecuteFetch(query, 1); err != nil {
 		return err
 	}
-
-	td.wd.ct.TableDiffRowCounts.Add(td.table.Name, dr.ProcessedRows)
 	return nil
 }
 
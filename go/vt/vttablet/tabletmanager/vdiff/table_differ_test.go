@@ -17,6 +17,7 @@ limitations under the License.
 package vdiff
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -161,3 +162,46 @@ func TestGetSourcePKCols_TableDroppedOnSource(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, td.tablePlan.sourcePkCols)
 }
+
+func TestSyncSourceStreams_ExternalMysql(t *testing.T) {
+	tvde := newTestVDiffEnv(t)
+	defer tvde.close()
+
+	ct := tvde.createController(t, 1)
+	source := ct.sources[tstenv.ShardName]
+	source.tablet = nil
+	source.external = "ext1"
+
+	td := &tableDiffer{
+		wd: &workflowDiffer{
+			ct: ct,
+		},
+		table: &tabletmanagerdatapb.TableDefinition{
+			Name: "t1",
+		},
+	}
+
+	// We do not wait for a position on the external MySQL, whose snapshot is
+	// checked against the target streams instead.
+	require.NoError(t, td.syncSourceStreams(context.Background()))
+	require.Equal(t, "external mysql ext1", source.shardStreamer.String())
+}
+
+func TestCheckExternalSourcePosition(t *testing.T) {
+	targetPos, err := binlogplayer.DecodePosition("MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-100")
+	require.NoError(t, err)
+	source := &migrationSource{
+		shardStreamer: &shardStreamer{external: "ext1"},
+		position:      targetPos,
+	}
+
+	// The snapshot of the external MySQL includes what the target replicated.
+	source.snapshotPosition = "MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-110"
+	require.NoError(t, checkExternalSourcePosition(source))
+	source.snapshotPosition = "MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-100"
+	require.NoError(t, checkExternalSourcePosition(source))
+
+	// The external MySQL is behind the target, e.g. as it is a lagging replica.
+	source.snapshotPosition = "MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-90"
+	require.EqualError(t, checkExternalSourcePosition(source), "the snapshot position MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-90 of external mysql ext1 is behind the position MySQL56/f69ed286-6909-11ed-8342-0a50724f3211:1-100 of the target streams")
+}
@@ -210,7 +210,7 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		diffReport *DiffReport
 		diffErr    error
 	)
-	defer func() {
+	stopDiffTimer := func() {
 		if diffTimer != nil {
 			if !diffTimer.Stop() {
 				select {
@@ -218,8 +218,10 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 				default:
 				}
 			}
+			diffTimer = nil
 		}
-	}()
+	}
+	defer stopDiffTimer()
 
 	maxDiffRuntime := time.Duration(24 * time.Hour * 365) // 1 year (effectively forever)
 	if wd.ct.options.CoreOptions.MaxDiffSeconds > 0 {
@@ -232,6 +234,9 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		return err
 	}
 
+	// When sampling, each of the sampled PK ranges is diffed in turn, with its
+	// own database snapshots.
+	sampleRanges := td.pendingSampleRanges()
 	for {
 		select {
 		case <-ctx.Done():
@@ -242,18 +247,16 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		}
 
 		if diffTimer != nil { // We're restarting the diff
-			if !diffTimer.Stop() {
-				select {
-				case <-diffTimer.C:
-				default:
-				}
-			}
-			diffTimer = nil
+			stopDiffTimer()
 			cancelShardStreams()
 			// Give the underlying resources (mainly MySQL) a moment to catch up
 			// before we pick up where we left off (but with new database snapshots).
 			time.Sleep(30 * time.Second)
 		}
+		if len(sampleRanges) > 0 {
+			td.sampleRange = &sampleRanges[0]
+			log.Infof("Diffing the %s range of table %s for vdiff %s", td.sampleRange, td.table.Name, wd.ct.uuid)
+		}
 		if err := td.initialize(ctx); err != nil { // Setup the consistent snapshots
 			return err
 		}
@@ -261,6 +264,12 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		diffTimer = time.NewTimer(maxDiffRuntime)
 		diffReport, diffErr = td.diff(ctx, wd.opts.CoreOptions, wd.opts.ReportOptions, diffTimer.C)
 		if diffErr == nil { // We finished the diff successfully
+			if len(sampleRanges) > 1 { // Move on to the next sampled range
+				sampleRanges = sampleRanges[1:]
+				stopDiffTimer()
+				cancelShardStreams()
+				continue
+			}
 			break
 		}
 		log.Errorf("Encountered an error diffing table %s for vdiff %s: %v", td.table.Name, wd.ct.uuid, diffErr)
@@ -283,6 +292,11 @@ func (wd *workflowDiffer) diffTable(ctx context.Context, dbClient binlogplayer.D
 		}
 	}
 
+	if td.isSampled() {
+		diffReport.SamplePercent = wd.opts.CoreOptions.SamplePct
+		diffReport.EstimatedMismatchedRows = estimateMismatchedRows(diffReport, wd.opts.CoreOptions.SamplePct)
+	}
+
 	log.Infof("Completed reconciliation on table %s for vdiff %s with updated report: %+v", td.table.Name, wd.ct.uuid, diffReport)
 	if err := td.updateTableStateAndReport(ctx, dbClient, CompletedState, diffReport); err != nil {
 		return err
@@ -439,6 +453,9 @@ func (wd *workflowDiffer) buildPlan(dbClient binlogplayer.DBClient, filter *binl
 			return vterrors.Wrapf(err, "could not get the primary key columns from the %s source keyspace",
 				wd.ct.sourceKeyspace)
 		}
+		if err := td.buildSampleRanges(dbClient, wd.opts.CoreOptions.SamplePct); err != nil {
+			return vterrors.Wrapf(err, "could not build the sample ranges for table %s", table.Name)
+		}
 	}
 	if len(wd.tableDiffers) == 0 {
 		return fmt.Errorf("no tables found to diff, %s:%s, on tablet %v",
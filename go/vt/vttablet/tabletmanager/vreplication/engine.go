@@ -109,6 +109,10 @@ type Engine struct {
 
 	throttlerClient *throttle.Client
 
+	// stateChangeNotifier, if set, is called when a stream is created,
+	// deleted, or changes state.
+	stateChangeNotifier func()
+
 	// This should only be set in Test Engines in order to short
 	// circuit functions as needed in unit tests. It's automatically
 	// enabled in NewSimpleTestEngine. This should NOT be used in
@@ -149,6 +153,18 @@ func NewEngine(env *vtenv.Environment, config *tabletenv.TabletConfig, ts *topo.
 	return vre
 }
 
+// SetStateChangeNotifier sets the function called when a stream is created,
+// deleted, or changes state. It must be called before the engine is opened.
+func (vre *Engine) SetStateChangeNotifier(notifier func()) {
+	vre.stateChangeNotifier = notifier
+}
+
+func (vre *Engine) notifyStateChange() {
+	if vre.stateChangeNotifier != nil {
+		vre.stateChangeNotifier()
+	}
+}
+
 // InitDBConfig should be invoked after the db name is computed.
 func (vre *Engine) InitDBConfig(dbcfgs *dbconfigs.DBConfigs) {
 	// If we're already initialized, it's a test engine. Ignore the call.
@@ -236,6 +252,12 @@ func (vre *Engine) ThrottlerClient() *throttle.Client {
 	return vre.throttlerClient
 }
 
+// ExternalVStreamerClient returns a VStreamerClient for the named external MySQL
+// connection, as defined in the tablet's externalConnections config.
+func (vre *Engine) ExternalVStreamerClient(name string) (VStreamerClient, error) {
+	return vre.ec.Get(name)
+}
+
 func (vre *Engine) openLocked(ctx context.Context) error {
 	rows, err := vre.readAllRows(ctx)
 	if err != nil {
@@ -396,6 +418,11 @@ func (vre *Engine) exec(query string, runAsAdmin bool) (*sqltypes.Result, error)
 		return nil, err
 	}
 
+	switch plan.opcode {
+	case insertQuery, updateQuery, deleteQuery:
+		defer vre.notifyStateChange()
+	}
+
 	switch plan.opcode {
 	case insertQuery:
 		qr, err := dbClient.ExecuteFetch(plan.query, 1)
@@ -539,6 +539,83 @@ func (st *vrStats) register() {
 			}
 			return result
 		})
+	stats.NewCountersFuncWithMultiLabels(
+		"VReplicationTableDMLCounts",
+		"vreplication row changes applied by the vplayer per target table and DML type per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "table", "type"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for key, count := range ct.blpStats.TableDMLCounts.Counts() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+strconv.Itoa(int(ct.id))+"."+key] = count
+				}
+			}
+			return result
+		})
+	stats.NewRateFunc(
+		"VReplicationTableDMLRates",
+		"vreplication row changes applied by the vplayer per second per stream, target table and DML type",
+		func() map[string][]float64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string][]float64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for key, rates := range ct.blpStats.TableDMLRates.Get() {
+					result[fmt.Sprintf("%s.%d.%s", ct.workflow, ct.id, key)] = rates
+				}
+			}
+			return result
+		})
+	stats.NewGaugesFuncWithMultiLabels(
+		"VReplicationTableApplyTimings",
+		"vreplication time spent applying statements by the vplayer per target table per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "table"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for table, t := range ct.blpStats.TableApplyTimings.Histograms() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+strconv.Itoa(int(ct.id))+"."+table] = t.Total()
+				}
+			}
+			return result
+		})
+	stats.NewGaugesFuncWithMultiLabels(
+		"VReplicationTableApplyTimingsCounts",
+		"vreplication statements applied by the vplayer per target table per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "table"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for table, count := range ct.blpStats.TableApplyTimings.Counts() {
+					if table == "All" {
+						continue
+					}
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+strconv.Itoa(int(ct.id))+"."+table] = count
+				}
+			}
+			return result
+		})
+	stats.NewGaugesFuncWithMultiLabels(
+		"VReplicationTrxBatchSizes",
+		"vreplication transactions applied by the vplayer per number of row changes per stream",
+		[]string{"source_keyspace", "source_shard", "workflow", "counts", "size"},
+		func() map[string]int64 {
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			result := make(map[string]int64, len(st.controllers))
+			for _, ct := range st.controllers {
+				for size, count := range ct.blpStats.TrxBatchSizes.Counts() {
+					result[ct.source.Keyspace+"."+ct.source.Shard+"."+ct.workflow+"."+strconv.Itoa(int(ct.id))+"."+size] = count
+				}
+			}
+			return result
+		})
 	stats.Publish("VReplicationConfig", stats.StringMapFunc(func() map[string]string {
 		st.mu.Lock()
 		defer st.mu.Unlock()
@@ -597,6 +674,9 @@ func (st *vrStats) status() *EngineStatus {
 			CopyLoopCount:         ct.blpStats.CopyLoopCount.Get(),
 			NoopQueryCounts:       ct.blpStats.NoopQueryCount.Counts(),
 			TableCopyTimings:      ct.blpStats.TableCopyTimings.Counts(),
+			TableDMLCounts:        ct.blpStats.TableDMLCounts.Counts(),
+			TableApplyLatencies:   tableApplyLatencies(ct.blpStats.TableApplyTimings),
+			TrxBatchSizes:         ct.blpStats.TrxBatchSizes.Counts(),
 		}
 		state := ct.blpStats.State.Load()
 		if state != nil {
@@ -637,6 +717,22 @@ type ControllerStatus struct {
 	CopyLoopCount         int64
 	NoopQueryCounts       map[string]int64
 	TableCopyTimings      map[string]int64
+	TableDMLCounts        map[string]int64
+	// TableApplyLatencies is the average time to apply a statement by the
+	// vplayer, per target table.
+	TableApplyLatencies map[string]time.Duration
+	TrxBatchSizes       map[string]int64
+}
+
+func tableApplyLatencies(timings *stats.Timings) map[string]time.Duration {
+	histograms := timings.Histograms()
+	latencies := make(map[string]time.Duration, len(histograms))
+	for table, h := range histograms {
+		if count := h.Count(); count > 0 {
+			latencies[table] = time.Duration(h.Total() / count)
+		}
+	}
+	return latencies
 }
 
 const vreplicationTemplate = `
@@ -30,6 +30,7 @@ import (
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
 )
 
@@ -211,6 +212,23 @@ func TestVReplicationStats(t *testing.T) {
 	require.Equal(t, int64(2), testStats.controllers[1].blpStats.DDLEventActions.Counts()[binlogdatapb.OnDDLAction_EXEC_IGNORE.String()])
 	require.Equal(t, int64(1), testStats.controllers[1].blpStats.DDLEventActions.Counts()[binlogdatapb.OnDDLAction_STOP.String()])
 
+	vp := &vplayer{vr: &vreplicator{stats: blpStats}}
+	vp.recordRowChanges("t1", []*binlogdatapb.RowChange{
+		{After: &querypb.Row{}},
+		{After: &querypb.Row{}},
+		{Before: &querypb.Row{}, After: &querypb.Row{}},
+	})
+	vp.recordRowChanges("t2", []*binlogdatapb.RowChange{{Before: &querypb.Row{}}})
+	require.EqualValues(t, 4, vp.trxRowChanges)
+	status := testStats.status().Controllers[0]
+	require.Equal(t, map[string]int64{"t1.insert": 2, "t1.update": 1, "t2.delete": 1}, status.TableDMLCounts)
+	blpStats.TrxBatchSizes.Add(vp.trxRowChanges)
+	require.EqualValues(t, 1, testStats.status().Controllers[0].TrxBatchSizes["10"])
+
+	blpStats.TableApplyTimings.Add("t1", 2*time.Millisecond)
+	blpStats.TableApplyTimings.Add("t1", 4*time.Millisecond)
+	require.Equal(t, map[string]time.Duration{"t1": 3 * time.Millisecond}, testStats.status().Controllers[0].TableApplyLatencies)
+
 	var tm int64 = 1234567890
 	blpStats.RecordHeartbeat(tm)
 	require.Equal(t, tm, blpStats.Heartbeat())
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/time/rate"
 	"google.golang.org/protobuf/encoding/prototext"
 
 	"vitess.io/vitess/go/bytes2"
@@ -414,6 +415,12 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	resultCh := make(chan *vcopierCopyTaskResult, parallelism*4)
 	defer close(resultCh)
 
+	// Cap the number of rows copied per second, if the workflow asks for it.
+	var rowsLimiter *rate.Limiter
+	if maxRows := vc.vr.workflowConfig.CopyPhaseMaxRowsPerSecond; maxRows > 0 {
+		rowsLimiter = rate.NewLimiter(rate.Limit(maxRows), int(min(maxRows, math.MaxInt32)))
+	}
+
 	var lastpk *querypb.Row
 	var pkfields []*querypb.Field
 
@@ -467,7 +474,8 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 				return nil
 			}
 			// verify throttler is happy, otherwise keep looping
-			if checkResult, ok := vc.vr.vre.throttlerClient.ThrottleCheckOKOrWaitAppName(ctx, throttlerapp.Name(vc.throttlerAppName)); ok {
+			maxLag := vc.vr.workflowConfig.CopyPhaseMaxReplicationLag.Seconds()
+			if checkResult, ok := vc.vr.vre.throttlerClient.ThrottleCheckOKOrWaitAppNameWithMaxLag(ctx, throttlerapp.Name(vc.throttlerAppName), maxLag); ok {
 				break // out of 'for' loop
 			} else { // we're throttled
 				_ = vc.vr.updateTimeThrottled(throttlerapp.VCopierName, checkResult.Summary())
@@ -504,6 +512,11 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 		if len(rows.Rows) == 0 {
 			return nil
 		}
+		if rowsLimiter != nil {
+			if err := waitForRows(ctx, rowsLimiter, len(rows.Rows)); err != nil {
+				return io.EOF
+			}
+		}
 
 		// Clone rows, since pointer values will change while async work is
 		// happening. Can skip this when there's no parallelism.
@@ -679,6 +692,19 @@ func (vc *vcopier) copyTable(ctx context.Context, tableName string, copyState ma
 	return nil
 }
 
+// waitForRows blocks until the limiter allows the given number of rows to be
+// copied, which may be more than its burst.
+func waitForRows(ctx context.Context, limiter *rate.Limiter, rows int) error {
+	for rows > 0 {
+		n := min(rows, limiter.Burst())
+		if err := limiter.WaitN(ctx, n); err != nil {
+			return err
+		}
+		rows -= n
+	}
+	return nil
+}
+
 // updatePos is called after the last table is copied in an atomic copy, to set the gtid so that the replicating phase
 // can start from the gtid where the snapshot with all tables was taken. It also updates the final copy row count.
 func (vc *vcopier) updatePos(ctx context.Context, gtid string) error {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,11 +31,13 @@ import (
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/throttle/throttlerapp"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 )
 
 const failedToRecordHeartbeatMsg = "failed to record heartbeat"
@@ -47,6 +50,10 @@ var (
 
 	// The error to return when we have detected a stall in the vplayer.
 	errVPlayerStalled = errors.New("progress stalled; vplayer was unable to replicate the transaction in a timely manner; examine the target mysqld instance health and the replicated queries' EXPLAIN output to see why queries are taking unusually long")
+
+	// The error to return when tables have been queued for re-materialization after a DDL,
+	// so that the vreplicator goes back to the copy phase.
+	errRematerialize = errors.New("tables queued for re-materialization")
 )
 
 // vplayer replays binlog events by pulling them from a vstreamer.
@@ -82,6 +89,8 @@ type vplayer struct {
 	timeOffsetNs int64
 	// numAccumulatedHeartbeats keeps track of how many heartbeats have been received since we updated the time_updated column of _vt.vreplication
 	numAccumulatedHeartbeats int
+	// trxRowChanges is the number of row changes applied in the current transaction.
+	trxRowChanges int64
 
 	// canAcceptStmtEvents is set to true if the current player can accept events in statement mode. Only true for filters that are match all.
 	canAcceptStmtEvents bool
@@ -351,6 +360,7 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 		qr, err := vp.query(ctx, sql)
 		vp.vr.stats.QueryCount.Add(vp.phase, 1)
 		vp.vr.stats.QueryTimings.Record(vp.phase, start)
+		vp.vr.stats.TableApplyTimings.Record(tplan.TargetName, start)
 		if vp.vr.workflowConfig.EnableHttpLog {
 			stats := NewVrLogStats("ROWCHANGE", start)
 			stats.Send(sql)
@@ -363,15 +373,21 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 		// then we can perform a simple bulk DELETE using an IN clause.
 		if (rowEvent.RowChanges[0].Before != nil && rowEvent.RowChanges[0].After == nil) &&
 			tplan.MultiDelete != nil {
-			_, err := tplan.applyBulkDeleteChanges(rowEvent.RowChanges, applyFunc, vp.vr.dbClient.maxBatchSize)
-			return err
+			if _, err := tplan.applyBulkDeleteChanges(rowEvent.RowChanges, applyFunc, vp.vr.dbClient.maxBatchSize); err != nil {
+				return err
+			}
+			vp.recordRowChanges(tplan.TargetName, rowEvent.RowChanges)
+			return nil
 		}
 		// If we're done with the copy phase then we will be replicating all INSERTS
 		// regardless of the PK value and can use a single INSERT statment with
 		// multiple VALUES clauses.
 		if len(vp.copyState) == 0 && (rowEvent.RowChanges[0].Before == nil && rowEvent.RowChanges[0].After != nil) {
-			_, err := tplan.applyBulkInsertChanges(rowEvent.RowChanges, applyFunc, vp.vr.dbClient.maxBatchSize)
-			return err
+			if _, err := tplan.applyBulkInsertChanges(rowEvent.RowChanges, applyFunc, vp.vr.dbClient.maxBatchSize); err != nil {
+				return err
+			}
+			vp.recordRowChanges(tplan.TargetName, rowEvent.RowChanges)
+			return nil
 		}
 	}
 
@@ -380,10 +396,27 @@ func (vp *vplayer) applyRowEvent(ctx context.Context, rowEvent *binlogdatapb.Row
 			return err
 		}
 	}
+	vp.recordRowChanges(tplan.TargetName, rowEvent.RowChanges)
 
 	return nil
 }
 
+// recordRowChanges records the row changes applied to a target table in the
+// stats of its DML mix, and in the size of the current transaction.
+func (vp *vplayer) recordRowChanges(table string, changes []*binlogdatapb.RowChange) {
+	for _, change := range changes {
+		dmlType := "update"
+		switch {
+		case change.Before == nil:
+			dmlType = "insert"
+		case change.After == nil:
+			dmlType = "delete"
+		}
+		vp.vr.stats.TableDMLCounts.Add([]string{table, dmlType}, 1)
+	}
+	vp.trxRowChanges += int64(len(changes))
+}
+
 // updatePos should get called at a minimum of vreplicationMinimumHeartbeatUpdateInterval.
 func (vp *vplayer) updatePos(ctx context.Context, ts int64) (posReached bool, err error) {
 	update := binlogplayer.GenerateUpdatePos(vp.vr.id, vp.pos, time.Now().Unix(), ts, vp.vr.stats.CopyRowCount.Get(), vp.vr.workflowConfig.StoreCompressedGTID)
@@ -406,6 +439,86 @@ func (vp *vplayer) updatePos(ctx context.Context, ts int64) (posReached bool, er
 	return posReached, nil
 }
 
+// rematerialize handles a DDL for the REMATERIALIZE on-ddl action: the target
+// tables which are materialized from the tables affected by the DDL are
+// truncated and queued in the copy_state table, so that they are copied again
+// from the source with the new schema. It returns errRematerialize if any table
+// was queued, in which case the vreplicator goes back to the copy phase.
+func (vp *vplayer) rematerialize(ctx context.Context, event *binlogdatapb.VEvent) error {
+	if vp.vr.WorkflowType != int32(binlogdatapb.VReplicationWorkflowType_Materialize) {
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "the %s on-ddl action is only supported for Materialize workflows",
+			binlogdatapb.OnDDLAction_REMATERIALIZE)
+	}
+	targetTables := vp.tablesToRematerialize(event.Statement)
+	if len(targetTables) > 0 {
+		// Truncating a table implicitly commits, so the tables are truncated
+		// before the copy_state rows and the position are saved. If we fail
+		// in between, the DDL will be received again and the tables will be
+		// truncated again.
+		for _, table := range targetTables {
+			if _, err := vp.query(ctx, "truncate table "+sqlparser.String(sqlparser.NewIdentifierCS(table))); err != nil {
+				return err
+			}
+		}
+		if err := vp.vr.dbClient.Begin(); err != nil {
+			return err
+		}
+		var buf strings.Builder
+		buf.WriteString("insert into _vt.copy_state(vrepl_id, table_name) values ")
+		for i, table := range targetTables {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "(%d, %s)", vp.vr.id, encodeString(table))
+		}
+		if _, err := vp.query(ctx, buf.String()); err != nil {
+			return err
+		}
+	}
+	posReached, err := vp.updatePos(ctx, event.Timestamp)
+	if err != nil {
+		return err
+	}
+	if len(targetTables) > 0 {
+		if err := vp.commit(); err != nil {
+			return err
+		}
+		vp.vr.insertLog(LogMessage, fmt.Sprintf("Re-materializing table(s) %s after DDL %s",
+			strings.Join(targetTables, ", "), event.Statement))
+		return errRematerialize
+	}
+	if posReached {
+		return io.EOF
+	}
+	return nil
+}
+
+// tablesToRematerialize returns the sorted names of the target tables which
+// are materialized from the tables affected by the DDL. If the DDL cannot be
+// parsed, all the target tables are returned.
+func (vp *vplayer) tablesToRematerialize(ddl string) []string {
+	var sourceTables []string
+	stmt, err := vp.vr.vre.env.Parser().Parse(ddl)
+	if ddlStmt, ok := stmt.(sqlparser.DDLStatement); err == nil && ok {
+		for _, table := range ddlStmt.AffectedTables() {
+			sourceTables = append(sourceTables, table.Name.String())
+		}
+	} else {
+		log.Warningf("Could not determine the tables affected by DDL %s, re-materializing all tables: %v", ddl, err)
+		for sourceTable := range vp.replicatorPlan.TablePlans {
+			sourceTables = append(sourceTables, sourceTable)
+		}
+	}
+	var targetTables []string
+	for _, sourceTable := range sourceTables {
+		if tablePlan, ok := vp.replicatorPlan.TablePlans[sourceTable]; ok && !slices.Contains(targetTables, tablePlan.TargetName) {
+			targetTables = append(targetTables, tablePlan.TargetName)
+		}
+	}
+	slices.Sort(targetTables)
+	return targetTables
+}
+
 func (vp *vplayer) mustUpdateHeartbeat() bool {
 	return vp.numAccumulatedHeartbeats >= vp.vr.workflowConfig.HeartbeatUpdateInterval ||
 		vp.numAccumulatedHeartbeats >= vreplicationMinimumHeartbeatUpdateInterval
@@ -549,7 +662,7 @@ func (vp *vplayer) applyEvents(ctx context.Context, relay *relayLog) error {
 					}
 				}
 				if err := vp.applyEvent(ctx, event, mustSave); err != nil {
-					if err != io.EOF {
+					if err != io.EOF && err != errRematerialize {
 						vp.vr.stats.ErrorCounts.Add([]string{"Apply"}, 1)
 						var table, tableLogMsg, gtidLogMsg string
 						switch {
@@ -681,6 +794,8 @@ func (vp *vplayer) applyEvent(ctx context.Context, event *binlogdatapb.VEvent, m
 		if err := vp.commit(); err != nil {
 			return err
 		}
+		vp.vr.stats.TrxBatchSizes.Add(vp.trxRowChanges)
+		vp.trxRowChanges = 0
 		if posReached {
 			return io.EOF
 		}
@@ -808,6 +923,8 @@ func (vp *vplayer) applyEvent(ctx context.Context, event *binlogdatapb.VEvent, m
 			if posReached {
 				return io.EOF
 			}
+		case binlogdatapb.OnDDLAction_REMATERIALIZE:
+			return vp.rematerialize(ctx, event)
 		}
 	case binlogdatapb.VEventType_JOURNAL:
 		if vp.vr.dbClient.InTransaction {
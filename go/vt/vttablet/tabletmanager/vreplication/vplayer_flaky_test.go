@@ -36,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/binlog/binlogplayer"
 	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/logutil"
+	"vitess.io/vitess/go/vt/vtenv"
 	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer/testenv"
 
@@ -2121,6 +2122,44 @@ func TestPlayerDDL(t *testing.T) {
 	cancel()
 }
 
+func TestTablesToRematerialize(t *testing.T) {
+	vp := &vplayer{
+		vr: &vreplicator{vre: &Engine{env: vtenv.NewTestEnv()}},
+		replicatorPlan: &ReplicatorPlan{
+			TablePlans: map[string]*TablePlan{
+				"orders":    {TargetName: "sales_by_sku"},
+				"customers": {TargetName: "customers_by_region"},
+				"products":  {TargetName: "products"},
+			},
+		},
+	}
+	testCases := []struct {
+		ddl  string
+		want []string
+	}{
+		{
+			ddl:  "alter table orders add column discount int",
+			want: []string{"sales_by_sku"},
+		},
+		{
+			ddl:  "rename table customers to customers_old, products to products_old",
+			want: []string{"customers_by_region", "products"},
+		},
+		{
+			ddl: "create table other(id int primary key)",
+		},
+		{
+			ddl:  "not a ddl",
+			want: []string{"customers_by_region", "products", "sales_by_sku"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ddl, func(t *testing.T) {
+			require.Equal(t, tc.want, vp.tablesToRematerialize(tc.ddl))
+		})
+	}
+}
+
 func TestGTIDCompress(t *testing.T) {
 	ctx := context.Background()
 	defer deleteTablet(addTablet(100))
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -72,6 +73,11 @@ const (
 	SQLMode          = "NO_AUTO_VALUE_ON_ZERO"
 	StrictSQLMode    = "STRICT_ALL_TABLES,NO_AUTO_VALUE_ON_ZERO"
 	setSQLModeQueryf = `SET @@session.sql_mode='%s'`
+	// disableTriggersVariable is the user variable which is set on the target
+	// connections when the workflow is configured to disable target triggers.
+	// Triggers on the target tables can check it to skip the rows written by
+	// VReplication, e.g.: IF @vreplication_disable_triggers IS NULL THEN ... END IF
+	disableTriggersVariable = "vreplication_disable_triggers"
 
 	sqlCreatePostCopyAction = `insert into _vt.post_copy_action(vrepl_id, table_name, action)
 	values(%a, %a, convert(%a using utf8mb4))`
@@ -248,6 +254,9 @@ func (vr *vreplicator) replicate(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := vr.setTargetSessionVariables(vr.dbClient); err != nil {
+		return err
+	}
 
 	colInfo, err := vr.buildColInfoMap(ctx)
 	if err != nil {
@@ -347,7 +356,12 @@ func (vr *vreplicator) replicate(ctx context.Context) error {
 				vr.stats.ErrorCounts.Add([]string{"Replicate"}, 1)
 				return err
 			}
-			return newVPlayer(vr, settings, nil, replication.Position{}, "replicate").play(ctx)
+			err := newVPlayer(vr, settings, nil, replication.Position{}, "replicate").play(ctx)
+			if err == errRematerialize {
+				// Go back to the copy phase to copy the re-materialized tables.
+				continue
+			}
+			return err
 		}
 	}
 }
@@ -531,6 +545,9 @@ func (vr *vreplicator) setState(state binlogdatapb.VReplicationWorkflowState, me
 	}
 	insertLog(vr.dbClient, LogStateChange, vr.id, state.String(), message)
 	vr.state = state
+	if vr.vre != nil {
+		vr.vre.notifyStateChange()
+	}
 
 	return nil
 }
@@ -634,6 +651,78 @@ func (vr *vreplicator) setSQLMode(ctx context.Context, dbClient *vdbClient) (fun
 	return resetFunc, nil
 }
 
+// targetSessionVariables are the session system variables which a workflow
+// can set on the connections used to write to the target. The connections are
+// dba connections, so variables which change what is written to the binlog,
+// like sql_log_bin or gtid_next, or which bypass privilege checks, cannot be
+// set. User variables can always be set.
+var targetSessionVariables = []string{
+	"auto_increment_increment",
+	"auto_increment_offset",
+	"block_encryption_mode",
+	"div_precision_increment",
+	"group_concat_max_len",
+	"innodb_lock_wait_timeout",
+	"lock_wait_timeout",
+	"sql_mode",
+	"time_zone",
+	"unique_checks",
+}
+
+// setTargetSessionVariables applies the session variables which the workflow
+// is configured to set on the connections used to write to the target. It is
+// called after setSQLMode so that the workflow can also override the sql_mode.
+func (vr *vreplicator) setTargetSessionVariables(dbClient *vdbClient) error {
+	query, err := buildTargetSessionVariablesQuery(vr.vre.env.Parser(), vr.workflowConfig)
+	if err != nil || query == "" {
+		return err
+	}
+	if _, err := dbClient.Execute(query); err != nil {
+		return fmt.Errorf("could not set the session variables on target using %s: %v", query, err)
+	}
+	return nil
+}
+
+// buildTargetSessionVariablesQuery returns the SET statement for the target
+// session variables of the workflow config, or an empty string if there are
+// none. Only session and user variables can be set.
+func buildTargetSessionVariablesQuery(parser *sqlparser.Parser, config *vttablet.VReplicationConfig) (string, error) {
+	set := &sqlparser.Set{}
+	if config.TargetSessionVariables != "" {
+		stmt, err := parser.Parse("set " + config.TargetSessionVariables)
+		if err != nil {
+			return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid target session variables %q: %v", config.TargetSessionVariables, err)
+		}
+		parsed, ok := stmt.(*sqlparser.Set)
+		if !ok {
+			return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid target session variables %q", config.TargetSessionVariables)
+		}
+		for _, expr := range parsed.Exprs {
+			switch expr.Var.Scope {
+			case sqlparser.VariableScope:
+			case sqlparser.NoScope, sqlparser.SessionScope:
+				if !slices.Contains(targetSessionVariables, expr.Var.Name.Lowered()) {
+					return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "session variable %s cannot be set on the target, only %s can",
+						sqlparser.String(expr.Var), strings.Join(targetSessionVariables, ", "))
+				}
+			default:
+				return "", vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "only session and user variables can be set on the target, not %s", sqlparser.String(expr.Var))
+			}
+		}
+		set.Exprs = parsed.Exprs
+	}
+	if config.DisableTargetTriggers {
+		set.Exprs = append(set.Exprs, &sqlparser.SetExpr{
+			Var:  sqlparser.NewSetVariable(disableTriggersVariable, sqlparser.VariableScope),
+			Expr: sqlparser.NewIntLiteral("1"),
+		})
+	}
+	if len(set.Exprs) == 0 {
+		return "", nil
+	}
+	return sqlparser.String(set), nil
+}
+
 // throttlerAppName returns the app name to be used by throttlerClient for this particular workflow
 // example results:
 //   - "vreplication" for most flows
@@ -1136,6 +1225,9 @@ func (vr *vreplicator) newClientConnection(ctx context.Context) (*vdbClient, err
 	if _, err := vr.setSQLMode(ctx, dbClient); err != nil {
 		return nil, vterrors.Wrap(err, "failed to set sql_mode")
 	}
+	if err := vr.setTargetSessionVariables(dbClient); err != nil {
+		return nil, vterrors.Wrap(err, "failed to set target session variables")
+	}
 	if err := vr.clearFKCheck(dbClient); err != nil {
 		return nil, vterrors.Wrap(err, "failed to clear foreign key check")
 	}
@@ -36,6 +36,7 @@ import (
 	"vitess.io/vitess/go/vt/dbconfigs"
 	"vitess.io/vitess/go/vt/mysqlctl"
 	"vitess.io/vitess/go/vt/schemadiff"
+	"vitess.io/vitess/go/vt/sqlparser"
 	vttablet "vitess.io/vitess/go/vt/vttablet/common"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -869,3 +870,68 @@ func TestThrottlerAppNames(t *testing.T) {
 	assert.Contains(t, vc.throttlerAppName, "vcopier")
 	assert.NotContains(t, vc.throttlerAppName, "vplayer")
 }
+
+func TestBuildTargetSessionVariablesQuery(t *testing.T) {
+	parser := sqlparser.NewTestParser()
+	tests := []struct {
+		name             string
+		sessionVariables string
+		disableTriggers  bool
+		want             string
+		wantErr          string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:             "session and user variables",
+			sessionVariables: "sql_mode='STRICT_TRANS_TABLES', @@session.unique_checks=0, @skip_audit=1",
+			want:             "set sql_mode = 'STRICT_TRANS_TABLES', @@unique_checks = 0, @skip_audit = 1",
+		},
+		{
+			name:            "disable triggers",
+			disableTriggers: true,
+			want:            "set @vreplication_disable_triggers = 1",
+		},
+		{
+			name:             "session variables and disable triggers",
+			sessionVariables: "@skip_audit=1",
+			disableTriggers:  true,
+			want:             "set @skip_audit = 1, @vreplication_disable_triggers = 1",
+		},
+		{
+			name:             "global variable",
+			sessionVariables: "global sql_mode=''",
+			wantErr:          "only session and user variables can be set on the target",
+		},
+		{
+			name:             "session variable which is not allowed",
+			sessionVariables: "@skip_audit=1, sql_log_bin=0",
+			wantErr:          "session variable sql_log_bin cannot be set on the target",
+		},
+		{
+			name:             "session scoped variable which is not allowed",
+			sessionVariables: "@@session.gtid_next='AUTOMATIC'",
+			wantErr:          "session variable @@gtid_next cannot be set on the target",
+		},
+		{
+			name:             "invalid",
+			sessionVariables: "sql_mode=",
+			wantErr:          "invalid target session variables",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := vttablet.GetDefaultVReplicationConfig()
+			config.TargetSessionVariables = tt.sessionVariables
+			config.DisableTargetTriggers = tt.disableTriggers
+			query, err := buildTargetSessionVariablesQuery(parser, config)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, query)
+		})
+	}
+}
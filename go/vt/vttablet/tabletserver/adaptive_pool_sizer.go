@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"cmp"
+	"context"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/dbconnpool"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+const sqlShowThreadsRunning = "show global status like 'Threads_running'"
+
+// adaptivePool is a connection pool resized by the adaptivePoolSizer.
+type adaptivePool struct {
+	name     string
+	min, max int64
+
+	capacity    func() int64
+	setCapacity func(ctx context.Context, capacity int64) error
+	// waitCount returns the cumulative number of times a caller had to wait
+	// for a connection of the pool.
+	waitCount func() int64
+
+	lastWaitCount int64
+}
+
+// poolLoad is a sample of the load of MySQL over an adjustment interval. The
+// latency and the error rate only cover the time spent in MySQL and the errors
+// signaling its overload: shrinking the pools makes the callers wait for a
+// connection and get rejected, which must not be read as more load.
+type poolLoad struct {
+	threadsRunning int64
+	latency        time.Duration
+	errorRate      float64
+}
+
+// adaptivePoolSizer adjusts the sizes of the query and transaction pools to
+// the load of MySQL: they are shrunk while MySQL is overloaded, as reported by
+// its threads_running and by the latency and error rate of the queries, and
+// grown while callers wait for a connection of a pool.
+type adaptivePoolSizer struct {
+	env   tabletenv.Env
+	qe    *QueryEngine
+	pools []*adaptivePool
+	ticks *timer.Timer
+
+	// threadsRunning returns the threads_running of MySQL.
+	threadsRunning func(ctx context.Context) (int64, error)
+	// dbaConn is the connection which reads the threads_running, kept open
+	// between the adjustments.
+	dbaConn *dbconnpool.DBConnection
+
+	targetSizes *stats.GaugesWithSingleLabel
+	resizes     *stats.CountersWithMultiLabels
+
+	// mu protects the following fields.
+	mu                     sync.Mutex
+	isOpen                 bool
+	lastQueries, lastTimes int64
+	lastErrors             int64
+}
+
+func newAdaptivePoolSizer(env tabletenv.Env, qe *QueryEngine, te *TxEngine) *adaptivePoolSizer {
+	config := env.Config()
+	s := &adaptivePoolSizer{
+		env: env,
+		qe:  qe,
+		pools: []*adaptivePool{{
+			name:        "ConnPool",
+			min:         int64(config.AdaptivePoolSizing.MinPoolSize),
+			max:         int64(cmp.Or(config.AdaptivePoolSizing.MaxPoolSize, config.OltpReadPool.Size)),
+			capacity:    qe.conns.Capacity,
+			setCapacity: qe.conns.SetCapacity,
+			waitCount:   qe.conns.Metrics.WaitCount,
+		}, {
+			name: "TransactionPool",
+			min:  int64(config.AdaptivePoolSizing.MinTxPoolSize),
+			max:  int64(cmp.Or(config.AdaptivePoolSizing.MaxTxPoolSize, config.TxPool.Size)),
+			capacity: func() int64 {
+				return te.txPool.scp.conns.Capacity()
+			},
+			setCapacity: func(ctx context.Context, capacity int64) error {
+				// The transaction pool has a second pool for the connections
+				// with the CLIENT_FOUND_ROWS capability.
+				if err := te.txPool.scp.conns.SetCapacity(ctx, capacity); err != nil {
+					return err
+				}
+				return te.txPool.scp.foundRowsPool.SetCapacity(ctx, capacity)
+			},
+			waitCount: func() int64 {
+				return te.txPool.scp.conns.Metrics.WaitCount() + te.txPool.scp.foundRowsPool.Metrics.WaitCount()
+			},
+		}},
+		targetSizes: env.Exporter().NewGaugesWithSingleLabel("AdaptivePoolTargetSize", "Size of the connection pools targeted by adaptive pool sizing", "Pool"),
+		resizes:     env.Exporter().NewCountersWithMultiLabels("AdaptivePoolResizes", "Resizes of the connection pools by adaptive pool sizing", []string{"Pool", "Direction"}),
+	}
+	s.threadsRunning = s.readThreadsRunning
+	if interval := config.AdaptivePoolSizing.Interval; interval > 0 {
+		s.ticks = timer.NewTimer(interval)
+	}
+	return s
+}
+
+// Open starts adjusting the sizes of the pools periodically.
+func (s *adaptivePoolSizer) Open() {
+	if s.ticks == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isOpen {
+		return
+	}
+	s.isOpen = true
+	s.lastQueries, s.lastTimes, s.lastErrors = s.queryTotals()
+	for _, pool := range s.pools {
+		pool.lastWaitCount = pool.waitCount()
+		s.targetSizes.Set(pool.name, pool.capacity())
+	}
+	s.ticks.Start(s.adjust)
+}
+
+// Close stops adjusting the sizes of the pools. The pools get back their
+// configured sizes when they are reopened.
+func (s *adaptivePoolSizer) Close() {
+	if s.ticks == nil {
+		return
+	}
+	s.ticks.Stop()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.isOpen = false
+	if s.dbaConn != nil {
+		s.dbaConn.Close()
+		s.dbaConn = nil
+	}
+}
+
+// adjust resizes the pools according to the load of MySQL since the last
+// adjustment.
+func (s *adaptivePoolSizer) adjust() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isOpen {
+		return
+	}
+
+	interval := s.env.Config().AdaptivePoolSizing.Interval
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), interval)
+	defer cancel()
+
+	load, err := s.sampleLoad(ctx)
+	if err != nil {
+		log.Warningf("Adaptive pool sizing cannot read the load of MySQL: %v", err)
+		return
+	}
+	overloaded := s.overloaded(load)
+
+	for _, pool := range s.pools {
+		waitCount := pool.waitCount()
+		waited := waitCount > pool.lastWaitCount
+		pool.lastWaitCount = waitCount
+
+		capacity := pool.capacity()
+		target := nextPoolSize(capacity, pool.min, pool.max, overloaded, waited)
+		s.targetSizes.Set(pool.name, target)
+		if target == capacity {
+			continue
+		}
+
+		direction := "Grow"
+		if target < capacity {
+			direction = "Shrink"
+		}
+		s.resizes.Add([]string{pool.name, direction}, 1)
+		log.Infof("Adaptive pool sizing resizes %s from %d to %d (threads_running: %d, latency: %v, error rate: %.2f)",
+			pool.name, capacity, target, load.threadsRunning, load.latency, load.errorRate)
+		// Shrinking the pool waits for its connections in use to be
+		// returned, which may take longer than the interval.
+		if err := pool.setCapacity(ctx, target); err != nil {
+			log.Warningf("Adaptive pool sizing cannot resize %s to %d: %v", pool.name, target, err)
+		}
+	}
+}
+
+// overloaded returns whether the load of MySQL exceeds any of the configured
+// thresholds.
+func (s *adaptivePoolSizer) overloaded(load poolLoad) bool {
+	config := s.env.Config().AdaptivePoolSizing
+	return (config.MaxThreadsRunning > 0 && load.threadsRunning > config.MaxThreadsRunning) ||
+		(config.MaxQueryLatency > 0 && load.latency > config.MaxQueryLatency) ||
+		(config.MaxErrorRate > 0 && load.errorRate > config.MaxErrorRate)
+}
+
+// nextPoolSize returns the size of a pool after an adjustment: it is shrunk
+// by a quarter while MySQL is overloaded, and grown by a tenth if callers
+// waited for a connection, always by at least one connection and within the
+// min and max sizes of the pool.
+func nextPoolSize(capacity, minSize, maxSize int64, overloaded, waited bool) int64 {
+	switch {
+	case overloaded:
+		capacity -= max(capacity/4, 1)
+	case waited:
+		capacity += max(capacity/10, 1)
+	}
+	return min(max(capacity, minSize), maxSize)
+}
+
+// sampleLoad returns the load of MySQL: its current threads_running, and the
+// average latency and the error rate of the queries in MySQL since the last
+// sample.
+func (s *adaptivePoolSizer) sampleLoad(ctx context.Context) (poolLoad, error) {
+	var load poolLoad
+	threadsRunning, err := s.threadsRunning(ctx)
+	if err != nil {
+		return load, err
+	}
+	load.threadsRunning = threadsRunning
+
+	queries, times, errors := s.queryTotals()
+	if queries > s.lastQueries {
+		load.latency = time.Duration((times - s.lastTimes) / (queries - s.lastQueries))
+		load.errorRate = float64(errors-s.lastErrors) / float64(queries-s.lastQueries)
+	}
+	s.lastQueries, s.lastTimes, s.lastErrors = queries, times, errors
+	return load, nil
+}
+
+// queryTotals returns the cumulative number, MySQL time in ns and overload
+// errors of the queries executed by the tablet in MySQL.
+func (s *adaptivePoolSizer) queryTotals() (queries, times, errors int64) {
+	return s.qe.mysqlQueries.Load(), s.qe.mysqlTime.Load(), s.qe.mysqlErrors.Load()
+}
+
+// readThreadsRunning reads the threads_running of MySQL. It must be called
+// with the mutex held.
+func (s *adaptivePoolSizer) readThreadsRunning(ctx context.Context) (int64, error) {
+	if s.dbaConn == nil {
+		conn, err := dbconnpool.NewDBConnection(ctx, s.env.Config().DB.DbaConnector())
+		if err != nil {
+			return 0, err
+		}
+		s.dbaConn = conn
+	}
+
+	qr, err := s.dbaConn.ExecuteFetch(sqlShowThreadsRunning, 1, false)
+	if err != nil {
+		// The connection is reopened by the next adjustment.
+		s.dbaConn.Close()
+		s.dbaConn = nil
+		return 0, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 2 {
+		return 0, nil
+	}
+	return qr.Rows[0][1].ToCastInt64()
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestNextPoolSize(t *testing.T) {
+	tests := []struct {
+		name               string
+		capacity           int64
+		overloaded, waited bool
+		expected           int64
+	}{
+		{name: "idle", capacity: 16, expected: 16},
+		{name: "waited", capacity: 16, waited: true, expected: 17},
+		{name: "waited on a large pool", capacity: 50, waited: true, expected: 55},
+		{name: "waited at max size", capacity: 100, waited: true, expected: 100},
+		{name: "overloaded", capacity: 16, overloaded: true, expected: 12},
+		{name: "overloaded while waited", capacity: 16, overloaded: true, waited: true, expected: 12},
+		{name: "overloaded at min size", capacity: 4, overloaded: true, expected: 4},
+		{name: "above max size", capacity: 150, expected: 100},
+		{name: "below min size", capacity: 1, expected: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextPoolSize(tt.capacity, 4, 100, tt.overloaded, tt.waited))
+		})
+	}
+}
+
+func TestAdaptivePoolSizer(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(db)
+	cfg.AdaptivePoolSizing.Interval = time.Hour
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "AdaptivePoolSizerTest")
+	qe := NewQueryEngine(env, schema.NewEngine(env))
+	s := newAdaptivePoolSizer(env, qe, NewTxEngine(env, nil))
+
+	var capacity, waitCount int64 = 16, 0
+	s.pools = []*adaptivePool{{
+		name:     "ConnPool",
+		min:      4,
+		max:      20,
+		capacity: func() int64 { return capacity },
+		setCapacity: func(ctx context.Context, c int64) error {
+			capacity = c
+			return nil
+		},
+		waitCount: func() int64 { return waitCount },
+	}}
+	threadsRunning := func(n string) {
+		db.AddQuery(sqlShowThreadsRunning, sqltypes.MakeTestResult(sqltypes.MakeTestFields("Variable_name|Value", "varchar|varchar"), "Threads_running|"+n))
+	}
+	threadsRunning("10")
+
+	resizes := s.resizes.Counts()
+	s.Open()
+	defer s.Close()
+	assert.EqualValues(t, 16, s.targetSizes.Counts()["ConnPool"])
+
+	// The pool is grown while callers wait for a connection.
+	waitCount = 3
+	s.adjust()
+	assert.EqualValues(t, 17, capacity)
+	s.adjust()
+	assert.EqualValues(t, 17, capacity)
+
+	// It is shrunk while MySQL has too many threads running, even if callers
+	// wait for a connection.
+	threadsRunning("100")
+	waitCount = 5
+	s.adjust()
+	assert.EqualValues(t, 13, capacity)
+	threadsRunning("10")
+
+	// The connection reading the threads_running is reused.
+	dbaConn := s.dbaConn
+	assert.NotNil(t, dbaConn)
+
+	// Or while the queries are slow in MySQL.
+	plan := &TabletPlan{Plan: &planbuilder.Plan{PlanID: planbuilder.PlanSelect}}
+	addStats := func(duration, mysqlTime time.Duration, errorCode vtrpcpb.Code) {
+		var errorCount int64
+		if errorCode != vtrpcpb.Code_OK {
+			errorCount = 1
+		}
+		for range 10 {
+			qe.AddStats(plan, "t1", "", topodatapb.TabletType_PRIMARY, 1, duration, mysqlTime, 0, 0, errorCount, errorCode.String())
+		}
+	}
+	addStats(time.Second, time.Second, vtrpcpb.Code_OK)
+	s.adjust()
+	assert.EqualValues(t, 10, capacity)
+
+	// Or while they time out in MySQL.
+	addStats(time.Millisecond, time.Millisecond, vtrpcpb.Code_DEADLINE_EXCEEDED)
+	s.adjust()
+	assert.EqualValues(t, 8, capacity)
+	assert.Same(t, dbaConn, s.dbaConn)
+
+	// The waits for a connection of the pools, their rejections and the
+	// errors of the queries themselves do not shrink the pools.
+	addStats(time.Second, time.Millisecond, vtrpcpb.Code_OK)
+	addStats(time.Second, 0, vtrpcpb.Code_RESOURCE_EXHAUSTED)
+	addStats(time.Millisecond, time.Millisecond, vtrpcpb.Code_ALREADY_EXISTS)
+	addStats(time.Millisecond, time.Millisecond, vtrpcpb.Code_INVALID_ARGUMENT)
+	s.adjust()
+	assert.EqualValues(t, 8, capacity)
+
+	s.adjust()
+	assert.EqualValues(t, 8, capacity)
+	assert.EqualValues(t, 8, s.targetSizes.Counts()["ConnPool"])
+	assert.EqualValues(t, 1, s.resizes.Counts()["ConnPool.Grow"]-resizes["ConnPool.Grow"])
+	assert.EqualValues(t, 3, s.resizes.Counts()["ConnPool.Shrink"]-resizes["ConnPool.Shrink"])
+}
@@ -82,6 +82,10 @@ func newPooledConn(ctx context.Context, pool *Pool, appParams dbconfigs.Connecto
 		dbaPool:     pool.dbaPool,
 		killTimeout: defaultKillTimeout,
 	}
+	if err := db.applyFakeTime(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
 	return db, nil
 }
 
@@ -98,6 +102,10 @@ func NewConn(ctx context.Context, params dbconfigs.Connector, dbaPool *dbconnpoo
 		env:         env,
 		killTimeout: defaultKillTimeout,
 	}
+	if err = dbconn.applyFakeTime(ctx); err != nil {
+		dbconn.Close()
+		return nil, err
+	}
 	if setting == nil {
 		return dbconn, nil
 	}
@@ -595,6 +603,9 @@ func (dbc *Conn) Reconnect(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err = dbc.applyFakeTime(ctx); err != nil {
+		return err
+	}
 	if dbc.setting != nil {
 		err = dbc.applySameSetting(ctx)
 		if err != nil {
@@ -620,6 +631,21 @@ func (dbc *Conn) CurrentForLogging() string {
 	return dbc.env.Environment().Parser().TruncateForLog(queryToLog)
 }
 
+// applyFakeTime freezes the clock of the MySQL session at the fake time of the environment,
+// if it has one.
+func (dbc *Conn) applyFakeTime(ctx context.Context) error {
+	if dbc.env == nil || dbc.env.Environment() == nil {
+		return nil
+	}
+	fakeTime := dbc.env.Environment().FakeTime()
+	if fakeTime.IsZero() {
+		return nil
+	}
+	query := fmt.Sprintf("set @@session.timestamp = %d.%06d", fakeTime.Unix(), fakeTime.Nanosecond()/1000)
+	_, err := dbc.execOnce(ctx, query, 1, false, false)
+	return err
+}
+
 func (dbc *Conn) applySameSetting(ctx context.Context) error {
 	_, err := dbc.execOnce(ctx, dbc.setting.ApplyQuery(), 1, false, false)
 	return err
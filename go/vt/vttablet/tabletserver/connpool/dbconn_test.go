@@ -717,3 +717,31 @@ func executeWithTimeout(
 	require.WithinDuration(t, timeQuery, timeKill, 150*time.Millisecond)
 	require.WithinDuration(t, timeKill, timeDone, responseTime)
 }
+
+func TestDBConnFakeTime(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	connPool := newPool()
+	params := dbconfigs.New(db.ConnParams())
+	connPool.Open(params, params, params)
+	defer connPool.Close()
+
+	env, err := vtenv.New(vtenv.Options{
+		FakeTime: time.Date(2024, time.January, 1, 12, 0, 0, 500000000, time.UTC),
+	})
+	require.NoError(t, err)
+	query := "set @@session.timestamp = 1704110400.500000"
+	db.AddQuery(query, &sqltypes.Result{})
+
+	dbConn, err := NewConn(context.Background(), params, connPool.dbaPool, nil, tabletenv.NewEnv(env, nil, "TestDBConnFakeTime"))
+	if dbConn != nil {
+		defer dbConn.Close()
+	}
+	require.NoError(t, err)
+	assert.Equal(t, 1, db.GetQueryCalledNum(query))
+
+	// The fake time is applied again after a reconnect.
+	err = dbConn.Reconnect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, db.GetQueryCalledNum(query))
+}
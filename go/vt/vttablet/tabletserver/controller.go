@@ -67,6 +67,10 @@ type Controller interface {
 	// ClearQueryPlanCache clears internal query plan cache
 	ClearQueryPlanCache()
 
+	// GetQueryPlans returns the cached query plans, after evicting, pinning or
+	// unpinning the plans of the given queries.
+	GetQueryPlans(ctx context.Context, req *tabletmanagerdata.GetQueryPlansRequest) (*tabletmanagerdata.GetQueryPlansResponse, error)
+
 	// ReloadSchema makes the query service reload its schema cache
 	ReloadSchema(ctx context.Context) error
 
@@ -88,6 +92,10 @@ type Controller interface {
 	// BroadcastHealth sends the current health to all listeners
 	BroadcastHealth()
 
+	// BroadcastVReplicationStateChange signals to the health stream listeners
+	// that the vreplication streams of the tablet changed.
+	BroadcastVReplicationStateChange()
+
 	// TopoServer returns the topo server.
 	TopoServer() *topo.Server
 
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var (
+	servingDependencies            []string
+	servingDependencyProbeTimeout  = 5 * time.Second
+	errServingDependenciesNotReady = errors.New("serving dependencies are not ready")
+)
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerDependencyGateFlags)
+	servenv.OnParseFor("vttablet", registerDependencyGateFlags)
+}
+
+func registerDependencyGateFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&servingDependencies, "serving-dependencies", servingDependencies, "comma-separated list of the external dependencies which must be reachable before the tablet starts serving for the first time, as tcp://host:port, dns://hostname, http://url or https://url")
+	fs.DurationVar(&servingDependencyProbeTimeout, "serving-dependency-probe-timeout", servingDependencyProbeTimeout, "timeout of each probe of --serving-dependencies")
+}
+
+// dependencyProbe checks that an external dependency is reachable.
+type dependencyProbe struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// dependencyGate keeps a tablet from serving until all its external
+// dependencies, such as sidecar agents, DNS or a KMS, are reachable. Once
+// they all were, the gate stays open: it only protects the startup of the
+// tablet, and must not block the later changes of its type.
+type dependencyGate struct {
+	probes  []dependencyProbe
+	timeout time.Duration
+
+	mu     sync.Mutex
+	passed bool
+	// failures holds the errors of the probes which failed the last time the
+	// gate was checked.
+	failures map[string]error
+}
+
+// newDependencyGate returns a dependencyGate for the given dependencies, or
+// nil if there are none.
+func newDependencyGate(dependencies []string, timeout time.Duration) (*dependencyGate, error) {
+	if len(dependencies) == 0 {
+		return nil, nil
+	}
+	g := &dependencyGate{timeout: timeout}
+	for _, dependency := range dependencies {
+		probe, err := newDependencyProbe(dependency, timeout)
+		if err != nil {
+			return nil, err
+		}
+		g.probes = append(g.probes, probe)
+	}
+	return g, nil
+}
+
+func newDependencyProbe(dependency string, timeout time.Duration) (dependencyProbe, error) {
+	u, err := url.Parse(dependency)
+	if err != nil {
+		return dependencyProbe{}, fmt.Errorf("invalid serving dependency %q: %v", dependency, err)
+	}
+	probe := dependencyProbe{name: dependency}
+	switch u.Scheme {
+	case "tcp":
+		if _, _, err := net.SplitHostPort(u.Host); err != nil {
+			return dependencyProbe{}, fmt.Errorf("invalid serving dependency %q: %v", dependency, err)
+		}
+		probe.check = func(ctx context.Context) error {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", u.Host)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		}
+	case "dns":
+		if u.Host == "" {
+			return dependencyProbe{}, fmt.Errorf("invalid serving dependency %q: missing hostname", dependency)
+		}
+		probe.check = func(ctx context.Context) error {
+			_, err := net.DefaultResolver.LookupHost(ctx, u.Host)
+			return err
+		}
+	case "http", "https":
+		client := &http.Client{Timeout: timeout}
+		probe.check = func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, dependency, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return fmt.Errorf("unexpected status %v", resp.Status)
+			}
+			return nil
+		}
+	default:
+		return dependencyProbe{}, fmt.Errorf("invalid serving dependency %q: unsupported scheme %q", dependency, u.Scheme)
+	}
+	return probe, nil
+}
+
+// Check probes all the dependencies concurrently, unless they were all
+// reachable before, and returns an error if any of them is not.
+func (g *dependencyGate) Check() error {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	passed := g.passed
+	g.mu.Unlock()
+	if passed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	errs := make([]error, len(g.probes))
+	var wg sync.WaitGroup
+	for i, probe := range g.probes {
+		wg.Go(func() {
+			errs[i] = probe.check(ctx)
+		})
+	}
+	wg.Wait()
+
+	failures := make(map[string]error)
+	for i, probe := range g.probes {
+		if errs[i] != nil {
+			failures[probe.name] = errs[i]
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures = failures
+	if len(failures) == 0 {
+		log.Infof("All the serving dependencies are reachable")
+		g.passed = true
+		return nil
+	}
+	return g.errLocked()
+}
+
+// Err returns an error listing the dependencies which were not reachable the
+// last time the gate was checked, or nil if there are none.
+func (g *dependencyGate) Err() error {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.errLocked()
+}
+
+func (g *dependencyGate) errLocked() error {
+	if len(g.failures) == 0 {
+		return nil
+	}
+	var details []string
+	for _, probe := range g.probes {
+		if err, ok := g.failures[probe.name]; ok {
+			details = append(details, fmt.Sprintf("%s: %v", probe.name, err))
+		}
+	}
+	return fmt.Errorf("%w: %s", errServingDependenciesNotReady, strings.Join(details, "; "))
+}
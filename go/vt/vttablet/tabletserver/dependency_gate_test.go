@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestNewDependencyGate(t *testing.T) {
+	g, err := newDependencyGate(nil, time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, g)
+	assert.NoError(t, g.Check())
+	assert.NoError(t, g.Err())
+
+	for _, dependency := range []string{"tcp://localhost", "dns://", "udp://localhost:53", "localhost:3306"} {
+		_, err := newDependencyGate([]string{dependency}, time.Second)
+		assert.ErrorContains(t, err, "invalid serving dependency", dependency)
+	}
+}
+
+func TestDependencyGateProbes(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	g, err := newDependencyGate([]string{"tcp://" + listener.Addr().String(), "dns://localhost", healthy.URL}, time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, g.Check())
+	assert.NoError(t, g.Err())
+
+	g, err = newDependencyGate([]string{healthy.URL, unhealthy.URL}, time.Second)
+	require.NoError(t, err)
+	err = g.Check()
+	assert.ErrorIs(t, err, errServingDependenciesNotReady)
+	assert.ErrorContains(t, err, unhealthy.URL+": unexpected status 503 Service Unavailable")
+	assert.NotContains(t, err.Error(), healthy.URL+":")
+	assert.Equal(t, err, g.Err())
+}
+
+func TestStateManagerDependencyGate(t *testing.T) {
+	defer func(saved time.Duration) { transitionRetryInterval = saved }(transitionRetryInterval)
+	transitionRetryInterval = 10 * time.Millisecond
+
+	var ready atomic.Bool
+	sm := newTestStateManager()
+	defer sm.StopService()
+	sm.dependencyGate = &dependencyGate{
+		timeout: time.Second,
+		probes: []dependencyProbe{{
+			name: "kms",
+			check: func(ctx context.Context) error {
+				if !ready.Load() {
+					return errors.New("unreachable")
+				}
+				return nil
+			},
+		}},
+	}
+
+	err := sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, "")
+	assert.ErrorIs(t, err, errServingDependenciesNotReady)
+	assert.Equal(t, StateNotServing, sm.State())
+	assert.Equal(t, topodatapb.TabletType_REPLICA, sm.Target().TabletType)
+
+	sm.Broadcast()
+	assert.Contains(t, sm.hs.state.RealtimeStats.HealthError, "kms: unreachable")
+
+	ready.Store(true)
+	assert.Eventually(t, func() bool {
+		return sm.State() == StateServing
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, sm.dependencyGate.Err())
+
+	// Once passed, the gate does not probe the dependencies anymore.
+	ready.Store(false)
+	err = sm.SetServingType(topodatapb.TabletType_PRIMARY, testNow, StateServing, "")
+	require.NoError(t, err)
+	assert.Equal(t, StateServing, sm.State())
+}
@@ -306,6 +306,7 @@ func (hs *healthStreamer) MakePrimary(serving bool) {
 				log.Errorf("periodic schema reload failed in health stream: %v", err)
 			}
 		}, false)
+		hs.se.RegisterStatsNotifier("healthStreamer", hs.sendTableStatsSignal)
 	}
 }
 
@@ -360,6 +361,21 @@ func (hs *healthStreamer) reload(created, altered, dropped []*schema.Table, udfs
 	return nil
 }
 
+// sendTableStatsSignal sends broadcast message about updated table statistics.
+func (hs *healthStreamer) sendTableStatsSignal() {
+	hs.fieldsMu.Lock()
+	defer hs.fieldsMu.Unlock()
+	// send signal only when primary is serving.
+	if !hs.isServingPrimary {
+		return
+	}
+
+	hs.state.RealtimeStats.TableStatsChanged = true
+	shr := hs.state.CloneVT()
+	hs.broadCastToClients(shr)
+	hs.state.RealtimeStats.TableStatsChanged = false
+}
+
 // sendUnresolvedTransactionSignal sends broadcast message about unresolved transactions.
 func (hs *healthStreamer) sendUnresolvedTransactionSignal() {
 	hs.fieldsMu.Lock()
@@ -374,3 +390,19 @@ func (hs *healthStreamer) sendUnresolvedTransactionSignal() {
 	hs.broadCastToClients(shr)
 	hs.state.RealtimeStats.TxUnresolved = false
 }
+
+// sendVReplicationStateSignal sends broadcast message about a change of the
+// vreplication streams.
+func (hs *healthStreamer) sendVReplicationStateSignal() {
+	hs.fieldsMu.Lock()
+	defer hs.fieldsMu.Unlock()
+	// send signal only when primary is serving.
+	if !hs.isServingPrimary {
+		return
+	}
+
+	hs.state.RealtimeStats.VreplicationStateChanged = true
+	shr := hs.state.CloneVT()
+	hs.broadCastToClients(shr)
+	hs.state.RealtimeStats.VreplicationStateChanged = false
+}
@@ -563,6 +563,83 @@ func TestReloadView(t *testing.T) {
 	}
 }
 
+// TestTableStatsSignal tests that the health streamer signals the significant
+// updates of the table statistics, but not the reloads which leave them
+// unchanged or nearly so.
+func TestTableStatsSignal(t *testing.T) {
+	cfg := newConfig(nil)
+	cfg.SignalWhenSchemaChange = true
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TestTableStatsSignal")
+	alias := &topodatapb.TabletAlias{
+		Cell: "cell",
+		Uid:  1,
+	}
+	blpFunc = testBlpFunc
+	se := schema.NewEngineForTests()
+	hs := newHealthStreamer(env, alias, se)
+	hs.Open()
+	defer hs.Close()
+	hs.MakePrimary(true)
+
+	ch, cancel := testStream(hs)
+	defer cancel()
+	<-ch
+
+	rows := map[string]int64{"t1": 100}
+	cardinalities := map[string]map[string]int64{"t1": {"PRIMARY": 100}}
+	se.SetTableStatsForTests(rows, cardinalities)
+	shr := <-ch
+	assert.True(t, shr.RealtimeStats.TableStatsChanged)
+
+	// The same statistics are not signaled again, nor small changes.
+	se.SetTableStatsForTests(rows, cardinalities)
+	se.SetTableStatsForTests(map[string]int64{"t1": 150}, map[string]map[string]int64{"t1": {"PRIMARY": 150}})
+	select {
+	case shr := <-ch:
+		t.Errorf("unexpected health message: %v", shr)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	se.SetTableStatsForTests(map[string]int64{"t1": 1000}, cardinalities)
+	shr = <-ch
+	assert.True(t, shr.RealtimeStats.TableStatsChanged)
+
+	// New tables are signaled.
+	se.SetTableStatsForTests(map[string]int64{"t1": 1000, "t2": 1}, cardinalities)
+	shr = <-ch
+	assert.True(t, shr.RealtimeStats.TableStatsChanged)
+}
+
+// TestVReplicationStateSignal tests that the health streamer signals the
+// changes of the vreplication streams, only when it is a serving primary.
+func TestVReplicationStateSignal(t *testing.T) {
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), newConfig(nil), "TestVReplicationStateSignal")
+	alias := &topodatapb.TabletAlias{
+		Cell: "cell",
+		Uid:  1,
+	}
+	blpFunc = testBlpFunc
+	hs := newHealthStreamer(env, alias, &schema.Engine{})
+	hs.Open()
+	defer hs.Close()
+
+	ch, cancel := testStream(hs)
+	defer cancel()
+	<-ch
+
+	hs.sendVReplicationStateSignal()
+	select {
+	case shr := <-ch:
+		t.Errorf("unexpected health message: %v", shr)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	hs.MakePrimary(true)
+	hs.sendVReplicationStateSignal()
+	shr := <-ch
+	assert.True(t, shr.RealtimeStats.VreplicationStateChanged)
+}
+
 func testStream(hs *healthStreamer) (<-chan *querypb.StreamHealthResponse, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ch := make(chan *querypb.StreamHealthResponse)
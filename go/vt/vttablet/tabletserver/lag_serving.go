@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// errTooFewServingReplicas is returned when a lagging tablet cannot stop
+// serving because too few tablets of its type would still serve.
+var errTooFewServingReplicas = errors.New("too few serving tablets would remain")
+
+// lagServingGuard makes a replica or rdonly tablet stop serving when its
+// replication lag exceeds --lag-not-serving-threshold, until its lag is under
+// --lag-serving-threshold again.
+//
+// The tablets of a shard within a cell record which of them stopped serving
+// in the LaggedReplicas object of the cell topo, which lets a tablet stop
+// serving only if at least --lag-not-serving-min-replicas other tablets of its
+// type still serve.
+type lagServingGuard struct {
+	ts                  *topo.Server
+	alias               *topodatapb.TabletAlias
+	notServingThreshold time.Duration
+	servingThreshold    time.Duration
+	minServing          int
+
+	// onChange is called when the tablet stops or starts serving again.
+	onChange func()
+
+	mu         sync.Mutex
+	notServing bool
+	// updating is set while the topo is being updated.
+	updating bool
+	// synced is set once the tablet is known to be recorded in the topo
+	// if and only if notServing is set. It is not set at startup, as the
+	// tablet may have been recorded by a previous run.
+	synced bool
+}
+
+// newLagServingGuard returns a lagServingGuard, or nil if the tablet must not
+// stop serving because of its replication lag.
+func newLagServingGuard(env tabletenv.Env, ts *topo.Server, alias *topodatapb.TabletAlias, onChange func()) *lagServingGuard {
+	cfg := env.Config().Healthcheck
+	if cfg.NotServingLagThreshold == 0 || ts == nil || alias == nil {
+		return nil
+	}
+	servingThreshold := cfg.ServingLagThreshold
+	if servingThreshold == 0 {
+		servingThreshold = cfg.NotServingLagThreshold / 2
+	}
+	return &lagServingGuard{
+		ts:                  ts,
+		alias:               alias.CloneVT(),
+		notServingThreshold: cfg.NotServingLagThreshold,
+		servingThreshold:    servingThreshold,
+		minServing:          cfg.MinServingReplicas,
+		onChange:            onChange,
+	}
+}
+
+// IsNotServing returns true if the tablet stopped serving because of its
+// replication lag.
+func (g *lagServingGuard) IsNotServing() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.notServing
+}
+
+// Observe is called with the replication lag of the tablet at every health
+// check. It starts updating the topo in the background if the tablet must stop
+// or start serving again. A replication error does not change anything, since
+// the tablet is unhealthy anyway.
+func (g *lagServingGuard) Observe(target *querypb.Target, lag time.Duration, replErr error) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.updating {
+		return
+	}
+
+	eligible := target.TabletType == topodatapb.TabletType_REPLICA || target.TabletType == topodatapb.TabletType_RDONLY
+	wantNotServing := g.notServing
+	switch {
+	case !eligible:
+		wantNotServing = false
+	case replErr != nil:
+	case lag > g.notServingThreshold:
+		wantNotServing = true
+	case lag < g.servingThreshold:
+		wantNotServing = false
+	}
+	if wantNotServing == g.notServing && g.synced {
+		return
+	}
+	g.updating = true
+	go g.update(target.CloneVT(), wantNotServing)
+}
+
+// update records whether the tablet stops serving in the topo, and then
+// changes its state accordingly.
+func (g *lagServingGuard) update(target *querypb.Target, notServing bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), topo.RemoteOperationTimeout)
+	defer cancel()
+
+	err := g.ts.UpdateLaggedReplicasFields(ctx, g.alias.Cell, target.Keyspace, target.Shard, func(lr *topodatapb.LaggedReplicas) error {
+		i := slices.IndexFunc(lr.TabletAliases, func(alias *topodatapb.TabletAlias) bool {
+			return topoproto.TabletAliasEqual(alias, g.alias)
+		})
+		switch {
+		case !notServing && i < 0, notServing && i >= 0:
+			return topo.NewError(topo.NoUpdateNeeded, g.alias.String())
+		case !notServing:
+			lr.TabletAliases = slices.Delete(lr.TabletAliases, i, i+1)
+			return nil
+		}
+		serving, err := g.countOtherServing(ctx, target, lr)
+		if err != nil {
+			return err
+		}
+		if serving < g.minServing {
+			return fmt.Errorf("%w: %d other %v tablets serve and at least %d must", errTooFewServingReplicas, serving, target.TabletType, g.minServing)
+		}
+		lr.TabletAliases = append(lr.TabletAliases, g.alias)
+		return nil
+	})
+
+	g.mu.Lock()
+	g.updating = false
+	changed := false
+	switch {
+	case err == nil:
+		g.synced = true
+		changed = g.notServing != notServing
+		g.notServing = notServing
+	case errors.Is(err, errTooFewServingReplicas):
+		// The tablet keeps serving, and tries again at the next health
+		// check.
+		g.synced = true
+		log.Warningf("Replication lag exceeds %v but the tablet keeps serving: %v", g.notServingThreshold, err)
+	default:
+		log.Errorf("Cannot update the lagged replicas of %v/%v in cell %v: %v", target.Keyspace, target.Shard, g.alias.Cell, err)
+	}
+	g.mu.Unlock()
+
+	if changed {
+		if notServing {
+			log.Infof("Replication lag exceeds %v, the tablet stops serving", g.notServingThreshold)
+		} else {
+			log.Infof("The tablet serves again")
+		}
+		g.onChange()
+	}
+}
+
+// countOtherServing returns the number of the other tablets of the shard
+// within the cell which have the type of the tablet and do not appear in the
+// lagged replicas.
+func (g *lagServingGuard) countOtherServing(ctx context.Context, target *querypb.Target, lr *topodatapb.LaggedReplicas) (int, error) {
+	sri, err := g.ts.GetShardReplication(ctx, g.alias.Cell, target.Keyspace, target.Shard)
+	if err != nil {
+		return 0, err
+	}
+	var aliases []*topodatapb.TabletAlias
+	for _, node := range sri.Nodes {
+		if topoproto.TabletAliasEqual(node.TabletAlias, g.alias) {
+			continue
+		}
+		if slices.ContainsFunc(lr.TabletAliases, func(alias *topodatapb.TabletAlias) bool {
+			return topoproto.TabletAliasEqual(alias, node.TabletAlias)
+		}) {
+			continue
+		}
+		aliases = append(aliases, node.TabletAlias)
+	}
+	tablets, err := g.ts.GetTabletMap(ctx, aliases, nil)
+	if err != nil {
+		return 0, err
+	}
+	serving := 0
+	for _, tablet := range tablets {
+		if tablet.Type == target.TabletType {
+			serving++
+		}
+	}
+	return serving, nil
+}
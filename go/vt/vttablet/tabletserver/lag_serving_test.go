@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/test/utils"
+	"vitess.io/vitess/go/vt/topo/memorytopo"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestLagServingGuard(t *testing.T) {
+	ctx := t.Context()
+	ts := memorytopo.NewServer(ctx, "cell1")
+	defer ts.Close()
+
+	aliases := make([]*topodatapb.TabletAlias, 3)
+	for i := range aliases {
+		aliases[i] = &topodatapb.TabletAlias{Cell: "cell1", Uid: uint32(100 + i)}
+		require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+			Alias:    aliases[i],
+			Keyspace: "ks",
+			Shard:    "0",
+			Type:     topodatapb.TabletType_REPLICA,
+		}))
+	}
+	// An rdonly tablet does not count as a serving replica.
+	require.NoError(t, ts.CreateTablet(ctx, &topodatapb.Tablet{
+		Alias:    &topodatapb.TabletAlias{Cell: "cell1", Uid: 200},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_RDONLY,
+	}))
+
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.Healthcheck.NotServingLagThreshold = 10 * time.Second
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "LagServingGuardTest")
+
+	// Tablets which do not stop serving when lagging have no guard.
+	noGuardCfg := tabletenv.NewDefaultConfig()
+	assert.Nil(t, newLagServingGuard(tabletenv.NewEnv(vtenv.NewTestEnv(), noGuardCfg, "LagServingGuardTest"), ts, aliases[0], func() {}))
+
+	var changes atomic.Int32
+	guards := make([]*lagServingGuard, len(aliases))
+	for i, alias := range aliases {
+		guards[i] = newLagServingGuard(env, ts, alias, func() { changes.Add(1) })
+	}
+	replica := &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA}
+	observe := func(g *lagServingGuard, target *querypb.Target, lag time.Duration, replErr error) {
+		g.Observe(target, lag, replErr)
+		require.Eventually(t, func() bool {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+			return !g.updating
+		}, 5*time.Second, time.Millisecond)
+	}
+	assertLagged := func(want ...*topodatapb.TabletAlias) {
+		lr, err := ts.GetLaggedReplicas(ctx, "cell1", "ks", "0")
+		require.NoError(t, err)
+		utils.MustMatch(t, want, lr.TabletAliases)
+	}
+
+	// onChange is called after the update completes.
+	assertChanges := func(want int32) {
+		assert.Eventually(t, func() bool { return changes.Load() == want }, 5*time.Second, time.Millisecond)
+	}
+
+	observe(guards[0], replica, time.Second, nil)
+	assert.False(t, guards[0].IsNotServing())
+	observe(guards[0], replica, 20*time.Second, nil)
+	assert.True(t, guards[0].IsNotServing())
+	assertLagged(aliases[0])
+
+	// A replication error does not change the serving state.
+	observe(guards[0], replica, 0, errors.New("replication stopped"))
+	assert.True(t, guards[0].IsNotServing())
+
+	// The second tablet can stop serving too, but not the last one.
+	observe(guards[1], replica, 20*time.Second, nil)
+	assert.True(t, guards[1].IsNotServing())
+	observe(guards[2], replica, 20*time.Second, nil)
+	assert.False(t, guards[2].IsNotServing())
+	assertLagged(aliases[0], aliases[1])
+	assertChanges(2)
+
+	// The tablet serves again only once its lag is under half of the
+	// threshold.
+	observe(guards[0], replica, 7*time.Second, nil)
+	assert.True(t, guards[0].IsNotServing())
+	observe(guards[0], replica, 3*time.Second, nil)
+	assert.False(t, guards[0].IsNotServing())
+	assertLagged(aliases[1])
+
+	// Now the last tablet can stop serving.
+	observe(guards[2], replica, 20*time.Second, nil)
+	assert.True(t, guards[2].IsNotServing())
+	assertLagged(aliases[1], aliases[2])
+
+	// A tablet which is promoted serves again.
+	observe(guards[1], &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_PRIMARY}, 0, nil)
+	assert.False(t, guards[1].IsNotServing())
+	assertLagged(aliases[2])
+	assertChanges(5)
+
+	// A restarted tablet removes itself from the lagged replicas.
+	restarted := newLagServingGuard(env, ts, aliases[2], func() { changes.Add(1) })
+	observe(restarted, replica, time.Second, nil)
+	assert.False(t, restarted.IsNotServing())
+	assertLagged()
+	assertChanges(5)
+}
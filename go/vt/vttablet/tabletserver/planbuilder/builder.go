@@ -150,6 +150,8 @@ func analyzeShow(show *sqlparser.Show, dbName string) (plan *Plan, err error) {
 		switch showInternal.Command {
 		case sqlparser.VitessMigrations:
 			return &Plan{PlanID: PlanShowMigrations, FullStmt: show}, nil
+		case sqlparser.VitessWorkflows:
+			return &Plan{PlanID: PlanShowWorkflows, FullStmt: show}, nil
 		case sqlparser.Table:
 			// rewrite WHERE clause if it exists
 			// `where Tables_in_Keyspace` => `where Tables_in_DbName`
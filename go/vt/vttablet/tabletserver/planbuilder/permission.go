@@ -62,7 +62,8 @@ func BuildPermissions(stmt sqlparser.Statement) []Permission {
 		*sqlparser.RevertMigration,
 		*sqlparser.ShowMigrationLogs,
 		*sqlparser.ShowThrottledApps,
-		*sqlparser.ShowThrottlerStatus:
+		*sqlparser.ShowThrottlerStatus,
+		*sqlparser.AlterWorkflow:
 		permissions = []Permission{} // TODO(shlomi) what are the correct permissions here? Table is unknown
 	case *sqlparser.Flush:
 		for _, t := range node.TableNames {
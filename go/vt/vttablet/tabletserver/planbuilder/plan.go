@@ -80,6 +80,8 @@ const (
 	PlanShowMigrationLogs
 	PlanShowThrottledApps
 	PlanShowThrottlerStatus
+	PlanAlterWorkflow
+	PlanShowWorkflows
 	NumPlans
 )
 
@@ -116,6 +118,8 @@ var planName = []string{
 	"ShowMigrationLogs",
 	"ShowThrottledApps",
 	"ShowThrottlerStatus",
+	"AlterWorkflow",
+	"ShowWorkflows",
 }
 
 func (pt PlanType) String() string {
@@ -228,6 +232,8 @@ func Build(env *vtenv.Environment, statement sqlparser.Statement, tables map[str
 		plan = &Plan{PlanID: PlanShowThrottledApps, FullStmt: stmt}
 	case *sqlparser.ShowThrottlerStatus:
 		plan = &Plan{PlanID: PlanShowThrottlerStatus, FullStmt: stmt}
+	case *sqlparser.AlterWorkflow:
+		plan = &Plan{PlanID: PlanAlterWorkflow, FullStmt: stmt}
 	case *sqlparser.Show:
 		plan, err = analyzeShow(stmt, dbName)
 	case *sqlparser.Analyze, sqlparser.Explain:
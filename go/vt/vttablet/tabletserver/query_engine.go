@@ -69,6 +69,9 @@ type TabletPlan struct {
 	RowsAffected uint64
 	RowsReturned uint64
 	ErrorCount   uint64
+
+	// HitCount is the number of times the plan was found in the cache.
+	HitCount uint64
 }
 
 // AddStats updates the stats for the current TabletPlan.
@@ -159,6 +162,11 @@ type QueryEngine struct {
 	settings         *SettingsCache
 	queryRuleSources *rules.Map
 
+	// pinnedPlans are looked up before the plan cache, so that the pinned
+	// plans are never evicted. pinnedMu protects them.
+	pinnedMu    sync.RWMutex
+	pinnedPlans map[PlanCacheKey]*pinnedPlan
+
 	// Pools
 	conns       *connpool.Pool
 	streamConns *connpool.Pool
@@ -194,6 +202,13 @@ type QueryEngine struct {
 	queryCounts, queryCountsWithTabletType, queryTimes, queryErrorCounts, queryErrorCountsWithCode, queryRowsAffected, queryRowsReturned, queryTextCharsProcessed *stats.CountersWithMultiLabels
 	queryEnginePlanCacheHits, queryEnginePlanCacheMisses                                                                                                          *stats.CounterFunc
 
+	// mysqlQueries, mysqlTime and mysqlErrors are the totals of the queries
+	// which reached MySQL, of their MySQL time in ns, and of their errors
+	// which signal an overloaded MySQL. Unlike queryTimes and queryErrorCounts,
+	// they exclude the waits for a pool connection, the queries rejected by
+	// the pools, and the errors caused by the queries themselves.
+	mysqlQueries, mysqlTime, mysqlErrors atomic.Int64
+
 	// stats flags
 	enablePerWorkloadTableMetrics bool
 
@@ -220,6 +235,7 @@ func NewQueryEngine(env tabletenv.Env, se *schema.Engine) *QueryEngine {
 	// Cache for query plans: user configured size with a doorkeeper by default to prevent one-off queries
 	// from thrashing the cache.
 	qe.plans = theine.NewStore[PlanCacheKey, *TabletPlan](config.QueryCacheMemory, config.QueryCacheDoorkeeper)
+	qe.pinnedPlans = make(map[PlanCacheKey]*pinnedPlan)
 
 	// cache for connection settings: default to 1/4th of the size for the query cache and do
 	// not use a doorkeeper because custom connection settings are rarely one-off and we always
@@ -389,6 +405,13 @@ func (qe *QueryEngine) getPlan(curSchema *currentSchema, sql string, noRowsLimit
 	}
 	plan := &TabletPlan{Plan: splan, Original: sql}
 	plan.Rules = qe.queryRuleSources.FilterByPlan(sql, plan.PlanID, plan.TableNames()...)
+	if plan.Rules.Rewrite(statement) {
+		// The query matches REWRITE rules, so it is planned again to be
+		// sent to MySQL as rewritten.
+		if plan.Plan, err = planbuilder.Build(qe.env.Environment(), statement, curSchema.tables, qe.env.Config().DB.DBName, noRowsLimit); err != nil {
+			return nil, err
+		}
+	}
 	plan.buildAuthorized()
 	if sqlparser.CachePlan(statement) {
 		return plan, nil
@@ -410,7 +433,7 @@ func (qe *QueryEngine) GetPlan(ctx context.Context, logStats *tabletenv.LogStats
 	if skipQueryPlanCache {
 		plan, err = qe.getPlan(curSchema, sql, noRowsLimit)
 	} else {
-		plan, logStats.CachedPlan, err = qe.plans.GetOrLoad(PlanCacheKey(qe.getPlanCacheKey(sql, noRowsLimit)), curSchema.epoch, func() (*TabletPlan, error) {
+		plan, logStats.CachedPlan, err = qe.getCachedPlan(PlanCacheKey(qe.getPlanCacheKey(sql, noRowsLimit)), curSchema.epoch, func() (*TabletPlan, error) {
 			return qe.getPlan(curSchema, sql, noRowsLimit)
 		})
 	}
@@ -434,6 +457,11 @@ func (qe *QueryEngine) getStreamPlan(curSchema *currentSchema, sql string) (*Tab
 
 	plan := &TabletPlan{Plan: splan, Original: sql}
 	plan.Rules = qe.queryRuleSources.FilterByPlan(sql, plan.PlanID, plan.TableName().String())
+	if plan.Rules.Rewrite(statement) {
+		if plan.Plan, err = planbuilder.BuildStreaming(statement, curSchema.tables); err != nil {
+			return nil, err
+		}
+	}
 	plan.buildAuthorized()
 
 	if sqlparser.CachePlan(statement) {
@@ -456,7 +484,7 @@ func (qe *QueryEngine) GetStreamPlan(ctx context.Context, logStats *tabletenv.Lo
 	if skipQueryPlanCache {
 		plan, err = qe.getStreamPlan(curSchema, sql)
 	} else {
-		plan, logStats.CachedPlan, err = qe.plans.GetOrLoad(PlanCacheKey(qe.getStreamPlanCacheKey(sql)), curSchema.epoch, func() (*TabletPlan, error) {
+		plan, logStats.CachedPlan, err = qe.getCachedPlan(PlanCacheKey(qe.getStreamPlanCacheKey(sql)), curSchema.epoch, func() (*TabletPlan, error) {
 			return qe.getStreamPlan(curSchema, sql)
 		})
 	}
@@ -578,6 +606,19 @@ func (qe *QueryEngine) QueryPlanCacheLen() (count int) {
 	return
 }
 
+// TableErrorCount returns the number of the failed queries on the table, as
+// counted by QueryErrorCounts.
+func (qe *QueryEngine) TableErrorCount(tableName string) (count int64) {
+	// The labels are joined with "." once the "." in them are replaced.
+	prefix := strings.ReplaceAll(tableName, ".", "_") + "."
+	for key, c := range qe.queryErrorCounts.Counts() {
+		if strings.HasPrefix(key, prefix) {
+			count += c
+		}
+	}
+	return count
+}
+
 // AddStats adds the given stats for the planName.tableName
 func (qe *QueryEngine) AddStats(plan *TabletPlan, tableName, workload string, tabletType topodata.TabletType, queryCount int64, duration, mysqlTime time.Duration, rowsAffected, rowsReturned, errorCount int64, errorCode string) {
 	// table names can contain "." characters, replace them!
@@ -595,6 +636,15 @@ func (qe *QueryEngine) AddStats(plan *TabletPlan, tableName, workload string, ta
 
 	qe.queryCountsWithTabletType.Add([]string{tableName, plan.PlanID.String(), tabletType.String()}, queryCount)
 
+	overloadError := errorCount > 0 && isOverloadErrorCode(errorCode)
+	if mysqlTime > 0 || overloadError {
+		qe.mysqlQueries.Add(queryCount)
+		qe.mysqlTime.Add(int64(mysqlTime))
+		if overloadError {
+			qe.mysqlErrors.Add(errorCount)
+		}
+	}
+
 	// queryErrorCountsWithCode is similar to queryErrorCounts except we have an additional dimension
 	// of error code.
 	if errorCount > 0 {
@@ -619,6 +669,17 @@ func (qe *QueryEngine) AddStats(plan *TabletPlan, tableName, workload string, ta
 	}
 }
 
+// isOverloadErrorCode returns whether the error code of a query signals an
+// overloaded MySQL: a timeout, a lock contention or a lost connection. The
+// rejections by the pools and the errors of the queries themselves do not.
+func isOverloadErrorCode(errorCode string) bool {
+	switch errorCode {
+	case vtrpcpb.Code_DEADLINE_EXCEEDED.String(), vtrpcpb.Code_ABORTED.String(), vtrpcpb.Code_UNAVAILABLE.String():
+		return true
+	}
+	return false
+}
+
 type perQueryStats struct {
 	Query        string
 	Table        string
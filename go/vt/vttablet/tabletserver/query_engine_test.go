@@ -632,6 +632,21 @@ func TestPlanCachePollution(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTableErrorCount(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(fakesqldb.New(t))
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TestTableErrorCount")
+	se := schema.NewEngine(env)
+	qe := NewQueryEngine(env, se)
+	plan := &TabletPlan{Plan: &planbuilder.Plan{PlanID: planbuilder.PlanInsert}}
+	qe.AddStats(plan, "t1", "", topodata.TabletType_PRIMARY, 3, 0, 0, 0, 0, 2, "ER_DUP_ENTRY")
+	qe.AddStats(plan, "t1_archive", "", topodata.TabletType_PRIMARY, 1, 0, 0, 0, 0, 1, "ER_DUP_ENTRY")
+	qe.AddStats(plan, "ks.t1", "", topodata.TabletType_PRIMARY, 1, 0, 0, 0, 0, 4, "ER_DUP_ENTRY")
+	assert.EqualValues(t, 2, qe.TableErrorCount("t1"))
+	assert.EqualValues(t, 4, qe.TableErrorCount("ks.t1"))
+	assert.Zero(t, qe.TableErrorCount("t2"))
+}
+
 func TestAddQueryStats(t *testing.T) {
 	fakeSelectPlan := &TabletPlan{
 		Plan: &planbuilder.Plan{
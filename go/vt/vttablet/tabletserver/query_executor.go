@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -162,6 +164,16 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return nil, reqThrottledErr
 	}
 
+	release, err := qre.tsv.resourceGroups.acquire(qre.ctx, planName, qre.pool("ConnPool"))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err = qre.waitForReadAfterWrite(); err != nil {
+		return nil, err
+	}
+
 	if qre.plan.PlanID == p.PlanNextval {
 		return qre.execNextval()
 	}
@@ -225,6 +237,10 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		return qre.execShowThrottledApps()
 	case p.PlanShowThrottlerStatus:
 		return qre.execShowThrottlerStatus()
+	case p.PlanAlterWorkflow:
+		return qre.execAlterWorkflow()
+	case p.PlanShowWorkflows:
+		return qre.execShowWorkflows(nil)
 	case p.PlanUnlockTables:
 		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "unlock tables should be executed with an existing connection")
 	case p.PlanSet:
@@ -335,6 +351,8 @@ func (qre *QueryExecutor) txConnExec(conn *StatefulConnection) (*sqltypes.Result
 		return qre.execProc(conn)
 	case p.PlanShowMigrations:
 		return qre.execShowMigrations(conn)
+	case p.PlanShowWorkflows:
+		return qre.execShowWorkflows(conn)
 	}
 	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "[BUG] %s unexpected plan type", qre.plan.PlanID.String())
 }
@@ -357,6 +375,16 @@ func (qre *QueryExecutor) Stream(callback StreamCallback) error {
 		return reqThrottledErr
 	}
 
+	release, err := qre.tsv.resourceGroups.acquire(qre.ctx, qre.plan.PlanID.String(), qre.pool("StreamConnPool"))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := qre.waitForReadAfterWrite(); err != nil {
+		return err
+	}
+
 	switch qre.plan.PlanID {
 	case p.PlanSelectStream:
 		if qre.bindVars[sqltypes.BvReplaceSchemaName] != nil {
@@ -707,6 +735,10 @@ func (qre *QueryExecutor) execNextval() (*sqltypes.Result, error) {
 			if cache < 1 {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid cache value for sequence %s: %d", tableName, cache)
 			}
+			if qre.tsv.env.DeterministicSequences() {
+				// Only reserve the requested values, so that no cached values are lost on restart.
+				cache = 1
+			}
 			newLast := nextID + cache
 			for newLast < t.SequenceInfo.NextVal+inc {
 				newLast += cache
@@ -829,6 +861,45 @@ func (qre *QueryExecutor) execOther() (*sqltypes.Result, error) {
 	return qre.execDBConn(conn.Conn, qre.query, true)
 }
 
+// waitForReadAfterWrite waits for a replica to apply the GTID set requested by
+// the options, so that the query reads the writes which produced it. It fails
+// with FAILED_PRECONDITION if the GTID set is not applied in time, and the
+// caller is expected to read from the primary instead.
+func (qre *QueryExecutor) waitForReadAfterWrite() error {
+	gtidSet := qre.options.GetReadAfterWriteGtidSet()
+	if gtidSet == "" || qre.connID != 0 || qre.targetTabletType == topodatapb.TabletType_PRIMARY {
+		return nil
+	}
+	timeout := time.Duration(qre.options.GetReadAfterWriteTimeout()) * time.Millisecond
+	if timeout <= 0 {
+		// MySQL waits with no limit for a timeout of 0, so there is no time
+		// left to wait instead.
+		qre.tsv.Stats().Warnings.Add("ReadAfterWriteTimeout", 1)
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "replica did not apply GTID set %s within %v", gtidSet, timeout)
+	}
+	query, err := sqlparser.ParseAndBind("select wait_for_executed_gtid_set(%a, %a)",
+		sqltypes.StringBindVariable(gtidSet),
+		sqltypes.Float64BindVariable(timeout.Seconds()),
+	)
+	if err != nil {
+		return err
+	}
+	conn, err := qre.getConn()
+	if err != nil {
+		return err
+	}
+	defer conn.Recycle()
+	qr, err := conn.Conn.Exec(qre.ctx, query, 1, false)
+	if err != nil {
+		return err
+	}
+	if len(qr.Rows) != 1 || qr.Rows[0][0].ToString() != "0" {
+		qre.tsv.Stats().Warnings.Add("ReadAfterWriteTimeout", 1)
+		return vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "replica did not apply GTID set %s within %v", gtidSet, timeout)
+	}
+	return nil
+}
+
 func (qre *QueryExecutor) getConn() (*connpool.PooledConn, error) {
 	span, ctx := trace.NewSpan(qre.ctx, "QueryExecutor.getConn")
 	defer span.Finish()
@@ -1075,6 +1146,35 @@ func (qre *QueryExecutor) execShowMigrationLogs() (*sqltypes.Result, error) {
 	return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting SHOW VITESS_MIGRATION plan")
 }
 
+func (qre *QueryExecutor) execAlterWorkflow() (*sqltypes.Result, error) {
+	alterWorkflow, ok := qre.plan.FullStmt.(*sqlparser.AlterWorkflow)
+	if !ok {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting ALTER VITESS_WORKFLOW plan")
+	}
+	return qre.tsv.AlterWorkflow(qre.ctx, alterWorkflow)
+}
+
+func (qre *QueryExecutor) execShowWorkflows(conn *StatefulConnection) (*sqltypes.Result, error) {
+	showStmt, ok := qre.plan.FullStmt.(*sqlparser.Show)
+	if !ok {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting SHOW VITESS_WORKFLOWS plan")
+	}
+	showBasic, ok := showStmt.Internal.(*sqlparser.ShowBasic)
+	if !ok || showBasic.Command != sqlparser.VitessWorkflows {
+		return nil, vterrors.New(vtrpcpb.Code_INTERNAL, "Expecting SHOW VITESS_WORKFLOWS plan")
+	}
+	query := buildShowWorkflowsQuery(showBasic, qre.tsv.config.DB.DBName)
+	if conn != nil {
+		return qre.execStatefulConn(conn, query, true)
+	}
+	pooledConn, err := qre.getConn()
+	if err != nil {
+		return nil, err
+	}
+	defer pooledConn.Recycle()
+	return qre.execDBConn(pooledConn.Conn, query, true)
+}
+
 func (qre *QueryExecutor) execShowThrottledApps() (*sqltypes.Result, error) {
 	if err := qre.tsv.lagThrottler.CheckIsOpen(); err != nil {
 		return nil, err
@@ -1319,6 +1419,15 @@ func (qre *QueryExecutor) execStreamSQL(conn *connpool.PooledConn, isTransaction
 	return nil
 }
 
+// pool returns the given pool, used by the query unless it executes on the
+// connection of a transaction or a reserved connection.
+func (qre *QueryExecutor) pool(pool string) string {
+	if qre.connID != 0 {
+		return ""
+	}
+	return pool
+}
+
 func (qre *QueryExecutor) recordUserQuery(queryType string, duration int64) {
 	var username string
 	if qre.tsv.config.SkipUserMetrics {
@@ -1354,6 +1463,8 @@ func (qre *QueryExecutor) GetSchemaDefinitions(tableType querypb.SchemaTableType
 		return qre.getTableDefinitions(tableNames, callback)
 	case querypb.SchemaTableType_UDFS:
 		return qre.getUDFs(callback)
+	case querypb.SchemaTableType_TABLE_STATS:
+		return qre.getTableStats(tableNames, callback)
 	}
 	return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid table type %v", tableType)
 }
@@ -1389,8 +1500,11 @@ func (qre *QueryExecutor) executeGetSchemaQuery(query string, callback func(sche
 	}
 	defer conn.Recycle()
 
+	tableRows := qre.tsv.se.GetTableRows()
+	indexCardinalities := qre.tsv.se.GetIndexCardinalities()
 	return qre.execStreamSQL(conn, false /* isTransaction */, query, func(result *sqltypes.Result) error {
 		schemaDef := make(map[string]string)
+		res := &querypb.GetSchemaResponse{TableDefinition: schemaDef}
 		for _, row := range result.Rows {
 			tableName := row[0].ToString()
 			// Schema RPC should ignore the internal table in the response.
@@ -1398,11 +1512,48 @@ func (qre *QueryExecutor) executeGetSchemaQuery(query string, callback func(sche
 				continue
 			}
 			schemaDef[tableName] = row[1].ToString()
+			addTableStats(res, tableName, tableRows, indexCardinalities)
 		}
-		return callback(&querypb.GetSchemaResponse{TableDefinition: schemaDef})
+		return callback(res)
 	})
 }
 
+// getTableStats returns the statistics of the tables, or of all the tables
+// when no table names are given, from the last reload of the schema engine
+// which included them.
+func (qre *QueryExecutor) getTableStats(tableNames []string, callback func(schemaRes *querypb.GetSchemaResponse) error) error {
+	tableRows := qre.tsv.se.GetTableRows()
+	indexCardinalities := qre.tsv.se.GetIndexCardinalities()
+	if len(tableNames) == 0 {
+		tableNames = slices.Collect(maps.Keys(tableRows))
+	}
+
+	res := &querypb.GetSchemaResponse{}
+	for _, tableName := range tableNames {
+		if schema.IsInternalOperationTableName(tableName) {
+			continue
+		}
+		addTableStats(res, tableName, tableRows, indexCardinalities)
+	}
+	return callback(res)
+}
+
+// addTableStats adds the statistics of the table to the response, if they are known.
+func addTableStats(res *querypb.GetSchemaResponse, tableName string, tableRows map[string]int64, indexCardinalities map[string]map[string]int64) {
+	if rows, ok := tableRows[tableName]; ok {
+		if res.TableRows == nil {
+			res.TableRows = make(map[string]int64)
+		}
+		res.TableRows[tableName] = rows
+	}
+	if cardinalities, ok := indexCardinalities[tableName]; ok {
+		if res.IndexCardinalities == nil {
+			res.IndexCardinalities = make(map[string]*querypb.IndexCardinalities)
+		}
+		res.IndexCardinalities[tableName] = &querypb.IndexCardinalities{Cardinalities: maps.Clone(cardinalities)}
+	}
+}
+
 func (qre *QueryExecutor) getUDFs(callback func(schemaRes *querypb.GetSchemaResponse) error) error {
 	query, err := eschema.GetFetchUDFsQuery(qre.tsv.env.Parser())
 	if err != nil {
@@ -699,6 +699,48 @@ func TestQueryExecutorPlanPassSelectWithLockOutsideATransaction(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestQueryExecutorReadAfterWrite(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table"
+	db.AddQuery("select * from test_table limit 10001", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	waitQuery := "select wait_for_executed_gtid_set('%s', 1.5)"
+	db.AddQuery(fmt.Sprintf(waitQuery, "uuid1:1-10"), sqltypes.MakeTestResult(sqltypes.MakeTestFields("w", "int64"), "0"))
+	db.AddQuery(fmt.Sprintf(waitQuery, "uuid1:1-20"), sqltypes.MakeTestResult(sqltypes.MakeTestFields("w", "int64"), "1"))
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+
+	execute := func(tabletType topodatapb.TabletType, gtidSet string, timeout int64) error {
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		qre.targetTabletType = tabletType
+		qre.options = &querypb.ExecuteOptions{ReadAfterWriteGtidSet: gtidSet, ReadAfterWriteTimeout: timeout}
+		_, err := qre.Execute()
+		return err
+	}
+
+	db.ResetQueryLog()
+	require.NoError(t, execute(topodatapb.TabletType_REPLICA, "uuid1:1-10", 1500))
+	assert.Equal(t, "select wait_for_executed_gtid_set('uuid1:1-10', 1.5);select * from test_table limit 10001", db.QueryLog())
+
+	err := execute(topodatapb.TabletType_REPLICA, "uuid1:1-20", 1500)
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+	assert.ErrorContains(t, err, "replica did not apply GTID set uuid1:1-20 within 1.5s")
+
+	// A timeout of 0 is not sent to MySQL, which would wait with no limit.
+	db.ResetQueryLog()
+	err = execute(topodatapb.TabletType_REPLICA, "uuid1:1-10", 0)
+	assert.Equal(t, vtrpcpb.Code_FAILED_PRECONDITION, vterrors.Code(err))
+	assert.Empty(t, db.QueryLog())
+
+	// The primary has applied its own writes.
+	db.ResetQueryLog()
+	require.NoError(t, execute(topodatapb.TabletType_PRIMARY, "uuid1:1-20", 1500))
+	assert.Equal(t, "select * from test_table limit 10001", db.QueryLog())
+}
+
 func TestQueryExecutorPlanNextval(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -821,6 +863,46 @@ func TestQueryExecutorPlanNextval(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorPlanNextvalDeterministic(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	selQuery := "select next_id, cache from seq where id = 0 for update"
+	db.AddQuery(selQuery, &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Type: sqltypes.Int64},
+			{Type: sqltypes.Int64},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt64(1),
+			sqltypes.NewInt64(1000),
+		}},
+	})
+	// The cache of the sequence is ignored: only the requested values are reserved.
+	updateQuery := "update seq set next_id = 3 where id = 0"
+	db.AddQuery(updateQuery, &sqltypes.Result{})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	env, err := vtenv.New(vtenv.Options{DeterministicSequences: true})
+	require.NoError(t, err)
+	tsv.env = env
+
+	qre := newTestQueryExecutor(ctx, tsv, "select next 2 values from seq", 0)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	want := &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "nextval",
+			Type: sqltypes.Int64,
+		}},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewInt64(1),
+		}},
+	}
+	assert.True(t, want.Equal(got), "got: %v", got)
+	assert.Equal(t, 1, db.GetQueryCalledNum(updateQuery))
+}
+
 func TestQueryExecutorMessageStreamACL(t *testing.T) {
 	ctx := t.Context()
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int64())
@@ -1270,6 +1352,43 @@ func TestQueryExecutorDenyListQRRetry(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorRewriteRule(t *testing.T) {
+	db := setUpQueryExecutorTest(t)
+	defer db.Close()
+	query := "select * from test_table where name = 1"
+	rewrittenQuery := "select * from test_table force index (idx_name) where `name` = 1 limit 10"
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+	}
+	// Only the rewritten query is known by MySQL.
+	db.AddQuery(rewrittenQuery, want)
+
+	rewriteRule := rules.NewQueryRule("force idx_name", "force idx_name", rules.QRRewrite)
+	rewriteRule.SetQueryCond("select.*")
+	rewriteRule.AddTableCond("test_table")
+	rewriteRule.SetRewrite(&rules.Rewrite{
+		IndexHints: []rules.IndexHint{{Table: "test_table", Type: "FORCE", Indexes: []string{"idx_name"}}},
+		Limit:      10,
+	})
+
+	rulesName := "rewriteRules"
+	qrs := rules.New()
+	qrs.Add(rewriteRule)
+
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, noFlags, db)
+	defer tsv.StopService()
+	tsv.qe.queryRuleSources.RegisterSource(rulesName)
+	defer tsv.qe.queryRuleSources.UnRegisterSource(rulesName)
+	require.NoError(t, tsv.qe.queryRuleSources.SetRules(rulesName, qrs))
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	assert.Equal(t, planbuilder.PlanSelect, qre.plan.PlanID)
+	got, err := qre.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
 func TestReplaceSchemaName(t *testing.T) {
 	db := setUpQueryExecutorTest(t)
 	defer db.Close()
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"vitess.io/vitess/go/protoutil"
+
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+)
+
+// pinnedPlan is a plan pinned in the QueryEngine, along with the epoch of
+// the schema it was built for. It is built again once the epoch changes, as
+// the plans of the cache are, but stays pinned.
+type pinnedPlan struct {
+	plan  *TabletPlan
+	epoch uint32
+}
+
+// getCachedPlan returns the pinned plan for the key, or else the plan from
+// the plan cache, loading it into the cache if it is not there.
+func (qe *QueryEngine) getCachedPlan(key PlanCacheKey, epoch uint32, load func() (*TabletPlan, error)) (plan *TabletPlan, cached bool, err error) {
+	qe.pinnedMu.RLock()
+	pinned := qe.pinnedPlans[key]
+	qe.pinnedMu.RUnlock()
+
+	switch {
+	case pinned == nil:
+		plan, cached, err = qe.plans.GetOrLoad(key, epoch, load)
+	case pinned.epoch >= epoch:
+		plan, cached = pinned.plan, true
+	default:
+		plan, err = load()
+		if err != nil && !errors.Is(err, errNoCache) {
+			return nil, false, err
+		}
+		qe.pinnedMu.Lock()
+		if qe.pinnedPlans[key] == pinned {
+			qe.pinnedPlans[key] = &pinnedPlan{plan: plan, epoch: epoch}
+		}
+		qe.pinnedMu.Unlock()
+		return plan, false, nil
+	}
+	if cached {
+		atomic.AddUint64(&plan.HitCount, 1)
+	}
+	return plan, cached, err
+}
+
+// planCacheKeys returns the keys under which the plans of the query may be
+// cached.
+func (qe *QueryEngine) planCacheKeys(query string) []PlanCacheKey {
+	return []PlanCacheKey{
+		PlanCacheKey(qe.getPlanCacheKey(query, false)),
+		PlanCacheKey(qe.getPlanCacheKey(query, true)),
+		PlanCacheKey(qe.getStreamPlanCacheKey(query)),
+	}
+}
+
+// cachedPlans returns the plans of the current epoch in the plan cache, by
+// key.
+func (qe *QueryEngine) cachedPlans() map[PlanCacheKey]*TabletPlan {
+	plans := make(map[PlanCacheKey]*TabletPlan)
+	qe.plans.Range(qe.schema.Load().epoch, func(key PlanCacheKey, plan *TabletPlan) bool {
+		plans[key] = plan
+		return true
+	})
+	return plans
+}
+
+// EvictQueryPlans evicts the plans of the given queries from the plan cache
+// and unpins them. It returns the number of plans evicted.
+func (qe *QueryEngine) EvictQueryPlans(queries []string) (evicted int) {
+	cached := qe.cachedPlans()
+
+	qe.pinnedMu.Lock()
+	defer qe.pinnedMu.Unlock()
+	for _, query := range queries {
+		for _, key := range qe.planCacheKeys(query) {
+			_, isCached := cached[key]
+			_, isPinned := qe.pinnedPlans[key]
+			if !isCached && !isPinned {
+				continue
+			}
+			qe.plans.Delete(key)
+			delete(qe.pinnedPlans, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// PinQueryPlans pins the cached plans of the given queries, so that they
+// are never evicted. It returns the number of plans pinned.
+func (qe *QueryEngine) PinQueryPlans(queries []string) (pinned int) {
+	cached := qe.cachedPlans()
+	epoch := qe.schema.Load().epoch
+
+	qe.pinnedMu.Lock()
+	defer qe.pinnedMu.Unlock()
+	for _, query := range queries {
+		for _, key := range qe.planCacheKeys(query) {
+			plan, ok := cached[key]
+			if !ok {
+				continue
+			}
+			if _, ok := qe.pinnedPlans[key]; ok {
+				continue
+			}
+			qe.pinnedPlans[key] = &pinnedPlan{plan: plan, epoch: epoch}
+			pinned++
+		}
+	}
+	return pinned
+}
+
+// UnpinQueryPlans unpins the plans of the given queries. They are cached
+// again the next time they are used. It returns the number of plans unpinned.
+func (qe *QueryEngine) UnpinQueryPlans(queries []string) (unpinned int) {
+	qe.pinnedMu.Lock()
+	defer qe.pinnedMu.Unlock()
+	for _, query := range queries {
+		for _, key := range qe.planCacheKeys(query) {
+			if _, ok := qe.pinnedPlans[key]; ok {
+				delete(qe.pinnedPlans, key)
+				unpinned++
+			}
+		}
+	}
+	return unpinned
+}
+
+// QueryPlans returns the pinned and cached plans whose query contains the
+// filter, the most hit first, up to limit plans if limit is positive.
+func (qe *QueryEngine) QueryPlans(filter string, limit int) []*tabletmanagerdatapb.QueryPlan {
+	plans := qe.cachedPlans()
+	pinned := make(map[PlanCacheKey]bool)
+	qe.pinnedMu.RLock()
+	for key, pp := range qe.pinnedPlans {
+		plans[key] = pp.plan
+		pinned[key] = true
+	}
+	qe.pinnedMu.RUnlock()
+
+	var result []*tabletmanagerdatapb.QueryPlan
+	for key, plan := range plans {
+		if !strings.Contains(plan.Original, filter) {
+			continue
+		}
+		queryCount, duration, mysqlTime, rowsAffected, rowsReturned, errorCount := plan.Stats()
+		qp := &tabletmanagerdatapb.QueryPlan{
+			Query:        plan.Original,
+			PlanType:     plan.PlanID.String(),
+			Streaming:    strings.HasPrefix(string(key), qe.getStreamPlanCacheKey("")),
+			HitCount:     atomic.LoadUint64(&plan.HitCount),
+			QueryCount:   queryCount,
+			Time:         protoutil.DurationToProto(duration),
+			MysqlTime:    protoutil.DurationToProto(mysqlTime),
+			RowsAffected: rowsAffected,
+			RowsReturned: rowsReturned,
+			ErrorCount:   errorCount,
+			Pinned:       pinned[key],
+		}
+		for _, table := range plan.TableNames() {
+			if table != "" {
+				qp.Tables = append(qp.Tables, table)
+			}
+		}
+		for _, rule := range plan.Rules.CopyUnderlying() {
+			qp.Rules = append(qp.Rules, rule.Name)
+		}
+		result = append(result, qp)
+	}
+
+	slices.SortFunc(result, func(a, b *tabletmanagerdatapb.QueryPlan) int {
+		return cmp.Or(
+			cmp.Compare(b.HitCount, a.HitCount),
+			strings.Compare(a.Query, b.Query),
+			strings.Compare(a.PlanType, b.PlanType),
+		)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
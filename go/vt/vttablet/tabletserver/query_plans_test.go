@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/streamlog"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema/schematest"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+)
+
+func TestQueryPlans(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	schematest.AddDefaultQueries(db)
+	db.AddQuery("select * from test_table_01 where 1 != 1", &sqltypes.Result{})
+	db.AddQuery("select * from test_table_02 where 1 != 1", &sqltypes.Result{})
+
+	qe := newTestQueryEngine(10*time.Second, true, newDBConfigs(db))
+	qe.se.Open()
+	qe.Open()
+	defer qe.Close()
+
+	ctx := context.Background()
+	logStats := tabletenv.NewLogStats(ctx, "GetPlanStats", streamlog.NewQueryLogConfigForTest())
+	query1, query2 := "select * from test_table_01", "select * from test_table_02"
+	getPlan := func(query string) *TabletPlan {
+		plan, err := qe.GetPlan(ctx, logStats, query, false, false)
+		require.NoError(t, err)
+		return plan
+	}
+	getPlan(query1)
+	_, err := qe.GetStreamPlan(ctx, logStats, query1, false)
+	require.NoError(t, err)
+	getPlan(query2)
+	require.Eventually(t, func() bool {
+		return len(qe.QueryPlans("", 0)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	getPlan(query1)
+
+	plans := qe.QueryPlans("", 0)
+	require.Len(t, plans, 3)
+	assert.Equal(t, query1, plans[0].Query)
+	assert.Equal(t, "Select", plans[0].PlanType)
+	assert.EqualValues(t, 1, plans[0].HitCount)
+	assert.False(t, plans[0].Streaming)
+	assert.False(t, plans[0].Pinned)
+
+	plans = qe.QueryPlans("test_table_02", 0)
+	require.Len(t, plans, 1)
+	assert.Equal(t, query2, plans[0].Query)
+	assert.Len(t, qe.QueryPlans("", 1), 1)
+
+	// The pinned plans are kept when they are evicted from the cache.
+	assert.Equal(t, 2, qe.PinQueryPlans([]string{query1, "select 1 from dual"}))
+	assert.Equal(t, 0, qe.PinQueryPlans([]string{query1}))
+	plan := getPlan(query1)
+	qe.plans.Delete(PlanCacheKey(query1))
+	assert.Same(t, plan, getPlan(query1))
+	assert.True(t, logStats.CachedPlan)
+	for _, plan := range qe.QueryPlans("test_table_01", 0) {
+		assert.True(t, plan.Pinned)
+	}
+
+	// And built again once the cache is cleared, staying pinned.
+	qe.ClearQueryPlanCache()
+	assert.NotSame(t, plan, getPlan(query1))
+	assert.False(t, logStats.CachedPlan)
+	plan = getPlan(query1)
+	assert.True(t, logStats.CachedPlan)
+	plans = qe.QueryPlans("", 0)
+	require.Len(t, plans, 2)
+	for _, plan := range plans {
+		assert.True(t, plan.Pinned)
+	}
+
+	assert.Equal(t, 2, qe.UnpinQueryPlans([]string{query1}))
+	assert.NotSame(t, plan, getPlan(query1))
+	require.Eventually(t, func() bool {
+		return len(qe.QueryPlans(query1, 0)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 1, qe.EvictQueryPlans([]string{query1, query2}))
+	assert.Empty(t, qe.QueryPlans("", 0))
+}
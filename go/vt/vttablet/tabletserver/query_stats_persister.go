@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/dbconnpool"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+const (
+	sqlReadQueryStats  = "select stat, labels, value from %s.query_stats where tablet_alias = %%a"
+	sqlWriteQueryStats = "insert into %s.query_stats(tablet_alias, stat, labels, value) values %s on duplicate key update value = values(value)"
+
+	// queryStatsBatchSize is the maximum number of rows written by a statement.
+	queryStatsBatchSize = 500
+)
+
+// queryStatsPersister saves the cumulative query statistics of the tablet in
+// the sidecar database, and restores them when the tablet starts, so that
+// a restart does not reset them.
+//
+// Only a primary saves its statistics, as the sidecar database of the other
+// tablets is read only. They are saved by tablet alias, so a promoted tablet
+// restores its own statistics and not those of the previous primary.
+type queryStatsPersister struct {
+	env   tabletenv.Env
+	alias string
+	stats map[string]*stats.CountersWithMultiLabels
+	ticks *timer.Timer
+
+	// mu protects the following fields.
+	mu       sync.Mutex
+	isOpen   bool
+	restored bool
+}
+
+func newQueryStatsPersister(env tabletenv.Env, alias *topodatapb.TabletAlias, qe *QueryEngine) *queryStatsPersister {
+	p := &queryStatsPersister{
+		env:   env,
+		alias: topoproto.TabletAliasString(alias),
+		stats: map[string]*stats.CountersWithMultiLabels{
+			"QueryCounts":               qe.queryCounts,
+			"QueryCountsWithTabletType": qe.queryCountsWithTabletType,
+			"QueryTimesNs":              qe.queryTimes,
+			"QueryErrorCounts":          qe.queryErrorCounts,
+			"QueryErrorCountsWithCode":  qe.queryErrorCountsWithCode,
+			"QueryRowsAffected":         qe.queryRowsAffected,
+			"QueryRowsReturned":         qe.queryRowsReturned,
+		},
+	}
+	if interval := env.Config().QueryStatsPersistInterval; interval > 0 {
+		p.ticks = timer.NewTimer(interval)
+	}
+	return p
+}
+
+// Open starts saving the statistics periodically.
+func (p *queryStatsPersister) Open() {
+	if p.ticks == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.isOpen {
+		return
+	}
+	p.isOpen = true
+	p.persistLocked()
+	p.ticks.Start(p.persist)
+}
+
+// Close stops saving the statistics, after saving them a last time.
+func (p *queryStatsPersister) Close() {
+	if p.ticks == nil {
+		return
+	}
+	p.ticks.Stop()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.isOpen {
+		return
+	}
+	p.persistLocked()
+	p.isOpen = false
+}
+
+func (p *queryStatsPersister) persist() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.persistLocked()
+}
+
+// persistLocked saves the statistics, once the ones saved before the tablet
+// started have been restored: saving them before would overwrite the latter.
+func (p *queryStatsPersister) persistLocked() {
+	ctx, cancel := context.WithTimeout(tabletenv.LocalContext(), p.env.Config().Oltp.QueryTimeout)
+	defer cancel()
+	conn, err := dbconnpool.NewDBConnection(ctx, p.env.Config().DB.DbaWithDB())
+	if err != nil {
+		log.Errorf("Cannot save the query statistics: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	if !p.restored {
+		if err := p.restore(conn); err != nil {
+			log.Errorf("Cannot restore the query statistics: %v", err)
+			return
+		}
+		p.restored = true
+	}
+	if err := p.save(conn); err != nil {
+		log.Errorf("Cannot save the query statistics: %v", err)
+	}
+}
+
+// restore adds the saved statistics of the tablet to its counters.
+func (p *queryStatsPersister) restore(conn *dbconnpool.DBConnection) error {
+	query, err := sqlparser.ParseAndBind(fmt.Sprintf(sqlReadQueryStats, sidecar.GetIdentifier()), sqltypes.StringBindVariable(p.alias))
+	if err != nil {
+		return err
+	}
+	qr, err := conn.ExecuteFetch(query, -1, true)
+	if err != nil {
+		return err
+	}
+	for _, row := range qr.Named().Rows {
+		counters := p.stats[row.AsString("stat", "")]
+		labels := strings.Split(row.AsString("labels", ""), ".")
+		value := row.AsInt64("value", 0)
+		// The labels of a counter change when the per workload metrics
+		// are enabled or disabled.
+		if counters == nil || len(labels) != len(counters.Labels()) || value <= 0 {
+			continue
+		}
+		counters.Add(labels, value)
+	}
+	return nil
+}
+
+// save writes the counters of the tablet.
+func (p *queryStatsPersister) save(conn *dbconnpool.DBConnection) error {
+	alias := sqltypes.EncodeStringSQL(p.alias)
+	var values []string
+	flush := func() error {
+		if len(values) == 0 {
+			return nil
+		}
+		query := fmt.Sprintf(sqlWriteQueryStats, sidecar.GetIdentifier(), strings.Join(values, ", "))
+		values = values[:0]
+		_, err := conn.ExecuteFetch(query, 0, false)
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.stats)) {
+		counts := p.stats[name].Counts()
+		for _, labels := range slices.Sorted(maps.Keys(counts)) {
+			values = append(values, fmt.Sprintf("(%s, %s, %s, %d)", alias, sqltypes.EncodeStringSQL(name), sqltypes.EncodeStringSQL(labels), counts[labels]))
+			if len(values) == queryStatsBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return flush()
+}
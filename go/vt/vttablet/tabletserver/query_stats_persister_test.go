@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vitess.io/vitess/go/mysql/fakesqldb"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestQueryStatsPersister(t *testing.T) {
+	db := fakesqldb.New(t)
+	defer db.Close()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.DB = newDBConfigs(db)
+	cfg.QueryStatsPersistInterval = time.Hour
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "QueryStatsPersisterTest")
+	qe := NewQueryEngine(env, schema.NewEngine(env))
+	p := newQueryStatsPersister(env, &topodatapb.TabletAlias{Cell: "zone1", Uid: 100}, qe)
+
+	db.AddQuery("select stat, labels, value from _vt.query_stats where tablet_alias = 'zone1-0000000100'", sqltypes.MakeTestResult(
+		sqltypes.MakeTestFields("stat|labels|value", "varbinary|varbinary|int64"),
+		"QueryCounts|t1.Select|5",
+		"QueryErrorCounts|t1.Select|1",
+		// Saved with the per workload metrics enabled.
+		"QueryCounts|t1.Select.olap|3",
+		"UnknownStat|t1.Select|4",
+	))
+	db.AddQueryPattern(`insert into _vt\.query_stats.*`, &sqltypes.Result{})
+
+	// The statistics are restored and saved when the persister opens.
+	qe.queryCounts.Add([]string{"t1", "Select"}, 2)
+	p.Open()
+	assert.Equal(t, map[string]int64{"t1.Select": 7}, qe.queryCounts.Counts())
+	assert.Equal(t, map[string]int64{"t1.Select": 1}, qe.queryErrorCounts.Counts())
+	// The query log is lowercased.
+	assert.Contains(t, db.QueryLog(), "insert into _vt.query_stats(tablet_alias, stat, labels, value) values ('zone1-0000000100', 'querycounts', 't1.select', 7), ('zone1-0000000100', 'queryerrorcounts', 't1.select', 1) on duplicate key update value = values(value)")
+
+	// They are restored once only, and saved a last time when it closes.
+	db.ResetQueryLog()
+	qe.queryCounts.Add([]string{"t1", "Select"}, 1)
+	p.Close()
+	p.Open()
+	p.Close()
+	assert.Equal(t, map[string]int64{"t1.Select": 8}, qe.queryCounts.Counts())
+	assert.NotContains(t, db.QueryLog(), "select stat")
+	assert.Equal(t, 3, db.GetQueryCalledNum("insert into _vt.query_stats(tablet_alias, stat, labels, value) values ('zone1-0000000100', 'QueryCounts', 't1.Select', 8), ('zone1-0000000100', 'QueryErrorCounts', 't1.Select', 1) on duplicate key update value = values(value)"))
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/semaphore"
+
+	"vitess.io/vitess/go/stats"
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/planbuilder"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+var resourceGroupsConfigFile string
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerResourceGroupsFlags)
+	servenv.OnParseFor("vttablet", registerResourceGroupsFlags)
+}
+
+func registerResourceGroupsFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&resourceGroupsConfigFile, "resource-groups-config", resourceGroupsConfigFile, "path to a JSON file defining resource groups: the queries are classified by caller, user or plan type into the first matching group, which limits how many of them execute at once and which fraction of the query and stream pools they may use")
+}
+
+// resourceGroupConfig defines a resource group in the --resource-groups-config
+// file. A query belongs to the group if it matches all its non-empty lists of
+// callers, users and plan types.
+type resourceGroupConfig struct {
+	Name string `json:"name"`
+	// Callers are the principals of the effective caller IDs of the queries.
+	Callers []string `json:"callers,omitempty"`
+	// Users are the usernames of the immediate caller IDs of the queries.
+	Users []string `json:"users,omitempty"`
+	// PlanTypes are the names of the plan types of the queries, such as
+	// Select, SelectStream or Insert.
+	PlanTypes []string `json:"plan_types,omitempty"`
+
+	// MaxConcurrency is how many queries of the group execute at once, 0 for
+	// no limit. The others wait for their turn.
+	MaxConcurrency int64 `json:"max_concurrency,omitempty"`
+	// MaxQueueSize is how many queries of the group may wait for their turn,
+	// 0 for no limit. The queries beyond it are rejected.
+	MaxQueueSize int64 `json:"max_queue_size,omitempty"`
+	// PoolQuota is the fraction of the connections of the query pool, and of
+	// the stream pool, which the queries of the group outside transactions
+	// may use at once, 0 for no limit. The queries beyond it are rejected.
+	PoolQuota float64 `json:"pool_quota,omitempty"`
+}
+
+// resourceGroupsConfig is the contents of the --resource-groups-config file.
+type resourceGroupsConfig struct {
+	Groups []*resourceGroupConfig `json:"groups"`
+}
+
+func loadResourceGroupsConfig(path string) (*resourceGroupsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &resourceGroupsConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(config.Groups))
+	for _, group := range config.Groups {
+		switch {
+		case group.Name == "" || group.Name == defaultResourceGroup:
+			return nil, fmt.Errorf("invalid resource group name %q", group.Name)
+		case names[group.Name]:
+			return nil, fmt.Errorf("duplicate resource group %s", group.Name)
+		case len(group.Callers) == 0 && len(group.Users) == 0 && len(group.PlanTypes) == 0:
+			return nil, fmt.Errorf("resource group %s matches no caller, user or plan type", group.Name)
+		case group.MaxConcurrency < 0 || group.MaxQueueSize < 0:
+			return nil, fmt.Errorf("resource group %s has a negative max_concurrency or max_queue_size", group.Name)
+		case group.PoolQuota < 0 || group.PoolQuota > 1:
+			return nil, fmt.Errorf("resource group %s has a pool_quota outside of [0, 1]", group.Name)
+		}
+		for _, planType := range group.PlanTypes {
+			if _, ok := planbuilder.PlanByName(planType); !ok {
+				return nil, fmt.Errorf("resource group %s has an unknown plan type %s", group.Name, planType)
+			}
+		}
+		names[group.Name] = true
+	}
+	return config, nil
+}
+
+// defaultResourceGroup is the group of the queries which match no other
+// group. It has no limits.
+const defaultResourceGroup = "default"
+
+// resourceGroup enforces the limits of a group on its queries.
+type resourceGroup struct {
+	config *resourceGroupConfig
+	// sem limits the number of queries executing at once, if the group has
+	// a MaxConcurrency.
+	sem *semaphore.Weighted
+
+	mu      sync.Mutex
+	waiting int64
+	// poolInUse is the number of connections of each pool used by the
+	// queries of the group.
+	poolInUse map[string]int64
+}
+
+// resourceGroups classifies the queries into resource groups and enforces
+// the limits of their group, so that one workload cannot exhaust the
+// connection pools of the tablet shared with others.
+type resourceGroups struct {
+	groups []*resourceGroup
+	// poolCapacities returns the capacities of the pools with quotas.
+	poolCapacities map[string]func() int64
+
+	queries    *stats.CountersWithSingleLabel
+	rejections *stats.CountersWithMultiLabels
+	waits      *servenv.TimingsWrapper
+	executing  *stats.GaugesWithSingleLabel
+}
+
+// newResourceGroups returns the resourceGroups defined by the config file,
+// or nil if there is none.
+func newResourceGroups(env tabletenv.Env, qe *QueryEngine, path string) (*resourceGroups, error) {
+	if path == "" {
+		return nil, nil
+	}
+	config, err := loadResourceGroupsConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --resource-groups-config %s: %v", path, err)
+	}
+
+	rg := &resourceGroups{
+		poolCapacities: map[string]func() int64{
+			"ConnPool":       qe.conns.Capacity,
+			"StreamConnPool": qe.streamConns.Capacity,
+		},
+		queries:    env.Exporter().NewCountersWithSingleLabel("ResourceGroupQueries", "Queries by resource group", "Group"),
+		rejections: env.Exporter().NewCountersWithMultiLabels("ResourceGroupRejections", "Queries rejected by the limits of their resource group", []string{"Group", "Reason"}),
+		waits:      env.Exporter().NewTimings("ResourceGroupWaits", "Waits of the queries for their turn in their resource group", "Group"),
+		executing:  env.Exporter().NewGaugesWithSingleLabel("ResourceGroupExecuting", "Queries executing by resource group", "Group"),
+	}
+	for _, groupConfig := range config.Groups {
+		group := &resourceGroup{
+			config:    groupConfig,
+			poolInUse: make(map[string]int64),
+		}
+		if groupConfig.MaxConcurrency > 0 {
+			group.sem = semaphore.NewWeighted(groupConfig.MaxConcurrency)
+		}
+		rg.groups = append(rg.groups, group)
+	}
+	return rg, nil
+}
+
+// classify returns the first group matched by the query, or nil for the
+// default group.
+func (rg *resourceGroups) classify(ctx context.Context, planType string) *resourceGroup {
+	caller := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx))
+	user := callerid.GetUsername(callerid.ImmediateCallerIDFromContext(ctx))
+	for _, group := range rg.groups {
+		config := group.config
+		if len(config.Callers) > 0 && !slices.Contains(config.Callers, caller) {
+			continue
+		}
+		if len(config.Users) > 0 && !slices.Contains(config.Users, user) {
+			continue
+		}
+		if len(config.PlanTypes) > 0 && !slices.Contains(config.PlanTypes, planType) {
+			continue
+		}
+		return group
+	}
+	return nil
+}
+
+// acquire waits for the turn of the query in its resource group, and
+// reserves a connection of the given pool in the quota of the group unless
+// pool is empty, as for the queries in transactions. It returns the function
+// to call once the query is done.
+func (rg *resourceGroups) acquire(ctx context.Context, planType string, pool string) (release func(), err error) {
+	if rg == nil {
+		return func() {}, nil
+	}
+	group := rg.classify(ctx, planType)
+	if group == nil {
+		rg.queries.Add(defaultResourceGroup, 1)
+		return func() {}, nil
+	}
+	name := group.config.Name
+	rg.queries.Add(name, 1)
+
+	if group.sem != nil {
+		if err := rg.wait(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+	releaseSem := func() {
+		if group.sem != nil {
+			group.sem.Release(1)
+		}
+	}
+
+	if pool != "" && group.config.PoolQuota > 0 {
+		quota := max(int64(group.config.PoolQuota*float64(rg.poolCapacities[pool]())), 1)
+		group.mu.Lock()
+		if group.poolInUse[pool] >= quota {
+			group.mu.Unlock()
+			releaseSem()
+			rg.rejections.Add([]string{name, "PoolQuota"}, 1)
+			return nil, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "resource group %s exceeded its quota of %d connections of %s", name, quota, pool)
+		}
+		group.poolInUse[pool]++
+		group.mu.Unlock()
+	} else {
+		pool = ""
+	}
+
+	rg.executing.Add(name, 1)
+	return func() {
+		rg.executing.Add(name, -1)
+		if pool != "" {
+			group.mu.Lock()
+			group.poolInUse[pool]--
+			group.mu.Unlock()
+		}
+		releaseSem()
+	}, nil
+}
+
+// wait waits for a query to be allowed to execute by the max concurrency of
+// its group.
+func (rg *resourceGroups) wait(ctx context.Context, group *resourceGroup) error {
+	name := group.config.Name
+	if group.sem.TryAcquire(1) {
+		return nil
+	}
+
+	group.mu.Lock()
+	if group.config.MaxQueueSize > 0 && group.waiting >= group.config.MaxQueueSize {
+		group.mu.Unlock()
+		rg.rejections.Add([]string{name, "QueueFull"}, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "resource group %s has too many queries waiting for their turn", name)
+	}
+	group.waiting++
+	group.mu.Unlock()
+	defer func() {
+		group.mu.Lock()
+		group.waiting--
+		group.mu.Unlock()
+	}()
+
+	start := time.Now()
+	err := group.sem.Acquire(ctx, 1)
+	rg.waits.Record(name, start)
+	if err != nil {
+		rg.rejections.Add([]string{name, "Timeout"}, 1)
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "resource group %s: timed out waiting for the turn of the query: %v", name, err)
+	}
+	return nil
+}
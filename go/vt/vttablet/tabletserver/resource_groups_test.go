@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/callerid"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/schema"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func writeResourceGroupsConfig(t *testing.T, config string) string {
+	configPath := path.Join(t.TempDir(), "resource_groups.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o644))
+	return configPath
+}
+
+func TestLoadResourceGroupsConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{name: "valid", config: `{"groups": [{"name": "analytics", "users": ["reporting"], "plan_types": ["SelectStream"], "max_concurrency": 2, "pool_quota": 0.25}]}`},
+		{name: "no name", config: `{"groups": [{"users": ["reporting"]}]}`, err: `invalid resource group name ""`},
+		{name: "default name", config: `{"groups": [{"name": "default", "users": ["reporting"]}]}`, err: `invalid resource group name "default"`},
+		{name: "duplicate", config: `{"groups": [{"name": "a", "users": ["u1"]}, {"name": "a", "users": ["u2"]}]}`, err: "duplicate resource group a"},
+		{name: "no match", config: `{"groups": [{"name": "a", "max_concurrency": 2}]}`, err: "resource group a matches no caller, user or plan type"},
+		{name: "negative concurrency", config: `{"groups": [{"name": "a", "users": ["u1"], "max_concurrency": -1}]}`, err: "resource group a has a negative max_concurrency or max_queue_size"},
+		{name: "pool quota", config: `{"groups": [{"name": "a", "users": ["u1"], "pool_quota": 2}]}`, err: "resource group a has a pool_quota outside of [0, 1]"},
+		{name: "plan type", config: `{"groups": [{"name": "a", "plan_types": ["Selec"]}]}`, err: "resource group a has an unknown plan type Selec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadResourceGroupsConfig(writeResourceGroupsConfig(t, tt.config))
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestResourceGroups(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	env := tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "ResourceGroupsTest")
+	qe := NewQueryEngine(env, schema.NewEngine(env))
+	configPath := writeResourceGroupsConfig(t, `{"groups": [
+		{"name": "analytics", "callers": ["analytics"], "max_concurrency": 1, "max_queue_size": 1},
+		{"name": "reporting", "users": ["reporting"], "plan_types": ["Select"], "pool_quota": 0.25}
+	]}`)
+	rg, err := newResourceGroups(env, qe, configPath)
+	require.NoError(t, err)
+	rg.poolCapacities["ConnPool"] = func() int64 { return 8 }
+	rejections := rg.rejections.Counts()
+
+	analyticsCtx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("analytics", "", ""), callerid.NewImmediateCallerID("app"))
+	reportingCtx := callerid.NewContext(context.Background(), nil, callerid.NewImmediateCallerID("reporting"))
+	assert.Equal(t, "analytics", rg.classify(analyticsCtx, "Insert").config.Name)
+	assert.Equal(t, "reporting", rg.classify(reportingCtx, "Select").config.Name)
+	assert.Nil(t, rg.classify(reportingCtx, "Insert"))
+	assert.Nil(t, rg.classify(context.Background(), "Select"))
+
+	// The queries of the analytics group execute one at a time, with a single
+	// one waiting for its turn.
+	release, err := rg.acquire(analyticsCtx, "Select", "ConnPool")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, rg.executing.Counts()["analytics"])
+	acquired := make(chan func())
+	go func() {
+		release, err := rg.acquire(analyticsCtx, "Select", "ConnPool")
+		assert.NoError(t, err)
+		acquired <- release
+	}()
+	require.Eventually(t, func() bool {
+		rg.groups[0].mu.Lock()
+		defer rg.groups[0].mu.Unlock()
+		return rg.groups[0].waiting == 1
+	}, 5*time.Second, time.Millisecond)
+	_, err = rg.acquire(analyticsCtx, "Select", "ConnPool")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualError(t, err, "resource group analytics has too many queries waiting for their turn")
+	release()
+	release = <-acquired
+
+	// A waiting query gives up with its context.
+	ctx, cancel := context.WithTimeout(analyticsCtx, 10*time.Millisecond)
+	defer cancel()
+	_, err = rg.acquire(ctx, "Select", "ConnPool")
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	release()
+
+	// The reporting group may use a quarter of the query pool, but its
+	// queries in transactions use no connection of the pool.
+	var releases []func()
+	for range 2 {
+		release, err := rg.acquire(reportingCtx, "Select", "ConnPool")
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	_, err = rg.acquire(reportingCtx, "Select", "ConnPool")
+	assert.EqualError(t, err, "resource group reporting exceeded its quota of 2 connections of ConnPool")
+	release, err = rg.acquire(reportingCtx, "Select", "")
+	require.NoError(t, err)
+	release()
+	releases[0]()
+	release, err = rg.acquire(reportingCtx, "Select", "ConnPool")
+	require.NoError(t, err)
+	release()
+	releases[1]()
+
+	assert.EqualValues(t, 0, rg.executing.Counts()["analytics"])
+	assert.EqualValues(t, 0, rg.executing.Counts()["reporting"])
+	assert.EqualValues(t, 1, rg.rejections.Counts()["analytics.QueueFull"]-rejections["analytics.QueueFull"])
+	assert.EqualValues(t, 1, rg.rejections.Counts()["analytics.Timeout"]-rejections["analytics.Timeout"])
+	assert.EqualValues(t, 1, rg.rejections.Counts()["reporting.PoolQuota"]-rejections["reporting.PoolQuota"])
+
+	// Without a config, there are no resource groups.
+	rg, err = newResourceGroups(env, qe, "")
+	require.NoError(t, err)
+	release, err = rg.acquire(analyticsCtx, "Select", "ConnPool")
+	require.NoError(t, err)
+	release()
+}
@@ -40,13 +40,53 @@ func (cached *BindVarCond) CachedSize(alloc bool) int64 {
 	return size
 }
 
+func (cached *IndexHint) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(64)
+	}
+	// field Table string
+	size += hack.RuntimeAllocSize(int64(len(cached.Table)))
+	// field Type string
+	size += hack.RuntimeAllocSize(int64(len(cached.Type)))
+	// field Indexes []string
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.Indexes)) * int64(16))
+		for _, elem := range cached.Indexes {
+			size += hack.RuntimeAllocSize(int64(len(elem)))
+		}
+	}
+	return size
+}
+
+func (cached *Rewrite) CachedSize(alloc bool) int64 {
+	if cached == nil {
+		return int64(0)
+	}
+	size := int64(0)
+	if alloc {
+		size += int64(32)
+	}
+	// field IndexHints []vitess.io/vitess/go/vt/vttablet/tabletserver/rules.IndexHint
+	{
+		size += hack.RuntimeAllocSize(int64(cap(cached.IndexHints)) * int64(56))
+		for _, elem := range cached.IndexHints {
+			size += elem.CachedSize(false)
+		}
+	}
+	return size
+}
+
 func (cached *Rule) CachedSize(alloc bool) int64 {
 	if cached == nil {
 		return int64(0)
 	}
 	size := int64(0)
 	if alloc {
-		size += int64(256)
+		size += int64(288)
 	}
 	// field Description string
 	size += hack.RuntimeAllocSize(int64(len(cached.Description)))
@@ -80,6 +120,8 @@ func (cached *Rule) CachedSize(alloc bool) int64 {
 			size += elem.CachedSize(false)
 		}
 	}
+	// field rewrite *vitess.io/vitess/go/vt/vttablet/tabletserver/rules.Rewrite
+	size += cached.rewrite.CachedSize(true)
 	return size
 }
 
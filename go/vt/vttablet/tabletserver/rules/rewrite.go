@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vterrors"
+
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+// Rewrite is the rewrite of the queries which match a REWRITE rule. It is a
+// stop-gap mitigation for known pathological queries, until they are fixed
+// in the application: the queries are rewritten before being sent to MySQL.
+type Rewrite struct {
+	// IndexHints replace the index hints of the tables of the query.
+	IndexHints []IndexHint `json:",omitempty"`
+	// Limit is added to the query if it has no limit.
+	Limit int `json:",omitempty"`
+}
+
+// IndexHint is an index hint set on a table by a Rewrite.
+type IndexHint struct {
+	Table string
+	// Type is USE, FORCE or IGNORE.
+	Type    string
+	Indexes []string
+}
+
+var indexHintTypes = map[string]sqlparser.IndexHintType{
+	"USE":    sqlparser.UseOp,
+	"FORCE":  sqlparser.ForceOp,
+	"IGNORE": sqlparser.IgnoreOp,
+}
+
+// Copy performs a deep copy of a Rewrite.
+func (rw *Rewrite) Copy() *Rewrite {
+	if rw == nil {
+		return nil
+	}
+	newrw := &Rewrite{Limit: rw.Limit}
+	for _, hint := range rw.IndexHints {
+		hint.Indexes = slices.Clone(hint.Indexes)
+		newrw.IndexHints = append(newrw.IndexHints, hint)
+	}
+	return newrw
+}
+
+// apply rewrites the statement, and returns whether it changed.
+func (rw *Rewrite) apply(stmt sqlparser.Statement) bool {
+	changed := false
+	if len(rw.IndexHints) > 0 {
+		_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			ate, ok := node.(*sqlparser.AliasedTableExpr)
+			if !ok {
+				return true, nil
+			}
+			tableName, ok := ate.Expr.(sqlparser.TableName)
+			if !ok {
+				return true, nil
+			}
+			for _, hint := range rw.IndexHints {
+				if tableName.Name.String() != hint.Table {
+					continue
+				}
+				indexHint := &sqlparser.IndexHint{Type: indexHintTypes[hint.Type]}
+				for _, index := range hint.Indexes {
+					indexHint.Indexes = append(indexHint.Indexes, sqlparser.NewIdentifierCI(index))
+				}
+				ate.Hints = sqlparser.IndexHints{indexHint}
+				changed = true
+			}
+			return true, nil
+		}, stmt)
+	}
+	if rw.Limit > 0 {
+		limit := &sqlparser.Limit{Rowcount: sqlparser.NewIntLiteral(strconv.Itoa(rw.Limit))}
+		switch stmt := stmt.(type) {
+		case *sqlparser.Select:
+			if stmt.Limit == nil {
+				stmt.Limit, changed = limit, true
+			}
+		case *sqlparser.Union:
+			if stmt.Limit == nil {
+				stmt.Limit, changed = limit, true
+			}
+		case *sqlparser.Update:
+			if stmt.Limit == nil {
+				stmt.Limit, changed = limit, true
+			}
+		case *sqlparser.Delete:
+			if stmt.Limit == nil {
+				stmt.Limit, changed = limit, true
+			}
+		}
+	}
+	return changed
+}
+
+// buildRewrite builds a Rewrite from the value of the Rewrite tag of a rule.
+func buildRewrite(v any) (*Rewrite, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want json object for Rewrite")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	rw := &Rewrite{}
+	if err := dec.Decode(rw); err != nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Rewrite: %v", err)
+	}
+	if len(rw.IndexHints) == 0 && rw.Limit == 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Rewrite needs IndexHints or a Limit")
+	}
+	if rw.Limit < 0 {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Limit in Rewrite: %d", rw.Limit)
+	}
+	for _, hint := range rw.IndexHints {
+		if hint.Table == "" {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Table missing in IndexHints")
+		}
+		if _, ok := indexHintTypes[hint.Type]; !ok {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid index hint Type %s", hint.Type)
+		}
+		if len(hint.Indexes) == 0 {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Indexes missing in IndexHints")
+		}
+	}
+	return rw, nil
+}
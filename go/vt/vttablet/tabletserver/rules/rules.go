@@ -190,6 +190,19 @@ func (qrs *Rules) GetAction(
 	return QRContinue, nil, 0, ""
 }
 
+// Rewrite applies the rewrites of the REWRITE rules to the statement, and
+// returns whether it changed. The rules must be filtered by plan, as the
+// REWRITE rules only have the conditions evaluated by FilterByPlan.
+func (qrs *Rules) Rewrite(stmt sqlparser.Statement) bool {
+	changed := false
+	for _, qr := range qrs.rules {
+		if qr.act == QRRewrite && qr.rewrite.apply(stmt) {
+			changed = true
+		}
+	}
+	return changed
+}
+
 // -----------------------------------------------
 
 // Rule represents one rule (conditions-action).
@@ -226,6 +239,9 @@ type Rule struct {
 
 	// a rule can timeout.
 	timeout time.Duration
+
+	// Rewrite of the query, for the REWRITE action.
+	rewrite *Rewrite
 }
 
 type namedRegexp struct {
@@ -274,6 +290,7 @@ func (qr *Rule) Equal(other *Rule) bool {
 		reflect.DeepEqual(qr.plans, other.plans) &&
 		reflect.DeepEqual(qr.tableNames, other.tableNames) &&
 		reflect.DeepEqual(qr.bindVarConds, other.bindVarConds) &&
+		reflect.DeepEqual(qr.rewrite, other.rewrite) &&
 		qr.act == other.act)
 }
 
@@ -290,6 +307,7 @@ func (qr *Rule) Copy() (newqr *Rule) {
 		act:             qr.act,
 		cancelCtx:       qr.cancelCtx,
 		timeout:         qr.timeout,
+		rewrite:         qr.rewrite.Copy(),
 	}
 	if qr.plans != nil {
 		newqr.plans = make([]planbuilder.PlanType, len(qr.plans))
@@ -341,6 +359,9 @@ func (qr *Rule) MarshalJSON() ([]byte, error) {
 	if qr.timeout != 0 {
 		safeEncode(b, `,"Timeout":`, qr.timeout)
 	}
+	if qr.rewrite != nil {
+		safeEncode(b, `,"Rewrite":`, qr.rewrite)
+	}
 	_, _ = b.WriteString("}")
 	return b.Bytes(), nil
 }
@@ -396,6 +417,11 @@ func (qr *Rule) SetTrailingCommentCond(pattern string) (err error) {
 	return
 }
 
+// SetRewrite sets the rewrite of the query, for the REWRITE action.
+func (qr *Rule) SetRewrite(rw *Rewrite) {
+	qr.rewrite = rw
+}
+
 // makeExact forces a full string match for the regex instead of substring
 func makeExact(pattern string) string {
 	return fmt.Sprintf("^%s$", pattern)
@@ -489,6 +515,10 @@ func (qr *Rule) GetAction(
 	bindVars map[string]*querypb.BindVariable,
 	marginComments sqlparser.MarginComments,
 ) Action {
+	if qr.act == QRRewrite {
+		// The query was rewritten when it was planned.
+		return QRContinue
+	}
 	if qr.cancelCtx != nil {
 		select {
 		case <-qr.cancelCtx.Done():
@@ -570,6 +600,7 @@ const (
 	QRFail
 	QRFailRetry
 	QRBuffer
+	QRRewrite
 )
 
 // MarshalJSON marshals to JSON.
@@ -583,6 +614,8 @@ func (act Action) MarshalJSON() ([]byte, error) {
 		str = "FAIL_RETRY"
 	case QRBuffer:
 		str = "BUFFER"
+	case QRRewrite:
+		str = "REWRITE"
 	default:
 		str = "INVALID"
 	}
@@ -883,6 +916,8 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 			if !ok {
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "want list for %s", k)
 			}
+		case "Rewrite":
+			// The object is validated when the rewrite is built.
 		default:
 			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "unrecognized tag %s", k)
 		}
@@ -947,6 +982,11 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 					return nil, err
 				}
 			}
+		case "Rewrite":
+			qr.rewrite, err = buildRewrite(v)
+			if err != nil {
+				return nil, err
+			}
 		case "Action":
 			switch sv {
 			case "FAIL":
@@ -955,11 +995,21 @@ func BuildQueryRule(ruleInfo map[string]any) (qr *Rule, err error) {
 				qr.act = QRFailRetry
 			case "BUFFER":
 				qr.act = QRBuffer
+			case "REWRITE":
+				qr.act = QRRewrite
 			default:
 				return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "invalid Action %s", sv)
 			}
 		}
 	}
+	if (qr.act == QRRewrite) != (qr.rewrite != nil) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Rewrite must be set for the REWRITE action only")
+	}
+	if qr.act == QRRewrite && (qr.requestIP.Regexp != nil || qr.user.Regexp != nil || qr.leadingComment.Regexp != nil || qr.trailingComment.Regexp != nil || qr.bindVarConds != nil) {
+		// The query is rewritten when it is planned, before these
+		// conditions can be evaluated.
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "REWRITE rules only support the Query, Plans and TableNames conditions")
+	}
 	return qr, nil
 }
 
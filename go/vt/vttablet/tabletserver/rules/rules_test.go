@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
@@ -733,6 +734,16 @@ var invalidjsons = []InvalidJSONCase{
 	{`[{"BindVarConds": [{"Name": "a", "OnAbsent": true, "OnMismatch": true, "Operator": "NOMATCH", "Value": "["}]}]`, "processing [: error parsing regexp: missing closing ]: `[$`"},
 	{`[{"Action": 1 }]`, "want string for Action"},
 	{`[{"Action": "foo" }]`, "invalid Action foo"},
+	{`[{"Action": "REWRITE" }]`, "Rewrite must be set for the REWRITE action only"},
+	{`[{"Action": "FAIL", "Rewrite": {"Limit": 10}}]`, "Rewrite must be set for the REWRITE action only"},
+	{`[{"Action": "REWRITE", "User": "u", "Rewrite": {"Limit": 10}}]`, "REWRITE rules only support the Query, Plans and TableNames conditions"},
+	{`[{"Action": "REWRITE", "Rewrite": 1}]`, "invalid Rewrite: json: cannot unmarshal number into Go value of type rules.Rewrite"},
+	{`[{"Action": "REWRITE", "Rewrite": {"Foo": 1}}]`, "invalid Rewrite: json: unknown field \"Foo\""},
+	{`[{"Action": "REWRITE", "Rewrite": {}}]`, "Rewrite needs IndexHints or a Limit"},
+	{`[{"Action": "REWRITE", "Rewrite": {"Limit": -1}}]`, "invalid Limit in Rewrite: -1"},
+	{`[{"Action": "REWRITE", "Rewrite": {"IndexHints": [{"Type": "FORCE", "Indexes": ["a"]}]}}]`, "Table missing in IndexHints"},
+	{`[{"Action": "REWRITE", "Rewrite": {"IndexHints": [{"Table": "t", "Type": "foo", "Indexes": ["a"]}]}}]`, "invalid index hint Type foo"},
+	{`[{"Action": "REWRITE", "Rewrite": {"IndexHints": [{"Table": "t", "Type": "FORCE"}]}}]`, "Indexes missing in IndexHints"},
 }
 
 func TestInvalidJSON(t *testing.T) {
@@ -755,6 +766,64 @@ func TestInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestRewrite(t *testing.T) {
+	qrs := New()
+	err := qrs.UnmarshalJSON([]byte(`[{
+		"Name": "r1",
+		"Query": "select .*",
+		"TableNames": ["t1"],
+		"Action": "REWRITE",
+		"Rewrite": {"IndexHints": [{"Table": "t1", "Type": "FORCE", "Indexes": ["idx_a", "idx_b"]}], "Limit": 100}
+	}]`))
+	require.NoError(t, err)
+
+	// The rule is marshaled back as it was written.
+	data, err := json.Marshal(qrs)
+	require.NoError(t, err)
+	other := New()
+	require.NoError(t, other.UnmarshalJSON(data))
+	assert.True(t, qrs.Equal(other))
+	assert.True(t, qrs.Equal(qrs.Copy()))
+
+	testcases := []struct {
+		query  string
+		tables []string
+		want   string
+	}{{
+		query:  "select * from t1 where a = 1",
+		tables: []string{"t1"},
+		want:   "select * from t1 force index (idx_a, idx_b) where a = 1 limit 100",
+	}, {
+		query:  "select * from t1 use index (idx_c) join t2 on t1.id = t2.id limit 10",
+		tables: []string{"t1", "t2"},
+		want:   "select * from t1 force index (idx_a, idx_b) join t2 on t1.id = t2.id limit 10",
+	}, {
+		query:  "select * from t2 where a = 1",
+		tables: []string{"t2"},
+	}, {
+		query:  "update t1 set a = 1",
+		tables: []string{"t1"},
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := sqlparser.NewTestParser().Parse(tc.query)
+			require.NoError(t, err)
+			filtered := qrs.FilterByPlan(tc.query, planbuilder.PlanSelect, tc.tables...)
+			changed := filtered.Rewrite(stmt)
+			if tc.want == "" {
+				assert.False(t, changed)
+				return
+			}
+			assert.True(t, changed)
+			assert.Equal(t, tc.want, sqlparser.String(stmt))
+
+			// The query was rewritten when it was planned.
+			act, _, _, _ := filtered.GetAction("", "", nil, sqlparser.MarginComments{})
+			assert.Equal(t, QRContinue, act)
+		})
+	}
+}
+
 func TestBuildQueryRuleActionFail(t *testing.T) {
 	var ruleInfo map[string]any
 	err := json.Unmarshal([]byte(`{"Action": "FAIL" }`), &ruleInfo)
@@ -61,10 +61,18 @@ const (
 	maxTableCount         = 10000
 	maxPartitionsPerTable = 8192
 	maxIndexesPerTable    = 64
+
+	// minTableStatsChange is the smallest change of the estimated number of
+	// rows of a table, or of distinct values of an index, which is notified
+	// to the stats notifiers, provided that it also doubled or halved.
+	minTableStatsChange = 100
 )
 
 type notifier func(full map[string]*Table, created, altered, dropped []*Table, udfsChanged bool)
 
+// statsNotifier is notified when the table statistics changed.
+type statsNotifier func()
+
 // Engine stores the schema info and performs operations that
 // keep itself up-to-date.
 type Engine struct {
@@ -77,11 +85,21 @@ type Engine struct {
 	tables     map[string]*Table
 	lastChange int64
 	// the position at which the schema was last loaded. it is only used in conjunction with ReloadAt
-	reloadAtPos replication.Position
-	notifierMu  sync.Mutex
-	notifiers   map[string]notifier
+	reloadAtPos    replication.Position
+	notifierMu     sync.Mutex
+	notifiers      map[string]notifier
+	statsNotifiers map[string]statsNotifier
 	// isServingPrimary stores if this tablet is currently the serving primary or not.
 	isServingPrimary bool
+	// tableRows and indexCardinalities store the estimated number of rows
+	// of the tables and of unique values of their indexes, as of the last
+	// reload which included the table statistics.
+	tableRows          map[string]int64
+	indexCardinalities map[string]map[string]int64
+	// notifiedTableRows and notifiedIndexCardinalities store the table
+	// statistics as of the last notification of the stats notifiers.
+	notifiedTableRows          map[string]int64
+	notifiedIndexCardinalities map[string]map[string]int64
 	// schemaCopy stores if the user has requested signals on schema changes. If they have, then we
 	// also track the underlying schema and make a copy of it in our MySQL instance.
 	schemaCopy bool
@@ -274,6 +292,7 @@ func (se *Engine) Open() error {
 		"dual": NewTable("dual", NoType),
 	}
 	se.notifiers = make(map[string]notifier)
+	se.statsNotifiers = make(map[string]statsNotifier)
 
 	if err := se.reload(ctx, false); err != nil {
 		return err
@@ -333,6 +352,7 @@ func (se *Engine) closeLocked() {
 	se.tables = make(map[string]*Table)
 	se.lastChange = 0
 	se.notifiers = make(map[string]notifier)
+	se.statsNotifiers = make(map[string]statsNotifier)
 	se.isOpen = false
 
 	// Unlock the mutex. If there is a tick blocked on this lock,
@@ -829,22 +849,75 @@ func (se *Engine) updateTableIndexMetrics(ctx context.Context, conn *connpool.Co
 
 	se.indexBytesGauge.ResetAll()
 	se.indexCardinalityGauge.ResetAll()
+	indexCardinalities := make(map[string]map[string]int64)
 	for _, idx := range indexes {
 		key := []string{idx.table, idx.index}
 		se.indexBytesGauge.Set(key, idx.bytes)
 		se.indexCardinalityGauge.Set(key, idx.cardinality)
+		if indexCardinalities[idx.table] == nil {
+			indexCardinalities[idx.table] = make(map[string]int64)
+		}
+		indexCardinalities[idx.table][idx.index] = idx.cardinality
 	}
 
 	se.tableRowsGauge.ResetAll()
 	se.tableClusteredIndexSizeGauge.ResetAll()
+	tableRows := make(map[string]int64, len(tables))
 	for _, tbl := range tables {
 		se.tableRowsGauge.Set(tbl.table, tbl.rows)
 		se.tableClusteredIndexSizeGauge.Set(tbl.table, tbl.rowBytes)
+		tableRows[tbl.table] = tbl.rows
 	}
 
+	se.setTableStats(tableRows, indexCardinalities)
 	return nil
 }
 
+// setTableStats stores the table statistics, and notifies the stats
+// notifiers if they changed significantly since they were last notified,
+// as each notification makes every vtgate reload the statistics and replan
+// its queries. It must be called while holding a lock on se.mu.
+func (se *Engine) setTableStats(tableRows map[string]int64, indexCardinalities map[string]map[string]int64) {
+	se.tableRows = tableRows
+	se.indexCardinalities = indexCardinalities
+	changed := statsChanged(se.notifiedTableRows, tableRows) ||
+		len(se.notifiedIndexCardinalities) != len(indexCardinalities)
+	for table, cardinalities := range indexCardinalities {
+		notified, ok := se.notifiedIndexCardinalities[table]
+		changed = changed || !ok || statsChanged(notified, cardinalities)
+	}
+	if !changed || !se.isOpen {
+		return
+	}
+	se.notifiedTableRows = tableRows
+	se.notifiedIndexCardinalities = indexCardinalities
+
+	se.notifierMu.Lock()
+	defer se.notifierMu.Unlock()
+	for _, f := range se.statsNotifiers {
+		f()
+	}
+}
+
+// statsChanged returns true if a statistic was added or removed, or if its
+// value doubled or halved by at least minTableStatsChange.
+func statsChanged(old, new map[string]int64) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for name, value := range new {
+		oldValue, ok := old[name]
+		if !ok {
+			return true
+		}
+		lo, hi := min(oldValue, value), max(oldValue, value)
+		if hi-lo >= minTableStatsChange && hi >= 2*lo {
+			return true
+		}
+	}
+	return false
+}
+
 func (se *Engine) mysqlTime(ctx context.Context, conn *connpool.Conn) (int64, error) {
 	// Keep `SELECT UNIX_TIMESTAMP` is in uppercase because binlog server queries are case sensitive and expect it to be so.
 	tm, err := conn.Exec(ctx, "SELECT UNIX_TIMESTAMP()", 1, false)
@@ -1007,6 +1080,18 @@ func (se *Engine) UnregisterNotifier(name string) {
 	log.Infof("schema Engine - finished UnregisterNotifier")
 }
 
+// RegisterStatsNotifier registers the function to be notified when the
+// table statistics change.
+func (se *Engine) RegisterStatsNotifier(name string, f func()) {
+	if !se.isOpen {
+		return
+	}
+
+	se.notifierMu.Lock()
+	defer se.notifierMu.Unlock()
+	se.statsNotifiers[name] = f
+}
+
 // broadcast must be called while holding a lock on se.mu.
 func (se *Engine) broadcast(created, altered, dropped []*Table, udfsChanged bool) {
 	if !se.isOpen {
@@ -1044,6 +1129,32 @@ func (se *Engine) GetSchema() map[string]*Table {
 	return tables
 }
 
+// GetTableRows returns the estimated number of rows of the tables. It is
+// only known once the schema was reloaded with the table statistics.
+func (se *Engine) GetTableRows() map[string]int64 {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	return maps.Clone(se.tableRows)
+}
+
+// GetIndexCardinalities returns the estimated number of unique values of
+// the indexes of each table. The maps are shared and must be treated as
+// read-only. Like the row counts, they are only known once the schema was
+// reloaded with the table statistics.
+func (se *Engine) GetIndexCardinalities() map[string]map[string]int64 {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	return maps.Clone(se.indexCardinalities)
+}
+
+// SetTableStatsForTests sets the table statistics, and notifies the stats
+// notifiers if they changed.
+func (se *Engine) SetTableStatsForTests(tableRows map[string]int64, indexCardinalities map[string]map[string]int64) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.setTableStats(tableRows, indexCardinalities)
+}
+
 // MarshalMinimalSchema returns a protobuf encoded binlogdata.MinimalSchema
 func (se *Engine) MarshalMinimalSchema() ([]byte, error) {
 	se.mu.Lock()
@@ -1110,11 +1221,12 @@ func (se *Engine) handleHTTPSchema(response http.ResponseWriter) {
 // doesn't reload.  Use SetTableForTests to set table schema.
 func NewEngineForTests() *Engine {
 	se := &Engine{
-		isOpen:    true,
-		tables:    make(map[string]*Table),
-		historian: newHistorian(false, 0, nil),
-		env:       tabletenv.NewEnv(vtenv.NewTestEnv(), tabletenv.NewDefaultConfig(), "SchemaEngineForTests"),
-		notifiers: make(map[string]notifier),
+		isOpen:         true,
+		tables:         make(map[string]*Table),
+		historian:      newHistorian(false, 0, nil),
+		env:            tabletenv.NewEnv(vtenv.NewTestEnv(), tabletenv.NewDefaultConfig(), "SchemaEngineForTests"),
+		notifiers:      make(map[string]notifier),
+		statsNotifiers: make(map[string]statsNotifier),
 	}
 	return se
 }
@@ -101,6 +101,7 @@ type stateManager struct {
 	demotePrimaryStalled bool
 	lameduck             bool
 	diskHealthMonitor    DiskHealthMonitor
+	dependencyGate       *dependencyGate
 	alsoAllow            []topodatapb.TabletType
 	reason               string
 	transitionErr        error
@@ -129,6 +130,11 @@ type stateManager struct {
 	throttler   lagThrottler
 	qThrottler  queryThrottler
 	tableGC     tableGarbageCollector
+	queryStats  subComponent
+	poolSizer   subComponent
+
+	// lagGuard is nil unless the tablet stops serving when lagging.
+	lagGuard *lagServingGuard
 
 	// hcticks starts on initialization and runs forever.
 	hcticks *timer.Timer
@@ -268,6 +274,16 @@ func (sm *stateManager) execTransition(tabletType topodatapb.TabletType, state s
 	var err error
 	switch state {
 	case StateServing:
+		// The tablet does not serve until its external dependencies are
+		// reachable, but it connects to MySQL meanwhile.
+		if err = sm.dependencyGate.Check(); err != nil {
+			if tabletType == topodatapb.TabletType_PRIMARY {
+				_ = sm.unservePrimary()
+			} else {
+				_ = sm.unserveNonPrimary(tabletType)
+			}
+			break
+		}
 		if tabletType == topodatapb.TabletType_PRIMARY {
 			err = sm.servePrimary()
 		} else {
@@ -478,6 +494,8 @@ func (sm *stateManager) servePrimary() error {
 	sm.qThrottler.Open()
 	sm.tableGC.Open()
 	sm.ddle.Open()
+	sm.queryStats.Open()
+	sm.poolSizer.Open()
 	sm.setState(topodatapb.TabletType_PRIMARY, StateServing)
 	return nil
 }
@@ -504,6 +522,7 @@ func (sm *stateManager) serveNonPrimary(wantTabletType topodatapb.TabletType) er
 	cancel := sm.terminateAllQueries(nil)
 	defer cancel()
 
+	sm.queryStats.Close()
 	sm.ddle.Close()
 	sm.tableGC.Close()
 	sm.messager.Close()
@@ -520,6 +539,7 @@ func (sm *stateManager) serveNonPrimary(wantTabletType topodatapb.TabletType) er
 	sm.watcher.Open()
 	sm.throttler.Open()
 	sm.qThrottler.Open()
+	sm.poolSizer.Open()
 	sm.setState(wantTabletType, StateServing)
 	return nil
 }
@@ -565,7 +585,11 @@ func (sm *stateManager) unserveCommon() {
 	log.Infof("Finished execution of terminateAllQueries")
 	defer cancel()
 
-	log.Infof("Started online ddl executor close")
+	log.Infof("Started adaptive pool sizer close")
+	sm.poolSizer.Close()
+	log.Infof("Finished adaptive pool sizer close. Started query stats persister close")
+	sm.queryStats.Close()
+	log.Infof("Finished query stats persister close. Started online ddl executor close")
 	sm.ddle.Close()
 	log.Infof("Finished online ddl executor close. Started table garbage collector close")
 	sm.tableGC.Close()
@@ -732,15 +756,20 @@ func (sm *stateManager) Broadcast() {
 		// If we are stalled while demoting primary, we should send an error for it.
 		err = vterrors.VT09031()
 	}
+	if err == nil {
+		err = sm.dependencyGate.Err()
+	}
 	sm.hs.ChangeState(sm.target.TabletType, sm.ptsTimestamp, lag, err, sm.isServingLocked())
 }
 
 func (sm *stateManager) refreshReplHealthLocked() (time.Duration, error) {
 	if sm.target.TabletType == topodatapb.TabletType_PRIMARY {
 		sm.replHealthy = true
+		sm.lagGuard.Observe(sm.target, 0, nil)
 		return 0, nil
 	}
 	lag, err := sm.rt.Status()
+	sm.lagGuard.Observe(sm.target, lag, err)
 	if err != nil {
 		if sm.replHealthy {
 			log.Infof("Going unhealthy due to replication error: %v", err)
@@ -789,7 +818,7 @@ func (sm *stateManager) IsServing() bool {
 }
 
 func (sm *stateManager) isServingLocked() bool {
-	return sm.state == StateServing && sm.wantState == StateServing && sm.replHealthy && !sm.demotePrimaryStalled && !sm.lameduck && !sm.diskHealthMonitor.IsDiskStalled()
+	return sm.state == StateServing && sm.wantState == StateServing && sm.replHealthy && !sm.demotePrimaryStalled && !sm.lameduck && !sm.diskHealthMonitor.IsDiskStalled() && !sm.lagGuard.IsNotServing()
 }
 
 func (sm *stateManager) AppendDetails(details []*kv) []*kv {
@@ -839,6 +868,20 @@ func (sm *stateManager) AppendDetails(details []*kv) []*kv {
 			Value: "ON",
 		})
 	}
+	if err := sm.dependencyGate.Err(); err != nil {
+		details = append(details, &kv{
+			Key:   "Dependencies",
+			Class: unhealthyClass,
+			Value: err.Error(),
+		})
+	}
+	if sm.lagGuard.IsNotServing() {
+		details = append(details, &kv{
+			Key:   "Lagging",
+			Class: unhealthyClass,
+			Value: "NOT SERVING",
+		})
+	}
 	if len(sm.alsoAllow) != 0 {
 		details = append(details, &kv{
 			Key:   "Also Serving",
@@ -91,6 +91,8 @@ func TestStateManagerServePrimary(t *testing.T) {
 	verifySubcomponent(t, 11, sm.qThrottler, testStateOpen)
 	verifySubcomponent(t, 12, sm.tableGC, testStateOpen)
 	verifySubcomponent(t, 13, sm.ddle, testStateOpen)
+	verifySubcomponent(t, 14, sm.queryStats, testStateOpen)
+	verifySubcomponent(t, 15, sm.poolSizer, testStateOpen)
 
 	assert.False(t, sm.se.(*testSchemaEngine).nonPrimary)
 	assert.True(t, sm.se.(*testSchemaEngine).ensureCalled)
@@ -105,20 +107,22 @@ func TestStateManagerServeNonPrimary(t *testing.T) {
 	err := sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, "")
 	require.NoError(t, err)
 
-	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.messager, testStateClosed)
-	verifySubcomponent(t, 4, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 1, sm.queryStats, testStateClosed)
+	verifySubcomponent(t, 2, sm.ddle, testStateClosed)
+	verifySubcomponent(t, 3, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 4, sm.messager, testStateClosed)
+	verifySubcomponent(t, 5, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 5, sm.se, testStateOpen)
-	verifySubcomponent(t, 6, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 7, sm.qe, testStateOpen)
-	verifySubcomponent(t, 8, sm.txThrottler, testStateOpen)
-	verifySubcomponent(t, 9, sm.te, testStateNonPrimary)
-	verifySubcomponent(t, 10, sm.rt, testStateNonPrimary)
-	verifySubcomponent(t, 11, sm.watcher, testStateOpen)
-	verifySubcomponent(t, 12, sm.throttler, testStateOpen)
+	verifySubcomponent(t, 6, sm.se, testStateOpen)
+	verifySubcomponent(t, 7, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 8, sm.qe, testStateOpen)
+	verifySubcomponent(t, 9, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 10, sm.te, testStateNonPrimary)
+	verifySubcomponent(t, 11, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 12, sm.watcher, testStateOpen)
+	verifySubcomponent(t, 13, sm.throttler, testStateOpen)
+	verifySubcomponent(t, 15, sm.poolSizer, testStateOpen)
 
 	assert.Equal(t, topodatapb.TabletType_REPLICA, sm.target.TabletType)
 	assert.Equal(t, StateServing, sm.state)
@@ -130,21 +134,23 @@ func TestStateManagerUnservePrimary(t *testing.T) {
 	err := sm.SetServingType(topodatapb.TabletType_PRIMARY, testNow, StateNotServing, "")
 	require.NoError(t, err)
 
-	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
+	verifySubcomponent(t, 1, sm.poolSizer, testStateClosed)
+	verifySubcomponent(t, 2, sm.queryStats, testStateClosed)
+	verifySubcomponent(t, 3, sm.ddle, testStateClosed)
+	verifySubcomponent(t, 4, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 5, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 6, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 7, sm.messager, testStateClosed)
+	verifySubcomponent(t, 8, sm.te, testStateClosed)
 
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
-	verifySubcomponent(t, 8, sm.watcher, testStateClosed)
-	verifySubcomponent(t, 9, sm.se, testStateOpen)
-	verifySubcomponent(t, 10, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 11, sm.qe, testStateOpen)
-	verifySubcomponent(t, 12, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 9, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 10, sm.watcher, testStateClosed)
+	verifySubcomponent(t, 11, sm.se, testStateOpen)
+	verifySubcomponent(t, 12, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 13, sm.qe, testStateOpen)
+	verifySubcomponent(t, 14, sm.txThrottler, testStateOpen)
 
-	verifySubcomponent(t, 13, sm.rt, testStatePrimary)
+	verifySubcomponent(t, 15, sm.rt, testStatePrimary)
 
 	assert.Equal(t, topodatapb.TabletType_PRIMARY, sm.target.TabletType)
 	assert.Equal(t, StateNotServing, sm.state)
@@ -188,23 +194,24 @@ func TestStateManagerUnserveNonPrimary(t *testing.T) {
 	err := sm.SetServingType(topodatapb.TabletType_RDONLY, testNow, StateNotServing, "")
 	require.NoError(t, err)
 
-	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
+	verifySubcomponent(t, 1, sm.poolSizer, testStateClosed)
+	verifySubcomponent(t, 3, sm.ddle, testStateClosed)
+	verifySubcomponent(t, 4, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 5, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 6, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 7, sm.messager, testStateClosed)
+	verifySubcomponent(t, 8, sm.te, testStateClosed)
 
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 9, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 8, sm.se, testStateOpen)
-	verifySubcomponent(t, 9, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 10, sm.qe, testStateOpen)
-	verifySubcomponent(t, 11, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 10, sm.se, testStateOpen)
+	verifySubcomponent(t, 11, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 12, sm.qe, testStateOpen)
+	verifySubcomponent(t, 13, sm.txThrottler, testStateOpen)
 
-	verifySubcomponent(t, 12, sm.rt, testStateNonPrimary)
-	verifySubcomponent(t, 13, sm.watcher, testStateOpen)
+	verifySubcomponent(t, 14, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 15, sm.watcher, testStateOpen)
 
 	assert.Equal(t, topodatapb.TabletType_RDONLY, sm.target.TabletType)
 	assert.Equal(t, StateNotServing, sm.state)
@@ -216,20 +223,21 @@ func TestStateManagerClose(t *testing.T) {
 	err := sm.SetServingType(topodatapb.TabletType_RDONLY, testNow, StateNotConnected, "")
 	require.NoError(t, err)
 
-	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.throttler, testStateClosed)
-	verifySubcomponent(t, 4, sm.qThrottler, testStateClosed)
-	verifySubcomponent(t, 5, sm.messager, testStateClosed)
-	verifySubcomponent(t, 6, sm.te, testStateClosed)
-	verifySubcomponent(t, 7, sm.tracker, testStateClosed)
-
-	verifySubcomponent(t, 8, sm.txThrottler, testStateClosed)
-	verifySubcomponent(t, 9, sm.qe, testStateClosed)
-	verifySubcomponent(t, 10, sm.watcher, testStateClosed)
-	verifySubcomponent(t, 11, sm.vstreamer, testStateClosed)
-	verifySubcomponent(t, 12, sm.rt, testStateClosed)
-	verifySubcomponent(t, 13, sm.se, testStateClosed)
+	verifySubcomponent(t, 1, sm.poolSizer, testStateClosed)
+	verifySubcomponent(t, 3, sm.ddle, testStateClosed)
+	verifySubcomponent(t, 4, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 5, sm.throttler, testStateClosed)
+	verifySubcomponent(t, 6, sm.qThrottler, testStateClosed)
+	verifySubcomponent(t, 7, sm.messager, testStateClosed)
+	verifySubcomponent(t, 8, sm.te, testStateClosed)
+	verifySubcomponent(t, 9, sm.tracker, testStateClosed)
+
+	verifySubcomponent(t, 10, sm.txThrottler, testStateClosed)
+	verifySubcomponent(t, 11, sm.qe, testStateClosed)
+	verifySubcomponent(t, 12, sm.watcher, testStateClosed)
+	verifySubcomponent(t, 13, sm.vstreamer, testStateClosed)
+	verifySubcomponent(t, 14, sm.rt, testStateClosed)
+	verifySubcomponent(t, 15, sm.se, testStateClosed)
 
 	assert.Equal(t, topodatapb.TabletType_RDONLY, sm.target.TabletType)
 	assert.Equal(t, StateNotConnected, sm.state)
@@ -328,20 +336,20 @@ func TestStateManagerSetServingTypeNoChange(t *testing.T) {
 	err = sm.SetServingType(topodatapb.TabletType_REPLICA, testNow, StateServing, "")
 	require.NoError(t, err)
 
-	verifySubcomponent(t, 1, sm.ddle, testStateClosed)
-	verifySubcomponent(t, 2, sm.tableGC, testStateClosed)
-	verifySubcomponent(t, 3, sm.messager, testStateClosed)
-	verifySubcomponent(t, 4, sm.tracker, testStateClosed)
+	verifySubcomponent(t, 2, sm.ddle, testStateClosed)
+	verifySubcomponent(t, 3, sm.tableGC, testStateClosed)
+	verifySubcomponent(t, 4, sm.messager, testStateClosed)
+	verifySubcomponent(t, 5, sm.tracker, testStateClosed)
 	assert.True(t, sm.se.(*testSchemaEngine).nonPrimary)
 
-	verifySubcomponent(t, 5, sm.se, testStateOpen)
-	verifySubcomponent(t, 6, sm.vstreamer, testStateOpen)
-	verifySubcomponent(t, 7, sm.qe, testStateOpen)
-	verifySubcomponent(t, 8, sm.txThrottler, testStateOpen)
-	verifySubcomponent(t, 9, sm.te, testStateNonPrimary)
-	verifySubcomponent(t, 10, sm.rt, testStateNonPrimary)
-	verifySubcomponent(t, 11, sm.watcher, testStateOpen)
-	verifySubcomponent(t, 12, sm.throttler, testStateOpen)
+	verifySubcomponent(t, 6, sm.se, testStateOpen)
+	verifySubcomponent(t, 7, sm.vstreamer, testStateOpen)
+	verifySubcomponent(t, 8, sm.qe, testStateOpen)
+	verifySubcomponent(t, 9, sm.txThrottler, testStateOpen)
+	verifySubcomponent(t, 10, sm.te, testStateNonPrimary)
+	verifySubcomponent(t, 11, sm.rt, testStateNonPrimary)
+	verifySubcomponent(t, 12, sm.watcher, testStateOpen)
+	verifySubcomponent(t, 13, sm.throttler, testStateOpen)
 
 	assert.Equal(t, topodatapb.TabletType_REPLICA, sm.target.TabletType)
 	assert.Equal(t, StateServing, sm.state)
@@ -817,6 +825,8 @@ func newTestStateManager() *stateManager {
 		throttler:         &testLagThrottler{},
 		qThrottler:        &testQueryThrottler{},
 		tableGC:           &testTableGC{},
+		queryStats:        &testSubcomponent{},
+		poolSizer:         &testSubcomponent{},
 		rw:                newRequestsWaiter(),
 	}
 	sm.Init(env, &querypb.Target{})
@@ -17,6 +17,7 @@ limitations under the License.
 package tabletenv
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -78,6 +79,8 @@ var (
 	semiSyncMonitorInterval             time.Duration
 	degradedThreshold                   time.Duration
 	unhealthyThreshold                  time.Duration
+	notServingLagThreshold              time.Duration
+	servingLagThreshold                 time.Duration
 	transitionGracePeriod               time.Duration
 	enableReplicationReporter           bool
 	queryThrottlerConfigRefreshInterval time.Duration
@@ -139,6 +142,15 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 
 	fs.DurationVar(&currentConfig.SchemaReloadInterval, "queryserver-config-schema-reload-time", defaultConfig.SchemaReloadInterval, "query server schema reload time, how often vttablet reloads schemas from underlying MySQL instance. vttablet keeps table schemas in its own memory and periodically refreshes it from MySQL. This config controls the reload time.")
 	fs.DurationVar(&currentConfig.SchemaChangeReloadTimeout, "schema-change-reload-timeout", defaultConfig.SchemaChangeReloadTimeout, "query server schema change reload timeout, this is how long to wait for the signaled schema reload operation to complete before giving up")
+	fs.DurationVar(&currentConfig.QueryStatsPersistInterval, "query-stats-persist-interval", defaultConfig.QueryStatsPersistInterval, "how often the primary saves its cumulative query statistics in the sidecar database, so that they are restored when the tablet restarts. Disabled if 0")
+	fs.DurationVar(&currentConfig.AdaptivePoolSizing.Interval, "adaptive-pool-sizing-interval", defaultConfig.AdaptivePoolSizing.Interval, "how often the sizes of the query and transaction pools are adjusted to the load of MySQL, within their min and max sizes. Disabled if 0")
+	fs.IntVar(&currentConfig.AdaptivePoolSizing.MinPoolSize, "adaptive-pool-min-size", defaultConfig.AdaptivePoolSizing.MinPoolSize, "minimum size of the query pool with adaptive pool sizing")
+	fs.IntVar(&currentConfig.AdaptivePoolSizing.MaxPoolSize, "adaptive-pool-max-size", defaultConfig.AdaptivePoolSizing.MaxPoolSize, "maximum size of the query pool with adaptive pool sizing. Defaults to --queryserver-config-pool-size if 0")
+	fs.IntVar(&currentConfig.AdaptivePoolSizing.MinTxPoolSize, "adaptive-tx-pool-min-size", defaultConfig.AdaptivePoolSizing.MinTxPoolSize, "minimum size of the transaction pool with adaptive pool sizing")
+	fs.IntVar(&currentConfig.AdaptivePoolSizing.MaxTxPoolSize, "adaptive-tx-pool-max-size", defaultConfig.AdaptivePoolSizing.MaxTxPoolSize, "maximum size of the transaction pool with adaptive pool sizing. Defaults to --queryserver-config-transaction-cap if 0")
+	fs.Int64Var(&currentConfig.AdaptivePoolSizing.MaxThreadsRunning, "adaptive-pool-max-threads-running", defaultConfig.AdaptivePoolSizing.MaxThreadsRunning, "MySQL threads_running above which adaptive pool sizing shrinks the pools. Ignored if 0")
+	fs.DurationVar(&currentConfig.AdaptivePoolSizing.MaxQueryLatency, "adaptive-pool-max-query-latency", defaultConfig.AdaptivePoolSizing.MaxQueryLatency, "average time of the queries in MySQL above which adaptive pool sizing shrinks the pools. Ignored if 0")
+	fs.Float64Var(&currentConfig.AdaptivePoolSizing.MaxErrorRate, "adaptive-pool-max-error-rate", defaultConfig.AdaptivePoolSizing.MaxErrorRate, "ratio of queries failing in MySQL on timeouts, lock waits or lost connections above which adaptive pool sizing shrinks the pools. Ignored if 0")
 	fs.BoolVar(&currentConfig.SignalWhenSchemaChange, "queryserver-config-schema-change-signal", defaultConfig.SignalWhenSchemaChange, "query server schema signal, will signal connected vtgates that schema has changed whenever this is detected. VTGates will need to have -schema-change-signal enabled for this to work")
 	fs.DurationVar(&currentConfig.Olap.TxTimeout, "queryserver-config-olap-transaction-timeout", defaultConfig.Olap.TxTimeout, "query server transaction timeout (in seconds), after which a transaction in an OLAP session will be killed")
 	fs.DurationVar(&currentConfig.Oltp.QueryTimeout, "queryserver-config-query-timeout", defaultConfig.Oltp.QueryTimeout, "query server query timeout, this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
@@ -181,6 +193,7 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxQueueSize, "hot-row-protection-max-queue-size", defaultConfig.HotRowProtection.MaxQueueSize, "Maximum number of BeginExecute RPCs which will be queued for the same row (range).")
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxGlobalQueueSize, "hot-row-protection-max-global-queue-size", defaultConfig.HotRowProtection.MaxGlobalQueueSize, "Global queue limit across all row (ranges). Useful to prevent that the queue can grow unbounded.")
 	utils.SetFlagIntVar(fs, &currentConfig.HotRowProtection.MaxConcurrency, "hot-row-protection-concurrent-transactions", defaultConfig.HotRowProtection.MaxConcurrency, "Number of concurrent transactions let through to the txpool/MySQL for the same hot row. Should be > 1 to have enough 'ready' transactions in MySQL and benefit from a pipelining effect.")
+	fs.StringVar(&currentConfig.HotRowProtection.RulesFile, "hot-row-protection-rules", defaultConfig.HotRowProtection.RulesFile, "Path to a JSON file of hot row protection queueing rules. The transactions on the tables of a rule are queued when the values of the columns of the rule in the equality conditions of their WHERE clause are the same, rather than their whole WHERE clause, with the max queue size, concurrency and max wait of the rule.")
 
 	utils.SetFlagBoolVar(fs, &currentConfig.EnableTransactionLimit, "enable-transaction-limit", defaultConfig.EnableTransactionLimit, "If true, limit on number of transactions open at the same time will be enforced for all users. User trying to open a new transaction after exhausting their limit will receive an error immediately, regardless of whether there are available slots or not.")
 	utils.SetFlagBoolVar(fs, &currentConfig.EnableTransactionLimitDryRun, "enable-transaction-limit-dry-run", defaultConfig.EnableTransactionLimitDryRun, "If true, limit on number of transactions open at the same time will be tracked for all users, but not enforced.")
@@ -204,6 +217,9 @@ func registerTabletEnvFlags(fs *pflag.FlagSet) {
 	utils.SetFlagDurationVar(fs, &healthCheckInterval, "health-check-interval", defaultConfig.Healthcheck.Interval, "Interval between health checks")
 	utils.SetFlagDurationVar(fs, &degradedThreshold, "degraded-threshold", defaultConfig.Healthcheck.DegradedThreshold, "replication lag after which a replica is considered degraded")
 	utils.SetFlagDurationVar(fs, &unhealthyThreshold, "unhealthy-threshold", defaultConfig.Healthcheck.UnhealthyThreshold, "replication lag after which a replica is considered unhealthy")
+	utils.SetFlagDurationVar(fs, &notServingLagThreshold, "lag-not-serving-threshold", defaultConfig.Healthcheck.NotServingLagThreshold, "replication lag after which a replica or rdonly tablet reports itself not serving so vtgate stops sending it queries, unless fewer than --lag-not-serving-min-replicas tablets of its type would still serve in its shard and cell. Disabled if not set")
+	utils.SetFlagDurationVar(fs, &servingLagThreshold, "lag-serving-threshold", defaultConfig.Healthcheck.ServingLagThreshold, "replication lag under which a tablet which stopped serving because of --lag-not-serving-threshold serves again. Half of --lag-not-serving-threshold if not set")
+	fs.IntVar(&currentConfig.Healthcheck.MinServingReplicas, "lag-not-serving-min-replicas", defaultConfig.Healthcheck.MinServingReplicas, "minimum number of other serving tablets of its type which must remain in its shard and cell for a tablet to stop serving because of --lag-not-serving-threshold")
 	utils.SetFlagDurationVar(fs, &transitionGracePeriod, "serving-state-grace-period", 0, "how long to pause after broadcasting health to vtgate, before enforcing a new serving state")
 	fs.DurationVar(&semiSyncMonitorInterval, "semi-sync-monitor-interval", defaultConfig.SemiSyncMonitor.Interval, "How frequently the semi-sync monitor checks if the primary is blocked on semi-sync ACKs")
 
@@ -281,6 +297,8 @@ func Init() {
 	currentConfig.Healthcheck.Interval = healthCheckInterval
 	currentConfig.Healthcheck.DegradedThreshold = degradedThreshold
 	currentConfig.Healthcheck.UnhealthyThreshold = unhealthyThreshold
+	currentConfig.Healthcheck.NotServingLagThreshold = notServingLagThreshold
+	currentConfig.Healthcheck.ServingLagThreshold = servingLagThreshold
 	currentConfig.GracePeriods.Transition = transitionGracePeriod
 	currentConfig.SemiSyncMonitor.Interval = semiSyncMonitorInterval
 
@@ -378,6 +396,9 @@ type TabletConfig struct {
 	EnablePerWorkloadTableMetrics       bool          `json:"-"`
 	SkipUserMetrics                     bool          `json:"-"`
 	QueryThrottlerConfigRefreshInterval time.Duration `json:"-"`
+	QueryStatsPersistInterval           time.Duration `json:"-"`
+
+	AdaptivePoolSizing AdaptivePoolSizingConfig `json:"-"`
 }
 
 func (cfg *TabletConfig) MarshalJSON() ([]byte, error) {
@@ -622,6 +643,8 @@ type HotRowProtectionConfig struct {
 	MaxQueueSize       int    `json:"maxQueueSize,omitempty"`
 	MaxGlobalQueueSize int    `json:"maxGlobalQueueSize,omitempty"`
 	MaxConcurrency     int    `json:"maxConcurrency,omitempty"`
+	// RulesFile is the path of the JSON file of the queueing rules.
+	RulesFile string `json:"rulesFile,omitempty"`
 }
 
 // SemiSyncMonitorConfig contains the config for the semi-sync monitor.
@@ -665,6 +688,13 @@ type HealthcheckConfig struct {
 	Interval           time.Duration
 	DegradedThreshold  time.Duration
 	UnhealthyThreshold time.Duration
+
+	// NotServingLagThreshold is the replication lag after which a replica
+	// stops serving, provided MinServingReplicas other replicas still
+	// serve. It stops serving until its lag is under ServingLagThreshold.
+	NotServingLagThreshold time.Duration
+	ServingLagThreshold    time.Duration
+	MinServingReplicas     int
 }
 
 func (cfg *HealthcheckConfig) MarshalJSON() ([]byte, error) {
@@ -672,6 +702,10 @@ func (cfg *HealthcheckConfig) MarshalJSON() ([]byte, error) {
 		IntervalSeconds           string `json:"intervalSeconds,omitempty"`
 		DegradedThresholdSeconds  string `json:"degradedThresholdSeconds,omitempty"`
 		UnhealthyThresholdSeconds string `json:"unhealthyThresholdSeconds,omitempty"`
+
+		NotServingLagThresholdSeconds string `json:"notServingLagThresholdSeconds,omitempty"`
+		ServingLagThresholdSeconds    string `json:"servingLagThresholdSeconds,omitempty"`
+		MinServingReplicas            int    `json:"minServingReplicas,omitempty"`
 	}
 
 	if d := cfg.Interval; d != 0 {
@@ -686,6 +720,16 @@ func (cfg *HealthcheckConfig) MarshalJSON() ([]byte, error) {
 		tmp.UnhealthyThresholdSeconds = d.String()
 	}
 
+	if d := cfg.NotServingLagThreshold; d != 0 {
+		tmp.NotServingLagThresholdSeconds = d.String()
+	}
+
+	if d := cfg.ServingLagThreshold; d != 0 {
+		tmp.ServingLagThresholdSeconds = d.String()
+	}
+
+	tmp.MinServingReplicas = cfg.MinServingReplicas
+
 	return json.Marshal(&tmp)
 }
 
@@ -694,6 +738,10 @@ func (cfg *HealthcheckConfig) UnmarshalJSON(data []byte) (err error) {
 		Interval           string `json:"intervalSeconds,omitempty"`
 		DegradedThreshold  string `json:"degradedThresholdSeconds,omitempty"`
 		UnhealthyThreshold string `json:"unhealthyThresholdSeconds,omitempty"`
+
+		NotServingLagThreshold string `json:"notServingLagThresholdSeconds,omitempty"`
+		ServingLagThreshold    string `json:"servingLagThresholdSeconds,omitempty"`
+		MinServingReplicas     int    `json:"minServingReplicas,omitempty"`
 	}
 
 	if err = json.Unmarshal(data, &tmp); err != nil {
@@ -721,6 +769,22 @@ func (cfg *HealthcheckConfig) UnmarshalJSON(data []byte) (err error) {
 		}
 	}
 
+	if tmp.NotServingLagThreshold != "" {
+		cfg.NotServingLagThreshold, err = time.ParseDuration(tmp.NotServingLagThreshold)
+		if err != nil {
+			return err
+		}
+	}
+
+	if tmp.ServingLagThreshold != "" {
+		cfg.ServingLagThreshold, err = time.ParseDuration(tmp.ServingLagThreshold)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg.MinServingReplicas = tmp.MinServingReplicas
+
 	return nil
 }
 
@@ -833,6 +897,28 @@ func (cfg *ReplicationTrackerConfig) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// AdaptivePoolSizingConfig contains the config for the adaptive sizing of the
+// query and transaction pools: they are shrunk when MySQL is overloaded, and
+// grown when queries wait for a connection, within their min and max sizes.
+type AdaptivePoolSizingConfig struct {
+	// Interval is how often the pool sizes are adjusted. Disabled if 0.
+	Interval time.Duration
+	// MinPoolSize and MaxPoolSize bound the size of the query pool. The
+	// configured size of the pool is the max size if MaxPoolSize is 0.
+	MinPoolSize int
+	MaxPoolSize int
+	// MinTxPoolSize and MaxTxPoolSize bound the size of the transaction
+	// pool. The configured size of the pool is the max size if MaxTxPoolSize
+	// is 0.
+	MinTxPoolSize int
+	MaxTxPoolSize int
+	// MaxThreadsRunning, MaxQueryLatency and MaxErrorRate are the thresholds
+	// above which MySQL is considered overloaded. Each is ignored if 0.
+	MaxThreadsRunning int64
+	MaxQueryLatency   time.Duration
+	MaxErrorRate      float64
+}
+
 // TransactionLimitConfig captures configuration of transaction pool slots
 // limiter configuration.
 type TransactionLimitConfig struct {
@@ -919,6 +1005,39 @@ func (c *TabletConfig) Verify() error {
 	if v := c.HotRowProtection.MaxConcurrency; v <= 0 {
 		return fmt.Errorf("--hot-row-protection-concurrent-transactions must be > 0 (specified value: %v)", v)
 	}
+	if notServing, serving := c.Healthcheck.NotServingLagThreshold, c.Healthcheck.ServingLagThreshold; serving > notServing {
+		return fmt.Errorf("--lag-serving-threshold must be <= --lag-not-serving-threshold (%v > %v)", serving, notServing)
+	}
+	if v := c.Healthcheck.MinServingReplicas; v < 0 {
+		return fmt.Errorf("--lag-not-serving-min-replicas must be >= 0 (specified value: %v)", v)
+	}
+	if err := c.verifyAdaptivePoolSizingConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyAdaptivePoolSizingConfig checks AdaptivePoolSizingConfig for sanity
+func (c *TabletConfig) verifyAdaptivePoolSizingConfig() error {
+	cfg := c.AdaptivePoolSizing
+	if cfg.Interval <= 0 {
+		return nil
+	}
+	if cfg.MinPoolSize <= 0 {
+		return fmt.Errorf("--adaptive-pool-min-size must be > 0 (specified value: %v)", cfg.MinPoolSize)
+	}
+	if maxSize := cmp.Or(cfg.MaxPoolSize, c.OltpReadPool.Size); maxSize < cfg.MinPoolSize {
+		return fmt.Errorf("--adaptive-pool-max-size must be >= --adaptive-pool-min-size (%v < %v)", maxSize, cfg.MinPoolSize)
+	}
+	if cfg.MinTxPoolSize <= 0 {
+		return fmt.Errorf("--adaptive-tx-pool-min-size must be > 0 (specified value: %v)", cfg.MinTxPoolSize)
+	}
+	if maxSize := cmp.Or(cfg.MaxTxPoolSize, c.TxPool.Size); maxSize < cfg.MinTxPoolSize {
+		return fmt.Errorf("--adaptive-tx-pool-max-size must be >= --adaptive-tx-pool-min-size (%v < %v)", maxSize, cfg.MinTxPoolSize)
+	}
+	if cfg.MaxErrorRate < 0 || cfg.MaxErrorRate > 1 {
+		return fmt.Errorf("--adaptive-pool-max-error-rate must be between 0 and 1 (specified value: %v)", cfg.MaxErrorRate)
+	}
 	return nil
 }
 
@@ -1064,6 +1183,7 @@ var defaultConfig = TabletConfig{
 		Interval:           20 * time.Second,
 		DegradedThreshold:  30 * time.Second,
 		UnhealthyThreshold: 2 * time.Hour,
+		MinServingReplicas: 1,
 	},
 	SemiSyncMonitor: SemiSyncMonitorConfig{
 		Interval: 10 * time.Second,
@@ -1131,6 +1251,14 @@ var defaultConfig = TabletConfig{
 	TwoPCAbandonAge: 15 * time.Minute,
 
 	QueryThrottlerConfigRefreshInterval: time.Minute,
+
+	AdaptivePoolSizing: AdaptivePoolSizingConfig{
+		MinPoolSize:       4,
+		MinTxPoolSize:     4,
+		MaxThreadsRunning: 64,
+		MaxQueryLatency:   500 * time.Millisecond,
+		MaxErrorRate:      0.1,
+	},
 }
 
 // defaultTxThrottlerConfig returns the default TxThrottlerConfigFlag object based on
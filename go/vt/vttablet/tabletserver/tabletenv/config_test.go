@@ -139,6 +139,7 @@ gracePeriods:
 healthcheck:
   degradedThresholdSeconds: 30s
   intervalSeconds: 20s
+  minServingReplicas: 1
   unhealthyThresholdSeconds: 2h0m0s
 hotRowProtection:
   maxConcurrency: 5
@@ -35,6 +35,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/querythrottler"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/mysql/sqlerror"
 	"vitess.io/vitess/go/pools/smartconnpool"
 	"vitess.io/vitess/go/sqltypes"
@@ -75,6 +76,7 @@ import (
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/txthrottler"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/vstreamer"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
 )
 
 // logPoolFull is for throttling transaction / query pool full messages in the log.
@@ -112,22 +114,26 @@ type TabletServer struct {
 	topoServer             *topo.Server
 
 	// These are sub-components of TabletServer.
-	statelessql  *QueryList
-	statefulql   *QueryList
-	olapql       *QueryList
-	se           *schema.Engine
-	rt           *repltracker.ReplTracker
-	vstreamer    *vstreamer.Engine
-	tracker      *schema.Tracker
-	watcher      *BinlogWatcher
-	qe           *QueryEngine
-	txThrottler  txthrottler.TxThrottler
-	te           *TxEngine
-	messager     *messager.Engine
-	hs           *healthStreamer
-	lagThrottler *throttle.Throttler
-	qThrottler   *throttle.Throttler
-	tableGC      *gc.TableGC
+	statelessql *QueryList
+	statefulql  *QueryList
+	olapql      *QueryList
+	se          *schema.Engine
+	rt          *repltracker.ReplTracker
+	vstreamer   *vstreamer.Engine
+	tracker     *schema.Tracker
+	watcher     *BinlogWatcher
+	qe          *QueryEngine
+	txThrottler txthrottler.TxThrottler
+	te          *TxEngine
+	messager    *messager.Engine
+	queryStats  *queryStatsPersister
+	poolSizer   *adaptivePoolSizer
+	// resourceGroups is nil without --resource-groups-config.
+	resourceGroups *resourceGroups
+	hs             *healthStreamer
+	lagThrottler   *throttle.Throttler
+	qThrottler     *throttle.Throttler
+	tableGC        *gc.TableGC
 
 	// sm manages state transitions.
 	sm                *stateManager
@@ -193,15 +199,29 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 	tsv.queryThrottler = querythrottler.NewQueryThrottler(ctx, tsv.qThrottler, tsv, alias, srvTopoServer)
 
 	tsv.vstreamer = vstreamer.NewEngine(tsv, srvTopoServer, tsv.se, tsv.lagThrottler, alias.Cell)
+	tsv.vstreamer.SetReplicaPositionsFunc(tsv.replicaPositions)
+	tsv.vstreamer.SetWorkflowPositionsFunc(tsv.workflowPositions)
 	tsv.tracker = schema.NewTracker(tsv, tsv.vstreamer, tsv.se)
 	tsv.watcher = NewBinlogWatcher(tsv, tsv.vstreamer, tsv.config)
 	tsv.qe = NewQueryEngine(tsv, tsv.se)
 	tsv.txThrottler = txthrottler.NewTxThrottler(tsv, topoServer)
 	tsv.te = NewTxEngine(tsv, tsv.hs.sendUnresolvedTransactionSignal)
 	tsv.messager = messager.NewEngine(tsv, tsv.se, tsv.vstreamer)
+	tsv.queryStats = newQueryStatsPersister(tsv, alias, tsv.qe)
+	tsv.poolSizer = newAdaptivePoolSizer(tsv, tsv.qe, tsv.te)
+	resourceGroups, err := newResourceGroups(tsv, tsv.qe, resourceGroupsConfigFile)
+	if err != nil {
+		log.Exitf("%v", err)
+	}
+	tsv.resourceGroups = resourceGroups
+	hotRowRules, err := txserializer.LoadRules(config.HotRowProtection.RulesFile)
+	if err != nil {
+		log.Exitf("%v", err)
+	}
+	tsv.qe.txSerializer.SetRules(hotRowRules)
 
 	tsv.tableGC = gc.NewTableGC(tsv, topoServer, tsv.lagThrottler)
-	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, alias, topoServer, tsv.lagThrottler, tabletTypeFunc, tsv.onlineDDLExecutorToggleTableBuffer, tsv.tableGC.RequestChecks, tsv.te.preparedPool.IsEmptyForTable)
+	tsv.onlineDDLExecutor = onlineddl.NewExecutor(tsv, alias, topoServer, tsv.lagThrottler, tabletTypeFunc, tsv.onlineDDLExecutorToggleTableBuffer, tsv.tableGC.RequestChecks, tsv.te.preparedPool.IsEmptyForTable, tsv.qe.TableErrorCount)
 
 	tsv.sm = &stateManager{
 		statelessql:       tsv.statelessql,
@@ -221,9 +241,17 @@ func NewTabletServer(ctx context.Context, env *vtenv.Environment, name string, c
 		throttler:         tsv.lagThrottler,
 		qThrottler:        tsv.qThrottler,
 		tableGC:           tsv.tableGC,
+		queryStats:        tsv.queryStats,
+		poolSizer:         tsv.poolSizer,
 		rw:                newRequestsWaiter(),
 		diskHealthMonitor: newDiskHealthMonitor(ctx),
 	}
+	tsv.sm.lagGuard = newLagServingGuard(tsv, topoServer, alias, tsv.sm.Broadcast)
+	dependencyGate, err := newDependencyGate(servingDependencies, servingDependencyProbeTimeout)
+	if err != nil {
+		log.Exitf("Invalid --serving-dependencies: %v", err)
+	}
+	tsv.sm.dependencyGate = dependencyGate
 
 	tsv.exporter.NewGaugeFunc("TabletState", "Tablet server state", func() int64 { return int64(tsv.sm.State()) })
 	tsv.checkMysqlGaugeFunc = tsv.exporter.NewGaugeFunc("CheckMySQLRunning", "Check MySQL operation currently in progress", tsv.sm.isCheckMySQLRunning)
@@ -517,6 +545,22 @@ func (tsv *TabletServer) ClearQueryPlanCache() {
 	tsv.qe.ClearQueryPlanCache()
 }
 
+// GetQueryPlans returns the query plans cached by the tablet, after evicting,
+// unpinning and pinning the plans of the queries of the request, in that
+// order.
+func (tsv *TabletServer) GetQueryPlans(ctx context.Context, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	resp := &tabletmanagerdatapb.GetQueryPlansResponse{}
+	if req.EvictAll {
+		resp.Evicted = uint32(len(tsv.qe.cachedPlans()))
+		tsv.qe.ClearQueryPlanCache()
+	}
+	resp.Evicted += uint32(tsv.qe.EvictQueryPlans(req.Evict))
+	resp.Unpinned = uint32(tsv.qe.UnpinQueryPlans(req.Unpin))
+	resp.Pinned = uint32(tsv.qe.PinQueryPlans(req.Pin))
+	resp.Plans = tsv.qe.QueryPlans(req.Filter, int(req.Limit))
+	return resp, nil
+}
+
 // QueryService returns the QueryService part of TabletServer.
 func (tsv *TabletServer) QueryService() queryservice.QueryService {
 	return tsv
@@ -991,12 +1035,23 @@ func (tsv *TabletServer) execute(ctx context.Context, target *querypb.Target, sq
 					}
 				}
 			}
+			if options.GetResultChecksum() {
+				result = withChecksum(result)
+			}
 			return nil
 		},
 	)
 	return result, err
 }
 
+// withChecksum returns a copy of the result with the checksum of its rows.
+// The result is copied as the consolidator can share it with other queries.
+func withChecksum(result *sqltypes.Result) *sqltypes.Result {
+	checksummed := *result
+	checksummed.Checksum = sqltypes.RowsChecksum(0, result.Rows)
+	return &checksummed
+}
+
 // smallerTimeout returns the smaller of the two timeouts.
 // 0 is treated as infinity.
 func smallerTimeout(t1, t2 time.Duration) time.Duration {
@@ -1029,6 +1084,13 @@ func (tsv *TabletServer) streamExecute(ctx context.Context, target *querypb.Targ
 		// so we can directly fetch the OLAP TX timeout.
 		timeout = getTransactionTimeout(options, tsv.config, querypb.ExecuteOptions_OLAP)
 	}
+	if options.GetResultChecksum() {
+		// Each streamed result has the checksum of its own rows.
+		send := callback
+		callback = func(result *sqltypes.Result) error {
+			return send(withChecksum(result))
+		}
+	}
 
 	return tsv.execRequest(
 		ctx, timeout,
@@ -1143,14 +1205,14 @@ func (tsv *TabletServer) beginWaitForSameRangeTransactions(ctx context.Context,
 		"", "waitForSameRangeTransactions", nil,
 		target, options, false, /* allowOnShutdown */
 		func(ctx context.Context, logStats *tabletenv.LogStats) error {
-			k, table := tsv.computeTxSerializerKey(ctx, logStats, sql, bindVariables)
+			k, table, rule := tsv.computeTxSerializerKey(ctx, logStats, sql, bindVariables)
 			if k == "" {
 				// Query is not subject to tx serialization/hot row protection.
 				return nil
 			}
 
 			startTime := time.Now()
-			done, waited, waitErr := tsv.qe.txSerializer.Wait(ctx, k, table)
+			done, waited, waitErr := tsv.qe.txSerializer.WaitForRule(ctx, k, table, rule)
 			txDone = done
 			if waited {
 				tsv.stats.WaitTimings.Record("TxSerializer", startTime)
@@ -1163,16 +1225,17 @@ func (tsv *TabletServer) beginWaitForSameRangeTransactions(ctx context.Context,
 
 // computeTxSerializerKey returns a unique string ("key") used to determine
 // whether two queries would update the same row (range).
-// Additionally, it returns the table name (needed for updating stats vars).
+// Additionally, it returns the table name (needed for updating stats vars) and
+// the queueing rule which the key was computed for, if any.
 // It returns an empty string as key if the row (range) cannot be parsed from
 // the query and bind variables or the table name is empty.
-func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *tabletenv.LogStats, sql string, bindVariables map[string]*querypb.BindVariable) (string, string) {
+func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *tabletenv.LogStats, sql string, bindVariables map[string]*querypb.BindVariable) (string, string, *txserializer.Rule) {
 	// Strip trailing comments so we don't pollute the query cache.
 	sql, _ = sqlparser.SplitMarginComments(sql)
 	plan, err := tsv.qe.GetPlan(ctx, logStats, sql, false, false)
 	if err != nil {
 		logComputeRowSerializerKey.Errorf("failed to get plan for query: %v err: %v", sql, err)
-		return "", ""
+		return "", "", nil
 	}
 
 	switch plan.PlanID {
@@ -1180,24 +1243,51 @@ func (tsv *TabletServer) computeTxSerializerKey(ctx context.Context, logStats *t
 	case planbuilder.PlanUpdate, planbuilder.PlanUpdateLimit,
 		planbuilder.PlanDelete, planbuilder.PlanDeleteLimit:
 	default:
-		return "", ""
+		return "", "", nil
 	}
 
 	tableName := plan.TableName()
 	if tableName.IsEmpty() || plan.WhereClause == nil {
 		// Do not serialize any queries without table name or where clause
-		return "", ""
+		return "", "", nil
+	}
+
+	// The first queueing rule of the table whose columns all have a value in
+	// the WHERE clause keys the row by these values.
+	if rules := tsv.qe.txSerializer.Rules(tableName.String()); len(rules) > 0 {
+		where := dmlWhereClause(tsv.env.Parser(), sql)
+		for _, rule := range rules {
+			if key, ok := rule.Key(tableName.String(), where, bindVariables); ok {
+				return key, tableName.String(), rule
+			}
+		}
 	}
 
 	where, err := plan.WhereClause.GenerateQuery(bindVariables, nil)
 	if err != nil {
 		logComputeRowSerializerKey.Errorf("failed to substitute bind vars in where clause: %v query: %v bind vars: %v", err, sql, bindVariables)
-		return "", ""
+		return "", "", nil
 	}
 
 	// Example: table1 where id = 1 and sub_id = 2
 	key := fmt.Sprintf("%s%s", tableName, where)
-	return key, tableName.String()
+	return key, tableName.String(), nil
+}
+
+// dmlWhereClause returns the WHERE clause of the UPDATE or DELETE query, or
+// nil if it has none or cannot be parsed.
+func dmlWhereClause(parser *sqlparser.Parser, sql string) *sqlparser.Where {
+	stmt, err := parser.Parse(sql)
+	if err != nil {
+		return nil
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Update:
+		return stmt.Where
+	case *sqlparser.Delete:
+		return stmt.Where
+	}
+	return nil
 }
 
 // MessageStream streams messages from the requested table.
@@ -1735,6 +1825,12 @@ func (tsv *TabletServer) BroadcastHealth() {
 	tsv.sm.Broadcast()
 }
 
+// BroadcastVReplicationStateChange signals to the health stream listeners
+// that the vreplication streams of the tablet changed.
+func (tsv *TabletServer) BroadcastVReplicationStateChange() {
+	tsv.hs.sendVReplicationStateSignal()
+}
+
 // EnterLameduck causes tabletserver to enter the lameduck state. This
 // state causes health checks to fail, but the behavior of tabletserver
 // otherwise remains the same. Any subsequent calls to SetServingType will
@@ -1766,6 +1862,90 @@ func (tsv *TabletServer) TopoServer() *topo.Server {
 	return tsv.topoServer
 }
 
+// replicaPositions returns the replication positions of the shard's replicas, when this tablet is a primary.
+// It is used by the vstreamer's binlog purge coordination, which does not purge binary logs still required
+// by any replica. An error is returned if the position of any replica cannot be determined.
+func (tsv *TabletServer) replicaPositions(ctx context.Context) ([]replication.Position, error) {
+	target := tsv.sm.Target()
+	if target == nil || target.TabletType != topodatapb.TabletType_PRIMARY || tsv.topoServer == nil {
+		return nil, nil
+	}
+	tablets, err := tsv.topoServer.GetTabletMapForShard(ctx, target.Keyspace, target.Shard)
+	if err != nil {
+		return nil, err
+	}
+	tmClient := tmclient.NewTabletManagerClient()
+	defer tmClient.Close()
+
+	var positions []replication.Position
+	for _, tabletInfo := range tablets {
+		if topoproto.TabletAliasEqual(tabletInfo.Alias, tsv.alias) || !topo.IsReplicaType(tabletInfo.Type) {
+			continue
+		}
+		status, err := tmClient.ReplicationStatus(ctx, tabletInfo.Tablet)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "reading replication status of %v", topoproto.TabletAliasString(tabletInfo.Alias))
+		}
+		pos, err := replication.DecodePosition(status.Position)
+		if err != nil {
+			return nil, vterrors.Wrapf(err, "decoding replication position of %v", topoproto.TabletAliasString(tabletInfo.Alias))
+		}
+		positions = append(positions, pos)
+	}
+	return positions, nil
+}
+
+// workflowPositions returns the positions of the vreplication workflows which stream from the shard of
+// this tablet, read from the primaries of all the shards. The stopped workflows are included, as they
+// resume from their position, but not the frozen ones.
+func (tsv *TabletServer) workflowPositions(ctx context.Context) ([]replication.Position, error) {
+	target := tsv.sm.Target()
+	if target == nil || tsv.topoServer == nil {
+		return nil, nil
+	}
+	keyspaces, err := tsv.topoServer.GetKeyspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tmClient := tmclient.NewTabletManagerClient()
+	defer tmClient.Close()
+
+	var positions []replication.Position
+	for _, keyspace := range keyspaces {
+		shards, err := tsv.topoServer.FindAllShardsInKeyspace(ctx, keyspace, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, shard := range shards {
+			if !shard.HasPrimary() {
+				continue
+			}
+			primary, err := tsv.topoServer.GetTablet(ctx, shard.PrimaryAlias)
+			if err != nil {
+				return nil, err
+			}
+			res, err := tmClient.ReadVReplicationWorkflows(ctx, primary.Tablet, &tabletmanagerdatapb.ReadVReplicationWorkflowsRequest{ExcludeFrozen: true})
+			if err != nil {
+				return nil, vterrors.Wrapf(err, "reading workflows of %v", topoproto.TabletAliasString(primary.Alias))
+			}
+			for _, workflow := range res.Workflows {
+				for _, stream := range workflow.Streams {
+					// A stream without a position has not started yet.
+					if stream.Bls.GetKeyspace() != target.Keyspace || stream.Bls.GetShard() != target.Shard || stream.Pos == "" {
+						continue
+					}
+					pos, err := replication.DecodePosition(stream.Pos)
+					if err != nil {
+						return nil, vterrors.Wrapf(err, "decoding position of workflow %v on %v", workflow.Workflow, topoproto.TabletAliasString(primary.Alias))
+					}
+					positions = append(positions, pos)
+				}
+			}
+		}
+	}
+	return positions, nil
+}
+
 // CheckThrottler issues a self check
 func (tsv *TabletServer) CheckThrottler(ctx context.Context, appName string, flags *throttle.CheckFlags) *throttle.CheckResult {
 	r := tsv.lagThrottler.Check(ctx, appName, nil, flags)
@@ -56,6 +56,7 @@ import (
 	"vitess.io/vitess/go/vt/topo/memorytopo"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/txserializer"
 
 	querypb "vitess.io/vitess/go/vt/proto/query"
 	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
@@ -909,6 +910,46 @@ func TestTabletServerStreamExecute(t *testing.T) {
 	}
 }
 
+func TestTabletServerResultChecksum(t *testing.T) {
+	ctx := t.Context()
+	db, tsv := setupTabletServerTest(t, ctx, "")
+	defer tsv.StopService()
+	defer db.Close()
+
+	executeSQL := "select * from test_table limit 1000"
+	executeSQLResult := &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Type: sqltypes.VarBinary},
+		},
+		Rows: [][]sqltypes.Value{
+			{sqltypes.NewVarBinary("row01")},
+			{sqltypes.NULL},
+		},
+	}
+	db.AddQuery(executeSQL, executeSQLResult)
+	want := sqltypes.RowsChecksum(0, executeSQLResult.Rows)
+
+	target := querypb.Target{TabletType: topodatapb.TabletType_PRIMARY}
+	options := &querypb.ExecuteOptions{ResultChecksum: true}
+	result, err := tsv.Execute(ctx, nil, &target, executeSQL, nil, 0, 0, options)
+	require.NoError(t, err)
+	assert.Equal(t, want, result.Checksum)
+
+	var checksum uint32
+	err = tsv.StreamExecute(ctx, nil, &target, executeSQL, nil, 0, 0, options, func(result *sqltypes.Result) error {
+		assert.Equal(t, sqltypes.RowsChecksum(0, result.Rows), result.Checksum)
+		checksum = sqltypes.RowsChecksum(checksum, result.Rows)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, want, checksum)
+
+	// Without the option, the results have no checksum.
+	result, err = tsv.Execute(ctx, nil, &target, executeSQL, nil, 0, 0, nil)
+	require.NoError(t, err)
+	assert.Zero(t, result.Checksum)
+}
+
 func TestTabletServerStreamExecuteComments(t *testing.T) {
 	ctx := t.Context()
 	db, tsv := setupTabletServerTest(t, ctx, "")
@@ -1461,6 +1502,42 @@ func TestSerializeTransactionsSameRow_RequestCanceled(t *testing.T) {
 	}
 }
 
+func TestComputeTxSerializerKeyWithRules(t *testing.T) {
+	ctx := t.Context()
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.Mode = tabletenv.Enable
+	db, tsv := setupTabletServerTestCustom(t, ctx, cfg, "", vtenv.NewTestEnv())
+	defer tsv.StopService()
+	defer db.Close()
+
+	rule := &txserializer.Rule{Name: "by_name", Table: "test_*", Columns: []string{"name"}}
+	tsv.qe.txSerializer.SetRules([]*txserializer.Rule{rule})
+	logStats := tabletenv.NewLogStats(ctx, "TestComputeTxSerializerKeyWithRules", streamlog.NewQueryLogConfigForTest())
+	bindVariables := map[string]*querypb.BindVariable{
+		"pk":   sqltypes.Int64BindVariable(1),
+		"name": sqltypes.Int64BindVariable(2),
+	}
+
+	// The transactions on the same name are queued together, whatever their
+	// other conditions.
+	for _, sql := range []string{
+		"update test_table set name_string = 'tx1' where pk = :pk and `name` = :name",
+		"update test_table set name_string = 'tx2' where `name` = 2 and name_string = 'tx1'",
+		"delete from test_table where :name = `name`",
+	} {
+		key, table, gotRule := tsv.computeTxSerializerKey(ctx, logStats, sql, bindVariables)
+		assert.Equal(t, "test_table where `name` = 2", key, sql)
+		assert.Equal(t, "test_table", table)
+		assert.Same(t, rule, gotRule)
+	}
+
+	// Without an equality condition on the name, the whole WHERE clause is the
+	// key.
+	key, _, gotRule := tsv.computeTxSerializerKey(ctx, logStats, "update test_table set name_string = 'tx1' where pk = :pk", bindVariables)
+	assert.Equal(t, "test_table where pk = 1", key)
+	assert.Nil(t, gotRule)
+}
+
 func TestMessageStream(t *testing.T) {
 	ctx := t.Context()
 	_, tsv, _, closer := newTestTxExecutor(t, ctx)
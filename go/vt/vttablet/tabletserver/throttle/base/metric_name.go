@@ -70,6 +70,9 @@ const (
 	HistoryListLengthMetricName      MetricName = "history_list_length"
 	MysqldLoadAvgMetricName          MetricName = "mysqld-loadavg"
 	MysqldDatadirUsedRatioMetricName MetricName = "mysqld-datadir-used-ratio"
+	MysqldCPUUtilMetricName          MetricName = "mysqld-cpu-util"
+	MysqldDatadirIOUtilMetricName    MetricName = "mysqld-datadir-io-util"
+	PrometheusMetricName             MetricName = "prometheus"
 )
 
 func (metric MetricName) DefaultScope() Scope {
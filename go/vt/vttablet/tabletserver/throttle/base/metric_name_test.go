@@ -244,6 +244,9 @@ func TestKnownMetricNames(t *testing.T) {
 	assert.Contains(t, KnownMetricNames, HistoryListLengthMetricName)
 	assert.Contains(t, KnownMetricNames, MysqldLoadAvgMetricName)
 	assert.Contains(t, KnownMetricNames, MysqldDatadirUsedRatioMetricName)
+	assert.Contains(t, KnownMetricNames, MysqldCPUUtilMetricName)
+	assert.Contains(t, KnownMetricNames, MysqldDatadirIOUtilMetricName)
+	assert.Contains(t, KnownMetricNames, PrometheusMetricName)
 }
 
 func TestKnownMetricNamesPascalCase(t *testing.T) {
@@ -256,6 +259,9 @@ func TestKnownMetricNamesPascalCase(t *testing.T) {
 		DefaultMetricName:                "Default",
 		MysqldLoadAvgMetricName:          "MysqldLoadavg",
 		MysqldDatadirUsedRatioMetricName: "MysqldDatadirUsedRatio",
+		MysqldCPUUtilMetricName:          "MysqldCpuUtil",
+		MysqldDatadirIOUtilMetricName:    "MysqldDatadirIoUtil",
+		PrometheusMetricName:             "Prometheus",
 	}
 	for _, metricName := range KnownMetricNames {
 		t.Run(metricName.String(), func(t *testing.T) {
@@ -105,6 +105,8 @@ func getMysqlHostMetric(ctx context.Context, params *SelfMetricReadParams, mysql
 var (
 	_ SelfMetric = registerSelfMetric(&MysqldLoadAvgSelfMetric{})
 	_ SelfMetric = registerSelfMetric(&MysqldDatadirUsedRatioSelfMetric{})
+	_ SelfMetric = registerSelfMetric(&MysqldCPUUtilSelfMetric{})
+	_ SelfMetric = registerSelfMetric(&MysqldDatadirIOUtilSelfMetric{})
 )
 
 // MysqldLoadAvgSelfMetric stands for the load average per cpu, on the MySQL host.
@@ -153,3 +155,51 @@ func (m *MysqldDatadirUsedRatioSelfMetric) RequiresConn() bool {
 func (m *MysqldDatadirUsedRatioSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
 	return getMysqlHostMetric(ctx, params, "datadir-used-ratio")
 }
+
+// MysqldCPUUtilSelfMetric stands for the CPU utilization of the MySQL host.
+// Range: 0.0 (idle) - 1.0 (fully busy)
+type MysqldCPUUtilSelfMetric struct{}
+
+func (m *MysqldCPUUtilSelfMetric) Name() MetricName {
+	return MysqldCPUUtilMetricName
+}
+
+func (m *MysqldCPUUtilSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *MysqldCPUUtilSelfMetric) DefaultThreshold() float64 {
+	return 0.9
+}
+
+func (m *MysqldCPUUtilSelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *MysqldCPUUtilSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	return getMysqlHostMetric(ctx, params, "cpu-util")
+}
+
+// MysqldDatadirIOUtilSelfMetric stands for the ratio of time the device where MySQL's datadir is located
+// spends doing I/Os. Range: 0.0 (idle) - 1.0 (saturated)
+type MysqldDatadirIOUtilSelfMetric struct{}
+
+func (m *MysqldDatadirIOUtilSelfMetric) Name() MetricName {
+	return MysqldDatadirIOUtilMetricName
+}
+
+func (m *MysqldDatadirIOUtilSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *MysqldDatadirIOUtilSelfMetric) DefaultThreshold() float64 {
+	return 0.9
+}
+
+func (m *MysqldDatadirIOUtilSelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *MysqldDatadirIOUtilSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	return getMysqlHostMetric(ctx, params, "datadir-io-util")
+}
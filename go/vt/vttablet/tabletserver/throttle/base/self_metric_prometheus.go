@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// PrometheusURL is the base URL of the Prometheus server evaluating PrometheusQuery,
+	// e.g. "http://prometheus:9090".
+	PrometheusURL string
+	// PrometheusQuery is a PromQL query returning a single value, such as the disk
+	// utilization of the host, which the "prometheus" metric reports.
+	PrometheusQuery string
+
+	prometheusQueryTimeout        = 5 * time.Second
+	cachedPrometheusMetric        atomic.Pointer[ThrottleMetric]
+	prometheusMetricCacheDuration = 1 * time.Second
+)
+
+var _ SelfMetric = registerSelfMetric(&PrometheusSelfMetric{})
+
+// PrometheusSelfMetric is the value returned by a custom Prometheus query, which makes it possible to
+// throttle on any signal collected by Prometheus.
+type PrometheusSelfMetric struct{}
+
+func (m *PrometheusSelfMetric) Name() MetricName {
+	return PrometheusMetricName
+}
+
+func (m *PrometheusSelfMetric) DefaultScope() Scope {
+	return SelfScope
+}
+
+func (m *PrometheusSelfMetric) DefaultThreshold() float64 {
+	return 0
+}
+
+func (m *PrometheusSelfMetric) RequiresConn() bool {
+	return false
+}
+
+func (m *PrometheusSelfMetric) Read(ctx context.Context, params *SelfMetricReadParams) *ThrottleMetric {
+	metric := cachedPrometheusMetric.Load()
+	if metric != nil {
+		return metric
+	}
+	metric = &ThrottleMetric{
+		Scope: SelfScope,
+	}
+	if PrometheusURL == "" || PrometheusQuery == "" {
+		return metric
+	}
+	metric.Value, metric.Err = readPrometheusQuery(ctx, PrometheusURL, PrometheusQuery)
+
+	cachedPrometheusMetric.Store(metric)
+	time.AfterFunc(prometheusMetricCacheDuration, func() {
+		cachedPrometheusMetric.Store(nil)
+	})
+
+	return metric
+}
+
+// prometheusQueryResponse is the response of the Prometheus instant query API.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// readPrometheusQuery evaluates the query on the Prometheus server, and returns its value. The query must
+// evaluate to a scalar, or to a vector of a single sample.
+func readPrometheusQuery(ctx context.Context, serverURL string, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, prometheusQueryTimeout)
+	defer cancel()
+
+	queryURL := strings.TrimSuffix(serverURL, "/") + "/api/v1/query?query=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var queryResp prometheusQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&queryResp); err != nil {
+		return 0, fmt.Errorf("invalid response from Prometheus (HTTP status %d): %w", resp.StatusCode, err)
+	}
+	if queryResp.Status != "success" {
+		return 0, fmt.Errorf("Prometheus query %s failed: %s", query, queryResp.Error)
+	}
+
+	// A sample is a [timestamp, "value"] pair.
+	var sample []any
+	switch queryResp.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(queryResp.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(queryResp.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("expecting a single sample for Prometheus query %s, got %d", query, len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q for Prometheus query %s", queryResp.Data.ResultType, query)
+	}
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("unexpected sample %v for Prometheus query %s", sample, query)
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample %v for Prometheus query %s", sample, query)
+	}
+	return strconv.ParseFloat(value, 64)
+}
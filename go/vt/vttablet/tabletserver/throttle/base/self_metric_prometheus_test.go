@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPrometheusQuery(t *testing.T) {
+	responses := map[string]string{
+		"vector":       `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"instance":"db1"},"value":[1700000000.1,"0.75"]}]}}`,
+		"scalar":       `{"status":"success","data":{"resultType":"scalar","result":[1700000000.1,"3"]}}`,
+		"empty vector": `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"matrix":       `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		"error":        `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+		"invalid":      `not json`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		w.Write([]byte(responses[r.URL.Query().Get("query")]))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	value, err := readPrometheusQuery(ctx, server.URL, "vector")
+	require.NoError(t, err)
+	assert.Equal(t, 0.75, value)
+
+	value, err = readPrometheusQuery(ctx, server.URL+"/", "scalar")
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, value)
+
+	_, err = readPrometheusQuery(ctx, server.URL, "empty vector")
+	assert.ErrorContains(t, err, "expecting a single sample")
+	_, err = readPrometheusQuery(ctx, server.URL, "matrix")
+	assert.ErrorContains(t, err, "unsupported result type")
+	_, err = readPrometheusQuery(ctx, server.URL, "error")
+	assert.ErrorContains(t, err, "parse error")
+	_, err = readPrometheusQuery(ctx, server.URL, "invalid")
+	assert.ErrorContains(t, err, "invalid response from Prometheus")
+}
+
+func TestPrometheusSelfMetric(t *testing.T) {
+	metric := RegisteredSelfMetrics[PrometheusMetricName]
+	require.NotNil(t, metric)
+
+	// Not configured: the metric is always 0.
+	result := metric.Read(context.Background(), &SelfMetricReadParams{})
+	require.NoError(t, result.Err)
+	assert.Equal(t, 0.0, result.Value)
+}
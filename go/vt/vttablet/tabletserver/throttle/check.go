@@ -65,9 +65,12 @@ var (
 
 // CheckFlags provide hints for a check
 type CheckFlags struct {
-	Scope                 base.Scope
-	ReadCheck             bool
-	OverrideThreshold     float64
+	Scope             base.Scope
+	ReadCheck         bool
+	OverrideThreshold float64
+	// MaxLagThreshold caps the threshold of the lag metric, so that an app can be throttled on a lower
+	// replication lag than the throttler would. The other metrics keep their thresholds. Zero means no cap.
+	MaxLagThreshold       float64
 	OKIfNotExists         bool
 	SkipRequestHeartbeats bool
 }
@@ -88,7 +91,7 @@ func NewThrottlerCheck(throttler *Throttler) *ThrottlerCheck {
 }
 
 // checkAppMetricResult allows an app to check on a metric
-func (check *ThrottlerCheck) checkAppMetricResult(ctx context.Context, appName string, metricResultFunc base.MetricResultFunc, flags *CheckFlags) (checkResult *CheckResult) {
+func (check *ThrottlerCheck) checkAppMetricResult(ctx context.Context, appName string, metricName base.MetricName, metricResultFunc base.MetricResultFunc, flags *CheckFlags) (checkResult *CheckResult) {
 	// Handle deprioritized app logic
 	denyApp := false
 	//
@@ -96,6 +99,9 @@ func (check *ThrottlerCheck) checkAppMetricResult(ctx context.Context, appName s
 	if flags.OverrideThreshold > 0 {
 		threshold = flags.OverrideThreshold
 	}
+	if metricName == base.LagMetricName && flags.MaxLagThreshold > 0 && flags.MaxLagThreshold < threshold {
+		threshold = flags.MaxLagThreshold
+	}
 	value, err := metricResult.Get()
 	if appName == "" {
 		return NewCheckResult(tabletmanagerdatapb.CheckThrottlerResponseCode_APP_DENIED, value, threshold, "", errors.New("no app indicated"))
@@ -166,7 +172,7 @@ func (check *ThrottlerCheck) Check(ctx context.Context, appName string, scope ba
 			return check.throttler.getScopedMetric(metricScope, metricName)
 		}
 
-		metricCheckResult := check.checkAppMetricResult(ctx, appName, metricResultFunc, flags)
+		metricCheckResult := check.checkAppMetricResult(ctx, appName, metricName, metricResultFunc, flags)
 		if !throttlerapp.VitessName.Equals(appName) {
 			go func(metricCheckResult *CheckResult) {
 				if metricScope == base.UndefinedScope {
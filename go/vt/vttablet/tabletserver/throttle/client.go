@@ -89,6 +89,17 @@ func (c *Client) clearSuccessfulResultsCache() {
 // be called very frequently.
 // The function is not thread safe.
 func (c *Client) ThrottleCheckOK(ctx context.Context, overrideAppName throttlerapp.Name) (checkResult *CheckResult, throttleCheckOK bool) {
+	return c.throttleCheckOK(ctx, overrideAppName, 0)
+}
+
+// ThrottleCheckOKWithMaxLag is like ThrottleCheckOK, but the lag metric is checked against maxLag,
+// in seconds, when it is lower than its threshold in the throttler. A zero maxLag is ignored.
+// The function is not thread safe.
+func (c *Client) ThrottleCheckOKWithMaxLag(ctx context.Context, overrideAppName throttlerapp.Name, maxLag float64) (checkResult *CheckResult, throttleCheckOK bool) {
+	return c.throttleCheckOK(ctx, overrideAppName, maxLag)
+}
+
+func (c *Client) throttleCheckOK(ctx context.Context, overrideAppName throttlerapp.Name, maxLag float64) (checkResult *CheckResult, throttleCheckOK bool) {
 	if c == nil {
 		// no client
 		return emptyCheckResult, true
@@ -109,7 +120,9 @@ func (c *Client) ThrottleCheckOK(ctx context.Context, overrideAppName throttlera
 		return emptyCheckResult, true
 	}
 	// It's time to run a throttler check
-	checkResult = c.throttler.Check(ctx, checkApp.String(), nil, &c.flags)
+	flags := c.flags
+	flags.MaxLagThreshold = maxLag
+	checkResult = c.throttler.Check(ctx, checkApp.String(), nil, &flags)
 	if !checkResult.IsOK() {
 		return checkResult, false
 	}
@@ -127,7 +140,15 @@ func (c *Client) ThrottleCheckOK(ctx context.Context, overrideAppName throttlera
 // Non-empty appName overrides the default appName.
 // The function is not thread safe.
 func (c *Client) ThrottleCheckOKOrWaitAppName(ctx context.Context, appName throttlerapp.Name) (checkResult *CheckResult, throttleCheckOK bool) {
-	checkResult, throttleCheckOK = c.ThrottleCheckOK(ctx, appName)
+	return c.ThrottleCheckOKOrWaitAppNameWithMaxLag(ctx, appName, 0)
+}
+
+// ThrottleCheckOKOrWaitAppNameWithMaxLag is like ThrottleCheckOKOrWaitAppName, but the lag metric
+// is checked against maxLag, in seconds, when it is lower than its threshold in the throttler. A zero
+// maxLag is ignored.
+// The function is not thread safe.
+func (c *Client) ThrottleCheckOKOrWaitAppNameWithMaxLag(ctx context.Context, appName throttlerapp.Name, maxLag float64) (checkResult *CheckResult, throttleCheckOK bool) {
+	checkResult, throttleCheckOK = c.throttleCheckOK(ctx, appName, maxLag)
 	if throttleCheckOK {
 		return checkResult, true
 	}
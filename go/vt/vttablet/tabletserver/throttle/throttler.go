@@ -115,6 +115,8 @@ func init() {
 
 func registerThrottlerFlags(fs *pflag.FlagSet) {
 	utils.SetFlagStringVar(fs, &throttleTabletTypes, "throttle-tablet-types", throttleTabletTypes, "Comma separated VTTablet types to be considered by the throttler. default: 'replica'. example: 'replica,rdonly'. 'replica' always implicitly included")
+	fs.StringVar(&base.PrometheusURL, "throttle-prometheus-url", base.PrometheusURL, "Base URL of the Prometheus server evaluating --throttle-prometheus-query for the throttler's 'prometheus' metric, e.g. 'http://prometheus:9090'.")
+	fs.StringVar(&base.PrometheusQuery, "throttle-prometheus-query", base.PrometheusQuery, "PromQL query returning a single value, e.g. the disk or CPU utilization of this tablet's host, reported by the throttler's 'prometheus' metric. Empty disables the metric.")
 }
 
 var ErrThrottlerNotOpen = errors.New("throttler not open")
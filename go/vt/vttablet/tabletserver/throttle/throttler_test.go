@@ -91,6 +91,24 @@ var (
 			Value: 0.85,
 			Err:   nil,
 		},
+		base.MysqldCPUUtilMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0.42,
+			Err:   nil,
+		},
+		base.MysqldDatadirIOUtilMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0.35,
+			Err:   nil,
+		},
+		base.PrometheusMetricName: &base.ThrottleMetric{
+			Scope: base.SelfScope,
+			Alias: "",
+			Value: 0,
+			Err:   nil,
+		},
 	}
 	replicaMetrics = map[string]*MetricResult{
 		base.LagMetricName.String(): {
@@ -121,6 +139,18 @@ var (
 			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
 			Value:        0.87,
 		},
+		base.MysqldCPUUtilMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0.52,
+		},
+		base.MysqldDatadirIOUtilMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0.45,
+		},
+		base.PrometheusMetricName.String(): {
+			ResponseCode: tabletmanagerdatapb.CheckThrottlerResponseCode_OK,
+			Value:        0,
+		},
 	}
 	nonPrimaryTabletType atomic.Int32
 )
@@ -1856,6 +1886,27 @@ func TestChecks(t *testing.T) {
 				assert.Equal(t, testAppName.String(), checkResult.AppName)
 				assert.Len(t, checkResult.Metrics, 1)
 			})
+			t.Run("max lag threshold", func(t *testing.T) {
+				checkResult := throttler.Check(ctx, testAppName.String(), nil, &CheckFlags{Scope: base.SelfScope, MaxLagThreshold: 0.2})
+				require.NotNil(t, checkResult)
+				assert.EqualValues(t, 0.3, checkResult.Value) // self lag value
+				assert.EqualValues(t, 0.2, checkResult.Threshold)
+				assert.ErrorIs(t, checkResult.Error, base.ErrThresholdExceeded)
+
+				// A max threshold above the one of the throttler does not loosen the check.
+				checkResult = throttler.Check(ctx, testAppName.String(), nil, &CheckFlags{Scope: base.SelfScope, MaxLagThreshold: 100})
+				require.NotNil(t, checkResult)
+				assert.EqualValues(t, tabletmanagerdatapb.CheckThrottlerResponseCode_OK, checkResult.ResponseCode)
+				assert.Less(t, checkResult.Threshold, float64(100))
+
+				// The other metrics keep their thresholds.
+				metricNames := base.MetricNames{base.LagMetricName, base.ThreadsRunningMetricName}
+				checkResult = throttler.Check(ctx, testAppName.String(), metricNames, &CheckFlags{Scope: base.SelfScope, MaxLagThreshold: 0.2})
+				require.NotNil(t, checkResult)
+				assert.EqualValues(t, 0.2, checkResult.Metrics[base.LagMetricName.String()].Threshold)
+				assert.Greater(t, checkResult.Metrics[base.ThreadsRunningMetricName.String()].Threshold, float64(0.2))
+				assert.EqualValues(t, tabletmanagerdatapb.CheckThrottlerResponseCode_OK, checkResult.Metrics[base.ThreadsRunningMetricName.String()].ResponseCode)
+			})
 			t.Run("explicit names", func(t *testing.T) {
 				checkResult := throttler.Check(ctx, testAppName.String(), base.KnownMetricNames, flags)
 				require.NotNil(t, checkResult)
@@ -2249,7 +2300,10 @@ func TestReplica(t *testing.T) {
 					case base.ThreadsRunningMetricName,
 						base.HistoryListLengthMetricName,
 						base.MysqldLoadAvgMetricName,
-						base.MysqldDatadirUsedRatioMetricName:
+						base.MysqldDatadirUsedRatioMetricName,
+						base.MysqldCPUUtilMetricName,
+						base.MysqldDatadirIOUtilMetricName,
+						base.PrometheusMetricName:
 						assert.NoError(t, metricResult.Error, "metricName=%v, value=%v, threshold=%v", metricName, metricResult.Value, metricResult.Threshold)
 					default:
 						assert.Fail(t, "unexpected metric", "name=%v", metricName)
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txserializer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// Rule is a queueing rule of the --hot-row-protection-rules file. By
+// default, the transactions are queued when their first UPDATE or DELETE has
+// the same WHERE clause as another one in flight. The transactions on a table
+// of a rule are instead queued when the values of the columns of the rule in
+// the equality conditions of their WHERE clause are the same, e.g. the sku of
+// an inventory row, whatever the other conditions.
+type Rule struct {
+	Name string `json:"name"`
+	// Table is the name of the tables of the rule, or a pattern matching them
+	// as in path.Match, e.g. inventory_*.
+	Table string `json:"table"`
+	// Columns identify the hot row. A transaction is only queued by the rule
+	// if its WHERE clause has an equality condition on each of them.
+	Columns []string `json:"columns"`
+
+	// MaxQueueSize is how many transactions may be queued for the same row,
+	// 0 for the --hot-row-protection-max-queue-size.
+	MaxQueueSize int `json:"max_queue_size,omitempty"`
+	// MaxConcurrency is how many transactions for the same row are let
+	// through at once, 0 for the --hot-row-protection-concurrent-transactions.
+	MaxConcurrency int `json:"max_concurrency,omitempty"`
+	// MaxWait is how long a transaction waits for its turn, e.g. 500ms, before
+	// it is rejected. If empty, it waits until the query timeout.
+	MaxWait string `json:"max_wait,omitempty"`
+
+	maxWait time.Duration
+}
+
+// rulesConfig is the contents of the --hot-row-protection-rules file.
+type rulesConfig struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// LoadRules returns the rules of the file, or none if path is empty.
+func LoadRules(path string) ([]*Rule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &rulesConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid --hot-row-protection-rules %s: %v", path, err)
+	}
+	if err := validateRules(config.Rules); err != nil {
+		return nil, fmt.Errorf("invalid --hot-row-protection-rules %s: %v", path, err)
+	}
+	return config.Rules, nil
+}
+
+func validateRules(rules []*Rule) error {
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		switch {
+		case rule.Name == "":
+			return fmt.Errorf("rule of table %q has no name", rule.Table)
+		case names[rule.Name]:
+			return fmt.Errorf("duplicate rule %s", rule.Name)
+		case rule.Table == "":
+			return fmt.Errorf("rule %s has no table", rule.Name)
+		case len(rule.Columns) == 0:
+			return fmt.Errorf("rule %s has no columns", rule.Name)
+		case rule.MaxQueueSize < 0 || rule.MaxConcurrency < 0:
+			return fmt.Errorf("rule %s has a negative max_queue_size or max_concurrency", rule.Name)
+		}
+		if _, err := path.Match(rule.Table, ""); err != nil {
+			return fmt.Errorf("rule %s has an invalid table pattern %q: %v", rule.Name, rule.Table, err)
+		}
+		if rule.MaxWait != "" {
+			maxWait, err := time.ParseDuration(rule.MaxWait)
+			if err != nil || maxWait <= 0 {
+				return fmt.Errorf("rule %s has an invalid max_wait %q", rule.Name, rule.MaxWait)
+			}
+			rule.maxWait = maxWait
+		}
+		names[rule.Name] = true
+	}
+	return nil
+}
+
+// matches returns whether the rule applies to the table.
+func (r *Rule) matches(table string) bool {
+	matched, _ := path.Match(r.Table, table)
+	return matched
+}
+
+// Key returns the key of the row of the table which the WHERE clause
+// updates, made of the values of the columns of the rule in its equality
+// conditions. It returns false if the WHERE clause has no equality condition
+// on one of the columns, or if the value of a bind variable is missing.
+func (r *Rule) Key(table string, where *sqlparser.Where, bindVariables map[string]*querypb.BindVariable) (string, bool) {
+	if where == nil {
+		return "", false
+	}
+	conditions := sqlparser.SplitAndExpression(nil, where.Expr)
+	var exprs []sqlparser.Expr
+	for _, column := range r.Columns {
+		cond := equalityCondition(conditions, column)
+		if cond == nil {
+			return "", false
+		}
+		exprs = append(exprs, cond)
+	}
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("%s where %v", table, sqlparser.AndExpressions(exprs...))
+	key, err := buf.ParsedQuery().GenerateQuery(bindVariables, nil)
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// equalityCondition returns the condition of the column being equal to a
+// value or a bind variable, or nil if there is none.
+func equalityCondition(conditions []sqlparser.Expr, column string) sqlparser.Expr {
+	for _, cond := range conditions {
+		cmp, ok := cond.(*sqlparser.ComparisonExpr)
+		if !ok || cmp.Operator != sqlparser.EqualOp {
+			continue
+		}
+		col, value := cmp.Left, cmp.Right
+		if _, ok := col.(*sqlparser.ColName); !ok {
+			col, value = value, col
+		}
+		colName, ok := col.(*sqlparser.ColName)
+		if !ok || !colName.Name.EqualString(column) {
+			continue
+		}
+		switch value.(type) {
+		case *sqlparser.Literal, *sqlparser.Argument:
+			return &sqlparser.ComparisonExpr{Operator: sqlparser.EqualOp, Left: sqlparser.NewColName(colName.Name.String()), Right: value}
+		}
+	}
+	return nil
+}
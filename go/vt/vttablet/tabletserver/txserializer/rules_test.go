@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package txserializer
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtenv"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tabletserver/tabletenv"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		err   string
+	}{
+		{name: "valid", rules: `{"rules": [{"name": "sku", "table": "inventory_*", "columns": ["sku"], "max_queue_size": 50, "max_concurrency": 1, "max_wait": "500ms"}]}`},
+		{name: "no name", rules: `{"rules": [{"table": "inventory", "columns": ["sku"]}]}`, err: `rule of table "inventory" has no name`},
+		{name: "duplicate", rules: `{"rules": [{"name": "a", "table": "t1", "columns": ["c"]}, {"name": "a", "table": "t2", "columns": ["c"]}]}`, err: "duplicate rule a"},
+		{name: "no table", rules: `{"rules": [{"name": "a", "columns": ["c"]}]}`, err: "rule a has no table"},
+		{name: "no columns", rules: `{"rules": [{"name": "a", "table": "t1"}]}`, err: "rule a has no columns"},
+		{name: "negative", rules: `{"rules": [{"name": "a", "table": "t1", "columns": ["c"], "max_queue_size": -1}]}`, err: "rule a has a negative max_queue_size or max_concurrency"},
+		{name: "pattern", rules: `{"rules": [{"name": "a", "table": "t[", "columns": ["c"]}]}`, err: `rule a has an invalid table pattern "t[": syntax error in pattern`},
+		{name: "max wait", rules: `{"rules": [{"name": "a", "table": "t1", "columns": ["c"], "max_wait": "1"}]}`, err: `rule a has an invalid max_wait "1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rulesPath := path.Join(t.TempDir(), "rules.json")
+			require.NoError(t, os.WriteFile(rulesPath, []byte(tt.rules), 0o644))
+			rules, err := LoadRules(rulesPath)
+			if tt.err == "" {
+				require.NoError(t, err)
+				require.Len(t, rules, 1)
+				assert.Equal(t, 500*time.Millisecond, rules[0].maxWait)
+				return
+			}
+			assert.EqualError(t, err, "invalid --hot-row-protection-rules "+rulesPath+": "+tt.err)
+		})
+	}
+
+	rules, err := LoadRules("")
+	assert.NoError(t, err)
+	assert.Nil(t, rules)
+}
+
+func TestRuleKey(t *testing.T) {
+	rule := &Rule{Name: "sku", Table: "inventory", Columns: []string{"sku", "warehouse"}}
+	bindVariables := map[string]*querypb.BindVariable{
+		"sku": sqltypes.StringBindVariable("abc"),
+	}
+	tests := []struct {
+		where string
+		key   string
+	}{
+		{where: "sku = :sku and warehouse = 1", key: "inventory where sku = 'abc' and warehouse = 1"},
+		{where: "warehouse = 1 and stock > 0 and 'abc' = sku", key: "inventory where sku = 'abc' and warehouse = 1"},
+		{where: "inventory.sku = 'abc' and inventory.warehouse = 1", key: "inventory where sku = 'abc' and warehouse = 1"},
+		// An equality condition is needed on each column.
+		{where: "sku = :sku"},
+		{where: "sku = :sku or warehouse = 1"},
+		{where: "sku in ('abc') and warehouse = 1"},
+		{where: "sku = upper(:sku) and warehouse = 1"},
+		{where: "sku = :missing and warehouse = 1"},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tt := range tests {
+		t.Run(tt.where, func(t *testing.T) {
+			stmt, err := parser.Parse("update inventory set stock = stock - 1 where " + tt.where)
+			require.NoError(t, err)
+			key, ok := rule.Key("inventory", stmt.(*sqlparser.Update).Where, bindVariables)
+			assert.Equal(t, tt.key != "", ok)
+			assert.Equal(t, tt.key, key)
+		})
+	}
+}
+
+func TestTxSerializerRules(t *testing.T) {
+	cfg := tabletenv.NewDefaultConfig()
+	cfg.HotRowProtection.MaxQueueSize = 10
+	cfg.HotRowProtection.MaxGlobalQueueSize = 10
+	cfg.HotRowProtection.MaxConcurrency = 5
+	txs := New(tabletenv.NewEnv(vtenv.NewTestEnv(), cfg, "TxSerializerTest"))
+	rules := []*Rule{
+		{Name: "sku", Table: "inventory_*", Columns: []string{"sku"}, MaxQueueSize: 2, MaxConcurrency: 1, maxWait: 10 * time.Millisecond},
+		{Name: "orders", Table: "orders", Columns: []string{"id"}},
+	}
+	txs.SetRules(rules)
+	assert.Equal(t, rules[:1], txs.Rules("inventory_eu"))
+	assert.Empty(t, txs.Rules("inventory"))
+	waits := txs.ruleWaits.Counts()["sku"]
+	timeouts := txs.ruleWaitTimeouts.Counts()["sku"]
+	queueExceeded := txs.ruleQueueExceeded.Counts()["sku"]
+
+	// A single transaction is let through for the same sku, the next one
+	// waits for at most the max wait of the rule.
+	ctx := context.Background()
+	key := "inventory_eu where sku = 'abc'"
+	done1, waited, err := txs.WaitForRule(ctx, key, "inventory_eu", rules[0])
+	require.NoError(t, err)
+	assert.False(t, waited)
+	assert.EqualValues(t, 1, txs.ruleQueued.Counts()["sku"])
+	_, waited, err = txs.WaitForRule(ctx, key, "inventory_eu", rules[0])
+	assert.True(t, waited)
+	assert.Equal(t, vtrpcpb.Code_RESOURCE_EXHAUSTED, vterrors.Code(err))
+	assert.EqualError(t, err, "hot row protection: transaction waited longer than 10ms for the same row (rule sku)")
+
+	// The queue of the row holds two transactions at most.
+	done2 := make(chan DoneFunc)
+	go func() {
+		done, _, err := txs.WaitForRule(ctx, key, "inventory_eu", rules[0])
+		assert.NoError(t, err)
+		done2 <- done
+	}()
+	require.Eventually(t, func() bool {
+		return txs.Pending(key) == 2
+	}, 5*time.Second, time.Millisecond)
+	_, _, err = txs.WaitForRule(ctx, key, "inventory_eu", rules[0])
+	assert.EqualError(t, err, "hot row protection: too many queued transactions (2 >= 2) for the same row (table + WHERE clause: 'inventory_eu where sku = 'abc'')")
+	assert.EqualValues(t, 2, txs.ruleQueued.Counts()["sku"])
+	done1()
+	(<-done2)()
+
+	assert.EqualValues(t, 0, txs.ruleQueued.Counts()["sku"])
+	assert.Equal(t, 0, txs.Pending(key))
+	assert.EqualValues(t, 2, txs.ruleWaits.Counts()["sku"]-waits)
+	assert.EqualValues(t, 1, txs.ruleWaitTimeouts.Counts()["sku"]-timeouts)
+	assert.EqualValues(t, 1, txs.ruleQueueExceeded.Counts()["sku"]-queueExceeded)
+}
@@ -80,6 +80,19 @@ type TxSerializer struct {
 	waits, waitsDryRun, queueExceeded, queueExceededDryRun *stats.CountersWithSingleLabel
 	globalQueueExceeded, globalQueueExceededDryRun         *stats.Counter
 
+	// ruleWaits, ruleQueueExceeded and ruleWaitTimeouts count per queueing
+	// rule the transactions which were queued, rejected because the queue of
+	// their row was full, and rejected because they waited longer than the
+	// max wait of the rule.
+	//
+	// ruleQueued is the number of transactions queued or in flight per rule.
+	ruleWaits, ruleQueueExceeded, ruleWaitTimeouts *stats.CountersWithSingleLabel
+	ruleQueued                                     *stats.GaugesWithSingleLabel
+
+	// rules are the queueing rules. They are set once by SetRules, before
+	// any transaction is queued.
+	rules []*Rule
+
 	log                          *logutil.ThrottledLogger
 	logDryRun                    *logutil.ThrottledLogger
 	logWaitsDryRun               *logutil.ThrottledLogger
@@ -124,6 +137,22 @@ func New(env tabletenv.Env) *TxSerializer {
 		globalQueueExceededDryRun: env.Exporter().NewCounter(
 			"TxSerializerGlobalQueueExceededDryRun",
 			"Dry-run stats for TxSerializerGlobalQueueExceeded"),
+		ruleWaits: env.Exporter().NewCountersWithSingleLabel(
+			"TxSerializerRuleWaits",
+			"Number of times a transaction was queued by a hot row protection rule because another transaction was already in flight for the same row",
+			"rule"),
+		ruleQueueExceeded: env.Exporter().NewCountersWithSingleLabel(
+			"TxSerializerRuleQueueExceeded",
+			"Number of transactions that were rejected because the max queue size per row of their hot row protection rule was exceeded",
+			"rule"),
+		ruleWaitTimeouts: env.Exporter().NewCountersWithSingleLabel(
+			"TxSerializerRuleWaitTimeouts",
+			"Number of transactions that were rejected because they waited longer than the max wait of their hot row protection rule",
+			"rule"),
+		ruleQueued: env.Exporter().NewGaugesWithSingleLabel(
+			"TxSerializerRuleQueued",
+			"Number of transactions queued or in flight per hot row protection rule",
+			"rule"),
 		log:                          logutil.NewThrottledLogger("HotRowProtection", 5*time.Second),
 		logDryRun:                    logutil.NewThrottledLogger("HotRowProtection DryRun", 5*time.Second),
 		logWaitsDryRun:               logutil.NewThrottledLogger("HotRowProtection Waits DryRun", 5*time.Second),
@@ -134,6 +163,23 @@ func New(env tabletenv.Env) *TxSerializer {
 	}
 }
 
+// SetRules sets the queueing rules. It must be called before any
+// transaction is queued.
+func (txs *TxSerializer) SetRules(rules []*Rule) {
+	txs.rules = rules
+}
+
+// Rules returns the queueing rules which apply to the table, in order.
+func (txs *TxSerializer) Rules(table string) []*Rule {
+	var rules []*Rule
+	for _, rule := range txs.rules {
+		if rule.matches(table) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 // DoneFunc is returned by Wait() and must be called by the caller.
 type DoneFunc func()
 
@@ -144,10 +190,16 @@ type DoneFunc func()
 // "waited" is true if Wait() had to wait for other transactions.
 // "err" is not nil if a) the context is done or b) a queue limit was reached.
 func (txs *TxSerializer) Wait(ctx context.Context, key, table string) (done DoneFunc, waited bool, err error) {
+	return txs.WaitForRule(ctx, key, table, nil)
+}
+
+// WaitForRule is like Wait, with the limits of the queueing rule which the
+// key was computed for, if rule is not nil.
+func (txs *TxSerializer) WaitForRule(ctx context.Context, key, table string, rule *Rule) (done DoneFunc, waited bool, err error) {
 	txs.mu.Lock()
 	defer txs.mu.Unlock()
 
-	waited, err = txs.lockLocked(ctx, key, table)
+	waited, err = txs.lockLocked(ctx, key, table, rule)
 	if err != nil {
 		if waited {
 			// Waiting failed early e.g. due a canceled context and we did NOT get the
@@ -159,18 +211,40 @@ func (txs *TxSerializer) Wait(ctx context.Context, key, table string) (done Done
 	return func() { txs.unlock(key) }, waited, nil
 }
 
+// limits returns the max queue size and the max concurrency per row of the
+// rule, or the default ones.
+func (txs *TxSerializer) limits(rule *Rule) (maxQueueSize, concurrentTransactions int) {
+	maxQueueSize, concurrentTransactions = txs.maxQueueSize, txs.concurrentTransactions
+	if rule != nil && rule.MaxQueueSize > 0 {
+		maxQueueSize = rule.MaxQueueSize
+	}
+	if rule != nil && rule.MaxConcurrency > 0 {
+		concurrentTransactions = rule.MaxConcurrency
+	}
+	return maxQueueSize, concurrentTransactions
+}
+
 // lockLocked queues this transaction. It will unblock immediately if this
 // transaction is the first in the queue or when it acquired a slot.
 // The method has the suffix "Locked" to clarify that "txs.mu" must be locked.
-func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (bool, error) {
+func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string, rule *Rule) (bool, error) {
 	q, ok := txs.queues[key]
 	if !ok {
 		// First transaction in the queue i.e. we don't wait and return immediately.
-		txs.queues[key] = newQueueForFirstTransaction(txs.concurrentTransactions)
+		q = newQueueForFirstTransaction(txs.concurrentTransactions)
+		q.rule = rule
+		txs.queues[key] = q
 		txs.globalSize++
+		if rule != nil {
+			txs.ruleQueued.Add(rule.Name, 1)
+		}
 		return false, nil
 	}
 
+	// The limits are those of the rule of the first transaction of the queue.
+	rule = q.rule
+	maxQueueSize, concurrentTransactions := txs.limits(rule)
+
 	if txs.globalSize >= txs.maxGlobalQueueSize {
 		if txs.dryRun {
 			txs.globalQueueExceededDryRun.Add(1)
@@ -182,22 +256,25 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 		}
 	}
 
-	if q.size >= txs.maxQueueSize {
+	if q.size >= maxQueueSize {
 		if txs.dryRun {
 			txs.queueExceededDryRun.Add(table, 1)
 			if txs.env.Config().SanitizeLogMessages {
-				txs.logQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, txs.maxQueueSize, txs.sanitizeKey(key))
+				txs.logQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, maxQueueSize, txs.sanitizeKey(key))
 			} else {
-				txs.logQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, txs.maxQueueSize, key)
+				txs.logQueueExceededDryRun.Warningf("Would have rejected BeginExecute RPC because there are too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, maxQueueSize, key)
 			}
 		} else {
 			txs.queueExceeded.Add(table, 1)
+			if rule != nil {
+				txs.ruleQueueExceeded.Add(rule.Name, 1)
+			}
 			if txs.env.Config().TerseErrors {
 				return false, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED,
-					"hot row protection: too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, txs.maxQueueSize, txs.sanitizeKey(key))
+					"hot row protection: too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, maxQueueSize, txs.sanitizeKey(key))
 			}
 			return false, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED,
-				"hot row protection: too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, txs.maxQueueSize, key)
+				"hot row protection: too many queued transactions (%d >= %d) for the same row (table + WHERE clause: '%v')", q.size, maxQueueSize, key)
 		}
 	}
 
@@ -206,7 +283,7 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 		// first time.
 
 		// As an optimization, we deferred the creation of the channel until now.
-		q.availableSlots = make(chan struct{}, txs.concurrentTransactions)
+		q.availableSlots = make(chan struct{}, concurrentTransactions)
 		q.availableSlots <- struct{}{}
 
 		// Include first transaction in the count at /debug/hotrows. (It was not
@@ -217,6 +294,9 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	txs.globalSize++
 	q.size++
 	q.count++
+	if rule != nil {
+		txs.ruleQueued.Add(rule.Name, 1)
+	}
 	if q.size > q.max {
 		q.max = q.size
 	}
@@ -246,13 +326,27 @@ func (txs *TxSerializer) lockLocked(ctx context.Context, key, table string) (boo
 	default:
 	}
 
-	// Blocking wait for the next available slot.
+	// Blocking wait for the next available slot, at most for the max wait of
+	// the rule.
 	txs.waits.Add(table, 1)
+	var timeout <-chan time.Time
+	if rule != nil {
+		txs.ruleWaits.Add(rule.Name, 1)
+		if rule.maxWait > 0 {
+			timer := time.NewTimer(rule.maxWait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+	}
 	select {
 	case q.availableSlots <- struct{}{}:
 		return true, nil
 	case <-ctx.Done():
 		return true, ctx.Err()
+	case <-timeout:
+		txs.ruleWaitTimeouts.Add(rule.Name, 1)
+		return true, vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED,
+			"hot row protection: transaction waited longer than %v for the same row (rule %s)", rule.maxWait, rule.Name)
 	}
 }
 
@@ -267,6 +361,9 @@ func (txs *TxSerializer) unlockLocked(key string, returnSlot bool) {
 	q := txs.queues[key]
 	q.size--
 	txs.globalSize--
+	if q.rule != nil {
+		txs.ruleQueued.Add(q.rule.Name, -1)
+	}
 
 	if q.size == 0 {
 		// This is the last transaction in flight.
@@ -375,6 +472,8 @@ type queue struct {
 	// max is the max of "size", i.e. the maximum number of transactions which
 	// were simultaneously queued for the same row range.
 	max int
+	// rule is the queueing rule of the row, if any.
+	rule *Rule
 
 	// availableSlots limits the number of concurrent transactions *per*
 	// hot row (range). It holds one element for each allowed pending
@@ -0,0 +1,305 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/mysql/replication"
+	"vitess.io/vitess/go/vt/dbconfigs"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+)
+
+var (
+	// binlogPurgeInterval is the interval at which binary logs are reviewed for purging. Zero disables
+	// binlog purge coordination, in which case MySQL's own binlog expiration applies.
+	binlogPurgeInterval time.Duration
+	// binlogPurgeRetainFiles is the minimal number of most recent binary logs that are never purged.
+	binlogPurgeRetainFiles = 10
+	// binlogPurgePITRRetention is the minimal time for which binary logs are retained once rotated, so
+	// that point-in-time recoveries can replay them.
+	binlogPurgePITRRetention time.Duration
+)
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerBinlogPurgeFlags)
+	servenv.OnParseFor("vttablet", registerBinlogPurgeFlags)
+}
+
+func registerBinlogPurgeFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&binlogPurgeInterval, "binlog-purge-interval", binlogPurgeInterval, "Interval at which the tablet purges binary logs that are no longer required by any active vstream, by any vreplication workflow streaming from the shard, even stopped, or by any of the shard's replicas, once they are older than binlog_expire_logs_seconds. When non-zero, MySQL's automatic binlog purge is disabled while the tablet is serving. Zero (default) disables binlog purge coordination.")
+	fs.IntVar(&binlogPurgeRetainFiles, "binlog-purge-retain-files", binlogPurgeRetainFiles, "Minimal number of most recent binary logs that are retained by binlog purge coordination, regardless of consumer positions.")
+	fs.DurationVar(&binlogPurgePITRRetention, "binlog-purge-pitr-retention", binlogPurgePITRRetention, "Minimal time for which binlog purge coordination retains the binary logs once rotated, so that point-in-time recoveries can replay them, regardless of consumer positions. Binary logs are retained for binlog_expire_logs_seconds too, if longer.")
+}
+
+// binlogFile is a binary log along with the GTID set executed before the log begins.
+type binlogFile struct {
+	name          string
+	previousGTIDs replication.Position
+	// rotated is when the log was rotated, zero for the current log. It is the time at which the tablet
+	// first saw the next log, which is never earlier than the actual rotation.
+	rotated time.Time
+}
+
+// binlogPurgeTarget returns the name of the binary log up to which (exclusive) logs may be purged, or an
+// empty string if no log may be purged. A log may only be purged if every consumer position contains the
+// previous GTIDs of the log that follows it, i.e. no consumer requires any event in the purged log, and
+// if it was rotated before expireBefore. The most recent retainFiles logs are always retained.
+func binlogPurgeTarget(files []binlogFile, consumers []replication.Position, retainFiles int, expireBefore time.Time) string {
+	target := len(files) - max(retainFiles, 1)
+	for _, consumer := range consumers {
+		for target > 0 && !consumer.AtLeast(files[target].previousGTIDs) {
+			target--
+		}
+	}
+	for target > 0 && (files[target-1].rotated.IsZero() || files[target-1].rotated.After(expireBefore)) {
+		target--
+	}
+	if target <= 0 {
+		return ""
+	}
+	return files[target].name
+}
+
+// binlogPurger periodically purges binary logs that are no longer required by the tablet's consumers:
+// the vstreams served by this tablet (vreplication workflows, VStream API clients, schema tracking, etc.),
+// the vreplication workflows streaming from the shard, even when stopped, and, on a primary, the shard's
+// replicas. As MySQL would, it retains the binary logs for binlog_expire_logs_seconds once rotated, and
+// never purges them when it is zero.
+type binlogPurger struct {
+	vse *Engine
+	cp  dbconfigs.Connector
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// previousGTIDs caches the previous GTIDs per binary log. Binary logs are immutable, hence the
+	// cache is only invalidated when logs are purged.
+	previousGTIDs map[string]replication.Position
+	// seen is when each binary log was first seen, which tells when the previous one was rotated.
+	seen map[string]time.Time
+	// restoreAutoPurge is the original value of @@binlog_expire_logs_auto_purge, restored on close.
+	restoreAutoPurge string
+}
+
+func newBinlogPurger(vse *Engine, cp dbconfigs.Connector) *binlogPurger {
+	return &binlogPurger{
+		vse:           vse,
+		cp:            cp,
+		previousGTIDs: make(map[string]replication.Position),
+		seen:          make(map[string]time.Time),
+	}
+}
+
+func (bp *binlogPurger) open() {
+	ctx, cancel := context.WithCancel(context.Background())
+	bp.cancel = cancel
+	bp.wg.Add(1)
+	go func() {
+		defer bp.wg.Done()
+		if err := bp.disableAutoPurge(ctx); err != nil {
+			log.Warningf("binlog purge: could not disable MySQL's automatic binlog purge: %v", err)
+		}
+		ticker := time.NewTicker(binlogPurgeInterval)
+		defer ticker.Stop()
+		for {
+			if err := bp.purge(ctx); err != nil && ctx.Err() == nil {
+				bp.vse.binlogPurgeErrors.Add(1)
+				log.Errorf("binlog purge: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (bp *binlogPurger) close() {
+	bp.cancel()
+	bp.wg.Wait()
+	if bp.restoreAutoPurge == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := bp.cp.Connect(ctx)
+	if err != nil {
+		log.Errorf("binlog purge: could not restore MySQL's automatic binlog purge: %v", err)
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.ExecuteFetch(fmt.Sprintf("set @@global.binlog_expire_logs_auto_purge=%s", bp.restoreAutoPurge), 0, false); err != nil {
+		log.Errorf("binlog purge: could not restore MySQL's automatic binlog purge: %v", err)
+	}
+}
+
+// disableAutoPurge disables MySQL's automatic binlog purge, which is unaware of binlog consumers.
+// binlog_expire_logs_auto_purge is only available as of MySQL 8.0.29.
+func (bp *binlogPurger) disableAutoPurge(ctx context.Context) error {
+	conn, err := bp.cp.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	rs, err := conn.ExecuteFetch("select @@global.binlog_expire_logs_auto_purge", 1, false)
+	if err != nil {
+		return err
+	}
+	if len(rs.Rows) != 1 {
+		return fmt.Errorf("unexpected result for binlog_expire_logs_auto_purge: %+v", rs.Rows)
+	}
+	original := rs.Rows[0][0].ToString()
+	if original == "0" {
+		return nil
+	}
+	if _, err := conn.ExecuteFetch("set @@global.binlog_expire_logs_auto_purge=0", 0, false); err != nil {
+		return err
+	}
+	bp.restoreAutoPurge = original
+	return nil
+}
+
+// purge purges binary logs that are not required by any consumer.
+func (bp *binlogPurger) purge(ctx context.Context) error {
+	consumers := bp.vse.StreamPositions()
+	replicaPositions, err := bp.vse.replicaPositions(ctx)
+	if err != nil {
+		bp.vse.binlogPurgeBlocked.Add(1)
+		return fmt.Errorf("could not read replica positions, purge is blocked: %v", err)
+	}
+	consumers = append(consumers, replicaPositions...)
+	workflowPositions, err := bp.vse.workflowPositions(ctx)
+	if err != nil {
+		bp.vse.binlogPurgeBlocked.Add(1)
+		return fmt.Errorf("could not read workflow positions, purge is blocked: %v", err)
+	}
+	consumers = append(consumers, workflowPositions...)
+
+	conn, err := bp.cp.Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	expireLogs, err := readExpireLogs(conn)
+	if err != nil {
+		return err
+	}
+
+	rs, err := conn.ExecuteFetch("SHOW BINARY LOGS", -1, false)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	files := make([]binlogFile, 0, len(rs.Rows))
+	existing := make(map[string]bool, len(rs.Rows))
+	for _, row := range rs.Rows {
+		name := row[0].ToString()
+		existing[name] = true
+		if _, ok := bp.seen[name]; !ok {
+			bp.seen[name] = now
+		}
+		if len(files) > 0 {
+			files[len(files)-1].rotated = bp.seen[name]
+		}
+		previousGTIDs, ok := bp.previousGTIDs[name]
+		if !ok {
+			previousGTIDs, err = readPreviousGTIDs(conn, name)
+			if err != nil {
+				return err
+			}
+			bp.previousGTIDs[name] = previousGTIDs
+		}
+		files = append(files, binlogFile{name: name, previousGTIDs: previousGTIDs})
+	}
+	for name := range bp.seen {
+		if !existing[name] {
+			delete(bp.previousGTIDs, name)
+			delete(bp.seen, name)
+		}
+	}
+
+	// MySQL never purges the binary logs when they do not expire.
+	if expireLogs == 0 {
+		return nil
+	}
+	target := binlogPurgeTarget(files, consumers, binlogPurgeRetainFiles, now.Add(-max(expireLogs, binlogPurgePITRRetention)))
+	if target == "" {
+		return nil
+	}
+	if _, err := conn.ExecuteFetch(fmt.Sprintf("PURGE BINARY LOGS TO '%s'", target), 0, false); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.name == target {
+			break
+		}
+		delete(bp.previousGTIDs, file.name)
+		delete(bp.seen, file.name)
+		bp.vse.binlogPurgeFilesPurged.Add(1)
+	}
+	log.Infof("binlog purge: purged binary logs up to %s", target)
+	return nil
+}
+
+// readExpireLogs reads binlog_expire_logs_seconds, the time for which MySQL retains the binary logs.
+func readExpireLogs(conn *mysql.Conn) (time.Duration, error) {
+	rs, err := conn.ExecuteFetch("select @@global.binlog_expire_logs_seconds", 1, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(rs.Rows) != 1 {
+		return 0, fmt.Errorf("unexpected result for binlog_expire_logs_seconds: %+v", rs.Rows)
+	}
+	seconds, err := strconv.ParseInt(rs.Rows[0][0].ToString(), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// readPreviousGTIDs reads the Previous_gtids event of the given binary log.
+func readPreviousGTIDs(conn *mysql.Conn, name string) (replication.Position, error) {
+	rs, err := conn.ExecuteFetch(fmt.Sprintf("SHOW BINLOG EVENTS IN '%s' LIMIT 2", name), 2, true)
+	if err != nil {
+		return replication.Position{}, err
+	}
+	for _, row := range rs.Named().Rows {
+		if row.AsString("Event_type", "") != "Previous_gtids" {
+			continue
+		}
+		// Multiple UUIDs are separated by newlines
+		info := strings.ReplaceAll(row.AsString("Info", ""), "\n", "")
+		gtidSet, err := replication.ParseMysql56GTIDSet(info)
+		if err != nil {
+			return replication.Position{}, err
+		}
+		return replication.Position{GTIDSet: gtidSet}, nil
+	}
+	return replication.Position{}, fmt.Errorf("previous GTIDs not found in binary log %s", name)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vstreamer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/replication"
+)
+
+func TestBinlogPurgeTarget(t *testing.T) {
+	const uuid = "16b1039f-22b6-11ed-b765-0a43f95f28a3"
+	mustPosition := func(gtids string) replication.Position {
+		gtidSet, err := replication.ParseMysql56GTIDSet(gtids)
+		require.NoError(t, err)
+		return replication.Position{GTIDSet: gtidSet}
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	files := []binlogFile{
+		{name: "binlog.000001", previousGTIDs: mustPosition(""), rotated: start.Add(time.Hour)},
+		{name: "binlog.000002", previousGTIDs: mustPosition(uuid + ":1-100"), rotated: start.Add(2 * time.Hour)},
+		{name: "binlog.000003", previousGTIDs: mustPosition(uuid + ":1-200"), rotated: start.Add(3 * time.Hour)},
+		{name: "binlog.000004", previousGTIDs: mustPosition(uuid + ":1-300"), rotated: start.Add(4 * time.Hour)},
+		{name: "binlog.000005", previousGTIDs: mustPosition(uuid + ":1-400")},
+	}
+	tcs := []struct {
+		name         string
+		consumers    []string
+		retainFiles  int
+		expireBefore time.Time
+		expect       string
+	}{
+		{
+			name:        "no consumers",
+			retainFiles: 1,
+			expect:      "binlog.000005",
+		},
+		{
+			name:        "no consumers, retain files",
+			retainFiles: 2,
+			expect:      "binlog.000004",
+		},
+		{
+			name:        "retain all files",
+			retainFiles: 5,
+		},
+		{
+			name:        "retain more files than exist",
+			retainFiles: 10,
+		},
+		{
+			name:        "zero retain files still retains the current binary log",
+			retainFiles: 0,
+			expect:      "binlog.000005",
+		},
+		{
+			name:        "consumer in the middle",
+			consumers:   []string{uuid + ":1-250"},
+			retainFiles: 1,
+			expect:      "binlog.000003",
+		},
+		{
+			name:        "consumer at file boundary",
+			consumers:   []string{uuid + ":1-200"},
+			retainFiles: 1,
+			expect:      "binlog.000003",
+		},
+		{
+			name:        "slowest consumer wins",
+			consumers:   []string{uuid + ":1-450", uuid + ":1-150", uuid + ":1-350"},
+			retainFiles: 1,
+			expect:      "binlog.000002",
+		},
+		{
+			name:        "consumer requires first binary log",
+			consumers:   []string{uuid + ":1-50"},
+			retainFiles: 1,
+		},
+		{
+			name:        "consumer with unrelated gtids requires everything",
+			consumers:   []string{"26b1039f-22b6-11ed-b765-0a43f95f28a3:1-1000"},
+			retainFiles: 1,
+		},
+		{
+			name:        "retain files limits consumer",
+			consumers:   []string{uuid + ":1-450"},
+			retainFiles: 3,
+			expect:      "binlog.000003",
+		},
+		{
+			name:         "logs rotated after expiry are retained",
+			retainFiles:  1,
+			expireBefore: start.Add(2*time.Hour + time.Minute),
+			expect:       "binlog.000003",
+		},
+		{
+			name:         "logs rotated at expiry are purged",
+			retainFiles:  1,
+			expireBefore: start.Add(time.Hour),
+			expect:       "binlog.000002",
+		},
+		{
+			name:         "no log expired",
+			retainFiles:  1,
+			expireBefore: start,
+		},
+		{
+			name:         "slowest of consumer and expiry wins",
+			consumers:    []string{uuid + ":1-150"},
+			retainFiles:  1,
+			expireBefore: start.Add(4 * time.Hour),
+			expect:       "binlog.000002",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var consumers []replication.Position
+			for _, consumer := range tc.consumers {
+				consumers = append(consumers, mustPosition(consumer))
+			}
+			expireBefore := tc.expireBefore
+			if expireBefore.IsZero() {
+				expireBefore = start.Add(24 * time.Hour)
+			}
+			assert.Equal(t, tc.expect, binlogPurgeTarget(files, consumers, tc.retainFiles, expireBefore))
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"vitess.io/vitess/go/acl"
+	"vitess.io/vitess/go/mysql/replication"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/vt/dbconfigs"
@@ -70,6 +71,7 @@ type Engine struct {
 	isOpen          int32 // 0 or 1 in place of atomic.Bool added in go 1.19
 	streamIdx       int
 	streamers       map[int]*uvstreamer
+	streamPositions map[int]*atomic.Pointer[replication.Position]
 	rowStreamers    map[int]*rowStreamer
 	tableStreamers  map[int]*tableStreamer
 	resultStreamers map[int]*resultStreamer
@@ -104,6 +106,18 @@ type Engine struct {
 	tableStreamerNumTables                 *stats.Counter
 
 	throttlerClient *throttle.Client
+
+	// binlogPurger is non-nil while the engine is open and binlog purge coordination is enabled.
+	binlogPurger *binlogPurger
+	// replicaPositionsFunc returns the replication positions of the shard's replicas, when this
+	// tablet is a primary. It is set by SetReplicaPositionsFunc.
+	replicaPositionsFunc func(ctx context.Context) ([]replication.Position, error)
+	// workflowPositionsFunc returns the positions of the vreplication workflows which stream from the
+	// shard. It is set by SetWorkflowPositionsFunc.
+	workflowPositionsFunc  func(ctx context.Context) ([]replication.Position, error)
+	binlogPurgeFilesPurged *stats.Counter
+	binlogPurgeBlocked     *stats.Counter
+	binlogPurgeErrors      *stats.Counter
 }
 
 const throttledLoggerInterval = 5 * time.Minute
@@ -120,6 +134,7 @@ func NewEngine(env tabletenv.Env, ts srvtopo.Server, se *schema.Engine, lagThrot
 		throttlerClient: throttle.NewBackgroundClient(lagThrottler, throttlerapp.VStreamerName, base.UndefinedScope),
 
 		streamers:       make(map[int]*uvstreamer),
+		streamPositions: make(map[int]*atomic.Pointer[replication.Position]),
 		rowStreamers:    make(map[int]*rowStreamer),
 		tableStreamers:  make(map[int]*tableStreamer),
 		resultStreamers: make(map[int]*resultStreamer),
@@ -145,6 +160,10 @@ func NewEngine(env tabletenv.Env, ts srvtopo.Server, se *schema.Engine, lagThrot
 		vstreamersEndedWithErrors:              env.Exporter().NewCounter("VStreamersEndedWithErrors", "Count of vstreamers that ended with errors"),
 		errorCounts:                            env.Exporter().NewCountersWithSingleLabel("VStreamerErrors", "Tracks errors in vstreamer", "type", "Catchup", "Copy", "Send", "TablePlan"),
 		vstreamerFlushedBinlogs:                env.Exporter().NewCounter("VStreamerFlushedBinlogs", "Number of times we've successfully executed a FLUSH BINARY LOGS statement when starting a vstream"),
+
+		binlogPurgeFilesPurged: env.Exporter().NewCounter("BinlogPurgeFilesPurged", "Number of binary logs purged by binlog purge coordination"),
+		binlogPurgeBlocked:     env.Exporter().NewCounter("BinlogPurgeBlocked", "Number of times binlog purge was blocked because replica positions could not be determined"),
+		binlogPurgeErrors:      env.Exporter().NewCounter("BinlogPurgeErrors", "Number of failed binlog purge attempts"),
 	}
 	env.Exporter().NewGaugeFunc("RowStreamerMaxInnoDBTrxHistLen", "", func() int64 { return env.Config().RowStreamer.MaxInnoDBTrxHistLen })
 	env.Exporter().NewGaugeFunc("RowStreamerMaxMySQLReplLagSecs", "", func() int64 { return env.Config().RowStreamer.MaxMySQLReplLagSecs })
@@ -166,7 +185,13 @@ func (vse *Engine) InitDBConfig(keyspace, shard string) {
 func (vse *Engine) Open() {
 	log.Info("VStreamer: opening")
 	// If it's not already open, then open it now.
-	atomic.CompareAndSwapInt32(&vse.isOpen, 0, 1)
+	if !atomic.CompareAndSwapInt32(&vse.isOpen, 0, 1) {
+		return
+	}
+	if binlogPurgeInterval > 0 {
+		vse.binlogPurger = newBinlogPurger(vse, vse.env.Config().DB.DbaConnector())
+		vse.binlogPurger.open()
+	}
 }
 
 // IsOpen checks if the engine is opened
@@ -194,6 +219,10 @@ func (vse *Engine) Close() {
 		}
 		atomic.StoreInt32(&vse.isOpen, 0)
 	}()
+	if vse.binlogPurger != nil {
+		vse.binlogPurger.close()
+		vse.binlogPurger = nil
+	}
 
 	// Wait only after releasing the lock because the end of every
 	// stream will use the lock to remove the entry from streamers.
@@ -247,6 +276,25 @@ func (vse *Engine) Stream(ctx context.Context, startPos string, tablePKs []*binl
 	// because this overhead should be incurred only if someone uses this feature.
 	vse.watcherOnce.Do(vse.setWatch)
 
+	// Track the position of the stream, so that binlogs it still requires are not purged.
+	// A start position such as "current" is not tracked until the first GTID event is sent.
+	streamPos := &atomic.Pointer[replication.Position]{}
+	if pos, err := replication.DecodePosition(startPos); err == nil && !pos.IsZero() {
+		streamPos.Store(&pos)
+	}
+	trackingSend := func(evs []*binlogdatapb.VEvent) error {
+		for i := len(evs) - 1; i >= 0; i-- {
+			if evs[i].Type != binlogdatapb.VEventType_GTID {
+				continue
+			}
+			if pos, err := replication.DecodePosition(evs[i].Gtid); err == nil {
+				streamPos.Store(&pos)
+			}
+			break
+		}
+		return send(evs)
+	}
+
 	// Create stream and add it to the map.
 	streamer, idx, err := func() (*uvstreamer, int, error) {
 		if atomic.LoadInt32(&vse.isOpen) == 0 {
@@ -255,9 +303,10 @@ func (vse *Engine) Stream(ctx context.Context, startPos string, tablePKs []*binl
 		vse.mu.Lock()
 		defer vse.mu.Unlock()
 		streamer := newUVStreamer(ctx, vse, vse.env.Config().DB.FilteredWithDB(), vse.se, startPos, tablePKs,
-			filter, vse.lvschema, throttlerApp, send, options)
+			filter, vse.lvschema, throttlerApp, trackingSend, options)
 		idx := vse.streamIdx
 		vse.streamers[idx] = streamer
+		vse.streamPositions[idx] = streamPos
 		vse.streamIdx++
 		// Now that we've added the stream, increment wg.
 		// This must be done before releasing the lock.
@@ -273,6 +322,7 @@ func (vse *Engine) Stream(ctx context.Context, startPos string, tablePKs []*binl
 		vse.mu.Lock()
 		defer vse.mu.Unlock()
 		delete(vse.streamers, idx)
+		delete(vse.streamPositions, idx)
 		vse.wg.Done()
 	}()
 
@@ -280,6 +330,46 @@ func (vse *Engine) Stream(ctx context.Context, startPos string, tablePKs []*binl
 	return streamer.Stream()
 }
 
+// StreamPositions returns the last known positions of all active binlog streams.
+func (vse *Engine) StreamPositions() []replication.Position {
+	vse.mu.Lock()
+	defer vse.mu.Unlock()
+	positions := make([]replication.Position, 0, len(vse.streamPositions))
+	for _, streamPos := range vse.streamPositions {
+		if pos := streamPos.Load(); pos != nil {
+			positions = append(positions, *pos)
+		}
+	}
+	return positions
+}
+
+// SetReplicaPositionsFunc sets the function which returns the replication positions of the shard's
+// replicas. Binlog purge coordination does not purge binary logs still required by those replicas.
+func (vse *Engine) SetReplicaPositionsFunc(f func(ctx context.Context) ([]replication.Position, error)) {
+	vse.replicaPositionsFunc = f
+}
+
+func (vse *Engine) replicaPositions(ctx context.Context) ([]replication.Position, error) {
+	if vse.replicaPositionsFunc == nil {
+		return nil, nil
+	}
+	return vse.replicaPositionsFunc(ctx)
+}
+
+// SetWorkflowPositionsFunc sets the function which returns the positions of the vreplication workflows
+// which stream from the shard, including the stopped ones. Binlog purge coordination does not purge
+// binary logs still required by those workflows.
+func (vse *Engine) SetWorkflowPositionsFunc(f func(ctx context.Context) ([]replication.Position, error)) {
+	vse.workflowPositionsFunc = f
+}
+
+func (vse *Engine) workflowPositions(ctx context.Context) ([]replication.Position, error) {
+	if vse.workflowPositionsFunc == nil {
+		return nil, nil
+	}
+	return vse.workflowPositionsFunc(ctx)
+}
+
 // StreamRows streams rows.
 // This streams the table data rows (so we can copy the table data snapshot)
 func (vse *Engine) StreamRows(ctx context.Context, query string, lastpk []sqltypes.Value,
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"context"
+	"fmt"
+
+	"vitess.io/vitess/go/constants/sidecar"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/textutil"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtctl/workflow"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vttablet/tmclient"
+
+	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "vitess.io/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+	vtctldatapb "vitess.io/vitess/go/vt/proto/vtctldata"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+)
+
+const sqlShowWorkflowsWhere = `select
+		id, workflow, workflow_type, workflow_sub_type, source, pos, stop_pos, state, message,
+		time_updated, transaction_timestamp, rows_copied
+	from %s.vreplication
+	where db_name = %s%s
+	order by workflow, id`
+
+// buildShowWorkflowsQuery returns the query that serves a `SHOW VITESS_WORKFLOWS` statement,
+// reading the vreplication streams of the given database.
+func buildShowWorkflowsQuery(showBasic *sqlparser.ShowBasic, dbName string) string {
+	whereExpr := ""
+	if showBasic.Filter != nil {
+		if showBasic.Filter.Filter != nil {
+			whereExpr = " and (" + sqlparser.String(showBasic.Filter.Filter) + ")"
+		} else if showBasic.Filter.Like != "" {
+			lit := sqlparser.String(sqlparser.NewStrLiteral(showBasic.Filter.Like))
+			whereExpr = fmt.Sprintf(" and (workflow LIKE %s OR state LIKE %s)", lit, lit)
+		}
+	}
+	return sqlparser.BuildParsedQuery(sqlShowWorkflowsWhere, sidecar.GetIdentifier(),
+		sqlparser.String(sqlparser.NewStrLiteral(dbName)), whereExpr).Query
+}
+
+// AlterWorkflow serves `ALTER VITESS_WORKFLOW ...` statements. STOP and START only affect
+// the streams on this tablet, whereas SWITCH TRAFFIC operates on the workflow as a whole,
+// across all shards of this tablet's keyspace.
+func (tsv *TabletServer) AlterWorkflow(ctx context.Context, alterWorkflow *sqlparser.AlterWorkflow) (*sqltypes.Result, error) {
+	target := tsv.sm.Target()
+	if target == nil || target.TabletType != topodatapb.TabletType_PRIMARY {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "ALTER VITESS_WORKFLOW must be executed on a PRIMARY tablet")
+	}
+	if tsv.topoServer == nil {
+		return nil, vterrors.Errorf(vtrpcpb.Code_FAILED_PRECONDITION, "ALTER VITESS_WORKFLOW requires a topo server")
+	}
+	tmClient := tmclient.NewTabletManagerClient()
+	defer tmClient.Close()
+
+	switch alterWorkflow.Type {
+	case sqlparser.StopWorkflowType:
+		return tsv.updateWorkflowState(ctx, tmClient, alterWorkflow.Workflow, binlogdatapb.VReplicationWorkflowState_Stopped)
+	case sqlparser.StartWorkflowType:
+		return tsv.updateWorkflowState(ctx, tmClient, alterWorkflow.Workflow, binlogdatapb.VReplicationWorkflowState_Running)
+	case sqlparser.SwitchTrafficWorkflowType:
+		return tsv.switchWorkflowTraffic(ctx, tmClient, target.Keyspace, alterWorkflow.Workflow)
+	}
+	return nil, vterrors.New(vtrpcpb.Code_UNIMPLEMENTED, "ALTER VITESS_WORKFLOW not implemented")
+}
+
+// updateWorkflowState sets the state of the given workflow's streams on this tablet. It goes
+// through the tablet manager so that the vreplication engine starts or stops the streams.
+func (tsv *TabletServer) updateWorkflowState(ctx context.Context, tmClient tmclient.TabletManagerClient, workflowName string, state binlogdatapb.VReplicationWorkflowState) (*sqltypes.Result, error) {
+	tabletInfo, err := tsv.topoServer.GetTablet(ctx, tsv.alias)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := tmClient.UpdateVReplicationWorkflow(ctx, tabletInfo.Tablet, &tabletmanagerdatapb.UpdateVReplicationWorkflowRequest{
+		Workflow:    workflowName,
+		Cells:       textutil.SimulatedNullStringSlice,
+		TabletTypes: textutil.SimulatedNullTabletTypeSlice,
+		State:       &state,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetResult() == nil {
+		return &sqltypes.Result{}, nil
+	}
+	return sqltypes.Proto3ToResult(resp.Result), nil
+}
+
+// switchWorkflowTraffic switches all traffic, for all tablet types, of the given workflow in
+// the given keyspace. The switch uses the same defaults as `vtctldclient SwitchTraffic`.
+func (tsv *TabletServer) switchWorkflowTraffic(ctx context.Context, tmClient tmclient.TabletManagerClient, keyspace, workflowName string) (*sqltypes.Result, error) {
+	wr := workflow.NewServer(tsv.env, tsv.topoServer, tmClient)
+	resp, err := wr.WorkflowSwitchTraffic(ctx, &vtctldatapb.WorkflowSwitchTrafficRequest{
+		Keyspace: keyspace,
+		Workflow: workflowName,
+		TabletTypes: []topodatapb.TabletType{
+			topodatapb.TabletType_PRIMARY,
+			topodatapb.TabletType_REPLICA,
+			topodatapb.TabletType_RDONLY,
+		},
+		Direction:                int32(workflow.DirectionForward),
+		EnableReverseReplication: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sqltypes.Result{
+		Fields: []*querypb.Field{
+			{Name: "summary", Type: sqltypes.VarChar},
+			{Name: "start_state", Type: sqltypes.VarChar},
+			{Name: "current_state", Type: sqltypes.VarChar},
+		},
+		Rows: [][]sqltypes.Value{{
+			sqltypes.NewVarChar(resp.Summary),
+			sqltypes.NewVarChar(resp.StartState),
+			sqltypes.NewVarChar(resp.CurrentState),
+		}},
+	}, nil
+}
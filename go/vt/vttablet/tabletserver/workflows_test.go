@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func TestBuildShowWorkflowsQuery(t *testing.T) {
+	const selectPrefix = "select\n\t\tid, workflow, workflow_type, workflow_sub_type, source, pos, stop_pos, state, message,\n\t\ttime_updated, transaction_timestamp, rows_copied\n\tfrom _vt.vreplication\n\twhere db_name = 'vt_commerce'"
+	const orderBy = "\n\torder by workflow, id"
+	tcs := []struct {
+		query  string
+		expect string
+	}{
+		{
+			query:  "show vitess_workflows",
+			expect: selectPrefix + orderBy,
+		},
+		{
+			query:  "show vitess_workflows like 'c2%'",
+			expect: selectPrefix + " and (workflow LIKE 'c2%' OR state LIKE 'c2%')" + orderBy,
+		},
+		{
+			query:  "show vitess_workflows where state = 'Running' or message != ''",
+			expect: selectPrefix + " and (state = 'Running' or message != '')" + orderBy,
+		},
+	}
+	parser := sqlparser.NewTestParser()
+	for _, tc := range tcs {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := parser.Parse(tc.query)
+			require.NoError(t, err)
+			show, ok := stmt.(*sqlparser.Show)
+			require.True(t, ok)
+			showBasic, ok := show.Internal.(*sqlparser.ShowBasic)
+			require.True(t, ok)
+			assert.Equal(t, tc.expect, buildShowWorkflowsQuery(showBasic, "vt_commerce"))
+		})
+	}
+}
@@ -179,6 +179,11 @@ func (tqsc *Controller) ReloadSchema(ctx context.Context) error {
 func (tqsc *Controller) ClearQueryPlanCache() {
 }
 
+// GetQueryPlans is part of the tabletserver.Controller interface
+func (tqsc *Controller) GetQueryPlans(context.Context, *tabletmanagerdata.GetQueryPlansRequest) (*tabletmanagerdata.GetQueryPlansResponse, error) {
+	return &tabletmanagerdata.GetQueryPlansResponse{}, nil
+}
+
 // RegisterQueryRuleSource is part of the tabletserver.Controller interface
 func (tqsc *Controller) RegisterQueryRuleSource(ruleSource string) {
 }
@@ -215,6 +220,10 @@ func (tqsc *Controller) BroadcastHealth() {
 	}
 }
 
+// BroadcastVReplicationStateChange is part of the tabletserver.Controller interface
+func (tqsc *Controller) BroadcastVReplicationStateChange() {
+}
+
 // TopoServer is part of the tabletserver.Controller interface.
 func (tqsc *Controller) TopoServer() *topo.Server {
 	return tqsc.TS
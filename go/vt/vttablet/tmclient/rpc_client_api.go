@@ -168,6 +168,12 @@ type TabletManagerClient interface {
 	// MysqlHostMetrics returns mysql system metrics
 	MysqlHostMetrics(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.MysqlHostMetricsRequest) (*tabletmanagerdatapb.MysqlHostMetricsResponse, error)
 
+	// GetMysqlErrorLogEvents returns the recent notable events of the MySQL error log of the tablet
+	GetMysqlErrorLogEvents(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error)
+
+	// GetQueryPlans returns the query plans cached by the tablet, after evicting, pinning or unpinning the plans of the given queries
+	GetQueryPlans(ctx context.Context, tablet *topodatapb.Tablet, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error)
+
 	//
 	// Replication related methods
 	//
@@ -433,6 +433,78 @@ func tmRPCTestGetGlobalStatusVarsPanic(ctx context.Context, t *testing.T, client
 	expectHandleRPCPanic(t, "GetGlobalStatusVars", false /*verbose*/, err)
 }
 
+var testGetMysqlErrorLogEventsRequest = &tabletmanagerdatapb.GetMysqlErrorLogEventsRequest{
+	Category: "disk_error",
+	Limit:    10,
+}
+
+var testGetMysqlErrorLogEventsReply = &tabletmanagerdatapb.GetMysqlErrorLogEventsResponse{
+	Events: []*tabletmanagerdatapb.MysqlErrorLogEvent{{
+		Category: "disk_error",
+		Severity: "ERROR",
+		Message:  "[ERROR] [MY-012592] [InnoDB] Operating system error number 28 in a file operation.",
+	}},
+	EventCounts: map[string]int64{"disk_error": 1},
+	Enabled:     true,
+}
+
+func (fra *fakeRPCTM) GetMysqlErrorLogEvents(ctx context.Context, req *tabletmanagerdatapb.GetMysqlErrorLogEventsRequest) (*tabletmanagerdatapb.GetMysqlErrorLogEventsResponse, error) {
+	if fra.panics {
+		panic(errors.New("test-triggered panic"))
+	}
+	compare(fra.t, "GetMysqlErrorLogEvents request", req, testGetMysqlErrorLogEventsRequest)
+	return testGetMysqlErrorLogEventsReply, nil
+}
+
+func tmRPCTestGetMysqlErrorLogEvents(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	result, err := client.GetMysqlErrorLogEvents(ctx, tablet, testGetMysqlErrorLogEventsRequest)
+	compareError(t, "GetMysqlErrorLogEvents", err, result, testGetMysqlErrorLogEventsReply)
+}
+
+func tmRPCTestGetMysqlErrorLogEventsPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetMysqlErrorLogEvents(ctx, tablet, testGetMysqlErrorLogEventsRequest)
+	expectHandleRPCPanic(t, "GetMysqlErrorLogEvents", false /*verbose*/, err)
+}
+
+var testGetQueryPlansRequest = &tabletmanagerdatapb.GetQueryPlansRequest{
+	Filter: "from t1",
+	Limit:  10,
+	Evict:  []string{"select * from t2"},
+	Pin:    []string{"select * from t1"},
+}
+
+var testGetQueryPlansReply = &tabletmanagerdatapb.GetQueryPlansResponse{
+	Plans: []*tabletmanagerdatapb.QueryPlan{{
+		Query:      "select * from t1",
+		PlanType:   "Select",
+		Tables:     []string{"t1"},
+		HitCount:   12,
+		QueryCount: 13,
+		Rules:      []string{"buffered_table"},
+		Pinned:     true,
+	}},
+	Evicted: 1,
+	Pinned:  1,
+}
+
+func (fra *fakeRPCTM) GetQueryPlans(ctx context.Context, req *tabletmanagerdatapb.GetQueryPlansRequest) (*tabletmanagerdatapb.GetQueryPlansResponse, error) {
+	if fra.panics {
+		panic(errors.New("test-triggered panic"))
+	}
+	compare(fra.t, "GetQueryPlans request", req, testGetQueryPlansRequest)
+	return testGetQueryPlansReply, nil
+}
+
+func tmRPCTestGetQueryPlans(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	result, err := client.GetQueryPlans(ctx, tablet, testGetQueryPlansRequest)
+	compareError(t, "GetQueryPlans", err, result, testGetQueryPlansReply)
+}
+
+func tmRPCTestGetQueryPlansPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
+	_, err := client.GetQueryPlans(ctx, tablet, testGetQueryPlansRequest)
+	expectHandleRPCPanic(t, "GetQueryPlans", false /*verbose*/, err)
+}
+
 func tmRPCTestGetUnresolvedTransactions(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.Tablet) {
 	_, err := client.GetUnresolvedTransactions(ctx, tablet, 0)
 	require.NoError(t, err)
@@ -1588,6 +1660,8 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestGetSchema(ctx, t, client, tablet)
 	tmRPCTestGetPermissions(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVars(ctx, t, client, tablet)
+	tmRPCTestGetMysqlErrorLogEvents(ctx, t, client, tablet)
+	tmRPCTestGetQueryPlans(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactions(ctx, t, client, tablet)
 	tmRPCTestReadTransaction(ctx, t, client, tablet)
 	tmRPCTestGetTransactionInfo(ctx, t, client, tablet)
@@ -1654,6 +1728,8 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, tablet *topodatapb.T
 	tmRPCTestGetSchemaPanic(ctx, t, client, tablet)
 	tmRPCTestGetPermissionsPanic(ctx, t, client, tablet)
 	tmRPCTestGetGlobalStatusVarsPanic(ctx, t, client, tablet)
+	tmRPCTestGetMysqlErrorLogEventsPanic(ctx, t, client, tablet)
+	tmRPCTestGetQueryPlansPanic(ctx, t, client, tablet)
 	tmRPCTestGetUnresolvedTransactionsPanic(ctx, t, client, tablet)
 	tmRPCTestReadTransactionPanic(ctx, t, client, tablet)
 	tmRPCTestGetTransactionInfoPanic(ctx, t, client, tablet)
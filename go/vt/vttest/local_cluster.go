@@ -133,6 +133,14 @@ type Config struct {
 
 	TransactionTimeout time.Duration
 
+	// FakeTime, if set, is the RFC 3339 time at which the clock of vtcombo and of its
+	// MySQL sessions is frozen, so that NOW() and similar functions are reproducible.
+	FakeTime string
+
+	// DeterministicSequences makes sequence values be allocated one at a time, so that
+	// generated values do not depend on restarts.
+	DeterministicSequences bool
+
 	// The host name to use for the table otherwise it will be resolved from the local hostname
 	TabletHostName string
 
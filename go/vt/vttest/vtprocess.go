@@ -306,6 +306,12 @@ func VtcomboProcess(environment Environment, args *Config, mysql MySQLManager) (
 	if args.TabletHostName != "" {
 		vt.ExtraArgs = append(vt.ExtraArgs, []string{"--tablet-hostname", args.TabletHostName}...)
 	}
+	if args.FakeTime != "" {
+		vt.ExtraArgs = append(vt.ExtraArgs, []string{"--fake-time", args.FakeTime}...)
+	}
+	if args.DeterministicSequences {
+		vt.ExtraArgs = append(vt.ExtraArgs, "--deterministic-sequences")
+	}
 	if servenv.GRPCAuth() == "mtls" {
 		vt.ExtraArgs = append(vt.ExtraArgs, []string{"--grpc-auth-mode", servenv.GRPCAuth(), "--grpc-key", servenv.GRPCKey(), "--grpc-cert", servenv.GRPCCert(), "--grpc-ca", servenv.GRPCCertificateAuthority(), "--grpc-auth-mtls-allowed-substrings", servenv.ClientCertSubstrings()}...)
 	}
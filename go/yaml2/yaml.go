@@ -25,4 +25,8 @@ var (
 	Marshal = yaml.Marshal
 	// Unmarshal unmarshals from YAML.
 	Unmarshal = yaml.Unmarshal
+	// JSONToYAML converts JSON to YAML.
+	JSONToYAML = yaml.JSONToYAML
+	// YAMLToJSON converts YAML to JSON.
+	YAMLToJSON = yaml.YAMLToJSON
 )